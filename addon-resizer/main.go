@@ -30,6 +30,7 @@ import (
 	"k8s.io/autoscaler/addon-resizer/nanny"
 
 	"k8s.io/client-go/kubernetes"
+	v1lister "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 )
@@ -48,6 +49,7 @@ var (
 	scaleUpDelay         = flag.Duration("scale-up-delay", time.Duration(0), "The time to wait after the addon-resizer start or last scaling operation before the scale up can be performed.")
 	recommendationOffset = flag.Int("recommendation-offset", 10, "A number from range 0-100. When the dependent's resources are rewritten, they are set to the closer end of the range defined by this percentage threshold.")
 	acceptanceOffset     = flag.Int("acceptance-offset", 20, "A number from range 0-100. The dependent's resources are rewritten when they deviate from expected by a percentage that is higher than this threshold. Can't be lower than recommendation-offset.")
+	stepsConfigMap       = flag.String("steps-config-map", "", "The name of a ConfigMap in the nanny's namespace holding a table of resource values by cluster size. When set, the table replaces the linear extrapolation of the base and extra-* flags, which are only used while the ConfigMap is missing. The ConfigMap is reloaded when it changes.")
 	// Flags to identify the container to nanny.
 	podNamespace  = flag.String("namespace", os.Getenv("MY_POD_NAMESPACE"), "The namespace of the ward. This defaults to the nanny pod's own namespace.")
 	deployment    = flag.String("deployment", "", "The name of the deployment being monitored. This is required.")
@@ -168,6 +170,20 @@ func main() {
 
 	log.Infof("Resources: %+v", resources)
 
+	var estimator nanny.ResourceEstimator = nanny.Estimator{
+		AcceptanceOffset:     int64(*acceptanceOffset),
+		RecommendationOffset: int64(*recommendationOffset),
+		Resources:            resources,
+	}
+	var stopConfigMapLister chan<- struct{}
+	if *stepsConfigMap != "" {
+		log.Infof("Using step table from ConfigMap %s", *stepsConfigMap)
+		var configMapLister v1lister.ConfigMapNamespaceLister
+		configMapLister, stopConfigMapLister = nanny.NewConfigMapLister(kubeClient, *podNamespace, *stepsConfigMap)
+		estimator = nanny.NewConfigMapStepEstimator(configMapLister, *stepsConfigMap,
+			int64(*acceptanceOffset), int64(*recommendationOffset), estimator)
+	}
+
 	// handle termination info
 	ch := make(chan os.Signal, 1)
 	signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
@@ -175,17 +191,16 @@ func main() {
 		<-ch
 		log.Infof("Received termination, signaling shutdown")
 		k8s.Stop()
+		if stopConfigMapLister != nil {
+			stopConfigMapLister <- struct{}{}
+		}
 		os.Exit(0)
 	}()
 
 	// Begin nannying.
 	nanny.PollAPIServer(
 		k8s,
-		nanny.Estimator{
-			AcceptanceOffset:     int64(*acceptanceOffset),
-			RecommendationOffset: int64(*recommendationOffset),
-			Resources:            resources,
-		},
+		estimator,
 		pollPeriod,
 		*scaleDownDelay,
 		*scaleUpDelay)
@@ -24,13 +24,18 @@ import (
 	core "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
 	kube_client "k8s.io/client-go/kubernetes"
 	kube_client_apps "k8s.io/client-go/kubernetes/typed/apps/v1"
 	v1appslister "k8s.io/client-go/listers/apps/v1"
 	v1lister "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
+
+	log "github.com/golang/glog"
 )
 
+const configMapSyncTimeout = 30 * time.Second
+
 type kubernetesClient struct {
 	nodeLister       v1lister.NodeLister
 	podLister        v1lister.PodNamespaceLister
@@ -149,3 +154,23 @@ func newDeploymentListerByNamespace(kubeClient kube_client.Interface, namespace
 	nsLister := lister.Deployments(namespace)
 	return nsLister, stopChannel
 }
+
+// NewConfigMapLister returns a lister of the ConfigMap with the given name, and
+// a channel stopping its reflector. It waits for the initial list of the
+// ConfigMap so that callers don't mistake an unsynced cache for a missing ConfigMap.
+func NewConfigMapLister(kubeClient kube_client.Interface, namespace, name string) (v1lister.ConfigMapNamespaceLister, chan<- struct{}) {
+	stopChannel := make(chan struct{})
+	listWatcher := cache.NewListWatchFromClient(kubeClient.CoreV1().RESTClient(), "configmaps", namespace, fields.OneTermEqualSelector("metadata.name", name))
+	store := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	lister := v1lister.NewConfigMapLister(store)
+	reflector := cache.NewReflector(listWatcher, &core.ConfigMap{}, store, time.Hour)
+	go reflector.Run(stopChannel)
+	err := wait.PollImmediate(100*time.Millisecond, configMapSyncTimeout, func() (bool, error) {
+		return reflector.LastSyncResourceVersion() != "", nil
+	})
+	if err != nil {
+		log.Warningf("ConfigMap %s in namespace %s was not synced within %v", name, namespace, configMapSyncTimeout)
+	}
+	nsLister := lister.ConfigMaps(namespace)
+	return nsLister, stopChannel
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nanny
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/ghodss/yaml"
+	api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1lister "k8s.io/client-go/listers/core/v1"
+
+	log "github.com/golang/glog"
+)
+
+// StepsConfigMapKey is the key of the ConfigMap data holding the step table.
+const StepsConfigMapKey = "steps"
+
+// Step defines the resource values used for clusters with at least MinNodes nodes.
+type Step struct {
+	MinNodes  uint64           `json:"minNodes"`
+	Resources api.ResourceList `json:"resources"`
+}
+
+// StepEstimator is a struct used for estimating accepted and recommended resource
+// requirements from a table of cluster size ranges instead of extrapolating
+// linearly with the number of nodes.
+type StepEstimator struct {
+	// Steps sorted by MinNodes. The first step is used for clusters smaller than
+	// its MinNodes.
+	Steps []Step
+	// Percentage offset defining acceptable resource range.
+	AcceptanceOffset int64
+	// Percentage offset defining recommended resource range.
+	RecommendationOffset int64
+}
+
+// ParseSteps parses a YAML list of steps and validates that it can be used by
+// a StepEstimator.
+func ParseSteps(data []byte) ([]Step, error) {
+	var steps []Step
+	if err := yaml.Unmarshal(data, &steps); err != nil {
+		return nil, fmt.Errorf("failed to parse steps: %v", err)
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("no steps defined")
+	}
+	sort.SliceStable(steps, func(i, j int) bool { return steps[i].MinNodes < steps[j].MinNodes })
+	for i, step := range steps {
+		if i > 0 && step.MinNodes == steps[i-1].MinNodes {
+			return nil, fmt.Errorf("more than one step defined for %d nodes", step.MinNodes)
+		}
+		// All steps must define the same resources, otherwise moving between
+		// steps would be seen as a change of the monitored resources.
+		if len(step.Resources) != len(steps[0].Resources) {
+			return nil, fmt.Errorf("step for %d nodes defines %d resources, expected %d", step.MinNodes, len(step.Resources), len(steps[0].Resources))
+		}
+		for name := range steps[0].Resources {
+			if _, ok := step.Resources[name]; !ok {
+				return nil, fmt.Errorf("step for %d nodes doesn't define resource %s", step.MinNodes, name)
+			}
+		}
+	}
+	return steps, nil
+}
+
+// Returns the resources of the last step starting at or below numNodes.
+func (e StepEstimator) resourcesForNodes(numNodes uint64) api.ResourceList {
+	i := sort.Search(len(e.Steps), func(i int) bool { return e.Steps[i].MinNodes > numNodes })
+	if i > 0 {
+		i--
+	}
+	log.V(4).Infof("Using step for %d nodes with %d nodes", e.Steps[i].MinNodes, numNodes)
+	return e.Steps[i].Resources.DeepCopy()
+}
+
+// Returns a ResourceListPair with the resources of the steps matching the node
+// counts offset/100 below and above numNodes.
+func (e StepEstimator) nodesAndOffsetToRange(numNodes uint64, offset int64) ResourceListPair {
+	return ResourceListPair{
+		lower: e.resourcesForNodes(getOffsetNodeCount(numNodes, -offset, math.Floor)),
+		upper: e.resourcesForNodes(getOffsetNodeCount(numNodes, offset, math.Ceil)),
+	}
+}
+
+func (e StepEstimator) scaleWithNodes(numNodes uint64) *EstimatorResult {
+	return &EstimatorResult{
+		RecommendedRange: e.nodesAndOffsetToRange(numNodes, e.RecommendationOffset),
+		AcceptableRange:  e.nodesAndOffsetToRange(numNodes, e.AcceptanceOffset),
+	}
+}
+
+// configMapStepEstimator estimates resources using the step table stored in a
+// ConfigMap. The table is reloaded whenever the ConfigMap changes. Until a
+// valid table is loaded, or when the ConfigMap is removed, the fallback
+// estimator is used.
+type configMapStepEstimator struct {
+	configMapLister      v1lister.ConfigMapNamespaceLister
+	configMap            string
+	acceptanceOffset     int64
+	recommendationOffset int64
+	fallback             ResourceEstimator
+	resourceVersion      string
+	estimator            *StepEstimator
+}
+
+// NewConfigMapStepEstimator returns a ResourceEstimator using the step table
+// stored under StepsConfigMapKey in the given ConfigMap.
+func NewConfigMapStepEstimator(configMapLister v1lister.ConfigMapNamespaceLister, configMap string, acceptanceOffset, recommendationOffset int64, fallback ResourceEstimator) ResourceEstimator {
+	return &configMapStepEstimator{
+		configMapLister:      configMapLister,
+		configMap:            configMap,
+		acceptanceOffset:     acceptanceOffset,
+		recommendationOffset: recommendationOffset,
+		fallback:             fallback,
+	}
+}
+
+// reload updates the step table if the ConfigMap changed since the last call.
+// An invalid table is reported once and the previous table is kept.
+func (e *configMapStepEstimator) reload() {
+	cm, err := e.configMapLister.Get(e.configMap)
+	if errors.IsNotFound(err) {
+		if e.estimator != nil {
+			log.Warningf("ConfigMap %s not found, falling back to linear estimation", e.configMap)
+		}
+		e.estimator = nil
+		e.resourceVersion = ""
+		return
+	}
+	if err != nil {
+		log.Errorf("Error while getting ConfigMap %s: %v", e.configMap, err)
+		return
+	}
+	if cm.ResourceVersion == e.resourceVersion {
+		return
+	}
+	e.resourceVersion = cm.ResourceVersion
+
+	steps, err := ParseSteps([]byte(cm.Data[StepsConfigMapKey]))
+	if err != nil {
+		log.Errorf("Invalid step table in ConfigMap %s, keeping the previous configuration: %v", e.configMap, err)
+		return
+	}
+	log.Infof("Loaded step table from ConfigMap %s: %+v", e.configMap, jsonOrValue(steps))
+	e.estimator = &StepEstimator{
+		Steps:                steps,
+		AcceptanceOffset:     e.acceptanceOffset,
+		RecommendationOffset: e.recommendationOffset,
+	}
+}
+
+func (e *configMapStepEstimator) scaleWithNodes(numNodes uint64) *EstimatorResult {
+	e.reload()
+	if e.estimator == nil {
+		return e.fallback.scaleWithNodes(numNodes)
+	}
+	return e.estimator.scaleWithNodes(numNodes)
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nanny
+
+import (
+	"testing"
+
+	api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1lister "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const stepsYAML = `
+- minNodes: 100
+  resources:
+    cpu: 500m
+    memory: 1Gi
+- minNodes: 0
+  resources:
+    cpu: 100m
+    memory: 200Mi
+- minNodes: 10
+  resources:
+    cpu: 200m
+    memory: 300Mi
+`
+
+var (
+	smallStepResources = api.ResourceList{
+		"cpu":    resource.MustParse("100m"),
+		"memory": resource.MustParse("200Mi"),
+	}
+	mediumStepResources = api.ResourceList{
+		"cpu":    resource.MustParse("200m"),
+		"memory": resource.MustParse("300Mi"),
+	}
+	largeStepResources = api.ResourceList{
+		"cpu":    resource.MustParse("500m"),
+		"memory": resource.MustParse("1Gi"),
+	}
+)
+
+func TestParseSteps(t *testing.T) {
+	testCases := []struct {
+		lineNum  int
+		data     string
+		minNodes []uint64
+		wantErr  bool
+	}{
+		{num(), stepsYAML, []uint64{0, 10, 100}, false},
+		{num(), "", nil, true},
+		{num(), "not a list", nil, true},
+		{num(), "- minNodes: 1\n  resources: {cpu: 1}\n- minNodes: 1\n  resources: {cpu: 2}", nil, true},
+		{num(), "- minNodes: 1\n  resources: {cpu: 1}\n- minNodes: 2\n  resources: {memory: 1Gi}", nil, true},
+		{num(), "- minNodes: 1\n  resources: {cpu: 1}\n- minNodes: 2\n  resources: {cpu: 2, memory: 1Gi}", nil, true},
+		{num(), "- minNodes: 1\n  resources: {cpu: invalid}", nil, true},
+	}
+
+	for _, tc := range testCases {
+		steps, err := ParseSteps([]byte(tc.data))
+		if (err != nil) != tc.wantErr {
+			t.Errorf("[test@line %d] unexpected error: %v", tc.lineNum, err)
+			continue
+		}
+		if len(steps) != len(tc.minNodes) {
+			t.Errorf("[test@line %d] got %d steps, want %d", tc.lineNum, len(steps), len(tc.minNodes))
+			continue
+		}
+		for i, step := range steps {
+			if step.MinNodes != tc.minNodes[i] {
+				t.Errorf("[test@line %d] step %d starts at %d nodes, want %d", tc.lineNum, i, step.MinNodes, tc.minNodes[i])
+			}
+		}
+	}
+}
+
+func TestStepEstimateResources(t *testing.T) {
+	steps, err := ParseSteps([]byte(stepsYAML))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	e := StepEstimator{Steps: steps, AcceptanceOffset: 20, RecommendationOffset: 10}
+	testCases := []struct {
+		lineNum         int
+		numNodes        uint64
+		estimatorResult EstimatorResult
+	}{
+		{num(), 0, EstimatorResult{
+			RecommendedRange: ResourceListPair{lower: smallStepResources, upper: smallStepResources},
+			AcceptableRange:  ResourceListPair{lower: smallStepResources, upper: smallStepResources},
+		}},
+		{num(), 9, EstimatorResult{
+			RecommendedRange: ResourceListPair{lower: smallStepResources, upper: mediumStepResources},
+			AcceptableRange:  ResourceListPair{lower: smallStepResources, upper: mediumStepResources},
+		}},
+		{num(), 50, EstimatorResult{
+			RecommendedRange: ResourceListPair{lower: mediumStepResources, upper: mediumStepResources},
+			AcceptableRange:  ResourceListPair{lower: mediumStepResources, upper: mediumStepResources},
+		}},
+		{num(), 90, EstimatorResult{
+			RecommendedRange: ResourceListPair{lower: mediumStepResources, upper: mediumStepResources},
+			AcceptableRange:  ResourceListPair{lower: mediumStepResources, upper: largeStepResources},
+		}},
+		{num(), 1000, EstimatorResult{
+			RecommendedRange: ResourceListPair{lower: largeStepResources, upper: largeStepResources},
+			AcceptableRange:  ResourceListPair{lower: largeStepResources, upper: largeStepResources},
+		}},
+	}
+
+	for _, tc := range testCases {
+		got := e.scaleWithNodes(tc.numNodes)
+		want := &tc.estimatorResult
+		verifyRange(t, tc.lineNum, "AcceptableRange", got.AcceptableRange, want.AcceptableRange)
+		verifyRange(t, tc.lineNum, "RecommendedRange", got.RecommendedRange, want.RecommendedRange)
+	}
+}
+
+func TestConfigMapStepEstimatorReload(t *testing.T) {
+	store := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	lister := v1lister.NewConfigMapLister(store).ConfigMaps("default")
+	e := NewConfigMapStepEstimator(lister, "steps", 20, 10, fullEstimator)
+	configMap := func(resourceVersion, steps string) *api.ConfigMap {
+		return &api.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "steps", Namespace: "default", ResourceVersion: resourceVersion},
+			Data:       map[string]string{StepsConfigMapKey: steps},
+		}
+	}
+
+	// Missing ConfigMap uses the fallback estimator.
+	verifyRange(t, num(), "AcceptableRange", e.scaleWithNodes(4).AcceptableRange, threeToFiveNodesResourcesRange)
+
+	if err := store.Add(configMap("1", stepsYAML)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	verifyRange(t, num(), "AcceptableRange", e.scaleWithNodes(50).AcceptableRange,
+		ResourceListPair{lower: mediumStepResources, upper: mediumStepResources})
+
+	// Invalid update keeps the previous table.
+	if err := store.Update(configMap("2", "invalid")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	verifyRange(t, num(), "AcceptableRange", e.scaleWithNodes(50).AcceptableRange,
+		ResourceListPair{lower: mediumStepResources, upper: mediumStepResources})
+
+	if err := store.Update(configMap("3", "- minNodes: 0\n  resources: {cpu: 100m, memory: 200Mi}")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	verifyRange(t, num(), "AcceptableRange", e.scaleWithNodes(50).AcceptableRange,
+		ResourceListPair{lower: smallStepResources, upper: smallStepResources})
+
+	if err := store.Delete(configMap("3", "")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	verifyRange(t, num(), "AcceptableRange", e.scaleWithNodes(4).AcceptableRange, threeToFiveNodesResourcesRange)
+}
@@ -93,7 +93,8 @@ func main() {
 	scaleClient, err := scaleclient.NewForConfig(cfg, restMapper, dynamic.LegacyAPIPathResolverFunc, scaleKindResolver)
 
 	podInformer := kubeInformerFactory.Core().V1().Pods()
-	core := controller.NewCore(controller.NewScaleClient(context.TODO(), scaleClient, restMapper), podInformer)
+	metricsClient := controller.NewMetricsClient(context.TODO(), kubeClient.Discovery().RESTClient())
+	core := controller.NewCore(controller.NewScaleClient(context.TODO(), scaleClient, restMapper), metricsClient, podInformer)
 
 	controller := controller.NewController(balancerClient,
 		balancerInformerFactory.Balancer().V1alpha1().Balancers(),
@@ -114,6 +114,13 @@ type BalancerTarget struct {
 	// +optional
 	// +kubebuilder:validation:Minimum=0
 	MaxReplicas *int32 `json:"maxReplicas,omitempty" protobuf:"varint,4,opt,name=maxReplicas"`
+
+	// MaxSurge is the maximum number of replicas that Balancer places inside of
+	// this target on top of its share, to replace replicas that failed to start
+	// in other targets. There will be no limit if not provided.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxSurge *int32 `json:"maxSurge,omitempty" protobuf:"varint,5,opt,name=maxSurge"`
 }
 
 // BalancerPolicyName is the name of the balancer Policy.
@@ -124,12 +131,15 @@ const (
 	PriorityPolicyName BalancerPolicyName = "priority"
 	// ProportionalPolicyName is the name used in Balancer Spec for proportional policy
 	ProportionalPolicyName BalancerPolicyName = "proportional"
+	// WeightedPolicyName is the name used in Balancer Spec for weighted policy.
+	WeightedPolicyName BalancerPolicyName = "weighted"
 )
 
 // BalancerPolicy defines Balancer policy for replica distribution.
 type BalancerPolicy struct {
 	// PolicyName decides how to balance replicas across the targets.
-	// Depending on the name one of the fields Priorities or Proportions must be set.
+	// Depending on the name one of the fields Priorities, Proportions or Weights
+	// must be set.
 	// +kubebuilder:validation:Required
 	PolicyName BalancerPolicyName `json:"policyName" protobuf:"bytes,1,name=policyName"`
 
@@ -147,6 +157,16 @@ type BalancerPolicy struct {
 	// replicas fail to start in one or more targets. No fallback happens if not-set.
 	// +optional
 	Fallback *FallbackPolicy `json:"fallback,omitempty" protobuf:"bytes,4,opt,name=fallback"`
+
+	// Weights contains detailed specification of how to balance when balancer
+	// policy name is set to Weighted.
+	// +optional
+	Weights *WeightedPolicy `json:"weights,omitempty" protobuf:"bytes,5,opt,name=weights"`
+
+	// Drain contains specification of how to gradually shift replicas from one
+	// target to another. No replicas are shifted if not-set.
+	// +optional
+	Drain *DrainPolicy `json:"drain,omitempty" protobuf:"bytes,6,opt,name=drain"`
 }
 
 // PriorityPolicy contains details for Priority-based policy for Balancer.
@@ -172,6 +192,93 @@ type ProportionalPolicy struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinProperties=2
 	TargetProportions map[string]int32 `json:"targetProportions" protobuf:"bytes,1,opt,name=targetProportions"`
+
+	// Metric, if set, adjusts TargetProportions with the values of an external
+	// metric of each target, e.g. the latency or the cost in the zone of the target.
+	// The proportions are recomputed every time Balancer processes the targets.
+	// Static TargetProportions are used if a metric value is not available.
+	// +optional
+	Metric *ProportionsMetric `json:"metric,omitempty" protobuf:"bytes,2,opt,name=metric"`
+}
+
+// ProportionsMetricType is the way the metric values adjust the proportions.
+type ProportionsMetricType string
+
+const (
+	// DirectProportionsMetricType multiplies the proportions by the metric
+	// values, e.g. by the free capacity of the targets.
+	DirectProportionsMetricType ProportionsMetricType = "Direct"
+	// InverseProportionsMetricType divides the proportions by the metric
+	// values, e.g. by the latency or the cost of the targets.
+	InverseProportionsMetricType ProportionsMetricType = "Inverse"
+)
+
+// ProportionsMetric contains information how to adjust the proportions of
+// the targets with the values of an external metric.
+type ProportionsMetric struct {
+	// Type decides whether the proportions are multiplied (Direct) or divided
+	// (Inverse) by the metric values.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=Direct;Inverse
+	Type ProportionsMetricType `json:"type" protobuf:"bytes,1,name=type"`
+
+	// TargetMetrics is a map from Balancer targets names to the metrics served
+	// by the external metrics API (external.metrics.k8s.io). The values of all
+	// series matching the selector are summed. Targets missing from the map keep
+	// their proportions.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinProperties=1
+	TargetMetrics map[string]hpa.MetricIdentifier `json:"targetMetrics" protobuf:"bytes,2,rep,name=targetMetrics"`
+}
+
+// WeightedPolicy contains details for Weight-based policy for Balancer.
+type WeightedPolicy struct {
+	// PriorityGroups is the priority-based list of groups of Balancer targets.
+	// The targets of the first group get the replicas until their maxReplicas
+	// are reached (or replicas fail to start). Then the replicas go to the second
+	// group and so on. Inside of a group, replicas are distributed proportionally
+	// to the weights of the targets. MinReplicas is guaranteed for a target,
+	// irrespective of the total Balancer's replica count, weights or the presence
+	// in a group.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	PriorityGroups []PriorityGroup `json:"priorityGroups" protobuf:"bytes,1,rep,name=priorityGroups"`
+}
+
+// PriorityGroup is a group of Balancer targets with the same priority.
+type PriorityGroup struct {
+	// TargetWeights is a map from Balancer targets names to weights. A target
+	// may be present in at most one group.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinProperties=1
+	TargetWeights map[string]int32 `json:"targetWeights" protobuf:"bytes,1,opt,name=targetWeights"`
+}
+
+// DrainPolicy contains information how to gradually shift replicas from
+// one Balancer target to another.
+type DrainPolicy struct {
+	// SourceTarget is the name of the Balancer target that is drained. At the
+	// end of the drain only its minReplicas are left inside of it.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	SourceTarget string `json:"sourceTarget" protobuf:"bytes,1,name=sourceTarget"`
+
+	// DestinationTarget is the name of the Balancer target that gets the
+	// replicas shifted from the source target, up to its maxReplicas.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	DestinationTarget string `json:"destinationTarget" protobuf:"bytes,2,name=destinationTarget"`
+
+	// StartTime is the time when the drain starts.
+	// +kubebuilder:validation:Required
+	StartTime metav1.Time `json:"startTime" protobuf:"bytes,3,name=startTime"`
+
+	// DurationSeconds defines over how long the replicas are shifted. The
+	// number of shifted replicas grows linearly with the time elapsed since
+	// StartTime. All replicas are shifted at once if 0.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=0
+	DurationSeconds int32 `json:"durationSeconds" protobuf:"varint,4,name=durationSeconds"`
 }
 
 // FallbackPolicy contains information how to recognize and handle replicas
@@ -22,6 +22,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	v2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -105,6 +106,16 @@ func (in *BalancerPolicy) DeepCopyInto(out *BalancerPolicy) {
 		*out = new(FallbackPolicy)
 		**out = **in
 	}
+	if in.Weights != nil {
+		in, out := &in.Weights, &out.Weights
+		*out = new(WeightedPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(DrainPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -180,6 +191,11 @@ func (in *BalancerTarget) DeepCopyInto(out *BalancerTarget) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -193,6 +209,23 @@ func (in *BalancerTarget) DeepCopy() *BalancerTarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainPolicy) DeepCopyInto(out *DrainPolicy) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainPolicy.
+func (in *DrainPolicy) DeepCopy() *DrainPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FallbackPolicy) DeepCopyInto(out *FallbackPolicy) {
 	*out = *in
@@ -210,6 +243,29 @@ func (in *FallbackPolicy) DeepCopy() *FallbackPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PriorityGroup) DeepCopyInto(out *PriorityGroup) {
+	*out = *in
+	if in.TargetWeights != nil {
+		in, out := &in.TargetWeights, &out.TargetWeights
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PriorityGroup.
+func (in *PriorityGroup) DeepCopy() *PriorityGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(PriorityGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PriorityPolicy) DeepCopyInto(out *PriorityPolicy) {
 	*out = *in
@@ -241,6 +297,11 @@ func (in *ProportionalPolicy) DeepCopyInto(out *ProportionalPolicy) {
 			(*out)[key] = val
 		}
 	}
+	if in.Metric != nil {
+		in, out := &in.Metric, &out.Metric
+		*out = new(ProportionsMetric)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -253,3 +314,49 @@ func (in *ProportionalPolicy) DeepCopy() *ProportionalPolicy {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProportionsMetric) DeepCopyInto(out *ProportionsMetric) {
+	*out = *in
+	if in.TargetMetrics != nil {
+		in, out := &in.TargetMetrics, &out.TargetMetrics
+		*out = make(map[string]v2.MetricIdentifier, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProportionsMetric.
+func (in *ProportionsMetric) DeepCopy() *ProportionsMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(ProportionsMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WeightedPolicy) DeepCopyInto(out *WeightedPolicy) {
+	*out = *in
+	if in.PriorityGroups != nil {
+		in, out := &in.PriorityGroups, &out.PriorityGroups
+		*out = make([]PriorityGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WeightedPolicy.
+func (in *WeightedPolicy) DeepCopy() *WeightedPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(WeightedPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -29,6 +29,7 @@ import (
 	"k8s.io/autoscaler/balancer/pkg/pods"
 	"k8s.io/autoscaler/balancer/pkg/policy"
 	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
 )
 
 // CoreInterface of the balancer controller. Handles individual Balancer reconciliation.
@@ -55,15 +56,17 @@ func newBalancerStatusInfo(replicas int32, updated bool) BalancerStatusInfo {
 
 // core is CoreInferface implementation.
 type core struct {
-	scaleClient ScaleClientInterface
-	podLister   corelisters.PodLister
-	podSynced   func() bool
+	scaleClient   ScaleClientInterface
+	metricsClient MetricsClientInterface
+	podLister     corelisters.PodLister
+	podSynced     func() bool
 }
 
-func newCoreForTests(client ScaleClientInterface, lister corelisters.PodLister) CoreInterface {
+func newCoreForTests(client ScaleClientInterface, metricsClient MetricsClientInterface, lister corelisters.PodLister) CoreInterface {
 	return &core{
-		scaleClient: client,
-		podLister:   lister,
+		scaleClient:   client,
+		metricsClient: metricsClient,
+		podLister:     lister,
 		podSynced: func() bool {
 			return true
 		},
@@ -71,11 +74,12 @@ func newCoreForTests(client ScaleClientInterface, lister corelisters.PodLister)
 }
 
 // NewCore returns an implementation of the CoreInterface.
-func NewCore(client ScaleClientInterface, informer v1.PodInformer) CoreInterface {
+func NewCore(client ScaleClientInterface, metricsClient MetricsClientInterface, informer v1.PodInformer) CoreInterface {
 	return &core{
-		scaleClient: client,
-		podLister:   informer.Lister(),
-		podSynced:   informer.Informer().HasSynced,
+		scaleClient:   client,
+		metricsClient: metricsClient,
+		podLister:     informer.Lister(),
+		podSynced:     informer.Informer().HasSynced,
 	}
 }
 
@@ -163,7 +167,7 @@ func (c *core) ProcessBalancer(balancer *balancerapi.Balancer, now time.Time) (*
 
 		statusInfo.replicasObserved += summary.Total
 	}
-	placement, _, err := policy.GetPlacement(balancer, summaries)
+	placement, _, err := policy.GetPlacement(balancer, summaries, c.getMetricValues(balancer), now)
 	if err != nil {
 		return &statusInfo, newBalancerError(ApplyingPolicyListing, err)
 	}
@@ -186,6 +190,25 @@ func (c *core) ProcessBalancer(balancer *balancerapi.Balancer, now time.Time) (*
 	return &statusInfo, nil
 }
 
+// getMetricValues returns the values of the metrics of the targets used by
+// the balancer policy. Targets whose metric is not available are skipped.
+func (c *core) getMetricValues(balancer *balancerapi.Balancer) map[string]float64 {
+	result := make(map[string]float64)
+	proportions := balancer.Spec.Policy.Proportions
+	if proportions == nil || proportions.Metric == nil {
+		return result
+	}
+	for name, metric := range proportions.Metric.TargetMetrics {
+		value, err := c.metricsClient.GetExternalMetric(balancer.Namespace, metric)
+		if err != nil {
+			klog.Warningf("Failed to get metric of target %s of balancer %s/%s: %v", name, balancer.Namespace, balancer.Name, err)
+			continue
+		}
+		result[name] = value
+	}
+	return result
+}
+
 func (c *core) IsSynced() bool {
 	return c.podSynced()
 }
@@ -152,12 +152,26 @@ func newBalancer(replicas int32) *balancerapi.Balancer {
 	}
 }
 
+func newBalancerWithLatencyMetric(replicas int32) *balancerapi.Balancer {
+	balancer := newBalancer(replicas)
+	balancer.Spec.Policy.Proportions.TargetProportions = map[string]int32{"a": 50, "b": 50}
+	balancer.Spec.Policy.Proportions.Metric = &balancerapi.ProportionsMetric{
+		Type: balancerapi.InverseProportionsMetricType,
+		TargetMetrics: map[string]hpa.MetricIdentifier{
+			"a": {Name: "latency-a"},
+			"b": {Name: "latency-b"},
+		},
+	}
+	return balancer
+}
+
 func TestProcessBalancer(t *testing.T) {
 
 	tests := []struct {
 		name               string
 		pods               []*v1.Pod
 		balancer           *balancerapi.Balancer
+		metrics            map[string]float64
 		scales             []*hpav1.Scale
 		noChange           bool
 		expected           map[string]int32
@@ -267,6 +281,28 @@ func TestProcessBalancer(t *testing.T) {
 			},
 			expected: map[string]int32{"a": 1, "b": 1},
 		},
+		{
+			name:     "No pods, 9 replicas, 50/50, a with double latency",
+			pods:     []*v1.Pod{},
+			balancer: newBalancerWithLatencyMetric(9),
+			metrics:  map[string]float64{"default/latency-a": 200, "default/latency-b": 100},
+			scales: []*hpav1.Scale{
+				newScale("a", 0),
+				newScale("b", 0),
+			},
+			expected: map[string]int32{"a": 3, "b": 6},
+		},
+		{
+			name:     "No pods, 9 replicas, 50/50, missing latency",
+			pods:     []*v1.Pod{},
+			balancer: newBalancerWithLatencyMetric(9),
+			metrics:  map[string]float64{"default/latency-a": 200},
+			scales: []*hpav1.Scale{
+				newScale("a", 0),
+				newScale("b", 0),
+			},
+			expected: map[string]int32{"a": 5, "b": 4},
+		},
 		{
 			name:               "Wrong targets",
 			pods:               []*v1.Pod{},
@@ -289,7 +325,11 @@ func TestProcessBalancer(t *testing.T) {
 				pods: tc.pods,
 			}
 
-			core := newCoreForTests(&scaleClient, &podLister)
+			metricsClient := metricsClientMock{
+				values: tc.metrics,
+			}
+
+			core := newCoreForTests(&scaleClient, &metricsClient, &podLister)
 			statusInfo, errorsInfo := core.ProcessBalancer(tc.balancer, time.Now())
 
 			if tc.balancerPhaseError != "" {
@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	hpa "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// externalMetricsPath is the path of the external metrics API.
+	externalMetricsPath = "/apis/external.metrics.k8s.io/v1beta1"
+)
+
+// MetricsClientInterface is an interface to get values of external metrics.
+type MetricsClientInterface interface {
+	// GetExternalMetric returns the sum of the values of all series of the
+	// external metric that match the metric selector.
+	GetExternalMetric(namespace string, metric hpa.MetricIdentifier) (float64, error)
+}
+
+// MetricsClient implements MetricsClientInterface and issues real queries to
+// the external metrics API.
+type MetricsClient struct {
+	context    context.Context
+	restClient rest.Interface
+}
+
+// externalMetricValueList is the subset of the external metrics API response
+// used by the MetricsClient.
+type externalMetricValueList struct {
+	Items []struct {
+		Value resource.Quantity `json:"value"`
+	} `json:"items"`
+}
+
+// NewMetricsClient builds metrics client. The REST client must be configured
+// for the root of the apiserver.
+func NewMetricsClient(context context.Context, restClient rest.Interface) *MetricsClient {
+	return &MetricsClient{
+		context:    context,
+		restClient: restClient,
+	}
+}
+
+// GetExternalMetric returns the sum of the values of all series of the
+// external metric that match the metric selector.
+func (m *MetricsClient) GetExternalMetric(namespace string, metric hpa.MetricIdentifier) (float64, error) {
+	selector := metav1.LabelSelector{}
+	if metric.Selector != nil {
+		selector = *metric.Selector
+	}
+	labelSelector, err := metav1.LabelSelectorAsSelector(&selector)
+	if err != nil {
+		return 0, fmt.Errorf("invalid selector of metric %s: %v", metric.Name, err)
+	}
+	raw, err := m.restClient.Get().
+		AbsPath(externalMetricsPath, "namespaces", namespace, metric.Name).
+		Param("labelSelector", labelSelector.String()).
+		Do(m.context).
+		Raw()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get external metric %s: %v", metric.Name, err)
+	}
+	values := externalMetricValueList{}
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return 0, fmt.Errorf("failed to parse external metric %s: %v", metric.Name, err)
+	}
+	if len(values.Items) == 0 {
+		return 0, fmt.Errorf("no values of external metric %s", metric.Name)
+	}
+	sum := 0.0
+	for _, item := range values.Items {
+		sum += item.Value.AsApproximateFloat64()
+	}
+	return sum, nil
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	hpa "k8s.io/api/autoscaling/v2"
+)
+
+type metricsClientMock struct {
+	values map[string]float64
+}
+
+func (m *metricsClientMock) GetExternalMetric(namespace string, metric hpa.MetricIdentifier) (float64, error) {
+	key := namespace + "/" + metric.Name
+	if value, found := m.values[key]; found {
+		return value, nil
+	}
+	return 0, fmt.Errorf("Not found: %s", key)
+}
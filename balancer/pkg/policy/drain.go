@@ -0,0 +1,52 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"math"
+	"time"
+
+	"k8s.io/autoscaler/balancer/pkg/apis/balancer.x-k8s.io/v1alpha1"
+)
+
+// drainTarget shifts replicas from the source target of the drain policy to
+// its destination target. The number of shifted replicas grows linearly with
+// the time elapsed since the start of the drain, until only the minimum is
+// left in the source target. The destination target gets at most its maximum.
+func drainTarget(drain *v1alpha1.DrainPolicy, infos map[string]*targetInfo,
+	placement ReplicaPlacement, now time.Time) {
+	if now.Before(drain.StartTime.Time) {
+		return
+	}
+	source, destination := infos[drain.SourceTarget], infos[drain.DestinationTarget]
+	drainable := placement[drain.SourceTarget] - source.min
+	if drainable <= 0 {
+		return
+	}
+	progress := 1.0
+	if drain.DurationSeconds > 0 {
+		duration := time.Duration(drain.DurationSeconds) * time.Second
+		progress = math.Min(1.0, float64(now.Sub(drain.StartTime.Time))/float64(duration))
+	}
+	shifted := int32(math.Floor(progress * float64(drainable)))
+	shifted = minInt32(shifted, destination.max-placement[drain.DestinationTarget])
+	if shifted <= 0 {
+		return
+	}
+	placement[drain.SourceTarget] -= shifted
+	placement[drain.DestinationTarget] += shifted
+}
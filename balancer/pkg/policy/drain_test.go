@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/balancer/pkg/apis/balancer.x-k8s.io/v1alpha1"
+)
+
+func TestDrainTarget(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	drain := &v1alpha1.DrainPolicy{
+		SourceTarget:      "a",
+		DestinationTarget: "b",
+		StartTime:         metav1.NewTime(start),
+		DurationSeconds:   600,
+	}
+	tests := []struct {
+		name      string
+		now       time.Time
+		infos     map[string]*targetInfo
+		placement ReplicaPlacement
+		expected  ReplicaPlacement
+	}{
+		{
+			name: "before start",
+			now:  start.Add(-time.Minute),
+			infos: map[string]*targetInfo{
+				"a": {max: maxReplicas},
+				"b": {max: maxReplicas},
+			},
+			placement: ReplicaPlacement{"a": 10, "b": 0},
+			expected:  ReplicaPlacement{"a": 10, "b": 0},
+		},
+		{
+			name: "half way",
+			now:  start.Add(5 * time.Minute),
+			infos: map[string]*targetInfo{
+				"a": {max: maxReplicas},
+				"b": {max: maxReplicas},
+			},
+			placement: ReplicaPlacement{"a": 10, "b": 2},
+			expected:  ReplicaPlacement{"a": 5, "b": 7},
+		},
+		{
+			name: "finished, min left in source",
+			now:  start.Add(time.Hour),
+			infos: map[string]*targetInfo{
+				"a": {min: 1, max: maxReplicas},
+				"b": {max: maxReplicas},
+			},
+			placement: ReplicaPlacement{"a": 10, "b": 2},
+			expected:  ReplicaPlacement{"a": 1, "b": 11},
+		},
+		{
+			name: "finished, destination max",
+			now:  start.Add(time.Hour),
+			infos: map[string]*targetInfo{
+				"a": {max: maxReplicas},
+				"b": {max: 8},
+			},
+			placement: ReplicaPlacement{"a": 10, "b": 2},
+			expected:  ReplicaPlacement{"a": 4, "b": 8},
+		},
+	}
+
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d: %s", i, tc.name), func(t *testing.T) {
+			drainTarget(drain, tc.infos, tc.placement, tc.now)
+			assert.Equal(t, tc.expected, tc.placement)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"math"
+
+	"k8s.io/autoscaler/balancer/pkg/apis/balancer.x-k8s.io/v1alpha1"
+)
+
+const (
+	// metricProportionScale is the factor by which the proportions are
+	// multiplied before they are adjusted with the metric values, so that
+	// the adjusted proportions keep their precision as integers.
+	metricProportionScale = 1000
+)
+
+// adjustProportions returns the proportions adjusted with the metric values of
+// the targets. Targets without a metric keep their proportion. Returns false if
+// a metric value is missing or invalid, in which case the static proportions
+// should be used.
+func adjustProportions(proportions map[string]int32, metric *v1alpha1.ProportionsMetric,
+	metricValues map[string]float64) (map[string]int32, bool) {
+	values := make(map[string]float64)
+	for name := range metric.TargetMetrics {
+		if _, found := proportions[name]; !found {
+			continue
+		}
+		value, found := metricValues[name]
+		if !found || value < 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+			return nil, false
+		}
+		if value == 0 && metric.Type == v1alpha1.InverseProportionsMetricType {
+			return nil, false
+		}
+		values[name] = value
+	}
+	if len(values) == 0 {
+		return nil, false
+	}
+
+	// Factors are normalized, so that the best target gets 1.
+	inverse := metric.Type == v1alpha1.InverseProportionsMetricType
+	first := true
+	var best float64
+	for _, value := range values {
+		if first || (inverse && value < best) || (!inverse && value > best) {
+			best = value
+			first = false
+		}
+	}
+	if best == 0 {
+		return nil, false
+	}
+	result := make(map[string]int32, len(proportions))
+	for name, proportion := range proportions {
+		factor := 1.0
+		if value, found := values[name]; found {
+			if inverse {
+				factor = best / value
+			} else {
+				factor = value / best
+			}
+		}
+		adjusted := math.Round(float64(proportion) * metricProportionScale * factor)
+		result[name] = int32(math.Min(adjusted, math.MaxInt32))
+	}
+	return result, true
+}
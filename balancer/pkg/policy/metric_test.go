@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	hpa "k8s.io/api/autoscaling/v2"
+	"k8s.io/autoscaler/balancer/pkg/apis/balancer.x-k8s.io/v1alpha1"
+)
+
+func TestAdjustProportions(t *testing.T) {
+	targetMetrics := map[string]hpa.MetricIdentifier{
+		"a": {Name: "metric-a"},
+		"b": {Name: "metric-b"},
+	}
+	tests := []struct {
+		name         string
+		metricType   v1alpha1.ProportionsMetricType
+		proportions  map[string]int32
+		metricValues map[string]float64
+		expected     map[string]int32
+		expectedOk   bool
+	}{
+		{
+			name:         "inverse, double latency",
+			metricType:   v1alpha1.InverseProportionsMetricType,
+			proportions:  map[string]int32{"a": 50, "b": 50},
+			metricValues: map[string]float64{"a": 200, "b": 100},
+			expected:     map[string]int32{"a": 25000, "b": 50000},
+			expectedOk:   true,
+		},
+		{
+			name:         "direct, double capacity",
+			metricType:   v1alpha1.DirectProportionsMetricType,
+			proportions:  map[string]int32{"a": 30, "b": 70},
+			metricValues: map[string]float64{"a": 20, "b": 10},
+			expected:     map[string]int32{"a": 30000, "b": 35000},
+			expectedOk:   true,
+		},
+		{
+			name:         "target without metric keeps its proportion",
+			metricType:   v1alpha1.InverseProportionsMetricType,
+			proportions:  map[string]int32{"a": 50, "b": 50, "c": 50},
+			metricValues: map[string]float64{"a": 200, "b": 100},
+			expected:     map[string]int32{"a": 25000, "b": 50000, "c": 50000},
+			expectedOk:   true,
+		},
+		{
+			name:         "missing metric value",
+			metricType:   v1alpha1.InverseProportionsMetricType,
+			proportions:  map[string]int32{"a": 50, "b": 50},
+			metricValues: map[string]float64{"a": 200},
+			expectedOk:   false,
+		},
+		{
+			name:         "zero latency",
+			metricType:   v1alpha1.InverseProportionsMetricType,
+			proportions:  map[string]int32{"a": 50, "b": 50},
+			metricValues: map[string]float64{"a": 200, "b": 0},
+			expectedOk:   false,
+		},
+		{
+			name:         "zero capacity everywhere",
+			metricType:   v1alpha1.DirectProportionsMetricType,
+			proportions:  map[string]int32{"a": 50, "b": 50},
+			metricValues: map[string]float64{"a": 0, "b": 0},
+			expectedOk:   false,
+		},
+	}
+
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d: %s", i, tc.name), func(t *testing.T) {
+			metric := &v1alpha1.ProportionsMetric{Type: tc.metricType, TargetMetrics: targetMetrics}
+			result, ok := adjustProportions(tc.proportions, metric, tc.metricValues)
+			assert.Equal(t, tc.expectedOk, ok)
+			if tc.expectedOk {
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
@@ -18,14 +18,21 @@ package policy
 
 import (
 	"fmt"
+	"time"
+
 	"k8s.io/autoscaler/balancer/pkg/apis/balancer.x-k8s.io/v1alpha1"
 	"k8s.io/autoscaler/balancer/pkg/pods"
+	"k8s.io/klog/v2"
 )
 
-// GetPlacement calculates the placement for the given balancer and pod summary
-// information for individual balancer targets.
-func GetPlacement(balancer *v1alpha1.Balancer, summaries map[string]pods.Summary) (ReplicaPlacement, PlacementProblems, error) {
+// GetPlacement calculates the placement for the given balancer, pod summary
+// information and metric values for individual balancer targets at the given time.
+func GetPlacement(balancer *v1alpha1.Balancer, summaries map[string]pods.Summary, metricValues map[string]float64, now time.Time) (ReplicaPlacement, PlacementProblems, error) {
 	targetMap := buildTargetMap(balancer.Spec.Targets)
+	var infos map[string]*targetInfo
+	var placement ReplicaPlacement
+	var problems PlacementProblems
+
 	switch balancer.Spec.Policy.PolicyName {
 	case v1alpha1.PriorityPolicyName:
 		if balancer.Spec.Policy.Priorities == nil {
@@ -34,10 +41,8 @@ func GetPlacement(balancer *v1alpha1.Balancer, summaries map[string]pods.Summary
 		if balancer.Spec.Policy.Priorities.TargetOrder == nil {
 			return nil, PlacementProblems{}, fmt.Errorf("incomplete policy definition: missing targetOrder")
 		}
-		infos := buildTargetInfoMapForPriority(targetMap, summaries)
-		placement, problems := distributeByPriority(balancer.Spec.Replicas, balancer.Spec.Policy.Priorities.TargetOrder, infos)
-		return placement, problems, nil
-
+		infos = buildTargetInfoMapForPriority(targetMap, summaries)
+		placement, problems = distributeByPriority(balancer.Spec.Replicas, balancer.Spec.Policy.Priorities.TargetOrder, infos)
 	case v1alpha1.ProportionalPolicyName:
 		if balancer.Spec.Policy.Proportions == nil {
 			return nil, PlacementProblems{}, fmt.Errorf("incomplete policy definition: missing proportions")
@@ -45,11 +50,38 @@ func GetPlacement(balancer *v1alpha1.Balancer, summaries map[string]pods.Summary
 		if balancer.Spec.Policy.Proportions.TargetProportions == nil {
 			return nil, PlacementProblems{}, fmt.Errorf("incomplete policy definition: missing targetProportions")
 		}
-		infos := buildTargetInfoMapForProportional(targetMap, summaries, balancer.Spec.Policy.Proportions.TargetProportions)
-		placement, problems := distributeByProportions(balancer.Spec.Replicas, infos)
-		return placement, problems, nil
-
+		proportions := balancer.Spec.Policy.Proportions.TargetProportions
+		if metric := balancer.Spec.Policy.Proportions.Metric; metric != nil {
+			if adjusted, ok := adjustProportions(proportions, metric, metricValues); ok {
+				proportions = adjusted
+			} else {
+				klog.V(2).Infof("Metric values of balancer %s/%s are not available, using static proportions",
+					balancer.Namespace, balancer.Name)
+			}
+		}
+		infos = buildTargetInfoMapForProportional(targetMap, summaries, proportions)
+		placement, problems = distributeByProportions(balancer.Spec.Replicas, infos)
+	case v1alpha1.WeightedPolicyName:
+		if balancer.Spec.Policy.Weights == nil {
+			return nil, PlacementProblems{}, fmt.Errorf("incomplete policy definition: missing weights")
+		}
+		if len(balancer.Spec.Policy.Weights.PriorityGroups) == 0 {
+			return nil, PlacementProblems{}, fmt.Errorf("incomplete policy definition: missing priorityGroups")
+		}
+		infos = buildTargetInfoMapForWeighted(targetMap, summaries, balancer.Spec.Policy.Weights.PriorityGroups)
+		placement, problems = distributeByWeights(balancer.Spec.Replicas, balancer.Spec.Policy.Weights.PriorityGroups, infos)
 	default:
 		return nil, PlacementProblems{}, fmt.Errorf("policy not supported: %v", balancer.Spec.Policy.PolicyName)
 	}
+
+	if drain := balancer.Spec.Policy.Drain; drain != nil {
+		if _, found := targetMap[drain.SourceTarget]; !found {
+			return nil, PlacementProblems{}, fmt.Errorf("incorrect drain definition: target %s not found", drain.SourceTarget)
+		}
+		if _, found := targetMap[drain.DestinationTarget]; !found {
+			return nil, PlacementProblems{}, fmt.Errorf("incorrect drain definition: target %s not found", drain.DestinationTarget)
+		}
+		drainTarget(drain, infos, placement, now)
+	}
+	return placement, problems, nil
 }
@@ -36,27 +36,33 @@ func distributeByPriority(replicas int32,
 		replicas = 0
 	}
 
+	// Replicas that fall back from targets with replicas that failed to start
+	// are placed on top of the shares of the next targets, up to their maxSurge.
+	var fallback int32
 	for _, key := range priorities {
 		info := infos[key]
 		free := info.max - placement[key]
-		if replicas < free {
-			placement[key] += replicas
-			replicas = 0
-		} else {
-			placement[key] += free
-			replicas -= free
+		placed := minInt32(replicas, free)
+		placement[key] += placed
+		replicas -= placed
+		free -= placed
+		if info.maxSurge != nil {
+			free = minInt32(free, *info.maxSurge)
 		}
+		surge := minInt32(fallback, free)
+		placement[key] += surge
+		fallback -= surge
 		// calculate how many may need to fall back to the other target = all new plus
 		// and those that are past deadline.
 		if infos[key].summary.NotStartedWithinDeadline > 0 {
-			fallback := info.summary.NotStartedWithinDeadline + placement[key] - info.summary.Total
-			if fallback > 0 {
-				replicas += fallback
+			f := info.summary.NotStartedWithinDeadline + placement[key] - info.summary.Total
+			if f > 0 {
+				fallback += f
 			}
 		}
 	}
-	if replicas > 0 {
-		problems.OverflowReplicas = replicas
+	if replicas+fallback > 0 {
+		problems.OverflowReplicas = replicas + fallback
 	}
 	return placement, problems
 }
@@ -180,6 +180,32 @@ func TestDistributeByPriority(t *testing.T) {
 			priorities: []string{"a", "b"},
 			expected:   ReplicaPlacement{"a": 10, "b": 9},
 		},
+		{
+			name:     "10 replicas, fallback limited by surge",
+			replicas: 10,
+			infos: map[string]*targetInfo{
+				"a": {max: maxReplicas,
+					summary: pods.Summary{
+						Total: 3, NotStartedWithinDeadline: 2}},
+				"b": {max: maxReplicas, maxSurge: int32Ptr(4)},
+			},
+			priorities: []string{"a", "b"},
+			expected:   ReplicaPlacement{"a": 10, "b": 4},
+			problems:   PlacementProblems{OverflowReplicas: 5},
+		},
+		{
+			name:     "10 replicas, surge on top of the share",
+			replicas: 10,
+			infos: map[string]*targetInfo{
+				"a": {max: 6,
+					summary: pods.Summary{
+						Total: 3, NotStartedWithinDeadline: 2}},
+				"b": {max: maxReplicas, maxSurge: int32Ptr(2)},
+			},
+			priorities: []string{"a", "b"},
+			expected:   ReplicaPlacement{"a": 6, "b": 6},
+			problems:   PlacementProblems{OverflowReplicas: 3},
+		},
 	}
 
 	for i, tc := range tests {
@@ -190,3 +216,7 @@ func TestDistributeByPriority(t *testing.T) {
 		})
 	}
 }
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
@@ -89,9 +89,9 @@ func distributeByProportions(replicas int32,
 	}
 
 	// If there are some replicas that need to be duplicated, distribute them,
-	// but only among non-problematic targets.
+	// but only among non-problematic targets and up to their maxSurge.
 	if replicas > 0 {
-		replicas = distributeGroupProportionally(replicas, notBlockedKeys, infos, placement)
+		replicas = distributeGroupProportionally(replicas, notBlockedKeys, withSurgeLimits(infos, placement), placement)
 	}
 	problems.OverflowReplicas = replicas
 	return placement, problems
@@ -265,6 +265,19 @@ func TestDistributeByProportions(t *testing.T) {
 			},
 			expected: ReplicaPlacement{"a": 10, "b": 20},
 		},
+		{
+			name:     "20 replicas, 50/50, with too few and fallback limited by surge",
+			replicas: 20,
+			infos: map[string]*targetInfo{
+				"a": {proportion: 50, min: 0, max: maxReplicas, summary: pods.Summary{
+					Total:                    3,
+					NotStartedWithinDeadline: 3,
+				}},
+				"b": {proportion: 50, min: 0, max: maxReplicas, maxSurge: int32Ptr(4)},
+			},
+			expected: ReplicaPlacement{"a": 10, "b": 14},
+			problems: PlacementProblems{OverflowReplicas: 6},
+		},
 	}
 
 	for i, tc := range tests {
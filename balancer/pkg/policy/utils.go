@@ -48,8 +48,11 @@ type targetInfo struct {
 	// count of pods of given type based on pod listener data.
 	summary pods.Summary
 
-	// proportion taken from ProportionalPolicy. 0 for other policies.
+	// proportion taken from ProportionalPolicy or weight taken from
+	// WeightedPolicy. 0 for other policies.
 	proportion int32
+	// maxSurge taken from BalancerTarget. No limit if nil.
+	maxSurge *int32
 }
 
 // PlacementProblems contains information about replicas that were problematic
@@ -87,6 +90,7 @@ func buildTargetInfoMapForProportional(
 		if target.MaxReplicas != nil {
 			result[name].max = *target.MaxReplicas
 		}
+		result[name].maxSurge = target.MaxSurge
 	}
 	return result
 }
@@ -100,3 +104,43 @@ func buildTargetInfoMapForPriority(
 
 	return buildTargetInfoMapForProportional(targetMap, summaryMap, map[string]int32{})
 }
+
+// buildTargetInfoMapForWeighted builds the target info map like
+// buildTargetInfoMapForProportional, taking the proportion of each target from
+// its weight in the priority group it belongs to. Targets not present in any
+// group get a proportion of 0. It assumes that all inputs are already
+// validated and consistent.
+func buildTargetInfoMapForWeighted(
+	targetMap map[string]v1alpha1.BalancerTarget,
+	summaryMap map[string]pods.Summary,
+	groups []v1alpha1.PriorityGroup) map[string]*targetInfo {
+	weights := make(map[string]int32)
+	for _, group := range groups {
+		for name, weight := range group.TargetWeights {
+			weights[name] = weight
+		}
+	}
+	return buildTargetInfoMapForProportional(targetMap, summaryMap, weights)
+}
+
+// withSurgeLimits returns a copy of infos in which the max of targets that
+// have maxSurge set is lowered, so that at most maxSurge replicas can be
+// placed on top of the given placement.
+func withSurgeLimits(infos map[string]*targetInfo, placement ReplicaPlacement) map[string]*targetInfo {
+	result := make(map[string]*targetInfo, len(infos))
+	for k, info := range infos {
+		limited := *info
+		if info.maxSurge != nil && placement[k]+*info.maxSurge < info.max {
+			limited.max = placement[k] + *info.maxSurge
+		}
+		result[k] = &limited
+	}
+	return result
+}
+
+func minInt32(a, b int32) int32 {
+	if a < b {
+		return a
+	}
+	return b
+}
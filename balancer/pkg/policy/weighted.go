@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"k8s.io/autoscaler/balancer/pkg/apis/balancer.x-k8s.io/v1alpha1"
+)
+
+// Main algorithm of the weighted policy. The function returns the
+// desired replica placement and information about problems.
+func distributeByWeights(replicas int32, groups []v1alpha1.PriorityGroup,
+	infos map[string]*targetInfo) (ReplicaPlacement, PlacementProblems) {
+	placement := make(ReplicaPlacement)
+	problems := PlacementProblems{}
+
+	// Place target minimums.
+	for k, info := range infos {
+		placement[k] = info.min
+		replicas -= placement[k]
+	}
+	// continue computations as there still may be fallbacks.
+	if replicas < 0 {
+		problems.MissingReplicas = -replicas
+		replicas = 0
+	}
+
+	var fallback int32
+	for _, group := range groups {
+		keys := make([]string, 0)
+		for k := range group.TargetWeights {
+			if _, found := infos[k]; found {
+				keys = append(keys, k)
+			}
+		}
+		// Distribute replicas among the group, ignoring not started replicas.
+		replicas = distributeGroupProportionally(replicas, keys, infos, placement)
+
+		// Checks which targets of the group have troubles and calculates the
+		// number of replicas that need to be duplicated in other targets until
+		// the base targets recover.
+		notBlockedKeys := make([]string, 0)
+		for _, key := range keys {
+			info := infos[key]
+			if info.summary.NotStartedWithinDeadline > 0 {
+				f := info.summary.NotStartedWithinDeadline + placement[key] - info.summary.Total
+				if f > 0 {
+					fallback += f
+				}
+			} else {
+				notBlockedKeys = append(notBlockedKeys, key)
+			}
+		}
+		// Replicas that need to be duplicated go to non-problematic targets of
+		// the group, up to their maxSurge, and then to the next groups.
+		if fallback > 0 {
+			fallback = distributeGroupProportionally(fallback, notBlockedKeys, withSurgeLimits(infos, placement), placement)
+		}
+	}
+	if replicas+fallback > 0 {
+		problems.OverflowReplicas = replicas + fallback
+	}
+	return placement, problems
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/autoscaler/balancer/pkg/apis/balancer.x-k8s.io/v1alpha1"
+	"k8s.io/autoscaler/balancer/pkg/pods"
+)
+
+func TestDistributeByWeights(t *testing.T) {
+	twoGroups := []v1alpha1.PriorityGroup{
+		{TargetWeights: map[string]int32{"a": 2, "b": 1}},
+		{TargetWeights: map[string]int32{"c": 1}},
+	}
+	tests := []struct {
+		name     string
+		replicas int32
+		groups   []v1alpha1.PriorityGroup
+		infos    map[string]*targetInfo
+		expected ReplicaPlacement
+		problems PlacementProblems
+	}{
+		{
+			name:     "9 replicas, 2/1 in the first group",
+			replicas: 9,
+			groups:   twoGroups,
+			infos: map[string]*targetInfo{
+				"a": {proportion: 2, max: maxReplicas},
+				"b": {proportion: 1, max: maxReplicas},
+				"c": {proportion: 1, max: maxReplicas},
+			},
+			expected: ReplicaPlacement{"a": 6, "b": 3, "c": 0},
+		},
+		{
+			name:     "9 replicas, first group full",
+			replicas: 9,
+			groups:   twoGroups,
+			infos: map[string]*targetInfo{
+				"a": {proportion: 2, max: 4},
+				"b": {proportion: 1, max: 2},
+				"c": {proportion: 1, max: maxReplicas},
+			},
+			expected: ReplicaPlacement{"a": 4, "b": 2, "c": 3},
+		},
+		{
+			name:     "9 replicas, all full",
+			replicas: 9,
+			groups:   twoGroups,
+			infos: map[string]*targetInfo{
+				"a": {proportion: 2, max: 4},
+				"b": {proportion: 1, max: 2},
+				"c": {proportion: 1, max: 1},
+			},
+			expected: ReplicaPlacement{"a": 4, "b": 2, "c": 1},
+			problems: PlacementProblems{OverflowReplicas: 2},
+		},
+		{
+			name:     "2 replicas, min outside of groups",
+			replicas: 2,
+			groups:   twoGroups,
+			infos: map[string]*targetInfo{
+				"a": {proportion: 2, max: maxReplicas},
+				"b": {proportion: 1, max: maxReplicas},
+				"c": {proportion: 1, max: maxReplicas},
+				"d": {min: 3, max: maxReplicas},
+			},
+			expected: ReplicaPlacement{"a": 0, "b": 0, "c": 0, "d": 3},
+			problems: PlacementProblems{MissingReplicas: 1},
+		},
+		{
+			name:     "9 replicas, fallback inside of the group",
+			replicas: 9,
+			groups:   twoGroups,
+			infos: map[string]*targetInfo{
+				"a": {proportion: 2, max: maxReplicas, summary: pods.Summary{
+					Total:                    6,
+					NotStartedWithinDeadline: 2,
+				}},
+				"b": {proportion: 1, max: maxReplicas},
+				"c": {proportion: 1, max: maxReplicas},
+			},
+			expected: ReplicaPlacement{"a": 6, "b": 5, "c": 0},
+		},
+		{
+			name:     "9 replicas, fallback limited by surge goes to the next group",
+			replicas: 9,
+			groups:   twoGroups,
+			infos: map[string]*targetInfo{
+				"a": {proportion: 2, max: maxReplicas, summary: pods.Summary{
+					Total:                    6,
+					NotStartedWithinDeadline: 3,
+				}},
+				"b": {proportion: 1, max: maxReplicas, maxSurge: int32Ptr(1)},
+				"c": {proportion: 1, max: maxReplicas, maxSurge: int32Ptr(1)},
+			},
+			expected: ReplicaPlacement{"a": 6, "b": 4, "c": 1},
+			problems: PlacementProblems{OverflowReplicas: 1},
+		},
+	}
+
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d: %s", i, tc.name), func(t *testing.T) {
+			result, problems := distributeByWeights(tc.replicas, tc.groups, tc.infos)
+			assert.Equal(t, tc.expected, result)
+			assert.Equal(t, tc.problems, problems)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudprovider
+
+// ScaleUpOperationState tells if the instances requested by a scale-up operation were provisioned.
+type ScaleUpOperationState int
+
+const (
+	// ScaleUpOperationAccepted means that the request was accepted, but not all instances are provisioned yet.
+	ScaleUpOperationAccepted ScaleUpOperationState = 1
+	// ScaleUpOperationProvisioned means that all requested instances were provisioned.
+	ScaleUpOperationProvisioned ScaleUpOperationState = 2
+	// ScaleUpOperationFailed means that the operation failed and won't provision the remaining instances.
+	ScaleUpOperationFailed ScaleUpOperationState = 3
+)
+
+// ScaleUpOperationStatus represents the status of a scale-up operation.
+type ScaleUpOperationStatus struct {
+	// State tells if the instances were provisioned.
+	State ScaleUpOperationState
+	// ProvisionedCount is the number of instances provisioned by the operation so far.
+	ProvisionedCount int
+	// ErrorInfo describes why the operation failed. Optional, only used for failed operations.
+	ErrorInfo *InstanceErrorInfo
+}
+
+// ScaleUpOperation is a handle of a scale-up requested with IncreaseSizeAsync.
+type ScaleUpOperation interface {
+	// Id returns an identifier of the operation, used for logging.
+	Id() string
+	// Status returns the current status of the operation. It's called in every loop until the operation
+	// finishes, cloud providers are responsible for limiting how often they query their APIs.
+	Status() (ScaleUpOperationStatus, error)
+}
+
+// AsyncSizeIncreaser is implemented by node groups able to increase their size asynchronously,
+// returning as soon as the request is accepted. Polling the returned operation allows
+// ClusterStateRegistry to tell scale-ups which are still being provisioned by the cloud provider
+// from the ones whose instances were provisioned but didn't register as nodes.
+type AsyncSizeIncreaser interface {
+	// IncreaseSizeAsync requests increasing the size of the node group, like IncreaseSize, without
+	// waiting for the instances to be provisioned. The target size of the node group has to include
+	// the requested instances once this method returns, and mustn't include the instances which weren't
+	// provisioned once the operation fails. ErrNotImplemented can be returned to fall back to IncreaseSize.
+	IncreaseSizeAsync(delta int) (ScaleUpOperation, error)
+}
@@ -42,6 +42,7 @@ type asgCache struct {
 	instanceToAsg        map[AwsInstanceRef]*asg
 	instanceStatus       map[AwsInstanceRef]*string
 	instanceLifecycle    map[AwsInstanceRef]*string
+	instanceTypeCounts   map[AwsRef]map[string]int
 	asgInstanceTypeCache *instanceTypeExpirationStore
 	mutex                sync.Mutex
 	awsService           *awsWrapper
@@ -50,6 +51,9 @@ type asgCache struct {
 	asgAutoDiscoverySpecs []asgAutoDiscoveryConfig
 	explicitlyConfigured  map[AwsRef]bool
 	autoscalingOptions    map[AwsRef]map[string]string
+	// autoprovisionedTags match ASGs created by node group autoprovisioning. They are
+	// discovered separately, as they don't need to match the auto-discovery tags.
+	autoprovisionedTags map[string]string
 }
 
 type launchTemplate struct {
@@ -72,7 +76,14 @@ type asg struct {
 	curSize        int
 	lastUpdateTime time.Time
 
+	// minSizeOverride and maxSizeOverride replace minSize and maxSize if limitsOverridden is set. They're kept
+	// when the ASG is refreshed.
+	limitsOverridden bool
+	minSizeOverride  int
+	maxSizeOverride  int
+
 	AvailabilityZones       []string
+	Subnets                 []string
 	LaunchConfigurationName string
 	LaunchTemplate          *launchTemplate
 	MixedInstancesPolicy    *mixedInstancesPolicy
@@ -87,6 +98,7 @@ func newASGCache(awsService *awsWrapper, explicitSpecs []string, autoDiscoverySp
 		instanceToAsg:         make(map[AwsInstanceRef]*asg),
 		instanceStatus:        make(map[AwsInstanceRef]*string),
 		instanceLifecycle:     make(map[AwsInstanceRef]*string),
+		instanceTypeCounts:    make(map[AwsRef]map[string]int),
 		asgInstanceTypeCache:  newAsgInstanceTypeCache(awsService),
 		interrupt:             make(chan struct{}),
 		asgAutoDiscoverySpecs: autoDiscoverySpecs,
@@ -153,6 +165,7 @@ func (m *asgCache) register(asg *asg) *asg {
 		// Those information are mainly required to create templates when scaling
 		// from zero
 		existing.AvailabilityZones = asg.AvailabilityZones
+		existing.Subnets = asg.Subnets
 		existing.LaunchConfigurationName = asg.LaunchConfigurationName
 		existing.LaunchTemplate = asg.LaunchTemplate
 		existing.MixedInstancesPolicy = asg.MixedInstancesPolicy
@@ -220,6 +233,19 @@ func (m *asgCache) findForInstance(instance AwsInstanceRef) *asg {
 	return nil
 }
 
+// FindInstanceByName returns the reference of the registered instance with the given EC2 instance id.
+func (m *asgCache) FindInstanceByName(name string) (AwsInstanceRef, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for instance := range m.instanceToAsg {
+		if instance.Name == name {
+			return instance, true
+		}
+	}
+	return AwsInstanceRef{}, false
+}
+
 // InstancesByAsg returns the nodes of an ASG
 func (m *asgCache) InstancesByAsg(ref AwsRef) ([]AwsInstanceRef, error) {
 	m.mutex.Lock()
@@ -232,6 +258,14 @@ func (m *asgCache) InstancesByAsg(ref AwsRef) ([]AwsInstanceRef, error) {
 	return nil, fmt.Errorf("error while looking for instances of ASG: %s", ref)
 }
 
+// InstanceTypeCounts returns the number of instances of each instance type in the ASG.
+func (m *asgCache) InstanceTypeCounts(ref AwsRef) map[string]int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.instanceTypeCounts[ref]
+}
+
 func (m *asgCache) InstanceStatus(ref AwsInstanceRef) (*string, error) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -422,6 +456,7 @@ func (m *asgCache) regenerate() error {
 	newAsgToInstancesCache := make(map[AwsRef][]AwsInstanceRef)
 	newInstanceStatusMap := make(map[AwsInstanceRef]*string)
 	newInstanceLifecycleMap := make(map[AwsInstanceRef]*string)
+	newInstanceTypeCounts := make(map[AwsRef]map[string]int)
 
 	// Fetch details of all ASGs
 	refreshNames := m.buildAsgNames()
@@ -440,6 +475,21 @@ func (m *asgCache) regenerate() error {
 
 	groups := append(namedGroups, taggedGroups...)
 
+	klog.V(4).Infof("Regenerating instance to ASG map for autoprovisioned ASG tags: %v", m.autoprovisionedTags)
+	autoprovisionedGroups, err := m.awsService.getAutoscalingGroupsByTags(m.autoprovisionedTags)
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]bool, len(groups))
+	for _, group := range groups {
+		seen[aws.StringValue(group.AutoScalingGroupName)] = true
+	}
+	for _, group := range autoprovisionedGroups {
+		if !seen[aws.StringValue(group.AutoScalingGroupName)] {
+			groups = append(groups, group)
+		}
+	}
+
 	// If currently any ASG has more Desired than running Instances, introduce placeholders
 	// for the instances to come up. This is required to track Desired instances that
 	// will never come up, like with Spot Request that can't be fulfilled
@@ -457,6 +507,7 @@ func (m *asgCache) regenerate() error {
 		asg = m.register(asg)
 
 		newAsgToInstancesCache[asg.AwsRef] = make([]AwsInstanceRef, len(group.Instances))
+		newInstanceTypeCounts[asg.AwsRef] = make(map[string]int)
 
 		for i, instance := range group.Instances {
 			ref := m.buildInstanceRefFromAWS(instance)
@@ -464,6 +515,9 @@ func (m *asgCache) regenerate() error {
 			newAsgToInstancesCache[asg.AwsRef][i] = ref
 			newInstanceStatusMap[ref] = instance.HealthStatus
 			newInstanceLifecycleMap[ref] = instance.LifecycleState
+			if instance.InstanceType != nil {
+				newInstanceTypeCounts[asg.AwsRef][*instance.InstanceType]++
+			}
 		}
 	}
 
@@ -494,6 +548,7 @@ func (m *asgCache) regenerate() error {
 	m.autoscalingOptions = newAutoscalingOptions
 	m.instanceStatus = newInstanceStatusMap
 	m.instanceLifecycle = newInstanceLifecycleMap
+	m.instanceTypeCounts = newInstanceTypeCounts
 	return nil
 }
 
@@ -508,13 +563,11 @@ func (m *asgCache) createPlaceholdersForDesiredNonStartedInstances(groups []*aut
 		klog.V(4).Infof("Instance group %s has only %d instances created while requested count is %d. "+
 			"Creating placeholder instances.", *g.AutoScalingGroupName, realInstances, desired)
 
-		healthStatus := ""
-		isAvailable, err := m.isNodeGroupAvailable(g)
+		healthStatus, err := m.unfulfillableStatus(g)
 		if err != nil {
 			klog.V(4).Infof("Could not check instance availability, creating placeholder node anyways: %v", err)
-		} else if !isAvailable {
+		} else if healthStatus != "" {
 			klog.Warningf("Instance group %s cannot provision any more nodes!", *g.AutoScalingGroupName)
-			healthStatus = placeholderUnfulfillableStatus
 		}
 
 		for i := realInstances; i < desired; i++ {
@@ -529,7 +582,9 @@ func (m *asgCache) createPlaceholdersForDesiredNonStartedInstances(groups []*aut
 	return groups
 }
 
-func (m *asgCache) isNodeGroupAvailable(group *autoscaling.Group) (bool, error) {
+// unfulfillableStatus returns the status of placeholder instances of an ASG whose last scale-up failed,
+// or an empty string if the ASG is still able to provision nodes.
+func (m *asgCache) unfulfillableStatus(group *autoscaling.Group) (string, error) {
 	input := &autoscaling.DescribeScalingActivitiesInput{
 		AutoScalingGroupName: group.AutoScalingGroupName,
 	}
@@ -538,7 +593,7 @@ func (m *asgCache) isNodeGroupAvailable(group *autoscaling.Group) (bool, error)
 	response, err := m.awsService.DescribeScalingActivities(input)
 	observeAWSRequest("DescribeScalingActivities", err, start)
 	if err != nil {
-		return true, err // If we can't describe the scaling activities we assume the node group is available
+		return "", err // If we can't describe the scaling activities we assume the node group is available
 	}
 
 	for _, activity := range response.Activities {
@@ -549,13 +604,16 @@ func (m *asgCache) isNodeGroupAvailable(group *autoscaling.Group) (bool, error)
 				break
 			} else if *activity.StatusCode == "Failed" {
 				klog.Warningf("ASG %s scaling failed with %s", asgRef.Name, *activity)
-				return false, nil
+				if reservationExhaustedRegex.MatchString(aws.StringValue(activity.StatusMessage)) {
+					return placeholderReservationExhaustedStatus, nil
+				}
+				return placeholderUnfulfillableStatus, nil
 			}
 		} else {
 			klog.V(4).Infof("asg %v is not registered yet, skipping DescribeScalingActivities check", asgRef.Name)
 		}
 	}
-	return true, nil
+	return "", nil
 }
 
 func (m *asgCache) buildAsgFromAWS(g *autoscaling.Group) (*asg, error) {
@@ -577,6 +635,7 @@ func (m *asgCache) buildAsgFromAWS(g *autoscaling.Group) (*asg, error) {
 
 		curSize:                 int(aws.Int64Value(g.DesiredCapacity)),
 		AvailabilityZones:       aws.StringValueSlice(g.AvailabilityZones),
+		Subnets:                 parseSubnets(aws.StringValue(g.VPCZoneIdentifier)),
 		LaunchConfigurationName: aws.StringValue(g.LaunchConfigurationName),
 		Tags:                    g.Tags,
 	}
@@ -62,6 +62,8 @@ func TestCreatePlaceholders(t *testing.T) {
 		groupLastUpdateTime time.Time
 		describeErr         error
 		asgToCheck          *string
+		// reservationExhausted is whether the failed activity is expected to be recognized as exhausted reservation.
+		reservationExhausted bool
 	}{
 		{
 			name:            "add placeholders successful",
@@ -87,6 +89,31 @@ func TestCreatePlaceholders(t *testing.T) {
 			},
 			groupLastUpdateTime: time.Unix(9, 0),
 		},
+		{
+			name:            "early abort if AWS scaling up fails on exhausted capacity reservation",
+			desiredCapacity: aws.Int64(1),
+			activities: []*autoscaling.Activity{
+				{
+					StatusCode:    aws.String("Failed"),
+					StatusMessage: aws.String("Could not launch On-Demand Instances. ReservationCapacityExceeded - The targeted Capacity Reservation does not have sufficient capacity. Launching EC2 instance failed."),
+					StartTime:     aws.Time(time.Unix(10, 0)),
+				},
+			},
+			groupLastUpdateTime:  time.Unix(9, 0),
+			reservationExhausted: true,
+		},
+		{
+			name:            "other errors mentioning capacity reservations aren't exhausted reservations",
+			desiredCapacity: aws.Int64(1),
+			activities: []*autoscaling.Activity{
+				{
+					StatusCode:    aws.String("Failed"),
+					StatusMessage: aws.String("Could not launch On-Demand Instances. InvalidParameterValue - The capacity reservation target is not valid. Launching EC2 instance failed."),
+					StartTime:     aws.Time(time.Unix(10, 0)),
+				},
+			},
+			groupLastUpdateTime: time.Unix(9, 0),
+		},
 		{
 			name:            "AWS scaling failed event before CA scale_up",
 			desiredCapacity: aws.Int64(1),
@@ -158,7 +185,11 @@ func TestCreatePlaceholders(t *testing.T) {
 			asgCache.createPlaceholdersForDesiredNonStartedInstances(groups)
 			assert.Equal(t, int64(len(groups[0].Instances)), *tc.desiredCapacity)
 			if tc.activities != nil && *tc.activities[0].StatusCode == "Failed" && tc.activities[0].StartTime.After(tc.groupLastUpdateTime) && asgName == registeredAsgName {
-				assert.Equal(t, *groups[0].Instances[0].HealthStatus, placeholderUnfulfillableStatus)
+				expectedStatus := placeholderUnfulfillableStatus
+				if tc.reservationExhausted {
+					expectedStatus = placeholderReservationExhaustedStatus
+				}
+				assert.Equal(t, *groups[0].Instances[0].HealthStatus, expectedStatus)
 			} else if len(groups[0].Instances) > 0 {
 				assert.Equal(t, *groups[0].Instances[0].HealthStatus, "")
 			}
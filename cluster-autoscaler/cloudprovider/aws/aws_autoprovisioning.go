@@ -0,0 +1,259 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/aws"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/service/autoscaling"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/service/ec2"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	klog "k8s.io/klog/v2"
+)
+
+const (
+	// autoprovisionedTagKeyPrefix followed by the cluster name marks ASGs created by node group
+	// autoprovisioning of that cluster. The tag value is autoprovisionedTagValue.
+	autoprovisionedTagKeyPrefix = "k8s.io/cluster-autoscaler/autoprovisioned/"
+	autoprovisionedTagValue     = "owned"
+	// autoprovisionedNamePrefix is the name prefix of ASGs created by node group autoprovisioning.
+	autoprovisionedNamePrefix  = "ca-autoprovisioned-"
+	nodeTemplateLabelTagPrefix = "k8s.io/cluster-autoscaler/node-template/label/"
+	nodeTemplateTaintTagPrefix = "k8s.io/cluster-autoscaler/node-template/taint/"
+	// autoprovisionedLaunchTemplateVersion is the launch template version used by autoprovisioned ASGs.
+	autoprovisionedLaunchTemplateVersion = "$Default"
+)
+
+// autoprovisioningConfig describes how ASGs are created by node group autoprovisioning.
+type autoprovisioningConfig struct {
+	instanceTypes  []string
+	launchTemplate string
+	subnets        []string
+	zones          []string
+	maxSize        int
+	clusterName    string
+}
+
+// enableAutoprovisioning validates the autoprovisioning options and starts discovering
+// previously autoprovisioned ASGs.
+func (m *AwsManager) enableAutoprovisioning(opts config.AWSOptions, clusterName string) error {
+	if clusterName == "" {
+		return fmt.Errorf("cluster name is required for node group autoprovisioning")
+	}
+	if opts.AutoprovisioningLaunchTemplate == "" {
+		return fmt.Errorf("launch template is required for node group autoprovisioning")
+	}
+	if len(opts.AutoprovisioningSubnets) == 0 {
+		return fmt.Errorf("at least one subnet is required for node group autoprovisioning")
+	}
+	if opts.AutoprovisioningMaxSize <= 0 {
+		return fmt.Errorf("max size of autoprovisioned node groups must be positive, got %d", opts.AutoprovisioningMaxSize)
+	}
+	for _, instanceType := range opts.AutoprovisioningInstanceTypes {
+		if _, found := m.instanceTypes[instanceType]; !found {
+			return fmt.Errorf("unknown EC2 instance type %q", instanceType)
+		}
+	}
+	zones, err := m.awsService.getAvailabilityZonesForSubnets(opts.AutoprovisioningSubnets)
+	if err != nil {
+		return err
+	}
+
+	m.autoprovisioning = &autoprovisioningConfig{
+		instanceTypes:  opts.AutoprovisioningInstanceTypes,
+		launchTemplate: opts.AutoprovisioningLaunchTemplate,
+		subnets:        opts.AutoprovisioningSubnets,
+		zones:          zones,
+		maxSize:        opts.AutoprovisioningMaxSize,
+		clusterName:    clusterName,
+	}
+	m.asgCache.mutex.Lock()
+	m.asgCache.autoprovisionedTags = map[string]string{autoprovisionedTagKey(clusterName): autoprovisionedTagValue}
+	m.asgCache.mutex.Unlock()
+	return m.forceRefresh()
+}
+
+// buildAutoprovisionedAsg builds a theoretical ASG running the given instance type.
+// The name is derived from the instance type, labels and taints so that it is stable across loops.
+func (m *AwsManager) buildAutoprovisionedAsg(instanceType string, labels map[string]string, taints []apiv1.Taint) *asg {
+	name := autoprovisionedAsgName(m.autoprovisioning.clusterName, instanceType, labels, taints)
+	tags := map[string]string{autoprovisionedTagKey(m.autoprovisioning.clusterName): autoprovisionedTagValue}
+	for key, value := range labels {
+		tags[nodeTemplateLabelTagPrefix+key] = value
+	}
+	for _, taint := range taints {
+		tags[nodeTemplateTaintTagPrefix+taint.Key] = fmt.Sprintf("%s:%s", taint.Value, taint.Effect)
+	}
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	tagDescriptions := make([]*autoscaling.TagDescription, 0, len(keys))
+	for _, key := range keys {
+		tagDescriptions = append(tagDescriptions, &autoscaling.TagDescription{
+			Key:          aws.String(key),
+			Value:        aws.String(tags[key]),
+			ResourceId:   aws.String(name),
+			ResourceType: aws.String("auto-scaling-group"),
+		})
+	}
+
+	return &asg{
+		AwsRef:            AwsRef{Name: name},
+		minSize:           0,
+		maxSize:           m.autoprovisioning.maxSize,
+		curSize:           0,
+		AvailabilityZones: m.autoprovisioning.zones,
+		MixedInstancesPolicy: &mixedInstancesPolicy{
+			launchTemplate: &launchTemplate{
+				name:    m.autoprovisioning.launchTemplate,
+				version: autoprovisionedLaunchTemplateVersion,
+			},
+			instanceTypesOverrides: []string{instanceType},
+		},
+		Tags: tagDescriptions,
+	}
+}
+
+// createAsg creates the ASG on the AWS side and returns it once it's registered in the cache.
+func (m *AwsManager) createAsg(theoretical *asg) (*asg, error) {
+	tags := make([]*autoscaling.Tag, 0, len(theoretical.Tags))
+	for _, tag := range theoretical.Tags {
+		tags = append(tags, &autoscaling.Tag{
+			Key:               tag.Key,
+			Value:             tag.Value,
+			ResourceId:        tag.ResourceId,
+			ResourceType:      tag.ResourceType,
+			PropagateAtLaunch: aws.Bool(false),
+		})
+	}
+	policy := theoretical.MixedInstancesPolicy
+	input := &autoscaling.CreateAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String(theoretical.Name),
+		MinSize:              aws.Int64(int64(theoretical.minSize)),
+		MaxSize:              aws.Int64(int64(theoretical.maxSize)),
+		DesiredCapacity:      aws.Int64(0),
+		VPCZoneIdentifier:    aws.String(strings.Join(m.autoprovisioning.subnets, ",")),
+		MixedInstancesPolicy: &autoscaling.MixedInstancesPolicy{
+			LaunchTemplate: &autoscaling.LaunchTemplate{
+				LaunchTemplateSpecification: &autoscaling.LaunchTemplateSpecification{
+					LaunchTemplateName: aws.String(policy.launchTemplate.name),
+					Version:            aws.String(policy.launchTemplate.version),
+				},
+				Overrides: []*autoscaling.LaunchTemplateOverrides{
+					{InstanceType: aws.String(policy.instanceTypesOverrides[0])},
+				},
+			},
+		},
+		Tags: tags,
+	}
+	start := time.Now()
+	_, err := m.awsService.CreateAutoScalingGroup(input)
+	observeAWSRequest("CreateAutoScalingGroup", err, start)
+	if err != nil {
+		return nil, err
+	}
+	klog.V(1).Infof("Created autoprovisioned ASG %s", theoretical.Name)
+
+	if err := m.forceRefresh(); err != nil {
+		return nil, err
+	}
+	for _, asg := range m.getAsgs() {
+		if asg.Name == theoretical.Name {
+			return asg, nil
+		}
+	}
+	return nil, fmt.Errorf("ASG %s was created but isn't discovered yet", theoretical.Name)
+}
+
+// deleteAsg deletes the ASG on the AWS side.
+func (m *AwsManager) deleteAsg(asg *asg) error {
+	start := time.Now()
+	_, err := m.awsService.DeleteAutoScalingGroup(&autoscaling.DeleteAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String(asg.Name),
+	})
+	observeAWSRequest("DeleteAutoScalingGroup", err, start)
+	if err != nil {
+		return err
+	}
+	klog.V(1).Infof("Deleted autoprovisioned ASG %s", asg.Name)
+	m.asgCache.mutex.Lock()
+	m.asgCache.unregister(asg)
+	m.asgCache.mutex.Unlock()
+	return nil
+}
+
+// isAutoprovisionedAsg checks whether the ASG was created by node group autoprovisioning of the given cluster.
+func isAutoprovisionedAsg(asg *asg, clusterName string) bool {
+	key := autoprovisionedTagKey(clusterName)
+	for _, tag := range asg.Tags {
+		if aws.StringValue(tag.Key) == key && aws.StringValue(tag.Value) == autoprovisionedTagValue {
+			return true
+		}
+	}
+	return false
+}
+
+func autoprovisionedTagKey(clusterName string) string {
+	return autoprovisionedTagKeyPrefix + clusterName
+}
+
+// autoprovisionedAsgName includes the cluster name, so that clusters autoprovisioning
+// in the same account and region don't claim each other's ASGs.
+func autoprovisionedAsgName(clusterName, instanceType string, labels map[string]string, taints []apiv1.Taint) string {
+	parts := make([]string, 0, len(labels)+len(taints))
+	for key, value := range labels {
+		parts = append(parts, fmt.Sprintf("label:%s=%s", key, value))
+	}
+	for _, taint := range taints {
+		parts = append(parts, fmt.Sprintf("taint:%s=%s:%s", taint.Key, taint.Value, taint.Effect))
+	}
+	sort.Strings(parts)
+	hash := fnv.New32a()
+	hash.Write([]byte(strings.Join(parts, ",")))
+	return fmt.Sprintf("%s%s-%s-%08x", autoprovisionedNamePrefix, clusterName, strings.ReplaceAll(instanceType, ".", "-"), hash.Sum32())
+}
+
+func (m *awsWrapper) getAvailabilityZonesForSubnets(subnets []string) ([]string, error) {
+	start := time.Now()
+	output, err := m.DescribeSubnets(&ec2.DescribeSubnetsInput{SubnetIds: aws.StringSlice(subnets)})
+	observeAWSRequest("DescribeSubnets", err, start)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var zones []string
+	for _, subnet := range output.Subnets {
+		zone := aws.StringValue(subnet.AvailabilityZone)
+		if zone != "" && !seen[zone] {
+			seen[zone] = true
+			zones = append(zones, zone)
+		}
+	}
+	if len(zones) == 0 {
+		return nil, fmt.Errorf("unable to find availability zones of subnets %v", subnets)
+	}
+	sort.Strings(zones)
+	return zones, nil
+}
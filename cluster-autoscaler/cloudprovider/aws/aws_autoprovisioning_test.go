@@ -0,0 +1,270 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/aws"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/service/autoscaling"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/service/ec2"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+)
+
+// newTestAutoprovisioningProvider returns a provider with autoprovisioning enabled, discovering the
+// autoprovisioned ASGs returned by groups.
+func newTestAutoprovisioningProvider(t *testing.T, a *autoScalingMock, groups func() []*autoscaling.Group) *awsCloudProvider {
+	e := &ec2Mock{}
+	e.On("DescribeSubnets", &ec2.DescribeSubnetsInput{SubnetIds: aws.StringSlice([]string{"subnet-a", "subnet-b"})}).Return(&ec2.DescribeSubnetsOutput{
+		Subnets: []*ec2.Subnet{
+			{SubnetId: aws.String("subnet-a"), AvailabilityZone: aws.String("us-east-1b")},
+			{SubnetId: aws.String("subnet-b"), AvailabilityZone: aws.String("us-east-1a")},
+		},
+	}, nil)
+	e.On("DescribeLaunchTemplateVersions", &ec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateName: aws.String("test-template"),
+		Versions:           aws.StringSlice([]string{autoprovisionedLaunchTemplateVersion}),
+	}).Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+		LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{{
+			LaunchTemplateData: &ec2.ResponseLaunchTemplateData{
+				BlockDeviceMappings: []*ec2.LaunchTemplateBlockDeviceMapping{{
+					DeviceName: aws.String("/dev/xvda"),
+					Ebs:        &ec2.LaunchTemplateEbsBlockDevice{VolumeSize: aws.Int64(100)},
+				}},
+			},
+		}},
+	})
+	a.On("DescribeAutoScalingGroupsPages",
+		&autoscaling.DescribeAutoScalingGroupsInput{
+			Filters: []*autoscaling.Filter{
+				{Name: aws.String("tag:" + autoprovisionedTagKey("test-cluster")), Values: aws.StringSlice([]string{autoprovisionedTagValue})},
+			},
+			MaxRecords: aws.Int64(maxRecordsReturnedByAPI),
+		},
+		mock.AnythingOfType("func(*autoscaling.DescribeAutoScalingGroupsOutput, bool) bool"),
+	).Run(func(args mock.Arguments) {
+		fn := args.Get(1).(func(*autoscaling.DescribeAutoScalingGroupsOutput, bool) bool)
+		fn(&autoscaling.DescribeAutoScalingGroupsOutput{AutoScalingGroups: groups()}, false)
+	}).Return(nil)
+
+	m := newTestAwsManagerWithMockServices(a, e, nil, nil, nil)
+	m.instanceTypes = map[string]*InstanceType{
+		"m5.large":  {InstanceType: "m5.large", VCPU: 2, MemoryMb: 8192},
+		"m5.xlarge": {InstanceType: "m5.xlarge", VCPU: 4, MemoryMb: 16384},
+	}
+	err := m.enableAutoprovisioning(config.AWSOptions{
+		AutoprovisioningInstanceTypes:  []string{"m5.large", "m5.xlarge"},
+		AutoprovisioningLaunchTemplate: "test-template",
+		AutoprovisioningSubnets:        []string{"subnet-a", "subnet-b"},
+		AutoprovisioningMaxSize:        10,
+	}, "test-cluster")
+	assert.NoError(t, err)
+	return testProvider(t, m)
+}
+
+func noAutoprovisionedGroups() []*autoscaling.Group {
+	return nil
+}
+
+func testAutoprovisionedGroup(name, instanceType string) *autoscaling.Group {
+	return &autoscaling.Group{
+		AutoScalingGroupName: aws.String(name),
+		MinSize:              aws.Int64(0),
+		MaxSize:              aws.Int64(10),
+		DesiredCapacity:      aws.Int64(0),
+		AvailabilityZones:    aws.StringSlice([]string{"us-east-1a", "us-east-1b"}),
+		MixedInstancesPolicy: &autoscaling.MixedInstancesPolicy{
+			LaunchTemplate: &autoscaling.LaunchTemplate{
+				LaunchTemplateSpecification: &autoscaling.LaunchTemplateSpecification{
+					LaunchTemplateName: aws.String("test-template"),
+					Version:            aws.String(autoprovisionedLaunchTemplateVersion),
+				},
+				Overrides: []*autoscaling.LaunchTemplateOverrides{{InstanceType: aws.String(instanceType)}},
+			},
+		},
+		Tags: []*autoscaling.TagDescription{
+			{Key: aws.String(autoprovisionedTagKey("test-cluster")), Value: aws.String(autoprovisionedTagValue)},
+		},
+	}
+}
+
+func TestEnableAutoprovisioningValidation(t *testing.T) {
+	valid := config.AWSOptions{
+		AutoprovisioningInstanceTypes:  []string{"m5.large"},
+		AutoprovisioningLaunchTemplate: "test-template",
+		AutoprovisioningSubnets:        []string{"subnet-a"},
+		AutoprovisioningMaxSize:        10,
+	}
+	for _, tc := range []struct {
+		name   string
+		modify func(*config.AWSOptions)
+	}{
+		{
+			name:   "missing launch template",
+			modify: func(o *config.AWSOptions) { o.AutoprovisioningLaunchTemplate = "" },
+		},
+		{
+			name:   "missing subnets",
+			modify: func(o *config.AWSOptions) { o.AutoprovisioningSubnets = nil },
+		},
+		{
+			name:   "non-positive max size",
+			modify: func(o *config.AWSOptions) { o.AutoprovisioningMaxSize = 0 },
+		},
+		{
+			name:   "unknown instance type",
+			modify: func(o *config.AWSOptions) { o.AutoprovisioningInstanceTypes = []string{"x9.huge"} },
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newTestAwsManagerWithMockServices(&autoScalingMock{}, &ec2Mock{}, nil, nil, nil)
+			m.instanceTypes = map[string]*InstanceType{"m5.large": {InstanceType: "m5.large"}}
+			opts := valid
+			tc.modify(&opts)
+			assert.Error(t, m.enableAutoprovisioning(opts, "test-cluster"))
+			assert.Nil(t, m.autoprovisioning)
+		})
+	}
+
+	t.Run("missing cluster name", func(t *testing.T) {
+		m := newTestAwsManagerWithMockServices(&autoScalingMock{}, &ec2Mock{}, nil, nil, nil)
+		m.instanceTypes = map[string]*InstanceType{"m5.large": {InstanceType: "m5.large"}}
+		assert.Error(t, m.enableAutoprovisioning(valid, ""))
+		assert.Nil(t, m.autoprovisioning)
+	})
+}
+
+func TestIsAutoprovisionedAsg(t *testing.T) {
+	group := &asg{Tags: []*autoscaling.TagDescription{
+		{Key: aws.String(autoprovisionedTagKey("test-cluster")), Value: aws.String(autoprovisionedTagValue)},
+	}}
+	assert.True(t, isAutoprovisionedAsg(group, "test-cluster"))
+	assert.False(t, isAutoprovisionedAsg(group, "other-cluster"))
+}
+
+func TestGetAvailableMachineTypes(t *testing.T) {
+	provider := testProvider(t, newTestAwsManagerWithMockServices(&autoScalingMock{}, nil, nil, nil, nil))
+	machineTypes, err := provider.GetAvailableMachineTypes()
+	assert.NoError(t, err)
+	assert.Empty(t, machineTypes)
+
+	provider = newTestAutoprovisioningProvider(t, &autoScalingMock{}, noAutoprovisionedGroups)
+	machineTypes, err = provider.GetAvailableMachineTypes()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"m5.large", "m5.xlarge"}, machineTypes)
+}
+
+func TestNewNodeGroup(t *testing.T) {
+	provider := newTestAutoprovisioningProvider(t, &autoScalingMock{}, noAutoprovisionedGroups)
+	labels := map[string]string{"team": "a"}
+	taints := []apiv1.Taint{{Key: "dedicated", Value: "a", Effect: apiv1.TaintEffectNoSchedule}}
+
+	nodeGroup, err := provider.NewNodeGroup("m5.xlarge", labels, nil, taints, map[string]resource.Quantity{})
+	assert.NoError(t, err)
+	assert.False(t, nodeGroup.Exist())
+	assert.True(t, nodeGroup.Autoprovisioned())
+	assert.Equal(t, 0, nodeGroup.MinSize())
+	assert.Equal(t, 10, nodeGroup.MaxSize())
+
+	// The name only depends on the instance type, labels and taints.
+	sameNodeGroup, err := provider.NewNodeGroup("m5.xlarge", map[string]string{"team": "a"}, nil, taints, map[string]resource.Quantity{})
+	assert.NoError(t, err)
+	assert.Equal(t, nodeGroup.Id(), sameNodeGroup.Id())
+	otherNodeGroup, err := provider.NewNodeGroup("m5.xlarge", map[string]string{"team": "b"}, nil, taints, map[string]resource.Quantity{})
+	assert.NoError(t, err)
+	assert.NotEqual(t, nodeGroup.Id(), otherNodeGroup.Id())
+
+	nodeInfo, err := nodeGroup.TemplateNodeInfo()
+	assert.NoError(t, err)
+	node := nodeInfo.Node()
+	assert.Equal(t, int64(4), node.Status.Capacity.Cpu().Value())
+	assert.Equal(t, int64(100*1024*1024*1024), node.Status.Capacity.StorageEphemeral().Value())
+	assert.Equal(t, "a", node.Labels["team"])
+	assert.Equal(t, "m5.xlarge", node.Labels[apiv1.LabelInstanceTypeStable])
+	assert.Equal(t, "us-east-1a", node.Labels[apiv1.LabelTopologyZone])
+	assert.Equal(t, taints, node.Spec.Taints)
+
+	_, err = provider.NewNodeGroup("c5.large", labels, nil, taints, map[string]resource.Quantity{})
+	assert.Error(t, err)
+}
+
+func TestNewNodeGroupNotEnabled(t *testing.T) {
+	provider := testProvider(t, newTestAwsManagerWithMockServices(&autoScalingMock{}, nil, nil, nil, nil))
+	_, err := provider.NewNodeGroup("m5.large", nil, nil, nil, map[string]resource.Quantity{})
+	assert.Equal(t, cloudprovider.ErrNotImplemented, err)
+}
+
+func TestCreateAutoprovisionedNodeGroup(t *testing.T) {
+	a := &autoScalingMock{}
+	var groups []*autoscaling.Group
+	provider := newTestAutoprovisioningProvider(t, a, func() []*autoscaling.Group { return groups })
+	nodeGroup, err := provider.NewNodeGroup("m5.large", map[string]string{}, nil, nil, map[string]resource.Quantity{})
+	assert.NoError(t, err)
+	name := nodeGroup.Id()
+
+	a.On("CreateAutoScalingGroup", mock.MatchedBy(func(input *autoscaling.CreateAutoScalingGroupInput) bool {
+		overrides := input.MixedInstancesPolicy.LaunchTemplate.Overrides
+		return aws.StringValue(input.AutoScalingGroupName) == name &&
+			aws.Int64Value(input.MaxSize) == 10 &&
+			aws.StringValue(input.VPCZoneIdentifier) == "subnet-a,subnet-b" &&
+			aws.StringValue(input.MixedInstancesPolicy.LaunchTemplate.LaunchTemplateSpecification.LaunchTemplateName) == "test-template" &&
+			len(overrides) == 1 && aws.StringValue(overrides[0].InstanceType) == "m5.large"
+	})).Return(&autoscaling.CreateAutoScalingGroupOutput{}, nil).Run(func(args mock.Arguments) {
+		groups = append(groups, testAutoprovisionedGroup(name, "m5.large"))
+	})
+
+	created, err := nodeGroup.Create()
+	assert.NoError(t, err)
+	assert.True(t, created.Exist())
+	assert.True(t, created.Autoprovisioned())
+	assert.Equal(t, name, created.Id())
+	assert.Len(t, provider.NodeGroups(), 1)
+	a.AssertNumberOfCalls(t, "CreateAutoScalingGroup", 1)
+
+	_, err = provider.NewNodeGroup("m5.large", map[string]string{}, nil, nil, map[string]resource.Quantity{})
+	assert.Equal(t, cloudprovider.ErrAlreadyExist, err)
+}
+
+func TestDeleteAutoprovisionedNodeGroup(t *testing.T) {
+	a := &autoScalingMock{}
+	provider := newTestAutoprovisioningProvider(t, a, func() []*autoscaling.Group {
+		return []*autoscaling.Group{testAutoprovisionedGroup("ca-autoprovisioned-test", "m5.large")}
+	})
+	nodeGroups := provider.NodeGroups()
+	assert.Len(t, nodeGroups, 1)
+
+	a.On("DeleteAutoScalingGroup", &autoscaling.DeleteAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String("ca-autoprovisioned-test"),
+	}).Return(&autoscaling.DeleteAutoScalingGroupOutput{}, nil)
+
+	assert.NoError(t, nodeGroups[0].Delete())
+	a.AssertNumberOfCalls(t, "DeleteAutoScalingGroup", 1)
+	assert.Empty(t, provider.NodeGroups())
+}
+
+func TestDeleteNodeGroupNotAutoprovisioned(t *testing.T) {
+	provider := testProvider(t, newTestAwsManagerWithAsgs(t, &autoScalingMock{}, nil, []string{"1:5:test-asg"}))
+	nodeGroups := provider.NodeGroups()
+	assert.Len(t, nodeGroups, 1)
+	assert.False(t, nodeGroups[0].Autoprovisioned())
+	assert.Equal(t, cloudprovider.ErrNotImplemented, nodeGroups[0].Delete())
+}
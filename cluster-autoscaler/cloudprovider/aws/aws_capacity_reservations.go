@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"regexp"
+	"slices"
+	"sync"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/aws"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/service/ec2"
+)
+
+// placeholderReservationExhaustedStatus is the status of placeholder instances of ASGs whose scale-up failed
+// because the targeted On-Demand Capacity Reservation or Capacity Block has no capacity left.
+const placeholderReservationExhaustedStatus = "placeholder-reservation-capacity-exceeded"
+
+// reservationExhaustedRegex matches the error codes in status messages of scaling activities which failed
+// because the capacity reservation consumed by the ASG is exhausted, e.g. "ReservationCapacityExceeded - ...".
+// Only the error code is matched, as the free-form part of other errors may mention capacity reservations too.
+var reservationExhaustedRegex = regexp.MustCompile(`\b(ReservationCapacityExceeded|InsufficientCapacityReservation) - `)
+
+// capacityReservationsCache holds the active capacity reservations and the capacity reservation
+// specifications of launch templates. It's invalidated on every refresh, as the number of available
+// reserved instances changes with every scale-up.
+type capacityReservationsCache struct {
+	mutex        sync.Mutex
+	reservations []*ec2.CapacityReservation
+	fetched      bool
+	specs        map[launchTemplate]*ec2.LaunchTemplateCapacityReservationSpecificationResponse
+}
+
+func (c *capacityReservationsCache) getReservations(awsService *awsWrapper) ([]*ec2.CapacityReservation, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.fetched {
+		return c.reservations, nil
+	}
+	reservations, err := awsService.getActiveCapacityReservations()
+	if err != nil {
+		return nil, err
+	}
+	c.reservations = reservations
+	c.fetched = true
+	return reservations, nil
+}
+
+func (c *capacityReservationsCache) getSpecification(awsService *awsWrapper, template launchTemplate) (*ec2.LaunchTemplateCapacityReservationSpecificationResponse, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if spec, found := c.specs[template]; found {
+		return spec, nil
+	}
+	templateData, err := awsService.getLaunchTemplateData(template.name, template.version)
+	if err != nil {
+		return nil, err
+	}
+	if c.specs == nil {
+		c.specs = make(map[launchTemplate]*ec2.LaunchTemplateCapacityReservationSpecificationResponse)
+	}
+	c.specs[template] = templateData.CapacityReservationSpecification
+	return templateData.CapacityReservationSpecification, nil
+}
+
+func (c *capacityReservationsCache) invalidate() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.reservations = nil
+	c.fetched = false
+	c.specs = nil
+}
+
+// consumesCapacityReservations checks whether instances launched with the specification consume capacity
+// reservations, either by targeting a specific reservation or Capacity Block, or any open reservation.
+func consumesCapacityReservations(spec *ec2.LaunchTemplateCapacityReservationSpecificationResponse) bool {
+	if spec == nil {
+		return false
+	}
+	if spec.CapacityReservationTarget != nil {
+		return spec.CapacityReservationTarget.CapacityReservationId != nil
+	}
+	return aws.StringValue(spec.CapacityReservationPreference) == ec2.CapacityReservationPreferenceOpen
+}
+
+// freeReservedInstances returns the number of instances of the given type which can still be launched in the
+// zones from the reservations consumed according to the specification. A targeted reservation is used on its own,
+// otherwise all open reservations matching the instance type are, except for Capacity Blocks which always
+// have to be targeted.
+func freeReservedInstances(spec *ec2.LaunchTemplateCapacityReservationSpecificationResponse, instanceType string, zones []string, reservations []*ec2.CapacityReservation) int64 {
+	var targetId string
+	if spec.CapacityReservationTarget != nil {
+		targetId = aws.StringValue(spec.CapacityReservationTarget.CapacityReservationId)
+	}
+	var free int64
+	for _, reservation := range reservations {
+		if aws.StringValue(reservation.State) != ec2.CapacityReservationStateActive {
+			continue
+		}
+		if targetId != "" {
+			if aws.StringValue(reservation.CapacityReservationId) != targetId {
+				continue
+			}
+		} else if aws.StringValue(reservation.InstanceMatchCriteria) != ec2.InstanceMatchCriteriaOpen ||
+			aws.StringValue(reservation.ReservationType) == ec2.CapacityReservationTypeCapacityBlock ||
+			aws.StringValue(reservation.InstanceType) != instanceType ||
+			!slices.Contains(zones, aws.StringValue(reservation.AvailabilityZone)) {
+			continue
+		}
+		free += aws.Int64Value(reservation.AvailableInstanceCount)
+	}
+	return free
+}
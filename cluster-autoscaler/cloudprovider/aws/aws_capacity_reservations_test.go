@@ -0,0 +1,163 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/aws"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/service/ec2"
+)
+
+func testCapacityReservation(id, instanceType, zone, matchCriteria, reservationType string, available int64) *ec2.CapacityReservation {
+	return &ec2.CapacityReservation{
+		CapacityReservationId:  aws.String(id),
+		InstanceType:           aws.String(instanceType),
+		AvailabilityZone:       aws.String(zone),
+		InstanceMatchCriteria:  aws.String(matchCriteria),
+		ReservationType:        aws.String(reservationType),
+		State:                  aws.String(ec2.CapacityReservationStateActive),
+		AvailableInstanceCount: aws.Int64(available),
+	}
+}
+
+func TestFreeReservedInstances(t *testing.T) {
+	expired := testCapacityReservation("cr-expired", "m5.large", "us-east-1a", ec2.InstanceMatchCriteriaOpen, ec2.CapacityReservationTypeDefault, 10)
+	expired.State = aws.String(ec2.CapacityReservationStateExpired)
+	reservations := []*ec2.CapacityReservation{
+		testCapacityReservation("cr-1", "m5.large", "us-east-1a", ec2.InstanceMatchCriteriaOpen, ec2.CapacityReservationTypeDefault, 2),
+		testCapacityReservation("cr-2", "m5.large", "us-east-1b", ec2.InstanceMatchCriteriaOpen, ec2.CapacityReservationTypeDefault, 3),
+		testCapacityReservation("cr-3", "m5.large", "us-east-1c", ec2.InstanceMatchCriteriaOpen, ec2.CapacityReservationTypeDefault, 4),
+		testCapacityReservation("cr-4", "m5.large", "us-east-1a", ec2.InstanceMatchCriteriaTargeted, ec2.CapacityReservationTypeDefault, 5),
+		testCapacityReservation("cr-5", "m5.xlarge", "us-east-1a", ec2.InstanceMatchCriteriaOpen, ec2.CapacityReservationTypeDefault, 6),
+		testCapacityReservation("cr-6", "p5.48xlarge", "us-east-1a", ec2.InstanceMatchCriteriaTargeted, ec2.CapacityReservationTypeCapacityBlock, 7),
+		expired,
+	}
+	for _, tc := range []struct {
+		name     string
+		spec     *ec2.LaunchTemplateCapacityReservationSpecificationResponse
+		consumes bool
+		expected int64
+	}{
+		{
+			name:     "no specification",
+			consumes: false,
+		},
+		{
+			name:     "reservations avoided",
+			spec:     &ec2.LaunchTemplateCapacityReservationSpecificationResponse{CapacityReservationPreference: aws.String(ec2.CapacityReservationPreferenceNone)},
+			consumes: false,
+		},
+		{
+			name:     "open reservations matching instance type and zones",
+			spec:     &ec2.LaunchTemplateCapacityReservationSpecificationResponse{CapacityReservationPreference: aws.String(ec2.CapacityReservationPreferenceOpen)},
+			consumes: true,
+			expected: 5,
+		},
+		{
+			name: "targeted reservation",
+			spec: &ec2.LaunchTemplateCapacityReservationSpecificationResponse{
+				CapacityReservationTarget: &ec2.CapacityReservationTargetResponse{CapacityReservationId: aws.String("cr-4")},
+			},
+			consumes: true,
+			expected: 5,
+		},
+		{
+			name: "targeted capacity block",
+			spec: &ec2.LaunchTemplateCapacityReservationSpecificationResponse{
+				CapacityReservationTarget: &ec2.CapacityReservationTargetResponse{CapacityReservationId: aws.String("cr-6")},
+			},
+			consumes: true,
+			expected: 7,
+		},
+		{
+			name: "targeted expired reservation",
+			spec: &ec2.LaunchTemplateCapacityReservationSpecificationResponse{
+				CapacityReservationTarget: &ec2.CapacityReservationTargetResponse{CapacityReservationId: aws.String("cr-expired")},
+			},
+			consumes: true,
+			expected: 0,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.consumes, consumesCapacityReservations(tc.spec))
+			if tc.consumes {
+				assert.Equal(t, tc.expected, freeReservedInstances(tc.spec, "m5.large", []string{"us-east-1a", "us-east-1b"}, reservations))
+			}
+		})
+	}
+}
+
+func TestGetAsgFreeReservedCapacity(t *testing.T) {
+	e := &ec2Mock{}
+	e.On("DescribeLaunchTemplateVersions", &ec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateName: aws.String("test-template"),
+		Versions:           []*string{aws.String("1")},
+	}).Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+		LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{
+			{
+				LaunchTemplateData: &ec2.ResponseLaunchTemplateData{
+					InstanceType: aws.String("m5.large"),
+					CapacityReservationSpecification: &ec2.LaunchTemplateCapacityReservationSpecificationResponse{
+						CapacityReservationPreference: aws.String(ec2.CapacityReservationPreferenceOpen),
+					},
+				},
+			},
+		},
+	}, nil).Once()
+	e.On("DescribeCapacityReservationsPages", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		fn := args.Get(1).(func(*ec2.DescribeCapacityReservationsOutput, bool) bool)
+		fn(&ec2.DescribeCapacityReservationsOutput{
+			CapacityReservations: []*ec2.CapacityReservation{
+				testCapacityReservation("cr-1", "m5.large", "us-east-1a", ec2.InstanceMatchCriteriaOpen, ec2.CapacityReservationTypeDefault, 2),
+			},
+		}, true)
+	}).Return(nil).Once()
+
+	origGetInstanceTypeFunc := getInstanceTypeForAsg
+	defer func() { getInstanceTypeForAsg = origGetInstanceTypeFunc }()
+	getInstanceTypeForAsg = func(m *asgCache, asg *asg) (string, error) {
+		return "m5.large", nil
+	}
+
+	m := newTestAwsManagerWithMockServices(&autoScalingMock{}, e, nil, nil, nil)
+	asg := &asg{
+		AwsRef:            AwsRef{Name: "test-asg"},
+		AvailabilityZones: []string{"us-east-1a"},
+		LaunchTemplate:    &launchTemplate{name: "test-template", version: "1"},
+	}
+
+	for i := 0; i < 2; i++ {
+		free, consumes, err := m.GetAsgFreeReservedCapacity(asg)
+		assert.NoError(t, err)
+		assert.True(t, consumes)
+		assert.Equal(t, int64(2), free)
+	}
+	// The launch template is described again once the cache is invalidated.
+	m.capacityReservations.invalidate()
+	e.On("DescribeLaunchTemplateVersions", mock.Anything).Return(&ec2.DescribeLaunchTemplateVersionsOutput{
+		LaunchTemplateVersions: []*ec2.LaunchTemplateVersion{{LaunchTemplateData: &ec2.ResponseLaunchTemplateData{}}},
+	}, nil).Once()
+	free, consumes, err := m.GetAsgFreeReservedCapacity(asg)
+	assert.NoError(t, err)
+	assert.False(t, consumes)
+	assert.Equal(t, int64(0), free)
+	e.AssertNumberOfCalls(t, "DescribeLaunchTemplateVersions", 2)
+	e.AssertNumberOfCalls(t, "DescribeCapacityReservationsPages", 1)
+}
@@ -22,10 +22,12 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"time"
 
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/service/sqs"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
@@ -170,14 +172,38 @@ func (aws *awsCloudProvider) Pricing() (cloudprovider.PricingModel, errors.Autos
 
 // GetAvailableMachineTypes get all machine types that can be requested from the cloud provider.
 func (aws *awsCloudProvider) GetAvailableMachineTypes() ([]string, error) {
-	return []string{}, nil
+	if aws.awsManager.autoprovisioning == nil {
+		return []string{}, nil
+	}
+	return aws.awsManager.autoprovisioning.instanceTypes, nil
 }
 
 // NewNodeGroup builds a theoretical node group based on the node definition provided. The node group is not automatically
 // created on the cloud provider side. The node group is not returned by NodeGroups() until it is created.
 func (aws *awsCloudProvider) NewNodeGroup(machineType string, labels map[string]string, systemLabels map[string]string,
 	taints []apiv1.Taint, extraResources map[string]resource.Quantity) (cloudprovider.NodeGroup, error) {
-	return nil, cloudprovider.ErrNotImplemented
+	if aws.awsManager.autoprovisioning == nil {
+		return nil, cloudprovider.ErrNotImplemented
+	}
+	allowed := false
+	for _, instanceType := range aws.awsManager.autoprovisioning.instanceTypes {
+		if instanceType == machineType {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, fmt.Errorf("instance type %q is not allowed for node group autoprovisioning", machineType)
+	}
+	asg := aws.awsManager.buildAutoprovisionedAsg(machineType, labels, taints)
+	if _, found := aws.awsManager.getAsgs()[asg.AwsRef]; found {
+		return nil, cloudprovider.ErrAlreadyExist
+	}
+	return &AwsNodeGroup{
+		asg:         asg,
+		awsManager:  aws.awsManager,
+		theoretical: true,
+	}, nil
 }
 
 // GetResourceLimiter returns struct containing limits (max, min) for resources (cores, memory etc.).
@@ -191,6 +217,67 @@ func (aws *awsCloudProvider) Refresh() error {
 	return aws.awsManager.Refresh()
 }
 
+// FreeReservedCapacity returns the number of nodes which can still be created in the node group from the
+// capacity reservations it consumes, and whether the node group consumes capacity reservations at all.
+func (aws *awsCloudProvider) FreeReservedCapacity(nodeGroup cloudprovider.NodeGroup) (int, bool) {
+	ng, ok := nodeGroup.(*AwsNodeGroup)
+	if !ok || ng.theoretical {
+		return 0, false
+	}
+	free, consumes, err := aws.awsManager.GetAsgFreeReservedCapacity(ng.asg)
+	if err != nil {
+		klog.Warningf("Failed to get free reserved capacity for ASG %s: %v", ng.asg.Name, err)
+		return 0, false
+	}
+	return int(free), consumes
+}
+
+// FreeSubnetCapacity returns the number of nodes which can still be created in the node group before the
+// IP addresses of its subnets run out, and whether it's known.
+func (aws *awsCloudProvider) FreeSubnetCapacity(nodeGroup cloudprovider.NodeGroup) (int, bool) {
+	ng, ok := nodeGroup.(*AwsNodeGroup)
+	if !ok || ng.theoretical {
+		return 0, false
+	}
+	free, known, err := aws.awsManager.GetAsgFreeSubnetCapacity(ng.asg)
+	if err != nil {
+		klog.Warningf("Failed to get free subnet capacity for ASG %s: %v", ng.asg.Name, err)
+		return 0, false
+	}
+	return int(free), known
+}
+
+// SpotInterruptionNotices returns the spot interruption warnings received since the last call, for instances
+// of registered ASGs.
+func (aws *awsCloudProvider) SpotInterruptionNotices() ([]cloudprovider.SpotInterruptionNotice, error) {
+	if aws.awsManager.spotInterruptions == nil {
+		return nil, nil
+	}
+	interruptions, err := aws.awsManager.spotInterruptions.receive()
+	notices := make([]cloudprovider.SpotInterruptionNotice, 0, len(interruptions))
+	for _, interruption := range interruptions {
+		instance, found := aws.awsManager.asgCache.FindInstanceByName(interruption.instanceID)
+		if !found {
+			klog.V(4).Infof("Spot interruption warning for instance %s, which isn't part of any registered ASG", interruption.instanceID)
+			continue
+		}
+		notices = append(notices, cloudprovider.SpotInterruptionNotice{
+			ProviderID:      instance.ProviderID,
+			TerminationTime: interruption.terminationTime,
+		})
+	}
+	return notices, err
+}
+
+// GC terminates instances launched by registered ASGs for the cluster which are no longer associated with them,
+// never registered as nodes and are failing their status checks, if enabled with --aws-orphaned-instance-gc-age.
+func (aws *awsCloudProvider) GC(registeredNodes []*apiv1.Node) error {
+	if aws.awsManager.orphanedInstances == nil {
+		return nil
+	}
+	return aws.awsManager.orphanedInstances.collect(registeredNodes, time.Now())
+}
+
 // AwsRef contains a reference to some entity in AWS world.
 type AwsRef struct {
 	Name string
@@ -221,18 +308,46 @@ func AwsRefFromProviderId(id string) (*AwsInstanceRef, error) {
 type AwsNodeGroup struct {
 	awsManager *AwsManager
 	asg        *asg
+	// theoretical is set for autoprovisioned node groups that are not created yet.
+	theoretical bool
 }
 
 // MaxSize returns maximum size of the node group.
 func (ng *AwsNodeGroup) MaxSize() int {
+	if ng.asg.limitsOverridden {
+		return ng.asg.maxSizeOverride
+	}
 	return ng.asg.maxSize
 }
 
 // MinSize returns minimum size of the node group.
 func (ng *AwsNodeGroup) MinSize() int {
+	if ng.asg.limitsOverridden {
+		return ng.asg.minSizeOverride
+	}
 	return ng.asg.minSize
 }
 
+// OverrideSizeLimits makes MinSize and MaxSize return the given sizes, which have to be within the min and max
+// sizes of the ASG, as the ASG rejects desired capacities outside of them.
+func (ng *AwsNodeGroup) OverrideSizeLimits(minSize, maxSize int) error {
+	if minSize > maxSize {
+		return fmt.Errorf("min size %d is greater than max size %d", minSize, maxSize)
+	}
+	if minSize < ng.asg.minSize || maxSize > ng.asg.maxSize {
+		return fmt.Errorf("sizes %d-%d are outside of the sizes %d-%d of ASG %s", minSize, maxSize, ng.asg.minSize, ng.asg.maxSize, ng.asg.Name)
+	}
+	ng.asg.limitsOverridden = true
+	ng.asg.minSizeOverride = minSize
+	ng.asg.maxSizeOverride = maxSize
+	return nil
+}
+
+// ResetSizeLimits makes MinSize and MaxSize return the min and max sizes of the ASG again.
+func (ng *AwsNodeGroup) ResetSizeLimits() {
+	ng.asg.limitsOverridden = false
+}
+
 // TargetSize returns the current TARGET size of the node group. It is possible that the
 // number is different from the number of nodes registered in Kubernetes.
 func (ng *AwsNodeGroup) TargetSize() (int, error) {
@@ -242,23 +357,39 @@ func (ng *AwsNodeGroup) TargetSize() (int, error) {
 // Exist checks if the node group really exists on the cloud provider side. Allows to tell the
 // theoretical node group from the real one.
 func (ng *AwsNodeGroup) Exist() bool {
-	return true
+	return !ng.theoretical
 }
 
 // Create creates the node group on the cloud provider side.
 func (ng *AwsNodeGroup) Create() (cloudprovider.NodeGroup, error) {
-	return nil, cloudprovider.ErrAlreadyExist
+	if !ng.theoretical {
+		return nil, cloudprovider.ErrAlreadyExist
+	}
+	asg, err := ng.awsManager.createAsg(ng.asg)
+	if err != nil {
+		return nil, err
+	}
+	return &AwsNodeGroup{
+		asg:        asg,
+		awsManager: ng.awsManager,
+	}, nil
 }
 
 // Autoprovisioned returns true if the node group is autoprovisioned.
 func (ng *AwsNodeGroup) Autoprovisioned() bool {
-	return false
+	if ng.awsManager.autoprovisioning == nil {
+		return false
+	}
+	return isAutoprovisionedAsg(ng.asg, ng.awsManager.autoprovisioning.clusterName)
 }
 
 // Delete deletes the node group on the cloud provider side.
 // This will be executed only for autoprovisioned node groups, once their size drops to 0.
 func (ng *AwsNodeGroup) Delete() error {
-	return cloudprovider.ErrNotImplemented
+	if !ng.Autoprovisioned() {
+		return cloudprovider.ErrNotImplemented
+	}
+	return ng.awsManager.deleteAsg(ng.asg)
 }
 
 // GetOptions returns NodeGroupAutoscalingOptions that should be used for this particular
@@ -387,6 +518,15 @@ func (ng *AwsNodeGroup) Nodes() ([]cloudprovider.Instance, error) {
 					ErrorMessage: "AWS cannot provision any more instances for this node group",
 				},
 			}
+		} else if instanceStatusString != nil && *instanceStatusString == placeholderReservationExhaustedStatus {
+			status = &cloudprovider.InstanceStatus{
+				State: cloudprovider.InstanceCreating,
+				ErrorInfo: &cloudprovider.InstanceErrorInfo{
+					ErrorClass:   cloudprovider.OutOfResourcesErrorClass,
+					ErrorCode:    placeholderReservationExhaustedStatus,
+					ErrorMessage: "AWS cannot provision any more instances for this node group from its capacity reservation",
+				},
+			}
 		}
 		instances[i] = cloudprovider.Instance{
 			Id:     asgNode.ProviderID,
@@ -412,6 +552,50 @@ func (ng *AwsNodeGroup) TemplateNodeInfo() (*framework.NodeInfo, error) {
 	return nodeInfo, nil
 }
 
+// WeightedTemplateNodeInfos returns a node template for each instance type of the ASG's MixedInstancesPolicy,
+// weighted by the number of instances of the type in the ASG. Instance types are weighted equally while the
+// ASG has no instances.
+func (ng *AwsNodeGroup) WeightedTemplateNodeInfos() ([]cloudprovider.WeightedTemplateNodeInfo, error) {
+	policy := ng.asg.MixedInstancesPolicy
+	if policy == nil || len(policy.instanceTypesOverrides) < 2 {
+		return nil, nil
+	}
+	template, err := ng.awsManager.getAsgTemplate(ng.asg)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := ng.awsManager.asgCache.InstanceTypeCounts(ng.asg.AwsRef)
+	total := 0
+	for _, instanceTypeName := range policy.instanceTypesOverrides {
+		total += counts[instanceTypeName]
+	}
+
+	var result []cloudprovider.WeightedTemplateNodeInfo
+	for _, instanceTypeName := range policy.instanceTypesOverrides {
+		instanceType, found := ng.awsManager.instanceTypes[instanceTypeName]
+		if !found {
+			klog.Warningf("ASG %q uses the unknown EC2 instance type %q, ignoring it", ng.asg.Name, instanceTypeName)
+			continue
+		}
+		instanceTemplate := *template
+		instanceTemplate.InstanceType = instanceType
+		node, err := ng.awsManager.buildNodeFromTemplate(ng.asg, &instanceTemplate)
+		if err != nil {
+			return nil, err
+		}
+		weight := counts[instanceTypeName]
+		if total == 0 {
+			weight = 1
+		}
+		result = append(result, cloudprovider.WeightedTemplateNodeInfo{
+			NodeInfo: framework.NewNodeInfo(node, nil, &framework.PodInfo{Pod: cloudprovider.BuildKubeProxy(ng.asg.Name)}),
+			Weight:   weight,
+		})
+	}
+	return result, nil
+}
+
 // BuildAWS builds AWS cloud provider, manager etc.
 func BuildAWS(opts config.AutoscalingOptions, do cloudprovider.NodeGroupDiscoveryOptions, rl *cloudprovider.ResourceLimiter) cloudprovider.CloudProvider {
 	var cfg io.ReadCloser
@@ -466,6 +650,28 @@ func BuildAWS(opts config.AutoscalingOptions, do cloudprovider.NodeGroupDiscover
 		klog.Fatalf("Failed to create AWS Manager: %v", err)
 	}
 
+	if opts.SpotInterruptionHandlingEnabled && opts.AWSOptions.SpotInterruptionQueueURL != "" {
+		manager.spotInterruptions = newSpotInterruptionQueue(sqs.New(sdkProvider.session), opts.AWSOptions.SpotInterruptionQueueURL)
+	}
+
+	if err := validateMaxPodsMode(opts.AWSOptions.MaxPodsMode); err != nil {
+		klog.Fatalf("Failed to configure max pods of node templates: %v", err)
+	}
+	manager.maxPodsMode = opts.AWSOptions.MaxPodsMode
+
+	if opts.AWSOptions.OrphanedInstanceGCAge > 0 {
+		if opts.ClusterName == "" {
+			klog.Fatalf("Failed to enable terminating orphaned instances: --cluster-name is required to tell which instances are owned by the cluster")
+		}
+		manager.orphanedInstances = newOrphanedInstancesCollector(&manager.awsService, manager.asgCache, opts.ClusterName, opts.AWSOptions.OrphanedInstanceGCAge, opts.ScaleDownDryRun)
+	}
+
+	if opts.NodeAutoprovisioningEnabled && len(opts.AWSOptions.AutoprovisioningInstanceTypes) > 0 {
+		if err := manager.enableAutoprovisioning(opts.AWSOptions, opts.ClusterName); err != nil {
+			klog.Fatalf("Failed to enable node group autoprovisioning: %v", err)
+		}
+	}
+
 	provider, err := BuildAwsCloudProvider(manager, rl)
 	if err != nil {
 		klog.Fatalf("Failed to create AWS cloud provider: %v", err)
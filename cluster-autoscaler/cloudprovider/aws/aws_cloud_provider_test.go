@@ -329,6 +329,24 @@ func TestTargetSize(t *testing.T) {
 	a.AssertNumberOfCalls(t, "DescribeAutoScalingGroupsPages", 1)
 }
 
+func TestOverrideSizeLimits(t *testing.T) {
+	a := &autoScalingMock{}
+	provider := testProvider(t, newTestAwsManagerWithAsgs(t, a, nil, []string{"1:5:test-asg"}))
+	asg := provider.NodeGroups()[0].(*AwsNodeGroup)
+
+	assert.NoError(t, asg.OverrideSizeLimits(3, 4))
+	assert.Equal(t, 3, asg.MinSize())
+	assert.Equal(t, 4, asg.MaxSize())
+
+	assert.Error(t, asg.OverrideSizeLimits(0, 4))
+	assert.Error(t, asg.OverrideSizeLimits(2, 6))
+	assert.Error(t, asg.OverrideSizeLimits(4, 3))
+
+	asg.ResetSizeLimits()
+	assert.Equal(t, 1, asg.MinSize())
+	assert.Equal(t, 5, asg.MaxSize())
+}
+
 func TestIncreaseSize(t *testing.T) {
 	a := &autoScalingMock{}
 	provider := testProvider(t, newTestAwsManagerWithAsgs(t, a, nil, []string{"1:5:test-asg"}))
@@ -848,3 +866,48 @@ func TestDeleteNodesWithPlaceholderAndStaleCache(t *testing.T) {
 	a.AssertNumberOfCalls(t, "TerminateInstanceInAutoScalingGroup", 2)
 
 }
+
+func TestWeightedTemplateNodeInfos(t *testing.T) {
+	origGetInstanceTypeFunc := getInstanceTypeForAsg
+	defer func() { getInstanceTypeForAsg = origGetInstanceTypeFunc }()
+	getInstanceTypeForAsg = func(m *asgCache, asg *asg) (string, error) {
+		return "m5.large", nil
+	}
+
+	instanceTypes, _ := GetStaticEC2InstanceTypes()
+	m := newTestAwsManagerWithMockServices(&autoScalingMock{}, nil, nil, nil, nil)
+	m.instanceTypes = instanceTypes
+	ref := AwsRef{Name: "test-asg"}
+	group := &asg{
+		AwsRef:            ref,
+		AvailabilityZones: []string{"us-east-1a"},
+		MixedInstancesPolicy: &mixedInstancesPolicy{
+			instanceTypesOverrides: []string{"m5.large", "m5.xlarge", "nonexistent.xlarge"},
+		},
+	}
+	nodeGroup := &AwsNodeGroup{awsManager: m, asg: group}
+
+	templates, err := nodeGroup.WeightedTemplateNodeInfos()
+	assert.NoError(t, err)
+	if assert.Len(t, templates, 2) {
+		assert.Equal(t, int64(2), templates[0].NodeInfo.Node().Status.Capacity.Cpu().Value())
+		assert.Equal(t, "m5.large", templates[0].NodeInfo.Node().Labels[apiv1.LabelInstanceTypeStable])
+		assert.Equal(t, 1, templates[0].Weight)
+		assert.Equal(t, int64(4), templates[1].NodeInfo.Node().Status.Capacity.Cpu().Value())
+		assert.Equal(t, "m5.xlarge", templates[1].NodeInfo.Node().Labels[apiv1.LabelInstanceTypeStable])
+		assert.Equal(t, 1, templates[1].Weight)
+	}
+
+	m.asgCache.instanceTypeCounts = map[AwsRef]map[string]int{ref: {"m5.large": 1, "m5.xlarge": 3}}
+	templates, err = nodeGroup.WeightedTemplateNodeInfos()
+	assert.NoError(t, err)
+	if assert.Len(t, templates, 2) {
+		assert.Equal(t, 1, templates[0].Weight)
+		assert.Equal(t, 3, templates[1].Weight)
+	}
+
+	group.MixedInstancesPolicy.instanceTypesOverrides = []string{"m5.large"}
+	templates, err = nodeGroup.WeightedTemplateNodeInfos()
+	assert.NoError(t, err)
+	assert.Empty(t, templates)
+}
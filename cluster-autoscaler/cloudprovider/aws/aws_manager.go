@@ -60,6 +60,13 @@ type AwsManager struct {
 	lastRefresh           time.Time
 	instanceTypes         map[string]*InstanceType
 	managedNodegroupCache *managedNodegroupCache
+	autoprovisioning      *autoprovisioningConfig
+	capacityReservations  capacityReservationsCache
+	rootVolumeSizes       rootVolumeSizesCache
+	subnets               subnetsCache
+	maxPodsMode           string
+	spotInterruptions     *spotInterruptionQueue
+	orphanedInstances     *orphanedInstancesCollector
 }
 
 type asgTemplate struct {
@@ -67,6 +74,8 @@ type asgTemplate struct {
 	Region       string
 	Zone         string
 	Tags         []*autoscaling.TagDescription
+	// RootVolumeSizeGiB is the size of the root volume set by the launch template, 0 if unknown.
+	RootVolumeSizeGiB int64
 }
 
 // createAwsManagerInternal allows for custom objects to be passed in by tests
@@ -117,6 +126,8 @@ func CreateAwsManager(awsSDKProvider *awsSDKProvider, discoveryOpts cloudprovide
 // Refresh is called before every main loop and can be used to dynamically update cloud provider state.
 // In particular the list of node groups returned by NodeGroups can change as a result of CloudProvider.Refresh().
 func (m *AwsManager) Refresh() error {
+	m.capacityReservations.invalidate()
+	m.subnets.invalidate()
 	if m.lastRefresh.Add(refreshInterval).After(time.Now()) {
 		return nil
 	}
@@ -128,6 +139,7 @@ func (m *AwsManager) forceRefresh() error {
 		klog.Errorf("Failed to regenerate ASG cache: %v", err)
 		return err
 	}
+	m.rootVolumeSizes.invalidate()
 	m.lastRefresh = time.Now()
 	klog.V(2).Infof("Refreshed ASG list, next refresh after %v", m.lastRefresh.Add(refreshInterval))
 	return nil
@@ -193,16 +205,88 @@ func (m *AwsManager) getAsgTemplate(asg *asg) (*asgTemplate, error) {
 		return nil, err
 	}
 
-	if t, ok := m.instanceTypes[instanceTypeName]; ok {
-		return &asgTemplate{
-			InstanceType: t,
-			Region:       region,
-			Zone:         az,
-			Tags:         asg.Tags,
-		}, nil
+	t, ok := m.instanceTypes[instanceTypeName]
+	if !ok {
+		return nil, fmt.Errorf("ASG %q uses the unknown EC2 instance type %q", asg.Name, instanceTypeName)
 	}
 
-	return nil, fmt.Errorf("ASG %q uses the unknown EC2 instance type %q", asg.Name, instanceTypeName)
+	return &asgTemplate{
+		InstanceType:      t,
+		Region:            region,
+		Zone:              az,
+		Tags:              asg.Tags,
+		RootVolumeSizeGiB: m.getAsgRootVolumeSize(asg),
+	}, nil
+}
+
+// getAsgRootVolumeSize returns the size in GiB of the root volume of the ASG's launch template, 0 if
+// the ASG has no launch template or it doesn't set the size.
+func (m *AwsManager) getAsgRootVolumeSize(asg *asg) int64 {
+	template := asg.LaunchTemplate
+	if asg.MixedInstancesPolicy != nil {
+		template = asg.MixedInstancesPolicy.launchTemplate
+	}
+	if template == nil {
+		return 0
+	}
+	size, err := m.rootVolumeSizes.get(&m.awsService, *template)
+	if err != nil {
+		klog.Warningf("Failed to get the root volume size of launch template %q of ASG %q: %v", template.name, asg.Name, err)
+		return 0
+	}
+	return size
+}
+
+// GetAsgFreeReservedCapacity returns the number of instances which can still be launched in the ASG from the
+// capacity reservations its launch template consumes, and whether it consumes capacity reservations at all.
+func (m *AwsManager) GetAsgFreeReservedCapacity(asg *asg) (int64, bool, error) {
+	template := asg.LaunchTemplate
+	if asg.MixedInstancesPolicy != nil {
+		template = asg.MixedInstancesPolicy.launchTemplate
+	}
+	if template == nil {
+		return 0, false, nil
+	}
+	spec, err := m.capacityReservations.getSpecification(&m.awsService, *template)
+	if err != nil {
+		return 0, false, err
+	}
+	if !consumesCapacityReservations(spec) {
+		return 0, false, nil
+	}
+	instanceType, err := getInstanceTypeForAsg(m.asgCache, asg)
+	if err != nil {
+		return 0, true, err
+	}
+	reservations, err := m.capacityReservations.getReservations(&m.awsService)
+	if err != nil {
+		return 0, true, fmt.Errorf("failed to describe capacity reservations: %w", err)
+	}
+	return freeReservedInstances(spec, instanceType, asg.AvailabilityZones, reservations), true, nil
+}
+
+// GetAsgFreeSubnetCapacity returns the number of instances which can still be launched in the ASG before the
+// IP addresses of its subnets run out, and whether its subnets are known.
+func (m *AwsManager) GetAsgFreeSubnetCapacity(asg *asg) (int64, bool, error) {
+	if len(asg.Subnets) == 0 {
+		return 0, false, nil
+	}
+	freeAddresses, err := m.subnets.getFreeAddresses(&m.awsService, asg.Subnets)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to describe subnets: %w", err)
+	}
+	if len(freeAddresses) == 0 {
+		return 0, false, nil
+	}
+	var instanceType *InstanceType
+	if m.maxPodsMode == maxPodsModeVpcCni {
+		name, err := getInstanceTypeForAsg(m.asgCache, asg)
+		if err != nil {
+			return 0, false, err
+		}
+		instanceType = m.instanceTypes[name]
+	}
+	return freeSubnetCapacity(freeAddresses, addressesPerNode(instanceType, m.maxPodsMode)), true, nil
 }
 
 // GetAsgOptions parse options extracted from ASG tags and merges them with provided defaults
@@ -257,6 +341,24 @@ func (m *AwsManager) GetAsgOptions(asg asg, defaults config.NodeGroupAutoscaling
 		}
 	}
 
+	if stringOpt, found := options[config.DefaultScaleDownSoftTaintOnlyKey]; found {
+		if opt, err := strconv.ParseBool(stringOpt); err != nil {
+			klog.Warningf("failed to convert asg %s %s tag to bool: %v",
+				asg.Name, config.DefaultScaleDownSoftTaintOnlyKey, err)
+		} else {
+			defaults.ScaleDownSoftTaintOnly = opt
+		}
+	}
+
+	if stringOpt, found := options[config.DefaultNodeAutoRepairEnabledKey]; found {
+		if opt, err := strconv.ParseBool(stringOpt); err != nil {
+			klog.Warningf("failed to convert asg %s %s tag to bool: %v",
+				asg.Name, config.DefaultNodeAutoRepairEnabledKey, err)
+		} else {
+			defaults.NodeAutoRepairEnabled = opt
+		}
+	}
+
 	return &defaults
 }
 
@@ -274,14 +376,17 @@ func (m *AwsManager) buildNodeFromTemplate(asg *asg, template *asgTemplate) (*ap
 		Capacity: apiv1.ResourceList{},
 	}
 
-	// TODO: get a real value.
-	node.Status.Capacity[apiv1.ResourcePods] = *resource.NewQuantity(110, resource.DecimalSI)
+	node.Status.Capacity[apiv1.ResourcePods] = *resource.NewQuantity(maxPods(template.InstanceType, m.maxPodsMode), resource.DecimalSI)
 	node.Status.Capacity[apiv1.ResourceCPU] = *resource.NewQuantity(template.InstanceType.VCPU, resource.DecimalSI)
 	node.Status.Capacity[gpu.ResourceNvidiaGPU] = *resource.NewQuantity(template.InstanceType.GPU, resource.DecimalSI)
 	node.Status.Capacity[apiv1.ResourceMemory] = *resource.NewQuantity(template.InstanceType.MemoryMb*1024*1024, resource.DecimalSI)
 
 	m.updateCapacityWithRequirementsOverrides(&node.Status.Capacity, asg.MixedInstancesPolicy)
 
+	if template.RootVolumeSizeGiB > 0 {
+		node.Status.Capacity[apiv1.ResourceEphemeralStorage] = *resource.NewQuantity(template.RootVolumeSizeGiB*1024*1024*1024, resource.BinarySI)
+	}
+
 	resourcesFromTags := extractAllocatableResourcesFromAsg(template.Tags)
 	klog.V(5).Infof("Extracted resources from ASG tags %v", resourcesFromTags)
 	for resourceName, val := range resourcesFromTags {
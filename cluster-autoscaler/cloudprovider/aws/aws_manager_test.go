@@ -153,6 +153,8 @@ func TestGetAsgOptions(t *testing.T) {
 				config.DefaultScaleDownUnneededTimeKey:         "not-a-duration",
 				"ScaleDownUnreadyTime":                         "",
 				config.DefaultIgnoreDaemonSetsUtilizationKey:   "not-a-bool",
+				config.DefaultScaleDownSoftTaintOnlyKey:        "not-a-bool",
+				config.DefaultNodeAutoRepairEnabledKey:         "not-a-bool",
 			},
 			expected: &defaultOptions,
 		},
@@ -179,6 +181,8 @@ func TestGetAsgOptions(t *testing.T) {
 				config.DefaultScaleDownGpuUtilizationThresholdKey: "0.7",
 				config.DefaultScaleDownUnreadyTimeKey:             "25m",
 				config.DefaultIgnoreDaemonSetsUtilizationKey:      "true",
+				config.DefaultScaleDownSoftTaintOnlyKey:           "true",
+				config.DefaultNodeAutoRepairEnabledKey:            "true",
 			},
 			expected: &config.NodeGroupAutoscalingOptions{
 				ScaleDownUtilizationThreshold:    0.42,
@@ -186,6 +190,8 @@ func TestGetAsgOptions(t *testing.T) {
 				ScaleDownUnneededTime:            time.Hour,
 				ScaleDownUnreadyTime:             25 * time.Minute,
 				IgnoreDaemonSetsUtilization:      true,
+				ScaleDownSoftTaintOnly:           true,
+				NodeAutoRepairEnabled:            true,
 			},
 		},
 		{
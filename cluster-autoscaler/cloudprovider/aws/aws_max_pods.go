@@ -0,0 +1,73 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+
+	klog "k8s.io/klog/v2"
+)
+
+const (
+	// maxPodsModeDefault uses the default max pods of the kubelet for all instance types.
+	maxPodsModeDefault = "default"
+	// maxPodsModeVpcCni limits the pods of nodes to the number of secondary IP addresses of their ENIs,
+	// like the Amazon VPC CNI plugin does.
+	maxPodsModeVpcCni = "vpc-cni"
+	// maxPodsModeVpcCniPrefixDelegation limits the pods of nodes to the number of IP addresses of the /28 prefixes
+	// assigned to their ENIs, like the Amazon VPC CNI plugin does with ENABLE_PREFIX_DELEGATION.
+	maxPodsModeVpcCniPrefixDelegation = "vpc-cni-prefix-delegation"
+
+	// defaultMaxPods is the default max pods of the kubelet.
+	defaultMaxPods = 110
+	// largeInstanceMaxPods is the max pods recommended by EKS for instance types with at least largeInstanceVCPU vCPUs.
+	largeInstanceMaxPods = 250
+	largeInstanceVCPU    = 30
+	// ipv4PrefixSize is the number of IP addresses of a /28 prefix.
+	ipv4PrefixSize = 16
+)
+
+func validateMaxPodsMode(mode string) error {
+	switch mode {
+	case "", maxPodsModeDefault, maxPodsModeVpcCni, maxPodsModeVpcCniPrefixDelegation:
+		return nil
+	}
+	return fmt.Errorf("unsupported max pods mode %q, expected one of %s, %s, %s", mode, maxPodsModeDefault, maxPodsModeVpcCni, maxPodsModeVpcCniPrefixDelegation)
+}
+
+// maxPods returns the number of pods nodes of the instance type can run with the networking mode, using the same
+// formula as the max pods calculator of EKS. The primary IP address of every ENI isn't available to pods, while
+// pods using the host network don't need an address. The default max pods is returned when the ENI limits of the
+// instance type are unknown, e.g. for instance types of the static list.
+func maxPods(instanceType *InstanceType, mode string) int64 {
+	if mode == "" || mode == maxPodsModeDefault {
+		return defaultMaxPods
+	}
+	if instanceType.MaxNetworkInterfaces <= 0 || instanceType.IPv4AddressesPerInterface <= 0 {
+		klog.V(4).Infof("ENI limits of instance type %s are unknown, using the default max pods", instanceType.InstanceType)
+		return defaultMaxPods
+	}
+	addressesPerInterface := instanceType.IPv4AddressesPerInterface - 1
+	if mode == maxPodsModeVpcCni {
+		return instanceType.MaxNetworkInterfaces*addressesPerInterface + 2
+	}
+	limit := int64(defaultMaxPods)
+	if instanceType.VCPU >= largeInstanceVCPU {
+		limit = largeInstanceMaxPods
+	}
+	return min(instanceType.MaxNetworkInterfaces*addressesPerInterface*ipv4PrefixSize+2, limit)
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/aws"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/service/autoscaling"
+)
+
+func TestMaxPods(t *testing.T) {
+	t3Small := &InstanceType{InstanceType: "t3.small", VCPU: 2, MaxNetworkInterfaces: 3, IPv4AddressesPerInterface: 4}
+	m5Large := &InstanceType{InstanceType: "m5.large", VCPU: 2, MaxNetworkInterfaces: 3, IPv4AddressesPerInterface: 10}
+	m5Metal := &InstanceType{InstanceType: "m5.metal", VCPU: 96, MaxNetworkInterfaces: 15, IPv4AddressesPerInterface: 50}
+	staticType := &InstanceType{InstanceType: "m5.large", VCPU: 2}
+
+	for _, tc := range []struct {
+		name         string
+		instanceType *InstanceType
+		mode         string
+		expected     int64
+	}{
+		{"no mode", t3Small, "", 110},
+		{"default mode", t3Small, maxPodsModeDefault, 110},
+		{"vpc cni", t3Small, maxPodsModeVpcCni, 11},
+		{"vpc cni on larger instance type", m5Large, maxPodsModeVpcCni, 29},
+		{"vpc cni without eni limits", staticType, maxPodsModeVpcCni, 110},
+		{"prefix delegation", t3Small, maxPodsModeVpcCniPrefixDelegation, 110},
+		{"prefix delegation with few vcpus", m5Large, maxPodsModeVpcCniPrefixDelegation, 110},
+		{"prefix delegation with many vcpus", m5Metal, maxPodsModeVpcCniPrefixDelegation, 250},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, maxPods(tc.instanceType, tc.mode))
+		})
+	}
+}
+
+func TestValidateMaxPodsMode(t *testing.T) {
+	assert.NoError(t, validateMaxPodsMode(""))
+	assert.NoError(t, validateMaxPodsMode(maxPodsModeVpcCniPrefixDelegation))
+	assert.Error(t, validateMaxPodsMode("calico"))
+}
+
+func TestBuildNodeFromTemplateWithMaxPodsMode(t *testing.T) {
+	awsManager := &AwsManager{maxPodsMode: maxPodsModeVpcCni}
+	asg := &asg{AwsRef: AwsRef{Name: "test-auto-scaling-group"}}
+	template := &asgTemplate{
+		InstanceType: &InstanceType{InstanceType: "t3.small", VCPU: 2, MemoryMb: 2048, MaxNetworkInterfaces: 3, IPv4AddressesPerInterface: 4},
+	}
+
+	node, err := awsManager.buildNodeFromTemplate(asg, template)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(11), node.Status.Capacity.Pods().Value())
+
+	// Tags override the max pods of the networking mode.
+	template.Tags = []*autoscaling.TagDescription{{
+		Key:   aws.String("k8s.io/cluster-autoscaler/node-template/resources/pods"),
+		Value: aws.String("17"),
+	}}
+	node, err = awsManager.buildNodeFromTemplate(asg, template)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(17), node.Status.Capacity.Pods().Value())
+}
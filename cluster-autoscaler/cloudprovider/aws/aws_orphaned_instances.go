@@ -0,0 +1,152 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/aws"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/service/ec2"
+	klog "k8s.io/klog/v2"
+)
+
+// orphanedInstancesGCInterval is how often instances are listed to find the orphaned ones.
+const orphanedInstancesGCInterval = 5 * time.Minute
+
+// clusterOwnershipTagPrefix prefixes the name of the cluster in the tag marking instances owned by it.
+const clusterOwnershipTagPrefix = "kubernetes.io/cluster/"
+
+// orphanedInstancesCollector terminates instances launched by registered ASGs for the cluster, which are no longer
+// associated with their ASG, never registered as nodes and are failing their EC2 status checks, e.g. because they
+// were detached from their ASG after failing to bootstrap. Such instances aren't part of any node group, so they
+// aren't removed along with other unregistered nodes.
+type orphanedInstancesCollector struct {
+	awsService *awsWrapper
+	asgCache   *asgCache
+	// clusterName is the name of the cluster, instances have to be tagged as owned by it to be terminated.
+	clusterName string
+	// minAge is how long ago an instance has to be launched, and how long it has to fail its status checks, before
+	// it's terminated.
+	minAge time.Duration
+	// dryRun makes the collector only log the instances it would terminate.
+	dryRun  bool
+	lastRun time.Time
+}
+
+func newOrphanedInstancesCollector(awsService *awsWrapper, asgCache *asgCache, clusterName string, minAge time.Duration, dryRun bool) *orphanedInstancesCollector {
+	return &orphanedInstancesCollector{
+		awsService:  awsService,
+		asgCache:    asgCache,
+		clusterName: clusterName,
+		minAge:      minAge,
+		dryRun:      dryRun,
+	}
+}
+
+// collect terminates the orphaned instances, unless it already ran within orphanedInstancesGCInterval.
+func (c *orphanedInstancesCollector) collect(registeredNodes []*apiv1.Node, now time.Time) error {
+	if now.Sub(c.lastRun) < orphanedInstancesGCInterval {
+		return nil
+	}
+	c.lastRun = now
+
+	registered := make(map[string]bool, len(registeredNodes))
+	for _, node := range registeredNodes {
+		if ref, err := AwsRefFromProviderId(node.Spec.ProviderID); err == nil {
+			registered[ref.Name] = true
+		}
+	}
+	var asgNames []string
+	for ref := range c.asgCache.Get() {
+		asgNames = append(asgNames, ref.Name)
+	}
+	if len(asgNames) == 0 {
+		return nil
+	}
+
+	instances, err := c.awsService.getInstancesLaunchedByAsgs(asgNames)
+	if err != nil {
+		return err
+	}
+	var candidates []string
+	for _, instance := range instances {
+		id := aws.StringValue(instance.InstanceId)
+		if registered[id] || instance.LaunchTime == nil || instance.LaunchTime.Add(c.minAge).After(now) {
+			continue
+		}
+		if !c.ownedByCluster(instance) {
+			continue
+		}
+		if _, found := c.asgCache.FindInstanceByName(id); found {
+			continue
+		}
+		candidates = append(candidates, id)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	// Instances in a warm pool, in standby or detached on purpose aren't members of the ASG, but they're still
+	// associated with it and are reported with their lifecycle state.
+	states, err := c.awsService.getAutoScalingLifecycleStates(candidates)
+	if err != nil {
+		return err
+	}
+	var unassociated []string
+	for _, id := range candidates {
+		if state, found := states[id]; found {
+			klog.V(4).Infof("Not terminating instance %s which never registered as a node, it's in lifecycle state %s", id, state)
+			continue
+		}
+		unassociated = append(unassociated, id)
+	}
+	if len(unassociated) == 0 {
+		return nil
+	}
+
+	// Only instances known to be broken are terminated, the ones which are merely slow to register are left alone.
+	impairedSince, err := c.awsService.getImpairedSince(unassociated)
+	if err != nil {
+		return err
+	}
+	var orphaned []string
+	for _, id := range unassociated {
+		if since, found := impairedSince[id]; found && !since.Add(c.minAge).After(now) {
+			orphaned = append(orphaned, id)
+		}
+	}
+	if len(orphaned) == 0 {
+		return nil
+	}
+	if c.dryRun {
+		klog.V(0).Infof("Dry-run: would terminate %d orphaned instances which never registered as nodes: %v", len(orphaned), orphaned)
+		return nil
+	}
+	klog.V(0).Infof("Terminating %d orphaned instances which never registered as nodes: %v", len(orphaned), orphaned)
+	return c.awsService.terminateInstances(orphaned)
+}
+
+// ownedByCluster returns whether the instance is tagged as owned by the cluster.
+func (c *orphanedInstancesCollector) ownedByCluster(instance *ec2.Instance) bool {
+	for _, tag := range instance.Tags {
+		if aws.StringValue(tag.Key) == clusterOwnershipTagPrefix+c.clusterName {
+			return aws.StringValue(tag.Value) == "owned"
+		}
+	}
+	return false
+}
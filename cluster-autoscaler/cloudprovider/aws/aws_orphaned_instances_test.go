@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/aws"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/service/autoscaling"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/service/ec2"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+func TestCollectOrphanedInstances(t *testing.T) {
+	now := time.Now()
+	instance := func(id string, launchTime time.Time, clusterTag string) *ec2.Instance {
+		i := &ec2.Instance{InstanceId: aws.String(id), LaunchTime: aws.Time(launchTime)}
+		if clusterTag != "" {
+			i.Tags = []*ec2.Tag{{Key: aws.String("kubernetes.io/cluster/test-cluster"), Value: aws.String(clusterTag)}}
+		}
+		return i
+	}
+	impaired := func(id string, since time.Time) *ec2.InstanceStatus {
+		return &ec2.InstanceStatus{
+			InstanceId: aws.String(id),
+			InstanceStatus: &ec2.InstanceStatusSummary{
+				Status: aws.String(ec2.SummaryStatusImpaired),
+				Details: []*ec2.InstanceStatusDetails{
+					{Status: aws.String(ec2.StatusTypeFailed), ImpairedSince: aws.Time(since)},
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		dryRun         bool
+		wantTerminated []string
+	}{
+		{
+			name:           "terminates failed orphaned instances",
+			wantTerminated: []string{"i-orphaned"},
+		},
+		{
+			name:   "dry-run",
+			dryRun: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			e := &ec2Mock{}
+			e.On("DescribeInstancesPages", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				input := args.Get(0).(*ec2.DescribeInstancesInput)
+				assert.Equal(t, "tag:aws:autoscaling:groupName", aws.StringValue(input.Filters[0].Name))
+				assert.Equal(t, []string{"test-asg"}, aws.StringValueSlice(input.Filters[0].Values))
+				fn := args.Get(1).(func(*ec2.DescribeInstancesOutput, bool) bool)
+				fn(&ec2.DescribeInstancesOutput{
+					Reservations: []*ec2.Reservation{
+						{Instances: []*ec2.Instance{
+							// Member of the ASG, which didn't register yet.
+							instance("i-member", now.Add(-2*time.Hour), "owned"),
+							// Detached from the ASG, but registered as a node.
+							instance("i-registered", now.Add(-2*time.Hour), "owned"),
+							// Not owned by the cluster.
+							instance("i-untagged", now.Add(-2*time.Hour), ""),
+							instance("i-shared", now.Add(-2*time.Hour), "shared"),
+						}},
+						{Instances: []*ec2.Instance{
+							instance("i-orphaned", now.Add(-2*time.Hour), "owned"),
+							instance("i-young", now.Add(-10*time.Minute), "owned"),
+							// Still associated with the ASG.
+							instance("i-warmed", now.Add(-2*time.Hour), "owned"),
+							instance("i-standby", now.Add(-2*time.Hour), "owned"),
+							// Passing its status checks, or failing them only recently.
+							instance("i-healthy", now.Add(-2*time.Hour), "owned"),
+							instance("i-recently-impaired", now.Add(-2*time.Hour), "owned"),
+						}},
+					},
+				}, true)
+			}).Return(nil)
+			e.On("DescribeInstanceStatusPages", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				input := args.Get(0).(*ec2.DescribeInstanceStatusInput)
+				assert.ElementsMatch(t, []string{"i-orphaned", "i-healthy", "i-recently-impaired"}, aws.StringValueSlice(input.InstanceIds))
+				fn := args.Get(1).(func(*ec2.DescribeInstanceStatusOutput, bool) bool)
+				fn(&ec2.DescribeInstanceStatusOutput{
+					InstanceStatuses: []*ec2.InstanceStatus{
+						impaired("i-orphaned", now.Add(-90*time.Minute)),
+						impaired("i-recently-impaired", now.Add(-10*time.Minute)),
+						{InstanceId: aws.String("i-healthy"), InstanceStatus: &ec2.InstanceStatusSummary{Status: aws.String(ec2.SummaryStatusOk)}},
+					},
+				}, true)
+			}).Return(nil)
+			if len(tc.wantTerminated) > 0 {
+				e.On("TerminateInstances", &ec2.TerminateInstancesInput{InstanceIds: aws.StringSlice(tc.wantTerminated)}).Return(&ec2.TerminateInstancesOutput{}, nil)
+			}
+			a := &autoScalingMock{}
+			a.On("DescribeAutoScalingInstancesPages", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				fn := args.Get(1).(func(*autoscaling.DescribeAutoScalingInstancesOutput, bool) bool)
+				fn(&autoscaling.DescribeAutoScalingInstancesOutput{
+					AutoScalingInstances: []*autoscaling.InstanceDetails{
+						{InstanceId: aws.String("i-warmed"), LifecycleState: aws.String(autoscaling.LifecycleStateWarmedRunning)},
+						{InstanceId: aws.String("i-standby"), LifecycleState: aws.String(autoscaling.LifecycleStateStandby)},
+					},
+				}, true)
+			}).Return(nil)
+
+			m := newTestAwsManagerWithMockServices(a, e, nil, nil, nil)
+			testAsg := &asg{AwsRef: AwsRef{Name: "test-asg"}}
+			m.asgCache.registeredAsgs[testAsg.AwsRef] = testAsg
+			m.asgCache.instanceToAsg[AwsInstanceRef{ProviderID: "aws:///us-east-1a/i-member", Name: "i-member"}] = testAsg
+			registered := BuildTestNode("registered", 1000, 1000)
+			registered.Spec.ProviderID = "aws:///us-east-1a/i-registered"
+
+			c := newOrphanedInstancesCollector(&m.awsService, m.asgCache, "test-cluster", time.Hour, tc.dryRun)
+			assert.NoError(t, c.collect([]*apiv1.Node{registered}, now))
+			// Instances aren't listed again until the interval passes.
+			assert.NoError(t, c.collect([]*apiv1.Node{registered}, now.Add(time.Minute)))
+			e.AssertNumberOfCalls(t, "DescribeInstancesPages", 1)
+			e.AssertNumberOfCalls(t, "TerminateInstances", len(tc.wantTerminated))
+			a.AssertCalled(t, "DescribeAutoScalingInstancesPages", mock.MatchedBy(func(input *autoscaling.DescribeAutoScalingInstancesInput) bool {
+				return assert.ElementsMatch(t, []string{"i-orphaned", "i-warmed", "i-standby", "i-healthy", "i-recently-impaired"}, aws.StringValueSlice(input.InstanceIds))
+			}), mock.Anything)
+
+			assert.NoError(t, c.collect([]*apiv1.Node{registered}, now.Add(orphanedInstancesGCInterval)))
+			e.AssertNumberOfCalls(t, "DescribeInstancesPages", 2)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"slices"
+	"sync"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/aws"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/service/ec2"
+)
+
+// rootDeviceNames are the root device names of the AMIs commonly used for nodes, e.g. /dev/xvda for
+// Amazon Linux and /dev/sda1 for Ubuntu.
+var rootDeviceNames = []string{"/dev/xvda", "/dev/sda1"}
+
+// rootVolumeSizesCache holds the size of the root volume of launch templates, which the ephemeral storage
+// of nodes is carved from. It's invalidated on every ASG refresh, as templates can point at their latest
+// version.
+type rootVolumeSizesCache struct {
+	mutex sync.Mutex
+	sizes map[launchTemplate]int64
+}
+
+// get returns the size in GiB of the root volume of the launch template, 0 if the template doesn't set it.
+func (c *rootVolumeSizesCache) get(awsService *awsWrapper, template launchTemplate) (int64, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if size, found := c.sizes[template]; found {
+		return size, nil
+	}
+	templateData, err := awsService.getLaunchTemplateData(template.name, template.version)
+	if err != nil {
+		return 0, err
+	}
+	if c.sizes == nil {
+		c.sizes = make(map[launchTemplate]int64)
+	}
+	size := rootVolumeSizeGiB(templateData.BlockDeviceMappings)
+	c.sizes[template] = size
+	return size, nil
+}
+
+func (c *rootVolumeSizesCache) invalidate() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.sizes = nil
+}
+
+// rootVolumeSizeGiB returns the size of the EBS volume mapped to a well-known root device, 0 if there's none.
+func rootVolumeSizeGiB(mappings []*ec2.LaunchTemplateBlockDeviceMapping) int64 {
+	for _, mapping := range mappings {
+		if mapping.Ebs == nil || !slices.Contains(rootDeviceNames, aws.StringValue(mapping.DeviceName)) {
+			continue
+		}
+		return aws.Int64Value(mapping.Ebs.VolumeSize)
+	}
+	return 0
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/aws"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/service/autoscaling"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/service/ec2"
+)
+
+func TestRootVolumeSizeGiB(t *testing.T) {
+	ebsMapping := func(deviceName string, size int64) *ec2.LaunchTemplateBlockDeviceMapping {
+		return &ec2.LaunchTemplateBlockDeviceMapping{
+			DeviceName: aws.String(deviceName),
+			Ebs:        &ec2.LaunchTemplateEbsBlockDevice{VolumeSize: aws.Int64(size)},
+		}
+	}
+	for _, tc := range []struct {
+		name     string
+		mappings []*ec2.LaunchTemplateBlockDeviceMapping
+		expected int64
+	}{
+		{
+			name: "no mappings",
+		},
+		{
+			name:     "amazon linux root volume",
+			mappings: []*ec2.LaunchTemplateBlockDeviceMapping{ebsMapping("/dev/xvdf", 500), ebsMapping("/dev/xvda", 100)},
+			expected: 100,
+		},
+		{
+			name:     "ubuntu root volume",
+			mappings: []*ec2.LaunchTemplateBlockDeviceMapping{ebsMapping("/dev/sda1", 200)},
+			expected: 200,
+		},
+		{
+			name: "root volume without size",
+			mappings: []*ec2.LaunchTemplateBlockDeviceMapping{{
+				DeviceName: aws.String("/dev/xvda"),
+				Ebs:        &ec2.LaunchTemplateEbsBlockDevice{},
+			}},
+		},
+		{
+			name: "instance store volume",
+			mappings: []*ec2.LaunchTemplateBlockDeviceMapping{{
+				DeviceName:  aws.String("/dev/xvda"),
+				VirtualName: aws.String("ephemeral0"),
+			}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, rootVolumeSizeGiB(tc.mappings))
+		})
+	}
+}
+
+func TestBuildNodeFromTemplateWithRootVolume(t *testing.T) {
+	awsManager := &AwsManager{}
+	asg := &asg{AwsRef: AwsRef{Name: "test-auto-scaling-group"}}
+	template := &asgTemplate{
+		InstanceType:      &InstanceType{InstanceType: "c5.xlarge", VCPU: 4, MemoryMb: 8192},
+		RootVolumeSizeGiB: 100,
+	}
+
+	node, err := awsManager.buildNodeFromTemplate(asg, template)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100*1024*1024*1024), node.Status.Capacity.StorageEphemeral().Value())
+
+	// Tags override the size of the root volume.
+	template.Tags = []*autoscaling.TagDescription{{
+		Key:   aws.String("k8s.io/cluster-autoscaler/node-template/resources/ephemeral-storage"),
+		Value: aws.String("50Gi"),
+	}}
+	node, err = awsManager.buildNodeFromTemplate(asg, template)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(50*1024*1024*1024), node.Status.Capacity.StorageEphemeral().Value())
+}
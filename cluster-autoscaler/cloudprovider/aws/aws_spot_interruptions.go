@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"encoding/json"
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/aws"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/service/sqs"
+	klog "k8s.io/klog/v2"
+)
+
+const (
+	spotInterruptionWarningDetailType = "EC2 Spot Instance Interruption Warning"
+	// spotInterruptionNotice is the time between the interruption warning and the termination of the instance.
+	spotInterruptionNotice = 2 * time.Minute
+	// maxSpotInterruptionBatches limits the number of batches of messages received in a single call.
+	maxSpotInterruptionBatches = 10
+	maxMessagesPerReceive      = 10
+)
+
+// sqsI is the interface abstracting specific API calls of the SQS service provided by AWS SDK for use in CA
+type sqsI interface {
+	DeleteMessage(input *sqs.DeleteMessageInput) (*sqs.DeleteMessageOutput, error)
+	ReceiveMessage(input *sqs.ReceiveMessageInput) (*sqs.ReceiveMessageOutput, error)
+}
+
+// spotInterruptionQueue receives EC2 Spot Instance Interruption Warnings delivered by EventBridge to an SQS queue.
+type spotInterruptionQueue struct {
+	sqs      sqsI
+	queueURL string
+}
+
+type spotInterruption struct {
+	instanceID      string
+	terminationTime time.Time
+}
+
+type spotInterruptionEvent struct {
+	DetailType string    `json:"detail-type"`
+	Time       time.Time `json:"time"`
+	Detail     struct {
+		InstanceID string `json:"instance-id"`
+	} `json:"detail"`
+}
+
+func newSpotInterruptionQueue(sqs sqsI, queueURL string) *spotInterruptionQueue {
+	return &spotInterruptionQueue{sqs: sqs, queueURL: queueURL}
+}
+
+// receive returns the interruptions from the messages waiting in the queue. All received messages are deleted,
+// including events of other types.
+func (q *spotInterruptionQueue) receive() ([]spotInterruption, error) {
+	var interruptions []spotInterruption
+	for i := 0; i < maxSpotInterruptionBatches; i++ {
+		start := time.Now()
+		output, err := q.sqs.ReceiveMessage(&sqs.ReceiveMessageInput{
+			QueueUrl:            aws.String(q.queueURL),
+			MaxNumberOfMessages: aws.Int64(maxMessagesPerReceive),
+		})
+		observeAWSRequest("ReceiveMessage", err, start)
+		if err != nil {
+			return interruptions, err
+		}
+		if len(output.Messages) == 0 {
+			break
+		}
+		for _, message := range output.Messages {
+			var event spotInterruptionEvent
+			if err := json.Unmarshal([]byte(aws.StringValue(message.Body)), &event); err != nil {
+				klog.Warningf("Failed to parse message %s from spot interruption queue: %v", aws.StringValue(message.MessageId), err)
+			} else if event.DetailType == spotInterruptionWarningDetailType && event.Detail.InstanceID != "" {
+				interruptions = append(interruptions, spotInterruption{
+					instanceID:      event.Detail.InstanceID,
+					terminationTime: event.Time.Add(spotInterruptionNotice),
+				})
+			}
+			start := time.Now()
+			_, err := q.sqs.DeleteMessage(&sqs.DeleteMessageInput{
+				QueueUrl:      aws.String(q.queueURL),
+				ReceiptHandle: message.ReceiptHandle,
+			})
+			observeAWSRequest("DeleteMessage", err, start)
+			if err != nil {
+				klog.Warningf("Failed to delete message %s from spot interruption queue: %v", aws.StringValue(message.MessageId), err)
+			}
+		}
+	}
+	return interruptions, nil
+}
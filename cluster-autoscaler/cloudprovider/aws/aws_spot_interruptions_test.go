@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/aws"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/service/sqs"
+)
+
+type sqsMock struct {
+	mock.Mock
+}
+
+func (s *sqsMock) DeleteMessage(input *sqs.DeleteMessageInput) (*sqs.DeleteMessageOutput, error) {
+	args := s.Called(input)
+	return args.Get(0).(*sqs.DeleteMessageOutput), args.Error(1)
+}
+
+func (s *sqsMock) ReceiveMessage(input *sqs.ReceiveMessageInput) (*sqs.ReceiveMessageOutput, error) {
+	args := s.Called(input)
+	return args.Get(0).(*sqs.ReceiveMessageOutput), args.Error(1)
+}
+
+func TestSpotInterruptionNotices(t *testing.T) {
+	provider := testProvider(t, newTestAwsManagerWithMockServices(&autoScalingMock{}, nil, nil, nil, nil))
+	notices, err := provider.SpotInterruptionNotices()
+	assert.NoError(t, err)
+	assert.Empty(t, notices)
+
+	s := &sqsMock{}
+	receiveInput := &sqs.ReceiveMessageInput{
+		QueueUrl:            aws.String("test-queue"),
+		MaxNumberOfMessages: aws.Int64(maxMessagesPerReceive),
+	}
+	s.On("ReceiveMessage", receiveInput).Return(&sqs.ReceiveMessageOutput{
+		Messages: []*sqs.Message{
+			{
+				MessageId:     aws.String("m1"),
+				ReceiptHandle: aws.String("r1"),
+				Body:          aws.String(`{"detail-type":"EC2 Spot Instance Interruption Warning","time":"2025-01-02T03:04:05Z","detail":{"instance-id":"i-1","instance-action":"terminate"}}`),
+			},
+			{
+				MessageId:     aws.String("m2"),
+				ReceiptHandle: aws.String("r2"),
+				Body:          aws.String(`{"detail-type":"EC2 Spot Instance Interruption Warning","time":"2025-01-02T03:04:05Z","detail":{"instance-id":"i-unknown","instance-action":"terminate"}}`),
+			},
+			{
+				MessageId:     aws.String("m3"),
+				ReceiptHandle: aws.String("r3"),
+				Body:          aws.String(`{"detail-type":"EC2 Instance Rebalance Recommendation","time":"2025-01-02T03:04:05Z","detail":{"instance-id":"i-1"}}`),
+			},
+			{
+				MessageId:     aws.String("m4"),
+				ReceiptHandle: aws.String("r4"),
+				Body:          aws.String(`not json`),
+			},
+		},
+	}, nil).Once()
+	s.On("ReceiveMessage", receiveInput).Return(&sqs.ReceiveMessageOutput{}, nil).Once()
+	s.On("DeleteMessage", mock.Anything).Return(&sqs.DeleteMessageOutput{}, nil)
+
+	m := newTestAwsManagerWithMockServices(&autoScalingMock{}, nil, nil, nil, nil)
+	m.spotInterruptions = newSpotInterruptionQueue(s, "test-queue")
+	instance := AwsInstanceRef{ProviderID: "aws:///us-east-1a/i-1", Name: "i-1"}
+	m.asgCache.instanceToAsg[instance] = &asg{AwsRef: AwsRef{Name: "test-asg"}}
+	provider = testProvider(t, m)
+
+	notices, err = provider.SpotInterruptionNotices()
+	assert.NoError(t, err)
+	assert.Equal(t, []cloudprovider.SpotInterruptionNotice{
+		{ProviderID: "aws:///us-east-1a/i-1", TerminationTime: time.Date(2025, 1, 2, 3, 6, 5, 0, time.UTC)},
+	}, notices)
+	s.AssertNumberOfCalls(t, "ReceiveMessage", 2)
+	s.AssertNumberOfCalls(t, "DeleteMessage", 4)
+}
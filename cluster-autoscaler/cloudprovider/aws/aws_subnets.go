@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/aws"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/service/ec2"
+)
+
+// subnetsCache holds the number of free IP addresses of subnets. It's invalidated on every refresh, as
+// addresses are taken by every node and pod launched in the subnets.
+type subnetsCache struct {
+	mutex         sync.Mutex
+	freeAddresses map[string]int64
+}
+
+// getFreeAddresses returns the number of free IP addresses of the subnets, only describing the ones
+// not cached yet.
+func (c *subnetsCache) getFreeAddresses(awsService *awsWrapper, subnets []string) (map[string]int64, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	var missing []string
+	for _, subnet := range subnets {
+		if _, found := c.freeAddresses[subnet]; !found {
+			missing = append(missing, subnet)
+		}
+	}
+	if len(missing) > 0 {
+		freeAddresses, err := awsService.getSubnetsFreeAddresses(missing)
+		if err != nil {
+			return nil, err
+		}
+		if c.freeAddresses == nil {
+			c.freeAddresses = make(map[string]int64)
+		}
+		for subnet, free := range freeAddresses {
+			c.freeAddresses[subnet] = free
+		}
+	}
+	result := make(map[string]int64, len(subnets))
+	for _, subnet := range subnets {
+		if free, found := c.freeAddresses[subnet]; found {
+			result[subnet] = free
+		}
+	}
+	return result, nil
+}
+
+func (c *subnetsCache) invalidate() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.freeAddresses = nil
+}
+
+func (m *awsWrapper) getSubnetsFreeAddresses(subnets []string) (map[string]int64, error) {
+	start := time.Now()
+	output, err := m.DescribeSubnets(&ec2.DescribeSubnetsInput{SubnetIds: aws.StringSlice(subnets)})
+	observeAWSRequest("DescribeSubnets", err, start)
+	if err != nil {
+		return nil, err
+	}
+	freeAddresses := make(map[string]int64, len(output.Subnets))
+	for _, subnet := range output.Subnets {
+		freeAddresses[aws.StringValue(subnet.SubnetId)] = aws.Int64Value(subnet.AvailableIpAddressCount)
+	}
+	return freeAddresses, nil
+}
+
+// parseSubnets returns the IDs of the subnets of a comma separated VPCZoneIdentifier.
+func parseSubnets(vpcZoneIdentifier string) []string {
+	var subnets []string
+	for _, subnet := range strings.Split(vpcZoneIdentifier, ",") {
+		if subnet = strings.TrimSpace(subnet); subnet != "" {
+			subnets = append(subnets, subnet)
+		}
+	}
+	return subnets
+}
+
+// addressesPerNode returns the number of IP addresses of its subnet taken by a new node of the instance type.
+// Besides the primary address of the node, the Amazon VPC CNI plugin attaches the secondary addresses of
+// the primary ENI or a /28 prefix when prefix delegation is enabled.
+func addressesPerNode(instanceType *InstanceType, mode string) int64 {
+	switch {
+	case mode == maxPodsModeVpcCni && instanceType != nil && instanceType.IPv4AddressesPerInterface > 0:
+		return instanceType.IPv4AddressesPerInterface
+	case mode == maxPodsModeVpcCniPrefixDelegation:
+		return 1 + ipv4PrefixSize
+	default:
+		return 1
+	}
+}
+
+// freeSubnetCapacity returns the number of nodes taking the given number of addresses each which can still be
+// launched in the subnets.
+func freeSubnetCapacity(freeAddresses map[string]int64, perNode int64) int64 {
+	var capacity int64
+	for _, free := range freeAddresses {
+		if free > 0 {
+			capacity += free / perNode
+		}
+	}
+	return capacity
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/aws"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/service/ec2"
+)
+
+func TestParseSubnets(t *testing.T) {
+	assert.Nil(t, parseSubnets(""))
+	assert.Equal(t, []string{"subnet-a", "subnet-b"}, parseSubnets("subnet-a, subnet-b,"))
+}
+
+func TestAddressesPerNode(t *testing.T) {
+	t3Small := &InstanceType{InstanceType: "t3.small", VCPU: 2, MaxNetworkInterfaces: 3, IPv4AddressesPerInterface: 4}
+	staticType := &InstanceType{InstanceType: "m5.large", VCPU: 2}
+
+	assert.Equal(t, int64(1), addressesPerNode(t3Small, maxPodsModeDefault))
+	assert.Equal(t, int64(4), addressesPerNode(t3Small, maxPodsModeVpcCni))
+	assert.Equal(t, int64(1), addressesPerNode(staticType, maxPodsModeVpcCni))
+	assert.Equal(t, int64(1), addressesPerNode(nil, maxPodsModeVpcCni))
+	assert.Equal(t, int64(17), addressesPerNode(nil, maxPodsModeVpcCniPrefixDelegation))
+}
+
+func TestFreeSubnetCapacity(t *testing.T) {
+	freeAddresses := map[string]int64{"subnet-a": 9, "subnet-b": 4, "subnet-c": 0}
+	assert.Equal(t, int64(13), freeSubnetCapacity(freeAddresses, 1))
+	// Addresses of different subnets can't be combined for a single node.
+	assert.Equal(t, int64(3), freeSubnetCapacity(freeAddresses, 4))
+	assert.Equal(t, int64(0), freeSubnetCapacity(freeAddresses, 17))
+}
+
+func TestGetAsgFreeSubnetCapacity(t *testing.T) {
+	e := &ec2Mock{}
+	e.On("DescribeSubnets", &ec2.DescribeSubnetsInput{SubnetIds: aws.StringSlice([]string{"subnet-a", "subnet-b"})}).Return(&ec2.DescribeSubnetsOutput{
+		Subnets: []*ec2.Subnet{
+			{SubnetId: aws.String("subnet-a"), AvailableIpAddressCount: aws.Int64(3)},
+			{SubnetId: aws.String("subnet-b"), AvailableIpAddressCount: aws.Int64(0)},
+		},
+	}, nil).Once()
+	e.On("DescribeSubnets", &ec2.DescribeSubnetsInput{SubnetIds: aws.StringSlice([]string{"subnet-c"})}).Return(&ec2.DescribeSubnetsOutput{
+		Subnets: []*ec2.Subnet{
+			{SubnetId: aws.String("subnet-c"), AvailableIpAddressCount: aws.Int64(2)},
+		},
+	}, nil).Once()
+
+	m := newTestAwsManagerWithMockServices(&autoScalingMock{}, e, nil, nil, nil)
+	asgA := &asg{AwsRef: AwsRef{Name: "test-asg-a"}, Subnets: []string{"subnet-a", "subnet-b"}}
+	asgB := &asg{AwsRef: AwsRef{Name: "test-asg-b"}, Subnets: []string{"subnet-b", "subnet-c"}}
+
+	for i := 0; i < 2; i++ {
+		free, known, err := m.GetAsgFreeSubnetCapacity(asgA)
+		assert.NoError(t, err)
+		assert.True(t, known)
+		assert.Equal(t, int64(3), free)
+	}
+	// Only the subnets which aren't cached yet are described.
+	free, known, err := m.GetAsgFreeSubnetCapacity(asgB)
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.Equal(t, int64(2), free)
+
+	free, known, err = m.GetAsgFreeSubnetCapacity(&asg{AwsRef: AwsRef{Name: "test-asg-no-subnets"}})
+	assert.NoError(t, err)
+	assert.False(t, known)
+	assert.Equal(t, int64(0), free)
+
+	// The subnets are described again once the cache is invalidated.
+	m.subnets.invalidate()
+	e.On("DescribeSubnets", &ec2.DescribeSubnetsInput{SubnetIds: aws.StringSlice([]string{"subnet-a", "subnet-b"})}).Return(&ec2.DescribeSubnetsOutput{
+		Subnets: []*ec2.Subnet{
+			{SubnetId: aws.String("subnet-a"), AvailableIpAddressCount: aws.Int64(0)},
+			{SubnetId: aws.String("subnet-b"), AvailableIpAddressCount: aws.Int64(0)},
+		},
+	}, nil).Once()
+	free, known, err = m.GetAsgFreeSubnetCapacity(asgA)
+	assert.NoError(t, err)
+	assert.True(t, known)
+	assert.Equal(t, int64(0), free)
+	e.AssertNumberOfCalls(t, "DescribeSubnets", 3)
+}
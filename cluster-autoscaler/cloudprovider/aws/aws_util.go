@@ -74,6 +74,10 @@ func transformInstanceType(rawInstanceType *ec2.InstanceTypeInfo) *InstanceType
 	if rawInstanceType.ProcessorInfo != nil && len(rawInstanceType.ProcessorInfo.SupportedArchitectures) > 0 {
 		instanceType.Architecture = interpretEc2SupportedArchitecure(*rawInstanceType.ProcessorInfo.SupportedArchitectures[0])
 	}
+	if rawInstanceType.NetworkInfo != nil {
+		instanceType.MaxNetworkInterfaces = aws.Int64Value(rawInstanceType.NetworkInfo.MaximumNetworkInterfaces)
+		instanceType.IPv4AddressesPerInterface = aws.Int64Value(rawInstanceType.NetworkInfo.Ipv4AddressesPerInterface)
+	}
 	return instanceType
 }
 
@@ -31,7 +31,10 @@ import (
 
 // autoScalingI is the interface abstracting specific API calls of the auto-scaling service provided by AWS SDK for use in CA
 type autoScalingI interface {
+	CreateAutoScalingGroup(input *autoscaling.CreateAutoScalingGroupInput) (*autoscaling.CreateAutoScalingGroupOutput, error)
+	DeleteAutoScalingGroup(input *autoscaling.DeleteAutoScalingGroupInput) (*autoscaling.DeleteAutoScalingGroupOutput, error)
 	DescribeAutoScalingGroupsPages(input *autoscaling.DescribeAutoScalingGroupsInput, fn func(*autoscaling.DescribeAutoScalingGroupsOutput, bool) bool) error
+	DescribeAutoScalingInstancesPages(input *autoscaling.DescribeAutoScalingInstancesInput, fn func(*autoscaling.DescribeAutoScalingInstancesOutput, bool) bool) error
 	DescribeLaunchConfigurations(*autoscaling.DescribeLaunchConfigurationsInput) (*autoscaling.DescribeLaunchConfigurationsOutput, error)
 	DescribeScalingActivities(*autoscaling.DescribeScalingActivitiesInput) (*autoscaling.DescribeScalingActivitiesOutput, error)
 	SetDesiredCapacity(input *autoscaling.SetDesiredCapacityInput) (*autoscaling.SetDesiredCapacityOutput, error)
@@ -40,9 +43,14 @@ type autoScalingI interface {
 
 // ec2I is the interface abstracting specific API calls of the EC2 service provided by AWS SDK for use in CA
 type ec2I interface {
+	DescribeCapacityReservationsPages(input *ec2.DescribeCapacityReservationsInput, fn func(*ec2.DescribeCapacityReservationsOutput, bool) bool) error
 	DescribeImages(input *ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error)
+	DescribeInstanceStatusPages(input *ec2.DescribeInstanceStatusInput, fn func(*ec2.DescribeInstanceStatusOutput, bool) bool) error
+	DescribeInstancesPages(input *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) error
 	DescribeLaunchTemplateVersions(input *ec2.DescribeLaunchTemplateVersionsInput) (*ec2.DescribeLaunchTemplateVersionsOutput, error)
+	DescribeSubnets(input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error)
 	GetInstanceTypesFromInstanceRequirementsPages(input *ec2.GetInstanceTypesFromInstanceRequirementsInput, fn func(*ec2.GetInstanceTypesFromInstanceRequirementsOutput, bool) bool) error
+	TerminateInstances(input *ec2.TerminateInstancesInput) (*ec2.TerminateInstancesOutput, error)
 }
 
 // eksI is the interface that represents a specific aspect of EKS (Elastic Kubernetes Service) which is provided by AWS SDK for use in CA
@@ -234,6 +242,147 @@ func (m *awsWrapper) getAutoscalingGroupsByTags(tags map[string]string) ([]*auto
 	return asgs, nil
 }
 
+func (m *awsWrapper) getActiveCapacityReservations() ([]*ec2.CapacityReservation, error) {
+	reservations := make([]*ec2.CapacityReservation, 0)
+	input := &ec2.DescribeCapacityReservationsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("state"),
+				Values: []*string{aws.String(ec2.CapacityReservationStateActive)},
+			},
+		},
+	}
+	start := time.Now()
+	err := m.DescribeCapacityReservationsPages(input, func(output *ec2.DescribeCapacityReservationsOutput, _ bool) bool {
+		reservations = append(reservations, output.CapacityReservations...)
+		return true
+	})
+	observeAWSRequest("DescribeCapacityReservationsPages", err, start)
+	if err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+// getInstancesLaunchedByAsgs returns the pending and running instances tagged as launched by one of the ASGs,
+// including the ones which are no longer their members.
+func (m *awsWrapper) getInstancesLaunchedByAsgs(asgNames []string) ([]*ec2.Instance, error) {
+	// DescribeInstances accepts up to 200 values per filter.
+	const maxFilterValues = 200
+	instances := make([]*ec2.Instance, 0)
+	for i := 0; i < len(asgNames); i += maxFilterValues {
+		end := i + maxFilterValues
+		if end > len(asgNames) {
+			end = len(asgNames)
+		}
+		input := &ec2.DescribeInstancesInput{
+			Filters: []*ec2.Filter{
+				{
+					Name:   aws.String("tag:aws:autoscaling:groupName"),
+					Values: aws.StringSlice(asgNames[i:end]),
+				},
+				{
+					Name:   aws.String("instance-state-name"),
+					Values: aws.StringSlice([]string{ec2.InstanceStateNamePending, ec2.InstanceStateNameRunning}),
+				},
+			},
+		}
+		start := time.Now()
+		err := m.DescribeInstancesPages(input, func(output *ec2.DescribeInstancesOutput, _ bool) bool {
+			for _, reservation := range output.Reservations {
+				instances = append(instances, reservation.Instances...)
+			}
+			return true
+		})
+		observeAWSRequest("DescribeInstancesPages", err, start)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return instances, nil
+}
+
+// getAutoScalingLifecycleStates returns the lifecycle states of the instances which are still associated with an
+// ASG, including the ones in its warm pool, in standby or being detached, by their ids.
+func (m *awsWrapper) getAutoScalingLifecycleStates(instanceIds []string) (map[string]string, error) {
+	// DescribeAutoScalingInstances accepts up to 50 instance ids.
+	const maxInstanceIds = 50
+	states := make(map[string]string)
+	for i := 0; i < len(instanceIds); i += maxInstanceIds {
+		end := i + maxInstanceIds
+		if end > len(instanceIds) {
+			end = len(instanceIds)
+		}
+		input := &autoscaling.DescribeAutoScalingInstancesInput{
+			InstanceIds: aws.StringSlice(instanceIds[i:end]),
+		}
+		start := time.Now()
+		err := m.DescribeAutoScalingInstancesPages(input, func(output *autoscaling.DescribeAutoScalingInstancesOutput, _ bool) bool {
+			for _, instance := range output.AutoScalingInstances {
+				states[aws.StringValue(instance.InstanceId)] = aws.StringValue(instance.LifecycleState)
+			}
+			return true
+		})
+		observeAWSRequest("DescribeAutoScalingInstancesPages", err, start)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return states, nil
+}
+
+// getImpairedSince returns when the instances failing their instance or system status checks started failing them,
+// by their ids. Instances passing their status checks, or which weren't checked yet, aren't returned.
+func (m *awsWrapper) getImpairedSince(instanceIds []string) (map[string]time.Time, error) {
+	// DescribeInstanceStatus accepts up to 100 instance ids.
+	const maxInstanceIds = 100
+	impairedSince := make(map[string]time.Time)
+	for i := 0; i < len(instanceIds); i += maxInstanceIds {
+		end := i + maxInstanceIds
+		if end > len(instanceIds) {
+			end = len(instanceIds)
+		}
+		input := &ec2.DescribeInstanceStatusInput{
+			InstanceIds: aws.StringSlice(instanceIds[i:end]),
+		}
+		start := time.Now()
+		err := m.DescribeInstanceStatusPages(input, func(output *ec2.DescribeInstanceStatusOutput, _ bool) bool {
+			for _, status := range output.InstanceStatuses {
+				for _, summary := range []*ec2.InstanceStatusSummary{status.InstanceStatus, status.SystemStatus} {
+					if summary == nil || aws.StringValue(summary.Status) != ec2.SummaryStatusImpaired {
+						continue
+					}
+					for _, details := range summary.Details {
+						if aws.StringValue(details.Status) != ec2.StatusTypeFailed || details.ImpairedSince == nil {
+							continue
+						}
+						id := aws.StringValue(status.InstanceId)
+						if since, found := impairedSince[id]; !found || details.ImpairedSince.Before(since) {
+							impairedSince[id] = *details.ImpairedSince
+						}
+					}
+				}
+			}
+			return true
+		})
+		observeAWSRequest("DescribeInstanceStatusPages", err, start)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return impairedSince, nil
+}
+
+func (m *awsWrapper) terminateInstances(instanceIds []string) error {
+	start := time.Now()
+	_, err := m.TerminateInstances(&ec2.TerminateInstancesInput{
+		InstanceIds: aws.StringSlice(instanceIds),
+	})
+	observeAWSRequest("TerminateInstances", err, start)
+	return err
+}
+
 func (m *awsWrapper) getInstanceTypeByLaunchTemplate(launchTemplate *launchTemplate) (string, error) {
 	templateData, err := m.getLaunchTemplateData(launchTemplate.name, launchTemplate.version)
 	if err != nil {
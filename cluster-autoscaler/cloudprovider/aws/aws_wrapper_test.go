@@ -35,11 +35,26 @@ type autoScalingMock struct {
 	mock.Mock
 }
 
+func (a *autoScalingMock) CreateAutoScalingGroup(input *autoscaling.CreateAutoScalingGroupInput) (*autoscaling.CreateAutoScalingGroupOutput, error) {
+	args := a.Called(input)
+	return args.Get(0).(*autoscaling.CreateAutoScalingGroupOutput), args.Error(1)
+}
+
+func (a *autoScalingMock) DeleteAutoScalingGroup(input *autoscaling.DeleteAutoScalingGroupInput) (*autoscaling.DeleteAutoScalingGroupOutput, error) {
+	args := a.Called(input)
+	return args.Get(0).(*autoscaling.DeleteAutoScalingGroupOutput), args.Error(1)
+}
+
 func (a *autoScalingMock) DescribeAutoScalingGroupsPages(i *autoscaling.DescribeAutoScalingGroupsInput, fn func(*autoscaling.DescribeAutoScalingGroupsOutput, bool) bool) error {
 	args := a.Called(i, fn)
 	return args.Error(0)
 }
 
+func (a *autoScalingMock) DescribeAutoScalingInstancesPages(i *autoscaling.DescribeAutoScalingInstancesInput, fn func(*autoscaling.DescribeAutoScalingInstancesOutput, bool) bool) error {
+	args := a.Called(i, fn)
+	return args.Error(0)
+}
+
 func (a *autoScalingMock) DescribeLaunchConfigurations(i *autoscaling.DescribeLaunchConfigurationsInput) (*autoscaling.DescribeLaunchConfigurationsOutput, error) {
 	args := a.Called(i)
 	return args.Get(0).(*autoscaling.DescribeLaunchConfigurationsOutput), nil
@@ -64,21 +79,46 @@ type ec2Mock struct {
 	mock.Mock
 }
 
+func (e *ec2Mock) DescribeCapacityReservationsPages(input *ec2.DescribeCapacityReservationsInput, fn func(*ec2.DescribeCapacityReservationsOutput, bool) bool) error {
+	args := e.Called(input, fn)
+	return args.Error(0)
+}
+
 func (e *ec2Mock) DescribeImages(input *ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error) {
 	args := e.Called(input)
 	return args.Get(0).(*ec2.DescribeImagesOutput), nil
 }
 
+func (e *ec2Mock) DescribeInstanceStatusPages(input *ec2.DescribeInstanceStatusInput, fn func(*ec2.DescribeInstanceStatusOutput, bool) bool) error {
+	args := e.Called(input, fn)
+	return args.Error(0)
+}
+
+func (e *ec2Mock) DescribeInstancesPages(input *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) error {
+	args := e.Called(input, fn)
+	return args.Error(0)
+}
+
 func (e *ec2Mock) DescribeLaunchTemplateVersions(i *ec2.DescribeLaunchTemplateVersionsInput) (*ec2.DescribeLaunchTemplateVersionsOutput, error) {
 	args := e.Called(i)
 	return args.Get(0).(*ec2.DescribeLaunchTemplateVersionsOutput), nil
 }
 
+func (e *ec2Mock) DescribeSubnets(input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+	args := e.Called(input)
+	return args.Get(0).(*ec2.DescribeSubnetsOutput), args.Error(1)
+}
+
 func (e *ec2Mock) GetInstanceTypesFromInstanceRequirementsPages(input *ec2.GetInstanceTypesFromInstanceRequirementsInput, fn func(*ec2.GetInstanceTypesFromInstanceRequirementsOutput, bool) bool) error {
 	args := e.Called(input, fn)
 	return args.Error(0)
 }
 
+func (e *ec2Mock) TerminateInstances(input *ec2.TerminateInstancesInput) (*ec2.TerminateInstancesOutput, error) {
+	args := e.Called(input)
+	return args.Get(0).(*ec2.TerminateInstancesOutput), args.Error(1)
+}
+
 type eksMock struct {
 	mock.Mock
 }
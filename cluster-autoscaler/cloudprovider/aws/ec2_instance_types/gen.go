@@ -54,11 +54,13 @@ package aws
 
 // InstanceType is spec of EC2 instance
 type InstanceType struct {
-	InstanceType string
-	VCPU         int64
-	MemoryMb     int64
-	GPU          int64
-	Architecture string
+	InstanceType              string
+	VCPU                      int64
+	MemoryMb                  int64
+	GPU                       int64
+	Architecture              string
+	MaxNetworkInterfaces      int64
+	IPv4AddressesPerInterface int64
 }
 
 // StaticListLastUpdateTime is a string declaring the last time the static list was updated.
@@ -68,11 +70,13 @@ var StaticListLastUpdateTime = "{{ .LastUpdateTime }}"
 var InstanceTypes = map[string]*InstanceType{
 {{- range .InstanceTypes }}
 	"{{ .InstanceType }}": {
-		InstanceType: "{{ .InstanceType }}",
-		VCPU:         {{ .VCPU }},
-		MemoryMb:     {{ .MemoryMb }},
-		GPU:          {{ .GPU }},
-		Architecture: "{{ .Architecture }}",
+		InstanceType:              "{{ .InstanceType }}",
+		VCPU:                      {{ .VCPU }},
+		MemoryMb:                  {{ .MemoryMb }},
+		GPU:                       {{ .GPU }},
+		Architecture:              "{{ .Architecture }}",
+		MaxNetworkInterfaces:      {{ .MaxNetworkInterfaces }},
+		IPv4AddressesPerInterface: {{ .IPv4AddressesPerInterface }},
 	},
 {{- end }}
 }
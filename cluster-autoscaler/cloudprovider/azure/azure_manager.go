@@ -25,6 +25,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2022-08-01/compute"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
@@ -337,6 +338,16 @@ func (m *AzureManager) getFilteredScaleSets(filter []labelAutoDiscoveryConfig) (
 				continue
 			}
 		}
+		if scaleSet.VirtualMachineScaleSetProperties != nil && scaleSet.OrchestrationMode == compute.Flexible {
+			if !m.config.EnableVmssFlexNodes {
+				klog.Warningf("ignoring vmss %q because of Flexible orchestration mode with 'enableVmssFlexNodes' turned off", *scaleSet.Name)
+				continue
+			}
+			if scaleSet.Sku == nil || scaleSet.Sku.Capacity == nil {
+				klog.Warningf("ignoring vmss %q because of Flexible orchestration mode without a scaling profile", *scaleSet.Name)
+				continue
+			}
+		}
 		spec := &dynamic.NodeGroupSpec{
 			Name:               *scaleSet.Name,
 			MinSize:            1,
@@ -1187,6 +1187,66 @@ func TestGetFilteredAutoscalingGroupsVmssWithConfiguredSizes(t *testing.T) {
 	assert.True(t, assert.ObjectsAreEqualValues(expectedAsgs, asgs), "expected %#v, but found: %#v", expectedAsgs, asgs)
 }
 
+func TestGetFilteredAutoscalingGroupsVmssFlex(t *testing.T) {
+	originalEnv := saveAndClearEnv()
+	t.Cleanup(func() {
+		loadEnv(originalEnv)
+	})
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	vmssTag := "fake-tag"
+	vmssTagValue := "fake-value"
+	ngdo := cloudprovider.NodeGroupDiscoveryOptions{
+		NodeGroupAutoDiscoverySpecs: []string{fmt.Sprintf("label:%s=%s,min=1,max=5", vmssTag, vmssTagValue)},
+	}
+	specs, err := ParseLabelAutoDiscoverySpecs(ngdo)
+	assert.NoError(t, err)
+
+	flexVMSS := fakeVMSSWithTags("flex-vmss", map[string]*string{vmssTag: &vmssTagValue})
+	flexVMSS.VirtualMachineScaleSetProperties = &compute.VirtualMachineScaleSetProperties{OrchestrationMode: compute.Flexible}
+	profilelessFlexVMSS := fakeVMSSWithTags("profileless-flex-vmss", map[string]*string{vmssTag: &vmssTagValue})
+	profilelessFlexVMSS.VirtualMachineScaleSetProperties = &compute.VirtualMachineScaleSetProperties{OrchestrationMode: compute.Flexible}
+	profilelessFlexVMSS.Sku = nil
+	uniformVMSS := fakeVMSSWithTags("uniform-vmss", map[string]*string{vmssTag: &vmssTagValue})
+
+	for _, tc := range []struct {
+		name                string
+		enableVmssFlexNodes bool
+		expectedNodeGroups  []string
+	}{
+		{
+			name:               "flexible scale sets are ignored when disabled",
+			expectedNodeGroups: []string{"uniform-vmss"},
+		},
+		{
+			name:                "flexible scale sets without scaling profile are ignored",
+			enableVmssFlexNodes: true,
+			expectedNodeGroups:  []string{"flex-vmss", "uniform-vmss"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			manager := newTestAzureManager(t)
+			manager.config.EnableVmssFlexNodes = tc.enableVmssFlexNodes
+			expectedScaleSets := []compute.VirtualMachineScaleSet{flexVMSS, profilelessFlexVMSS, uniformVMSS}
+			mockVMSSClient := mockvmssclient.NewMockInterface(ctrl)
+			mockVMSSClient.EXPECT().List(gomock.Any(), manager.config.ResourceGroup).Return(expectedScaleSets, nil).AnyTimes()
+			manager.azClient.virtualMachineScaleSetsClient = mockVMSSClient
+			err := manager.forceRefresh()
+			assert.NoError(t, err)
+
+			asgs, err := manager.getFilteredNodeGroups(specs)
+			assert.NoError(t, err)
+			var names []string
+			for _, asg := range asgs {
+				names = append(names, asg.Id())
+			}
+			assert.ElementsMatch(t, tc.expectedNodeGroups, names)
+		})
+	}
+}
+
 func TestGetFilteredAutoscalingGroupsWithInvalidVMType(t *testing.T) {
 	originalEnv := saveAndClearEnv()
 	t.Cleanup(func() {
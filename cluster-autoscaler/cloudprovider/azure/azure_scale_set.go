@@ -240,6 +240,13 @@ func (scaleSet *ScaleSet) getCurSize() (int64, *GetVMSSFailedError) {
 		}
 	}
 
+	// Flexible scale sets without a scaling profile have no capacity and can't be scaled.
+	if set.Sku == nil || set.Sku.Capacity == nil {
+		err := fmt.Errorf("vmss %q has no capacity, flexible scale sets need a scaling profile to be autoscaled", scaleSet.Name)
+		klog.Errorf("failed to get size of VMSS: %s, error: %v", scaleSet.Name, err)
+		return -1, newGetVMSSFailedError(err, false)
+	}
+
 	vmssSizeMutex.Lock()
 	curSize := *set.Sku.Capacity
 	vmssSizeMutex.Unlock()
@@ -398,6 +405,39 @@ func (scaleSet *ScaleSet) GetFlexibleScaleSetVms() ([]compute.VirtualMachine, *r
 	return vmList, nil
 }
 
+// addFlexibleScaleSetVmsInstanceView sets the instance view of the given flexible scale set VMs, which isn't
+// returned by GetFlexibleScaleSetVms, so that their power state is known.
+func (scaleSet *ScaleSet) addFlexibleScaleSetVmsInstanceView(vms []compute.VirtualMachine) *retry.Error {
+	ctx, cancel := getContextWithTimeout(vmssContextTimeout)
+	defer cancel()
+
+	vmssInfo, err := scaleSet.getVMSSFromCache()
+	if err != nil {
+		return &retry.Error{RawError: err}
+	}
+	instanceViews, rerr := scaleSet.manager.azClient.virtualMachinesClient.ListVmssFlexVMsWithOnlyInstanceView(ctx, *vmssInfo.ID)
+	if rerr != nil {
+		klog.Errorf("VirtualMachinesClient.ListVmssFlexVMsWithOnlyInstanceView failed for %s: %v", scaleSet.Name, rerr)
+		return rerr
+	}
+
+	instanceViewByID := make(map[string]*compute.VirtualMachineInstanceView, len(instanceViews))
+	for _, vm := range instanceViews {
+		if vm.ID != nil && vm.VirtualMachineProperties != nil {
+			instanceViewByID[strings.ToLower(*vm.ID)] = vm.InstanceView
+		}
+	}
+	for i := range vms {
+		if vms[i].ID == nil || vms[i].VirtualMachineProperties == nil {
+			continue
+		}
+		if instanceView, found := instanceViewByID[strings.ToLower(*vms[i].ID)]; found {
+			vms[i].InstanceView = instanceView
+		}
+	}
+	return nil
+}
+
 // DecreaseTargetSize decreases the target size of the node group. This function
 // doesn't permit to delete any existing node and can be used only to reduce the
 // request for new nodes that have not been yet fulfilled. Delta should be negative.
@@ -716,6 +756,13 @@ func (scaleSet *ScaleSet) buildScaleSetCacheForFlex() error {
 		return rerr.Error()
 	}
 
+	// Power state is only needed to tell VMs that failed to provision from the ones that failed while running.
+	if scaleSet.enableFastDeleteOnFailedProvisioning {
+		if rerr := scaleSet.addFlexibleScaleSetVmsInstanceView(vms); rerr != nil {
+			klog.Warningf("Failed to get instance view of VMs in %s, their power state is unknown: %v", scaleSet.Name, rerr)
+		}
+	}
+
 	scaleSet.instanceCache = buildInstanceCacheForFlex(vms, scaleSet.enableFastDeleteOnFailedProvisioning)
 	scaleSet.lastInstanceRefresh = lastRefresh
 
@@ -790,7 +837,7 @@ func buildInstanceCacheForFlex(vms []compute.VirtualMachine, enableFastDeleteOnF
 // addVMToCache used by orchestrationMode == compute.Flexible
 func addVMToCache(instances *[]cloudprovider.Instance, id, provisioningState *string, powerState string, enableFastDeleteOnFailedProvisioning bool) {
 	// The resource ID is empty string, which indicates the instance may be in deleting state.
-	if len(*id) == 0 {
+	if id == nil || len(*id) == 0 {
 		return
 	}
 
@@ -32,6 +32,7 @@ import (
 	"sigs.k8s.io/cloud-provider-azure/pkg/azureclients/vmclient/mockvmclient"
 	"sigs.k8s.io/cloud-provider-azure/pkg/azureclients/vmssclient/mockvmssclient"
 	"sigs.k8s.io/cloud-provider-azure/pkg/azureclients/vmssvmclient/mockvmssvmclient"
+	"sigs.k8s.io/cloud-provider-azure/pkg/retry"
 )
 
 const (
@@ -1159,6 +1160,75 @@ func TestScaleSetNodes(t *testing.T) {
 
 }
 
+func TestFlexScaleSetNodesOnVMProvisioningFailedWithFastDelete(t *testing.T) {
+	testCases := map[string]struct {
+		statuses        *[]compute.InstanceViewStatus
+		instanceViewErr *retry.Error
+		expectedState   cloudprovider.InstanceState
+		expectedError   bool
+	}{
+		"failed provisioning with no power state is reported as a creation error": {
+			statuses:      &[]compute.InstanceViewStatus{{Code: to.StringPtr("ProvisioningState/failed/AllocationFailed")}},
+			expectedState: cloudprovider.InstanceCreating,
+			expectedError: true,
+		},
+		"failed provisioning of a running VM is reported as running": {
+			statuses:      &[]compute.InstanceViewStatus{{Code: to.StringPtr(vmPowerStateRunning)}},
+			expectedState: cloudprovider.InstanceRunning,
+		},
+		"failed provisioning with unknown instance view is reported as running": {
+			instanceViewErr: &retry.Error{RawError: fmt.Errorf("instance view unavailable")},
+			expectedState:   cloudprovider.InstanceRunning,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			expectedScaleSets := newTestVMSSList(3, "test-asg", "eastus", compute.Flexible)
+			expectedVMs := newTestVMList(3)
+			expectedVMs[2].ProvisioningState = to.StringPtr(provisioningStateFailed)
+			instanceViews := []compute.VirtualMachine{{
+				ID: expectedVMs[2].ID,
+				VirtualMachineProperties: &compute.VirtualMachineProperties{
+					InstanceView: &compute.VirtualMachineInstanceView{Statuses: testCase.statuses},
+				},
+			}}
+
+			provider := newTestProvider(t)
+			provider.azureManager.config.EnableVmssFlexNodes = true
+			mockVMSSClient := mockvmssclient.NewMockInterface(ctrl)
+			mockVMSSClient.EXPECT().List(gomock.Any(), provider.azureManager.config.ResourceGroup).Return(expectedScaleSets, nil).AnyTimes()
+			provider.azureManager.azClient.virtualMachineScaleSetsClient = mockVMSSClient
+			mockVMClient := mockvmclient.NewMockInterface(ctrl)
+			mockVMClient.EXPECT().List(gomock.Any(), provider.azureManager.config.ResourceGroup).Return([]compute.VirtualMachine{}, nil).AnyTimes()
+			mockVMClient.EXPECT().ListVmssFlexVMsWithoutInstanceView(gomock.Any(), "test-asg").Return(expectedVMs, nil).AnyTimes()
+			mockVMClient.EXPECT().ListVmssFlexVMsWithOnlyInstanceView(gomock.Any(), "test-asg").Return(instanceViews, testCase.instanceViewErr).AnyTimes()
+			provider.azureManager.azClient.virtualMachinesClient = mockVMClient
+
+			provider.azureManager.RegisterNodeGroup(newTestScaleSetWithFastDelete(provider.azureManager, "test-asg"))
+			provider.azureManager.explicitlyConfigured["test-asg"] = true
+			err := provider.azureManager.Refresh()
+			assert.NoError(t, err)
+
+			nodeGroups := provider.NodeGroups()
+			assert.Equal(t, 1, len(nodeGroups))
+			instances, err := nodeGroups[0].Nodes()
+			assert.NoError(t, err)
+			assert.Equal(t, 3, len(instances))
+			assert.Equal(t, testCase.expectedState, instances[2].Status.State)
+			if testCase.expectedError {
+				assert.NotNil(t, instances[2].Status.ErrorInfo)
+				assert.Equal(t, cloudprovider.OutOfResourcesErrorClass, instances[2].Status.ErrorInfo.ErrorClass)
+			} else {
+				assert.Nil(t, instances[2].Status.ErrorInfo)
+			}
+		})
+	}
+}
+
 func TestScaleSetEnableVmssFlexNodesFlag(t *testing.T) {
 
 	// flag set to false
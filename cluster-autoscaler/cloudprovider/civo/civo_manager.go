@@ -54,6 +54,8 @@ type Manager struct {
 	clusterID     string
 	nodeGroups    []*NodeGroup
 	discoveryOpts cloudprovider.NodeGroupDiscoveryOptions
+	// autoDiscoveryConfigs select the node pools to scale by their labels.
+	autoDiscoveryConfigs []dynamic.LabelAutoDiscoveryConfig
 }
 
 // Config is the configuration of the Civo cloud provider
@@ -103,16 +105,27 @@ func newManager(configReader io.Reader, discoveryOpts cloudprovider.NodeGroupDis
 
 	Region = cfg.Region
 
+	autoDiscoveryConfigs, err := dynamic.ParseLabelAutoDiscoverySpecs(discoveryOpts.NodeGroupAutoDiscoverySpecs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse node group auto discovery specs: %v", err)
+	}
+	for _, c := range autoDiscoveryConfigs {
+		if c.MinSize == nil || c.MaxSize == nil {
+			return nil, fmt.Errorf("min and max sizes are required in node group auto discovery specs")
+		}
+	}
+
 	civoClient, err := civocloud.NewClientWithURL(cfg.ApiKey, cfg.ApiURL, cfg.Region)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't initialize Civo client: %s", err)
 	}
 
 	m := &Manager{
-		client:        civoClient,
-		clusterID:     cfg.ClusterID,
-		nodeGroups:    make([]*NodeGroup, 0),
-		discoveryOpts: discoveryOpts,
+		client:               civoClient,
+		clusterID:            cfg.ClusterID,
+		nodeGroups:           make([]*NodeGroup, 0),
+		discoveryOpts:        discoveryOpts,
+		autoDiscoveryConfigs: autoDiscoveryConfigs,
 	}
 
 	return m, nil
@@ -158,7 +171,11 @@ func (m *Manager) Refresh() error {
 		}
 	}
 
-	if poolConfigFound {
+	if len(m.autoDiscoveryConfigs) > 0 {
+		poolGroups = append(poolGroups, m.getAutoDiscoveredNodeGroups(poolGroups, pools)...)
+	}
+
+	if poolConfigFound || len(m.autoDiscoveryConfigs) > 0 {
 		m.nodeGroups = poolGroups
 	} else if workerConfigFound {
 		for _, nodePool := range pools {
@@ -213,6 +230,44 @@ func (m *Manager) getNodeGroupConfig(spec *dynamic.NodeGroupSpec, pools []civocl
 	return nil
 }
 
+// getAutoDiscoveredNodeGroups returns the node groups of the cluster pools selected by their labels, except
+// for the ones configured explicitly.
+func (m *Manager) getAutoDiscoveredNodeGroups(configured []*NodeGroup, pools []civocloud.KubernetesPool) []*NodeGroup {
+	var nodeGroups []*NodeGroup
+	for _, nodePool := range pools {
+		if containsNodeGroup(configured, nodePool.ID) {
+			continue
+		}
+		config := dynamic.MatchLabelAutoDiscoveryConfigs(m.autoDiscoveryConfigs, nodePool.Labels)
+		if config == nil {
+			continue
+		}
+		np := nodePool
+		minSize, maxSize := config.Sizes(0, 0)
+		klog.V(4).Infof("auto discovered node pool: %q min: %d max: %d", nodePool.ID, minSize, maxSize)
+
+		nodeGroups = append(nodeGroups, &NodeGroup{
+			id:           nodePool.ID,
+			clusterID:    m.clusterID,
+			client:       m.client,
+			nodePool:     &np,
+			minSize:      minSize,
+			maxSize:      maxSize,
+			nodeTemplate: getCivoNodeTemplate(nodePool, m.client),
+		})
+	}
+	return nodeGroups
+}
+
+func containsNodeGroup(nodeGroups []*NodeGroup, id string) bool {
+	for _, ng := range nodeGroups {
+		if ng.id == id {
+			return true
+		}
+	}
+	return false
+}
+
 // getCivoNodeTemplate returns the CivoNodeTemplate for the given node pool
 func getCivoNodeTemplate(pool civocloud.KubernetesPool, client nodeGroupClient) *CivoNodeTemplate {
 	template := &CivoNodeTemplate{}
@@ -258,3 +258,54 @@ func TestCivoManager_RefreshWithNodeSpecPool(t *testing.T) {
 		assert.Equal(t, 10, manager.nodeGroups[1].maxSize, "maximum node for node group does not match")
 	})
 }
+
+func TestCivoManager_RefreshWithAutoDiscovery(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		cfg := `{"cluster_id": "123456", "api_key": "123-123-123", "api_url": "https://api.civo.com", "region": "test"}`
+		nodeGroupDiscoveryOptions := cloudprovider.NodeGroupDiscoveryOptions{
+			NodeGroupSpecs:              []string{"2:3:pool-1"},
+			NodeGroupAutoDiscoverySpecs: []string{"label:autoscale=true,min=0,max=10"},
+		}
+		manager, err := newManager(bytes.NewBufferString(cfg), nodeGroupDiscoveryOptions)
+		assert.NoError(t, err)
+
+		client := &civoClientMock{}
+
+		client.On("ListKubernetesClusterPools", manager.clusterID).Return(
+			[]civocloud.KubernetesPool{
+				{ID: "pool-1", Count: 2, Size: "small", Labels: map[string]string{"autoscale": "true"}},
+				{ID: "pool-2", Count: 1, Size: "small", Labels: map[string]string{"autoscale": "true", "gpu": "true"}},
+				{ID: "pool-3", Count: 1, Size: "small", Labels: map[string]string{"autoscale": "false"}},
+				{ID: "pool-4", Count: 1, Size: "small"},
+			},
+			nil,
+		).Once()
+
+		client.On("FindInstanceSizes", "small").Return(
+			&civocloud.InstanceSize{
+				Name:     "small",
+				CPUCores: 1,
+			}, nil,
+		)
+
+		manager.client = client
+		err = manager.Refresh()
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(manager.nodeGroups), "number of node groups do not match")
+		assert.Equal(t, "pool-1", manager.nodeGroups[0].id, "explicitly configured node group does not match")
+		assert.Equal(t, 2, manager.nodeGroups[0].minSize, "minimum node for node group does not match")
+		assert.Equal(t, 3, manager.nodeGroups[0].maxSize, "maximum node for node group does not match")
+		assert.Equal(t, "pool-2", manager.nodeGroups[1].id, "auto discovered node group does not match")
+		assert.Equal(t, 0, manager.nodeGroups[1].minSize, "minimum node for node group does not match")
+		assert.Equal(t, 10, manager.nodeGroups[1].maxSize, "maximum node for node group does not match")
+	})
+
+	t.Run("missing sizes", func(t *testing.T) {
+		cfg := `{"cluster_id": "123456", "api_key": "123-123-123", "api_url": "https://api.civo.com", "region": "test"}`
+		nodeGroupDiscoveryOptions := cloudprovider.NodeGroupDiscoveryOptions{
+			NodeGroupAutoDiscoverySpecs: []string{"label:autoscale=true"},
+		}
+		_, err := newManager(bytes.NewBufferString(cfg), nodeGroupDiscoveryOptions)
+		assert.Error(t, err)
+	})
+}
@@ -164,6 +164,11 @@ var ErrIllegalConfiguration = errors.NewAutoscalerError(errors.InternalError, "C
 
 // NodeGroup contains configuration info and functions to control a set
 // of nodes that have the same capacity and set of labels.
+//
+// With --binpacking-parallelism greater than 1, Id, MaxSize, TargetSize and
+// WeightedTemplateNodeInfos of MixedTemplatesNodeGroup may be called
+// concurrently during a scale-up simulation, so they must be safe for
+// concurrent use.
 type NodeGroup interface {
 	// MaxSize returns maximum size of the node group.
 	MaxSize() int
@@ -376,7 +376,16 @@ func (ng *nodegroup) TemplateNodeInfo() (*framework.NodeInfo, error) {
 }
 
 func (ng *nodegroup) buildTemplateLabels(nodeName string) (map[string]string, error) {
-	labels := cloudprovider.JoinStringMaps(buildGenericLabels(nodeName), ng.scalableResource.Labels())
+	labels := buildGenericLabels(nodeName)
+	if systemInfo := ng.scalableResource.InstanceSystemInfo(); systemInfo != nil {
+		if arch := SystemArchitectureFromString(systemInfo.Architecture); arch != UnknownArch {
+			labels[corev1.LabelArchStable] = arch.Name()
+		}
+		if systemInfo.OperatingSystem != "" {
+			labels[corev1.LabelOSStable] = systemInfo.OperatingSystem
+		}
+	}
+	labels = cloudprovider.JoinStringMaps(labels, ng.scalableResource.Labels())
 
 	nodes, err := ng.Nodes()
 	if err != nil {
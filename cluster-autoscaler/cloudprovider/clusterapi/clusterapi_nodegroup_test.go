@@ -1517,6 +1517,30 @@ func TestNodeGroupTemplateNodeInfo(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "When the NodeGroup can scale from zero, the hugepages and extended resources capacity annotations are included",
+			nodeGroupAnnotations: map[string]string{
+				memoryKey:                  "2048Mi",
+				cpuKey:                     "2",
+				hugepagesKeyPrefix + "2Mi": "512Mi",
+				extendedResourcesKey:       "example.com/fpga=2",
+			},
+			config: testCaseConfig{
+				expectedErr: nil,
+				expectedCapacity: map[corev1.ResourceName]int64{
+					corev1.ResourceCPU:    2,
+					corev1.ResourceMemory: 2048 * 1024 * 1024,
+					corev1.ResourcePods:   110,
+					"hugepages-2Mi":       512 * 1024 * 1024,
+					"example.com/fpga":    2,
+				},
+				expectedNodeLabels: map[string]string{
+					"kubernetes.io/os":       "linux",
+					"kubernetes.io/arch":     "amd64",
+					"kubernetes.io/hostname": "random value",
+				},
+			},
+		},
 		{
 			name: "When the NodeGroup can scale from zero and the Node still exists, it includes the known node labels",
 			nodeGroupAnnotations: map[string]string{
@@ -1645,6 +1669,56 @@ func TestNodeGroupTemplateNodeInfo(t *testing.T) {
 
 }
 
+func TestNodeGroupTemplateNodeInfoSystemInfo(t *testing.T) {
+	annotations := map[string]string{
+		nodeGroupMinSizeAnnotationKey: "0",
+		nodeGroupMaxSizeAnnotationKey: "10",
+		labelsKey:                     "my-custom-label=custom-value",
+	}
+	capacity := map[string]string{
+		cpuStatusKey:    "2",
+		memoryStatusKey: "4G",
+	}
+
+	test := func(t *testing.T, testConfig *testConfig) {
+		testConfig.nodes = []*corev1.Node{}
+		if err := unstructured.SetNestedStringMap(testConfig.machineTemplate.Object, map[string]string{
+			"architecture":    "arm64",
+			"operatingSystem": "windows",
+		}, "status", "nodeInfo"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		controller, stop := mustCreateTestController(t, testConfig)
+		defer stop()
+
+		nodegroups, err := controller.nodeGroups()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if l := len(nodegroups); l != 1 {
+			t.Fatalf("expected 1 nodegroup, got %d", l)
+		}
+
+		nodeInfo, err := nodegroups[0].TemplateNodeInfo()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		labels := nodeInfo.Node().GetLabels()
+		assert.Equal(t, "arm64", labels[corev1.LabelArchStable])
+		assert.Equal(t, "windows", labels[corev1.LabelOSStable])
+		assert.Equal(t, "custom-value", labels["my-custom-label"])
+	}
+
+	t.Run("MachineSet", func(t *testing.T) {
+		test(t, createMachineSetTestConfig(testNamespace, RandomString(6), RandomString(6), 0, annotations, capacity))
+	})
+
+	t.Run("MachineDeployment", func(t *testing.T) {
+		test(t, createMachineDeploymentTestConfig(testNamespace, RandomString(6), RandomString(6), 0, annotations, capacity))
+	})
+}
+
 func TestNodeGroupGetOptions(t *testing.T) {
 	enableScaleAnnotations := map[string]string{
 		nodeGroupMinSizeAnnotationKey: "1",
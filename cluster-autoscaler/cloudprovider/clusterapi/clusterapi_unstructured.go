@@ -265,6 +265,22 @@ func (r unstructuredScalableResource) InstanceCapacity() (map[corev1.ResourceNam
 		capacityAnnotations[corev1.ResourceName(gpuType)] = gpuCount
 	}
 
+	hugepages, err := r.InstanceHugepagesCapacityAnnotation()
+	if err != nil {
+		return nil, err
+	}
+	for name, quantity := range hugepages {
+		capacityAnnotations[name] = quantity
+	}
+
+	extendedResources, err := r.InstanceExtendedResourcesCapacityAnnotation()
+	if err != nil {
+		return nil, err
+	}
+	for name, quantity := range extendedResources {
+		capacityAnnotations[name] = quantity
+	}
+
 	maxPods, err := r.InstanceMaxPodsCapacityAnnotation()
 	if err != nil {
 		return nil, err
@@ -325,6 +341,34 @@ func (r unstructuredScalableResource) InstanceMaxPodsCapacityAnnotation() (resou
 	return parseMaxPodsCapacity(r.unstructured.GetAnnotations())
 }
 
+func (r unstructuredScalableResource) InstanceHugepagesCapacityAnnotation() (map[corev1.ResourceName]resource.Quantity, error) {
+	return parseHugepagesCapacity(r.unstructured.GetAnnotations())
+}
+
+func (r unstructuredScalableResource) InstanceExtendedResourcesCapacityAnnotation() (map[corev1.ResourceName]resource.Quantity, error) {
+	return parseExtendedResourcesCapacity(r.unstructured.GetAnnotations())
+}
+
+// InstanceSystemInfo returns the architecture and operating system of the nodes, as reported
+// in the status.nodeInfo field of the machine template infrastructure resource. Returns nil
+// if the infrastructure provider doesn't report them.
+func (r unstructuredScalableResource) InstanceSystemInfo() *corev1.NodeSystemInfo {
+	infraObj, err := r.readInfrastructureReferenceResource()
+	if err != nil || infraObj == nil {
+		return nil
+	}
+
+	nodeInfo, found, err := unstructured.NestedStringMap(infraObj.Object, "status", "nodeInfo")
+	if !found || err != nil {
+		return nil
+	}
+
+	return &corev1.NodeSystemInfo{
+		Architecture:    nodeInfo["architecture"],
+		OperatingSystem: nodeInfo["operatingSystem"],
+	}
+}
+
 func (r unstructuredScalableResource) readInfrastructureReferenceResource() (*unstructured.Unstructured, error) {
 	infraref, found, err := unstructured.NestedStringMap(r.unstructured.Object, "spec", "template", "spec", "infrastructureRef")
 	if !found || err != nil {
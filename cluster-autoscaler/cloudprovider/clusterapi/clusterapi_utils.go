@@ -26,6 +26,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -40,6 +41,12 @@ const (
 	maxPodsKey      = "capacity.cluster-autoscaler.kubernetes.io/maxPods"
 	taintsKey       = "capacity.cluster-autoscaler.kubernetes.io/taints"
 	labelsKey       = "capacity.cluster-autoscaler.kubernetes.io/labels"
+	// hugepagesKeyPrefix is the prefix of the annotations holding the hugepages capacity by page size,
+	// e.g. capacity.cluster-autoscaler.kubernetes.io/hugepages-2Mi.
+	hugepagesKeyPrefix = "capacity.cluster-autoscaler.kubernetes.io/hugepages-"
+	// extendedResourcesKey is the annotation holding the capacity of extended resources, in the
+	// form "example.com/resource1=1,example.com/resource2=2".
+	extendedResourcesKey = "capacity.cluster-autoscaler.kubernetes.io/extended-resources"
 	// UnknownArch is used if the Architecture is Unknown
 	UnknownArch SystemArchitecture = ""
 	// Amd64 is used if the Architecture is x86_64
@@ -282,6 +289,52 @@ func parseMaxPodsCapacity(annotations map[string]string) (resource.Quantity, err
 	return parseIntKey(annotations, maxPodsKey)
 }
 
+// parseHugepagesCapacity returns the hugepages capacity by page size, keyed by the
+// hugepages resource name (e.g. hugepages-2Mi).
+func parseHugepagesCapacity(annotations map[string]string) (map[corev1.ResourceName]resource.Quantity, error) {
+	capacity := map[corev1.ResourceName]resource.Quantity{}
+	for key := range annotations {
+		if !strings.HasPrefix(key, hugepagesKeyPrefix) {
+			continue
+		}
+		pageSize := strings.TrimPrefix(key, hugepagesKeyPrefix)
+		if _, err := resource.ParseQuantity(pageSize); err != nil {
+			return nil, fmt.Errorf("invalid hugepages size in annotation %q: %v", key, err)
+		}
+		resourceName := corev1.ResourceName(corev1.ResourceHugePagesPrefix + pageSize)
+		quantity, err := parseKey(annotations, key)
+		if err != nil {
+			return nil, fmt.Errorf("value %q from annotation %q expected to be a quantity: %v", annotations[key], key, err)
+		}
+		if !quantity.IsZero() {
+			capacity[resourceName] = quantity
+		}
+	}
+	return capacity, nil
+}
+
+// parseExtendedResourcesCapacity returns the capacity of the extended resources, from an
+// annotation value of the form "example.com/resource1=1,example.com/resource2=2".
+func parseExtendedResourcesCapacity(annotations map[string]string) (map[corev1.ResourceName]resource.Quantity, error) {
+	capacity := map[corev1.ResourceName]resource.Quantity{}
+	val, found := annotations[extendedResourcesKey]
+	if !found || val == "" {
+		return capacity, nil
+	}
+	for _, extendedResource := range strings.Split(val, ",") {
+		split := strings.SplitN(extendedResource, "=", 2)
+		if len(split) != 2 || split[0] == "" {
+			return nil, fmt.Errorf("invalid extended resource %q from annotation %q, expected the form name=quantity", extendedResource, extendedResourcesKey)
+		}
+		quantity, err := resource.ParseQuantity(split[1])
+		if err != nil {
+			return nil, fmt.Errorf("value %q of extended resource %q expected to be a quantity: %v", split[1], split[0], err)
+		}
+		capacity[corev1.ResourceName(split[0])] = quantity
+	}
+	return capacity, nil
+}
+
 func clusterNameFromResource(r *unstructured.Unstructured) string {
 	// Use Spec.ClusterName if defined (only available on v1alpha3+ types)
 	clusterName, found, err := unstructured.NestedString(r.Object, "spec", "clusterName")
@@ -24,6 +24,7 @@ import (
 	"sync"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -622,6 +623,96 @@ func TestParseMaxPodsCapacity(t *testing.T) {
 	}
 }
 
+func TestParseHugepagesCapacity(t *testing.T) {
+	for _, tc := range []struct {
+		description      string
+		annotations      map[string]string
+		expectedCapacity map[corev1.ResourceName]resource.Quantity
+		expectedError    bool
+	}{{
+		description:      "nil annotations",
+		expectedCapacity: map[corev1.ResourceName]resource.Quantity{},
+	}, {
+		description: "valid quantities",
+		annotations: map[string]string{
+			hugepagesKeyPrefix + "2Mi": "512Mi",
+			hugepagesKeyPrefix + "1Gi": "2Gi",
+			cpuKey:                     "2",
+		},
+		expectedCapacity: map[corev1.ResourceName]resource.Quantity{
+			"hugepages-2Mi": resource.MustParse("512Mi"),
+			"hugepages-1Gi": resource.MustParse("2Gi"),
+		},
+	}, {
+		description:   "bad page size",
+		annotations:   map[string]string{hugepagesKeyPrefix + "large": "2Gi"},
+		expectedError: true,
+	}, {
+		description:   "bad quantity",
+		annotations:   map[string]string{hugepagesKeyPrefix + "2Mi": "not-a-quantity"},
+		expectedError: true,
+	}} {
+		t.Run(tc.description, func(t *testing.T) {
+			got, err := parseHugepagesCapacity(tc.annotations)
+			if tc.expectedError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tc.expectedCapacity, got) {
+				t.Errorf("expected %v, got %v", tc.expectedCapacity, got)
+			}
+		})
+	}
+}
+
+func TestParseExtendedResourcesCapacity(t *testing.T) {
+	for _, tc := range []struct {
+		description      string
+		annotations      map[string]string
+		expectedCapacity map[corev1.ResourceName]resource.Quantity
+		expectedError    bool
+	}{{
+		description:      "nil annotations",
+		expectedCapacity: map[corev1.ResourceName]resource.Quantity{},
+	}, {
+		description: "valid quantities",
+		annotations: map[string]string{extendedResourcesKey: "example.com/fpga=2,example.com/dongle=1"},
+		expectedCapacity: map[corev1.ResourceName]resource.Quantity{
+			"example.com/fpga":   resource.MustParse("2"),
+			"example.com/dongle": resource.MustParse("1"),
+		},
+	}, {
+		description:   "missing quantity",
+		annotations:   map[string]string{extendedResourcesKey: "example.com/fpga"},
+		expectedError: true,
+	}, {
+		description:   "bad quantity",
+		annotations:   map[string]string{extendedResourcesKey: "example.com/fpga=not-a-quantity"},
+		expectedError: true,
+	}} {
+		t.Run(tc.description, func(t *testing.T) {
+			got, err := parseExtendedResourcesCapacity(tc.annotations)
+			if tc.expectedError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tc.expectedCapacity, got) {
+				t.Errorf("expected %v, got %v", tc.expectedCapacity, got)
+			}
+		})
+	}
+}
+
 func Test_clusterNameFromResource(t *testing.T) {
 	for _, tc := range []struct {
 		name     string
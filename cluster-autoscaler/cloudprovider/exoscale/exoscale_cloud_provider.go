@@ -24,7 +24,6 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	egoscale "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/exoscale/internal/github.com/exoscale/egoscale/v2"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
-	"k8s.io/autoscaler/cluster-autoscaler/config/dynamic"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
 )
@@ -99,38 +98,9 @@ func (e *exoscaleCloudProvider) NodeGroupForNode(node *apiv1.Node) (cloudprovide
 			)
 		}
 
-		// nodeGroupSpec contains the configuration spec from the '--nodes' flag
-		// which includes the min and max size of the node group.
-		var nodeGroupSpec *dynamic.NodeGroupSpec
-		for _, spec := range e.manager.discoveryOpts.NodeGroupSpecs {
-			s, err := dynamic.SpecFromString(spec, scaleToZeroSupported)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse node group spec: %v", err)
-			}
-
-			if s.Name == *sksNodepool.Name {
-				nodeGroupSpec = s
-				break
-			}
-		}
-		var minSize, maxSize int
-		if nodeGroupSpec != nil {
-			minSize = nodeGroupSpec.MinSize
-			maxSize = nodeGroupSpec.MaxSize
-		} else {
-			minSize = 1
-			maxSize, err = e.manager.computeInstanceQuota()
-			if err != nil {
-				return nil, err
-			}
-		}
-
-		nodeGroup = &sksNodepoolNodeGroup{
-			sksNodepool: sksNodepool,
-			sksCluster:  sksCluster,
-			m:           e.manager,
-			minSize:     minSize,
-			maxSize:     maxSize,
+		nodeGroup, err = e.manager.newSKSNodepoolNodeGroup(sksCluster, sksNodepool)
+		if err != nil {
+			return nil, err
 		}
 		debugf("found node %s belonging to SKS Nodepool %s", toNodeID(node.Spec.ProviderID), *sksNodepool.ID)
 	} else {
@@ -86,6 +86,11 @@ func (m *exoscaleClientMock) GetInstancePool(ctx context.Context, zone, id strin
 	return args.Get(0).(*egoscale.InstancePool), args.Error(1)
 }
 
+func (m *exoscaleClientMock) GetInstanceType(ctx context.Context, zone, id string) (*egoscale.InstanceType, error) {
+	args := m.Called(ctx, zone, id)
+	return args.Get(0).(*egoscale.InstanceType), args.Error(1)
+}
+
 func (m *exoscaleClientMock) GetQuota(ctx context.Context, zone string, resource string) (*egoscale.Quota, error) {
 	args := m.Called(ctx, zone, resource)
 	return args.Get(0).(*egoscale.Quota), args.Error(1)
@@ -25,6 +25,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	egoscale "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/exoscale/internal/github.com/exoscale/egoscale/v2"
 	exoapi "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/exoscale/internal/github.com/exoscale/egoscale/v2/api"
+	"k8s.io/autoscaler/cluster-autoscaler/config/dynamic"
 )
 
 type exoscaleClient interface {
@@ -32,6 +33,7 @@ type exoscaleClient interface {
 	EvictSKSNodepoolMembers(context.Context, string, *egoscale.SKSCluster, *egoscale.SKSNodepool, []string) error
 	GetInstance(context.Context, string, string) (*egoscale.Instance, error)
 	GetInstancePool(context.Context, string, string) (*egoscale.InstancePool, error)
+	GetInstanceType(context.Context, string, string) (*egoscale.InstanceType, error)
 	GetQuota(context.Context, string, string) (*egoscale.Quota, error)
 	ListSKSClusters(context.Context, string) ([]*egoscale.SKSCluster, error)
 	ScaleInstancePool(context.Context, string, *egoscale.InstancePool, int64) error
@@ -110,6 +112,10 @@ func (m *Manager) Refresh() error {
 	}
 	m.nodeGroups = nodeGroups
 
+	if err := m.discoverSKSNodepools(); err != nil {
+		return err
+	}
+
 	if len(m.nodeGroups) == 0 {
 		infof("cluster-autoscaler is disabled: no node groups found")
 	}
@@ -117,6 +123,94 @@ func (m *Manager) Refresh() error {
 	return nil
 }
 
+// discoverSKSNodepools adds the SKS Nodepools specified via the `--nodes` flag
+// which aren't known yet to the node groups. Other node groups are discovered
+// from the nodes belonging to them, so this allows scaling up Nodepools from 0.
+func (m *Manager) discoverSKSNodepools() error {
+	specs, err := m.nodeGroupSpecs()
+	if err != nil {
+		return err
+	}
+	for _, ng := range m.nodeGroups {
+		if sksNodepoolNodeGroup, ok := ng.(*sksNodepoolNodeGroup); ok {
+			delete(specs, *sksNodepoolNodeGroup.sksNodepool.Name)
+		}
+	}
+	if len(specs) == 0 {
+		return nil
+	}
+
+	sksClusters, err := m.client.ListSKSClusters(m.ctx, m.zone)
+	if err != nil {
+		errorf("unable to list SKS clusters: %v", err)
+		return err
+	}
+	for _, c := range sksClusters {
+		for _, n := range c.Nodepools {
+			if _, ok := specs[*n.Name]; !ok || n.InstancePoolID == nil {
+				continue
+			}
+			nodeGroup, err := m.newSKSNodepoolNodeGroup(c, n)
+			if err != nil {
+				return err
+			}
+			debugf("discovered SKS Nodepool %s from node group specs", *n.ID)
+			m.nodeGroups = append(m.nodeGroups, nodeGroup)
+			delete(specs, *n.Name)
+		}
+	}
+
+	return nil
+}
+
+// nodeGroupSpecs returns the node group specs from the `--nodes` flag, by name.
+func (m *Manager) nodeGroupSpecs() (map[string]*dynamic.NodeGroupSpec, error) {
+	specs := make(map[string]*dynamic.NodeGroupSpec, len(m.discoveryOpts.NodeGroupSpecs))
+	for _, spec := range m.discoveryOpts.NodeGroupSpecs {
+		s, err := dynamic.SpecFromString(spec, scaleToZeroSupported)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse node group spec: %v", err)
+		}
+		if _, ok := specs[s.Name]; !ok {
+			specs[s.Name] = s
+		}
+	}
+
+	return specs, nil
+}
+
+// newSKSNodepoolNodeGroup returns a node group for the given SKS Nodepool, sized
+// according to its spec from the `--nodes` flag if any.
+func (m *Manager) newSKSNodepoolNodeGroup(
+	sksCluster *egoscale.SKSCluster,
+	sksNodepool *egoscale.SKSNodepool,
+) (*sksNodepoolNodeGroup, error) {
+	specs, err := m.nodeGroupSpecs()
+	if err != nil {
+		return nil, err
+	}
+
+	var minSize, maxSize int
+	if nodeGroupSpec, ok := specs[*sksNodepool.Name]; ok {
+		minSize = nodeGroupSpec.MinSize
+		maxSize = nodeGroupSpec.MaxSize
+	} else {
+		minSize = 1
+		maxSize, err = m.computeInstanceQuota()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &sksNodepoolNodeGroup{
+		sksNodepool: sksNodepool,
+		sksCluster:  sksCluster,
+		m:           m,
+		minSize:     minSize,
+		maxSize:     maxSize,
+	}, nil
+}
+
 func (m *Manager) computeInstanceQuota() (int, error) {
 	instanceQuota, err := m.client.GetQuota(m.ctx, m.zone, "instance")
 	if err != nil {
@@ -52,3 +52,43 @@ func (ts *cloudProviderTestSuite) TestComputeInstanceQuota() {
 	ts.Require().NoError(err)
 	ts.Require().Equal(int(testComputeInstanceQuotaLimit), actual)
 }
+
+func (ts *cloudProviderTestSuite) TestRefresh_DiscoverSKSNodepools() {
+	ts.p.manager.discoveryOpts.NodeGroupSpecs = []string{"0:5:" + testSKSNodepoolName}
+
+	ts.p.manager.client.(*exoscaleClientMock).
+		On("ListSKSClusters", ts.p.manager.ctx, ts.p.manager.zone).
+		Return(
+			[]*egoscale.SKSCluster{{
+				ID:   &testSKSClusterID,
+				Name: &testSKSClusterName,
+				Nodepools: []*egoscale.SKSNodepool{{
+					ID:             &testSKSNodepoolID,
+					InstancePoolID: &testInstancePoolID,
+					Name:           &testSKSNodepoolName,
+				}},
+			}},
+			nil,
+		).
+		Once()
+
+	ts.p.manager.client.(*exoscaleClientMock).
+		On("GetInstancePool", ts.p.manager.ctx, ts.p.manager.zone, testInstancePoolID).
+		Return(
+			&egoscale.InstancePool{
+				ID:   &testInstancePoolID,
+				Name: &testInstancePoolName,
+			},
+			nil,
+		)
+
+	ts.Require().NoError(ts.p.manager.Refresh())
+	ts.Require().Len(ts.p.manager.nodeGroups, 1)
+	ts.Require().Equal(testInstancePoolID, ts.p.manager.nodeGroups[0].Id())
+	ts.Require().Equal(0, ts.p.manager.nodeGroups[0].MinSize())
+	ts.Require().Equal(5, ts.p.manager.nodeGroups[0].MaxSize())
+
+	// Known Nodepools aren't listed again.
+	ts.Require().NoError(ts.p.manager.Refresh())
+	ts.Require().Len(ts.p.manager.nodeGroups, 1)
+}
@@ -19,17 +19,25 @@ package exoscale
 import (
 	"context"
 	"fmt"
+	"math/rand"
+	"sort"
 	"sync"
 
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	egoscale "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/exoscale/internal/github.com/exoscale/egoscale/v2"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
 )
 
 const (
-	scaleToZeroSupported = false
+	scaleToZeroSupported = true
+
+	// sksNodeMaxPods is the maximum number of Pods per node set by SKS.
+	sksNodeMaxPods = 110
 )
 
 // sksNodepoolNodeGroup implements cloudprovider.NodeGroup interface for Exoscale SKS Nodepools.
@@ -39,6 +47,10 @@ type sksNodepoolNodeGroup struct {
 
 	m *Manager
 
+	// instanceType is the Compute instance type of the Nodepool members,
+	// retrieved when building the template node for the first time.
+	instanceType *egoscale.InstanceType
+
 	sync.Mutex
 
 	minSize int
@@ -199,7 +211,92 @@ func (n *sksNodepoolNodeGroup) Nodes() ([]cloudprovider.Instance, error) {
 // capacity and allocatable information as well as all pods that are started on
 // the node by default, using manifest (most likely only kube-proxy). Implementation optional.
 func (n *sksNodepoolNodeGroup) TemplateNodeInfo() (*framework.NodeInfo, error) {
-	return nil, cloudprovider.ErrNotImplemented
+	if n.sksNodepool.InstanceTypeID == nil {
+		return nil, fmt.Errorf("unknown instance type of SKS Nodepool %s", *n.sksNodepool.ID)
+	}
+
+	if n.instanceType == nil || *n.instanceType.ID != *n.sksNodepool.InstanceTypeID {
+		instanceType, err := n.m.client.GetInstanceType(n.m.ctx, n.m.zone, *n.sksNodepool.InstanceTypeID)
+		if err != nil {
+			errorf("unable to retrieve Compute instance type %s: %v", *n.sksNodepool.InstanceTypeID, err)
+			return nil, err
+		}
+		n.instanceType = instanceType
+	}
+
+	nodeName := fmt.Sprintf("%s-template-%d", *n.sksNodepool.Name, rand.Int63())
+	node := apiv1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   nodeName,
+			Labels: n.templateLabels(nodeName),
+		},
+		Spec: apiv1.NodeSpec{
+			Taints: n.templateTaints(),
+		},
+		Status: apiv1.NodeStatus{
+			Capacity:   apiv1.ResourceList{},
+			Conditions: cloudprovider.BuildReadyConditions(),
+		},
+	}
+
+	if n.instanceType.CPUs != nil {
+		node.Status.Capacity[apiv1.ResourceCPU] = *resource.NewQuantity(*n.instanceType.CPUs, resource.DecimalSI)
+	}
+	if n.instanceType.Memory != nil {
+		node.Status.Capacity[apiv1.ResourceMemory] = *resource.NewQuantity(*n.instanceType.Memory, resource.BinarySI)
+	}
+	if n.instanceType.GPUs != nil && *n.instanceType.GPUs > 0 {
+		node.Status.Capacity[gpu.ResourceNvidiaGPU] = *resource.NewQuantity(*n.instanceType.GPUs, resource.DecimalSI)
+	}
+	if n.sksNodepool.DiskSize != nil {
+		node.Status.Capacity[apiv1.ResourceEphemeralStorage] = *resource.NewQuantity(*n.sksNodepool.DiskSize<<30, resource.BinarySI)
+	}
+	node.Status.Capacity[apiv1.ResourcePods] = *resource.NewQuantity(sksNodeMaxPods, resource.DecimalSI)
+	node.Status.Allocatable = node.Status.Capacity.DeepCopy()
+
+	return framework.NewNodeInfo(&node, nil, &framework.PodInfo{Pod: cloudprovider.BuildKubeProxy(*n.sksNodepool.Name)}), nil
+}
+
+// templateLabels returns the labels of the template node, the well-known
+// labels set on SKS nodes merged with the Nodepool labels.
+func (n *sksNodepoolNodeGroup) templateLabels(nodeName string) map[string]string {
+	labels := map[string]string{
+		apiv1.LabelOSStable:           cloudprovider.DefaultOS,
+		apiv1.LabelArchStable:         cloudprovider.DefaultArch,
+		apiv1.LabelHostname:           nodeName,
+		apiv1.LabelTopologyRegion:     n.m.zone,
+		apiv1.LabelInstanceTypeStable: fmt.Sprintf("%s.%s", *n.instanceType.Family, *n.instanceType.Size),
+	}
+	if n.sksNodepool.Labels != nil {
+		for k, v := range *n.sksNodepool.Labels {
+			labels[k] = v
+		}
+	}
+
+	return labels
+}
+
+// templateTaints returns the taints of the template node, set on SKS nodes
+// from the Nodepool taints. Taints with an unknown effect are ignored.
+func (n *sksNodepoolNodeGroup) templateTaints() []apiv1.Taint {
+	if n.sksNodepool.Taints == nil {
+		return nil
+	}
+
+	taints := make([]apiv1.Taint, 0, len(*n.sksNodepool.Taints))
+	for key, t := range *n.sksNodepool.Taints {
+		effect := apiv1.TaintEffect(t.Effect)
+		switch effect {
+		case apiv1.TaintEffectNoSchedule, apiv1.TaintEffectPreferNoSchedule, apiv1.TaintEffectNoExecute:
+		default:
+			infof("ignoring taint %s of SKS Nodepool %s with unknown effect %q", key, *n.sksNodepool.ID, t.Effect)
+			continue
+		}
+		taints = append(taints, apiv1.Taint{Key: key, Value: t.Value, Effect: effect})
+	}
+	sort.Slice(taints, func(i, j int) bool { return taints[i].Key < taints[j].Key })
+
+	return taints
 }
 
 // Exist checks if the node group really exists on the cloud provider side. Allows to tell the
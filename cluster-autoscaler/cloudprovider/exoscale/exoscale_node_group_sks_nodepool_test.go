@@ -19,8 +19,10 @@ package exoscale
 import (
 	"github.com/stretchr/testify/mock"
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	egoscale "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/exoscale/internal/github.com/exoscale/egoscale/v2"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
 )
 
 func (ts *cloudProviderTestSuite) TestSKSNodepoolNodeGroup_MaxSize() {
@@ -249,6 +251,77 @@ func (ts *cloudProviderTestSuite) TestSKSNodepoolNodeGroup_Nodes() {
 	ts.Require().Equal(cloudprovider.InstanceRunning, instances[0].Status.State)
 }
 
+func (ts *cloudProviderTestSuite) TestSKSNodepoolNodeGroup_TemplateNodeInfo() {
+	var (
+		instanceTypeID           = ts.randomID()
+		instanceTypeCPUs   int64 = 12
+		instanceTypeFamily       = "gpu2"
+		instanceTypeGPUs   int64 = 1
+		instanceTypeMemory int64 = 56 << 30
+		instanceTypeSize         = "small"
+		diskSize           int64 = 100
+	)
+
+	ts.p.manager.client.(*exoscaleClientMock).
+		On("GetInstanceType", ts.p.manager.ctx, ts.p.manager.zone, instanceTypeID).
+		Return(
+			&egoscale.InstanceType{
+				CPUs:   &instanceTypeCPUs,
+				Family: &instanceTypeFamily,
+				GPUs:   &instanceTypeGPUs,
+				ID:     &instanceTypeID,
+				Memory: &instanceTypeMemory,
+				Size:   &instanceTypeSize,
+			},
+			nil,
+		).
+		Once()
+
+	nodeGroup := &sksNodepoolNodeGroup{
+		sksNodepool: &egoscale.SKSNodepool{
+			DiskSize:       &diskSize,
+			ID:             &testSKSNodepoolID,
+			InstanceTypeID: &instanceTypeID,
+			Labels:         &map[string]string{"pool": "gpu"},
+			Name:           &testSKSNodepoolName,
+			Taints: &map[string]*egoscale.SKSNodepoolTaint{
+				"nvidia.com/gpu": {Effect: "NoSchedule", Value: "present"},
+				"invalid":        {Effect: "Unknown", Value: "x"},
+			},
+		},
+		sksCluster: &egoscale.SKSCluster{
+			ID:   &testSKSClusterID,
+			Name: &testSKSClusterName,
+		},
+		m:       ts.p.manager,
+		minSize: 0,
+		maxSize: int(testComputeInstanceQuotaLimit),
+	}
+
+	nodeInfo, err := nodeGroup.TemplateNodeInfo()
+	ts.Require().NoError(err)
+	node := nodeInfo.Node()
+	ts.Require().Equal("gpu", node.Labels["pool"])
+	ts.Require().Equal("gpu2.small", node.Labels[apiv1.LabelInstanceTypeStable])
+	ts.Require().Equal(testZone, node.Labels[apiv1.LabelTopologyRegion])
+	ts.Require().Equal(node.Name, node.Labels[apiv1.LabelHostname])
+	ts.Require().Equal([]apiv1.Taint{{
+		Key:    "nvidia.com/gpu",
+		Value:  "present",
+		Effect: apiv1.TaintEffectNoSchedule,
+	}}, node.Spec.Taints)
+	ts.Require().Equal(instanceTypeCPUs, node.Status.Allocatable.Cpu().Value())
+	ts.Require().Equal(instanceTypeMemory, node.Status.Allocatable.Memory().Value())
+	ts.Require().Equal(diskSize<<30, node.Status.Allocatable.StorageEphemeral().Value())
+	ts.Require().Equal(instanceTypeGPUs, node.Status.Allocatable.Name(gpu.ResourceNvidiaGPU, resource.DecimalSI).Value())
+	ts.Require().Len(nodeInfo.Pods(), 1)
+
+	// The instance type is only retrieved once.
+	_, err = nodeGroup.TemplateNodeInfo()
+	ts.Require().NoError(err)
+	ts.p.manager.client.(*exoscaleClientMock).AssertNumberOfCalls(ts.T(), "GetInstanceType", 1)
+}
+
 func (ts *cloudProviderTestSuite) TestSKSNodepoolNodeGroup_Exist() {
 	nodeGroup := &sksNodepoolNodeGroup{
 		sksNodepool: &egoscale.SKSNodepool{
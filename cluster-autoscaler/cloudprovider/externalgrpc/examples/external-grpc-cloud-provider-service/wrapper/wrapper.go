@@ -20,9 +20,11 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sync"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -37,12 +39,16 @@ type Wrapper struct {
 	protos.UnimplementedCloudProviderServer
 
 	provider cloudprovider.CloudProvider
+
+	mutex             sync.Mutex
+	nodeGroupsRefresh chan struct{} // closed when the cloud provider is refreshed, to notify WatchNodeGroups streams
 }
 
 // NewCloudProviderGrpcWrapper creates a grpc wrapper for a cloud provider implementation.
 func NewCloudProviderGrpcWrapper(provider cloudprovider.CloudProvider) *Wrapper {
 	return &Wrapper{
-		provider: provider,
+		provider:          provider,
+		nodeGroupsRefresh: make(chan struct{}),
 	}
 }
 
@@ -74,19 +80,62 @@ func debug(req fmt.Stringer) {
 	klog.V(10).Infof("got gRPC request: %T %s", req, req)
 }
 
-// NodeGroups is the wrapper for the cloud provider NodeGroups method.
-func (w *Wrapper) NodeGroups(_ context.Context, req *protos.NodeGroupsRequest) (*protos.NodeGroupsResponse, error) {
-	debug(req)
-
+func (w *Wrapper) pbNodeGroups() []*protos.NodeGroup {
 	pbNgs := make([]*protos.NodeGroup, 0)
 	for _, ng := range w.provider.NodeGroups() {
 		pbNgs = append(pbNgs, pbNodeGroup(ng))
 	}
+	return pbNgs
+}
+
+// NodeGroups is the wrapper for the cloud provider NodeGroups method.
+func (w *Wrapper) NodeGroups(_ context.Context, req *protos.NodeGroupsRequest) (*protos.NodeGroupsResponse, error) {
+	debug(req)
+
 	return &protos.NodeGroupsResponse{
-		NodeGroups: pbNgs,
+		NodeGroups: w.pbNodeGroups(),
 	}, nil
 }
 
+// WatchNodeGroups streams the cloud provider node groups, checking if they changed
+// every time the cloud provider is refreshed.
+func (w *Wrapper) WatchNodeGroups(req *protos.WatchNodeGroupsRequest, stream protos.CloudProvider_WatchNodeGroupsServer) error {
+	debug(req)
+
+	var sent []*protos.NodeGroup
+	for {
+		w.mutex.Lock()
+		refreshed := w.nodeGroupsRefresh
+		w.mutex.Unlock()
+
+		pbNgs := w.pbNodeGroups()
+		if sent == nil || !equalNodeGroups(sent, pbNgs) {
+			if err := stream.Send(&protos.WatchNodeGroupsResponse{NodeGroups: pbNgs}); err != nil {
+				return err
+			}
+			sent = pbNgs
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-refreshed:
+		}
+	}
+}
+
+func equalNodeGroups(a, b []*protos.NodeGroup) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !proto.Equal(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 // NodeGroupForNode is the wrapper for the cloud provider NodeGroupForNode method.
 func (w *Wrapper) NodeGroupForNode(_ context.Context, req *protos.NodeGroupForNodeRequest) (*protos.NodeGroupForNodeResponse, error) {
 	debug(req)
@@ -200,6 +249,13 @@ func (w *Wrapper) Refresh(_ context.Context, req *protos.RefreshRequest) (*proto
 	debug(req)
 
 	err := w.provider.Refresh()
+
+	// wake up WatchNodeGroups streams to check if node groups changed
+	w.mutex.Lock()
+	close(w.nodeGroupsRefresh)
+	w.nodeGroupsRefresh = make(chan struct{})
+	w.mutex.Unlock()
+
 	return &protos.RefreshResponse{}, err
 }
 
@@ -21,7 +21,9 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"math"
 	"net"
 	"sync"
 	"time"
@@ -33,6 +35,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/externalgrpc/protos"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
@@ -44,6 +47,9 @@ import (
 
 const (
 	defaultGRPCTimeout = 5 * time.Second
+
+	watchNodeGroupsInitialBackoff = 1 * time.Second
+	watchNodeGroupsMaxBackoff     = 1 * time.Minute
 )
 
 // externalGrpcCloudProvider implements CloudProvider interface.
@@ -57,6 +63,11 @@ type externalGrpcCloudProvider struct {
 	nodeGroupsCache       []cloudprovider.NodeGroup          // used to cache NodeGroups grpc calls. Discarded at each Refresh()
 	gpuLabelCache         *string                            // used to cache GPULabel grpc calls
 	gpuTypesCache         map[string]struct{}                // used to cache GetAvailableGPUTypes grpc calls
+	watchedNodeGroups     []*protos.NodeGroup                // latest node groups streamed by WatchNodeGroups, nil while not watching
+	templateCache         *templateNodeInfoCache             // used to cache NodeGroupTemplateNodeInfo grpc calls with cacheTtl
+
+	watchBackoff wait.Backoff // backoff of WatchNodeGroups reconnections
+	stopWatch    context.CancelFunc
 }
 
 // Name returns name of the cloud provider.
@@ -73,6 +84,11 @@ func (e *externalGrpcCloudProvider) NodeGroups() []cloudprovider.NodeGroup {
 		klog.V(5).Info("Returning cached NodeGroups")
 		return e.nodeGroupsCache
 	}
+	if e.watchedNodeGroups != nil {
+		klog.V(5).Info("Returning watched NodeGroups")
+		e.nodeGroupsCache = e.nodeGroupsFromProtos(e.watchedNodeGroups)
+		return e.nodeGroupsCache
+	}
 	nodeGroups := make([]cloudprovider.NodeGroup, 0)
 	ctx, cancel := context.WithTimeout(context.Background(), e.grpcTimeout)
 	defer cancel()
@@ -82,21 +98,87 @@ func (e *externalGrpcCloudProvider) NodeGroups() []cloudprovider.NodeGroup {
 		klog.V(1).Infof("Error on gRPC call NodeGroups: %v", err)
 		return nodeGroups
 	}
-	for _, pbNg := range res.GetNodeGroups() {
+	e.nodeGroupsCache = e.nodeGroupsFromProtos(res.GetNodeGroups())
+	return e.nodeGroupsCache
+}
+
+func (e *externalGrpcCloudProvider) nodeGroupsFromProtos(pbNgs []*protos.NodeGroup) []cloudprovider.NodeGroup {
+	nodeGroups := make([]cloudprovider.NodeGroup, 0, len(pbNgs))
+	for _, pbNg := range pbNgs {
 		ng := &NodeGroup{
-			id:          pbNg.Id,
-			minSize:     int(pbNg.MinSize),
-			maxSize:     int(pbNg.MaxSize),
-			debug:       pbNg.Debug,
-			client:      e.client,
-			grpcTimeout: e.grpcTimeout,
+			id:            pbNg.Id,
+			minSize:       int(pbNg.MinSize),
+			maxSize:       int(pbNg.MaxSize),
+			debug:         pbNg.Debug,
+			client:        e.client,
+			grpcTimeout:   e.grpcTimeout,
+			templateCache: e.templateCache,
 		}
 		nodeGroups = append(nodeGroups, ng)
 	}
-	e.nodeGroupsCache = nodeGroups
 	return nodeGroups
 }
 
+// watchNodeGroups keeps the node groups up to date with the WatchNodeGroups stream until the context
+// is done, reconnecting with exponential backoff when the stream breaks. NodeGroups falls back to
+// polling while the stream is down, and for good if the service doesn't implement WatchNodeGroups.
+func (e *externalGrpcCloudProvider) watchNodeGroups(ctx context.Context) {
+	backoff := e.watchBackoff
+	for {
+		err := e.receiveNodeGroups(ctx, func() { backoff = e.watchBackoff })
+		e.setWatchedNodeGroups(nil)
+		if ctx.Err() != nil {
+			return
+		}
+		if st, ok := status.FromError(err); ok && st.Code() == codes.Unimplemented {
+			klog.V(1).Info("gRPC stream WatchNodeGroups not implemented, polling NodeGroups instead")
+			return
+		}
+		delay := backoff.Step()
+		klog.V(1).Infof("Error on gRPC stream WatchNodeGroups, reconnecting in %v: %v", delay, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// receiveNodeGroups opens a WatchNodeGroups stream and receives node groups until it breaks,
+// calling onReceive for every message.
+func (e *externalGrpcCloudProvider) receiveNodeGroups(ctx context.Context, onReceive func()) error {
+	klog.V(5).Info("Opening gRPC stream WatchNodeGroups")
+	stream, err := e.client.WatchNodeGroups(ctx, &protos.WatchNodeGroupsRequest{})
+	if err != nil {
+		return err
+	}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return fmt.Errorf("stream closed by server")
+		}
+		if err != nil {
+			return err
+		}
+		klog.V(5).Infof("Received %d node groups from gRPC stream WatchNodeGroups", len(res.GetNodeGroups()))
+		// the node groups are watched even if there are none
+		e.setWatchedNodeGroups(append([]*protos.NodeGroup{}, res.GetNodeGroups()...))
+		onReceive()
+	}
+}
+
+// setWatchedNodeGroups replaces the watched node groups, nil meaning that they aren't watched anymore,
+// and discards the cached NodeGroups.
+func (e *externalGrpcCloudProvider) setWatchedNodeGroups(pbNgs []*protos.NodeGroup) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if pbNgs == nil && e.watchedNodeGroups == nil {
+		return
+	}
+	e.watchedNodeGroups = pbNgs
+	e.nodeGroupsCache = nil
+}
+
 // NodeGroupForNode returns the node group for the given node, nil if the node
 // should not be processed by cluster autoscaler, or non-nil error if such
 // occurred. Must be implemented.
@@ -129,12 +211,13 @@ func (e *externalGrpcCloudProvider) NodeGroupForNode(node *apiv1.Node) (cloudpro
 		return nil, nil
 	}
 	ng := &NodeGroup{
-		id:          pbNg.GetId(),
-		maxSize:     int(pbNg.GetMaxSize()),
-		minSize:     int(pbNg.GetMinSize()),
-		debug:       pbNg.GetDebug(),
-		client:      e.client,
-		grpcTimeout: e.grpcTimeout,
+		id:            pbNg.GetId(),
+		maxSize:       int(pbNg.GetMaxSize()),
+		minSize:       int(pbNg.GetMinSize()),
+		debug:         pbNg.GetDebug(),
+		client:        e.client,
+		grpcTimeout:   e.grpcTimeout,
+		templateCache: e.templateCache,
 	}
 	e.nodeGroupForNodeCache[nodeID] = ng
 	return ng, nil
@@ -286,6 +369,7 @@ func (e *externalGrpcCloudProvider) GetNodeGpuConfig(node *apiv1.Node) *cloudpro
 
 // Cleanup cleans up open resources before the cloud provider is destroyed, i.e. go routines etc.
 func (e *externalGrpcCloudProvider) Cleanup() error {
+	e.stopWatch()
 	ctx, cancel := context.WithTimeout(context.Background(), e.grpcTimeout)
 	defer cancel()
 	klog.V(5).Info("Performing gRPC call Cleanup")
@@ -305,6 +389,7 @@ func (e *externalGrpcCloudProvider) Refresh() error {
 	e.nodeGroupForNodeCache = make(map[string]cloudprovider.NodeGroup)
 	e.nodeGroupsCache = nil
 	e.mutex.Unlock()
+	e.templateCache.prune()
 	ctx, cancel := context.WithTimeout(context.Background(), e.grpcTimeout)
 	defer cancel()
 	klog.V(5).Info("Performing gRPC call Refresh")
@@ -403,12 +488,28 @@ func newExternalGrpcCloudProviderClient(config []byte) (protos.CloudProviderClie
 }
 
 func newExternalGrpcCloudProvider(client protos.CloudProviderClient, grpcTimeout time.Duration, rl *cloudprovider.ResourceLimiter) cloudprovider.CloudProvider {
-	return &externalGrpcCloudProvider{
+	return newExternalGrpcCloudProviderWithBackoff(client, grpcTimeout, rl, wait.Backoff{
+		Duration: watchNodeGroupsInitialBackoff,
+		Factor:   2,
+		Jitter:   0.1,
+		Steps:    math.MaxInt32,
+		Cap:      watchNodeGroupsMaxBackoff,
+	})
+}
+
+func newExternalGrpcCloudProviderWithBackoff(client protos.CloudProviderClient, grpcTimeout time.Duration, rl *cloudprovider.ResourceLimiter, watchBackoff wait.Backoff) *externalGrpcCloudProvider {
+	ctx, cancel := context.WithCancel(context.Background())
+	e := &externalGrpcCloudProvider{
 		resourceLimiter:       rl,
 		client:                client,
 		grpcTimeout:           grpcTimeout,
 		nodeGroupForNodeCache: make(map[string]cloudprovider.NodeGroup),
+		templateCache:         newTemplateNodeInfoCache(time.Now),
+		watchBackoff:          watchBackoff,
+		stopWatch:             cancel,
 	}
+	go e.watchNodeGroups(ctx)
+	return e
 }
 
 // externalGrpcNode converts an apiv1.Node to a protos.ExternalGrpcNode.
@@ -27,6 +27,7 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/anypb"
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/externalgrpc/protos"
 )
@@ -114,6 +115,88 @@ func TestCloudProvider_NodeGroups(t *testing.T) {
 
 }
 
+func TestCloudProvider_WatchNodeGroups(t *testing.T) {
+	client, m, teardown := setupTest(t)
+	defer teardown()
+
+	updates := make(chan []*protos.NodeGroup)
+	m.watchNodeGroups = func(_ *protos.WatchNodeGroupsRequest, stream protos.CloudProvider_WatchNodeGroupsServer) error {
+		for {
+			select {
+			case <-stream.Context().Done():
+				return nil
+			case ngs := <-updates:
+				if ngs == nil {
+					return status.Error(codes.Unavailable, "mock error")
+				}
+				if err := stream.Send(&protos.WatchNodeGroupsResponse{NodeGroups: ngs}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	m.On("Refresh", mock.Anything, mock.Anything).Return(&protos.RefreshResponse{}, nil)
+	m.On("Cleanup", mock.Anything, mock.Anything).Return(&protos.CleanupResponse{}, nil)
+
+	c := newExternalGrpcCloudProviderWithBackoff(client, defaultGRPCTimeout, nil, wait.Backoff{Duration: 10 * time.Millisecond, Steps: 1})
+	watchedIds := func() []string {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		if c.watchedNodeGroups == nil {
+			return nil
+		}
+		ids := []string{}
+		for _, ng := range c.watchedNodeGroups {
+			ids = append(ids, ng.Id)
+		}
+		return ids
+	}
+	nodeGroupIds := func() []string {
+		ids := []string{}
+		for _, ng := range c.NodeGroups() {
+			ids = append(ids, ng.Id())
+		}
+		return ids
+	}
+
+	// test node groups sent when the stream is opened
+	updates <- []*protos.NodeGroup{{Id: "1", MinSize: 1, MaxSize: 10}}
+	assert.Eventually(t, func() bool { return len(watchedIds()) == 1 }, time.Second, 10*time.Millisecond)
+	ngs := c.NodeGroups()
+	assert.Equal(t, 1, len(ngs))
+	assert.Equal(t, "1", ngs[0].Id())
+	assert.Equal(t, 1, ngs[0].MinSize())
+	assert.Equal(t, 10, ngs[0].MaxSize())
+
+	// test node groups changes replacing cached node groups
+	updates <- []*protos.NodeGroup{{Id: "1"}, {Id: "2"}}
+	assert.Eventually(t, func() bool { return len(watchedIds()) == 2 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"1", "2"}, nodeGroupIds())
+	assert.NoError(t, c.Refresh())
+	assert.Equal(t, []string{"1", "2"}, nodeGroupIds())
+
+	// test reconnecting after the stream breaks
+	updates <- nil
+	updates <- []*protos.NodeGroup{{Id: "3"}}
+	assert.Eventually(t, func() bool {
+		ids := watchedIds()
+		return len(ids) == 1 && ids[0] == "3"
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"3"}, nodeGroupIds())
+
+	// test no node groups
+	updates <- []*protos.NodeGroup{}
+	assert.Eventually(t, func() bool { return watchedIds() != nil && len(watchedIds()) == 0 }, time.Second, 10*time.Millisecond)
+	assert.Empty(t, nodeGroupIds())
+
+	// node groups were never polled
+	m.AssertNotCalled(t, "NodeGroups", mock.Anything, mock.Anything)
+
+	// test stopping to watch on cleanup
+	assert.NoError(t, c.Cleanup())
+	assert.Eventually(t, func() bool { return watchedIds() == nil }, time.Second, 10*time.Millisecond)
+}
+
 func TestCloudProvider_NodeGroupForNode(t *testing.T) {
 	client, m, teardown := setupTest(t)
 	defer teardown()
@@ -18,6 +18,7 @@ package externalgrpc
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
@@ -43,8 +44,66 @@ type NodeGroup struct {
 	client      protos.CloudProviderClient
 	grpcTimeout time.Duration
 
-	mutex    sync.Mutex
-	nodeInfo **framework.NodeInfo // used to cache NodeGroupTemplateNodeInfo() grpc calls
+	mutex         sync.Mutex
+	nodeInfo      **framework.NodeInfo   // used to cache NodeGroupTemplateNodeInfo() grpc calls without cacheTtl
+	templateCache *templateNodeInfoCache // used to cache NodeGroupTemplateNodeInfo() grpc calls with cacheTtl, shared by all NodeGroups
+}
+
+// templateNodeInfoCache caches the templates of node groups for the cacheTtl returned by the
+// NodeGroupTemplateNodeInfo grpc calls, across the NodeGroup objects created after each Refresh().
+type templateNodeInfoCache struct {
+	mutex   sync.Mutex
+	entries map[string]templateNodeInfoCacheEntry
+	now     func() time.Time
+}
+
+type templateNodeInfoCacheEntry struct {
+	nodeInfo *framework.NodeInfo
+	etag     string
+	expires  time.Time
+}
+
+func newTemplateNodeInfoCache(now func() time.Time) *templateNodeInfoCache {
+	return &templateNodeInfoCache{
+		entries: make(map[string]templateNodeInfoCacheEntry),
+		now:     now,
+	}
+}
+
+// get returns the cached template of a node group, nil if there is none, and whether it
+// didn't expire yet.
+func (c *templateNodeInfoCache) get(id string) (*templateNodeInfoCacheEntry, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, found := c.entries[id]
+	if !found {
+		return nil, false
+	}
+	return &entry, c.now().Before(entry.expires)
+}
+
+func (c *templateNodeInfoCache) put(id string, nodeInfo *framework.NodeInfo, etag string, ttl time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.entries[id] = templateNodeInfoCacheEntry{nodeInfo: nodeInfo, etag: etag, expires: c.now().Add(ttl)}
+}
+
+func (c *templateNodeInfoCache) delete(id string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.entries, id)
+}
+
+// prune discards the expired templates which can't be revalidated.
+func (c *templateNodeInfoCache) prune() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	now := c.now()
+	for id, entry := range c.entries {
+		if entry.etag == "" && !now.Before(entry.expires) {
+			delete(c.entries, id)
+		}
+	}
 }
 
 // MaxSize returns maximum size of the node group.
@@ -205,6 +264,10 @@ func (n *NodeGroup) Nodes() ([]cloudprovider.Instance, error) {
 // complex approach and does not cover all the scenarios. For the sake of simplicity,
 // the `nodeInfo` is defined as a Kubernetes `k8s.io.api.core.v1.Node` type
 // where the system could still extract certain info about the node.
+//
+// The cloud provider controls how long the template is cached with the cacheTtl
+// of the response, and can avoid computing it again once it expires by returning
+// notModified for the etag of the cached template.
 func (n *NodeGroup) TemplateNodeInfo() (*framework.NodeInfo, error) {
 	n.mutex.Lock()
 	defer n.mutex.Unlock()
@@ -213,12 +276,25 @@ func (n *NodeGroup) TemplateNodeInfo() (*framework.NodeInfo, error) {
 		klog.V(5).Infof("Returning cached nodeInfo for node group %v", n.id)
 		return *n.nodeInfo, nil
 	}
+	var cached *templateNodeInfoCacheEntry
+	if n.templateCache != nil {
+		var fresh bool
+		cached, fresh = n.templateCache.get(n.id)
+		if fresh {
+			klog.V(5).Infof("Returning cached nodeInfo for node group %v", n.id)
+			return cached.nodeInfo, nil
+		}
+	}
+	req := &protos.NodeGroupTemplateNodeInfoRequest{
+		Id: n.id,
+	}
+	if cached != nil {
+		req.Etag = cached.etag
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), n.grpcTimeout)
 	defer cancel()
 	klog.V(5).Infof("Performing gRPC call NodeGroupTemplateNodeInfo for node group %v", n.id)
-	res, err := n.client.NodeGroupTemplateNodeInfo(ctx, &protos.NodeGroupTemplateNodeInfoRequest{
-		Id: n.id,
-	})
+	res, err := n.client.NodeGroupTemplateNodeInfo(ctx, req)
 	if err != nil {
 		st, ok := status.FromError(err)
 		if ok && st.Code() == codes.Unimplemented {
@@ -227,16 +303,39 @@ func (n *NodeGroup) TemplateNodeInfo() (*framework.NodeInfo, error) {
 		klog.V(1).Infof("Error on gRPC call NodeGroupTemplateNodeInfo: %v", err)
 		return nil, err
 	}
-	pbNodeInfo := res.GetNodeInfo()
-	if pbNodeInfo == nil {
-		n.nodeInfo = new(*framework.NodeInfo)
-		return nil, nil
+	if res.GetNotModified() {
+		if req.Etag == "" {
+			return nil, fmt.Errorf("nodeInfo of node group %v not modified, but no etag was sent", n.id)
+		}
+		klog.V(5).Infof("Cached nodeInfo for node group %v not modified", n.id)
+		etag := res.GetEtag()
+		if etag == "" {
+			etag = cached.etag
+		}
+		n.cacheNodeInfo(cached.nodeInfo, etag, res.GetCacheTtl())
+		return cached.nodeInfo, nil
+	}
+	var nodeInfo *framework.NodeInfo
+	if pbNodeInfo := res.GetNodeInfo(); pbNodeInfo != nil {
+		nodeInfo = framework.NewNodeInfo(pbNodeInfo, nil)
 	}
-	nodeInfo := framework.NewNodeInfo(pbNodeInfo, nil)
-	n.nodeInfo = &nodeInfo
+	n.cacheNodeInfo(nodeInfo, res.GetEtag(), res.GetCacheTtl())
 	return nodeInfo, nil
 }
 
+// cacheNodeInfo caches the template for the given ttl, or for the lifetime of the NodeGroup object
+// if there is none.
+func (n *NodeGroup) cacheNodeInfo(nodeInfo *framework.NodeInfo, etag string, ttl *metav1.Duration) {
+	if ttl == nil || n.templateCache == nil {
+		n.nodeInfo = &nodeInfo
+		if n.templateCache != nil {
+			n.templateCache.delete(n.id)
+		}
+		return
+	}
+	n.templateCache.put(n.id, nodeInfo, etag, ttl.Duration)
+}
+
 // Exist checks if the node group really exists on the cloud provider side.
 // Allows to tell the theoretical node group from the real one. Implementation
 // required.
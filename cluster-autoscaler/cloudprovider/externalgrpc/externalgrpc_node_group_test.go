@@ -240,6 +240,99 @@ func TestCloudProvider_TemplateNodeInfo(t *testing.T) {
 
 }
 
+func TestCloudProvider_TemplateNodeInfoCacheTtl(t *testing.T) {
+	client, m, teardown := setupTest(t)
+	defer teardown()
+
+	apiv1Node1 := &apiv1.Node{}
+	apiv1Node1.Name = "node1"
+
+	apiv1Node2 := &apiv1.Node{}
+	apiv1Node2.Name = "node2"
+
+	now := time.Now()
+	cache := newTemplateNodeInfoCache(func() time.Time { return now })
+	newNodeGroup := func() *NodeGroup {
+		return &NodeGroup{
+			id:            "nodeGroup1",
+			client:        client,
+			grpcTimeout:   defaultGRPCTimeout,
+			templateCache: cache,
+		}
+	}
+	expectCall := func(etag string, res *protos.NodeGroupTemplateNodeInfoResponse) {
+		m.On(
+			"NodeGroupTemplateNodeInfo", mock.Anything, mock.MatchedBy(func(req *protos.NodeGroupTemplateNodeInfoRequest) bool {
+				return req.Id == "nodeGroup1" && req.Etag == etag
+			}),
+		).Return(res, nil).Once()
+	}
+
+	// test answer cached for the ttl across NodeGroup objects
+	expectCall("", &protos.NodeGroupTemplateNodeInfoResponse{
+		NodeInfo: apiv1Node1,
+		CacheTtl: &v1.Duration{Duration: time.Minute},
+		Etag:     "v1",
+	})
+	nodeInfo, err := newNodeGroup().TemplateNodeInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, apiv1Node1.Name, nodeInfo.Node().Name)
+
+	now = now.Add(30 * time.Second)
+	nodeInfo, err = newNodeGroup().TemplateNodeInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, apiv1Node1.Name, nodeInfo.Node().Name)
+	m.AssertNumberOfCalls(t, "NodeGroupTemplateNodeInfo", 1)
+
+	// test expired answer revalidated with its etag
+	now = now.Add(time.Minute)
+	expectCall("v1", &protos.NodeGroupTemplateNodeInfoResponse{
+		CacheTtl:    &v1.Duration{Duration: time.Minute},
+		NotModified: true,
+	})
+	ng := newNodeGroup()
+	nodeInfo, err = ng.TemplateNodeInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, apiv1Node1.Name, nodeInfo.Node().Name)
+	m.AssertNumberOfCalls(t, "NodeGroupTemplateNodeInfo", 2)
+
+	nodeInfo, err = ng.TemplateNodeInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, apiv1Node1.Name, nodeInfo.Node().Name)
+	m.AssertNumberOfCalls(t, "NodeGroupTemplateNodeInfo", 2)
+
+	// test modified answer without ttl, cached for the lifetime of the NodeGroup object
+	now = now.Add(2 * time.Minute)
+	expectCall("v1", &protos.NodeGroupTemplateNodeInfoResponse{
+		NodeInfo: apiv1Node2,
+	})
+	ng = newNodeGroup()
+	nodeInfo, err = ng.TemplateNodeInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, apiv1Node2.Name, nodeInfo.Node().Name)
+
+	now = now.Add(2 * time.Minute)
+	nodeInfo, err = ng.TemplateNodeInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, apiv1Node2.Name, nodeInfo.Node().Name)
+	m.AssertNumberOfCalls(t, "NodeGroupTemplateNodeInfo", 3)
+
+	expectCall("", &protos.NodeGroupTemplateNodeInfoResponse{
+		NodeInfo: apiv1Node2,
+	})
+	nodeInfo, err = newNodeGroup().TemplateNodeInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, apiv1Node2.Name, nodeInfo.Node().Name)
+	m.AssertNumberOfCalls(t, "NodeGroupTemplateNodeInfo", 4)
+
+	// test not modified answer without a cached template
+	expectCall("", &protos.NodeGroupTemplateNodeInfoResponse{
+		NotModified: true,
+	})
+	_, err = newNodeGroup().TemplateNodeInfo()
+	assert.Error(t, err)
+}
+
 func TestCloudProvider_GetOptions(t *testing.T) {
 	client, m, teardown := setupTest(t)
 	defer teardown()
@@ -31,6 +31,8 @@ type cloudProviderServerMock struct {
 	protos.UnimplementedCloudProviderServer
 
 	mock.Mock
+	// watchNodeGroups handles WatchNodeGroups streams, which are unimplemented if nil
+	watchNodeGroups func(*protos.WatchNodeGroupsRequest, protos.CloudProvider_WatchNodeGroupsServer) error
 }
 
 func (c *cloudProviderServerMock) NodeGroups(ctx context.Context, req *protos.NodeGroupsRequest) (*protos.NodeGroupsResponse, error) {
@@ -73,6 +75,13 @@ func (c *cloudProviderServerMock) Refresh(ctx context.Context, req *protos.Refre
 	return args.Get(0).(*protos.RefreshResponse), args.Error(1)
 }
 
+func (c *cloudProviderServerMock) WatchNodeGroups(req *protos.WatchNodeGroupsRequest, stream protos.CloudProvider_WatchNodeGroupsServer) error {
+	if c.watchNodeGroups == nil {
+		return c.UnimplementedCloudProviderServer.WatchNodeGroups(req, stream)
+	}
+	return c.watchNodeGroups(req, stream)
+}
+
 func (c *cloudProviderServerMock) NodeGroupTargetSize(ctx context.Context, req *protos.NodeGroupTargetSizeRequest) (*protos.NodeGroupTargetSizeResponse, error) {
 	args := c.Called(ctx, req)
 	return args.Get(0).(*protos.NodeGroupTargetSizeResponse), args.Error(1)
@@ -42,6 +42,7 @@ const (
 	CloudProvider_GetAvailableGPUTypes_FullMethodName        = "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/GetAvailableGPUTypes"
 	CloudProvider_Cleanup_FullMethodName                     = "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/Cleanup"
 	CloudProvider_Refresh_FullMethodName                     = "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/Refresh"
+	CloudProvider_WatchNodeGroups_FullMethodName             = "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/WatchNodeGroups"
 	CloudProvider_NodeGroupTargetSize_FullMethodName         = "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/NodeGroupTargetSize"
 	CloudProvider_NodeGroupIncreaseSize_FullMethodName       = "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/NodeGroupIncreaseSize"
 	CloudProvider_NodeGroupDeleteNodes_FullMethodName        = "/clusterautoscaler.cloudprovider.v1.externalgrpc.CloudProvider/NodeGroupDeleteNodes"
@@ -77,6 +78,10 @@ type CloudProviderClient interface {
 	Cleanup(ctx context.Context, in *CleanupRequest, opts ...grpc.CallOption) (*CleanupResponse, error)
 	// Refresh is called before every main loop and can be used to dynamically update cloud provider state.
 	Refresh(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (*RefreshResponse, error)
+	// WatchNodeGroups streams all node groups configured for this cloud provider, when the
+	// stream is opened and then every time they change, so that they don't need to be polled.
+	// Implementation optional: if unimplemented return error code 12 (for `Unimplemented`)
+	WatchNodeGroups(ctx context.Context, in *WatchNodeGroupsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchNodeGroupsResponse], error)
 	// NodeGroupTargetSize returns the current target size of the node group. It is possible
 	// that the number of nodes in Kubernetes is different at the moment but should be equal
 	// to the size of a node group once everything stabilizes (new nodes finish startup and
@@ -197,6 +202,25 @@ func (c *cloudProviderClient) Refresh(ctx context.Context, in *RefreshRequest, o
 	return out, nil
 }
 
+func (c *cloudProviderClient) WatchNodeGroups(ctx context.Context, in *WatchNodeGroupsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchNodeGroupsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CloudProvider_ServiceDesc.Streams[0], CloudProvider_WatchNodeGroups_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchNodeGroupsRequest, WatchNodeGroupsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CloudProvider_WatchNodeGroupsClient = grpc.ServerStreamingClient[WatchNodeGroupsResponse]
+
 func (c *cloudProviderClient) NodeGroupTargetSize(ctx context.Context, in *NodeGroupTargetSizeRequest, opts ...grpc.CallOption) (*NodeGroupTargetSizeResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(NodeGroupTargetSizeResponse)
@@ -293,6 +317,10 @@ type CloudProviderServer interface {
 	Cleanup(context.Context, *CleanupRequest) (*CleanupResponse, error)
 	// Refresh is called before every main loop and can be used to dynamically update cloud provider state.
 	Refresh(context.Context, *RefreshRequest) (*RefreshResponse, error)
+	// WatchNodeGroups streams all node groups configured for this cloud provider, when the
+	// stream is opened and then every time they change, so that they don't need to be polled.
+	// Implementation optional: if unimplemented return error code 12 (for `Unimplemented`)
+	WatchNodeGroups(*WatchNodeGroupsRequest, grpc.ServerStreamingServer[WatchNodeGroupsResponse]) error
 	// NodeGroupTargetSize returns the current target size of the node group. It is possible
 	// that the number of nodes in Kubernetes is different at the moment but should be equal
 	// to the size of a node group once everything stabilizes (new nodes finish startup and
@@ -357,6 +385,9 @@ func (UnimplementedCloudProviderServer) Cleanup(context.Context, *CleanupRequest
 func (UnimplementedCloudProviderServer) Refresh(context.Context, *RefreshRequest) (*RefreshResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Refresh not implemented")
 }
+func (UnimplementedCloudProviderServer) WatchNodeGroups(*WatchNodeGroupsRequest, grpc.ServerStreamingServer[WatchNodeGroupsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchNodeGroups not implemented")
+}
 func (UnimplementedCloudProviderServer) NodeGroupTargetSize(context.Context, *NodeGroupTargetSizeRequest) (*NodeGroupTargetSizeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method NodeGroupTargetSize not implemented")
 }
@@ -543,6 +574,17 @@ func _CloudProvider_Refresh_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CloudProvider_WatchNodeGroups_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchNodeGroupsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CloudProviderServer).WatchNodeGroups(m, &grpc.GenericServerStream[WatchNodeGroupsRequest, WatchNodeGroupsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CloudProvider_WatchNodeGroupsServer = grpc.ServerStreamingServer[WatchNodeGroupsResponse]
+
 func _CloudProvider_NodeGroupTargetSize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(NodeGroupTargetSizeRequest)
 	if err := dec(in); err != nil {
@@ -737,6 +779,12 @@ var CloudProvider_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _CloudProvider_NodeGroupGetOptions_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchNodeGroups",
+			Handler:       _CloudProvider_WatchNodeGroups_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "cloudprovider/externalgrpc/protos/externalgrpc.proto",
 }
@@ -597,7 +597,7 @@ func GetErrorInfo(errorCode, errorMessage, instanceStatus string, previousErrorI
 		}
 	} else if isReservationCapacityExceeded(errorMessage) {
 		return &cloudprovider.InstanceErrorInfo{
-			ErrorClass: cloudprovider.OtherErrorClass,
+			ErrorClass: cloudprovider.OutOfResourcesErrorClass,
 			ErrorCode:  ErrorReservationCapacityExceeded,
 		}
 	} else if isReservationIncompatible(errorMessage) {
@@ -225,7 +225,7 @@ func TestErrors(t *testing.T) {
 			errorCodes:         []string{"CONDITION_NOT_MET"},
 			errorMessage:       "Specified reservation 'rsv-name' does not have available resources for the request.",
 			expectedErrorCode:  "RESERVATION_CAPACITY_EXCEEDED",
-			expectedErrorClass: cloudprovider.OtherErrorClass,
+			expectedErrorClass: cloudprovider.OutOfResourcesErrorClass,
 		},
 		{
 			errorCodes:         []string{"CONDITION_NOT_MET"},
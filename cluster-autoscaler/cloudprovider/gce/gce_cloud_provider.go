@@ -148,6 +148,21 @@ func (gce *GceCloudProvider) Refresh() error {
 	return gce.gceManager.Refresh()
 }
 
+// FreeReservedCapacity returns the number of nodes which can still be created in the node group from the
+// reservations it consumes, and whether the node group consumes reservations at all.
+func (gce *GceCloudProvider) FreeReservedCapacity(nodeGroup cloudprovider.NodeGroup) (int, bool) {
+	mig, ok := nodeGroup.(*gceMig)
+	if !ok {
+		return 0, false
+	}
+	free, consumes, err := gce.gceManager.GetMigFreeReservedCapacity(mig)
+	if err != nil {
+		klog.Warningf("Failed to get free reserved capacity for MIG %s: %v", mig.GceRef(), err)
+		return 0, false
+	}
+	return int(free), consumes
+}
+
 // GceRef contains s reference to some entity in GCE world.
 type GceRef struct {
 	Project string
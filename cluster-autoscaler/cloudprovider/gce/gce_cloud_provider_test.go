@@ -93,6 +93,11 @@ func (m *gceManagerMock) GetMigOptions(mig Mig, defaults config.NodeGroupAutosca
 	return args.Get(0).(*config.NodeGroupAutoscalingOptions)
 }
 
+func (m *gceManagerMock) GetMigFreeReservedCapacity(mig Mig) (int64, bool, error) {
+	args := m.Called(mig)
+	return args.Get(0).(int64), args.Bool(1), args.Error(2)
+}
+
 func (m *gceManagerMock) GetMigTemplateNode(mig Mig) (*apiv1.Node, error) {
 	args := m.Called(mig)
 	return args.Get(0).(*apiv1.Node), args.Error(1)
@@ -94,6 +94,9 @@ type GceManager interface {
 	GetMigSize(mig Mig) (int64, error)
 	// GetMigOptions returns MIG's NodeGroupAutoscalingOptions
 	GetMigOptions(mig Mig, defaults config.NodeGroupAutoscalingOptions) *config.NodeGroupAutoscalingOptions
+	// GetMigFreeReservedCapacity returns the number of instances which can still be created in MIG from
+	// the reservations its instance template consumes, and whether it consumes reservations at all.
+	GetMigFreeReservedCapacity(mig Mig) (int64, bool, error)
 
 	// SetMigSize sets MIG size.
 	SetMigSize(mig Mig, size int64) error
@@ -122,6 +125,7 @@ type gceManagerImpl struct {
 	explicitlyConfigured     map[GceRef]bool
 	migAutoDiscoverySpecs    []migAutoDiscoveryConfig
 	reserved                 *GceReserved
+	reservations             reservationsCache
 	localSSDDiskSizeProvider localssdsize.LocalSSDSizeProvider
 }
 
@@ -303,6 +307,7 @@ func (m *gceManagerImpl) Refresh() error {
 	m.cache.InvalidateAllMigBasenames()
 	m.cache.InvalidateAllListManagedInstancesResults()
 	m.cache.InvalidateAllMigInstanceTemplateNames()
+	m.reservations.invalidate()
 	if m.lastRefresh.Add(refreshInterval).After(time.Now()) {
 		return nil
 	}
@@ -610,7 +615,28 @@ func (m *gceManagerImpl) GetMigTemplateNode(mig Mig) (*apiv1.Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	return m.templates.BuildNodeFromTemplate(mig, migOsInfo, template, kubeEnv, machineType.CPU, machineType.Memory, nil, m.reserved, m.localSSDDiskSizeProvider)
+	return m.templates.BuildNodeFromTemplate(mig, migOsInfo, template, kubeEnv, machineType.CPU, machineType.Memory, extractMaxPodsFromKubeEnv(kubeEnv), m.reserved, m.localSSDDiskSizeProvider)
+}
+
+// GetMigFreeReservedCapacity returns the number of instances which can still be created in MIG from
+// the reservations its instance template consumes, and whether it consumes reservations at all.
+func (m *gceManagerImpl) GetMigFreeReservedCapacity(mig Mig) (int64, bool, error) {
+	template, err := m.migInfoProvider.GetMigInstanceTemplate(mig.GceRef())
+	if err != nil {
+		return 0, false, err
+	}
+	if !consumesReservations(template) {
+		return 0, false, nil
+	}
+	var free int64
+	for projectId, names := range reservationProjects(template, mig.GceRef().Project) {
+		reservations, err := m.reservations.get(projectId, m.GceService.FetchReservationsInProject)
+		if err != nil {
+			return 0, true, fmt.Errorf("failed to fetch reservations in project %s: %w", projectId, err)
+		}
+		free += freeReservedCapacity(template, mig.GceRef().Zone, reservations, names)
+	}
+	return free, true, nil
 }
 
 // parseMIGAutoDiscoverySpecs returns any provided NodeGroupAutoDiscoverySpecs
@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gce
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	gce "google.golang.org/api/compute/v1"
+)
+
+const (
+	consumeAnyReservation      = "ANY_RESERVATION"
+	consumeSpecificReservation = "SPECIFIC_RESERVATION"
+	reservationStatusReady     = "READY"
+)
+
+// reservationsCache holds the reservations per project. It's invalidated on every refresh,
+// as the number of reserved instances in use changes with every scale-up.
+type reservationsCache struct {
+	mutex        sync.Mutex
+	reservations map[string][]*gce.Reservation
+}
+
+func (c *reservationsCache) get(projectId string, fetch func(projectId string) ([]*gce.Reservation, error)) ([]*gce.Reservation, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if reservations, found := c.reservations[projectId]; found {
+		return reservations, nil
+	}
+	reservations, err := fetch(projectId)
+	if err != nil {
+		return nil, err
+	}
+	if c.reservations == nil {
+		c.reservations = make(map[string][]*gce.Reservation)
+	}
+	c.reservations[projectId] = reservations
+	return reservations, nil
+}
+
+func (c *reservationsCache) invalidate() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.reservations = nil
+}
+
+// reservationProjects returns the projects holding the reservations which can be consumed by instances
+// of the template, keyed by project with the names of the specific reservations as values.
+func reservationProjects(template *gce.InstanceTemplate, projectId string) map[string][]string {
+	affinity := template.Properties.ReservationAffinity
+	switch affinity.ConsumeReservationType {
+	case consumeAnyReservation:
+		return map[string][]string{projectId: nil}
+	case consumeSpecificReservation:
+		projects := make(map[string][]string)
+		for _, value := range affinity.Values {
+			// Shared reservations are referenced as projects/<project>/reservations/<name>.
+			project, name := projectId, value
+			if parts := strings.Split(value, "/"); len(parts) == 4 && parts[0] == "projects" && parts[2] == "reservations" {
+				project, name = parts[1], parts[3]
+			}
+			projects[project] = append(projects[project], name)
+		}
+		return projects
+	default:
+		return nil
+	}
+}
+
+// consumesReservations checks whether instances of the template consume reservations.
+func consumesReservations(template *gce.InstanceTemplate) bool {
+	if template == nil || template.Properties == nil || template.Properties.ReservationAffinity == nil {
+		return false
+	}
+	consumeType := template.Properties.ReservationAffinity.ConsumeReservationType
+	return consumeType == consumeAnyReservation || consumeType == consumeSpecificReservation
+}
+
+// freeReservedCapacity returns the number of instances of the template which can still be created in the zone
+// from the given reservations. With specific reservation affinity, only the named reservations are considered,
+// otherwise all reservations matching the machine type that don't require to be targeted specifically.
+func freeReservedCapacity(template *gce.InstanceTemplate, zone string, reservations []*gce.Reservation, names []string) int64 {
+	var free int64
+	for _, reservation := range reservations {
+		if reservation.Status != reservationStatusReady || reservation.SpecificReservation == nil {
+			continue
+		}
+		if reservation.Zone != zone && !strings.HasSuffix(reservation.Zone, "/zones/"+zone) {
+			continue
+		}
+		if names != nil {
+			if !slices.Contains(names, reservation.Name) {
+				continue
+			}
+		} else {
+			properties := reservation.SpecificReservation.InstanceProperties
+			if reservation.SpecificReservationRequired || properties == nil || properties.MachineType != template.Properties.MachineType {
+				continue
+			}
+		}
+		if count := reservation.SpecificReservation.Count - reservation.SpecificReservation.InUseCount; count > 0 {
+			free += count
+		}
+	}
+	return free
+}
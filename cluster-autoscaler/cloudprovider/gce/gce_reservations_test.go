@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gce
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gce "google.golang.org/api/compute/v1"
+)
+
+func testReservation(name, zone, machineType string, count, inUse int64, required bool) *gce.Reservation {
+	return &gce.Reservation{
+		Name:                        name,
+		Zone:                        "https://www.googleapis.com/compute/v1/projects/project1/zones/" + zone,
+		Status:                      reservationStatusReady,
+		SpecificReservationRequired: required,
+		SpecificReservation: &gce.AllocationSpecificSKUReservation{
+			Count:      count,
+			InUseCount: inUse,
+			InstanceProperties: &gce.AllocationSpecificSKUAllocationReservedInstanceProperties{
+				MachineType: machineType,
+			},
+		},
+	}
+}
+
+func testReservationTemplate(consumeType string, values ...string) *gce.InstanceTemplate {
+	return &gce.InstanceTemplate{
+		Properties: &gce.InstanceProperties{
+			MachineType: "n2-standard-4",
+			ReservationAffinity: &gce.ReservationAffinity{
+				ConsumeReservationType: consumeType,
+				Key:                    "compute.googleapis.com/reservation-name",
+				Values:                 values,
+			},
+		},
+	}
+}
+
+func TestReservationProjects(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		template *gce.InstanceTemplate
+		expected map[string][]string
+	}{
+		{
+			name:     "no reservation",
+			template: testReservationTemplate("NO_RESERVATION"),
+			expected: nil,
+		},
+		{
+			name:     "any reservation",
+			template: testReservationTemplate(consumeAnyReservation),
+			expected: map[string][]string{"project1": nil},
+		},
+		{
+			name:     "specific reservations",
+			template: testReservationTemplate(consumeSpecificReservation, "rsv1", "projects/project2/reservations/rsv2"),
+			expected: map[string][]string{"project1": {"rsv1"}, "project2": {"rsv2"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, reservationProjects(tc.template, "project1"))
+		})
+	}
+}
+
+func TestFreeReservedCapacity(t *testing.T) {
+	notReady := testReservation("rsv-not-ready", "us-central1-b", "n2-standard-4", 10, 0, false)
+	notReady.Status = "CREATING"
+	reservations := []*gce.Reservation{
+		testReservation("rsv1", "us-central1-b", "n2-standard-4", 5, 2, false),
+		testReservation("rsv2", "us-central1-b", "n2-standard-4", 4, 0, true),
+		testReservation("rsv3", "us-central1-b", "n2-standard-8", 6, 0, false),
+		testReservation("rsv4", "us-central1-c", "n2-standard-4", 7, 0, false),
+		testReservation("rsv5", "us-central1-b", "n2-standard-4", 2, 3, false),
+		notReady,
+	}
+	for _, tc := range []struct {
+		name     string
+		names    []string
+		zone     string
+		expected int64
+	}{
+		{
+			name:     "any reservation matches machine type and zone",
+			zone:     "us-central1-b",
+			expected: 3,
+		},
+		{
+			name:     "any reservation in other zone",
+			zone:     "us-central1-c",
+			expected: 7,
+		},
+		{
+			name:     "specific reservations",
+			names:    []string{"rsv2", "rsv3"},
+			zone:     "us-central1-b",
+			expected: 10,
+		},
+		{
+			name:     "specific reservation in other zone",
+			names:    []string{"rsv1"},
+			zone:     "us-central1-c",
+			expected: 0,
+		},
+		{
+			name:     "specific reservation not ready",
+			names:    []string{"rsv-not-ready"},
+			zone:     "us-central1-b",
+			expected: 0,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			template := testReservationTemplate(consumeAnyReservation)
+			assert.Equal(t, tc.expected, freeReservedCapacity(template, tc.zone, reservations, tc.names))
+		})
+	}
+}
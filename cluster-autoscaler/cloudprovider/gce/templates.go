@@ -432,6 +432,26 @@ func extractKubeReservedFromKubeEnv(kubeEnv KubeEnv) (string, error) {
 	return kubeReserved, nil
 }
 
+// extractMaxPodsFromKubeEnv returns the --max-pods argument of the kubelet, which the pods-per-node setting of
+// GKE node pools is passed as, or nil if it isn't set.
+func extractMaxPodsFromKubeEnv(kubeEnv KubeEnv) *int64 {
+	maxPodsRegexp := regexp.MustCompile(`--max-pods=([0-9]+)`)
+	for _, name := range []string{"KUBELET_ARGS", "KUBELET_TEST_ARGS"} {
+		kubeletArgs, _ := kubeEnv.Var(name)
+		matches := maxPodsRegexp.FindStringSubmatch(kubeletArgs)
+		if len(matches) < 2 {
+			continue
+		}
+		maxPods, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil || maxPods <= 0 {
+			klog.Warningf("ignoring invalid --max-pods in %s in kube-env: %q", name, matches[1])
+			continue
+		}
+		return &maxPods
+	}
+	return nil
+}
+
 func extractExtendedResourcesFromKubeEnv(kubeEnv KubeEnv) (apiv1.ResourceList, error) {
 	extendedResourcesAsString, found, err := extractAutoscalerVarFromKubeEnv(kubeEnv, "extended_resources")
 	if err != nil {
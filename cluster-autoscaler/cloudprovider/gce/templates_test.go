@@ -35,6 +35,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	quota "k8s.io/apiserver/pkg/quota/v1"
+	"k8s.io/utils/ptr"
 )
 
 // TestBuildNodeFromTemplateSetsResources tests that capacity and allocatable
@@ -1004,6 +1005,41 @@ func TestExtractKubeReservedFromKubeEnv(t *testing.T) {
 	}
 }
 
+func TestExtractMaxPodsFromKubeEnv(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		kubeEnvValue    string
+		expectedMaxPods *int64
+	}{
+		{
+			name: "max pods in kubelet args",
+			kubeEnvValue: "DNS_SERVER_IP: '10.0.0.10'\n" +
+				"KUBELET_ARGS: --v=2 --max-pods=32 --experimental-allocatable-ignore-eviction\n",
+			expectedMaxPods: ptr.To[int64](32),
+		},
+		{
+			name: "max pods in kubelet test args",
+			kubeEnvValue: "KUBELET_ARGS: --v=2\n" +
+				"KUBELET_TEST_ARGS: --max-pods=64\n",
+			expectedMaxPods: ptr.To[int64](64),
+		},
+		{
+			name:         "invalid max pods",
+			kubeEnvValue: "KUBELET_ARGS: --max-pods=0\n",
+		},
+		{
+			name:         "no max pods",
+			kubeEnvValue: "KUBELET_ARGS: --v=2\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			kubeEnv, err := ParseKubeEnv("test", tc.kubeEnvValue)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedMaxPods, extractMaxPodsFromKubeEnv(kubeEnv))
+		})
+	}
+}
+
 func TestExtractOperatingSystemFromKubeEnv(t *testing.T) {
 	type testCase struct {
 		name                    string
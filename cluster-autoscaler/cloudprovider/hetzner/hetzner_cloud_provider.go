@@ -213,6 +213,10 @@ func BuildHetzner(_ config.AutoscalingOptions, do cloudprovider.NodeGroupDiscove
 			klog.Fatalf("Failed to get servers for for node pool %s error: %v", nodegroupSpec, err)
 		}
 
+		if _, err := manager.cachedDatacenters.getDatacentersForRegions(spec.regions); err != nil {
+			klog.Fatalf("Failed to get datacenters for node pool %s error: %v", nodegroupSpec, err)
+		}
+
 		var placementGroup *hcloud.PlacementGroup
 		if manager.clusterConfig.IsUsingNewFormat {
 			_, ok := manager.clusterConfig.NodeConfigs[spec.name]
@@ -240,7 +244,7 @@ func BuildHetzner(_ config.AutoscalingOptions, do cloudprovider.NodeGroupDiscove
 			minSize:            spec.minSize,
 			maxSize:            spec.maxSize,
 			instanceType:       strings.ToLower(spec.instanceType),
-			region:             strings.ToLower(spec.region),
+			regions:            spec.regions,
 			targetSize:         len(servers),
 			clusterUpdateMutex: &clusterUpdateLock,
 			placementGroup:     placementGroup,
@@ -287,9 +291,14 @@ func createNodePoolSpec(groupSpec string) (*hetznerNodeGroupSpec, error) {
 
 	definition := hetznerNodeGroupSpec{
 		instanceType: tokens[2],
-		region:       tokens[3],
 		name:         tokens[4],
 	}
+	for _, region := range strings.Split(tokens[3], ",") {
+		if region == "" {
+			return nil, fmt.Errorf("empty region in %s", tokens[3])
+		}
+		definition.regions = append(definition.regions, strings.ToLower(region))
+	}
 	if size, err := strconv.Atoi(tokens[0]); err == nil {
 		definition.minSize = size
 	} else {
@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hetzner
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/hetzner/hcloud-go/hcloud"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+)
+
+const (
+	datacentersCacheKey = "hetzner-datacenters-cache"
+	// Server type availability in datacenters changes frequently, so it's cached for a short time only.
+	datacentersCachedTTL   = time.Minute * 1
+	datacentersCacheMinTTL = 5
+	datacentersCacheMaxTTL = 60
+)
+
+type datacentersCache struct {
+	cache.Store
+	mngJitterClock      clock.Clock
+	hcloudClient        *hcloud.Client
+	hcloudClientContext context.Context
+}
+
+type datacentersClock struct {
+	clock.Clock
+
+	jitter bool
+	sync.RWMutex
+}
+
+func (c *datacentersClock) Since(ts time.Time) time.Duration {
+	since := time.Since(ts)
+	c.RLock()
+	defer c.RUnlock()
+	if c.jitter {
+		return since + (time.Second * time.Duration(rand.IntnRange(datacentersCacheMinTTL, datacentersCacheMaxTTL)))
+	}
+	return since
+}
+
+type datacentersCachedObject struct {
+	name        string
+	datacenters []*hcloud.Datacenter
+}
+
+func newDatacentersCache(ctx context.Context, hcloudClient *hcloud.Client) *datacentersCache {
+	jc := &datacentersClock{}
+	return newDatacentersCacheWithClock(
+		ctx,
+		hcloudClient,
+		jc,
+		cache.NewExpirationStore(func(obj interface{}) (s string, e error) {
+			return obj.(datacentersCachedObject).name, nil
+		}, &cache.TTLPolicy{
+			TTL:   datacentersCachedTTL,
+			Clock: jc,
+		}),
+	)
+}
+
+func newDatacentersCacheWithClock(ctx context.Context, hcloudClient *hcloud.Client, jc clock.Clock, store cache.Store) *datacentersCache {
+	return &datacentersCache{
+		store,
+		jc,
+		hcloudClient,
+		ctx,
+	}
+}
+
+func (m *datacentersCache) datacenters() ([]*hcloud.Datacenter, error) {
+	klog.Warning("Fetching datacenters from Hetzner API")
+
+	datacenters, err := m.hcloudClient.Datacenter.All(m.hcloudClientContext)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheObject := datacentersCachedObject{
+		name:        datacentersCacheKey,
+		datacenters: datacenters,
+	}
+
+	if err := m.Add(cacheObject); err != nil {
+		return nil, err
+	}
+
+	return datacenters, nil
+}
+
+func (m *datacentersCache) getAllDatacenters() ([]*hcloud.Datacenter, error) {
+	// List expires old entries
+	cacheList := m.List()
+	klog.V(5).Infof("Current datacentersCache len: %d\n", len(cacheList))
+
+	if obj, found, err := m.GetByKey(datacentersCacheKey); err == nil && found {
+		foundDatacenters := obj.(datacentersCachedObject)
+
+		return foundDatacenters.datacenters, nil
+	}
+
+	return m.datacenters()
+}
+
+// getDatacentersForRegions returns the datacenters matching the given regions, in the order of the regions.
+// A region is either a location name (e.g. fsn1), matching all of its datacenters, or a datacenter name
+// (e.g. fsn1-dc14).
+func (m *datacentersCache) getDatacentersForRegions(regions []string) ([]*hcloud.Datacenter, error) {
+	datacenters, err := m.getAllDatacenters()
+	if err != nil {
+		return nil, err
+	}
+
+	var foundDatacenters []*hcloud.Datacenter
+	seen := make(map[string]bool)
+	for _, region := range regions {
+		found := false
+		for _, datacenter := range datacenters {
+			if datacenter.Name != region && (datacenter.Location == nil || datacenter.Location.Name != region) {
+				continue
+			}
+			found = true
+			if !seen[datacenter.Name] {
+				seen[datacenter.Name] = true
+				foundDatacenters = append(foundDatacenters, datacenter)
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no datacenter found for region %s", region)
+		}
+	}
+
+	return foundDatacenters, nil
+}
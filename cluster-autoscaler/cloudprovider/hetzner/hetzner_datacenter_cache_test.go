@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hetzner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/hetzner/hcloud-go/hcloud"
+)
+
+func TestDatacentersCache(t *testing.T) {
+	c := newDatacentersCache(context.Background(), nil)
+
+	datacenters := []*hcloud.Datacenter{
+		{
+			Name:     "fsn1-dc14",
+			Location: &hcloud.Location{Name: "fsn1"},
+		},
+		{
+			Name:     "nbg1-dc3",
+			Location: &hcloud.Location{Name: "nbg1"},
+		},
+		{
+			Name:     "hel1-dc2",
+			Location: &hcloud.Location{Name: "hel1"},
+		},
+	}
+
+	err := c.Add(datacentersCachedObject{
+		name:        datacentersCacheKey,
+		datacenters: datacenters,
+	})
+	require.NoError(t, err)
+
+	foundDatacenters, err := c.getAllDatacenters()
+	require.NoError(t, err)
+	assert.Equal(t, 3, len(foundDatacenters))
+
+	foundDatacenters, err = c.getDatacentersForRegions([]string{"hel1", "fsn1-dc14", "fsn1"})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(foundDatacenters))
+	assert.Equal(t, "hel1-dc2", foundDatacenters[0].Name)
+	assert.Equal(t, "fsn1-dc14", foundDatacenters[1].Name)
+
+	_, err = c.getDatacentersForRegions([]string{"fsn1", "ash"})
+	require.Error(t, err)
+}
@@ -43,18 +43,19 @@ var (
 // hetznerManager handles Hetzner communication and data caching of
 // node groups
 type hetznerManager struct {
-	client           *hcloud.Client
-	nodeGroups       map[string]*hetznerNodeGroup
-	apiCallContext   context.Context
-	clusterConfig    *ClusterConfig
-	sshKey           *hcloud.SSHKey
-	network          *hcloud.Network
-	firewall         *hcloud.Firewall
-	createTimeout    time.Duration
-	publicIPv4       bool
-	publicIPv6       bool
-	cachedServerType *serverTypeCache
-	cachedServers    *serversCache
+	client            *hcloud.Client
+	nodeGroups        map[string]*hetznerNodeGroup
+	apiCallContext    context.Context
+	clusterConfig     *ClusterConfig
+	sshKey            *hcloud.SSHKey
+	network           *hcloud.Network
+	firewall          *hcloud.Firewall
+	createTimeout     time.Duration
+	publicIPv4        bool
+	publicIPv6        bool
+	cachedServerType  *serverTypeCache
+	cachedServers     *serversCache
+	cachedDatacenters *datacentersCache
 }
 
 // ClusterConfig holds the configuration for all the nodepools
@@ -200,18 +201,19 @@ func newManager() (*hetznerManager, error) {
 	}
 
 	m := &hetznerManager{
-		client:           client,
-		nodeGroups:       make(map[string]*hetznerNodeGroup),
-		sshKey:           sshKey,
-		network:          network,
-		firewall:         firewall,
-		createTimeout:    createTimeout,
-		apiCallContext:   ctx,
-		publicIPv4:       publicIPv4,
-		publicIPv6:       publicIPv6,
-		clusterConfig:    clusterConfig,
-		cachedServerType: newServerTypeCache(ctx, client),
-		cachedServers:    newServersCache(ctx, client),
+		client:            client,
+		nodeGroups:        make(map[string]*hetznerNodeGroup),
+		sshKey:            sshKey,
+		network:           network,
+		firewall:          firewall,
+		createTimeout:     createTimeout,
+		apiCallContext:    ctx,
+		publicIPv4:        publicIPv4,
+		publicIPv6:        publicIPv6,
+		clusterConfig:     clusterConfig,
+		cachedServerType:  newServerTypeCache(ctx, client),
+		cachedServers:     newServersCache(ctx, client),
+		cachedDatacenters: newDatacentersCache(ctx, client),
 	}
 
 	return m, nil
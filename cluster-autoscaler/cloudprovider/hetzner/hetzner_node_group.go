@@ -44,7 +44,7 @@ type hetznerNodeGroup struct {
 	minSize      int
 	maxSize      int
 	targetSize   int
-	region       string
+	regions      []string
 	instanceType string
 
 	clusterUpdateMutex *sync.Mutex
@@ -55,7 +55,7 @@ type hetznerNodeGroupSpec struct {
 	name         string
 	minSize      int
 	maxSize      int
-	region       string
+	regions      []string
 	instanceType string
 }
 
@@ -104,13 +104,18 @@ func (n *hetznerNodeGroup) IncreaseSize(delta int) error {
 	n.clusterUpdateMutex.Lock()
 	defer n.clusterUpdateMutex.Unlock()
 
-	available, err := serverTypeAvailable(n.manager, n.instanceType, n.region)
+	datacenters, err := availableDatacenters(n)
 	if err != nil {
-		return fmt.Errorf("failed to check if type %s is available in region %s error: %v", n.instanceType, n.region, err)
+		return fmt.Errorf("failed to check if type %s is available in regions %s error: %v", n.instanceType, strings.Join(n.regions, ","), err)
 	}
-	if !available {
-		return fmt.Errorf("server type %s not available in region %s", n.instanceType, n.region)
+	if len(datacenters) == 0 {
+		return fmt.Errorf("server type %s not available in regions %s", n.instanceType, strings.Join(n.regions, ","))
 	}
+	servers, err := n.manager.allServers(n.id)
+	if err != nil {
+		return err
+	}
+	plannedDatacenters := spreadOverDatacenters(datacenters, servers, delta)
 
 	defer func() {
 		// create new servers cache
@@ -131,14 +136,14 @@ func (n *hetznerNodeGroup) IncreaseSize(delta int) error {
 	errsCh := make(chan error, delta)
 	for i := 0; i < delta; i++ {
 		waitGroup.Add(1)
-		go func() {
+		go func(datacenter *hcloud.Datacenter) {
 			defer waitGroup.Done()
-			err := createServer(n)
+			err := createServer(n, withPreferredDatacenter(datacenter, datacenters))
 			if err != nil {
 				actualDelta--
 				errsCh <- err
 			}
-		}()
+		}(plannedDatacenters[i])
 	}
 	waitGroup.Wait()
 	close(errsCh)
@@ -385,13 +390,18 @@ func buildNodeGroupLabels(n *hetznerNodeGroup) (map[string]string, error) {
 	if err != nil {
 		return nil, err
 	}
+	datacenter, err := templateDatacenter(n)
+	if err != nil {
+		return nil, err
+	}
 	klog.V(4).Infof("Build node group label for %s", n.id)
 
 	labels := map[string]string{
 		apiv1.LabelInstanceType:              n.instanceType,
-		apiv1.LabelTopologyRegion:            n.region,
+		apiv1.LabelTopologyRegion:            datacenter.Location.Name,
+		apiv1.LabelTopologyZone:              datacenter.Name,
 		apiv1.LabelArchStable:                archLabel,
-		"csi.hetzner.cloud/location":         n.region,
+		"csi.hetzner.cloud/location":         datacenter.Location.Name,
 		"instance.hetzner.cloud/provided-by": "cloud",
 		nodeGroupLabel:                       n.id,
 	}
@@ -420,19 +430,89 @@ func getMachineTypeResourceList(m *hetznerManager, instanceType string) (apiv1.R
 	}, nil
 }
 
-func serverTypeAvailable(manager *hetznerManager, instanceType string, region string) (bool, error) {
-	serverType, err := manager.cachedServerType.getServerType(instanceType)
+// availableDatacenters returns the datacenters of the node group regions in which its server type
+// can currently be created.
+func availableDatacenters(n *hetznerNodeGroup) ([]*hcloud.Datacenter, error) {
+	datacenters, err := n.manager.cachedDatacenters.getDatacentersForRegions(n.regions)
+	if err != nil {
+		return nil, err
+	}
+
+	return datacentersWithServerType(datacenters, n.instanceType), nil
+}
+
+// templateDatacenter returns the datacenter the next server of the node group would be created in.
+// If the server type isn't available anywhere, the first datacenter of the node group is used so
+// that a template can still be built.
+func templateDatacenter(n *hetznerNodeGroup) (*hcloud.Datacenter, error) {
+	datacenters, err := n.manager.cachedDatacenters.getDatacentersForRegions(n.regions)
+	if err != nil {
+		return nil, err
+	}
+	if len(datacenters) == 0 {
+		return nil, fmt.Errorf("no datacenter found for regions %s", strings.Join(n.regions, ","))
+	}
+	servers, err := n.manager.allServers(n.id)
 	if err != nil {
-		return false, err
+		return nil, err
+	}
+
+	available := datacentersWithServerType(datacenters, n.instanceType)
+	if len(available) == 0 {
+		return datacenters[0], nil
+	}
+	return spreadOverDatacenters(available, servers, 1)[0], nil
+}
+
+func datacentersWithServerType(datacenters []*hcloud.Datacenter, instanceType string) []*hcloud.Datacenter {
+	var found []*hcloud.Datacenter
+	for _, datacenter := range datacenters {
+		for _, serverType := range datacenter.ServerTypes.Available {
+			if serverType.Name == instanceType {
+				found = append(found, datacenter)
+				break
+			}
+		}
 	}
 
-	for _, price := range serverType.Pricings {
-		if price.Location.Name == region {
-			return true, nil
+	return found
+}
+
+// spreadOverDatacenters returns the datacenters the next count servers should be created in, so that
+// the servers are spread evenly. Ties are broken by the order of the datacenters.
+func spreadOverDatacenters(datacenters []*hcloud.Datacenter, servers []*hcloud.Server, count int) []*hcloud.Datacenter {
+	serversPerDatacenter := make(map[string]int)
+	for _, server := range servers {
+		if server.Datacenter != nil {
+			serversPerDatacenter[server.Datacenter.Name]++
 		}
 	}
 
-	return false, nil
+	planned := make([]*hcloud.Datacenter, 0, count)
+	for i := 0; i < count; i++ {
+		var selected *hcloud.Datacenter
+		for _, datacenter := range datacenters {
+			if selected == nil || serversPerDatacenter[datacenter.Name] < serversPerDatacenter[selected.Name] {
+				selected = datacenter
+			}
+		}
+		serversPerDatacenter[selected.Name]++
+		planned = append(planned, selected)
+	}
+
+	return planned
+}
+
+// withPreferredDatacenter returns the datacenters with the preferred one first.
+func withPreferredDatacenter(preferred *hcloud.Datacenter, datacenters []*hcloud.Datacenter) []*hcloud.Datacenter {
+	ordered := []*hcloud.Datacenter{preferred}
+	for _, datacenter := range datacenters {
+		if datacenter != preferred {
+			ordered = append(ordered, datacenter)
+		}
+	}
+
+	return ordered
 }
 
 func instanceTypeArch(manager *hetznerManager, instanceType string) (string, error) {
@@ -451,7 +531,8 @@ func instanceTypeArch(manager *hetznerManager, instanceType string) (string, err
 	}
 }
 
-func createServer(n *hetznerNodeGroup) error {
+// createServer creates a server in the first of the given datacenters having enough capacity.
+func createServer(n *hetznerNodeGroup, datacenters []*hcloud.Datacenter) error {
 	ctx, cancel := context.WithTimeout(n.manager.apiCallContext, n.manager.createTimeout)
 	defer cancel()
 
@@ -471,11 +552,41 @@ func createServer(n *hetznerNodeGroup) error {
 		cloudInit = n.manager.clusterConfig.NodeConfigs[n.id].CloudInit
 	}
 
+	var serverCreateResult hcloud.ServerCreateResult
+	for _, datacenter := range datacenters {
+		serverCreateResult, err = createServerInDatacenter(ctx, n, datacenter, serverType, image, cloudInit)
+		if err == nil {
+			break
+		}
+		if !hcloud.IsError(err, hcloud.ErrorCodeResourceUnavailable) {
+			return err
+		}
+		klog.Warningf("Server type %s unavailable in datacenter %s, trying next datacenter: %v", n.instanceType, datacenter.Name, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	server := serverCreateResult.Server
+
+	actions := append(serverCreateResult.NextActions, serverCreateResult.Action)
+
+	// Delete the server if any action (most importantly create_server & start_server) fails
+	err = n.manager.client.Action.WaitFor(ctx, actions...)
+	if err != nil {
+		_ = n.manager.deleteServer(server)
+		return fmt.Errorf("failed to start server %s error: %v", server.Name, err)
+	}
+
+	return nil
+}
+
+func createServerInDatacenter(ctx context.Context, n *hetznerNodeGroup, datacenter *hcloud.Datacenter, serverType *hcloud.ServerType, image *hcloud.Image, cloudInit string) (hcloud.ServerCreateResult, error) {
 	StartAfterCreate := true
 	opts := hcloud.ServerCreateOpts{
 		Name:             newNodeName(n),
 		UserData:         cloudInit,
-		Location:         &hcloud.Location{Name: n.region},
+		Datacenter:       &hcloud.Datacenter{Name: datacenter.Name},
 		ServerType:       serverType,
 		Image:            image,
 		StartAfterCreate: &StartAfterCreate,
@@ -501,21 +612,10 @@ func createServer(n *hetznerNodeGroup) error {
 
 	serverCreateResult, _, err := n.manager.client.Server.Create(ctx, opts)
 	if err != nil {
-		return fmt.Errorf("could not create server type %s in region %s: %v", n.instanceType, n.region, err)
+		return serverCreateResult, fmt.Errorf("could not create server type %s in datacenter %s: %w", n.instanceType, datacenter.Name, err)
 	}
 
-	server := serverCreateResult.Server
-
-	actions := append(serverCreateResult.NextActions, serverCreateResult.Action)
-
-	// Delete the server if any action (most importantly create_server & start_server) fails
-	err = n.manager.client.Action.WaitFor(ctx, actions...)
-	if err != nil {
-		_ = n.manager.deleteServer(server)
-		return fmt.Errorf("failed to start server %s error: %v", server.Name, err)
-	}
-
-	return nil
+	return serverCreateResult, nil
 }
 
 // findImage searches for an image ID corresponding to the supplied
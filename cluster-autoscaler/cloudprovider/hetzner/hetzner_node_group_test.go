@@ -0,0 +1,45 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hetzner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/hetzner/hcloud-go/hcloud"
+)
+
+func TestSpreadOverDatacenters(t *testing.T) {
+	cx22 := &hcloud.ServerType{Name: "cx22"}
+	cax11 := &hcloud.ServerType{Name: "cax11"}
+	fsn1 := &hcloud.Datacenter{Name: "fsn1-dc14", ServerTypes: hcloud.DatacenterServerTypes{Available: []*hcloud.ServerType{cx22, cax11}}}
+	nbg1 := &hcloud.Datacenter{Name: "nbg1-dc3", ServerTypes: hcloud.DatacenterServerTypes{Available: []*hcloud.ServerType{cx22}}}
+	hel1 := &hcloud.Datacenter{Name: "hel1-dc2", ServerTypes: hcloud.DatacenterServerTypes{Available: []*hcloud.ServerType{cax11}}}
+
+	available := datacentersWithServerType([]*hcloud.Datacenter{fsn1, nbg1, hel1}, "cx22")
+	assert.Equal(t, []*hcloud.Datacenter{fsn1, nbg1}, available)
+
+	servers := []*hcloud.Server{
+		{Name: "s1", Datacenter: fsn1},
+		{Name: "s2", Datacenter: fsn1},
+		{Name: "s3", Datacenter: nbg1},
+	}
+	planned := spreadOverDatacenters(available, servers, 3)
+	assert.Equal(t, []*hcloud.Datacenter{nbg1, fsn1, nbg1}, planned)
+
+	assert.Equal(t, []*hcloud.Datacenter{nbg1, fsn1, hel1}, withPreferredDatacenter(nbg1, []*hcloud.Datacenter{fsn1, nbg1, hel1}))
+}
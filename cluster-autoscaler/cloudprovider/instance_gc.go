@@ -0,0 +1,30 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudprovider
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// InstanceGarbageCollector is implemented by cloud providers able to delete orphaned instances, which were created
+// for the cluster but never joined it, e.g. because their bootstrap failed, and which aren't part of any node group,
+// so they aren't removed along with other unregistered nodes.
+type InstanceGarbageCollector interface {
+	// GC deletes the orphaned instances, given all nodes registered in the cluster. It's called in every loop,
+	// cloud providers are responsible for limiting how often they look for orphaned instances.
+	GC(registeredNodes []*apiv1.Node) error
+}
@@ -149,5 +149,26 @@ func LoadConfigFile(kubeClient kubeclient.Interface) (*KwokProviderConfig, error
 		kwokConfig.Kwok = &KwokConfig{}
 	}
 
+	if kwokConfig.Scenario != nil {
+		if err := validateScenario(kwokConfig.Scenario); err != nil {
+			return nil, err
+		}
+	}
+
 	return &kwokConfig, nil
 }
+
+func validateScenario(s *ScenarioConfig) error {
+	for i, phase := range s.Phases {
+		if phase.After.Duration < 0 {
+			return fmt.Errorf("'scenario.phases[%d].after' in kwok config can't be negative: %v", i, phase.After.Duration)
+		}
+		if phase.ProvisioningDelay.Duration < 0 {
+			return fmt.Errorf("'scenario.phases[%d].provisioningDelay' in kwok config can't be negative: %v", i, phase.ProvisioningDelay.Duration)
+		}
+		if phase.FailureRate < 0 || phase.FailureRate > 1 {
+			return fmt.Errorf("'scenario.phases[%d].failureRate' in kwok config should be between 0 and 1: %v", i, phase.FailureRate)
+		}
+	}
+	return nil
+}
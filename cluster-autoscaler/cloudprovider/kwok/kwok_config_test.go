@@ -18,12 +18,14 @@ package kwok
 
 import (
 	"testing"
+	"time"
 
 	"os"
 
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
 	core "k8s.io/client-go/testing"
@@ -283,3 +285,20 @@ func TestLoadConfigFile(t *testing.T) {
 	assert.NotNil(t, kwokConfig.status)
 	assert.NotEmpty(t, kwokConfig.status.gpuLabel)
 }
+
+func TestValidateScenario(t *testing.T) {
+	valid := &ScenarioConfig{Phases: []ScenarioPhase{
+		{ProvisioningDelay: metav1.Duration{Duration: time.Minute}, FailureRate: 0.1},
+		{After: metav1.Duration{Duration: 10 * time.Minute}, Stockout: true},
+	}}
+	assert.NoError(t, validateScenario(valid))
+
+	for _, phase := range []ScenarioPhase{
+		{After: metav1.Duration{Duration: -time.Minute}},
+		{ProvisioningDelay: metav1.Duration{Duration: -time.Minute}},
+		{FailureRate: -0.1},
+		{FailureRate: 1.1},
+	} {
+		assert.Error(t, validateScenario(&ScenarioConfig{Phases: []ScenarioPhase{phase}}))
+	}
+}
@@ -57,22 +57,47 @@ func (nodeGroup *NodeGroup) TargetSize() (int, error) {
 
 // IncreaseSize increases NodeGroup size.
 func (nodeGroup *NodeGroup) IncreaseSize(delta int) error {
+	_, err := nodeGroup.increaseSize(delta, false)
+	return err
+}
+
+// IncreaseSizeAsync increases NodeGroup size, returning an operation reporting when the requested
+// nodes are provisioned. It's only implemented for nodegroups with a scenario enabling async scale-ups.
+func (nodeGroup *NodeGroup) IncreaseSizeAsync(delta int) (cloudprovider.ScaleUpOperation, error) {
+	if nodeGroup.scenario == nil || !nodeGroup.scenario.asyncScaleUps {
+		return nil, cloudprovider.ErrNotImplemented
+	}
+	instances, err := nodeGroup.increaseSize(delta, true)
+	if err != nil {
+		return nil, err
+	}
+	return &scaleUpOperation{
+		id:        fmt.Sprintf("%s-%s", nodeGroup.name, rand.String(5)),
+		nodeGroup: nodeGroup,
+		delta:     delta,
+		instances: instances,
+	}, nil
+}
+
+// increaseSize requests delta new nodes and returns the instances which weren't created right away
+func (nodeGroup *NodeGroup) increaseSize(delta int, async bool) ([]*pendingInstance, error) {
 	if delta <= 0 {
-		return fmt.Errorf(sizeIncreaseMustBePositiveErr)
+		return nil, fmt.Errorf(sizeIncreaseMustBePositiveErr)
 	}
 	size := nodeGroup.targetSize
 	newSize := int(size) + delta
 	if newSize > nodeGroup.MaxSize() {
-		return fmt.Errorf("%s, desired: %d max: %d", maxSizeReachedErr, newSize, nodeGroup.MaxSize())
+		return nil, fmt.Errorf("%s, desired: %d max: %d", maxSizeReachedErr, newSize, nodeGroup.MaxSize())
 	}
 
 	klog.V(5).Infof("increasing size of nodegroup '%s' to %v (old size: %v, delta: %v)", nodeGroup.name, newSize, size, delta)
 
 	schedNode, err := nodeGroup.TemplateNodeInfo()
 	if err != nil {
-		return fmt.Errorf("couldn't create a template node for nodegroup %s", nodeGroup.name)
+		return nil, fmt.Errorf("couldn't create a template node for nodegroup %s", nodeGroup.name)
 	}
 
+	pending := []*pendingInstance{}
 	for i := 0; i < delta; i++ {
 		node := schedNode.Node()
 		node.Name = fmt.Sprintf("%s-%s", nodeGroup.name, rand.String(5))
@@ -81,14 +106,23 @@ func (nodeGroup *NodeGroup) IncreaseSize(delta int) error {
 		}
 		node.Annotations["metrics.k8s.io/resource-metrics-path"] = fmt.Sprintf("/metrics/nodes/%s/metrics/resource", node.Name)
 		node.Spec.ProviderID = getProviderID(node.Name)
+		if nodeGroup.scenario != nil {
+			if instance := nodeGroup.scenario.requestInstance(nodeGroup.name, node.DeepCopy()); instance != nil {
+				instance.async = async
+				pending = append(pending, instance)
+				nodeGroup.pendingInstances = append(nodeGroup.pendingInstances, instance)
+				nodeGroup.targetSize += 1
+				continue
+			}
+		}
 		_, err := nodeGroup.kubeClient.CoreV1().Nodes().Create(context.Background(), node, v1.CreateOptions{})
 		if err != nil {
-			return fmt.Errorf("couldn't create new node '%s': %v", node.Name, err)
+			return pending, fmt.Errorf("couldn't create new node '%s': %v", node.Name, err)
 		}
 		nodeGroup.targetSize += 1
 	}
 
-	return nil
+	return pending, nil
 }
 
 // AtomicIncreaseSize is not implemented.
@@ -98,6 +132,11 @@ func (nodeGroup *NodeGroup) AtomicIncreaseSize(delta int) error {
 
 // DeleteNodes deletes the specified nodes from the node group.
 func (nodeGroup *NodeGroup) DeleteNodes(nodes []*apiv1.Node) error {
+	nodes = nodeGroup.deletePendingInstances(nodes)
+	if len(nodes) == 0 {
+		return nil
+	}
+
 	size := nodeGroup.targetSize
 	if size <= nodeGroup.MinSize() {
 		return fmt.Errorf(minSizeReachedErr)
@@ -148,10 +187,69 @@ func (nodeGroup *NodeGroup) DecreaseTargetSize(delta int) error {
 	}
 
 	nodeGroup.targetSize = newSize
+	// drop the pending instances which don't fit in the new size, the most recent ones first
+	for len(nodeGroup.pendingInstances) > 0 && len(nodes)+len(nodeGroup.pendingInstances) > newSize {
+		nodeGroup.pendingInstances = nodeGroup.pendingInstances[:len(nodeGroup.pendingInstances)-1]
+	}
 
 	return nil
 }
 
+// deletePendingInstances deletes the pending instances of the given nodes
+// and returns the remaining nodes
+func (nodeGroup *NodeGroup) deletePendingInstances(nodes []*apiv1.Node) []*apiv1.Node {
+	if len(nodeGroup.pendingInstances) == 0 {
+		return nodes
+	}
+	remaining := []*apiv1.Node{}
+	for _, node := range nodes {
+		found := false
+		for i, instance := range nodeGroup.pendingInstances {
+			if instance.node.Spec.ProviderID == node.Spec.ProviderID {
+				nodeGroup.pendingInstances = append(nodeGroup.pendingInstances[:i], nodeGroup.pendingInstances[i+1:]...)
+				nodeGroup.targetSize -= 1
+				found = true
+				break
+			}
+		}
+		if !found {
+			remaining = append(remaining, node)
+		}
+	}
+	return remaining
+}
+
+// dropPendingInstances removes the given instances, if still pending, from the nodegroup
+func (nodeGroup *NodeGroup) dropPendingInstances(instances []*pendingInstance) {
+	for _, dropped := range instances {
+		for i, instance := range nodeGroup.pendingInstances {
+			if instance == dropped {
+				nodeGroup.pendingInstances = append(nodeGroup.pendingInstances[:i], nodeGroup.pendingInstances[i+1:]...)
+				nodeGroup.targetSize -= 1
+				break
+			}
+		}
+	}
+}
+
+// provisionPendingInstances creates the nodes of the pending instances provisioned by now,
+// failed instances are kept until they get deleted
+func (nodeGroup *NodeGroup) provisionPendingInstances() {
+	pending := []*pendingInstance{}
+	for _, instance := range nodeGroup.pendingInstances {
+		if instance.errorInfo != nil || !nodeGroup.scenario.ready(instance) {
+			pending = append(pending, instance)
+			continue
+		}
+		_, err := nodeGroup.kubeClient.CoreV1().Nodes().Create(context.Background(), instance.node, v1.CreateOptions{})
+		if err != nil {
+			klog.Errorf("couldn't create new node '%s': %v", instance.node.Name, err)
+			pending = append(pending, instance)
+		}
+	}
+	nodeGroup.pendingInstances = pending
+}
+
 // getNodeNamesForNodeGroup returns list of nodes belonging to the nodegroup
 func (nodeGroup *NodeGroup) getNodeNamesForNodeGroup() ([]string, error) {
 	names := []string{}
@@ -191,6 +289,13 @@ func (nodeGroup *NodeGroup) Nodes() ([]cloudprovider.Instance, error) {
 			ErrorInfo: nil,
 		}})
 	}
+	for _, instance := range nodeGroup.pendingInstances {
+		status := &cloudprovider.InstanceStatus{State: cloudprovider.InstanceCreating}
+		if instance.errorInfo != nil && !instance.async && nodeGroup.scenario.ready(instance) {
+			status.ErrorInfo = instance.errorInfo
+		}
+		instances = append(instances, cloudprovider.Instance{Id: instance.node.Spec.ProviderID, Status: status})
+	}
 	return instances, nil
 }
 
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
@@ -145,7 +146,10 @@ func (kwok *KwokCloudProvider) Refresh() error {
 	}
 
 	for _, ng := range kwok.nodeGroups {
-		ng.targetSize = targetSizeInCluster[ng.Id()]
+		ng.targetSize = targetSizeInCluster[ng.Id()] + len(ng.pendingInstances)
+		if ng.scenario != nil {
+			ng.provisionPendingInstances()
+		}
 	}
 
 	return nil
@@ -246,6 +250,14 @@ func BuildKwokProvider(ko *kwokOptions) (*KwokCloudProvider, error) {
 
 	nodegroups = createNodegroups(nodeTemplates, ko.kubeClient, kwokConfig, ko.ngNodeListerFn, ko.allNodesLister)
 
+	if kwokConfig.Scenario != nil {
+		s := newScenario(kwokConfig.Scenario, time.Now)
+		for _, ng := range nodegroups {
+			ng.scenario = s
+		}
+		klog.Infof("scripting node provisioning with a scenario of %d phases", len(kwokConfig.Scenario.Phases))
+	}
+
 	return &KwokCloudProvider{
 		nodeGroups:      nodegroups,
 		kubeClient:      ko.kubeClient,
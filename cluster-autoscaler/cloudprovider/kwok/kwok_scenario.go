@@ -0,0 +1,189 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kwok
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	klog "k8s.io/klog/v2"
+)
+
+const (
+	stockoutErrorCode           = "STOCKOUT"
+	provisioningFailedErrorCode = "PROVISIONING_FAILED"
+)
+
+// scenario decides how the nodes requested by scale-ups are provisioned,
+// following the phases of a ScenarioConfig
+type scenario struct {
+	// phases are sorted by their start time
+	phases []ScenarioPhase
+	start  time.Time
+	now    func() time.Time
+	// asyncScaleUps makes nodegroups implement cloudprovider.AsyncSizeIncreaser
+	asyncScaleUps bool
+
+	// rand is shared by nodegroups scaled up in parallel
+	randLock sync.Mutex
+	rand     *rand.Rand
+}
+
+// pendingInstance is an instance requested by a scale-up which isn't a node yet
+type pendingInstance struct {
+	node *apiv1.Node
+	// readyAt is when the instance is provisioned, or fails if errorInfo is set
+	readyAt   time.Time
+	errorInfo *cloudprovider.InstanceErrorInfo
+	// async is set for instances requested by an asynchronous scale-up, whose failure
+	// is reported by the scale-up operation instead of the instance status
+	async bool
+}
+
+func newScenario(config *ScenarioConfig, now func() time.Time) *scenario {
+	phases := append([]ScenarioPhase{}, config.Phases...)
+	sort.SliceStable(phases, func(i, j int) bool {
+		return phases[i].After.Duration < phases[j].After.Duration
+	})
+	return &scenario{
+		phases:        phases,
+		start:         now(),
+		now:           now,
+		asyncScaleUps: config.AsyncScaleUps,
+		rand:          rand.New(rand.NewSource(config.Seed)),
+	}
+}
+
+// currentPhase returns the latest started phase applying to the nodegroup,
+// nil if there is none
+func (s *scenario) currentPhase(ngName string) *ScenarioPhase {
+	elapsed := s.now().Sub(s.start)
+	var current *ScenarioPhase
+	for i := range s.phases {
+		if s.phases[i].After.Duration > elapsed {
+			break
+		}
+		if appliesTo(&s.phases[i], ngName) {
+			current = &s.phases[i]
+		}
+	}
+	return current
+}
+
+func appliesTo(phase *ScenarioPhase, ngName string) bool {
+	if len(phase.Nodegroups) == 0 {
+		return true
+	}
+	for _, name := range phase.Nodegroups {
+		if name == ngName {
+			return true
+		}
+	}
+	return false
+}
+
+// requestInstance decides how the node requested for the nodegroup is provisioned,
+// it returns nil if the node should be created right away
+func (s *scenario) requestInstance(ngName string, node *apiv1.Node) *pendingInstance {
+	phase := s.currentPhase(ngName)
+	if phase == nil {
+		return nil
+	}
+	now := s.now()
+	if phase.Stockout {
+		klog.V(2).Infof("scenario: node '%s' of nodegroup '%s' failed because of a stockout", node.Name, ngName)
+		return &pendingInstance{
+			node:    node,
+			readyAt: now,
+			errorInfo: &cloudprovider.InstanceErrorInfo{
+				ErrorClass:   cloudprovider.OutOfResourcesErrorClass,
+				ErrorCode:    stockoutErrorCode,
+				ErrorMessage: "kwok scenario: out of resources",
+			},
+		}
+	}
+	instance := &pendingInstance{
+		node:    node,
+		readyAt: now.Add(phase.ProvisioningDelay.Duration),
+	}
+	if s.fails(phase.FailureRate) {
+		klog.V(2).Infof("scenario: node '%s' of nodegroup '%s' will fail being provisioned at %v", node.Name, ngName, instance.readyAt)
+		instance.errorInfo = &cloudprovider.InstanceErrorInfo{
+			ErrorClass:   cloudprovider.OtherErrorClass,
+			ErrorCode:    provisioningFailedErrorCode,
+			ErrorMessage: "kwok scenario: provisioning failed",
+		}
+	} else if phase.ProvisioningDelay.Duration == 0 {
+		return nil
+	}
+	return instance
+}
+
+func (s *scenario) fails(failureRate float64) bool {
+	if failureRate <= 0 {
+		return false
+	}
+	s.randLock.Lock()
+	defer s.randLock.Unlock()
+	return s.rand.Float64() < failureRate
+}
+
+// ready tells if the instance was provisioned or failed by now
+func (s *scenario) ready(instance *pendingInstance) bool {
+	return !s.now().Before(instance.readyAt)
+}
+
+// scaleUpOperation is an asynchronous scale-up of a nodegroup with a scenario
+type scaleUpOperation struct {
+	id        string
+	nodeGroup *NodeGroup
+	delta     int
+	// instances are the requested instances which weren't created right away
+	instances []*pendingInstance
+}
+
+// Id returns the identifier of the operation.
+func (op *scaleUpOperation) Id() string {
+	return op.id
+}
+
+// Status returns the status of the operation. Once all the requested instances are either
+// provisioned or failed, the failed ones are removed from the nodegroup and the operation fails.
+func (op *scaleUpOperation) Status() (cloudprovider.ScaleUpOperationStatus, error) {
+	status := cloudprovider.ScaleUpOperationStatus{State: cloudprovider.ScaleUpOperationProvisioned, ProvisionedCount: op.delta}
+	failed := []*pendingInstance{}
+	for _, instance := range op.instances {
+		if !op.nodeGroup.scenario.ready(instance) {
+			status.State = cloudprovider.ScaleUpOperationAccepted
+			status.ProvisionedCount--
+		} else if instance.errorInfo != nil {
+			failed = append(failed, instance)
+			status.ProvisionedCount--
+		}
+	}
+	if status.State == cloudprovider.ScaleUpOperationAccepted || len(failed) == 0 {
+		return status, nil
+	}
+	status.State = cloudprovider.ScaleUpOperationFailed
+	status.ErrorInfo = failed[0].errorInfo
+	op.nodeGroup.dropPendingInstances(failed)
+	return status, nil
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kwok
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestScenarioCurrentPhase(t *testing.T) {
+	now := time.Now()
+	s := newScenario(&ScenarioConfig{
+		Phases: []ScenarioPhase{
+			{After: metav1.Duration{Duration: 10 * time.Minute}, Nodegroups: []string{"ng-1"}, Stockout: true},
+			{After: metav1.Duration{Duration: 0}, ProvisioningDelay: metav1.Duration{Duration: time.Minute}},
+			{After: metav1.Duration{Duration: 20 * time.Minute}, FailureRate: 0.5},
+		},
+	}, func() time.Time { return now })
+
+	assert.Equal(t, time.Minute, s.currentPhase("ng-1").ProvisioningDelay.Duration)
+	assert.False(t, s.currentPhase("ng-1").Stockout)
+
+	now = now.Add(10 * time.Minute)
+	assert.True(t, s.currentPhase("ng-1").Stockout)
+	assert.False(t, s.currentPhase("ng-2").Stockout)
+
+	now = now.Add(10 * time.Minute)
+	assert.Equal(t, 0.5, s.currentPhase("ng-1").FailureRate)
+	assert.Equal(t, 0.5, s.currentPhase("ng-2").FailureRate)
+
+	s = newScenario(&ScenarioConfig{
+		Phases: []ScenarioPhase{{After: metav1.Duration{Duration: time.Minute}, Stockout: true}},
+	}, func() time.Time { return now })
+	assert.Nil(t, s.currentPhase("ng-1"))
+}
+
+func TestScenarioFailureRateIsReproducible(t *testing.T) {
+	config := &ScenarioConfig{Seed: 42, Phases: []ScenarioPhase{{FailureRate: 0.5}}}
+	failures := func() []bool {
+		s := newScenario(config, time.Now)
+		result := []bool{}
+		for i := 0; i < 20; i++ {
+			result = append(result, s.requestInstance("ng", &apiv1.Node{}) != nil)
+		}
+		return result
+	}
+	first := failures()
+	assert.Equal(t, first, failures())
+	assert.Contains(t, first, true)
+	assert.Contains(t, first, false)
+}
+
+func TestNodeGroupWithScenario(t *testing.T) {
+	now := time.Now()
+	fakeClient := fake.NewSimpleClientset()
+	ng := &NodeGroup{
+		name:       "ng",
+		kubeClient: fakeClient,
+		lister:     kube_util.NewTestNodeLister(nil),
+		nodeTemplate: &apiv1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "template-node-ng",
+			},
+		},
+		minSize: 0,
+		maxSize: 10,
+		scenario: newScenario(&ScenarioConfig{
+			Phases: []ScenarioPhase{
+				{ProvisioningDelay: metav1.Duration{Duration: time.Minute}},
+				{After: metav1.Duration{Duration: 10 * time.Minute}, Stockout: true},
+				{After: metav1.Duration{Duration: 20 * time.Minute}, FailureRate: 1},
+			},
+		}, func() time.Time { return now }),
+	}
+	listNodes := func() []*apiv1.Node {
+		nodes, err := fakeClient.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+		assert.NoError(t, err)
+		result := []*apiv1.Node{}
+		for i := range nodes.Items {
+			result = append(result, &nodes.Items[i])
+		}
+		return result
+	}
+	createdNodes := func() int {
+		return len(listNodes())
+	}
+	instanceStatuses := func() []*cloudprovider.InstanceStatus {
+		instances, err := ng.Nodes()
+		assert.NoError(t, err)
+		statuses := []*cloudprovider.InstanceStatus{}
+		for _, instance := range instances {
+			statuses = append(statuses, instance.Status)
+		}
+		return statuses
+	}
+
+	// nodes are created after the provisioning delay
+	assert.NoError(t, ng.IncreaseSize(2))
+	assert.Equal(t, 2, ng.targetSize)
+	assert.Len(t, ng.pendingInstances, 2)
+	assert.Equal(t, 0, createdNodes())
+	for _, status := range instanceStatuses() {
+		assert.Equal(t, cloudprovider.InstanceCreating, status.State)
+		assert.Nil(t, status.ErrorInfo)
+	}
+	ng.provisionPendingInstances()
+	assert.Equal(t, 0, createdNodes())
+
+	now = now.Add(time.Minute)
+	ng.provisionPendingInstances()
+	assert.Equal(t, 2, createdNodes())
+	assert.Empty(t, ng.pendingInstances)
+
+	// nodes fail right away during a stockout
+	now = now.Add(9 * time.Minute)
+	assert.NoError(t, ng.IncreaseSize(1))
+	assert.Equal(t, 3, ng.targetSize)
+	statuses := instanceStatuses()
+	if assert.Len(t, statuses, 1) && assert.NotNil(t, statuses[0].ErrorInfo) {
+		assert.Equal(t, cloudprovider.OutOfResourcesErrorClass, statuses[0].ErrorInfo.ErrorClass)
+		assert.Equal(t, stockoutErrorCode, statuses[0].ErrorInfo.ErrorCode)
+	}
+	ng.provisionPendingInstances()
+	assert.Equal(t, 2, createdNodes())
+
+	// failed instances are deleted without deleting nodes
+	failed := &apiv1.Node{Spec: apiv1.NodeSpec{ProviderID: ng.pendingInstances[0].node.Spec.ProviderID}}
+	assert.NoError(t, ng.DeleteNodes([]*apiv1.Node{failed}))
+	assert.Equal(t, 2, ng.targetSize)
+	assert.Empty(t, ng.pendingInstances)
+
+	// nodes fail being provisioned
+	now = now.Add(10 * time.Minute)
+	assert.NoError(t, ng.IncreaseSize(1))
+	statuses = instanceStatuses()
+	if assert.Len(t, statuses, 1) && assert.NotNil(t, statuses[0].ErrorInfo) {
+		assert.Equal(t, cloudprovider.OtherErrorClass, statuses[0].ErrorInfo.ErrorClass)
+		assert.Equal(t, provisioningFailedErrorCode, statuses[0].ErrorInfo.ErrorCode)
+	}
+
+	// decreasing the target size drops pending instances
+	ng.lister = kube_util.NewTestNodeLister(listNodes())
+	assert.NoError(t, ng.DecreaseTargetSize(-1))
+	assert.Equal(t, 2, ng.targetSize)
+	assert.Empty(t, ng.pendingInstances)
+}
+
+func TestNodeGroupWithAsyncScenario(t *testing.T) {
+	now := time.Now()
+	fakeClient := fake.NewSimpleClientset()
+	ng := &NodeGroup{
+		name:       "ng",
+		kubeClient: fakeClient,
+		lister:     kube_util.NewTestNodeLister(nil),
+		nodeTemplate: &apiv1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "template-node-ng",
+			},
+		},
+		minSize: 0,
+		maxSize: 10,
+		scenario: newScenario(&ScenarioConfig{
+			AsyncScaleUps: true,
+			Phases: []ScenarioPhase{
+				{ProvisioningDelay: metav1.Duration{Duration: time.Minute}},
+				{After: metav1.Duration{Duration: 10 * time.Minute}, Stockout: true},
+			},
+		}, func() time.Time { return now }),
+	}
+
+	// the operation is provisioned once the provisioning delay passes
+	operation, err := ng.IncreaseSizeAsync(2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, ng.targetSize)
+	status, err := operation.Status()
+	assert.NoError(t, err)
+	assert.Equal(t, cloudprovider.ScaleUpOperationStatus{State: cloudprovider.ScaleUpOperationAccepted}, status)
+
+	now = now.Add(time.Minute)
+	status, err = operation.Status()
+	assert.NoError(t, err)
+	assert.Equal(t, cloudprovider.ScaleUpOperationStatus{State: cloudprovider.ScaleUpOperationProvisioned, ProvisionedCount: 2}, status)
+	ng.provisionPendingInstances()
+	assert.Empty(t, ng.pendingInstances)
+
+	// failed instances fail the operation instead of being reported as instances with errors
+	now = now.Add(9 * time.Minute)
+	operation, err = ng.IncreaseSizeAsync(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, ng.targetSize)
+	instances, err := ng.Nodes()
+	assert.NoError(t, err)
+	for _, instance := range instances {
+		assert.Nil(t, instance.Status.ErrorInfo)
+	}
+	status, err = operation.Status()
+	assert.NoError(t, err)
+	assert.Equal(t, cloudprovider.ScaleUpOperationFailed, status.State)
+	assert.Equal(t, 0, status.ProvisionedCount)
+	if assert.NotNil(t, status.ErrorInfo) {
+		assert.Equal(t, stockoutErrorCode, status.ErrorInfo.ErrorCode)
+	}
+	// the failed instances aren't part of the target size anymore
+	assert.Equal(t, 2, ng.targetSize)
+	assert.Empty(t, ng.pendingInstances)
+
+	// without async scale-ups, IncreaseSize is used
+	ng.scenario.asyncScaleUps = false
+	_, err = ng.IncreaseSizeAsync(1)
+	assert.Equal(t, cloudprovider.ErrNotImplemented, err)
+}
@@ -18,6 +18,7 @@ package kwok
 
 import (
 	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	listersv1 "k8s.io/client-go/listers/core/v1"
 
@@ -59,6 +60,11 @@ type NodeGroup struct {
 	minSize      int
 	targetSize   int
 	maxSize      int
+	// scenario scripts how new nodes are provisioned, nil to create them right away
+	scenario *scenario
+	// pendingInstances are the instances requested by scale-ups which aren't nodes yet,
+	// either because they're still being provisioned or because they failed
+	pendingInstances []*pendingInstance
 }
 
 // NodegroupsConfig defines options for creating nodegroups
@@ -90,6 +96,34 @@ type GPUConfig struct {
 type KwokConfig struct {
 }
 
+// ScenarioConfig scripts how the kwok provider provisions nodes over time,
+// e.g. to test the backoff and retry logic of the autoscaler
+type ScenarioConfig struct {
+	// Seed seeds the random failures, so that runs of a scenario are reproducible
+	Seed int64 `json:"seed" yaml:"seed"`
+	// Phases are the phases of the scenario, the latest started phase applying
+	// to a nodegroup defines how its nodes are provisioned
+	Phases []ScenarioPhase `json:"phases" yaml:"phases"`
+	// AsyncScaleUps makes scale-ups return operations reporting when the requested nodes are provisioned,
+	// instead of reporting nodes which failed being provisioned as instances with errors
+	AsyncScaleUps bool `json:"asyncScaleUps" yaml:"asyncScaleUps"`
+}
+
+// ScenarioPhase defines how nodes are provisioned from some time on
+type ScenarioPhase struct {
+	// After is the time since the start of the kwok provider when the phase starts
+	After metav1.Duration `json:"after" yaml:"after"`
+	// Nodegroups are the nodegroups the phase applies to (all nodegroups if empty)
+	Nodegroups []string `json:"nodegroups" yaml:"nodegroups"`
+	// ProvisioningDelay is the time it takes for a requested node to be created
+	ProvisioningDelay metav1.Duration `json:"provisioningDelay" yaml:"provisioningDelay"`
+	// FailureRate is the probability of a requested node to fail being provisioned
+	// once ProvisioningDelay passes (between 0 and 1)
+	FailureRate float64 `json:"failureRate" yaml:"failureRate"`
+	// Stockout makes all the requested nodes fail right away with an out of resources error
+	Stockout bool `json:"stockout" yaml:"stockout"`
+}
+
 // KwokProviderConfig is the struct to hold kwok provider config
 type KwokProviderConfig struct {
 	APIVersion    string            `json:"apiVersion" yaml:"apiVersion"`
@@ -98,6 +132,7 @@ type KwokProviderConfig struct {
 	Nodes         *NodeConfig       `json:"nodes" yaml:"nodes"`
 	ConfigMap     *ConfigMapConfig  `json:"configmap" yaml:"configmap"`
 	Kwok          *KwokConfig       `json:"kwok" yaml:"kwok"`
+	Scenario      *ScenarioConfig   `json:"scenario" yaml:"scenario"`
 	status        *GroupingConfig
 }
 
@@ -25,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/config/dynamic"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
 	klog "k8s.io/klog/v2"
@@ -126,9 +127,9 @@ func BuildLinode(
 	rl *cloudprovider.ResourceLimiter,
 ) cloudprovider.CloudProvider {
 
-	// the cloud provider automatically uses all node pools in linode.
-	// This means we don't use the cloudprovider.NodeGroupDiscoveryOptions
-	// flags (which can be set via '--node-group-auto-discovery' or '-nodes')
+	// the cloud provider automatically uses all node pools in linode, unless
+	// '--node-group-auto-discovery' label specs select them by their tags.
+	// The '--nodes' flag isn't used.
 
 	if opts.CloudConfig == "" {
 		klog.Fatalf("No config file provided, please specify it via the --cloud-config flag")
@@ -138,7 +139,7 @@ func BuildLinode(
 		klog.Fatalf("Could not open cloud provider configuration file %q, error: %v", opts.CloudConfig, err)
 	}
 	defer configFile.Close()
-	lcp, err := newLinodeCloudProvider(configFile, rl)
+	lcp, err := newLinodeCloudProvider(configFile, do, rl)
 	if err != nil {
 		klog.Fatalf("Could not create linode cloud provider: %v", err)
 	}
@@ -151,11 +152,15 @@ func (l *linodeCloudProvider) Refresh() error {
 	return l.manager.refresh()
 }
 
-func newLinodeCloudProvider(config io.Reader, rl *cloudprovider.ResourceLimiter) (cloudprovider.CloudProvider, error) {
+func newLinodeCloudProvider(config io.Reader, do cloudprovider.NodeGroupDiscoveryOptions, rl *cloudprovider.ResourceLimiter) (cloudprovider.CloudProvider, error) {
 	m, err := newManager(config)
 	if err != nil {
 		return nil, fmt.Errorf("could not create linode manager: %v", err)
 	}
+	m.autoDiscoveryConfigs, err = dynamic.ParseLabelAutoDiscoverySpecs(do.NodeGroupAutoDiscoverySpecs)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse node group auto discovery specs: %v", err)
+	}
 
 	err = m.refresh()
 	if err != nil {
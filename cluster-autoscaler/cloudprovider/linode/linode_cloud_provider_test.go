@@ -34,7 +34,7 @@ func TestCloudProvider_newLinodeCloudProvider(t *testing.T) {
 linode-token=123123123
 lke-cluster-id=456456
 `)
-	_, err := newLinodeCloudProvider(cfg, rl)
+	_, err := newLinodeCloudProvider(cfg, cloudprovider.NodeGroupDiscoveryOptions{}, rl)
 	assert.NoError(t, err)
 
 	// test error on creating a linode provider when config is bad
@@ -43,7 +43,7 @@ lke-cluster-id=456456
 linode-token=123123123
 lke-cluster-id=456456
 `)
-	_, err = newLinodeCloudProvider(cfg, rl)
+	_, err = newLinodeCloudProvider(cfg, cloudprovider.NodeGroupDiscoveryOptions{}, rl)
 	assert.Error(t, err)
 }
 
@@ -20,8 +20,10 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/linode/linodego"
+	"k8s.io/autoscaler/cluster-autoscaler/config/dynamic"
 	klog "k8s.io/klog/v2"
 )
 
@@ -31,6 +33,8 @@ type manager struct {
 	client     linodeAPIClient
 	config     *linodeConfig
 	nodeGroups map[string]*NodeGroup // key: NodeGroup.id
+	// autoDiscoveryConfigs select the LKE pools to import by their labels, all pools are imported if empty
+	autoDiscoveryConfigs []dynamic.LabelAutoDiscoveryConfig
 }
 
 func newManager(config io.Reader) (*manager, error) {
@@ -60,6 +64,15 @@ func (m *manager) refresh() error {
 		if found {
 			continue
 		}
+		// skip this pool if it is not selected by any of the auto discovery specs
+		var discoveryConfig *dynamic.LabelAutoDiscoveryConfig
+		if len(m.autoDiscoveryConfigs) > 0 {
+			discoveryConfig = dynamic.MatchLabelAutoDiscoveryConfigs(m.autoDiscoveryConfigs, poolLabels(&pool))
+			if discoveryConfig == nil {
+				klog.V(2).Infof("The LKE pool %d is not selected by any auto discovery spec, will exclude it from the node groups", pool.ID)
+				continue
+			}
+		}
 		// check if the nodes in the pool are more than 1, if so skip it
 		if pool.Count > 1 {
 			klog.V(2).Infof("The LKE pool %d has more than one node (current nodes in pool: %d), will exclude it from the node groups",
@@ -76,6 +89,9 @@ func (m *manager) refresh() error {
 		} else {
 			// create a new node group with this pool in it
 			ng := buildNodeGroup(&lkeClusterPools[i], m.config, m.client)
+			if discoveryConfig != nil {
+				ng.minSize, ng.maxSize = discoveryConfig.Sizes(ng.minSize, ng.maxSize)
+			}
 			nodeGroups[linodeType] = ng
 		}
 	}
@@ -101,6 +117,18 @@ func (m *manager) refresh() error {
 	return nil
 }
 
+// poolLabels returns the labels auto discovery specs select LKE pools by: the Linode type
+// of the pool as "type", and its tags of the form key=value.
+func poolLabels(pool *linodego.LKEClusterPool) map[string]string {
+	labels := map[string]string{"type": pool.Type}
+	for _, tag := range pool.Tags {
+		if k, v, found := strings.Cut(tag, "="); found && k != "" {
+			labels[k] = v
+		}
+	}
+	return labels
+}
+
 func buildNodeGroup(pool *linodego.LKEClusterPool, cfg *linodeConfig, client linodeAPIClient) *NodeGroup {
 	// get specific min and max size for a node group, if defined in the config
 	minSize := cfg.defaultMinSize
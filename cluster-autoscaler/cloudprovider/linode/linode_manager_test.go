@@ -24,6 +24,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/linode/linodego"
+	"k8s.io/autoscaler/cluster-autoscaler/config/dynamic"
 )
 
 func TestManager_newManager(t *testing.T) {
@@ -146,3 +147,45 @@ max-size=5
 	assert.Error(t, err)
 
 }
+
+func TestManager_refreshWithAutoDiscovery(t *testing.T) {
+	cfg := strings.NewReader(`
+[global]
+linode-token=123123123
+lke-cluster-id=456456
+defaut-min-size-per-linode-type=2
+defaut-max-size-per-linode-type=10
+`)
+	m, err := newManager(cfg)
+	assert.NoError(t, err)
+	m.autoDiscoveryConfigs, err = dynamic.ParseLabelAutoDiscoverySpecs([]string{
+		"label:autoscaler=enabled,max=5",
+		"label:type=g6-standard-4",
+	})
+	assert.NoError(t, err)
+
+	client := linodeClientMock{}
+	m.client = &client
+	ctx := context.Background()
+
+	client.On(
+		"ListLKEClusterPools", ctx, 456456, nil,
+	).Return(
+		[]linodego.LKEClusterPool{
+			{ID: 1, Count: 1, Type: "g6-standard-1", Tags: []string{"autoscaler=enabled"}},
+			{ID: 2, Count: 1, Type: "g6-standard-1", Tags: []string{"autoscaler=enabled", "team"}},
+			{ID: 3, Count: 1, Type: "g6-standard-2", Tags: []string{"autoscaler=disabled"}},
+			{ID: 4, Count: 1, Type: "g6-standard-2"},
+			{ID: 5, Count: 1, Type: "g6-standard-4"},
+		},
+		nil,
+	).Once()
+	err = m.refresh()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(m.nodeGroups))
+	assert.Equal(t, 2, len(m.nodeGroups["g6-standard-1"].lkePools))
+	assert.Equal(t, 2, m.nodeGroups["g6-standard-1"].minSize)
+	assert.Equal(t, 5, m.nodeGroups["g6-standard-1"].maxSize)
+	assert.Equal(t, 1, len(m.nodeGroups["g6-standard-4"].lkePools))
+	assert.Equal(t, 10, m.nodeGroups["g6-standard-4"].maxSize)
+}
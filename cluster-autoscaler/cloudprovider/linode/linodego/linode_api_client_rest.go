@@ -64,6 +64,7 @@ type LKEClusterPool struct {
 	Type    string                 `json:"type"`
 	Disks   []LKEClusterPoolDisk   `json:"disks"`
 	Linodes []LKEClusterPoolLinode `json:"nodes"`
+	Tags    []string               `json:"tags"`
 }
 
 // LKEClusterPoolDisk represents a node disk in an LKEClusterPool object
@@ -0,0 +1,36 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudprovider
+
+import (
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+)
+
+// WeightedTemplateNodeInfo is a template of some of the nodes created by a node group, along with the
+// relative share of the node group's new nodes expected to be created from it.
+type WeightedTemplateNodeInfo struct {
+	NodeInfo *framework.NodeInfo
+	Weight   int
+}
+
+// MixedTemplatesNodeGroup is implemented by node groups creating nodes of different shapes, e.g. AWS ASGs
+// with a MixedInstancesPolicy listing several instance types.
+type MixedTemplatesNodeGroup interface {
+	// WeightedTemplateNodeInfos returns a template for each shape of nodes the node group creates. It returns
+	// no templates if all nodes are created from TemplateNodeInfo().
+	WeightedTemplateNodeInfos() ([]WeightedTemplateNodeInfo, error)
+}
@@ -56,6 +56,8 @@ type Shape struct {
 	GPU                     int
 	MemoryInBytes           float32
 	EphemeralStorageInBytes float32
+	// CapacityReservationID is the capacity reservation the instances are launched in, if any.
+	CapacityReservationID string
 }
 
 // CreateShapeGetter creates a new oci shape getter.
@@ -82,11 +84,16 @@ func (osf *shapeGetterImpl) Refresh() {
 func (osf *shapeGetterImpl) GetNodePoolShape(np *oke.NodePool, ephemeralStorage int64) (*Shape, error) {
 	shapeName := *np.NodeShape
 	if np.NodeShapeConfig != nil {
+		ocpus := getFloat32(np.NodeShapeConfig.Ocpus)
+		memoryInGBs := getFloat32(np.NodeShapeConfig.MemoryInGBs)
+		if np.NodeShapeConfig.MemoryInGBs == nil {
+			memoryInGBs = ocpus * osf.defaultMemoryPerOcpuInGBs(np.CompartmentId, shapeName)
+		}
 		return &Shape{
 			Name: shapeName,
-			CPU:  *np.NodeShapeConfig.Ocpus * 2,
+			CPU:  ocpus * 2,
 			// num_bytes * kilo * mega * giga
-			MemoryInBytes:           *np.NodeShapeConfig.MemoryInGBs * 1024 * 1024 * 1024,
+			MemoryInBytes:           memoryInGBs * 1024 * 1024 * 1024,
 			GPU:                     0,
 			EphemeralStorageInBytes: float32(ephemeralStorage),
 		}, nil
@@ -170,35 +177,26 @@ func (osf *shapeGetterImpl) GetInstancePoolShape(ip *core.InstancePool) (*Shape,
 			if instanceDetails.LaunchDetails.Shape != nil {
 				shape.Name = *instanceDetails.LaunchDetails.Shape
 			}
-			if instanceDetails.LaunchDetails.ShapeConfig.Ocpus != nil {
-				shape.CPU = *instanceDetails.LaunchDetails.ShapeConfig.Ocpus
-				// Minimum amount of memory unless explicitly set higher
-				shape.MemoryInBytes = *instanceDetails.LaunchDetails.ShapeConfig.Ocpus * 1024 * 1024 * 1024
+			shapeConfig := instanceDetails.LaunchDetails.ShapeConfig
+			if shapeConfig.Ocpus != nil {
+				shape.CPU = *shapeConfig.Ocpus
+				// Flexible shapes get the default amount of memory per OCPU of the shape unless explicitly set
+				memoryPerOcpuInGBs := float32(1)
+				if shapeConfig.MemoryInGBs == nil {
+					if defaultMemoryPerOcpuInGBs := osf.defaultMemoryPerOcpuInGBs(instanceConfig.CompartmentId, shape.Name); defaultMemoryPerOcpuInGBs > 0 {
+						memoryPerOcpuInGBs = defaultMemoryPerOcpuInGBs
+					}
+				}
+				shape.MemoryInBytes = *shapeConfig.Ocpus * memoryPerOcpuInGBs * 1024 * 1024 * 1024
 			}
-			if instanceDetails.LaunchDetails.ShapeConfig.MemoryInGBs != nil {
-				shape.MemoryInBytes = *instanceDetails.LaunchDetails.ShapeConfig.MemoryInGBs * 1024 * 1024 * 1024
+			if shapeConfig.MemoryInGBs != nil {
+				shape.MemoryInBytes = *shapeConfig.MemoryInGBs * 1024 * 1024 * 1024
 			}
 		} else {
 			// Fetch the shape object by name
-			var page *string
-			var everyShape []core.Shape
-			for {
-				// List all available shapes
-				lisShapesReq := core.ListShapesRequest{}
-				lisShapesReq.CompartmentId = instanceConfig.CompartmentId
-				lisShapesReq.Page = page
-				lisShapesReq.Limit = common.Int(50)
-
-				listShapes, err := osf.shapeClient.ListShapes(context.Background(), lisShapesReq)
-				if err != nil {
-					return nil, err
-				}
-
-				everyShape = append(everyShape, listShapes.Items...)
-
-				if page = listShapes.OpcNextPage; listShapes.OpcNextPage == nil {
-					break
-				}
+			everyShape, err := osf.listShapes(instanceConfig.CompartmentId)
+			if err != nil {
+				return nil, err
 			}
 
 			for _, nextShape := range everyShape {
@@ -216,6 +214,15 @@ func (osf *shapeGetterImpl) GetInstancePoolShape(ip *core.InstancePool) (*Shape,
 				}
 			}
 		}
+
+		if instanceDetails.LaunchDetails != nil {
+			if sourceDetails, ok := instanceDetails.LaunchDetails.SourceDetails.(core.InstanceConfigurationInstanceSourceViaImageDetails); ok && sourceDetails.BootVolumeSizeInGBs != nil {
+				shape.EphemeralStorageInBytes = float32(*sourceDetails.BootVolumeSizeInGBs) * 1024 * 1024 * 1024
+			}
+			if instanceDetails.LaunchDetails.CapacityReservationId != nil {
+				shape.CapacityReservationID = *instanceDetails.LaunchDetails.CapacityReservationId
+			}
+		}
 	} else {
 		return nil, fmt.Errorf("(compute) instance configuration for instance-pool %s not found", *ip.Id)
 	}
@@ -229,6 +236,46 @@ func (osf *shapeGetterImpl) GetInstancePoolShape(ip *core.InstancePool) (*Shape,
 	return shape, nil
 }
 
+// listShapes lists all the shapes available in the compartment.
+func (osf *shapeGetterImpl) listShapes(compartmentID *string) ([]core.Shape, error) {
+	var page *string
+	var everyShape []core.Shape
+	for {
+		lisShapesReq := core.ListShapesRequest{}
+		lisShapesReq.CompartmentId = compartmentID
+		lisShapesReq.Page = page
+		lisShapesReq.Limit = common.Int(50)
+
+		listShapes, err := osf.shapeClient.ListShapes(context.Background(), lisShapesReq)
+		if err != nil {
+			return nil, err
+		}
+
+		everyShape = append(everyShape, listShapes.Items...)
+
+		if page = listShapes.OpcNextPage; listShapes.OpcNextPage == nil {
+			break
+		}
+	}
+	return everyShape, nil
+}
+
+// defaultMemoryPerOcpuInGBs returns the amount of memory per OCPU flexible shape instances get unless
+// set explicitly, or 0 if it's unknown.
+func (osf *shapeGetterImpl) defaultMemoryPerOcpuInGBs(compartmentID *string, shapeName string) float32 {
+	everyShape, err := osf.listShapes(compartmentID)
+	if err != nil {
+		klog.Warningf("unable to list shapes to get the default memory of flexible shape %s: %v", shapeName, err)
+		return 0
+	}
+	for _, s := range everyShape {
+		if s.Shape != nil && *s.Shape == shapeName && s.MemoryOptions != nil {
+			return getFloat32(s.MemoryOptions.DefaultPerOcpuInGBs)
+		}
+	}
+	return 0
+}
+
 // getFloat32 is a helper to get a float32 pointer value or default to 0.
 func getFloat32(f *float32) float32 {
 	if f == nil {
@@ -164,6 +164,49 @@ func TestNodePoolGetShape(t *testing.T) {
 	}
 }
 
+func TestGetInstancePoolFlexShapeDefaults(t *testing.T) {
+	shapeClient := &mockShapeClient{
+		listShapeResponses: []core.ListShapesResponse{
+			{
+				Items: []core.Shape{
+					{
+						Shape:         common.String("VM.Standard.E4.Flex"),
+						MemoryOptions: &core.ShapeMemoryOptions{DefaultPerOcpuInGBs: common.Float32(16)},
+					},
+				},
+			},
+		},
+		getInstanceConfigResp: core.GetInstanceConfigurationResponse{
+			InstanceConfiguration: core.InstanceConfiguration{
+				Id: common.String("ocid1.instanceconfiguration.oc1.phx.aaaaaaaa2"),
+				InstanceDetails: core.ComputeInstanceDetails{
+					LaunchDetails: &core.InstanceConfigurationLaunchInstanceDetails{
+						Shape:                 common.String("VM.Standard.E4.Flex"),
+						ShapeConfig:           &core.InstanceConfigurationLaunchInstanceShapeConfigDetails{Ocpus: common.Float32(2)},
+						SourceDetails:         core.InstanceConfigurationInstanceSourceViaImageDetails{BootVolumeSizeInGBs: common.Int64(100)},
+						CapacityReservationId: common.String("ocid1.capacityreservation.oc1.phx.aaaaaaaa1"),
+					},
+				},
+			},
+		},
+	}
+
+	expected := &Shape{
+		Name:                    "VM.Standard.E4.Flex",
+		CPU:                     2,
+		MemoryInBytes:           2 * 16 * 1024 * 1024 * 1024,
+		EphemeralStorageInBytes: 100 * 1024 * 1024 * 1024,
+		CapacityReservationID:   "ocid1.capacityreservation.oc1.phx.aaaaaaaa1",
+	}
+	shape, err := CreateShapeGetter(shapeClient).GetInstancePoolShape(&core.InstancePool{Id: common.String("ocid1.instancepool.oc1.phx.aaaaaaaa2")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(shape, expected) {
+		t.Errorf("wanted %+v ; got %+v", expected, shape)
+	}
+}
+
 func TestGetInstancePoolShape(t *testing.T) {
 
 	testCases := map[string]struct {
@@ -34,6 +34,7 @@ type ComputeMgmtClient interface {
 // ComputeClient wraps core.ComputeClient exposing the functions we actually require.
 type ComputeClient interface {
 	ListVnicAttachments(ctx context.Context, request core.ListVnicAttachmentsRequest) (core.ListVnicAttachmentsResponse, error)
+	GetComputeCapacityReservation(ctx context.Context, request core.GetComputeCapacityReservationRequest) (core.GetComputeCapacityReservationResponse, error)
 }
 
 // VirtualNetworkClient wraps core.VirtualNetworkClient exposing the functions we actually require.
@@ -575,6 +576,32 @@ func (c *instancePoolCache) getSize(id string) (int, error) {
 	return *pool.Size, nil
 }
 
+// getCapacityReservationAvailability returns the number of instances of the given shape which can still be launched
+// in the capacity reservation.
+func (c *instancePoolCache) getCapacityReservationAvailability(capacityReservationID, shapeName string) (int, error) {
+	resp, err := c.computeClient.GetComputeCapacityReservation(context.Background(), core.GetComputeCapacityReservationRequest{
+		CapacityReservationId: common.String(capacityReservationID),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	available := int64(0)
+	for _, config := range resp.ComputeCapacityReservation.InstanceReservationConfigs {
+		if config.InstanceShape == nil || *config.InstanceShape != shapeName || config.ReservedCount == nil {
+			continue
+		}
+		used := int64(0)
+		if config.UsedCount != nil {
+			used = *config.UsedCount
+		}
+		if *config.ReservedCount > used {
+			available += *config.ReservedCount - used
+		}
+	}
+	return int(available), nil
+}
+
 // removeInstanceSummaryFromCache removes looks through the pool cache for an InstanceSummary with the specified ID and
 // removes it if found
 func (c *instancePoolCache) removeInstanceSummaryFromCache(instancePoolID, instanceID string) {
@@ -371,6 +371,10 @@ func (m *InstancePoolManagerImpl) GetInstancePoolSize(ip InstancePoolNodeGroup)
 func (m *InstancePoolManagerImpl) SetInstancePoolSize(np InstancePoolNodeGroup, size int) error {
 	klog.Infof("SetInstancePoolSize (%d) called on instance pool %s", size, np.Id())
 
+	if err := m.checkCapacityReservation(np, size); err != nil {
+		return err
+	}
+
 	setSizeErr := m.instancePoolCache.setSize(np.Id(), size)
 	klog.V(5).Infof("SetInstancePoolSize was called: refreshing instance pool cache")
 	// refresh instance pool cache after update (regardless if there was an error or not)
@@ -388,6 +392,36 @@ func (m *InstancePoolManagerImpl) SetInstancePoolSize(np InstancePoolNodeGroup,
 	return nil
 }
 
+// checkCapacityReservation returns an error if the instance-pool launches its instances in a capacity reservation
+// which doesn't have enough capacity left to increase its size. Launching instances beyond the reserved capacity
+// fails, so it's better to let the autoscaler back off and try other instance-pools right away.
+func (m *InstancePoolManagerImpl) checkCapacityReservation(np InstancePoolNodeGroup, size int) error {
+	currentSize, err := m.instancePoolCache.getSize(np.Id())
+	if err != nil || size <= currentSize {
+		return nil
+	}
+
+	instancePool, err := m.instancePoolCache.getInstancePool(np.Id())
+	if err != nil {
+		return nil
+	}
+	shape, err := m.ShapeGetter.GetInstancePoolShape(instancePool)
+	if err != nil || shape.CapacityReservationID == "" {
+		return nil
+	}
+
+	available, err := m.instancePoolCache.getCapacityReservationAvailability(shape.CapacityReservationID, shape.Name)
+	if err != nil {
+		klog.Warningf("unable to get capacity reservation %s of instance pool %s: %v", shape.CapacityReservationID, np.Id(), err)
+		return nil
+	}
+	if delta := size - currentSize; delta > available {
+		return fmt.Errorf("capacity reservation %s of instance pool %s has capacity for %d more instances, %d requested",
+			shape.CapacityReservationID, np.Id(), available, delta)
+	}
+	return nil
+}
+
 // DeleteInstances deletes the given instances. All instances must be controlled by the same instance-pool.
 func (m *InstancePoolManagerImpl) DeleteInstances(instancePool InstancePoolNodeGroup, instances []ocicommon.OciRef) error {
 	klog.Infof("DeleteInstances called on instance pool %s", instancePool.Id())
@@ -443,6 +477,9 @@ func (m *InstancePoolManagerImpl) buildNodeFromTemplate(instancePool *core.Insta
 	node.Status.Capacity[apiv1.ResourceCPU] = *resource.NewQuantity(int64(shape.CPU), resource.DecimalSI)
 	node.Status.Capacity[apiv1.ResourceMemory] = *resource.NewQuantity(int64(shape.MemoryInBytes), resource.DecimalSI)
 	node.Status.Capacity[consts.ResourceGPU] = *resource.NewQuantity(int64(shape.GPU), resource.DecimalSI)
+	if shape.EphemeralStorageInBytes > 0 {
+		node.Status.Capacity[apiv1.ResourceEphemeralStorage] = *resource.NewQuantity(int64(shape.EphemeralStorageInBytes), resource.DecimalSI)
+	}
 
 	node.Status.Allocatable = node.Status.Capacity
 
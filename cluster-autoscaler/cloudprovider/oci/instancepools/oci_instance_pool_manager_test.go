@@ -95,8 +95,9 @@ type mockVirtualNetworkClient struct {
 }
 
 type mockComputeClient struct {
-	err                         error
-	listVnicAttachmentsResponse core.ListVnicAttachmentsResponse
+	err                                   error
+	listVnicAttachmentsResponse           core.ListVnicAttachmentsResponse
+	getComputeCapacityReservationResponse core.GetComputeCapacityReservationResponse
 }
 
 type mockWorkRequestClient struct {
@@ -119,6 +120,10 @@ func (m *mockComputeClient) ListVnicAttachments(ctx context.Context, request cor
 	return m.listVnicAttachmentsResponse, m.err
 }
 
+func (m *mockComputeClient) GetComputeCapacityReservation(ctx context.Context, request core.GetComputeCapacityReservationRequest) (core.GetComputeCapacityReservationResponse, error) {
+	return m.getComputeCapacityReservationResponse, m.err
+}
+
 func (m *mockVirtualNetworkClient) GetVnic(context.Context, core.GetVnicRequest) (core.GetVnicResponse, error) {
 	return m.getVnicResponse, m.err
 }
@@ -237,9 +242,9 @@ func TestInstancePoolFromArgs(t *testing.T) {
 func TestGetSetInstancePoolSize(t *testing.T) {
 
 	nodePoolCache := newInstancePoolCache(computeManagementClient, computeClient, virtualNetworkClient, workRequestsClient)
-	nodePoolCache.poolCache["ocid1.instancepool.oc1.phx.aaaaaaaai"] = &core.InstancePool{Size: common.Int(2)}
+	nodePoolCache.poolCache["ocid1.instancepool.oc1.phx.aaaaaaaai"] = &core.InstancePool{Id: common.String("ocid1.instancepool.oc1.phx.aaaaaaaai"), Size: common.Int(2)}
 
-	manager := &InstancePoolManagerImpl{instancePoolCache: nodePoolCache}
+	manager := &InstancePoolManagerImpl{instancePoolCache: nodePoolCache, ShapeGetter: ocicommon.CreateShapeGetter(shapeClient)}
 	size, err := manager.GetInstancePoolSize(InstancePoolNodeGroup{id: "ocid1.instancepool.oc1.phx.aaaaaaaai"})
 	if err != nil {
 		t.Fatalf("unexpected error: %+v", err)
@@ -271,6 +276,50 @@ func TestGetSetInstancePoolSize(t *testing.T) {
 
 }
 
+func TestSetInstancePoolSizeWithCapacityReservation(t *testing.T) {
+	reservedLaunchDetails := launchDetails
+	reservedLaunchDetails.CapacityReservationId = common.String("ocid1.capacityreservation.oc1.phx.aaaaaaaa1")
+	reservedShapeClient := &mockShapeClient{
+		getInstanceConfigResp: core.GetInstanceConfigurationResponse{
+			InstanceConfiguration: core.InstanceConfiguration{
+				Id:              common.String("ocid1.instanceconfiguration.oc1.phx.aaaaaaaa1"),
+				InstanceDetails: core.ComputeInstanceDetails{LaunchDetails: &reservedLaunchDetails},
+			},
+		},
+	}
+	reservationComputeClient := &mockComputeClient{
+		getComputeCapacityReservationResponse: core.GetComputeCapacityReservationResponse{
+			ComputeCapacityReservation: core.ComputeCapacityReservation{
+				InstanceReservationConfigs: []core.InstanceReservationConfig{
+					{InstanceShape: common.String("VM.Standard.E3.Flex"), ReservedCount: common.Int64(4), UsedCount: common.Int64(2)},
+					{InstanceShape: common.String("VM.Standard2.8"), ReservedCount: common.Int64(10), UsedCount: common.Int64(0)},
+				},
+			},
+		},
+	}
+
+	nodePoolCache := newInstancePoolCache(computeManagementClient, reservationComputeClient, virtualNetworkClient, workRequestsClient)
+	nodePoolCache.poolCache["ocid1.instancepool.oc1.phx.aaaaaaaar"] = &core.InstancePool{
+		Id:                      common.String("ocid1.instancepool.oc1.phx.aaaaaaaar"),
+		InstanceConfigurationId: common.String("ocid1.instanceconfiguration.oc1.phx.aaaaaaaa1"),
+		Size:                    common.Int(2),
+	}
+	manager := &InstancePoolManagerImpl{instancePoolCache: nodePoolCache, ShapeGetter: ocicommon.CreateShapeGetter(reservedShapeClient)}
+
+	// Only 2 instances of the shape can be launched in the reservation.
+	err := manager.SetInstancePoolSize(InstancePoolNodeGroup{id: "ocid1.instancepool.oc1.phx.aaaaaaaar"}, 5)
+	if err == nil {
+		t.Fatalf("expected an error increasing the size beyond the capacity reservation")
+	}
+	size, err := manager.GetInstancePoolSize(InstancePoolNodeGroup{id: "ocid1.instancepool.oc1.phx.aaaaaaaar"})
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if size != 2 {
+		t.Errorf("got size %d ; wanted size 2", size)
+	}
+}
+
 func TestGetInstancePoolForInstance(t *testing.T) {
 
 	nodePoolCache := newInstancePoolCache(computeManagementClient, computeClient, virtualNetworkClient, workRequestsClient)
@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudprovider
+
+// ScaleUpFailureCategory is a cloud provider independent category of scale-up failures.
+type ScaleUpFailureCategory string
+
+const (
+	// ScaleUpFailureQuota means that a quota or limit of the cloud account was exceeded.
+	ScaleUpFailureQuota ScaleUpFailureCategory = "quota"
+	// ScaleUpFailureStockout means that the cloud provider ran out of capacity for the requested instances.
+	ScaleUpFailureStockout ScaleUpFailureCategory = "stockout"
+	// ScaleUpFailureIPExhaustion means that there are no IP addresses left for new instances.
+	ScaleUpFailureIPExhaustion ScaleUpFailureCategory = "ipExhaustion"
+	// ScaleUpFailurePlacementGroupConflict means that the instances couldn't be placed according to
+	// the placement group or placement policy of the node group.
+	ScaleUpFailurePlacementGroupConflict ScaleUpFailureCategory = "placementGroupConflict"
+	// ScaleUpFailureMisconfiguration means that the node group, its permissions or reservations are misconfigured.
+	ScaleUpFailureMisconfiguration ScaleUpFailureCategory = "misconfiguration"
+	// ScaleUpFailureTimeout means that the nodes didn't register in time.
+	ScaleUpFailureTimeout ScaleUpFailureCategory = "timeout"
+	// ScaleUpFailureOther means that the failure doesn't fall into any other category.
+	ScaleUpFailureOther ScaleUpFailureCategory = "other"
+)
+
+// ScaleUpFailureCategories lists all scale-up failure categories.
+var ScaleUpFailureCategories = []ScaleUpFailureCategory{
+	ScaleUpFailureQuota,
+	ScaleUpFailureStockout,
+	ScaleUpFailureIPExhaustion,
+	ScaleUpFailurePlacementGroupConflict,
+	ScaleUpFailureMisconfiguration,
+	ScaleUpFailureTimeout,
+	ScaleUpFailureOther,
+}
+
+// scaleUpFailureCategoriesByErrorCode maps error codes reported in InstanceErrorInfo
+// by the cloud providers to scale-up failure categories.
+var scaleUpFailureCategoriesByErrorCode = map[string]ScaleUpFailureCategory{
+	// Reported by ClusterStateRegistry if nodes don't register in time.
+	"timeout": ScaleUpFailureTimeout,
+	// Reported by ClusterStateRegistry if instances requested asynchronously aren't provisioned in time.
+	"provisioningTimeout": ScaleUpFailureTimeout,
+	// Reported if increasing the node group size fails with a configuration error.
+	"configurationError": ScaleUpFailureMisconfiguration,
+
+	// GCE
+	"QUOTA_EXCEEDED":                          ScaleUpFailureQuota,
+	"RESOURCE_POOL_EXHAUSTED":                 ScaleUpFailureStockout,
+	"RESERVATION_CAPACITY_EXCEEDED":           ScaleUpFailureStockout,
+	"IP_SPACE_EXHAUSTED":                      ScaleUpFailureIPExhaustion,
+	"PERMISSIONS_ERROR":                       ScaleUpFailureMisconfiguration,
+	"VM_EXTERNAL_IP_ACCESS_POLICY_CONSTRAINT": ScaleUpFailureMisconfiguration,
+	"INVALID_RESERVATION":                     ScaleUpFailureMisconfiguration,
+	"RESERVATION_NOT_FOUND":                   ScaleUpFailureMisconfiguration,
+	"RESERVATION_INCOMPATIBLE":                ScaleUpFailureMisconfiguration,
+	"UNSUPPORTED_TPU_CONFIGURATION":           ScaleUpFailureMisconfiguration,
+
+	// AWS
+	"placeholder-cannot-be-fulfilled":           ScaleUpFailureStockout,
+	"placeholder-reservation-capacity-exceeded": ScaleUpFailureStockout,
+	"InsufficientInstanceCapacity":              ScaleUpFailureStockout,
+	"InstanceLimitExceeded":                     ScaleUpFailureQuota,
+	"VcpuLimitExceeded":                         ScaleUpFailureQuota,
+	"InsufficientFreeAddressesInSubnet":         ScaleUpFailureIPExhaustion,
+
+	// Azure
+	"AllocationFailed":                      ScaleUpFailureStockout,
+	"ZonalAllocationFailed":                 ScaleUpFailureStockout,
+	"SkuNotAvailable":                       ScaleUpFailureStockout,
+	"QuotaExceeded":                         ScaleUpFailureQuota,
+	"SubnetIsFull":                          ScaleUpFailureIPExhaustion,
+	"OverconstrainedAllocationRequest":      ScaleUpFailurePlacementGroupConflict,
+	"OverconstrainedZonalAllocationRequest": ScaleUpFailurePlacementGroupConflict,
+	"VMExtensionProvisioningFailed":         ScaleUpFailureMisconfiguration,
+}
+
+// ScaleUpFailureCategoryOf returns the category of a scale-up failure with the
+// given error info. Error codes unknown to the taxonomy are categorized by their
+// error class.
+func ScaleUpFailureCategoryOf(errorInfo InstanceErrorInfo) ScaleUpFailureCategory {
+	if category, found := scaleUpFailureCategoriesByErrorCode[errorInfo.ErrorCode]; found {
+		return category
+	}
+	if errorInfo.ErrorClass == OutOfResourcesErrorClass {
+		return ScaleUpFailureStockout
+	}
+	return ScaleUpFailureOther
+}
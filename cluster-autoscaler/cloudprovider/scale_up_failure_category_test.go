@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudprovider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScaleUpFailureCategoryOf(t *testing.T) {
+	testCases := []struct {
+		name      string
+		errorInfo InstanceErrorInfo
+		want      ScaleUpFailureCategory
+	}{
+		{
+			name:      "known quota code",
+			errorInfo: InstanceErrorInfo{ErrorClass: OutOfResourcesErrorClass, ErrorCode: "QUOTA_EXCEEDED"},
+			want:      ScaleUpFailureQuota,
+		},
+		{
+			name:      "known code wins over error class",
+			errorInfo: InstanceErrorInfo{ErrorClass: OtherErrorClass, ErrorCode: "placeholder-cannot-be-fulfilled"},
+			want:      ScaleUpFailureStockout,
+		},
+		{
+			name:      "ip exhaustion",
+			errorInfo: InstanceErrorInfo{ErrorClass: OtherErrorClass, ErrorCode: "IP_SPACE_EXHAUSTED"},
+			want:      ScaleUpFailureIPExhaustion,
+		},
+		{
+			name:      "placement",
+			errorInfo: InstanceErrorInfo{ErrorClass: OtherErrorClass, ErrorCode: "OverconstrainedAllocationRequest"},
+			want:      ScaleUpFailurePlacementGroupConflict,
+		},
+		{
+			name:      "misconfiguration",
+			errorInfo: InstanceErrorInfo{ErrorClass: OtherErrorClass, ErrorCode: "PERMISSIONS_ERROR"},
+			want:      ScaleUpFailureMisconfiguration,
+		},
+		{
+			name:      "timeout",
+			errorInfo: InstanceErrorInfo{ErrorClass: OtherErrorClass, ErrorCode: "timeout"},
+			want:      ScaleUpFailureTimeout,
+		},
+		{
+			name:      "unknown out of resources code",
+			errorInfo: InstanceErrorInfo{ErrorClass: OutOfResourcesErrorClass, ErrorCode: "provisioning-state-failed"},
+			want:      ScaleUpFailureStockout,
+		},
+		{
+			name:      "unknown code",
+			errorInfo: InstanceErrorInfo{ErrorClass: OtherErrorClass, ErrorCode: "cloudProviderError"},
+			want:      ScaleUpFailureOther,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, ScaleUpFailureCategoryOf(tc.errorInfo))
+		})
+	}
+}
@@ -0,0 +1,28 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudprovider
+
+// SizeLimitsOverridingNodeGroup is implemented by node groups whose min and max sizes can be overridden at run time,
+// e.g. by scheduled scaling windows. Overrides only change the limits respected by the cluster autoscaler, not the
+// ones configured in the cloud provider, and are kept until they're reset.
+type SizeLimitsOverridingNodeGroup interface {
+	// OverrideSizeLimits makes MinSize and MaxSize return the given sizes. It returns an error if the node group
+	// can't be scaled within them.
+	OverrideSizeLimits(minSize, maxSize int) error
+	// ResetSizeLimits makes MinSize and MaxSize return the sizes configured in the cloud provider again.
+	ResetSizeLimits()
+}
@@ -0,0 +1,36 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudprovider
+
+import (
+	"time"
+)
+
+// SpotInterruptionNotice is a notice that a spot or preemptible instance is about to be interrupted.
+type SpotInterruptionNotice struct {
+	// ProviderID is the provider id of the interrupted instance, as in Node.Spec.ProviderID.
+	ProviderID string
+	// TerminationTime is the time the instance is expected to be terminated at.
+	TerminationTime time.Time
+}
+
+// SpotInterruptionSource is implemented by cloud providers able to report upcoming interruptions
+// of spot or preemptible instances.
+type SpotInterruptionSource interface {
+	// SpotInterruptionNotices returns the interruption notices received since the last call.
+	SpotInterruptionNotices() ([]SpotInterruptionNotice, error)
+}
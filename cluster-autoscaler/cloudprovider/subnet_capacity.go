@@ -0,0 +1,27 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudprovider
+
+// SubnetCapacityChecker is implemented by cloud providers able to tell whether the subnets of node groups
+// have IP addresses left for new nodes. Node groups whose subnets are exhausted are skipped in scale-up,
+// so that alternative node groups are scaled up instead of ones whose instances would fail to launch.
+type SubnetCapacityChecker interface {
+	// FreeSubnetCapacity returns the number of nodes the free IP addresses of the node group's subnets
+	// are enough for, and whether it's known. It's called in every loop, cloud providers are responsible
+	// for limiting how often they query their APIs.
+	FreeSubnetCapacity(nodeGroup NodeGroup) (int, bool)
+}
@@ -327,6 +327,10 @@ type TestNodeGroup struct {
 	labels          map[string]string
 	taints          []apiv1.Taint
 	opts            *config.NodeGroupAutoscalingOptions
+	// minSizeOverride and maxSizeOverride replace minSize and maxSize if limitsOverridden is set.
+	limitsOverridden bool
+	minSizeOverride  int
+	maxSizeOverride  int
 }
 
 // NewTestNodeGroup creates a TestNodeGroup without setting up the realted TestCloudProvider.
@@ -351,6 +355,9 @@ func (tng *TestNodeGroup) MaxSize() int {
 	tng.Lock()
 	defer tng.Unlock()
 
+	if tng.limitsOverridden {
+		return tng.maxSizeOverride
+	}
 	return tng.maxSize
 }
 
@@ -359,9 +366,34 @@ func (tng *TestNodeGroup) MinSize() int {
 	tng.Lock()
 	defer tng.Unlock()
 
+	if tng.limitsOverridden {
+		return tng.minSizeOverride
+	}
 	return tng.minSize
 }
 
+// OverrideSizeLimits makes MinSize and MaxSize return the given sizes.
+func (tng *TestNodeGroup) OverrideSizeLimits(minSize, maxSize int) error {
+	tng.Lock()
+	defer tng.Unlock()
+
+	if minSize > maxSize {
+		return fmt.Errorf("min size %d is greater than max size %d", minSize, maxSize)
+	}
+	tng.limitsOverridden = true
+	tng.minSizeOverride = minSize
+	tng.maxSizeOverride = maxSize
+	return nil
+}
+
+// ResetSizeLimits makes MinSize and MaxSize return the sizes the node group was created with.
+func (tng *TestNodeGroup) ResetSizeLimits() {
+	tng.Lock()
+	defer tng.Unlock()
+
+	tng.limitsOverridden = false
+}
+
 // TargetSize returns the current target size of the node group. It is possible that the
 // number of nodes in Kubernetes is different at the moment but should be equal
 // to Size() once everything stabilizes (new nodes finish startup and registration or
@@ -25,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/config/dynamic"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
 	"k8s.io/klog/v2"
@@ -168,8 +169,13 @@ func BuildVultr(opts config.AutoscalingOptions, do cloudprovider.NodeGroupDiscov
 		klog.Fatalf("Failed to create Vultr manager: %v", err)
 	}
 
-	// the cloud provider automatically uses all node pools in Vultr.
-	// This means we don't use the cloudprovider.NodeGroupDiscoveryOptions
-	// flags (which can be set via '--node-group-auto-discovery' or '-nodes')
+	// the cloud provider automatically uses all node pools in Vultr with the
+	// auto scaler enabled, unless node pools are selected with the
+	// '--node-group-auto-discovery' flag. The '--nodes' flag isn't used.
+	manager.autoDiscoveryConfigs, err = dynamic.ParseLabelAutoDiscoverySpecs(do.NodeGroupAutoDiscoverySpecs)
+	if err != nil {
+		klog.Fatalf("Failed to parse node group auto discovery specs: %v", err)
+	}
+
 	return newVultrCloudProvider(manager, rl)
 }
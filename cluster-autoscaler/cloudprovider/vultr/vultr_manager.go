@@ -27,6 +27,7 @@ import (
 
 	"golang.org/x/oauth2"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/vultr/govultr"
+	"k8s.io/autoscaler/cluster-autoscaler/config/dynamic"
 	"k8s.io/klog/v2"
 )
 
@@ -40,6 +41,8 @@ type manager struct {
 	clusterID  string
 	client     vultrClient
 	nodeGroups []*NodeGroup
+	// autoDiscoveryConfigs select the node pools to scale by their labels, see nodePoolLabels.
+	autoDiscoveryConfigs []dynamic.LabelAutoDiscoveryConfig
 }
 
 // Config is the configuration of the Vultr cloud provider
@@ -99,12 +102,21 @@ func (m *manager) Refresh() error {
 
 	var group []*NodeGroup
 	for _, nodePool := range nodePools {
-
-		if !nodePool.AutoScaler {
+		minSize, maxSize := nodePool.MinNodes, nodePool.MaxNodes
+
+		if len(m.autoDiscoveryConfigs) > 0 {
+			// node pools selected by the auto discovery specs are scaled even if the auto scaler
+			// isn't enabled for them in the Vultr API
+			config := dynamic.MatchLabelAutoDiscoveryConfigs(m.autoDiscoveryConfigs, nodePoolLabels(nodePool))
+			if config == nil {
+				continue
+			}
+			minSize, maxSize = config.Sizes(minSize, maxSize)
+		} else if !nodePool.AutoScaler {
 			continue
 		}
 
-		klog.V(3).Infof("adding node pool: %q name with min nodes %d and max nodes %d", nodePool.Label, nodePool.MinNodes, nodePool.MaxNodes)
+		klog.V(3).Infof("adding node pool: %q name with min nodes %d and max nodes %d", nodePool.Label, minSize, maxSize)
 
 		np := nodePool
 		group = append(group, &NodeGroup{
@@ -112,11 +124,20 @@ func (m *manager) Refresh() error {
 			clusterID: m.clusterID,
 			client:    m.client,
 			nodePool:  &np, // we had to set this as a pointer because we don't return the [] as []*
-			minSize:   nodePool.MinNodes,
-			maxSize:   nodePool.MaxNodes,
+			minSize:   minSize,
+			maxSize:   maxSize,
 		})
 	}
 
 	m.nodeGroups = group
 	return nil
 }
+
+// nodePoolLabels returns the labels auto discovery specs select node pools by: their tag, label (name) and plan.
+func nodePoolLabels(nodePool govultr.NodePool) map[string]string {
+	return map[string]string{
+		"tag":   nodePool.Tag,
+		"label": nodePool.Label,
+		"plan":  nodePool.Plan,
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/vultr/govultr"
+	"k8s.io/autoscaler/cluster-autoscaler/config/dynamic"
 )
 
 func TestManager_newManager(t *testing.T) {
@@ -100,3 +101,57 @@ func TestManager_Refresh(t *testing.T) {
 	assert.Equal(t, manager.nodeGroups[1].maxSize, 8, "minimum node for first group does not match")
 
 }
+
+func TestManager_RefreshWithAutoDiscovery(t *testing.T) {
+	config := `{"token": "123-456", "cluster_id": "abc"}`
+
+	manager, err := newManager(strings.NewReader(config))
+	require.NoError(t, err)
+	manager.autoDiscoveryConfigs, err = dynamic.ParseLabelAutoDiscoverySpecs([]string{"label:tag=gpu,max=4", "label:plan=vc2-4c-8gb,min=2,max=6"})
+	require.NoError(t, err)
+
+	client := &vultrClientMock{}
+	ctx := context.Background()
+
+	client.On("ListNodePools", ctx, manager.clusterID, nil).Return(
+		[]govultr.NodePool{
+			{
+				ID:         "1234",
+				Tag:        "gpu",
+				AutoScaler: false,
+				MinNodes:   1,
+				MaxNodes:   2,
+			},
+			{
+				ID:         "4567",
+				Plan:       "vc2-4c-8gb",
+				AutoScaler: true,
+				MinNodes:   5,
+				MaxNodes:   8,
+			},
+			{
+				ID:         "9876",
+				Tag:        "cpu",
+				AutoScaler: true,
+				MinNodes:   5,
+				MaxNodes:   8,
+			},
+		},
+		&govultr.Meta{},
+		nil,
+	).Once()
+
+	manager.client = client
+
+	err = manager.Refresh()
+	assert.NoError(t, err)
+	require.Equal(t, 2, len(manager.nodeGroups), "number of nodepools do not match")
+
+	assert.Equal(t, "1234", manager.nodeGroups[0].id, "first node group does not match")
+	assert.Equal(t, 1, manager.nodeGroups[0].minSize, "minimum node for first group does not match")
+	assert.Equal(t, 4, manager.nodeGroups[0].maxSize, "maximum node for first group does not match")
+
+	assert.Equal(t, "4567", manager.nodeGroups[1].id, "second node group does not match")
+	assert.Equal(t, 2, manager.nodeGroups[1].minSize, "minimum node for second group does not match")
+	assert.Equal(t, 6, manager.nodeGroups[1].maxSize, "maximum node for second group does not match")
+}
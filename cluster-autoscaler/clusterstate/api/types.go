@@ -62,6 +62,11 @@ const (
 	ClusterAutoscalerNoActivity ClusterAutoscalerConditionStatus = "NoActivity"
 	// ClusterAutoscalerBackoff status means that due to a recently failed scale-up no further scale-ups attempts will be made for some time.
 	ClusterAutoscalerBackoff ClusterAutoscalerConditionStatus = "Backoff"
+
+	// Statuses for ScaleUp and ScaleDown condition types.
+
+	// ClusterAutoscalerPaused status means that the operation is paused until PausedUntil.
+	ClusterAutoscalerPaused ClusterAutoscalerConditionStatus = "Paused"
 )
 
 // RegisteredUnreadyNodeCount contains node counts of registered but unready nodes.
@@ -123,6 +128,8 @@ type NodeGroupHealthCondition struct {
 type ClusterScaleUpCondition struct {
 	// Status of the scale up.
 	Status ClusterAutoscalerConditionStatus `json:"status,omitempty" yaml:"status,omitempty"`
+	// PausedUntil is the time until which the scale up is paused. Only set with the Paused status.
+	PausedUntil *metav1.Time `json:"pausedUntil,omitempty" yaml:"pausedUntil,omitempty"`
 	// LastProbeTime is the last time we probed the condition.
 	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty" yaml:"lastProbeTime,omitempty"`
 	// LastTransitionTime is the time since when the condition was in the given state.
@@ -135,6 +142,8 @@ type BackoffInfo struct {
 	ErrorCode string `json:"errorCode,omitempty" yaml:"errorCode,omitempty"`
 	// ErrorMessage is human readable description of error condition
 	ErrorMessage string `json:"errorMessage,omitempty" yaml:"errorMessage,omitempty"`
+	// BackoffUntil is the time until which scale ups are backed off.
+	BackoffUntil *metav1.Time `json:"backoffUntil,omitempty" yaml:"backoffUntil,omitempty"`
 }
 
 // NodeGroupScaleUpCondition contains information about scale up condition for a node group.
@@ -143,6 +152,8 @@ type NodeGroupScaleUpCondition struct {
 	Status ClusterAutoscalerConditionStatus `json:"status,omitempty" yaml:"status,omitempty"`
 	// LastProbeTime is the last time we probed the condition.
 	BackoffInfo BackoffInfo `json:"backoffInfo,omitempty" yaml:"backoffInfo,omitempty"`
+	// PausedUntil is the time until which the scale up is paused. Only set with the Paused status.
+	PausedUntil *metav1.Time `json:"pausedUntil,omitempty" yaml:"pausedUntil,omitempty"`
 	// LastProbeTime is the last time we probed the condition.
 	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty" yaml:"lastProbeTime,omitempty"`
 	// LastTransitionTime is the time since when the condition was in the given state.
@@ -155,12 +166,36 @@ type ScaleDownCondition struct {
 	Status ClusterAutoscalerConditionStatus `json:"status,omitempty" yaml:"status,omitempty"`
 	// Candidates number for the scale down.
 	Candidates int `json:"candidates,omitempty" yaml:"candidates,omitempty"`
+	// PausedUntil is the time until which the scale down is paused. Only set with the Paused status.
+	PausedUntil *metav1.Time `json:"pausedUntil,omitempty" yaml:"pausedUntil,omitempty"`
 	// LastProbeTime is the last time we probed the condition.
 	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty" yaml:"lastProbeTime,omitempty"`
 	// LastTransitionTime is the time since when the condition was in the given state.
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty" yaml:"lastTransitionTime,omitempty"`
 }
 
+// PodUtilization contains the share of node allocatable requested by a pod.
+type PodUtilization struct {
+	// Namespace of the pod.
+	Namespace string `json:"namespace" yaml:"namespace"`
+	// Name of the pod.
+	Name string `json:"name" yaml:"name"`
+	// Utilization maps resource names to the fraction of node allocatable requested by the pod.
+	Utilization map[string]float64 `json:"utilization,omitempty" yaml:"utilization,omitempty"`
+}
+
+// NodeUtilizationBreakdown explains utilization of a node that is not scaled down because of high utilization.
+type NodeUtilizationBreakdown struct {
+	// Name of the node.
+	Name string `json:"name" yaml:"name"`
+	// ResourceName is the name of the highest utilized resource.
+	ResourceName string `json:"resourceName" yaml:"resourceName"`
+	// Utilization of the highest utilized resource.
+	Utilization float64 `json:"utilization" yaml:"utilization"`
+	// TopPods lists pods with the highest requests of the highest utilized resource.
+	TopPods []PodUtilization `json:"topPods,omitempty" yaml:"topPods,omitempty"`
+}
+
 // ClusterWideStatus contains status that apply to the whole cluster.
 type ClusterWideStatus struct {
 	// Health contains information about health condition of the cluster.
@@ -169,6 +204,8 @@ type ClusterWideStatus struct {
 	ScaleUp ClusterScaleUpCondition `json:"scaleUp,omitempty" yaml:"scaleUp,omitempty"`
 	// ScaleDown contains information about scale down condition of the node group.
 	ScaleDown ScaleDownCondition `json:"scaleDown,omitempty" yaml:"scaleDown,omitempty"`
+	// UtilizationBreakdowns lists pods holding nodes that are not scaled down because of high utilization.
+	UtilizationBreakdowns []NodeUtilizationBreakdown `json:"utilizationBreakdowns,omitempty" yaml:"utilizationBreakdowns,omitempty"`
 }
 
 // NodeGroupStatus contains status of an individual node group on which CA works..
@@ -181,6 +218,9 @@ type NodeGroupStatus struct {
 	ScaleUp NodeGroupScaleUpCondition `json:"scaleUp,omitempty" yaml:"scaleUp,omitempty"`
 	// ScaleDown contains information about scale down condition of the node group.
 	ScaleDown ScaleDownCondition `json:"scaleDown,omitempty" yaml:"scaleDown,omitempty"`
+	// HealthScore is the health score of the node group based on its recent scale-ups, from 0 (unhealthy)
+	// to 1 (healthy). Only set with adaptive node group backoff.
+	HealthScore *float64 `json:"healthScore,omitempty" yaml:"healthScore,omitempty"`
 }
 
 // ClusterAutoscalerStatus contains ClusterAutoscaler status.
@@ -195,4 +235,46 @@ type ClusterAutoscalerStatus struct {
 	ClusterWide ClusterWideStatus `json:"clusterWide,omitempty" yaml:"clusterWide,omitempty"`
 	// NodeGroups contains status information of individual node groups on which CA works.
 	NodeGroups []NodeGroupStatus `json:"nodeGroups,omitempty" yaml:"nodeGroups,omitempty"`
+	// LastError contains information about the error that ended the last autoscaler loop. It's cleared
+	// once a loop succeeds.
+	LastError *LoopError `json:"lastError,omitempty" yaml:"lastError,omitempty"`
+	// LeaderHandoff contains operations in progress, taken over by the next leader.
+	// Only set with leader state handoff.
+	LeaderHandoff *LeaderHandoff `json:"leaderHandoff,omitempty" yaml:"leaderHandoff,omitempty"`
+}
+
+// LeaderHandoff contains operations started by the current leader which the next leader has to take over.
+type LeaderHandoff struct {
+	// ScaleUps lists scale-ups for which nodes haven't registered yet.
+	ScaleUps []ScaleUpInProgress `json:"scaleUps,omitempty" yaml:"scaleUps,omitempty"`
+	// NodeDeletions lists nodes tainted for deletion whose deletion hasn't finished yet.
+	NodeDeletions []NodeDeletionInProgress `json:"nodeDeletions,omitempty" yaml:"nodeDeletions,omitempty"`
+}
+
+// ScaleUpInProgress contains information about a scale-up of a node group that hasn't finished yet.
+type ScaleUpInProgress struct {
+	// NodeGroup is the name of the scaled up node group.
+	NodeGroup string `json:"nodeGroup" yaml:"nodeGroup"`
+	// Increase is the number of nodes added by the scale-up.
+	Increase int `json:"increase" yaml:"increase"`
+	// Time is the time of the scale-up.
+	Time metav1.Time `json:"time" yaml:"time"`
+}
+
+// NodeDeletionInProgress contains information about a node being deleted.
+type NodeDeletionInProgress struct {
+	// Name of the node.
+	Name string `json:"name" yaml:"name"`
+	// Drain tells whether the pods of the node are evicted before the deletion.
+	Drain bool `json:"drain,omitempty" yaml:"drain,omitempty"`
+}
+
+// LoopError contains information about an error that ended an autoscaler loop.
+type LoopError struct {
+	// Type of the error, e.g. 'cloudProviderError'.
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	// Message is human readable description of the error.
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+	// Time is the time of the loop that ended with the error.
+	Time metav1.Time `json:"time,omitempty" yaml:"time,omitempty"`
 }
@@ -26,6 +26,7 @@ import (
 
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/api"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/pause"
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
 	"k8s.io/autoscaler/cluster-autoscaler/metrics"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
@@ -80,6 +81,20 @@ type ScaleDownRequest struct {
 	ExpectedDeleteTime time.Time
 }
 
+// scaleUpOperation is an asynchronous scale-up operation which hasn't finished yet.
+type scaleUpOperation struct {
+	nodeGroup cloudprovider.NodeGroup
+	operation cloudprovider.ScaleUpOperation
+	delta     int
+	time      time.Time
+}
+
+// scaleUpOperationStatus is the result of polling a scaleUpOperation.
+type scaleUpOperationStatus struct {
+	status cloudprovider.ScaleUpOperationStatus
+	err    error
+}
+
 // ClusterStateRegistryConfig contains configuration information for ClusterStateRegistry.
 type ClusterStateRegistryConfig struct {
 	// Maximum percentage of unready nodes in total, if the number of unready nodes is higher than OkTotalUnreadyCount.
@@ -114,14 +129,17 @@ type UnregisteredNode struct {
 type ScaleUpFailure struct {
 	NodeGroup cloudprovider.NodeGroup
 	Reason    metrics.FailedScaleUpReason
-	Time      time.Time
+	// Category is the provider independent category of the failure.
+	Category cloudprovider.ScaleUpFailureCategory
+	Time     time.Time
 }
 
 // ClusterStateRegistry is a structure to keep track the current state of the cluster.
 type ClusterStateRegistry struct {
 	sync.Mutex
 	config                             ClusterStateRegistryConfig
-	scaleUpRequests                    map[string]*ScaleUpRequest // nodeGroupName -> ScaleUpRequest
+	scaleUpRequests                    map[string]*ScaleUpRequest     // nodeGroupName -> ScaleUpRequest
+	scaleUpOperations                  map[string][]*scaleUpOperation // nodeGroupName -> operations of the scale-up request
 	scaleDownRequests                  []*ScaleDownRequest
 	nodes                              []*apiv1.Node
 	nodeInfosForGroups                 map[string]*framework.NodeInfo
@@ -143,6 +161,7 @@ type ClusterStateRegistry struct {
 	interrupt                          chan struct{}
 	nodeGroupConfigProcessor           nodegroupconfig.NodeGroupConfigProcessor
 	asyncNodeGroupStateChecker         asyncnodegroups.AsyncNodeGroupStateChecker
+	pauses                             *pause.Registry
 
 	// scaleUpFailures contains information about scale-up failures for each node group. It should be
 	// cleared periodically to avoid unnecessary accumulation.
@@ -154,12 +173,15 @@ type NodeGroupScalingSafety struct {
 	SafeToScale   bool
 	Healthy       bool
 	BackoffStatus backoff.Status
+	// PausedUntil is the time until which scaling of the node group is paused, zero if it isn't paused.
+	PausedUntil time.Time
 }
 
 // NewClusterStateRegistry creates new ClusterStateRegistry.
 func NewClusterStateRegistry(cloudProvider cloudprovider.CloudProvider, config ClusterStateRegistryConfig, logRecorder *utils.LogEventRecorder, backoff backoff.Backoff, nodeGroupConfigProcessor nodegroupconfig.NodeGroupConfigProcessor, asyncNodeGroupStateChecker asyncnodegroups.AsyncNodeGroupStateChecker) *ClusterStateRegistry {
 	return &ClusterStateRegistry{
 		scaleUpRequests:                 make(map[string]*ScaleUpRequest),
+		scaleUpOperations:               make(map[string][]*scaleUpOperation),
 		scaleDownRequests:               make([]*ScaleDownRequest, 0),
 		nodes:                           make([]*apiv1.Node, 0),
 		cloudProvider:                   cloudProvider,
@@ -181,6 +203,21 @@ func NewClusterStateRegistry(cloudProvider cloudprovider.CloudProvider, config C
 	}
 }
 
+// SetPauses makes the registry consider node groups whose scale-up is paused by
+// the given Registry unsafe to scale up, and report paused operations in the status.
+func (csr *ClusterStateRegistry) SetPauses(pauses *pause.Registry) {
+	csr.pauses = pauses
+}
+
+// PausedUntil returns the time until which operation is paused for the node group,
+// or cluster-wide if nodeGroup is empty, zero if it isn't paused.
+func (csr *ClusterStateRegistry) PausedUntil(operation pause.Operation, nodeGroup string, now time.Time) time.Time {
+	if csr.pauses == nil {
+		return time.Time{}
+	}
+	return csr.pauses.PausedUntil(operation, nodeGroup, now)
+}
+
 // Start starts components running in background.
 func (csr *ClusterStateRegistry) Start() {
 	csr.cloudProviderNodeInstancesCache.Start(csr.interrupt)
@@ -198,6 +235,31 @@ func (csr *ClusterStateRegistry) RegisterScaleUp(nodeGroup cloudprovider.NodeGro
 	csr.registerOrUpdateScaleUpNoLock(nodeGroup, delta, currentTime)
 }
 
+// RegisterScaleUpOperation registers an asynchronous operation of a scale-up registered with RegisterScaleUp.
+// The operation is polled until it finishes, so that scale-ups whose instances weren't provisioned yet can be
+// told from the ones whose nodes don't register, and failed operations are reported as scale-up failures.
+func (csr *ClusterStateRegistry) RegisterScaleUpOperation(nodeGroup cloudprovider.NodeGroup, operation cloudprovider.ScaleUpOperation, delta int, currentTime time.Time) {
+	csr.Lock()
+	defer csr.Unlock()
+	csr.scaleUpOperations[nodeGroup.Id()] = append(csr.scaleUpOperations[nodeGroup.Id()], &scaleUpOperation{
+		nodeGroup: nodeGroup,
+		operation: operation,
+		delta:     delta,
+		time:      currentTime,
+	})
+}
+
+// GetScaleUpRequests returns a copy of the scale-up requests that haven't finished yet, by node group id.
+func (csr *ClusterStateRegistry) GetScaleUpRequests() map[string]ScaleUpRequest {
+	csr.Lock()
+	defer csr.Unlock()
+	result := make(map[string]ScaleUpRequest, len(csr.scaleUpRequests))
+	for nodeGroupId, request := range csr.scaleUpRequests {
+		result[nodeGroupId] = *request
+	}
+	return result
+}
+
 // MaxNodeProvisionTime returns MaxNodeProvisionTime value that should be used for the given NodeGroup.
 // TODO(BigDarkClown): remove this method entirely, it is a redundant wrapper
 func (csr *ClusterStateRegistry) MaxNodeProvisionTime(nodeGroup cloudprovider.NodeGroup) (time.Duration, error) {
@@ -258,7 +320,7 @@ func (csr *ClusterStateRegistry) RegisterScaleDown(nodeGroup cloudprovider.NodeG
 }
 
 // To be executed under a lock.
-func (csr *ClusterStateRegistry) updateScaleRequests(currentTime time.Time) {
+func (csr *ClusterStateRegistry) updateScaleRequests(currentTime time.Time, operationStatuses map[*scaleUpOperation]scaleUpOperationStatus) {
 	// clean up stale backoff info
 	csr.backoff.RemoveStaleBackoffData(currentTime)
 
@@ -266,36 +328,52 @@ func (csr *ClusterStateRegistry) updateScaleRequests(currentTime time.Time) {
 		if csr.asyncNodeGroupStateChecker.IsUpcoming(scaleUpRequest.NodeGroup) {
 			continue
 		}
+		csr.updateScaleUpOperationsNoLock(nodeGroupName, operationStatuses, currentTime)
+		if _, found := csr.scaleUpRequests[nodeGroupName]; !found {
+			// All the instances requested by the scale-up failed to provision.
+			continue
+		}
 		if !csr.areThereUpcomingNodesInNodeGroup(nodeGroupName) {
 			// scale up finished successfully, remove request
 			delete(csr.scaleUpRequests, nodeGroupName)
 			klog.V(4).Infof("Scale up in group %v finished successfully in %v",
 				nodeGroupName, currentTime.Sub(scaleUpRequest.Time))
+			if healthScorer, ok := csr.backoff.(backoff.HealthScorer); ok {
+				healthScorer.RegisterScaleUpSuccess(scaleUpRequest.NodeGroup, csr.nodeInfosForGroups[nodeGroupName], currentTime.Sub(scaleUpRequest.Time), currentTime)
+			}
 			continue
 		}
 
 		if scaleUpRequest.ExpectedAddTime.Before(currentTime) {
 			klog.Warningf("Scale-up timed out for node group %v after %v",
 				nodeGroupName, currentTime.Sub(scaleUpRequest.Time))
-			csr.logRecorder.Eventf(apiv1.EventTypeWarning, "ScaleUpTimedOut",
-				"Nodes added to group %s failed to register within %v",
-				scaleUpRequest.NodeGroup.Id(), currentTime.Sub(scaleUpRequest.Time))
-			availableGPUTypes := csr.cloudProvider.GetAvailableGPUTypes()
-			gpuResource, gpuType := "", ""
-			nodeInfo, err := scaleUpRequest.NodeGroup.TemplateNodeInfo()
-			if err != nil {
-				klog.Warningf("Failed to get template node info for a node group: %s", err)
+			errorCode, errorMessage := "timeout", fmt.Sprintf("Scale-up timed out for node group %v after %v", nodeGroupName, currentTime.Sub(scaleUpRequest.Time))
+			if len(csr.scaleUpOperations[nodeGroupName]) > 0 {
+				// The cloud provider didn't provision the instances, so it's not the nodes failing to register.
+				errorCode = "provisioningTimeout"
+				errorMessage = fmt.Sprintf("Instances requested for node group %v weren't provisioned within %v", nodeGroupName, currentTime.Sub(scaleUpRequest.Time))
+				csr.logRecorder.Eventf(apiv1.EventTypeWarning, "ScaleUpTimedOut",
+					"Instances requested for group %s weren't provisioned within %v",
+					scaleUpRequest.NodeGroup.Id(), currentTime.Sub(scaleUpRequest.Time))
 			} else {
-				gpuResource, gpuType = gpu.GetGpuInfoForMetrics(csr.cloudProvider.GetNodeGpuConfig(nodeInfo.Node()), availableGPUTypes, nodeInfo.Node(), scaleUpRequest.NodeGroup)
+				csr.logRecorder.Eventf(apiv1.EventTypeWarning, "ScaleUpTimedOut",
+					"Nodes added to group %s failed to register within %v",
+					scaleUpRequest.NodeGroup.Id(), currentTime.Sub(scaleUpRequest.Time))
 			}
+			gpuResource, gpuType := csr.gpuInfoForMetrics(scaleUpRequest.NodeGroup)
 			csr.registerFailedScaleUpNoLock(scaleUpRequest.NodeGroup, metrics.Timeout, cloudprovider.InstanceErrorInfo{
 				ErrorClass:   cloudprovider.OtherErrorClass,
-				ErrorCode:    "timeout",
-				ErrorMessage: fmt.Sprintf("Scale-up timed out for node group %v after %v", nodeGroupName, currentTime.Sub(scaleUpRequest.Time)),
+				ErrorCode:    errorCode,
+				ErrorMessage: errorMessage,
 			}, gpuResource, gpuType, currentTime)
 			delete(csr.scaleUpRequests, nodeGroupName)
 		}
 	}
+	for nodeGroupName := range csr.scaleUpOperations {
+		if _, found := csr.scaleUpRequests[nodeGroupName]; !found {
+			delete(csr.scaleUpOperations, nodeGroupName)
+		}
+	}
 
 	newScaleDownRequests := make([]*ScaleDownRequest, 0)
 	for _, scaleDownRequest := range csr.scaleDownRequests {
@@ -306,6 +384,99 @@ func (csr *ClusterStateRegistry) updateScaleRequests(currentTime time.Time) {
 	csr.scaleDownRequests = newScaleDownRequests
 }
 
+// pollScaleUpOperations gets the status of all scale-up operations which haven't finished yet.
+// Cloud providers may query their APIs to get the status, so the operations are polled without
+// holding the lock.
+func (csr *ClusterStateRegistry) pollScaleUpOperations() map[*scaleUpOperation]scaleUpOperationStatus {
+	csr.Lock()
+	var operations []*scaleUpOperation
+	for _, nodeGroupOperations := range csr.scaleUpOperations {
+		operations = append(operations, nodeGroupOperations...)
+	}
+	csr.Unlock()
+
+	statuses := make(map[*scaleUpOperation]scaleUpOperationStatus, len(operations))
+	for _, op := range operations {
+		status, err := op.operation.Status()
+		statuses[op] = scaleUpOperationStatus{status: status, err: err}
+	}
+	return statuses
+}
+
+// updateScaleUpOperationsNoLock updates the operations of the scale-up of the node group with their polled
+// statuses, forgetting the finished ones. For failed operations, a scale-up failure is registered and the
+// instances which weren't provisioned are removed from the scale-up request. Operations registered after
+// the statuses were polled are left for the next loop. To be executed under a lock.
+func (csr *ClusterStateRegistry) updateScaleUpOperationsNoLock(nodeGroupName string, operationStatuses map[*scaleUpOperation]scaleUpOperationStatus, currentTime time.Time) {
+	operations, found := csr.scaleUpOperations[nodeGroupName]
+	if !found {
+		return
+	}
+	var pending []*scaleUpOperation
+	for _, op := range operations {
+		polled, found := operationStatuses[op]
+		if !found {
+			pending = append(pending, op)
+			continue
+		}
+		if polled.err != nil {
+			klog.Warningf("Failed to get status of scale-up operation %s of node group %s: %v", op.operation.Id(), nodeGroupName, polled.err)
+			pending = append(pending, op)
+			continue
+		}
+		status := polled.status
+		switch status.State {
+		case cloudprovider.ScaleUpOperationProvisioned:
+			klog.V(4).Infof("Scale-up operation %s of node group %s provisioned %d instances in %v", op.operation.Id(), nodeGroupName, op.delta, currentTime.Sub(op.time))
+		case cloudprovider.ScaleUpOperationFailed:
+			csr.registerFailedScaleUpOperationNoLock(op, status, currentTime)
+		default:
+			pending = append(pending, op)
+		}
+	}
+	if len(pending) == 0 {
+		delete(csr.scaleUpOperations, nodeGroupName)
+		return
+	}
+	csr.scaleUpOperations[nodeGroupName] = pending
+}
+
+// To be executed under a lock.
+func (csr *ClusterStateRegistry) registerFailedScaleUpOperationNoLock(op *scaleUpOperation, status cloudprovider.ScaleUpOperationStatus, currentTime time.Time) {
+	nodeGroupName := op.nodeGroup.Id()
+	errorInfo := cloudprovider.InstanceErrorInfo{
+		ErrorClass:   cloudprovider.OtherErrorClass,
+		ErrorCode:    "operationFailed",
+		ErrorMessage: fmt.Sprintf("Scale-up operation %s failed", op.operation.Id()),
+	}
+	if status.ErrorInfo != nil {
+		errorInfo = *status.ErrorInfo
+	}
+	klog.Warningf("Scale-up operation %s of node group %s failed after provisioning %d out of %d instances: %s",
+		op.operation.Id(), nodeGroupName, status.ProvisionedCount, op.delta, errorInfo.ErrorMessage)
+	csr.logRecorder.Eventf(apiv1.EventTypeWarning, "FailedToScaleUpGroup",
+		"Scale-up failed for group %s after provisioning %d out of %d instances: %s",
+		nodeGroupName, status.ProvisionedCount, op.delta, errorInfo.ErrorMessage)
+	gpuResource, gpuType := csr.gpuInfoForMetrics(op.nodeGroup)
+	csr.registerFailedScaleUpNoLock(op.nodeGroup, metrics.CloudProviderError, errorInfo, gpuResource, gpuType, currentTime)
+	// The scale-up request may be gone already, e.g. if an earlier operation failed to provision its instances too.
+	if _, found := csr.scaleUpRequests[nodeGroupName]; !found {
+		return
+	}
+	if unprovisioned := op.delta - status.ProvisionedCount; unprovisioned > 0 {
+		csr.registerOrUpdateScaleUpNoLock(op.nodeGroup, -unprovisioned, currentTime)
+	}
+}
+
+func (csr *ClusterStateRegistry) gpuInfoForMetrics(nodeGroup cloudprovider.NodeGroup) (gpuResource, gpuType string) {
+	nodeInfo, err := nodeGroup.TemplateNodeInfo()
+	if err != nil {
+		klog.Warningf("Failed to get template node info for a node group: %s", err)
+		return "", ""
+	}
+	return gpu.GetGpuInfoForMetrics(csr.cloudProvider.GetNodeGpuConfig(nodeInfo.Node()), csr.cloudProvider.GetAvailableGPUTypes(), nodeInfo.Node(), nodeGroup)
+}
+
 // To be executed under a lock.
 func (csr *ClusterStateRegistry) backoffNodeGroup(nodeGroup cloudprovider.NodeGroup, errorInfo cloudprovider.InstanceErrorInfo, currentTime time.Time) {
 	nodeGroupInfo := csr.nodeInfosForGroups[nodeGroup.Id()]
@@ -332,8 +503,10 @@ func (csr *ClusterStateRegistry) RegisterFailedScaleDown(_ cloudprovider.NodeGro
 }
 
 func (csr *ClusterStateRegistry) registerFailedScaleUpNoLock(nodeGroup cloudprovider.NodeGroup, reason metrics.FailedScaleUpReason, errorInfo cloudprovider.InstanceErrorInfo, gpuResourceName, gpuType string, currentTime time.Time) {
-	csr.scaleUpFailures[nodeGroup.Id()] = append(csr.scaleUpFailures[nodeGroup.Id()], ScaleUpFailure{NodeGroup: nodeGroup, Reason: reason, Time: currentTime})
+	category := cloudprovider.ScaleUpFailureCategoryOf(errorInfo)
+	csr.scaleUpFailures[nodeGroup.Id()] = append(csr.scaleUpFailures[nodeGroup.Id()], ScaleUpFailure{NodeGroup: nodeGroup, Reason: reason, Category: category, Time: currentTime})
 	metrics.RegisterFailedScaleUp(reason, gpuResourceName, gpuType)
+	metrics.RegisterScaleUpFailureCategory(category)
 	csr.backoffNodeGroup(nodeGroup, errorInfo, currentTime)
 }
 
@@ -352,6 +525,7 @@ func (csr *ClusterStateRegistry) UpdateNodes(nodes []*apiv1.Node, nodeInfosForGr
 	}
 	cloudProviderNodesRemoved := csr.getCloudProviderDeletedNodes(nodes)
 	notRegistered := getNotRegisteredNodes(nodes, cloudProviderNodeInstances, currentTime)
+	operationStatuses := csr.pollScaleUpOperations()
 
 	csr.Lock()
 	defer csr.Unlock()
@@ -368,7 +542,7 @@ func (csr *ClusterStateRegistry) UpdateNodes(nodes []*apiv1.Node, nodeInfosForGr
 	// update acceptable ranges based on requests from last loop and targetSizes
 	// updateScaleRequests relies on acceptableRanges being up to date
 	csr.updateAcceptableRanges(targetSizes)
-	csr.updateScaleRequests(currentTime)
+	csr.updateScaleRequests(currentTime, operationStatuses)
 	csr.handleInstanceCreationErrors(currentTime)
 	//  recalculate acceptable ranges after removing timed out requests
 	csr.updateAcceptableRanges(targetSizes)
@@ -468,11 +642,27 @@ func (csr *ClusterStateRegistry) BackoffStatusForNodeGroup(nodeGroup cloudprovid
 	return csr.backoff.BackoffStatus(nodeGroup, csr.nodeInfosForGroups[nodeGroup.Id()], now)
 }
 
+// NodeGroupHealthScore returns the health score of the node group, from 0 (unhealthy) to 1 (healthy),
+// and whether it is known. Health scores are only known if the backoff scores node group health.
+func (csr *ClusterStateRegistry) NodeGroupHealthScore(nodeGroup cloudprovider.NodeGroup, now time.Time) (float64, bool) {
+	healthScorer, ok := csr.backoff.(backoff.HealthScorer)
+	if !ok {
+		return 0, false
+	}
+	return healthScorer.HealthScore(nodeGroup, csr.nodeInfosForGroups[nodeGroup.Id()], now), true
+}
+
 // NodeGroupScaleUpSafety returns information about node group safety to be scaled up now.
 func (csr *ClusterStateRegistry) NodeGroupScaleUpSafety(nodeGroup cloudprovider.NodeGroup, now time.Time) NodeGroupScalingSafety {
 	isHealthy := csr.IsNodeGroupHealthy(nodeGroup.Id())
 	backoffStatus := csr.backoff.BackoffStatus(nodeGroup, csr.nodeInfosForGroups[nodeGroup.Id()], now)
-	return NodeGroupScalingSafety{SafeToScale: isHealthy && !backoffStatus.IsBackedOff, Healthy: isHealthy, BackoffStatus: backoffStatus}
+	pausedUntil := csr.PausedUntil(pause.ScaleUp, nodeGroup.Id(), now)
+	return NodeGroupScalingSafety{
+		SafeToScale:   isHealthy && !backoffStatus.IsBackedOff && pausedUntil.IsZero(),
+		Healthy:       isHealthy,
+		BackoffStatus: backoffStatus,
+		PausedUntil:   pausedUntil,
+	}
 }
 
 func (csr *ClusterStateRegistry) getProvisionedAndTargetSizesForNodeGroup(nodeGroupName string) (provisioned, target int, ok bool) {
@@ -803,16 +993,24 @@ func (csr *ClusterStateRegistry) GetStatus(now time.Time) *api.ClusterAutoscaler
 
 		// Scale down.
 		nodeGroupStatus.ScaleDown = buildScaleDownStatusNodeGroup(
-			csr.candidatesForScaleDown[nodeGroup.Id()], csr.lastScaleDownUpdateTime, nodeGroupLastStatus.ScaleDown)
+			csr.candidatesForScaleDown[nodeGroup.Id()], csr.lastScaleDownUpdateTime,
+			csr.PausedUntil(pause.ScaleDown, nodeGroup.Id(), now), nodeGroupLastStatus.ScaleDown)
+
+		// Health score.
+		if score, found := csr.NodeGroupHealthScore(nodeGroup, now); found {
+			nodeGroupStatus.HealthScore = &score
+		}
 
 		result.NodeGroups = append(result.NodeGroups, nodeGroupStatus)
 	}
 	result.ClusterWide.Health =
 		buildHealthStatusClusterwide(csr.IsClusterHealthy(), csr.totalReadiness, csr.lastStatus.ClusterWide.Health)
 	result.ClusterWide.ScaleUp =
-		buildScaleUpStatusClusterwide(result.NodeGroups, csr.totalReadiness, csr.lastStatus.ClusterWide.ScaleUp)
+		buildScaleUpStatusClusterwide(result.NodeGroups, csr.totalReadiness,
+			csr.PausedUntil(pause.ScaleUp, "", now), csr.lastStatus.ClusterWide.ScaleUp)
 	result.ClusterWide.ScaleDown =
-		buildScaleDownStatusClusterwide(csr.candidatesForScaleDown, csr.lastScaleDownUpdateTime, csr.lastStatus.ClusterWide.ScaleDown)
+		buildScaleDownStatusClusterwide(csr.candidatesForScaleDown, csr.lastScaleDownUpdateTime,
+			csr.PausedUntil(pause.ScaleDown, "", now), csr.lastStatus.ClusterWide.ScaleDown)
 
 	csr.lastStatus = result
 	return result
@@ -869,6 +1067,9 @@ func (csr *ClusterStateRegistry) buildScaleUpStatusNodeGroup(nodeGroup cloudprov
 	}
 	if isScaleUpInProgress {
 		condition.Status = api.ClusterAutoscalerInProgress
+	} else if !scaleUpSafety.PausedUntil.IsZero() {
+		condition.Status = api.ClusterAutoscalerPaused
+		condition.PausedUntil = pausedUntilTime(scaleUpSafety.PausedUntil)
 	} else if !scaleUpSafety.Healthy {
 		condition.Status = api.ClusterAutoscalerUnhealthy
 	} else if !scaleUpSafety.SafeToScale {
@@ -877,6 +1078,10 @@ func (csr *ClusterStateRegistry) buildScaleUpStatusNodeGroup(nodeGroup cloudprov
 			ErrorCode:    scaleUpSafety.BackoffStatus.ErrorInfo.ErrorCode,
 			ErrorMessage: truncateIfExceedMaxLength(scaleUpSafety.BackoffStatus.ErrorInfo.ErrorMessage, maxErrorMessageSize),
 		}
+		if !scaleUpSafety.BackoffStatus.BackoffUntil.IsZero() {
+			backoffUntil := metav1.NewTime(scaleUpSafety.BackoffStatus.BackoffUntil)
+			condition.BackoffInfo.BackoffUntil = &backoffUntil
+		}
 	} else {
 		condition.Status = api.ClusterAutoscalerNoActivity
 	}
@@ -888,12 +1093,15 @@ func (csr *ClusterStateRegistry) buildScaleUpStatusNodeGroup(nodeGroup cloudprov
 	return condition
 }
 
-func buildScaleDownStatusNodeGroup(candidates []string, lastProbed time.Time, lastStatus api.ScaleDownCondition) api.ScaleDownCondition {
+func buildScaleDownStatusNodeGroup(candidates []string, lastProbed, pausedUntil time.Time, lastStatus api.ScaleDownCondition) api.ScaleDownCondition {
 	condition := api.ScaleDownCondition{
 		Candidates:    len(candidates),
 		LastProbeTime: metav1.Time{Time: lastProbed},
 	}
-	if len(candidates) > 0 {
+	if !pausedUntil.IsZero() {
+		condition.Status = api.ClusterAutoscalerPaused
+		condition.PausedUntil = pausedUntilTime(pausedUntil)
+	} else if len(candidates) > 0 {
 		condition.Status = api.ClusterAutoscalerCandidatesPresent
 	} else {
 		condition.Status = api.ClusterAutoscalerNoCandidates
@@ -924,7 +1132,7 @@ func buildHealthStatusClusterwide(isHealthy bool, readiness Readiness, lastStatu
 	return condition
 }
 
-func buildScaleUpStatusClusterwide(nodeGroupsStatuses []api.NodeGroupStatus, readiness Readiness, lastStatus api.ClusterScaleUpCondition) api.ClusterScaleUpCondition {
+func buildScaleUpStatusClusterwide(nodeGroupsStatuses []api.NodeGroupStatus, readiness Readiness, pausedUntil time.Time, lastStatus api.ClusterScaleUpCondition) api.ClusterScaleUpCondition {
 	isScaleUpInProgress := false
 	for _, nodeGroupStatus := range nodeGroupsStatuses {
 		if nodeGroupStatus.ScaleUp.Status == api.ClusterAutoscalerInProgress {
@@ -937,6 +1145,9 @@ func buildScaleUpStatusClusterwide(nodeGroupsStatuses []api.NodeGroupStatus, rea
 	}
 	if isScaleUpInProgress {
 		condition.Status = api.ClusterAutoscalerInProgress
+	} else if !pausedUntil.IsZero() {
+		condition.Status = api.ClusterAutoscalerPaused
+		condition.PausedUntil = pausedUntilTime(pausedUntil)
 	} else {
 		condition.Status = api.ClusterAutoscalerNoActivity
 	}
@@ -948,7 +1159,7 @@ func buildScaleUpStatusClusterwide(nodeGroupsStatuses []api.NodeGroupStatus, rea
 	return condition
 }
 
-func buildScaleDownStatusClusterwide(candidates map[string][]string, lastProbed time.Time, lastStatus api.ScaleDownCondition) api.ScaleDownCondition {
+func buildScaleDownStatusClusterwide(candidates map[string][]string, lastProbed, pausedUntil time.Time, lastStatus api.ScaleDownCondition) api.ScaleDownCondition {
 	totalCandidates := 0
 	for _, val := range candidates {
 		totalCandidates += len(val)
@@ -957,7 +1168,10 @@ func buildScaleDownStatusClusterwide(candidates map[string][]string, lastProbed
 		Candidates:    totalCandidates,
 		LastProbeTime: metav1.Time{Time: lastProbed},
 	}
-	if totalCandidates > 0 {
+	if !pausedUntil.IsZero() {
+		condition.Status = api.ClusterAutoscalerPaused
+		condition.PausedUntil = pausedUntilTime(pausedUntil)
+	} else if totalCandidates > 0 {
 		condition.Status = api.ClusterAutoscalerCandidatesPresent
 	} else {
 		condition.Status = api.ClusterAutoscalerNoCandidates
@@ -970,6 +1184,11 @@ func buildScaleDownStatusClusterwide(candidates map[string][]string, lastProbed
 	return condition
 }
 
+func pausedUntilTime(pausedUntil time.Time) *metav1.Time {
+	t := metav1.NewTime(pausedUntil)
+	return &t
+}
+
 // GetIncorrectNodeGroupSize gets IncorrectNodeGroupSizeInformation for the given node group.
 func (csr *ClusterStateRegistry) GetIncorrectNodeGroupSize(nodeGroupName string) *IncorrectNodeGroupSize {
 	result, found := csr.incorrectNodeGroupSizes[nodeGroupName]
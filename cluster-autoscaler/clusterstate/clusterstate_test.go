@@ -26,6 +26,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/api"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/pause"
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
 	"k8s.io/autoscaler/cluster-autoscaler/metrics"
@@ -36,6 +37,7 @@ import (
 	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
 	"k8s.io/client-go/kubernetes/fake"
 	kube_record "k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 
 	"github.com/stretchr/testify/assert"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/backoff"
@@ -529,7 +531,7 @@ func TestExpiredScaleUp(t *testing.T) {
 	assert.False(t, clusterstate.IsNodeGroupHealthy("ng1"))
 	assert.Equal(t, clusterstate.GetScaleUpFailures(), map[string][]ScaleUpFailure{
 		"ng1": {
-			{NodeGroup: provider.GetNodeGroup("ng1"), Time: now, Reason: metrics.Timeout},
+			{NodeGroup: provider.GetNodeGroup("ng1"), Time: now, Reason: metrics.Timeout, Category: cloudprovider.ScaleUpFailureTimeout},
 		},
 	})
 }
@@ -550,7 +552,7 @@ func TestRegisterScaleDown(t *testing.T) {
 	now := time.Now()
 	clusterstate.RegisterScaleDown(provider.GetNodeGroup("ng1"), "ng1-1", now.Add(time.Minute), now)
 	assert.Equal(t, 1, len(clusterstate.scaleDownRequests))
-	clusterstate.updateScaleRequests(now.Add(5 * time.Minute))
+	clusterstate.updateScaleRequests(now.Add(5*time.Minute), nil)
 	assert.Equal(t, 0, len(clusterstate.scaleDownRequests))
 	assert.Empty(t, clusterstate.GetScaleUpFailures())
 }
@@ -834,6 +836,75 @@ func TestCloudProviderDeletedNodes(t *testing.T) {
 	assert.Equal(t, 0, len(GetCloudProviderDeletedNodeNames(clusterstate)))
 }
 
+func TestPauses(t *testing.T) {
+	now := time.Now()
+
+	ng1_1 := BuildTestNode("ng1-1", 1000, 1000)
+	SetNodeReadyState(ng1_1, true, now.Add(-time.Minute))
+	ng2_1 := BuildTestNode("ng2-1", 1000, 1000)
+	SetNodeReadyState(ng2_1, true, now.Add(-time.Minute))
+
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 1, 10, 1)
+	provider.AddNodeGroup("ng2", 1, 10, 1)
+	ng1 := provider.GetNodeGroup("ng1")
+	ng2 := provider.GetNodeGroup("ng2")
+	provider.AddNode("ng1", ng1_1)
+	provider.AddNode("ng2", ng2_1)
+
+	fakeClient := &fake.Clientset{}
+	fakeLogRecorder, _ := utils.NewStatusMapRecorder(fakeClient, "kube-system", kube_record.NewFakeRecorder(5), false, "my-cool-configmap")
+	clusterstate := NewClusterStateRegistry(provider, ClusterStateRegistryConfig{}, fakeLogRecorder, newBackoff(),
+		nodegroupconfig.NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{MaxNodeProvisionTime: 15 * time.Minute}),
+		asyncnodegroups.NewDefaultAsyncNodeGroupStateChecker())
+	pauses := pause.NewRegistry()
+	clusterstate.SetPauses(pauses)
+	err := clusterstate.UpdateNodes([]*apiv1.Node{ng1_1, ng2_1}, nil, now)
+	assert.NoError(t, err)
+
+	until := now.Add(time.Hour)
+	assert.NoError(t, pauses.Pause(pause.Pause{Operation: pause.ScaleUp, NodeGroup: "ng1", Until: metav1.NewTime(until)}))
+	assert.Equal(t, NodeGroupScalingSafety{SafeToScale: false, Healthy: true, PausedUntil: until}, clusterstate.NodeGroupScaleUpSafety(ng1, now))
+	assert.Equal(t, NodeGroupScalingSafety{SafeToScale: true, Healthy: true}, clusterstate.NodeGroupScaleUpSafety(ng2, now))
+
+	status := clusterstate.GetStatus(now)
+	assert.Equal(t, api.ClusterAutoscalerNoActivity, status.ClusterWide.ScaleUp.Status)
+	assert.Equal(t, api.ClusterAutoscalerNoCandidates, status.ClusterWide.ScaleDown.Status)
+	ng1Status := getNodeGroupStatus(status, "ng1")
+	assert.Equal(t, api.ClusterAutoscalerPaused, ng1Status.ScaleUp.Status)
+	if assert.NotNil(t, ng1Status.ScaleUp.PausedUntil) {
+		assert.True(t, until.Equal(ng1Status.ScaleUp.PausedUntil.Time))
+	}
+	assert.Equal(t, api.ClusterAutoscalerNoCandidates, ng1Status.ScaleDown.Status)
+	assert.Equal(t, api.ClusterAutoscalerNoActivity, getNodeGroupStatus(status, "ng2").ScaleUp.Status)
+
+	// Cluster-wide pauses apply to all node groups.
+	assert.NoError(t, pauses.Pause(pause.Pause{Operation: pause.ScaleDown, Until: metav1.NewTime(until)}))
+	status = clusterstate.GetStatus(now)
+	assert.Equal(t, api.ClusterAutoscalerPaused, status.ClusterWide.ScaleDown.Status)
+	if assert.NotNil(t, status.ClusterWide.ScaleDown.PausedUntil) {
+		assert.True(t, until.Equal(status.ClusterWide.ScaleDown.PausedUntil.Time))
+	}
+	assert.Equal(t, api.ClusterAutoscalerPaused, getNodeGroupStatus(status, "ng2").ScaleDown.Status)
+
+	// Operations are resumed once the pauses expire.
+	now = until
+	assert.Equal(t, NodeGroupScalingSafety{SafeToScale: true, Healthy: true}, clusterstate.NodeGroupScaleUpSafety(ng1, now))
+	status = clusterstate.GetStatus(now)
+	assert.Equal(t, api.ClusterAutoscalerNoCandidates, status.ClusterWide.ScaleDown.Status)
+	assert.Nil(t, status.ClusterWide.ScaleDown.PausedUntil)
+	assert.Equal(t, api.ClusterAutoscalerNoActivity, getNodeGroupStatus(status, "ng1").ScaleUp.Status)
+}
+
+func getNodeGroupStatus(status *api.ClusterAutoscalerStatus, name string) api.NodeGroupStatus {
+	for _, nodeGroupStatus := range status.NodeGroups {
+		if nodeGroupStatus.Name == name {
+			return nodeGroupStatus
+		}
+	}
+	return api.NodeGroupStatus{}
+}
+
 func TestScaleUpBackoff(t *testing.T) {
 	now := time.Now()
 
@@ -865,13 +936,15 @@ func TestScaleUpBackoff(t *testing.T) {
 	clusterstate.RegisterScaleUp(provider.GetNodeGroup("ng1"), 1, now.Add(-180*time.Second))
 	err := clusterstate.UpdateNodes([]*apiv1.Node{ng1_1, ng1_2, ng1_3}, nil, now)
 	assert.NoError(t, err)
+	backoffUntil := now.Add(5 * time.Minute /*InitialNodeGroupBackoffDuration*/)
 	assert.True(t, clusterstate.IsClusterHealthy())
 	assert.True(t, clusterstate.IsNodeGroupHealthy("ng1"))
 	assert.Equal(t, NodeGroupScalingSafety{
 		SafeToScale: false,
 		Healthy:     true,
 		BackoffStatus: backoff.Status{
-			IsBackedOff: true,
+			IsBackedOff:  true,
+			BackoffUntil: backoffUntil,
 			ErrorInfo: cloudprovider.InstanceErrorInfo{
 				ErrorClass:   cloudprovider.OtherErrorClass,
 				ErrorCode:    "timeout",
@@ -880,7 +953,8 @@ func TestScaleUpBackoff(t *testing.T) {
 		},
 	}, clusterstate.NodeGroupScaleUpSafety(ng1, now))
 	assert.Equal(t, backoff.Status{
-		IsBackedOff: true,
+		IsBackedOff:  true,
+		BackoffUntil: backoffUntil,
 		ErrorInfo: cloudprovider.InstanceErrorInfo{
 			ErrorClass:   cloudprovider.OtherErrorClass,
 			ErrorCode:    "timeout",
@@ -898,13 +972,15 @@ func TestScaleUpBackoff(t *testing.T) {
 
 	err = clusterstate.UpdateNodes([]*apiv1.Node{ng1_1, ng1_2, ng1_3}, nil, now)
 	assert.NoError(t, err)
+	backoffUntil = now.Add(10 * time.Minute)
 	assert.True(t, clusterstate.IsClusterHealthy())
 	assert.True(t, clusterstate.IsNodeGroupHealthy("ng1"))
 	assert.Equal(t, NodeGroupScalingSafety{
 		SafeToScale: false,
 		Healthy:     true,
 		BackoffStatus: backoff.Status{
-			IsBackedOff: true,
+			IsBackedOff:  true,
+			BackoffUntil: backoffUntil,
 			ErrorInfo: cloudprovider.InstanceErrorInfo{
 				ErrorClass:   cloudprovider.OtherErrorClass,
 				ErrorCode:    "timeout",
@@ -918,7 +994,8 @@ func TestScaleUpBackoff(t *testing.T) {
 		SafeToScale: false,
 		Healthy:     true,
 		BackoffStatus: backoff.Status{
-			IsBackedOff: true,
+			IsBackedOff:  true,
+			BackoffUntil: backoffUntil,
 			ErrorInfo: cloudprovider.InstanceErrorInfo{
 				ErrorClass:   cloudprovider.OtherErrorClass,
 				ErrorCode:    "timeout",
@@ -940,7 +1017,8 @@ func TestScaleUpBackoff(t *testing.T) {
 		SafeToScale: false,
 		Healthy:     true,
 		BackoffStatus: backoff.Status{
-			IsBackedOff: true,
+			IsBackedOff:  true,
+			BackoffUntil: backoffUntil,
 			ErrorInfo: cloudprovider.InstanceErrorInfo{
 				ErrorClass:   cloudprovider.OtherErrorClass,
 				ErrorCode:    "timeout",
@@ -949,7 +1027,8 @@ func TestScaleUpBackoff(t *testing.T) {
 		},
 	}, clusterstate.NodeGroupScaleUpSafety(ng1, now))
 	assert.Equal(t, backoff.Status{
-		IsBackedOff: true,
+		IsBackedOff:  true,
+		BackoffUntil: backoffUntil,
 		ErrorInfo: cloudprovider.InstanceErrorInfo{
 			ErrorClass:   cloudprovider.OtherErrorClass,
 			ErrorCode:    "timeout",
@@ -1081,11 +1160,11 @@ func TestScaleUpFailures(t *testing.T) {
 	failures := clusterstate.GetScaleUpFailures()
 	assert.Equal(t, map[string][]ScaleUpFailure{
 		"ng1": {
-			{NodeGroup: provider.GetNodeGroup("ng1"), Reason: metrics.Timeout, Time: now},
-			{NodeGroup: provider.GetNodeGroup("ng1"), Reason: metrics.APIError, Time: now.Add(time.Minute)},
+			{NodeGroup: provider.GetNodeGroup("ng1"), Reason: metrics.Timeout, Category: cloudprovider.ScaleUpFailureTimeout, Time: now},
+			{NodeGroup: provider.GetNodeGroup("ng1"), Reason: metrics.APIError, Category: cloudprovider.ScaleUpFailureOther, Time: now.Add(time.Minute)},
 		},
 		"ng2": {
-			{NodeGroup: provider.GetNodeGroup("ng2"), Reason: metrics.Timeout, Time: now},
+			{NodeGroup: provider.GetNodeGroup("ng2"), Reason: metrics.Timeout, Category: cloudprovider.ScaleUpFailureTimeout, Time: now},
 		},
 	}, failures)
 
@@ -1569,3 +1648,171 @@ func TestUpcomingNodesFromUpcomingNodeGroups(t *testing.T) {
 	}
 
 }
+
+func TestNodeGroupHealthScore(t *testing.T) {
+	now := time.Now()
+
+	ng1_1 := BuildTestNode("ng1-1", 1000, 1000)
+	SetNodeReadyState(ng1_1, true, now.Add(-time.Minute))
+
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 1, 10, 1)
+	provider.AddNode("ng1", ng1_1)
+
+	for _, tc := range []struct {
+		name                string
+		backoff             backoff.Backoff
+		expectedHealthScore *float64
+	}{
+		{
+			name:    "exponential backoff",
+			backoff: newBackoff(),
+		},
+		{
+			name:                "adaptive backoff",
+			backoff:             backoff.NewIdBasedAdaptiveBackoff(5*time.Minute, 30*time.Minute, time.Hour, 5*time.Minute),
+			expectedHealthScore: ptr.To(0.5),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient := &fake.Clientset{}
+			fakeLogRecorder, _ := utils.NewStatusMapRecorder(fakeClient, "kube-system", kube_record.NewFakeRecorder(5), false, "my-cool-configmap")
+			clusterstate := NewClusterStateRegistry(provider, ClusterStateRegistryConfig{}, fakeLogRecorder, tc.backoff,
+				nodegroupconfig.NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{MaxNodeProvisionTime: 15 * time.Minute}), asyncnodegroups.NewDefaultAsyncNodeGroupStateChecker())
+			// The scale-up finished successfully, its node took 10 minutes to become ready.
+			clusterstate.RegisterScaleUp(provider.GetNodeGroup("ng1"), 1, now.Add(-10*time.Minute))
+			assert.NoError(t, clusterstate.UpdateNodes([]*apiv1.Node{ng1_1}, nil, now))
+
+			status := clusterstate.GetStatus(now)
+			assert.Len(t, status.NodeGroups, 1)
+			assert.Equal(t, tc.expectedHealthScore, status.NodeGroups[0].HealthScore)
+		})
+	}
+}
+
+type fakeScaleUpOperation struct {
+	status cloudprovider.ScaleUpOperationStatus
+}
+
+func (o *fakeScaleUpOperation) Id() string {
+	return "operation"
+}
+
+func (o *fakeScaleUpOperation) Status() (cloudprovider.ScaleUpOperationStatus, error) {
+	return o.status, nil
+}
+
+func TestScaleUpOperations(t *testing.T) {
+	now := time.Now()
+	stockout := &cloudprovider.InstanceErrorInfo{
+		ErrorClass:   cloudprovider.OutOfResourcesErrorClass,
+		ErrorCode:    "InsufficientInstanceCapacity",
+		ErrorMessage: "no capacity",
+	}
+	for _, tc := range []struct {
+		desc              string
+		scaleUpTime       time.Time
+		status            cloudprovider.ScaleUpOperationStatus
+		operations        int
+		wantFailures      []ScaleUpFailure
+		wantIncrease      int
+		wantEvents        []string
+		wantOperationLeft bool
+	}{
+		{
+			desc:              "operation in progress",
+			scaleUpTime:       now,
+			status:            cloudprovider.ScaleUpOperationStatus{State: cloudprovider.ScaleUpOperationAccepted},
+			wantIncrease:      4,
+			wantOperationLeft: true,
+		},
+		{
+			desc:         "operation provisioned",
+			scaleUpTime:  now,
+			status:       cloudprovider.ScaleUpOperationStatus{State: cloudprovider.ScaleUpOperationProvisioned, ProvisionedCount: 4},
+			wantIncrease: 4,
+		},
+		{
+			desc:         "operation failed",
+			scaleUpTime:  now,
+			status:       cloudprovider.ScaleUpOperationStatus{State: cloudprovider.ScaleUpOperationFailed, ProvisionedCount: 1, ErrorInfo: stockout},
+			wantFailures: []ScaleUpFailure{{Reason: metrics.CloudProviderError, Category: cloudprovider.ScaleUpFailureStockout, Time: now}},
+			wantIncrease: 1,
+			wantEvents:   []string{"Warning FailedToScaleUpGroup Scale-up failed for group ng1 after provisioning 1 out of 4 instances: no capacity"},
+		},
+		{
+			desc:         "operation failed without provisioning instances",
+			scaleUpTime:  now,
+			status:       cloudprovider.ScaleUpOperationStatus{State: cloudprovider.ScaleUpOperationFailed},
+			wantFailures: []ScaleUpFailure{{Reason: metrics.CloudProviderError, Category: cloudprovider.ScaleUpFailureOther, Time: now}},
+			wantEvents:   []string{"Warning FailedToScaleUpGroup Scale-up failed for group ng1 after provisioning 0 out of 4 instances: Scale-up operation operation failed"},
+		},
+		{
+			desc:        "operations failed after the scale-up request is gone",
+			scaleUpTime: now,
+			status:      cloudprovider.ScaleUpOperationStatus{State: cloudprovider.ScaleUpOperationFailed},
+			operations:  2,
+			wantFailures: []ScaleUpFailure{
+				{Reason: metrics.CloudProviderError, Category: cloudprovider.ScaleUpFailureOther, Time: now},
+				{Reason: metrics.CloudProviderError, Category: cloudprovider.ScaleUpFailureOther, Time: now},
+			},
+			wantEvents: []string{
+				"Warning FailedToScaleUpGroup Scale-up failed for group ng1 after provisioning 0 out of 4 instances: Scale-up operation operation failed",
+				"Warning FailedToScaleUpGroup Scale-up failed for group ng1 after provisioning 0 out of 4 instances: Scale-up operation operation failed",
+			},
+		},
+		{
+			desc:         "instances not provisioned in time",
+			scaleUpTime:  now.Add(-3 * time.Minute),
+			status:       cloudprovider.ScaleUpOperationStatus{State: cloudprovider.ScaleUpOperationAccepted},
+			wantFailures: []ScaleUpFailure{{Reason: metrics.Timeout, Category: cloudprovider.ScaleUpFailureTimeout, Time: now}},
+			wantEvents:   []string{"Warning ScaleUpTimedOut Instances requested for group ng1 weren't provisioned within 3m0s"},
+		},
+		{
+			desc:         "provisioned nodes not registered in time",
+			scaleUpTime:  now.Add(-3 * time.Minute),
+			status:       cloudprovider.ScaleUpOperationStatus{State: cloudprovider.ScaleUpOperationProvisioned, ProvisionedCount: 4},
+			wantFailures: []ScaleUpFailure{{Reason: metrics.Timeout, Category: cloudprovider.ScaleUpFailureTimeout, Time: now}},
+			wantEvents:   []string{"Warning ScaleUpTimedOut Nodes added to group ng1 failed to register within 3m0s"},
+		},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			ng1_1 := BuildTestNode("ng1-1", 1000, 1000)
+			SetNodeReadyState(ng1_1, true, now.Add(-time.Hour))
+			provider := testprovider.NewTestCloudProvider(nil, nil)
+			provider.AddNodeGroup("ng1", 1, 10, 5)
+			provider.AddNode("ng1", ng1_1)
+			nodeGroup := provider.GetNodeGroup("ng1")
+
+			fakeRecorder := kube_record.NewFakeRecorder(5)
+			fakeLogRecorder, err := utils.NewStatusMapRecorder(fake.NewSimpleClientset(), "kube-system", fakeRecorder, true, "my-cool-configmap")
+			assert.NoError(t, err)
+			clusterstate := NewClusterStateRegistry(provider, ClusterStateRegistryConfig{
+				MaxTotalUnreadyPercentage: 10,
+				OkTotalUnreadyCount:       1,
+			}, fakeLogRecorder, newBackoff(), nodegroupconfig.NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{MaxNodeProvisionTime: 2 * time.Minute}), asyncnodegroups.NewDefaultAsyncNodeGroupStateChecker())
+			clusterstate.RegisterScaleUp(nodeGroup, 4, tc.scaleUpTime)
+			for i := 0; i < max(tc.operations, 1); i++ {
+				clusterstate.RegisterScaleUpOperation(nodeGroup, &fakeScaleUpOperation{status: tc.status}, 4, tc.scaleUpTime)
+			}
+			assert.NoError(t, clusterstate.UpdateNodes([]*apiv1.Node{ng1_1}, nil, now))
+
+			wantFailures := map[string][]ScaleUpFailure{}
+			if len(tc.wantFailures) > 0 {
+				for i := range tc.wantFailures {
+					tc.wantFailures[i].NodeGroup = nodeGroup
+				}
+				wantFailures = map[string][]ScaleUpFailure{"ng1": tc.wantFailures}
+			}
+			assert.Equal(t, wantFailures, clusterstate.GetScaleUpFailures())
+			assert.Equal(t, tc.wantIncrease, clusterstate.GetScaleUpRequests()["ng1"].Increase)
+			_, found := clusterstate.scaleUpOperations["ng1"]
+			assert.Equal(t, tc.wantOperationLeft, found)
+			var events []string
+			for len(fakeRecorder.Events) > 0 {
+				events = append(events, <-fakeRecorder.Events)
+			}
+			assert.Equal(t, tc.wantEvents, events)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pause
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/basicauth"
+	klog "k8s.io/klog/v2"
+)
+
+// Path is the path the handler is served on.
+const Path = "/pause"
+
+// Response lists the pauses in effect after a request.
+type Response struct {
+	Pauses []Pause `json:"pauses"`
+}
+
+// Handler serves the pause endpoint:
+//   - GET lists the pauses in effect,
+//   - POST pauses an operation for a duration,
+//   - DELETE resumes an operation paused through the endpoint.
+//
+// The operation, node group, duration and reason are passed as the operation,
+// nodeGroup, duration and reason query parameters. The operation defaults to All
+// and the node group to none, i.e. the operation is paused cluster-wide. Requests
+// are authenticated with HTTP basic auth, using one of the configured credentials.
+type Handler struct {
+	registry    *Registry
+	maxDuration time.Duration
+	// credentials are the passwords by user name.
+	credentials map[string]string
+	now         func() time.Time
+}
+
+// NewHandler returns a Handler changing pauses of the given Registry and accepting
+// the given passwords by user name. Pauses can't be longer than maxDuration.
+func NewHandler(registry *Registry, maxDuration time.Duration, credentials map[string]string) *Handler {
+	return &Handler{
+		registry:    registry,
+		maxDuration: maxDuration,
+		credentials: credentials,
+		now:         time.Now,
+	}
+}
+
+// ServeHTTP handles a single request to the pause endpoint.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !basicauth.Authenticated(r, h.credentials) {
+		basicauth.Unauthorized(w)
+		return
+	}
+	query := r.URL.Query()
+	operation := Operation(query.Get("operation"))
+	if operation == "" {
+		operation = All
+	}
+	nodeGroup := query.Get("nodeGroup")
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		duration, err := time.ParseDuration(query.Get("duration"))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid duration: %v", err), http.StatusBadRequest)
+			return
+		}
+		if duration <= 0 || duration > h.maxDuration {
+			http.Error(w, fmt.Sprintf("duration must be positive and at most %v, got %v", h.maxDuration, duration), http.StatusBadRequest)
+			return
+		}
+		pause := Pause{
+			Operation: operation,
+			NodeGroup: nodeGroup,
+			Until:     metav1.NewTime(h.now().Add(duration)),
+			Reason:    query.Get("reason"),
+		}
+		if err := h.registry.Pause(pause); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	case http.MethodDelete:
+		if _, err := h.registry.Resume(operation, nodeGroup); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "only GET, POST and DELETE are supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	response := Response{Pauses: h.registry.Active(h.now())}
+	if response.Pauses == nil {
+		response.Pauses = []Pause{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		klog.Errorf("Failed to write pause response: %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pause
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestHandler(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	registry := NewRegistry()
+	handler := NewHandler(registry, 24*time.Hour, map[string]string{"admin": "secret"})
+	handler.now = func() time.Time { return now }
+
+	serve := func(method, target string) (int, Response) {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(method, target, nil)
+		req.SetBasicAuth("admin", "secret")
+		handler.ServeHTTP(recorder, req)
+		response := Response{}
+		if recorder.Code == http.StatusOK {
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		}
+		return recorder.Code, response
+	}
+
+	code, response := serve(http.MethodGet, Path)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Empty(t, response.Pauses)
+
+	for _, setAuth := range []func(*http.Request){
+		func(*http.Request) {},
+		func(req *http.Request) { req.SetBasicAuth("admin", "wrong") },
+	} {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, Path+"?duration=1h", nil)
+		setAuth(req)
+		handler.ServeHTTP(recorder, req)
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	}
+	assert.Empty(t, registry.Active(now))
+
+	for _, target := range []string{
+		Path,
+		Path + "?duration=forever",
+		Path + "?duration=-1h",
+		Path + "?duration=25h",
+		Path + "?duration=1h&operation=ScaleSideways",
+	} {
+		code, _ = serve(http.MethodPost, target)
+		assert.Equal(t, http.StatusBadRequest, code, target)
+	}
+	code, _ = serve(http.MethodPut, Path)
+	assert.Equal(t, http.StatusMethodNotAllowed, code)
+
+	code, response = serve(http.MethodPost, Path+"?duration=30m&nodeGroup=ng1&reason=maintenance")
+	assert.Equal(t, http.StatusOK, code)
+	until := metav1.NewTime(now.Add(30 * time.Minute))
+	if assert.Len(t, response.Pauses, 2) {
+		assert.Equal(t, ScaleDown, response.Pauses[0].Operation)
+		assert.Equal(t, ScaleUp, response.Pauses[1].Operation)
+		for _, p := range response.Pauses {
+			assert.Equal(t, "ng1", p.NodeGroup)
+			assert.Equal(t, "maintenance", p.Reason)
+			assert.True(t, until.Equal(&p.Until))
+		}
+	}
+
+	code, response = serve(http.MethodDelete, Path+"?operation=ScaleUp&nodeGroup=ng1")
+	assert.Equal(t, http.StatusOK, code)
+	if assert.Len(t, response.Pauses, 1) {
+		assert.Equal(t, ScaleDown, response.Pauses[0].Operation)
+	}
+	assert.True(t, registry.PausedUntil(ScaleUp, "ng1", now).IsZero())
+	assert.Equal(t, until.Time, registry.PausedUntil(ScaleDown, "ng1", now))
+
+	code, response = serve(http.MethodDelete, Path+"?nodeGroup=ng1")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Empty(t, response.Pauses)
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pause
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klog "k8s.io/klog/v2"
+)
+
+// Operation is an autoscaling operation which can be paused.
+type Operation string
+
+const (
+	// ScaleUp pauses scale-ups, including scale-ups to the min size of node groups.
+	ScaleUp Operation = "ScaleUp"
+	// ScaleDown pauses removal of unneeded nodes.
+	ScaleDown Operation = "ScaleDown"
+	// All pauses both scale-up and scale-down.
+	All Operation = "All"
+)
+
+// Pause pauses an operation cluster-wide or for a single node group until a given time.
+type Pause struct {
+	// Operation is the paused operation.
+	Operation Operation `json:"operation"`
+	// NodeGroup is the id of the paused node group. The operation is paused cluster-wide if it's empty.
+	NodeGroup string `json:"nodeGroup,omitempty"`
+	// Until is the time at which the operation is resumed.
+	Until metav1.Time `json:"until"`
+	// Reason is a human readable description of why the operation is paused.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Spec is the part of the spec of the ClusterAutoscalerStatus object declaring pauses.
+type Spec struct {
+	// Pauses lists the declared pauses. Expired ones are ignored.
+	Pauses []Pause `json:"pauses,omitempty"`
+}
+
+func validateOperation(operation Operation) error {
+	switch operation {
+	case ScaleUp, ScaleDown, All:
+		return nil
+	default:
+		return fmt.Errorf("unknown operation %q, expected one of %s, %s, %s", operation, ScaleUp, ScaleDown, All)
+	}
+}
+
+func (p Pause) validate() error {
+	if err := validateOperation(p.Operation); err != nil {
+		return err
+	}
+	if p.Until.IsZero() {
+		return fmt.Errorf("pause of %s has no end time", p.Operation)
+	}
+	return nil
+}
+
+// split returns the pauses of single operations p consists of.
+func (p Pause) split() []Pause {
+	if p.Operation != All {
+		return []Pause{p}
+	}
+	scaleUp, scaleDown := p, p
+	scaleUp.Operation = ScaleUp
+	scaleDown.Operation = ScaleDown
+	return []Pause{scaleUp, scaleDown}
+}
+
+func (p Pause) String() string {
+	scope := "cluster-wide"
+	if p.NodeGroup != "" {
+		scope = "of node group " + p.NodeGroup
+	}
+	return fmt.Sprintf("%s %s until %s", p.Operation, scope, p.Until.UTC().Format(time.RFC3339))
+}
+
+type key struct {
+	operation Operation
+	nodeGroup string
+}
+
+// Registry keeps track of paused operations. Pauses are either requested through
+// the pause endpoint, and kept in memory until they expire or are resumed, or
+// declared in the spec of the ClusterAutoscalerStatus object, and replaced every
+// loop. Operations are resumed automatically once their pauses expire.
+type Registry struct {
+	mutex     sync.Mutex
+	requested map[key]Pause
+	declared  []Pause
+}
+
+// NewRegistry returns a Registry without any pauses.
+func NewRegistry() *Registry {
+	return &Registry{
+		requested: make(map[key]Pause),
+	}
+}
+
+// Pause pauses p.Operation, replacing a previously requested pause of the same
+// operation and node group.
+func (r *Registry) Pause(p Pause) error {
+	if err := p.validate(); err != nil {
+		return err
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, single := range p.split() {
+		r.requested[key{operation: single.Operation, nodeGroup: single.NodeGroup}] = single
+		klog.Infof("Pausing %v", single)
+	}
+	return nil
+}
+
+// Resume removes the requested pauses of operation for the given node group, or
+// the cluster-wide ones if nodeGroup is empty. Declared pauses have to be removed
+// from the ClusterAutoscalerStatus object. It returns the number of removed pauses.
+func (r *Registry) Resume(operation Operation, nodeGroup string) (int, error) {
+	if err := validateOperation(operation); err != nil {
+		return 0, err
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	removed := 0
+	for _, single := range (Pause{Operation: operation, NodeGroup: nodeGroup}).split() {
+		k := key{operation: single.Operation, nodeGroup: single.NodeGroup}
+		if p, found := r.requested[k]; found {
+			delete(r.requested, k)
+			klog.Infof("Resuming %v", p)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// SetDeclared replaces the declared pauses. Invalid pauses are ignored.
+func (r *Registry) SetDeclared(pauses []Pause) {
+	var declared []Pause
+	for _, p := range pauses {
+		if err := p.validate(); err != nil {
+			klog.Warningf("Ignoring declared pause: %v", err)
+			continue
+		}
+		declared = append(declared, p.split()...)
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.declared = declared
+}
+
+// Active returns the pauses in effect at now, sorted by node group and operation.
+// Expired requested pauses are removed.
+func (r *Registry) Active(now time.Time) []Pause {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var active []Pause
+	for k, p := range r.requested {
+		if !now.Before(p.Until.Time) {
+			delete(r.requested, k)
+			klog.Infof("Resuming %v, the pause expired", p)
+			continue
+		}
+		active = append(active, p)
+	}
+	for _, p := range r.declared {
+		if now.Before(p.Until.Time) {
+			active = append(active, p)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool {
+		if active[i].NodeGroup != active[j].NodeGroup {
+			return active[i].NodeGroup < active[j].NodeGroup
+		}
+		if active[i].Operation != active[j].Operation {
+			return active[i].Operation < active[j].Operation
+		}
+		return active[i].Until.Before(&active[j].Until)
+	})
+	return active
+}
+
+// PausedUntil returns the time until which operation is paused for the given
+// node group, by a pause of the node group or a cluster-wide one, or only by a
+// cluster-wide one if nodeGroup is empty. If several pauses apply, the latest
+// end time is returned. The zero time is returned if operation isn't paused.
+func (r *Registry) PausedUntil(operation Operation, nodeGroup string, now time.Time) time.Time {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var until time.Time
+	apply := func(p Pause) {
+		if p.Operation != operation || (p.NodeGroup != "" && p.NodeGroup != nodeGroup) {
+			return
+		}
+		if now.Before(p.Until.Time) && p.Until.After(until) {
+			until = p.Until.Time
+		}
+	}
+	for _, p := range r.requested {
+		apply(p)
+	}
+	for _, p := range r.declared {
+		apply(p)
+	}
+	return until
+}
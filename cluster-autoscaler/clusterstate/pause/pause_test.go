@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pause
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRegistry(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	inHour := metav1.NewTime(now.Add(time.Hour))
+	inDay := metav1.NewTime(now.Add(24 * time.Hour))
+	r := NewRegistry()
+
+	assert.True(t, r.PausedUntil(ScaleUp, "ng1", now).IsZero())
+	assert.Empty(t, r.Active(now))
+
+	assert.Error(t, r.Pause(Pause{Operation: "ScaleSideways", Until: inHour}))
+	assert.Error(t, r.Pause(Pause{Operation: ScaleUp}))
+
+	assert.NoError(t, r.Pause(Pause{Operation: All, NodeGroup: "ng1", Until: inHour, Reason: "incident"}))
+	assert.NoError(t, r.Pause(Pause{Operation: ScaleDown, Until: inHour}))
+	assert.Equal(t, inHour.Time, r.PausedUntil(ScaleUp, "ng1", now))
+	assert.Equal(t, inHour.Time, r.PausedUntil(ScaleDown, "ng1", now))
+	assert.True(t, r.PausedUntil(ScaleUp, "ng2", now).IsZero())
+	assert.Equal(t, inHour.Time, r.PausedUntil(ScaleDown, "ng2", now))
+	assert.True(t, r.PausedUntil(ScaleUp, "", now).IsZero())
+	assert.Equal(t, []Pause{
+		{Operation: ScaleDown, Until: inHour},
+		{Operation: ScaleDown, NodeGroup: "ng1", Until: inHour, Reason: "incident"},
+		{Operation: ScaleUp, NodeGroup: "ng1", Until: inHour, Reason: "incident"},
+	}, r.Active(now))
+
+	// Pausing again replaces the previous pause, declared pauses are added to requested ones.
+	assert.NoError(t, r.Pause(Pause{Operation: ScaleDown, Until: metav1.NewTime(now.Add(time.Minute))}))
+	r.SetDeclared([]Pause{
+		{Operation: ScaleUp, NodeGroup: "ng1", Until: inDay},
+		{Operation: "Unknown", Until: inDay},
+	})
+	assert.Equal(t, now.Add(time.Minute), r.PausedUntil(ScaleDown, "ng2", now))
+	assert.Equal(t, inDay.Time, r.PausedUntil(ScaleUp, "ng1", now))
+
+	removed, err := r.Resume(ScaleUp, "ng1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assert.Equal(t, inDay.Time, r.PausedUntil(ScaleUp, "ng1", now))
+	assert.Equal(t, inHour.Time, r.PausedUntil(ScaleDown, "ng1", now))
+	_, err = r.Resume("Unknown", "")
+	assert.Error(t, err)
+
+	// Expired pauses are ignored and requested ones are removed.
+	later := now.Add(2 * time.Hour)
+	assert.True(t, r.PausedUntil(ScaleDown, "ng1", later).IsZero())
+	assert.Equal(t, []Pause{{Operation: ScaleUp, NodeGroup: "ng1", Until: inDay}}, r.Active(later))
+	assert.Empty(t, r.requested)
+
+	r.SetDeclared(nil)
+	assert.Empty(t, r.Active(now))
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kube_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/api"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+// StatusObjectKind is the kind of the ClusterAutoscalerStatus CRD.
+const StatusObjectKind = "ClusterAutoscalerStatus"
+
+// StatusObjectResource is the resource of the ClusterAutoscalerStatus CRD.
+var StatusObjectResource = schema.GroupVersionResource{
+	Group:    "autoscaling.x-k8s.io",
+	Version:  "v1alpha1",
+	Resource: "clusterautoscalerstatuses",
+}
+
+// StatusObjectWriter writes ClusterAutoscalerStatus to a ClusterAutoscalerStatus
+// object, a machine-readable alternative to the status ConfigMap.
+type StatusObjectWriter struct {
+	client    dynamic.Interface
+	namespace string
+	name      string
+}
+
+// NewStatusObjectWriter returns a StatusObjectWriter for the ClusterAutoscalerStatus
+// object with the given namespace and name.
+func NewStatusObjectWriter(kubeConfig *rest.Config, namespace, name string) (*StatusObjectWriter, error) {
+	client, err := dynamic.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ClusterAutoscalerStatus client: %v", err)
+	}
+	return newStatusObjectWriter(client, namespace, name), nil
+}
+
+func newStatusObjectWriter(client dynamic.Interface, namespace, name string) *StatusObjectWriter {
+	return &StatusObjectWriter{
+		client:    client,
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+// Read returns the status of the ClusterAutoscalerStatus object, or nil if
+// the object doesn't exist.
+func (w *StatusObjectWriter) Read() (*api.ClusterAutoscalerStatus, error) {
+	obj, err := w.client.Resource(StatusObjectResource).Namespace(w.namespace).Get(context.TODO(), w.name, metav1.GetOptions{})
+	if kube_errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ClusterAutoscalerStatus %s/%s: %v", w.namespace, w.name, err)
+	}
+	statusMap, found, err := unstructured.NestedMap(obj.Object, "status")
+	if err != nil || !found {
+		return nil, err
+	}
+	status := &api.ClusterAutoscalerStatus{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(statusMap, status); err != nil {
+		return nil, fmt.Errorf("failed to convert ClusterAutoscalerStatus %s/%s: %v", w.namespace, w.name, err)
+	}
+	return status, nil
+}
+
+// ReadSpec converts the spec of the ClusterAutoscalerStatus object into spec.
+// spec is left unchanged if the object or its spec don't exist.
+func (w *StatusObjectWriter) ReadSpec(spec interface{}) error {
+	obj, err := w.client.Resource(StatusObjectResource).Namespace(w.namespace).Get(context.TODO(), w.name, metav1.GetOptions{})
+	if kube_errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read ClusterAutoscalerStatus %s/%s: %v", w.namespace, w.name, err)
+	}
+	specMap, found, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil || !found {
+		return err
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(specMap, spec); err != nil {
+		return fmt.Errorf("failed to convert spec of ClusterAutoscalerStatus %s/%s: %v", w.namespace, w.name, err)
+	}
+	return nil
+}
+
+// Write updates the status of the ClusterAutoscalerStatus object or creates
+// the object if it doesn't exist.
+func (w *StatusObjectWriter) Write(status api.ClusterAutoscalerStatus, currentTime time.Time) error {
+	status.Time = currentTime.Format(ConfigMapLastUpdateFormat)
+	statusMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&status)
+	if err != nil {
+		return fmt.Errorf("failed to convert ClusterAutoscalerStatus: %v", err)
+	}
+	objects := w.client.Resource(StatusObjectResource).Namespace(w.namespace)
+	obj, err := objects.Get(context.TODO(), w.name, metav1.GetOptions{})
+	if err == nil {
+		obj.Object["status"] = statusMap
+		_, err = objects.Update(context.TODO(), obj, metav1.UpdateOptions{})
+	} else if kube_errors.IsNotFound(err) {
+		obj = &unstructured.Unstructured{Object: map[string]interface{}{"status": statusMap}}
+		obj.SetAPIVersion(StatusObjectResource.GroupVersion().String())
+		obj.SetKind(StatusObjectKind)
+		obj.SetNamespace(w.namespace)
+		obj.SetName(w.name)
+		_, err = objects.Create(context.TODO(), obj, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write ClusterAutoscalerStatus %s/%s: %v", w.namespace, w.name, err)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/api"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+)
+
+func TestStatusObjectWriter(t *testing.T) {
+	client := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		StatusObjectResource: "ClusterAutoscalerStatusList",
+	})
+	writer := newStatusObjectWriter(client, "kube-system", "cluster-autoscaler-status")
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	backoffUntil := metav1.NewTime(now.Add(5 * time.Minute))
+
+	status := api.ClusterAutoscalerStatus{
+		AutoscalerStatus: api.ClusterAutoscalerRunning,
+		ClusterWide: api.ClusterWideStatus{
+			Health: api.ClusterHealthCondition{Status: api.ClusterAutoscalerHealthy},
+		},
+		NodeGroups: []api.NodeGroupStatus{{
+			Name: "ng1",
+			ScaleUp: api.NodeGroupScaleUpCondition{
+				Status: api.ClusterAutoscalerBackoff,
+				BackoffInfo: api.BackoffInfo{
+					ErrorCode:    "QUOTA_EXCEEDED",
+					ErrorMessage: "Not enough CPU",
+					BackoffUntil: &backoffUntil,
+				},
+			},
+		}},
+	}
+	assert.NoError(t, writer.Write(status, now))
+
+	obj, err := client.Resource(StatusObjectResource).Namespace("kube-system").Get(context.TODO(), "cluster-autoscaler-status", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, StatusObjectKind, obj.GetKind())
+	autoscalerStatus, _, _ := unstructured.NestedString(obj.Object, "status", "autoscalerStatus")
+	assert.Equal(t, "Running", autoscalerStatus)
+	statusTime, _, _ := unstructured.NestedString(obj.Object, "status", "time")
+	assert.Equal(t, now.Format(ConfigMapLastUpdateFormat), statusTime)
+	nodeGroups, _, _ := unstructured.NestedSlice(obj.Object, "status", "nodeGroups")
+	assert.Len(t, nodeGroups, 1)
+	until, _, _ := unstructured.NestedString(nodeGroups[0].(map[string]interface{}), "scaleUp", "backoffInfo", "backoffUntil")
+	assert.Equal(t, "2025-01-02T03:09:05Z", until)
+
+	// Existing object is updated in place.
+	status.LastError = &api.LoopError{Type: "cloudProviderError", Message: "boom", Time: metav1.NewTime(now)}
+	assert.NoError(t, writer.Write(status, now.Add(time.Minute)))
+	obj, err = client.Resource(StatusObjectResource).Namespace("kube-system").Get(context.TODO(), "cluster-autoscaler-status", metav1.GetOptions{})
+	assert.NoError(t, err)
+	lastError, _, _ := unstructured.NestedString(obj.Object, "status", "lastError", "message")
+	assert.Equal(t, "boom", lastError)
+	statusTime, _, _ = unstructured.NestedString(obj.Object, "status", "time")
+	assert.Equal(t, now.Add(time.Minute).Format(ConfigMapLastUpdateFormat), statusTime)
+}
+
+func TestStatusObjectWriterRead(t *testing.T) {
+	client := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		StatusObjectResource: "ClusterAutoscalerStatusList",
+	})
+	writer := newStatusObjectWriter(client, "kube-system", "cluster-autoscaler-status")
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	status, err := writer.Read()
+	assert.NoError(t, err)
+	assert.Nil(t, status)
+
+	handoff := &api.LeaderHandoff{
+		ScaleUps:      []api.ScaleUpInProgress{{NodeGroup: "ng1", Increase: 3, Time: metav1.NewTime(now)}},
+		NodeDeletions: []api.NodeDeletionInProgress{{Name: "n1", Drain: true}},
+	}
+	assert.NoError(t, writer.Write(api.ClusterAutoscalerStatus{AutoscalerStatus: api.ClusterAutoscalerRunning, LeaderHandoff: handoff}, now))
+
+	status, err = writer.Read()
+	assert.NoError(t, err)
+	if assert.NotNil(t, status) {
+		assert.Equal(t, api.ClusterAutoscalerRunning, status.AutoscalerStatus)
+		if assert.NotNil(t, status.LeaderHandoff) && assert.Len(t, status.LeaderHandoff.ScaleUps, 1) {
+			scaleUp := status.LeaderHandoff.ScaleUps[0]
+			assert.Equal(t, "ng1", scaleUp.NodeGroup)
+			assert.Equal(t, 3, scaleUp.Increase)
+			assert.True(t, now.Equal(scaleUp.Time.Time))
+			assert.Equal(t, handoff.NodeDeletions, status.LeaderHandoff.NodeDeletions)
+		}
+	}
+}
+
+func TestStatusObjectWriterReadSpec(t *testing.T) {
+	client := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		StatusObjectResource: "ClusterAutoscalerStatusList",
+	})
+	writer := newStatusObjectWriter(client, "kube-system", "cluster-autoscaler-status")
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	type testSpec struct {
+		Value string `json:"value"`
+	}
+
+	spec := testSpec{}
+	assert.NoError(t, writer.ReadSpec(&spec))
+	assert.Equal(t, testSpec{}, spec)
+
+	assert.NoError(t, writer.Write(api.ClusterAutoscalerStatus{AutoscalerStatus: api.ClusterAutoscalerRunning}, now))
+	assert.NoError(t, writer.ReadSpec(&spec))
+	assert.Equal(t, testSpec{}, spec)
+
+	// The spec is written by users and kept when the status is written.
+	objects := client.Resource(StatusObjectResource).Namespace("kube-system")
+	obj, err := objects.Get(context.TODO(), "cluster-autoscaler-status", metav1.GetOptions{})
+	assert.NoError(t, err)
+	obj.Object["spec"] = map[string]interface{}{"value": "set"}
+	_, err = objects.Update(context.TODO(), obj, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Write(api.ClusterAutoscalerStatus{AutoscalerStatus: api.ClusterAutoscalerRunning}, now.Add(time.Minute)))
+
+	assert.NoError(t, writer.ReadSpec(&spec))
+	assert.Equal(t, testSpec{Value: "set"}, spec)
+}
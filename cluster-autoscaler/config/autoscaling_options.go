@@ -19,6 +19,7 @@ package config
 import (
 	"time"
 
+	apiv1 "k8s.io/api/core/v1"
 	gce_localssdsize "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/gce/localssdsize"
 	kubelet_config "k8s.io/kubernetes/pkg/kubelet/apis/config"
 	scheduler_config "k8s.io/kubernetes/pkg/scheduler/apis/config"
@@ -34,6 +35,16 @@ type GpuLimits struct {
 	Max int64
 }
 
+// NodeDeletionQuota limits node deletion calls made to a cloud provider
+type NodeDeletionQuota struct {
+	// Name of the cloud provider the quota applies to (e.g. aws)
+	CloudProviderName string
+	// Maximum number of node deletion calls per second, 0 means no limit
+	QPS float64
+	// Maximum number of nodes deleted in a single call, 0 means no limit
+	MaxBatchSize int
+}
+
 // NodeGroupAutoscalingOptions contain various options to customize how autoscaling of
 // a given NodeGroup works. Different options can be used for each NodeGroup.
 type NodeGroupAutoscalingOptions struct {
@@ -54,6 +65,11 @@ type NodeGroupAutoscalingOptions struct {
 	ZeroOrMaxNodeScaling bool
 	// IgnoreDaemonSetsUtilization sets if daemonsets utilization should be considered during node scale-down
 	IgnoreDaemonSetsUtilization bool
+	// ScaleDownSoftTaintOnly means that unneeded nodes of a node group are only soft-tainted and cordoned instead of
+	// being deleted, e.g. because the node group is backed by reserved capacity which is paid for anyway.
+	ScaleDownSoftTaintOnly bool
+	// NodeAutoRepairEnabled means that long NotReady and long unregistered nodes of a node group are recreated.
+	NodeAutoRepairEnabled bool
 }
 
 // GCEOptions contain autoscaling options specific to GCE cloud provider.
@@ -71,6 +87,28 @@ type GCEOptions struct {
 	BulkMigInstancesListingEnabled bool
 }
 
+// AWSOptions contain autoscaling options specific to AWS cloud provider.
+type AWSOptions struct {
+	// AutoprovisioningInstanceTypes is the allowlist of instance types autoprovisioned ASGs can use.
+	AutoprovisioningInstanceTypes []string
+	// AutoprovisioningLaunchTemplate is the name of the launch template used by autoprovisioned ASGs.
+	AutoprovisioningLaunchTemplate string
+	// AutoprovisioningSubnets are ids of subnets autoprovisioned ASGs launch instances in.
+	AutoprovisioningSubnets []string
+	// AutoprovisioningMaxSize is the maximum size of autoprovisioned ASGs.
+	AutoprovisioningMaxSize int
+	// SpotInterruptionQueueURL is the URL of the SQS queue EventBridge delivers spot interruption warnings to.
+	SpotInterruptionQueueURL string
+	// OrphanedInstanceGCAge is the minimum age of instances launched by registered ASGs for the cluster, which are no
+	// longer associated with them and never registered as nodes, and the minimum time they have to fail their EC2
+	// status checks, before they're terminated. Zero disables terminating them.
+	OrphanedInstanceGCAge time.Duration
+	// MaxPodsMode is the networking mode the max pods of ASG templates are inferred from: "default" for the
+	// default max pods of the kubelet, "vpc-cni" or "vpc-cni-prefix-delegation" for the ENI limits of
+	// instance types with the Amazon VPC CNI plugin.
+	MaxPodsMode string
+}
+
 const (
 	// DefaultMaxAllocatableDifferenceRatio describes how Node.Status.Allocatable can differ between groups in the same NodeGroupSet
 	DefaultMaxAllocatableDifferenceRatio = 0.05
@@ -106,6 +144,15 @@ type AutoscalingOptions struct {
 	NodeGroupDefaults NodeGroupAutoscalingOptions
 	// MaxNodesTotal sets the maximum number of nodes in the whole cluster
 	MaxNodesTotal int
+	// MaxClusterHourlyCost sets the maximum hourly cost of all node groups in the cluster. 0 means no limit.
+	MaxClusterHourlyCost float64
+	// ClusterCostBudgetMode is either "hard", capping scale-ups exceeding MaxClusterHourlyCost to the remaining budget, or "soft", only warning about them.
+	ClusterCostBudgetMode string
+	// NodeGroupHourlyPrices are hourly prices of a single node, by node group id. Prices of other node groups
+	// are taken from the cloud provider pricing model.
+	NodeGroupHourlyPrices map[string]float64
+	// CostMetricsEnabled is used to enable/disable estimating the savings of scale-down and the hourly cost of the cluster in each loop.
+	CostMetricsEnabled bool
 	// MaxCoresTotal sets the maximum number of cores in the whole cluster
 	MaxCoresTotal int64
 	// MinCoresTotal sets the minimum number of cores in the whole cluster
@@ -120,14 +167,48 @@ type AutoscalingOptions struct {
 	NodeGroupAutoDiscovery []string
 	// EstimatorName is the estimator used to estimate the number of needed nodes in scale up.
 	EstimatorName string
+	// EstimatorGpuPodOrdering makes the estimator take GPU requests into account when ordering pods by size.
+	EstimatorGpuPodOrdering bool
 	// ExpanderNames sets the chain of node group expanders to be used in scale up
 	ExpanderNames string
+	// Profile is the name of the profile whose coordinated defaults were applied to options not set explicitly.
+	Profile string
 	// GRPCExpanderCert is the location of the cert passed to the gRPC server for TLS when using the gRPC expander
 	GRPCExpanderCert string
+	// DrainabilityWebhookURL is the URL of a webhook deciding whether nodes can be drained. Disabled if empty.
+	DrainabilityWebhookURL string
+	// DrainabilityWebhookCACert is the location of the CA cert used to verify the drainability webhook server cert.
+	DrainabilityWebhookCACert string
+	// DrainabilityWebhookTimeout is the timeout of drainability webhook calls.
+	DrainabilityWebhookTimeout time.Duration
+	// DrainabilityWebhookFailurePolicy defines how drainability webhook errors are handled, either Fail or Ignore.
+	DrainabilityWebhookFailurePolicy string
 	// GRPCExpanderURL is the url of the gRPC server when using the gRPC expander
 	GRPCExpanderURL string
+	// GRPCExpanderClientCert is the location of the client cert presented to the gRPC server for mTLS when using the gRPC expander
+	GRPCExpanderClientCert string
+	// GRPCExpanderClientKey is the location of the client private key used for mTLS when using the gRPC expander
+	GRPCExpanderClientKey string
+	// ExpanderPlugins are paths to Go plugins providing expanders
+	ExpanderPlugins []string
+	// PriceLiveCacheTTL is how long prices fetched by the price-live expander are cached
+	PriceLiveCacheTTL time.Duration
+	// PriorityExpanderTieBreaker lists expanders choosing among the node groups with the highest priority in the priority expander
+	PriorityExpanderTieBreaker string
 	// IgnoreMirrorPodsUtilization is whether CA will ignore Mirror pods when calculating resource utilization for scaling down
 	IgnoreMirrorPodsUtilization bool
+	// ScaleDownUtilizationExtendedResources is a list of additional resources (e.g. hugepages-2Mi, ephemeral-storage)
+	// taken into account when calculating node utilization for scaling down.
+	ScaleDownUtilizationExtendedResources []apiv1.ResourceName
+	// ScaleDownUtilizationSource determines whether cpu and memory utilization used for scaling down is based on
+	// pod requests ("requests"), actual node usage ("actual") or the higher of the two ("max").
+	ScaleDownUtilizationSource string
+	// ScaleDownUsagePrometheusAddress is the address of Prometheus used to fetch actual node usage. If empty,
+	// metrics.k8s.io API is used instead.
+	ScaleDownUsagePrometheusAddress string
+	// ScaleDownUtilizationBreakdownPods is the number of pods listed in the utilization breakdown reported for nodes
+	// that are not removed because of high utilization. 0 disables the breakdown.
+	ScaleDownUtilizationBreakdownPods int
 	// MaxGracefulTerminationSec is maximum number of seconds scale down waits for pods to terminate before
 	// removing the node from cloud provider.
 	// DrainPriorityConfig takes higher precedence and MaxGracefulTerminationSec will not be applicable when the DrainPriorityConfig is set.
@@ -136,6 +217,12 @@ type AutoscalingOptions struct {
 	// This field is optional and could be nil.
 	// DrainPriorityConfig takes higher precedence and MaxGracefulTerminationSec will not be applicable when the DrainPriorityConfig is set.
 	DrainPriorityConfig []kubelet_config.ShutdownGracePeriodByPodPriority
+	// DrainByPodPriority makes scale down evict pods in the ascending order of their priorities, waiting for
+	// pods of each priority to terminate before evicting pods of a higher priority.
+	DrainByPodPriority bool
+	// NamespaceEvictionLimits limit the number of pods evicted per minute from a namespace during scale down.
+	// The limit for key "*" applies to all namespaces without their own limit. 0 means no limit.
+	NamespaceEvictionLimits map[string]int
 	// MaxTotalUnreadyPercentage is the maximum percentage of unready nodes after which CA halts operations
 	MaxTotalUnreadyPercentage float64
 	// OkTotalUnreadyCount is the number of allowed unready nodes, irrespective of max-total-unready-percentage
@@ -154,6 +241,12 @@ type AutoscalingOptions struct {
 	EnforceNodeGroupMinSize bool
 	// ScaleDownEnabled is used to allow CA to scale down the cluster
 	ScaleDownEnabled bool
+	// ScaleDownDryRun makes CA plan scale-down without tainting, draining or deleting nodes, reporting the plan instead
+	ScaleDownDryRun bool
+	// ScaleDownDryRunReportFile is the file the scale-down dry-run report is written to. If empty, the report is written to a ConfigMap
+	ScaleDownDryRunReportFile string
+	// ScaleDownDryRunReportInterval is how often the scale-down dry-run report is written
+	ScaleDownDryRunReportInterval time.Duration
 	// ScaleDownUnreadyEnabled is used to allow CA to scale down unready nodes of the cluster
 	ScaleDownUnreadyEnabled bool
 	// ScaleDownDelayAfterAdd sets the duration from the last scale up to the time when CA starts to check scale down options
@@ -178,6 +271,30 @@ type AutoscalingOptions struct {
 	// The formula to calculate additional candidates number is following:
 	// max(#nodes * ScaleDownCandidatesPoolRatio, ScaleDownCandidatesPoolMinCount)
 	ScaleDownCandidatesPoolMinCount int
+	// ScaleDownSortingStrategies are names of strategies ordering scale down candidates, applied in order.
+	ScaleDownSortingStrategies []string
+	// ScaleDownNodeGroupPriorityConfigMap is the name of the ConfigMap with node group priorities ordering scale down
+	// candidates, nodes of node groups with higher priorities are scaled down first. Empty disables the ordering.
+	ScaleDownNodeGroupPriorityConfigMap string
+	// ScalingScheduleConfigMap is the name of the ConfigMap with scheduled scaling windows overriding the min and max
+	// sizes of node groups. Empty disables scheduled scaling.
+	ScalingScheduleConfigMap string
+	// ScaleDownConsolidationEnabled enables replacing multiple underutilized nodes with a
+	// single node from another node group, when all their pods fit on it.
+	ScaleDownConsolidationEnabled bool
+	// MaxNodesPerConsolidation is the maximum number of nodes replaced by a single node.
+	MaxNodesPerConsolidation int
+	// SpotInterruptionHandlingEnabled enables cordoning and draining nodes whose spot or
+	// preemptible instances are about to be interrupted.
+	SpotInterruptionHandlingEnabled bool
+	// SpotInterruptionPreScaleEnabled enables scaling up the node groups of interrupted nodes
+	// before their pods become pending.
+	SpotInterruptionPreScaleEnabled bool
+	// NodeAutoRepairNotReadyTime is how long a node has to be NotReady before it's recreated in node groups
+	// with node auto-repair enabled.
+	NodeAutoRepairNotReadyTime time.Duration
+	// MaxNodeAutoRepairsPerHour is the maximum number of nodes recreated by node auto-repair per hour.
+	MaxNodeAutoRepairsPerHour int
 	// ScaleDownSimulationTimeout defines the maximum time that can be
 	// spent on scale down simulation.
 	ScaleDownSimulationTimeout time.Duration
@@ -186,10 +303,38 @@ type AutoscalingOptions struct {
 	SchedulerConfig *scheduler_config.KubeSchedulerConfiguration
 	// NodeDeletionDelayTimeout is maximum time CA waits for removing delay-deletion.cluster-autoscaler.kubernetes.io/ annotations before deleting the node.
 	NodeDeletionDelayTimeout time.Duration
+	// PreDeleteHook is how drained nodes are handed over to external controllers before they're deleted, either
+	// "annotation" or "node-deletion-hook". Empty disables pre-delete hooks.
+	PreDeleteHook string
+	// PreDeleteHookTimeout is the maximum time CA waits for a pre-delete hook to complete before deleting the node.
+	PreDeleteHookTimeout time.Duration
 	// WriteStatusConfigMap tells if the status information should be written to a ConfigMap
 	WriteStatusConfigMap bool
 	// StaticConfigMapName
 	StatusConfigMapName string
+	// WriteStatusObject tells if the status information should be written to a ClusterAutoscalerStatus object
+	WriteStatusObject bool
+	// StatusObjectName is the name of the ClusterAutoscalerStatus object
+	StatusObjectName string
+	// LeaderStateHandoffEnabled tells if a new leader should take over scale-ups and node deletions in progress
+	// from the previous one, using the ClusterAutoscalerStatus object
+	LeaderStateHandoffEnabled bool
+	// ScaleUpIntentsEnabled tells if scale-ups in progress should be persisted as ScaleUpIntent objects, to be
+	// resumed, or rolled back once timed out, after a restart.
+	ScaleUpIntentsEnabled bool
+	// PredictiveScaleUpEnabled tells if node groups should be scaled up ahead of scale-ups predicted from their
+	// history, persisted as ScaleUpHistory objects.
+	PredictiveScaleUpEnabled bool
+	// PredictiveScaleUpConfidenceThreshold is the fraction of past weeks with a scale-up in an hour of the week
+	// above which a scale-up in it is predicted.
+	PredictiveScaleUpConfidenceThreshold float64
+	// PredictiveScaleUpMaxNodes is the maximum number of nodes added ahead of predicted scale-ups whose hour
+	// hasn't ended yet.
+	PredictiveScaleUpMaxNodes int
+	// PredictiveScaleUpLeadTime is how long before a predicted scale-up node groups are scaled up.
+	PredictiveScaleUpLeadTime time.Duration
+	// OptionsConfigMapName is the name of the ConfigMap overriding a subset of options at runtime. Empty disables reloading.
+	OptionsConfigMapName string
 	// BalanceSimilarNodeGroups enables logic that identifies node groups with similar machines and tries to balance node count between them.
 	BalanceSimilarNodeGroups bool
 	// ConfigNamespace is the namespace cluster-autoscaler is running in and all related configmaps live in
@@ -198,6 +343,15 @@ type AutoscalingOptions struct {
 	ClusterName string
 	// UnremovableNodeRecheckTimeout is the timeout before we check again a node that couldn't be removed before
 	UnremovableNodeRecheckTimeout time.Duration
+	// ScaleDownPdbLookaheadWaves is the number of drain waves, after the one which can start right away, into which
+	// scale-down can schedule nodes blocked by PodDisruptionBudgets. 0 disables the lookahead.
+	ScaleDownPdbLookaheadWaves int
+	// ScaleDownSimulationCacheEnabled makes scale-down cache the outcomes of moving the pods of unneeded nodes, keyed
+	// by a hash of the pods and of the destination nodes, so they aren't simulated again in a stable cluster.
+	ScaleDownSimulationCacheEnabled bool
+	// ScaleDownIncrementalEligibilityEnabled makes scale-down recalculate utilization of candidates only when the
+	// nodes or the pods scheduled on them changed, as observed by pod informer events.
+	ScaleDownIncrementalEligibilityEnabled bool
 	// Pods with priority below cutoff are expendable. They can be killed without any consideration during scale down and they don't cause scale-up.
 	// Pods with null priority (PodPriority disabled) are non-expendable.
 	ExpendablePodsPriorityCutoff int
@@ -212,6 +366,9 @@ type AutoscalingOptions struct {
 	MaxBulkSoftTaintTime time.Duration
 	// MaxPodEvictionTime sets the maximum time CA tries to evict a pod before giving up.
 	MaxPodEvictionTime time.Duration
+	// ForceDeletePodsAfter is the time after which pods evicted during a node drain, which are still terminating, are
+	// force deleted. 0 disables force deletion, unless it's enabled for a pod with an annotation.
+	ForceDeletePodsAfter time.Duration
 	// StartupTaints is a list of taints CA considers to reflect transient node
 	// status that should be removed when creating a node template for scheduling.
 	// startup taints are expected to appear during node startup.
@@ -226,8 +383,16 @@ type AutoscalingOptions struct {
 	// BalancingLabels is a list of labels to use when comparing if two node groups are similar.
 	// If this is set, only labels are used to compare node groups. It is mutually exclusive with BalancingExtraIgnoredLabels.
 	BalancingLabels []string
+	// BalancingRequiredLabels is a list of labels that have to be set to the same value on two nodes for their node groups
+	// to be similar, on top of the other comparison logic and even if they are ignored by it.
+	BalancingRequiredLabels []string
+	// BalancingInstanceFamilyMatch makes node groups of different instance types of the same family, e.g. m5.xlarge and
+	// m5a.xlarge, be considered similar if they match otherwise.
+	BalancingInstanceFamilyMatch bool
 	// AWSUseStaticInstanceList tells if AWS cloud provider use static instance type list or dynamically fetch from remote APIs.
 	AWSUseStaticInstanceList bool
+	// AWSOptions contain autoscaling options specific to AWS cloud provider.
+	AWSOptions AWSOptions
 	// GCEOptions contain autoscaling options specific to GCE cloud provider.
 	GCEOptions GCEOptions
 	// KubeClientOpts specify options for kube client
@@ -249,6 +414,15 @@ type AutoscalingOptions struct {
 	MaxNodeGroupBackoffDuration time.Duration
 	// NodeGroupBackoffResetTimeout is the time after last failed scale-up when the backoff duration is reset.
 	NodeGroupBackoffResetTimeout time.Duration
+	// NodeGroupBackoffPolicy is the policy of backing off node groups after failed scale-ups, either exponential or adaptive.
+	NodeGroupBackoffPolicy string
+	// NodeGroupHealthWindow is the time window of scale-ups taken into account by the health score of node groups.
+	NodeGroupHealthWindow time.Duration
+	// NodeGroupHealthTargetReadinessLatency is the node readiness latency of scale-ups above which the health score of node groups is lowered.
+	NodeGroupHealthTargetReadinessLatency time.Duration
+	// ZonalStockoutCooldown is how long all node groups of a zone and instance type are backed off after a stockout
+	// in one of them. Zero disables it.
+	ZonalStockoutCooldown time.Duration
 	// MaxScaleDownParallelism is the maximum number of nodes (both empty and needing drain) that can be deleted in parallel.
 	MaxScaleDownParallelism int
 	// MaxDrainParallelism is the maximum number of nodes needing drain, that can be drained and deleted in parallel.
@@ -259,20 +433,42 @@ type AutoscalingOptions struct {
 	// Note that this is strictly a performance optimization aimed at limiting binpacking time, not a tool to rate-limit
 	// scale-up. There is nothing stopping CA from adding MaxNodesPerScaleUp every loop.
 	MaxNodesPerScaleUp int
+	// MaxNodesPerScaleUpPerOwner controls how many nodes can be added in a single scale-up for pods of a single
+	// controller, e.g. a Deployment, a ReplicaSet or a Job. 0 means no limit.
+	MaxNodesPerScaleUpPerOwner int
 	// MaxNodeGroupBinpackingDuration is a maximum time that can be spent binpacking a single NodeGroup. If the threshold
 	// is exceeded binpacking will be cut short and a partial scale-up will be performed.
 	MaxNodeGroupBinpackingDuration time.Duration
+	// ScaleUpForUnsatisfiableTopologySpread makes scale-up simulations treat topology spread constraints of unschedulable
+	// pods with whenUnsatisfiable: ScheduleAnyway as DoNotSchedule, so that node groups keeping the skew are scaled up,
+	// even if they are in a topology domain without nodes.
+	ScaleUpForUnsatisfiableTopologySpread bool
+	// ScaleUpForVolumeTopology makes node groups considered in scale-up match the topology of persistent volumes bound to
+	// unschedulable pods, adding aliases of well-known topology labels used by CSI drivers to their templates, and emits
+	// events on pods whose volumes don't match the topology of any node.
+	ScaleUpForVolumeTopology bool
+	// SubnetCapacityCheckEnabled makes scale-up skip node groups whose subnets have no free IP addresses left for new
+	// nodes and cap the nodes added to node groups by them, for cloud providers implementing SubnetCapacityChecker.
+	SubnetCapacityCheckEnabled bool
+	// BinpackingParallelism is the maximum number of node groups for which binpacking is run concurrently during
+	// a scale-up. Each concurrent binpacking uses a separate copy of the cluster snapshot.
+	BinpackingParallelism int
 	// MaxBinpackingTime is the maximum time spend on binpacking for a single scale-up.
 	// If binpacking is limited by this, scale-up will continue with the already calculated scale-up options.
 	MaxBinpackingTime time.Duration
 	// NodeDeletionBatcherInterval is a time for how long CA ScaleDown gather nodes to delete them in batch.
 	NodeDeletionBatcherInterval time.Duration
+	// NodeDeletionQuotas limit the rate and size of node deletion calls made to cloud providers.
+	NodeDeletionQuotas []NodeDeletionQuota
 	// SkipNodesWithSystemPods tells if nodes with pods from kube-system should be deleted (except for DaemonSet or mirror pods)
 	SkipNodesWithSystemPods bool
 	// SkipNodesWithLocalStorage tells if nodes with pods with local storage, e.g. EmptyDir or HostPath, should be deleted
 	SkipNodesWithLocalStorage bool
 	// SkipNodesWithCustomControllerPods tells if nodes with custom-controller owned pods should be skipped from deletion (skip if 'true')
 	SkipNodesWithCustomControllerPods bool
+	// SkipNodesWithUnattachableVolumes tells if nodes with pods whose CSI volumes can't be attached to any other node,
+	// due to CSI volume attach limits, should be skipped from deletion (skip if 'true')
+	SkipNodesWithUnattachableVolumes bool
 	// MinReplicaCount controls the minimum number of replicas that a replica set or replication controller should have
 	// to allow their pods deletion in scale down
 	MinReplicaCount int
@@ -287,16 +483,26 @@ type AutoscalingOptions struct {
 	DynamicNodeDeleteDelayAfterTaintEnabled bool
 	// BypassedSchedulers are used to specify which schedulers to bypass their processing
 	BypassedSchedulers map[string]bool
+	// NodeGroupConfigCrdEnabled tells if per-NodeGroup options can be overridden with NodeGroupConfig CRs.
+	NodeGroupConfigCrdEnabled bool
 	// ProvisioningRequestEnabled tells if CA processes ProvisioningRequest.
 	ProvisioningRequestEnabled bool
 	// AsyncNodeGroupsEnabled tells if CA creates/deletes node groups asynchronously.
 	AsyncNodeGroupsEnabled bool
+	// NodeAutoprovisioningEnabled tells if CA creates node groups for pods that don't fit
+	// on nodes of any existing node group.
+	NodeAutoprovisioningEnabled bool
+	// MaxAutoprovisionedNodeGroupCount is the maximum number of autoprovisioned node groups.
+	MaxAutoprovisionedNodeGroupCount int
 	// ProvisioningRequestInitialBackoffTime is the initial time for ProvisioningRequest be considered by CA after failed ScaleUp request.
 	ProvisioningRequestInitialBackoffTime time.Duration
 	// ProvisioningRequestMaxBackoffTime is the max time for ProvisioningRequest be considered by CA after failed ScaleUp request.
 	ProvisioningRequestMaxBackoffTime time.Duration
 	// ProvisioningRequestMaxCacheSize is the max size for ProvisioningRequest cache that is stored for retry backoff.
 	ProvisioningRequestMaxBackoffCacheSize int
+	// ProvisioningRequestMultiNodeGroupTimeout is the time within which all node groups of a best-effort atomic
+	// scale-up spread over multiple node groups have to provide the requested capacity, before the scale-up is reverted.
+	ProvisioningRequestMultiNodeGroupTimeout time.Duration
 	// CheckCapacityBatchProcessing is used to enable/disable batch processing of check capacity provisioning class
 	CheckCapacityBatchProcessing bool
 	// CheckCapacityProvisioningRequestMaxBatchSize is the maximum number of provisioning requests to process in a single batch
@@ -320,6 +526,38 @@ type AutoscalingOptions struct {
 	MaxFailingTime time.Duration
 	// DebuggingSnapshotEnabled is used to enable/disable debugging snapshot creation.
 	DebuggingSnapshotEnabled bool
+	// ScaleUpSimulationEnabled is used to enable/disable the scale-up simulation endpoint.
+	ScaleUpSimulationEnabled bool
+	// ScaleUpSimulationBasicAuthFile is the file with the basic auth credentials accepted by the scale-up simulation endpoint, a <user>:<password> pair per line.
+	ScaleUpSimulationBasicAuthFile string
+	// AutoscalingPauseEnabled is used to enable/disable pausing scale-up and scale-down through the pause endpoint
+	// and the spec of the status object.
+	AutoscalingPauseEnabled bool
+	// MaxPauseDuration is the longest duration for which an operation can be paused through the pause endpoint.
+	MaxPauseDuration time.Duration
+	// AutoscalingPauseBasicAuthFile is the file with the basic auth credentials accepted by the pause endpoint, a <user>:<password> pair per line.
+	// The endpoint isn't served if empty.
+	AutoscalingPauseBasicAuthFile string
+	// DecisionLoggingEnabled is used to enable/disable logging a structured record for each scale-up and scale-down
+	// decision, with a trace id linking records of the same loop.
+	DecisionLoggingEnabled bool
+	// ScalingDecisionHistoryEnabled is used to enable/disable persisting scale-ups and scale-downs as ScalingDecision objects.
+	ScalingDecisionHistoryEnabled bool
+	// ScalingDecisionHistoryMaxCount is the maximum number of ScalingDecision objects kept, the oldest are deleted first.
+	ScalingDecisionHistoryMaxCount int
+	// ScalingDecisionHistoryMaxAge is the age after which ScalingDecision objects are deleted.
+	ScalingDecisionHistoryMaxAge time.Duration
+	// WebUIEnabled is used to enable/disable the read-only web UI.
+	WebUIEnabled bool
+	// WebUIBasicAuthFile is the file with the basic auth credentials accepted by the web UI, a <user>:<password> pair per line.
+	WebUIBasicAuthFile string
+	// NodeGroupLimitEventsEnabled is used to enable/disable events and metrics on pending pods blocked by node groups
+	// at their max size and unneeded nodes kept by node groups at their min size.
+	NodeGroupLimitEventsEnabled bool
+	// TracingEndpoint is the OTLP gRPC endpoint the spans of the main loop are exported to. Tracing is disabled if empty.
+	TracingEndpoint string
+	// TracingSamplingRatePerMillion is the number of loops out of a million which are traced.
+	TracingSamplingRatePerMillion int32
 	// EnableProfiling is debug/pprof endpoint enabled.
 	EnableProfiling bool
 	// Address is the address of an auxiliary endpoint exposing process information like metrics, health checks and profiling data.
@@ -338,6 +576,9 @@ type AutoscalingOptions struct {
 	ProactiveScaleupEnabled bool
 	// PodInjectionLimit limits total number of pods while injecting fake pods.
 	PodInjectionLimit int
+	// CapacityBuffersEnabled tells if the headroom declared in CapacityBuffer objects should be kept by injecting
+	// virtual pods into the simulation.
+	CapacityBuffersEnabled bool
 }
 
 // KubeClientOptions specify options for kube client
@@ -22,6 +22,18 @@ const (
 	// SchedulerConfigFileFlag is the name of the flag
 	// for passing in custom scheduler config for in-tree scheduelr plugins
 	SchedulerConfigFileFlag = "scheduler-config-file"
+	// ProfileFlag is the name of the flag selecting the profile of coordinated defaults
+	ProfileFlag = "profile"
+	// ScanIntervalFlag is the name of the flag setting ScanInterval
+	ScanIntervalFlag = "scan-interval"
+	// ScaleDownUtilizationThresholdFlag is the name of the flag setting ScaleDownUtilizationThreshold
+	ScaleDownUtilizationThresholdFlag = "scale-down-utilization-threshold"
+	// ScaleDownGpuUtilizationThresholdFlag is the name of the flag setting ScaleDownGpuUtilizationThreshold
+	ScaleDownGpuUtilizationThresholdFlag = "scale-down-gpu-utilization-threshold"
+	// ScaleDownUnneededTimeFlag is the name of the flag setting ScaleDownUnneededTime
+	ScaleDownUnneededTimeFlag = "scale-down-unneeded-time"
+	// ExpanderFlag is the name of the flag setting ExpanderNames
+	ExpanderFlag = "expander"
 
 	// DefaultMaxClusterCores is the default maximum number of cores in the cluster.
 	DefaultMaxClusterCores = 5000 * 64
@@ -40,6 +52,10 @@ const (
 	DefaultMaxNodeProvisionTimeKey = "maxnodeprovisiontime"
 	// DefaultIgnoreDaemonSetsUtilizationKey identifies IgnoreDaemonSetsUtilization autoscaling option
 	DefaultIgnoreDaemonSetsUtilizationKey = "ignoredaemonsetsutilization"
+	// DefaultScaleDownSoftTaintOnlyKey identifies ScaleDownSoftTaintOnly autoscaling option
+	DefaultScaleDownSoftTaintOnlyKey = "scaledownsofttaintonly"
+	// DefaultNodeAutoRepairEnabledKey identifies NodeAutoRepairEnabled autoscaling option
+	DefaultNodeAutoRepairEnabledKey = "nodeautorepairenabled"
 
 	// DefaultScaleDownUnneededTime is the default time duration for which CA waits before deleting an unneeded node
 	DefaultScaleDownUnneededTime = 10 * time.Minute
@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamic
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// LabelAutoDiscoverer is the discoverer of --node-group-auto-discovery specs selecting node groups by labels.
+	LabelAutoDiscoverer = "label"
+
+	labelAutoDiscoveryKeyMinSize = "min"
+	labelAutoDiscoveryKeyMaxSize = "max"
+)
+
+// LabelAutoDiscoveryConfig represents a --node-group-auto-discovery spec in the form of
+// `label:<key>=<value>[,<key>=<value>...][,min=<minSize>][,max=<maxSize>]`, selecting the node
+// groups having all the given labels. What the labels of a node group are depends on the cloud provider,
+// e.g. node pool labels or tags.
+type LabelAutoDiscoveryConfig struct {
+	// Selector are the labels the node groups need to have.
+	Selector map[string]string
+	// MinSize is the min size of the selected node groups, nil if not specified.
+	MinSize *int
+	// MaxSize is the max size of the selected node groups, nil if not specified.
+	MaxSize *int
+}
+
+// ParseLabelAutoDiscoverySpecs parses --node-group-auto-discovery specs of the label discoverer.
+func ParseLabelAutoDiscoverySpecs(specs []string) ([]LabelAutoDiscoveryConfig, error) {
+	configs := make([]LabelAutoDiscoveryConfig, 0, len(specs))
+	for _, spec := range specs {
+		config, err := parseLabelAutoDiscoverySpec(spec)
+		if err != nil {
+			return nil, err
+		}
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
+
+func parseLabelAutoDiscoverySpec(spec string) (LabelAutoDiscoveryConfig, error) {
+	config := LabelAutoDiscoveryConfig{Selector: make(map[string]string)}
+
+	discoverer, selector, found := strings.Cut(spec, ":")
+	if !found {
+		return config, fmt.Errorf("spec %q should be %s:key=value,key=value", spec, LabelAutoDiscoverer)
+	}
+	if discoverer != LabelAutoDiscoverer {
+		return config, fmt.Errorf("unsupported discoverer specified: %s", discoverer)
+	}
+
+	for _, arg := range strings.Split(selector, ",") {
+		k, v, found := strings.Cut(arg, "=")
+		if !found || k == "" || v == "" {
+			return config, fmt.Errorf("invalid key=value pair %q in spec %q", arg, spec)
+		}
+		switch k {
+		case labelAutoDiscoveryKeyMinSize, labelAutoDiscoveryKeyMaxSize:
+			size, err := strconv.Atoi(v)
+			if err != nil || size < 0 {
+				return config, fmt.Errorf("invalid %s size %q in spec %q", k, v, spec)
+			}
+			if k == labelAutoDiscoveryKeyMinSize {
+				config.MinSize = &size
+			} else {
+				config.MaxSize = &size
+			}
+		default:
+			config.Selector[k] = v
+		}
+	}
+
+	if len(config.Selector) == 0 {
+		return config, fmt.Errorf("no labels to select node groups by in spec %q", spec)
+	}
+	if config.MinSize != nil && config.MaxSize != nil && *config.MaxSize < *config.MinSize {
+		return config, fmt.Errorf("max size %d must be greater than or equal to min size %d in spec %q", *config.MaxSize, *config.MinSize, spec)
+	}
+	return config, nil
+}
+
+// Matches returns true if the labels contain all the labels of the selector.
+func (c LabelAutoDiscoveryConfig) Matches(labels map[string]string) bool {
+	for k, v := range c.Selector {
+		if value, found := labels[k]; !found || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchLabelAutoDiscoveryConfigs returns the first config matching the labels of a node group, nil if
+// the node group isn't selected by any of them.
+func MatchLabelAutoDiscoveryConfigs(configs []LabelAutoDiscoveryConfig, labels map[string]string) *LabelAutoDiscoveryConfig {
+	for i := range configs {
+		if configs[i].Matches(labels) {
+			return &configs[i]
+		}
+	}
+	return nil
+}
+
+// Sizes returns the min and max sizes of the config, or the given defaults for the ones not specified.
+func (c LabelAutoDiscoveryConfig) Sizes(defaultMinSize, defaultMaxSize int) (int, int) {
+	minSize, maxSize := defaultMinSize, defaultMaxSize
+	if c.MinSize != nil {
+		minSize = *c.MinSize
+	}
+	if c.MaxSize != nil {
+		maxSize = *c.MaxSize
+	}
+	return minSize, maxSize
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLabelAutoDiscoverySpecs(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+	for _, tc := range []struct {
+		name    string
+		specs   []string
+		want    []LabelAutoDiscoveryConfig
+		wantErr bool
+	}{
+		{
+			name:  "no specs",
+			specs: nil,
+			want:  []LabelAutoDiscoveryConfig{},
+		},
+		{
+			name:  "labels and sizes",
+			specs: []string{"label:env=prod,team=infra,min=1,max=10", "label:autoscaler=true"},
+			want: []LabelAutoDiscoveryConfig{
+				{Selector: map[string]string{"env": "prod", "team": "infra"}, MinSize: intPtr(1), MaxSize: intPtr(10)},
+				{Selector: map[string]string{"autoscaler": "true"}},
+			},
+		},
+		{
+			name:    "unsupported discoverer",
+			specs:   []string{"asg:tag=foo"},
+			wantErr: true,
+		},
+		{
+			name:    "missing discoverer",
+			specs:   []string{"env=prod"},
+			wantErr: true,
+		},
+		{
+			name:    "no labels",
+			specs:   []string{"label:min=1,max=3"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid pair",
+			specs:   []string{"label:env"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid size",
+			specs:   []string{"label:env=prod,min=-1"},
+			wantErr: true,
+		},
+		{
+			name:    "max lower than min",
+			specs:   []string{"label:env=prod,min=3,max=2"},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			configs, err := ParseLabelAutoDiscoverySpecs(tc.specs)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, configs)
+		})
+	}
+}
+
+func TestMatchLabelAutoDiscoveryConfigs(t *testing.T) {
+	configs, err := ParseLabelAutoDiscoverySpecs([]string{"label:env=prod,team=infra,max=5", "label:env=dev"})
+	assert.NoError(t, err)
+
+	config := MatchLabelAutoDiscoveryConfigs(configs, map[string]string{"env": "prod", "team": "infra", "other": "x"})
+	if assert.NotNil(t, config) {
+		minSize, maxSize := config.Sizes(1, 10)
+		assert.Equal(t, 1, minSize)
+		assert.Equal(t, 5, maxSize)
+	}
+	config = MatchLabelAutoDiscoveryConfigs(configs, map[string]string{"env": "dev"})
+	if assert.NotNil(t, config) {
+		assert.Equal(t, map[string]string{"env": "dev"}, config.Selector)
+	}
+	assert.Nil(t, MatchLabelAutoDiscoveryConfigs(configs, map[string]string{"env": "prod"}))
+	assert.Nil(t, MatchLabelAutoDiscoveryConfigs(configs, nil))
+}
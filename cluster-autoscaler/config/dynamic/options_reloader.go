@@ -0,0 +1,278 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamic
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/client-go/informers"
+	kube_client "k8s.io/client-go/kubernetes"
+	v1lister "k8s.io/client-go/listers/core/v1"
+	klog "k8s.io/klog/v2"
+)
+
+// reloadableOption is an AutoscalingOption that can be changed without restarting CA.
+type reloadableOption interface {
+	// reset sets the option in dst to its value in base.
+	reset(dst, base *config.AutoscalingOptions)
+	// parse sets the option in dst to the given value.
+	parse(dst *config.AutoscalingOptions, value string) error
+	// differs tells if the option has different values in a and b.
+	differs(a, b *config.AutoscalingOptions) bool
+}
+
+type option[T comparable] struct {
+	field     func(*config.AutoscalingOptions) *T
+	parseFunc func(string) (T, error)
+}
+
+func (o option[T]) reset(dst, base *config.AutoscalingOptions) {
+	*o.field(dst) = *o.field(base)
+}
+
+func (o option[T]) parse(dst *config.AutoscalingOptions, value string) error {
+	v, err := o.parseFunc(value)
+	if err != nil {
+		return err
+	}
+	*o.field(dst) = v
+	return nil
+}
+
+func (o option[T]) differs(a, b *config.AutoscalingOptions) bool {
+	return *o.field(a) != *o.field(b)
+}
+
+func parseNonNegativeDuration(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err == nil && d < 0 {
+		err = fmt.Errorf("duration can't be negative")
+	}
+	return d, err
+}
+
+func parsePositiveDuration(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err == nil && d <= 0 {
+		err = fmt.Errorf("duration has to be positive")
+	}
+	return d, err
+}
+
+func parseNonNegativeInt(value string) (int, error) {
+	i, err := strconv.Atoi(value)
+	if err == nil && i < 0 {
+		err = fmt.Errorf("value can't be negative")
+	}
+	return i, err
+}
+
+func parsePositiveInt(value string) (int, error) {
+	i, err := strconv.Atoi(value)
+	if err == nil && i <= 0 {
+		err = fmt.Errorf("value has to be positive")
+	}
+	return i, err
+}
+
+func parseThreshold(value string) (float64, error) {
+	f, err := strconv.ParseFloat(value, 64)
+	if err == nil && (f < 0 || f > 1) {
+		err = fmt.Errorf("threshold has to be between 0 and 1")
+	}
+	return f, err
+}
+
+func parseNonEmptyString(value string) (string, error) {
+	if value == "" {
+		return "", fmt.Errorf("value can't be empty")
+	}
+	return value, nil
+}
+
+// reloadableOptions are the options, named as their flags, that can be
+// changed with the options ConfigMap.
+var reloadableOptions = map[string]reloadableOption{
+	"scan-interval": option[time.Duration]{
+		field:     func(o *config.AutoscalingOptions) *time.Duration { return &o.ScanInterval },
+		parseFunc: parsePositiveDuration,
+	},
+	"max-nodes-total": option[int]{
+		field:     func(o *config.AutoscalingOptions) *int { return &o.MaxNodesTotal },
+		parseFunc: parseNonNegativeInt,
+	},
+	"expander": option[string]{
+		field:     func(o *config.AutoscalingOptions) *string { return &o.ExpanderNames },
+		parseFunc: parseNonEmptyString,
+	},
+	"new-pod-scale-up-delay": option[time.Duration]{
+		field:     func(o *config.AutoscalingOptions) *time.Duration { return &o.NewPodScaleUpDelay },
+		parseFunc: parseNonNegativeDuration,
+	},
+	"scale-down-enabled": option[bool]{
+		field:     func(o *config.AutoscalingOptions) *bool { return &o.ScaleDownEnabled },
+		parseFunc: strconv.ParseBool,
+	},
+	"scale-down-delay-after-add": option[time.Duration]{
+		field:     func(o *config.AutoscalingOptions) *time.Duration { return &o.ScaleDownDelayAfterAdd },
+		parseFunc: parseNonNegativeDuration,
+	},
+	"scale-down-delay-after-delete": option[time.Duration]{
+		field:     func(o *config.AutoscalingOptions) *time.Duration { return &o.ScaleDownDelayAfterDelete },
+		parseFunc: parseNonNegativeDuration,
+	},
+	"scale-down-delay-after-failure": option[time.Duration]{
+		field:     func(o *config.AutoscalingOptions) *time.Duration { return &o.ScaleDownDelayAfterFailure },
+		parseFunc: parseNonNegativeDuration,
+	},
+	"scale-down-unneeded-time": option[time.Duration]{
+		field:     func(o *config.AutoscalingOptions) *time.Duration { return &o.NodeGroupDefaults.ScaleDownUnneededTime },
+		parseFunc: parseNonNegativeDuration,
+	},
+	"scale-down-unready-time": option[time.Duration]{
+		field:     func(o *config.AutoscalingOptions) *time.Duration { return &o.NodeGroupDefaults.ScaleDownUnreadyTime },
+		parseFunc: parseNonNegativeDuration,
+	},
+	"scale-down-utilization-threshold": option[float64]{
+		field:     func(o *config.AutoscalingOptions) *float64 { return &o.NodeGroupDefaults.ScaleDownUtilizationThreshold },
+		parseFunc: parseThreshold,
+	},
+	"scale-down-gpu-utilization-threshold": option[float64]{
+		field: func(o *config.AutoscalingOptions) *float64 {
+			return &o.NodeGroupDefaults.ScaleDownGpuUtilizationThreshold
+		},
+		parseFunc: parseThreshold,
+	},
+	"ignore-daemonsets-utilization": option[bool]{
+		field:     func(o *config.AutoscalingOptions) *bool { return &o.NodeGroupDefaults.IgnoreDaemonSetsUtilization },
+		parseFunc: strconv.ParseBool,
+	},
+	"max-node-provision-time": option[time.Duration]{
+		field:     func(o *config.AutoscalingOptions) *time.Duration { return &o.NodeGroupDefaults.MaxNodeProvisionTime },
+		parseFunc: parsePositiveDuration,
+	},
+	"max-scale-down-parallelism": option[int]{
+		field:     func(o *config.AutoscalingOptions) *int { return &o.MaxScaleDownParallelism },
+		parseFunc: parsePositiveInt,
+	},
+	"max-drain-parallelism": option[int]{
+		field:     func(o *config.AutoscalingOptions) *int { return &o.MaxDrainParallelism },
+		parseFunc: parsePositiveInt,
+	},
+}
+
+// OptionsReloader reads overrides of reloadableOptions from a ConfigMap.
+// Options missing from the ConfigMap, or all of them if the ConfigMap
+// doesn't exist, are reset to the values they were started with.
+type OptionsReloader struct {
+	configMapLister v1lister.ConfigMapNamespaceLister
+	name            string
+	base            config.AutoscalingOptions
+	lastVersion     string
+	initialized     bool
+}
+
+// NewOptionsReloader returns an OptionsReloader for the given ConfigMap. It
+// blocks until the initial sync of the ConfigMap informer completes.
+func NewOptionsReloader(kubeClient kube_client.Interface, namespace, name string, base config.AutoscalingOptions) (*OptionsReloader, error) {
+	factory := informers.NewSharedInformerFactoryWithOptions(kubeClient, 1*time.Hour,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+		}))
+	configMapLister := factory.Core().V1().ConfigMaps().Lister()
+	stopCh := make(chan struct{})
+	factory.Start(stopCh)
+	for _, synced := range factory.WaitForCacheSync(stopCh) {
+		if !synced {
+			close(stopCh)
+			return nil, fmt.Errorf("can't sync options ConfigMap informer")
+		}
+	}
+	return newOptionsReloader(configMapLister.ConfigMaps(namespace), name, base), nil
+}
+
+func newOptionsReloader(configMapLister v1lister.ConfigMapNamespaceLister, name string, base config.AutoscalingOptions) *OptionsReloader {
+	return &OptionsReloader{
+		configMapLister: configMapLister,
+		name:            name,
+		base:            base,
+	}
+}
+
+// Reload returns current with reloadableOptions set to the values from the
+// ConfigMap, along with the sorted names of options that differ from current.
+// If the ConfigMap didn't change since the last call, current is returned
+// as is. An invalid ConfigMap is reported once and otherwise ignored.
+func (r *OptionsReloader) Reload(current config.AutoscalingOptions) (config.AutoscalingOptions, []string, error) {
+	var data map[string]string
+	version := ""
+	cm, err := r.configMapLister.Get(r.name)
+	if err != nil && !errors.IsNotFound(err) {
+		return current, nil, err
+	}
+	if cm != nil && err == nil {
+		data = cm.Data
+		version = cm.ResourceVersion
+	}
+	if r.initialized && version == r.lastVersion {
+		return current, nil, nil
+	}
+	r.initialized = true
+	r.lastVersion = version
+
+	updated, err := r.apply(current, data)
+	if err != nil {
+		return current, nil, fmt.Errorf("invalid options ConfigMap %s: %v", r.name, err)
+	}
+	var changed []string
+	for name, opt := range reloadableOptions {
+		if opt.differs(&current, &updated) {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return updated, changed, nil
+}
+
+func (r *OptionsReloader) apply(current config.AutoscalingOptions, data map[string]string) (config.AutoscalingOptions, error) {
+	for key := range data {
+		if _, found := reloadableOptions[key]; !found {
+			return current, fmt.Errorf("option %q can't be reloaded", key)
+		}
+	}
+	updated := current
+	for name, opt := range reloadableOptions {
+		opt.reset(&updated, &r.base)
+		if value, found := data[name]; found {
+			if err := opt.parse(&updated, value); err != nil {
+				return current, fmt.Errorf("invalid value %q of option %q: %v", value, name, err)
+			}
+		}
+	}
+	if len(data) > 0 {
+		klog.V(4).Infof("Applied options from ConfigMap %s: %v", r.name, data)
+	}
+	return updated, nil
+}
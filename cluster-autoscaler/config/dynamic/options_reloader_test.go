@@ -0,0 +1,104 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dynamic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	v1lister "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestOptionsReloader(t *testing.T) {
+	base := config.AutoscalingOptions{
+		ScanInterval:  10 * time.Second,
+		ExpanderNames: "least-waste",
+		MaxNodesTotal: 100,
+		NodeGroupDefaults: config.NodeGroupAutoscalingOptions{
+			ScaleDownUtilizationThreshold: 0.5,
+			ScaleDownUnneededTime:         10 * time.Minute,
+		},
+		MaxGracefulTerminationSec: 600,
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	lister := v1lister.NewConfigMapLister(indexer).ConfigMaps("kube-system")
+	r := newOptionsReloader(lister, "ca-options", base)
+
+	setConfigMap := func(version string, data map[string]string) {
+		assert.NoError(t, indexer.Update(&apiv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "ca-options", ResourceVersion: version},
+			Data:       data,
+		}))
+	}
+
+	// No ConfigMap, flag values are kept.
+	opts, changed, err := r.Reload(base)
+	assert.NoError(t, err)
+	assert.Empty(t, changed)
+	assert.Equal(t, base, opts)
+
+	setConfigMap("1", map[string]string{
+		"scan-interval":                    "30s",
+		"expander":                         "priority,least-waste",
+		"scale-down-utilization-threshold": "0.7",
+	})
+	opts, changed, err = r.Reload(opts)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"expander", "scale-down-utilization-threshold", "scan-interval"}, changed)
+	assert.Equal(t, 30*time.Second, opts.ScanInterval)
+	assert.Equal(t, "priority,least-waste", opts.ExpanderNames)
+	assert.Equal(t, 0.7, opts.NodeGroupDefaults.ScaleDownUtilizationThreshold)
+	assert.Equal(t, 10*time.Minute, opts.NodeGroupDefaults.ScaleDownUnneededTime)
+	assert.Equal(t, 100, opts.MaxNodesTotal)
+
+	// Unchanged ConfigMap is not applied again.
+	opts, changed, err = r.Reload(opts)
+	assert.NoError(t, err)
+	assert.Empty(t, changed)
+
+	// Invalid ConfigMaps are reported and leave the options as they were.
+	for version, data := range map[string]map[string]string{
+		"2": {"scale-down-utilization-threshold": "1.5"},
+		"3": {"scan-interval": "never"},
+		"4": {"max-graceful-termination-sec": "60"},
+	} {
+		setConfigMap(version, data)
+		updated, changed, err := r.Reload(opts)
+		assert.Error(t, err, version)
+		assert.Empty(t, changed, version)
+		assert.Equal(t, opts, updated, version)
+	}
+
+	// Removed keys are reset to the flag values.
+	setConfigMap("5", map[string]string{"max-nodes-total": "50"})
+	opts, changed, err = r.Reload(opts)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"expander", "max-nodes-total", "scale-down-utilization-threshold", "scan-interval"}, changed)
+	assert.Equal(t, 10*time.Second, opts.ScanInterval)
+	assert.Equal(t, 50, opts.MaxNodesTotal)
+
+	assert.NoError(t, indexer.Delete(&apiv1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "ca-options"}}))
+	opts, changed, err = r.Reload(opts)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"max-nodes-total"}, changed)
+	assert.Equal(t, base, opts)
+}
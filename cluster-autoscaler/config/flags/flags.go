@@ -28,13 +28,21 @@ import (
 	cloudBuilder "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/builder"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/gce/localssdsize"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/predelete"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup/costbudget"
 	"k8s.io/autoscaler/cluster-autoscaler/estimator"
 	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/scaledowncandidates/strategies"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/utilization"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/backoff"
 	scheduler_util "k8s.io/autoscaler/cluster-autoscaler/utils/scheduler"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/units"
 
+	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/rest"
 	klog "k8s.io/klog/v2"
+	v1helper "k8s.io/kubernetes/pkg/apis/core/v1/helper"
 	kubelet_config "k8s.io/kubernetes/pkg/kubelet/apis/config"
 	scheduler_config "k8s.io/kubernetes/pkg/scheduler/apis/config"
 )
@@ -80,13 +88,33 @@ var (
 		"How long after node deletion that scale down evaluation resumes")
 	scaleDownDelayAfterFailure = flag.Duration("scale-down-delay-after-failure", config.DefaultScaleDownDelayAfterFailure,
 		"How long after scale down failure that scale down evaluation resumes")
-	scaleDownUnneededTime = flag.Duration("scale-down-unneeded-time", config.DefaultScaleDownUnneededTime,
+	scaleDownUnneededTime = flag.Duration(config.ScaleDownUnneededTimeFlag, config.DefaultScaleDownUnneededTime,
 		"How long a node should be unneeded before it is eligible for scale down")
 	scaleDownUnreadyTime = flag.Duration("scale-down-unready-time", config.DefaultScaleDownUnreadyTime,
 		"How long an unready node should be unneeded before it is eligible for scale down")
-	scaleDownUtilizationThreshold = flag.Float64("scale-down-utilization-threshold", config.DefaultScaleDownUtilizationThreshold,
-		"The maximum value between the sum of cpu requests and sum of memory requests of all pods running on the node divided by node's corresponding allocatable resource, below which a node can be considered for scale down")
-	scaleDownGpuUtilizationThreshold = flag.Float64("scale-down-gpu-utilization-threshold", config.DefaultScaleDownGpuUtilizationThreshold,
+	scaleDownDryRun = flag.Bool("scale-down-dry-run", false,
+		"Should CA only plan scale down, without tainting, draining or deleting nodes. The plan, along with nodes that can't be removed and estimated savings, is periodically written as a JSON report. Requires --scale-down-enabled.")
+	scaleDownDryRunReportFile = flag.String("scale-down-dry-run-report-file", "",
+		"File the scale down dry-run report is written to. If empty, the report is written to the cluster-autoscaler-scale-down-dry-run ConfigMap in the namespace passed via --namespace.")
+	scaleDownDryRunReportInterval = flag.Duration("scale-down-dry-run-report-interval", time.Minute,
+		"How often the scale down dry-run report is written")
+	scaleDownConsolidationEnabled = flag.Bool("scale-down-consolidation-enabled", false,
+		"Should CA replace multiple underutilized nodes with a single node from another node group when all their pods fit on it. The new node is created before the replaced nodes are drained.")
+	maxNodesPerConsolidation = flag.Int("max-nodes-per-consolidation", 5,
+		"Maximum number of nodes replaced by a single node during consolidation")
+	spotInterruptionHandlingEnabled = flag.Bool("spot-interruption-handling-enabled", false,
+		"Should CA cordon and drain nodes whose spot or preemptible instances are about to be interrupted, as reported by the cloud provider or by agents running on the nodes")
+	spotInterruptionPreScaleEnabled = flag.Bool("spot-interruption-pre-scale-enabled", false,
+		"Should CA scale up the node group of each interrupted node by one node right away, instead of waiting for its pods to become pending. Requires --spot-interruption-handling-enabled.")
+	nodeAutoRepairEnabled = flag.Bool("node-auto-repair-enabled", false,
+		"Should CA recreate nodes which are NotReady for longer than --node-auto-repair-not-ready-time, or unregistered for longer than --max-node-provision-time, by deleting them and scaling up their node group. Can be overridden per node group.")
+	nodeAutoRepairNotReadyTime = flag.Duration("node-auto-repair-not-ready-time", 20*time.Minute,
+		"How long a node has to be NotReady before it's recreated by node auto-repair")
+	maxNodeAutoRepairsPerHour = flag.Int("max-node-auto-repairs-per-hour", 10,
+		"Maximum number of nodes recreated by node auto-repair per hour")
+	scaleDownUtilizationThreshold = flag.Float64(config.ScaleDownUtilizationThresholdFlag, config.DefaultScaleDownUtilizationThreshold,
+		"The maximum value between the sum of cpu requests and sum of memory requests (and sums of requests of resources passed via --scale-down-utilization-extended-resource) of all pods running on the node divided by node's corresponding allocatable resource, below which a node can be considered for scale down")
+	scaleDownGpuUtilizationThreshold = flag.Float64(config.ScaleDownGpuUtilizationThresholdFlag, config.DefaultScaleDownGpuUtilizationThreshold,
 		"Sum of gpu requests of all pods running on the node divided by node's allocatable resource, below which a node can be considered for scale down."+
 			"Utilization calculation only cares about gpu resource for accelerator node. cpu and memory utilization will be ignored.")
 	scaleDownNonEmptyCandidatesCount = flag.Int("scale-down-non-empty-candidates-count", 30,
@@ -105,13 +133,36 @@ var (
 			"for scale down when some candidates from previous iteration are no longer valid."+
 			"When calculating the pool size for additional candidates we take"+
 			"max(#nodes * scale-down-candidates-pool-ratio, scale-down-candidates-pool-min-count).")
-	schedulerConfigFile         = flag.String(config.SchedulerConfigFileFlag, "", "scheduler-config allows changing configuration of in-tree scheduler plugins acting on PreFilter and Filter extension points")
+	scaleDownSortingStrategies = pflag.StringSlice("scale-down-candidates-sorting-strategies", []string{},
+		"Comma separated list of strategies ordering scale down candidates, deciding which empty and underutilized nodes are removed first. "+
+			"Each strategy is only used for nodes the previous ones consider equal. Empty nodes and candidates from the previous iteration always come first. "+
+			"Available values: ["+strings.Join(strategies.AvailableStrategies, ",")+"]")
+	scaleDownNodeGroupPriorityConfigMap = flag.String("scale-down-node-group-priority-config-map", "",
+		"Name of the ConfigMap, in the namespace of the CA config, with node group priorities ordering scale down candidates. "+
+			"Its 'priorities' key has the format of the priority expander configuration, nodes of node groups with higher priorities are removed first. "+
+			"Applied after empty nodes and candidates from the previous iteration, before --scale-down-candidates-sorting-strategies. Empty disables the ordering.")
+	scalingScheduleConfigMap = flag.String("scaling-schedule-config-map", "",
+		"Name of the ConfigMap, in the namespace of the CA config, with scheduled scaling windows. "+
+			"Its 'windows' key lists windows setting the min and/or max sizes of node groups matching regular expressions, for a duration each time a cron schedule fires. "+
+			"Requires a cloud provider able to override node group sizes, e.g. AWS. Empty disables scheduled scaling.")
+	schedulerConfigFile            = flag.String(config.SchedulerConfigFileFlag, "", "scheduler-config allows changing configuration of in-tree scheduler plugins acting on PreFilter and Filter extension points")
+	additionalSchedulerConfigFiles = multiStringFlag("additional-scheduler-config-file",
+		"Path to a scheduler config with profiles of additional schedulers running in the cluster. Pods with spec.schedulerName matching one of the profiles are simulated with it, other pods are simulated with the default profile. Can be passed multiple times.")
 	nodeDeletionDelayTimeout    = flag.Duration("node-deletion-delay-timeout", 2*time.Minute, "Maximum time CA waits for removing delay-deletion.cluster-autoscaler.kubernetes.io/ annotations before deleting the node.")
+	preDeleteHook               = flag.String("pre-delete-hook", "", "How CA hands drained nodes over to external controllers, e.g. log shippers or storage drainers, before deleting them. 'annotation' sets the "+predelete.PreDeleteAnnotation+" annotation and waits for the "+predelete.PreDeleteAcknowledgedAnnotation+" annotation, 'node-deletion-hook' creates a NodeDeletionHook object named after the node and waits for it to complete. Empty disables pre-delete hooks.")
+	preDeleteHookTimeout        = flag.Duration("pre-delete-hook-timeout", 5*time.Minute, "Maximum time CA waits for a pre-delete hook to complete before deleting the node.")
 	nodeDeletionBatcherInterval = flag.Duration("node-deletion-batcher-interval", 0*time.Second, "How long CA ScaleDown gather nodes to delete them in batch.")
-	scanInterval                = flag.Duration("scan-interval", config.DefaultScanInterval, "How often cluster is reevaluated for scale up or down")
+	scanInterval                = flag.Duration(config.ScanIntervalFlag, config.DefaultScanInterval, "How often cluster is reevaluated for scale up or down")
+	profile                     = flag.String(config.ProfileFlag, config.BalancedProfile, "Profile of coordinated defaults for --scan-interval, --scale-down-utilization-threshold, --scale-down-gpu-utilization-threshold, --scale-down-unneeded-time and --expander. Available values: ["+strings.Join(config.ProfileNames(), ",")+"]. optimize-utilization removes underutilized nodes sooner, optimize-latency reacts faster and keeps spare nodes for longer. Flags set explicitly override the profile.")
 	maxNodesTotal               = flag.Int("max-nodes-total", 0, "Maximum number of nodes in all node groups. Cluster autoscaler will not grow the cluster beyond this number.")
+	maxClusterHourlyCost        = flag.Float64("max-cluster-hourly-cost", 0, "Maximum hourly cost of all node groups in the cluster, in the currency of the node group prices. Scale-ups that would exceed it are handled according to --cluster-cost-budget-mode. 0 means no limit.")
+	clusterCostBudgetMode       = flag.String("cluster-cost-budget-mode", costbudget.HardMode, "What to do with scale-ups exceeding --max-cluster-hourly-cost. 'hard' caps them to the remaining budget and rejects scale-ups of node groups without a known price, 'soft' only emits a warning event.")
+	nodeGroupHourlyPrices       = multiStringFlag("node-group-hourly-price", "Hourly price of a single node of a node group, in the format <node_group_id>:<price>. Prices of node groups without one are taken from the cloud provider pricing model. Can be passed multiple times.")
+	costMetricsEnabled          = flag.Bool("cost-metrics-enabled", false, "Whether CA estimates, using the node group prices, the cost saved by nodes removed by scale-down, accrued per node group in the scaled_down_cost_savings_total metric if --emit-per-nodegroup-metrics is set, and the hourly cost of the cluster in each loop, reported by the cluster_hourly_cost metric.")
 	coresTotal                  = flag.String("cores-total", minMaxFlagString(0, config.DefaultMaxClusterCores), "Minimum and maximum number of cores in cluster, in the format <min>:<max>. Cluster autoscaler will not scale the cluster beyond these numbers.")
 	memoryTotal                 = flag.String("memory-total", minMaxFlagString(0, config.DefaultMaxClusterMemory), "Minimum and maximum number of gigabytes of memory in cluster, in the format <min>:<max>. Cluster autoscaler will not scale the cluster beyond these numbers.")
+	nodeDeletionQuotas          = multiStringFlag("node-deletion-quota", "Limits node deletion calls made to a cloud provider during scale down, in the format <cloud_provider>:<qps>:<max_batch_size>. Deletions are grouped per node group and split into calls of at most max_batch_size nodes, issued at most qps times per second. 0 means no limit. Only the quota of the cloud provider passed via --cloud-provider is applied. Can be passed multiple times.")
+	namespaceEvictionLimits     = multiStringFlag("max-evictions-per-minute-per-namespace", "Maximum number of pods evicted per minute from a namespace during scale down, in the format <namespace>:<limit>. Namespace '*' sets the limit for all namespaces without their own limit. Evictions above the limit are delayed, up to --max-pod-eviction-time, after which the pod is left on the node and its scale down fails. 0 means no limit. Can be passed multiple times.")
 	gpuTotal                    = multiStringFlag("gpu-total", "Minimum and maximum number of different GPUs in cluster, in the format <gpu_type>:<min>:<max>. Cluster autoscaler will not scale the cluster beyond these numbers. Can be passed multiple times. CURRENTLY THIS FLAG ONLY WORKS ON GKE.")
 	cloudProviderFlag           = flag.String("cloud-provider", cloudBuilder.DefaultCloudProvider,
 		"Cloud provider type. Available values: ["+strings.Join(cloudBuilder.AvailableCloudProviders, ",")+"]")
@@ -125,6 +176,7 @@ var (
 	parallelScaleUp           = flag.Bool("parallel-scale-up", false, "Whether to allow parallel node groups scale up. Experimental: may not work on some cloud providers, enable at your own risk.")
 	maxNodeProvisionTime      = flag.Duration("max-node-provision-time", 15*time.Minute, "The default maximum time CA waits for node to be provisioned - the value can be overridden per node group")
 	maxPodEvictionTime        = flag.Duration("max-pod-eviction-time", 2*time.Minute, "Maximum time CA tries to evict a pod before giving up")
+	forceDeletePodsAfter      = flag.Duration("force-delete-pods-after", 0, "Time after which pods evicted during a node drain, which are still terminating (e.g. because of finalizers), are force deleted with a zero grace period. Can be overridden per pod with the 'cluster-autoscaler.kubernetes.io/force-delete-after' annotation. Capped at --max-pod-eviction-time. 0 disables force deletion.")
 	nodeGroupsFlag            = multiStringFlag(
 		"nodes",
 		"sets min,max size and other configuration data for a node group in a format accepted by cloud provider. Can be used multiple times. Format: <min>:<max>:<other...>")
@@ -138,35 +190,72 @@ var (
 			"Can be used multiple times.")
 
 	estimatorFlag = flag.String("estimator", estimator.BinpackingEstimatorName,
-		"Type of resource estimator to be used in scale up. Available values: ["+strings.Join(estimator.AvailableEstimators, ",")+"]")
+		"Type of resource estimator to be used in scale up. Available values: ["+strings.Join(estimator.AvailableEstimators, ",")+"]. "+
+			"binpacking-best-fit places each pod on the simulated node that leaves the least unused CPU, memory and GPU instead of the first node it fits on, which may reduce the number of nodes requested for pods of different sizes.")
 
-	expanderFlag = flag.String("expander", expander.LeastWasteExpanderName, "Type of node group expander to be used in scale up. Available values: ["+strings.Join(expander.AvailableExpanders, ",")+"]. Specifying multiple values separated by commas will call the expanders in succession until there is only one option remaining. Ties still existing after this process are broken randomly.")
+	estimatorGpuPodOrdering = flag.Bool("estimator-gpu-pod-ordering", false, "If true, the estimator takes GPU requests into account when ordering pods from the largest to the smallest, so that pods requesting a bigger share of a node's GPUs are placed first.")
 
-	grpcExpanderCert = flag.String("grpc-expander-cert", "", "Path to cert used by gRPC server over TLS")
-	grpcExpanderURL  = flag.String("grpc-expander-url", "", "URL to reach gRPC expander server.")
+	expanderFlag = flag.String(config.ExpanderFlag, expander.LeastWasteExpanderName, "Type of node group expander to be used in scale up. Available values: ["+strings.Join(expander.AvailableExpanders, ",")+"]. Specifying multiple values separated by commas will call the expanders in succession until there is only one option remaining. Ties still existing after this process are broken randomly. Expanders followed by a weight, e.g. least-waste:0.7,price:0.3, are combined into one step choosing options with the best weighted sum of normalized scores. Weights are supported by least-waste, least-nodes, most-pods, price, price-live and health.")
+
+	grpcExpanderCert       = flag.String("grpc-expander-cert", "", "Path to cert used by gRPC server over TLS")
+	grpcExpanderURL        = flag.String("grpc-expander-url", "", "URL to reach gRPC expander server.")
+	grpcExpanderClientCert = flag.String("grpc-expander-client-cert", "", "Path to client cert presented to gRPC expander server for mTLS. Requires --grpc-expander-client-key.")
+	grpcExpanderClientKey  = flag.String("grpc-expander-client-key", "", "Path to client private key used for mTLS with gRPC expander server. Requires --grpc-expander-client-cert.")
+	expanderPluginsFlag    = multiStringFlag("expander-plugin", "Path to a Go plugin (.so file) providing an expander, which can then be selected in --expander by the name the plugin exports. The plugin must be built with the same Go version and dependencies as Cluster Autoscaler, which must be built with the goplugin build tag. Can be passed multiple times.")
+	priceLiveCacheTTL      = flag.Duration("price-live-cache-ttl", time.Hour, "How long instance prices fetched from cloud provider pricing APIs by the price-live expander are cached.")
+	priorityTieBreaker     = flag.String("priority-expander-tie-breaker", "", "Expanders choosing one of the node groups with the highest priority in the priority expander, in the format of --expander. Can't contain the priority expander. Empty passes all of them on to the next expander.")
+
+	drainabilityWebhookURL           = flag.String("drainability-webhook-url", "", "HTTPS URL of a webhook called with the node and its pods before the node is considered drainable during scale down. The webhook responds with an Allow, Deny or Block decision, with a reason required for Block. It runs before the built-in drainability rules: Deny and Block prevent draining the node, Allow leaves the decision to the built-in rules. Must use the https scheme. Disabled if empty.")
+	drainabilityWebhookCACert        = flag.String("drainability-webhook-ca-cert", "", "Path to the CA certificate used to verify the drainability webhook server certificate. System CAs are used if empty.")
+	drainabilityWebhookTimeout       = flag.Duration("drainability-webhook-timeout", 5*time.Second, "Timeout of drainability webhook calls.")
+	drainabilityWebhookFailurePolicy = flag.String("drainability-webhook-failure-policy", "Fail", "How drainability webhook errors are handled. Available values: [Fail,Ignore]. Fail blocks draining the node, Ignore leaves the decision to other drainability rules.")
 
 	ignoreDaemonSetsUtilization = flag.Bool("ignore-daemonsets-utilization", false,
 		"Should CA ignore DaemonSet pods when calculating resource utilization for scaling down")
 	ignoreMirrorPodsUtilization = flag.Bool("ignore-mirror-pods-utilization", false,
 		"Should CA ignore Mirror pods when calculating resource utilization for scaling down")
+	scaleDownUtilizationExtendedResources = multiStringFlag("scale-down-utilization-extended-resource",
+		"Specifies an additional resource (e.g. hugepages-2Mi, ephemeral-storage) to take into account when calculating node utilization for scaling down. Ignored for nodes with GPU and for nodes exposing DRA resource slices when DRA is enabled. Can be used multiple times.")
+	scaleDownUtilizationSource = flag.String("scale-down-utilization-source", string(utilization.RequestsSource),
+		"What cpu and memory utilization used for scaling down is based on. Available values: requests (sum of pod requests), actual (node usage reported by metrics.k8s.io or Prometheus), max (the higher of the two)")
+	scaleDownUsagePrometheusAddress = flag.String("scale-down-usage-prometheus-address", "",
+		"Address of Prometheus used to fetch actual node usage when --scale-down-utilization-source is not requests. If empty, metrics.k8s.io API is used.")
+	scaleDownUtilizationBreakdownPods = flag.Int("scale-down-utilization-breakdown-pods", 0,
+		"Number of pods with the highest requests reported in the cluster-autoscaler.kubernetes.io/scale-down-disabled-reason node annotation and the status ConfigMap for nodes that are not scaled down because of high utilization. 0 disables the breakdown.")
+	scaleDownIncrementalEligibilityEnabled = flag.Bool("scale-down-incremental-eligibility-enabled", false,
+		"Should CA recalculate utilization of scale down candidates only when the nodes or the pods scheduled on them change, instead of every loop.")
 
 	writeStatusConfigMapFlag     = flag.Bool("write-status-configmap", true, "Should CA write status information to a configmap")
 	statusConfigMapName          = flag.String("status-config-map-name", "cluster-autoscaler-status", "Status configmap name")
+	writeStatusObjectFlag        = flag.Bool("write-status-object", false, "Should CA write status information to a ClusterAutoscalerStatus object. Requires the ClusterAutoscalerStatus CRD to be installed.")
+	statusObjectName             = flag.String("status-object-name", "cluster-autoscaler-status", "Name of the ClusterAutoscalerStatus object in the namespace passed via --namespace")
+	leaderStateHandoffEnabled    = flag.Bool("leader-state-handoff-enabled", false, "Should a new leader take over scale-ups and node deletions in progress from the previous one, instead of forgetting the scale-ups and removing the ToBeDeleted taints. The state is handed off through the ClusterAutoscalerStatus object. Requires --write-status-object.")
+	scaleUpIntentsEnabled        = flag.Bool("scale-up-intents-enabled", false, "Should CA persist scale-ups in progress as ScaleUpIntent objects in the namespace passed via --namespace, so that after a restart it resumes them, or rolls them back if they timed out in the meantime. Requires the ScaleUpIntent CRD to be installed.")
+	optionsConfigMapName         = flag.String("options-config-map-name", "", "Name of a ConfigMap in the namespace passed via --namespace overriding a subset of flags at runtime, without restarting CA. Keys are flag names, e.g. scale-down-utilization-threshold. Removing a key restores the flag value. Empty disables reloading.")
 	maxInactivityTimeFlag        = flag.Duration("max-inactivity", 10*time.Minute, "Maximum time from last recorded autoscaler activity before automatic restart")
 	maxBinpackingTimeFlag        = flag.Duration("max-binpacking-time", 5*time.Minute, "Maximum time spend on binpacking for a single scale-up. If binpacking is limited by this, scale-up will continue with the already calculated scale-up options.")
 	maxFailingTimeFlag           = flag.Duration("max-failing-time", 15*time.Minute, "Maximum time from last recorded successful autoscaler run before automatic restart")
 	balanceSimilarNodeGroupsFlag = flag.Bool("balance-similar-node-groups", false, "Detect similar node groups and balance the number of nodes between them")
 
-	unremovableNodeRecheckTimeout = flag.Duration("unremovable-node-recheck-timeout", 5*time.Minute, "The timeout before we check again a node that couldn't be removed before")
-	expendablePodsPriorityCutoff  = flag.Int("expendable-pods-priority-cutoff", -10, "Pods with priority below cutoff will be expendable. They can be killed without any consideration during scale down and they don't cause scale up. Pods with null priority (PodPriority disabled) are non expendable.")
-	regional                      = flag.Bool("regional", false, "Cluster is regional.")
-	newPodScaleUpDelay            = flag.Duration("new-pod-scale-up-delay", 0*time.Second, "Pods less than this old will not be considered for scale-up. Can be increased for individual pods through annotation 'cluster-autoscaler.kubernetes.io/pod-scale-up-delay'.")
-
-	startupTaintsFlag         = multiStringFlag("startup-taint", "Specifies a taint to ignore in node templates when considering to scale a node group (Equivalent to ignore-taint)")
-	statusTaintsFlag          = multiStringFlag("status-taint", "Specifies a taint to ignore in node templates when considering to scale a node group but nodes will not be treated as unready")
-	balancingIgnoreLabelsFlag = multiStringFlag("balancing-ignore-label", "Specifies a label to ignore in addition to the basic and cloud-provider set of labels when comparing if two node groups are similar")
-	balancingLabelsFlag       = multiStringFlag("balancing-label", "Specifies a label to use for comparing if two node groups are similar, rather than the built in heuristics. Setting this flag disables all other comparison logic, and cannot be combined with --balancing-ignore-label.")
-	awsUseStaticInstanceList  = flag.Bool("aws-use-static-instance-list", false, "Should CA fetch instance types in runtime or use a static list. AWS only")
+	predictiveScaleUpEnabled             = flag.Bool("predictive-scale-up-enabled", false, "Should CA learn the hours of the week node groups are scaled up in for pending pods, persisted as ScaleUpHistory objects in the namespace passed via --namespace, and scale node groups up ahead of scale-ups predicted to recur. Requires the ScaleUpHistory CRD to be installed.")
+	predictiveScaleUpConfidenceThreshold = flag.Float64("predictive-scale-up-confidence-threshold", 0.8, "Fraction of past weeks with a scale-up of a node group in an hour of the week from which CA predicts a scale-up in it, greater than 0 and at most 1. Requires --predictive-scale-up-enabled.")
+	predictiveScaleUpMaxNodes            = flag.Int("predictive-scale-up-max-nodes", 10, "Maximum number of nodes added ahead of predicted scale-ups whose hour hasn't ended yet, across all node groups. Requires --predictive-scale-up-enabled.")
+	predictiveScaleUpLeadTime            = flag.Duration("predictive-scale-up-lead-time", 10*time.Minute, "How long before a predicted scale-up CA scales the node group up. Requires --predictive-scale-up-enabled.")
+
+	unremovableNodeRecheckTimeout   = flag.Duration("unremovable-node-recheck-timeout", 5*time.Minute, "The timeout before we check again a node that couldn't be removed before")
+	scaleDownPdbLookaheadWaves      = flag.Int("scale-down-pdb-lookahead-waves", 0, "Maximum number of drain waves, after the one which can start right away, into which scale down schedules unneeded nodes blocked by PodDisruptionBudgets, assuming that the budgets recover between waves. Scheduled nodes are removed as soon as the budgets allow it, instead of being re-checked after --unremovable-node-recheck-timeout. 0 disables the lookahead.")
+	scaleDownSimulationCacheEnabled = flag.Bool("scale-down-simulation-cache-enabled", false, "Should CA cache the outcomes of moving the pods of scale down candidates to other nodes, keyed by a hash of the pods and of the destination nodes with their pods, so that they aren't simulated again while the cluster doesn't change.")
+	expendablePodsPriorityCutoff    = flag.Int("expendable-pods-priority-cutoff", -10, "Pods with priority below cutoff will be expendable. They can be killed without any consideration during scale down and they don't cause scale up. Pods with null priority (PodPriority disabled) are non expendable.")
+	regional                        = flag.Bool("regional", false, "Cluster is regional.")
+	newPodScaleUpDelay              = flag.Duration("new-pod-scale-up-delay", 0*time.Second, "Pods less than this old will not be considered for scale-up. Can be increased for individual pods through annotation 'cluster-autoscaler.kubernetes.io/pod-scale-up-delay'.")
+
+	startupTaintsFlag            = multiStringFlag("startup-taint", "Specifies a taint to ignore in node templates when considering to scale a node group (Equivalent to ignore-taint)")
+	statusTaintsFlag             = multiStringFlag("status-taint", "Specifies a taint to ignore in node templates when considering to scale a node group but nodes will not be treated as unready")
+	balancingIgnoreLabelsFlag    = multiStringFlag("balancing-ignore-label", "Specifies a label to ignore in addition to the basic and cloud-provider set of labels when comparing if two node groups are similar")
+	balancingLabelsFlag          = multiStringFlag("balancing-label", "Specifies a label to use for comparing if two node groups are similar, rather than the built in heuristics. Setting this flag disables all other comparison logic, and cannot be combined with --balancing-ignore-label.")
+	balancingRequireLabelsFlag   = multiStringFlag("balancing-require-label", "Specifies a label that has to be set to the same value on two node groups for them to be similar, on top of the other comparison logic. Labels ignored by the comparison logic can be required too.")
+	balancingInstanceFamilyMatch = flag.Bool("balancing-instance-family-match", false, "Consider node groups of different instance types of the same family (e.g. m5.xlarge and m5a.xlarge, or n2-standard-8 and n2d-standard-8) similar, as long as their resources and other labels match.")
+	awsUseStaticInstanceList     = flag.Bool("aws-use-static-instance-list", false, "Should CA fetch instance types in runtime or use a static list. AWS only")
 
 	// GCE specific flags
 	concurrentGceRefreshes             = flag.Int("gce-concurrent-refreshes", 1, "Maximum number of concurrent refreshes per cloud object type.")
@@ -180,6 +269,20 @@ var (
 	userAgent                          = flag.String("user-agent", "cluster-autoscaler", "User agent used for HTTP calls.")
 	emitPerNodeGroupMetrics            = flag.Bool("emit-per-nodegroup-metrics", false, "If true, emit per node group metrics.")
 	debuggingSnapshotEnabled           = flag.Bool("debugging-snapshot-enabled", false, "Whether the debugging snapshot of cluster autoscaler feature is enabled")
+	autoscalingPauseEnabled            = flag.Bool("autoscaling-pause-enabled", false, "Whether scale-up and scale-down can be paused, cluster-wide or per node group, through the /pause endpoint and the spec of the ClusterAutoscalerStatus object written with --write-status-object. Pauses end automatically. The /pause endpoint requires --autoscaling-pause-basic-auth-file.")
+	autoscalingPauseBasicAuthFile      = flag.String("autoscaling-pause-basic-auth-file", "", "File with the HTTP basic auth credentials accepted by the /pause endpoint, with a <user>:<password> pair per line. The endpoint isn't served if empty.")
+	maxPauseDuration                   = flag.Duration("max-pause-duration", 24*time.Hour, "Longest duration for which scale-up or scale-down can be paused through the /pause endpoint.")
+	decisionLoggingEnabled             = flag.Bool("decision-logging-enabled", false, "Whether CA logs a structured record, with the \""+status.DecisionLogMessage+"\" message, for each scale-up attempt, node group scaled up, pod not triggering a scale-up, node evaluated for scale-down and node scaled down. Records of the same loop share a traceID. Use with --logging-format=json to get one JSON object per record.")
+	scalingDecisionHistoryEnabled      = flag.Bool("scaling-decision-history-enabled", false, "Whether CA persists each node group scaled up and each node scaled down, with the reason and the pods which triggered or were evicted by it, as a ScalingDecision object in the namespace passed via --namespace. Requires the ScalingDecision CRD.")
+	scalingDecisionHistoryMaxCount     = flag.Int("scaling-decision-history-max-count", 1000, "Maximum number of ScalingDecision objects kept when --scaling-decision-history-enabled is set. The oldest are deleted first.")
+	scalingDecisionHistoryMaxAge       = flag.Duration("scaling-decision-history-max-age", 7*24*time.Hour, "Age after which ScalingDecision objects are deleted when --scaling-decision-history-enabled is set.")
+	tracingEndpoint                    = flag.String("tracing-endpoint", "", "OTLP gRPC endpoint, e.g. localhost:4317, which OpenTelemetry spans of the main loop, scale-up, scale-down and cloud provider calls are exported to. Tracing is disabled if empty.")
+	tracingSamplingRatePerMillion      = flag.Int("tracing-sampling-rate-per-million", 1000000, "Number of loops out of a million which are traced when --tracing-endpoint is set.")
+	webUIEnabled                       = flag.Bool("web-ui-enabled", false, "Whether a read-only web UI, rendering the state of node groups, unneeded and unremovable nodes, recent scale events and backoffs, is served on the /ui path of --address. Requires --web-ui-basic-auth-file.")
+	webUIBasicAuthFile                 = flag.String("web-ui-basic-auth-file", "", "File with the HTTP basic auth credentials accepted by the web UI, with a <user>:<password> pair per line.")
+	nodeGroupLimitEventsEnabled        = flag.Bool("node-group-limit-events-enabled", true, "Whether CA emits a NodeGroupMaxSizeReached event on pending pods which node groups at their max size keep from triggering a scale-up, and a NodeGroupMinSizeReached event on unneeded nodes which aren't removed because their node group is at its min size. The pods and nodes are also counted per node group by the node_group_max_size_reached_pending_pods and node_group_min_size_reached_unneeded_nodes metrics if --emit-per-nodegroup-metrics is set.")
+	scaleUpSimulationEnabled           = flag.Bool("scale-up-simulation-enabled", false, "Whether the /simulate/scale-up endpoint, returning node groups and node counts a scale-up for the posted pods would use without scaling up, is enabled. Requests are answered by the leader in its next loop. Requires --scale-up-simulation-basic-auth-file.")
+	scaleUpSimulationBasicAuthFile     = flag.String("scale-up-simulation-basic-auth-file", "", "File with the HTTP basic auth credentials accepted by the /simulate/scale-up endpoint, with a <user>:<password> pair per line.")
 	nodeInfoCacheExpireTime            = flag.Duration("node-info-cache-expire-time", 87600*time.Hour, "Node Info cache expire time for each item. Default value is 10 years.")
 
 	initialNodeGroupBackoffDuration = flag.Duration("initial-node-group-backoff-duration", 5*time.Minute,
@@ -188,14 +291,28 @@ var (
 		"maxNodeGroupBackoffDuration is the maximum backoff duration for a NodeGroup after new nodes failed to start.")
 	nodeGroupBackoffResetTimeout = flag.Duration("node-group-backoff-reset-timeout", 3*time.Hour,
 		"nodeGroupBackoffResetTimeout is the time after last failed scale-up when the backoff duration is reset.")
+	nodeGroupBackoffPolicy = flag.String("node-group-backoff-policy", backoff.ExponentialBackoffPolicy,
+		"Policy of backing off node groups after failed scale-ups. 'exponential' doubles the backoff duration on every consecutive failure. 'adaptive' derives it from a health score of the node group based on the success rate and node readiness latency of its recent scale-ups.")
+	nodeGroupHealthWindow = flag.Duration("node-group-health-window", time.Hour,
+		"Time window of scale-ups taken into account by the health score of node groups. Only used with --node-group-backoff-policy=adaptive.")
+	nodeGroupHealthTargetReadinessLatency = flag.Duration("node-group-health-target-readiness-latency", 5*time.Minute,
+		"Node readiness latency of scale-ups above which the health score of node groups is lowered. Zero disables it. Only used with --node-group-backoff-policy=adaptive.")
+	zonalStockoutCooldown = flag.Duration("zonal-stockout-cooldown", 0,
+		"How long all node groups of a zone and instance type are excluded from scale-up after a stockout in one of them, so that node groups in other zones are scaled up instead. Zero disables it.")
 	maxScaleDownParallelismFlag             = flag.Int("max-scale-down-parallelism", 10, "Maximum number of nodes (both empty and needing drain) that can be deleted in parallel.")
 	maxDrainParallelismFlag                 = flag.Int("max-drain-parallelism", 1, "Maximum number of nodes needing drain, that can be drained and deleted in parallel.")
 	recordDuplicatedEvents                  = flag.Bool("record-duplicated-events", false, "enable duplication of similar events within a 5 minute window.")
 	maxNodesPerScaleUp                      = flag.Int("max-nodes-per-scaleup", 1000, "Max nodes added in a single scale-up. This is intended strictly for optimizing CA algorithm latency and not a tool to rate-limit scale-up throughput.")
+	maxNodesPerScaleUpPerOwner              = flag.Int("max-nodes-per-scaleup-per-owner", 0, "Max nodes added in a single scale-up for pods of a single controller (e.g. Deployment, ReplicaSet or Job), protecting other workloads from a single one consuming the whole node budget. Pods without a controller are not limited. 0 means no limit.")
 	maxNodeGroupBinpackingDuration          = flag.Duration("max-nodegroup-binpacking-duration", 10*time.Second, "Maximum time that will be spent in binpacking simulation for each NodeGroup.")
+	scaleUpForUnsatisfiableTopologySpread   = flag.Bool("scale-up-for-unsatisfiable-topology-spread", false, "Should CA treat topology spread constraints of unschedulable pods with whenUnsatisfiable: ScheduleAnyway as DoNotSchedule in scale-up simulations, scaling up node groups keeping the skew, e.g. in a zone without nodes, instead of any node group fitting the pods. Pods whose spread can't be kept by any node group don't trigger scale-up.")
+	scaleUpForVolumeTopology                = flag.Bool("scale-up-for-volume-topology", false, "Should CA make node group templates match the topology of persistent volumes bound to unschedulable pods, by adding topology labels of CSI drivers (e.g. topology.ebs.csi.aws.com/zone) which existing nodes show to be aliases of well-known topology labels, so that pods whose volumes are in a zone without nodes trigger scale-up of node groups in that zone. Pods whose volumes don't match any node get an event listing the node groups matching them.")
+	subnetCapacityCheckEnabled              = flag.Bool("subnet-capacity-check-enabled", false, "Should CA skip node groups whose subnets have no free IP addresses left for new nodes in scale-up, reporting them as SubnetExhausted, and cap the nodes added to node groups by the free IP addresses of their subnets, so that alternative node groups are scaled up instead. Requires a cloud provider able to check subnet capacity, e.g. AWS.")
+	binpackingParallelism                   = flag.Int("binpacking-parallelism", 1, "Maximum number of NodeGroups for which binpacking simulation is run in parallel during a scale-up. Each parallel simulation uses a separate copy of the cluster snapshot.")
 	skipNodesWithSystemPods                 = flag.Bool("skip-nodes-with-system-pods", true, "If true cluster autoscaler will wait for --blocking-system-pod-distruption-timeout before deleting nodes with pods from kube-system (except for DaemonSet or mirror pods)")
 	skipNodesWithLocalStorage               = flag.Bool("skip-nodes-with-local-storage", true, "If true cluster autoscaler will never delete nodes with pods with local storage, e.g. EmptyDir or HostPath")
 	skipNodesWithCustomControllerPods       = flag.Bool("skip-nodes-with-custom-controller-pods", true, "If true cluster autoscaler will never delete nodes with pods owned by custom controllers")
+	skipNodesWithUnattachableVolumes        = flag.Bool("skip-nodes-with-unattachable-volumes", false, "If true cluster autoscaler will never delete nodes with pods whose CSI persistent volumes can't be attached to any other ready node due to CSI volume attach limits")
 	minReplicaCount                         = flag.Int("min-replica-count", 0, "Minimum number or replicas that a replica set or replication controller should have to allow their pods deletion in scale down")
 	bspDisruptionTimeout                    = flag.Duration("blocking-system-pod-distruption-timeout", time.Hour, "The timeout after which CA will evict non-pdb-assigned blocking system pods, applicable only when --skip-nodes-with-system-pods is set to true")
 	nodeDeleteDelayAfterTaint               = flag.Duration("node-delete-delay-after-taint", 5*time.Second, "How long to wait before deleting a node after tainting it")
@@ -211,14 +328,27 @@ var (
 			"--max-graceful-termination-sec flag should not be set when this flag is set. Not setting this flag will use unordered evictor by default."+
 			"Priority evictor reuses the concepts of drain logic in kubelet(https://github.com/kubernetes/enhancements/tree/master/keps/sig-node/2712-pod-priority-based-graceful-node-shutdown#migration-from-the-node-graceful-shutdown-feature)."+
 			"Eg. flag usage:  '10000:20,1000:100,0:60'")
+	drainByPodPriority                           = flag.Bool("drain-by-pod-priority", false, "If true, CA evicts pods from a drained node in the ascending order of their priorities and waits for pods of each priority to terminate before evicting pods of a higher priority. The termination grace period configured by --drain-priority-config or --max-graceful-termination-sec for a range of priorities is split between the priorities of the pods in it.")
+	nodeGroupConfigCrdEnabled                    = flag.Bool("enable-node-group-config-crd", false, "Whether scale-down unneeded time, scale-down utilization threshold, scale-down soft taint only mode, node auto-repair and max node provision time can be overridden per node group with NodeGroupConfig CRs in the namespace passed via --namespace. Values from NodeGroupConfig CRs take precedence over values provided by the cloud provider.")
 	provisioningRequestsEnabled                  = flag.Bool("enable-provisioning-requests", false, "Whether the clusterautoscaler will be handling the ProvisioningRequest CRs.")
 	provisioningRequestInitialBackoffTime        = flag.Duration("provisioning-request-initial-backoff-time", 1*time.Minute, "Initial backoff time for ProvisioningRequest retry after failed ScaleUp.")
 	provisioningRequestMaxBackoffTime            = flag.Duration("provisioning-request-max-backoff-time", 10*time.Minute, "Max backoff time for ProvisioningRequest retry after failed ScaleUp.")
 	provisioningRequestMaxBackoffCacheSize       = flag.Int("provisioning-request-max-backoff-cache-size", 1000, "Max size for ProvisioningRequest cache size used for retry backoff mechanism.")
+	provisioningRequestMultiNodeGroupTimeout     = flag.Duration("provisioning-request-multi-node-group-scale-up-timeout", 15*time.Minute, "Time within which all node groups of a best-effort atomic ProvisioningRequest scale-up spread over multiple node groups have to provide the requested capacity, before the scale-up is reverted.")
 	frequentLoopsEnabled                         = flag.Bool("frequent-loops-enabled", false, "Whether clusterautoscaler triggers new iterations more frequently when it's needed")
 	asyncNodeGroupsEnabled                       = flag.Bool("async-node-groups", false, "Whether clusterautoscaler creates and deletes node groups asynchronously. Experimental: requires cloud provider supporting async node group operations, enable at your own risk.")
+	nodeAutoprovisioningEnabled                  = flag.Bool("node-autoprovisioning-enabled", false, "Should CA create new node groups, with machine types provided by the cloud provider, for pods that don't fit on nodes of any existing node group, and delete them once they are empty. Requires cloud provider support.")
+	maxAutoprovisionedNodeGroupCount             = flag.Int("max-autoprovisioned-node-group-count", 15, "The maximum number of autoprovisioned node groups in the cluster.")
+	awsAutoprovisioningInstanceTypes             = pflag.StringSlice("aws-autoprovisioning-instance-types", []string{}, "Comma separated list of EC2 instance types autoprovisioned ASGs can use. Requires --node-autoprovisioning-enabled. AWS only")
+	awsAutoprovisioningLaunchTemplate            = flag.String("aws-autoprovisioning-launch-template", "", "Name of the launch template used by autoprovisioned ASGs, its instance type is overridden. AWS only")
+	awsAutoprovisioningSubnets                   = pflag.StringSlice("aws-autoprovisioning-subnets", []string{}, "Comma separated list of ids of subnets autoprovisioned ASGs launch instances in. AWS only")
+	awsAutoprovisioningMaxSize                   = flag.Int("aws-autoprovisioning-max-size", 100, "Maximum size of autoprovisioned ASGs. AWS only")
+	awsSpotInterruptionQueueURL                  = flag.String("aws-spot-interruption-queue-url", "", "URL of the SQS queue EventBridge delivers EC2 Spot Instance Interruption Warnings to. Requires --spot-interruption-handling-enabled. AWS only")
+	awsOrphanedInstanceGCAge                     = flag.Duration("aws-orphaned-instance-gc-age", 0, "Minimum age of instances launched by registered ASGs for the cluster, which are no longer associated with them and never registered as nodes, and minimum time they have to fail their EC2 status checks, before they're terminated. Requires --cluster-name. 0 disables terminating them. AWS only")
+	awsMaxPodsMode                               = flag.String("aws-max-pods-mode", "default", "Networking mode the max pods of nodes are inferred from for scale-up from 0 nodes: 'default' for the default 110 pods, 'vpc-cni' for the ENI limits of the instance type with the Amazon VPC CNI plugin, 'vpc-cni-prefix-delegation' for those limits with prefix delegation enabled. The k8s.io/cluster-autoscaler/node-template/resources/pods ASG tag takes precedence. AWS only")
 	proactiveScaleupEnabled                      = flag.Bool("enable-proactive-scaleup", false, "Whether to enable/disable proactive scale-ups, defaults to false")
 	podInjectionLimit                            = flag.Int("pod-injection-limit", 5000, "Limits total number of pods while injecting fake pods. If unschedulable pods already exceeds the limit, pod injection is disabled but pods are not truncated.")
+	capacityBuffersEnabled                       = flag.Bool("capacity-buffers-enabled", false, "Should CA keep the headroom declared in CapacityBuffer objects of all namespaces, by injecting virtual pods of their shape into the simulation. Requires the CapacityBuffer CRD to be installed.")
 	checkCapacityBatchProcessing                 = flag.Bool("check-capacity-batch-processing", false, "Whether to enable batch processing for check capacity requests.")
 	checkCapacityProvisioningRequestMaxBatchSize = flag.Int("check-capacity-provisioning-request-max-batch-size", 10, "Maximum number of provisioning requests to process in a single batch.")
 	checkCapacityProvisioningRequestBatchTimebox = flag.Duration("check-capacity-provisioning-request-batch-timebox", 10*time.Second, "Maximum time to process a batch of provisioning requests.")
@@ -260,15 +390,74 @@ func createAutoscalingOptions() config.AutoscalingOptions {
 		klog.Fatalf("Failed to parse flags: %v", err)
 	}
 
+	parsedNodeDeletionQuotas, err := parseMultipleNodeDeletionQuotas(*nodeDeletionQuotas)
+	if err != nil {
+		klog.Fatalf("Failed to parse flags: %v", err)
+	}
+
+	parsedNamespaceEvictionLimits, err := parseNamespaceEvictionLimits(*namespaceEvictionLimits)
+	if err != nil {
+		klog.Fatalf("Failed to parse flags: %v", err)
+	}
+
+	parsedNodeGroupHourlyPrices, err := parseNodeGroupHourlyPrices(*nodeGroupHourlyPrices)
+	if err != nil {
+		klog.Fatalf("Failed to parse flags: %v", err)
+	}
+	if *clusterCostBudgetMode != costbudget.HardMode && *clusterCostBudgetMode != costbudget.SoftMode {
+		klog.Fatalf("Failed to parse flags: unknown cluster cost budget mode %q", *clusterCostBudgetMode)
+	}
+
+	if *preDeleteHook != "" && *preDeleteHook != predelete.AnnotationMode && *preDeleteHook != predelete.NodeDeletionHookMode {
+		klog.Fatalf("Failed to parse flags: unknown pre-delete hook %q", *preDeleteHook)
+	}
+	if *preDeleteHook != "" && *preDeleteHookTimeout <= 0 {
+		klog.Fatalf("Failed to parse flags: --pre-delete-hook-timeout must be greater than 0")
+	}
+
 	var parsedSchedConfig *scheduler_config.KubeSchedulerConfiguration
 	// if scheduler config flag was set by the user
 	if pflag.CommandLine.Changed(config.SchedulerConfigFileFlag) {
 		parsedSchedConfig, err = scheduler_util.ConfigFromPath(*schedulerConfigFile)
 	}
+	if err == nil && len(*additionalSchedulerConfigFiles) > 0 {
+		parsedSchedConfig, err = scheduler_util.AddProfilesFromPaths(parsedSchedConfig, *additionalSchedulerConfigFiles)
+	}
 	if err != nil {
 		klog.Fatalf("Failed to get scheduler config: %v", err)
 	}
 
+	parsedScaleDownUtilizationExtendedResources, err := parseResourceNames(*scaleDownUtilizationExtendedResources)
+	if err != nil {
+		klog.Fatalf("Failed to parse flags: %v", err)
+	}
+
+	if _, err := utilization.ParseSource(*scaleDownUtilizationSource); err != nil {
+		klog.Fatalf("Failed to parse flags: %v", err)
+	}
+
+	if *leaderStateHandoffEnabled && !*writeStatusObjectFlag {
+		klog.Fatalf("Invalid configuration, --leader-state-handoff-enabled requires --write-status-object")
+	}
+	if *scaleUpSimulationEnabled && *scaleUpSimulationBasicAuthFile == "" {
+		klog.Fatalf("Invalid configuration, --scale-up-simulation-enabled requires --scale-up-simulation-basic-auth-file")
+	}
+	if *webUIEnabled && *webUIBasicAuthFile == "" {
+		klog.Fatalf("Invalid configuration, --web-ui-enabled requires --web-ui-basic-auth-file")
+	}
+
+	if *scalingDecisionHistoryMaxCount < 1 || *scalingDecisionHistoryMaxAge <= 0 {
+		klog.Fatalf("Failed to parse flags: --scaling-decision-history-max-count and --scaling-decision-history-max-age must be positive, got %v and %v", *scalingDecisionHistoryMaxCount, *scalingDecisionHistoryMaxAge)
+	}
+
+	if *tracingSamplingRatePerMillion < 0 || *tracingSamplingRatePerMillion > 1000000 {
+		klog.Fatalf("Failed to parse flags: --tracing-sampling-rate-per-million must be between 0 and 1000000, got %v", *tracingSamplingRatePerMillion)
+	}
+
+	if *predictiveScaleUpConfidenceThreshold <= 0 || *predictiveScaleUpConfidenceThreshold > 1 {
+		klog.Fatalf("Failed to parse flags: --predictive-scale-up-confidence-threshold must be greater than 0 and at most 1, got %v", *predictiveScaleUpConfidenceThreshold)
+	}
+
 	if isFlagPassed("drain-priority-config") && isFlagPassed("max-graceful-termination-sec") {
 		klog.Fatalf("Invalid configuration, could not use --drain-priority-config together with --max-graceful-termination-sec")
 	}
@@ -281,7 +470,7 @@ func createAutoscalingOptions() config.AutoscalingOptions {
 		}
 	}
 
-	return config.AutoscalingOptions{
+	options := config.AutoscalingOptions{
 		NodeGroupDefaults: config.NodeGroupAutoscalingOptions{
 			ScaleDownUtilizationThreshold:    *scaleDownUtilizationThreshold,
 			ScaleDownGpuUtilizationThreshold: *scaleDownGpuUtilizationThreshold,
@@ -289,55 +478,90 @@ func createAutoscalingOptions() config.AutoscalingOptions {
 			ScaleDownUnreadyTime:             *scaleDownUnreadyTime,
 			IgnoreDaemonSetsUtilization:      *ignoreDaemonSetsUtilization,
 			MaxNodeProvisionTime:             *maxNodeProvisionTime,
+			NodeAutoRepairEnabled:            *nodeAutoRepairEnabled,
 		},
-		CloudConfig:                      *cloudConfig,
-		CloudProviderName:                *cloudProviderFlag,
-		NodeGroupAutoDiscovery:           *nodeGroupAutoDiscoveryFlag,
-		MaxTotalUnreadyPercentage:        *maxTotalUnreadyPercentage,
-		OkTotalUnreadyCount:              *okTotalUnreadyCount,
-		ScaleUpFromZero:                  *scaleUpFromZero,
-		ParallelScaleUp:                  *parallelScaleUp,
-		EstimatorName:                    *estimatorFlag,
-		ExpanderNames:                    *expanderFlag,
-		GRPCExpanderCert:                 *grpcExpanderCert,
-		GRPCExpanderURL:                  *grpcExpanderURL,
-		IgnoreMirrorPodsUtilization:      *ignoreMirrorPodsUtilization,
-		MaxBulkSoftTaintCount:            *maxBulkSoftTaintCount,
-		MaxBulkSoftTaintTime:             *maxBulkSoftTaintTime,
-		MaxGracefulTerminationSec:        *maxGracefulTerminationFlag,
-		MaxPodEvictionTime:               *maxPodEvictionTime,
-		MaxNodesTotal:                    *maxNodesTotal,
-		MaxCoresTotal:                    maxCoresTotal,
-		MinCoresTotal:                    minCoresTotal,
-		MaxMemoryTotal:                   maxMemoryTotal,
-		MinMemoryTotal:                   minMemoryTotal,
-		GpuTotal:                         parsedGpuTotal,
-		NodeGroups:                       *nodeGroupsFlag,
-		EnforceNodeGroupMinSize:          *enforceNodeGroupMinSize,
-		ScaleDownDelayAfterAdd:           *scaleDownDelayAfterAdd,
-		ScaleDownDelayTypeLocal:          *scaleDownDelayTypeLocal,
-		ScaleDownDelayAfterDelete:        *scaleDownDelayAfterDelete,
-		ScaleDownDelayAfterFailure:       *scaleDownDelayAfterFailure,
-		ScaleDownEnabled:                 *scaleDownEnabled,
-		ScaleDownUnreadyEnabled:          *scaleDownUnreadyEnabled,
-		ScaleDownNonEmptyCandidatesCount: *scaleDownNonEmptyCandidatesCount,
-		ScaleDownCandidatesPoolRatio:     *scaleDownCandidatesPoolRatio,
-		ScaleDownCandidatesPoolMinCount:  *scaleDownCandidatesPoolMinCount,
-		DrainPriorityConfig:              drainPriorityConfigMap,
-		SchedulerConfig:                  parsedSchedConfig,
-		WriteStatusConfigMap:             *writeStatusConfigMapFlag,
-		StatusConfigMapName:              *statusConfigMapName,
-		BalanceSimilarNodeGroups:         *balanceSimilarNodeGroupsFlag,
-		ConfigNamespace:                  *namespace,
-		ClusterName:                      *clusterName,
-		UnremovableNodeRecheckTimeout:    *unremovableNodeRecheckTimeout,
-		ExpendablePodsPriorityCutoff:     *expendablePodsPriorityCutoff,
-		Regional:                         *regional,
-		NewPodScaleUpDelay:               *newPodScaleUpDelay,
-		StartupTaints:                    append(*ignoreTaintsFlag, *startupTaintsFlag...),
-		StatusTaints:                     *statusTaintsFlag,
-		BalancingExtraIgnoredLabels:      *balancingIgnoreLabelsFlag,
-		BalancingLabels:                  *balancingLabelsFlag,
+		CloudConfig:                         *cloudConfig,
+		CloudProviderName:                   *cloudProviderFlag,
+		NodeGroupAutoDiscovery:              *nodeGroupAutoDiscoveryFlag,
+		MaxTotalUnreadyPercentage:           *maxTotalUnreadyPercentage,
+		OkTotalUnreadyCount:                 *okTotalUnreadyCount,
+		ScaleUpFromZero:                     *scaleUpFromZero,
+		ParallelScaleUp:                     *parallelScaleUp,
+		EstimatorName:                       *estimatorFlag,
+		EstimatorGpuPodOrdering:             *estimatorGpuPodOrdering,
+		ExpanderNames:                       *expanderFlag,
+		GRPCExpanderCert:                    *grpcExpanderCert,
+		GRPCExpanderURL:                     *grpcExpanderURL,
+		ExpanderPlugins:                     *expanderPluginsFlag,
+		DrainabilityWebhookURL:              *drainabilityWebhookURL,
+		DrainabilityWebhookCACert:           *drainabilityWebhookCACert,
+		DrainabilityWebhookTimeout:          *drainabilityWebhookTimeout,
+		DrainabilityWebhookFailurePolicy:    *drainabilityWebhookFailurePolicy,
+		IgnoreMirrorPodsUtilization:         *ignoreMirrorPodsUtilization,
+		MaxBulkSoftTaintCount:               *maxBulkSoftTaintCount,
+		MaxBulkSoftTaintTime:                *maxBulkSoftTaintTime,
+		MaxGracefulTerminationSec:           *maxGracefulTerminationFlag,
+		MaxPodEvictionTime:                  *maxPodEvictionTime,
+		ForceDeletePodsAfter:                *forceDeletePodsAfter,
+		MaxNodesTotal:                       *maxNodesTotal,
+		MaxClusterHourlyCost:                *maxClusterHourlyCost,
+		ClusterCostBudgetMode:               *clusterCostBudgetMode,
+		NodeGroupHourlyPrices:               parsedNodeGroupHourlyPrices,
+		CostMetricsEnabled:                  *costMetricsEnabled,
+		MaxCoresTotal:                       maxCoresTotal,
+		MinCoresTotal:                       minCoresTotal,
+		MaxMemoryTotal:                      maxMemoryTotal,
+		MinMemoryTotal:                      minMemoryTotal,
+		GpuTotal:                            parsedGpuTotal,
+		NodeGroups:                          *nodeGroupsFlag,
+		EnforceNodeGroupMinSize:             *enforceNodeGroupMinSize,
+		ScaleDownDelayAfterAdd:              *scaleDownDelayAfterAdd,
+		ScaleDownDelayTypeLocal:             *scaleDownDelayTypeLocal,
+		ScaleDownDelayAfterDelete:           *scaleDownDelayAfterDelete,
+		ScaleDownDelayAfterFailure:          *scaleDownDelayAfterFailure,
+		ScaleDownEnabled:                    *scaleDownEnabled,
+		ScaleDownDryRun:                     *scaleDownDryRun,
+		ScaleDownDryRunReportFile:           *scaleDownDryRunReportFile,
+		ScaleDownDryRunReportInterval:       *scaleDownDryRunReportInterval,
+		ScaleDownUnreadyEnabled:             *scaleDownUnreadyEnabled,
+		ScaleDownNonEmptyCandidatesCount:    *scaleDownNonEmptyCandidatesCount,
+		ScaleDownCandidatesPoolRatio:        *scaleDownCandidatesPoolRatio,
+		ScaleDownCandidatesPoolMinCount:     *scaleDownCandidatesPoolMinCount,
+		ScaleDownSortingStrategies:          *scaleDownSortingStrategies,
+		ScaleDownNodeGroupPriorityConfigMap: *scaleDownNodeGroupPriorityConfigMap,
+		ScalingScheduleConfigMap:            *scalingScheduleConfigMap,
+		ScaleDownConsolidationEnabled:       *scaleDownConsolidationEnabled,
+		MaxNodesPerConsolidation:            *maxNodesPerConsolidation,
+		SpotInterruptionHandlingEnabled:     *spotInterruptionHandlingEnabled,
+		SpotInterruptionPreScaleEnabled:     *spotInterruptionPreScaleEnabled,
+		NodeAutoRepairNotReadyTime:          *nodeAutoRepairNotReadyTime,
+		MaxNodeAutoRepairsPerHour:           *maxNodeAutoRepairsPerHour,
+		DrainPriorityConfig:                 drainPriorityConfigMap,
+		DrainByPodPriority:                  *drainByPodPriority,
+		NamespaceEvictionLimits:             parsedNamespaceEvictionLimits,
+		SchedulerConfig:                     parsedSchedConfig,
+		WriteStatusConfigMap:                *writeStatusConfigMapFlag,
+		StatusConfigMapName:                 *statusConfigMapName,
+		WriteStatusObject:                   *writeStatusObjectFlag,
+		StatusObjectName:                    *statusObjectName,
+		LeaderStateHandoffEnabled:           *leaderStateHandoffEnabled,
+		ScaleUpIntentsEnabled:               *scaleUpIntentsEnabled,
+		OptionsConfigMapName:                *optionsConfigMapName,
+		BalanceSimilarNodeGroups:            *balanceSimilarNodeGroupsFlag,
+		ConfigNamespace:                     *namespace,
+		ClusterName:                         *clusterName,
+		UnremovableNodeRecheckTimeout:       *unremovableNodeRecheckTimeout,
+		ScaleDownPdbLookaheadWaves:          *scaleDownPdbLookaheadWaves,
+		ScaleDownSimulationCacheEnabled:     *scaleDownSimulationCacheEnabled,
+		ExpendablePodsPriorityCutoff:        *expendablePodsPriorityCutoff,
+		Regional:                            *regional,
+		NewPodScaleUpDelay:                  *newPodScaleUpDelay,
+		StartupTaints:                       append(*ignoreTaintsFlag, *startupTaintsFlag...),
+		StatusTaints:                        *statusTaintsFlag,
+		BalancingExtraIgnoredLabels:         *balancingIgnoreLabelsFlag,
+		BalancingLabels:                     *balancingLabelsFlag,
+		BalancingRequiredLabels:             *balancingRequireLabelsFlag,
+		BalancingInstanceFamilyMatch:        *balancingInstanceFamilyMatch,
 		KubeClientOpts: config.KubeClientOptions{
 			Master:          *kubernetes,
 			KubeConfigPath:  *kubeConfigFile,
@@ -346,35 +570,57 @@ func createAutoscalingOptions() config.AutoscalingOptions {
 			KubeClientQPS:   float32(*kubeClientQPS),
 		},
 		NodeDeletionDelayTimeout: *nodeDeletionDelayTimeout,
+		PreDeleteHook:            *preDeleteHook,
+		PreDeleteHookTimeout:     *preDeleteHookTimeout,
 		AWSUseStaticInstanceList: *awsUseStaticInstanceList,
+		AWSOptions: config.AWSOptions{
+			AutoprovisioningInstanceTypes:  *awsAutoprovisioningInstanceTypes,
+			AutoprovisioningLaunchTemplate: *awsAutoprovisioningLaunchTemplate,
+			AutoprovisioningSubnets:        *awsAutoprovisioningSubnets,
+			AutoprovisioningMaxSize:        *awsAutoprovisioningMaxSize,
+			SpotInterruptionQueueURL:       *awsSpotInterruptionQueueURL,
+			OrphanedInstanceGCAge:          *awsOrphanedInstanceGCAge,
+			MaxPodsMode:                    *awsMaxPodsMode,
+		},
 		GCEOptions: config.GCEOptions{
 			ConcurrentRefreshes:            *concurrentGceRefreshes,
 			MigInstancesMinRefreshWaitTime: *gceMigInstancesMinRefreshWaitTime,
 			LocalSSDDiskSizeProvider:       localssdsize.NewSimpleLocalSSDProvider(),
 			BulkMigInstancesListingEnabled: *bulkGceMigInstancesListingEnabled,
 		},
-		ClusterAPICloudConfigAuthoritative: *clusterAPICloudConfigAuthoritative,
-		CordonNodeBeforeTerminate:          *cordonNodeBeforeTerminate,
-		DaemonSetEvictionForEmptyNodes:     *daemonSetEvictionForEmptyNodes,
-		DaemonSetEvictionForOccupiedNodes:  *daemonSetEvictionForOccupiedNodes,
-		UserAgent:                          *userAgent,
-		InitialNodeGroupBackoffDuration:    *initialNodeGroupBackoffDuration,
-		MaxNodeGroupBackoffDuration:        *maxNodeGroupBackoffDuration,
-		NodeGroupBackoffResetTimeout:       *nodeGroupBackoffResetTimeout,
-		MaxScaleDownParallelism:            *maxScaleDownParallelismFlag,
-		MaxDrainParallelism:                *maxDrainParallelismFlag,
-		RecordDuplicatedEvents:             *recordDuplicatedEvents,
-		MaxNodesPerScaleUp:                 *maxNodesPerScaleUp,
-		MaxNodeGroupBinpackingDuration:     *maxNodeGroupBinpackingDuration,
-		MaxBinpackingTime:                  *maxBinpackingTimeFlag,
-		NodeDeletionBatcherInterval:        *nodeDeletionBatcherInterval,
-		SkipNodesWithSystemPods:            *skipNodesWithSystemPods,
-		SkipNodesWithLocalStorage:          *skipNodesWithLocalStorage,
-		MinReplicaCount:                    *minReplicaCount,
-		BspDisruptionTimeout:               *bspDisruptionTimeout,
-		NodeDeleteDelayAfterTaint:          *nodeDeleteDelayAfterTaint,
-		ScaleDownSimulationTimeout:         *scaleDownSimulationTimeout,
-		SkipNodesWithCustomControllerPods:  *skipNodesWithCustomControllerPods,
+		ClusterAPICloudConfigAuthoritative:    *clusterAPICloudConfigAuthoritative,
+		CordonNodeBeforeTerminate:             *cordonNodeBeforeTerminate,
+		DaemonSetEvictionForEmptyNodes:        *daemonSetEvictionForEmptyNodes,
+		DaemonSetEvictionForOccupiedNodes:     *daemonSetEvictionForOccupiedNodes,
+		UserAgent:                             *userAgent,
+		InitialNodeGroupBackoffDuration:       *initialNodeGroupBackoffDuration,
+		MaxNodeGroupBackoffDuration:           *maxNodeGroupBackoffDuration,
+		NodeGroupBackoffResetTimeout:          *nodeGroupBackoffResetTimeout,
+		NodeGroupBackoffPolicy:                *nodeGroupBackoffPolicy,
+		NodeGroupHealthWindow:                 *nodeGroupHealthWindow,
+		NodeGroupHealthTargetReadinessLatency: *nodeGroupHealthTargetReadinessLatency,
+		ZonalStockoutCooldown:                 *zonalStockoutCooldown,
+		MaxScaleDownParallelism:               *maxScaleDownParallelismFlag,
+		MaxDrainParallelism:                   *maxDrainParallelismFlag,
+		RecordDuplicatedEvents:                *recordDuplicatedEvents,
+		MaxNodesPerScaleUp:                    *maxNodesPerScaleUp,
+		MaxNodesPerScaleUpPerOwner:            *maxNodesPerScaleUpPerOwner,
+		MaxNodeGroupBinpackingDuration:        *maxNodeGroupBinpackingDuration,
+		BinpackingParallelism:                 *binpackingParallelism,
+		ScaleUpForUnsatisfiableTopologySpread: *scaleUpForUnsatisfiableTopologySpread,
+		ScaleUpForVolumeTopology:              *scaleUpForVolumeTopology,
+		SubnetCapacityCheckEnabled:            *subnetCapacityCheckEnabled,
+		MaxBinpackingTime:                     *maxBinpackingTimeFlag,
+		NodeDeletionBatcherInterval:           *nodeDeletionBatcherInterval,
+		NodeDeletionQuotas:                    parsedNodeDeletionQuotas,
+		SkipNodesWithSystemPods:               *skipNodesWithSystemPods,
+		SkipNodesWithLocalStorage:             *skipNodesWithLocalStorage,
+		SkipNodesWithUnattachableVolumes:      *skipNodesWithUnattachableVolumes,
+		MinReplicaCount:                       *minReplicaCount,
+		BspDisruptionTimeout:                  *bspDisruptionTimeout,
+		NodeDeleteDelayAfterTaint:             *nodeDeleteDelayAfterTaint,
+		ScaleDownSimulationTimeout:            *scaleDownSimulationTimeout,
+		SkipNodesWithCustomControllerPods:     *skipNodesWithCustomControllerPods,
 		NodeGroupSetRatios: config.NodeGroupDifferenceRatios{
 			MaxCapacityMemoryDifferenceRatio: *maxCapacityMemoryDifferenceRatio,
 			MaxAllocatableDifferenceRatio:    *maxAllocatableDifferenceRatio,
@@ -382,11 +628,15 @@ func createAutoscalingOptions() config.AutoscalingOptions {
 		},
 		DynamicNodeDeleteDelayAfterTaintEnabled:      *dynamicNodeDeleteDelayAfterTaintEnabled,
 		BypassedSchedulers:                           scheduler_util.GetBypassedSchedulersMap(*bypassedSchedulers),
+		NodeGroupConfigCrdEnabled:                    *nodeGroupConfigCrdEnabled,
 		ProvisioningRequestEnabled:                   *provisioningRequestsEnabled,
 		AsyncNodeGroupsEnabled:                       *asyncNodeGroupsEnabled,
+		NodeAutoprovisioningEnabled:                  *nodeAutoprovisioningEnabled,
+		MaxAutoprovisionedNodeGroupCount:             *maxAutoprovisionedNodeGroupCount,
 		ProvisioningRequestInitialBackoffTime:        *provisioningRequestInitialBackoffTime,
 		ProvisioningRequestMaxBackoffTime:            *provisioningRequestMaxBackoffTime,
 		ProvisioningRequestMaxBackoffCacheSize:       *provisioningRequestMaxBackoffCacheSize,
+		ProvisioningRequestMultiNodeGroupTimeout:     *provisioningRequestMultiNodeGroupTimeout,
 		CheckCapacityBatchProcessing:                 *checkCapacityBatchProcessing,
 		CheckCapacityProvisioningRequestMaxBatchSize: *checkCapacityProvisioningRequestMaxBatchSize,
 		CheckCapacityProvisioningRequestBatchTimebox: *checkCapacityProvisioningRequestBatchTimebox,
@@ -406,7 +656,39 @@ func createAutoscalingOptions() config.AutoscalingOptions {
 		NodeInfoCacheExpireTime:                      *nodeInfoCacheExpireTime,
 		ProactiveScaleupEnabled:                      *proactiveScaleupEnabled,
 		PodInjectionLimit:                            *podInjectionLimit,
+		CapacityBuffersEnabled:                       *capacityBuffersEnabled,
+		ScaleDownUtilizationExtendedResources:        parsedScaleDownUtilizationExtendedResources,
+		ScaleDownUtilizationSource:                   *scaleDownUtilizationSource,
+		ScaleDownUsagePrometheusAddress:              *scaleDownUsagePrometheusAddress,
+		ScaleDownUtilizationBreakdownPods:            *scaleDownUtilizationBreakdownPods,
+		ScaleDownIncrementalEligibilityEnabled:       *scaleDownIncrementalEligibilityEnabled,
+		PredictiveScaleUpEnabled:                     *predictiveScaleUpEnabled,
+		PredictiveScaleUpConfidenceThreshold:         *predictiveScaleUpConfidenceThreshold,
+		PredictiveScaleUpMaxNodes:                    *predictiveScaleUpMaxNodes,
+		PredictiveScaleUpLeadTime:                    *predictiveScaleUpLeadTime,
+		GRPCExpanderClientCert:                       *grpcExpanderClientCert,
+		GRPCExpanderClientKey:                        *grpcExpanderClientKey,
+		PriceLiveCacheTTL:                            *priceLiveCacheTTL,
+		PriorityExpanderTieBreaker:                   *priorityTieBreaker,
+		ScaleUpSimulationEnabled:                     *scaleUpSimulationEnabled,
+		ScaleUpSimulationBasicAuthFile:               *scaleUpSimulationBasicAuthFile,
+		DecisionLoggingEnabled:                       *decisionLoggingEnabled,
+		ScalingDecisionHistoryEnabled:                *scalingDecisionHistoryEnabled,
+		ScalingDecisionHistoryMaxCount:               *scalingDecisionHistoryMaxCount,
+		ScalingDecisionHistoryMaxAge:                 *scalingDecisionHistoryMaxAge,
+		NodeGroupLimitEventsEnabled:                  *nodeGroupLimitEventsEnabled,
+		WebUIEnabled:                                 *webUIEnabled,
+		WebUIBasicAuthFile:                           *webUIBasicAuthFile,
+		TracingEndpoint:                              *tracingEndpoint,
+		TracingSamplingRatePerMillion:                int32(*tracingSamplingRatePerMillion),
+		AutoscalingPauseEnabled:                      *autoscalingPauseEnabled,
+		MaxPauseDuration:                             *maxPauseDuration,
+		AutoscalingPauseBasicAuthFile:                *autoscalingPauseBasicAuthFile,
+	}
+	if err := config.ApplyProfile(&options, *profile, pflag.CommandLine.Changed); err != nil {
+		klog.Fatalf("Failed to parse flags: %v", err)
 	}
+	return options
 }
 
 func isFlagPassed(name string) bool {
@@ -457,6 +739,24 @@ func parseMinMaxFlag(flag string) (int64, int64, error) {
 	return min, max, nil
 }
 
+func parseResourceNames(flags MultiStringFlag) ([]apiv1.ResourceName, error) {
+	var resourceNames []apiv1.ResourceName
+	for _, flag := range flags {
+		name := apiv1.ResourceName(strings.TrimSpace(flag))
+		if name == "" {
+			continue
+		}
+		if name == apiv1.ResourceCPU || name == apiv1.ResourceMemory {
+			return nil, fmt.Errorf("%s utilization is always calculated and cannot be passed as an extended resource", name)
+		}
+		if name != apiv1.ResourceEphemeralStorage && !v1helper.IsHugePageResourceName(name) && !v1helper.IsExtendedResourceName(name) {
+			return nil, fmt.Errorf("unsupported extended resource: %s", name)
+		}
+		resourceNames = append(resourceNames, name)
+	}
+	return resourceNames, nil
+}
+
 func parseMultipleGpuLimits(flags MultiStringFlag) ([]config.GpuLimits, error) {
 	parsedFlags := make([]config.GpuLimits, 0, len(flags))
 	for _, flag := range flags {
@@ -500,6 +800,90 @@ func parseSingleGpuLimit(limits string) (config.GpuLimits, error) {
 	return parsedGpuLimits, nil
 }
 
+func parseMultipleNodeDeletionQuotas(flags MultiStringFlag) ([]config.NodeDeletionQuota, error) {
+	parsedFlags := make([]config.NodeDeletionQuota, 0, len(flags))
+	for _, flag := range flags {
+		parsedFlag, err := parseSingleNodeDeletionQuota(flag)
+		if err != nil {
+			return nil, err
+		}
+		parsedFlags = append(parsedFlags, parsedFlag)
+	}
+	return parsedFlags, nil
+}
+
+func parseSingleNodeDeletionQuota(quota string) (config.NodeDeletionQuota, error) {
+	parts := strings.Split(quota, ":")
+	if len(parts) != 3 || parts[0] == "" {
+		return config.NodeDeletionQuota{}, fmt.Errorf("incorrect node deletion quota specification: %v", quota)
+	}
+	qps, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil {
+		return config.NodeDeletionQuota{}, fmt.Errorf("incorrect node deletion quota - qps is not a number: %v", quota)
+	}
+	maxBatchSize, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return config.NodeDeletionQuota{}, fmt.Errorf("incorrect node deletion quota - max batch size is not integer: %v", quota)
+	}
+	if qps < 0 {
+		return config.NodeDeletionQuota{}, fmt.Errorf("incorrect node deletion quota - qps is less than 0; %v", quota)
+	}
+	if maxBatchSize < 0 {
+		return config.NodeDeletionQuota{}, fmt.Errorf("incorrect node deletion quota - max batch size is less than 0; %v", quota)
+	}
+	return config.NodeDeletionQuota{
+		CloudProviderName: parts[0],
+		QPS:               qps,
+		MaxBatchSize:      maxBatchSize,
+	}, nil
+}
+
+func parseNamespaceEvictionLimits(flags MultiStringFlag) (map[string]int, error) {
+	parsedLimits := make(map[string]int, len(flags))
+	for _, flag := range flags {
+		parts := strings.Split(flag, ":")
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("incorrect namespace eviction limit specification: %v", flag)
+		}
+		limit, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("incorrect namespace eviction limit - limit is not integer: %v", flag)
+		}
+		if limit < 0 {
+			return nil, fmt.Errorf("incorrect namespace eviction limit - limit is less than 0; %v", flag)
+		}
+		if _, found := parsedLimits[parts[0]]; found {
+			return nil, fmt.Errorf("duplicate namespace eviction limit for namespace %s", parts[0])
+		}
+		parsedLimits[parts[0]] = limit
+	}
+	return parsedLimits, nil
+}
+
+func parseNodeGroupHourlyPrices(flags MultiStringFlag) (map[string]float64, error) {
+	parsedPrices := make(map[string]float64, len(flags))
+	for _, flag := range flags {
+		// Node group ids may contain colons, e.g. when they are URLs.
+		idx := strings.LastIndex(flag, ":")
+		if idx <= 0 {
+			return nil, fmt.Errorf("incorrect node group hourly price specification: %v", flag)
+		}
+		nodeGroupId := flag[:idx]
+		price, err := strconv.ParseFloat(flag[idx+1:], 64)
+		if err != nil {
+			return nil, fmt.Errorf("incorrect node group hourly price - price is not a number: %v", flag)
+		}
+		if price < 0 {
+			return nil, fmt.Errorf("incorrect node group hourly price - price is less than 0: %v", flag)
+		}
+		if _, found := parsedPrices[nodeGroupId]; found {
+			return nil, fmt.Errorf("duplicate hourly price for node group %s", nodeGroupId)
+		}
+		parsedPrices[nodeGroupId] = price
+	}
+	return parsedPrices, nil
+}
+
 // parseShutdownGracePeriodsAndPriorities parse priorityGracePeriodStr and returns an array of ShutdownGracePeriodByPodPriority if succeeded.
 // Otherwise, returns an empty list
 func parseShutdownGracePeriodsAndPriorities(priorityGracePeriodStr string) []kubelet_config.ShutdownGracePeriodByPodPriority {
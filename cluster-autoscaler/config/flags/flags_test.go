@@ -19,6 +19,7 @@ package flags
 import (
 	"testing"
 
+	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
 	kubelet_config "k8s.io/kubernetes/pkg/kubelet/apis/config"
 
@@ -93,6 +94,209 @@ func TestParseSingleGpuLimit(t *testing.T) {
 	}
 }
 
+func TestParseSingleNodeDeletionQuota(t *testing.T) {
+	testcases := []struct {
+		input                string
+		expectedQuota        config.NodeDeletionQuota
+		expectedErrorMessage string
+	}{
+		{
+			input:         "aws:2.5:50",
+			expectedQuota: config.NodeDeletionQuota{CloudProviderName: "aws", QPS: 2.5, MaxBatchSize: 50},
+		},
+		{
+			input:         "gce:0:0",
+			expectedQuota: config.NodeDeletionQuota{CloudProviderName: "gce"},
+		},
+		{
+			input:                "aws:1",
+			expectedErrorMessage: "incorrect node deletion quota specification: aws:1",
+		},
+		{
+			input:                ":1:10",
+			expectedErrorMessage: "incorrect node deletion quota specification: :1:10",
+		},
+		{
+			input:                "aws:x:10",
+			expectedErrorMessage: "incorrect node deletion quota - qps is not a number: aws:x:10",
+		},
+		{
+			input:                "aws:1:1.5",
+			expectedErrorMessage: "incorrect node deletion quota - max batch size is not integer: aws:1:1.5",
+		},
+		{
+			input:                "aws:-1:10",
+			expectedErrorMessage: "incorrect node deletion quota - qps is less than 0; aws:-1:10",
+		},
+		{
+			input:                "aws:1:-10",
+			expectedErrorMessage: "incorrect node deletion quota - max batch size is less than 0; aws:1:-10",
+		},
+	}
+
+	for _, testcase := range testcases {
+		quota, err := parseSingleNodeDeletionQuota(testcase.input)
+		if testcase.expectedErrorMessage != "" {
+			if assert.Error(t, err) {
+				assert.Equal(t, testcase.expectedErrorMessage, err.Error())
+			}
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, testcase.expectedQuota, quota)
+		}
+	}
+}
+
+func TestParseNamespaceEvictionLimits(t *testing.T) {
+	testcases := []struct {
+		input                MultiStringFlag
+		expectedLimits       map[string]int
+		expectedErrorMessage string
+	}{
+		{
+			input:          MultiStringFlag{},
+			expectedLimits: map[string]int{},
+		},
+		{
+			input:          MultiStringFlag{"*:10", "kube-system:0", "tenant:5"},
+			expectedLimits: map[string]int{"*": 10, "kube-system": 0, "tenant": 5},
+		},
+		{
+			input:                MultiStringFlag{"tenant"},
+			expectedErrorMessage: "incorrect namespace eviction limit specification: tenant",
+		},
+		{
+			input:                MultiStringFlag{":5"},
+			expectedErrorMessage: "incorrect namespace eviction limit specification: :5",
+		},
+		{
+			input:                MultiStringFlag{"tenant:x"},
+			expectedErrorMessage: "incorrect namespace eviction limit - limit is not integer: tenant:x",
+		},
+		{
+			input:                MultiStringFlag{"tenant:-1"},
+			expectedErrorMessage: "incorrect namespace eviction limit - limit is less than 0; tenant:-1",
+		},
+		{
+			input:                MultiStringFlag{"tenant:1", "tenant:2"},
+			expectedErrorMessage: "duplicate namespace eviction limit for namespace tenant",
+		},
+	}
+
+	for _, testcase := range testcases {
+		limits, err := parseNamespaceEvictionLimits(testcase.input)
+		if testcase.expectedErrorMessage != "" {
+			if assert.Error(t, err) {
+				assert.Equal(t, testcase.expectedErrorMessage, err.Error())
+			}
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, testcase.expectedLimits, limits)
+		}
+	}
+}
+
+func TestParseNodeGroupHourlyPrices(t *testing.T) {
+	testcases := []struct {
+		input                MultiStringFlag
+		expectedPrices       map[string]float64
+		expectedErrorMessage string
+	}{
+		{
+			input:          MultiStringFlag{},
+			expectedPrices: map[string]float64{},
+		},
+		{
+			input:          MultiStringFlag{"ng1:0.5", "https://example.com/ng2:1.25"},
+			expectedPrices: map[string]float64{"ng1": 0.5, "https://example.com/ng2": 1.25},
+		},
+		{
+			input:                MultiStringFlag{"ng1"},
+			expectedErrorMessage: "incorrect node group hourly price specification: ng1",
+		},
+		{
+			input:                MultiStringFlag{":1"},
+			expectedErrorMessage: "incorrect node group hourly price specification: :1",
+		},
+		{
+			input:                MultiStringFlag{"ng1:x"},
+			expectedErrorMessage: "incorrect node group hourly price - price is not a number: ng1:x",
+		},
+		{
+			input:                MultiStringFlag{"ng1:-1"},
+			expectedErrorMessage: "incorrect node group hourly price - price is less than 0: ng1:-1",
+		},
+		{
+			input:                MultiStringFlag{"ng1:1", "ng1:2"},
+			expectedErrorMessage: "duplicate hourly price for node group ng1",
+		},
+	}
+
+	for _, testcase := range testcases {
+		prices, err := parseNodeGroupHourlyPrices(testcase.input)
+		if testcase.expectedErrorMessage != "" {
+			if assert.Error(t, err) {
+				assert.Equal(t, testcase.expectedErrorMessage, err.Error())
+			}
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, testcase.expectedPrices, prices)
+		}
+	}
+}
+
+func TestParseResourceNames(t *testing.T) {
+	testCases := []struct {
+		name    string
+		input   MultiStringFlag
+		want    []apiv1.ResourceName
+		wantErr bool
+	}{
+		{
+			name:  "no flags",
+			input: nil,
+			want:  nil,
+		},
+		{
+			name:  "supported resources are trimmed",
+			input: MultiStringFlag{" hugepages-2Mi", "ephemeral-storage ", "example.com/foo"},
+			want:  []apiv1.ResourceName{"hugepages-2Mi", apiv1.ResourceEphemeralStorage, "example.com/foo"},
+		},
+		{
+			name:  "empty values are dropped",
+			input: MultiStringFlag{"", " ", "hugepages-1Gi"},
+			want:  []apiv1.ResourceName{"hugepages-1Gi"},
+		},
+		{
+			name:    "cpu is rejected",
+			input:   MultiStringFlag{"hugepages-2Mi", "cpu"},
+			wantErr: true,
+		},
+		{
+			name:    "memory is rejected",
+			input:   MultiStringFlag{"memory"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown native resource is rejected",
+			input:   MultiStringFlag{"hugepage-2Mi"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseResourceNames(tc.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
 func TestParseShutdownGracePeriodsAndPriorities(t *testing.T) {
 	testCases := []struct {
 		name  string
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// OptimizeUtilizationProfile removes underutilized nodes sooner and more aggressively, at the cost of
+	// more frequent scale-ups.
+	OptimizeUtilizationProfile = "optimize-utilization"
+	// BalancedProfile keeps the default behavior of the cluster autoscaler.
+	BalancedProfile = "balanced"
+	// OptimizeLatencyProfile reacts to unschedulable pods faster and keeps spare nodes around for longer,
+	// at the cost of more underutilized nodes.
+	OptimizeLatencyProfile = "optimize-latency"
+)
+
+// Profile is a bundle of coordinated defaults for options trading cost against latency.
+type Profile struct {
+	// ScanInterval is the default of ScanInterval.
+	ScanInterval time.Duration
+	// ScaleDownUtilizationThreshold is the default of NodeGroupDefaults.ScaleDownUtilizationThreshold.
+	ScaleDownUtilizationThreshold float64
+	// ScaleDownGpuUtilizationThreshold is the default of NodeGroupDefaults.ScaleDownGpuUtilizationThreshold.
+	ScaleDownGpuUtilizationThreshold float64
+	// ScaleDownUnneededTime is the default of NodeGroupDefaults.ScaleDownUnneededTime.
+	ScaleDownUnneededTime time.Duration
+	// ExpanderNames is the default of ExpanderNames.
+	ExpanderNames string
+}
+
+// Profiles are the available profiles by name.
+var Profiles = map[string]Profile{
+	OptimizeUtilizationProfile: {
+		ScanInterval:                     DefaultScanInterval,
+		ScaleDownUtilizationThreshold:    0.7,
+		ScaleDownGpuUtilizationThreshold: 0.7,
+		ScaleDownUnneededTime:            2 * time.Minute,
+		ExpanderNames:                    "least-waste",
+	},
+	BalancedProfile: {
+		ScanInterval:                     DefaultScanInterval,
+		ScaleDownUtilizationThreshold:    DefaultScaleDownUtilizationThreshold,
+		ScaleDownGpuUtilizationThreshold: DefaultScaleDownGpuUtilizationThreshold,
+		ScaleDownUnneededTime:            DefaultScaleDownUnneededTime,
+		ExpanderNames:                    "least-waste",
+	},
+	OptimizeLatencyProfile: {
+		ScanInterval:                     5 * time.Second,
+		ScaleDownUtilizationThreshold:    0.3,
+		ScaleDownGpuUtilizationThreshold: 0.3,
+		ScaleDownUnneededTime:            20 * time.Minute,
+		ExpanderNames:                    "most-pods,least-waste",
+	},
+}
+
+// ProfileNames returns the sorted names of the available profiles.
+func ProfileNames() []string {
+	names := make([]string, 0, len(Profiles))
+	for name := range Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ApplyProfile sets the options bundled in the named profile, except for the ones whose flags were set
+// explicitly, as reported by isFlagSet, so that individual flags always override the profile.
+func ApplyProfile(options *AutoscalingOptions, name string, isFlagSet func(flagName string) bool) error {
+	profile, found := Profiles[name]
+	if !found {
+		return fmt.Errorf("unknown profile %q, available profiles: %v", name, ProfileNames())
+	}
+	if !isFlagSet(ScanIntervalFlag) {
+		options.ScanInterval = profile.ScanInterval
+	}
+	if !isFlagSet(ScaleDownUtilizationThresholdFlag) {
+		options.NodeGroupDefaults.ScaleDownUtilizationThreshold = profile.ScaleDownUtilizationThreshold
+	}
+	if !isFlagSet(ScaleDownGpuUtilizationThresholdFlag) {
+		options.NodeGroupDefaults.ScaleDownGpuUtilizationThreshold = profile.ScaleDownGpuUtilizationThreshold
+	}
+	if !isFlagSet(ScaleDownUnneededTimeFlag) {
+		options.NodeGroupDefaults.ScaleDownUnneededTime = profile.ScaleDownUnneededTime
+	}
+	if !isFlagSet(ExpanderFlag) {
+		options.ExpanderNames = profile.ExpanderNames
+	}
+	options.Profile = name
+	return nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyProfile(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		profile    string
+		setFlags   []string
+		wantErr    bool
+		wantScan   time.Duration
+		wantUtil   float64
+		wantGpu    float64
+		wantTime   time.Duration
+		wantExpand string
+	}{
+		{
+			name:       "balanced profile keeps the defaults",
+			profile:    BalancedProfile,
+			wantScan:   DefaultScanInterval,
+			wantUtil:   DefaultScaleDownUtilizationThreshold,
+			wantGpu:    DefaultScaleDownGpuUtilizationThreshold,
+			wantTime:   DefaultScaleDownUnneededTime,
+			wantExpand: "least-waste",
+		},
+		{
+			name:       "optimize-utilization profile",
+			profile:    OptimizeUtilizationProfile,
+			wantScan:   DefaultScanInterval,
+			wantUtil:   0.7,
+			wantGpu:    0.7,
+			wantTime:   2 * time.Minute,
+			wantExpand: "least-waste",
+		},
+		{
+			name:       "optimize-latency profile",
+			profile:    OptimizeLatencyProfile,
+			wantScan:   5 * time.Second,
+			wantUtil:   0.3,
+			wantGpu:    0.3,
+			wantTime:   20 * time.Minute,
+			wantExpand: "most-pods,least-waste",
+		},
+		{
+			name:       "flags set explicitly override the profile",
+			profile:    OptimizeLatencyProfile,
+			setFlags:   []string{ScanIntervalFlag, ScaleDownUtilizationThresholdFlag, ExpanderFlag},
+			wantScan:   time.Minute,
+			wantUtil:   0.9,
+			wantGpu:    0.3,
+			wantTime:   20 * time.Minute,
+			wantExpand: "price",
+		},
+		{
+			name:    "unknown profile",
+			profile: "optimize-everything",
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			options := AutoscalingOptions{
+				ScanInterval:  time.Minute,
+				ExpanderNames: "price",
+				NodeGroupDefaults: NodeGroupAutoscalingOptions{
+					ScaleDownUtilizationThreshold:    0.9,
+					ScaleDownGpuUtilizationThreshold: 0.9,
+					ScaleDownUnneededTime:            time.Hour,
+				},
+			}
+			isFlagSet := func(flagName string) bool {
+				for _, name := range tc.setFlags {
+					if name == flagName {
+						return true
+					}
+				}
+				return false
+			}
+			err := ApplyProfile(&options, tc.profile, isFlagSet)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.profile, options.Profile)
+			assert.Equal(t, tc.wantScan, options.ScanInterval)
+			assert.Equal(t, tc.wantUtil, options.NodeGroupDefaults.ScaleDownUtilizationThreshold)
+			assert.Equal(t, tc.wantGpu, options.NodeGroupDefaults.ScaleDownGpuUtilizationThreshold)
+			assert.Equal(t, tc.wantTime, options.NodeGroupDefaults.ScaleDownUnneededTime)
+			assert.Equal(t, tc.wantExpand, options.ExpanderNames)
+		})
+	}
+}
@@ -17,19 +17,30 @@ limitations under the License.
 package core
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	cloudBuilder "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/builder"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/pause"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/config/dynamic"
 	"k8s.io/autoscaler/cluster-autoscaler/context"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/pdb"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/predelete"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup/dryrun"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleupforecast"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleupintent"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scalingschedule"
 	"k8s.io/autoscaler/cluster-autoscaler/debuggingsnapshot"
 	"k8s.io/autoscaler/cluster-autoscaler/estimator"
 	"k8s.io/autoscaler/cluster-autoscaler/expander"
 	"k8s.io/autoscaler/cluster-autoscaler/expander/factory"
+	"k8s.io/autoscaler/cluster-autoscaler/expander/goplugin"
+	"k8s.io/autoscaler/cluster-autoscaler/expander/health"
 	"k8s.io/autoscaler/cluster-autoscaler/observers/loopstart"
 	ca_processors "k8s.io/autoscaler/cluster-autoscaler/processors"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
@@ -39,10 +50,13 @@ import (
 	draprovider "k8s.io/autoscaler/cluster-autoscaler/simulator/dynamicresources/provider"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/options"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/utilization"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/backoff"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	"k8s.io/autoscaler/cluster-autoscaler/webui"
 	"k8s.io/client-go/informers"
 	kube_client "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
 )
 
 // AutoscalerOptions is the whole set of options for configuring an autoscaler
@@ -55,6 +69,7 @@ type AutoscalerOptions struct {
 	FrameworkHandle        *framework.Handle
 	ClusterSnapshot        clustersnapshot.ClusterSnapshot
 	ExpanderStrategy       expander.Strategy
+	ExpanderFactory        *factory.Factory
 	EstimatorBuilder       estimator.EstimatorBuilder
 	Processors             *ca_processors.AutoscalingProcessors
 	LoopStartNotifier      *loopstart.ObserversList
@@ -65,6 +80,26 @@ type AutoscalerOptions struct {
 	DeleteOptions          options.NodeDeleteOptions
 	DrainabilityRules      rules.Rules
 	DraProvider            *draprovider.Provider
+	UsageProvider          utilization.UsageProvider
+	// ScaleUpSimulationHandler, if set, gets its scale-up simulation requests processed by the autoscaler loop.
+	ScaleUpSimulationHandler *dryrun.Handler
+	// OptionsReloader, if set, provides options changed at runtime, applied at the beginning of each loop.
+	OptionsReloader *dynamic.OptionsReloader
+	// StatusObjectWriter, if set, writes the autoscaler status to a ClusterAutoscalerStatus object.
+	StatusObjectWriter *utils.StatusObjectWriter
+	// ScaleUpIntentStore, if set, persists scale-ups in progress as ScaleUpIntent objects.
+	ScaleUpIntentStore *scaleupintent.Store
+	// ScaleUpHistoryStore, if set, persists the scale-up history of node groups as ScaleUpHistory objects, used
+	// to scale them up ahead of predicted scale-ups.
+	ScaleUpHistoryStore *scaleupforecast.Store
+	// ScalingSchedule, if set, overrides the limits of node groups with scheduled scaling windows in each loop.
+	ScalingSchedule *scalingschedule.Schedule
+	// Pauses, if set, pause scale-up and scale-down cluster-wide or per node group.
+	Pauses *pause.Registry
+	// PreDeleteHook, if set, is run on nodes after they're drained and before they're deleted.
+	PreDeleteHook predelete.Hook
+	// WebUI, if set, is updated at the end of each loop with the data rendered by the web UI.
+	WebUI *webui.State
 }
 
 // Autoscaler is the main component of CA which scales up/down node groups according to its configuration
@@ -80,6 +115,8 @@ type Autoscaler interface {
 	LastScaleUpTime() time.Time
 	// LastScaleUpTime is a time of the last scale down
 	LastScaleDownDeleteTime() time.Time
+	// ScanInterval is how often the cluster should be reevaluated
+	ScanInterval() time.Duration
 }
 
 // NewAutoscaler creates an autoscaler of an appropriate type according to the parameters
@@ -105,6 +142,17 @@ func NewAutoscaler(opts AutoscalerOptions, informerFactory informers.SharedInfor
 		opts.DeleteOptions,
 		opts.DrainabilityRules,
 		opts.DraProvider,
+		opts.UsageProvider,
+		opts.ScaleUpSimulationHandler,
+		opts.OptionsReloader,
+		opts.ExpanderFactory,
+		opts.StatusObjectWriter,
+		opts.ScaleUpIntentStore,
+		opts.ScaleUpHistoryStore,
+		opts.ScalingSchedule,
+		opts.Pauses,
+		opts.PreDeleteHook,
+		opts.WebUI,
 	), nil
 }
 
@@ -135,10 +183,44 @@ func initializeDefaultOptions(opts *AutoscalerOptions, informerFactory informers
 	if opts.CloudProvider == nil {
 		opts.CloudProvider = cloudBuilder.NewCloudProvider(opts.AutoscalingOptions, informerFactory)
 	}
+	if opts.Backoff == nil {
+		switch opts.NodeGroupBackoffPolicy {
+		case "", backoff.ExponentialBackoffPolicy:
+			opts.Backoff =
+				backoff.NewIdBasedExponentialBackoff(opts.InitialNodeGroupBackoffDuration, opts.MaxNodeGroupBackoffDuration, opts.NodeGroupBackoffResetTimeout)
+		case backoff.AdaptiveBackoffPolicy:
+			opts.Backoff =
+				backoff.NewIdBasedAdaptiveBackoff(opts.InitialNodeGroupBackoffDuration, opts.MaxNodeGroupBackoffDuration, opts.NodeGroupHealthWindow, opts.NodeGroupHealthTargetReadinessLatency)
+		default:
+			return fmt.Errorf("unknown node group backoff policy %q", opts.NodeGroupBackoffPolicy)
+		}
+		if opts.ZonalStockoutCooldown > 0 {
+			opts.Backoff = backoff.NewZonalStockoutBackoff(opts.Backoff, opts.ZonalStockoutCooldown)
+		}
+	}
+	if opts.ExpanderFactory == nil {
+		opts.ExpanderFactory = factory.NewFactory()
+		opts.ExpanderFactory.RegisterDefaultExpanders(opts.CloudProvider, opts.AutoscalingKubeClients, opts.KubeClient, opts.ConfigNamespace, opts.GRPCExpanderCert, opts.GRPCExpanderURL, opts.GRPCExpanderClientCert, opts.GRPCExpanderClientKey, opts.CloudProviderName, opts.PriceLiveCacheTTL, opts.PriorityExpanderTieBreaker, opts.Backoff)
+		opts.ExpanderFactory.RegisterFallibleFilter(expander.HealthExpanderName, func() (expander.Filter, error) {
+			healthScorer, ok := opts.Backoff.(backoff.HealthScorer)
+			if !ok {
+				return nil, fmt.Errorf("--node-group-backoff-policy=%s is required", backoff.AdaptiveBackoffPolicy)
+			}
+			return health.NewFilter(healthScorer), nil
+		})
+		for _, path := range opts.ExpanderPlugins {
+			name, newFilter, err := goplugin.Load(path)
+			if err != nil {
+				return err
+			}
+			if opts.ExpanderFactory.IsRegistered(name) {
+				return fmt.Errorf("expander %s provided by plugin %s is already registered", name, path)
+			}
+			opts.ExpanderFactory.RegisterFallibleFilter(name, newFilter)
+		}
+	}
 	if opts.ExpanderStrategy == nil {
-		expanderFactory := factory.NewFactory()
-		expanderFactory.RegisterDefaultExpanders(opts.CloudProvider, opts.AutoscalingKubeClients, opts.KubeClient, opts.ConfigNamespace, opts.GRPCExpanderCert, opts.GRPCExpanderURL)
-		expanderStrategy, err := expanderFactory.Build(strings.Split(opts.ExpanderNames, ","))
+		expanderStrategy, err := opts.ExpanderFactory.Build(strings.Split(opts.ExpanderNames, ","))
 		if err != nil {
 			return err
 		}
@@ -150,10 +232,30 @@ func initializeDefaultOptions(opts *AutoscalerOptions, informerFactory informers
 			estimator.NewSngCapacityThreshold(),
 			estimator.NewClusterCapacityThreshold(),
 		}
-		estimatorBuilder, err := estimator.NewEstimatorBuilder(
+		if opts.SubnetCapacityCheckEnabled {
+			if checker, ok := opts.CloudProvider.(cloudprovider.SubnetCapacityChecker); ok {
+				thresholds = append(thresholds, estimator.NewSubnetCapacityThreshold(checker))
+			} else {
+				klog.Warningf("Cloud provider %s can't check subnet capacity, ignoring --subnet-capacity-check-enabled", opts.CloudProvider.Name())
+			}
+		}
+		// Limiters keep the state of a single estimation, every estimator gets its own limiter
+		// so that binpacking for different node groups can run concurrently.
+		newLimiter := func() estimator.EstimationLimiter {
+			limiter := estimator.NewThresholdBasedEstimationLimiter(thresholds)
+			if opts.MaxNodesPerScaleUpPerOwner > 0 {
+				limiter = estimator.NewOwnerBasedEstimationLimiter(limiter, opts.MaxNodesPerScaleUpPerOwner)
+			}
+			return limiter
+		}
+		podOrderer := estimator.NewDecreasingPodOrderer()
+		if opts.EstimatorGpuPodOrdering {
+			podOrderer = estimator.NewGpuAwareDecreasingPodOrderer()
+		}
+		estimatorBuilder, err := estimator.NewConcurrentEstimatorBuilder(
 			opts.EstimatorName,
-			estimator.NewThresholdBasedEstimationLimiter(thresholds),
-			estimator.NewDecreasingPodOrderer(),
+			newLimiter,
+			podOrderer,
 			/* EstimationAnalyserFunc */ nil,
 		)
 		if err != nil {
@@ -161,16 +263,23 @@ func initializeDefaultOptions(opts *AutoscalerOptions, informerFactory informers
 		}
 		opts.EstimatorBuilder = estimatorBuilder
 	}
-	if opts.Backoff == nil {
-		opts.Backoff =
-			backoff.NewIdBasedExponentialBackoff(opts.InitialNodeGroupBackoffDuration, opts.MaxNodeGroupBackoffDuration, opts.NodeGroupBackoffResetTimeout)
-	}
 	if opts.DrainabilityRules == nil {
 		opts.DrainabilityRules = rules.Default(opts.DeleteOptions)
 	}
 	if opts.DraProvider == nil && opts.DynamicResourceAllocationEnabled {
 		opts.DraProvider = draprovider.NewProviderFromInformers(informerFactory)
 	}
+	if opts.UsageProvider == nil && opts.ScaleDownUtilizationSource != "" && opts.ScaleDownUtilizationSource != string(utilization.RequestsSource) {
+		if opts.ScaleDownUsagePrometheusAddress != "" {
+			usageProvider, err := utilization.NewPrometheusUsageProvider(opts.ScaleDownUsagePrometheusAddress, utilization.DefaultUsageRefreshInterval)
+			if err != nil {
+				return err
+			}
+			opts.UsageProvider = usageProvider
+		} else {
+			opts.UsageProvider = utilization.NewMetricsServerUsageProvider(opts.KubeClient.Discovery().RESTClient(), utilization.DefaultUsageRefreshInterval)
+		}
+	}
 
 	return nil
 }
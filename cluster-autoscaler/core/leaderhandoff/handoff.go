@@ -0,0 +1,190 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package leaderhandoff
+
+import (
+	ctx "context"
+	"sort"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/api"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown"
+	"k8s.io/autoscaler/cluster-autoscaler/observers/nodegroupchange"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
+	klog "k8s.io/klog/v2"
+)
+
+// Handoff hands over scale-ups and node deletions in progress from one leader to the next through the
+// ClusterAutoscalerStatus object. Without it, a new leader doesn't know about the scale-ups of the previous
+// one, so they never time out, and removes the ToBeDeleted taints of nodes being drained, so that their
+// remaining pods stay while the already evicted ones were rescheduled elsewhere.
+type Handoff struct {
+	context              *context.AutoscalingContext
+	clusterStateRegistry *clusterstate.ClusterStateRegistry
+	scaleStateNotifier   *nodegroupchange.NodeGroupChangeObserversList
+	actuator             scaledown.Actuator
+	// pendingScaleUps are the scale-ups handed off and not taken over yet.
+	pendingScaleUps []api.ScaleUpInProgress
+	// pendingDeletions maps names of the nodes whose deletion was handed off and not resumed yet
+	// to whether they need to be drained.
+	pendingDeletions map[string]bool
+}
+
+// NewHandoff creates a new Handoff.
+func NewHandoff(context *context.AutoscalingContext, clusterStateRegistry *clusterstate.ClusterStateRegistry,
+	scaleStateNotifier *nodegroupchange.NodeGroupChangeObserversList, actuator scaledown.Actuator) *Handoff {
+	return &Handoff{
+		context:              context,
+		clusterStateRegistry: clusterStateRegistry,
+		scaleStateNotifier:   scaleStateNotifier,
+		actuator:             actuator,
+		pendingDeletions:     make(map[string]bool),
+	}
+}
+
+// State returns the operations in progress, to be taken over by the next leader. Operations handed off
+// by the previous leader which weren't resumed yet are included, so that they aren't lost if the
+// leadership changes again before they are.
+func (h *Handoff) State() *api.LeaderHandoff {
+	state := &api.LeaderHandoff{}
+	state.ScaleUps = append(state.ScaleUps, h.pendingScaleUps...)
+	for nodeGroupId, request := range h.clusterStateRegistry.GetScaleUpRequests() {
+		state.ScaleUps = append(state.ScaleUps, api.ScaleUpInProgress{
+			NodeGroup: nodeGroupId,
+			Increase:  request.Increase,
+			Time:      metav1.NewTime(request.Time),
+		})
+	}
+	sort.Slice(state.ScaleUps, func(i, j int) bool { return state.ScaleUps[i].NodeGroup < state.ScaleUps[j].NodeGroup })
+
+	deletions := make(map[string]bool, len(h.pendingDeletions))
+	for name, drain := range h.pendingDeletions {
+		deletions[name] = drain
+	}
+	empty, drained := h.actuator.CheckStatus().DeletionsInProgress()
+	for _, name := range empty {
+		deletions[name] = false
+	}
+	for _, name := range drained {
+		deletions[name] = true
+	}
+	for name, drain := range deletions {
+		state.NodeDeletions = append(state.NodeDeletions, api.NodeDeletionInProgress{Name: name, Drain: drain})
+	}
+	sort.Slice(state.NodeDeletions, func(i, j int) bool { return state.NodeDeletions[i].Name < state.NodeDeletions[j].Name })
+	return state
+}
+
+// Restore records the operations handed off by the previous leader, to be taken over by Resume.
+func (h *Handoff) Restore(state *api.LeaderHandoff) {
+	h.pendingScaleUps = append(h.pendingScaleUps, state.ScaleUps...)
+	for _, deletion := range state.NodeDeletions {
+		klog.V(0).Infof("Leader handoff: taking over deletion of node %s", deletion.Name)
+		h.pendingDeletions[deletion.Name] = deletion.Drain
+	}
+}
+
+// IsPendingDeletion returns whether the deletion of the node was handed off and is going to be resumed, in
+// which case its ToBeDeleted taint mustn't be removed.
+func (h *Handoff) IsPendingDeletion(nodeName string) bool {
+	_, found := h.pendingDeletions[nodeName]
+	return found
+}
+
+// Resume takes over the operations handed off by the previous leader. It has to be called after the cloud
+// provider refresh, so that the node groups of the scale-ups are known. Scale-ups are registered with their
+// original time, so that they time out as if the leader didn't change. Spans of the resumed deletions are
+// nested in the span of traceContext.
+func (h *Handoff) Resume(traceContext ctx.Context, allNodes []*apiv1.Node) {
+	h.resumeScaleUps()
+	h.resumeDeletions(traceContext, allNodes)
+}
+
+func (h *Handoff) resumeScaleUps() {
+	if len(h.pendingScaleUps) == 0 {
+		return
+	}
+	nodeGroups := make(map[string]cloudprovider.NodeGroup)
+	for _, nodeGroup := range h.context.CloudProvider.NodeGroups() {
+		nodeGroups[nodeGroup.Id()] = nodeGroup
+	}
+	// Scale-ups already resumed from their ScaleUpIntents mustn't be registered twice.
+	requests := h.clusterStateRegistry.GetScaleUpRequests()
+	for _, scaleUp := range h.pendingScaleUps {
+		nodeGroup, found := nodeGroups[scaleUp.NodeGroup]
+		if _, tracked := requests[scaleUp.NodeGroup]; !found || tracked || scaleUp.Increase <= 0 {
+			klog.V(1).Infof("Leader handoff: skipping scale-up of %d nodes in node group %s", scaleUp.Increase, scaleUp.NodeGroup)
+			continue
+		}
+		klog.V(0).Infof("Leader handoff: taking over scale-up of node group %s by %d nodes started at %v", scaleUp.NodeGroup, scaleUp.Increase, scaleUp.Time.Time)
+		h.scaleStateNotifier.RegisterScaleUp(nodeGroup, scaleUp.Increase, scaleUp.Time.Time)
+	}
+	h.pendingScaleUps = nil
+}
+
+// resumeDeletions resumes the handed off node deletions. Nodes which no longer exist or no longer have the
+// ToBeDeleted taint are skipped. Deletions are resumed once, nodes whose deletion couldn't be started are
+// untainted.
+func (h *Handoff) resumeDeletions(traceContext ctx.Context, allNodes []*apiv1.Node) {
+	if len(h.pendingDeletions) == 0 {
+		return
+	}
+	var empty, drain []*apiv1.Node
+	for _, node := range allNodes {
+		needsDrain, found := h.pendingDeletions[node.Name]
+		if !found {
+			continue
+		}
+		if !taints.HasToBeDeletedTaint(node) {
+			klog.V(1).Infof("Leader handoff: node %s is no longer tainted, not resuming its deletion", node.Name)
+			continue
+		}
+		if needsDrain {
+			drain = append(drain, node)
+		} else {
+			empty = append(empty, node)
+		}
+	}
+	h.pendingDeletions = make(map[string]bool)
+	if len(empty) == 0 && len(drain) == 0 {
+		return
+	}
+
+	klog.V(0).Infof("Leader handoff: resuming deletion of %d empty nodes and %d nodes to drain", len(empty), len(drain))
+	if _, _, err := h.actuator.StartDeletion(traceContext, empty, drain); err != nil {
+		klog.Errorf("Leader handoff: failed to resume node deletions: %v", err)
+	}
+
+	inProgress := make(map[string]bool)
+	emptyInProgress, drainedInProgress := h.actuator.CheckStatus().DeletionsInProgress()
+	for _, name := range append(emptyInProgress, drainedInProgress...) {
+		inProgress[name] = true
+	}
+	for _, node := range append(empty, drain...) {
+		if inProgress[node.Name] {
+			continue
+		}
+		klog.Warningf("Leader handoff: deletion of node %s wasn't resumed, removing its ToBeDeleted taint", node.Name)
+		if _, err := taints.CleanToBeDeleted(node, h.context.ClientSet, h.context.CordonNodeBeforeTerminate); err != nil {
+			klog.Errorf("Leader handoff: failed to remove ToBeDeleted taint from node %s: %v", node.Name, err)
+		}
+	}
+}
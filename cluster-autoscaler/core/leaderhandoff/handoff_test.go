@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package leaderhandoff
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/api"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	. "k8s.io/autoscaler/cluster-autoscaler/core/test"
+	"k8s.io/autoscaler/cluster-autoscaler/observers/nodegroupchange"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroups/asyncnodegroups"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// fakeActuator starts the deletion of the nodes it accepts and reports them as in progress.
+type fakeActuator struct {
+	accepted map[string]bool
+	empty    []string
+	drained  []string
+}
+
+func (a *fakeActuator) StartDeletion(_ context.Context, empty, needDrain []*apiv1.Node) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError) {
+	for _, node := range empty {
+		if a.accepted[node.Name] {
+			a.empty = append(a.empty, node.Name)
+		}
+	}
+	for _, node := range needDrain {
+		if a.accepted[node.Name] {
+			a.drained = append(a.drained, node.Name)
+		}
+	}
+	return status.ScaleDownNodeDeleteStarted, nil, nil
+}
+
+func (a *fakeActuator) StartForceDeletion(traceContext context.Context, empty, needDrain []*apiv1.Node) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError) {
+	return a.StartDeletion(traceContext, empty, needDrain)
+}
+
+func (a *fakeActuator) CheckStatus() scaledown.ActuationStatus {
+	return a
+}
+
+func (a *fakeActuator) ClearResultsNotNewerThan(time.Time) {}
+
+func (a *fakeActuator) DeletionResults() (map[string]status.NodeDeleteResult, time.Time) {
+	return nil, time.Time{}
+}
+
+func (a *fakeActuator) DeletionsInProgress() ([]string, []string) {
+	return a.empty, a.drained
+}
+
+func (a *fakeActuator) DeletionsCount(string) int {
+	return len(a.empty) + len(a.drained)
+}
+
+func (a *fakeActuator) RecentEvictions() []*apiv1.Pod {
+	return nil
+}
+
+func TestHandoff(t *testing.T) {
+	now := time.Now()
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 0, 10, 3)
+	provider.AddNodeGroup("ng2", 0, 10, 1)
+
+	toBeDeleted := apiv1.Taint{Key: taints.ToBeDeletedTaint, Effect: apiv1.TaintEffectNoSchedule}
+	client := fake.NewSimpleClientset()
+	var nodes []*apiv1.Node
+	for _, name := range []string{"empty", "drained", "rejected", "untainted"} {
+		node := BuildTestNode(name, 1000, 1000)
+		if name != "untainted" {
+			node.Spec.Taints = []apiv1.Taint{toBeDeleted}
+		}
+		provider.AddNode("ng1", node)
+		nodes = append(nodes, node)
+		_, err := client.CoreV1().Nodes().Create(context.TODO(), node.DeepCopy(), metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+
+	options := config.AutoscalingOptions{}
+	ctx, err := NewScaleTestAutoscalingContext(options, client, nil, provider, nil, nil)
+	assert.NoError(t, err)
+	csr := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, ctx.LogRecorder, NewBackoff(),
+		nodegroupconfig.NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{MaxNodeProvisionTime: 15 * time.Minute}),
+		asyncnodegroups.NewDefaultAsyncNodeGroupStateChecker())
+	notifier := nodegroupchange.NewNodeGroupChangeObserversList()
+	notifier.Register(csr)
+	actuator := &fakeActuator{accepted: map[string]bool{"empty": true, "drained": true, "untainted": true}}
+
+	h := NewHandoff(&ctx, csr, notifier, actuator)
+	h.Restore(&api.LeaderHandoff{
+		ScaleUps: []api.ScaleUpInProgress{
+			{NodeGroup: "ng2", Increase: 2, Time: metav1.NewTime(now.Add(-time.Minute))},
+			{NodeGroup: "unknown", Increase: 1, Time: metav1.NewTime(now)},
+		},
+		NodeDeletions: []api.NodeDeletionInProgress{
+			{Name: "empty"},
+			{Name: "drained", Drain: true},
+			{Name: "rejected", Drain: true},
+			{Name: "untainted"},
+			{Name: "missing"},
+		},
+	})
+	for _, name := range []string{"empty", "drained", "rejected", "untainted", "missing"} {
+		assert.True(t, h.IsPendingDeletion(name), name)
+	}
+
+	// Operations not resumed yet are handed off again.
+	state := h.State()
+	assert.Len(t, state.ScaleUps, 2)
+	assert.Len(t, state.NodeDeletions, 5)
+
+	h.Resume(context.Background(), nodes)
+	assert.False(t, h.IsPendingDeletion("empty"))
+	assert.Equal(t, []string{"empty"}, actuator.empty)
+	assert.Equal(t, []string{"drained"}, actuator.drained)
+
+	requests := csr.GetScaleUpRequests()
+	if assert.Contains(t, requests, "ng2") {
+		assert.Equal(t, 2, requests["ng2"].Increase)
+		assert.WithinDuration(t, now.Add(-time.Minute), requests["ng2"].Time, 0)
+	}
+	assert.NotContains(t, requests, "unknown")
+
+	rejected, err := client.CoreV1().Nodes().Get(context.TODO(), "rejected", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.False(t, taints.HasToBeDeletedTaint(rejected))
+
+	state = h.State()
+	assert.Equal(t, []api.ScaleUpInProgress{{NodeGroup: "ng2", Increase: 2, Time: metav1.NewTime(now.Add(-time.Minute))}}, state.ScaleUps)
+	assert.Equal(t, []api.NodeDeletionInProgress{{Name: "drained", Drain: true}, {Name: "empty"}}, state.NodeDeletions)
+
+	// Operations are resumed once.
+	h.Resume(context.Background(), nodes)
+	assert.Equal(t, []string{"empty"}, actuator.empty)
+	assert.Equal(t, []string{"drained"}, actuator.drained)
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package noderepair
+
+import (
+	ctx "context"
+	"reflect"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup"
+	"k8s.io/autoscaler/cluster-autoscaler/metrics"
+	"k8s.io/autoscaler/cluster-autoscaler/observers/nodegroupchange"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/tracing"
+	"k8s.io/client-go/util/flowcontrol"
+	klog "k8s.io/klog/v2"
+)
+
+// Repairer recreates nodes which are NotReady or unregistered for too long, in node groups with node auto-repair
+// enabled. A node is recreated by deleting its instance and scaling its node group back up with the scale-up
+// orchestrator, so that the node group replaces it with a new instance within the scale-up limits.
+type Repairer struct {
+	context             *context.AutoscalingContext
+	configProcessor     nodegroupconfig.NodeGroupConfigProcessor
+	scaleStateNotifier  *nodegroupchange.NodeGroupChangeObserversList
+	scaleUpOrchestrator scaleup.Orchestrator
+	// limiter is nil if the number of repairs isn't limited.
+	limiter flowcontrol.RateLimiter
+	// repaired contains the instances already repaired, which may still be listed until they're gone.
+	repaired map[string]bool
+}
+
+// NewRepairer creates a new Repairer, limiting the repairs to context.MaxNodeAutoRepairsPerHour.
+func NewRepairer(context *context.AutoscalingContext, configProcessor nodegroupconfig.NodeGroupConfigProcessor, scaleStateNotifier *nodegroupchange.NodeGroupChangeObserversList, scaleUpOrchestrator scaleup.Orchestrator) *Repairer {
+	var limiter flowcontrol.RateLimiter
+	if limit := context.MaxNodeAutoRepairsPerHour; limit > 0 {
+		limiter = flowcontrol.NewTokenBucketRateLimiter(float32(limit)/float32(time.Hour.Seconds()), limit)
+	}
+	return &Repairer{
+		context:             context,
+		configProcessor:     configProcessor,
+		scaleStateNotifier:  scaleStateNotifier,
+		scaleUpOrchestrator: scaleUpOrchestrator,
+		limiter:             limiter,
+		repaired:            make(map[string]bool),
+	}
+}
+
+type repairCandidate struct {
+	node      *apiv1.Node
+	nodeGroup cloudprovider.NodeGroup
+	reason    metrics.NodeAutoRepairReason
+}
+
+// RepairNodes recreates the registered nodes which are NotReady for longer than NodeAutoRepairNotReadyTime and the
+// unregistered ones which didn't register within the max node provision time of their node group. Each instance is
+// repaired once. With scale-down dry-run, the nodes are only reported. Spans of the repairs are nested in the span
+// of traceContext.
+func (r *Repairer) RepairNodes(traceContext ctx.Context, allNodes, readyNodes []*apiv1.Node, unregisteredNodes []clusterstate.UnregisteredNode, nodeInfosForGroups map[string]*framework.NodeInfo, now time.Time) {
+	existing := make(map[string]bool, len(allNodes)+len(unregisteredNodes))
+	for _, node := range allNodes {
+		existing[instanceId(node)] = true
+	}
+	for _, unregistered := range unregisteredNodes {
+		existing[instanceId(unregistered.Node)] = true
+	}
+	for id := range r.repaired {
+		if !existing[id] {
+			delete(r.repaired, id)
+		}
+	}
+
+	for _, candidate := range r.repairCandidates(allNodes, unregisteredNodes, now) {
+		if r.context.ScaleDownDryRun {
+			klog.V(1).Infof("Node auto-repair dry-run: would recreate %s node %s of node group %s", candidate.reason, candidate.node.Name, candidate.nodeGroup.Id())
+			r.repaired[instanceId(candidate.node)] = true
+			continue
+		}
+		if r.limiter != nil && !r.limiter.TryAccept() {
+			klog.V(1).Infof("Node auto-repair: repair rate limit reached, not recreating node %s", candidate.node.Name)
+			metrics.RegisterNodeAutoRepair(candidate.reason, metrics.NodeAutoRepairRateLimited)
+			continue
+		}
+		r.repaired[instanceId(candidate.node)] = true
+		r.repair(traceContext, candidate, readyNodes, nodeInfosForGroups, now)
+	}
+}
+
+// repairCandidates returns the nodes which should be recreated and weren't repaired yet.
+func (r *Repairer) repairCandidates(allNodes []*apiv1.Node, unregisteredNodes []clusterstate.UnregisteredNode, now time.Time) []repairCandidate {
+	var candidates []repairCandidate
+	for _, node := range allNodes {
+		if r.repaired[instanceId(node)] || taints.HasToBeDeletedTaint(node) {
+			continue
+		}
+		ready, lastTransitionTime, err := kube_util.GetReadinessState(node)
+		if err != nil {
+			klog.Warningf("Node auto-repair: failed to get readiness of node %s: %v", node.Name, err)
+			continue
+		}
+		if ready || lastTransitionTime.Add(r.context.NodeAutoRepairNotReadyTime).After(now) {
+			continue
+		}
+		if nodeGroup := r.repairableNodeGroup(node); nodeGroup != nil {
+			candidates = append(candidates, repairCandidate{node: node, nodeGroup: nodeGroup, reason: metrics.NotReadyRepair})
+		}
+	}
+	for _, unregistered := range unregisteredNodes {
+		if r.repaired[instanceId(unregistered.Node)] {
+			continue
+		}
+		nodeGroup := r.repairableNodeGroup(unregistered.Node)
+		if nodeGroup == nil {
+			continue
+		}
+		maxNodeProvisionTime, err := r.configProcessor.GetMaxNodeProvisionTime(nodeGroup)
+		if err != nil {
+			klog.Warningf("Node auto-repair: failed to get max node provision time of node group %s: %v", nodeGroup.Id(), err)
+			continue
+		}
+		if unregistered.UnregisteredSince.Add(maxNodeProvisionTime).Before(now) {
+			candidates = append(candidates, repairCandidate{node: unregistered.Node, nodeGroup: nodeGroup, reason: metrics.UnregisteredRepair})
+		}
+	}
+	return candidates
+}
+
+// repairableNodeGroup returns the node group of the node, or nil if it doesn't have one with node auto-repair enabled.
+func (r *Repairer) repairableNodeGroup(node *apiv1.Node) cloudprovider.NodeGroup {
+	nodeGroup, err := r.context.CloudProvider.NodeGroupForNode(node)
+	if err != nil {
+		klog.Warningf("Node auto-repair: failed to get node group for %s: %v", node.Name, err)
+		return nil
+	}
+	if nodeGroup == nil || reflect.ValueOf(nodeGroup).IsNil() {
+		return nil
+	}
+	if !r.Repairs(nodeGroup) {
+		return nil
+	}
+	return nodeGroup
+}
+
+// Repairs checks whether node auto-repair is enabled for the node group.
+func (r *Repairer) Repairs(nodeGroup cloudprovider.NodeGroup) bool {
+	enabled, err := r.configProcessor.GetNodeAutoRepairEnabled(nodeGroup)
+	if err != nil {
+		klog.Warningf("Node auto-repair: failed to check if it's enabled for node group %s: %v", nodeGroup.Id(), err)
+		return false
+	}
+	return enabled
+}
+
+// repair deletes the instance of the node and scales its node group back up. The instance is deleted regardless of
+// the min size of the node group, which is only violated until the node group is scaled up. The scale-up goes
+// through the scale-up orchestrator, so it respects backoff, pauses, max sizes, resource limits and cost budget.
+func (r *Repairer) repair(traceContext ctx.Context, candidate repairCandidate, readyNodes []*apiv1.Node, nodeInfosForGroups map[string]*framework.NodeInfo, now time.Time) {
+	node, nodeGroup := candidate.node, candidate.nodeGroup
+	klog.V(0).Infof("Node auto-repair: recreating %s node %s of node group %s", candidate.reason, node.Name, nodeGroup.Id())
+
+	_, span := tracing.Start(traceContext, "NodeGroup.DeleteNodes", attribute.String("node_group", nodeGroup.Id()), attribute.Int("nodes", 1))
+	err := nodeGroup.ForceDeleteNodes([]*apiv1.Node{node})
+	if err == cloudprovider.ErrNotImplemented {
+		err = nodeGroup.DeleteNodes([]*apiv1.Node{node})
+	}
+	tracing.End(span, err)
+	if err != nil {
+		klog.Errorf("Node auto-repair: failed to delete node %s: %v", node.Name, err)
+		r.context.LogRecorder.Eventf(apiv1.EventTypeWarning, "NodeAutoRepairFailed", "Failed to delete %s node %s: %v", candidate.reason, node.Name, err)
+		metrics.RegisterNodeAutoRepair(candidate.reason, metrics.NodeAutoRepairFailed)
+		return
+	}
+	r.scaleStateNotifier.RegisterScaleDown(nodeGroup, node.Name, now, now)
+
+	// The deleted node doesn't count towards the cluster limits anymore.
+	var remainingNodes []*apiv1.Node
+	for _, readyNode := range readyNodes {
+		if readyNode.Name != node.Name {
+			remainingNodes = append(remainingNodes, readyNode)
+		}
+	}
+	scaleUpStatus, aErr := r.scaleUpOrchestrator.ScaleUpNodeGroup(traceContext, nodeGroup, 1, remainingNodes, nodeInfosForGroups)
+	if aErr != nil {
+		klog.Errorf("Node auto-repair: failed to increase size of %s to replace node %s: %v", nodeGroup.Id(), node.Name, aErr)
+		r.context.LogRecorder.Eventf(apiv1.EventTypeWarning, "NodeAutoRepairFailed", "Deleted %s node %s, but failed to scale up group %s to replace it: %v", candidate.reason, node.Name, nodeGroup.Id(), aErr)
+		metrics.RegisterNodeAutoRepair(candidate.reason, metrics.NodeAutoRepairFailed)
+		return
+	}
+	if scaleUpStatus == nil || scaleUpStatus.Result != status.ScaleUpSuccessful {
+		klog.Warningf("Node auto-repair: scale-up of %s not possible, not replacing node %s", nodeGroup.Id(), node.Name)
+		r.context.LogRecorder.Eventf(apiv1.EventTypeWarning, "NodeAutoRepairFailed", "Deleted %s node %s, but scale-up of group %s to replace it isn't possible", candidate.reason, node.Name, nodeGroup.Id())
+		metrics.RegisterNodeAutoRepair(candidate.reason, metrics.NodeAutoRepairFailed)
+		return
+	}
+	r.context.LogRecorder.Eventf(apiv1.EventTypeNormal, "NodeAutoRepair", "Recreating %s node %s of group %s", candidate.reason, node.Name, nodeGroup.Id())
+	metrics.RegisterNodeAutoRepair(candidate.reason, metrics.NodeAutoRepairSucceeded)
+}
+
+func instanceId(node *apiv1.Node) string {
+	if node.Spec.ProviderID != "" {
+		return node.Spec.ProviderID
+	}
+	return node.Name
+}
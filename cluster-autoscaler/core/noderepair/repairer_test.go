@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package noderepair
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	. "k8s.io/autoscaler/cluster-autoscaler/core/test"
+	"k8s.io/autoscaler/cluster-autoscaler/core/test/scaleuptest"
+	"k8s.io/autoscaler/cluster-autoscaler/observers/nodegroupchange"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRepairNodes(t *testing.T) {
+	now := time.Now()
+	for _, tc := range []struct {
+		name              string
+		maxRepairsPerHour int
+		dryRun            bool
+		wantDeleted       []string
+	}{
+		{
+			name:        "unlimited",
+			wantDeleted: []string{"ng1/n2", "ng1/u1"},
+		},
+		{
+			name:              "rate limited",
+			maxRepairsPerHour: 1,
+			wantDeleted:       []string{"ng1/n2"},
+		},
+		{
+			name:   "dry-run",
+			dryRun: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var deleted []string
+			scaledUp := make(map[string]int)
+			provider := testprovider.NewTestCloudProvider(func(nodeGroup string, delta int) error {
+				scaledUp[nodeGroup] += delta
+				return nil
+			}, func(nodeGroup string, node string) error {
+				deleted = append(deleted, nodeGroup+"/"+node)
+				return nil
+			})
+			provider.AddNodeGroupWithCustomOptions("ng1", 4, 10, 4, &config.NodeGroupAutoscalingOptions{
+				NodeAutoRepairEnabled: true,
+				MaxNodeProvisionTime:  15 * time.Minute,
+			})
+			provider.AddNodeGroup("ng2", 0, 10, 1)
+
+			ready := BuildTestNode("n1", 1000, 1000)
+			SetNodeReadyState(ready, true, now.Add(-time.Hour))
+			longNotReady := BuildTestNode("n2", 1000, 1000)
+			SetNodeReadyState(longNotReady, false, now.Add(-time.Hour))
+			recentlyNotReady := BuildTestNode("n3", 1000, 1000)
+			SetNodeReadyState(recentlyNotReady, false, now.Add(-5*time.Minute))
+			otherGroupNotReady := BuildTestNode("n4", 1000, 1000)
+			SetNodeReadyState(otherGroupNotReady, false, now.Add(-time.Hour))
+			longUnregistered := BuildTestNode("u1", 1000, 1000)
+			recentlyUnregistered := BuildTestNode("u2", 1000, 1000)
+			for _, node := range []*apiv1.Node{ready, longNotReady, recentlyNotReady, longUnregistered, recentlyUnregistered} {
+				provider.AddNode("ng1", node)
+			}
+			provider.AddNode("ng2", otherGroupNotReady)
+			allNodes := []*apiv1.Node{ready, longNotReady, recentlyNotReady, otherGroupNotReady}
+			unregisteredNodes := []clusterstate.UnregisteredNode{
+				{Node: longUnregistered, UnregisteredSince: now.Add(-time.Hour)},
+				{Node: recentlyUnregistered, UnregisteredSince: now.Add(-5 * time.Minute)},
+			}
+
+			options := config.AutoscalingOptions{
+				NodeGroupDefaults:          config.NodeGroupAutoscalingOptions{MaxNodeProvisionTime: 15 * time.Minute},
+				NodeAutoRepairNotReadyTime: 20 * time.Minute,
+				MaxNodeAutoRepairsPerHour:  tc.maxRepairsPerHour,
+				ScaleDownDryRun:            tc.dryRun,
+			}
+			ctx, err := NewScaleTestAutoscalingContext(options, fake.NewSimpleClientset(), nil, provider, nil, nil)
+			assert.NoError(t, err)
+
+			r := NewRepairer(&ctx, nodegroupconfig.NewDefaultNodeGroupConfigProcessor(options.NodeGroupDefaults), nodegroupchange.NewNodeGroupChangeObserversList(), &scaleuptest.FakeOrchestrator{})
+			r.RepairNodes(context.Background(), allNodes, []*apiv1.Node{ready}, unregisteredNodes, nil, now)
+			// Nodes are repaired once.
+			r.RepairNodes(context.Background(), allNodes, []*apiv1.Node{ready}, unregisteredNodes, nil, now)
+
+			assert.ElementsMatch(t, tc.wantDeleted, deleted)
+			wantScaledUp := map[string]int{}
+			if len(tc.wantDeleted) > 0 {
+				wantScaledUp["ng1"] = len(tc.wantDeleted)
+			}
+			assert.Equal(t, wantScaledUp, scaledUp)
+			targetSize, err := provider.GetNodeGroup("ng1").TargetSize()
+			assert.NoError(t, err)
+			assert.Equal(t, 4, targetSize)
+		})
+	}
+}
+
+func TestRepairNodesScaleUpNotPossible(t *testing.T) {
+	now := time.Now()
+	var deleted []string
+	provider := testprovider.NewTestCloudProvider(func(nodeGroup string, delta int) error {
+		t.Errorf("unexpected scale-up of node group %s", nodeGroup)
+		return nil
+	}, func(nodeGroup string, node string) error {
+		deleted = append(deleted, nodeGroup+"/"+node)
+		return nil
+	})
+	// The orchestrator refuses to scale up node groups exceeding their max size.
+	provider.AddNodeGroupWithCustomOptions("ng1", 0, 1, 2, &config.NodeGroupAutoscalingOptions{NodeAutoRepairEnabled: true})
+	notReady := BuildTestNode("n1", 1000, 1000)
+	SetNodeReadyState(notReady, false, now.Add(-time.Hour))
+	provider.AddNode("ng1", notReady)
+
+	options := config.AutoscalingOptions{NodeAutoRepairNotReadyTime: 20 * time.Minute}
+	ctx, err := NewScaleTestAutoscalingContext(options, fake.NewSimpleClientset(), nil, provider, nil, nil)
+	assert.NoError(t, err)
+	r := NewRepairer(&ctx, nodegroupconfig.NewDefaultNodeGroupConfigProcessor(options.NodeGroupDefaults), nodegroupchange.NewNodeGroupChangeObserversList(), &scaleuptest.FakeOrchestrator{})
+	r.RepairNodes(context.Background(), []*apiv1.Node{notReady}, nil, nil, nil, now)
+	assert.Equal(t, []string{"ng1/n1"}, deleted)
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package podlistprocessor
 
 import (
+	default_context "context"
 	"testing"
 	"time"
 
@@ -285,11 +286,11 @@ type mockActuator struct {
 	status *mockActuationStatus
 }
 
-func (m *mockActuator) StartDeletion(_, _ []*apiv1.Node) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError) {
+func (m *mockActuator) StartDeletion(_ default_context.Context, _, _ []*apiv1.Node) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError) {
 	return status.ScaleDownError, []*status.ScaleDownNode{}, nil
 }
 
-func (m *mockActuator) StartForceDeletion(_, _ []*apiv1.Node) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError) {
+func (m *mockActuator) StartForceDeletion(_ default_context.Context, _, _ []*apiv1.Node) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError) {
 	return status.ScaleDownError, []*status.ScaleDownNode{}, nil
 }
 
@@ -0,0 +1,77 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podlistprocessor
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+)
+
+type enforceTopologySpread struct {
+}
+
+// NewEnforceTopologySpreadPodListProcessor creates a PodListProcessor which makes topology spread
+// constraints of unschedulable pods with whenUnsatisfiable: ScheduleAnyway behave as DoNotSchedule
+// in scale-up simulations. The scheduler only prefers nodes keeping the skew for such pods, so without
+// it any node group fitting the pods could be scaled up. With it, only node groups keeping the skew
+// are, which provisions nodes in a new topology domain (e.g. a zone without nodes) when the skew can't
+// be kept in the existing ones. Pods whose spread can't be kept by any node group don't trigger scale-up.
+func NewEnforceTopologySpreadPodListProcessor() *enforceTopologySpread {
+	return &enforceTopologySpread{}
+}
+
+// Process replaces pods having ScheduleAnyway topology spread constraints with copies having DoNotSchedule ones.
+func (p *enforceTopologySpread) Process(context *context.AutoscalingContext, pods []*apiv1.Pod) ([]*apiv1.Pod, error) {
+	var processedPods []*apiv1.Pod
+	for i, pod := range pods {
+		if !hasScheduleAnywaySpread(pod) {
+			if processedPods != nil {
+				processedPods = append(processedPods, pod)
+			}
+			continue
+		}
+		if processedPods == nil {
+			// Copy slice only if we need to replace some pods.
+			processedPods = append(make([]*apiv1.Pod, 0, len(pods)), pods[:i]...)
+		}
+		processedPods = append(processedPods, enforceTopologySpreadConstraints(pod))
+	}
+	if processedPods == nil {
+		return pods, nil
+	}
+	return processedPods, nil
+}
+
+func (p *enforceTopologySpread) CleanUp() {
+}
+
+func hasScheduleAnywaySpread(pod *apiv1.Pod) bool {
+	for _, constraint := range pod.Spec.TopologySpreadConstraints {
+		if constraint.WhenUnsatisfiable == apiv1.ScheduleAnyway {
+			return true
+		}
+	}
+	return false
+}
+
+func enforceTopologySpreadConstraints(pod *apiv1.Pod) *apiv1.Pod {
+	enforced := pod.DeepCopy()
+	for i := range enforced.Spec.TopologySpreadConstraints {
+		enforced.Spec.TopologySpreadConstraints[i].WhenUnsatisfiable = apiv1.DoNotSchedule
+	}
+	return enforced
+}
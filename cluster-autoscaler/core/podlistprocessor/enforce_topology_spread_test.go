@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podlistprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+func withZoneSpread(whenUnsatisfiable apiv1.UnsatisfiableConstraintAction) func(*apiv1.Pod) {
+	return func(pod *apiv1.Pod) {
+		pod.Spec.TopologySpreadConstraints = append(pod.Spec.TopologySpreadConstraints, apiv1.TopologySpreadConstraint{
+			MaxSkew:           1,
+			TopologyKey:       apiv1.LabelTopologyZone,
+			WhenUnsatisfiable: whenUnsatisfiable,
+			MatchLabelKeys:    []string{"pod-template-hash"},
+		})
+	}
+}
+
+func TestEnforceTopologySpreadPodListProcessor(t *testing.T) {
+	noSpread := test.BuildTestPod("no-spread", 1000, 1)
+	hardSpread := test.BuildTestPod("hard-spread", 1000, 1, withZoneSpread(apiv1.DoNotSchedule))
+	softSpread := test.BuildTestPod("soft-spread", 1000, 1, withZoneSpread(apiv1.ScheduleAnyway))
+	mixedSpread := test.BuildTestPod("mixed-spread", 1000, 1, withZoneSpread(apiv1.DoNotSchedule), withZoneSpread(apiv1.ScheduleAnyway))
+
+	testCases := []struct {
+		name     string
+		pods     []*apiv1.Pod
+		wantPods []*apiv1.Pod
+	}{
+		{
+			name: "no pods",
+		},
+		{
+			name:     "pods without soft spread are kept",
+			pods:     []*apiv1.Pod{noSpread, hardSpread},
+			wantPods: []*apiv1.Pod{noSpread, hardSpread},
+		},
+		{
+			name: "soft spread is enforced",
+			pods: []*apiv1.Pod{noSpread, softSpread, hardSpread, mixedSpread},
+			wantPods: []*apiv1.Pod{
+				noSpread,
+				test.BuildTestPod("soft-spread", 1000, 1, withZoneSpread(apiv1.DoNotSchedule)),
+				hardSpread,
+				test.BuildTestPod("mixed-spread", 1000, 1, withZoneSpread(apiv1.DoNotSchedule), withZoneSpread(apiv1.DoNotSchedule)),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			processor := NewEnforceTopologySpreadPodListProcessor()
+			pods, err := processor.Process(nil, tc.pods)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantPods, pods)
+		})
+	}
+	// The processed pods aren't modified.
+	assert.Equal(t, apiv1.ScheduleAnyway, softSpread.Spec.TopologySpreadConstraints[0].WhenUnsatisfiable)
+}
@@ -28,10 +28,12 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/budgets"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/deletiontracker"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/pdb"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/predelete"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
 	"k8s.io/autoscaler/cluster-autoscaler/core/utils"
 	"k8s.io/autoscaler/cluster-autoscaler/metrics"
 	"k8s.io/autoscaler/cluster-autoscaler/observers/nodegroupchange"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/utilizationcalculator"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot/predicate"
@@ -40,7 +42,6 @@ import (
 	draprovider "k8s.io/autoscaler/cluster-autoscaler/simulator/dynamicresources/provider"
 	drasnapshot "k8s.io/autoscaler/cluster-autoscaler/simulator/dynamicresources/snapshot"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/options"
-	"k8s.io/autoscaler/cluster-autoscaler/simulator/utilization"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/expiring"
 	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
@@ -70,6 +71,7 @@ type Actuator struct {
 	nodeDeleteDelayAfterTaint time.Duration
 	pastLatencies             *expiring.List
 	draProvider               *draprovider.Provider
+	utilizationCalculator     utilizationcalculator.UtilizationCalculator
 }
 
 // actuatorNodeGroupConfigGetter is an interface to limit the functions that can be used
@@ -85,9 +87,9 @@ func NewActuator(ctx *context.AutoscalingContext, scaleStateNotifier nodegroupch
 	legacyFlagDrainConfig := SingleRuleDrainConfig(ctx.MaxGracefulTerminationSec)
 	var evictor Evictor
 	if len(ctx.DrainPriorityConfig) > 0 {
-		evictor = NewEvictor(ndt, ctx.DrainPriorityConfig, true)
+		evictor = NewEvictor(ndt, ctx.DrainPriorityConfig, true, ctx.DrainByPodPriority, ctx.NamespaceEvictionLimits)
 	} else {
-		evictor = NewEvictor(ndt, legacyFlagDrainConfig, false)
+		evictor = NewEvictor(ndt, legacyFlagDrainConfig, false, ctx.DrainByPodPriority, ctx.NamespaceEvictionLimits)
 	}
 	return &Actuator{
 		ctx:                       ctx,
@@ -100,9 +102,20 @@ func NewActuator(ctx *context.AutoscalingContext, scaleStateNotifier nodegroupch
 		nodeDeleteDelayAfterTaint: ctx.NodeDeleteDelayAfterTaint,
 		pastLatencies:             expiring.NewList(),
 		draProvider:               draProvider,
+		utilizationCalculator:     utilizationcalculator.NewDefaultUtilizationCalculator(),
 	}
 }
 
+// SetUtilizationCalculator makes the actuator report utilization of deleted nodes calculated by the calculator.
+func (a *Actuator) SetUtilizationCalculator(utilizationCalculator utilizationcalculator.UtilizationCalculator) {
+	a.utilizationCalculator = utilizationCalculator
+}
+
+// SetPreDeleteHook makes the actuator run the hook on each node after it's drained and before it's deleted.
+func (a *Actuator) SetPreDeleteHook(hook predelete.Hook) {
+	a.nodeDeletionScheduler.preDeleteHook = hook
+}
+
 // CheckStatus should returns an immutable snapshot of ongoing deletions.
 func (a *Actuator) CheckStatus() scaledown.ActuationStatus {
 	return a.nodeDeletionTracker.Snapshot()
@@ -120,19 +133,19 @@ func (a *Actuator) DeletionResults() (map[string]status.NodeDeleteResult, time.T
 }
 
 // StartDeletion triggers a new deletion process.
-func (a *Actuator) StartDeletion(empty, drain []*apiv1.Node) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError) {
-	return a.startDeletion(empty, drain, false)
+func (a *Actuator) StartDeletion(traceContext default_context.Context, empty, drain []*apiv1.Node) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError) {
+	return a.startDeletion(traceContext, empty, drain, false)
 }
 
 // StartForceDeletion triggers a new forced deletion process. It will bypass PDBs and forcefully delete the pods and the nodes.
-func (a *Actuator) StartForceDeletion(empty, drain []*apiv1.Node) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError) {
-	return a.startDeletion(empty, drain, true)
+func (a *Actuator) StartForceDeletion(traceContext default_context.Context, empty, drain []*apiv1.Node) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError) {
+	return a.startDeletion(traceContext, empty, drain, true)
 }
 
 // startDeletion contains the shared logic for deleting nodes. It handles both
 // normal deletions (respecting PDBs) and forced deletions (bypassing PDBs),
 // determined by the 'force' parameter.
-func (a *Actuator) startDeletion(empty, drain []*apiv1.Node, force bool) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError) {
+func (a *Actuator) startDeletion(traceContext default_context.Context, empty, drain []*apiv1.Node, force bool) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError) {
 	a.nodeDeletionScheduler.ResetAndReportMetrics()
 	deletionStartTime := time.Now()
 	defer func() { metrics.UpdateDuration(metrics.ScaleDownNodeDeletion, time.Since(deletionStartTime)) }()
@@ -150,7 +163,7 @@ func (a *Actuator) startDeletion(empty, drain []*apiv1.Node, force bool) (status
 			return status.ScaleDownError, scaledDownNodes, err
 		}
 
-		emptyScaledDown := a.deleteAsyncEmpty(emptyToDelete, nodeDeleteDelayAfterTaint, force)
+		emptyScaledDown := a.deleteAsyncEmpty(traceContext, emptyToDelete, nodeDeleteDelayAfterTaint, force)
 		scaledDownNodes = append(scaledDownNodes, emptyScaledDown...)
 	}
 
@@ -163,7 +176,7 @@ func (a *Actuator) startDeletion(empty, drain []*apiv1.Node, force bool) (status
 		}
 
 		// All nodes involved in the scale-down should be tainted now - start draining and deleting nodes asynchronously.
-		drainScaledDown := a.deleteAsyncDrain(drainToDelete, nodeDeleteDelayAfterTaint, force)
+		drainScaledDown := a.deleteAsyncDrain(traceContext, drainToDelete, nodeDeleteDelayAfterTaint, force)
 		scaledDownNodes = append(scaledDownNodes, drainScaledDown...)
 	}
 
@@ -172,7 +185,7 @@ func (a *Actuator) startDeletion(empty, drain []*apiv1.Node, force bool) (status
 
 // deleteAsyncEmpty immediately starts deletions asynchronously.
 // scaledDownNodes return value contains all nodes for which deletion successfully started.
-func (a *Actuator) deleteAsyncEmpty(NodeGroupViews []*budgets.NodeGroupView, nodeDeleteDelayAfterTaint time.Duration, force bool) (reportedSDNodes []*status.ScaleDownNode) {
+func (a *Actuator) deleteAsyncEmpty(traceContext default_context.Context, NodeGroupViews []*budgets.NodeGroupView, nodeDeleteDelayAfterTaint time.Duration, force bool) (reportedSDNodes []*status.ScaleDownNode) {
 	for _, bucket := range NodeGroupViews {
 		for _, node := range bucket.Nodes {
 			klog.V(0).Infof("Scale-down: removing empty node %q", node.Name)
@@ -189,7 +202,7 @@ func (a *Actuator) deleteAsyncEmpty(NodeGroupViews []*budgets.NodeGroupView, nod
 	}
 
 	for _, bucket := range NodeGroupViews {
-		go a.deleteNodesAsync(bucket.Nodes, bucket.Group, false, force, bucket.BatchSize, nodeDeleteDelayAfterTaint)
+		go a.deleteNodesAsync(traceContext, bucket.Nodes, bucket.Group, false, force, bucket.BatchSize, nodeDeleteDelayAfterTaint)
 	}
 
 	return reportedSDNodes
@@ -264,7 +277,7 @@ func (a *Actuator) taintNodesSync(NodeGroupViews []*budgets.NodeGroupView) (time
 
 // deleteAsyncDrain asynchronously starts deletions with drain for all provided nodes. scaledDownNodes return value contains all nodes for which
 // deletion successfully started.
-func (a *Actuator) deleteAsyncDrain(NodeGroupViews []*budgets.NodeGroupView, nodeDeleteDelayAfterTaint time.Duration, force bool) (reportedSDNodes []*status.ScaleDownNode) {
+func (a *Actuator) deleteAsyncDrain(traceContext default_context.Context, NodeGroupViews []*budgets.NodeGroupView, nodeDeleteDelayAfterTaint time.Duration, force bool) (reportedSDNodes []*status.ScaleDownNode) {
 	for _, bucket := range NodeGroupViews {
 		for _, drainNode := range bucket.Nodes {
 			if sdNode, err := a.scaleDownNodeToReport(drainNode, true); err == nil {
@@ -280,13 +293,13 @@ func (a *Actuator) deleteAsyncDrain(NodeGroupViews []*budgets.NodeGroupView, nod
 	}
 
 	for _, bucket := range NodeGroupViews {
-		go a.deleteNodesAsync(bucket.Nodes, bucket.Group, true, force, bucket.BatchSize, nodeDeleteDelayAfterTaint)
+		go a.deleteNodesAsync(traceContext, bucket.Nodes, bucket.Group, true, force, bucket.BatchSize, nodeDeleteDelayAfterTaint)
 	}
 
 	return reportedSDNodes
 }
 
-func (a *Actuator) deleteNodesAsync(nodes []*apiv1.Node, nodeGroup cloudprovider.NodeGroup, drain bool, force bool, batchSize int, nodeDeleteDelayAfterTaint time.Duration) {
+func (a *Actuator) deleteNodesAsync(traceContext default_context.Context, nodes []*apiv1.Node, nodeGroup cloudprovider.NodeGroup, drain bool, force bool, batchSize int, nodeDeleteDelayAfterTaint time.Duration) {
 	var remainingPdbTracker pdb.RemainingPdbTracker
 	var registry kube_util.ListerRegistry
 
@@ -348,11 +361,11 @@ func (a *Actuator) deleteNodesAsync(nodes []*apiv1.Node, nodeGroup cloudprovider
 		}
 
 		if force {
-			go a.nodeDeletionScheduler.scheduleForceDeletion(nodeInfo, nodeGroup, batchSize, drain)
+			go a.nodeDeletionScheduler.scheduleForceDeletion(traceContext, nodeInfo, nodeGroup, batchSize, drain)
 			continue
 		}
 
-		go a.nodeDeletionScheduler.ScheduleDeletion(nodeInfo, nodeGroup, batchSize, drain)
+		go a.nodeDeletionScheduler.ScheduleDeletion(traceContext, nodeInfo, nodeGroup, batchSize, drain)
 	}
 }
 
@@ -371,8 +384,7 @@ func (a *Actuator) scaleDownNodeToReport(node *apiv1.Node, drain bool) (*status.
 		return nil, err
 	}
 
-	gpuConfig := a.ctx.CloudProvider.GetNodeGpuConfig(node)
-	utilInfo, err := utilization.Calculate(nodeInfo, ignoreDaemonSetsUtilization, a.ctx.IgnoreMirrorPodsUtilization, a.ctx.DynamicResourceAllocationEnabled, gpuConfig, time.Now())
+	utilInfo, err := a.utilizationCalculator.Calculate(a.ctx, nodeInfo, ignoreDaemonSetsUtilization, time.Now())
 	if err != nil {
 		return nil, err
 	}
@@ -401,12 +413,11 @@ func (a *Actuator) taintNode(node *apiv1.Node) error {
 
 func (a *Actuator) createSnapshot(nodes []*apiv1.Node) (clustersnapshot.ClusterSnapshot, error) {
 	snapshot := predicate.NewPredicateSnapshot(store.NewBasicSnapshotStore(), a.ctx.FrameworkHandle, a.ctx.DynamicResourceAllocationEnabled)
-	pods, err := a.ctx.AllPodLister().List()
+	scheduledPods, err := kube_util.ListScheduledPods(a.ctx.AllPodLister())
 	if err != nil {
 		return nil, err
 	}
 
-	scheduledPods := kube_util.ScheduledPods(pods)
 	nonExpendableScheduledPods := utils.FilterOutExpendablePods(scheduledPods, a.ctx.ExpendablePodsPriorityCutoff)
 
 	var draSnapshot drasnapshot.Snapshot
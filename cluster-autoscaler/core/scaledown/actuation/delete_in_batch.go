@@ -17,11 +17,13 @@ limitations under the License.
 package actuation
 
 import (
+	default_context "context"
 	"fmt"
 	"reflect"
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/deletiontracker"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
@@ -30,6 +32,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/tracing"
 	"k8s.io/klog/v2"
 
 	apiv1 "k8s.io/api/core/v1"
@@ -54,6 +57,10 @@ type NodeDeletionBatcher struct {
 	deletionsPerNodeGroup map[string][]*apiv1.Node
 	deleteInterval        time.Duration
 	drainedNodeDeletions  map[string]bool
+	quota                 *deletionQuota
+	// traceContexts carry the span which the deletion of the nodes batched for a node group is nested in,
+	// the span of the first deletion of the batch.
+	traceContexts map[string]default_context.Context
 }
 
 // NewNodeDeletionBatcher return new NodeBatchDeleter
@@ -65,17 +72,24 @@ func NewNodeDeletionBatcher(ctx *context.AutoscalingContext, scaleStateNotifier
 		deleteInterval:        deleteInterval,
 		drainedNodeDeletions:  make(map[string]bool),
 		scaleStateNotifier:    scaleStateNotifier,
+		quota:                 newDeletionQuota(ctx.CloudProviderName, ctx.NodeDeletionQuotas),
+		traceContexts:         make(map[string]default_context.Context),
 	}
 }
 
-// AddNodes adds node list to delete candidates and schedules deletion. The deletion is performed asynchronously.
-func (d *NodeDeletionBatcher) AddNodes(nodes []*apiv1.Node, nodeGroup cloudprovider.NodeGroup, drain bool) {
+// AddNodes adds node list to delete candidates and schedules deletion. The deletion is performed asynchronously,
+// its span is nested in the span of traceContext.
+func (d *NodeDeletionBatcher) AddNodes(traceContext default_context.Context, nodes []*apiv1.Node, nodeGroup cloudprovider.NodeGroup, drain bool) {
 	// If delete interval is 0, than instantly start node deletion.
 	if d.deleteInterval == 0 {
-		go d.deleteNodesAndRegisterStatus(nodes, nodeGroup.Id(), drain)
+		drainedNodeDeletions := make(map[string]bool)
+		for _, node := range nodes {
+			drainedNodeDeletions[node.Name] = drain
+		}
+		go d.deleteNodesAndRegisterStatus(traceContext, nodes, nodeGroup.Id(), drainedNodeDeletions)
 		return
 	}
-	first := d.addNodesToBucket(nodes, nodeGroup, drain)
+	first := d.addNodesToBucket(traceContext, nodes, nodeGroup, drain)
 	if first {
 		// Just in case a node group implementation is not thread-safe, the async "remove" function will obtain a new instance of it to preform deletion.
 		go func(nodeGroupId string) {
@@ -85,20 +99,25 @@ func (d *NodeDeletionBatcher) AddNodes(nodes []*apiv1.Node, nodeGroup cloudprovi
 	}
 }
 
-func (d *NodeDeletionBatcher) deleteNodesAndRegisterStatus(nodes []*apiv1.Node, nodeGroupId string, drain bool) {
-	nodeGroup, err := deleteNodesFromCloudProvider(d.ctx, d.scaleStateNotifier, nodes)
-	for _, node := range nodes {
-		if err != nil {
-			result := status.NodeDeleteResult{ResultType: status.NodeDeleteErrorFailedToDelete, Err: err}
-			CleanUpAndRecordErrorForFailedScaleDownEvent(d.ctx, node, nodeGroupId, drain, d.nodeDeletionTracker, "", result)
-		} else {
-			RegisterAndRecordSuccessfulScaleDownEvent(d.ctx, d.scaleStateNotifier, node, nodeGroup, drain, d.nodeDeletionTracker)
+// deleteNodesAndRegisterStatus deletes nodes in batches allowed by the deletion quota, and records the result of each batch.
+func (d *NodeDeletionBatcher) deleteNodesAndRegisterStatus(traceContext default_context.Context, nodes []*apiv1.Node, nodeGroupId string, drainedNodeDeletions map[string]bool) {
+	for _, batch := range d.quota.split(d.ctx, nodes) {
+		d.quota.wait()
+		nodeGroup, err := deleteNodesFromCloudProvider(traceContext, d.ctx, d.scaleStateNotifier, batch)
+		for _, node := range batch {
+			drain := drainedNodeDeletions[node.Name]
+			if err != nil {
+				result := status.NodeDeleteResult{ResultType: status.NodeDeleteErrorFailedToDelete, Err: err}
+				CleanUpAndRecordErrorForFailedScaleDownEvent(d.ctx, node, nodeGroupId, drain, d.nodeDeletionTracker, "", result)
+			} else {
+				RegisterAndRecordSuccessfulScaleDownEvent(d.ctx, d.scaleStateNotifier, node, nodeGroup, drain, d.nodeDeletionTracker)
+			}
 		}
 	}
 }
 
 // AddToBucket adds node to delete candidates and return if it's a first node in the group.
-func (d *NodeDeletionBatcher) addNodesToBucket(nodes []*apiv1.Node, nodeGroup cloudprovider.NodeGroup, drain bool) bool {
+func (d *NodeDeletionBatcher) addNodesToBucket(traceContext default_context.Context, nodes []*apiv1.Node, nodeGroup cloudprovider.NodeGroup, drain bool) bool {
 	d.Lock()
 	defer d.Unlock()
 	for _, node := range nodes {
@@ -107,6 +126,7 @@ func (d *NodeDeletionBatcher) addNodesToBucket(nodes []*apiv1.Node, nodeGroup cl
 	val, ok := d.deletionsPerNodeGroup[nodeGroup.Id()]
 	if !ok || len(val) == 0 {
 		d.deletionsPerNodeGroup[nodeGroup.Id()] = nodes
+		d.traceContexts[nodeGroup.Id()] = traceContext
 		return true
 	}
 	d.deletionsPerNodeGroup[nodeGroup.Id()] = append(d.deletionsPerNodeGroup[nodeGroup.Id()], nodes...)
@@ -122,32 +142,24 @@ func (d *NodeDeletionBatcher) remove(nodeGroupId string) error {
 		return fmt.Errorf("Node Group %s is not present in the batch deleter", nodeGroupId)
 	}
 	delete(d.deletionsPerNodeGroup, nodeGroupId)
+	traceContext := d.traceContexts[nodeGroupId]
+	delete(d.traceContexts, nodeGroupId)
 	drainedNodeDeletions := make(map[string]bool)
 	for _, node := range nodes {
 		drainedNodeDeletions[node.Name] = d.drainedNodeDeletions[node.Name]
 		delete(d.drainedNodeDeletions, node.Name)
 	}
 
-	go func(nodes []*apiv1.Node, drainedNodeDeletions map[string]bool) {
-		var result status.NodeDeleteResult
-		nodeGroup, err := deleteNodesFromCloudProvider(d.ctx, d.scaleStateNotifier, nodes)
-		for _, node := range nodes {
-			drain := drainedNodeDeletions[node.Name]
-			if err != nil {
-				result = status.NodeDeleteResult{ResultType: status.NodeDeleteErrorFailedToDelete, Err: err}
-				CleanUpAndRecordErrorForFailedScaleDownEvent(d.ctx, node, nodeGroupId, drain, d.nodeDeletionTracker, "", result)
-			} else {
-				RegisterAndRecordSuccessfulScaleDownEvent(d.ctx, d.scaleStateNotifier, node, nodeGroup, drain, d.nodeDeletionTracker)
-			}
-		}
-	}(nodes, drainedNodeDeletions)
+	go d.deleteNodesAndRegisterStatus(traceContext, nodes, nodeGroupId, drainedNodeDeletions)
 	return nil
 }
 
 // deleteNodeFromCloudProvider removes the given nodes from cloud provider. No extra pre-deletion actions are executed on
-// the Kubernetes side.
-func deleteNodesFromCloudProvider(ctx *context.AutoscalingContext, scaleStateNotifier nodegroupchange.NodeGroupChangeObserver, nodes []*apiv1.Node) (cloudprovider.NodeGroup, error) {
-	nodeGroup, err := ctx.CloudProvider.NodeGroupForNode(nodes[0])
+// the Kubernetes side. The span of the deletion is nested in the span of traceContext.
+func deleteNodesFromCloudProvider(traceContext default_context.Context, ctx *context.AutoscalingContext, scaleStateNotifier nodegroupchange.NodeGroupChangeObserver, nodes []*apiv1.Node) (nodeGroup cloudprovider.NodeGroup, err error) {
+	_, span := tracing.Start(traceContext, "NodeGroup.DeleteNodes", attribute.Int("nodes", len(nodes)))
+	defer func() { tracing.End(span, err) }()
+	nodeGroup, err = ctx.CloudProvider.NodeGroupForNode(nodes[0])
 	if err != nil {
 		return nodeGroup, errors.NewAutoscalerErrorf(errors.CloudProviderError, "failed to find node group for %s: %v", nodes[0].Name, err)
 	}
@@ -17,6 +17,7 @@ limitations under the License.
 package actuation
 
 import (
+	default_context "context"
 	"fmt"
 	"testing"
 	"time"
@@ -86,6 +87,7 @@ func TestAddNodeToBucket(t *testing.T) {
 			nodeDeletionTracker:   nil,
 			deletionsPerNodeGroup: make(map[string][]*apiv1.Node),
 			drainedNodeDeletions:  make(map[string]bool),
+			traceContexts:         make(map[string]default_context.Context),
 		}
 		batchCount := 0
 		for _, node := range test.nodes {
@@ -93,7 +95,7 @@ func TestAddNodeToBucket(t *testing.T) {
 			if err != nil {
 				t.Errorf("couldn't get node info for node %s: %s", node.Name, err)
 			}
-			first := d.addNodesToBucket([]*apiv1.Node{node}, nodeGroup, test.drained)
+			first := d.addNodesToBucket(default_context.Background(), []*apiv1.Node{node}, nodeGroup, test.drained)
 			if first {
 				batchCount += 1
 			}
@@ -175,6 +177,7 @@ func TestRemove(t *testing.T) {
 				deletionsPerNodeGroup: make(map[string][]*apiv1.Node),
 				scaleStateNotifier:    scaleStateNotifier,
 				drainedNodeDeletions:  make(map[string]bool),
+				traceContexts:         make(map[string]default_context.Context),
 			}
 			nodes := generateNodes(0, test.numNodes, ng)
 			failedDeletion := test.failedDeletion
@@ -191,7 +194,7 @@ func TestRemove(t *testing.T) {
 						Key:    taints.ToBeDeletedTaint,
 						Effect: apiv1.TaintEffectNoSchedule,
 					})
-					d.addNodesToBucket([]*apiv1.Node{node}, nodeGroup, true)
+					d.addNodesToBucket(default_context.Background(), []*apiv1.Node{node}, nodeGroup, true)
 				}
 			}
 
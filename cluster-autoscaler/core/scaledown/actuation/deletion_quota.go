@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actuation
+
+import (
+	"reflect"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/klog/v2"
+)
+
+// deletionQuota limits the rate and size of node deletion calls made to the cloud provider,
+// so that large scale-downs don't get throttled by provider API quotas. The rate limit is
+// shared by all node groups, while batches are always formed out of nodes of a single node group.
+type deletionQuota struct {
+	// limiter is nil if the rate of deletion calls isn't limited.
+	limiter      flowcontrol.RateLimiter
+	maxBatchSize int
+}
+
+// newDeletionQuota returns the quota configured for the given cloud provider. If there is none,
+// the returned quota doesn't limit deletions.
+func newDeletionQuota(cloudProviderName string, quotas []config.NodeDeletionQuota) *deletionQuota {
+	q := &deletionQuota{}
+	for _, quota := range quotas {
+		if quota.CloudProviderName != cloudProviderName {
+			continue
+		}
+		q.maxBatchSize = quota.MaxBatchSize
+		q.limiter = nil
+		if quota.QPS > 0 {
+			q.limiter = flowcontrol.NewTokenBucketRateLimiter(float32(quota.QPS), 1)
+		}
+		klog.V(1).Infof("Limiting node deletions for %s cloud provider to %v calls per second and %d nodes per call (0 means no limit)", cloudProviderName, quota.QPS, quota.MaxBatchSize)
+	}
+	return q
+}
+
+// split divides nodes of a single node group into batches deleted in separate calls. Nodes
+// of node groups with ZeroOrMaxNodeScaling enabled are never split, as they need to be deleted at once.
+func (q *deletionQuota) split(ctx *context.AutoscalingContext, nodes []*apiv1.Node) [][]*apiv1.Node {
+	if q == nil || q.maxBatchSize <= 0 || len(nodes) <= q.maxBatchSize {
+		return [][]*apiv1.Node{nodes}
+	}
+	nodeGroup, err := ctx.CloudProvider.NodeGroupForNode(nodes[0])
+	if err == nil && nodeGroup != nil && !reflect.ValueOf(nodeGroup).IsNil() {
+		opts, err := nodeGroup.GetOptions(ctx.NodeGroupDefaults)
+		if err == nil && opts != nil && opts.ZeroOrMaxNodeScaling {
+			return [][]*apiv1.Node{nodes}
+		}
+	}
+	var batches [][]*apiv1.Node
+	for start := 0; start < len(nodes); start += q.maxBatchSize {
+		end := min(start+q.maxBatchSize, len(nodes))
+		batches = append(batches, nodes[start:end])
+	}
+	return batches
+}
+
+// wait blocks until a deletion call is allowed by the rate limit.
+func (q *deletionQuota) wait() {
+	if q != nil && q.limiter != nil {
+		q.limiter.Accept()
+	}
+}
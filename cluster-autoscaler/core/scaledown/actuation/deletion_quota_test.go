@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actuation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	. "k8s.io/autoscaler/cluster-autoscaler/core/test"
+)
+
+func TestNewDeletionQuota(t *testing.T) {
+	quotas := []config.NodeDeletionQuota{
+		{CloudProviderName: "gce", QPS: 1, MaxBatchSize: 10},
+		{CloudProviderName: "aws", MaxBatchSize: 20},
+	}
+
+	q := newDeletionQuota("aws", quotas)
+	assert.Equal(t, 20, q.maxBatchSize)
+	assert.Nil(t, q.limiter)
+
+	q = newDeletionQuota("gce", quotas)
+	assert.Equal(t, 10, q.maxBatchSize)
+	assert.NotNil(t, q.limiter)
+
+	q = newDeletionQuota("azure", quotas)
+	assert.Equal(t, 0, q.maxBatchSize)
+	assert.Nil(t, q.limiter)
+}
+
+func TestDeletionQuotaSplit(t *testing.T) {
+	testCases := []struct {
+		name             string
+		quota            *deletionQuota
+		numNodes         int
+		zeroOrMaxScaling bool
+		wantBatchSizes   []int
+	}{
+		{
+			name:           "no quota",
+			quota:          nil,
+			numNodes:       5,
+			wantBatchSizes: []int{5},
+		},
+		{
+			name:           "unlimited batch size",
+			quota:          &deletionQuota{},
+			numNodes:       5,
+			wantBatchSizes: []int{5},
+		},
+		{
+			name:           "nodes fit in a single batch",
+			quota:          &deletionQuota{maxBatchSize: 5},
+			numNodes:       5,
+			wantBatchSizes: []int{5},
+		},
+		{
+			name:           "nodes split into batches",
+			quota:          &deletionQuota{maxBatchSize: 2},
+			numNodes:       5,
+			wantBatchSizes: []int{2, 2, 1},
+		},
+		{
+			name:             "zero or max node scaling is never split",
+			quota:            &deletionQuota{maxBatchSize: 2},
+			numNodes:         5,
+			zeroOrMaxScaling: true,
+			wantBatchSizes:   []int{5},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider := testprovider.NewTestCloudProvider(nil, nil)
+			provider.AddNodeGroupWithCustomOptions("ng", 0, 10, tc.numNodes, &config.NodeGroupAutoscalingOptions{ZeroOrMaxNodeScaling: tc.zeroOrMaxScaling})
+			nodes := generateNodes(0, tc.numNodes, "ng")
+			for _, node := range nodes {
+				provider.AddNode("ng", node)
+			}
+			ctx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{}, nil, nil, provider, nil, nil)
+			assert.NoError(t, err)
+
+			var gotBatchSizes []int
+			for _, batch := range tc.quota.split(&ctx, nodes) {
+				gotBatchSizes = append(gotBatchSizes, len(batch))
+			}
+			assert.Equal(t, tc.wantBatchSizes, gotBatchSizes)
+		})
+	}
+}
@@ -26,6 +26,7 @@ import (
 	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	kube_errors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/autoscaler/cluster-autoscaler/metrics"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
 	"k8s.io/klog/v2"
@@ -34,6 +35,7 @@ import (
 	acontext "k8s.io/autoscaler/cluster-autoscaler/context"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/daemonset"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	pod_util "k8s.io/autoscaler/cluster-autoscaler/utils/pod"
 )
@@ -57,10 +59,12 @@ type Evictor struct {
 	evictionRegister                 evictionRegister
 	shutdownGracePeriodByPodPriority []kubelet_config.ShutdownGracePeriodByPodPriority
 	fullDsEviction                   bool
+	drainByPodPriority               bool
+	namespaceEvictionLimiter         *namespaceEvictionLimiter
 }
 
 // NewEvictor returns an instance of Evictor.
-func NewEvictor(evictionRegister evictionRegister, shutdownGracePeriodByPodPriority []kubelet_config.ShutdownGracePeriodByPodPriority, fullDsEviction bool) Evictor {
+func NewEvictor(evictionRegister evictionRegister, shutdownGracePeriodByPodPriority []kubelet_config.ShutdownGracePeriodByPodPriority, fullDsEviction bool, drainByPodPriority bool, namespaceEvictionLimits map[string]int) Evictor {
 	sort.Slice(shutdownGracePeriodByPodPriority, func(i, j int) bool {
 		return shutdownGracePeriodByPodPriority[i].Priority < shutdownGracePeriodByPodPriority[j].Priority
 	})
@@ -71,6 +75,8 @@ func NewEvictor(evictionRegister evictionRegister, shutdownGracePeriodByPodPrior
 		evictionRegister:                 evictionRegister,
 		shutdownGracePeriodByPodPriority: shutdownGracePeriodByPodPriority,
 		fullDsEviction:                   fullDsEviction,
+		drainByPodPriority:               drainByPodPriority,
+		namespaceEvictionLimiter:         newNamespaceEvictionLimiter(namespaceEvictionLimits),
 	}
 }
 
@@ -106,10 +112,15 @@ func (e Evictor) EvictDaemonSetPods(ctx *acontext.AutoscalingContext, nodeInfo *
 
 // drainNodeWithPodsBasedOnPodPriority performs drain logic on the node based on pod priorities.
 // Removes all pods, giving each pod group up to ShutdownGracePeriodSeconds to finish. The list of pods to evict has to be provided.
+// If drainByPodPriority is set, pods of each priority form a separate group, so that lower priority pods terminate
+// before higher priority pods are evicted.
 func (e Evictor) drainNodeWithPodsBasedOnPodPriority(ctx *acontext.AutoscalingContext, node *apiv1.Node, fullEvictionPods, bestEffortEvictionPods []*apiv1.Pod, force bool) (map[string]status.PodEvictionResult, error) {
 	evictionResults := make(map[string]status.PodEvictionResult)
 
 	groups := groupByPriority(e.shutdownGracePeriodByPodPriority, fullEvictionPods, bestEffortEvictionPods)
+	if e.drainByPodPriority {
+		groups = splitByPodPriority(groups)
+	}
 	for _, group := range groups {
 		for _, pod := range group.FullEvictionPods {
 			evictionResults[pod.Name] = status.PodEvictionResult{Pod: pod, TimedOut: false,
@@ -142,19 +153,48 @@ func (e Evictor) drainNodeWithPodsBasedOnPodPriority(ctx *acontext.AutoscalingCo
 
 func (e Evictor) waitPodsToDisappear(ctx *acontext.AutoscalingContext, node *apiv1.Node, pods []*apiv1.Pod, evictionResults map[string]status.PodEvictionResult,
 	maxTermination int64) (map[string]status.PodEvictionResult, error) {
+	waitTime := time.Duration(maxTermination)*time.Second + e.PodEvictionHeadroom
+	// Pods from different namespaces may have the same name.
+	forceDeleteDeadlines := make(map[types.NamespacedName]time.Duration)
+	for _, pod := range pods {
+		if after := forceDeleteAfter(ctx, pod); after > 0 {
+			forceDeleteDeadlines[namespacedName(pod)] = after
+			waitTime = max(waitTime, after)
+		}
+	}
+	start := time.Now()
+	forceDeleted := make(map[types.NamespacedName]bool)
+	// forceDeleteIfDue force deletes a pod which is still terminating after its force delete deadline.
+	forceDeleteIfDue := func(pod *apiv1.Pod) bool {
+		key := namespacedName(pod)
+		after, found := forceDeleteDeadlines[key]
+		if found && !forceDeleted[key] && time.Now().Sub(start) >= after && forceDeleteTerminatingPod(ctx, pod, after) == nil {
+			forceDeleted[key] = true
+		}
+		return forceDeleted[key]
+	}
+
+	// Without force delete deadlines, there is no need to check the remaining pods once one of them isn't gone.
+	scanAll := len(forceDeleteDeadlines) > 0
 	var allGone bool
-	for start := time.Now(); time.Now().Sub(start) < time.Duration(maxTermination)*time.Second+e.PodEvictionHeadroom; time.Sleep(5 * time.Second) {
+	for ; time.Now().Sub(start) < waitTime; time.Sleep(5 * time.Second) {
 		allGone = true
 		for _, pod := range pods {
+			if forceDeleted[namespacedName(pod)] {
+				continue
+			}
 			podReturned, err := ctx.ClientSet.CoreV1().Pods(pod.Namespace).Get(context.TODO(), pod.Name, metav1.GetOptions{})
 			if err == nil && (podReturned == nil || podReturned.Spec.NodeName == node.Name) {
+				if forceDeleteIfDue(pod) {
+					continue
+				}
 				klog.V(1).Infof("Not deleted yet %s/%s", pod.Namespace, pod.Name)
 				allGone = false
-				break
-			}
-			if err != nil && !kube_errors.IsNotFound(err) {
+			} else if err != nil && !kube_errors.IsNotFound(err) {
 				klog.Errorf("Failed to check pod %s/%s: %v", pod.Namespace, pod.Name, err)
 				allGone = false
+			}
+			if !allGone && !scanAll {
 				break
 			}
 		}
@@ -163,16 +203,30 @@ func (e Evictor) waitPodsToDisappear(ctx *acontext.AutoscalingContext, node *api
 		}
 	}
 
+	allGone = true
 	for _, pod := range pods {
+		if forceDeleted[namespacedName(pod)] {
+			evictionResults[pod.Name] = status.PodEvictionResult{Pod: pod, TimedOut: false, Err: nil}
+			continue
+		}
 		podReturned, err := ctx.ClientSet.CoreV1().Pods(pod.Namespace).Get(context.TODO(), pod.Name, metav1.GetOptions{})
 		if err == nil && (podReturned == nil || podReturned.Name == "" || podReturned.Spec.NodeName == node.Name) {
+			if forceDeleteIfDue(pod) {
+				evictionResults[pod.Name] = status.PodEvictionResult{Pod: pod, TimedOut: false, Err: nil}
+				continue
+			}
 			evictionResults[pod.Name] = status.PodEvictionResult{Pod: pod, TimedOut: true, Err: nil}
+			allGone = false
 		} else if err != nil && !kube_errors.IsNotFound(err) {
 			evictionResults[pod.Name] = status.PodEvictionResult{Pod: pod, TimedOut: true, Err: err}
+			allGone = false
 		} else {
 			evictionResults[pod.Name] = status.PodEvictionResult{Pod: pod, TimedOut: false, Err: nil}
 		}
 	}
+	if allGone {
+		return evictionResults, nil
+	}
 
 	return evictionResults, errors.NewAutoscalerErrorf(errors.TransientError, "Failed to drain node %s/%s: pods remaining after timeout", node.Namespace, node.Name)
 }
@@ -234,6 +288,14 @@ func (e Evictor) evictPod(ctx *acontext.AutoscalingContext, podToEvict *apiv1.Po
 		termination = maxTermination
 	}
 
+	if err := e.namespaceEvictionLimiter.wait(podToEvict.Namespace, retryUntil); err != nil {
+		klog.Errorf("Skipping eviction of pod %s/%s, eviction limit of its namespace not available within allowed timeout: %v", podToEvict.Namespace, podToEvict.Name, err)
+		if fullEvictionPod {
+			ctx.Recorder.Eventf(podToEvict, apiv1.EventTypeWarning, "ScaleDownFailed", "failed to delete pod for ScaleDown")
+		}
+		return status.PodEvictionResult{Pod: podToEvict, TimedOut: true, Err: fmt.Errorf("eviction limit of namespace %s not available for pod %s within allowed timeout", podToEvict.Namespace, podToEvict.Name)}
+	}
+
 	var lastError error
 	for first := true; first || time.Now().Before(retryUntil); time.Sleep(e.EvictionRetryTime) {
 		first = false
@@ -296,6 +358,44 @@ func forceDeletePod(ctx *acontext.AutoscalingContext, pod *apiv1.Pod) error {
 	return nil
 }
 
+// forceDeleteAfter returns the time after which the evicted pod is force deleted if it's still terminating,
+// or 0 if it shouldn't be force deleted. The time is capped at MaxPodEvictionTime, so that annotations can't
+// make a drain wait longer than that.
+func forceDeleteAfter(ctx *acontext.AutoscalingContext, pod *apiv1.Pod) time.Duration {
+	after := ctx.ForceDeletePodsAfter
+	if value, found := pod.Annotations[drain.PodForceDeleteAfterKey]; found {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			klog.Warningf("Failed to parse %s annotation of pod %s/%s, using the default: %v", drain.PodForceDeleteAfterKey, pod.Namespace, pod.Name, err)
+		} else {
+			after = parsed
+		}
+	}
+	if after > 0 && ctx.MaxPodEvictionTime > 0 && after > ctx.MaxPodEvictionTime {
+		klog.V(1).Infof("Force delete time %v of pod %s/%s exceeds the max pod eviction time, using %v", after, pod.Namespace, pod.Name, ctx.MaxPodEvictionTime)
+		after = ctx.MaxPodEvictionTime
+	}
+	return after
+}
+
+func namespacedName(pod *apiv1.Pod) types.NamespacedName {
+	return types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+}
+
+// forceDeleteTerminatingPod deletes the evicted pod with a zero grace period. Pods blocked by finalizers are
+// considered deleted afterwards, even though their objects remain until the finalizers are removed.
+func forceDeleteTerminatingPod(ctx *acontext.AutoscalingContext, pod *apiv1.Pod, after time.Duration) error {
+	klog.Warningf("Force deleting pod %s/%s, still terminating %v after eviction", pod.Namespace, pod.Name, after)
+	gracePeriod := int64(0)
+	err := ctx.ClientSet.CoreV1().Pods(pod.Namespace).Delete(context.TODO(), pod.Name, metav1.DeleteOptions{GracePeriodSeconds: &gracePeriod})
+	if err != nil && !kube_errors.IsNotFound(err) {
+		klog.Errorf("Failed to force delete pod %s/%s, error: %v", pod.Namespace, pod.Name, err)
+		return err
+	}
+	ctx.Recorder.Eventf(pod, apiv1.EventTypeWarning, "ScaleDownForceDeleted", "force deleted pod still terminating %v after eviction for ScaleDown", after)
+	return nil
+}
+
 type podEvictionGroup struct {
 	kubelet_config.ShutdownGracePeriodByPodPriority
 	FullEvictionPods       []*apiv1.Pod
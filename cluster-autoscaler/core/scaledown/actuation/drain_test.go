@@ -42,6 +42,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot/testsnapshot"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/daemonset"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
 	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
 	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
 	"k8s.io/client-go/kubernetes/fake"
@@ -665,6 +666,226 @@ func assertEvictedPods(t *testing.T, wantEvictedPods []*apiv1.Pod, evictionResul
 	assert.ElementsMatch(t, wantEvictedPods, evicted)
 }
 
+func TestDrainNodeForceDeletesTerminatingPods(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		forceDeletePodsAfter time.Duration
+		annotation           string
+		wantForceDeleted     bool
+	}{
+		{
+			name: "force deletion disabled",
+		},
+		{
+			name:                 "force deletion enabled by flag",
+			forceDeletePodsAfter: time.Nanosecond,
+			wantForceDeleted:     true,
+		},
+		{
+			name:             "force deletion enabled by annotation",
+			annotation:       "1ns",
+			wantForceDeleted: true,
+		},
+		{
+			name:                 "force deletion disabled by annotation",
+			forceDeletePodsAfter: time.Nanosecond,
+			annotation:           "0s",
+		},
+		{
+			name:                 "invalid annotation",
+			forceDeletePodsAfter: time.Nanosecond,
+			annotation:           "soon",
+			wantForceDeleted:     true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			n1 := BuildTestNode("n1", 1000, 1000)
+			SetNodeReadyState(n1, true, time.Time{})
+			p1 := BuildTestPod("p1", 100, 0, WithNodeName(n1.Name))
+			if tc.annotation != "" {
+				p1.Annotations = map[string]string{drain.PodForceDeleteAfterKey: tc.annotation}
+			}
+
+			var forceDeleted []string
+			fakeClient := &fake.Clientset{}
+			fakeClient.Fake.AddReactor("get", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				// The pod is stuck terminating, e.g. because of a finalizer.
+				return true, p1, nil
+			})
+			fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				return true, nil, nil
+			})
+			fakeClient.Fake.AddReactor("delete", "pods", func(action core.Action) (bool, runtime.Object, error) {
+				deleteAction := action.(core.DeleteAction)
+				assert.Equal(t, int64(0), *deleteAction.GetDeleteOptions().GracePeriodSeconds)
+				forceDeleted = append(forceDeleted, deleteAction.GetName())
+				return true, nil, nil
+			})
+
+			options := config.AutoscalingOptions{
+				MaxPodEvictionTime:   5 * time.Second,
+				ForceDeletePodsAfter: tc.forceDeletePodsAfter,
+			}
+			ctx, err := NewScaleTestAutoscalingContext(options, fakeClient, nil, nil, nil, nil)
+			assert.NoError(t, err)
+			evictor := Evictor{
+				EvictionRetryTime:                0,
+				PodEvictionHeadroom:              0,
+				shutdownGracePeriodByPodPriority: SingleRuleDrainConfig(0),
+			}
+			clustersnapshot.InitializeClusterSnapshotOrDie(t, ctx.ClusterSnapshot, []*apiv1.Node{n1}, []*apiv1.Pod{p1})
+			nodeInfo, err := ctx.ClusterSnapshot.GetNodeInfo(n1.Name)
+			assert.NoError(t, err)
+			results, err := evictor.DrainNode(&ctx, nodeInfo)
+			if tc.wantForceDeleted {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"p1"}, forceDeleted)
+				assert.True(t, results["p1"].WasEvictionSuccessful())
+			} else {
+				assert.Error(t, err)
+				assert.Empty(t, forceDeleted)
+				assert.True(t, results["p1"].TimedOut)
+			}
+		})
+	}
+}
+
+func TestDrainNodeForceDeletesPodsByNamespacedName(t *testing.T) {
+	n1 := BuildTestNode("n1", 1000, 1000)
+	SetNodeReadyState(n1, true, time.Time{})
+	p1 := BuildTestPod("p", 100, 0, WithNodeName(n1.Name))
+	p1.Namespace = "ns1"
+	p1.Annotations = map[string]string{drain.PodForceDeleteAfterKey: "1ns"}
+	p2 := BuildTestPod("p", 100, 0, WithNodeName(n1.Name))
+	p2.Namespace = "ns2"
+
+	var forceDeleted []string
+	fakeClient := &fake.Clientset{}
+	fakeClient.Fake.AddReactor("get", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		// Both pods are stuck terminating.
+		if action.GetNamespace() == p1.Namespace {
+			return true, p1, nil
+		}
+		return true, p2, nil
+	})
+	fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		return true, nil, nil
+	})
+	fakeClient.Fake.AddReactor("delete", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		forceDeleted = append(forceDeleted, action.GetNamespace()+"/"+action.(core.DeleteAction).GetName())
+		return true, nil, nil
+	})
+
+	options := config.AutoscalingOptions{
+		MaxPodEvictionTime: 5 * time.Second,
+	}
+	ctx, err := NewScaleTestAutoscalingContext(options, fakeClient, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	evictor := Evictor{
+		shutdownGracePeriodByPodPriority: SingleRuleDrainConfig(0),
+	}
+	clustersnapshot.InitializeClusterSnapshotOrDie(t, ctx.ClusterSnapshot, []*apiv1.Node{n1}, []*apiv1.Pod{p1, p2})
+	nodeInfo, err := ctx.ClusterSnapshot.GetNodeInfo(n1.Name)
+	assert.NoError(t, err)
+	_, err = evictor.DrainNode(&ctx, nodeInfo)
+	// The pod without the annotation is still terminating.
+	assert.Error(t, err)
+	assert.Equal(t, []string{"ns1/p"}, forceDeleted)
+}
+
+func TestDrainNodeStopsCheckingPodsWithoutForceDelete(t *testing.T) {
+	n1 := BuildTestNode("n1", 1000, 1000)
+	SetNodeReadyState(n1, true, time.Time{})
+	pods := []*apiv1.Pod{
+		BuildTestPod("p1", 100, 0, WithNodeName(n1.Name)),
+		BuildTestPod("p2", 100, 0, WithNodeName(n1.Name)),
+		BuildTestPod("p3", 100, 0, WithNodeName(n1.Name)),
+	}
+
+	gets := 0
+	fakeClient := &fake.Clientset{}
+	fakeClient.Fake.AddReactor("get", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		gets++
+		// All pods are stuck terminating.
+		for _, pod := range pods {
+			if pod.Name == action.(core.GetAction).GetName() {
+				return true, pod, nil
+			}
+		}
+		return true, nil, nil
+	})
+	fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		return true, nil, nil
+	})
+
+	options := config.AutoscalingOptions{
+		MaxPodEvictionTime: 5 * time.Second,
+	}
+	ctx, err := NewScaleTestAutoscalingContext(options, fakeClient, nil, nil, nil, nil)
+	assert.NoError(t, err)
+	evictor := Evictor{
+		PodEvictionHeadroom:              time.Second,
+		shutdownGracePeriodByPodPriority: SingleRuleDrainConfig(0),
+	}
+	clustersnapshot.InitializeClusterSnapshotOrDie(t, ctx.ClusterSnapshot, []*apiv1.Node{n1}, pods)
+	nodeInfo, err := ctx.ClusterSnapshot.GetNodeInfo(n1.Name)
+	assert.NoError(t, err)
+	_, err = evictor.DrainNode(&ctx, nodeInfo)
+	assert.Error(t, err)
+	// One pod is checked while waiting, all of them are checked once the wait times out.
+	assert.Equal(t, 1+len(pods), gets)
+}
+
+func TestForceDeleteAfter(t *testing.T) {
+	for _, tc := range []struct {
+		name                 string
+		forceDeletePodsAfter time.Duration
+		annotation           string
+		want                 time.Duration
+	}{
+		{
+			name:                 "flag",
+			forceDeletePodsAfter: time.Minute,
+			want:                 time.Minute,
+		},
+		{
+			name:                 "annotation overrides flag",
+			forceDeletePodsAfter: time.Minute,
+			annotation:           "2m",
+			want:                 2 * time.Minute,
+		},
+		{
+			name:                 "flag capped at max pod eviction time",
+			forceDeletePodsAfter: time.Hour,
+			want:                 5 * time.Minute,
+		},
+		{
+			name:       "annotation capped at max pod eviction time",
+			annotation: "1000h",
+			want:       5 * time.Minute,
+		},
+		{
+			name:                 "disabled by annotation",
+			forceDeletePodsAfter: time.Minute,
+			annotation:           "0s",
+			want:                 0,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := &acontext.AutoscalingContext{AutoscalingOptions: config.AutoscalingOptions{
+				MaxPodEvictionTime:   5 * time.Minute,
+				ForceDeletePodsAfter: tc.forceDeletePodsAfter,
+			}}
+			pod := BuildTestPod("p1", 100, 0)
+			if tc.annotation != "" {
+				pod.Annotations = map[string]string{drain.PodForceDeleteAfterKey: tc.annotation}
+			}
+			assert.Equal(t, tc.want, forceDeleteAfter(ctx, pod))
+		})
+	}
+}
+
 func TestPodsToEvict(t *testing.T) {
 	for tn, tc := range map[string]struct {
 		pods               []*apiv1.Pod
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actuation
+
+import (
+	default_context "context"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/budgets"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/deletiontracker"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	"k8s.io/autoscaler/cluster-autoscaler/observers/nodegroupchange"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules"
+	draprovider "k8s.io/autoscaler/cluster-autoscaler/simulator/dynamicresources/provider"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/options"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	"k8s.io/klog/v2"
+)
+
+// DryRunActuator plans deletions the same way Actuator does, but never taints,
+// drains or deletes nodes. Instead of starting deletions, StartDeletion returns
+// ScaleDownNoNodeDeleted along with nodes that would have been scaled down.
+type DryRunActuator struct {
+	*Actuator
+}
+
+// NewDryRunActuator returns a new instance of DryRunActuator.
+func NewDryRunActuator(ctx *context.AutoscalingContext, scaleStateNotifier nodegroupchange.NodeGroupChangeObserver, ndt *deletiontracker.NodeDeletionTracker, deleteOptions options.NodeDeleteOptions, drainabilityRules rules.Rules, configGetter actuatorNodeGroupConfigGetter, draProvider *draprovider.Provider) *DryRunActuator {
+	return &DryRunActuator{
+		Actuator: NewActuator(ctx, scaleStateNotifier, ndt, deleteOptions, drainabilityRules, configGetter, draProvider),
+	}
+}
+
+// StartDeletion returns nodes that would be scaled down, without deleting them.
+func (a *DryRunActuator) StartDeletion(_ default_context.Context, empty, drain []*apiv1.Node) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError) {
+	return a.planDeletion(empty, drain)
+}
+
+// StartForceDeletion returns nodes that would be forcefully scaled down, without deleting them.
+func (a *DryRunActuator) StartForceDeletion(_ default_context.Context, empty, drain []*apiv1.Node) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError) {
+	return a.planDeletion(empty, drain)
+}
+
+func (a *DryRunActuator) planDeletion(empty, drain []*apiv1.Node) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError) {
+	emptyToDelete, drainToDelete := a.budgetProcessor.CropNodes(a.nodeDeletionTracker, empty, drain)
+	plannedNodes := make([]*status.ScaleDownNode, 0)
+	plannedNodes = append(plannedNodes, a.plannedNodesToReport(emptyToDelete, false)...)
+	plannedNodes = append(plannedNodes, a.plannedNodesToReport(drainToDelete, true)...)
+	return status.ScaleDownNoNodeDeleted, plannedNodes, nil
+}
+
+func (a *DryRunActuator) plannedNodesToReport(nodeGroupViews []*budgets.NodeGroupView, drain bool) []*status.ScaleDownNode {
+	var plannedNodes []*status.ScaleDownNode
+	for _, bucket := range nodeGroupViews {
+		for _, node := range bucket.Nodes {
+			klog.V(1).Infof("Scale-down dry-run: would remove node %q (drain: %v)", node.Name, drain)
+			sdNode, err := a.scaleDownNodeToReport(node, drain)
+			if err != nil {
+				klog.Errorf("Scale-down dry-run: couldn't report node %q: %v", node.Name, err)
+				continue
+			}
+			plannedNodes = append(plannedNodes, sdNode)
+		}
+	}
+	return plannedNodes
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actuation
+
+import (
+	default_context "context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/deletiontracker"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	. "k8s.io/autoscaler/cluster-autoscaler/core/test"
+	"k8s.io/autoscaler/cluster-autoscaler/observers/nodegroupchange"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/options"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+)
+
+func TestDryRunActuator(t *testing.T) {
+	empty := BuildTestNode("empty", 1000, 1000)
+	toDrain := BuildTestNode("to-drain", 1000, 1000)
+	overBudget := BuildTestNode("over-budget", 1000, 1000)
+	pod := BuildTestPod("p1", 100, 0)
+	pod.Spec.NodeName = toDrain.Name
+
+	fakeClient := &fake.Clientset{}
+	fakeClient.Fake.AddReactor("*", "*", func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetVerb() != "get" && action.GetVerb() != "list" {
+			t.Errorf("Unexpected %s of %s during dry-run", action.GetVerb(), action.GetResource().Resource)
+		}
+		return false, nil, nil
+	})
+	provider := testprovider.NewTestCloudProvider(nil, func(nodeGroup string, node string) error {
+		t.Errorf("Unexpected deletion of node %s during dry-run", node)
+		return nil
+	})
+	provider.AddNodeGroup("ng1", 0, 10, 3)
+	for _, node := range []*apiv1.Node{empty, toDrain, overBudget} {
+		provider.AddNode("ng1", node)
+	}
+
+	opts := config.AutoscalingOptions{
+		MaxScaleDownParallelism: 2,
+		MaxDrainParallelism:     1,
+		NodeGroupDefaults:       config.NodeGroupAutoscalingOptions{},
+	}
+	registry := kube_util.NewListerRegistry(nil, nil, kube_util.NewTestPodLister([]*apiv1.Pod{pod}), nil, nil, nil, nil, nil, nil)
+	ctx, err := NewScaleTestAutoscalingContext(opts, fakeClient, registry, provider, nil, nil)
+	assert.NoError(t, err)
+	for _, nodeInfo := range []*framework.NodeInfo{framework.NewTestNodeInfo(empty), framework.NewTestNodeInfo(toDrain, pod), framework.NewTestNodeInfo(overBudget, pod)} {
+		assert.NoError(t, ctx.ClusterSnapshot.AddNodeInfo(nodeInfo))
+	}
+
+	ndt := deletiontracker.NewNodeDeletionTracker(0)
+	deleteOptions := options.NodeDeleteOptions{}
+	actuator := NewDryRunActuator(&ctx, nodegroupchange.NewNodeGroupChangeObserversList(), ndt, deleteOptions, rules.Default(deleteOptions), nodegroupconfig.NewDefaultNodeGroupConfigProcessor(opts.NodeGroupDefaults), nil)
+
+	result, plannedNodes, err := actuator.StartDeletion(default_context.Background(), []*apiv1.Node{empty}, []*apiv1.Node{toDrain, overBudget})
+	assert.NoError(t, err)
+	assert.Equal(t, status.ScaleDownNoNodeDeleted, result)
+	var plannedNames []string
+	for _, sdNode := range plannedNodes {
+		plannedNames = append(plannedNames, sdNode.Node.Name)
+		if sdNode.Node.Name == toDrain.Name {
+			assert.Equal(t, []*apiv1.Pod{pod}, sdNode.EvictedPods)
+		} else {
+			assert.Empty(t, sdNode.EvictedPods)
+		}
+	}
+	assert.Equal(t, []string{"empty", "to-drain"}, plannedNames)
+
+	emptyInProgress, drainInProgress := actuator.CheckStatus().DeletionsInProgress()
+	assert.Empty(t, emptyInProgress)
+	assert.Empty(t, drainInProgress)
+}
@@ -17,8 +17,10 @@ limitations under the License.
 package actuation
 
 import (
+	default_context "context"
 	"sync"
 
+	"go.opentelemetry.io/otel/attribute"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
 	"k8s.io/klog/v2"
@@ -27,13 +29,15 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/config"
 	"k8s.io/autoscaler/cluster-autoscaler/context"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/deletiontracker"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/predelete"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
 	"k8s.io/autoscaler/cluster-autoscaler/metrics"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/tracing"
 )
 
 type batcher interface {
-	AddNodes(nodes []*apiv1.Node, nodeGroup cloudprovider.NodeGroup, drain bool)
+	AddNodes(traceContext default_context.Context, nodes []*apiv1.Node, nodeGroup cloudprovider.NodeGroup, drain bool)
 }
 
 // GroupDeletionScheduler is a wrapper over NodeDeletionBatcher responsible for grouping nodes for deletion
@@ -44,6 +48,7 @@ type GroupDeletionScheduler struct {
 	nodeDeletionTracker *deletiontracker.NodeDeletionTracker
 	nodeDeletionBatcher batcher
 	evictor             Evictor
+	preDeleteHook       predelete.Hook
 	nodeQueue           map[string][]*apiv1.Node
 	failuresForGroup    map[string]bool
 }
@@ -75,19 +80,19 @@ func (ds *GroupDeletionScheduler) ResetAndReportMetrics() {
 }
 
 // ScheduleDeletion schedules deletion of the node. Nodes that should be deleted in groups are queued until whole group is scheduled for deletion,
-// other nodes are passed over to NodeDeletionBatcher immediately.
-func (ds *GroupDeletionScheduler) ScheduleDeletion(nodeInfo *framework.NodeInfo, nodeGroup cloudprovider.NodeGroup, batchSize int, drain bool) {
-	ds.scheduleDeletion(nodeInfo, nodeGroup, batchSize, drain, false)
+// other nodes are passed over to NodeDeletionBatcher immediately. Spans of the drain and deletion are nested in the span of traceContext.
+func (ds *GroupDeletionScheduler) ScheduleDeletion(traceContext default_context.Context, nodeInfo *framework.NodeInfo, nodeGroup cloudprovider.NodeGroup, batchSize int, drain bool) {
+	ds.scheduleDeletion(traceContext, nodeInfo, nodeGroup, batchSize, drain, false)
 }
 
 // scheduleForceDeletion schedules forced node deletion, similar to ScheduleDeletion but bypassing eviction errors and PDB checks.
-func (ds *GroupDeletionScheduler) scheduleForceDeletion(nodeInfo *framework.NodeInfo, nodeGroup cloudprovider.NodeGroup, batchSize int, drain bool) {
-	ds.scheduleDeletion(nodeInfo, nodeGroup, batchSize, drain, true)
+func (ds *GroupDeletionScheduler) scheduleForceDeletion(traceContext default_context.Context, nodeInfo *framework.NodeInfo, nodeGroup cloudprovider.NodeGroup, batchSize int, drain bool) {
+	ds.scheduleDeletion(traceContext, nodeInfo, nodeGroup, batchSize, drain, true)
 }
 
 // scheduleDeletion handles the common logic for scheduling node deletion, supporting
 // both normal and forced deletion based on the 'force' parameter.
-func (ds *GroupDeletionScheduler) scheduleDeletion(nodeInfo *framework.NodeInfo, nodeGroup cloudprovider.NodeGroup, batchSize int, drain bool, force bool) {
+func (ds *GroupDeletionScheduler) scheduleDeletion(traceContext default_context.Context, nodeInfo *framework.NodeInfo, nodeGroup cloudprovider.NodeGroup, batchSize int, drain bool, force bool) {
 	opts, err := nodeGroup.GetOptions(ds.ctx.NodeGroupDefaults)
 	if err != nil && err != cloudprovider.ErrNotImplemented {
 		nodeDeleteResult := status.NodeDeleteResult{ResultType: status.NodeDeleteErrorInternal, Err: errors.NewAutoscalerErrorf(errors.InternalError, "GetOptions returned error %v", err)}
@@ -98,11 +103,15 @@ func (ds *GroupDeletionScheduler) scheduleDeletion(nodeInfo *framework.NodeInfo,
 		opts = &config.NodeGroupAutoscalingOptions{}
 	}
 
-	nodeDeleteResult := ds.prepareNodeForDeletion(nodeInfo, drain, force)
+	nodeDeleteResult := ds.prepareNodeForDeletion(traceContext, nodeInfo, drain, force)
 	if nodeDeleteResult.Err != nil {
 		if force {
 			klog.Infof("Starting force deletion of node %s", nodeInfo.Node().Name)
-			if err := nodeGroup.ForceDeleteNodes([]*apiv1.Node{nodeInfo.Node()}); err != nil {
+			_, span := tracing.Start(traceContext, "NodeGroup.ForceDeleteNodes",
+				attribute.String("node_group", nodeGroup.Id()), attribute.String("node", nodeInfo.Node().Name))
+			err := nodeGroup.ForceDeleteNodes([]*apiv1.Node{nodeInfo.Node()})
+			tracing.End(span, err)
+			if err != nil {
 				focrefulNodeDeleteResult := status.NodeDeleteResult{ResultType: status.NodeDeleteErrorFailedToDelete, Err: err}
 				ds.AbortNodeDeletion(nodeInfo.Node(), nodeGroup.Id(), drain, "forceful node deletion failed", focrefulNodeDeleteResult, true)
 				return
@@ -113,12 +122,15 @@ func (ds *GroupDeletionScheduler) scheduleDeletion(nodeInfo *framework.NodeInfo,
 		}
 	}
 
-	ds.addToBatcher(nodeInfo, nodeGroup, batchSize, drain, opts.ZeroOrMaxNodeScaling)
+	ds.addToBatcher(traceContext, nodeInfo, nodeGroup, batchSize, drain, opts.ZeroOrMaxNodeScaling)
 }
 
 // prepareNodeForDeletion is a long-running operation, so it needs to avoid locking the AtomicDeletionScheduler object
-func (ds *GroupDeletionScheduler) prepareNodeForDeletion(nodeInfo *framework.NodeInfo, drain bool, force bool) status.NodeDeleteResult {
+func (ds *GroupDeletionScheduler) prepareNodeForDeletion(traceContext default_context.Context, nodeInfo *framework.NodeInfo, drain bool, force bool) (result status.NodeDeleteResult) {
 	node := nodeInfo.Node()
+	_, span := tracing.Start(traceContext, "PrepareNodeForDeletion",
+		attribute.String("node", node.Name), attribute.Bool("drain", drain), attribute.Bool("force", force))
+	defer func() { tracing.End(span, result.Err) }()
 	if drain {
 		var evictionResults map[string]status.PodEvictionResult
 		var err error
@@ -139,10 +151,15 @@ func (ds *GroupDeletionScheduler) prepareNodeForDeletion(nodeInfo *framework.Nod
 	if err := WaitForDelayDeletion(node, ds.ctx.ListerRegistry.AllNodeLister(), ds.ctx.AutoscalingOptions.NodeDeletionDelayTimeout); err != nil {
 		return status.NodeDeleteResult{ResultType: status.NodeDeleteErrorFailedToDelete, Err: err}
 	}
+	if ds.preDeleteHook != nil {
+		if err := ds.preDeleteHook.Run(node.Name); err != nil {
+			return status.NodeDeleteResult{ResultType: status.NodeDeleteErrorFailedToDelete, Err: errors.ToAutoscalerError(errors.ApiCallError, err)}
+		}
+	}
 	return status.NodeDeleteResult{ResultType: status.NodeDeleteOk}
 }
 
-func (ds *GroupDeletionScheduler) addToBatcher(nodeInfo *framework.NodeInfo, nodeGroup cloudprovider.NodeGroup, batchSize int, drain, atomic bool) {
+func (ds *GroupDeletionScheduler) addToBatcher(traceContext default_context.Context, nodeInfo *framework.NodeInfo, nodeGroup cloudprovider.NodeGroup, batchSize int, drain, atomic bool) {
 	ds.Lock()
 	defer ds.Unlock()
 	ds.nodeQueue[nodeGroup.Id()] = append(ds.nodeQueue[nodeGroup.Id()], nodeInfo.Node())
@@ -157,7 +174,7 @@ func (ds *GroupDeletionScheduler) addToBatcher(nodeInfo *framework.NodeInfo, nod
 			return
 		}
 	}
-	ds.nodeDeletionBatcher.AddNodes(ds.nodeQueue[nodeGroup.Id()], nodeGroup, drain)
+	ds.nodeDeletionBatcher.AddNodes(traceContext, ds.nodeQueue[nodeGroup.Id()], nodeGroup, drain)
 	ds.nodeQueue[nodeGroup.Id()] = []*apiv1.Node{}
 }
 
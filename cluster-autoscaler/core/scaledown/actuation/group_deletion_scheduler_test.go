@@ -17,6 +17,7 @@ limitations under the License.
 package actuation
 
 import (
+	default_context "context"
 	"fmt"
 	"testing"
 	"time"
@@ -199,11 +200,70 @@ func TestScheduleDeletion(t *testing.T) {
 	}
 }
 
+func TestScheduleDeletionPreDeleteHook(t *testing.T) {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	ng := sizedNodeGroup("ng", 3, false, false)
+	ng.SetCloudProvider(provider)
+	provider.InsertNodeGroup(ng)
+	nodes := generateNodes(0, 2, "ng")
+	for _, node := range nodes {
+		provider.AddNode(ng.Id(), node)
+	}
+
+	batcher := &countingBatcher{}
+	tracker := deletiontracker.NewNodeDeletionTracker(0)
+	podLister := kube_util.NewTestPodLister([]*apiv1.Pod{})
+	pdbLister := kube_util.NewTestPodDisruptionBudgetLister([]*policyv1.PodDisruptionBudget{})
+	dsLister, err := kube_util.NewTestDaemonSetLister([]*appsv1.DaemonSet{})
+	if err != nil {
+		t.Fatalf("Couldn't create daemonset lister")
+	}
+	registry := kube_util.NewListerRegistry(nil, nil, podLister, pdbLister, dsLister, nil, nil, nil, nil)
+	ctx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{}, &fake.Clientset{}, registry, provider, nil, nil)
+	if err != nil {
+		t.Fatalf("Couldn't set up autoscaling context: %v", err)
+	}
+	scheduler := NewGroupDeletionScheduler(&ctx, tracker, batcher, Evictor{EvictionRetryTime: 0, PodEvictionHeadroom: DefaultPodEvictionHeadroom})
+	hook := &fakePreDeleteHook{failFor: nodes[1].Name}
+	scheduler.preDeleteHook = hook
+
+	if err := scheduleAll([]*budgets.NodeGroupView{{Group: ng, Nodes: nodes}}, scheduler); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff([]string{nodes[0].Name, nodes[1].Name}, hook.ran); diff != "" {
+		t.Errorf("Pre-delete hook runs diff (-want +got):\n%s", diff)
+	}
+	if batcher.addedNodes != 1 {
+		t.Errorf("Incorrect number of deleted nodes, want 1 but got %v", batcher.addedNodes)
+	}
+	wantNodeDeleteResults := map[string]status.NodeDeleteResult{
+		nodes[1].Name: {ResultType: status.NodeDeleteErrorFailedToDelete, Err: cmpopts.AnyError},
+	}
+	gotDeletionResult, _ := tracker.DeletionResults()
+	if diff := cmp.Diff(wantNodeDeleteResults, gotDeletionResult, cmpopts.EquateEmpty(), cmpopts.EquateErrors()); diff != "" {
+		t.Errorf("NodeDeleteResults diff (-want +got):\n%s", diff)
+	}
+}
+
+type fakePreDeleteHook struct {
+	failFor string
+	ran     []string
+}
+
+func (h *fakePreDeleteHook) Run(nodeName string) error {
+	h.ran = append(h.ran, nodeName)
+	if nodeName == h.failFor {
+		return fmt.Errorf("simulated pre-delete hook failure")
+	}
+	return nil
+}
+
 type countingBatcher struct {
 	addedNodes int
 }
 
-func (b *countingBatcher) AddNodes(nodes []*apiv1.Node, nodeGroup cloudprovider.NodeGroup, drain bool) {
+func (b *countingBatcher) AddNodes(_ default_context.Context, nodes []*apiv1.Node, nodeGroup cloudprovider.NodeGroup, drain bool) {
 	b.addedNodes += len(nodes)
 }
 
@@ -214,7 +274,7 @@ func scheduleAll(toSchedule []*budgets.NodeGroupView, scheduler *GroupDeletionSc
 			return fmt.Errorf("failed to get target size for node group %q: %s", bucket.Group.Id(), err)
 		}
 		for _, node := range bucket.Nodes {
-			scheduler.ScheduleDeletion(framework.NewTestNodeInfo(node), bucket.Group, bucketSize, false)
+			scheduler.ScheduleDeletion(default_context.Background(), framework.NewTestNodeInfo(node), bucket.Group, bucketSize, false)
 		}
 	}
 	return nil
@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actuation
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/klog/v2"
+)
+
+// allNamespaces is the key of the eviction limit applied to namespaces without their own limit.
+const allNamespaces = "*"
+
+// namespaceEvictionLimiter paces pod evictions per namespace, so that draining many nodes at once
+// doesn't restart too many pods of a single namespace at the same time. It's shared by all drains.
+type namespaceEvictionLimiter struct {
+	sync.Mutex
+	limits   map[string]int
+	limiters map[string]flowcontrol.RateLimiter
+}
+
+// newNamespaceEvictionLimiter returns a limiter enforcing the given per-minute eviction limits, or nil
+// if there are no limits.
+func newNamespaceEvictionLimiter(limits map[string]int) *namespaceEvictionLimiter {
+	if len(limits) == 0 {
+		return nil
+	}
+	return &namespaceEvictionLimiter{
+		limits:   limits,
+		limiters: make(map[string]flowcontrol.RateLimiter),
+	}
+}
+
+// wait blocks until a pod from the namespace can be evicted, or returns an error if it can't be
+// evicted before the deadline.
+func (l *namespaceEvictionLimiter) wait(namespace string, deadline time.Time) error {
+	if l == nil {
+		return nil
+	}
+	limiter := l.limiter(namespace)
+	if limiter == nil || limiter.TryAccept() {
+		return nil
+	}
+	klog.V(2).Infof("Eviction limit reached for namespace %s, delaying eviction", namespace)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	return limiter.Wait(ctx)
+}
+
+func (l *namespaceEvictionLimiter) limiter(namespace string) flowcontrol.RateLimiter {
+	l.Lock()
+	defer l.Unlock()
+	if limiter, found := l.limiters[namespace]; found {
+		return limiter
+	}
+	limit, found := l.limits[namespace]
+	if !found {
+		limit = l.limits[allNamespaces]
+	}
+	var limiter flowcontrol.RateLimiter
+	if limit > 0 {
+		limiter = flowcontrol.NewTokenBucketRateLimiter(float32(limit)/60, limit)
+	}
+	l.limiters[namespace] = limiter
+	return limiter
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actuation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamespaceEvictionLimiter(t *testing.T) {
+	assert.Nil(t, newNamespaceEvictionLimiter(nil))
+
+	l := newNamespaceEvictionLimiter(map[string]int{
+		allNamespaces: 2,
+		"limited":     1,
+		"unlimited":   0,
+	})
+
+	assert.Nil(t, l.limiter("unlimited"))
+
+	limited := l.limiter("limited")
+	assert.True(t, limited.TryAccept())
+	assert.False(t, limited.TryAccept())
+	assert.Same(t, limited, l.limiter("limited"))
+
+	other := l.limiter("other")
+	assert.True(t, other.TryAccept())
+	assert.True(t, other.TryAccept())
+	assert.False(t, other.TryAccept())
+	assert.NotSame(t, other, l.limiter("another"))
+}
+
+func TestNamespaceEvictionLimiterWait(t *testing.T) {
+	var nilLimiter *namespaceEvictionLimiter
+	assert.NoError(t, nilLimiter.wait("ns", time.Now()))
+
+	l := newNamespaceEvictionLimiter(map[string]int{"limited": 1})
+	assert.NoError(t, l.wait("limited", time.Now().Add(time.Second)))
+	assert.NoError(t, l.wait("unlimited", time.Now()))
+
+	// The next token is available in a minute, so waiting for it past the deadline fails right away.
+	start := time.Now()
+	assert.Error(t, l.wait("limited", start.Add(time.Second)))
+	assert.Less(t, time.Since(start), time.Second)
+}
@@ -43,11 +43,68 @@ func groupByPriority(shutdownGracePeriodByPodPriority []kubelet_config.ShutdownG
 	return groups
 }
 
-func groupIndex(pod *apiv1.Pod, groups []podEvictionGroup) int {
-	var priority int32
+// splitByPodPriority splits each group into groups of pods with the same priority, in the ascending order of
+// priorities. The shutdown grace period of a group is split between the groups it was split into, higher priorities
+// getting the remainder, so that draining them in turn doesn't take longer than the grace period of the group. If
+// the grace period is shorter than a second per priority, the lowest priorities are kept together in the first group.
+func splitByPodPriority(groups []podEvictionGroup) []podEvictionGroup {
+	var result []podEvictionGroup
+	for _, group := range groups {
+		byPriority := make(map[int32]*podEvictionGroup)
+		var priorities []int32
+		subgroup := func(pod *apiv1.Pod) *podEvictionGroup {
+			priority := podPriority(pod)
+			if _, found := byPriority[priority]; !found {
+				byPriority[priority] = &podEvictionGroup{ShutdownGracePeriodByPodPriority: group.ShutdownGracePeriodByPodPriority}
+				priorities = append(priorities, priority)
+			}
+			return byPriority[priority]
+		}
+		for _, pod := range group.FullEvictionPods {
+			sg := subgroup(pod)
+			sg.FullEvictionPods = append(sg.FullEvictionPods, pod)
+		}
+		for _, pod := range group.BestEffortEvictionPods {
+			sg := subgroup(pod)
+			sg.BestEffortEvictionPods = append(sg.BestEffortEvictionPods, pod)
+		}
+		sort.Slice(priorities, func(i, j int) bool { return priorities[i] < priorities[j] })
+
+		var subgroups []podEvictionGroup
+		gracePeriod := group.ShutdownGracePeriodSeconds
+		for i, priority := range priorities {
+			sg := byPriority[priority]
+			if gracePeriod > 0 && int64(len(priorities)-i) >= gracePeriod && len(subgroups) > 0 {
+				merged := &subgroups[0]
+				merged.FullEvictionPods = append(merged.FullEvictionPods, sg.FullEvictionPods...)
+				merged.BestEffortEvictionPods = append(merged.BestEffortEvictionPods, sg.BestEffortEvictionPods...)
+				continue
+			}
+			subgroups = append(subgroups, *sg)
+		}
+		if gracePeriod > 0 {
+			count := int64(len(subgroups))
+			for i := range subgroups {
+				subgroups[i].ShutdownGracePeriodSeconds = gracePeriod / count
+				if int64(i) >= count-gracePeriod%count {
+					subgroups[i].ShutdownGracePeriodSeconds++
+				}
+			}
+		}
+		result = append(result, subgroups...)
+	}
+	return result
+}
+
+func podPriority(pod *apiv1.Pod) int32 {
 	if pod.Spec.Priority != nil {
-		priority = *pod.Spec.Priority
+		return *pod.Spec.Priority
 	}
+	return 0
+}
+
+func groupIndex(pod *apiv1.Pod, groups []podEvictionGroup) int {
+	priority := podPriority(pod)
 
 	// Find the group index according to the priority.
 	index := sort.Search(len(groups), func(i int) bool {
@@ -183,3 +183,87 @@ func TestGroupByPriority(t *testing.T) {
 	groups := groupByPriority(shutdownGracePeriodByPodPriority, []*apiv1.Pod{p1, p2, p3, p4, p5}, []*apiv1.Pod{p6, p7, p8, p9, p10})
 	assert.Equal(t, wantGroups, groups)
 }
+
+func TestSplitByPodPriority(t *testing.T) {
+	p1 := BuildTestPod("p1", 100, 0)
+	p2 := BuildTestPod("p2", 300, 0)
+	p3 := BuildTestPod("p3", 150, 0)
+	p4 := BuildTestPod("p4", 100, 0)
+	p5 := BuildTestPod("p5", 300, 0)
+
+	priority100 := int32(100)
+	priority500 := int32(500)
+	priority2000 := int32(2000)
+	p1.Spec.Priority = &priority500
+	p2.Spec.Priority = &priority100
+	p3.Spec.Priority = &priority500
+	p4.Spec.Priority = &priority2000
+
+	shutdownGracePeriodByPodPriority := []kubelet_config.ShutdownGracePeriodByPodPriority{
+		{
+			Priority:                   0,
+			ShutdownGracePeriodSeconds: 10,
+		},
+		{
+			Priority:                   1000,
+			ShutdownGracePeriodSeconds: 2,
+		},
+	}
+
+	groups := []podEvictionGroup{
+		{
+			ShutdownGracePeriodByPodPriority: shutdownGracePeriodByPodPriority[0],
+			FullEvictionPods:                 []*apiv1.Pod{p1, p2},
+			BestEffortEvictionPods:           []*apiv1.Pod{p3, p5},
+		},
+		{
+			ShutdownGracePeriodByPodPriority: shutdownGracePeriodByPodPriority[1],
+			FullEvictionPods:                 []*apiv1.Pod{p4},
+		},
+	}
+
+	withGracePeriod := func(period kubelet_config.ShutdownGracePeriodByPodPriority, seconds int64) kubelet_config.ShutdownGracePeriodByPodPriority {
+		period.ShutdownGracePeriodSeconds = seconds
+		return period
+	}
+	wantGroups := []podEvictionGroup{
+		{
+			ShutdownGracePeriodByPodPriority: withGracePeriod(shutdownGracePeriodByPodPriority[0], 3),
+			BestEffortEvictionPods:           []*apiv1.Pod{p5},
+		},
+		{
+			ShutdownGracePeriodByPodPriority: withGracePeriod(shutdownGracePeriodByPodPriority[0], 3),
+			FullEvictionPods:                 []*apiv1.Pod{p2},
+		},
+		{
+			ShutdownGracePeriodByPodPriority: withGracePeriod(shutdownGracePeriodByPodPriority[0], 4),
+			FullEvictionPods:                 []*apiv1.Pod{p1},
+			BestEffortEvictionPods:           []*apiv1.Pod{p3},
+		},
+		{
+			ShutdownGracePeriodByPodPriority: shutdownGracePeriodByPodPriority[1],
+			FullEvictionPods:                 []*apiv1.Pod{p4},
+		},
+	}
+	assert.Equal(t, wantGroups, splitByPodPriority(groups))
+
+	// With a grace period shorter than a second per priority, the lowest priorities are evicted together.
+	groups[0].ShutdownGracePeriodSeconds = 2
+	wantGroups = []podEvictionGroup{
+		{
+			ShutdownGracePeriodByPodPriority: withGracePeriod(shutdownGracePeriodByPodPriority[0], 1),
+			FullEvictionPods:                 []*apiv1.Pod{p2},
+			BestEffortEvictionPods:           []*apiv1.Pod{p5},
+		},
+		{
+			ShutdownGracePeriodByPodPriority: withGracePeriod(shutdownGracePeriodByPodPriority[0], 1),
+			FullEvictionPods:                 []*apiv1.Pod{p1},
+			BestEffortEvictionPods:           []*apiv1.Pod{p3},
+		},
+		{
+			ShutdownGracePeriodByPodPriority: shutdownGracePeriodByPodPriority[1],
+			FullEvictionPods:                 []*apiv1.Pod{p4},
+		},
+	}
+	assert.Equal(t, wantGroups, splitByPodPriority(groups))
+}
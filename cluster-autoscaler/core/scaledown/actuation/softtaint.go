@@ -71,6 +71,30 @@ func UpdateSoftDeletionTaints(context *context.AutoscalingContext, uneededNodes,
 	return
 }
 
+// UpdateSoftTaintOnlyCordons cordons unneeded nodes from node groups which only soft-taint and cordon them
+// instead of deleting them, and uncordons such nodes once they're needed again.
+func UpdateSoftTaintOnlyCordons(context *context.AutoscalingContext, unneededNodes, neededNodes []*apiv1.Node) (errors []error) {
+	for _, node := range neededNodes {
+		if !taints.IsCordonedDeletionCandidate(node) {
+			continue
+		}
+		if _, err := taints.UncordonDeletionCandidate(node, context.ClientSet); err != nil {
+			errors = append(errors, err)
+			klog.Warningf("Uncordoning soft tainted %s error %v", node.Name, err)
+		}
+	}
+	for _, node := range unneededNodes {
+		if taints.HasToBeDeletedTaint(node) || taints.IsCordonedDeletionCandidate(node) {
+			continue
+		}
+		if _, err := taints.CordonDeletionCandidate(node, context.ClientSet); err != nil {
+			errors = append(errors, err)
+			klog.Warningf("Cordoning soft tainted %s error %v", node.Name, err)
+		}
+	}
+	return
+}
+
 // Get current time. Proxy for unit tests.
 var now func() time.Time = time.Now
 
@@ -195,6 +195,27 @@ func TestSoftTaintTimeLimit(t *testing.T) {
 	assert.Equal(t, 0, countDeletionCandidateTaints(t, fakeClient))
 }
 
+func TestSoftTaintOnlyCordons(t *testing.T) {
+	n1 := BuildTestNode("n1", 1000, 1000)
+	n2 := BuildTestNode("n2", 1000, 1000)
+	n2.Spec.Unschedulable = true
+	n2.Spec.Taints = []apiv1.Taint{{Key: taints.DeletionCandidateTaint, Effect: apiv1.TaintEffectPreferNoSchedule}}
+	fakeClient := fake.NewSimpleClientset(n1, n2)
+
+	actx, err := test.NewScaleTestAutoscalingContext(config.AutoscalingOptions{}, fakeClient, nil, testprovider.NewTestCloudProvider(nil, nil), nil, nil)
+	assert.NoError(t, err)
+
+	errs := UpdateSoftTaintOnlyCordons(&actx, []*apiv1.Node{n1}, []*apiv1.Node{n2})
+	assert.Empty(t, errs)
+	assert.True(t, taints.IsCordonedDeletionCandidate(getNode(t, fakeClient, "n1")))
+	assert.False(t, getNode(t, fakeClient, "n2").Spec.Unschedulable)
+	assert.True(t, hasDeletionCandidateTaint(t, fakeClient, "n2"))
+
+	errs = UpdateSoftTaintOnlyCordons(&actx, nil, getAllNodes(t, fakeClient))
+	assert.Empty(t, errs)
+	assert.False(t, getNode(t, fakeClient, "n1").Spec.Unschedulable)
+}
+
 func countDeletionCandidateTaints(t *testing.T, client kubernetes.Interface) (total int) {
 	t.Helper()
 	for _, node := range getAllNodes(t, client) {
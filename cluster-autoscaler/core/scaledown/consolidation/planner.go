@@ -0,0 +1,325 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consolidation
+
+import (
+	ctx "context"
+	"fmt"
+	"sort"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/options"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/utilization"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
+	klog "k8s.io/klog/v2"
+)
+
+// Consolidation is a replacement of multiple nodes with a single node from another node group.
+type Consolidation struct {
+	// Nodes are the nodes to be drained once the replacement node is ready.
+	Nodes []*apiv1.Node
+	// NodeGroup is the node group the replacement node is created in.
+	NodeGroup cloudprovider.NodeGroup
+	// StartTime is the time the replacement node was requested.
+	StartTime time.Time
+	// knownInstances are ids of the instances in NodeGroup before the scale-up.
+	knownInstances map[string]bool
+}
+
+// Planner proposes replacing multiple underutilized nodes, whose pods can't be moved to
+// other existing nodes, with a single node from another node group. The replacement node
+// is created first, the replaced nodes are drained only once it is ready.
+type Planner struct {
+	context             *context.AutoscalingContext
+	scaleUpOrchestrator scaleup.Orchestrator
+	deleteOptions       options.NodeDeleteOptions
+	drainabilityRules   rules.Rules
+	maxNodes            int
+	pending             *Consolidation
+}
+
+// NewPlanner creates a new Planner replacing at most maxNodes nodes at once. Replacement
+// nodes are requested through the scale-up orchestrator.
+func NewPlanner(context *context.AutoscalingContext, scaleUpOrchestrator scaleup.Orchestrator, deleteOptions options.NodeDeleteOptions, drainabilityRules rules.Rules, maxNodes int) *Planner {
+	return &Planner{
+		context:             context,
+		scaleUpOrchestrator: scaleUpOrchestrator,
+		deleteOptions:       deleteOptions,
+		drainabilityRules:   drainabilityRules,
+		maxNodes:            maxNodes,
+	}
+}
+
+// Candidates returns nodes that can't be removed only because their pods don't fit on
+// other nodes, ordered by ascending utilization.
+func Candidates(unremovableNodes []*simulator.UnremovableNode, utilizationMap map[string]utilization.Info) []*apiv1.Node {
+	var candidates []*apiv1.Node
+	for _, unremovable := range unremovableNodes {
+		if unremovable.Reason == simulator.NoPlaceToMovePods {
+			candidates = append(candidates, unremovable.Node)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return utilizationMap[candidates[i].Name].Utilization < utilizationMap[candidates[j].Name].Utilization
+	})
+	return candidates
+}
+
+// InProgress returns true if a replacement node was requested, but the replaced nodes aren't drained yet.
+func (p *Planner) InProgress() bool {
+	return p.pending != nil
+}
+
+// StartConsolidation looks for a consolidation of the candidates and, if one is found,
+// scales up the node group of the replacement node. The scale-up is subject to the same
+// backoff, limit and cost budget checks as regular scale-ups, the consolidation isn't
+// started if it's rejected. Spans of the scale-up are nested in the span of traceContext.
+func (p *Planner) StartConsolidation(traceContext ctx.Context, candidates []*apiv1.Node, nodes []*apiv1.Node, nodeInfosForGroups map[string]*framework.NodeInfo, now time.Time) errors.AutoscalerError {
+	if p.pending != nil {
+		return nil
+	}
+	consolidation, err := p.Propose(candidates, nodeInfosForGroups, now)
+	if err != nil {
+		return errors.ToAutoscalerError(errors.InternalError, err)
+	}
+	if consolidation == nil {
+		return nil
+	}
+	instances, err := consolidation.NodeGroup.Nodes()
+	if err != nil {
+		return errors.ToAutoscalerError(errors.CloudProviderError, err).AddPrefix("failed to list instances of %s: ", consolidation.NodeGroup.Id())
+	}
+	consolidation.knownInstances = make(map[string]bool, len(instances))
+	for _, instance := range instances {
+		consolidation.knownInstances[instance.Id] = true
+	}
+	klog.V(0).Infof("Consolidation: replacing %d nodes with a new node from %s", len(consolidation.Nodes), consolidation.NodeGroup.Id())
+	scaleUpStatus, aErr := p.scaleUpOrchestrator.ScaleUpNodeGroup(traceContext, consolidation.NodeGroup, 1, nodes, nodeInfosForGroups)
+	if aErr != nil {
+		return aErr.AddPrefix("failed to scale up %s: ", consolidation.NodeGroup.Id())
+	}
+	if scaleUpStatus == nil || scaleUpStatus.Result != status.ScaleUpSuccessful {
+		klog.V(1).Infof("Consolidation: scale-up of %s not possible, not replacing nodes", consolidation.NodeGroup.Id())
+		return nil
+	}
+	p.context.LogRecorder.Eventf(apiv1.EventTypeNormal, "ScaledUpGroup", "Consolidation: group %s scaled up to replace %d nodes", consolidation.NodeGroup.Id(), len(consolidation.Nodes))
+	p.pending = consolidation
+	return nil
+}
+
+// NodesToDrain returns the nodes replaced by the pending consolidation once the
+// replacement node is ready. The pending consolidation is dropped after it's returned,
+// or if the replacement node doesn't become ready within max node provision time.
+func (p *Planner) NodesToDrain(allNodes []*apiv1.Node, now time.Time) []*apiv1.Node {
+	if p.pending == nil {
+		return nil
+	}
+	maxNodeProvisionTime := p.context.NodeGroupDefaults.MaxNodeProvisionTime
+	if maxNodeProvisionTime > 0 && now.Sub(p.pending.StartTime) > maxNodeProvisionTime {
+		klog.Warningf("Consolidation: replacement node from %s not ready after %v, giving up", p.pending.NodeGroup.Id(), maxNodeProvisionTime)
+		p.pending = nil
+		return nil
+	}
+	if !p.replacementReady(allNodes) {
+		return nil
+	}
+	existing := make(map[string]bool, len(allNodes))
+	for _, node := range allNodes {
+		existing[node.Name] = true
+	}
+	var nodes []*apiv1.Node
+	for _, node := range p.pending.Nodes {
+		if existing[node.Name] {
+			nodes = append(nodes, node)
+		}
+	}
+	p.pending = nil
+	return nodes
+}
+
+func (p *Planner) replacementReady(allNodes []*apiv1.Node) bool {
+	for _, node := range allNodes {
+		if p.pending.knownInstances[node.Spec.ProviderID] {
+			continue
+		}
+		if ready, _, _ := kube_util.GetReadinessState(node); !ready {
+			continue
+		}
+		nodeGroup, err := p.context.CloudProvider.NodeGroupForNode(node)
+		if err != nil || nodeGroup == nil {
+			continue
+		}
+		if nodeGroup.Id() == p.pending.NodeGroup.Id() {
+			return true
+		}
+	}
+	return false
+}
+
+// Propose returns the consolidation replacing the most nodes, or saving the most if
+// pricing is available, or nil if no node group can replace at least two candidates.
+// Candidates are considered in the given order.
+func (p *Planner) Propose(candidates []*apiv1.Node, nodeInfosForGroups map[string]*framework.NodeInfo, now time.Time) (*Consolidation, error) {
+	candidateGroups := make(map[string]cloudprovider.NodeGroup)
+	var validCandidates []*apiv1.Node
+	for _, candidate := range candidates {
+		if taints.HasToBeDeletedTaint(candidate) {
+			continue
+		}
+		nodeGroup, err := p.context.CloudProvider.NodeGroupForNode(candidate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find node group for %s: %v", candidate.Name, err)
+		}
+		if nodeGroup == nil || nodeGroup.Id() == "" {
+			continue
+		}
+		candidateGroups[candidate.Name] = nodeGroup
+		validCandidates = append(validCandidates, candidate)
+	}
+	if len(validCandidates) < 2 {
+		return nil, nil
+	}
+
+	pricing, pricingErr := p.context.CloudProvider.Pricing()
+	var best *Consolidation
+	var bestSavings float64
+	for _, nodeGroup := range p.context.CloudProvider.NodeGroups() {
+		template, found := nodeInfosForGroups[nodeGroup.Id()]
+		if !found {
+			continue
+		}
+		targetSize, err := nodeGroup.TargetSize()
+		if err != nil {
+			klog.Warningf("Consolidation: failed to get target size of %s: %v", nodeGroup.Id(), err)
+			continue
+		}
+		if targetSize >= nodeGroup.MaxSize() {
+			continue
+		}
+		nodes, err := p.fit(template, validCandidates, candidateGroups, nodeGroup, now)
+		if err != nil {
+			return nil, err
+		}
+		if len(nodes) < 2 {
+			continue
+		}
+		consolidation := &Consolidation{Nodes: nodes, NodeGroup: nodeGroup, StartTime: now}
+		if pricingErr == nil {
+			savings, err := savings(pricing, nodes, template.Node(), now)
+			if err != nil {
+				klog.Warningf("Consolidation: failed to price replacing nodes with %s: %v", nodeGroup.Id(), err)
+				continue
+			}
+			if savings <= 0 || (best != nil && savings <= bestSavings) {
+				continue
+			}
+			best, bestSavings = consolidation, savings
+		} else if best == nil || len(nodes) > len(best.Nodes) {
+			best = consolidation
+		}
+	}
+	return best, nil
+}
+
+// fit returns the candidates, outside of the given node group, whose pods all fit on a single
+// new node created from the template.
+func (p *Planner) fit(template *framework.NodeInfo, candidates []*apiv1.Node, candidateGroups map[string]cloudprovider.NodeGroup, nodeGroup cloudprovider.NodeGroup, now time.Time) ([]*apiv1.Node, error) {
+	snapshot := p.context.ClusterSnapshot
+	snapshot.Fork()
+	defer snapshot.Revert()
+
+	newNodeInfo, err := simulator.SanitizedNodeInfo(template, "consolidation")
+	if err != nil {
+		return nil, err
+	}
+	if err := snapshot.AddNodeInfo(newNodeInfo); err != nil {
+		return nil, err
+	}
+	newNodeName := newNodeInfo.Node().Name
+
+	var nodes []*apiv1.Node
+	removed := make(map[string]int)
+	for _, candidate := range candidates {
+		if p.maxNodes > 0 && len(nodes) >= p.maxNodes {
+			break
+		}
+		candidateGroup := candidateGroups[candidate.Name]
+		if candidateGroup.Id() == nodeGroup.Id() {
+			continue
+		}
+		size, err := candidateGroup.TargetSize()
+		if err != nil || size-removed[candidateGroup.Id()] <= candidateGroup.MinSize() {
+			continue
+		}
+		nodeInfo, err := snapshot.GetNodeInfo(candidate.Name)
+		if err != nil {
+			continue
+		}
+		pods, _, blockingPod, err := simulator.GetPodsToMove(nodeInfo, p.deleteOptions, p.drainabilityRules, p.context.ListerRegistry, nil, now)
+		if err != nil || blockingPod != nil {
+			continue
+		}
+		schedulingErr, cleanupErr := clustersnapshot.WithForkedSnapshot(snapshot, func() (bool, error) {
+			for _, pod := range pods {
+				if err := snapshot.UnschedulePod(pod.Namespace, pod.Name, candidate.Name); err != nil {
+					return false, err
+				}
+				newPod := pod.DeepCopy()
+				newPod.Spec.NodeName = ""
+				if err := snapshot.SchedulePod(newPod, newNodeName); err != nil {
+					return false, err
+				}
+			}
+			return true, nil
+		})
+		if schedulingErr != nil || cleanupErr != nil {
+			continue
+		}
+		nodes = append(nodes, candidate)
+		removed[candidateGroup.Id()]++
+	}
+	return nodes, nil
+}
+
+// savings returns the difference between the hourly price of the replaced nodes and the replacement node.
+func savings(pricing cloudprovider.PricingModel, nodes []*apiv1.Node, replacement *apiv1.Node, now time.Time) (float64, error) {
+	end := now.Add(time.Hour)
+	var total float64
+	for _, node := range nodes {
+		price, err := pricing.NodePrice(node, now, end)
+		if err != nil {
+			return 0, err
+		}
+		total += price
+	}
+	price, err := pricing.NodePrice(replacement, now, end)
+	if err != nil {
+		return 0, err
+	}
+	return total - price, nil
+}
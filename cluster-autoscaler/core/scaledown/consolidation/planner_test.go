@@ -0,0 +1,272 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consolidation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	. "k8s.io/autoscaler/cluster-autoscaler/core/test"
+	"k8s.io/autoscaler/cluster-autoscaler/core/test/scaleuptest"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/options"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/utilization"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+type fakePricingModel struct {
+	// prices are hourly prices of nodes, by node name prefix.
+	prices map[string]float64
+}
+
+func (m *fakePricingModel) NodePrice(node *apiv1.Node, _, _ time.Time) (float64, error) {
+	for prefix, price := range m.prices {
+		if strings.HasPrefix(node.Name, prefix) {
+			return price, nil
+		}
+	}
+	return 0, fmt.Errorf("no price for %s", node.Name)
+}
+
+func (m *fakePricingModel) PodPrice(_ *apiv1.Pod, _, _ time.Time) (float64, error) {
+	return 0, nil
+}
+
+type testNodeGroup struct {
+	id                       string
+	min, max, size           int
+	templateCpu, templateMem int64
+}
+
+func TestPropose(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		podCpu    int64
+		sourceMin int
+		targets   []testNodeGroup
+		prices    map[string]float64
+		maxNodes  int
+		wantGroup string
+		wantNodes []string
+	}{
+		{
+			name:      "all nodes replaced",
+			podCpu:    300,
+			targets:   []testNodeGroup{{id: "big", max: 5, templateCpu: 2000, templateMem: 2000}},
+			wantGroup: "big",
+			wantNodes: []string{"n1", "n2", "n3"},
+		},
+		{
+			name:      "limited by max nodes",
+			podCpu:    300,
+			targets:   []testNodeGroup{{id: "big", max: 5, templateCpu: 2000, templateMem: 2000}},
+			maxNodes:  2,
+			wantGroup: "big",
+			wantNodes: []string{"n1", "n2"},
+		},
+		{
+			name:      "only some pods fit",
+			podCpu:    800,
+			targets:   []testNodeGroup{{id: "big", max: 5, templateCpu: 2000, templateMem: 2000}},
+			wantGroup: "big",
+			wantNodes: []string{"n1", "n2"},
+		},
+		{
+			name:    "replacement too small",
+			podCpu:  800,
+			targets: []testNodeGroup{{id: "big", max: 5, templateCpu: 1000, templateMem: 2000}},
+		},
+		{
+			name:    "target node group at max size",
+			podCpu:  300,
+			targets: []testNodeGroup{{id: "big", max: 1, size: 1, templateCpu: 2000, templateMem: 2000}},
+		},
+		{
+			name:      "source node group min size",
+			podCpu:    300,
+			sourceMin: 2,
+			targets:   []testNodeGroup{{id: "big", max: 5, templateCpu: 2000, templateMem: 2000}},
+		},
+		{
+			name:   "cheapest replacement",
+			podCpu: 300,
+			targets: []testNodeGroup{
+				{id: "big", max: 5, templateCpu: 2000, templateMem: 2000},
+				{id: "bigger", max: 5, templateCpu: 4000, templateMem: 4000},
+			},
+			prices:    map[string]float64{"n": 1, "big-": 2.5, "bigger-": 1.5},
+			wantGroup: "bigger",
+			wantNodes: []string{"n1", "n2", "n3"},
+		},
+		{
+			name:    "replacement more expensive",
+			podCpu:  300,
+			targets: []testNodeGroup{{id: "big", max: 5, templateCpu: 2000, templateMem: 2000}},
+			prices:  map[string]float64{"n": 1, "big-": 4},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			provider := testprovider.NewTestCloudProvider(nil, nil)
+			if tc.prices != nil {
+				provider.SetPricingModel(&fakePricingModel{prices: tc.prices})
+			}
+			provider.AddNodeGroup("small", tc.sourceMin, 10, 3)
+			var nodes []*apiv1.Node
+			var pods []*apiv1.Pod
+			for i := 1; i <= 3; i++ {
+				node := BuildTestNode(fmt.Sprintf("n%d", i), 1000, 1000)
+				SetNodeReadyState(node, true, time.Time{})
+				provider.AddNode("small", node)
+				nodes = append(nodes, node)
+				pod := BuildTestPod(fmt.Sprintf("p%d", i), tc.podCpu, 100, WithNodeName(node.Name))
+				pods = append(pods, SetRSPodSpec(pod, "rs"))
+			}
+			nodeInfosForGroups := make(map[string]*framework.NodeInfo)
+			for _, target := range tc.targets {
+				provider.AddNodeGroup(target.id, target.min, target.max, target.size)
+				template := BuildTestNode(target.id+"-template", target.templateCpu, target.templateMem)
+				SetNodeReadyState(template, true, time.Time{})
+				nodeInfosForGroups[target.id] = framework.NewTestNodeInfo(template)
+			}
+
+			ctx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{}, nil, nil, provider, nil, nil)
+			assert.NoError(t, err)
+			clustersnapshot.InitializeClusterSnapshotOrDie(t, ctx.ClusterSnapshot, nodes, pods)
+
+			p := NewPlanner(&ctx, &scaleuptest.FakeOrchestrator{}, options.NodeDeleteOptions{}, nil, tc.maxNodes)
+			consolidation, err := p.Propose(nodes, nodeInfosForGroups, time.Now())
+			assert.NoError(t, err)
+			if tc.wantGroup == "" {
+				assert.Nil(t, consolidation)
+				return
+			}
+			if assert.NotNil(t, consolidation) {
+				assert.Equal(t, tc.wantGroup, consolidation.NodeGroup.Id())
+				var gotNodes []string
+				for _, node := range consolidation.Nodes {
+					gotNodes = append(gotNodes, node.Name)
+				}
+				assert.Equal(t, tc.wantNodes, gotNodes)
+			}
+			// The simulation must not leave any changes in the snapshot.
+			nodeInfos, err := ctx.ClusterSnapshot.ListNodeInfos()
+			assert.NoError(t, err)
+			assert.Len(t, nodeInfos, 3)
+		})
+	}
+}
+
+func TestStartConsolidation(t *testing.T) {
+	now := time.Now()
+	for _, tc := range []struct {
+		name             string
+		rejectScaleUp    bool
+		replacementReady bool
+		drainTime        time.Time
+		wantNodesToDrain []string
+		wantInProgress   bool
+	}{
+		{
+			name:          "scale-up rejected",
+			rejectScaleUp: true,
+		},
+		{
+			name:             "replacement node ready",
+			replacementReady: true,
+			drainTime:        now.Add(time.Minute),
+			wantNodesToDrain: []string{"n1", "n2"},
+		},
+		{
+			name:           "replacement node not ready",
+			drainTime:      now.Add(time.Minute),
+			wantInProgress: true,
+		},
+		{
+			name:             "replacement node timed out",
+			replacementReady: true,
+			drainTime:        now.Add(time.Hour),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var scaleUps []string
+			provider := testprovider.NewTestCloudProvider(func(nodeGroup string, delta int) error {
+				scaleUps = append(scaleUps, fmt.Sprintf("%s:%d", nodeGroup, delta))
+				return nil
+			}, nil)
+			provider.AddNodeGroup("small", 0, 10, 2)
+			provider.AddNodeGroup("big", 0, 10, 0)
+			var nodes []*apiv1.Node
+			var pods []*apiv1.Pod
+			for i := 1; i <= 2; i++ {
+				node := BuildTestNode(fmt.Sprintf("n%d", i), 1000, 1000)
+				SetNodeReadyState(node, true, time.Time{})
+				provider.AddNode("small", node)
+				nodes = append(nodes, node)
+				pod := BuildTestPod(fmt.Sprintf("p%d", i), 600, 100, WithNodeName(node.Name))
+				pods = append(pods, SetRSPodSpec(pod, "rs"))
+			}
+			template := BuildTestNode("big-template", 2000, 2000)
+			SetNodeReadyState(template, true, time.Time{})
+			nodeInfosForGroups := map[string]*framework.NodeInfo{"big": framework.NewTestNodeInfo(template)}
+
+			autoscalingOptions := config.AutoscalingOptions{NodeGroupDefaults: config.NodeGroupAutoscalingOptions{MaxNodeProvisionTime: 15 * time.Minute}}
+			ctx, err := NewScaleTestAutoscalingContext(autoscalingOptions, nil, nil, provider, nil, nil)
+			assert.NoError(t, err)
+			clustersnapshot.InitializeClusterSnapshotOrDie(t, ctx.ClusterSnapshot, nodes, pods)
+
+			unremovable := []*simulator.UnremovableNode{
+				{Node: nodes[1], Reason: simulator.NoPlaceToMovePods},
+				{Node: nodes[0], Reason: simulator.NoPlaceToMovePods},
+			}
+			utilizationMap := map[string]utilization.Info{"n1": {Utilization: 0.6}, "n2": {Utilization: 0.7}}
+			p := NewPlanner(&ctx, &scaleuptest.FakeOrchestrator{RejectAll: tc.rejectScaleUp}, options.NodeDeleteOptions{}, nil, 5)
+			assert.NoError(t, p.StartConsolidation(context.Background(), Candidates(unremovable, utilizationMap), nodes, nodeInfosForGroups, now))
+			if tc.rejectScaleUp {
+				assert.Empty(t, scaleUps)
+				assert.False(t, p.InProgress())
+				return
+			}
+			assert.Equal(t, []string{"big:1"}, scaleUps)
+			assert.True(t, p.InProgress())
+
+			// Another consolidation isn't started while one is in progress.
+			assert.NoError(t, p.StartConsolidation(context.Background(), Candidates(unremovable, utilizationMap), nodes, nodeInfosForGroups, now))
+			assert.Equal(t, []string{"big:1"}, scaleUps)
+
+			replacement := BuildTestNode("big-1", 2000, 2000)
+			SetNodeReadyState(replacement, tc.replacementReady, time.Time{})
+			provider.AddNode("big", replacement)
+
+			var gotNodesToDrain []string
+			for _, node := range p.NodesToDrain(append(nodes, replacement), tc.drainTime) {
+				gotNodesToDrain = append(gotNodesToDrain, node.Name)
+			}
+			assert.Equal(t, tc.wantNodesToDrain, gotNodesToDrain)
+			assert.Equal(t, tc.wantInProgress, p.InProgress())
+		})
+	}
+}
@@ -18,12 +18,14 @@ package eligibility
 
 import (
 	"reflect"
+	"sync"
 	"time"
 
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/context"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/actuation"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/unremovable"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/utilizationcalculator"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/utilization"
@@ -41,7 +43,32 @@ const (
 
 // Checker is responsible for deciding which nodes pass the criteria for scale down.
 type Checker struct {
-	configGetter nodeGroupConfigGetter
+	configGetter          nodeGroupConfigGetter
+	utilizationCalculator utilizationcalculator.UtilizationCalculator
+	podChanges            podChangeTracker
+	// utilizationCache contains utilization of nodes calculated in previous loops, by node name.
+	utilizationCache map[string]cachedUtilization
+	// changedNodesMutex guards changedNodes, which are updated from the pod informer event handlers.
+	changedNodesMutex sync.Mutex
+	// changedNodes are the nodes whose pods changed since the start of the last FilterOutUnremovable call.
+	changedNodes map[string]bool
+	// loopChangedNodes are the nodes whose pods changed before the current FilterOutUnremovable call.
+	loopChangedNodes map[string]bool
+}
+
+type podChangeTracker interface {
+	// AddPodChangeHandler registers a handler called with the node name whenever a pod scheduled
+	// on the node is added, updated or removed.
+	AddPodChangeHandler(handler func(nodeName string))
+	// HasSynced returns true once changes of all pods are tracked.
+	HasSynced() bool
+}
+
+type cachedUtilization struct {
+	resourceVersion   string
+	podCount          int
+	skipDaemonSetPods bool
+	utilInfo          utilization.Info
 }
 
 type nodeGroupConfigGetter interface {
@@ -54,12 +81,38 @@ type nodeGroupConfigGetter interface {
 }
 
 // NewChecker creates a new Checker object.
-func NewChecker(configGetter nodeGroupConfigGetter) *Checker {
+func NewChecker(configGetter nodeGroupConfigGetter, utilizationCalculator utilizationcalculator.UtilizationCalculator) *Checker {
 	return &Checker{
-		configGetter: configGetter,
+		configGetter:          configGetter,
+		utilizationCalculator: utilizationCalculator,
 	}
 }
 
+// SetPodChangeTracker makes the Checker reuse utilization of nodes calculated in previous loops, and
+// recalculate it only for nodes which changed or whose pods changed, as reported by the tracker events.
+// In large clusters, this avoids recalculating utilization of all scale-down candidates every loop.
+func (c *Checker) SetPodChangeTracker(tracker podChangeTracker) {
+	c.podChanges = tracker
+	c.utilizationCache = make(map[string]cachedUtilization)
+	c.changedNodes = make(map[string]bool)
+	tracker.AddPodChangeHandler(c.onPodChange)
+}
+
+func (c *Checker) onPodChange(nodeName string) {
+	c.changedNodesMutex.Lock()
+	defer c.changedNodesMutex.Unlock()
+	c.changedNodes[nodeName] = true
+}
+
+// takeChangedNodes returns the nodes whose pods changed since the previous call.
+func (c *Checker) takeChangedNodes() map[string]bool {
+	c.changedNodesMutex.Lock()
+	defer c.changedNodesMutex.Unlock()
+	changed := c.changedNodes
+	c.changedNodes = make(map[string]bool)
+	return changed
+}
+
 // FilterOutUnremovable accepts a list of nodes that are candidates for
 // scale down and filters out nodes that cannot be removed, along with node
 // utilization info.
@@ -71,6 +124,9 @@ func (c *Checker) FilterOutUnremovable(context *context.AutoscalingContext, scal
 	utilizationMap := make(map[string]utilization.Info)
 	currentlyUnneededNodeNames := make([]string, 0, len(scaleDownCandidates))
 	utilLogsQuota := klogx.NewLoggingQuota(20)
+	if c.podChanges != nil {
+		c.loopChangedNodes = c.takeChangedNodes()
+	}
 
 	for _, node := range scaleDownCandidates {
 		nodeInfo, err := context.ClusterSnapshot.GetNodeInfo(node.Name)
@@ -87,12 +143,12 @@ func (c *Checker) FilterOutUnremovable(context *context.AutoscalingContext, scal
 			continue
 		}
 
-		reason, utilInfo := c.unremovableReasonAndNodeUtilization(context, timestamp, nodeInfo, utilLogsQuota)
+		reason, utilInfo, breakdown := c.unremovableReasonAndNodeUtilization(context, timestamp, nodeInfo, utilLogsQuota)
 		if utilInfo != nil {
 			utilizationMap[node.Name] = *utilInfo
 		}
 		if reason != simulator.NoReason {
-			ineligible = append(ineligible, &simulator.UnremovableNode{Node: node, Reason: reason})
+			ineligible = append(ineligible, &simulator.UnremovableNode{Node: node, Reason: reason, UtilizationBreakdown: breakdown})
 			continue
 		}
 
@@ -100,73 +156,137 @@ func (c *Checker) FilterOutUnremovable(context *context.AutoscalingContext, scal
 	}
 
 	klogx.V(4).Over(utilLogsQuota).Infof("Skipped logging utilization for %d other nodes", -utilLogsQuota.Left())
+	c.dropUtilizationOfRemovedNodes(scaleDownCandidates)
 	if skipped > 0 {
 		klog.V(1).Infof("Scale-down calculation: ignoring %v nodes unremovable in the last %v", skipped, context.AutoscalingOptions.UnremovableNodeRecheckTimeout)
 	}
 	return currentlyUnneededNodeNames, utilizationMap, ineligible
 }
 
-func (c *Checker) unremovableReasonAndNodeUtilization(context *context.AutoscalingContext, timestamp time.Time, nodeInfo *framework.NodeInfo, utilLogsQuota *klogx.Quota) (simulator.UnremovableReason, *utilization.Info) {
+func (c *Checker) unremovableReasonAndNodeUtilization(context *context.AutoscalingContext, timestamp time.Time, nodeInfo *framework.NodeInfo, utilLogsQuota *klogx.Quota) (simulator.UnremovableReason, *utilization.Info, *utilization.Breakdown) {
 	node := nodeInfo.Node()
 
 	if actuation.IsNodeBeingDeleted(node, timestamp) {
 		klog.V(1).Infof("Skipping %s from delete consideration - the node is currently being deleted", node.Name)
-		return simulator.CurrentlyBeingDeleted, nil
+		return simulator.CurrentlyBeingDeleted, nil, nil
 	}
 
 	// Skip nodes marked with no scale down annotation
 	if HasNoScaleDownAnnotation(node) {
 		klog.V(1).Infof("Skipping %s from delete consideration - the node is marked as no scale down", node.Name)
-		return simulator.ScaleDownDisabledAnnotation, nil
+		return simulator.ScaleDownDisabledAnnotation, nil, nil
 	}
 
 	nodeGroup, err := context.CloudProvider.NodeGroupForNode(node)
 	if err != nil {
 		klog.Warningf("Node group not found for node %v: %v", node.Name, err)
-		return simulator.UnexpectedError, nil
+		return simulator.UnexpectedError, nil, nil
 	}
 	if nodeGroup == nil || reflect.ValueOf(nodeGroup).IsNil() {
 		// We should never get here as non-autoscaled nodes should not be included in scaleDownCandidates list
 		// (and the default PreFilteringScaleDownNodeProcessor would indeed filter them out).
 		klog.Warningf("Skipped %s from delete consideration - the node is not autoscaled", node.Name)
-		return simulator.NotAutoscaled, nil
+		return simulator.NotAutoscaled, nil, nil
 	}
 
 	ignoreDaemonSetsUtilization, err := c.configGetter.GetIgnoreDaemonSetsUtilization(nodeGroup)
 	if err != nil {
 		klog.Warningf("Couldn't retrieve `IgnoreDaemonSetsUtilization` option for node %v: %v", node.Name, err)
-		return simulator.UnexpectedError, nil
+		return simulator.UnexpectedError, nil, nil
 	}
 
-	gpuConfig := context.CloudProvider.GetNodeGpuConfig(node)
-	utilInfo, err := utilization.Calculate(nodeInfo, ignoreDaemonSetsUtilization, context.IgnoreMirrorPodsUtilization, context.DynamicResourceAllocationEnabled, gpuConfig, timestamp)
+	utilInfo, err := c.calculateUtilization(context, nodeInfo, ignoreDaemonSetsUtilization, timestamp)
 	if err != nil {
 		klog.Warningf("Failed to calculate utilization for %s: %v", node.Name, err)
-		return simulator.UnexpectedError, nil
+		return simulator.UnexpectedError, nil, nil
 	}
+	utilInfo = utilization.ApplyUsage(utilInfo, nodeInfo, context.UsageProvider, utilization.Source(context.ScaleDownUtilizationSource))
 
 	// If scale down of unready nodes is disabled, skip the node if it is unready
 	if !context.ScaleDownUnreadyEnabled {
 		ready, _, _ := kube_util.GetReadinessState(node)
 		if !ready {
 			klog.V(4).Infof("Skipping unready node %s from delete consideration - scale-down of unready nodes is disabled", node.Name)
-			return simulator.ScaleDownUnreadyDisabled, nil
+			return simulator.ScaleDownUnreadyDisabled, nil, nil
 		}
 	}
 
 	underutilized, err := c.isNodeBelowUtilizationThreshold(context, node, nodeGroup, utilInfo)
 	if err != nil {
 		klog.Warningf("Failed to check utilization thresholds for %s: %v", node.Name, err)
-		return simulator.UnexpectedError, nil
+		return simulator.UnexpectedError, nil, nil
 	}
 	if !underutilized {
 		klog.V(4).Infof("Node %s unremovable: %s requested (%.6g%% of allocatable) is above the scale-down utilization threshold", node.Name, utilInfo.ResourceName, utilInfo.Utilization*100)
-		return simulator.NotUnderutilized, &utilInfo
+		var breakdown *utilization.Breakdown
+		if context.ScaleDownUtilizationBreakdownPods > 0 {
+			breakdown = utilization.CalculateBreakdown(nodeInfo, utilInfo, context.ScaleDownUtilizationBreakdownPods, ignoreDaemonSetsUtilization, context.IgnoreMirrorPodsUtilization, timestamp)
+		}
+		return simulator.NotUnderutilized, &utilInfo, breakdown
 	}
 
 	klogx.V(4).UpTo(utilLogsQuota).Infof("Node %s - %s requested is %.6g%% of allocatable", node.Name, utilInfo.ResourceName, utilInfo.Utilization*100)
 
-	return simulator.NoReason, &utilInfo
+	return simulator.NoReason, &utilInfo, nil
+}
+
+// calculateUtilization returns utilization of the node, reusing the utilization calculated in a previous
+// loop if neither the node nor its pods changed.
+// Utilization of nodes whose pods changed before this loop isn't cached until the next loop: the cluster
+// snapshot could have been taken before the change.
+func (c *Checker) calculateUtilization(context *context.AutoscalingContext, nodeInfo *framework.NodeInfo, skipDaemonSetPods bool, timestamp time.Time) (utilization.Info, error) {
+	if c.podChanges == nil {
+		return c.utilizationCalculator.Calculate(context, nodeInfo, skipDaemonSetPods, timestamp)
+	}
+	node := nodeInfo.Node()
+	reusable := c.podChanges.HasSynced() && !c.loopChangedNodes[node.Name] && isUtilizationReusable(context, nodeInfo)
+	if cached, found := c.utilizationCache[node.Name]; found && reusable &&
+		cached.resourceVersion == node.ResourceVersion &&
+		cached.podCount == len(nodeInfo.Pods()) && cached.skipDaemonSetPods == skipDaemonSetPods {
+		return cached.utilInfo, nil
+	}
+	utilInfo, err := c.utilizationCalculator.Calculate(context, nodeInfo, skipDaemonSetPods, timestamp)
+	if err != nil || !reusable {
+		delete(c.utilizationCache, node.Name)
+		return utilInfo, err
+	}
+	c.utilizationCache[node.Name] = cachedUtilization{
+		resourceVersion:   node.ResourceVersion,
+		podCount:          len(nodeInfo.Pods()),
+		skipDaemonSetPods: skipDaemonSetPods,
+		utilInfo:          utilInfo,
+	}
+	return utilInfo, nil
+}
+
+// isUtilizationReusable checks if utilization of the node can only change along with the node or its pods.
+// It isn't the case for nodes with terminating pods, which stop counting once they terminate for too long,
+// and with Dynamic Resource Allocation, where utilization depends on resource slices and claims.
+func isUtilizationReusable(context *context.AutoscalingContext, nodeInfo *framework.NodeInfo) bool {
+	if context.DynamicResourceAllocationEnabled || nodeInfo.Node().ResourceVersion == "" {
+		return false
+	}
+	for _, podInfo := range nodeInfo.Pods() {
+		if podInfo.Pod.DeletionTimestamp != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Checker) dropUtilizationOfRemovedNodes(scaleDownCandidates []*apiv1.Node) {
+	if len(c.utilizationCache) == 0 {
+		return
+	}
+	candidates := make(map[string]bool, len(scaleDownCandidates))
+	for _, node := range scaleDownCandidates {
+		candidates[node.Name] = true
+	}
+	for nodeName := range c.utilizationCache {
+		if !candidates[nodeName] {
+			delete(c.utilizationCache, nodeName)
+		}
+	}
 }
 
 // isNodeBelowUtilizationThreshold determines if a given node utilization is below threshold.
@@ -25,14 +25,19 @@ import (
 
 	apiv1 "k8s.io/api/core/v1"
 	resourceapi "k8s.io/api/resource/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/unremovable"
 	. "k8s.io/autoscaler/cluster-autoscaler/core/test"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/utilizationcalculator"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator"
 	drasnapshot "k8s.io/autoscaler/cluster-autoscaler/simulator/dynamicresources/snapshot"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/utilization"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
 	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
 	"k8s.io/client-go/kubernetes/fake"
@@ -48,6 +53,28 @@ type testCase struct {
 	wantUnremovable             []*simulator.UnremovableNode
 	scaleDownUnready            bool
 	ignoreDaemonSetsUtilization bool
+	extendedResources           []apiv1.ResourceName
+	utilizationSource           utilization.Source
+	usageProvider               utilization.UsageProvider
+	breakdownPods               int
+	utilizationCalculator       utilizationcalculator.UtilizationCalculator
+}
+
+type fakeUsageProvider map[string]apiv1.ResourceList
+
+func (p fakeUsageProvider) NodeUsage(nodeName string) (apiv1.ResourceList, error) {
+	return p[nodeName], nil
+}
+
+type fakeUtilizationCalculator struct {
+	utilInfo utilization.Info
+}
+
+func (c *fakeUtilizationCalculator) Calculate(_ *context.AutoscalingContext, _ *framework.NodeInfo, _ bool, _ time.Time) (utilization.Info, error) {
+	return c.utilInfo, nil
+}
+
+func (c *fakeUtilizationCalculator) CleanUp() {
 }
 
 func getTestCases(ignoreDaemonSetsUtilization bool, suffix string, now time.Time) []testCase {
@@ -74,6 +101,15 @@ func getTestCases(ignoreDaemonSetsUtilization bool, suffix string, now time.Time
 	dsPod := BuildTestPod("dsPod", 500, 0, WithDSController())
 	dsPod.Spec.NodeName = "regular"
 
+	hugePages := apiv1.ResourceName("hugepages-2Mi")
+	hugePagesNode := BuildTestNode("hugePages", 1000, 10)
+	hugePagesNode.Status.Allocatable[hugePages] = *resource.NewQuantity(1000, resource.DecimalSI)
+	SetNodeReadyState(hugePagesNode, true, time.Time{})
+
+	hugePagesPod := BuildTestPod("hugePagesPod", 100, 0)
+	hugePagesPod.Spec.NodeName = "hugePages"
+	hugePagesPod.Spec.Containers[0].Resources.Requests[hugePages] = *resource.NewQuantity(800, resource.DecimalSI)
+
 	brokenUtilNode := BuildTestNode("regular", 0, 0)
 	regularNodeIncompleteResourceSlice := &resourceapi.ResourceSlice{
 		ObjectMeta: metav1.ObjectMeta{Name: "regularNodeIncompleteResourceSlice", UID: "regularNodeIncompleteResourceSlice"},
@@ -125,6 +161,71 @@ func getTestCases(ignoreDaemonSetsUtilization bool, suffix string, now time.Time
 			wantUnremovable:  []*simulator.UnremovableNode{},
 			scaleDownUnready: true,
 		},
+		{
+			desc:             "node with highly utilized extended resource stays if the resource is not configured",
+			nodes:            []*apiv1.Node{hugePagesNode},
+			pods:             []*apiv1.Pod{hugePagesPod},
+			wantUnneeded:     []string{"hugePages"},
+			wantUnremovable:  []*simulator.UnremovableNode{},
+			scaleDownUnready: true,
+		},
+		{
+			desc:              "node with highly utilized extended resource is filtered out if the resource is configured",
+			nodes:             []*apiv1.Node{hugePagesNode},
+			pods:              []*apiv1.Pod{hugePagesPod},
+			extendedResources: []apiv1.ResourceName{hugePages},
+			wantUnneeded:      []string{},
+			wantUnremovable:   []*simulator.UnremovableNode{{Node: hugePagesNode, Reason: simulator.NotUnderutilized}},
+			scaleDownUnready:  true,
+		},
+		{
+			desc:          "highly utilized node reports utilization breakdown",
+			nodes:         []*apiv1.Node{regularNode},
+			pods:          []*apiv1.Pod{bigPod, smallPod},
+			breakdownPods: 1,
+			wantUnneeded:  []string{},
+			wantUnremovable: []*simulator.UnremovableNode{{
+				Node:   regularNode,
+				Reason: simulator.NotUnderutilized,
+				UtilizationBreakdown: &utilization.Breakdown{
+					ResourceName: apiv1.ResourceCPU,
+					Utilization:  0.7,
+					TopPods: []utilization.PodUtilization{
+						{Namespace: "default", Name: "bigPod", Utilization: map[apiv1.ResourceName]float64{apiv1.ResourceCPU: 0.6, apiv1.ResourceMemory: 0}},
+					},
+				},
+			}},
+			scaleDownUnready: true,
+		},
+		{
+			desc:                  "underutilized node is filtered out if custom utilization calculator reports high utilization",
+			nodes:                 []*apiv1.Node{regularNode},
+			pods:                  []*apiv1.Pod{smallPod},
+			utilizationCalculator: &fakeUtilizationCalculator{utilInfo: utilization.Info{CpuUtil: 0.9, ResourceName: apiv1.ResourceCPU, Utilization: 0.9}},
+			wantUnneeded:          []string{},
+			wantUnremovable:       []*simulator.UnremovableNode{{Node: regularNode, Reason: simulator.NotUnderutilized}},
+			scaleDownUnready:      true,
+		},
+		{
+			desc:              "underutilized node with high actual usage is filtered out",
+			nodes:             []*apiv1.Node{regularNode},
+			pods:              []*apiv1.Pod{smallPod},
+			utilizationSource: utilization.ActualSource,
+			usageProvider:     fakeUsageProvider{"regular": {apiv1.ResourceCPU: *resource.NewMilliQuantity(900, resource.DecimalSI)}},
+			wantUnneeded:      []string{},
+			wantUnremovable:   []*simulator.UnremovableNode{{Node: regularNode, Reason: simulator.NotUnderutilized}},
+			scaleDownUnready:  true,
+		},
+		{
+			desc:              "highly utilized node with low actual usage stays",
+			nodes:             []*apiv1.Node{regularNode},
+			pods:              []*apiv1.Pod{bigPod},
+			utilizationSource: utilization.ActualSource,
+			usageProvider:     fakeUsageProvider{"regular": {apiv1.ResourceCPU: *resource.NewMilliQuantity(100, resource.DecimalSI)}},
+			wantUnneeded:      []string{"regular"},
+			wantUnremovable:   []*simulator.UnremovableNode{},
+			scaleDownUnready:  true,
+		},
 		{
 			desc:             "node is filtered out if utilization can't be calculated",
 			nodes:            []*apiv1.Node{brokenUtilNode},
@@ -210,9 +311,12 @@ func TestFilterOutUnremovable(t *testing.T) {
 		t.Run(tc.desc, func(t *testing.T) {
 			t.Parallel()
 			options := config.AutoscalingOptions{
-				DynamicResourceAllocationEnabled: tc.draEnabled,
-				UnremovableNodeRecheckTimeout:    5 * time.Minute,
-				ScaleDownUnreadyEnabled:          tc.scaleDownUnready,
+				DynamicResourceAllocationEnabled:      tc.draEnabled,
+				UnremovableNodeRecheckTimeout:         5 * time.Minute,
+				ScaleDownUnreadyEnabled:               tc.scaleDownUnready,
+				ScaleDownUtilizationExtendedResources: tc.extendedResources,
+				ScaleDownUtilizationSource:            string(tc.utilizationSource),
+				ScaleDownUtilizationBreakdownPods:     tc.breakdownPods,
 				NodeGroupDefaults: config.NodeGroupAutoscalingOptions{
 					ScaleDownUtilizationThreshold:    config.DefaultScaleDownUtilizationThreshold,
 					ScaleDownGpuUtilizationThreshold: config.DefaultScaleDownGpuUtilizationThreshold,
@@ -222,7 +326,11 @@ func TestFilterOutUnremovable(t *testing.T) {
 				},
 			}
 			s := nodegroupconfig.NewDefaultNodeGroupConfigProcessor(options.NodeGroupDefaults)
-			c := NewChecker(s)
+			utilizationCalculator := tc.utilizationCalculator
+			if utilizationCalculator == nil {
+				utilizationCalculator = utilizationcalculator.NewDefaultUtilizationCalculator()
+			}
+			c := NewChecker(s, utilizationCalculator)
 			provider := testprovider.NewTestCloudProvider(nil, nil)
 			provider.AddNodeGroup("ng1", 1, 10, 2)
 			for _, n := range tc.nodes {
@@ -232,6 +340,7 @@ func TestFilterOutUnremovable(t *testing.T) {
 			if err != nil {
 				t.Fatalf("Could not create autoscaling context: %v", err)
 			}
+			context.UsageProvider = tc.usageProvider
 			if err := context.ClusterSnapshot.SetClusterState(tc.nodes, tc.pods, tc.draSnapshot); err != nil {
 				t.Fatalf("Could not SetClusterState: %v", err)
 			}
@@ -246,3 +355,132 @@ func TestFilterOutUnremovable(t *testing.T) {
 		})
 	}
 }
+
+type countingUtilizationCalculator struct {
+	utilizationcalculator.UtilizationCalculator
+	calculated []string
+}
+
+func (c *countingUtilizationCalculator) Calculate(context *context.AutoscalingContext, nodeInfo *framework.NodeInfo, skipDaemonSetPods bool, currentTime time.Time) (utilization.Info, error) {
+	c.calculated = append(c.calculated, nodeInfo.Node().Name)
+	return c.UtilizationCalculator.Calculate(context, nodeInfo, skipDaemonSetPods, currentTime)
+}
+
+type fakePodChangeTracker struct {
+	handler func(nodeName string)
+}
+
+func (t *fakePodChangeTracker) AddPodChangeHandler(handler func(nodeName string)) {
+	t.handler = handler
+}
+
+func (t *fakePodChangeTracker) HasSynced() bool {
+	return true
+}
+
+func TestFilterOutUnremovableReusesUtilization(t *testing.T) {
+	now := time.Now()
+	options := config.AutoscalingOptions{
+		UnremovableNodeRecheckTimeout: 5 * time.Minute,
+		NodeGroupDefaults: config.NodeGroupAutoscalingOptions{
+			ScaleDownUtilizationThreshold:    config.DefaultScaleDownUtilizationThreshold,
+			ScaleDownGpuUtilizationThreshold: config.DefaultScaleDownGpuUtilizationThreshold,
+		},
+	}
+	calculator := &countingUtilizationCalculator{UtilizationCalculator: utilizationcalculator.NewDefaultUtilizationCalculator()}
+	tracker := &fakePodChangeTracker{}
+	c := NewChecker(nodegroupconfig.NewDefaultNodeGroupConfigProcessor(options.NodeGroupDefaults), calculator)
+	c.SetPodChangeTracker(tracker)
+
+	n1 := BuildTestNode("n1", 1000, 10)
+	n2 := BuildTestNode("n2", 1000, 10)
+	n1.ResourceVersion, n2.ResourceVersion = "1", "1"
+	SetNodeReadyState(n1, true, time.Time{})
+	SetNodeReadyState(n2, true, time.Time{})
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 1, 10, 2)
+	provider.AddNode("ng1", n1)
+	provider.AddNode("ng1", n2)
+	context, err := NewScaleTestAutoscalingContext(options, &fake.Clientset{}, nil, provider, nil, nil)
+	if err != nil {
+		t.Fatalf("Could not create autoscaling context: %v", err)
+	}
+
+	p1 := BuildScheduledTestPod("p1", 100, 1, "n1")
+	p2 := BuildScheduledTestPod("p2", 100, 1, "n2")
+	terminating := BuildScheduledTestPod("p2", 100, 1, "n2")
+	terminating.DeletionTimestamp = &metav1.Time{Time: now}
+	for _, step := range []struct {
+		desc           string
+		update         func()
+		pods           []*apiv1.Pod
+		wantCalculated []string
+		wantUnneeded   []string
+	}{
+		{
+			desc:           "first loop",
+			pods:           []*apiv1.Pod{p1, p2},
+			wantCalculated: []string{"n1", "n2"},
+			wantUnneeded:   []string{"n1", "n2"},
+		},
+		{
+			desc:         "nothing changed",
+			pods:         []*apiv1.Pod{p1, p2},
+			wantUnneeded: []string{"n1", "n2"},
+		},
+		{
+			desc:           "pods on a node changed",
+			update:         func() { tracker.handler("n1") },
+			pods:           []*apiv1.Pod{BuildScheduledTestPod("p1", 800, 1, "n1"), p2},
+			wantCalculated: []string{"n1"},
+			wantUnneeded:   []string{"n2"},
+		},
+		{
+			desc:           "utilization of a node changed in the previous loop is cached now",
+			update:         func() { n2.ResourceVersion = "2" },
+			pods:           []*apiv1.Pod{BuildScheduledTestPod("p1", 800, 1, "n1"), p2},
+			wantCalculated: []string{"n1", "n2"},
+			wantUnneeded:   []string{"n2"},
+		},
+		{
+			desc:         "nothing changed again",
+			pods:         []*apiv1.Pod{BuildScheduledTestPod("p1", 800, 1, "n1"), p2},
+			wantUnneeded: []string{"n2"},
+		},
+		{
+			desc:           "pod injected in the snapshot",
+			pods:           []*apiv1.Pod{BuildScheduledTestPod("p1", 800, 1, "n1"), p2, BuildScheduledTestPod("injected", 800, 1, "n2")},
+			wantCalculated: []string{"n2"},
+			wantUnneeded:   []string{},
+		},
+		{
+			desc:           "terminating pod",
+			pods:           []*apiv1.Pod{BuildScheduledTestPod("p1", 800, 1, "n1"), terminating},
+			wantCalculated: []string{"n2"},
+			wantUnneeded:   []string{"n2"},
+		},
+		{
+			desc:           "terminating pod, nothing changed",
+			pods:           []*apiv1.Pod{BuildScheduledTestPod("p1", 800, 1, "n1"), terminating},
+			wantCalculated: []string{"n2"},
+			wantUnneeded:   []string{"n2"},
+		},
+	} {
+		t.Run(step.desc, func(t *testing.T) {
+			if step.update != nil {
+				step.update()
+			}
+			if err := context.ClusterSnapshot.SetClusterState([]*apiv1.Node{n1, n2}, step.pods, drasnapshot.Snapshot{}); err != nil {
+				t.Fatalf("Could not SetClusterState: %v", err)
+			}
+			calculator.calculated = nil
+			gotUnneeded, _, _ := c.FilterOutUnremovable(&context, []*apiv1.Node{n1, n2}, now, unremovable.NewNodes())
+			if diff := cmp.Diff(step.wantUnneeded, gotUnneeded); diff != "" {
+				t.Errorf("FilterOutUnremovable(): unexpected unneeded (-want +got): %s", diff)
+			}
+			if diff := cmp.Diff(step.wantCalculated, calculator.calculated); diff != "" {
+				t.Errorf("FilterOutUnremovable(): unexpected nodes with calculated utilization (-want +got): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planner
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/pdb"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
+	klog "k8s.io/klog/v2"
+)
+
+// drainSchedule assigns unneeded nodes which can't be drained right away because of PodDisruptionBudgets to later
+// drain waves. The drains which can start right away are wave 0. Every later wave starts with the budgets the PDBs
+// have once all their pods are healthy, assuming that pods evicted in earlier waves are running elsewhere by then.
+type drainSchedule struct {
+	maxWaves  int
+	pdbs      []*policyv1.PodDisruptionBudget
+	waves     []pdb.RemainingPdbTracker
+	nodeWaves map[string]int
+}
+
+func newDrainSchedule(maxWaves int, pdbs []*policyv1.PodDisruptionBudget) *drainSchedule {
+	healthyPdbs := make([]*policyv1.PodDisruptionBudget, 0, len(pdbs))
+	for _, budget := range pdbs {
+		budget = budget.DeepCopy()
+		// Once all pods are healthy, the pods above the desired number of healthy ones can be disrupted.
+		budget.Status.DisruptionsAllowed = max(budget.Status.DisruptionsAllowed, budget.Status.ExpectedPods-budget.Status.DesiredHealthy)
+		healthyPdbs = append(healthyPdbs, budget)
+	}
+	return &drainSchedule{
+		maxWaves:  maxWaves,
+		pdbs:      healthyPdbs,
+		nodeWaves: make(map[string]int),
+	}
+}
+
+// waveFor returns the first later wave whose remaining budgets allow evicting the pods, along with the tracker of
+// its budgets. Waves in which the pods can be evicted in parallel are preferred, unless the budgets don't allow it
+// even in an empty wave. Returns a nil tracker if the pods can't be evicted in any wave within the limit.
+func (s *drainSchedule) waveFor(pods []*apiv1.Pod) (int, pdb.RemainingPdbTracker) {
+	for i := 0; i < s.maxWaves; i++ {
+		empty := i == len(s.waves)
+		if empty {
+			tracker := pdb.NewBasicRemainingPdbTracker()
+			if err := tracker.SetPdbs(s.pdbs); err != nil {
+				klog.Errorf("Failed to set PDBs of drain wave %d: %v", i+1, err)
+				return 0, nil
+			}
+			s.waves = append(s.waves, tracker)
+		}
+		canRemove, inParallel, _ := s.waves[i].CanRemovePods(pods)
+		if canRemove && (inParallel || empty) {
+			return i + 1, s.waves[i]
+		}
+		if empty {
+			// All later waves would be empty as well.
+			break
+		}
+	}
+	return 0, nil
+}
+
+// schedule records the wave in which the node is drained.
+func (s *drainSchedule) schedule(nodeName string, wave int) {
+	s.nodeWaves[nodeName] = wave
+}
+
+// wave returns the wave in which the node is drained, 0 for nodes which can be drained right away.
+func (s *drainSchedule) wave(nodeName string) int {
+	if s == nil {
+		return 0
+	}
+	return s.nodeWaves[nodeName]
+}
+
+func blockedByPdb(unremovable *simulator.UnremovableNode) bool {
+	return unremovable.Reason == simulator.BlockedByPod && unremovable.BlockingPod != nil && unremovable.BlockingPod.Reason == drain.NotEnoughPdb
+}
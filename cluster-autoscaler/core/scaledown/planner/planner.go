@@ -30,6 +30,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/resource"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/unneeded"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/unremovable"
+	"k8s.io/autoscaler/cluster-autoscaler/metrics"
 	"k8s.io/autoscaler/cluster-autoscaler/processors"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/nodes"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator"
@@ -39,6 +40,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/scheduling"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/utilization"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
 	pod_util "k8s.io/autoscaler/cluster-autoscaler/utils/pod"
 	klog "k8s.io/klog/v2"
 )
@@ -76,6 +78,8 @@ type Planner struct {
 	cc                    controllerReplicasCalculator
 	scaleDownSetProcessor nodes.ScaleDownSetProcessor
 	scaleDownContext      *nodes.ScaleDownContext
+	drainSchedule         *drainSchedule
+	simulationCache       *simulator.SimulationCache
 }
 
 // New creates a new Planner object.
@@ -85,19 +89,34 @@ func New(context *context.AutoscalingContext, processors *processors.Autoscaling
 	if minUpdateInterval == 0*time.Nanosecond {
 		minUpdateInterval = 1 * time.Nanosecond
 	}
+	rs := simulator.NewRemovalSimulator(context.ListerRegistry, context.ClusterSnapshot, deleteOptions, drainabilityRules, true)
+	var simulationCache *simulator.SimulationCache
+	if context.AutoscalingOptions.ScaleDownSimulationCacheEnabled {
+		simulationCache = simulator.NewSimulationCache()
+		rs.SetSimulationCache(simulationCache)
+	}
+	eligibilityChecker := eligibility.NewChecker(processors.NodeGroupConfigProcessor, processors.UtilizationCalculator)
+	if context.AutoscalingOptions.ScaleDownIncrementalEligibilityEnabled {
+		if podIndex, ok := context.ListerRegistry.AllPodLister().(*kube_util.PodIndex); ok {
+			eligibilityChecker.SetPodChangeTracker(podIndex)
+		} else {
+			klog.Warningf("Pods aren't indexed, utilization of scale down candidates will be recalculated every loop")
+		}
+	}
 	return &Planner{
 		context:               context,
 		unremovableNodes:      unremovable.NewNodes(),
 		unneededNodes:         unneeded.NewNodes(processors.NodeGroupConfigProcessor, resourceLimitsFinder),
-		rs:                    simulator.NewRemovalSimulator(context.ListerRegistry, context.ClusterSnapshot, deleteOptions, drainabilityRules, true),
+		rs:                    rs,
 		actuationInjector:     scheduling.NewHintingSimulator(),
-		eligibilityChecker:    eligibility.NewChecker(processors.NodeGroupConfigProcessor),
+		eligibilityChecker:    eligibilityChecker,
 		nodeUtilizationMap:    make(map[string]utilization.Info),
 		resourceLimitsFinder:  resourceLimitsFinder,
 		cc:                    newControllerReplicasCalculator(context.ListerRegistry),
 		scaleDownSetProcessor: processors.ScaleDownSetProcessor,
 		scaleDownContext:      nodes.NewDefaultScaleDownContext(),
 		minUpdateInterval:     minUpdateInterval,
+		simulationCache:       simulationCache,
 	}
 }
 
@@ -122,6 +141,9 @@ func (p *Planner) UpdateClusterState(podDestinations, scaleDownCandidates []*api
 	podDestinations = filterOutOngoingDeletions(podDestinations, deletions)
 	scaleDownCandidates = filterOutOngoingDeletions(scaleDownCandidates, deletions)
 	p.categorizeNodes(asMap(nodeNames(podDestinations)), scaleDownCandidates)
+	if p.simulationCache != nil {
+		metrics.RegisterScaleDownSimulationCacheLookups(p.simulationCache.TakeStats())
+	}
 	p.rs.DropOldHints()
 	p.actuationInjector.DropOldHints()
 	return nil
@@ -152,7 +174,7 @@ func (p *Planner) NodesToDelete(_ time.Time) (empty, needDrain []*apiv1.Node) {
 	emptyRemovableNodes, needDrainRemovableNodes, unremovableNodes := p.unneededNodes.RemovableAt(p.context, *p.scaleDownContext, p.latestUpdate)
 	p.addUnremovableNodes(unremovableNodes)
 
-	needDrainRemovableNodes = sortByRisk(needDrainRemovableNodes)
+	needDrainRemovableNodes = p.filterOutLaterDrainWaves(sortByRisk(needDrainRemovableNodes))
 	candidatesToBeRemoved := append(emptyRemovableNodes, needDrainRemovableNodes...)
 
 	nodesToRemove, unremovableNodes := p.scaleDownSetProcessor.FilterUnremovableNodes(p.context, p.scaleDownContext, candidatesToBeRemoved)
@@ -169,6 +191,21 @@ func (p *Planner) NodesToDelete(_ time.Time) (empty, needDrain []*apiv1.Node) {
 	return empty, needDrain
 }
 
+// filterOutLaterDrainWaves filters out nodes whose drains are scheduled after other nodes because of
+// PodDisruptionBudgets, marking them as unremovable for now.
+func (p *Planner) filterOutLaterDrainWaves(nodes []simulator.NodeToBeRemoved) []simulator.NodeToBeRemoved {
+	var filtered []simulator.NodeToBeRemoved
+	for _, node := range nodes {
+		if wave := p.drainSchedule.wave(node.Node.Name); wave > 0 {
+			klog.V(4).Infof("Skipping %s - drain scheduled in wave %d", node.Node.Name, wave)
+			p.unremovableNodes.Add(&simulator.UnremovableNode{Node: node.Node, Reason: simulator.DrainScheduledLater})
+			continue
+		}
+		filtered = append(filtered, node)
+	}
+	return filtered
+}
+
 func (p *Planner) addUnremovableNodes(unremovableNodes []simulator.UnremovableNode) {
 	for _, u := range unremovableNodes {
 		p.unremovableNodes.Add(&u)
@@ -270,6 +307,10 @@ func (p *Planner) categorizeNodes(podDestinations map[string]bool, scaleDownCand
 		p.unremovableNodes.Add(n)
 	}
 	p.nodeUtilizationMap = utilizationMap
+	p.drainSchedule = nil
+	if p.context.AutoscalingOptions.ScaleDownPdbLookaheadWaves > 0 {
+		p.drainSchedule = newDrainSchedule(p.context.AutoscalingOptions.ScaleDownPdbLookaheadWaves, p.context.RemainingPdbTracker.GetPdbs())
+	}
 	timer := time.NewTimer(p.context.ScaleDownSimulationTimeout)
 
 	for i, node := range currentlyUnneededNodeNames {
@@ -281,14 +322,25 @@ func (p *Planner) categorizeNodes(podDestinations map[string]bool, scaleDownCand
 			klog.V(4).Infof("%d out of %d nodes skipped in scale down simulation: there are already %d unneeded nodes so no point in looking for more. Total atomic scale down nodes: %d", len(currentlyUnneededNodeNames)-i, len(currentlyUnneededNodeNames), len(removableList), atomicScaleDownNodesCount)
 			break
 		}
-		removable, unremovable := p.rs.SimulateNodeRemoval(node, podDestinations, p.latestUpdate, p.context.RemainingPdbTracker)
+		remainingPdbTracker := p.context.RemainingPdbTracker
+		removable, unremovable := p.rs.SimulateNodeRemoval(node, podDestinations, p.latestUpdate, remainingPdbTracker)
+		if unremovable != nil && p.drainSchedule != nil && blockedByPdb(unremovable) {
+			if wave, tracker := p.drainSchedule.waveFor(p.podsToMove(node)); tracker != nil {
+				removable, unremovable = p.rs.SimulateNodeRemoval(node, podDestinations, p.latestUpdate, tracker)
+				if removable != nil {
+					klog.V(2).Infof("Node %s is blocked by PodDisruptionBudgets, scheduling its drain in wave %d", node, wave)
+					p.drainSchedule.schedule(node, wave)
+					remainingPdbTracker = tracker
+				}
+			}
+		}
 		if removable != nil {
-			_, inParallel, _ := p.context.RemainingPdbTracker.CanRemovePods(removable.PodsToReschedule)
+			_, inParallel, _ := remainingPdbTracker.CanRemovePods(removable.PodsToReschedule)
 			if !inParallel {
 				removable.IsRisky = true
 			}
 			delete(podDestinations, removable.Node.Name)
-			p.context.RemainingPdbTracker.RemovePods(removable.PodsToReschedule)
+			remainingPdbTracker.RemovePods(removable.PodsToReschedule)
 			removableList = append(removableList, *removable)
 			if p.atomicScaleDownNode(removable) {
 				atomicScaleDownNodesCount++
@@ -306,6 +358,20 @@ func (p *Planner) categorizeNodes(podDestinations map[string]bool, scaleDownCand
 	}
 }
 
+// podsToMove returns the pods which would be evicted from the node, for checking PodDisruptionBudgets.
+func (p *Planner) podsToMove(nodeName string) []*apiv1.Pod {
+	nodeInfo, err := p.context.ClusterSnapshot.GetNodeInfo(nodeName)
+	if err != nil {
+		klog.Errorf("Can't retrieve node %s from snapshot, err: %v", nodeName, err)
+		return nil
+	}
+	pods := make([]*apiv1.Pod, 0, len(nodeInfo.Pods()))
+	for _, podInfo := range nodeInfo.Pods() {
+		pods = append(pods, podInfo.Pod)
+	}
+	return pod_util.FilterRecreatablePods(pods)
+}
+
 // atomicScaleDownNode checks if the removable node would be considered for atomic scale down.
 func (p *Planner) atomicScaleDownNode(node *simulator.NodeToBeRemoved) bool {
 	nodeGroup, err := p.context.CloudProvider.NodeGroupForNode(node.Node)
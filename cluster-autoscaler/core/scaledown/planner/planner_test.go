@@ -25,6 +25,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
@@ -876,6 +877,112 @@ func TestNodesToDelete(t *testing.T) {
 	}
 }
 
+func TestPdbLookahead(t *testing.T) {
+	testCases := []struct {
+		name            string
+		waves           int
+		wantUnneeded    []string
+		wantUnremovable map[string]simulator.UnremovableReason
+	}{
+		{
+			name:         "lookahead disabled",
+			waves:        0,
+			wantUnneeded: []string{"n1"},
+			wantUnremovable: map[string]simulator.UnremovableReason{
+				"n2": simulator.BlockedByPod,
+				"n3": simulator.BlockedByPod,
+			},
+		},
+		{
+			name:         "one wave",
+			waves:        1,
+			wantUnneeded: []string{"n1", "n2"},
+			wantUnremovable: map[string]simulator.UnremovableReason{
+				"n2": simulator.DrainScheduledLater,
+				"n3": simulator.BlockedByPod,
+			},
+		},
+		{
+			name:         "enough waves",
+			waves:        3,
+			wantUnneeded: []string{"n1", "n2", "n3"},
+			wantUnremovable: map[string]simulator.UnremovableReason{
+				"n2": simulator.DrainScheduledLater,
+				"n3": simulator.DrainScheduledLater,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			nodes := []*apiv1.Node{
+				BuildTestNode("n1", 1000, 10),
+				BuildTestNode("n2", 1000, 10),
+				BuildTestNode("n3", 1000, 10),
+				BuildTestNode("destination", 10000, 100),
+			}
+			var pods []*apiv1.Pod
+			for _, node := range nodes[:3] {
+				pod := SetRSPodSpec(BuildScheduledTestPod("p-"+node.Name, 100, 1, node.Name), "rs")
+				pod.Labels = map[string]string{"app": "a"}
+				pods = append(pods, pod)
+			}
+			budget := &policyv1.PodDisruptionBudget{
+				ObjectMeta: metav1.ObjectMeta{Name: "pdb", Namespace: "default"},
+				Spec: policyv1.PodDisruptionBudgetSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "a"}},
+				},
+				Status: policyv1.PodDisruptionBudgetStatus{
+					DisruptionsAllowed: 1,
+					CurrentHealthy:     3,
+					DesiredHealthy:     2,
+					ExpectedPods:       3,
+				},
+			}
+
+			rsLister, err := kube_util.NewTestReplicaSetLister(generateReplicaSets("rs", 3))
+			assert.NoError(t, err)
+			registry := kube_util.NewListerRegistry(nil, nil, nil, nil, nil, nil, nil, rsLister, nil)
+			provider := testprovider.NewTestCloudProvider(nil, nil)
+			provider.AddNodeGroup("ng1", 0, 10, len(nodes))
+			for _, node := range nodes {
+				provider.AddNode("ng1", node)
+			}
+			context, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{
+				NodeGroupDefaults: config.NodeGroupAutoscalingOptions{
+					ScaleDownUnneededTime: 10 * time.Minute,
+				},
+				ScaleDownSimulationTimeout: 10 * time.Second,
+				MaxScaleDownParallelism:    10,
+				ScaleDownPdbLookaheadWaves: tc.waves,
+			}, &fake.Clientset{}, registry, provider, nil, nil)
+			assert.NoError(t, err)
+			clustersnapshot.InitializeClusterSnapshotOrDie(t, context.ClusterSnapshot, nodes, pods)
+			p := New(&context, processorstest.NewTestProcessors(&context), options.NodeDeleteOptions{}, nil)
+			p.eligibilityChecker = &fakeEligibilityChecker{eligible: asMap([]string{"n1", "n2", "n3"})}
+
+			now := time.Now()
+			for _, ts := range []time.Time{now.Add(-time.Hour), now} {
+				assert.NoError(t, context.RemainingPdbTracker.SetPdbs([]*policyv1.PodDisruptionBudget{budget}))
+				assert.NoError(t, p.UpdateClusterState(nodes, nodes, &fakeActuationStatus{}, ts))
+			}
+			assert.ElementsMatch(t, tc.wantUnneeded, nodeNames(p.UnneededNodes()))
+
+			empty, drain := p.NodesToDelete(now)
+			assert.Empty(t, empty)
+			assert.Equal(t, []string{"n1"}, nodeNames(drain))
+			gotUnremovable := make(map[string]simulator.UnremovableReason)
+			for _, u := range p.UnremovableNodes() {
+				if u.Node.Name != "destination" {
+					gotUnremovable[u.Node.Name] = u.Reason
+				}
+			}
+			assert.Equal(t, tc.wantUnremovable, gotUnremovable)
+		})
+	}
+}
+
 func sizedNodeGroup(id string, size int, atomic bool) cloudprovider.NodeGroup {
 	ng := testprovider.NewTestNodeGroup(id, 10000, 0, size, true, false, "n1-standard-2", nil, nil)
 	ng.SetOptions(&config.NodeGroupAutoscalingOptions{
@@ -0,0 +1,98 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predelete
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kube_client "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// PreDeleteAnnotation is set by Cluster Autoscaler on a drained node it's
+	// about to delete, to the time the hook was requested at.
+	PreDeleteAnnotation = "cluster-autoscaler.kubernetes.io/pre-delete"
+	// PreDeleteAcknowledgedAnnotation is set by an external controller on a node
+	// with the PreDeleteAnnotation once it's done with the node.
+	PreDeleteAcknowledgedAnnotation = "cluster-autoscaler.kubernetes.io/pre-delete-acknowledged"
+)
+
+// AnnotationHook requests the pre-delete hook by annotating the node with the
+// PreDeleteAnnotation, and waits for the PreDeleteAcknowledgedAnnotation.
+type AnnotationHook struct {
+	client       kube_client.Interface
+	timeout      time.Duration
+	pollInterval time.Duration
+	now          func() time.Time
+}
+
+// NewAnnotationHook returns an AnnotationHook waiting up to timeout for acknowledgements.
+func NewAnnotationHook(client kube_client.Interface, timeout time.Duration) *AnnotationHook {
+	return &AnnotationHook{
+		client:       client,
+		timeout:      timeout,
+		pollInterval: defaultPollInterval,
+		now:          time.Now,
+	}
+}
+
+// Run annotates the node, waits for the acknowledgement and removes both annotations,
+// so that they don't stay behind if deletion of the node fails later on.
+func (h *AnnotationHook) Run(nodeName string) error {
+	requested := h.now().UTC().Format(time.RFC3339)
+	// A stale acknowledgement, left by an earlier deletion attempt, is cleared.
+	if err := h.patchAnnotations(nodeName, &requested, nil); err != nil {
+		return err
+	}
+	waitForCompletion(nodeName, PreDeleteAnnotation+" annotation", h.pollInterval, h.timeout, func(ctx context.Context) (bool, error) {
+		node, err := h.client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		_, found := node.Annotations[PreDeleteAcknowledgedAnnotation]
+		return found, nil
+	})
+	if err := h.patchAnnotations(nodeName, nil, nil); err != nil {
+		klog.Warningf("Failed to remove the pre-delete annotations of node %s: %v", nodeName, err)
+	}
+	return nil
+}
+
+// patchAnnotations sets both annotations of the node, removing the ones set to nil.
+func (h *AnnotationHook) patchAnnotations(nodeName string, preDelete, acknowledged *string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]*string{
+				PreDeleteAnnotation:             preDelete,
+				PreDeleteAcknowledgedAnnotation: acknowledged,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build the pre-delete annotation patch for node %s: %v", nodeName, err)
+	}
+	if _, err := h.client.CoreV1().Nodes().Patch(context.TODO(), nodeName, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to update the pre-delete annotations of node %s: %v", nodeName, err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predelete
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestAnnotationHook(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, tc := range []struct {
+		name        string
+		acknowledge bool
+	}{
+		{name: "acknowledged", acknowledge: true},
+		{name: "timed out"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			node := BuildTestNode("n1", 1000, 1000)
+			// Left behind by an earlier deletion attempt.
+			node.Annotations = map[string]string{PreDeleteAcknowledgedAnnotation: "true", "other": "value"}
+			client := fake.NewSimpleClientset(node)
+			hook := NewAnnotationHook(client, time.Hour)
+			hook.pollInterval = 10 * time.Millisecond
+			hook.now = func() time.Time { return now }
+			if !tc.acknowledge {
+				hook.timeout = 50 * time.Millisecond
+			}
+
+			done := make(chan error)
+			go func() { done <- hook.Run(node.Name) }()
+
+			if tc.acknowledge {
+				assert.Eventually(t, func() bool {
+					n, err := client.CoreV1().Nodes().Get(context.TODO(), node.Name, metav1.GetOptions{})
+					return err == nil && n.Annotations[PreDeleteAnnotation] == "2025-01-02T03:04:05Z" && n.Annotations[PreDeleteAcknowledgedAnnotation] == ""
+				}, 5*time.Second, 10*time.Millisecond)
+				n, err := client.CoreV1().Nodes().Get(context.TODO(), node.Name, metav1.GetOptions{})
+				assert.NoError(t, err)
+				n.Annotations[PreDeleteAcknowledgedAnnotation] = "true"
+				_, err = client.CoreV1().Nodes().Update(context.TODO(), n, metav1.UpdateOptions{})
+				assert.NoError(t, err)
+			}
+
+			select {
+			case err := <-done:
+				assert.NoError(t, err)
+			case <-time.After(5 * time.Second):
+				t.Fatal("pre-delete hook didn't return")
+			}
+			n, err := client.CoreV1().Nodes().Get(context.TODO(), node.Name, metav1.GetOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]string{"other": "value"}, n.Annotations)
+		})
+	}
+}
+
+func TestAnnotationHookMissingNode(t *testing.T) {
+	hook := NewAnnotationHook(fake.NewSimpleClientset(), time.Hour)
+	assert.Error(t, hook.Run("n1"))
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predelete
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// AnnotationMode makes Cluster Autoscaler request the pre-delete hook of a
+	// node through the PreDeleteAnnotation.
+	AnnotationMode = "annotation"
+	// NodeDeletionHookMode makes Cluster Autoscaler request the pre-delete hook
+	// of a node through a NodeDeletionHook object.
+	NodeDeletionHookMode = "node-deletion-hook"
+
+	defaultPollInterval = 5 * time.Second
+)
+
+// Hook lets external controllers finish their work on a node, e.g. shipping logs
+// or detaching storage, after the node is drained and before it's deleted.
+type Hook interface {
+	// Run requests the hook of the node and waits until it completes or times
+	// out, whichever comes first. Deletion of the node proceeds after a timeout,
+	// an error is only returned if the hook couldn't be requested.
+	Run(nodeName string) error
+}
+
+// waitForCompletion polls completed until it returns true or the timeout is
+// reached. Errors returned by completed are logged and don't stop the wait.
+func waitForCompletion(nodeName, hookName string, interval, timeout time.Duration, completed func(context.Context) (bool, error)) {
+	klog.V(1).Infof("Waiting up to %v for the pre-delete hook of node %s to complete (%s)", timeout, nodeName, hookName)
+	err := wait.PollUntilContextTimeout(context.Background(), interval, timeout, false, func(ctx context.Context) (bool, error) {
+		done, err := completed(ctx)
+		if err != nil {
+			klog.Warningf("Failed to check the pre-delete hook of node %s: %v", nodeName, err)
+			return false, nil
+		}
+		return done, nil
+	})
+	if err != nil {
+		klog.Warningf("Pre-delete hook of node %s didn't complete within %v (%s), deleting the node anyway", nodeName, timeout, hookName)
+		return
+	}
+	klog.V(2).Infof("Pre-delete hook of node %s completed (%s)", nodeName, hookName)
+}
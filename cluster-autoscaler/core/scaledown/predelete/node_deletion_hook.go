@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predelete
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kube_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+// NodeDeletionHookKind is the kind of the NodeDeletionHook CRD.
+const NodeDeletionHookKind = "NodeDeletionHook"
+
+// NodeDeletionHookResource is the resource of the NodeDeletionHook CRD.
+var NodeDeletionHookResource = schema.GroupVersionResource{
+	Group:    "autoscaling.x-k8s.io",
+	Version:  "v1alpha1",
+	Resource: "nodedeletionhooks",
+}
+
+// NodeDeletionHookSpec is the spec of a NodeDeletionHook object, written by
+// Cluster Autoscaler.
+type NodeDeletionHookSpec struct {
+	// NodeName is the name of the node about to be deleted.
+	NodeName string `json:"nodeName"`
+	// RequestTime is the time the hook was requested at.
+	RequestTime metav1.Time `json:"requestTime"`
+	// Deadline is the time the node gets deleted at, unless the hook completes earlier.
+	Deadline metav1.Time `json:"deadline"`
+}
+
+// NodeDeletionHookStatus is the status of a NodeDeletionHook object, written by
+// an external controller.
+type NodeDeletionHookStatus struct {
+	// Completed tells if the controller is done with the node.
+	Completed bool `json:"completed,omitempty"`
+}
+
+// NodeDeletionHookClient requests the pre-delete hook by creating a cluster-scoped
+// NodeDeletionHook object named after the node, and waits for the object to
+// be marked completed in its status.
+type NodeDeletionHookClient struct {
+	client       dynamic.Interface
+	timeout      time.Duration
+	pollInterval time.Duration
+	now          func() time.Time
+}
+
+// NewNodeDeletionHookClient returns a NodeDeletionHookClient waiting up to timeout for hooks to complete.
+func NewNodeDeletionHookClient(kubeConfig *rest.Config, timeout time.Duration) (*NodeDeletionHookClient, error) {
+	client, err := dynamic.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create NodeDeletionHook client: %v", err)
+	}
+	return newNodeDeletionHookClient(client, timeout), nil
+}
+
+func newNodeDeletionHookClient(client dynamic.Interface, timeout time.Duration) *NodeDeletionHookClient {
+	return &NodeDeletionHookClient{
+		client:       client,
+		timeout:      timeout,
+		pollInterval: defaultPollInterval,
+		now:          time.Now,
+	}
+}
+
+// Run creates the NodeDeletionHook object of the node, waits for it to complete
+// and deletes it, so that it doesn't stay behind if deletion of the node fails
+// later on.
+func (c *NodeDeletionHookClient) Run(nodeName string) error {
+	objects := c.client.Resource(NodeDeletionHookResource)
+	// An object left by an earlier deletion attempt is replaced, along with its status.
+	if err := c.delete(nodeName); err != nil {
+		return err
+	}
+	now := c.now()
+	spec := NodeDeletionHookSpec{
+		NodeName:    nodeName,
+		RequestTime: metav1.NewTime(now),
+		Deadline:    metav1.NewTime(now.Add(c.timeout)),
+	}
+	specMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&spec)
+	if err != nil {
+		return fmt.Errorf("failed to convert NodeDeletionHook: %v", err)
+	}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": specMap}}
+	obj.SetAPIVersion(NodeDeletionHookResource.GroupVersion().String())
+	obj.SetKind(NodeDeletionHookKind)
+	obj.SetName(nodeName)
+	if _, err := objects.Create(context.TODO(), obj, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create NodeDeletionHook %s: %v", nodeName, err)
+	}
+
+	waitForCompletion(nodeName, NodeDeletionHookKind+" "+nodeName, c.pollInterval, c.timeout, func(ctx context.Context) (bool, error) {
+		obj, err := objects.Get(ctx, nodeName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		statusMap, found, err := unstructured.NestedMap(obj.Object, "status")
+		if err != nil || !found {
+			return false, err
+		}
+		var status NodeDeletionHookStatus
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(statusMap, &status); err != nil {
+			return false, fmt.Errorf("failed to convert the status of NodeDeletionHook %s: %v", nodeName, err)
+		}
+		return status.Completed, nil
+	})
+	if err := c.delete(nodeName); err != nil {
+		klog.Warningf("Failed to clean up after the pre-delete hook of node %s: %v", nodeName, err)
+	}
+	return nil
+}
+
+// delete removes the NodeDeletionHook object of the node, if any.
+func (c *NodeDeletionHookClient) delete(nodeName string) error {
+	err := c.client.Resource(NodeDeletionHookResource).Delete(context.TODO(), nodeName, metav1.DeleteOptions{})
+	if err != nil && !kube_errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete NodeDeletionHook %s: %v", nodeName, err)
+	}
+	return nil
+}
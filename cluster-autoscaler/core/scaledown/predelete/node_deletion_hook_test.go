@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predelete
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	kube_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+)
+
+func TestNodeDeletionHookClient(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, tc := range []struct {
+		name     string
+		complete bool
+	}{
+		{name: "completed", complete: true},
+		{name: "timed out"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+				NodeDeletionHookResource: "NodeDeletionHookList",
+			})
+			objects := client.Resource(NodeDeletionHookResource)
+			// Left behind by an earlier deletion attempt.
+			stale := &unstructured.Unstructured{Object: map[string]interface{}{"status": map[string]interface{}{"completed": true}}}
+			stale.SetAPIVersion(NodeDeletionHookResource.GroupVersion().String())
+			stale.SetKind(NodeDeletionHookKind)
+			stale.SetName("n1")
+			_, err := objects.Create(context.TODO(), stale, metav1.CreateOptions{})
+			assert.NoError(t, err)
+
+			hookClient := newNodeDeletionHookClient(client, time.Hour)
+			hookClient.pollInterval = 10 * time.Millisecond
+			hookClient.now = func() time.Time { return now }
+			if !tc.complete {
+				hookClient.timeout = 50 * time.Millisecond
+			}
+
+			done := make(chan error)
+			go func() { done <- hookClient.Run("n1") }()
+
+			if tc.complete {
+				var obj *unstructured.Unstructured
+				assert.Eventually(t, func() bool {
+					obj, err = objects.Get(context.TODO(), "n1", metav1.GetOptions{})
+					if err != nil {
+						return false
+					}
+					_, found := obj.Object["status"]
+					return !found
+				}, 5*time.Second, 10*time.Millisecond)
+				nodeName, _, _ := unstructured.NestedString(obj.Object, "spec", "nodeName")
+				assert.Equal(t, "n1", nodeName)
+				deadline, _, _ := unstructured.NestedString(obj.Object, "spec", "deadline")
+				assert.Equal(t, "2025-01-02T04:04:05Z", deadline)
+				assert.NoError(t, unstructured.SetNestedField(obj.Object, true, "status", "completed"))
+				_, err = objects.Update(context.TODO(), obj, metav1.UpdateOptions{})
+				assert.NoError(t, err)
+			}
+
+			select {
+			case err := <-done:
+				assert.NoError(t, err)
+			case <-time.After(5 * time.Second):
+				t.Fatal("pre-delete hook didn't return")
+			}
+			_, err = objects.Get(context.TODO(), "n1", metav1.GetOptions{})
+			assert.True(t, kube_errors.IsNotFound(err))
+		})
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package scaledown
 
 import (
+	ctx "context"
 	"time"
 
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
@@ -55,10 +56,11 @@ type Actuator interface {
 	// StartDeletion triggers a new deletion process. Nodes passed to this
 	// function are not guaranteed to be deleted, it is possible for the
 	// Actuator to ignore some of them e.g. if max configured level of
-	// parallelism is reached.
-	StartDeletion(empty, needDrain []*apiv1.Node) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError)
+	// parallelism is reached. Spans of the drains and deletions, which outlive
+	// the call, are nested in the span of traceContext.
+	StartDeletion(traceContext ctx.Context, empty, needDrain []*apiv1.Node) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError)
 	// StartForceDeletion triggers a new forced deletion process. It bypasses PDBs and forcefully deletes the pods and the nodes.
-	StartForceDeletion(empty, needDrain []*apiv1.Node) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError)
+	StartForceDeletion(traceContext ctx.Context, empty, needDrain []*apiv1.Node) (status.ScaleDownResult, []*status.ScaleDownNode, errors.AutoscalerError)
 	// CheckStatus returns an immutable snapshot of ongoing deletions.
 	CheckStatus() ActuationStatus
 	// ClearResultsNotNewerThan removes information about deletions finished
@@ -35,6 +35,10 @@ type ScaleDownStatus struct {
 	RemovedNodeGroups     []cloudprovider.NodeGroup
 	NodeDeleteResults     map[string]NodeDeleteResult
 	NodeDeleteResultsAsOf time.Time
+	// UnneededNodes lists nodes that can be deleted now or in the near future.
+	UnneededNodes []*apiv1.Node
+	// DryRunScaledDownNodes lists nodes that would have been scaled down if scale-down dry-run wasn't enabled.
+	DryRunScaledDownNodes []*ScaleDownNode
 }
 
 // SetUnremovableNodesInfo sets the status of nodes that were found to be unremovable.
@@ -55,11 +59,12 @@ func (s *ScaleDownStatus) SetUnremovableNodesInfo(unremovableNodes []*simulator.
 		}
 
 		s.UnremovableNodes = append(s.UnremovableNodes, &UnremovableNode{
-			Node:        unremovableNode.Node,
-			NodeGroup:   nodeGroup,
-			UtilInfo:    utilInfoPtr,
-			Reason:      unremovableNode.Reason,
-			BlockingPod: unremovableNode.BlockingPod,
+			Node:                 unremovableNode.Node,
+			NodeGroup:            nodeGroup,
+			UtilInfo:             utilInfoPtr,
+			Reason:               unremovableNode.Reason,
+			BlockingPod:          unremovableNode.BlockingPod,
+			UtilizationBreakdown: unremovableNode.UtilizationBreakdown,
 		})
 	}
 }
@@ -71,6 +76,8 @@ type UnremovableNode struct {
 	UtilInfo    *utilization.Info
 	Reason      simulator.UnremovableReason
 	BlockingPod *drain.BlockingPod
+	// UtilizationBreakdown lists pods holding a NotUnderutilized node.
+	UtilizationBreakdown *utilization.Breakdown
 }
 
 // ScaleDownNode represents the state of a node that's being scaled down.
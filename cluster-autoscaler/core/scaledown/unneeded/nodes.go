@@ -53,6 +53,8 @@ type scaleDownTimeGetter interface {
 	GetScaleDownUnneededTime(nodeGroup cloudprovider.NodeGroup) (time.Duration, error)
 	// GetScaleDownUnreadyTime returns ScaleDownUnreadyTime value that should be used for a given NodeGroup.
 	GetScaleDownUnreadyTime(nodeGroup cloudprovider.NodeGroup) (time.Duration, error)
+	// GetScaleDownSoftTaintOnly returns ScaleDownSoftTaintOnly value that should be used for a given NodeGroup.
+	GetScaleDownSoftTaintOnly(nodeGroup cloudprovider.NodeGroup) (bool, error)
 }
 
 // NewNodes returns a new initialized Nodes object.
@@ -182,6 +184,17 @@ func (n *Nodes) unremovableReason(context *context.AutoscalingContext, scaleDown
 		}
 	}
 
+	// Nodes in soft taint only node groups are cordoned instead of being deleted, so size and resource limits don't apply.
+	softTaintOnly, err := n.sdtg.GetScaleDownSoftTaintOnly(nodeGroup)
+	if err != nil {
+		klog.Errorf("Error trying to get ScaleDownSoftTaintOnly for node %s (in group: %s)", node.Name, nodeGroup.Id())
+		return simulator.UnexpectedError
+	}
+	if softTaintOnly {
+		klog.V(4).Infof("Skipping %s - node group only soft-taints and cordons unneeded nodes", node.Name)
+		return simulator.ScaleDownSoftTaintOnly
+	}
+
 	if reason := verifyMinSize(node.Name, nodeGroup, nodeGroupSize, scaleDownContext.ActuationStatus); reason != simulator.NoReason {
 		return reason
 	}
@@ -135,6 +135,7 @@ func TestRemovableAt(t *testing.T) {
 		minSize             int
 		targetSize          int
 		numOngoingDeletions int
+		softTaintOnly       bool
 		numEmptyToRemove    int
 		numDrainToRemove    int
 	}{
@@ -168,6 +169,17 @@ func TestRemovableAt(t *testing.T) {
 			numEmptyToRemove:    2,
 			numDrainToRemove:    0,
 		},
+		{
+			name:                "Node group only soft-taints unneeded nodes",
+			numEmpty:            3,
+			numDrain:            2,
+			minSize:             1,
+			targetSize:          10,
+			numOngoingDeletions: 0,
+			softTaintOnly:       true,
+			numEmptyToRemove:    0,
+			numDrainToRemove:    0,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -201,9 +213,9 @@ func TestRemovableAt(t *testing.T) {
 			ctx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{ScaleDownSimulationTimeout: 5 * time.Minute}, &fake.Clientset{}, registry, provider, nil, nil)
 			assert.NoError(t, err)
 
-			n := NewNodes(&fakeScaleDownTimeGetter{}, &resource.LimitsFinder{})
+			n := NewNodes(&fakeScaleDownTimeGetter{softTaintOnly: tc.softTaintOnly}, &resource.LimitsFinder{})
 			n.Update(removableNodes, time.Now())
-			gotEmptyToRemove, gotDrainToRemove, _ := n.RemovableAt(&ctx, nodes.ScaleDownContext{
+			gotEmptyToRemove, gotDrainToRemove, unremovable := n.RemovableAt(&ctx, nodes.ScaleDownContext{
 				ActuationStatus:     as,
 				ResourcesLeft:       resource.Limits{},
 				ResourcesWithLimits: []string{},
@@ -211,6 +223,12 @@ func TestRemovableAt(t *testing.T) {
 			if len(gotDrainToRemove) != tc.numDrainToRemove || len(gotEmptyToRemove) != tc.numEmptyToRemove {
 				t.Errorf("%s: getNodesToRemove() return %d, %d, want %d, %d", tc.name, len(gotEmptyToRemove), len(gotDrainToRemove), tc.numEmptyToRemove, tc.numDrainToRemove)
 			}
+			if tc.softTaintOnly {
+				assert.Len(t, unremovable, tc.numEmpty+tc.numDrain)
+				for _, u := range unremovable {
+					assert.Equal(t, simulator.ScaleDownSoftTaintOnly, u.Reason)
+				}
+			}
 		})
 	}
 }
@@ -236,7 +254,9 @@ func (f *fakeActuationStatus) DeletionsCount(nodeGroup string) int {
 	return f.deletionCount[nodeGroup]
 }
 
-type fakeScaleDownTimeGetter struct{}
+type fakeScaleDownTimeGetter struct {
+	softTaintOnly bool
+}
 
 func (f *fakeScaleDownTimeGetter) GetScaleDownUnneededTime(cloudprovider.NodeGroup) (time.Duration, error) {
 	return 0 * time.Second, nil
@@ -245,3 +265,7 @@ func (f *fakeScaleDownTimeGetter) GetScaleDownUnneededTime(cloudprovider.NodeGro
 func (f *fakeScaleDownTimeGetter) GetScaleDownUnreadyTime(cloudprovider.NodeGroup) (time.Duration, error) {
 	return 0 * time.Second, nil
 }
+
+func (f *fakeScaleDownTimeGetter) GetScaleDownSoftTaintOnly(cloudprovider.NodeGroup) (bool, error) {
+	return f.softTaintOnly, nil
+}
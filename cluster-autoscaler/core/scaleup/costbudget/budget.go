@@ -0,0 +1,193 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package costbudget
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupset"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+)
+
+const (
+	// HardMode rejects scale-ups that would exceed the budget.
+	HardMode = "hard"
+	// SoftMode only warns about scale-ups that would exceed the budget.
+	SoftMode = "soft"
+)
+
+// Budget limits the hourly cost of all node groups in the cluster.
+type Budget struct {
+	cloudProvider  cloudprovider.CloudProvider
+	maxHourlyCost  float64
+	mode           string
+	nodeGroupPrice map[string]float64
+}
+
+// CheckResult contains the result of checking a scale-up against the budget.
+type CheckResult struct {
+	// Exceeded is true if the scale-up would exceed the budget.
+	Exceeded bool
+	// CurrentCost is the hourly cost of the cluster before the scale-up.
+	CurrentCost float64
+	// ScaleUpCost is the hourly cost added by the scale-up.
+	ScaleUpCost float64
+	// CappedScaleUpInfos is the scale-up reduced to the nodes fitting into the budget. Node groups which
+	// can't get any node are dropped. It is the unchanged scale-up if the budget isn't exceeded.
+	CappedScaleUpInfos []nodegroupset.ScaleUpInfo
+	// CappedScaleUpCost is the hourly cost added by CappedScaleUpInfos.
+	CappedScaleUpCost float64
+}
+
+// NewBudget creates a budget limiting the hourly cost of the cluster to maxHourlyCost.
+// Node group prices are taken from nodeGroupPrices, or computed with the cloud
+// provider pricing model for node groups without a configured price.
+func NewBudget(cloudProvider cloudprovider.CloudProvider, maxHourlyCost float64, mode string, nodeGroupPrices map[string]float64) *Budget {
+	return &Budget{
+		cloudProvider:  cloudProvider,
+		maxHourlyCost:  maxHourlyCost,
+		mode:           mode,
+		nodeGroupPrice: nodeGroupPrices,
+	}
+}
+
+// Enabled returns true if the cluster cost is limited.
+func (b *Budget) Enabled() bool {
+	return b.maxHourlyCost > 0
+}
+
+// Mode returns the mode of the budget.
+func (b *Budget) Mode() string {
+	return b.mode
+}
+
+// Soft returns true if scale-ups exceeding the budget should only be warned about.
+func (b *Budget) Soft() bool {
+	return b.mode == SoftMode
+}
+
+// MaxHourlyCost returns the maximum hourly cost of the cluster.
+func (b *Budget) MaxHourlyCost() float64 {
+	return b.maxHourlyCost
+}
+
+// NodePrice returns the hourly price of a single node of the node group.
+func (b *Budget) NodePrice(nodeGroup cloudprovider.NodeGroup, nodeInfo *framework.NodeInfo, now time.Time) (float64, error) {
+	if price, found := b.nodeGroupPrice[nodeGroup.Id()]; found {
+		return price, nil
+	}
+	if nodeInfo == nil {
+		return 0, fmt.Errorf("no price configured and no node template for node group %s", nodeGroup.Id())
+	}
+	pricing, pricingErr := b.cloudProvider.Pricing()
+	if pricingErr != nil {
+		return 0, fmt.Errorf("no price configured for node group %s and no pricing model: %v", nodeGroup.Id(), pricingErr)
+	}
+	price, err := pricing.NodePrice(nodeInfo.Node(), now, now.Add(time.Hour))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get node price for node group %s: %v", nodeGroup.Id(), err)
+	}
+	return price, nil
+}
+
+// ClusterCost returns the hourly cost of all node groups at their target sizes.
+// Nodes that don't belong to any node group are not included.
+func (b *Budget) ClusterCost(nodeGroups []cloudprovider.NodeGroup, nodeInfos map[string]*framework.NodeInfo, now time.Time) (float64, error) {
+	var cost float64
+	for _, nodeGroup := range nodeGroups {
+		targetSize, err := nodeGroup.TargetSize()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get target size of node group %s: %v", nodeGroup.Id(), err)
+		}
+		if targetSize == 0 {
+			continue
+		}
+		price, err := b.NodePrice(nodeGroup, nodeInfos[nodeGroup.Id()], now)
+		if err != nil {
+			return 0, err
+		}
+		cost += price * float64(targetSize)
+	}
+	return cost, nil
+}
+
+// CheckScaleUp checks if the given scale-up would make the cluster exceed the budget, and caps it to the nodes
+// fitting into the remaining budget. Nodes are removed one by one from the node group adding the most nodes,
+// so that balanced scale-ups stay balanced. An error is returned if the price of any node group is unknown.
+func (b *Budget) CheckScaleUp(scaleUpInfos []nodegroupset.ScaleUpInfo, nodeInfos map[string]*framework.NodeInfo, now time.Time) (CheckResult, error) {
+	currentCost, err := b.ClusterCost(b.cloudProvider.NodeGroups(), nodeInfos, now)
+	if err != nil {
+		return CheckResult{}, err
+	}
+	prices := make([]float64, len(scaleUpInfos))
+	var scaleUpCost float64
+	for i, info := range scaleUpInfos {
+		price, err := b.NodePrice(info.Group, nodeInfos[info.Group.Id()], now)
+		if err != nil {
+			return CheckResult{}, err
+		}
+		prices[i] = price
+		scaleUpCost += price * float64(info.NewSize-info.CurrentSize)
+	}
+	result := CheckResult{
+		Exceeded:           currentCost+scaleUpCost > b.maxHourlyCost,
+		CurrentCost:        currentCost,
+		ScaleUpCost:        scaleUpCost,
+		CappedScaleUpInfos: scaleUpInfos,
+		CappedScaleUpCost:  scaleUpCost,
+	}
+	if !result.Exceeded {
+		return result, nil
+	}
+
+	capped := make([]nodegroupset.ScaleUpInfo, len(scaleUpInfos))
+	copy(capped, scaleUpInfos)
+	cappedCost := scaleUpCost
+	for currentCost+cappedCost > b.maxHourlyCost {
+		largest := -1
+		for i, info := range capped {
+			delta := info.NewSize - info.CurrentSize
+			if delta <= 0 {
+				continue
+			}
+			if largest == -1 {
+				largest = i
+				continue
+			}
+			largestDelta := capped[largest].NewSize - capped[largest].CurrentSize
+			if delta > largestDelta || (delta == largestDelta && prices[i] > prices[largest]) {
+				largest = i
+			}
+		}
+		if largest == -1 {
+			break
+		}
+		capped[largest].NewSize--
+		cappedCost -= prices[largest]
+	}
+	result.CappedScaleUpInfos = nil
+	result.CappedScaleUpCost = 0
+	for i, info := range capped {
+		if info.NewSize > info.CurrentSize {
+			result.CappedScaleUpInfos = append(result.CappedScaleUpInfos, info)
+			result.CappedScaleUpCost += prices[i] * float64(info.NewSize-info.CurrentSize)
+		}
+	}
+	return result, nil
+}
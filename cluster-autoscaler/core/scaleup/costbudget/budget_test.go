@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package costbudget
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupset"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+type testPricingModel struct {
+	nodePrice map[string]float64
+}
+
+func (tpm *testPricingModel) NodePrice(node *apiv1.Node, startTime time.Time, endTime time.Time) (float64, error) {
+	if price, found := tpm.nodePrice[node.Name]; found {
+		return price * endTime.Sub(startTime).Hours(), nil
+	}
+	return 0, fmt.Errorf("price for node %v not found", node.Name)
+}
+
+func (tpm *testPricingModel) PodPrice(pod *apiv1.Pod, startTime time.Time, endTime time.Time) (float64, error) {
+	return 0, fmt.Errorf("price for pod %v not found", pod.Name)
+}
+
+func TestCheckScaleUp(t *testing.T) {
+	now := time.Now()
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 0, 10, 2)
+	provider.AddNodeGroup("ng2", 0, 10, 1)
+	provider.AddNodeGroup("ng3", 0, 10, 0)
+	provider.SetPricingModel(&testPricingModel{nodePrice: map[string]float64{"ng2-template": 2}})
+	nodeInfos := map[string]*framework.NodeInfo{
+		"ng1": framework.NewTestNodeInfo(BuildTestNode("ng1-template", 1000, 1000)),
+		"ng2": framework.NewTestNodeInfo(BuildTestNode("ng2-template", 1000, 1000)),
+		"ng3": framework.NewTestNodeInfo(BuildTestNode("ng3-template", 1000, 1000)),
+	}
+	budget := NewBudget(provider, 10, HardMode, map[string]float64{"ng1": 1.5})
+
+	// ng1: 2 nodes with a configured price of 1.5, ng2: 1 node priced by the pricing model at 2.
+	cost, err := budget.ClusterCost(provider.NodeGroups(), nodeInfos, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 5.0, cost)
+
+	result, err := budget.CheckScaleUp([]nodegroupset.ScaleUpInfo{
+		{Group: provider.GetNodeGroup("ng1"), CurrentSize: 2, NewSize: 4},
+		{Group: provider.GetNodeGroup("ng2"), CurrentSize: 1, NewSize: 2},
+	}, nodeInfos, now)
+	assert.NoError(t, err)
+	assert.False(t, result.Exceeded)
+	assert.Equal(t, 5.0, result.CurrentCost)
+	assert.Equal(t, 5.0, result.ScaleUpCost)
+	assert.Equal(t, 5.0, result.CappedScaleUpCost)
+	assert.Len(t, result.CappedScaleUpInfos, 2)
+
+	// The remaining budget of 5 fits 2 nodes of ng2.
+	result, err = budget.CheckScaleUp([]nodegroupset.ScaleUpInfo{
+		{Group: provider.GetNodeGroup("ng2"), CurrentSize: 1, NewSize: 4},
+	}, nodeInfos, now)
+	assert.NoError(t, err)
+	assert.True(t, result.Exceeded)
+	assert.Equal(t, 6.0, result.ScaleUpCost)
+	assert.Equal(t, 4.0, result.CappedScaleUpCost)
+	assert.Equal(t, []nodegroupset.ScaleUpInfo{{Group: provider.GetNodeGroup("ng2"), CurrentSize: 1, NewSize: 3}}, result.CappedScaleUpInfos)
+
+	// Nodes are removed from the node group adding the most nodes first, keeping the scale-up balanced.
+	result, err = budget.CheckScaleUp([]nodegroupset.ScaleUpInfo{
+		{Group: provider.GetNodeGroup("ng1"), CurrentSize: 2, NewSize: 5},
+		{Group: provider.GetNodeGroup("ng2"), CurrentSize: 1, NewSize: 3},
+	}, nodeInfos, now)
+	assert.NoError(t, err)
+	assert.True(t, result.Exceeded)
+	assert.Equal(t, 5.0, result.CappedScaleUpCost)
+	assert.Equal(t, []nodegroupset.ScaleUpInfo{
+		{Group: provider.GetNodeGroup("ng1"), CurrentSize: 2, NewSize: 4},
+		{Group: provider.GetNodeGroup("ng2"), CurrentSize: 1, NewSize: 2},
+	}, result.CappedScaleUpInfos)
+
+	// No node fits into a budget that is already used up.
+	budget = NewBudget(provider, 6, HardMode, map[string]float64{"ng1": 1.5})
+	result, err = budget.CheckScaleUp([]nodegroupset.ScaleUpInfo{
+		{Group: provider.GetNodeGroup("ng2"), CurrentSize: 1, NewSize: 2},
+	}, nodeInfos, now)
+	assert.NoError(t, err)
+	assert.True(t, result.Exceeded)
+	assert.Empty(t, result.CappedScaleUpInfos)
+
+	// ng3 has neither a configured price nor a price in the pricing model.
+	_, err = budget.CheckScaleUp([]nodegroupset.ScaleUpInfo{
+		{Group: provider.GetNodeGroup("ng3"), CurrentSize: 0, NewSize: 1},
+	}, nodeInfos, now)
+	assert.Error(t, err)
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dryrun contains the HTTP handler of the scale-up simulation endpoint.
+// Requests are queued by the handler and processed by the autoscaler loop, as
+// simulation needs a consistent view of the cluster that is only available there.
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/basicauth"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// Path is the path the handler is served on.
+	Path = "/simulate/scale-up"
+
+	maxPendingRequests = 10
+	maxRequestBytes    = 10 << 20
+)
+
+// Request is a scale-up simulation request.
+type Request struct {
+	// Pods to simulate scale-up for. Missing names and namespaces are defaulted.
+	Pods []apiv1.Pod `json:"pods"`
+}
+
+// Response is a result of scale-up simulation.
+type Response struct {
+	// ScaleUps lists node groups that would be scaled up.
+	ScaleUps []NodeGroupScaleUp `json:"scaleUps"`
+	// PodsFittingExistingNodes lists pods that fit on existing or upcoming nodes and don't need a scale-up.
+	PodsFittingExistingNodes []string `json:"podsFittingExistingNodes"`
+	// PodsTriggeringScaleUp lists pods that would be scheduled on new nodes.
+	PodsTriggeringScaleUp []string `json:"podsTriggeringScaleUp"`
+	// PodsRemainingUnschedulable lists pods that wouldn't be helped by a scale-up, along with the reasons.
+	PodsRemainingUnschedulable []UnschedulablePod `json:"podsRemainingUnschedulable"`
+}
+
+// NodeGroupScaleUp describes a scale-up of a single node group.
+type NodeGroupScaleUp struct {
+	NodeGroup   string `json:"nodeGroup"`
+	CurrentSize int    `json:"currentSize"`
+	NewSize     int    `json:"newSize"`
+	// NewNodeGroup is true if the node group doesn't exist yet and would be created.
+	NewNodeGroup bool `json:"newNodeGroup,omitempty"`
+}
+
+// UnschedulablePod describes a pod that wouldn't be helped by a scale-up.
+type UnschedulablePod struct {
+	Pod string `json:"pod"`
+	// Reasons maps node groups to the reasons they were rejected or skipped for the pod.
+	Reasons map[string][]string `json:"reasons,omitempty"`
+}
+
+// SimulateFunc simulates scale-up for given pods.
+type SimulateFunc func(pods []*apiv1.Pod) (*Response, error)
+
+type pendingRequest struct {
+	ctx    context.Context
+	pods   []*apiv1.Pod
+	result chan simulationResult
+}
+
+type simulationResult struct {
+	response *Response
+	err      error
+}
+
+// Handler serves scale-up simulation requests. Requests have to authenticate
+// with HTTP basic auth, using one of the configured credentials.
+type Handler struct {
+	requests chan *pendingRequest
+	timeout  time.Duration
+	// credentials are the passwords by user name.
+	credentials map[string]string
+}
+
+// NewHandler returns a new Handler accepting the given passwords by user name.
+// Requests not processed within timeout fail.
+func NewHandler(timeout time.Duration, credentials map[string]string) *Handler {
+	return &Handler{
+		requests:    make(chan *pendingRequest, maxPendingRequests),
+		timeout:     timeout,
+		credentials: credentials,
+	}
+}
+
+// ServeHTTP queues a simulation request and waits for its result.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !basicauth.Authenticated(r, h.credentials) {
+		basicauth.Unauthorized(w)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	var request Request
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&request); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if len(request.Pods) == 0 {
+		http.Error(w, "no pods to simulate scale-up for", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+	pending := &pendingRequest{ctx: ctx, pods: preparePods(request.Pods), result: make(chan simulationResult, 1)}
+	select {
+	case h.requests <- pending:
+	default:
+		http.Error(w, "too many pending simulation requests", http.StatusTooManyRequests)
+		return
+	}
+
+	select {
+	case result := <-pending.result:
+		if result.err != nil {
+			http.Error(w, fmt.Sprintf("simulation failed: %v", result.err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(result.response); err != nil {
+			klog.Errorf("Failed to write scale-up simulation response: %v", err)
+		}
+	case <-ctx.Done():
+		if r.Context().Err() == nil {
+			http.Error(w, "simulation request wasn't processed in time", http.StatusServiceUnavailable)
+		}
+	}
+}
+
+// ProcessPending runs simulate for all pending requests. It is meant to be
+// called by the autoscaler loop. Requests that timed out are dropped.
+func (h *Handler) ProcessPending(simulate SimulateFunc) {
+	for {
+		select {
+		case pending := <-h.requests:
+			if pending.ctx.Err() != nil {
+				continue
+			}
+			response, err := simulate(pending.pods)
+			pending.result <- simulationResult{response: response, err: err}
+		default:
+			return
+		}
+	}
+}
+
+// preparePods defaults fields required for pods to be simulated.
+func preparePods(pods []apiv1.Pod) []*apiv1.Pod {
+	result := make([]*apiv1.Pod, 0, len(pods))
+	for i := range pods {
+		pod := pods[i].DeepCopy()
+		if pod.Name == "" {
+			pod.Name = fmt.Sprintf("simulated-pod-%d", i)
+		}
+		if pod.Namespace == "" {
+			pod.Namespace = apiv1.NamespaceDefault
+		}
+		pod.UID = types.UID(fmt.Sprintf("simulated-%d-%s-%s", i, pod.Namespace, pod.Name))
+		pod.Spec.NodeName = ""
+		pod.Status = apiv1.PodStatus{Phase: apiv1.PodPending}
+		result = append(result, pod)
+	}
+	return result
+}
+
+// NewResponse builds a Response out of pods fitting existing nodes and status
+// of the simulated scale-up.
+func NewResponse(podsFittingExistingNodes []*apiv1.Pod, scaleUpStatus *status.ScaleUpStatus) *Response {
+	response := &Response{
+		ScaleUps:                   []NodeGroupScaleUp{},
+		PodsFittingExistingNodes:   podNames(podsFittingExistingNodes),
+		PodsTriggeringScaleUp:      []string{},
+		PodsRemainingUnschedulable: []UnschedulablePod{},
+	}
+	if scaleUpStatus == nil {
+		return response
+	}
+	for _, info := range scaleUpStatus.ScaleUpInfos {
+		response.ScaleUps = append(response.ScaleUps, NodeGroupScaleUp{
+			NodeGroup:    info.Group.Id(),
+			CurrentSize:  info.CurrentSize,
+			NewSize:      info.NewSize,
+			NewNodeGroup: !info.Group.Exist(),
+		})
+	}
+	response.PodsTriggeringScaleUp = podNames(scaleUpStatus.PodsTriggeredScaleUp)
+	for _, noScaleUpInfo := range scaleUpStatus.PodsRemainUnschedulable {
+		reasons := make(map[string][]string)
+		for nodeGroup, r := range noScaleUpInfo.RejectedNodeGroups {
+			reasons[nodeGroup] = append(reasons[nodeGroup], r.Reasons()...)
+		}
+		for nodeGroup, r := range noScaleUpInfo.SkippedNodeGroups {
+			reasons[nodeGroup] = append(reasons[nodeGroup], r.Reasons()...)
+		}
+		response.PodsRemainingUnschedulable = append(response.PodsRemainingUnschedulable, UnschedulablePod{
+			Pod:     podName(noScaleUpInfo.Pod),
+			Reasons: reasons,
+		})
+	}
+	return response
+}
+
+func podNames(pods []*apiv1.Pod) []string {
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, podName(pod))
+	}
+	sort.Strings(names)
+	return names
+}
+
+func podName(pod *apiv1.Pod) string {
+	return pod.Namespace + "/" + pod.Name
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupset"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+var testCredentials = map[string]string{"admin": "secret"}
+
+func newRequest(method string, body []byte) *http.Request {
+	req := httptest.NewRequest(method, Path, bytes.NewReader(body))
+	req.SetBasicAuth("admin", "secret")
+	return req
+}
+
+func postRequest(t *testing.T, h *Handler, request Request) *httptest.ResponseRecorder {
+	body, err := json.Marshal(request)
+	assert.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, newRequest(http.MethodPost, body))
+	return recorder
+}
+
+func TestHandler(t *testing.T) {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 1, 10, 2)
+	ng1 := provider.GetNodeGroup("ng1")
+
+	h := NewHandler(time.Minute, testCredentials)
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			case <-time.After(10 * time.Millisecond):
+				h.ProcessPending(func(pods []*apiv1.Pod) (*Response, error) {
+					assert.Equal(t, 2, len(pods))
+					assert.Equal(t, "default", pods[0].Namespace)
+					assert.Equal(t, "simulated-pod-1", pods[1].Name)
+					assert.NotEqual(t, pods[0].UID, pods[1].UID)
+					return NewResponse([]*apiv1.Pod{pods[0]}, &status.ScaleUpStatus{
+						Result:               status.ScaleUpSuccessful,
+						ScaleUpInfos:         []nodegroupset.ScaleUpInfo{{Group: ng1, CurrentSize: 2, NewSize: 3, MaxSize: 10}},
+						PodsTriggeredScaleUp: []*apiv1.Pod{pods[1]},
+					}), nil
+				})
+			}
+		}
+	}()
+
+	recorder := postRequest(t, h, Request{Pods: []apiv1.Pod{*BuildTestPod("p1", 100, 0), {}}})
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var response Response
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, Response{
+		ScaleUps:                   []NodeGroupScaleUp{{NodeGroup: "ng1", CurrentSize: 2, NewSize: 3}},
+		PodsFittingExistingNodes:   []string{"default/p1"},
+		PodsTriggeringScaleUp:      []string{"default/simulated-pod-1"},
+		PodsRemainingUnschedulable: []UnschedulablePod{},
+	}, response)
+}
+
+func TestHandlerErrors(t *testing.T) {
+	h := NewHandler(10*time.Millisecond, testCredentials)
+
+	recorder := httptest.NewRecorder()
+	h.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, Path, bytes.NewReader([]byte(`{"pods": [{}]}`))))
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	assert.Equal(t, 0, len(h.requests))
+
+	recorder = httptest.NewRecorder()
+	h.ServeHTTP(recorder, newRequest(http.MethodGet, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	h.ServeHTTP(recorder, newRequest(http.MethodPost, []byte("{")))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = postRequest(t, h, Request{})
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	// Requests not processed in time time out, but stay queued until the next ProcessPending.
+	for i := 0; i < maxPendingRequests; i++ {
+		recorder = postRequest(t, h, Request{Pods: []apiv1.Pod{*BuildTestPod("p1", 100, 0)}})
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	}
+	recorder = postRequest(t, h, Request{Pods: []apiv1.Pod{*BuildTestPod("p1", 100, 0)}})
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+
+	processed := 0
+	h.ProcessPending(func(pods []*apiv1.Pod) (*Response, error) {
+		processed++
+		return nil, nil
+	})
+	assert.Equal(t, 0, processed)
+
+	h = NewHandler(time.Minute, testCredentials)
+	go func() {
+		for len(h.requests) == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		h.ProcessPending(func(pods []*apiv1.Pod) (*Response, error) {
+			return nil, fmt.Errorf("simulation failed")
+		})
+	}()
+	recorder = postRequest(t, h, Request{Pods: []apiv1.Pod{*BuildTestPod("p1", 100, 0)}})
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package orchestrator
 
 import (
+	ctx "context"
 	"sync"
 	"time"
 
@@ -51,10 +52,13 @@ type AsyncNodeGroupInitializer struct {
 	scaleUpStatusProcessor status.ScaleUpStatusProcessor
 	context                *context.AutoscalingContext
 	atomicScaleUp          bool
+	// traceContext carries the span of the node group creation, which the spans of the initial scale-up are nested in.
+	traceContext ctx.Context
 }
 
 // NewAsyncNodeGroupInitializer creates a new AsyncNodeGroupInitializer instance.
 func NewAsyncNodeGroupInitializer(
+	traceContext ctx.Context,
 	option *expander.Option,
 	nodeInfo *framework.NodeInfo,
 	scaleUpExecutor *scaleUpExecutor,
@@ -75,6 +79,7 @@ func NewAsyncNodeGroupInitializer(
 		scaleUpStatusProcessor: scaleUpStatusProcessor,
 		context:                context,
 		atomicScaleUp:          atomicScaleUp,
+		traceContext:           traceContext,
 	}
 }
 
@@ -140,7 +145,7 @@ func (s *AsyncNodeGroupInitializer) InitializeNodeGroup(result nodegroups.AsyncN
 		}
 	}
 	klog.Infof("Starting initial scale-up for async created node groups. Scale ups: %v", scaleUpInfos)
-	err, failedNodeGroups := s.scaleUpExecutor.ExecuteScaleUps(scaleUpInfos, nodeInfos, time.Now(), s.atomicScaleUp)
+	err, failedNodeGroups := s.scaleUpExecutor.ExecuteScaleUps(s.traceContext, scaleUpInfos, nodeInfos, time.Now(), s.atomicScaleUp)
 	if err != nil {
 		var failedNodeGroupIds []string
 		for _, failedNodeGroup := range failedNodeGroups {
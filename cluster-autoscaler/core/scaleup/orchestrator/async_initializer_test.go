@@ -17,6 +17,7 @@ limitations under the License.
 package orchestrator
 
 import (
+	ctx "context"
 	"fmt"
 	"testing"
 
@@ -106,7 +107,7 @@ func TestNodePoolAsyncInitialization(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			scaleUpStatusProcessor := &fakeScaleUpStatusProcessor{}
-			initializer := NewAsyncNodeGroupInitializer(&option, nodeInfo, executor, taints.TaintConfig{}, nil, scaleUpStatusProcessor, &context, false)
+			initializer := NewAsyncNodeGroupInitializer(ctx.Background(), &option, nodeInfo, executor, taints.TaintConfig{}, nil, scaleUpStatusProcessor, &context, false)
 			initializer.SetTargetSize(upcomingNodeGroup.Id(), int64(scaleUpSize))
 			asyncResult := nodegroups.AsyncNodeGroupCreationResult{
 				CreationResult: nodegroups.CreateNodeGroupResult{MainCreatedNodeGroup: tc.nodeGroup},
@@ -17,10 +17,12 @@ limitations under the License.
 package orchestrator
 
 import (
+	ctx "context"
 	"fmt"
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
 	"k8s.io/klog/v2"
@@ -33,6 +35,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupset"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/tracing"
 )
 
 // ScaleUpExecutor scales up node groups.
@@ -59,7 +62,9 @@ func newScaleUpExecutor(
 // May scale up groups concurrently when autoscler option is enabled.
 // In case of issues returns an error and a scale up info which failed to execute.
 // If there were multiple concurrent errors one combined error is returned.
+// Spans of the node group resizes are nested in the span of traceContext.
 func (e *scaleUpExecutor) ExecuteScaleUps(
+	traceContext ctx.Context,
 	scaleUpInfos []nodegroupset.ScaleUpInfo,
 	nodeInfos map[string]*framework.NodeInfo,
 	now time.Time,
@@ -67,12 +72,13 @@ func (e *scaleUpExecutor) ExecuteScaleUps(
 ) (errors.AutoscalerError, []cloudprovider.NodeGroup) {
 	options := e.autoscalingContext.AutoscalingOptions
 	if options.ParallelScaleUp {
-		return e.executeScaleUpsParallel(scaleUpInfos, nodeInfos, now, atomic)
+		return e.executeScaleUpsParallel(traceContext, scaleUpInfos, nodeInfos, now, atomic)
 	}
-	return e.executeScaleUpsSync(scaleUpInfos, nodeInfos, now, atomic)
+	return e.executeScaleUpsSync(traceContext, scaleUpInfos, nodeInfos, now, atomic)
 }
 
 func (e *scaleUpExecutor) executeScaleUpsSync(
+	traceContext ctx.Context,
 	scaleUpInfos []nodegroupset.ScaleUpInfo,
 	nodeInfos map[string]*framework.NodeInfo,
 	now time.Time,
@@ -85,7 +91,7 @@ func (e *scaleUpExecutor) executeScaleUpsSync(
 			klog.Errorf("ExecuteScaleUp: failed to get node info for node group %s", scaleUpInfo.Group.Id())
 			continue
 		}
-		if aErr := e.executeScaleUp(scaleUpInfo, nodeInfo, availableGPUTypes, now, atomic); aErr != nil {
+		if aErr := e.executeScaleUp(traceContext, scaleUpInfo, nodeInfo, availableGPUTypes, now, atomic); aErr != nil {
 			return aErr, []cloudprovider.NodeGroup{scaleUpInfo.Group}
 		}
 	}
@@ -93,6 +99,7 @@ func (e *scaleUpExecutor) executeScaleUpsSync(
 }
 
 func (e *scaleUpExecutor) executeScaleUpsParallel(
+	traceContext ctx.Context,
 	scaleUpInfos []nodegroupset.ScaleUpInfo,
 	nodeInfos map[string]*framework.NodeInfo,
 	now time.Time,
@@ -118,7 +125,7 @@ func (e *scaleUpExecutor) executeScaleUpsParallel(
 				klog.Errorf("ExecuteScaleUp: failed to get node info for node group %s", info.Group.Id())
 				return
 			}
-			if aErr := e.executeScaleUp(info, nodeInfo, availableGPUTypes, now, atomic); aErr != nil {
+			if aErr := e.executeScaleUp(traceContext, info, nodeInfo, availableGPUTypes, now, atomic); aErr != nil {
 				errResults <- errResult{err: aErr, info: &info}
 			}
 		}(scaleUpInfo)
@@ -141,18 +148,29 @@ func (e *scaleUpExecutor) executeScaleUpsParallel(
 	return nil, nil
 }
 
-func (e *scaleUpExecutor) increaseSize(nodeGroup cloudprovider.NodeGroup, increase int, atomic bool) error {
+// increaseSize increases the size of the node group, returning the scale-up operation if the node
+// group increased its size asynchronously.
+func (e *scaleUpExecutor) increaseSize(traceContext ctx.Context, nodeGroup cloudprovider.NodeGroup, increase int, atomic bool) (operation cloudprovider.ScaleUpOperation, err error) {
+	_, span := tracing.Start(traceContext, "NodeGroup.IncreaseSize",
+		attribute.String("node_group", nodeGroup.Id()), attribute.Int("delta", increase), attribute.Bool("atomic", atomic))
+	defer func() { tracing.End(span, err) }()
 	if atomic {
 		if err := nodeGroup.AtomicIncreaseSize(increase); err != cloudprovider.ErrNotImplemented {
-			return err
+			return nil, err
 		}
 		// If error is cloudprovider.ErrNotImplemented, fall back to non-atomic
 		// increase - cloud provider doesn't support it.
+	} else if asyncIncreaser, ok := nodeGroup.(cloudprovider.AsyncSizeIncreaser); ok {
+		operation, err := asyncIncreaser.IncreaseSizeAsync(increase)
+		if err != cloudprovider.ErrNotImplemented {
+			return operation, err
+		}
 	}
-	return nodeGroup.IncreaseSize(increase)
+	return nil, nodeGroup.IncreaseSize(increase)
 }
 
 func (e *scaleUpExecutor) executeScaleUp(
+	traceContext ctx.Context,
 	info nodegroupset.ScaleUpInfo,
 	nodeInfo *framework.NodeInfo,
 	availableGPUTypes map[string]struct{},
@@ -165,7 +183,8 @@ func (e *scaleUpExecutor) executeScaleUp(
 	e.autoscalingContext.LogRecorder.Eventf(apiv1.EventTypeNormal, "ScaledUpGroup",
 		"Scale-up: setting group %s size to %d instead of %d (max: %d)", info.Group.Id(), info.NewSize, info.CurrentSize, info.MaxSize)
 	increase := info.NewSize - info.CurrentSize
-	if err := e.increaseSize(info.Group, increase, atomic); err != nil {
+	operation, err := e.increaseSize(traceContext, info.Group, increase, atomic)
+	if err != nil {
 		e.autoscalingContext.LogRecorder.Eventf(apiv1.EventTypeWarning, "FailedToScaleUpGroup", "Scale-up failed for group %s: %v", info.Group.Id(), err)
 		aerr := errors.ToAutoscalerError(errors.CloudProviderError, err).AddPrefix("failed to increase node group size: ")
 		e.scaleStateNotifier.RegisterFailedScaleUp(info.Group, string(aerr.Type()), aerr.Error(), gpuResourceName, gpuType, now)
@@ -180,6 +199,10 @@ func (e *scaleUpExecutor) executeScaleUp(
 		return nil
 	}
 	e.scaleStateNotifier.RegisterScaleUp(info.Group, increase, time.Now())
+	if operationObserver, ok := e.scaleStateNotifier.(nodegroupchange.ScaleUpOperationObserver); ok && operation != nil {
+		klog.V(2).Infof("Scale-up of group %s accepted as operation %s", info.Group.Id(), operation.Id())
+		operationObserver.RegisterScaleUpOperation(info.Group, operation, increase, time.Now())
+	}
 	metrics.RegisterScaleUp(increase, gpuResourceName, gpuType)
 	e.autoscalingContext.LogRecorder.Eventf(apiv1.EventTypeNormal, "ScaledUpGroup",
 		"Scale-up: group %s size set to %d instead of %d (max: %d)", info.Group.Id(), info.NewSize, info.CurrentSize, info.MaxSize)
@@ -17,14 +17,18 @@ limitations under the License.
 package orchestrator
 
 import (
+	ctx "context"
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
 	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup/costbudget"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup/equivalence"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup/resource"
 	"k8s.io/autoscaler/cluster-autoscaler/estimator"
@@ -35,10 +39,12 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupset"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/klogx"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/tracing"
 	"k8s.io/klog/v2"
 )
 
@@ -47,9 +53,11 @@ type ScaleUpOrchestrator struct {
 	autoscalingContext   *context.AutoscalingContext
 	processors           *ca_processors.AutoscalingProcessors
 	resourceManager      *resource.Manager
+	costBudget           *costbudget.Budget
 	clusterStateRegistry *clusterstate.ClusterStateRegistry
 	scaleUpExecutor      *scaleUpExecutor
 	estimatorBuilder     estimator.EstimatorBuilder
+	binpackingHandles    []*framework.Handle
 	taintConfig          taints.TaintConfig
 	initialized          bool
 }
@@ -73,16 +81,20 @@ func (o *ScaleUpOrchestrator) Initialize(
 	o.processors = processors
 	o.clusterStateRegistry = clusterStateRegistry
 	o.estimatorBuilder = estimatorBuilder
+	o.binpackingHandles = newBinpackingHandles(autoscalingContext)
 	o.taintConfig = taintConfig
 	o.resourceManager = resource.NewManager(processors.CustomResourcesProcessor)
+	o.costBudget = costbudget.NewBudget(autoscalingContext.CloudProvider, autoscalingContext.MaxClusterHourlyCost, autoscalingContext.ClusterCostBudgetMode, autoscalingContext.NodeGroupHourlyPrices)
 	o.scaleUpExecutor = newScaleUpExecutor(autoscalingContext, processors.ScaleStateNotifier, o.processors.AsyncNodeGroupStateChecker)
 	o.initialized = true
 }
 
 // ScaleUp tries to scale the cluster up. Returns appropriate status or error if
 // an unexpected error occurred. Assumes that all nodes in the cluster are ready
-// and in sync with instance groups.
+// and in sync with instance groups. Spans of the scale-up are nested in the span of
+// traceContext.
 func (o *ScaleUpOrchestrator) ScaleUp(
+	traceContext ctx.Context,
 	unschedulablePods []*apiv1.Pod,
 	nodes []*apiv1.Node,
 	daemonSets []*appsv1.DaemonSet,
@@ -103,103 +115,23 @@ func (o *ScaleUpOrchestrator) ScaleUp(
 	podEquivalenceGroups := equivalence.BuildPodGroups(unschedulablePods)
 	metrics.UpdateDurationFromStart(metrics.BuildPodEquivalenceGroups, buildPodEquivalenceGroupsStart)
 
-	upcomingNodes, aErr := o.UpcomingNodes(nodeInfos)
+	planning, aErr := o.planScaleUp(unschedulablePods, nodes, nodeInfos)
 	if aErr != nil {
-		return status.UpdateScaleUpError(&status.ScaleUpStatus{}, aErr.AddPrefix("could not get upcoming nodes: "))
-	}
-	klog.V(4).Infof("Upcoming %d nodes", len(upcomingNodes))
-
-	nodeGroups := o.autoscalingContext.CloudProvider.NodeGroups()
-	if o.processors != nil && o.processors.NodeGroupListProcessor != nil {
-		var err error
-		nodeGroups, nodeInfos, err = o.processors.NodeGroupListProcessor.Process(o.autoscalingContext, nodeGroups, nodeInfos, unschedulablePods)
-		if err != nil {
-			return status.UpdateScaleUpError(&status.ScaleUpStatus{}, errors.ToAutoscalerError(errors.InternalError, err))
-		}
+		return status.UpdateScaleUpError(&status.ScaleUpStatus{}, aErr)
 	}
+	nodeGroups, nodeInfos, skippedNodeGroups, now := planning.nodeGroups, planning.nodeInfos, planning.skippedNodeGroups, planning.now
 
-	// Initialise binpacking limiter.
-	o.processors.BinpackingLimiter.InitBinpacking(o.autoscalingContext, nodeGroups)
-
-	resourcesLeft, aErr := o.resourceManager.ResourcesLeft(o.autoscalingContext, nodeInfos, nodes)
+	bestOption, newNodes, schedulablePodGroups, aErr := o.bestScaleUpOption(traceContext, planning, podEquivalenceGroups, planning.validNodeGroups, len(unschedulablePods), allOrNothing)
 	if aErr != nil {
-		return status.UpdateScaleUpError(&status.ScaleUpStatus{}, aErr.AddPrefix("could not compute total resources: "))
-	}
-
-	now := time.Now()
-
-	// Filter out invalid node groups
-	validNodeGroups, skippedNodeGroups := o.filterValidScaleUpNodeGroups(nodeGroups, nodeInfos, resourcesLeft, len(nodes)+len(upcomingNodes), now)
-
-	// Mark skipped node groups as processed.
-	for nodegroupID := range skippedNodeGroups {
-		o.processors.BinpackingLimiter.MarkProcessed(o.autoscalingContext, nodegroupID)
-	}
-
-	// Calculate expansion options
-	schedulablePodGroups := map[string][]estimator.PodEquivalenceGroup{}
-	var options []expander.Option
-
-	for _, nodeGroup := range validNodeGroups {
-		schedulablePodGroups[nodeGroup.Id()] = o.SchedulablePodGroups(podEquivalenceGroups, nodeGroup, nodeInfos[nodeGroup.Id()])
-	}
-
-	for _, nodeGroup := range validNodeGroups {
-		option := o.ComputeExpansionOption(nodeGroup, schedulablePodGroups, nodeInfos, len(nodes)+len(upcomingNodes), now, allOrNothing)
-		o.processors.BinpackingLimiter.MarkProcessed(o.autoscalingContext, nodeGroup.Id())
-
-		if len(option.Pods) == 0 || option.NodeCount == 0 {
-			klog.V(4).Infof("No pod can fit to %s", nodeGroup.Id())
-		} else if allOrNothing && len(option.Pods) < len(unschedulablePods) {
-			klog.V(4).Infof("Some pods can't fit to %s, giving up due to all-or-nothing scale-up strategy", nodeGroup.Id())
-		} else {
-			options = append(options, option)
-		}
-
-		if o.processors.BinpackingLimiter.StopBinpacking(o.autoscalingContext, options) {
-			break
-		}
-	}
-
-	// Finalize binpacking limiter.
-	o.processors.BinpackingLimiter.FinalizeBinpacking(o.autoscalingContext, options)
-
-	if len(options) == 0 {
-		klog.V(1).Info("No expansion options")
-		return &status.ScaleUpStatus{
-			Result:                  status.ScaleUpNoOptionsAvailable,
-			PodsRemainUnschedulable: GetRemainingPods(podEquivalenceGroups, skippedNodeGroups),
-			ConsideredNodeGroups:    nodeGroups,
-		}, nil
+		return status.UpdateScaleUpError(&status.ScaleUpStatus{PodsTriggeredScaleUp: bestOption.Pods}, aErr)
 	}
-
-	// Pick some expansion option.
-	bestOption := o.autoscalingContext.ExpanderStrategy.BestOption(options, nodeInfos)
-	if bestOption == nil || bestOption.NodeCount <= 0 {
+	if bestOption == nil {
 		return &status.ScaleUpStatus{
 			Result:                  status.ScaleUpNoOptionsAvailable,
 			PodsRemainUnschedulable: GetRemainingPods(podEquivalenceGroups, skippedNodeGroups),
 			ConsideredNodeGroups:    nodeGroups,
 		}, nil
 	}
-	klog.V(1).Infof("Best option to resize: %s", bestOption.NodeGroup.Id())
-	if len(bestOption.Debug) > 0 {
-		klog.V(1).Info(bestOption.Debug)
-	}
-	klog.V(1).Infof("Estimated %d nodes needed in %s", bestOption.NodeCount, bestOption.NodeGroup.Id())
-
-	// Cap new nodes to supported number of nodes in the cluster.
-	newNodes, aErr := o.GetCappedNewNodeCount(bestOption.NodeCount, len(nodes)+len(upcomingNodes))
-	if aErr != nil {
-		return status.UpdateScaleUpError(&status.ScaleUpStatus{PodsTriggeredScaleUp: bestOption.Pods}, aErr)
-	}
-
-	newNodes, aErr = o.applyLimits(newNodes, resourcesLeft, bestOption.NodeGroup, nodeInfos)
-	if aErr != nil {
-		return status.UpdateScaleUpError(
-			&status.ScaleUpStatus{PodsTriggeredScaleUp: bestOption.Pods},
-			aErr)
-	}
 
 	if newNodes < bestOption.NodeCount {
 		klog.V(1).Infof("Only %d nodes can be added to %s due to cluster-wide limits", newNodes, bestOption.NodeGroup.Id())
@@ -223,12 +155,15 @@ func (o *ScaleUpOrchestrator) ScaleUp(
 		}
 		var scaleUpStatus *status.ScaleUpStatus
 		oldId := bestOption.NodeGroup.Id()
+		createTraceContext, createSpan := tracing.Start(traceContext, "NodeGroup.Create",
+			attribute.String("node_group", oldId), attribute.Bool("async", o.autoscalingContext.AsyncNodeGroupsEnabled))
 		if o.autoscalingContext.AsyncNodeGroupsEnabled {
-			initializer := NewAsyncNodeGroupInitializer(bestOption, nodeInfos[oldId], o.scaleUpExecutor, o.taintConfig, daemonSets, o.processors.ScaleUpStatusProcessor, o.autoscalingContext, allOrNothing)
+			initializer := NewAsyncNodeGroupInitializer(createTraceContext, bestOption, nodeInfos[oldId], o.scaleUpExecutor, o.taintConfig, daemonSets, o.processors.ScaleUpStatusProcessor, o.autoscalingContext, allOrNothing)
 			createNodeGroupResults, scaleUpStatus, aErr = o.CreateNodeGroupAsync(bestOption, nodeInfos, schedulablePodGroups, podEquivalenceGroups, daemonSets, initializer)
 		} else {
 			createNodeGroupResults, scaleUpStatus, aErr = o.CreateNodeGroup(bestOption, nodeInfos, schedulablePodGroups, podEquivalenceGroups, daemonSets)
 		}
+		tracing.End(createSpan, aErr)
 		if aErr != nil {
 			return scaleUpStatus, aErr
 		}
@@ -256,9 +191,16 @@ func (o *ScaleUpOrchestrator) ScaleUp(
 		}
 	}
 
+	if scaleUpInfos = o.applyCostBudget(scaleUpInfos, nodeInfos, now, allOrNothing); len(scaleUpInfos) == 0 {
+		markedEquivalenceGroups := markAllGroupsAsUnschedulable(podEquivalenceGroups, CostBudgetExceededReason)
+		scaleUpStatus := buildNoOptionsAvailableStatus(markedEquivalenceGroups, skippedNodeGroups, nodeGroups)
+		scaleUpStatus.CreateNodeGroupResults = createNodeGroupResults
+		return scaleUpStatus, nil
+	}
+
 	// Execute scale up.
 	klog.V(1).Infof("Final scale-up plan: %v", scaleUpInfos)
-	aErr, failedNodeGroups := o.scaleUpExecutor.ExecuteScaleUps(scaleUpInfos, nodeInfos, now, allOrNothing)
+	aErr, failedNodeGroups := o.scaleUpExecutor.ExecuteScaleUps(traceContext, scaleUpInfos, nodeInfos, now, allOrNothing)
 	if aErr != nil {
 		return status.UpdateScaleUpError(
 			&status.ScaleUpStatus{
@@ -282,6 +224,394 @@ func (o *ScaleUpOrchestrator) ScaleUp(
 	}, nil
 }
 
+// scaleUpPlanning is the state ScaleUp, SimulateScaleUp and ScaleUpAcrossNodeGroups plan their
+// scale-ups from.
+type scaleUpPlanning struct {
+	nodeGroups        []cloudprovider.NodeGroup
+	nodeInfos         map[string]*framework.NodeInfo
+	resourcesLeft     resource.Limits
+	validNodeGroups   []cloudprovider.NodeGroup
+	skippedNodeGroups map[string]status.Reasons
+	// currentNodeCount is the number of nodes in the cluster, including upcoming ones.
+	currentNodeCount int
+	now              time.Time
+}
+
+// planScaleUp lists the node groups through the node group list processor and filters out the ones
+// which can't be scaled up, taking upcoming nodes and cluster-wide resource limits into account.
+func (o *ScaleUpOrchestrator) planScaleUp(
+	unschedulablePods []*apiv1.Pod,
+	nodes []*apiv1.Node,
+	nodeInfos map[string]*framework.NodeInfo,
+) (*scaleUpPlanning, errors.AutoscalerError) {
+	upcomingNodes, aErr := o.UpcomingNodes(nodeInfos)
+	if aErr != nil {
+		return nil, aErr.AddPrefix("could not get upcoming nodes: ")
+	}
+	klog.V(4).Infof("Upcoming %d nodes", len(upcomingNodes))
+
+	nodeGroups := o.autoscalingContext.CloudProvider.NodeGroups()
+	if o.processors != nil && o.processors.NodeGroupListProcessor != nil {
+		var err error
+		nodeGroups, nodeInfos, err = o.processors.NodeGroupListProcessor.Process(o.autoscalingContext, nodeGroups, nodeInfos, unschedulablePods)
+		if err != nil {
+			return nil, errors.ToAutoscalerError(errors.InternalError, err)
+		}
+	}
+
+	// Initialise binpacking limiter.
+	o.processors.BinpackingLimiter.InitBinpacking(o.autoscalingContext, nodeGroups)
+
+	resourcesLeft, aErr := o.resourceManager.ResourcesLeft(o.autoscalingContext, nodeInfos, nodes)
+	if aErr != nil {
+		return nil, aErr.AddPrefix("could not compute total resources: ")
+	}
+
+	now := time.Now()
+	currentNodeCount := len(nodes) + len(upcomingNodes)
+
+	// Filter out invalid node groups
+	validNodeGroups, skippedNodeGroups := o.filterValidScaleUpNodeGroups(nodeGroups, nodeInfos, resourcesLeft, currentNodeCount, now)
+
+	// Mark skipped node groups as processed.
+	for nodegroupID := range skippedNodeGroups {
+		o.processors.BinpackingLimiter.MarkProcessed(o.autoscalingContext, nodegroupID)
+	}
+
+	return &scaleUpPlanning{
+		nodeGroups:        nodeGroups,
+		nodeInfos:         nodeInfos,
+		resourcesLeft:     resourcesLeft,
+		validNodeGroups:   validNodeGroups,
+		skippedNodeGroups: skippedNodeGroups,
+		currentNodeCount:  currentNodeCount,
+		now:               now,
+	}, nil
+}
+
+// bestScaleUpOption computes the expansion options of the node groups for the pods, picks the best one
+// and caps its node count to the cluster-wide limits. Returns a nil option if there is none. If capping
+// fails, the option is returned with the error.
+func (o *ScaleUpOrchestrator) bestScaleUpOption(
+	traceContext ctx.Context,
+	planning *scaleUpPlanning,
+	podEquivalenceGroups []*equivalence.PodGroup,
+	nodeGroups []cloudprovider.NodeGroup,
+	podCount int,
+	allOrNothing bool,
+) (*expander.Option, int, map[string][]estimator.PodEquivalenceGroup, errors.AutoscalerError) {
+	options, schedulablePodGroups := o.computeExpansionOptions(traceContext, podEquivalenceGroups, nodeGroups, planning.nodeInfos, planning.currentNodeCount, planning.now, allOrNothing, podCount)
+	if len(options) == 0 {
+		klog.V(1).Info("No expansion options")
+		return nil, 0, schedulablePodGroups, nil
+	}
+
+	// Pick some expansion option.
+	bestOption := o.bestOption(traceContext, options, planning.nodeInfos)
+	if bestOption == nil || bestOption.NodeCount <= 0 {
+		return nil, 0, schedulablePodGroups, nil
+	}
+	klog.V(1).Infof("Best option to resize: %s", bestOption.NodeGroup.Id())
+	if len(bestOption.Debug) > 0 {
+		klog.V(1).Info(bestOption.Debug)
+	}
+	klog.V(1).Infof("Estimated %d nodes needed in %s", bestOption.NodeCount, bestOption.NodeGroup.Id())
+
+	// Cap new nodes to supported number of nodes in the cluster.
+	newNodes, aErr := o.GetCappedNewNodeCount(bestOption.NodeCount, planning.currentNodeCount)
+	if aErr != nil {
+		return bestOption, 0, schedulablePodGroups, aErr
+	}
+	newNodes, aErr = o.applyLimits(newNodes, planning.resourcesLeft, bestOption.NodeGroup, planning.nodeInfos)
+	if aErr != nil {
+		return bestOption, 0, schedulablePodGroups, aErr
+	}
+	return bestOption, newNodes, schedulablePodGroups, nil
+}
+
+// SimulateScaleUp computes the scale-up that ScaleUp would perform for the
+// given unschedulable pods, without creating node groups or resizing them.
+// For node groups that don't exist yet, the returned scale-up info has zero
+// current size and isn't balanced across similar node groups. The scale-up is
+// checked against the cluster cost budget like in ScaleUp.
+func (o *ScaleUpOrchestrator) SimulateScaleUp(
+	traceContext ctx.Context,
+	unschedulablePods []*apiv1.Pod,
+	nodes []*apiv1.Node,
+	nodeInfos map[string]*framework.NodeInfo,
+) (*status.ScaleUpStatus, errors.AutoscalerError) {
+	if !o.initialized {
+		return status.UpdateScaleUpError(&status.ScaleUpStatus{}, errors.NewAutoscalerError(errors.InternalError, "ScaleUpOrchestrator is not initialized"))
+	}
+
+	podEquivalenceGroups := equivalence.BuildPodGroups(unschedulablePods)
+
+	planning, aErr := o.planScaleUp(unschedulablePods, nodes, nodeInfos)
+	if aErr != nil {
+		return status.UpdateScaleUpError(&status.ScaleUpStatus{}, aErr)
+	}
+	nodeGroups, nodeInfos, skippedNodeGroups, now := planning.nodeGroups, planning.nodeInfos, planning.skippedNodeGroups, planning.now
+
+	bestOption, newNodes, schedulablePodGroups, aErr := o.bestScaleUpOption(traceContext, planning, podEquivalenceGroups, planning.validNodeGroups, len(unschedulablePods), false)
+	if aErr != nil {
+		return status.UpdateScaleUpError(&status.ScaleUpStatus{PodsTriggeredScaleUp: bestOption.Pods}, aErr)
+	}
+	if bestOption == nil {
+		return &status.ScaleUpStatus{
+			Result:                  status.ScaleUpNoOptionsAvailable,
+			PodsRemainUnschedulable: GetRemainingPods(podEquivalenceGroups, skippedNodeGroups),
+			ConsideredNodeGroups:    nodeGroups,
+		}, nil
+	}
+
+	var scaleUpInfos []nodegroupset.ScaleUpInfo
+	if bestOption.NodeGroup.Exist() {
+		scaleUpInfos, aErr = o.balanceScaleUps(now, bestOption.NodeGroup, newNodes, nodeInfos, schedulablePodGroups)
+		if aErr != nil {
+			return status.UpdateScaleUpError(&status.ScaleUpStatus{PodsTriggeredScaleUp: bestOption.Pods}, aErr)
+		}
+	} else {
+		scaleUpInfos = []nodegroupset.ScaleUpInfo{{
+			Group:       bestOption.NodeGroup,
+			CurrentSize: 0,
+			NewSize:     min(newNodes, bestOption.NodeGroup.MaxSize()),
+			MaxSize:     bestOption.NodeGroup.MaxSize(),
+		}}
+	}
+
+	// The outcome isn't recorded, the scale-up isn't executed.
+	if scaleUpInfos = o.checkCostBudget(scaleUpInfos, nodeInfos, now, false /* allOrNothing disabled */).scaleUpInfos; len(scaleUpInfos) == 0 {
+		markedEquivalenceGroups := markAllGroupsAsUnschedulable(podEquivalenceGroups, CostBudgetExceededReason)
+		return buildNoOptionsAvailableStatus(markedEquivalenceGroups, skippedNodeGroups, nodeGroups), nil
+	}
+
+	return &status.ScaleUpStatus{
+		Result:                  status.ScaleUpSuccessful,
+		ScaleUpInfos:            scaleUpInfos,
+		PodsRemainUnschedulable: GetRemainingPods(podEquivalenceGroups, skippedNodeGroups),
+		ConsideredNodeGroups:    nodeGroups,
+		PodsTriggeredScaleUp:    bestOption.Pods,
+		PodsAwaitEvaluation:     GetPodsAwaitingEvaluation(podEquivalenceGroups, bestOption.NodeGroup.Id()),
+	}, nil
+}
+
+// ScaleUpAcrossNodeGroups requests enough capacity for all unschedulablePods, possibly
+// spread over several node groups, or doesn't request it at all. Node groups are picked
+// one after another by the expander, each for the pods that don't fit on the nodes
+// planned in the previously picked ones. Node groups that don't exist yet aren't considered.
+// If requesting capacity from one of the node groups fails, the scale-ups of the others
+// are reverted.
+func (o *ScaleUpOrchestrator) ScaleUpAcrossNodeGroups(
+	traceContext ctx.Context,
+	unschedulablePods []*apiv1.Pod,
+	nodes []*apiv1.Node,
+	nodeInfos map[string]*framework.NodeInfo,
+) (*status.ScaleUpStatus, errors.AutoscalerError) {
+	if !o.initialized {
+		return status.UpdateScaleUpError(&status.ScaleUpStatus{}, errors.NewAutoscalerError(errors.InternalError, "ScaleUpOrchestrator is not initialized"))
+	}
+
+	podEquivalenceGroups := equivalence.BuildPodGroups(unschedulablePods)
+
+	planning, aErr := o.planScaleUp(unschedulablePods, nodes, nodeInfos)
+	if aErr != nil {
+		return status.UpdateScaleUpError(&status.ScaleUpStatus{}, aErr)
+	}
+	nodeGroups, nodeInfos, resourcesLeft, skippedNodeGroups, now := planning.nodeGroups, planning.nodeInfos, planning.resourcesLeft, planning.skippedNodeGroups, planning.now
+
+	var candidates []cloudprovider.NodeGroup
+	for _, nodeGroup := range planning.validNodeGroups {
+		if nodeGroup.Exist() {
+			candidates = append(candidates, nodeGroup)
+		}
+	}
+	noCapacityStatus := func() *status.ScaleUpStatus {
+		// Can't execute a scale-up that will accommodate all pods, so nothing is considered schedulable.
+		klog.V(1).Info("Not attempting scale-up due to all-or-nothing strategy: not all pods would be accommodated")
+		markedEquivalenceGroups := markAllGroupsAsUnschedulable(podEquivalenceGroups, AllOrNothingReason)
+		return buildNoOptionsAvailableStatus(markedEquivalenceGroups, skippedNodeGroups, nodeGroups)
+	}
+
+	var scaleUpInfos []nodegroupset.ScaleUpInfo
+	var podsTriggeredScaleUp []*apiv1.Pod
+	remainingPods := unschedulablePods
+	for len(remainingPods) > 0 {
+		var fittingCandidates []cloudprovider.NodeGroup
+		for _, nodeGroup := range candidates {
+			if o.IsNodeGroupResourceExceeded(resourcesLeft, nodeGroup, nodeInfos[nodeGroup.Id()], 1) == nil {
+				fittingCandidates = append(fittingCandidates, nodeGroup)
+			}
+		}
+		o.processors.BinpackingLimiter.InitBinpacking(o.autoscalingContext, fittingCandidates)
+		bestOption, newNodes, _, aErr := o.bestScaleUpOption(traceContext, planning, equivalence.BuildPodGroups(remainingPods), fittingCandidates, len(remainingPods), false)
+		if aErr != nil {
+			return status.UpdateScaleUpError(&status.ScaleUpStatus{PodsTriggeredScaleUp: bestOption.Pods}, aErr)
+		}
+		if bestOption == nil {
+			klog.V(1).Infof("No node group can fit the remaining %d pods", len(remainingPods))
+			return noCapacityStatus(), nil
+		}
+		currentSize, err := bestOption.NodeGroup.TargetSize()
+		if err != nil {
+			return status.UpdateScaleUpError(&status.ScaleUpStatus{PodsTriggeredScaleUp: bestOption.Pods}, errors.ToAutoscalerError(errors.CloudProviderError, err))
+		}
+		if newNodes < bestOption.NodeCount || currentSize+newNodes > bestOption.NodeGroup.MaxSize() {
+			klog.V(1).Infof("Can only add %d nodes to %s, need %d nodes", min(newNodes, bestOption.NodeGroup.MaxSize()-currentSize), bestOption.NodeGroup.Id(), bestOption.NodeCount)
+			return noCapacityStatus(), nil
+		}
+
+		scaleUpInfos = append(scaleUpInfos, nodegroupset.ScaleUpInfo{
+			Group:       bestOption.NodeGroup,
+			CurrentSize: currentSize,
+			NewSize:     currentSize + newNodes,
+			MaxSize:     bestOption.NodeGroup.MaxSize(),
+		})
+		podsTriggeredScaleUp = append(podsTriggeredScaleUp, bestOption.Pods...)
+		planning.currentNodeCount += newNodes
+		delta, aErr := o.resourceManager.DeltaForNode(o.autoscalingContext, nodeInfos[bestOption.NodeGroup.Id()], bestOption.NodeGroup)
+		if aErr != nil {
+			return status.UpdateScaleUpError(&status.ScaleUpStatus{PodsTriggeredScaleUp: bestOption.Pods}, aErr)
+		}
+		for resourceName, resourceDelta := range delta {
+			if left, found := resourcesLeft[resourceName]; found {
+				resourcesLeft[resourceName] = left - resourceDelta*int64(newNodes)
+			}
+		}
+
+		remainingPods = podsNotIn(remainingPods, bestOption.Pods)
+		var otherCandidates []cloudprovider.NodeGroup
+		for _, nodeGroup := range candidates {
+			if nodeGroup.Id() != bestOption.NodeGroup.Id() {
+				otherCandidates = append(otherCandidates, nodeGroup)
+			}
+		}
+		candidates = otherCandidates
+	}
+
+	if scaleUpInfos = o.applyCostBudget(scaleUpInfos, nodeInfos, now, true /* allOrNothing */); len(scaleUpInfos) == 0 {
+		markedEquivalenceGroups := markAllGroupsAsUnschedulable(podEquivalenceGroups, CostBudgetExceededReason)
+		return buildNoOptionsAvailableStatus(markedEquivalenceGroups, skippedNodeGroups, nodeGroups), nil
+	}
+
+	klog.V(1).Infof("Final multi node group scale-up plan: %v", scaleUpInfos)
+	aErr, failedNodeGroups := o.scaleUpExecutor.ExecuteScaleUps(traceContext, scaleUpInfos, nodeInfos, now, true)
+	if aErr != nil {
+		o.revertScaleUps(scaleUpInfos, failedNodeGroups)
+		return status.UpdateScaleUpError(
+			&status.ScaleUpStatus{
+				FailedResizeNodeGroups: failedNodeGroups,
+				PodsTriggeredScaleUp:   podsTriggeredScaleUp,
+			},
+			aErr,
+		)
+	}
+
+	o.clusterStateRegistry.Recalculate()
+	return &status.ScaleUpStatus{
+		Result:                  status.ScaleUpSuccessful,
+		ScaleUpInfos:            scaleUpInfos,
+		PodsRemainUnschedulable: GetRemainingPods(podEquivalenceGroups, skippedNodeGroups),
+		ConsideredNodeGroups:    nodeGroups,
+		PodsTriggeredScaleUp:    podsTriggeredScaleUp,
+	}, nil
+}
+
+// revertScaleUps reverts the scale-ups that were executed before one of them failed.
+func (o *ScaleUpOrchestrator) revertScaleUps(scaleUpInfos []nodegroupset.ScaleUpInfo, failedNodeGroups []cloudprovider.NodeGroup) {
+	failed := make(map[string]bool)
+	for _, nodeGroup := range failedNodeGroups {
+		failed[nodeGroup.Id()] = true
+	}
+	for _, info := range scaleUpInfos {
+		if failed[info.Group.Id()] {
+			continue
+		}
+		targetSize, err := info.Group.TargetSize()
+		if err != nil || targetSize < info.NewSize {
+			// The scale-up wasn't executed.
+			continue
+		}
+		klog.V(0).Infof("Reverting scale-up of %s to size %d", info.Group.Id(), info.CurrentSize)
+		if err := info.Group.DecreaseTargetSize(info.CurrentSize - info.NewSize); err != nil {
+			klog.Errorf("Failed to revert scale-up of %s: %v", info.Group.Id(), err)
+			continue
+		}
+		o.processors.ScaleStateNotifier.RegisterScaleUp(info.Group, info.CurrentSize-info.NewSize, time.Now())
+	}
+}
+
+func podsNotIn(pods, excluded []*apiv1.Pod) []*apiv1.Pod {
+	excludedUIDs := make(map[types.UID]bool, len(excluded))
+	for _, pod := range excluded {
+		excludedUIDs[pod.UID] = true
+	}
+	var result []*apiv1.Pod
+	for _, pod := range pods {
+		if !excludedUIDs[pod.UID] {
+			result = append(result, pod)
+		}
+	}
+	return result
+}
+
+// computeExpansionOptions computes expansion options for valid node groups,
+// respecting the binpacking limiter.
+func (o *ScaleUpOrchestrator) computeExpansionOptions(
+	traceContext ctx.Context,
+	podEquivalenceGroups []*equivalence.PodGroup,
+	validNodeGroups []cloudprovider.NodeGroup,
+	nodeInfos map[string]*framework.NodeInfo,
+	currentNodeCount int,
+	now time.Time,
+	allOrNothing bool,
+	unschedulablePodCount int,
+) ([]expander.Option, map[string][]estimator.PodEquivalenceGroup) {
+	schedulablePodGroups := map[string][]estimator.PodEquivalenceGroup{}
+	var options []expander.Option
+
+	for _, nodeGroup := range validNodeGroups {
+		schedulablePodGroups[nodeGroup.Id()] = o.SchedulablePodGroups(podEquivalenceGroups, nodeGroup, nodeInfos[nodeGroup.Id()])
+	}
+
+	// processOption adds the option computed for a node group and returns true if binpacking should stop.
+	processOption := func(option expander.Option) bool {
+		nodeGroup := option.NodeGroup
+		o.processors.BinpackingLimiter.MarkProcessed(o.autoscalingContext, nodeGroup.Id())
+
+		if len(option.Pods) == 0 || option.NodeCount == 0 {
+			klog.V(4).Infof("No pod can fit to %s", nodeGroup.Id())
+		} else if allOrNothing && len(option.Pods) < unschedulablePodCount {
+			klog.V(4).Infof("Some pods can't fit to %s, giving up due to all-or-nothing scale-up strategy", nodeGroup.Id())
+		} else {
+			options = append(options, option)
+		}
+
+		return o.processors.BinpackingLimiter.StopBinpacking(o.autoscalingContext, options)
+	}
+
+	if len(o.binpackingHandles) > 1 {
+		o.computeExpansionOptionsInParallel(traceContext, validNodeGroups, schedulablePodGroups, nodeInfos, currentNodeCount, now, allOrNothing, processOption)
+	} else {
+		for _, nodeGroup := range validNodeGroups {
+			option := o.ComputeExpansionOption(traceContext, nodeGroup, schedulablePodGroups, nodeInfos, currentNodeCount, now, allOrNothing)
+			if processOption(option) {
+				break
+			}
+		}
+	}
+
+	// Finalize binpacking limiter.
+	o.processors.BinpackingLimiter.FinalizeBinpacking(o.autoscalingContext, options)
+	return options, schedulablePodGroups
+}
+
+// bestOption picks the expansion option to execute with the expander.
+func (o *ScaleUpOrchestrator) bestOption(traceContext ctx.Context, options []expander.Option, nodeInfos map[string]*framework.NodeInfo) *expander.Option {
+	_, span := tracing.Start(traceContext, "Expander.BestOption", attribute.Int("options", len(options)))
+	defer tracing.End(span, nil)
+	return o.autoscalingContext.ExpanderStrategy.BestOption(options, nodeInfos)
+}
+
 func (o *ScaleUpOrchestrator) applyLimits(newNodes int, resourcesLeft resource.Limits, nodeGroup cloudprovider.NodeGroup, nodeInfos map[string]*framework.NodeInfo) (int, errors.AutoscalerError) {
 	nodeInfo, found := nodeInfos[nodeGroup.Id()]
 	if !found {
@@ -297,6 +627,7 @@ func (o *ScaleUpOrchestrator) applyLimits(newNodes int, resourcesLeft resource.L
 // size is the TargetSize queried directly from cloud providers. Returns
 // appropriate status or error if an unexpected error occurred.
 func (o *ScaleUpOrchestrator) ScaleUpToNodeGroupMinSize(
+	traceContext ctx.Context,
 	nodes []*apiv1.Node,
 	nodeInfos map[string]*framework.NodeInfo,
 ) (*status.ScaleUpStatus, errors.AutoscalerError) {
@@ -372,9 +703,12 @@ func (o *ScaleUpOrchestrator) ScaleUpToNodeGroupMinSize(
 		klog.V(1).Info("ScaleUpToNodeGroupMinSize: scale up not needed")
 		return &status.ScaleUpStatus{Result: status.ScaleUpNotNeeded}, nil
 	}
+	if scaleUpInfos = o.applyCostBudget(scaleUpInfos, nodeInfos, now, false /* allOrNothing disabled */); len(scaleUpInfos) == 0 {
+		return &status.ScaleUpStatus{Result: status.ScaleUpNoOptionsAvailable}, nil
+	}
 
 	klog.V(1).Infof("ScaleUpToNodeGroupMinSize: final scale-up plan: %v", scaleUpInfos)
-	aErr, failedNodeGroups := o.scaleUpExecutor.ExecuteScaleUps(scaleUpInfos, nodeInfos, now, false /* allOrNothing disabled */)
+	aErr, failedNodeGroups := o.scaleUpExecutor.ExecuteScaleUps(traceContext, scaleUpInfos, nodeInfos, now, false /* allOrNothing disabled */)
 	if aErr != nil {
 		return status.UpdateScaleUpError(
 			&status.ScaleUpStatus{
@@ -392,6 +726,94 @@ func (o *ScaleUpOrchestrator) ScaleUpToNodeGroupMinSize(
 	}, nil
 }
 
+// ScaleUpNodeGroup tries to scale up the given node group by newNodes nodes, for callers
+// which pick the node group on their own instead of asking the expander. The node group
+// is skipped if it isn't ready to scale up or if the scale-up would exceed the cost budget,
+// and the number of new nodes is capped by the node group max size, the resource limits and
+// the cluster wide node count limit. Returns appropriate status or error if an unexpected
+// error occurred.
+func (o *ScaleUpOrchestrator) ScaleUpNodeGroup(
+	traceContext ctx.Context,
+	nodeGroup cloudprovider.NodeGroup,
+	newNodes int,
+	nodes []*apiv1.Node,
+	nodeInfos map[string]*framework.NodeInfo,
+) (*status.ScaleUpStatus, errors.AutoscalerError) {
+	if !o.initialized {
+		return status.UpdateScaleUpError(&status.ScaleUpStatus{}, errors.NewAutoscalerError(errors.InternalError, "ScaleUpOrchestrator is not initialized"))
+	}
+
+	now := time.Now()
+	noOptionsStatus := &status.ScaleUpStatus{
+		Result:               status.ScaleUpNoOptionsAvailable,
+		ConsideredNodeGroups: []cloudprovider.NodeGroup{nodeGroup},
+	}
+	if skipReason := o.IsNodeGroupReadyToScaleUp(nodeGroup, now); skipReason != nil {
+		klog.V(1).Infof("ScaleUpNodeGroup: not scaling up node group %s: %v", nodeGroup.Id(), skipReason.Reasons())
+		return noOptionsStatus, nil
+	}
+	nodeInfo, found := nodeInfos[nodeGroup.Id()]
+	if !found {
+		return status.UpdateScaleUpError(&status.ScaleUpStatus{}, errors.NewAutoscalerErrorf(errors.InternalError, "no node info for node group %s", nodeGroup.Id()))
+	}
+	targetSize, err := nodeGroup.TargetSize()
+	if err != nil {
+		return status.UpdateScaleUpError(&status.ScaleUpStatus{}, errors.ToAutoscalerError(errors.CloudProviderError, err).AddPrefix("failed to get target size of node group %s: ", nodeGroup.Id()))
+	}
+	newNodes = min(newNodes, nodeGroup.MaxSize()-targetSize)
+	if newNodes < 1 {
+		klog.V(1).Infof("ScaleUpNodeGroup: node group %s reached its max size %d", nodeGroup.Id(), nodeGroup.MaxSize())
+		return noOptionsStatus, nil
+	}
+
+	upcomingNodes, aErr := o.UpcomingNodes(nodeInfos)
+	if aErr != nil {
+		return status.UpdateScaleUpError(&status.ScaleUpStatus{}, aErr.AddPrefix("could not get upcoming nodes: "))
+	}
+	newNodes, aErr = o.GetCappedNewNodeCount(newNodes, len(nodes)+len(upcomingNodes))
+	if aErr != nil {
+		klog.V(1).Infof("ScaleUpNodeGroup: not scaling up node group %s: %v", nodeGroup.Id(), aErr)
+		return noOptionsStatus, nil
+	}
+	resourcesLeft, aErr := o.resourceManager.ResourcesLeft(o.autoscalingContext, nodeInfos, nodes)
+	if aErr != nil {
+		return status.UpdateScaleUpError(&status.ScaleUpStatus{}, aErr.AddPrefix("could not compute total resources: "))
+	}
+	newNodes, aErr = o.resourceManager.ApplyLimits(o.autoscalingContext, newNodes, resourcesLeft, nodeInfo, nodeGroup)
+	if aErr != nil {
+		klog.V(1).Infof("ScaleUpNodeGroup: not scaling up node group %s: %v", nodeGroup.Id(), aErr)
+		return noOptionsStatus, nil
+	}
+
+	scaleUpInfos := []nodegroupset.ScaleUpInfo{{
+		Group:       nodeGroup,
+		CurrentSize: targetSize,
+		NewSize:     targetSize + newNodes,
+		MaxSize:     nodeGroup.MaxSize(),
+	}}
+	if scaleUpInfos = o.applyCostBudget(scaleUpInfos, nodeInfos, now, false /* allOrNothing disabled */); len(scaleUpInfos) == 0 {
+		return noOptionsStatus, nil
+	}
+
+	klog.V(1).Infof("ScaleUpNodeGroup: final scale-up plan: %v", scaleUpInfos)
+	aErr, failedNodeGroups := o.scaleUpExecutor.ExecuteScaleUps(traceContext, scaleUpInfos, nodeInfos, now, false /* allOrNothing disabled */)
+	if aErr != nil {
+		return status.UpdateScaleUpError(
+			&status.ScaleUpStatus{
+				FailedResizeNodeGroups: failedNodeGroups,
+			},
+			aErr,
+		)
+	}
+
+	o.clusterStateRegistry.Recalculate()
+	return &status.ScaleUpStatus{
+		Result:               status.ScaleUpSuccessful,
+		ScaleUpInfos:         scaleUpInfos,
+		ConsideredNodeGroups: []cloudprovider.NodeGroup{nodeGroup},
+	}, nil
+}
+
 // filterValidScaleUpNodeGroups filters the node groups that are valid for scale-up
 func (o *ScaleUpOrchestrator) filterValidScaleUpNodeGroups(
 	nodeGroups []cloudprovider.NodeGroup,
@@ -434,6 +856,12 @@ func (o *ScaleUpOrchestrator) filterValidScaleUpNodeGroups(
 			}
 		}
 
+		if o.isNodeGroupSubnetExhausted(nodeGroup, numNodes) {
+			klog.V(4).Infof("Skipping node group %s - subnets have no free IP addresses", nodeGroup.Id())
+			skippedNodeGroups[nodeGroup.Id()] = SubnetExhaustedReason
+			continue
+		}
+
 		nodeInfo, found := nodeInfos[nodeGroup.Id()]
 		if !found {
 			klog.Errorf("No node info for: %s", nodeGroup.Id())
@@ -452,6 +880,7 @@ func (o *ScaleUpOrchestrator) filterValidScaleUpNodeGroups(
 
 // ComputeExpansionOption computes expansion option based on pending pods and cluster state.
 func (o *ScaleUpOrchestrator) ComputeExpansionOption(
+	traceContext ctx.Context,
 	nodeGroup cloudprovider.NodeGroup,
 	schedulablePodGroups map[string][]estimator.PodEquivalenceGroup,
 	nodeInfos map[string]*framework.NodeInfo,
@@ -468,15 +897,37 @@ func (o *ScaleUpOrchestrator) ComputeExpansionOption(
 	}
 
 	option.SimilarNodeGroups = o.ComputeSimilarNodeGroups(nodeGroup, nodeInfos, schedulablePodGroups, now)
+	o.estimateExpansionOption(traceContext, &option, o.autoscalingContext.ClusterSnapshot, podGroups, nodeInfo, currentNodeCount)
+	o.applyZeroOrMaxNodeScaling(&option, allOrNothing)
+	return option
+}
 
+// estimateExpansionOption fills in the number of nodes and the pods scheduled on them for the option,
+// by running the estimator against the given cluster snapshot.
+func (o *ScaleUpOrchestrator) estimateExpansionOption(
+	traceContext ctx.Context,
+	option *expander.Option,
+	clusterSnapshot clustersnapshot.ClusterSnapshot,
+	podGroups []estimator.PodEquivalenceGroup,
+	nodeInfo *framework.NodeInfo,
+	currentNodeCount int,
+) {
+	nodeGroup := option.NodeGroup
+	_, span := tracing.Start(traceContext, "Estimate", attribute.String("node_group", nodeGroup.Id()))
+	defer tracing.End(span, nil)
 	estimateStart := time.Now()
 	expansionEstimator := o.estimatorBuilder(
-		o.autoscalingContext.ClusterSnapshot,
+		clusterSnapshot,
 		estimator.NewEstimationContext(o.autoscalingContext.MaxNodesTotal, option.SimilarNodeGroups, currentNodeCount),
 	)
 	option.NodeCount, option.Pods = expansionEstimator.Estimate(podGroups, nodeInfo, nodeGroup)
 	metrics.UpdateDurationFromStart(metrics.Estimate, estimateStart)
+}
 
+// applyZeroOrMaxNodeScaling adjusts the estimated number of nodes of the option if its node group only scales
+// from zero to its max size.
+func (o *ScaleUpOrchestrator) applyZeroOrMaxNodeScaling(option *expander.Option, allOrNothing bool) {
+	nodeGroup := option.NodeGroup
 	autoscalingOptions, err := nodeGroup.GetOptions(o.autoscalingContext.NodeGroupDefaults)
 	if err != nil && err != cloudprovider.ErrNotImplemented {
 		klog.Errorf("Failed to get autoscaling options for node group %s: %v", nodeGroup.Id(), err)
@@ -496,8 +947,6 @@ func (o *ScaleUpOrchestrator) ComputeExpansionOption(
 			option.NodeCount = nodeGroup.MaxSize()
 		}
 	}
-
-	return option
 }
 
 // CreateNodeGroup will try to create a new node group based on the initialOption.
@@ -641,6 +1090,10 @@ func (o *ScaleUpOrchestrator) IsNodeGroupReadyToScaleUp(nodeGroup cloudprovider.
 		return nil
 	}
 	if scaleUpSafety := o.clusterStateRegistry.NodeGroupScaleUpSafety(nodeGroup, now); !scaleUpSafety.SafeToScale {
+		if !scaleUpSafety.PausedUntil.IsZero() {
+			klog.V(2).Infof("Node group %s is not ready for scaleup - paused until %v", nodeGroup.Id(), scaleUpSafety.PausedUntil)
+			return PausedReason
+		}
 		if !scaleUpSafety.Healthy {
 			klog.Warningf("Node group %s is not ready for scaleup - unhealthy", nodeGroup.Id())
 			return NotReadyReason
@@ -681,6 +1134,20 @@ func (o *ScaleUpOrchestrator) IsNodeGroupResourceExceeded(resourcesLeft resource
 	return nil
 }
 
+// isNodeGroupSubnetExhausted returns true if subnet capacity checks are enabled and the subnets of the node group
+// don't have free IP addresses for the given number of nodes.
+func (o *ScaleUpOrchestrator) isNodeGroupSubnetExhausted(nodeGroup cloudprovider.NodeGroup, numNodes int) bool {
+	if !o.autoscalingContext.SubnetCapacityCheckEnabled {
+		return false
+	}
+	checker, ok := o.autoscalingContext.CloudProvider.(cloudprovider.SubnetCapacityChecker)
+	if !ok {
+		return false
+	}
+	free, known := checker.FreeSubnetCapacity(nodeGroup)
+	return known && free < numNodes
+}
+
 // GetCappedNewNodeCount caps resize according to cluster wide node count limit.
 func (o *ScaleUpOrchestrator) GetCappedNewNodeCount(newNodeCount, currentNodeCount int) (int, errors.AutoscalerError) {
 	if o.autoscalingContext.MaxNodesTotal > 0 && newNodeCount+currentNodeCount > o.autoscalingContext.MaxNodesTotal {
@@ -694,6 +1161,86 @@ func (o *ScaleUpOrchestrator) GetCappedNewNodeCount(newNodeCount, currentNodeCou
 	return newNodeCount, nil
 }
 
+// costBudgetCheck is the outcome of checking a scale-up against the cluster cost budget.
+type costBudgetCheck struct {
+	// scaleUpInfos is the scale-up capped to the nodes fitting into the remaining budget, empty if it's rejected.
+	scaleUpInfos []nodegroupset.ScaleUpInfo
+	result       costbudget.CheckResult
+	// err is set if the cost of the scale-up couldn't be computed.
+	err error
+}
+
+// applyCostBudget checks the scale-up against the cluster cost budget and returns it capped to the nodes fitting
+// into the remaining budget, recording the outcome in logs, events and metrics. See checkCostBudget.
+func (o *ScaleUpOrchestrator) applyCostBudget(scaleUpInfos []nodegroupset.ScaleUpInfo, nodeInfos map[string]*framework.NodeInfo, now time.Time, allOrNothing bool) []nodegroupset.ScaleUpInfo {
+	if !o.costBudget.Enabled() {
+		return scaleUpInfos
+	}
+	check := o.checkCostBudget(scaleUpInfos, nodeInfos, now, allOrNothing)
+	o.recordCostBudgetCheck(scaleUpInfos, check)
+	return check.scaleUpInfos
+}
+
+// checkCostBudget checks the scale-up against the cluster cost budget, without recording the outcome. The scale-up
+// is capped to the nodes fitting into the remaining budget, or rejected: if no node fits, if an all-or-nothing
+// scale-up would have to be capped, or if its cost can't be computed. Scale-ups exceeding a soft budget are kept.
+func (o *ScaleUpOrchestrator) checkCostBudget(scaleUpInfos []nodegroupset.ScaleUpInfo, nodeInfos map[string]*framework.NodeInfo, now time.Time, allOrNothing bool) costBudgetCheck {
+	if !o.costBudget.Enabled() {
+		return costBudgetCheck{scaleUpInfos: scaleUpInfos}
+	}
+	result, err := o.costBudget.CheckScaleUp(scaleUpInfos, nodeInfos, now)
+	check := costBudgetCheck{scaleUpInfos: scaleUpInfos, result: result, err: err}
+	switch {
+	case o.costBudget.Soft():
+	case err != nil:
+		check.scaleUpInfos = nil
+	case !result.Exceeded:
+	case len(result.CappedScaleUpInfos) == 0 || allOrNothing:
+		check.scaleUpInfos = nil
+	default:
+		check.scaleUpInfos = result.CappedScaleUpInfos
+	}
+	return check
+}
+
+// recordCostBudgetCheck logs the outcome of checking the scale-up against the cluster cost budget, emits events
+// about scale-ups exceeding it and updates the cost metrics.
+func (o *ScaleUpOrchestrator) recordCostBudgetCheck(scaleUpInfos []nodegroupset.ScaleUpInfo, check costBudgetCheck) {
+	result := check.result
+	if check.err != nil {
+		if o.costBudget.Soft() {
+			klog.Warningf("Failed to check scale-up %v against the cluster cost budget: %v", scaleUpInfos, check.err)
+			return
+		}
+		klog.Errorf("Not attempting scale-up %v: failed to check it against the cluster cost budget: %v", scaleUpInfos, check.err)
+		o.autoscalingContext.LogRecorder.Eventf(apiv1.EventTypeWarning, "ScaleUpCostUnknown", "Scale-up rejected: failed to check it against the cluster cost budget: %v", check.err)
+		return
+	}
+	// With cost metrics enabled, the gauge is updated in each loop by the cost metrics processor instead,
+	// so that it doesn't alternate between estimates made from different nodes.
+	if !o.autoscalingContext.CostMetricsEnabled {
+		metrics.UpdateClusterHourlyCost(result.CurrentCost)
+	}
+	if !result.Exceeded {
+		return
+	}
+	metrics.RegisterCostBudgetExceeded(o.costBudget.Mode())
+	newCost := result.CurrentCost + result.ScaleUpCost
+	if o.costBudget.Soft() {
+		klog.Warningf("Scale-up %v increases cluster hourly cost to %.2f, exceeding the budget of %.2f", scaleUpInfos, newCost, o.costBudget.MaxHourlyCost())
+		o.autoscalingContext.LogRecorder.Eventf(apiv1.EventTypeWarning, "ScaleUpCostBudgetExceeded", "Scale-up increases cluster hourly cost to %.2f, exceeding the budget of %.2f", newCost, o.costBudget.MaxHourlyCost())
+		return
+	}
+	if len(check.scaleUpInfos) == 0 {
+		klog.V(1).Infof("Not attempting scale-up %v: it would increase cluster hourly cost to %.2f, exceeding the budget of %.2f", scaleUpInfos, newCost, o.costBudget.MaxHourlyCost())
+		o.autoscalingContext.LogRecorder.Eventf(apiv1.EventTypeWarning, "ScaleUpCostBudgetExceeded", "Scale-up rejected: it would increase cluster hourly cost to %.2f, exceeding the budget of %.2f", newCost, o.costBudget.MaxHourlyCost())
+		return
+	}
+	cappedCost := result.CurrentCost + result.CappedScaleUpCost
+	klog.V(1).Infof("Capping scale-up %v to %v: it would increase cluster hourly cost to %.2f, exceeding the budget of %.2f", scaleUpInfos, check.scaleUpInfos, newCost, o.costBudget.MaxHourlyCost())
+	o.autoscalingContext.LogRecorder.Eventf(apiv1.EventTypeWarning, "ScaleUpCostBudgetExceeded", "Scale-up capped: it would increase cluster hourly cost to %.2f, exceeding the budget of %.2f, capped scale-up increases it to %.2f", newCost, o.costBudget.MaxHourlyCost(), cappedCost)
+}
+
 func (o *ScaleUpOrchestrator) balanceScaleUps(
 	now time.Time,
 	nodeGroup cloudprovider.NodeGroup,
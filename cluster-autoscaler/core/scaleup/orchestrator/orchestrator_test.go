@@ -17,6 +17,7 @@ limitations under the License.
 package orchestrator
 
 import (
+	ctx "context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -29,11 +30,14 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/pause"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
 	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup/costbudget"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup/resource"
 	. "k8s.io/autoscaler/cluster-autoscaler/core/test"
 	"k8s.io/autoscaler/cluster-autoscaler/core/utils"
@@ -1063,7 +1067,7 @@ func runSimpleScaleUpTest(t *testing.T, config *ScaleUpTestConfig) *ScaleUpTestR
 	context.ExpanderStrategy = expander
 
 	// scale up
-	scaleUpStatus, scaleUpErr := orchestrator.ScaleUp(extraPods, nodes, []*appsv1.DaemonSet{}, nodeInfos, config.AllOrNothing)
+	scaleUpStatus, scaleUpErr := orchestrator.ScaleUp(ctx.Background(), extraPods, nodes, []*appsv1.DaemonSet{}, nodeInfos, config.AllOrNothing)
 	processors.ScaleUpStatusProcessor.Process(&context, scaleUpStatus)
 
 	// aggregate group size changes
@@ -1164,7 +1168,7 @@ func TestScaleUpUnhealthy(t *testing.T) {
 	processors := processorstest.NewTestProcessors(&context)
 	suOrchestrator := New()
 	suOrchestrator.Initialize(&context, processors, clusterState, newEstimatorBuilder(), taints.TaintConfig{})
-	scaleUpStatus, err := suOrchestrator.ScaleUp([]*apiv1.Pod{p3}, nodes, []*appsv1.DaemonSet{}, nodeInfos, false)
+	scaleUpStatus, err := suOrchestrator.ScaleUp(ctx.Background(), []*apiv1.Pod{p3}, nodes, []*appsv1.DaemonSet{}, nodeInfos, false)
 
 	assert.NoError(t, err)
 	// Node group is unhealthy.
@@ -1219,7 +1223,7 @@ func TestBinpackingLimiter(t *testing.T) {
 	expander := NewMockReportingStrategy(t, nil, nil)
 	context.ExpanderStrategy = expander
 
-	scaleUpStatus, err := suOrchestrator.ScaleUp([]*apiv1.Pod{extraPod}, nodes, []*appsv1.DaemonSet{}, nodeInfos, false)
+	scaleUpStatus, err := suOrchestrator.ScaleUp(ctx.Background(), []*apiv1.Pod{extraPod}, nodes, []*appsv1.DaemonSet{}, nodeInfos, false)
 	processors.ScaleUpStatusProcessor.Process(&context, scaleUpStatus)
 	assert.NoError(t, err)
 	assert.True(t, scaleUpStatus.WasSuccessful())
@@ -1229,6 +1233,104 @@ func TestBinpackingLimiter(t *testing.T) {
 	assert.True(t, len(expansionOptions) == 1)
 }
 
+func TestParallelBinpacking(t *testing.T) {
+	testCases := []struct {
+		name              string
+		parallelism       int
+		binpackingLimiter bool
+		wantOptions       []GroupSizeChange
+		wantOptionsCount  int
+	}{
+		{
+			name:        "sequential",
+			parallelism: 1,
+			wantOptions: []GroupSizeChange{{GroupName: "ng1", SizeChange: 4}, {GroupName: "ng2", SizeChange: 2}, {GroupName: "ng3", SizeChange: 2}, {GroupName: "ng4", SizeChange: 1}, {GroupName: "ng5", SizeChange: 1}},
+		},
+		{
+			name:        "parallel",
+			parallelism: 3,
+			wantOptions: []GroupSizeChange{{GroupName: "ng1", SizeChange: 4}, {GroupName: "ng2", SizeChange: 2}, {GroupName: "ng3", SizeChange: 2}, {GroupName: "ng4", SizeChange: 1}, {GroupName: "ng5", SizeChange: 1}},
+		},
+		{
+			name:        "more workers than node groups",
+			parallelism: 10,
+			wantOptions: []GroupSizeChange{{GroupName: "ng1", SizeChange: 4}, {GroupName: "ng2", SizeChange: 2}, {GroupName: "ng3", SizeChange: 2}, {GroupName: "ng4", SizeChange: 1}, {GroupName: "ng5", SizeChange: 1}},
+		},
+		{
+			name:              "parallel with binpacking limiter",
+			parallelism:       3,
+			binpackingLimiter: true,
+			wantOptionsCount:  1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			now := time.Now()
+			provider := testprovider.NewTestCloudProvider(func(nodeGroup string, increase int) error {
+				return nil
+			}, nil)
+			var nodes []*apiv1.Node
+			var scheduledPods []*apiv1.Pod
+			for i := 1; i <= 5; i++ {
+				// Existing nodes are full, node groups differ in the number of new pods fitting on a node.
+				node := BuildTestNode(fmt.Sprintf("n%d", i), int64(i*1000), 1000)
+				SetNodeReadyState(node, true, now.Add(-2*time.Minute))
+				nodes = append(nodes, node)
+				scheduledPods = append(scheduledPods, BuildScheduledTestPod(fmt.Sprintf("p%d", i), int64(i*1000), 0, node.Name))
+				ng := fmt.Sprintf("ng%d", i)
+				provider.AddNodeGroup(ng, 1, 10, 1)
+				provider.AddNode(ng, node)
+			}
+
+			podLister := kube_util.NewTestPodLister(scheduledPods)
+			listers := kube_util.NewListerRegistry(nil, nil, podLister, nil, nil, nil, nil, nil, nil)
+			options := defaultOptions
+			options.BinpackingParallelism = tc.parallelism
+			context, err := NewScaleTestAutoscalingContext(options, &fake.Clientset{}, listers, provider, nil, nil)
+			assert.NoError(t, err)
+			err = context.ClusterSnapshot.SetClusterState(nodes, scheduledPods, drasnapshot.Snapshot{})
+			assert.NoError(t, err)
+			nodeInfos, err := nodeinfosprovider.NewDefaultTemplateNodeInfoProvider(nil, false).
+				Process(&context, nodes, []*appsv1.DaemonSet{}, taints.TaintConfig{}, now)
+			assert.NoError(t, err)
+
+			clusterState := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, context.LogRecorder, NewBackoff(), nodegroupconfig.NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{MaxNodeProvisionTime: 15 * time.Minute}), asyncnodegroups.NewDefaultAsyncNodeGroupStateChecker())
+			clusterState.UpdateNodes(nodes, nodeInfos, now)
+
+			processors := processorstest.NewTestProcessors(&context)
+			if tc.binpackingLimiter {
+				processors.BinpackingLimiter = &MockBinpackingLimiter{}
+			}
+			suOrchestrator := New()
+			suOrchestrator.Initialize(&context, processors, clusterState, newEstimatorBuilder(), taints.TaintConfig{})
+			if tc.parallelism > 1 {
+				assert.Len(t, suOrchestrator.binpackingHandles, tc.parallelism)
+			}
+
+			expander := NewMockReportingStrategy(t, nil, nil)
+			context.ExpanderStrategy = expander
+
+			var pods []*apiv1.Pod
+			for i := 0; i < 4; i++ {
+				pods = append(pods, BuildTestPod(fmt.Sprintf("p-new-%d", i), 900, 0))
+			}
+			scaleUpStatus, err := suOrchestrator.ScaleUp(ctx.Background(), pods, nodes, []*appsv1.DaemonSet{}, nodeInfos, false)
+			assert.NoError(t, err)
+			assert.True(t, scaleUpStatus.WasSuccessful())
+
+			if tc.wantOptions != nil {
+				assert.ElementsMatch(t, tc.wantOptions, expander.LastInputOptions())
+			} else {
+				assert.Len(t, expander.LastInputOptions(), tc.wantOptionsCount)
+			}
+			// Binpacking mustn't leave anything behind in the cluster snapshot.
+			nodeInfosList, err := context.ClusterSnapshot.ListNodeInfos()
+			assert.NoError(t, err)
+			assert.Len(t, nodeInfosList, len(nodes))
+		})
+	}
+}
+
 func TestScaleUpNoHelp(t *testing.T) {
 	n1 := BuildTestNode("n1", 100, 1000)
 	now := time.Now()
@@ -1267,7 +1369,7 @@ func TestScaleUpNoHelp(t *testing.T) {
 	processors := processorstest.NewTestProcessors(&context)
 	suOrchestrator := New()
 	suOrchestrator.Initialize(&context, processors, clusterState, newEstimatorBuilder(), taints.TaintConfig{})
-	scaleUpStatus, err := suOrchestrator.ScaleUp([]*apiv1.Pod{p3}, nodes, []*appsv1.DaemonSet{}, nodeInfos, false)
+	scaleUpStatus, err := suOrchestrator.ScaleUp(ctx.Background(), []*apiv1.Pod{p3}, nodes, []*appsv1.DaemonSet{}, nodeInfos, false)
 	processors.ScaleUpStatusProcessor.Process(&context, scaleUpStatus)
 
 	assert.NoError(t, err)
@@ -1513,7 +1615,7 @@ func TestScaleUpBalanceGroups(t *testing.T) {
 			processors := processorstest.NewTestProcessors(&context)
 			suOrchestrator := New()
 			suOrchestrator.Initialize(&context, processors, clusterState, newEstimatorBuilder(), taints.TaintConfig{})
-			scaleUpStatus, typedErr := suOrchestrator.ScaleUp(pods, nodes, []*appsv1.DaemonSet{}, nodeInfos, false)
+			scaleUpStatus, typedErr := suOrchestrator.ScaleUp(ctx.Background(), pods, nodes, []*appsv1.DaemonSet{}, nodeInfos, false)
 
 			assert.NoError(t, typedErr)
 			assert.True(t, scaleUpStatus.WasSuccessful())
@@ -1574,7 +1676,7 @@ func TestScaleUpAutoprovisionedNodeGroup(t *testing.T) {
 
 	suOrchestrator := New()
 	suOrchestrator.Initialize(&context, processors, clusterState, newEstimatorBuilder(), taints.TaintConfig{})
-	scaleUpStatus, err := suOrchestrator.ScaleUp([]*apiv1.Pod{p1}, nodes, []*appsv1.DaemonSet{}, nodeInfos, false)
+	scaleUpStatus, err := suOrchestrator.ScaleUp(ctx.Background(), []*apiv1.Pod{p1}, nodes, []*appsv1.DaemonSet{}, nodeInfos, false)
 	assert.NoError(t, err)
 	assert.True(t, scaleUpStatus.WasSuccessful())
 	assert.Equal(t, "autoprovisioned-T1", utils.GetStringFromChan(createdGroups))
@@ -1626,7 +1728,7 @@ func TestScaleUpBalanceAutoprovisionedNodeGroups(t *testing.T) {
 
 	suOrchestrator := New()
 	suOrchestrator.Initialize(&context, processors, clusterState, newEstimatorBuilder(), taints.TaintConfig{})
-	scaleUpStatus, err := suOrchestrator.ScaleUp([]*apiv1.Pod{p1, p2, p3}, nodes, []*appsv1.DaemonSet{}, nodeInfos, false)
+	scaleUpStatus, err := suOrchestrator.ScaleUp(ctx.Background(), []*apiv1.Pod{p1, p2, p3}, nodes, []*appsv1.DaemonSet{}, nodeInfos, false)
 	assert.NoError(t, err)
 	assert.True(t, scaleUpStatus.WasSuccessful())
 	assert.Equal(t, "autoprovisioned-T1", utils.GetStringFromChan(createdGroups))
@@ -1683,7 +1785,7 @@ func TestScaleUpToMeetNodeGroupMinSize(t *testing.T) {
 
 	suOrchestrator := New()
 	suOrchestrator.Initialize(&context, processors, clusterState, newEstimatorBuilder(), taints.TaintConfig{})
-	scaleUpStatus, err := suOrchestrator.ScaleUpToNodeGroupMinSize(nodes, nodeInfos)
+	scaleUpStatus, err := suOrchestrator.ScaleUpToNodeGroupMinSize(ctx.Background(), nodes, nodeInfos)
 	assert.NoError(t, err)
 	assert.True(t, scaleUpStatus.WasSuccessful())
 	assert.Equal(t, 1, len(scaleUpStatus.ScaleUpInfos))
@@ -1691,6 +1793,692 @@ func TestScaleUpToMeetNodeGroupMinSize(t *testing.T) {
 	assert.Equal(t, "ng1", scaleUpStatus.ScaleUpInfos[0].Group.Id())
 }
 
+func TestScaleUpToMeetNodeGroupMinSizeCostBudget(t *testing.T) {
+	podLister := kube_util.NewTestPodLister([]*apiv1.Pod{})
+	listers := kube_util.NewListerRegistry(nil, nil, podLister, nil, nil, nil, nil, nil, nil)
+	provider := testprovider.NewTestCloudProvider(func(nodeGroup string, increase int) error {
+		t.Errorf("unexpected scale-up of node group %s by %d", nodeGroup, increase)
+		return nil
+	}, nil)
+	n1 := BuildTestNode("n1", 16000, 32)
+	SetNodeReadyState(n1, true, time.Now())
+	provider.AddNodeGroup("ng1", 3, 10, 1)
+	provider.AddNode("ng1", n1)
+
+	// The budget is used up by the node ng1 already has.
+	options := config.AutoscalingOptions{
+		EstimatorName:         estimator.BinpackingEstimatorName,
+		MaxCoresTotal:         config.DefaultMaxClusterCores,
+		MaxMemoryTotal:        config.DefaultMaxClusterMemory,
+		MaxClusterHourlyCost:  1.5,
+		ClusterCostBudgetMode: costbudget.HardMode,
+		NodeGroupHourlyPrices: map[string]float64{"ng1": 1},
+	}
+	context, err := NewScaleTestAutoscalingContext(options, &fake.Clientset{}, listers, provider, nil, nil)
+	assert.NoError(t, err)
+
+	nodes := []*apiv1.Node{n1}
+	err = context.ClusterSnapshot.SetClusterState(nodes, nil, drasnapshot.Snapshot{})
+	assert.NoError(t, err)
+	nodeInfos, _ := nodeinfosprovider.NewDefaultTemplateNodeInfoProvider(nil, false).Process(&context, nodes, []*appsv1.DaemonSet{}, taints.TaintConfig{}, time.Now())
+	processors := processorstest.NewTestProcessors(&context)
+	clusterState := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, context.LogRecorder, NewBackoff(), nodegroupconfig.NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{MaxNodeProvisionTime: 15 * time.Minute}), asyncnodegroups.NewDefaultAsyncNodeGroupStateChecker())
+	clusterState.UpdateNodes(nodes, nodeInfos, time.Now())
+
+	suOrchestrator := New()
+	suOrchestrator.Initialize(&context, processors, clusterState, newEstimatorBuilder(), taints.TaintConfig{})
+	scaleUpStatus, err := suOrchestrator.ScaleUpToNodeGroupMinSize(ctx.Background(), nodes, nodeInfos)
+	assert.NoError(t, err)
+	assert.Equal(t, status.ScaleUpNoOptionsAvailable, scaleUpStatus.Result)
+}
+
+func TestScaleUpNodeGroup(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		newNodes      int
+		maxCores      int64
+		backoff       bool
+		wantIncrease  int
+		wantNoOptions bool
+	}{
+		{
+			name:         "scale-up",
+			newNodes:     2,
+			maxCores:     1000,
+			wantIncrease: 2,
+		},
+		{
+			name:         "capped by max size",
+			newNodes:     20,
+			maxCores:     1000,
+			wantIncrease: 9,
+		},
+		{
+			name:         "capped by resource limits",
+			newNodes:     2,
+			maxCores:     48,
+			wantIncrease: 1,
+		},
+		{
+			name:          "resource limits reached",
+			newNodes:      2,
+			maxCores:      32,
+			wantNoOptions: true,
+		},
+		{
+			name:          "backed off",
+			newNodes:      2,
+			maxCores:      1000,
+			backoff:       true,
+			wantNoOptions: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			podLister := kube_util.NewTestPodLister([]*apiv1.Pod{})
+			listers := kube_util.NewListerRegistry(nil, nil, podLister, nil, nil, nil, nil, nil, nil)
+			increase := 0
+			provider := testprovider.NewTestCloudProvider(func(nodeGroup string, delta int) error {
+				assert.Equal(t, "ng1", nodeGroup)
+				increase += delta
+				return nil
+			}, nil)
+			provider.SetResourceLimiter(cloudprovider.NewResourceLimiter(
+				map[string]int64{cloudprovider.ResourceNameCores: 0, cloudprovider.ResourceNameMemory: 0},
+				map[string]int64{cloudprovider.ResourceNameCores: tc.maxCores, cloudprovider.ResourceNameMemory: 1000},
+			))
+			n1 := BuildTestNode("n1", 16000, 32)
+			SetNodeReadyState(n1, true, time.Now())
+			n2 := BuildTestNode("n2", 16000, 32)
+			SetNodeReadyState(n2, true, time.Now())
+			provider.AddNodeGroup("ng1", 1, 10, 1)
+			provider.AddNode("ng1", n1)
+			provider.AddNodeGroup("ng2", 1, 10, 1)
+			provider.AddNode("ng2", n2)
+			ng1 := provider.GetNodeGroup("ng1")
+
+			options := config.AutoscalingOptions{
+				EstimatorName:  estimator.BinpackingEstimatorName,
+				MaxCoresTotal:  config.DefaultMaxClusterCores,
+				MaxMemoryTotal: config.DefaultMaxClusterMemory,
+			}
+			context, err := NewScaleTestAutoscalingContext(options, &fake.Clientset{}, listers, provider, nil, nil)
+			assert.NoError(t, err)
+
+			nodes := []*apiv1.Node{n1, n2}
+			err = context.ClusterSnapshot.SetClusterState(nodes, nil, drasnapshot.Snapshot{})
+			assert.NoError(t, err)
+			nodeInfos, _ := nodeinfosprovider.NewDefaultTemplateNodeInfoProvider(nil, false).Process(&context, nodes, []*appsv1.DaemonSet{}, taints.TaintConfig{}, time.Now())
+			processors := processorstest.NewTestProcessors(&context)
+			clusterState := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, context.LogRecorder, NewBackoff(), nodegroupconfig.NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{MaxNodeProvisionTime: 15 * time.Minute}), asyncnodegroups.NewDefaultAsyncNodeGroupStateChecker())
+			clusterState.UpdateNodes(nodes, nodeInfos, time.Now())
+			processors.ScaleStateNotifier.Register(clusterState)
+			if tc.backoff {
+				clusterState.RegisterFailedScaleUp(ng1, "QuotaExceeded", "quota exceeded", "", "", time.Now())
+			}
+
+			suOrchestrator := New()
+			suOrchestrator.Initialize(&context, processors, clusterState, newEstimatorBuilder(), taints.TaintConfig{})
+			scaleUpStatus, aErr := suOrchestrator.ScaleUpNodeGroup(ctx.Background(), ng1, tc.newNodes, nodes, nodeInfos)
+			assert.NoError(t, aErr)
+			if tc.wantNoOptions {
+				assert.Equal(t, status.ScaleUpNoOptionsAvailable, scaleUpStatus.Result)
+				assert.Equal(t, 0, increase)
+				return
+			}
+			assert.True(t, scaleUpStatus.WasSuccessful())
+			assert.Equal(t, tc.wantIncrease, increase)
+			assert.Equal(t, 1+tc.wantIncrease, scaleUpStatus.ScaleUpInfos[0].NewSize)
+			assert.True(t, clusterState.IsNodeGroupScalingUp("ng1"))
+		})
+	}
+}
+
+func TestSimulateScaleUp(t *testing.T) {
+	podLister := kube_util.NewTestPodLister([]*apiv1.Pod{})
+	listers := kube_util.NewListerRegistry(nil, nil, podLister, nil, nil, nil, nil, nil, nil)
+	provider := testprovider.NewTestCloudProvider(func(nodeGroup string, increase int) error {
+		t.Fatalf("Unexpected scale-up of %s by %d during simulation", nodeGroup, increase)
+		return nil
+	}, nil)
+	n1 := BuildTestNode("n1", 1000, 1000)
+	SetNodeReadyState(n1, true, time.Now())
+	provider.AddNodeGroup("ng1", 1, 10, 1)
+	provider.AddNode("ng1", n1)
+
+	options := config.AutoscalingOptions{
+		EstimatorName:  estimator.BinpackingEstimatorName,
+		MaxCoresTotal:  config.DefaultMaxClusterCores,
+		MaxMemoryTotal: config.DefaultMaxClusterMemory,
+	}
+	context, err := NewScaleTestAutoscalingContext(options, &fake.Clientset{}, listers, provider, nil, nil)
+	assert.NoError(t, err)
+
+	nodes := []*apiv1.Node{n1}
+	err = context.ClusterSnapshot.SetClusterState(nodes, nil, drasnapshot.Snapshot{})
+	assert.NoError(t, err)
+	nodeInfos, _ := nodeinfosprovider.NewDefaultTemplateNodeInfoProvider(nil, false).Process(&context, nodes, []*appsv1.DaemonSet{}, taints.TaintConfig{}, time.Now())
+	processors := processorstest.NewTestProcessors(&context)
+	clusterState := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, context.LogRecorder, NewBackoff(), nodegroupconfig.NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{MaxNodeProvisionTime: 15 * time.Minute}), asyncnodegroups.NewDefaultAsyncNodeGroupStateChecker())
+	clusterState.UpdateNodes(nodes, nodeInfos, time.Now())
+
+	suOrchestrator := New()
+	suOrchestrator.Initialize(&context, processors, clusterState, newEstimatorBuilder(), taints.TaintConfig{})
+	pods := []*apiv1.Pod{BuildTestPod("p1", 800, 0), BuildTestPod("p2", 800, 0), BuildTestPod("p3", 800, 0), BuildTestPod("too-big", 2000, 0)}
+	scaleUpStatus, err := suOrchestrator.SimulateScaleUp(ctx.Background(), pods, nodes, nodeInfos)
+	assert.NoError(t, err)
+	assert.True(t, scaleUpStatus.WasSuccessful())
+	assert.Equal(t, 1, len(scaleUpStatus.ScaleUpInfos))
+	assert.Equal(t, "ng1", scaleUpStatus.ScaleUpInfos[0].Group.Id())
+	assert.Equal(t, 1, scaleUpStatus.ScaleUpInfos[0].CurrentSize)
+	assert.Equal(t, 4, scaleUpStatus.ScaleUpInfos[0].NewSize)
+	assert.ElementsMatch(t, pods[:3], scaleUpStatus.PodsTriggeredScaleUp)
+	assert.Equal(t, 1, len(scaleUpStatus.PodsRemainUnschedulable))
+	assert.Equal(t, "too-big", scaleUpStatus.PodsRemainUnschedulable[0].Pod.Name)
+
+	targetSize, err := provider.GetNodeGroup("ng1").TargetSize()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, targetSize)
+}
+
+func TestSimulateScaleUpCostBudget(t *testing.T) {
+	testCases := []struct {
+		name            string
+		maxHourlyCost   float64
+		expectedSuccess bool
+		expectedNewSize int
+	}{
+		{
+			name:            "scale-up within hard budget",
+			maxHourlyCost:   4,
+			expectedSuccess: true,
+			expectedNewSize: 4,
+		},
+		{
+			name:            "scale-up capped to hard budget",
+			maxHourlyCost:   2.5,
+			expectedSuccess: true,
+			expectedNewSize: 2,
+		},
+		{
+			name:          "no node fits into hard budget",
+			maxHourlyCost: 1.5,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			podLister := kube_util.NewTestPodLister([]*apiv1.Pod{})
+			listers := kube_util.NewListerRegistry(nil, nil, podLister, nil, nil, nil, nil, nil, nil)
+			provider := testprovider.NewTestCloudProvider(func(nodeGroup string, increase int) error {
+				t.Fatalf("Unexpected scale-up of %s by %d during simulation", nodeGroup, increase)
+				return nil
+			}, nil)
+			n1 := BuildTestNode("n1", 1000, 1000)
+			SetNodeReadyState(n1, true, time.Now())
+			provider.AddNodeGroup("ng1", 1, 10, 1)
+			provider.AddNode("ng1", n1)
+
+			options := config.AutoscalingOptions{
+				EstimatorName:         estimator.BinpackingEstimatorName,
+				MaxCoresTotal:         config.DefaultMaxClusterCores,
+				MaxMemoryTotal:        config.DefaultMaxClusterMemory,
+				MaxClusterHourlyCost:  tc.maxHourlyCost,
+				ClusterCostBudgetMode: costbudget.HardMode,
+				NodeGroupHourlyPrices: map[string]float64{"ng1": 1},
+			}
+			context, err := NewScaleTestAutoscalingContext(options, &fake.Clientset{}, listers, provider, nil, nil)
+			assert.NoError(t, err)
+
+			nodes := []*apiv1.Node{n1}
+			err = context.ClusterSnapshot.SetClusterState(nodes, nil, drasnapshot.Snapshot{})
+			assert.NoError(t, err)
+			nodeInfos, _ := nodeinfosprovider.NewDefaultTemplateNodeInfoProvider(nil, false).Process(&context, nodes, []*appsv1.DaemonSet{}, taints.TaintConfig{}, time.Now())
+			processors := processorstest.NewTestProcessors(&context)
+			clusterState := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, context.LogRecorder, NewBackoff(), nodegroupconfig.NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{MaxNodeProvisionTime: 15 * time.Minute}), asyncnodegroups.NewDefaultAsyncNodeGroupStateChecker())
+			clusterState.UpdateNodes(nodes, nodeInfos, time.Now())
+
+			suOrchestrator := New()
+			suOrchestrator.Initialize(&context, processors, clusterState, newEstimatorBuilder(), taints.TaintConfig{})
+			pods := []*apiv1.Pod{BuildTestPod("p1", 800, 0), BuildTestPod("p2", 800, 0), BuildTestPod("p3", 800, 0)}
+			scaleUpStatus, err := suOrchestrator.SimulateScaleUp(ctx.Background(), pods, nodes, nodeInfos)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedSuccess, scaleUpStatus.WasSuccessful())
+			if tc.expectedSuccess {
+				assert.Equal(t, 1, len(scaleUpStatus.ScaleUpInfos))
+				assert.Equal(t, tc.expectedNewSize, scaleUpStatus.ScaleUpInfos[0].NewSize)
+			} else {
+				assert.Len(t, scaleUpStatus.PodsRemainUnschedulable, 3)
+				for _, noScaleUpInfo := range scaleUpStatus.PodsRemainUnschedulable {
+					assert.Equal(t, CostBudgetExceededReason, noScaleUpInfo.RejectedNodeGroups["ng1"])
+				}
+			}
+		})
+	}
+}
+
+func TestScaleUpAcrossNodeGroups(t *testing.T) {
+	testCases := []struct {
+		name               string
+		podCount           int
+		expectedSuccess    bool
+		expectedTargetSize int
+	}{
+		{
+			name:               "pods spread over both node groups",
+			podCount:           4,
+			expectedSuccess:    true,
+			expectedTargetSize: 6,
+		},
+		{
+			name:               "pods fit in a single node group",
+			podCount:           2,
+			expectedSuccess:    true,
+			expectedTargetSize: 4,
+		},
+		{
+			name:               "not enough capacity in all node groups",
+			podCount:           5,
+			expectedSuccess:    false,
+			expectedTargetSize: 2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			n1 := BuildTestNode("n1", 1000, 1000)
+			SetNodeReadyState(n1, true, time.Now())
+			n2 := BuildTestNode("n2", 1000, 1000)
+			SetNodeReadyState(n2, true, time.Now())
+			nodes := []*apiv1.Node{n1, n2}
+			scheduledPods := []*apiv1.Pod{BuildScheduledTestPod("p1", 800, 0, "n1"), BuildScheduledTestPod("p2", 800, 0, "n2")}
+
+			podLister := kube_util.NewTestPodLister(scheduledPods)
+			listers := kube_util.NewListerRegistry(nil, nil, podLister, nil, nil, nil, nil, nil, nil)
+			provider := testprovider.NewTestCloudProvider(func(nodeGroup string, increase int) error {
+				return nil
+			}, nil)
+			provider.AddNodeGroup("ng1", 1, 3, 1)
+			provider.AddNode("ng1", n1)
+			provider.AddNodeGroup("ng2", 1, 3, 1)
+			provider.AddNode("ng2", n2)
+
+			options := config.AutoscalingOptions{
+				EstimatorName:  estimator.BinpackingEstimatorName,
+				MaxCoresTotal:  config.DefaultMaxClusterCores,
+				MaxMemoryTotal: config.DefaultMaxClusterMemory,
+			}
+			context, err := NewScaleTestAutoscalingContext(options, &fake.Clientset{}, listers, provider, nil, nil)
+			assert.NoError(t, err)
+			err = context.ClusterSnapshot.SetClusterState(nodes, scheduledPods, drasnapshot.Snapshot{})
+			assert.NoError(t, err)
+			nodeInfos, _ := nodeinfosprovider.NewDefaultTemplateNodeInfoProvider(nil, false).Process(&context, nodes, []*appsv1.DaemonSet{}, taints.TaintConfig{}, time.Now())
+			processors := processorstest.NewTestProcessors(&context)
+			clusterState := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, context.LogRecorder, NewBackoff(), nodegroupconfig.NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{MaxNodeProvisionTime: 15 * time.Minute}), asyncnodegroups.NewDefaultAsyncNodeGroupStateChecker())
+			clusterState.UpdateNodes(nodes, nodeInfos, time.Now())
+			estimatorBuilder, _ := estimator.NewEstimatorBuilder(
+				estimator.BinpackingEstimatorName,
+				estimator.NewThresholdBasedEstimationLimiter([]estimator.Threshold{estimator.NewSngCapacityThreshold()}),
+				estimator.NewDecreasingPodOrderer(),
+				nil,
+			)
+
+			suOrchestrator := New()
+			suOrchestrator.Initialize(&context, processors, clusterState, estimatorBuilder, taints.TaintConfig{})
+			var pods []*apiv1.Pod
+			for i := 0; i < tc.podCount; i++ {
+				pods = append(pods, BuildTestPod(fmt.Sprintf("new-pod-%d", i), 800, 0))
+			}
+			scaleUpStatus, err := suOrchestrator.ScaleUpAcrossNodeGroups(ctx.Background(), pods, nodes, nodeInfos)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedSuccess, scaleUpStatus.WasSuccessful())
+			if tc.expectedSuccess {
+				assert.ElementsMatch(t, pods, scaleUpStatus.PodsTriggeredScaleUp)
+			}
+			totalTargetSize := 0
+			for _, nodeGroup := range provider.NodeGroups() {
+				targetSize, err := nodeGroup.TargetSize()
+				assert.NoError(t, err)
+				totalTargetSize += targetSize
+			}
+			assert.Equal(t, tc.expectedTargetSize, totalTargetSize)
+		})
+	}
+}
+
+func TestScaleUpCostBudget(t *testing.T) {
+	testCases := []struct {
+		name               string
+		maxHourlyCost      float64
+		mode               string
+		unknownPrice       bool
+		expectedSuccess    bool
+		expectedTargetSize int
+	}{
+		{
+			name:               "no budget",
+			expectedSuccess:    true,
+			expectedTargetSize: 3,
+		},
+		{
+			name:               "scale-up within hard budget",
+			maxHourlyCost:      3,
+			mode:               costbudget.HardMode,
+			expectedSuccess:    true,
+			expectedTargetSize: 3,
+		},
+		{
+			name:               "scale-up capped to hard budget",
+			maxHourlyCost:      2.5,
+			mode:               costbudget.HardMode,
+			expectedSuccess:    true,
+			expectedTargetSize: 2,
+		},
+		{
+			name:               "no node fits into hard budget",
+			maxHourlyCost:      1.5,
+			mode:               costbudget.HardMode,
+			expectedSuccess:    false,
+			expectedTargetSize: 1,
+		},
+		{
+			name:               "scale-up exceeding soft budget",
+			maxHourlyCost:      2.5,
+			mode:               costbudget.SoftMode,
+			expectedSuccess:    true,
+			expectedTargetSize: 3,
+		},
+		{
+			name:               "unknown price with hard budget",
+			maxHourlyCost:      10,
+			mode:               costbudget.HardMode,
+			unknownPrice:       true,
+			expectedSuccess:    false,
+			expectedTargetSize: 1,
+		},
+		{
+			name:               "unknown price with soft budget",
+			maxHourlyCost:      10,
+			mode:               costbudget.SoftMode,
+			unknownPrice:       true,
+			expectedSuccess:    true,
+			expectedTargetSize: 3,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			n1 := BuildTestNode("n1", 1000, 1000)
+			SetNodeReadyState(n1, true, time.Now())
+			nodes := []*apiv1.Node{n1}
+			scheduledPods := []*apiv1.Pod{BuildScheduledTestPod("p1", 800, 0, "n1")}
+
+			podLister := kube_util.NewTestPodLister(scheduledPods)
+			listers := kube_util.NewListerRegistry(nil, nil, podLister, nil, nil, nil, nil, nil, nil)
+			provider := testprovider.NewTestCloudProvider(func(nodeGroup string, increase int) error {
+				return nil
+			}, nil)
+			provider.AddNodeGroup("ng1", 1, 5, 1)
+			provider.AddNode("ng1", n1)
+
+			prices := map[string]float64{"ng1": 1}
+			if tc.unknownPrice {
+				// The test cloud provider has no pricing model.
+				prices = nil
+			}
+			options := config.AutoscalingOptions{
+				EstimatorName:         estimator.BinpackingEstimatorName,
+				MaxCoresTotal:         config.DefaultMaxClusterCores,
+				MaxMemoryTotal:        config.DefaultMaxClusterMemory,
+				MaxClusterHourlyCost:  tc.maxHourlyCost,
+				ClusterCostBudgetMode: tc.mode,
+				NodeGroupHourlyPrices: prices,
+			}
+			context, err := NewScaleTestAutoscalingContext(options, &fake.Clientset{}, listers, provider, nil, nil)
+			assert.NoError(t, err)
+			err = context.ClusterSnapshot.SetClusterState(nodes, scheduledPods, drasnapshot.Snapshot{})
+			assert.NoError(t, err)
+			nodeInfos, _ := nodeinfosprovider.NewDefaultTemplateNodeInfoProvider(nil, false).Process(&context, nodes, []*appsv1.DaemonSet{}, taints.TaintConfig{}, time.Now())
+			processors := processorstest.NewTestProcessors(&context)
+			clusterState := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, context.LogRecorder, NewBackoff(), nodegroupconfig.NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{MaxNodeProvisionTime: 15 * time.Minute}), asyncnodegroups.NewDefaultAsyncNodeGroupStateChecker())
+			clusterState.UpdateNodes(nodes, nodeInfos, time.Now())
+			estimatorBuilder, _ := estimator.NewEstimatorBuilder(
+				estimator.BinpackingEstimatorName,
+				estimator.NewThresholdBasedEstimationLimiter([]estimator.Threshold{estimator.NewSngCapacityThreshold()}),
+				estimator.NewDecreasingPodOrderer(),
+				nil,
+			)
+
+			suOrchestrator := New()
+			suOrchestrator.Initialize(&context, processors, clusterState, estimatorBuilder, taints.TaintConfig{})
+			pods := []*apiv1.Pod{BuildTestPod("new-pod-1", 800, 0), BuildTestPod("new-pod-2", 800, 0)}
+			scaleUpStatus, err := suOrchestrator.ScaleUp(ctx.Background(), pods, nodes, []*appsv1.DaemonSet{}, nodeInfos, false)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedSuccess, scaleUpStatus.WasSuccessful())
+			if !tc.expectedSuccess {
+				assert.Len(t, scaleUpStatus.PodsRemainUnschedulable, 2)
+				for _, noScaleUpInfo := range scaleUpStatus.PodsRemainUnschedulable {
+					assert.Equal(t, CostBudgetExceededReason, noScaleUpInfo.RejectedNodeGroups["ng1"])
+				}
+			}
+			targetSize, err := provider.GetNodeGroup("ng1").TargetSize()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedTargetSize, targetSize)
+		})
+	}
+}
+
+type subnetCapacityCloudProvider struct {
+	*testprovider.TestCloudProvider
+	freeSubnetCapacity map[string]int
+}
+
+func (p *subnetCapacityCloudProvider) FreeSubnetCapacity(nodeGroup cloudprovider.NodeGroup) (int, bool) {
+	free, found := p.freeSubnetCapacity[nodeGroup.Id()]
+	return free, found
+}
+
+func TestScaleUpSubnetCapacity(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		checkEnabled            bool
+		freeSubnetCapacity      map[string]int
+		expectedSuccess         bool
+		expectedTotalTargetSize int
+		expectedTargetSizes     map[string]int
+	}{
+		{
+			name:                    "check disabled",
+			freeSubnetCapacity:      map[string]int{"ng1": 0, "ng2": 0},
+			expectedSuccess:         true,
+			expectedTotalTargetSize: 4,
+		},
+		{
+			name:                    "unknown subnet capacity",
+			checkEnabled:            true,
+			freeSubnetCapacity:      map[string]int{},
+			expectedSuccess:         true,
+			expectedTotalTargetSize: 4,
+		},
+		{
+			name:                    "exhausted subnets of a node group",
+			checkEnabled:            true,
+			freeSubnetCapacity:      map[string]int{"ng1": 0, "ng2": 5},
+			expectedSuccess:         true,
+			expectedTotalTargetSize: 4,
+			expectedTargetSizes:     map[string]int{"ng1": 1, "ng2": 3},
+		},
+		{
+			name:                    "scale-up capped by free subnet capacity",
+			checkEnabled:            true,
+			freeSubnetCapacity:      map[string]int{"ng1": 0, "ng2": 1},
+			expectedSuccess:         true,
+			expectedTotalTargetSize: 3,
+			expectedTargetSizes:     map[string]int{"ng1": 1, "ng2": 2},
+		},
+		{
+			name:                    "all subnets exhausted",
+			checkEnabled:            true,
+			freeSubnetCapacity:      map[string]int{"ng1": 0, "ng2": 0},
+			expectedSuccess:         false,
+			expectedTotalTargetSize: 2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			n1 := BuildTestNode("n1", 1000, 1000)
+			SetNodeReadyState(n1, true, time.Now())
+			n2 := BuildTestNode("n2", 1000, 1000)
+			SetNodeReadyState(n2, true, time.Now())
+			nodes := []*apiv1.Node{n1, n2}
+			scheduledPods := []*apiv1.Pod{BuildScheduledTestPod("p1", 800, 0, "n1"), BuildScheduledTestPod("p2", 800, 0, "n2")}
+
+			podLister := kube_util.NewTestPodLister(scheduledPods)
+			listers := kube_util.NewListerRegistry(nil, nil, podLister, nil, nil, nil, nil, nil, nil)
+			testProvider := testprovider.NewTestCloudProvider(func(nodeGroup string, increase int) error {
+				return nil
+			}, nil)
+			testProvider.AddNodeGroup("ng1", 1, 5, 1)
+			testProvider.AddNode("ng1", n1)
+			testProvider.AddNodeGroup("ng2", 1, 5, 1)
+			testProvider.AddNode("ng2", n2)
+			provider := &subnetCapacityCloudProvider{TestCloudProvider: testProvider, freeSubnetCapacity: tc.freeSubnetCapacity}
+
+			options := config.AutoscalingOptions{
+				EstimatorName:              estimator.BinpackingEstimatorName,
+				MaxCoresTotal:              config.DefaultMaxClusterCores,
+				MaxMemoryTotal:             config.DefaultMaxClusterMemory,
+				SubnetCapacityCheckEnabled: tc.checkEnabled,
+			}
+			context, err := NewScaleTestAutoscalingContext(options, &fake.Clientset{}, listers, provider, nil, nil)
+			assert.NoError(t, err)
+			err = context.ClusterSnapshot.SetClusterState(nodes, scheduledPods, drasnapshot.Snapshot{})
+			assert.NoError(t, err)
+			nodeInfos, _ := nodeinfosprovider.NewDefaultTemplateNodeInfoProvider(nil, false).Process(&context, nodes, []*appsv1.DaemonSet{}, taints.TaintConfig{}, time.Now())
+			processors := processorstest.NewTestProcessors(&context)
+			clusterState := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, context.LogRecorder, NewBackoff(), nodegroupconfig.NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{MaxNodeProvisionTime: 15 * time.Minute}), asyncnodegroups.NewDefaultAsyncNodeGroupStateChecker())
+			clusterState.UpdateNodes(nodes, nodeInfos, time.Now())
+			thresholds := []estimator.Threshold{estimator.NewSngCapacityThreshold()}
+			if tc.checkEnabled {
+				thresholds = append(thresholds, estimator.NewSubnetCapacityThreshold(provider))
+			}
+			estimatorBuilder, _ := estimator.NewEstimatorBuilder(
+				estimator.BinpackingEstimatorName,
+				estimator.NewThresholdBasedEstimationLimiter(thresholds),
+				estimator.NewDecreasingPodOrderer(),
+				nil,
+			)
+
+			suOrchestrator := New()
+			suOrchestrator.Initialize(&context, processors, clusterState, estimatorBuilder, taints.TaintConfig{})
+			pods := []*apiv1.Pod{BuildTestPod("new-pod-1", 800, 0), BuildTestPod("new-pod-2", 800, 0)}
+			scaleUpStatus, err := suOrchestrator.ScaleUp(ctx.Background(), pods, nodes, []*appsv1.DaemonSet{}, nodeInfos, false)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedSuccess, scaleUpStatus.WasSuccessful())
+			if !tc.expectedSuccess {
+				assert.Len(t, scaleUpStatus.PodsRemainUnschedulable, 2)
+				for _, noScaleUpInfo := range scaleUpStatus.PodsRemainUnschedulable {
+					assert.Equal(t, SubnetExhaustedReason, noScaleUpInfo.SkippedNodeGroups["ng1"])
+					assert.Equal(t, SubnetExhaustedReason, noScaleUpInfo.SkippedNodeGroups["ng2"])
+				}
+			}
+			totalTargetSize := 0
+			for _, ng := range provider.NodeGroups() {
+				targetSize, err := ng.TargetSize()
+				assert.NoError(t, err)
+				totalTargetSize += targetSize
+				if expectedTargetSize, found := tc.expectedTargetSizes[ng.Id()]; found {
+					assert.Equal(t, expectedTargetSize, targetSize, ng.Id())
+				}
+			}
+			assert.Equal(t, tc.expectedTotalTargetSize, totalTargetSize)
+		})
+	}
+}
+
+func TestScaleUpPausedNodeGroups(t *testing.T) {
+	testCases := []struct {
+		name                string
+		pausedNodeGroups    []string
+		expectedSuccess     bool
+		expectedTargetSizes map[string]int
+	}{
+		{
+			name:            "no pauses",
+			expectedSuccess: true,
+		},
+		{
+			name:                "paused node group",
+			pausedNodeGroups:    []string{"ng1"},
+			expectedSuccess:     true,
+			expectedTargetSizes: map[string]int{"ng1": 1, "ng2": 3},
+		},
+		{
+			name:                "all node groups paused",
+			pausedNodeGroups:    []string{"ng1", "ng2"},
+			expectedSuccess:     false,
+			expectedTargetSizes: map[string]int{"ng1": 1, "ng2": 1},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			now := time.Now()
+			n1 := BuildTestNode("n1", 1000, 1000)
+			SetNodeReadyState(n1, true, now)
+			n2 := BuildTestNode("n2", 1000, 1000)
+			SetNodeReadyState(n2, true, now)
+			nodes := []*apiv1.Node{n1, n2}
+			scheduledPods := []*apiv1.Pod{BuildScheduledTestPod("p1", 800, 0, "n1"), BuildScheduledTestPod("p2", 800, 0, "n2")}
+
+			podLister := kube_util.NewTestPodLister(scheduledPods)
+			listers := kube_util.NewListerRegistry(nil, nil, podLister, nil, nil, nil, nil, nil, nil)
+			provider := testprovider.NewTestCloudProvider(func(nodeGroup string, increase int) error {
+				return nil
+			}, nil)
+			provider.AddNodeGroup("ng1", 1, 5, 1)
+			provider.AddNode("ng1", n1)
+			provider.AddNodeGroup("ng2", 1, 5, 1)
+			provider.AddNode("ng2", n2)
+
+			options := config.AutoscalingOptions{
+				EstimatorName:  estimator.BinpackingEstimatorName,
+				MaxCoresTotal:  config.DefaultMaxClusterCores,
+				MaxMemoryTotal: config.DefaultMaxClusterMemory,
+			}
+			context, err := NewScaleTestAutoscalingContext(options, &fake.Clientset{}, listers, provider, nil, nil)
+			assert.NoError(t, err)
+			err = context.ClusterSnapshot.SetClusterState(nodes, scheduledPods, drasnapshot.Snapshot{})
+			assert.NoError(t, err)
+			nodeInfos, _ := nodeinfosprovider.NewDefaultTemplateNodeInfoProvider(nil, false).Process(&context, nodes, []*appsv1.DaemonSet{}, taints.TaintConfig{}, now)
+			processors := processorstest.NewTestProcessors(&context)
+			clusterState := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, context.LogRecorder, NewBackoff(), nodegroupconfig.NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{MaxNodeProvisionTime: 15 * time.Minute}), asyncnodegroups.NewDefaultAsyncNodeGroupStateChecker())
+			pauses := pause.NewRegistry()
+			for _, nodeGroup := range tc.pausedNodeGroups {
+				assert.NoError(t, pauses.Pause(pause.Pause{Operation: pause.ScaleUp, NodeGroup: nodeGroup, Until: metav1.NewTime(now.Add(time.Hour))}))
+			}
+			clusterState.SetPauses(pauses)
+			clusterState.UpdateNodes(nodes, nodeInfos, now)
+
+			suOrchestrator := New()
+			suOrchestrator.Initialize(&context, processors, clusterState, newEstimatorBuilder(), taints.TaintConfig{})
+			pods := []*apiv1.Pod{BuildTestPod("new-pod-1", 800, 0), BuildTestPod("new-pod-2", 800, 0)}
+			scaleUpStatus, err := suOrchestrator.ScaleUp(ctx.Background(), pods, nodes, []*appsv1.DaemonSet{}, nodeInfos, false)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedSuccess, scaleUpStatus.WasSuccessful())
+			if !tc.expectedSuccess {
+				assert.Len(t, scaleUpStatus.PodsRemainUnschedulable, 2)
+				for _, noScaleUpInfo := range scaleUpStatus.PodsRemainUnschedulable {
+					assert.Equal(t, PausedReason, noScaleUpInfo.SkippedNodeGroups["ng1"])
+					assert.Equal(t, PausedReason, noScaleUpInfo.SkippedNodeGroups["ng2"])
+				}
+			}
+			for _, ng := range provider.NodeGroups() {
+				targetSize, err := ng.TargetSize()
+				assert.NoError(t, err)
+				if expectedTargetSize, found := tc.expectedTargetSizes[ng.Id()]; found {
+					assert.Equal(t, expectedTargetSize, targetSize, ng.Id())
+				}
+			}
+		})
+	}
+}
+
 func TestScaleupAsyncNodeGroupsEnabled(t *testing.T) {
 	t1 := BuildTestNode("t1", 100, 0)
 	SetNodeReadyState(t1, true, time.Time{})
@@ -1776,7 +2564,7 @@ func TestScaleupAsyncNodeGroupsEnabled(t *testing.T) {
 
 		suOrchestrator := New()
 		suOrchestrator.Initialize(&context, processors, clusterState, newEstimatorBuilder(), taints.TaintConfig{})
-		scaleUpStatus, err := suOrchestrator.ScaleUp(tc.podsToAdd, nodes, []*appsv1.DaemonSet{}, nodeInfos, false)
+		scaleUpStatus, err := suOrchestrator.ScaleUp(ctx.Background(), tc.podsToAdd, nodes, []*appsv1.DaemonSet{}, nodeInfos, false)
 		assert.NoError(t, err)
 		assert.True(t, scaleUpStatus.WasSuccessful())
 
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orchestrator
+
+import (
+	ctx "context"
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/estimator"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot/predicate"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+)
+
+// newBinpackingHandles builds a framework handle for each of the binpacking workers. Scheduler plugins
+// read the cluster state through their handle, so workers running them concurrently can't share one.
+// Returns nil if binpacking should run sequentially.
+func newBinpackingHandles(autoscalingContext *context.AutoscalingContext) []*framework.Handle {
+	if autoscalingContext.BinpackingParallelism <= 1 || autoscalingContext.FrameworkHandle == nil {
+		return nil
+	}
+	handles := make([]*framework.Handle, 0, autoscalingContext.BinpackingParallelism)
+	for i := 0; i < autoscalingContext.BinpackingParallelism; i++ {
+		handle, err := autoscalingContext.FrameworkHandle.Copy()
+		if err != nil {
+			klog.Errorf("Failed to create framework handle for parallel binpacking, binpacking will run sequentially: %v", err)
+			return nil
+		}
+		handles = append(handles, handle)
+	}
+	return handles
+}
+
+// computeExpansionOptionsInParallel computes expansion options for node groups, running binpacking for up to
+// len(o.binpackingHandles) node groups at a time. Each worker binpacks on its own copy of the cluster snapshot.
+// Node group methods called by the estimator run concurrently, see cloudprovider.NodeGroup.
+//
+// Node groups are binpacked in batches, and the options of a batch are passed to processOption in the order of
+// node groups, until it returns true. This keeps the binpacking limiter seeing the same sequence of options as if
+// binpacking was sequential, at the cost of the options computed after the limiter stops binpacking.
+func (o *ScaleUpOrchestrator) computeExpansionOptionsInParallel(
+	traceContext ctx.Context,
+	nodeGroups []cloudprovider.NodeGroup,
+	schedulablePodGroups map[string][]estimator.PodEquivalenceGroup,
+	nodeInfos map[string]*framework.NodeInfo,
+	currentNodeCount int,
+	now time.Time,
+	allOrNothing bool,
+	processOption func(expander.Option) bool,
+) {
+	workers := len(o.binpackingHandles)
+	snapshots := make([]clustersnapshot.ClusterSnapshot, workers)
+	for i, handle := range o.binpackingHandles {
+		snapshots[i] = predicate.NewPredicateSnapshot(o.autoscalingContext.ClusterSnapshot.Clone(), handle, o.autoscalingContext.DynamicResourceAllocationEnabled)
+	}
+
+	for start := 0; start < len(nodeGroups); start += workers {
+		batch := nodeGroups[start:min(start+workers, len(nodeGroups))]
+		options := make([]expander.Option, len(batch))
+		for i, nodeGroup := range batch {
+			options[i] = expander.Option{NodeGroup: nodeGroup}
+			if len(schedulablePodGroups[nodeGroup.Id()]) > 0 {
+				// Similar node groups are found sequentially, only the estimation runs concurrently.
+				options[i].SimilarNodeGroups = o.ComputeSimilarNodeGroups(nodeGroup, nodeInfos, schedulablePodGroups, now)
+			}
+		}
+
+		workqueue.ParallelizeUntil(ctx.Background(), workers, len(batch), func(i int) {
+			podGroups := schedulablePodGroups[batch[i].Id()]
+			if len(podGroups) == 0 {
+				return
+			}
+			o.estimateExpansionOption(traceContext, &options[i], snapshots[i], podGroups, nodeInfos[batch[i].Id()], currentNodeCount)
+		})
+
+		for _, option := range options {
+			if len(schedulablePodGroups[option.NodeGroup.Id()]) > 0 {
+				// Node group options are read sequentially, like similar node groups.
+				o.applyZeroOrMaxNodeScaling(&option, allOrNothing)
+			}
+			if processOption(option) {
+				return
+			}
+		}
+	}
+}
@@ -34,4 +34,6 @@ func (sr *RejectedReasons) Reasons() []string {
 var (
 	// AllOrNothingReason means the node group was rejected because not all pods would fit it when using all-or-nothing strategy.
 	AllOrNothingReason = NewRejectedReasons("not all pods would fit and scale-up is using all-or-nothing strategy")
+	// CostBudgetExceededReason means the scale-up was rejected because it would exceed the cluster hourly cost budget.
+	CostBudgetExceededReason = NewRejectedReasons("scale-up would exceed the cluster hourly cost budget")
 )
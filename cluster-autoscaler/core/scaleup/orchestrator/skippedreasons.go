@@ -43,6 +43,10 @@ var (
 	MaxLimitReachedReason = NewSkippedReasons("max node group size reached")
 	// NotReadyReason node group is not ready.
 	NotReadyReason = NewSkippedReasons("not ready for scale-up")
+	// SubnetExhaustedReason node group's subnets have no free IP addresses left for new nodes.
+	SubnetExhaustedReason = NewSkippedReasons("SubnetExhausted: no free IP addresses in node group subnets")
+	// PausedReason node group's scale-up is paused.
+	PausedReason = NewSkippedReasons("scale-up paused")
 )
 
 // MaxResourceLimitReached contains information why given node group was skipped.
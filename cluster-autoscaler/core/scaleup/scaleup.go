@@ -17,8 +17,11 @@ limitations under the License.
 package scaleup
 
 import (
+	ctx "context"
+
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
 	"k8s.io/autoscaler/cluster-autoscaler/context"
 	"k8s.io/autoscaler/cluster-autoscaler/estimator"
@@ -42,8 +45,10 @@ type Orchestrator interface {
 	)
 	// ScaleUp tries to scale the cluster up. Returns appropriate status or error if
 	// an unexpected error occurred. Assumes that all nodes in the cluster are ready
-	// and in sync with instance groups.
+	// and in sync with instance groups. Spans of the scale-up are nested in the span of
+	// traceContext.
 	ScaleUp(
+		traceContext ctx.Context,
 		unschedulablePods []*apiv1.Pod,
 		nodes []*apiv1.Node,
 		daemonSets []*appsv1.DaemonSet,
@@ -55,6 +60,17 @@ type Orchestrator interface {
 	// size is the TargetSize queried directly from cloud providers. Returns
 	// appropriate status or error if an unexpected error occurred.
 	ScaleUpToNodeGroupMinSize(
+		traceContext ctx.Context,
+		nodes []*apiv1.Node,
+		nodeInfos map[string]*framework.NodeInfo,
+	) (*status.ScaleUpStatus, errors.AutoscalerError)
+	// ScaleUpNodeGroup tries to scale up the given node group by newNodes nodes,
+	// subject to the same backoff, limit and cost budget checks as ScaleUp.
+	// Returns appropriate status or error if an unexpected error occurred.
+	ScaleUpNodeGroup(
+		traceContext ctx.Context,
+		nodeGroup cloudprovider.NodeGroup,
+		newNodes int,
 		nodes []*apiv1.Node,
 		nodeInfos map[string]*framework.NodeInfo,
 	) (*status.ScaleUpStatus, errors.AutoscalerError)
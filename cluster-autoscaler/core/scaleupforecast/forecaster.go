@@ -0,0 +1,335 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaleupforecast
+
+import (
+	ctx "context"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	klog "k8s.io/klog/v2"
+)
+
+const (
+	slotDuration = time.Hour
+	week         = 7 * 24 * time.Hour
+	// minObservedWeeks is the number of weeks a slot has to be observed for before scale-ups in it
+	// are predicted, a single week doesn't tell whether a scale-up recurs.
+	minObservedWeeks = 2
+)
+
+// firstWeek is the start of week 0, the first Sunday after the Unix epoch.
+var firstWeek = time.Date(1970, time.January, 4, 0, 0, 0, 0, time.UTC)
+
+func weekOf(t time.Time) int64 {
+	return int64(t.Sub(firstWeek) / week)
+}
+
+func hourOf(t time.Time) int {
+	return int(t.Sub(firstWeek) % week / slotDuration)
+}
+
+func slotStart(weekNumber int64, hour int) time.Time {
+	return firstWeek.Add(time.Duration(weekNumber)*week + time.Duration(hour)*slotDuration)
+}
+
+// observedWeeks returns the number of weeks the given hour of the week started in between since and now.
+func observedWeeks(since, now time.Time, hour int) int {
+	first := weekOf(since)
+	if slotStart(first, hour).Before(since.Truncate(slotDuration)) {
+		first++
+	}
+	last := weekOf(now)
+	if slotStart(last, hour).After(now) {
+		last--
+	}
+	if last < first {
+		return 0
+	}
+	return int(last - first + 1)
+}
+
+// findSlot returns the slot of the given hour, or nil if the node group has no history in it.
+func (h *History) findSlot(hour int) *Slot {
+	i := sort.Search(len(h.Slots), func(i int) bool { return h.Slots[i].Hour >= hour })
+	if i < len(h.Slots) && h.Slots[i].Hour == hour {
+		return &h.Slots[i]
+	}
+	return nil
+}
+
+// slot returns the slot of the given hour, adding it if needed.
+func (h *History) slot(hour int) *Slot {
+	if slot := h.findSlot(hour); slot != nil {
+		return slot
+	}
+	i := sort.Search(len(h.Slots), func(i int) bool { return h.Slots[i].Hour >= hour })
+	h.Slots = append(h.Slots, Slot{})
+	copy(h.Slots[i+1:], h.Slots[i:])
+	h.Slots[i] = Slot{Hour: hour}
+	return &h.Slots[i]
+}
+
+// recordScaleUp counts a scale-up by delta nodes in the given week. A week the slot was pre-scaled
+// for becomes a scale-up week, in which the predicted nodes were added on top of the scale-up.
+func (s *Slot) recordScaleUp(weekNumber int64, delta int) {
+	switch {
+	case s.LastWeek != weekNumber:
+		s.ScaleUpWeeks++
+	case s.LastWeekPreScaled:
+		s.Nodes += s.predictedNodes()
+		s.PreScaledWeeks--
+		s.ScaleUpWeeks++
+	}
+	s.LastWeek = weekNumber
+	s.LastWeekPreScaled = false
+	s.Nodes += delta
+}
+
+// recordPreScale counts a pre-scale for the given week.
+func (s *Slot) recordPreScale(weekNumber int64) {
+	s.PreScaledWeeks++
+	s.LastWeek = weekNumber
+	s.LastWeekPreScaled = true
+}
+
+// confidence returns the fraction of the observed weeks, excluding pre-scaled ones, with a scale-up
+// in the slot.
+func (s *Slot) confidence(observedWeeks int) float64 {
+	weeks := observedWeeks - s.PreScaledWeeks
+	if weeks < minObservedWeeks || s.ScaleUpWeeks == 0 {
+		return 0
+	}
+	return math.Min(1, float64(s.ScaleUpWeeks)/float64(weeks))
+}
+
+// predictedNodes returns the average number of nodes added in the weeks with a scale-up in the slot.
+func (s *Slot) predictedNodes() int {
+	if s.ScaleUpWeeks == 0 {
+		return 0
+	}
+	return (s.Nodes + s.ScaleUpWeeks/2) / s.ScaleUpWeeks
+}
+
+type preScale struct {
+	nodeGroupId string
+	nodes       int
+	// since is the time of the pre-scale, nodes of the node group created since are protected from
+	// scale-down until the slot ends.
+	since time.Time
+	until time.Time
+}
+
+// Forecaster learns the hours of the week node groups are scaled up in for pending pods, and scales
+// them up ahead of scale-ups predicted to recur. The history is persisted as ScaleUpHistory objects,
+// so that it survives restarts of CA.
+//
+// A slot, an hour of the week, is predicted once it was observed for at least two weeks, if the
+// fraction of weeks with a scale-up in it reaches the confidence threshold. The node group is then
+// scaled up by the average number of nodes added in these weeks, lead time before the slot starts.
+// Node groups are scaled up through the scale-up orchestrator, which applies the same limits as to other
+// scale-ups. Pre-scaled nodes count towards the cap on preemptive nodes, and are protected from scale-down,
+// until the end of their slot, after which they're regular nodes, removed by scale-down if they turn out
+// to be unneeded. Weeks in which
+// a slot was pre-scaled and no further scale-up was needed don't change its confidence.
+type Forecaster struct {
+	context             *context.AutoscalingContext
+	scaleUpOrchestrator scaleup.Orchestrator
+	store               *Store
+	// histories are the histories of node groups, by node group id.
+	histories map[string]*History
+	// loaded is set once the persisted histories were listed.
+	loaded bool
+	// preScales are the pre-scales whose slot hasn't ended yet.
+	preScales []preScale
+}
+
+// NewForecaster creates a new Forecaster.
+func NewForecaster(context *context.AutoscalingContext, scaleUpOrchestrator scaleup.Orchestrator, store *Store) *Forecaster {
+	return &Forecaster{
+		context:             context,
+		scaleUpOrchestrator: scaleUpOrchestrator,
+		store:               store,
+		histories:           make(map[string]*History),
+	}
+}
+
+// RecordScaleUp adds the scale-up for pending pods to the history of the scaled up node groups.
+func (f *Forecaster) RecordScaleUp(scaleUpStatus *status.ScaleUpStatus, currentTime time.Time) {
+	if scaleUpStatus == nil || !scaleUpStatus.WasSuccessful() || !f.load() {
+		return
+	}
+	weekNumber, hour := weekOf(currentTime), hourOf(currentTime)
+	for _, info := range scaleUpStatus.ScaleUpInfos {
+		delta := info.NewSize - info.CurrentSize
+		if delta <= 0 {
+			continue
+		}
+		history := f.history(info.Group.Id(), currentTime)
+		history.slot(hour).recordScaleUp(weekNumber, delta)
+		f.write(history)
+	}
+}
+
+// PreScale scales up node groups whose scale-up is predicted within the lead time, within the span of
+// traceContext. It's called in loops without a scale-up for pending pods, which would otherwise be
+// counted in the prediction.
+func (f *Forecaster) PreScale(traceContext ctx.Context, readyNodes []*apiv1.Node, nodeInfosForGroups map[string]*framework.NodeInfo, currentTime time.Time) {
+	if !f.load() {
+		return
+	}
+	outstanding := 0
+	var preScales []preScale
+	for _, p := range f.preScales {
+		if p.until.After(currentTime) {
+			outstanding += p.nodes
+			preScales = append(preScales, p)
+		}
+	}
+	f.preScales = preScales
+
+	target := currentTime.Add(f.context.PredictiveScaleUpLeadTime)
+	weekNumber, hour := weekOf(target), hourOf(target)
+	for _, nodeGroup := range f.context.CloudProvider.NodeGroups() {
+		if outstanding >= f.context.PredictiveScaleUpMaxNodes {
+			klog.V(4).Infof("Predictive scale-up: %d preemptive nodes outstanding, not pre-scaling more", outstanding)
+			return
+		}
+		history, found := f.histories[nodeGroup.Id()]
+		if !found || !nodeGroup.Exist() {
+			continue
+		}
+		slot := history.findSlot(hour)
+		if slot == nil || slot.LastWeek == weekNumber {
+			// Already scaled up or pre-scaled this week.
+			continue
+		}
+		confidence := slot.confidence(observedWeeks(history.Since.Time, currentTime, hour))
+		if confidence < f.context.PredictiveScaleUpConfidenceThreshold {
+			continue
+		}
+		nodes := slot.predictedNodes()
+		if nodes > f.context.PredictiveScaleUpMaxNodes-outstanding {
+			nodes = f.context.PredictiveScaleUpMaxNodes - outstanding
+		}
+		if nodes <= 0 {
+			continue
+		}
+		// The orchestrator skips node groups which are backed off or unhealthy, and caps the nodes by the
+		// max size of the node group, the resource limits and the cost budget.
+		scaleUpStatus, aErr := f.scaleUpOrchestrator.ScaleUpNodeGroup(traceContext, nodeGroup, nodes, readyNodes, nodeInfosForGroups)
+		if aErr != nil {
+			f.context.LogRecorder.Eventf(apiv1.EventTypeWarning, "FailedToScaleUpGroup", "Predictive scale-up failed for group %s: %v", nodeGroup.Id(), aErr)
+			klog.Errorf("Predictive scale-up: failed to scale up %s: %v", nodeGroup.Id(), aErr)
+			continue
+		}
+		if scaleUpStatus == nil || scaleUpStatus.Result != status.ScaleUpSuccessful {
+			klog.V(2).Infof("Predictive scale-up: scale-up of %s not possible, not pre-scaling it", nodeGroup.Id())
+			continue
+		}
+		nodes = 0
+		for _, info := range scaleUpStatus.ScaleUpInfos {
+			nodes += info.NewSize - info.CurrentSize
+		}
+		klog.V(0).Infof("Predictive scale-up: group %s scaled up by %d nodes ahead of a scale-up predicted at %v with confidence %.2f",
+			nodeGroup.Id(), nodes, slotStart(weekNumber, hour), confidence)
+		f.context.LogRecorder.Eventf(apiv1.EventTypeNormal, "PredictiveScaleUp",
+			"Group %s scaled up by %d nodes ahead of a scale-up predicted at %v with confidence %.2f", nodeGroup.Id(), nodes, slotStart(weekNumber, hour), confidence)
+		slot.recordPreScale(weekNumber)
+		f.write(history)
+		outstanding += nodes
+		f.preScales = append(f.preScales, preScale{
+			nodeGroupId: nodeGroup.Id(),
+			nodes:       nodes,
+			since:       currentTime,
+			until:       slotStart(weekNumber, hour).Add(slotDuration),
+		})
+	}
+}
+
+// FilterOutPreScaled removes the nodes added ahead of predicted scale-ups from the scale-down candidates
+// until the end of their slot, so that they aren't removed before the scale-up they were added for. These
+// are the nodes of pre-scaled node groups created since the pre-scale.
+func (f *Forecaster) FilterOutPreScaled(candidates []*apiv1.Node, currentTime time.Time) []*apiv1.Node {
+	protectedSince := make(map[string]time.Time)
+	for _, p := range f.preScales {
+		if !p.until.After(currentTime) {
+			continue
+		}
+		if since, found := protectedSince[p.nodeGroupId]; !found || p.since.Before(since) {
+			protectedSince[p.nodeGroupId] = p.since
+		}
+	}
+	if len(protectedSince) == 0 {
+		return candidates
+	}
+	var result []*apiv1.Node
+	for _, node := range candidates {
+		nodeGroup, err := f.context.CloudProvider.NodeGroupForNode(node)
+		if err == nil && nodeGroup != nil && !reflect.ValueOf(nodeGroup).IsNil() {
+			if since, found := protectedSince[nodeGroup.Id()]; found && !node.CreationTimestamp.Time.Before(since) {
+				klog.V(4).Infof("Predictive scale-up: node %s was added ahead of a predicted scale-up, not scaling it down", node.Name)
+				continue
+			}
+		}
+		result = append(result, node)
+	}
+	return result
+}
+
+// load takes over the persisted histories. Listing them is retried until it succeeds, nothing is
+// recorded or predicted until then.
+func (f *Forecaster) load() bool {
+	if f.loaded {
+		return true
+	}
+	histories, err := f.store.List()
+	if err != nil {
+		klog.Errorf("Failed to list scale-up histories, not forecasting scale-ups: %v", err)
+		return false
+	}
+	for i := range histories {
+		f.histories[histories[i].NodeGroup] = &histories[i]
+	}
+	f.loaded = true
+	return true
+}
+
+func (f *Forecaster) history(nodeGroupId string, currentTime time.Time) *History {
+	history, found := f.histories[nodeGroupId]
+	if !found {
+		history = &History{NodeGroup: nodeGroupId, Since: metav1.NewTime(currentTime)}
+		f.histories[nodeGroupId] = history
+	}
+	return history
+}
+
+// write persists the history of a node group. The history is kept in memory if it fails, and
+// persisted again with the next change.
+func (f *Forecaster) write(history *History) {
+	if err := f.store.Write(*history); err != nil {
+		klog.Errorf("Failed to persist scale-up history: %v", err)
+	}
+}
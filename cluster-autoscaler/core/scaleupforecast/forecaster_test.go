@@ -0,0 +1,303 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaleupforecast
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	. "k8s.io/autoscaler/cluster-autoscaler/core/test"
+	"k8s.io/autoscaler/cluster-autoscaler/core/test/scaleuptest"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupset"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/nodegroupcrd"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestStore(t *testing.T) {
+	store := &Store{nodegroupcrd.NewFakeStore(historyObjects)}
+	history := History{
+		NodeGroup: "https://example.com/ng1",
+		Since:     metav1.NewTime(time.Date(2025, 1, 6, 9, 30, 0, 0, time.UTC)),
+		Slots:     []Slot{{Hour: 33, ScaleUpWeeks: 1, Nodes: 3, LastWeek: 2866}},
+	}
+
+	assert.NoError(t, store.Write(history))
+	history.Slots[0].ScaleUpWeeks = 2
+	assert.NoError(t, store.Write(history))
+	histories, err := store.List()
+	assert.NoError(t, err)
+	if assert.Len(t, histories, 1) {
+		assert.Equal(t, history.NodeGroup, histories[0].NodeGroup)
+		assert.True(t, history.Since.Equal(&histories[0].Since))
+		assert.Equal(t, history.Slots, histories[0].Slots)
+	}
+}
+
+func TestSlotTime(t *testing.T) {
+	// Monday 9:30 UTC.
+	monday := time.Date(2025, 1, 6, 9, 30, 0, 0, time.UTC)
+	assert.Equal(t, 33, hourOf(monday))
+	assert.Equal(t, weekOf(monday), weekOf(time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, weekOf(monday)-1, weekOf(time.Date(2025, 1, 4, 23, 59, 0, 0, time.UTC)))
+	assert.True(t, time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC).Equal(slotStart(weekOf(monday), 33)))
+
+	for _, tc := range []struct {
+		name  string
+		since time.Time
+		now   time.Time
+		want  int
+	}{
+		{"since in the slot", monday, monday.Add(time.Minute), 1},
+		{"since after the slot", monday.Add(time.Hour), monday.Add(7 * 24 * time.Hour), 1},
+		{"now before the slot", monday, monday.Add(14*24*time.Hour - time.Hour), 2},
+		{"now in the slot", monday, monday.Add(14 * 24 * time.Hour), 3},
+		{"now before since", monday, monday.Add(-time.Hour), 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, observedWeeks(tc.since, tc.now, 33))
+		})
+	}
+}
+
+func TestSlot(t *testing.T) {
+	var s Slot
+	s.recordScaleUp(1, 3)
+	s.recordScaleUp(1, 1)
+	s.recordScaleUp(2, 4)
+	assert.Equal(t, Slot{ScaleUpWeeks: 2, Nodes: 8, LastWeek: 2}, s)
+	assert.Equal(t, 4, s.predictedNodes())
+	assert.Equal(t, 1.0, s.confidence(2))
+	assert.Equal(t, 0.5, s.confidence(4))
+	assert.Zero(t, (&Slot{ScaleUpWeeks: 1, Nodes: 1}).confidence(1))
+
+	// A pre-scaled week doesn't change the confidence.
+	s.recordPreScale(3)
+	assert.Equal(t, 1.0, s.confidence(3))
+	// Unless a scale-up is still needed, the predicted nodes are then counted in it.
+	s.recordScaleUp(3, 2)
+	assert.Equal(t, Slot{ScaleUpWeeks: 3, Nodes: 14, LastWeek: 3}, s)
+}
+
+func scaleUpStatus(provider *testprovider.TestCloudProvider, nodeGroupId string, delta int) *status.ScaleUpStatus {
+	return &status.ScaleUpStatus{
+		Result:       status.ScaleUpSuccessful,
+		ScaleUpInfos: []nodegroupset.ScaleUpInfo{{Group: provider.GetNodeGroup(nodeGroupId), CurrentSize: 1, NewSize: 1 + delta}},
+	}
+}
+
+func TestForecaster(t *testing.T) {
+	// Monday 9:30 UTC, scale-ups are predicted from Monday 8:50 with the default lead time.
+	firstScaleUp := time.Date(2025, 1, 6, 9, 30, 0, 0, time.UTC)
+	weekLater := func(weeks int, d time.Duration) time.Time {
+		return firstScaleUp.Add(time.Duration(weeks)*7*24*time.Hour + d)
+	}
+
+	for _, tc := range []struct {
+		name       string
+		scaleUps   []int
+		maxNodes   int
+		threshold  float64
+		maxSize    int
+		preScaleAt time.Time
+		wantDelta  int
+	}{
+		{
+			name:       "scale-up in two weeks",
+			scaleUps:   []int{3, 5},
+			preScaleAt: weekLater(2, -35*time.Minute),
+			wantDelta:  4,
+		},
+		{
+			name:       "before the lead time",
+			scaleUps:   []int{3, 5},
+			preScaleAt: weekLater(2, -45*time.Minute),
+		},
+		{
+			name:       "single week",
+			scaleUps:   []int{3},
+			preScaleAt: weekLater(1, -35*time.Minute),
+		},
+		{
+			name:       "below the confidence threshold",
+			scaleUps:   []int{3, 0, 5},
+			preScaleAt: weekLater(3, -35*time.Minute),
+		},
+		{
+			name:       "lower confidence threshold",
+			scaleUps:   []int{3, 0, 5},
+			threshold:  0.6,
+			preScaleAt: weekLater(3, -35*time.Minute),
+			wantDelta:  4,
+		},
+		{
+			name:       "capped by max preemptive nodes",
+			scaleUps:   []int{3, 5},
+			maxNodes:   2,
+			preScaleAt: weekLater(2, -35*time.Minute),
+			wantDelta:  2,
+		},
+		{
+			name:       "capped by max size",
+			scaleUps:   []int{3, 5},
+			maxSize:    3,
+			preScaleAt: weekLater(2, -35*time.Minute),
+			wantDelta:  2,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			options := config.AutoscalingOptions{
+				PredictiveScaleUpConfidenceThreshold: 0.8,
+				PredictiveScaleUpMaxNodes:            10,
+				PredictiveScaleUpLeadTime:            10 * time.Minute,
+			}
+			if tc.threshold > 0 {
+				options.PredictiveScaleUpConfidenceThreshold = tc.threshold
+			}
+			if tc.maxNodes > 0 {
+				options.PredictiveScaleUpMaxNodes = tc.maxNodes
+			}
+			maxSize := 10
+			if tc.maxSize > 0 {
+				maxSize = tc.maxSize
+			}
+			sizeChanges := make(map[string]int)
+			provider := testprovider.NewTestCloudProvider(func(id string, delta int) error {
+				sizeChanges[id] += delta
+				return nil
+			}, nil)
+			provider.AddNodeGroup("ng1", 0, maxSize, 1)
+			node := BuildTestNode("ng1-1", 1000, 1000)
+			SetNodeReadyState(node, true, firstScaleUp.Add(-time.Hour))
+			provider.AddNode("ng1", node)
+
+			ctx, err := NewScaleTestAutoscalingContext(options, fake.NewSimpleClientset(), nil, provider, nil, nil)
+			assert.NoError(t, err)
+			store := &Store{nodegroupcrd.NewFakeStore(historyObjects)}
+
+			forecaster := NewForecaster(&ctx, &scaleuptest.FakeOrchestrator{CapAtMaxSize: true}, store)
+			// Nodes added by week, weeks without scale-ups are 0.
+			for weeks, delta := range tc.scaleUps {
+				if delta == 0 {
+					continue
+				}
+				forecaster.RecordScaleUp(scaleUpStatus(provider, "ng1", delta), weekLater(weeks, 0))
+			}
+			// The history is taken over after a restart.
+			forecaster = NewForecaster(&ctx, &scaleuptest.FakeOrchestrator{CapAtMaxSize: true}, store)
+			forecaster.PreScale(context.Background(), nil, nil, tc.preScaleAt)
+			forecaster.PreScale(context.Background(), nil, nil, tc.preScaleAt.Add(time.Minute))
+
+			if tc.wantDelta == 0 {
+				assert.Empty(t, sizeChanges)
+				return
+			}
+			assert.Equal(t, map[string]int{"ng1": tc.wantDelta}, sizeChanges)
+			histories, err := store.List()
+			assert.NoError(t, err)
+			if assert.Len(t, histories, 1) && assert.Len(t, histories[0].Slots, 1) {
+				assert.Equal(t, 1, histories[0].Slots[0].PreScaledWeeks)
+			}
+		})
+	}
+}
+
+func TestForecasterMaxNodesAcrossNodeGroups(t *testing.T) {
+	firstScaleUp := time.Date(2025, 1, 6, 9, 30, 0, 0, time.UTC)
+	preScaleAt := firstScaleUp.Add(14*24*time.Hour - 35*time.Minute)
+	options := config.AutoscalingOptions{
+		PredictiveScaleUpConfidenceThreshold: 0.8,
+		PredictiveScaleUpMaxNodes:            5,
+		PredictiveScaleUpLeadTime:            10 * time.Minute,
+	}
+	sizeChanges := make(map[string]int)
+	provider := testprovider.NewTestCloudProvider(func(id string, delta int) error {
+		sizeChanges[id] += delta
+		return nil
+	}, nil)
+	var nodes []*apiv1.Node
+	for _, id := range []string{"ng1", "ng2"} {
+		provider.AddNodeGroup(id, 0, 10, 1)
+		node := BuildTestNode(id+"-1", 1000, 1000)
+		SetNodeReadyState(node, true, firstScaleUp.Add(-time.Hour))
+		provider.AddNode(id, node)
+		nodes = append(nodes, node)
+	}
+
+	ctx, err := NewScaleTestAutoscalingContext(options, fake.NewSimpleClientset(), nil, provider, nil, nil)
+	assert.NoError(t, err)
+	forecaster := NewForecaster(&ctx, &scaleuptest.FakeOrchestrator{CapAtMaxSize: true}, &Store{nodegroupcrd.NewFakeStore(historyObjects)})
+	for weeks := 0; weeks < 2; weeks++ {
+		scaleUpTime := firstScaleUp.Add(time.Duration(weeks) * 7 * 24 * time.Hour)
+		forecaster.RecordScaleUp(scaleUpStatus(provider, "ng1", 3), scaleUpTime)
+		forecaster.RecordScaleUp(scaleUpStatus(provider, "ng2", 3), scaleUpTime)
+	}
+	forecaster.PreScale(context.Background(), nil, nil, preScaleAt)
+	assert.Equal(t, 5, sizeChanges["ng1"]+sizeChanges["ng2"])
+
+	// Pre-scaled nodes count towards the cap until the end of their slot.
+	forecaster.PreScale(context.Background(), nil, nil, preScaleAt.Add(time.Minute))
+	assert.Equal(t, 5, sizeChanges["ng1"]+sizeChanges["ng2"])
+	assert.Len(t, forecaster.preScales, 2)
+	forecaster.PreScale(context.Background(), nil, nil, preScaleAt.Add(65*time.Minute))
+	assert.Empty(t, forecaster.preScales)
+}
+
+func TestForecasterFilterOutPreScaled(t *testing.T) {
+	firstScaleUp := time.Date(2025, 1, 6, 9, 30, 0, 0, time.UTC)
+	preScaleAt := firstScaleUp.Add(14*24*time.Hour - 35*time.Minute)
+	options := config.AutoscalingOptions{
+		PredictiveScaleUpConfidenceThreshold: 0.8,
+		PredictiveScaleUpMaxNodes:            10,
+		PredictiveScaleUpLeadTime:            10 * time.Minute,
+	}
+	provider := testprovider.NewTestCloudProvider(func(string, int) error { return nil }, nil)
+	provider.AddNodeGroup("ng1", 0, 10, 1)
+	provider.AddNodeGroup("ng2", 0, 10, 1)
+	oldNode := BuildTestNode("ng1-1", 1000, 1000)
+	oldNode.CreationTimestamp = metav1.NewTime(firstScaleUp.Add(-time.Hour))
+	provider.AddNode("ng1", oldNode)
+	preScaledNode := BuildTestNode("ng1-2", 1000, 1000)
+	preScaledNode.CreationTimestamp = metav1.NewTime(preScaleAt.Add(5 * time.Minute))
+	provider.AddNode("ng1", preScaledNode)
+	otherNode := BuildTestNode("ng2-1", 1000, 1000)
+	otherNode.CreationTimestamp = metav1.NewTime(preScaleAt.Add(5 * time.Minute))
+	provider.AddNode("ng2", otherNode)
+	candidates := []*apiv1.Node{oldNode, preScaledNode, otherNode}
+
+	ctx, err := NewScaleTestAutoscalingContext(options, fake.NewSimpleClientset(), nil, provider, nil, nil)
+	assert.NoError(t, err)
+	forecaster := NewForecaster(&ctx, &scaleuptest.FakeOrchestrator{CapAtMaxSize: true}, &Store{nodegroupcrd.NewFakeStore(historyObjects)})
+	assert.Equal(t, candidates, forecaster.FilterOutPreScaled(candidates, preScaleAt))
+
+	for weeks := 0; weeks < 2; weeks++ {
+		forecaster.RecordScaleUp(scaleUpStatus(provider, "ng1", 3), firstScaleUp.Add(time.Duration(weeks)*7*24*time.Hour))
+	}
+	forecaster.PreScale(context.Background(), nil, nil, preScaleAt)
+
+	// Nodes added since the pre-scale are protected until the end of its slot.
+	assert.Equal(t, []*apiv1.Node{oldNode, otherNode}, forecaster.FilterOutPreScaled(candidates, preScaleAt.Add(time.Hour)))
+	assert.Equal(t, candidates, forecaster.FilterOutPreScaled(candidates, preScaleAt.Add(65*time.Minute)))
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaleupforecast
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/nodegroupcrd"
+	"k8s.io/client-go/rest"
+)
+
+// HistoryKind is the kind of the ScaleUpHistory CRD.
+const HistoryKind = "ScaleUpHistory"
+
+// HistoryResource is the resource of the ScaleUpHistory CRD.
+var HistoryResource = schema.GroupVersionResource{
+	Group:    "autoscaling.x-k8s.io",
+	Version:  "v1alpha1",
+	Resource: "scaleuphistories",
+}
+
+// History is the spec of a ScaleUpHistory object, the scale-ups of a node group
+// by hour of the week.
+type History struct {
+	// NodeGroup is the id of the node group.
+	NodeGroup string `json:"nodeGroup"`
+	// Since is the time the history of the node group started at.
+	Since metav1.Time `json:"since"`
+	// Slots are the hours of the week the node group was scaled up or pre-scaled in,
+	// sorted by hour.
+	Slots []Slot `json:"slots,omitempty"`
+}
+
+// Slot is the history of a node group in an hour of the week.
+type Slot struct {
+	// Hour is the hour of the week, in UTC, counted from Sunday midnight.
+	Hour int `json:"hour"`
+	// ScaleUpWeeks is the number of weeks the node group was scaled up in this hour.
+	ScaleUpWeeks int `json:"scaleUpWeeks"`
+	// PreScaledWeeks is the number of weeks the node group was pre-scaled for this
+	// hour and wasn't scaled up in it. They aren't taken into account in predictions.
+	PreScaledWeeks int `json:"preScaledWeeks"`
+	// Nodes is the total number of nodes added in this hour over ScaleUpWeeks.
+	Nodes int `json:"nodes"`
+	// LastWeek is the last week a scale-up or a pre-scale was counted in, in weeks
+	// since the Unix epoch.
+	LastWeek int64 `json:"lastWeek"`
+	// LastWeekPreScaled tells if LastWeek was counted as a pre-scaled week.
+	LastWeekPreScaled bool `json:"lastWeekPreScaled,omitempty"`
+}
+
+// Store persists the scale-up history of node groups as ScaleUpHistory objects, one per node group.
+type Store struct {
+	*nodegroupcrd.Store[History]
+}
+
+var historyObjects = nodegroupcrd.Objects[History]{
+	Resource:   HistoryResource,
+	Kind:       HistoryKind,
+	ListKind:   "ScaleUpHistoryList",
+	NamePrefix: "scale-up-history",
+	NodeGroup:  func(spec History) string { return spec.NodeGroup },
+}
+
+// NewStore returns a Store keeping ScaleUpHistory objects in the given namespace.
+func NewStore(kubeConfig *rest.Config, namespace string) (*Store, error) {
+	store, err := nodegroupcrd.NewStore(kubeConfig, namespace, historyObjects)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{store}, nil
+}
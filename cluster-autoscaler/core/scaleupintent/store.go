@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaleupintent
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/nodegroupcrd"
+	"k8s.io/client-go/rest"
+)
+
+// IntentKind is the kind of the ScaleUpIntent CRD.
+const IntentKind = "ScaleUpIntent"
+
+// IntentResource is the resource of the ScaleUpIntent CRD.
+var IntentResource = schema.GroupVersionResource{
+	Group:    "autoscaling.x-k8s.io",
+	Version:  "v1alpha1",
+	Resource: "scaleupintents",
+}
+
+// Intent is the spec of a ScaleUpIntent object, a scale-up of a node group that
+// hasn't finished yet.
+type Intent struct {
+	// NodeGroup is the id of the node group being scaled up.
+	NodeGroup string `json:"nodeGroup"`
+	// Delta is the number of nodes added to the node group.
+	Delta int `json:"delta"`
+	// Time is the time the scale-up started at.
+	Time metav1.Time `json:"time"`
+	// Deadline is the time the nodes are expected to register by, after which
+	// the scale-up times out.
+	Deadline metav1.Time `json:"deadline"`
+}
+
+func (i Intent) equal(other Intent) bool {
+	return i.NodeGroup == other.NodeGroup && i.Delta == other.Delta &&
+		i.Time.Equal(&other.Time) && i.Deadline.Equal(&other.Deadline)
+}
+
+// Store persists scale-up intents as ScaleUpIntent objects, one per node group.
+type Store struct {
+	*nodegroupcrd.Store[Intent]
+}
+
+var intentObjects = nodegroupcrd.Objects[Intent]{
+	Resource:   IntentResource,
+	Kind:       IntentKind,
+	ListKind:   "ScaleUpIntentList",
+	NamePrefix: "scale-up",
+	NodeGroup:  func(spec Intent) string { return spec.NodeGroup },
+}
+
+// NewStore returns a Store keeping ScaleUpIntent objects in the given namespace.
+func NewStore(kubeConfig *rest.Config, namespace string) (*Store, error) {
+	store, err := nodegroupcrd.NewStore(kubeConfig, namespace, intentObjects)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{store}, nil
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaleupintent
+
+import (
+	"fmt"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/metrics"
+	"k8s.io/autoscaler/cluster-autoscaler/observers/nodegroupchange"
+	klog "k8s.io/klog/v2"
+)
+
+// Tracker persists the scale-ups tracked by the ClusterStateRegistry as ScaleUpIntent objects, so that
+// they survive a restart of CA. Without them, a restarted CA doesn't know which node groups are being
+// scaled up: their scale-ups never time out and their nodes are only considered missing once they
+// don't show up for another max node provision time.
+//
+// Tracker is a NodeGroupChangeObserver, it has to be registered after the ClusterStateRegistry so that
+// intents are written right after a scale-up, and not only at the end of the loop.
+type Tracker struct {
+	context              *context.AutoscalingContext
+	clusterStateRegistry *clusterstate.ClusterStateRegistry
+	scaleStateNotifier   *nodegroupchange.NodeGroupChangeObserversList
+	store                *Store
+	// written are the intents persisted by this instance, by node group id.
+	written map[string]Intent
+	// resumed are the ids of the node groups whose scale-up was started by the previous instance.
+	resumed map[string]bool
+	// resumeDone is set once the intents of the previous instance were taken over.
+	resumeDone bool
+}
+
+// NewTracker creates a new Tracker.
+func NewTracker(context *context.AutoscalingContext, clusterStateRegistry *clusterstate.ClusterStateRegistry,
+	scaleStateNotifier *nodegroupchange.NodeGroupChangeObserversList, store *Store) *Tracker {
+	return &Tracker{
+		context:              context,
+		clusterStateRegistry: clusterStateRegistry,
+		scaleStateNotifier:   scaleStateNotifier,
+		store:                store,
+		written:              make(map[string]Intent),
+		resumed:              make(map[string]bool),
+	}
+}
+
+// Resume takes over the intents persisted by the previous instance. It has to be called after the
+// cluster state is updated. Scale-ups that are still within their deadline are registered with their
+// original time, so that their nodes count as upcoming and they time out as if CA didn't restart.
+// Scale-ups past their deadline are rolled back right away. Intents are taken over once, listing
+// them is retried until it succeeds.
+func (t *Tracker) Resume(currentTime time.Time) {
+	if t.resumeDone {
+		return
+	}
+	intents, err := t.store.List()
+	if err != nil {
+		klog.Errorf("Failed to list scale-up intents, not resuming scale-ups: %v", err)
+		return
+	}
+	t.resumeDone = true
+
+	nodeGroups := make(map[string]cloudprovider.NodeGroup)
+	for _, nodeGroup := range t.context.CloudProvider.NodeGroups() {
+		nodeGroups[nodeGroup.Id()] = nodeGroup
+	}
+	requests := t.clusterStateRegistry.GetScaleUpRequests()
+	for _, intent := range intents {
+		nodeGroup, found := nodeGroups[intent.NodeGroup]
+		if !found || intent.Delta <= 0 {
+			klog.V(1).Infof("Dropping scale-up intent of %d nodes for node group %s", intent.Delta, intent.NodeGroup)
+			t.delete(intent.NodeGroup)
+			continue
+		}
+		if _, found := requests[intent.NodeGroup]; found {
+			// Already tracked, the intent is updated by the next Sync.
+			t.written[intent.NodeGroup] = intent
+			continue
+		}
+		if !intent.Deadline.After(currentTime) {
+			errMsg := fmt.Sprintf("Scale-up of node group %s by %d nodes started at %v timed out at %v", intent.NodeGroup, intent.Delta, intent.Time.Time, intent.Deadline.Time)
+			klog.Warning(errMsg)
+			t.scaleStateNotifier.RegisterFailedScaleUp(nodeGroup, string(metrics.Timeout), errMsg, "", "", currentTime)
+			t.rollBack(nodeGroup, intent)
+			t.delete(intent.NodeGroup)
+			continue
+		}
+		klog.V(0).Infof("Resuming scale-up of node group %s by %d nodes started at %v", intent.NodeGroup, intent.Delta, intent.Time.Time)
+		t.written[intent.NodeGroup] = intent
+		t.resumed[intent.NodeGroup] = true
+		t.scaleStateNotifier.RegisterScaleUp(nodeGroup, intent.Delta, intent.Time.Time)
+	}
+}
+
+// Sync makes the persisted intents match the scale-ups tracked by the ClusterStateRegistry. Intents of
+// finished scale-ups are removed. Scale-ups started by the previous instance which timed out are rolled
+// back, the node group size fix-up can't be relied on for them as it only starts counting at restart.
+func (t *Tracker) Sync(currentTime time.Time) {
+	requests := t.clusterStateRegistry.GetScaleUpRequests()
+	for nodeGroupId, intent := range t.written {
+		if _, found := requests[nodeGroupId]; found {
+			continue
+		}
+		if t.resumed[nodeGroupId] && !intent.Deadline.After(currentTime) {
+			if nodeGroup := t.nodeGroup(nodeGroupId); nodeGroup != nil {
+				t.rollBack(nodeGroup, intent)
+			}
+		}
+		delete(t.resumed, nodeGroupId)
+		t.delete(nodeGroupId)
+	}
+	for nodeGroupId, request := range requests {
+		t.write(nodeGroupId, request)
+	}
+}
+
+// rollBack decreases the target size of a node group by the nodes of the intent which didn't show up.
+func (t *Tracker) rollBack(nodeGroup cloudprovider.NodeGroup, intent Intent) {
+	incorrectSize := t.clusterStateRegistry.GetIncorrectNodeGroupSize(nodeGroup.Id())
+	if incorrectSize == nil || incorrectSize.CurrentSize >= incorrectSize.ExpectedSize {
+		return
+	}
+	missing := incorrectSize.ExpectedSize - incorrectSize.CurrentSize
+	if missing > intent.Delta {
+		missing = intent.Delta
+	}
+	klog.Warningf("Rolling back scale-up of node group %s started at %v: decreasing target size by %d", nodeGroup.Id(), intent.Time.Time, missing)
+	if err := nodeGroup.DecreaseTargetSize(-missing); err != nil {
+		klog.Errorf("Failed to roll back scale-up of node group %s: %v", nodeGroup.Id(), err)
+		return
+	}
+	t.context.LogRecorder.Eventf(apiv1.EventTypeWarning, "ScaleUpRolledBack",
+		"Scale-up of %s by %d nodes started at %v timed out, decreased target size by %d", nodeGroup.Id(), intent.Delta, intent.Time.Time, missing)
+}
+
+func (t *Tracker) nodeGroup(nodeGroupId string) cloudprovider.NodeGroup {
+	for _, nodeGroup := range t.context.CloudProvider.NodeGroups() {
+		if nodeGroup.Id() == nodeGroupId {
+			return nodeGroup
+		}
+	}
+	return nil
+}
+
+func (t *Tracker) write(nodeGroupId string, request clusterstate.ScaleUpRequest) {
+	intent := Intent{
+		NodeGroup: nodeGroupId,
+		Delta:     request.Increase,
+		Time:      metav1.NewTime(request.Time),
+		Deadline:  metav1.NewTime(request.ExpectedAddTime),
+	}
+	if written, found := t.written[nodeGroupId]; found && written.equal(intent) {
+		return
+	}
+	if err := t.store.Write(intent); err != nil {
+		klog.Errorf("Failed to persist scale-up intent: %v", err)
+		return
+	}
+	t.written[nodeGroupId] = intent
+}
+
+func (t *Tracker) delete(nodeGroupId string) {
+	if err := t.store.Delete(nodeGroupId); err != nil {
+		klog.Errorf("Failed to remove scale-up intent: %v", err)
+		return
+	}
+	delete(t.written, nodeGroupId)
+}
+
+// RegisterScaleUp persists the intent of the scale-up right away, so that it isn't lost if CA
+// crashes before the end of the loop.
+func (t *Tracker) RegisterScaleUp(nodeGroup cloudprovider.NodeGroup, _ int, _ time.Time) {
+	if request, found := t.clusterStateRegistry.GetScaleUpRequests()[nodeGroup.Id()]; found {
+		t.write(nodeGroup.Id(), request)
+	}
+}
+
+// RegisterScaleDown is a no-op.
+func (t *Tracker) RegisterScaleDown(_ cloudprovider.NodeGroup, _ string, _ time.Time, _ time.Time) {
+}
+
+// RegisterFailedScaleUp is a no-op, intents of failed scale-ups are removed by Sync.
+func (t *Tracker) RegisterFailedScaleUp(_ cloudprovider.NodeGroup, _ string, _ string, _, _ string, _ time.Time) {
+}
+
+// RegisterFailedScaleDown is a no-op.
+func (t *Tracker) RegisterFailedScaleDown(_ cloudprovider.NodeGroup, _ string, _ time.Time) {
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaleupintent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	. "k8s.io/autoscaler/cluster-autoscaler/core/test"
+	"k8s.io/autoscaler/cluster-autoscaler/observers/nodegroupchange"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroups/asyncnodegroups"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/nodegroupcrd"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestStore(t *testing.T) {
+	store := &Store{nodegroupcrd.NewFakeStore(intentObjects)}
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	intent := Intent{NodeGroup: "https://example.com/ng1", Delta: 2, Time: metav1.NewTime(now), Deadline: metav1.NewTime(now.Add(15 * time.Minute))}
+
+	assert.NoError(t, store.Write(intent))
+	intent.Delta = 3
+	assert.NoError(t, store.Write(intent))
+	intents, err := store.List()
+	assert.NoError(t, err)
+	if assert.Len(t, intents, 1) {
+		assert.True(t, intent.equal(intents[0]))
+	}
+
+	assert.NoError(t, store.Delete(intent.NodeGroup))
+	assert.NoError(t, store.Delete(intent.NodeGroup))
+	intents, err = store.List()
+	assert.NoError(t, err)
+	assert.Empty(t, intents)
+}
+
+func TestTracker(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	maxNodeProvisionTime := 15 * time.Minute
+	sizeChanges := make(map[string]int)
+	provider := testprovider.NewTestCloudProvider(func(id string, delta int) error {
+		sizeChanges[id] += delta
+		return nil
+	}, nil)
+	// ng1 was scaled up by 2 and ng2 by 3 before the restart, none of their new nodes registered.
+	provider.AddNodeGroup("ng1", 0, 10, 3)
+	provider.AddNodeGroup("ng2", 0, 10, 5)
+	provider.AddNodeGroup("ng3", 0, 10, 1)
+	var nodes []*apiv1.Node
+	for _, n := range []struct{ name, nodeGroup string }{{"ng1-1", "ng1"}, {"ng2-1", "ng2"}, {"ng2-2", "ng2"}, {"ng3-1", "ng3"}} {
+		node := BuildTestNode(n.name, 1000, 1000)
+		SetNodeReadyState(node, true, now.Add(-time.Hour))
+		provider.AddNode(n.nodeGroup, node)
+		nodes = append(nodes, node)
+	}
+
+	ctx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{}, fake.NewSimpleClientset(), nil, provider, nil, nil)
+	assert.NoError(t, err)
+	csr := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, ctx.LogRecorder, NewBackoff(),
+		nodegroupconfig.NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{MaxNodeProvisionTime: maxNodeProvisionTime}),
+		asyncnodegroups.NewDefaultAsyncNodeGroupStateChecker())
+	notifier := nodegroupchange.NewNodeGroupChangeObserversList()
+	notifier.Register(csr)
+	store := &Store{nodegroupcrd.NewFakeStore(intentObjects)}
+	tracker := NewTracker(&ctx, csr, notifier, store)
+	notifier.Register(tracker)
+
+	started := now.Add(-10 * time.Minute)
+	for _, intent := range []Intent{
+		{NodeGroup: "ng1", Delta: 2, Time: metav1.NewTime(started), Deadline: metav1.NewTime(started.Add(maxNodeProvisionTime))},
+		{NodeGroup: "ng2", Delta: 3, Time: metav1.NewTime(now.Add(-time.Hour)), Deadline: metav1.NewTime(now.Add(-time.Minute))},
+		{NodeGroup: "unknown", Delta: 1, Time: metav1.NewTime(started), Deadline: metav1.NewTime(started.Add(maxNodeProvisionTime))},
+	} {
+		assert.NoError(t, store.Write(intent))
+	}
+
+	assert.NoError(t, csr.UpdateNodes(nodes, nil, now))
+	tracker.Resume(now)
+	tracker.Sync(now)
+
+	// Scale-up within its deadline is resumed with its original time.
+	requests := csr.GetScaleUpRequests()
+	assert.Len(t, requests, 1)
+	if assert.Contains(t, requests, "ng1") {
+		assert.Equal(t, 2, requests["ng1"].Increase)
+		assert.True(t, started.Equal(requests["ng1"].Time))
+	}
+	// Timed out scale-up is rolled back and backed off.
+	assert.Equal(t, map[string]int{"ng2": -3}, sizeChanges)
+	assert.True(t, csr.BackoffStatusForNodeGroup(provider.GetNodeGroup("ng2"), now).IsBackedOff)
+	intents, err := store.List()
+	assert.NoError(t, err)
+	if assert.Len(t, intents, 1) {
+		assert.Equal(t, "ng1", intents[0].NodeGroup)
+	}
+
+	// New scale-ups are persisted right away.
+	assert.NoError(t, provider.GetNodeGroup("ng3").IncreaseSize(1))
+	notifier.RegisterScaleUp(provider.GetNodeGroup("ng3"), 1, now)
+	intents, err = store.List()
+	assert.NoError(t, err)
+	assert.Len(t, intents, 2)
+
+	// Resumed scale-up times out and is rolled back, the other one finishes.
+	later := started.Add(maxNodeProvisionTime + time.Minute)
+	ng3Node := BuildTestNode("ng3-2", 1000, 1000)
+	SetNodeReadyState(ng3Node, true, later)
+	provider.AddNode("ng3", ng3Node)
+	assert.NoError(t, csr.UpdateNodes(append(nodes, ng3Node), nil, later))
+	tracker.Sync(later)
+	assert.Equal(t, map[string]int{"ng1": -2, "ng2": -3, "ng3": 1}, sizeChanges)
+	intents, err = store.List()
+	assert.NoError(t, err)
+	assert.Empty(t, intents)
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalingschedule
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronField is a field of a cron expression, with the range of its values.
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	// 7 is accepted as Sunday, like 0.
+	{"day of week", 0, 7},
+}
+
+// cronSchedule is a parsed cron expression with the 5 standard fields: minute, hour, day of month, month and
+// day of week. Each field is a set of values, bit i is set if value i matches.
+type cronSchedule struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+	// dayOfMonthAny and dayOfWeekAny tell if the fields are "*". If both are restricted, a day matching either
+	// of them matches, like in cron.
+	dayOfMonthAny, dayOfWeekAny bool
+}
+
+// parseCron parses a cron expression with 5 fields separated by spaces. Each field is "*" or a comma separated list
+// of values and ranges ("1-5"), optionally followed by a step ("*/15", "8-18/2").
+func parseCron(expression string) (*cronSchedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("cron expression %q has %d fields, expected %d", expression, len(fields), len(cronFields))
+	}
+	var values [5]uint64
+	for i, field := range fields {
+		v, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %v", expression, err)
+		}
+		values[i] = v
+	}
+	// Sunday is 0, 7 is only accepted for convenience.
+	if values[4]&(1<<7) != 0 {
+		values[4] = values[4]&^(1<<7) | 1
+	}
+	return &cronSchedule{
+		minute:        values[0],
+		hour:          values[1],
+		dayOfMonth:    values[2],
+		month:         values[3],
+		dayOfWeek:     values[4],
+		dayOfMonthAny: fields[2] == "*",
+		dayOfWeekAny:  fields[4] == "*",
+	}, nil
+}
+
+func parseCronField(field string, f cronField) (uint64, error) {
+	var values uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			rangePart = part[:i]
+			s, err := strconv.Atoi(part[i+1:])
+			if err != nil || s <= 0 {
+				return 0, fmt.Errorf("invalid step in %s %q", f.name, part)
+			}
+			step = s
+		}
+		first, last := f.min, f.max
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if first, err = parseCronValue(bounds[0], f); err != nil {
+				return 0, err
+			}
+			last = first
+			if len(bounds) == 2 {
+				if last, err = parseCronValue(bounds[1], f); err != nil {
+					return 0, err
+				}
+			} else if step > 1 {
+				// "5/15" means every 15 starting at 5.
+				last = f.max
+			}
+			if first > last {
+				return 0, fmt.Errorf("invalid range in %s %q", f.name, part)
+			}
+		}
+		for v := first; v <= last; v += step {
+			values |= 1 << uint(v)
+		}
+	}
+	return values, nil
+}
+
+func parseCronValue(value string, f cronField) (int, error) {
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", f.name, value)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("%s %d out of range [%d, %d]", f.name, v, f.min, f.max)
+	}
+	return v, nil
+}
+
+// matches tells if the schedule fires at the minute of t, in the location of t.
+func (c *cronSchedule) matches(t time.Time) bool {
+	if c.minute&(1<<uint(t.Minute())) == 0 || c.hour&(1<<uint(t.Hour())) == 0 {
+		return false
+	}
+	return c.matchesDay(t.Year(), t.Month(), t.Day(), t.Weekday())
+}
+
+// matchesDay tells if the schedule fires on the given day.
+func (c *cronSchedule) matchesDay(year int, month time.Month, day int, weekday time.Weekday) bool {
+	if c.month&(1<<uint(month)) == 0 {
+		return false
+	}
+	dayOfMonth := c.dayOfMonth&(1<<uint(day)) != 0
+	dayOfWeek := c.dayOfWeek&(1<<uint(weekday)) != 0
+	if c.dayOfMonthAny || c.dayOfWeekAny {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+// lastFiringWithin returns the last time, not after now, the schedule fired at, if it fired less than the given
+// duration ago. It goes back day by day, and only checks the hours and minutes the schedule fires at on the days
+// it fires, so the cost doesn't depend on the number of minutes in duration.
+func (c *cronSchedule) lastFiringWithin(now time.Time, duration time.Duration) (time.Time, bool) {
+	earliest := now.Add(-duration)
+	loc := now.Location()
+	year, month, day := now.Date()
+	hourLimit, minuteLimit := now.Hour(), now.Minute()
+	for {
+		date := time.Date(year, month, day, 0, 0, 0, 0, loc)
+		if !time.Date(year, month, day+1, 0, 0, 0, 0, loc).After(earliest) {
+			return time.Time{}, false
+		}
+		if c.matchesDay(year, month, day, date.Weekday()) {
+			for hour := highestAtMost(c.hour, hourLimit); hour >= 0; hour = highestAtMost(c.hour, hour-1) {
+				if hour < hourLimit {
+					minuteLimit = 59
+				}
+				for minute := highestAtMost(c.minute, minuteLimit); minute >= 0; minute = highestAtMost(c.minute, minute-1) {
+					firing := time.Date(year, month, day, hour, minute, 0, 0, loc)
+					if firing.Hour() != hour || firing.Minute() != minute || firing.After(now) {
+						// The time doesn't exist, or is repeated, because of a daylight saving time change.
+						continue
+					}
+					if !firing.After(earliest) {
+						return time.Time{}, false
+					}
+					return firing, true
+				}
+			}
+		}
+		year, month, day = time.Date(year, month, day-1, 0, 0, 0, 0, loc).Date()
+		hourLimit, minuteLimit = 23, 59
+	}
+}
+
+// highestAtMost returns the highest value in the set not greater than limit, -1 if there is none.
+func highestAtMost(values uint64, limit int) int {
+	if limit < 0 {
+		return -1
+	}
+	return bits.Len64(values&(uint64(2)<<uint(limit)-1)) - 1
+}
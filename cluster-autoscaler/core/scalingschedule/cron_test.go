@@ -0,0 +1,137 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalingschedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCron(t *testing.T) {
+	for _, expression := range []string{
+		"* * * * *",
+		"0 8 * * 1-5",
+		"*/15 8-18/2 1,15 1-12 0,7",
+		"5/10 * * * *",
+	} {
+		_, err := parseCron(expression)
+		assert.NoError(t, err, expression)
+	}
+	for _, expression := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"MON * * * *",
+	} {
+		_, err := parseCron(expression)
+		assert.Error(t, err, expression)
+	}
+}
+
+func TestCronMatches(t *testing.T) {
+	// Monday.
+	monday := time.Date(2025, time.March, 3, 8, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		expression string
+		time       time.Time
+		matches    bool
+	}{
+		{"0 8 * * 1-5", monday, true},
+		{"0 8 * * 1-5", monday.Add(time.Minute), false},
+		{"0 8 * * 1-5", monday.AddDate(0, 0, 5), false},
+		{"*/15 * * * *", monday.Add(45 * time.Minute), true},
+		{"5/10 * * * *", monday.Add(25 * time.Minute), true},
+		{"5/10 * * * *", monday.Add(20 * time.Minute), false},
+		{"0 8 * * 0", monday.AddDate(0, 0, 6), true},
+		{"0 8 * * 7", monday.AddDate(0, 0, 6), true},
+		// Restricted days of month and week match either.
+		{"0 8 15 * 1", monday, true},
+		{"0 8 3 * 0", monday, true},
+		{"0 8 15 * 0", monday, false},
+		// A restricted day of month and any day of week match the day of month only.
+		{"0 8 15 * *", monday, false},
+		{"0 8 * 4 *", monday, false},
+	} {
+		schedule, err := parseCron(tc.expression)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.matches, schedule.matches(tc.time), "%s at %v", tc.expression, tc.time)
+	}
+}
+
+func TestCronLastFiringWithin(t *testing.T) {
+	schedule, err := parseCron("0 8 * * 1-5")
+	assert.NoError(t, err)
+	monday := time.Date(2025, time.March, 3, 8, 0, 0, 0, time.UTC)
+
+	last, found := schedule.lastFiringWithin(monday.Add(9*time.Hour+59*time.Minute+30*time.Second), 10*time.Hour)
+	assert.True(t, found)
+	assert.Equal(t, monday, last)
+
+	_, found = schedule.lastFiringWithin(monday.Add(10*time.Hour), 10*time.Hour)
+	assert.False(t, found)
+
+	_, found = schedule.lastFiringWithin(monday.Add(-time.Minute), 10*time.Hour)
+	assert.False(t, found)
+}
+
+func TestCronLastFiringWithinLongDurations(t *testing.T) {
+	monday := time.Date(2025, time.March, 3, 8, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		expression string
+		now        time.Time
+		duration   time.Duration
+		wantLast   time.Time
+		wantFound  bool
+	}{
+		{"* * * * *", monday.Add(30 * time.Second), time.Minute, monday, true},
+		{"*/15 * * * *", monday.Add(14 * time.Minute), 10 * time.Minute, time.Time{}, false},
+		{"30 7 * * *", monday, time.Hour, monday.Add(-30 * time.Minute), true},
+		{"0 8 1 * *", monday, 40 * 24 * time.Hour, time.Date(2025, time.March, 1, 8, 0, 0, 0, time.UTC), true},
+		{"0 0 29 2 *", monday, 400 * 24 * time.Hour, time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC), true},
+		{"0 0 29 2 *", monday, 300 * 24 * time.Hour, time.Time{}, false},
+		{"59 23 31 12 *", monday, 365 * 24 * time.Hour, time.Date(2024, time.December, 31, 23, 59, 0, 0, time.UTC), true},
+	} {
+		schedule, err := parseCron(tc.expression)
+		assert.NoError(t, err)
+		last, found := schedule.lastFiringWithin(tc.now, tc.duration)
+		assert.Equal(t, tc.wantFound, found, tc.expression)
+		assert.Equal(t, tc.wantLast, last, tc.expression)
+	}
+}
+
+func TestCronLastFiringWithinDaylightSavingTime(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+	// On 2025-03-30, clocks go from 2:00 to 3:00, so 2:30 doesn't exist.
+	schedule, err := parseCron("30 2 * * *")
+	assert.NoError(t, err)
+	last, found := schedule.lastFiringWithin(time.Date(2025, time.March, 30, 12, 0, 0, 0, loc), 48*time.Hour)
+	assert.True(t, found)
+	assert.Equal(t, time.Date(2025, time.March, 29, 2, 30, 0, 0, loc), last)
+}
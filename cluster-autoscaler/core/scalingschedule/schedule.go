@@ -0,0 +1,280 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalingschedule
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+	// Time zones of windows are loaded from the embedded database, as images may not have one.
+	_ "time/tzdata"
+
+	"gopkg.in/yaml.v2"
+
+	apiv1 "k8s.io/api/core/v1"
+	kube_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	v1lister "k8s.io/client-go/listers/core/v1"
+	klog "k8s.io/klog/v2"
+)
+
+const (
+	// ConfigMapKey is the key of the ConfigMap holding the scaling windows.
+	ConfigMapKey = "windows"
+	// maxWindowDuration is the maximum duration of a window, windows repeating less often than weekly aren't
+	// supported.
+	maxWindowDuration = 7 * 24 * time.Hour
+)
+
+// windowConfig is a scaling window, as configured in the ConfigMap.
+type windowConfig struct {
+	Name       string   `yaml:"name"`
+	NodeGroups []string `yaml:"nodeGroups"`
+	Schedule   string   `yaml:"schedule"`
+	Duration   string   `yaml:"duration"`
+	TimeZone   string   `yaml:"timeZone"`
+	MinSize    *int     `yaml:"minSize"`
+	MaxSize    *int     `yaml:"maxSize"`
+}
+
+// window sets the min and/or max sizes of the node groups matching its regular expressions, for the given duration
+// each time its cron schedule fires.
+type window struct {
+	name     string
+	regexps  []*regexp.Regexp
+	schedule *cronSchedule
+	duration time.Duration
+	location *time.Location
+	minSize  *int
+	maxSize  *int
+}
+
+// limits are the sizes a window set for a node group, or failed to.
+type limits struct {
+	window           string
+	minSize, maxSize int
+	rejected         bool
+}
+
+// Schedule overrides the min and max sizes of node groups while scheduled scaling windows are active, e.g. to keep
+// a minimum of 10 nodes during business hours and of 2 at night. Windows are configured in a ConfigMap, and applied
+// in every loop to node groups implementing cloudprovider.SizeLimitsOverridingNodeGroup. If several windows match
+// a node group, the first active one in the ConfigMap is applied. Node group limits changed by windows are reported
+// with events on the ConfigMap.
+type Schedule struct {
+	configMapName   string
+	configMapLister v1lister.ConfigMapNamespaceLister
+	// configMap is the last ConfigMap read, events are recorded on it.
+	configMap *apiv1.ConfigMap
+	// resourceVersion is the version of the last ConfigMap parsed.
+	resourceVersion string
+	windows         []window
+	// applied are the limits set by windows, by node group id.
+	applied map[string]limits
+}
+
+// NewSchedule returns a Schedule reading scaling windows from the ConfigMap with the given name.
+func NewSchedule(configMapLister v1lister.ConfigMapNamespaceLister, configMapName string) *Schedule {
+	return &Schedule{
+		configMapName:   configMapName,
+		configMapLister: configMapLister,
+		applied:         make(map[string]limits),
+	}
+}
+
+// Apply reloads the windows if the ConfigMap changed and overrides the limits of node groups with the windows
+// active at the given time. It has to be called after the cloud provider is refreshed. An invalid ConfigMap is
+// reported and ignored, the last valid windows are kept.
+func (s *Schedule) Apply(ctx *context.AutoscalingContext, now time.Time) {
+	s.reloadConfigMap(ctx)
+	seen := make(map[string]bool)
+	for _, nodeGroup := range ctx.CloudProvider.NodeGroups() {
+		overriding, ok := nodeGroup.(cloudprovider.SizeLimitsOverridingNodeGroup)
+		if !ok || !nodeGroup.Exist() {
+			continue
+		}
+		id := nodeGroup.Id()
+		seen[id] = true
+		overriding.ResetSizeLimits()
+		previous, wasApplied := s.applied[id]
+
+		w := s.activeWindow(id, now)
+		if w == nil {
+			if wasApplied {
+				delete(s.applied, id)
+				s.eventf(ctx, apiv1.EventTypeNormal, "ScalingWindowEnded",
+					"Scaling window %s ended, limits of node group %s reset to %d-%d", previous.window, id, nodeGroup.MinSize(), nodeGroup.MaxSize())
+			}
+			continue
+		}
+
+		current := limits{window: w.name, minSize: nodeGroup.MinSize(), maxSize: nodeGroup.MaxSize()}
+		if w.minSize != nil {
+			current.minSize = *w.minSize
+		}
+		if w.maxSize != nil {
+			current.maxSize = *w.maxSize
+		}
+		err := overriding.OverrideSizeLimits(current.minSize, current.maxSize)
+		current.rejected = err != nil
+		s.applied[id] = current
+		if wasApplied && previous == current {
+			continue
+		}
+		if err != nil {
+			klog.Warningf("Failed to apply limits of scaling window %s to node group %s: %v", w.name, id, err)
+			s.eventf(ctx, apiv1.EventTypeWarning, "ScalingWindowRejected",
+				"Limits %d-%d of scaling window %s can't be applied to node group %s: %v", current.minSize, current.maxSize, w.name, id, err)
+			continue
+		}
+		klog.V(1).Infof("Scaling window %s set limits of node group %s to %d-%d", w.name, id, current.minSize, current.maxSize)
+		s.eventf(ctx, apiv1.EventTypeNormal, "ScalingWindowChangedLimits",
+			"Scaling window %s set limits of node group %s to %d-%d", w.name, id, current.minSize, current.maxSize)
+	}
+	for id := range s.applied {
+		if !seen[id] {
+			delete(s.applied, id)
+		}
+	}
+}
+
+// eventf records an event on the ConfigMap, or on the status ConfigMap if the ConfigMap was deleted.
+func (s *Schedule) eventf(ctx *context.AutoscalingContext, eventType, reason, messageFmt string, args ...interface{}) {
+	if s.configMap != nil {
+		ctx.Recorder.Eventf(s.configMap, eventType, reason, messageFmt, args...)
+		return
+	}
+	ctx.LogRecorder.Eventf(eventType, reason, messageFmt, args...)
+}
+
+func (s *Schedule) activeWindow(nodeGroupId string, now time.Time) *window {
+	for i := range s.windows {
+		w := &s.windows[i]
+		if !w.matches(nodeGroupId) {
+			continue
+		}
+		if _, active := w.schedule.lastFiringWithin(now.In(w.location), w.duration); active {
+			return w
+		}
+	}
+	return nil
+}
+
+func (w *window) matches(nodeGroupId string) bool {
+	for _, re := range w.regexps {
+		if re.MatchString(nodeGroupId) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Schedule) reloadConfigMap(ctx *context.AutoscalingContext) {
+	cm, err := s.configMapLister.Get(s.configMapName)
+	if kube_errors.IsNotFound(err) {
+		if s.windows != nil {
+			klog.Warningf("Scaling schedule config map %s not found, ignoring scaling windows", s.configMapName)
+		}
+		s.configMap = nil
+		s.windows = nil
+		s.resourceVersion = ""
+		return
+	}
+	if err != nil {
+		klog.Warningf("Failed to get scaling schedule config map %s: %v", s.configMapName, err)
+		return
+	}
+	s.configMap = cm
+	if cm.ResourceVersion == s.resourceVersion {
+		return
+	}
+	s.resourceVersion = cm.ResourceVersion
+	windows, err := parseWindows(cm.Data[ConfigMapKey])
+	if err != nil {
+		msg := fmt.Sprintf("Wrong configuration of scaling windows in config map %s: %v. Ignoring update.", s.configMapName, err)
+		klog.Warning(msg)
+		ctx.Recorder.Event(cm, apiv1.EventTypeWarning, "ScalingScheduleConfigMapInvalid", msg)
+		return
+	}
+	klog.V(1).Infof("Loaded %d scaling windows from config map %s", len(windows), s.configMapName)
+	s.windows = windows
+}
+
+func parseWindows(windowsYAML string) ([]window, error) {
+	if windowsYAML == "" {
+		return nil, fmt.Errorf("%s key is missing or empty", ConfigMapKey)
+	}
+	var configs []windowConfig
+	if err := yaml.UnmarshalStrict([]byte(windowsYAML), &configs); err != nil {
+		return nil, fmt.Errorf("can't parse YAML with windows: %v", err)
+	}
+	windows := make([]window, 0, len(configs))
+	names := make(map[string]bool)
+	for i, config := range configs {
+		w, err := parseWindow(config)
+		if err != nil {
+			return nil, fmt.Errorf("window %d: %v", i, err)
+		}
+		if names[w.name] {
+			return nil, fmt.Errorf("window %d: duplicate name %q", i, w.name)
+		}
+		names[w.name] = true
+		windows = append(windows, w)
+	}
+	return windows, nil
+}
+
+func parseWindow(config windowConfig) (window, error) {
+	w := window{name: config.Name, minSize: config.MinSize, maxSize: config.MaxSize}
+	if w.name == "" {
+		return w, fmt.Errorf("name is missing")
+	}
+	if len(config.NodeGroups) == 0 {
+		return w, fmt.Errorf("nodeGroups are missing")
+	}
+	for _, expression := range config.NodeGroups {
+		re, err := regexp.Compile(expression)
+		if err != nil {
+			return w, fmt.Errorf("can't compile node group regexp %s: %v", expression, err)
+		}
+		w.regexps = append(w.regexps, re)
+	}
+	var err error
+	if w.schedule, err = parseCron(config.Schedule); err != nil {
+		return w, err
+	}
+	if w.duration, err = time.ParseDuration(config.Duration); err != nil {
+		return w, fmt.Errorf("invalid duration: %v", err)
+	}
+	if w.duration <= 0 || w.duration > maxWindowDuration {
+		return w, fmt.Errorf("duration has to be positive and at most %v", maxWindowDuration)
+	}
+	if w.location, err = time.LoadLocation(config.TimeZone); err != nil {
+		return w, fmt.Errorf("invalid time zone: %v", err)
+	}
+	if w.minSize == nil && w.maxSize == nil {
+		return w, fmt.Errorf("minSize or maxSize has to be set")
+	}
+	if (w.minSize != nil && *w.minSize < 0) || (w.maxSize != nil && *w.maxSize < 0) {
+		return w, fmt.Errorf("sizes can't be negative")
+	}
+	if w.minSize != nil && w.maxSize != nil && *w.minSize > *w.maxSize {
+		return w, fmt.Errorf("minSize %d is greater than maxSize %d", *w.minSize, *w.maxSize)
+	}
+	return w, nil
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalingschedule
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	. "k8s.io/autoscaler/cluster-autoscaler/core/test"
+	v1lister "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	kube_record "k8s.io/client-go/tools/record"
+)
+
+const (
+	testNamespace     = "kube-system"
+	testConfigMapName = "scaling-schedule"
+)
+
+func buildConfigMap(resourceVersion, windows string) *apiv1.ConfigMap {
+	return &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       testNamespace,
+			Name:            testConfigMapName,
+			ResourceVersion: resourceVersion,
+		},
+		Data: map[string]string{ConfigMapKey: windows},
+	}
+}
+
+func drainEvents(recorder *kube_record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 1, 10, 1)
+	provider.AddNodeGroup("ng2", 0, 5, 0)
+	provider.AddNodeGroup("other", 0, 5, 0)
+	ctx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{}, nil, nil, provider, nil, nil)
+	assert.NoError(t, err)
+	recorder := ctx.Recorder.(*kube_record.FakeRecorder)
+
+	store := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	lister := v1lister.NewConfigMapLister(store).ConfigMaps(testNamespace)
+	schedule := NewSchedule(lister, testConfigMapName)
+
+	limitsAt := func(now time.Time) map[string][2]int {
+		schedule.Apply(&ctx, now)
+		result := make(map[string][2]int)
+		for _, nodeGroup := range provider.NodeGroups() {
+			result[nodeGroup.Id()] = [2]int{nodeGroup.MinSize(), nodeGroup.MaxSize()}
+		}
+		return result
+	}
+	monday := time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+
+	// Without the config map, limits are kept.
+	assert.Equal(t, map[string][2]int{"ng1": {1, 10}, "ng2": {0, 5}, "other": {0, 5}}, limitsAt(monday.Add(9*time.Hour)))
+	assert.Empty(t, drainEvents(recorder))
+
+	assert.NoError(t, store.Add(buildConfigMap("1", `
+- name: business-hours
+  nodeGroups: [ng1]
+  schedule: "0 8 * * 1-5"
+  duration: 10h
+  minSize: 5
+- name: nights
+  nodeGroups: ["ng.*"]
+  schedule: "0 20 * * *"
+  duration: 12h
+  maxSize: 3
+- name: too-large
+  nodeGroups: [ng2]
+  schedule: "0 12 * * 0"
+  duration: 1h
+  minSize: 20
+`)))
+	assert.Equal(t, map[string][2]int{"ng1": {5, 10}, "ng2": {0, 5}, "other": {0, 5}}, limitsAt(monday.Add(9*time.Hour)))
+	assert.Equal(t, []string{"Normal ScalingWindowChangedLimits Scaling window business-hours set limits of node group ng1 to 5-10"}, drainEvents(recorder))
+
+	// Events are only recorded when limits change.
+	assert.Equal(t, map[string][2]int{"ng1": {5, 10}, "ng2": {0, 5}, "other": {0, 5}}, limitsAt(monday.Add(10*time.Hour)))
+	assert.Empty(t, drainEvents(recorder))
+
+	assert.Equal(t, map[string][2]int{"ng1": {1, 3}, "ng2": {0, 3}, "other": {0, 5}}, limitsAt(monday.Add(21*time.Hour)))
+	assert.ElementsMatch(t, []string{
+		"Normal ScalingWindowChangedLimits Scaling window nights set limits of node group ng1 to 1-3",
+		"Normal ScalingWindowChangedLimits Scaling window nights set limits of node group ng2 to 0-3",
+	}, drainEvents(recorder))
+
+	// The first active window is applied.
+	assert.Equal(t, map[string][2]int{"ng1": {5, 10}, "ng2": {0, 5}, "other": {0, 5}}, limitsAt(monday.Add(32*time.Hour)))
+	assert.ElementsMatch(t, []string{
+		"Normal ScalingWindowChangedLimits Scaling window business-hours set limits of node group ng1 to 5-10",
+		"Normal ScalingWindowEnded Scaling window nights ended, limits of node group ng2 reset to 0-5",
+	}, drainEvents(recorder))
+
+	// Limits rejected by the node group are reported once.
+	sunday := monday.AddDate(0, 0, 6)
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, map[string][2]int{"ng1": {1, 10}, "ng2": {0, 5}, "other": {0, 5}}, limitsAt(sunday.Add(12*time.Hour)))
+	}
+	events := drainEvents(recorder)
+	sort.Strings(events)
+	assert.Len(t, events, 2)
+	assert.Equal(t, "Normal ScalingWindowEnded Scaling window business-hours ended, limits of node group ng1 reset to 1-10", events[0])
+	assert.True(t, strings.HasPrefix(events[1], "Warning ScalingWindowRejected Limits 20-5 of scaling window too-large can't be applied to node group ng2"), events[1])
+
+	// Invalid updates are ignored.
+	assert.NoError(t, store.Update(buildConfigMap("2", `
+- name: nights
+  nodeGroups: ["ng.*"]
+  schedule: "0 25 * * *"
+  duration: 12h
+  maxSize: 3
+`)))
+	assert.Equal(t, map[string][2]int{"ng1": {1, 3}, "ng2": {0, 3}, "other": {0, 5}}, limitsAt(monday.Add(21*time.Hour)))
+	events = drainEvents(recorder)
+	sort.Strings(events)
+	assert.Len(t, events, 3)
+	assert.True(t, strings.HasPrefix(events[2], "Warning ScalingScheduleConfigMapInvalid"), events[2])
+
+	// Limits are reset once the config map is deleted.
+	assert.NoError(t, store.Delete(buildConfigMap("2", "")))
+	assert.Equal(t, map[string][2]int{"ng1": {1, 10}, "ng2": {0, 5}, "other": {0, 5}}, limitsAt(monday.Add(21*time.Hour)))
+	assert.Len(t, drainEvents(recorder), 0)
+}
+
+func TestParseWindows(t *testing.T) {
+	windows, err := parseWindows(`
+- name: business-hours
+  nodeGroups: [ng1, "ng2-.*"]
+  schedule: "0 8 * * 1-5"
+  duration: 10h
+  timeZone: Europe/Paris
+  minSize: 5
+  maxSize: 20
+`)
+	assert.NoError(t, err)
+	assert.Len(t, windows, 1)
+	assert.Equal(t, "business-hours", windows[0].name)
+	assert.Equal(t, 10*time.Hour, windows[0].duration)
+	assert.Equal(t, "Europe/Paris", windows[0].location.String())
+	assert.True(t, windows[0].matches("ng2-a"))
+	assert.False(t, windows[0].matches("ng3"))
+
+	valid := "name: w\n  nodeGroups: [ng1]\n  schedule: \"0 8 * * *\"\n  duration: 1h\n"
+	for name, windowsYAML := range map[string]string{
+		"empty":             "",
+		"not a list":        "name: w",
+		"unknown field":     "- " + valid + "  minSize: 1\n  unknown: 1",
+		"no name":           "- nodeGroups: [ng1]\n  schedule: \"0 8 * * *\"\n  duration: 1h\n  minSize: 1",
+		"no node groups":    "- name: w\n  schedule: \"0 8 * * *\"\n  duration: 1h\n  minSize: 1",
+		"invalid regexp":    "- name: w\n  nodeGroups: [\"(\"]\n  schedule: \"0 8 * * *\"\n  duration: 1h\n  minSize: 1",
+		"invalid schedule":  "- name: w\n  nodeGroups: [ng1]\n  schedule: \"0 8 * *\"\n  duration: 1h\n  minSize: 1",
+		"no duration":       "- name: w\n  nodeGroups: [ng1]\n  schedule: \"0 8 * * *\"\n  minSize: 1",
+		"too long duration": "- name: w\n  nodeGroups: [ng1]\n  schedule: \"0 8 * * *\"\n  duration: 200h\n  minSize: 1",
+		"invalid time zone": "- " + valid + "  timeZone: Mars/Olympus\n  minSize: 1",
+		"no sizes":          "- " + valid,
+		"negative size":     "- " + valid + "  minSize: -1",
+		"min above max":     "- " + valid + "  minSize: 3\n  maxSize: 2",
+		"duplicate name":    "- " + valid + "  minSize: 1\n- " + valid + "  minSize: 2",
+	} {
+		_, err := parseWindows(windowsYAML)
+		assert.Error(t, err, name)
+	}
+}
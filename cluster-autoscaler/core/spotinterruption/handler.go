@@ -0,0 +1,217 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spotinterruption
+
+import (
+	ctx "context"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/actuation"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
+	klog "k8s.io/klog/v2"
+)
+
+const (
+	// AwsNodeTerminationHandlerTaint is set by aws-node-termination-handler in IMDS mode on nodes
+	// whose spot instance received an interruption notice.
+	AwsNodeTerminationHandlerTaint = "aws-node-termination-handler/spot-itn"
+	// GkeImpendingNodeTerminationTaint is set by GKE on nodes whose Spot or preemptible VM is about to be preempted.
+	GkeImpendingNodeTerminationTaint = "cloud.google.com/impending-node-termination"
+	// AzurePreemptScheduledCondition is set by node-problem-detector on AKS nodes whose Spot VM
+	// has a Preempt event scheduled, as reported by Azure Scheduled Events.
+	AzurePreemptScheduledCondition apiv1.NodeConditionType = "PreemptScheduled"
+
+	// awsInterruptionNotice is the time between the spot interruption notice and the termination on AWS.
+	awsInterruptionNotice = 2 * time.Minute
+	// preemptionNotice is the time between the preemption notice and the termination on GCP and Azure.
+	preemptionNotice = 30 * time.Second
+)
+
+// Handler cordons and drains nodes whose spot or preemptible instances are about to be interrupted, so that
+// their pods are rescheduled before the termination. Optionally, the node groups of the interrupted nodes
+// are scaled up right away, so that replacement capacity is requested before the pods become pending.
+type Handler struct {
+	context             *context.AutoscalingContext
+	scaleUpOrchestrator scaleup.Orchestrator
+	preScale            bool
+	// handled maps names of the interrupted nodes already handled to their termination time.
+	handled map[string]time.Time
+	// drain is replaced in tests.
+	drain func(node *apiv1.Node, terminationTime time.Time)
+}
+
+// NewHandler creates a new Handler. If preScale is set, the node group of each interrupted node is scaled up by one node,
+// through the scale-up orchestrator, so that backoff, node group and resource limits are respected.
+func NewHandler(context *context.AutoscalingContext, scaleUpOrchestrator scaleup.Orchestrator, preScale bool) *Handler {
+	h := &Handler{
+		context:             context,
+		scaleUpOrchestrator: scaleUpOrchestrator,
+		preScale:            preScale,
+		handled:             make(map[string]time.Time),
+	}
+	h.drain = h.drainNode
+	return h
+}
+
+// HandleInterruptions handles the nodes with new interruption notices, reported by the cloud provider or by
+// agents running on the nodes. Each node is handled once. Ready nodes and node infos of node groups are used
+// to scale up node groups of the interrupted nodes, within the span of traceContext.
+func (h *Handler) HandleInterruptions(traceContext ctx.Context, allNodes, readyNodes []*apiv1.Node, nodeInfosForGroups map[string]*framework.NodeInfo, now time.Time) {
+	existing := make(map[string]bool, len(allNodes))
+	for _, node := range allNodes {
+		existing[node.Name] = true
+	}
+	for name := range h.handled {
+		if !existing[name] {
+			delete(h.handled, name)
+		}
+	}
+
+	for _, interrupted := range h.interruptedNodes(allNodes, now) {
+		if _, found := h.handled[interrupted.node.Name]; found {
+			continue
+		}
+		h.handled[interrupted.node.Name] = interrupted.terminationTime
+		h.handle(traceContext, interrupted.node, interrupted.terminationTime, readyNodes, nodeInfosForGroups)
+	}
+}
+
+type interruptedNode struct {
+	node            *apiv1.Node
+	terminationTime time.Time
+}
+
+// interruptedNodes returns the nodes with interruption notices from the cloud provider or from the node signals.
+func (h *Handler) interruptedNodes(allNodes []*apiv1.Node, now time.Time) []interruptedNode {
+	byProviderID := make(map[string]*apiv1.Node, len(allNodes))
+	for _, node := range allNodes {
+		byProviderID[node.Spec.ProviderID] = node
+	}
+
+	var interrupted []interruptedNode
+	seen := make(map[string]bool)
+	if source, ok := h.context.CloudProvider.(cloudprovider.SpotInterruptionSource); ok {
+		notices, err := source.SpotInterruptionNotices()
+		if err != nil {
+			klog.Errorf("Failed to get spot interruption notices: %v", err)
+		}
+		for _, notice := range notices {
+			node, found := byProviderID[notice.ProviderID]
+			if !found {
+				klog.V(4).Infof("Spot interruption notice for %s, which isn't registered as a node", notice.ProviderID)
+				continue
+			}
+			if !seen[node.Name] {
+				seen[node.Name] = true
+				interrupted = append(interrupted, interruptedNode{node: node, terminationTime: notice.TerminationTime})
+			}
+		}
+	}
+	for _, node := range allNodes {
+		if seen[node.Name] {
+			continue
+		}
+		if notice, found := nodeInterruptionNotice(node); found {
+			interrupted = append(interrupted, interruptedNode{node: node, terminationTime: now.Add(notice)})
+		}
+	}
+	return interrupted
+}
+
+// nodeInterruptionNotice checks whether an agent running on the node reported its upcoming interruption
+// and returns the time left until the termination.
+func nodeInterruptionNotice(node *apiv1.Node) (time.Duration, bool) {
+	for _, taint := range node.Spec.Taints {
+		switch taint.Key {
+		case AwsNodeTerminationHandlerTaint:
+			return awsInterruptionNotice, true
+		case GkeImpendingNodeTerminationTaint:
+			return preemptionNotice, true
+		}
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == AzurePreemptScheduledCondition && condition.Status == apiv1.ConditionTrue {
+			return preemptionNotice, true
+		}
+	}
+	return 0, false
+}
+
+func (h *Handler) handle(traceContext ctx.Context, node *apiv1.Node, terminationTime time.Time, readyNodes []*apiv1.Node, nodeInfosForGroups map[string]*framework.NodeInfo) {
+	klog.V(0).Infof("Spot interruption: node %s is going to be terminated at %v", node.Name, terminationTime)
+	h.context.LogRecorder.Eventf(apiv1.EventTypeNormal, "SpotInterruption", "Node %s is going to be interrupted, draining it", node.Name)
+
+	if _, err := taints.MarkToBeDeleted(node, h.context.ClientSet, true); err != nil {
+		klog.Errorf("Spot interruption: failed to cordon node %s: %v", node.Name, err)
+		return
+	}
+	if h.preScale {
+		h.scaleUp(traceContext, node, readyNodes, nodeInfosForGroups)
+	}
+	h.drain(node, terminationTime)
+}
+
+// scaleUp requests a replacement node in the node group of the interrupted node.
+func (h *Handler) scaleUp(traceContext ctx.Context, node *apiv1.Node, readyNodes []*apiv1.Node, nodeInfosForGroups map[string]*framework.NodeInfo) {
+	nodeGroup, err := h.context.CloudProvider.NodeGroupForNode(node)
+	if err != nil || nodeGroup == nil || nodeGroup.Id() == "" {
+		klog.Warningf("Spot interruption: no node group found for node %s, not scaling up: %v", node.Name, err)
+		return
+	}
+	scaleUpStatus, aErr := h.scaleUpOrchestrator.ScaleUpNodeGroup(traceContext, nodeGroup, 1, readyNodes, nodeInfosForGroups)
+	if aErr != nil {
+		h.context.LogRecorder.Eventf(apiv1.EventTypeWarning, "FailedToScaleUpGroup", "Spot interruption scale-up failed for group %s: %v", nodeGroup.Id(), aErr)
+		klog.Errorf("Spot interruption: failed to scale up %s: %v", nodeGroup.Id(), aErr)
+		return
+	}
+	if scaleUpStatus == nil || scaleUpStatus.Result != status.ScaleUpSuccessful {
+		klog.V(1).Infof("Spot interruption: scale-up of %s not possible, not replacing node %s", nodeGroup.Id(), node.Name)
+		return
+	}
+	h.context.LogRecorder.Eventf(apiv1.EventTypeNormal, "ScaledUpGroup", "Spot interruption: group %s scaled up to replace node %s", nodeGroup.Id(), node.Name)
+}
+
+// drainNode evicts the pods of the node in the background, limiting their graceful termination to the time
+// left until the termination.
+func (h *Handler) drainNode(node *apiv1.Node, terminationTime time.Time) {
+	nodeInfo, err := h.context.ClusterSnapshot.GetNodeInfo(node.Name)
+	if err != nil {
+		klog.Errorf("Spot interruption: failed to get node info of %s: %v", node.Name, err)
+		return
+	}
+	gracePeriod := int(time.Until(terminationTime).Seconds())
+	if gracePeriod > h.context.MaxGracefulTerminationSec {
+		gracePeriod = h.context.MaxGracefulTerminationSec
+	}
+	if gracePeriod < 0 {
+		gracePeriod = 0
+	}
+	evictor := actuation.NewEvictor(nil, actuation.SingleRuleDrainConfig(gracePeriod), false, h.context.DrainByPodPriority, h.context.NamespaceEvictionLimits)
+	go func() {
+		if _, err := evictor.DrainNode(h.context, nodeInfo); err != nil {
+			klog.Warningf("Spot interruption: failed to drain node %s: %v", node.Name, err)
+			return
+		}
+		klog.V(1).Infof("Spot interruption: node %s drained", node.Name)
+	}()
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spotinterruption
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	. "k8s.io/autoscaler/cluster-autoscaler/core/test"
+	"k8s.io/autoscaler/cluster-autoscaler/core/test/scaleuptest"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type spotInterruptionProvider struct {
+	*testprovider.TestCloudProvider
+	notices []cloudprovider.SpotInterruptionNotice
+}
+
+func (p *spotInterruptionProvider) SpotInterruptionNotices() ([]cloudprovider.SpotInterruptionNotice, error) {
+	notices := p.notices
+	p.notices = nil
+	return notices, nil
+}
+
+func TestHandleInterruptions(t *testing.T) {
+	now := time.Now()
+	for _, tc := range []struct {
+		name            string
+		notices         []cloudprovider.SpotInterruptionNotice
+		taints          map[string][]apiv1.Taint
+		conditions      map[string][]apiv1.NodeCondition
+		preScale        bool
+		maxSize         int
+		wantDrained     map[string]time.Time
+		wantScaleUpSize int
+	}{
+		{
+			name:        "no interruptions",
+			maxSize:     5,
+			wantDrained: map[string]time.Time{},
+		},
+		{
+			name:        "cloud provider notice",
+			notices:     []cloudprovider.SpotInterruptionNotice{{ProviderID: "n1", TerminationTime: now.Add(time.Minute)}, {ProviderID: "unknown"}},
+			maxSize:     5,
+			wantDrained: map[string]time.Time{"n1": now.Add(time.Minute)},
+		},
+		{
+			name: "node signals",
+			taints: map[string][]apiv1.Taint{
+				"n1": {{Key: AwsNodeTerminationHandlerTaint, Effect: apiv1.TaintEffectNoSchedule}},
+				"n2": {{Key: GkeImpendingNodeTerminationTaint, Effect: apiv1.TaintEffectNoSchedule}},
+			},
+			conditions: map[string][]apiv1.NodeCondition{
+				"n3": {{Type: AzurePreemptScheduledCondition, Status: apiv1.ConditionTrue}},
+			},
+			maxSize:     5,
+			wantDrained: map[string]time.Time{"n1": now.Add(awsInterruptionNotice), "n2": now.Add(preemptionNotice), "n3": now.Add(preemptionNotice)},
+		},
+		{
+			name:            "pre-scale",
+			notices:         []cloudprovider.SpotInterruptionNotice{{ProviderID: "n1", TerminationTime: now.Add(time.Minute)}, {ProviderID: "n2", TerminationTime: now.Add(time.Minute)}},
+			preScale:        true,
+			maxSize:         5,
+			wantDrained:     map[string]time.Time{"n1": now.Add(time.Minute), "n2": now.Add(time.Minute)},
+			wantScaleUpSize: 2,
+		},
+		{
+			name:            "pre-scale rejected by the orchestrator",
+			notices:         []cloudprovider.SpotInterruptionNotice{{ProviderID: "n1", TerminationTime: now.Add(time.Minute)}, {ProviderID: "n2", TerminationTime: now.Add(time.Minute)}},
+			preScale:        true,
+			maxSize:         4,
+			wantDrained:     map[string]time.Time{"n1": now.Add(time.Minute), "n2": now.Add(time.Minute)},
+			wantScaleUpSize: 1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			scaledUp := 0
+			provider := &spotInterruptionProvider{
+				TestCloudProvider: testprovider.NewTestCloudProvider(func(string, int) error {
+					scaledUp++
+					return nil
+				}, nil),
+				notices: tc.notices,
+			}
+			provider.AddNodeGroup("ng", 0, tc.maxSize, 3)
+			var nodes []*apiv1.Node
+			var objects []*apiv1.Node
+			for _, name := range []string{"n1", "n2", "n3"} {
+				node := BuildTestNode(name, 1000, 1000)
+				node.Spec.Taints = tc.taints[name]
+				node.Status.Conditions = tc.conditions[name]
+				provider.AddNode("ng", node)
+				nodes = append(nodes, node)
+				objects = append(objects, node.DeepCopy())
+			}
+			client := fake.NewSimpleClientset()
+			for _, node := range objects {
+				_, err := client.CoreV1().Nodes().Create(context.TODO(), node, metav1.CreateOptions{})
+				assert.NoError(t, err)
+			}
+			ctx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{}, client, nil, provider, nil, nil)
+			assert.NoError(t, err)
+
+			h := NewHandler(&ctx, &scaleuptest.FakeOrchestrator{}, tc.preScale)
+			drained := make(map[string]time.Time)
+			h.drain = func(node *apiv1.Node, terminationTime time.Time) {
+				drained[node.Name] = terminationTime
+			}
+			h.HandleInterruptions(context.Background(), nodes, nodes, nil, now)
+			// Nodes are handled once.
+			h.HandleInterruptions(context.Background(), nodes, nodes, nil, now)
+
+			assert.Equal(t, tc.wantDrained, drained)
+			assert.Equal(t, tc.wantScaleUpSize, scaledUp)
+			for _, node := range nodes {
+				updated, err := client.CoreV1().Nodes().Get(context.TODO(), node.Name, metav1.GetOptions{})
+				assert.NoError(t, err)
+				_, wantCordoned := tc.wantDrained[node.Name]
+				assert.Equal(t, wantCordoned, taints.HasToBeDeletedTaint(updated), node.Name)
+				assert.Equal(t, wantCordoned, updated.Spec.Unschedulable, node.Name)
+			}
+		})
+	}
+}
@@ -17,31 +17,50 @@ limitations under the License.
 package core
 
 import (
+	ctx "context"
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/api"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/pause"
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/config/dynamic"
 	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/leaderhandoff"
+	"k8s.io/autoscaler/cluster-autoscaler/core/noderepair"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/actuation"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/consolidation"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/deletiontracker"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/pdb"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/planner"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/predelete"
 	scaledownstatus "k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup/dryrun"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup/orchestrator"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleupforecast"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleupintent"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scalingschedule"
+	"k8s.io/autoscaler/cluster-autoscaler/core/spotinterruption"
 	core_utils "k8s.io/autoscaler/cluster-autoscaler/core/utils"
 	"k8s.io/autoscaler/cluster-autoscaler/debuggingsnapshot"
 	"k8s.io/autoscaler/cluster-autoscaler/estimator"
 	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/expander/factory"
 	"k8s.io/autoscaler/cluster-autoscaler/metrics"
 	"k8s.io/autoscaler/cluster-autoscaler/observers/loopstart"
 	ca_processors "k8s.io/autoscaler/cluster-autoscaler/processors"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/binpacking"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
@@ -50,14 +69,22 @@ import (
 	drasnapshot "k8s.io/autoscaler/cluster-autoscaler/simulator/dynamicresources/snapshot"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/options"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/scheduling"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/utilization"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/backoff"
 	caerrors "k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	pod_util "k8s.io/autoscaler/cluster-autoscaler/utils/pod"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/tracing"
+	"k8s.io/autoscaler/cluster-autoscaler/webui"
 	"k8s.io/utils/integer"
 
+	"go.opentelemetry.io/otel/attribute"
 	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 )
 
@@ -94,6 +121,37 @@ type StaticAutoscaler struct {
 	initialized             bool
 	taintConfig             taints.TaintConfig
 	draProvider             *draprovider.Provider
+	// scaleUpSimulationHandler, if set, queues scale-up simulation requests processed by scaleUpSimulator.
+	scaleUpSimulationHandler *dryrun.Handler
+	scaleUpSimulator         *orchestrator.ScaleUpOrchestrator
+	// consolidationPlanner, if set, replaces multiple underutilized nodes with a single node.
+	consolidationPlanner *consolidation.Planner
+	// spotInterruptionHandler, if set, drains nodes whose spot instances are about to be interrupted.
+	spotInterruptionHandler *spotinterruption.Handler
+	// nodeRepairer recreates long NotReady and long unregistered nodes of node groups with node auto-repair enabled.
+	nodeRepairer *noderepair.Repairer
+	// optionsReloader, if set, provides options changed at runtime. Changed expanders are built with expanderFactory.
+	optionsReloader *dynamic.OptionsReloader
+	expanderFactory *factory.Factory
+	// optionsLock guards the swap of AutoscalingOptions and ExpanderStrategy by reloadOptions
+	// against reads from outside of the loop, e.g. by ScanInterval.
+	optionsLock sync.RWMutex
+	// statusObjectWriter, if set, writes the status to a ClusterAutoscalerStatus object.
+	statusObjectWriter *utils.StatusObjectWriter
+	lastLoopError      *api.LoopError
+	// leaderHandoff, if set, hands over scale-ups and node deletions in progress to the next leader
+	// through the ClusterAutoscalerStatus object.
+	leaderHandoff *leaderhandoff.Handoff
+	// scaleUpIntents, if set, persists scale-ups in progress, to be resumed after a restart.
+	scaleUpIntents *scaleupintent.Tracker
+	// scaleUpForecaster, if set, scales node groups up ahead of scale-ups predicted from their history.
+	scaleUpForecaster *scaleupforecast.Forecaster
+	// scalingSchedule, if set, overrides the limits of node groups with scheduled scaling windows.
+	scalingSchedule *scalingschedule.Schedule
+	// pauses, if set, pause scale-up and scale-down cluster-wide or per node group.
+	pauses *pause.Registry
+	// webUI, if set, is updated with the status and scale-down candidates at the end of each loop.
+	webUI *webui.State
 }
 
 type staticAutoscalerProcessorCallbacks struct {
@@ -147,7 +205,18 @@ func NewStaticAutoscaler(
 	scaleUpOrchestrator scaleup.Orchestrator,
 	deleteOptions options.NodeDeleteOptions,
 	drainabilityRules rules.Rules,
-	draProvider *draprovider.Provider) *StaticAutoscaler {
+	draProvider *draprovider.Provider,
+	usageProvider utilization.UsageProvider,
+	scaleUpSimulationHandler *dryrun.Handler,
+	optionsReloader *dynamic.OptionsReloader,
+	expanderFactory *factory.Factory,
+	statusObjectWriter *utils.StatusObjectWriter,
+	scaleUpIntentStore *scaleupintent.Store,
+	scaleUpHistoryStore *scaleupforecast.Store,
+	scalingSchedule *scalingschedule.Schedule,
+	pauses *pause.Registry,
+	preDeleteHook predelete.Hook,
+	webUI *webui.State) *StaticAutoscaler {
 
 	klog.V(4).Infof("Creating new static autoscaler with opts: %v", opts)
 
@@ -156,6 +225,9 @@ func NewStaticAutoscaler(
 		OkTotalUnreadyCount:       opts.OkTotalUnreadyCount,
 	}
 	clusterStateRegistry := clusterstate.NewClusterStateRegistry(cloudProvider, clusterStateConfig, autoscalingKubeClients.LogRecorder, backoff, processors.NodeGroupConfigProcessor, processors.AsyncNodeGroupStateChecker)
+	if pauses != nil {
+		clusterStateRegistry.SetPauses(pauses)
+	}
 	processorCallbacks := newStaticAutoscalerProcessorCallbacks()
 	autoscalingContext := context.NewAutoscalingContext(
 		opts,
@@ -167,7 +239,8 @@ func NewStaticAutoscaler(
 		processorCallbacks,
 		debuggingSnapshotter,
 		remainingPdbTracker,
-		clusterStateRegistry)
+		clusterStateRegistry,
+		usageProvider)
 
 	taintConfig := taints.NewTaintConfig(opts)
 	processors.ScaleDownCandidatesNotifier.Register(clusterStateRegistry)
@@ -179,7 +252,19 @@ func NewStaticAutoscaler(
 	processorCallbacks.scaleDownPlanner = scaleDownPlanner
 
 	ndt := deletiontracker.NewNodeDeletionTracker(0 * time.Second)
-	scaleDownActuator := actuation.NewActuator(autoscalingContext, processors.ScaleStateNotifier, ndt, deleteOptions, drainabilityRules, processors.NodeGroupConfigProcessor, draProvider)
+	var scaleDownActuator scaledown.Actuator
+	if opts.ScaleDownDryRun {
+		dryRunActuator := actuation.NewDryRunActuator(autoscalingContext, processors.ScaleStateNotifier, ndt, deleteOptions, drainabilityRules, processors.NodeGroupConfigProcessor, draProvider)
+		dryRunActuator.SetUtilizationCalculator(processors.UtilizationCalculator)
+		scaleDownActuator = dryRunActuator
+	} else {
+		actuator := actuation.NewActuator(autoscalingContext, processors.ScaleStateNotifier, ndt, deleteOptions, drainabilityRules, processors.NodeGroupConfigProcessor, draProvider)
+		actuator.SetUtilizationCalculator(processors.UtilizationCalculator)
+		if preDeleteHook != nil {
+			actuator.SetPreDeleteHook(preDeleteHook)
+		}
+		scaleDownActuator = actuator
+	}
 	autoscalingContext.ScaleDownActuator = scaleDownActuator
 
 	if scaleUpOrchestrator == nil {
@@ -187,24 +272,110 @@ func NewStaticAutoscaler(
 	}
 	scaleUpOrchestrator.Initialize(autoscalingContext, processors, clusterStateRegistry, estimatorBuilder, taintConfig)
 
+	var consolidationPlanner *consolidation.Planner
+	if opts.ScaleDownConsolidationEnabled {
+		consolidationPlanner = consolidation.NewPlanner(autoscalingContext, scaleUpOrchestrator, deleteOptions, drainabilityRules, opts.MaxNodesPerConsolidation)
+	}
+
+	var spotInterruptionHandler *spotinterruption.Handler
+	if opts.SpotInterruptionHandlingEnabled {
+		spotInterruptionHandler = spotinterruption.NewHandler(autoscalingContext, scaleUpOrchestrator, opts.SpotInterruptionPreScaleEnabled)
+	}
+
+	var leaderHandoff *leaderhandoff.Handoff
+	if opts.LeaderStateHandoffEnabled && statusObjectWriter != nil {
+		leaderHandoff = leaderhandoff.NewHandoff(autoscalingContext, clusterStateRegistry, processors.ScaleStateNotifier, scaleDownActuator)
+	}
+
+	var scaleUpIntents *scaleupintent.Tracker
+	if scaleUpIntentStore != nil {
+		scaleUpIntents = scaleupintent.NewTracker(autoscalingContext, clusterStateRegistry, processors.ScaleStateNotifier, scaleUpIntentStore)
+		// Registered after the ClusterStateRegistry, intents are built from its scale-up requests.
+		processors.ScaleStateNotifier.Register(scaleUpIntents)
+	}
+
+	var scaleUpForecaster *scaleupforecast.Forecaster
+	if scaleUpHistoryStore != nil {
+		scaleUpForecaster = scaleupforecast.NewForecaster(autoscalingContext, scaleUpOrchestrator, scaleUpHistoryStore)
+	}
+
+	// Simulation uses a dedicated orchestrator, so that it never executes a scale-up,
+	// regardless of the orchestrator used for actual scale-ups. It also gets its own
+	// binpacking limiter, which keeps state across a scale-up, not to interfere with
+	// the scale-up of the loop.
+	var scaleUpSimulator *orchestrator.ScaleUpOrchestrator
+	if scaleUpSimulationHandler != nil {
+		simulationProcessors := *processors
+		simulationProcessors.BinpackingLimiter = binpacking.NewTimeLimiter(opts.MaxBinpackingTime)
+		scaleUpSimulator = orchestrator.New()
+		scaleUpSimulator.Initialize(autoscalingContext, &simulationProcessors, clusterStateRegistry, estimatorBuilder, taintConfig)
+	}
+
 	// Set the initial scale times to be less than the start time so as to
 	// not start in cooldown mode.
 	initialScaleTime := time.Now().Add(-time.Hour)
 	return &StaticAutoscaler{
-		AutoscalingContext:      autoscalingContext,
-		lastScaleUpTime:         initialScaleTime,
-		lastScaleDownDeleteTime: initialScaleTime,
-		lastScaleDownFailTime:   initialScaleTime,
-		scaleDownPlanner:        scaleDownPlanner,
-		scaleDownActuator:       scaleDownActuator,
-		scaleUpOrchestrator:     scaleUpOrchestrator,
-		processors:              processors,
-		loopStartNotifier:       loopStartNotifier,
-		processorCallbacks:      processorCallbacks,
-		clusterStateRegistry:    clusterStateRegistry,
-		taintConfig:             taintConfig,
-		draProvider:             draProvider,
+		AutoscalingContext:       autoscalingContext,
+		lastScaleUpTime:          initialScaleTime,
+		lastScaleDownDeleteTime:  initialScaleTime,
+		lastScaleDownFailTime:    initialScaleTime,
+		scaleDownPlanner:         scaleDownPlanner,
+		scaleDownActuator:        scaleDownActuator,
+		consolidationPlanner:     consolidationPlanner,
+		spotInterruptionHandler:  spotInterruptionHandler,
+		nodeRepairer:             noderepair.NewRepairer(autoscalingContext, processors.NodeGroupConfigProcessor, processors.ScaleStateNotifier, scaleUpOrchestrator),
+		scaleUpOrchestrator:      scaleUpOrchestrator,
+		processors:               processors,
+		loopStartNotifier:        loopStartNotifier,
+		processorCallbacks:       processorCallbacks,
+		clusterStateRegistry:     clusterStateRegistry,
+		taintConfig:              taintConfig,
+		draProvider:              draProvider,
+		scaleUpSimulationHandler: scaleUpSimulationHandler,
+		scaleUpSimulator:         scaleUpSimulator,
+		optionsReloader:          optionsReloader,
+		expanderFactory:          expanderFactory,
+		statusObjectWriter:       statusObjectWriter,
+		leaderHandoff:            leaderHandoff,
+		scaleUpIntents:           scaleUpIntents,
+		scaleUpForecaster:        scaleUpForecaster,
+		scalingSchedule:          scalingSchedule,
+		pauses:                   pauses,
+		webUI:                    webUI,
+	}
+}
+
+// simulateScaleUp computes how the cluster would be scaled up for the given
+// pods, leaving both the cluster and the cluster snapshot unchanged.
+func (a *StaticAutoscaler) simulateScaleUp(traceContext ctx.Context, pods []*apiv1.Pod, readyNodes []*apiv1.Node, nodeInfosForGroups map[string]*framework.NodeInfo) (*dryrun.Response, error) {
+	a.ClusterSnapshot.Fork()
+	defer a.ClusterSnapshot.Revert()
+
+	statuses, _, err := scheduling.NewHintingSimulator().TrySchedulePods(a.ClusterSnapshot, pods, scheduling.ScheduleAnywhere, false)
+	if err != nil {
+		return nil, err
+	}
+	scheduled := make(map[types.UID]bool, len(statuses))
+	podsFittingExistingNodes := make([]*apiv1.Pod, 0, len(statuses))
+	for _, s := range statuses {
+		scheduled[s.Pod.UID] = true
+		podsFittingExistingNodes = append(podsFittingExistingNodes, s.Pod)
+	}
+	var podsToScaleUp []*apiv1.Pod
+	for _, pod := range pods {
+		if !scheduled[pod.UID] {
+			podsToScaleUp = append(podsToScaleUp, pod)
+		}
+	}
+	if len(podsToScaleUp) == 0 {
+		return dryrun.NewResponse(podsFittingExistingNodes, nil), nil
+	}
+
+	scaleUpStatus, aErr := a.scaleUpSimulator.SimulateScaleUp(traceContext, podsToScaleUp, readyNodes, nodeInfosForGroups)
+	if aErr != nil {
+		return nil, aErr
 	}
+	return dryrun.NewResponse(podsFittingExistingNodes, scaleUpStatus), nil
 }
 
 // LastScaleUpTime returns last scale up time
@@ -217,6 +388,60 @@ func (a *StaticAutoscaler) LastScaleDownDeleteTime() time.Time {
 	return a.lastScaleDownDeleteTime
 }
 
+// ScanInterval returns how often the cluster should be reevaluated
+func (a *StaticAutoscaler) ScanInterval() time.Duration {
+	a.optionsLock.RLock()
+	defer a.optionsLock.RUnlock()
+	return a.AutoscalingOptions.ScanInterval
+}
+
+// reloadOptions applies options changed at runtime, if there are any. The reloaded options
+// are validated, and the expander built, before anything is swapped in, so that an invalid
+// ConfigMap leaves the options in use untouched.
+func (a *StaticAutoscaler) reloadOptions() {
+	if a.optionsReloader == nil {
+		return
+	}
+	opts, changed, err := a.optionsReloader.Reload(a.AutoscalingOptions)
+	if err == nil && len(changed) == 0 {
+		return
+	}
+	var strategy expander.Strategy
+	if err == nil && opts.ExpanderNames != a.ExpanderNames {
+		var aErr caerrors.AutoscalerError
+		if strategy, aErr = a.expanderFactory.Build(strings.Split(opts.ExpanderNames, ",")); aErr != nil {
+			err = aErr
+		}
+	}
+	if err != nil {
+		klog.Errorf("Failed to reload options: %v", err)
+		a.LogRecorder.Eventf(apiv1.EventTypeWarning, "OptionsReloadFailed", "Failed to reload options: %v", err)
+		return
+	}
+	a.optionsLock.Lock()
+	a.AutoscalingOptions = opts
+	if strategy != nil {
+		a.ExpanderStrategy = strategy
+	}
+	a.optionsLock.Unlock()
+	a.processors.NodeGroupConfigProcessor.SetNodeGroupDefaults(opts.NodeGroupDefaults)
+	klog.Infof("Reloaded options: %s", strings.Join(changed, ", "))
+	a.LogRecorder.Eventf(apiv1.EventTypeNormal, "OptionsReloaded", "Reloaded options: %s", strings.Join(changed, ", "))
+}
+
+// recordLoopError records the error that ended the loop, or clears the recorded error after a successful loop.
+func (a *StaticAutoscaler) recordLoopError(loopErr caerrors.AutoscalerError, currentTime time.Time) {
+	if loopErr == nil {
+		a.lastLoopError = nil
+		return
+	}
+	a.lastLoopError = &api.LoopError{
+		Type:    string(loopErr.Type()),
+		Message: loopErr.Error(),
+		Time:    metav1.NewTime(currentTime),
+	}
+}
+
 // Start starts components running in background.
 func (a *StaticAutoscaler) Start() error {
 	a.clusterStateRegistry.Start()
@@ -230,12 +455,22 @@ func (a *StaticAutoscaler) cleanUpIfRequired() {
 		return
 	}
 
+	if a.leaderHandoff != nil {
+		a.restoreLeaderHandoff()
+	}
+
 	// CA can die at any time. Removing taints that might have been left from the previous run.
 	if allNodes, err := a.AllNodeLister().List(); err != nil {
 		klog.Errorf("Failed to list ready nodes, not cleaning up taints: %v", err)
 	} else {
 		// Make sure we are only cleaning taints from selected node groups.
 		selectedNodes := filterNodesFromSelectedGroups(a.CloudProvider, allNodes...)
+		if a.leaderHandoff != nil {
+			// Nodes whose deletion is resumed keep their taints.
+			selectedNodes = slices.DeleteFunc(selectedNodes, func(node *apiv1.Node) bool {
+				return a.leaderHandoff.IsPendingDeletion(node.Name)
+			})
+		}
 		taints.CleanAllToBeDeleted(selectedNodes,
 			a.AutoscalingContext.ClientSet, a.Recorder, a.CordonNodeBeforeTerminate)
 		if a.AutoscalingContext.AutoscalingOptions.MaxBulkSoftTaintCount == 0 {
@@ -247,6 +482,21 @@ func (a *StaticAutoscaler) cleanUpIfRequired() {
 	a.initialized = true
 }
 
+// restoreLeaderHandoff takes over the operations in progress of the previous leader, read from the
+// ClusterAutoscalerStatus object before it's overwritten at the end of the first loop.
+func (a *StaticAutoscaler) restoreLeaderHandoff() {
+	status, err := a.statusObjectWriter.Read()
+	if err != nil {
+		klog.Errorf("Failed to read the leader handoff, not taking over operations in progress: %v", err)
+		return
+	}
+	if status == nil || status.LeaderHandoff == nil {
+		klog.V(1).Info("No leader handoff found")
+		return
+	}
+	a.leaderHandoff.Restore(status.LeaderHandoff)
+}
+
 func (a *StaticAutoscaler) initializeRemainingPdbTracker() caerrors.AutoscalerError {
 	a.RemainingPdbTracker.Clear()
 
@@ -263,7 +513,13 @@ func (a *StaticAutoscaler) initializeRemainingPdbTracker() caerrors.AutoscalerEr
 }
 
 // RunOnce iterates over node groups and scales them up/down if necessary
-func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerError {
+func (a *StaticAutoscaler) RunOnce(currentTime time.Time) (loopErr caerrors.AutoscalerError) {
+	traceContext, endLoop := a.startPhase(ctx.Background(), "RunOnce")
+	// Deferred first, so that the loop span includes the status updates deferred below.
+	defer func() { endLoop(loopErr) }()
+	// Loops ending before the status is written still record their error, for the next status update.
+	defer func() { a.recordLoopError(loopErr, currentTime) }()
+	a.reloadOptions()
 	a.cleanUpIfRequired()
 	a.processorCallbacks.reset()
 	a.clusterStateRegistry.PeriodicCleanup()
@@ -283,6 +539,10 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 		klog.Errorf("Failed to get node list: %v", typedErr)
 		return typedErr
 	}
+	// Simulations use the real node objects: nodes cordoned by soft-taint-only node groups can't take pods,
+	// so scale-up doesn't count on their capacity. The rest of the loop uses uncordoned copies.
+	snapshotNodes := allNodes
+	allNodes, readyNodes = a.uncordonSoftTaintOnlyNodes(allNodes, readyNodes)
 
 	if abortLoop, err := a.processors.ActionableClusterProcessor.ShouldAbort(
 		a.AutoscalingContext, allNodes, readyNodes, currentTime); abortLoop {
@@ -307,7 +567,9 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 	scaleDownActuationStatus := a.scaleDownActuator.CheckStatus()
 	// Call CloudProvider.Refresh before any other calls to cloud provider.
 	refreshStart := time.Now()
+	_, endRefresh := a.startPhase(traceContext, "CloudProviderRefresh")
 	err = a.AutoscalingContext.CloudProvider.Refresh()
+	endRefresh(err)
 	if a.AutoscalingOptions.AsyncNodeGroupsEnabled {
 		// Some node groups might have been created asynchronously, without registering in CSR.
 		a.clusterStateRegistry.Recalculate()
@@ -318,6 +580,12 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 		return caerrors.ToAutoscalerError(caerrors.CloudProviderError, err)
 	}
 	a.loopStartNotifier.Refresh()
+	if a.scalingSchedule != nil {
+		a.scalingSchedule.Apply(a.AutoscalingContext, currentTime)
+	}
+	if a.pauses != nil {
+		a.updatePauses(currentTime)
+	}
 
 	// Update node groups min/max and maximum number of nodes being set for all node groups after cloud provider refresh
 	maxNodesCount := 0
@@ -344,7 +612,10 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 		}
 	}
 
-	if err := a.ClusterSnapshot.SetClusterState(allNodes, nonExpendableScheduledPods, draSnapshot); err != nil {
+	_, endSetClusterState := a.startPhase(traceContext, "SetClusterState")
+	err = a.ClusterSnapshot.SetClusterState(snapshotNodes, nonExpendableScheduledPods, draSnapshot)
+	endSetClusterState(err)
+	if err != nil {
 		return caerrors.ToAutoscalerError(caerrors.InternalError, err).AddPrefix("failed to initialize ClusterSnapshot: ")
 	}
 	// Initialize Pod Disruption Budget tracking
@@ -352,7 +623,9 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 		return typedErr.AddPrefix("failed to initialize RemainingPdbTracker: ")
 	}
 
+	_, endTemplateNodeInfos := a.startPhase(traceContext, "TemplateNodeInfos")
 	nodeInfosForGroups, autoscalerError := a.processors.TemplateNodeInfoProvider.Process(autoscalingContext, readyNodes, daemonsets, a.taintConfig, currentTime)
+	endTemplateNodeInfos(autoscalerError)
 	if autoscalerError != nil {
 		klog.Errorf("Failed to get node infos for groups: %v", autoscalerError)
 		return autoscalerError.AddPrefix("failed to build node infos for node groups: ")
@@ -360,22 +633,63 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 
 	a.DebuggingSnapshotter.SetTemplateNodes(nodeInfosForGroups)
 
-	if typedErr := a.updateClusterState(allNodes, nodeInfosForGroups, currentTime); typedErr != nil {
+	_, endUpdateClusterState := a.startPhase(traceContext, "UpdateClusterState")
+	typedErr = a.updateClusterState(allNodes, nodeInfosForGroups, currentTime)
+	endUpdateClusterState(typedErr)
+	if typedErr != nil {
 		klog.Errorf("Failed to update cluster state: %v", typedErr)
 		return typedErr
 	}
 	metrics.UpdateDurationFromStart(metrics.UpdateState, stateUpdateStart)
 
+	// Interrupted nodes are drained even if the cluster isn't healthy, they're going away regardless.
+	if a.spotInterruptionHandler != nil {
+		interruptionsContext, endInterruptions := a.startPhase(traceContext, "HandleSpotInterruptions")
+		a.spotInterruptionHandler.HandleInterruptions(interruptionsContext, allNodes, readyNodes, nodeInfosForGroups, currentTime)
+		endInterruptions(nil)
+	}
+	// Scale-ups started before a restart are resumed before the ones handed off, which are then skipped.
+	if a.scaleUpIntents != nil {
+		a.scaleUpIntents.Resume(currentTime)
+		a.scaleUpIntents.Sync(currentTime)
+	}
+	// Deletions interrupted by a leader change are resumed regardless of the cluster health too, the nodes
+	// are already tainted.
+	if a.leaderHandoff != nil {
+		handoffContext, endHandoff := a.startPhase(traceContext, "ResumeLeaderHandoff")
+		a.leaderHandoff.Resume(handoffContext, allNodes)
+		endHandoff(nil)
+	}
+
 	scaleUpStatus := &status.ScaleUpStatus{Result: status.ScaleUpNotTried}
 	scaleUpStatusProcessorAlreadyCalled := false
 	scaleDownStatus := &scaledownstatus.ScaleDownStatus{Result: scaledownstatus.ScaleDownNotTried}
+	var unschedulablePodsToHelp []*apiv1.Pod
 
 	defer func() {
+		a.emitScaleUpFailureEvents(unschedulablePodsToHelp, nodeInfosForGroups)
+
 		// Update status information when the loop is done (regardless of reason)
-		if autoscalingContext.WriteStatusConfigMap {
-			status := a.clusterStateRegistry.GetStatus(currentTime)
-			utils.WriteStatusConfigMap(autoscalingContext.ClientSet, autoscalingContext.ConfigNamespace,
-				*status, a.AutoscalingContext.LogRecorder, a.AutoscalingContext.StatusConfigMapName, currentTime)
+		a.recordLoopError(loopErr, currentTime)
+		var status *api.ClusterAutoscalerStatus
+		if autoscalingContext.WriteStatusConfigMap || a.statusObjectWriter != nil || a.webUI != nil {
+			_, endWriteStatus := a.startPhase(traceContext, "WriteStatus")
+			defer endWriteStatus(nil)
+			status = a.clusterStateRegistry.GetStatus(currentTime)
+			status.ClusterWide.UtilizationBreakdowns = utilizationBreakdowns(a.scaleDownPlanner.UnremovableNodes())
+			status.LastError = a.lastLoopError
+			if autoscalingContext.WriteStatusConfigMap {
+				utils.WriteStatusConfigMap(autoscalingContext.ClientSet, autoscalingContext.ConfigNamespace,
+					*status, a.AutoscalingContext.LogRecorder, a.AutoscalingContext.StatusConfigMapName, currentTime)
+			}
+			if a.statusObjectWriter != nil {
+				if a.leaderHandoff != nil {
+					status.LeaderHandoff = a.leaderHandoff.State()
+				}
+				if err := a.statusObjectWriter.Write(*status, currentTime); err != nil {
+					klog.Errorf("Failed to write status object: %v", err)
+				}
+			}
 		}
 
 		// This deferred processor execution allows the processors to handle a situation when a scale-(up|down)
@@ -390,6 +704,7 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 			scaleDownStatus.NodeDeleteResultsAsOf = nodeDeletionResultsAsOf
 			a.scaleDownActuator.ClearResultsNotNewerThan(scaleDownStatus.NodeDeleteResultsAsOf)
 			scaleDownStatus.SetUnremovableNodesInfo(a.scaleDownPlanner.UnremovableNodes(), a.scaleDownPlanner.NodeUtilizationMap(), a.CloudProvider)
+			scaleDownStatus.UnneededNodes = a.scaleDownPlanner.UnneededNodes()
 
 			a.processors.ScaleDownStatusProcessor.Process(a.AutoscalingContext, scaleDownStatus)
 		}
@@ -400,6 +715,14 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 				klog.Errorf("AutoscalingStatusProcessor error: %v.", err)
 			}
 		}
+
+		if a.webUI != nil {
+			a.webUI.Update(*status,
+				webui.UnneededNodes(a.scaleDownPlanner.UnneededNodes(), a.scaleDownPlanner.NodeUtilizationMap(), a.CloudProvider),
+				webui.UnremovableNodes(a.scaleDownPlanner.UnremovableNodes(), a.CloudProvider),
+				webui.ScaleEvents(scaleUpStatus, scaleDownStatus, currentTime),
+				currentTime)
+		}
 	}()
 
 	// Check if there are any nodes that failed to register in Kubernetes
@@ -407,8 +730,10 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 	unregisteredNodes := a.clusterStateRegistry.GetUnregisteredNodes()
 	if len(unregisteredNodes) > 0 {
 		klog.V(1).Infof("%d unregistered nodes present", len(unregisteredNodes))
+		_, endRemoveUnregistered := a.startPhase(traceContext, "RemoveUnregisteredNodes", attribute.Int("nodes", len(unregisteredNodes)))
 		removedAny, err := a.removeOldUnregisteredNodes(unregisteredNodes,
 			a.clusterStateRegistry, currentTime, autoscalingContext.LogRecorder)
+		endRemoveUnregistered(err)
 		// There was a problem with removing unregistered nodes. Retry in the next loop.
 		if err != nil {
 			klog.Warningf("Failed to remove unregistered nodes: %v", err)
@@ -417,6 +742,16 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 			klog.V(0).Infof("Some unregistered nodes were removed")
 		}
 	}
+	// Instances which never joined the cluster and aren't part of any node group aren't reported as
+	// unregistered nodes, cloud providers supporting it delete them on their own.
+	if gc, ok := a.CloudProvider.(cloudprovider.InstanceGarbageCollector); ok {
+		_, endGC := a.startPhase(traceContext, "GarbageCollectInstances")
+		err := gc.GC(allNodes)
+		endGC(err)
+		if err != nil {
+			klog.Warningf("Failed to garbage collect orphaned instances: %v", err)
+		}
+	}
 
 	if !a.clusterStateRegistry.IsClusterHealthy() {
 		klog.Warning("Cluster is not ready for autoscaling")
@@ -425,12 +760,24 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 		return nil
 	}
 
+	// Nodes aren't repaired while the cluster is unhealthy, many unready nodes usually have a common cause
+	// which recreating them doesn't fix.
+	if a.nodeRepairer != nil {
+		repairContext, endRepair := a.startPhase(traceContext, "RepairNodes")
+		a.nodeRepairer.RepairNodes(repairContext, allNodes, readyNodes, unregisteredNodes, nodeInfosForGroups, currentTime)
+		endRepair(nil)
+	}
+
+	_, endDeleteCreatedWithErrors := a.startPhase(traceContext, "DeleteCreatedNodesWithErrors")
 	a.deleteCreatedNodesWithErrors()
+	endDeleteCreatedWithErrors(nil)
 
 	// Check if there has been a constant difference between the number of nodes in k8s and
 	// the number of nodes on the cloud provider side.
 	// TODO: andrewskim - add protection for ready AWS nodes.
+	_, endFixNodeGroupSizes := a.startPhase(traceContext, "FixNodeGroupSizes")
 	fixedSomething, err := fixNodeGroupSize(autoscalingContext, a.clusterStateRegistry, currentTime)
+	endFixNodeGroupSizes(err)
 	if err != nil {
 		klog.Errorf("Failed to fix node group sizes: %v", err)
 		return caerrors.ToAutoscalerError(caerrors.CloudProviderError, err)
@@ -484,7 +831,19 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 		a.AutoscalingContext.DebuggingSnapshotter.SetClusterNodes(l)
 	}
 
-	unschedulablePodsToHelp, err := a.processors.PodListProcessor.Process(a.AutoscalingContext, unschedulablePods)
+	// Simulations run before the pod list processors, which schedule pending pods in the cluster
+	// snapshot and keep state between loops, so that they only see existing and upcoming nodes.
+	if a.scaleUpSimulationHandler != nil {
+		simulationContext, endSimulations := a.startPhase(traceContext, "SimulateScaleUps")
+		a.scaleUpSimulationHandler.ProcessPending(func(pods []*apiv1.Pod) (*dryrun.Response, error) {
+			return a.simulateScaleUp(simulationContext, pods, readyNodes, nodeInfosForGroups)
+		})
+		endSimulations(nil)
+	}
+
+	_, endProcessPods := a.startPhase(traceContext, "ProcessPods")
+	unschedulablePodsToHelp, err = a.processors.PodListProcessor.Process(a.AutoscalingContext, unschedulablePods)
+	endProcessPods(err)
 
 	if err != nil {
 		klog.Warningf("Failed to process unschedulable pods: %v", err)
@@ -492,6 +851,7 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 
 	// finally, filter out pods that are too "young" to safely be considered for a scale-up (delay is configurable)
 	unschedulablePodsToHelp = a.filterOutYoungPods(unschedulablePodsToHelp, currentTime)
+
 	preScaleUp := func() time.Time {
 		scaleUpStart := time.Now()
 		metrics.UpdateLastTime(metrics.ScaleUp, scaleUpStart)
@@ -520,11 +880,17 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 	}
 
 	shouldScaleUp := true
+	scaleUpPaused := false
 
 	if len(unschedulablePodsToHelp) == 0 {
 		scaleUpStatus.Result = status.ScaleUpNotNeeded
 		klog.V(1).Info("No unschedulable pods")
 		shouldScaleUp = false
+	} else if pausedUntil := a.clusterStateRegistry.PausedUntil(pause.ScaleUp, "", currentTime); !pausedUntil.IsZero() {
+		scaleUpStatus.Result = status.ScaleUpInCooldown
+		klog.V(1).Infof("Scale-up is paused until %v", pausedUntil)
+		shouldScaleUp = false
+		scaleUpPaused = true
 	} else if a.MaxNodesTotal > 0 && len(readyNodes) >= a.MaxNodesTotal {
 		scaleUpStatus.Result = status.ScaleUpLimitedByMaxNodesTotal
 		klog.Warningf("Max total nodes in cluster reached: %v. Current number of ready nodes: %v", a.MaxNodesTotal, len(readyNodes))
@@ -553,14 +919,26 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 		shouldScaleUp = false
 	}
 
-	if shouldScaleUp || a.processors.ScaleUpEnforcer.ShouldForceScaleUp(unschedulablePodsToHelp) {
+	if !scaleUpPaused && (shouldScaleUp || a.processors.ScaleUpEnforcer.ShouldForceScaleUp(unschedulablePodsToHelp)) {
 		scaleUpStart := preScaleUp()
-		scaleUpStatus, typedErr = a.scaleUpOrchestrator.ScaleUp(unschedulablePodsToHelp, readyNodes, daemonsets, nodeInfosForGroups, false)
+		scaleUpContext, endScaleUp := a.startPhase(traceContext, "ScaleUp", attribute.Int("pods", len(unschedulablePodsToHelp)))
+		scaleUpStatus, typedErr = a.scaleUpOrchestrator.ScaleUp(scaleUpContext, unschedulablePodsToHelp, readyNodes, daemonsets, nodeInfosForGroups, false)
+		endScaleUp(typedErr)
+		if a.scaleUpForecaster != nil && typedErr == nil {
+			a.scaleUpForecaster.RecordScaleUp(scaleUpStatus, currentTime)
+		}
 		if exit, err := postScaleUp(scaleUpStart); exit {
 			return err
 		}
 	}
 
+	// Node groups are only pre-scaled in loops without a scale-up for pending pods.
+	if a.scaleUpForecaster != nil {
+		preScaleContext, endPreScale := a.startPhase(traceContext, "PreScale")
+		a.scaleUpForecaster.PreScale(preScaleContext, readyNodes, nodeInfosForGroups, currentTime)
+		endPreScale(nil)
+	}
+
 	if a.ScaleDownEnabled {
 		unneededStart := time.Now()
 
@@ -593,7 +971,16 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 			}
 		}
 
+		if a.pauses != nil {
+			scaleDownCandidates = a.filterOutScaleDownPaused(scaleDownCandidates, currentTime)
+		}
+		if a.scaleUpForecaster != nil {
+			scaleDownCandidates = a.scaleUpForecaster.FilterOutPreScaled(scaleDownCandidates, currentTime)
+		}
+
+		_, endFindUnneeded := a.startPhase(traceContext, "FindUnneeded", attribute.Int("candidates", len(scaleDownCandidates)))
 		typedErr := a.scaleDownPlanner.UpdateClusterState(podDestinations, scaleDownCandidates, scaleDownActuationStatus, currentTime)
+		endFindUnneeded(typedErr)
 		// Update clusterStateRegistry and metrics regardless of whether ScaleDown was successful or not.
 		unneededNodes := a.scaleDownPlanner.UnneededNodes()
 		a.processors.ScaleDownCandidatesNotifier.Update(unneededNodes, currentTime)
@@ -614,9 +1001,10 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 		metrics.UpdateScaleDownInCooldown(scaleDownInCooldown)
 		// We want to delete unneeded Node Groups only if here is no current delete
 		// in progress.
+		scaleDownPausedUntil := a.clusterStateRegistry.PausedUntil(pause.ScaleDown, "", currentTime)
 		_, drained := scaleDownActuationStatus.DeletionsInProgress()
 		var removedNodeGroups []cloudprovider.NodeGroup
-		if len(drained) == 0 {
+		if len(drained) == 0 && !a.ScaleDownDryRun && scaleDownPausedUntil.IsZero() {
 			var err error
 			removedNodeGroups, err = a.processors.NodeGroupManager.RemoveUnneededNodeGroups(autoscalingContext)
 			if err != nil {
@@ -625,7 +1013,11 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 			scaleDownStatus.RemovedNodeGroups = removedNodeGroups
 		}
 
-		if scaleDownInCooldown {
+		if !scaleDownPausedUntil.IsZero() {
+			klog.V(1).Infof("Scale-down is paused until %v", scaleDownPausedUntil)
+			scaleDownStatus.Result = scaledownstatus.ScaleDownInCooldown
+			a.updateSoftDeletionTaints(allNodes)
+		} else if scaleDownInCooldown {
 			scaleDownStatus.Result = scaledownstatus.ScaleDownInCooldown
 			a.updateSoftDeletionTaints(allNodes)
 		} else if len(scaleDownCandidates) == 0 {
@@ -639,9 +1031,18 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 			scaleDownStart := time.Now()
 			metrics.UpdateLastTime(metrics.ScaleDown, scaleDownStart)
 			empty, needDrain := a.scaleDownPlanner.NodesToDelete(currentTime)
-			scaleDownResult, scaledDownNodes, typedErr := a.scaleDownActuator.StartDeletion(empty, needDrain)
+			if a.consolidationPlanner != nil {
+				needDrain = a.addConsolidatedNodes(empty, needDrain, allNodes, currentTime)
+			}
+			scaleDownContext, endScaleDown := a.startPhase(traceContext, "ScaleDown", attribute.Int("empty", len(empty)), attribute.Int("drain", len(needDrain)))
+			scaleDownResult, scaledDownNodes, typedErr := a.scaleDownActuator.StartDeletion(scaleDownContext, empty, needDrain)
+			endScaleDown(typedErr)
 			scaleDownStatus.Result = scaleDownResult
-			scaleDownStatus.ScaledDownNodes = scaledDownNodes
+			if a.ScaleDownDryRun {
+				scaleDownStatus.DryRunScaledDownNodes = scaledDownNodes
+			} else {
+				scaleDownStatus.ScaledDownNodes = scaledDownNodes
+			}
 			metrics.UpdateDurationFromStart(metrics.ScaleDown, scaleDownStart)
 			metrics.UpdateUnremovableNodesCount(countsByReason(a.scaleDownPlanner.UnremovableNodes()))
 
@@ -650,6 +1051,14 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 			if scaleDownStatus.Result == scaledownstatus.ScaleDownNodeDeleteStarted {
 				a.lastScaleDownDeleteTime = currentTime
 				a.clusterStateRegistry.Recalculate()
+			} else if scaleDownStatus.Result == scaledownstatus.ScaleDownNoNodeDeleted && a.consolidationPlanner != nil && !a.ScaleDownDryRun {
+				candidates := consolidation.Candidates(a.scaleDownPlanner.UnremovableNodes(), a.scaleDownPlanner.NodeUtilizationMap())
+				consolidationContext, endConsolidation := a.startPhase(traceContext, "Consolidation", attribute.Int("candidates", len(candidates)))
+				err := a.consolidationPlanner.StartConsolidation(consolidationContext, candidates, readyNodes, nodeInfosForGroups, currentTime)
+				endConsolidation(err)
+				if err != nil {
+					klog.Errorf("Failed to start consolidation: %v", err)
+				}
 			}
 			a.updateSoftDeletionTaints(allNodes)
 			if typedErr != nil {
@@ -662,7 +1071,9 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 
 	if a.EnforceNodeGroupMinSize {
 		scaleUpStart := preScaleUp()
-		scaleUpStatus, typedErr = a.scaleUpOrchestrator.ScaleUpToNodeGroupMinSize(readyNodes, nodeInfosForGroups)
+		scaleUpContext, endScaleUp := a.startPhase(traceContext, "ScaleUpToNodeGroupMinSize")
+		scaleUpStatus, typedErr = a.scaleUpOrchestrator.ScaleUpToNodeGroupMinSize(scaleUpContext, readyNodes, nodeInfosForGroups)
+		endScaleUp(typedErr)
 		if exit, err := postScaleUp(scaleUpStart); exit {
 			return err
 		}
@@ -671,8 +1082,18 @@ func (a *StaticAutoscaler) RunOnce(currentTime time.Time) caerrors.AutoscalerErr
 	return nil
 }
 
+// startPhase starts the span of a phase of the loop, nested in the span of traceContext. Components called
+// in the phase get the returned context, so that their spans are nested in the phase, which the returned
+// function ends.
+func (a *StaticAutoscaler) startPhase(traceContext ctx.Context, name string, attributes ...attribute.KeyValue) (phaseContext ctx.Context, endPhase func(err error)) {
+	phaseContext, span := tracing.Start(traceContext, name, attributes...)
+	return phaseContext, func(err error) {
+		tracing.End(span, err)
+	}
+}
+
 func (a *StaticAutoscaler) updateSoftDeletionTaints(allNodes []*apiv1.Node) {
-	if a.AutoscalingContext.AutoscalingOptions.MaxBulkSoftTaintCount != 0 {
+	if a.AutoscalingContext.AutoscalingOptions.MaxBulkSoftTaintCount != 0 && !a.ScaleDownDryRun {
 		taintableNodes := a.scaleDownPlanner.UnneededNodes()
 
 		// Make sure we are only cleaning taints from selected node groups.
@@ -684,6 +1105,129 @@ func (a *StaticAutoscaler) updateSoftDeletionTaints(allNodes []*apiv1.Node) {
 		untaintableNodes := subtractNodes(selectedNodes, taintableNodes)
 		actuation.UpdateSoftDeletionTaints(a.AutoscalingContext, taintableNodes, untaintableNodes)
 	}
+	if !a.ScaleDownDryRun {
+		a.updateSoftTaintOnlyCordons()
+	}
+}
+
+// updateSoftTaintOnlyCordons cordons nodes which would be removed if their node groups didn't only soft-taint
+// unneeded nodes, and uncordons such nodes once they're no longer unneeded. The real node objects are used,
+// as RunOnce hides these cordons from the rest of the loop.
+func (a *StaticAutoscaler) updateSoftTaintOnlyCordons() {
+	nodes, err := a.AllNodeLister().List()
+	if err != nil {
+		klog.Errorf("Failed to list all nodes: %v", err)
+		return
+	}
+	toCordon := make(map[string]bool)
+	for _, unremovable := range a.scaleDownPlanner.UnremovableNodes() {
+		if unremovable.Reason == simulator.ScaleDownSoftTaintOnly {
+			toCordon[unremovable.Node.Name] = true
+		}
+	}
+	unneeded := make(map[string]bool)
+	for _, node := range a.scaleDownPlanner.UnneededNodes() {
+		unneeded[node.Name] = true
+	}
+	var cordonNodes, uncordonNodes []*apiv1.Node
+	for _, node := range nodes {
+		if toCordon[node.Name] {
+			cordonNodes = append(cordonNodes, node)
+		} else if !unneeded[node.Name] && taints.IsCordonedDeletionCandidate(node) && a.isSoftTaintOnly(node) {
+			uncordonNodes = append(uncordonNodes, node)
+		}
+	}
+	actuation.UpdateSoftTaintOnlyCordons(a.AutoscalingContext, cordonNodes, uncordonNodes)
+}
+
+// updatePauses replaces the declared pauses with the ones in the spec of the status object, if it's written,
+// and logs the pauses in effect.
+func (a *StaticAutoscaler) updatePauses(currentTime time.Time) {
+	if a.statusObjectWriter != nil {
+		spec := pause.Spec{}
+		if err := a.statusObjectWriter.ReadSpec(&spec); err != nil {
+			klog.Errorf("Failed to read declared pauses, keeping the previous ones: %v", err)
+		} else {
+			a.pauses.SetDeclared(spec.Pauses)
+		}
+	}
+	for _, p := range a.pauses.Active(currentTime) {
+		klog.V(1).Infof("Paused %v", p)
+	}
+}
+
+// filterOutScaleDownPaused removes nodes whose scale-down is paused, cluster-wide or for their node group,
+// from the scale-down candidates.
+func (a *StaticAutoscaler) filterOutScaleDownPaused(candidates []*apiv1.Node, currentTime time.Time) []*apiv1.Node {
+	var result []*apiv1.Node
+	for _, node := range candidates {
+		nodeGroupId := ""
+		if nodeGroup, err := a.CloudProvider.NodeGroupForNode(node); err == nil && nodeGroup != nil && !reflect.ValueOf(nodeGroup).IsNil() {
+			nodeGroupId = nodeGroup.Id()
+		}
+		if a.clusterStateRegistry.PausedUntil(pause.ScaleDown, nodeGroupId, currentTime).IsZero() {
+			result = append(result, node)
+		}
+	}
+	return result
+}
+
+// isSoftTaintOnly returns true if the node belongs to a node group which only soft-taints and cordons unneeded nodes.
+func (a *StaticAutoscaler) isSoftTaintOnly(node *apiv1.Node) bool {
+	nodeGroup, err := a.CloudProvider.NodeGroupForNode(node)
+	if err != nil || nodeGroup == nil || reflect.ValueOf(nodeGroup).IsNil() {
+		return false
+	}
+	softTaintOnly, err := a.processors.NodeGroupConfigProcessor.GetScaleDownSoftTaintOnly(nodeGroup)
+	if err != nil {
+		klog.Errorf("Failed to get ScaleDownSoftTaintOnly for node group %s: %v", nodeGroup.Id(), err)
+		return false
+	}
+	return softTaintOnly
+}
+
+// uncordonSoftTaintOnlyNodes replaces nodes cordoned by updateSoftTaintOnlyCordons with uncordoned copies, so that
+// they're still counted as ready and considered for scale-down. Such nodes are uncordoned once they're no longer
+// unneeded. The cluster snapshot keeps them cordoned.
+func (a *StaticAutoscaler) uncordonSoftTaintOnlyNodes(allNodes, readyNodes []*apiv1.Node) ([]*apiv1.Node, []*apiv1.Node) {
+	var uncordoned []*apiv1.Node
+	for i, node := range allNodes {
+		if !taints.IsCordonedDeletionCandidate(node) || !a.isSoftTaintOnly(node) {
+			continue
+		}
+		if uncordoned == nil {
+			allNodes = slices.Clone(allNodes)
+		}
+		node = node.DeepCopy()
+		node.Spec.Unschedulable = false
+		allNodes[i] = node
+		uncordoned = append(uncordoned, node)
+	}
+	// Ready nodes don't include cordoned nodes.
+	for _, node := range uncordoned {
+		if kube_util.IsNodeReadyAndSchedulable(node) {
+			readyNodes = append(readyNodes, node)
+		}
+	}
+	return allNodes, readyNodes
+}
+
+// addConsolidatedNodes returns needDrain extended with nodes replaced by a consolidation
+// whose replacement node is ready.
+func (a *StaticAutoscaler) addConsolidatedNodes(empty, needDrain, allNodes []*apiv1.Node, currentTime time.Time) []*apiv1.Node {
+	toDelete := make(map[string]bool, len(empty)+len(needDrain))
+	for _, node := range empty {
+		toDelete[node.Name] = true
+	}
+	for _, node := range needDrain {
+		toDelete[node.Name] = true
+	}
+	for _, node := range a.consolidationPlanner.NodesToDrain(allNodes, currentTime) {
+		if !toDelete[node.Name] {
+			needDrain = append(needDrain, node)
+		}
+	}
+	return needDrain
 }
 
 func (a *StaticAutoscaler) addUpcomingNodesToClusterSnapshot(upcomingCounts map[string]int, nodeInfosForGroups map[string]*framework.NodeInfo) error {
@@ -799,6 +1343,10 @@ func (a *StaticAutoscaler) removeOldUnregisteredNodes(allUnregisteredNodes []clu
 			klog.Warningf("Failed to remove unregistered nodes from node group %s: %v", nodeGroupId, err)
 			continue
 		}
+		if a.ScaleDownDryRun {
+			klog.V(1).Infof("Scale-down dry-run: would remove %v unregistered nodes from node group %s", len(nodesToDelete), nodeGroupId)
+			continue
+		}
 
 		if a.ForceDeleteLongUnregisteredNodes {
 			err = nodeGroup.ForceDeleteNodes(nodesToDelete)
@@ -841,6 +1389,11 @@ func (a *StaticAutoscaler) oldUnregisteredNodes(allUnregisteredNodes []clusterst
 			continue
 		}
 
+		if a.nodeRepairer != nil && a.nodeRepairer.Repairs(nodeGroup) {
+			// Unregistered nodes of the node group are recreated instead.
+			continue
+		}
+
 		maxNodeProvisionTime, err := csr.MaxNodeProvisionTime(nodeGroup)
 		if err != nil {
 			return nil, fmt.Errorf("failed to retrieve maxNodeProvisionTime for node %s in nodeGroup %s", unregisteredNode.Node.Name, nodeGroup.Id())
@@ -879,6 +1432,10 @@ func (a *StaticAutoscaler) deleteCreatedNodesWithErrors() {
 		if nodeGroup == nil {
 			err = fmt.Errorf("node group %s not found", nodeGroupId)
 		} else if nodesToDelete, err = overrideNodesToDeleteForZeroOrMax(a.NodeGroupDefaults, nodeGroup, nodesToDelete); err == nil {
+			if a.ScaleDownDryRun {
+				klog.V(1).Infof("Scale-down dry-run: would remove %v nodes that failed to create from node group %s", len(nodesToDelete), nodeGroupId)
+				continue
+			}
 			err = nodeGroup.DeleteNodes(nodesToDelete)
 		}
 
@@ -966,6 +1523,75 @@ func (a *StaticAutoscaler) filterOutYoungPods(allUnschedulablePods []*apiv1.Pod,
 	return oldUnschedulablePods
 }
 
+// emitScaleUpFailureEvents emits an event on each of the given pods for each
+// category of scale-up failures registered in this loop, so that users can
+// tell why their pods are still pending without access to CA logs. Only the
+// node groups whose template node the pod fits on are reported to it.
+func (a *StaticAutoscaler) emitScaleUpFailureEvents(pods []*apiv1.Pod, nodeInfosForGroups map[string]*framework.NodeInfo) {
+	if len(pods) == 0 {
+		return
+	}
+	failures := a.clusterStateRegistry.GetScaleUpFailures()
+	if len(failures) == 0 {
+		return
+	}
+	var candidates []*apiv1.Pod
+	for _, pod := range pods {
+		if !pod_util.IsCapacityBufferPod(pod) {
+			candidates = append(candidates, pod)
+		}
+	}
+	helpedPods := make(map[string]map[types.UID]bool, len(failures))
+	for nodeGroupId := range failures {
+		helpedPods[nodeGroupId] = a.podsFittingNodeGroup(candidates, nodeInfosForGroups[nodeGroupId])
+	}
+
+	for _, pod := range candidates {
+		nodeGroupsByCategory := make(map[cloudprovider.ScaleUpFailureCategory][]string)
+		for nodeGroupId, nodeGroupFailures := range failures {
+			if !helpedPods[nodeGroupId][pod.UID] {
+				continue
+			}
+			seen := make(map[cloudprovider.ScaleUpFailureCategory]bool)
+			for _, failure := range nodeGroupFailures {
+				if !seen[failure.Category] {
+					seen[failure.Category] = true
+					nodeGroupsByCategory[failure.Category] = append(nodeGroupsByCategory[failure.Category], nodeGroupId)
+				}
+			}
+		}
+		for _, category := range cloudprovider.ScaleUpFailureCategories {
+			nodeGroups, found := nodeGroupsByCategory[category]
+			if !found {
+				continue
+			}
+			sort.Strings(nodeGroups)
+			a.Recorder.Eventf(pod, apiv1.EventTypeWarning, "ScaleUpFailed",
+				"scale-up failed because of %s in node group(s): %s", category, strings.Join(nodeGroups, ", "))
+		}
+	}
+}
+
+// podsFittingNodeGroup returns the UIDs of the pods that pass scheduling predicates on the template node of a node group.
+func (a *StaticAutoscaler) podsFittingNodeGroup(pods []*apiv1.Pod, nodeInfo *framework.NodeInfo) map[types.UID]bool {
+	fitting := make(map[types.UID]bool)
+	if nodeInfo == nil {
+		return fitting
+	}
+	a.ClusterSnapshot.Fork()
+	defer a.ClusterSnapshot.Revert()
+	if err := a.ClusterSnapshot.AddNodeInfo(nodeInfo); err != nil {
+		klog.Errorf("Failed to add template node %s to the snapshot: %v", nodeInfo.Node().Name, err)
+		return fitting
+	}
+	for _, pod := range pods {
+		if err := a.ClusterSnapshot.CheckPredicates(pod, nodeInfo.Node().Name); err == nil {
+			fitting[pod.UID] = true
+		}
+	}
+	return fitting
+}
+
 // ExitCleanUp performs all necessary clean-ups when the autoscaler's exiting.
 func (a *StaticAutoscaler) ExitCleanUp() {
 	a.processors.CleanUp()
@@ -1101,6 +1727,29 @@ func countsByReason(nodes []*simulator.UnremovableNode) map[simulator.Unremovabl
 	return counts
 }
 
+func utilizationBreakdowns(nodes []*simulator.UnremovableNode) []api.NodeUtilizationBreakdown {
+	var breakdowns []api.NodeUtilizationBreakdown
+	for _, node := range nodes {
+		if node.UtilizationBreakdown == nil {
+			continue
+		}
+		breakdown := api.NodeUtilizationBreakdown{
+			Name:         node.Node.Name,
+			ResourceName: string(node.UtilizationBreakdown.ResourceName),
+			Utilization:  node.UtilizationBreakdown.Utilization,
+		}
+		for _, pod := range node.UtilizationBreakdown.TopPods {
+			podUtil := api.PodUtilization{Namespace: pod.Namespace, Name: pod.Name, Utilization: make(map[string]float64, len(pod.Utilization))}
+			for resourceName, util := range pod.Utilization {
+				podUtil.Utilization[string(resourceName)] = util
+			}
+			breakdown.TopPods = append(breakdown.TopPods, podUtil)
+		}
+		breakdowns = append(breakdowns, breakdown)
+	}
+	return breakdowns
+}
+
 func subtractNodesByName(nodes []*apiv1.Node, namesToRemove []string) []*apiv1.Node {
 	var c []*apiv1.Node
 	removeSet := make(map[string]bool)
@@ -1148,9 +1797,20 @@ func nodeNames(ns []*apiv1.Node) []string {
 }
 
 func listPods(podLister kube_util.PodLister, bypassedSchedulers map[string]bool) (scheduled, unschedulable, unprocessed []*apiv1.Pod, err error) {
-	pods, err := podLister.List()
+	if indexedLister, ok := podLister.(kube_util.IndexedPodLister); ok {
+		scheduled, unschedulable, unprocessed, err = listIndexedPods(indexedLister, bypassedSchedulers)
+	} else {
+		scheduled, unschedulable, unprocessed, err = listAllPods(podLister, bypassedSchedulers)
+	}
 	if err != nil {
 		klog.Errorf("Failed to list pods: %v", err)
+	}
+	return
+}
+
+func listAllPods(podLister kube_util.PodLister, bypassedSchedulers map[string]bool) (scheduled, unschedulable, unprocessed []*apiv1.Pod, err error) {
+	pods, err := podLister.List()
+	if err != nil {
 		return nil, nil, nil, err
 	}
 	scheduled = kube_util.ScheduledPods(pods)
@@ -1158,11 +1818,35 @@ func listPods(podLister kube_util.PodLister, bypassedSchedulers map[string]bool)
 	if len(bypassedSchedulers) > 0 {
 		unprocessed = kube_util.SchedulerUnprocessedPods(pods, bypassedSchedulers)
 	}
+	logPodCounts(len(pods), len(scheduled), len(unschedulable), len(unprocessed))
+	return
+}
+
+// listIndexedPods lists pods from the index, only going through pods which aren't scheduled
+// instead of all pods in the cluster.
+func listIndexedPods(podLister kube_util.IndexedPodLister, bypassedSchedulers map[string]bool) (scheduled, unschedulable, unprocessed []*apiv1.Pod, err error) {
+	if scheduled, err = podLister.ScheduledPods(); err != nil {
+		return nil, nil, nil, err
+	}
+	if unschedulable, err = podLister.UnschedulablePods(); err != nil {
+		return nil, nil, nil, err
+	}
+	unscheduled, err := podLister.UnscheduledPods()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(bypassedSchedulers) > 0 {
+		unprocessed = kube_util.SchedulerUnprocessedPods(unscheduled, bypassedSchedulers)
+	}
+	logPodCounts(len(scheduled)+len(unscheduled), len(scheduled), len(unschedulable), len(unprocessed))
+	return
+}
+
+func logPodCounts(all, scheduled, unschedulable, unprocessed int) {
 	// Skip logging in case of the boring scenario, when all pods are scheduled.
-	if len(pods) != len(scheduled) {
-		ignored := len(pods) - len(scheduled) - len(unschedulable) - len(unprocessed)
+	if all != scheduled {
+		ignored := all - scheduled - unschedulable - unprocessed
 		klog.Infof("Found %d pods in the cluster: %d scheduled, %d unschedulable, %d unprocessed by scheduler, %d ignored (most likely using custom scheduler)",
-			len(pods), len(scheduled), len(unschedulable), len(unprocessed), ignored)
+			all, scheduled, unschedulable, unprocessed, ignored)
 	}
-	return
 }
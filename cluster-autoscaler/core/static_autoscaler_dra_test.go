@@ -165,6 +165,7 @@ func TestStaticAutoscalerDynamicResources(t *testing.T) {
 	node1GpuA1slice := &testNodeGroupDef{name: "node1GpuA1slice", cpu: 1000, mem: 1000, slicesTemplateFunc: nodeTemplateResourceSlices(exampleDriver, 1, 0, []testDevice{{name: gpuDevice + "-0", attributes: map[string]string{gpuAttribute: gpuTypeA}}})}
 	node1GpuB1slice := &testNodeGroupDef{name: "node1GpuB1slice", cpu: 1000, mem: 1000, slicesTemplateFunc: nodeTemplateResourceSlices(exampleDriver, 1, 0, []testDevice{{name: gpuDevice + "-0", attributes: map[string]string{gpuAttribute: gpuTypeB}}})}
 	node3GpuA1slice := &testNodeGroupDef{name: "node3GpuA1slice", cpu: 1000, mem: 1000, slicesTemplateFunc: nodeTemplateResourceSlices(exampleDriver, 1, 0, testDevices(gpuDevice, 3, map[string]string{gpuAttribute: gpuTypeA}, nil))}
+	node3GpuB1slice := &testNodeGroupDef{name: "node3GpuB1slice", cpu: 1000, mem: 1000, slicesTemplateFunc: nodeTemplateResourceSlices(exampleDriver, 1, 0, testDevices(gpuDevice, 3, map[string]string{gpuAttribute: gpuTypeB}, nil))}
 	node3GpuA3slice := &testNodeGroupDef{name: "node3GpuA3slice", cpu: 1000, mem: 1000, slicesTemplateFunc: nodeTemplateResourceSlices(exampleDriver, 3, 0, testDevices(gpuDevice, 3, map[string]string{gpuAttribute: gpuTypeA}, nil))}
 	node1Nic1slice := &testNodeGroupDef{name: "node1Nic1slice", cpu: 1000, mem: 1000, slicesTemplateFunc: nodeTemplateResourceSlices(exampleDriver, 1, 0, []testDevice{{name: nicDevice + "-0", attributes: map[string]string{nicAttribute: nicTypeA}}})}
 	node1Gpu1Nic1slice := &testNodeGroupDef{name: "node1Gpu1Nic1slice", cpu: 1000, mem: 1000, slicesTemplateFunc: nodeTemplateResourceSlices(exampleDriver, 1, 0, []testDevice{
@@ -184,6 +185,20 @@ func TestStaticAutoscalerDynamicResources(t *testing.T) {
 	sharedGpuBClaim := testResourceClaim("sharedGpuBClaim", nil, "", []testDeviceRequest{req1GpuB}, nil, nil)
 	sharedAllocatedGlobalClaim := testResourceClaim("sharedGlobalClaim", nil, "", []testDeviceRequest{req1Global}, []testAllocation{{request: req1Global.name, driver: exampleDriver, pool: "global-pool", device: globalDevice + "-0"}}, nil)
 
+	// A shared claim allocated to a GPU on node3GpuB1slice-0, used by a replicated pod and a DaemonSet pod on that node.
+	sharedNodeLocalGpuBClaimName := "sharedNodeLocalGpuBClaim"
+	sharedNodeLocalGpuBClaimStub := testResourceClaim(sharedNodeLocalGpuBClaimName, nil, "", []testDeviceRequest{req1GpuB}, nil, nil)
+	sharingPod := scheduledPod(baseSmallPod, "sharing-0", node3GpuB1slice.name+"-0", nil, sharedNodeLocalGpuBClaimStub)
+	sharingDsPod := scheduledPod(baseSmallPod, "sharing-ds-0", node3GpuB1slice.name+"-0", nil, sharedNodeLocalGpuBClaimStub)
+	WithDSController()(sharingDsPod.pod)
+	sharingRemotePod := scheduledPod(baseSmallPod, "sharing-remote-0", node3GpuB1slice.name+"-1", nil, sharedNodeLocalGpuBClaimStub)
+	sharedNodeLocalGpuBAllocation := []testAllocation{{request: req1GpuB.name, driver: exampleDriver, pool: node3GpuB1slice.name + "-0", device: gpuDevice + "-0"}}
+	sharedNodeLocalGpuBClaim := testResourceClaim(sharedNodeLocalGpuBClaimName, nil, node3GpuB1slice.name+"-0", []testDeviceRequest{req1GpuB}, sharedNodeLocalGpuBAllocation, []*apiv1.Pod{sharingPod.pod, sharingDsPod.pod})
+	sharedRemoteGpuBClaim := testResourceClaim(sharedNodeLocalGpuBClaimName, nil, node3GpuB1slice.name+"-0", []testDeviceRequest{req1GpuB}, sharedNodeLocalGpuBAllocation, []*apiv1.Pod{sharingPod.pod, sharingRemotePod.pod})
+	// Keeps node3GpuB1slice-1 from being scaled down, so that it can be used as the destination.
+	unreplicatedPod := scheduledPod(baseSmallPod, "unreplicated-0", node3GpuB1slice.name+"-1", nil)
+	unreplicatedPod.pod.OwnerReferences = nil
+
 	testCases := map[string]struct {
 		nodeGroups           map[*testNodeGroupDef]int
 		templatePods         map[string][]testPod
@@ -323,6 +338,32 @@ func TestStaticAutoscalerDynamicResources(t *testing.T) {
 				{nodeName: node1GpuA1slice.name + "-1", reason: simulator.NoPlaceToMovePods},
 			},
 		},
+		"scale-down: node-local shared claim is re-allocated together with the pods using it": {
+			extraResourceClaims: []*resourceapi.ResourceClaim{sharedNodeLocalGpuBClaim},
+			nodeGroups:          map[*testNodeGroupDef]int{node3GpuB1slice: 2},
+			pods: []testPod{
+				sharingPod,
+				sharingDsPod,
+				unreplicatedPod,
+			},
+			// The DaemonSet pod is deleted together with the node, so the claim is deallocated and can be re-allocated on the other node.
+			expectedScaleDowns:   map[string][]string{node3GpuB1slice.name: {node3GpuB1slice.name + "-0"}},
+			expectedNoScaleDowns: []noScaleDownDef{{nodeName: node3GpuB1slice.name + "-1", reason: simulator.BlockedByPod}},
+		},
+		"no scale-down: node-local shared claim is in use outside of the node": {
+			extraResourceClaims: []*resourceapi.ResourceClaim{sharedRemoteGpuBClaim},
+			nodeGroups:          map[*testNodeGroupDef]int{node3GpuB1slice: 2},
+			pods: []testPod{
+				sharingPod,
+				sharingRemotePod,
+				unreplicatedPod,
+			},
+			// The claim stays allocated on node3GpuB1slice-0 because of the pod on the other node, so it can't be re-allocated anywhere else.
+			expectedNoScaleDowns: []noScaleDownDef{
+				{nodeName: node3GpuB1slice.name + "-0", reason: simulator.BlockedByPod},
+				{nodeName: node3GpuB1slice.name + "-1", reason: simulator.BlockedByPod},
+			},
+		},
 		"no scale-down: no place to reschedule": {
 			nodeGroups: map[*testNodeGroupDef]int{node3GpuA1slice: 3},
 			pods: []testPod{
@@ -674,7 +715,7 @@ func testResourceClaim(claimName string, owningPod *apiv1.Pod, nodeName string,
 				},
 			}
 		} else {
-			for _, pod := range podReservations {
+			for _, pod := range reservedFor {
 				podReservations = append(podReservations, resourceapi.ResourceClaimConsumerReference{
 					APIGroup: "",
 					Resource: "pods",
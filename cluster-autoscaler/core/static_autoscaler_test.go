@@ -42,8 +42,11 @@ import (
 	mockprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/mocks"
 	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/api"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/pause"
 	clusterstate_utils "k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
 	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/config/dynamic"
 	"k8s.io/autoscaler/cluster-autoscaler/context"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/actuation"
@@ -54,6 +57,10 @@ import (
 	. "k8s.io/autoscaler/cluster-autoscaler/core/test"
 	core_utils "k8s.io/autoscaler/cluster-autoscaler/core/utils"
 	"k8s.io/autoscaler/cluster-autoscaler/estimator"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/expander/factory"
+	"k8s.io/autoscaler/cluster-autoscaler/expander/mostpods"
+	"k8s.io/autoscaler/cluster-autoscaler/expander/waste"
 	"k8s.io/autoscaler/cluster-autoscaler/observers/loopstart"
 	ca_processors "k8s.io/autoscaler/cluster-autoscaler/processors"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
@@ -1710,6 +1717,12 @@ func TestStaticAutoscalerInstanceCreationErrors(t *testing.T) {
 	// propagate nodes info in cluster state
 	clusterState.UpdateNodes([]*apiv1.Node{}, nil, now)
 
+	// nothing is deleted in scale-down dry-run
+	autoscaler.ScaleDownDryRun = true
+	autoscaler.deleteCreatedNodesWithErrors()
+	nodeGroupA.AssertNumberOfCalls(t, "DeleteNodes", 0)
+	autoscaler.ScaleDownDryRun = false
+
 	// delete nodes with create errors
 	autoscaler.deleteCreatedNodesWithErrors()
 
@@ -2249,6 +2262,14 @@ func TestRemoveOldUnregisteredNodes(t *testing.T) {
 	assert.NoError(t, err)
 	assert.False(t, removed)
 
+	// Nothing should be removed in scale-down dry-run.
+	autoscaler.ScaleDownDryRun = true
+	removed, err = autoscaler.removeOldUnregisteredNodes(unregisteredNodes, clusterState, now, fakeLogRecorder)
+	assert.NoError(t, err)
+	assert.False(t, removed)
+	assert.Empty(t, deletedNodes)
+	autoscaler.ScaleDownDryRun = false
+
 	// ng1_2 should be removed.
 	removed, err = autoscaler.removeOldUnregisteredNodes(unregisteredNodes, clusterState, now, fakeLogRecorder)
 	assert.NoError(t, err)
@@ -2522,6 +2543,7 @@ func TestStaticAutoscalerRunOnceInvokesScaleDownStatusProcessor(t *testing.T) {
 						Node: n2,
 					},
 				},
+				UnneededNodes: []*apiv1.Node{n2},
 				UnremovableNodes: []*status.UnremovableNode{
 					{
 						Node:   n1,
@@ -2694,6 +2716,82 @@ func TestFilterNodesFromSelectedGroups(t *testing.T) {
 	}
 }
 
+func TestUncordonSoftTaintOnlyNodes(t *testing.T) {
+	softTaint := apiv1.Taint{Key: taints.DeletionCandidateTaint, Effect: apiv1.TaintEffectPreferNoSchedule}
+	cordoned := BuildTestNode("cordoned", 1000, 1000)
+	SetNodeReadyState(cordoned, true, time.Now())
+	cordoned.Spec.Unschedulable = true
+	cordoned.Spec.Taints = []apiv1.Taint{softTaint}
+	ready := BuildTestNode("ready", 1000, 1000)
+	SetNodeReadyState(ready, true, time.Now())
+	otherGroup := BuildTestNode("other-group", 1000, 1000)
+	SetNodeReadyState(otherGroup, true, time.Now())
+	otherGroup.Spec.Unschedulable = true
+	otherGroup.Spec.Taints = []apiv1.Taint{softTaint}
+
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroupWithCustomOptions("soft-taint-only", 0, 10, 2, &config.NodeGroupAutoscalingOptions{ScaleDownSoftTaintOnly: true})
+	provider.AddNodeGroup("other", 0, 10, 1)
+	provider.AddNode("soft-taint-only", cordoned)
+	provider.AddNode("soft-taint-only", ready)
+	provider.AddNode("other", otherGroup)
+
+	ctx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{}, &fake.Clientset{}, nil, provider, nil, nil)
+	assert.NoError(t, err)
+	autoscaler := &StaticAutoscaler{
+		AutoscalingContext: &ctx,
+		processors:         processorstest.NewTestProcessors(&ctx),
+	}
+
+	allNodes, readyNodes := autoscaler.uncordonSoftTaintOnlyNodes([]*apiv1.Node{cordoned, ready, otherGroup}, []*apiv1.Node{ready})
+	assert.Equal(t, []string{"cordoned", "ready", "other-group"}, nodeNames(allNodes))
+	assert.False(t, allNodes[0].Spec.Unschedulable)
+	assert.True(t, allNodes[2].Spec.Unschedulable)
+	assert.ElementsMatch(t, []string{"ready", "cordoned"}, nodeNames(readyNodes))
+	// The listed nodes aren't modified.
+	assert.True(t, cordoned.Spec.Unschedulable)
+}
+
+func TestFilterOutScaleDownPaused(t *testing.T) {
+	now := time.Now()
+	n1 := BuildTestNode("n1", 1000, 1000)
+	n2 := BuildTestNode("n2", 1000, 1000)
+	n3 := BuildTestNode("n3", 1000, 1000)
+
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("paused", 0, 10, 1)
+	provider.AddNodeGroup("other", 0, 10, 1)
+	provider.AddNode("paused", n1)
+	provider.AddNode("other", n2)
+
+	ctx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{}, &fake.Clientset{}, nil, provider, nil, nil)
+	assert.NoError(t, err)
+	processors := processorstest.NewTestProcessors(&ctx)
+	pauses := pause.NewRegistry()
+	clusterState := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, ctx.LogRecorder, NewBackoff(), processors.NodeGroupConfigProcessor, processors.AsyncNodeGroupStateChecker)
+	clusterState.SetPauses(pauses)
+	autoscaler := &StaticAutoscaler{
+		AutoscalingContext:   &ctx,
+		processors:           processors,
+		clusterStateRegistry: clusterState,
+		pauses:               pauses,
+	}
+	candidates := []*apiv1.Node{n1, n2, n3}
+
+	assert.Equal(t, candidates, autoscaler.filterOutScaleDownPaused(candidates, now))
+
+	// Scale-up pauses don't affect scale-down.
+	assert.NoError(t, pauses.Pause(pause.Pause{Operation: pause.ScaleUp, Until: metav1.NewTime(now.Add(time.Hour))}))
+	assert.NoError(t, pauses.Pause(pause.Pause{Operation: pause.ScaleDown, NodeGroup: "paused", Until: metav1.NewTime(now.Add(time.Hour))}))
+	assert.Equal(t, []*apiv1.Node{n2, n3}, autoscaler.filterOutScaleDownPaused(candidates, now))
+
+	// The pause ends automatically.
+	assert.Equal(t, candidates, autoscaler.filterOutScaleDownPaused(candidates, now.Add(time.Hour)))
+
+	assert.NoError(t, pauses.Pause(pause.Pause{Operation: pause.All, Until: metav1.NewTime(now.Add(time.Hour))}))
+	assert.Empty(t, autoscaler.filterOutScaleDownPaused(candidates, now))
+}
+
 func waitForDeleteToFinish(t *testing.T, deleteFinished <-chan bool) {
 	t.Helper()
 	select {
@@ -2856,6 +2954,139 @@ func buildStaticAutoscaler(t *testing.T, provider cloudprovider.CloudProvider, a
 	return autoscaler
 }
 
+func TestStaticAutoscalerReloadOptions(t *testing.T) {
+	options := config.AutoscalingOptions{
+		ScanInterval:  10 * time.Second,
+		ExpanderNames: expander.LeastWasteExpanderName,
+		NodeGroupDefaults: config.NodeGroupAutoscalingOptions{
+			ScaleDownUtilizationThreshold: 0.5,
+		},
+	}
+	fakeClient := fake.NewSimpleClientset(&apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "cluster-autoscaler-options", ResourceVersion: "1"},
+		Data: map[string]string{
+			"scan-interval":                    "1m",
+			"expander":                         expander.MostPodsExpanderName,
+			"scale-down-utilization-threshold": "0.7",
+		},
+	})
+	reloader, err := dynamic.NewOptionsReloader(fakeClient, "kube-system", "cluster-autoscaler-options", options)
+	assert.NoError(t, err)
+
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 0, 10, 1)
+	ctx, err := NewScaleTestAutoscalingContext(options, fakeClient, nil, provider, nil, nil)
+	assert.NoError(t, err)
+	processors := processorstest.NewTestProcessors(&ctx)
+	expanderFactory := factory.NewFactory()
+	expanderFactory.RegisterFilter(expander.MostPodsExpanderName, mostpods.NewFilter)
+	expanderFactory.RegisterFilter(expander.LeastWasteExpanderName, waste.NewFilter)
+
+	autoscaler := &StaticAutoscaler{
+		AutoscalingContext: &ctx,
+		processors:         processors,
+		optionsReloader:    reloader,
+		expanderFactory:    expanderFactory,
+	}
+	strategy := autoscaler.ExpanderStrategy
+	autoscaler.reloadOptions()
+
+	assert.Equal(t, time.Minute, autoscaler.ScanInterval())
+	assert.Equal(t, expander.MostPodsExpanderName, autoscaler.ExpanderNames)
+	assert.NotSame(t, strategy, autoscaler.ExpanderStrategy)
+	threshold, err := processors.NodeGroupConfigProcessor.GetScaleDownUtilizationThreshold(provider.GetNodeGroup("ng1"))
+	assert.NoError(t, err)
+	assert.Equal(t, 0.7, threshold)
+}
+
+func TestStaticAutoscalerReloadOptionsUnusableExpander(t *testing.T) {
+	options := config.AutoscalingOptions{
+		ScanInterval:  10 * time.Second,
+		ExpanderNames: expander.LeastWasteExpanderName,
+	}
+	fakeClient := fake.NewSimpleClientset(&apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "cluster-autoscaler-options", ResourceVersion: "1"},
+		Data: map[string]string{
+			"scan-interval": "1m",
+			"expander":      expander.PriceBasedExpanderName,
+		},
+	})
+	reloader, err := dynamic.NewOptionsReloader(fakeClient, "kube-system", "cluster-autoscaler-options", options)
+	assert.NoError(t, err)
+
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	ctx, err := NewScaleTestAutoscalingContext(options, fakeClient, nil, provider, nil, nil)
+	assert.NoError(t, err)
+	expanderFactory := factory.NewFactory()
+	expanderFactory.RegisterFilter(expander.LeastWasteExpanderName, waste.NewFilter)
+	expanderFactory.RegisterFallibleFilter(expander.PriceBasedExpanderName, func() (expander.Filter, error) {
+		return nil, fmt.Errorf("pricing not available")
+	})
+
+	autoscaler := &StaticAutoscaler{
+		AutoscalingContext: &ctx,
+		processors:         processorstest.NewTestProcessors(&ctx),
+		optionsReloader:    reloader,
+		expanderFactory:    expanderFactory,
+	}
+	strategy := autoscaler.ExpanderStrategy
+	autoscaler.reloadOptions()
+
+	assert.Equal(t, 10*time.Second, autoscaler.ScanInterval())
+	assert.Equal(t, expander.LeastWasteExpanderName, autoscaler.ExpanderNames)
+	assert.Equal(t, strategy, autoscaler.ExpanderStrategy)
+}
+
+func TestStaticAutoscalerRecordLoopError(t *testing.T) {
+	now := time.Now()
+	autoscaler := &StaticAutoscaler{}
+	autoscaler.recordLoopError(errors.NewAutoscalerError(errors.CloudProviderError, "boom"), now)
+	assert.Equal(t, &api.LoopError{Type: string(errors.CloudProviderError), Message: "boom", Time: metav1.NewTime(now)}, autoscaler.lastLoopError)
+	autoscaler.recordLoopError(nil, now.Add(time.Minute))
+	assert.Nil(t, autoscaler.lastLoopError)
+}
+
+func TestStaticAutoscalerEmitScaleUpFailureEvents(t *testing.T) {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 0, 10, 0)
+	provider.AddNodeGroup("ng2", 0, 10, 0)
+	provider.AddNodeGroup("ng3", 0, 10, 0)
+	ctx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{}, fake.NewSimpleClientset(), nil, provider, nil, nil)
+	assert.NoError(t, err)
+	processors := processorstest.NewTestProcessors(&ctx)
+	clusterState := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, ctx.LogRecorder, NewBackoff(), processors.NodeGroupConfigProcessor, processors.AsyncNodeGroupStateChecker)
+	autoscaler := &StaticAutoscaler{
+		AutoscalingContext:   &ctx,
+		clusterStateRegistry: clusterState,
+	}
+	now := time.Now()
+	clusterState.RegisterFailedScaleUp(provider.GetNodeGroup("ng2"), "timeout", "", "", "", now)
+	clusterState.RegisterFailedScaleUp(provider.GetNodeGroup("ng1"), "timeout", "", "", "", now)
+	clusterState.RegisterFailedScaleUp(provider.GetNodeGroup("ng1"), "timeout", "", "", "", now)
+	clusterState.RegisterFailedScaleUp(provider.GetNodeGroup("ng3"), "cloudProviderError", "", "", "", now)
+
+	nodeInfosForGroups := map[string]*framework.NodeInfo{
+		"ng1": framework.NewTestNodeInfo(BuildTestNode("ng1-template", 4000, 4000)),
+		"ng2": framework.NewTestNodeInfo(BuildTestNode("ng2-template", 1000, 1000)),
+		"ng3": framework.NewTestNodeInfo(BuildTestNode("ng3-template", 4000, 4000)),
+	}
+
+	autoscaler.emitScaleUpFailureEvents([]*apiv1.Pod{BuildTestPod("p1", 100, 100), BuildTestPod("p2", 2000, 2000)}, nodeInfosForGroups)
+
+	recorder := ctx.Recorder.(*kube_record.FakeRecorder)
+	var events []string
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	assert.Equal(t, []string{
+		"Warning ScaleUpFailed scale-up failed because of timeout in node group(s): ng1, ng2",
+		"Warning ScaleUpFailed scale-up failed because of other in node group(s): ng3",
+		// ng2 nodes are too small for p2.
+		"Warning ScaleUpFailed scale-up failed because of timeout in node group(s): ng1",
+		"Warning ScaleUpFailed scale-up failed because of other in node group(s): ng3",
+	}, events)
+}
+
 func buildFakeClient(t *testing.T, nodes ...*apiv1.Node) *fake.Clientset {
 	fakeClient := fake.NewSimpleClientset()
 	for _, node := range nodes {
@@ -0,0 +1,65 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaleuptest
+
+import (
+	"context"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupset"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+)
+
+// FakeOrchestrator is a scale-up orchestrator for tests. ScaleUpNodeGroup resizes node
+// groups directly, without the scale-up limits other than the max size of the node group.
+// Scale-ups above the max size are rejected, unless CapAtMaxSize is set. The other methods
+// of scaleup.Orchestrator aren't implemented.
+type FakeOrchestrator struct {
+	scaleup.Orchestrator
+	// CapAtMaxSize makes scale-ups above the max size add nodes up to it instead of being rejected.
+	CapAtMaxSize bool
+	// RejectAll rejects all scale-ups.
+	RejectAll bool
+}
+
+// ScaleUpNodeGroup increases the size of the node group by newNodes.
+func (o *FakeOrchestrator) ScaleUpNodeGroup(_ context.Context, nodeGroup cloudprovider.NodeGroup, newNodes int, _ []*apiv1.Node, _ map[string]*framework.NodeInfo) (*status.ScaleUpStatus, errors.AutoscalerError) {
+	if o.RejectAll {
+		return &status.ScaleUpStatus{Result: status.ScaleUpNoOptionsAvailable}, nil
+	}
+	targetSize, err := nodeGroup.TargetSize()
+	if err != nil {
+		return nil, errors.ToAutoscalerError(errors.CloudProviderError, err)
+	}
+	if o.CapAtMaxSize {
+		newNodes = min(newNodes, nodeGroup.MaxSize()-targetSize)
+	}
+	if newNodes < 1 || targetSize+newNodes > nodeGroup.MaxSize() {
+		return &status.ScaleUpStatus{Result: status.ScaleUpNoOptionsAvailable}, nil
+	}
+	if err := nodeGroup.IncreaseSize(newNodes); err != nil {
+		return nil, errors.ToAutoscalerError(errors.CloudProviderError, err)
+	}
+	return &status.ScaleUpStatus{
+		Result:       status.ScaleUpSuccessful,
+		ScaleUpInfos: []nodegroupset.ScaleUpInfo{{Group: nodeGroup, CurrentSize: targetSize, NewSize: targetSize + newNodes, MaxSize: nodeGroup.MaxSize()}},
+	}, nil
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package estimator
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
+	podutils "k8s.io/autoscaler/cluster-autoscaler/utils/pod"
+)
+
+// BinpackingNodeScorer scores a node added during binpacking for a given pod. When set, the pod is placed
+// on the node with the lowest score among the nodes it fits on, instead of the first one it fits on.
+// The second return value is false if the pod clearly can't fit on the node, which allows skipping
+// scheduling simulation for it.
+type BinpackingNodeScorer func(pod *apiv1.Pod, nodeInfo *framework.NodeInfo) (float64, bool)
+
+// LeastRemainingResourcesNodeScorer is a BinpackingNodeScorer implementing best-fit: it scores nodes by
+// the sum of CPU, memory, GPU and ephemeral storage fractions that would remain free after placing the pod
+// on them. GPU and ephemeral storage are taken into account only for nodes having them.
+func LeastRemainingResourcesNodeScorer(pod *apiv1.Pod, nodeInfo *framework.NodeInfo) (float64, bool) {
+	podRequests := podutils.PodRequests(pod)
+	allocatable := nodeInfo.Node().Status.Allocatable
+	requested := nodeInfo.ToScheduler().Requested
+
+	score := 0.0
+	for _, r := range []struct {
+		allocatable int64
+		requested   int64
+		podRequest  int64
+	}{
+		{allocatable.Cpu().MilliValue(), requested.MilliCPU, podRequests.Cpu().MilliValue()},
+		{allocatable.Memory().Value(), requested.Memory, podRequests.Memory().Value()},
+		{allocatable.Name(gpu.ResourceNvidiaGPU, "").Value(), requested.ScalarResources[gpu.ResourceNvidiaGPU], podRequests.Name(gpu.ResourceNvidiaGPU, "").Value()},
+		{allocatable.StorageEphemeral().Value(), requested.EphemeralStorage, podRequests.StorageEphemeral().Value()},
+	} {
+		if r.allocatable <= 0 {
+			if r.podRequest > 0 {
+				return 0, false
+			}
+			continue
+		}
+		remaining := r.allocatable - r.requested - r.podRequest
+		if remaining < 0 {
+			return 0, false
+		}
+		score += float64(remaining) / float64(r.allocatable)
+	}
+	return score, true
+}
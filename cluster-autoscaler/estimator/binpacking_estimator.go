@@ -18,6 +18,7 @@ package estimator
 
 import (
 	"fmt"
+	"sort"
 
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
@@ -34,7 +35,7 @@ type BinpackingNodeEstimator struct {
 	podOrderer             EstimationPodOrderer
 	context                EstimationContext
 	estimationAnalyserFunc EstimationAnalyserFunc // optional
-
+	nodeScorer             BinpackingNodeScorer   // optional, first-fit is used if not set
 }
 
 // estimationState contains helper variables to avoid coping them independently in each function.
@@ -44,6 +45,9 @@ type estimationState struct {
 	lastNodeName     string
 	newNodeNames     map[string]bool
 	newNodesWithPods map[string]bool
+	// nodeTemplates are set if the node group creates nodes of different shapes.
+	nodeTemplates    []*weightedNodeTemplate
+	lastNodeTemplate *weightedNodeTemplate
 }
 
 func (s *estimationState) trackScheduledPod(pod *apiv1.Pod, nodeName string) {
@@ -68,6 +72,21 @@ func NewBinpackingNodeEstimator(
 	}
 }
 
+// NewBestFitBinpackingNodeEstimator builds a new BinpackingNodeEstimator placing each pod on the best node
+// according to nodeScorer, instead of the first one it fits on.
+func NewBestFitBinpackingNodeEstimator(
+	clusterSnapshot clustersnapshot.ClusterSnapshot,
+	limiter EstimationLimiter,
+	podOrderer EstimationPodOrderer,
+	context EstimationContext,
+	estimationAnalyserFunc EstimationAnalyserFunc,
+	nodeScorer BinpackingNodeScorer,
+) *BinpackingNodeEstimator {
+	e := NewBinpackingNodeEstimator(clusterSnapshot, limiter, podOrderer, context, estimationAnalyserFunc)
+	e.nodeScorer = nodeScorer
+	return e
+}
+
 func newEstimationState() *estimationState {
 	return &estimationState{
 		scheduledPods:    []*apiv1.Pod{},
@@ -86,6 +105,10 @@ func newEstimationState() *estimationState {
 // While it is a multi-dimensional bin packing (cpu, mem, ports) in most cases the main dimension
 // will be cpu thus the estimated overprovisioning of 11/9 * optimal + 6/9 should be
 // still be maintained.
+// If a BinpackingNodeScorer is set, Best-Fit Decreasing is used instead: each pod is
+// placed on the node with the lowest score among the ones it fits on.
+// If the node group creates nodes of different shapes, new nodes are built from its weighted templates
+// in proportion to their weights instead of from nodeTemplate alone.
 // It is assumed that all pods from the given list can fit to nodeTemplate.
 // Returns the number of nodes needed to accommodate all pods from the list.
 func (e *BinpackingNodeEstimator) Estimate(
@@ -105,6 +128,7 @@ func (e *BinpackingNodeEstimator) Estimate(
 	}()
 
 	estimationState := newEstimationState()
+	estimationState.nodeTemplates = mixedNodeTemplates(nodeTemplate, nodeGroup)
 	for _, podsEquivalenceGroup := range podsEquivalenceGroups {
 		var err error
 		var remainingPods []*apiv1.Pod
@@ -137,9 +161,15 @@ func (e *BinpackingNodeEstimator) tryToScheduleOnExistingNodes(
 		pod := pods[index]
 
 		// Try to schedule the pod on all nodes created during simulation
-		nodeName, err := e.clusterSnapshot.SchedulePodOnAnyNodeMatching(pod, func(nodeInfo *framework.NodeInfo) bool {
-			return estimationState.newNodeNames[nodeInfo.Node().Name]
-		})
+		var nodeName string
+		var err clustersnapshot.SchedulingError
+		if e.nodeScorer != nil {
+			nodeName, err = e.schedulePodOnBestNode(estimationState, pod)
+		} else {
+			nodeName, err = e.clusterSnapshot.SchedulePodOnAnyNodeMatching(pod, func(nodeInfo *framework.NodeInfo) bool {
+				return estimationState.newNodeNames[nodeInfo.Node().Name]
+			})
+		}
 		if err != nil && err.Type() == clustersnapshot.SchedulingInternalError {
 			// Unexpected error.
 			return nil, err
@@ -153,6 +183,43 @@ func (e *BinpackingNodeEstimator) tryToScheduleOnExistingNodes(
 	return pods[index:], nil
 }
 
+// schedulePodOnBestNode schedules the pod on the node created during simulation with the lowest score
+// among the ones it fits on.
+func (e *BinpackingNodeEstimator) schedulePodOnBestNode(
+	estimationState *estimationState,
+	pod *apiv1.Pod,
+) (string, clustersnapshot.SchedulingError) {
+	type scoredNode struct {
+		name  string
+		score float64
+	}
+	var candidates []scoredNode
+	for nodeName := range estimationState.newNodeNames {
+		nodeInfo, err := e.clusterSnapshot.GetNodeInfo(nodeName)
+		if err != nil {
+			return "", clustersnapshot.NewSchedulingInternalError(pod, err.Error())
+		}
+		if score, fits := e.nodeScorer(pod, nodeInfo); fits {
+			candidates = append(candidates, scoredNode{name: nodeName, score: score})
+		}
+	}
+	// Break ties by name to keep the result deterministic.
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score < candidates[j].score
+		}
+		return candidates[i].name < candidates[j].name
+	})
+	for _, candidate := range candidates {
+		if err := e.clusterSnapshot.SchedulePod(pod, candidate.name); err == nil {
+			return candidate.name, nil
+		} else if err.Type() == clustersnapshot.SchedulingInternalError {
+			return "", err
+		}
+	}
+	return "", clustersnapshot.NewNoNodesPassingPredicatesFoundError(pod)
+}
+
 func (e *BinpackingNodeEstimator) tryToScheduleOnNewNodes(
 	estimationState *estimationState,
 	nodeTemplate *framework.NodeInfo,
@@ -188,12 +255,17 @@ func (e *BinpackingNodeEstimator) tryToScheduleOnNewNodes(
 			// The thresholdBasedEstimationLimiter implementation assumes that for
 			// each call that returns true, one node gets added. Therefore this
 			// must be the last check right before really adding a node.
-			if !e.limiter.PermissionToAddNode() {
+			if !e.permissionToAddNode(pod) {
 				break
 			}
 
 			// Add new node
-			if err := e.addNewNodeToSnapshot(estimationState, nodeTemplate); err != nil {
+			template := nodeTemplate
+			if len(estimationState.nodeTemplates) > 0 {
+				estimationState.lastNodeTemplate = nextNodeTemplate(estimationState.nodeTemplates)
+				template = estimationState.lastNodeTemplate.nodeInfo
+			}
+			if err := e.addNewNodeToSnapshot(estimationState, template); err != nil {
 				return fmt.Errorf("Error while adding new node for template to ClusterSnapshot; %w", err)
 			}
 
@@ -206,7 +278,12 @@ func (e *BinpackingNodeEstimator) tryToScheduleOnNewNodes(
 				return err
 			} else if err != nil {
 				// The pod can't be scheduled on the new node because of scheduling predicates.
-				break
+				// It might still fit on a node built from another one of the node group's templates.
+				if scheduled, err := e.replaceNewNodeForPod(estimationState, pod); err != nil {
+					return err
+				} else if !scheduled {
+					break
+				}
 			}
 			// The pod got scheduled on the new node.
 			estimationState.trackScheduledPod(pod, estimationState.lastNodeName)
@@ -215,6 +292,42 @@ func (e *BinpackingNodeEstimator) tryToScheduleOnNewNodes(
 	return nil
 }
 
+// permissionToAddNode asks the limiter for permission to add a node for the pod.
+func (e *BinpackingNodeEstimator) permissionToAddNode(pod *apiv1.Pod) bool {
+	if limiter, ok := e.limiter.(PodAwareEstimationLimiter); ok {
+		return limiter.PermissionToAddNodeForPod(pod)
+	}
+	return e.limiter.PermissionToAddNode()
+}
+
+// replaceNewNodeForPod replaces the last new node, which the pod doesn't fit on, with a node built from
+// another one of the node group's templates the pod fits on. It returns whether the pod got scheduled.
+func (e *BinpackingNodeEstimator) replaceNewNodeForPod(
+	estimationState *estimationState,
+	pod *apiv1.Pod,
+) (bool, error) {
+	failedTemplate := estimationState.lastNodeTemplate
+	for _, template := range estimationState.nodeTemplates {
+		if template == failedTemplate {
+			continue
+		}
+		if err := e.clusterSnapshot.RemoveNodeInfo(estimationState.lastNodeName); err != nil {
+			return false, err
+		}
+		delete(estimationState.newNodeNames, estimationState.lastNodeName)
+		if err := e.addNewNodeToSnapshot(estimationState, template.nodeInfo); err != nil {
+			return false, fmt.Errorf("Error while adding new node for template to ClusterSnapshot; %w", err)
+		}
+		estimationState.lastNodeTemplate = template
+		if err := e.clusterSnapshot.SchedulePod(pod, estimationState.lastNodeName); err == nil {
+			return true, nil
+		} else if err.Type() == clustersnapshot.SchedulingInternalError {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
 func (e *BinpackingNodeEstimator) addNewNodeToSnapshot(
 	estimationState *estimationState,
 	template *framework.NodeInfo,
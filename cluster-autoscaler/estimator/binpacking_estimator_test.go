@@ -17,6 +17,7 @@ limitations under the License.
 package estimator
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -25,8 +26,11 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot/testsnapshot"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
 	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/units"
 )
@@ -207,23 +211,133 @@ func TestBinpackingEstimate(t *testing.T) {
 		},
 	}
 	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			clusterSnapshot := testsnapshot.NewTestSnapshotOrDie(t)
-			// Add one node in different zone to trigger topology spread constraints
-			err := clusterSnapshot.AddNodeInfo(framework.NewTestNodeInfo(makeNode(100, 100, 10, "oldnode", "zone-jupiter")))
-			assert.NoError(t, err)
+		for _, bestFit := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s, best-fit: %v", tc.name, bestFit), func(t *testing.T) {
+				clusterSnapshot := testsnapshot.NewTestSnapshotOrDie(t)
+				// Add one node in different zone to trigger topology spread constraints
+				err := clusterSnapshot.AddNodeInfo(framework.NewTestNodeInfo(makeNode(100, 100, 10, "oldnode", "zone-jupiter")))
+				assert.NoError(t, err)
 
-			limiter := NewThresholdBasedEstimationLimiter([]Threshold{NewStaticThreshold(tc.maxNodes, time.Duration(0))})
-			processor := NewDecreasingPodOrderer()
-			estimator := NewBinpackingNodeEstimator(clusterSnapshot, limiter, processor, nil /* EstimationContext */, nil /* EstimationAnalyserFunc */)
-			node := makeNode(tc.millicores, tc.memory, 10, "template", "zone-mars")
-			nodeInfo := framework.NewTestNodeInfo(node)
+				limiter := NewThresholdBasedEstimationLimiter([]Threshold{NewStaticThreshold(tc.maxNodes, time.Duration(0))})
+				processor := NewDecreasingPodOrderer()
+				estimator := NewBinpackingNodeEstimator(clusterSnapshot, limiter, processor, nil /* EstimationContext */, nil /* EstimationAnalyserFunc */)
+				if bestFit {
+					estimator = NewBestFitBinpackingNodeEstimator(clusterSnapshot, limiter, processor, nil /* EstimationContext */, nil /* EstimationAnalyserFunc */, LeastRemainingResourcesNodeScorer)
+				}
+				node := makeNode(tc.millicores, tc.memory, 10, "template", "zone-mars")
+				nodeInfo := framework.NewTestNodeInfo(node)
 
-			estimatedNodes, estimatedPods := estimator.Estimate(tc.podsEquivalenceGroup, nodeInfo, nil)
-			assert.Equal(t, tc.expectNodeCount, estimatedNodes)
-			assert.Equal(t, tc.expectPodCount, len(estimatedPods))
-			if tc.expectProcessedPods != nil {
-				assert.Equal(t, tc.expectProcessedPods, estimatedPods)
+				estimatedNodes, estimatedPods := estimator.Estimate(tc.podsEquivalenceGroup, nodeInfo, nil)
+				assert.Equal(t, tc.expectNodeCount, estimatedNodes)
+				assert.Equal(t, tc.expectPodCount, len(estimatedPods))
+				if tc.expectProcessedPods != nil {
+					assert.Equal(t, tc.expectProcessedPods, estimatedPods)
+				}
+			})
+		}
+	}
+}
+
+func TestBestFitBinpackingEstimate(t *testing.T) {
+	// "large" fills one node, two "medium" pods fill the second node almost entirely. The "small" pod
+	// fits on both, best-fit should place it on the fuller second node.
+	large := BuildTestPod("large", 600, 600*units.MiB, WithNamespace("universe"))
+	medium := BuildTestPod("medium", 450, 450*units.MiB, WithNamespace("universe"))
+	small := BuildTestPod("small", 100, 100*units.MiB, WithNamespace("universe"))
+	podsEquivalenceGroups := []PodEquivalenceGroup{
+		makePodEquivalenceGroup(small, 1),
+		makePodEquivalenceGroup(medium, 2),
+		makePodEquivalenceGroup(large, 1),
+	}
+
+	clusterSnapshot := testsnapshot.NewTestSnapshotOrDie(t)
+	limiter := NewThresholdBasedEstimationLimiter([]Threshold{NewStaticThreshold(0, time.Duration(0))})
+	smallPodNode := ""
+	analyser := func(snapshot clustersnapshot.ClusterSnapshot, _ cloudprovider.NodeGroup, _ map[string]bool) {
+		nodeInfos, err := snapshot.ListNodeInfos()
+		assert.NoError(t, err)
+		for _, nodeInfo := range nodeInfos {
+			for _, podInfo := range nodeInfo.Pods() {
+				if podInfo.Pod.Name == "small" {
+					smallPodNode = nodeInfo.Node().Name
+				}
+			}
+		}
+	}
+	estimator := NewBestFitBinpackingNodeEstimator(clusterSnapshot, limiter, NewDecreasingPodOrderer(), nil /* EstimationContext */, analyser, LeastRemainingResourcesNodeScorer)
+	nodeInfo := framework.NewTestNodeInfo(makeNode(1000, 1000, 10, "template", "zone-mars"))
+
+	estimatedNodes, estimatedPods := estimator.Estimate(podsEquivalenceGroups, nodeInfo, nil)
+	assert.Equal(t, 2, estimatedNodes)
+	assert.Equal(t, 4, len(estimatedPods))
+	assert.Equal(t, "template-e-1", smallPodNode)
+}
+
+func TestLeastRemainingResourcesNodeScorer(t *testing.T) {
+	node := makeNode(1000, 1000, 10, "node", "zone-mars")
+	node.Status.Allocatable[gpu.ResourceNvidiaGPU] = *resource.NewQuantity(2, resource.DecimalSI)
+	nodeInfo := framework.NewTestNodeInfo(node, BuildTestPod("running", 500, 250*units.MiB))
+	storageNode := AddEphemeralStorageToNode(makeNode(1000, 1000, 10, "storage-node", "zone-mars"), 100*units.GiB)
+	storageNodeInfo := framework.NewTestNodeInfo(storageNode, BuildTestPodWithEphemeralStorage("running", 500, 250*units.MiB, 50*units.GiB))
+	gpuPod := func(gpus int64) *apiv1.Pod {
+		pod := BuildTestPod("p", 0, 0)
+		RequestGpuForPod(pod, gpus)
+		return pod
+	}
+
+	testCases := []struct {
+		name      string
+		pod       *apiv1.Pod
+		nodeInfo  *framework.NodeInfo
+		wantScore float64
+		wantFits  bool
+	}{
+		{
+			name:      "cpu and memory",
+			pod:       BuildTestPod("p", 250, 250*units.MiB),
+			wantScore: 0.25 + 0.5 + 1,
+			wantFits:  true,
+		},
+		{
+			name:      "gpu",
+			pod:       gpuPod(1),
+			wantScore: 0.5 + 0.75 + 0.5,
+			wantFits:  true,
+		},
+		{
+			name: "too much cpu",
+			pod:  BuildTestPod("p", 600, 0),
+		},
+		{
+			name: "too many gpus",
+			pod:  gpuPod(3),
+		},
+		{
+			name:      "ephemeral storage",
+			pod:       BuildTestPodWithEphemeralStorage("p", 250, 250*units.MiB, 25*units.GiB),
+			nodeInfo:  storageNodeInfo,
+			wantScore: 0.25 + 0.5 + 0.25,
+			wantFits:  true,
+		},
+		{
+			name:     "too much ephemeral storage",
+			pod:      BuildTestPodWithEphemeralStorage("p", 250, 250*units.MiB, 60*units.GiB),
+			nodeInfo: storageNodeInfo,
+		},
+		{
+			name: "ephemeral storage on node without it",
+			pod:  BuildTestPodWithEphemeralStorage("p", 250, 250*units.MiB, 1*units.GiB),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.nodeInfo == nil {
+				tc.nodeInfo = nodeInfo
+			}
+			score, fits := LeastRemainingResourcesNodeScorer(tc.pod, tc.nodeInfo)
+			assert.Equal(t, tc.wantFits, fits)
+			if tc.wantFits {
+				assert.InDelta(t, tc.wantScore, score, 1e-9)
 			}
 		})
 	}
@@ -277,3 +391,66 @@ func BenchmarkBinpackingEstimate(b *testing.B) {
 		assert.Equal(b, expectPodCount, len(estimatedPods))
 	}
 }
+
+type mixedTemplatesNodeGroup struct {
+	cloudprovider.NodeGroup
+	templates []cloudprovider.WeightedTemplateNodeInfo
+}
+
+func (ng *mixedTemplatesNodeGroup) WeightedTemplateNodeInfos() ([]cloudprovider.WeightedTemplateNodeInfo, error) {
+	return ng.templates, nil
+}
+
+func TestBinpackingEstimateMixedTemplates(t *testing.T) {
+	testCases := []struct {
+		name            string
+		smallWeight     int
+		largeWeight     int
+		pods            []PodEquivalenceGroup
+		expectNodeCount int
+		expectPodCount  int
+	}{
+		{
+			name:            "new nodes follow the template weights",
+			smallWeight:     1,
+			largeWeight:     1,
+			pods:            []PodEquivalenceGroup{makePodEquivalenceGroup(BuildTestPod("estimatee", 500, 100), 10)},
+			expectNodeCount: 2,
+			expectPodCount:  10,
+		},
+		{
+			name:            "only small nodes",
+			smallWeight:     1,
+			largeWeight:     0,
+			pods:            []PodEquivalenceGroup{makePodEquivalenceGroup(BuildTestPod("estimatee", 500, 100), 10)},
+			expectNodeCount: 5,
+			expectPodCount:  10,
+		},
+		{
+			name:            "pods not fitting the picked template",
+			smallWeight:     3,
+			largeWeight:     1,
+			pods:            []PodEquivalenceGroup{makePodEquivalenceGroup(BuildTestPod("estimatee", 2000, 100), 2)},
+			expectNodeCount: 1,
+			expectPodCount:  2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clusterSnapshot := testsnapshot.NewTestSnapshotOrDie(t)
+			limiter := NewThresholdBasedEstimationLimiter([]Threshold{NewStaticThreshold(0, time.Duration(0))})
+			estimator := NewBinpackingNodeEstimator(clusterSnapshot, limiter, NewDecreasingPodOrderer(), nil /* EstimationContext */, nil /* EstimationAnalyserFunc */)
+			nodeGroup := &mixedTemplatesNodeGroup{
+				templates: []cloudprovider.WeightedTemplateNodeInfo{
+					{NodeInfo: framework.NewTestNodeInfo(makeNode(1000, 1000, 10, "small", "zone-mars")), Weight: tc.smallWeight},
+					{NodeInfo: framework.NewTestNodeInfo(makeNode(4000, 1000, 10, "large", "zone-mars")), Weight: tc.largeWeight},
+				},
+			}
+			nodeInfo := framework.NewTestNodeInfo(makeNode(1000, 1000, 10, "template", "zone-mars"))
+
+			estimatedNodes, estimatedPods := estimator.Estimate(tc.pods, nodeInfo, nodeGroup)
+			assert.Equal(t, tc.expectNodeCount, estimatedNodes)
+			assert.Equal(t, tc.expectPodCount, len(estimatedPods))
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
 
 	podutils "k8s.io/autoscaler/cluster-autoscaler/utils/pod"
 )
@@ -35,6 +36,8 @@ type podScoreInfo struct {
 // DecreasingPodOrderer is the default implementation of the EstimationPodOrderer
 // It implements sorting pods by pod score in decreasing order
 type DecreasingPodOrderer struct {
+	// gpuAware makes GPU requests count towards the pod score.
+	gpuAware bool
 }
 
 // NewDecreasingPodOrderer returns the object of DecreasingPodOrderer
@@ -42,6 +45,12 @@ func NewDecreasingPodOrderer() *DecreasingPodOrderer {
 	return &DecreasingPodOrderer{}
 }
 
+// NewGpuAwareDecreasingPodOrderer returns a DecreasingPodOrderer which also takes
+// GPU requests into account on nodes with GPUs.
+func NewGpuAwareDecreasingPodOrderer() *DecreasingPodOrderer {
+	return &DecreasingPodOrderer{gpuAware: true}
+}
+
 // Order is the processing func that sorts the pods based on the size of the pod
 func (d *DecreasingPodOrderer) Order(podsEquivalentGroups []PodEquivalenceGroup, nodeTemplate *framework.NodeInfo, _ cloudprovider.NodeGroup) []PodEquivalenceGroup {
 	podInfos := make([]*podScoreInfo, 0, len(podsEquivalentGroups))
@@ -58,7 +67,8 @@ func (d *DecreasingPodOrderer) Order(podsEquivalentGroups []PodEquivalenceGroup,
 }
 
 // calculatePodScore score for  pod and returns podScoreInfo structure.
-// Score is defined as cpu_sum/node_capacity + mem_sum/node_capacity.
+// Score is defined as cpu_sum/node_capacity + mem_sum/node_capacity, plus ephemeral_storage_sum/node_capacity
+// for nodes with ephemeral storage and, if the orderer is GPU aware, gpu_sum/node_capacity for nodes with GPUs.
 // Pods that have bigger requirements should be processed first, thus have higher scores.
 func (d *DecreasingPodOrderer) calculatePodScore(podsEquivalentGroup PodEquivalenceGroup, nodeTemplate *framework.NodeInfo) *podScoreInfo {
 	samplePod := podsEquivalentGroup.Exemplar()
@@ -80,6 +90,14 @@ func (d *DecreasingPodOrderer) calculatePodScore(podsEquivalentGroup PodEquivale
 	if memAllocatable, ok := nodeTemplate.Node().Status.Allocatable[apiv1.ResourceMemory]; ok && memAllocatable.Value() > 0 {
 		score += float64(podMemory.Value()) / float64(memAllocatable.Value())
 	}
+	if gpuAllocatable, ok := nodeTemplate.Node().Status.Allocatable[gpu.ResourceNvidiaGPU]; d.gpuAware && ok && gpuAllocatable.Value() > 0 {
+		podGpu := podRequests[gpu.ResourceNvidiaGPU]
+		score += float64(podGpu.Value()) / float64(gpuAllocatable.Value())
+	}
+	if storageAllocatable, ok := nodeTemplate.Node().Status.Allocatable[apiv1.ResourceEphemeralStorage]; ok && storageAllocatable.Value() > 0 {
+		podStorage := podRequests[apiv1.ResourceEphemeralStorage]
+		score += float64(podStorage.Value()) / float64(storageAllocatable.Value())
+	}
 
 	return &podScoreInfo{
 		score:               score,
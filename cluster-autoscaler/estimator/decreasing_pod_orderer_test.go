@@ -21,8 +21,11 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/units"
 )
 
 func TestPodPriorityProcessor(t *testing.T) {
@@ -63,3 +66,27 @@ func TestPodPriorityProcessor(t *testing.T) {
 		})
 	}
 }
+
+func TestPodPriorityProcessorGpu(t *testing.T) {
+	gpuPod := test.BuildTestPod("gpu", 1, 1)
+	test.RequestGpuForPod(gpuPod, 1)
+	pg1 := PodEquivalenceGroup{Pods: []*v1.Pod{gpuPod}}
+	pg2 := PodEquivalenceGroup{Pods: []*v1.Pod{test.BuildTestPod("p2", 2, 100)}}
+	node := makeNode(4, 600, 10, "node1", "zone-sun")
+	node.Status.Allocatable[gpu.ResourceNvidiaGPU] = *resource.NewQuantity(1, resource.DecimalSI)
+
+	actual := NewGpuAwareDecreasingPodOrderer().Order([]PodEquivalenceGroup{pg2, pg1}, framework.NewTestNodeInfo(node), nil)
+	assert.Equal(t, []PodEquivalenceGroup{pg1, pg2}, actual)
+
+	actual = NewDecreasingPodOrderer().Order([]PodEquivalenceGroup{pg1, pg2}, framework.NewTestNodeInfo(node), nil)
+	assert.Equal(t, []PodEquivalenceGroup{pg2, pg1}, actual)
+}
+
+func TestPodPriorityProcessorEphemeralStorage(t *testing.T) {
+	pg1 := PodEquivalenceGroup{Pods: []*v1.Pod{test.BuildTestPodWithEphemeralStorage("storage", 1, 1, 80*units.GiB)}}
+	pg2 := PodEquivalenceGroup{Pods: []*v1.Pod{test.BuildTestPod("p2", 2, 100)}}
+	node := test.AddEphemeralStorageToNode(makeNode(4, 600, 10, "node1", "zone-sun"), 100*units.GiB)
+
+	actual := NewDecreasingPodOrderer().Order([]PodEquivalenceGroup{pg2, pg1}, framework.NewTestNodeInfo(node), nil)
+	assert.Equal(t, []PodEquivalenceGroup{pg1, pg2}, actual)
+}
@@ -28,10 +28,13 @@ import (
 const (
 	// BinpackingEstimatorName is the name of binpacking estimator.
 	BinpackingEstimatorName = "binpacking"
+	// BestFitBinpackingEstimatorName is the name of binpacking estimator placing pods on the node
+	// that leaves the least CPU, memory and GPU unused, instead of the first node they fit on.
+	BestFitBinpackingEstimatorName = "binpacking-best-fit"
 )
 
 // AvailableEstimators is a list of available estimators.
-var AvailableEstimators = []string{BinpackingEstimatorName}
+var AvailableEstimators = []string{BinpackingEstimatorName, BestFitBinpackingEstimatorName}
 
 // PodEquivalenceGroup represents a group of pods, which have the same scheduling
 // requirements and are managed by the same controller.
@@ -63,12 +66,25 @@ type EstimationAnalyserFunc func(clustersnapshot.ClusterSnapshot, cloudprovider.
 
 // NewEstimatorBuilder creates a new estimator object from flag.
 func NewEstimatorBuilder(name string, limiter EstimationLimiter, orderer EstimationPodOrderer, estimationAnalyserFunc EstimationAnalyserFunc) (EstimatorBuilder, error) {
+	return NewConcurrentEstimatorBuilder(name, func() EstimationLimiter { return limiter }, orderer, estimationAnalyserFunc)
+}
+
+// NewConcurrentEstimatorBuilder creates a new estimator object from flag, building a new limiter
+// with newLimiter for every estimator. Estimators created by the returned builder don't share
+// any state, so they can run concurrently on separate cluster snapshots.
+func NewConcurrentEstimatorBuilder(name string, newLimiter func() EstimationLimiter, orderer EstimationPodOrderer, estimationAnalyserFunc EstimationAnalyserFunc) (EstimatorBuilder, error) {
 	switch name {
 	case BinpackingEstimatorName:
 		return func(
 			clusterSnapshot clustersnapshot.ClusterSnapshot,
 			context EstimationContext) Estimator {
-			return NewBinpackingNodeEstimator(clusterSnapshot, limiter, orderer, context, estimationAnalyserFunc)
+			return NewBinpackingNodeEstimator(clusterSnapshot, newLimiter(), orderer, context, estimationAnalyserFunc)
+		}, nil
+	case BestFitBinpackingEstimatorName:
+		return func(
+			clusterSnapshot clustersnapshot.ClusterSnapshot,
+			context EstimationContext) Estimator {
+			return NewBestFitBinpackingNodeEstimator(clusterSnapshot, newLimiter(), orderer, context, estimationAnalyserFunc, LeastRemainingResourcesNodeScorer)
 		}, nil
 	}
 	return nil, fmt.Errorf("unknown estimator: %s", name)
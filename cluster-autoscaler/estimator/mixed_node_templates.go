@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package estimator
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/klog/v2"
+)
+
+// weightedNodeTemplate is one of the templates new nodes of a node group creating nodes of different shapes
+// are built from.
+type weightedNodeTemplate struct {
+	nodeInfo      *framework.NodeInfo
+	weight        int
+	currentWeight int
+}
+
+// mixedNodeTemplates returns the weighted templates of the node group if it creates nodes of different shapes.
+// The templates are derived from nodeTemplate, so that they have the same pods, labels and taints, only the
+// capacity and the instance type are taken from the node group's templates.
+func mixedNodeTemplates(nodeTemplate *framework.NodeInfo, nodeGroup cloudprovider.NodeGroup) []*weightedNodeTemplate {
+	mixed, ok := nodeGroup.(cloudprovider.MixedTemplatesNodeGroup)
+	if !ok {
+		return nil
+	}
+	templates, err := mixed.WeightedTemplateNodeInfos()
+	if err != nil {
+		klog.Warningf("Failed to get weighted templates of node group %s, using a single template: %v", nodeGroup.Id(), err)
+		return nil
+	}
+	if len(templates) < 2 {
+		return nil
+	}
+	var result []*weightedNodeTemplate
+	for _, template := range templates {
+		node := nodeTemplate.Node().DeepCopy()
+		applyNodeShape(node, template.NodeInfo.Node())
+		result = append(result, &weightedNodeTemplate{
+			nodeInfo: framework.NewNodeInfo(node, nodeTemplate.LocalResourceSlices, nodeTemplate.Pods()...),
+			weight:   template.Weight,
+		})
+	}
+	return result
+}
+
+// applyNodeShape sets the capacity and the instance type of the node to the ones of shape. Resources reserved
+// on the node, i.e. the difference between its capacity and allocatable, are kept reserved in place of the ones
+// reserved on shape.
+func applyNodeShape(node, shape *apiv1.Node) {
+	capacity := shape.Status.Capacity.DeepCopy()
+	allocatable := shape.Status.Allocatable.DeepCopy()
+	for name := range allocatable {
+		shapeCapacity, hasShapeCapacity := capacity[name]
+		nodeCapacity, hasCapacity := node.Status.Capacity[name]
+		nodeAllocatable, hasAllocatable := node.Status.Allocatable[name]
+		if !hasShapeCapacity || !hasCapacity || !hasAllocatable {
+			continue
+		}
+		quantity := shapeCapacity.DeepCopy()
+		quantity.Sub(nodeCapacity)
+		quantity.Add(nodeAllocatable)
+		if quantity.Sign() < 0 {
+			quantity.Set(0)
+		}
+		allocatable[name] = quantity
+	}
+	node.Status.Capacity = capacity
+	node.Status.Allocatable = allocatable
+
+	for _, label := range []string{apiv1.LabelInstanceTypeStable, apiv1.LabelInstanceType} {
+		if value, found := shape.Labels[label]; found {
+			if node.Labels == nil {
+				node.Labels = make(map[string]string)
+			}
+			node.Labels[label] = value
+		}
+	}
+}
+
+// nextNodeTemplate picks the template of the next new node with smooth weighted round-robin, so that the
+// shares of new nodes built from each template follow their weights.
+func nextNodeTemplate(templates []*weightedNodeTemplate) *weightedNodeTemplate {
+	var next *weightedNodeTemplate
+	total := 0
+	for _, template := range templates {
+		template.currentWeight += template.weight
+		total += template.weight
+		if next == nil || template.currentWeight > next.currentWeight {
+			next = template
+		}
+	}
+	next.currentWeight -= total
+	return next
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package estimator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestNextNodeTemplate(t *testing.T) {
+	a := &weightedNodeTemplate{weight: 3}
+	b := &weightedNodeTemplate{weight: 1}
+	c := &weightedNodeTemplate{weight: 0}
+	templates := []*weightedNodeTemplate{a, b, c}
+
+	var picked []*weightedNodeTemplate
+	for i := 0; i < 8; i++ {
+		picked = append(picked, nextNodeTemplate(templates))
+	}
+	assert.Equal(t, []*weightedNodeTemplate{a, a, b, a, a, a, b, a}, picked)
+}
+
+func TestApplyNodeShape(t *testing.T) {
+	node := makeNode(2000, 4000, 10, "template", "zone-mars")
+	node.Labels[apiv1.LabelInstanceTypeStable] = "m5.large"
+	node.Status.Allocatable = node.Status.Capacity.DeepCopy()
+	node.Status.Allocatable[apiv1.ResourceCPU] = *resource.NewMilliQuantity(1900, resource.DecimalSI)
+
+	shape := makeNode(8000, 16000, 10, "shape", "zone-mars")
+	shape.Labels[apiv1.LabelInstanceTypeStable] = "m5.2xlarge"
+
+	applyNodeShape(node, shape)
+	assert.Equal(t, "m5.2xlarge", node.Labels[apiv1.LabelInstanceTypeStable])
+	assert.Equal(t, "template", node.Labels[apiv1.LabelHostname])
+	assert.Equal(t, int64(8000), node.Status.Capacity.Cpu().MilliValue())
+	assert.Equal(t, int64(7900), node.Status.Allocatable.Cpu().MilliValue())
+	assert.Equal(t, shape.Status.Capacity.Memory().Value(), node.Status.Allocatable.Memory().Value())
+}
+
+func TestApplyNodeShapeWithReservedResources(t *testing.T) {
+	node := makeNode(2000, 4000, 10, "template", "zone-mars")
+	node.Status.Allocatable = node.Status.Capacity.DeepCopy()
+	node.Status.Allocatable[apiv1.ResourceCPU] = *resource.NewMilliQuantity(1900, resource.DecimalSI)
+
+	shape := makeNode(8000, 16000, 10, "shape", "zone-mars")
+	shape.Status.Allocatable = shape.Status.Capacity.DeepCopy()
+	shape.Status.Allocatable[apiv1.ResourceCPU] = *resource.NewMilliQuantity(7500, resource.DecimalSI)
+	shape.Status.Allocatable[apiv1.ResourcePods] = *resource.NewQuantity(5, resource.DecimalSI)
+
+	applyNodeShape(node, shape)
+	assert.Equal(t, int64(8000), node.Status.Capacity.Cpu().MilliValue())
+	assert.Equal(t, int64(7900), node.Status.Allocatable.Cpu().MilliValue())
+	assert.Equal(t, int64(10), node.Status.Allocatable.Pods().Value())
+}
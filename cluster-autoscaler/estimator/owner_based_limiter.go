@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package estimator
+
+import (
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	klog "k8s.io/klog/v2"
+)
+
+// PodAwareEstimationLimiter is an EstimationLimiter which also limits nodes
+// added for specific pods.
+type PodAwareEstimationLimiter interface {
+	EstimationLimiter
+	// PermissionToAddNodeForPod is called by an estimator instead of
+	// PermissionToAddNode when it wants to add a node to schedule the given pod.
+	PermissionToAddNodeForPod(pod *apiv1.Pod) bool
+}
+
+type ownerBasedEstimationLimiter struct {
+	limiter          EstimationLimiter
+	maxNodesPerOwner int
+	nodesPerOwner    map[podOwner]int
+}
+
+// podOwner identifies the workload owning a pod.
+type podOwner struct {
+	namespace string
+	kind      string
+	name      string
+}
+
+// NewOwnerBasedEstimationLimiter returns an EstimationLimiter that prevents adding
+// more than maxNodesPerOwner nodes for pods of a single controller in one
+// estimation, on top of the limits of the given limiter. Pods of all
+// ReplicaSets of a Deployment count towards the limit of the Deployment. Nodes
+// added for pods without a controller are only limited by the given limiter.
+func NewOwnerBasedEstimationLimiter(limiter EstimationLimiter, maxNodesPerOwner int) PodAwareEstimationLimiter {
+	return &ownerBasedEstimationLimiter{
+		limiter:          limiter,
+		maxNodesPerOwner: maxNodesPerOwner,
+	}
+}
+
+func (obel *ownerBasedEstimationLimiter) StartEstimation(podsEquivalenceGroups []PodEquivalenceGroup, nodeGroup cloudprovider.NodeGroup, context EstimationContext) {
+	obel.nodesPerOwner = make(map[podOwner]int)
+	obel.limiter.StartEstimation(podsEquivalenceGroups, nodeGroup, context)
+}
+
+func (obel *ownerBasedEstimationLimiter) EndEstimation() {
+	obel.limiter.EndEstimation()
+}
+
+func (obel *ownerBasedEstimationLimiter) PermissionToAddNode() bool {
+	return obel.limiter.PermissionToAddNode()
+}
+
+func (obel *ownerBasedEstimationLimiter) PermissionToAddNodeForPod(pod *apiv1.Pod) bool {
+	owner, found := ownerOf(pod)
+	if !found {
+		return obel.limiter.PermissionToAddNode()
+	}
+	if obel.nodesPerOwner[owner] >= obel.maxNodesPerOwner {
+		klog.V(4).Infof("Capping binpacking after adding %d nodes for pods of %s %s/%s", obel.nodesPerOwner[owner], owner.kind, owner.namespace, owner.name)
+		return false
+	}
+	if !obel.limiter.PermissionToAddNode() {
+		return false
+	}
+	obel.nodesPerOwner[owner]++
+	return true
+}
+
+// ownerOf returns the controller of the pod, or the Deployment owning it if the
+// controller is a ReplicaSet created by a Deployment. Such ReplicaSets are named
+// after the Deployment and the pod template hash, which is also set on the pods.
+func ownerOf(pod *apiv1.Pod) (podOwner, bool) {
+	controller := metav1.GetControllerOf(pod)
+	if controller == nil {
+		return podOwner{}, false
+	}
+	owner := podOwner{namespace: pod.Namespace, kind: controller.Kind, name: controller.Name}
+	if hash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; controller.Kind == "ReplicaSet" && hash != "" {
+		if deployment, found := strings.CutSuffix(controller.Name, "-"+hash); found && deployment != "" {
+			owner.kind = "Deployment"
+			owner.name = deployment
+		}
+	}
+	return owner, true
+}
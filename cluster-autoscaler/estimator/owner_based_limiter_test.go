@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package estimator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot/testsnapshot"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/units"
+)
+
+func TestOwnerBasedLimiter(t *testing.T) {
+	podA := BuildTestPod("a", 100, 100, WithControllerOwnerRef("rs-a", "ReplicaSet", "uid-a"))
+	podB := BuildTestPod("b", 100, 100, WithControllerOwnerRef("rs-b", "ReplicaSet", "uid-b"))
+	podC := BuildTestPod("c", 100, 100)
+
+	limiter := NewOwnerBasedEstimationLimiter(NewThresholdBasedEstimationLimiter([]Threshold{NewStaticThreshold(4, 0)}), 2)
+	limiter.StartEstimation(nil, nil, nil)
+	assert.True(t, limiter.PermissionToAddNodeForPod(podA))
+	assert.True(t, limiter.PermissionToAddNodeForPod(podA))
+	assert.False(t, limiter.PermissionToAddNodeForPod(podA))
+	assert.True(t, limiter.PermissionToAddNodeForPod(podB))
+	assert.True(t, limiter.PermissionToAddNodeForPod(podC))
+	// The limit of the wrapped limiter is reached.
+	assert.False(t, limiter.PermissionToAddNodeForPod(podB))
+	assert.False(t, limiter.PermissionToAddNodeForPod(podC))
+	limiter.EndEstimation()
+
+	// Limits are reset for every estimation.
+	limiter.StartEstimation(nil, nil, nil)
+	assert.True(t, limiter.PermissionToAddNodeForPod(podA))
+	assert.True(t, limiter.PermissionToAddNode())
+	limiter.EndEstimation()
+}
+
+func TestOwnerBasedLimiterDuringRollout(t *testing.T) {
+	oldPod := BuildTestPod("old", 100, 100, WithControllerOwnerRef("deployment-5d4f8", "ReplicaSet", "uid-old"), WithLabels(map[string]string{"pod-template-hash": "5d4f8"}))
+	newPod := BuildTestPod("new", 100, 100, WithControllerOwnerRef("deployment-7c9b2", "ReplicaSet", "uid-new"), WithLabels(map[string]string{"pod-template-hash": "7c9b2"}))
+	otherPod := BuildTestPod("other", 100, 100, WithControllerOwnerRef("rs-other", "ReplicaSet", "uid-other"))
+
+	limiter := NewOwnerBasedEstimationLimiter(NewThresholdBasedEstimationLimiter(nil), 2)
+	limiter.StartEstimation(nil, nil, nil)
+	assert.True(t, limiter.PermissionToAddNodeForPod(oldPod))
+	assert.True(t, limiter.PermissionToAddNodeForPod(newPod))
+	// Both ReplicaSets belong to the same Deployment.
+	assert.False(t, limiter.PermissionToAddNodeForPod(newPod))
+	assert.False(t, limiter.PermissionToAddNodeForPod(oldPod))
+	assert.True(t, limiter.PermissionToAddNodeForPod(otherPod))
+	limiter.EndEstimation()
+}
+
+func TestBinpackingEstimateWithOwnerBasedLimiter(t *testing.T) {
+	podA := BuildTestPod("a", 600, 600*units.MiB, WithControllerOwnerRef("rs-a", "ReplicaSet", "uid-a"))
+	podB := BuildTestPod("b", 600, 600*units.MiB, WithControllerOwnerRef("rs-b", "ReplicaSet", "uid-b"))
+	podsEquivalenceGroups := []PodEquivalenceGroup{
+		makePodEquivalenceGroup(podA, 5),
+		makePodEquivalenceGroup(podB, 5),
+	}
+
+	clusterSnapshot := testsnapshot.NewTestSnapshotOrDie(t)
+	limiter := NewOwnerBasedEstimationLimiter(NewThresholdBasedEstimationLimiter([]Threshold{NewStaticThreshold(0, time.Duration(0))}), 2)
+	estimator := NewBinpackingNodeEstimator(clusterSnapshot, limiter, NewDecreasingPodOrderer(), nil /* EstimationContext */, nil /* EstimationAnalyserFunc */)
+	nodeInfo := framework.NewTestNodeInfo(makeNode(1000, 1000, 10, "template", "zone-mars"))
+
+	estimatedNodes, estimatedPods := estimator.Estimate(podsEquivalenceGroups, nodeInfo, nil)
+	assert.Equal(t, 4, estimatedNodes)
+	assert.Equal(t, []*apiv1.Pod{podA, podA, podB, podB}, estimatedPods)
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package estimator
+
+import (
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+)
+
+type subnetCapacityThreshold struct {
+	checker cloudprovider.SubnetCapacityChecker
+}
+
+// NodeLimit returns maximum number of new nodes that can be added to the node group and similar node
+// groups based on the free IP addresses of their subnets. Possible return values are:
+//   - -1 when the subnets of this node group AND similar node groups have no free IP addresses
+//   - 0 when the free IP addresses of any of them are unknown. Return value of 0 means that there is no limit.
+//   - Any positive number representing maximum possible number of new nodes
+func (t *subnetCapacityThreshold) NodeLimit(nodeGroup cloudprovider.NodeGroup, context EstimationContext) int {
+	nodeGroups := []cloudprovider.NodeGroup{nodeGroup}
+	if context != nil {
+		nodeGroups = append(nodeGroups, context.SimilarNodeGroups()...)
+	}
+	totalFreeCapacity := 0
+	for _, ng := range nodeGroups {
+		free, known := t.checker.FreeSubnetCapacity(ng)
+		if !known {
+			return 0
+		}
+		if free > 0 {
+			totalFreeCapacity += free
+		}
+	}
+	if totalFreeCapacity <= 0 {
+		return -1
+	}
+	return totalFreeCapacity
+}
+
+// DurationLimit always returns 0 for this threshold, meaning that no limit is set.
+func (t *subnetCapacityThreshold) DurationLimit(cloudprovider.NodeGroup, EstimationContext) time.Duration {
+	return 0
+}
+
+// NewSubnetCapacityThreshold returns a Threshold that can be used to limit binpacking
+// by the free IP addresses of the subnets of node groups
+func NewSubnetCapacityThreshold(checker cloudprovider.SubnetCapacityChecker) Threshold {
+	return &subnetCapacityThreshold{checker: checker}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package estimator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+)
+
+type fakeSubnetCapacityChecker map[string]int
+
+func (c fakeSubnetCapacityChecker) FreeSubnetCapacity(nodeGroup cloudprovider.NodeGroup) (int, bool) {
+	free, found := c[nodeGroup.Id()]
+	return free, found
+}
+
+func TestSubnetCapacityThreshold(t *testing.T) {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	for _, id := range []string{"main-ng", "ng1", "ng2", "unknown"} {
+		provider.AddNodeGroup(id, 0, 100, 0)
+	}
+	nodeGroups := func(ids ...string) []cloudprovider.NodeGroup {
+		var result []cloudprovider.NodeGroup
+		for _, id := range ids {
+			result = append(result, provider.GetNodeGroup(id))
+		}
+		return result
+	}
+
+	for _, tc := range []struct {
+		name              string
+		free              fakeSubnetCapacityChecker
+		similarNodeGroups []cloudprovider.NodeGroup
+		wantThreshold     int
+	}{
+		{
+			name:          "free capacity of the node group",
+			free:          fakeSubnetCapacityChecker{"main-ng": 5},
+			wantThreshold: 5,
+		},
+		{
+			name:              "free capacity of similar node groups",
+			free:              fakeSubnetCapacityChecker{"main-ng": 5, "ng1": 0, "ng2": 10},
+			similarNodeGroups: nodeGroups("ng1", "ng2"),
+			wantThreshold:     15,
+		},
+		{
+			name:              "no free capacity",
+			free:              fakeSubnetCapacityChecker{"main-ng": 0, "ng1": 0},
+			similarNodeGroups: nodeGroups("ng1"),
+			wantThreshold:     -1,
+		},
+		{
+			name:          "unknown free capacity",
+			free:          fakeSubnetCapacityChecker{},
+			wantThreshold: 0,
+		},
+		{
+			name:              "unknown free capacity of a similar node group",
+			free:              fakeSubnetCapacityChecker{"main-ng": 0},
+			similarNodeGroups: nodeGroups("unknown"),
+			wantThreshold:     0,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			context := estimationContext{similarNodeGroups: tc.similarNodeGroups}
+			threshold := NewSubnetCapacityThreshold(tc.free)
+			assert.Equal(t, tc.wantThreshold, threshold.NodeLimit(provider.GetNodeGroup("main-ng"), &context))
+			assert.Zero(t, threshold.DurationLimit(provider.GetNodeGroup("main-ng"), &context))
+		})
+	}
+}
@@ -24,7 +24,7 @@ import (
 
 var (
 	// AvailableExpanders is a list of available expander options
-	AvailableExpanders = []string{RandomExpanderName, MostPodsExpanderName, LeastWasteExpanderName, PriceBasedExpanderName, PriorityBasedExpanderName, GRPCExpanderName}
+	AvailableExpanders = []string{RandomExpanderName, MostPodsExpanderName, LeastWasteExpanderName, PriceBasedExpanderName, PriceLiveExpanderName, PriorityBasedExpanderName, GRPCExpanderName, ReservationBasedExpanderName, HealthExpanderName}
 	// RandomExpanderName selects a node group at random
 	RandomExpanderName = "random"
 	// MostPodsExpanderName selects a node group that fits the most pods
@@ -36,10 +36,17 @@ var (
 	// PriceBasedExpanderName selects a node group that is the most cost-effective and consistent with
 	// the preferred node size for the cluster
 	PriceBasedExpanderName = "price"
+	// PriceLiveExpanderName works like the price expander, but uses prices fetched at runtime from public
+	// pricing APIs of the cloud provider instead of the cloud provider's pricing model
+	PriceLiveExpanderName = "price-live"
 	// PriorityBasedExpanderName selects a node group based on a user-configured priorities assigned to group names
 	PriorityBasedExpanderName = "priority"
 	// GRPCExpanderName uses the gRPC client expander to call to an external gRPC server to select a node group for scale up
 	GRPCExpanderName = "grpc"
+	// ReservationBasedExpanderName selects node groups whose capacity reservations have room for the new nodes
+	ReservationBasedExpanderName = "reservation"
+	// HealthExpanderName selects node groups with the highest health score, based on their recent scale-ups
+	HealthExpanderName = "health"
 )
 
 // Option describes an option to expand the cluster.
@@ -60,3 +67,10 @@ type Strategy interface {
 type Filter interface {
 	BestOptions(options []Option, nodeInfo map[string]*framework.NodeInfo) []Option
 }
+
+// Scorer describes an interface for scoring options, so that scores of multiple expanders can be combined
+// with weights. Lower scores are better. Options that can't be scored are omitted from the returned map,
+// which is keyed by node group id.
+type Scorer interface {
+	ScoreOptions(options []Option, nodeInfo map[string]*framework.NodeInfo) map[string]float64
+}
@@ -17,6 +17,12 @@ limitations under the License.
 package factory
 
 import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/context"
 	"k8s.io/autoscaler/cluster-autoscaler/expander"
@@ -24,9 +30,12 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/expander/leastnodes"
 	"k8s.io/autoscaler/cluster-autoscaler/expander/mostpods"
 	"k8s.io/autoscaler/cluster-autoscaler/expander/price"
+	"k8s.io/autoscaler/cluster-autoscaler/expander/pricelive"
 	"k8s.io/autoscaler/cluster-autoscaler/expander/priority"
 	"k8s.io/autoscaler/cluster-autoscaler/expander/random"
+	"k8s.io/autoscaler/cluster-autoscaler/expander/reservation"
 	"k8s.io/autoscaler/cluster-autoscaler/expander/waste"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/backoff"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
 
@@ -36,27 +45,49 @@ import (
 
 // Factory can create expander.Strategy based on provided expander names.
 type Factory struct {
-	createFunc map[string]func() expander.Filter
+	createFunc map[string]func() (expander.Filter, error)
 }
 
 // NewFactory returns a new Factory.
 func NewFactory() *Factory {
 	return &Factory{
-		createFunc: make(map[string]func() expander.Filter),
+		createFunc: make(map[string]func() (expander.Filter, error)),
 	}
 }
 
 // RegisterFilter registers a function that can provision a new expander.Filter under the specified name.
 func (f *Factory) RegisterFilter(name string, createFunc func() expander.Filter) {
+	f.createFunc[name] = func() (expander.Filter, error) {
+		return createFunc(), nil
+	}
+}
+
+// RegisterFallibleFilter registers a function that can provision a new expander.Filter under the specified name,
+// or fail if the expander can't be used with the current configuration. The error is returned by Build, so that
+// expanders changed at runtime don't crash CA.
+func (f *Factory) RegisterFallibleFilter(name string, createFunc func() (expander.Filter, error)) {
 	f.createFunc[name] = createFunc
 }
 
-// Build creates a new expander.Strategy based on a list of expander.Filter names.
+// IsRegistered returns whether an expander.Filter is registered under the specified name.
+func (f *Factory) IsRegistered(name string) bool {
+	_, found := f.createFunc[name]
+	return found
+}
+
+// Build creates a new expander.Strategy based on a list of expander.Filter names. Consecutive names followed
+// by a weight, e.g. "least-waste:0.7", "price:0.3", are combined into a single filter choosing options based on
+// weighted sum of the expanders' scores, instead of calling the expanders in succession.
 func (f *Factory) Build(names []string) (expander.Strategy, errors.AutoscalerError) {
 	var filters []expander.Filter
+	var weightedScorers []weightedScorer
 	seenExpanders := map[string]struct{}{}
 	strategySeen := false
-	for i, name := range names {
+	for i, nameWithWeight := range names {
+		name, weight, weighted, err := parseExpanderName(nameWithWeight)
+		if err != nil {
+			return nil, err
+		}
 		if _, ok := seenExpanders[name]; ok {
 			return nil, errors.NewAutoscalerErrorf(errors.InternalError, "Expander %s was specified multiple times, each expander must not be specified more than once", name)
 		}
@@ -66,36 +97,101 @@ func (f *Factory) Build(names []string) (expander.Strategy, errors.AutoscalerErr
 		seenExpanders[name] = struct{}{}
 
 		create, known := f.createFunc[name]
-		if known {
-			filters = append(filters, create())
-		} else {
+		if !known {
 			return nil, errors.NewAutoscalerErrorf(errors.InternalError, "Expander %s not supported", name)
 		}
-		if _, ok := filters[len(filters)-1].(expander.Strategy); ok {
+		filter, createErr := create()
+		if createErr != nil {
+			return nil, errors.NewAutoscalerErrorf(errors.ConfigurationError, "Couldn't create expander %s: %v", name, createErr)
+		}
+		if weighted {
+			scorer, ok := filter.(expander.Scorer)
+			if !ok {
+				return nil, errors.NewAutoscalerErrorf(errors.InternalError, "Expander %s doesn't support weights", name)
+			}
+			weightedScorers = append(weightedScorers, weightedScorer{name: name, scorer: scorer, weight: weight})
+			continue
+		}
+		if len(weightedScorers) > 0 {
+			filters = append(filters, newWeightedFilter(weightedScorers))
+			weightedScorers = nil
+		}
+		filters = append(filters, filter)
+		if _, ok := filter.(expander.Strategy); ok {
 			strategySeen = true
 		}
 	}
+	if len(weightedScorers) > 0 {
+		filters = append(filters, newWeightedFilter(weightedScorers))
+	}
 	return newChainStrategy(filters, random.NewStrategy()), nil
 }
 
+// parseExpanderName splits an expander name of the form <name>[:<weight>].
+func parseExpanderName(nameWithWeight string) (string, float64, bool, errors.AutoscalerError) {
+	name, weightStr, weighted := strings.Cut(nameWithWeight, ":")
+	if !weighted {
+		return name, 0, false, nil
+	}
+	weight, err := strconv.ParseFloat(weightStr, 64)
+	if err != nil || weight <= 0 || math.IsInf(weight, 0) || math.IsNaN(weight) {
+		return "", 0, false, errors.NewAutoscalerErrorf(errors.InternalError, "Invalid weight %q of expander %s, must be a positive number", weightStr, name)
+	}
+	return name, weight, true, nil
+}
+
 // RegisterDefaultExpanders is a convenience function, registering all known expanders in the Factory.
-func (f *Factory) RegisterDefaultExpanders(cloudProvider cloudprovider.CloudProvider, autoscalingKubeClients *context.AutoscalingKubeClients, kubeClient kube_client.Interface, configNamespace string, GRPCExpanderCert string, GRPCExpanderURL string) {
+func (f *Factory) RegisterDefaultExpanders(cloudProvider cloudprovider.CloudProvider, autoscalingKubeClients *context.AutoscalingKubeClients, kubeClient kube_client.Interface, configNamespace string, GRPCExpanderCert string, GRPCExpanderURL string, GRPCExpanderClientCert string, GRPCExpanderClientKey string, cloudProviderName string, priceLiveCacheTTL time.Duration, priorityTieBreaker string, nodeGroupBackoff backoff.Backoff) {
 	f.RegisterFilter(expander.RandomExpanderName, random.NewFilter)
 	f.RegisterFilter(expander.MostPodsExpanderName, mostpods.NewFilter)
 	f.RegisterFilter(expander.LeastWasteExpanderName, waste.NewFilter)
 	f.RegisterFilter(expander.LeastNodesExpanderName, leastnodes.NewFilter)
-	f.RegisterFilter(expander.PriceBasedExpanderName, func() expander.Filter {
+	f.RegisterFallibleFilter(expander.PriceBasedExpanderName, func() (expander.Filter, error) {
 		if _, err := cloudProvider.Pricing(); err != nil {
-			klog.Fatalf("Couldn't access cloud provider pricing for %s expander: %v", expander.PriceBasedExpanderName, err)
+			return nil, fmt.Errorf("couldn't access cloud provider pricing: %v", err)
+		}
+		return price.NewFilter(cloudProvider, price.NewSimplePreferredNodeProvider(autoscalingKubeClients.AllNodeLister()), price.SimpleNodeUnfitness), nil
+	})
+	f.RegisterFallibleFilter(expander.PriceLiveExpanderName, func() (expander.Filter, error) {
+		source, err := pricelive.NewSource(cloudProviderName, priceLiveCacheTTL)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't create price source: %v", err)
 		}
-		return price.NewFilter(cloudProvider, price.NewSimplePreferredNodeProvider(autoscalingKubeClients.AllNodeLister()), price.SimpleNodeUnfitness)
+		return price.NewFilterWithPricingModel(cloudProvider, pricelive.NewPricingModel(source, priceLiveCacheTTL), price.NewSimplePreferredNodeProvider(autoscalingKubeClients.AllNodeLister()), price.SimpleNodeUnfitness), nil
 	})
-	f.RegisterFilter(expander.PriorityBasedExpanderName, func() expander.Filter {
+	f.RegisterFallibleFilter(expander.PriorityBasedExpanderName, func() (expander.Filter, error) {
+		var tieBreaker expander.Strategy
+		if priorityTieBreaker != "" {
+			names := strings.Split(priorityTieBreaker, ",")
+			for _, name := range names {
+				if name, _, _ := strings.Cut(name, ":"); name == expander.PriorityBasedExpanderName {
+					return nil, fmt.Errorf("tie-breaker can't contain the %s expander", expander.PriorityBasedExpanderName)
+				}
+			}
+			var err error
+			if tieBreaker, err = f.Build(names); err != nil {
+				return nil, fmt.Errorf("couldn't create tie-breaker %s: %v", priorityTieBreaker, err)
+			}
+		}
 		// It seems other listers do the same here - they never receive the termination msg on the ch.
 		// This should be currently OK.
 		stopChannel := make(chan struct{})
 		lister := kubernetes.NewConfigMapListerForNamespace(kubeClient, stopChannel, configNamespace)
-		return priority.NewFilter(lister.ConfigMaps(configNamespace), autoscalingKubeClients.Recorder)
+		return priority.NewFilterWithTieBreaking(lister.ConfigMaps(configNamespace), autoscalingKubeClients.Recorder, tieBreaker, nodeGroupBackoff), nil
+	})
+	f.RegisterFallibleFilter(expander.GRPCExpanderName, func() (expander.Filter, error) {
+		// The gRPC client exits on missing certificate, insecure connections aren't allowed.
+		if GRPCExpanderCert == "" {
+			return nil, fmt.Errorf("gRPC expander certificate not specified")
+		}
+		return grpcplugin.NewFilter(GRPCExpanderCert, GRPCExpanderURL, GRPCExpanderClientCert, GRPCExpanderClientKey), nil
+	})
+	f.RegisterFilter(expander.ReservationBasedExpanderName, func() expander.Filter {
+		provider, ok := cloudProvider.(reservation.ReservedCapacityProvider)
+		if !ok {
+			klog.Errorf("Cloud provider %s doesn't support the %s expander, it will pass all options on", cloudProvider.Name(), expander.ReservationBasedExpanderName)
+			return reservation.NewFilter(nil)
+		}
+		return reservation.NewFilter(provider)
 	})
-	f.RegisterFilter(expander.GRPCExpanderName, func() expander.Filter { return grpcplugin.NewFilter(GRPCExpanderCert, GRPCExpanderURL) })
 }
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package factory
+
+import (
+	"math"
+
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	klog "k8s.io/klog/v2"
+)
+
+// scoreEpsilon is the difference below which combined scores are considered equal.
+const scoreEpsilon = 1e-9
+
+type weightedScorer struct {
+	name   string
+	scorer expander.Scorer
+	weight float64
+}
+
+// weightedFilter combines scores of multiple expanders. Scores of each expander are normalized to [0, 1]
+// across the options, multiplied by the expander's weight and summed up. Options with the lowest combined
+// score are returned. Options that any of the expanders can't score are dropped. If all weights are zero,
+// or no option could be scored, the options are returned unfiltered.
+type weightedFilter struct {
+	scorers []weightedScorer
+}
+
+func newWeightedFilter(scorers []weightedScorer) *weightedFilter {
+	return &weightedFilter{scorers: scorers}
+}
+
+func (w *weightedFilter) BestOptions(options []expander.Option, nodeInfo map[string]*framework.NodeInfo) []expander.Option {
+	if !w.hasWeights() {
+		return options
+	}
+	combined := make(map[string]float64, len(options))
+	for _, option := range options {
+		combined[option.NodeGroup.Id()] = 0
+	}
+	for _, s := range w.scorers {
+		scores := s.scorer.ScoreOptions(options, nodeInfo)
+		for id := range combined {
+			if _, found := scores[id]; !found {
+				klog.V(4).Infof("Weighted expander: %s couldn't score %s, dropping it", s.name, id)
+				delete(combined, id)
+			}
+		}
+		minScore, maxScore := math.Inf(1), math.Inf(-1)
+		for id := range combined {
+			minScore = math.Min(minScore, scores[id])
+			maxScore = math.Max(maxScore, scores[id])
+		}
+		if maxScore-minScore < scoreEpsilon {
+			// All remaining options are equally good according to this expander.
+			continue
+		}
+		for id := range combined {
+			combined[id] += s.weight * (scores[id] - minScore) / (maxScore - minScore)
+		}
+	}
+
+	bestScore := math.Inf(1)
+	for _, score := range combined {
+		bestScore = math.Min(bestScore, score)
+	}
+	var bestOptions []expander.Option
+	for _, option := range options {
+		score, found := combined[option.NodeGroup.Id()]
+		if !found {
+			continue
+		}
+		klog.V(4).Infof("Weighted expander: combined score of %s is %f", option.NodeGroup.Id(), score)
+		if score-bestScore < scoreEpsilon {
+			bestOptions = append(bestOptions, option)
+		}
+	}
+	if len(bestOptions) == 0 {
+		klog.V(4).Info("Weighted expander: no option could be scored, options are not filtered")
+		return options
+	}
+	return bestOptions
+}
+
+func (w *weightedFilter) hasWeights() bool {
+	for _, s := range w.scorers {
+		if s.weight > 0 {
+			return true
+		}
+	}
+	return false
+}
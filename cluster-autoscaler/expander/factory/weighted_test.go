@@ -0,0 +1,175 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package factory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+)
+
+type testNodeGroup struct {
+	cloudprovider.NodeGroup
+	id string
+}
+
+func (n *testNodeGroup) Id() string { return n.id }
+
+func newNodeGroupOption(id string) expander.Option {
+	return expander.Option{NodeGroup: &testNodeGroup{id: id}, Debug: id}
+}
+
+// testScorer scores options using a fixed map and selects the ones with the lowest score.
+type testScorer map[string]float64
+
+func (s testScorer) ScoreOptions(options []expander.Option, nodeInfo map[string]*framework.NodeInfo) map[string]float64 {
+	scores := make(map[string]float64)
+	for _, option := range options {
+		if score, found := s[option.NodeGroup.Id()]; found {
+			scores[option.NodeGroup.Id()] = score
+		}
+	}
+	return scores
+}
+
+func (s testScorer) BestOptions(options []expander.Option, nodeInfo map[string]*framework.NodeInfo) []expander.Option {
+	return newWeightedFilter([]weightedScorer{{scorer: s, weight: 1}}).BestOptions(options, nodeInfo)
+}
+
+// notAFilter drops the option for node group "a".
+type notAFilter struct{}
+
+func (notAFilter) BestOptions(options []expander.Option, nodeInfo map[string]*framework.NodeInfo) []expander.Option {
+	var ret []expander.Option
+	for _, option := range options {
+		if option.NodeGroup.Id() != "a" {
+			ret = append(ret, option)
+		}
+	}
+	return ret
+}
+
+func TestWeightedFilter(t *testing.T) {
+	// Waste-like scores favor "a", price-like scores favor "c".
+	waste := testScorer{"a": 0.1, "b": 0.3, "c": 0.5}
+	price := testScorer{"a": 10, "b": 6, "c": 2}
+	options := []expander.Option{newNodeGroupOption("a"), newNodeGroupOption("b"), newNodeGroupOption("c")}
+
+	for name, tc := range map[string]struct {
+		scorers []weightedScorer
+		options []expander.Option
+		want    []string
+	}{
+		"first expander dominates": {
+			scorers: []weightedScorer{{scorer: waste, weight: 0.7}, {scorer: price, weight: 0.3}},
+			options: options,
+			want:    []string{"a"},
+		},
+		"second expander dominates": {
+			scorers: []weightedScorer{{scorer: waste, weight: 0.3}, {scorer: price, weight: 0.7}},
+			options: options,
+			want:    []string{"c"},
+		},
+		"equal weights tie": {
+			scorers: []weightedScorer{{scorer: waste, weight: 1}, {scorer: price, weight: 1}},
+			options: options,
+			want:    []string{"a", "b", "c"},
+		},
+		"options that can't be scored are dropped": {
+			scorers: []weightedScorer{{scorer: waste, weight: 0.7}, {scorer: testScorer{"b": 1, "c": 1}, weight: 0.3}},
+			options: options,
+			want:    []string{"b"},
+		},
+		"all weights zero": {
+			scorers: []weightedScorer{{scorer: waste, weight: 0}, {scorer: testScorer{"b": 1}, weight: 0}},
+			options: options,
+			want:    []string{"a", "b", "c"},
+		},
+		"no option can be scored": {
+			scorers: []weightedScorer{{scorer: testScorer{}, weight: 1}},
+			options: options,
+			want:    []string{"a", "b", "c"},
+		},
+		"no options": {
+			scorers: []weightedScorer{{scorer: waste, weight: 1}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			for _, option := range newWeightedFilter(tc.scorers).BestOptions(tc.options, nil) {
+				got = append(got, option.NodeGroup.Id())
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestBuildWeighted(t *testing.T) {
+	f := NewFactory()
+	f.RegisterFilter("waste", func() expander.Filter { return testScorer{"a": 0.1, "b": 0.3, "c": 0.5} })
+	f.RegisterFilter("price", func() expander.Filter { return testScorer{"a": 10, "b": 6, "c": 2} })
+	f.RegisterFilter("not-a", func() expander.Filter { return notAFilter{} })
+	options := []expander.Option{newNodeGroupOption("a"), newNodeGroupOption("b"), newNodeGroupOption("c")}
+
+	for name, tc := range map[string]struct {
+		names   []string
+		want    string
+		wantErr bool
+	}{
+		"weighted": {
+			names: []string{"waste:0.7", "price:0.3"},
+			want:  "a",
+		},
+		"weights change the outcome": {
+			names: []string{"waste:0.3", "price:0.7"},
+			want:  "c",
+		},
+		"weighted after a filter": {
+			names: []string{"not-a", "waste:0.7", "price:0.3"},
+			want:  "b",
+		},
+		"invalid weight": {
+			names:   []string{"waste:abc", "price:0.3"},
+			wantErr: true,
+		},
+		"negative weight": {
+			names:   []string{"waste:-1"},
+			wantErr: true,
+		},
+		"expander without scores": {
+			names:   []string{"not-a:0.5", "price:0.5"},
+			wantErr: true,
+		},
+		"duplicated expander": {
+			names:   []string{"waste:0.5", "waste:0.5"},
+			wantErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			strategy, err := f.Build(tc.names)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, strategy.BestOption(options, nil).NodeGroup.Id())
+		})
+	}
+}
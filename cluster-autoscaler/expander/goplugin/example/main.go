@@ -0,0 +1,54 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Example expander plugin, preferring options whose nodes are labeled with the
+// preferred label. Build it with:
+//
+//	go build -buildmode=plugin -o example.so ./expander/goplugin/example
+package main
+
+import (
+	"k8s.io/autoscaler/cluster-autoscaler/expander/goplugin"
+)
+
+const preferredLabel = "example.com/preferred"
+
+// ExpanderName is the name the expander is selected by in --expander.
+var ExpanderName = "example-plugin"
+
+type preferredLabelExpander struct{}
+
+// NewExpander creates the expander.
+func NewExpander() goplugin.Expander {
+	return &preferredLabelExpander{}
+}
+
+// BestOptions returns options whose template nodes have the preferred label, or all options if there are none.
+func (e *preferredLabelExpander) BestOptions(options []goplugin.Option) []string {
+	var preferred, all []string
+	for _, option := range options {
+		all = append(all, option.NodeGroupId)
+		if option.TemplateNode != nil && option.TemplateNode.Labels[preferredLabel] == "true" {
+			preferred = append(preferred, option.NodeGroupId)
+		}
+	}
+	if len(preferred) == 0 {
+		return all
+	}
+	return preferred
+}
+
+func main() {}
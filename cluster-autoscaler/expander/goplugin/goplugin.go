@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package goplugin
+
+import (
+	"fmt"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// NameSymbol is the symbol of the string variable with the expander name, exported by expander plugins.
+	NameSymbol = "ExpanderName"
+	// NewExpanderSymbol is the symbol of the func() Expander creating the expander, exported by expander plugins.
+	NewExpanderSymbol = "NewExpander"
+)
+
+// Option is an expansion option, as seen by expander plugins.
+type Option struct {
+	// NodeGroupId is the id of the node group to scale up.
+	NodeGroupId string
+	// SimilarNodeGroupIds are the ids of the node groups the scale-up would be balanced with.
+	SimilarNodeGroupIds []string
+	// NodeCount is the number of nodes to add.
+	NodeCount int
+	// Pods are the pending pods the new nodes would fit.
+	Pods []*apiv1.Pod
+	// TemplateNode is the template of the new nodes. Nil if unknown.
+	TemplateNode *apiv1.Node
+	// Debug is a human readable description of the option.
+	Debug string
+}
+
+// Expander is implemented by expander plugins.
+type Expander interface {
+	// BestOptions returns the node group ids of the best options.
+	BestOptions(options []Option) []string
+}
+
+// Load opens the Go plugin at the given path and returns the name of the
+// expander it provides and a function creating the expander filter. Plugins
+// are only supported in binaries built with the goplugin build tag.
+func Load(path string) (string, func() (expander.Filter, error), error) {
+	lookup, err := openPlugin(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to open expander plugin %s: %v", path, err)
+	}
+	nameSymbol, err := lookup(NameSymbol)
+	if err != nil {
+		return "", nil, fmt.Errorf("expander plugin %s doesn't export %s: %v", path, NameSymbol, err)
+	}
+	name, ok := nameSymbol.(*string)
+	if !ok || *name == "" {
+		return "", nil, fmt.Errorf("%s exported by expander plugin %s is not a non-empty string", NameSymbol, path)
+	}
+	newExpanderSymbol, err := lookup(NewExpanderSymbol)
+	if err != nil {
+		return "", nil, fmt.Errorf("expander plugin %s doesn't export %s: %v", path, NewExpanderSymbol, err)
+	}
+	newExpander, ok := newExpanderSymbol.(func() Expander)
+	if !ok {
+		return "", nil, fmt.Errorf("%s exported by expander plugin %s is not a func() Expander", NewExpanderSymbol, path)
+	}
+	return *name, func() (filter expander.Filter, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("expander plugin %s panicked: %v", *name, r)
+			}
+		}()
+		return NewFilter(*name, newExpander()), nil
+	}, nil
+}
+
+type pluginFilter struct {
+	name     string
+	expander Expander
+}
+
+// NewFilter returns an expansion filter that calls out to the expander provided by a plugin.
+func NewFilter(name string, e Expander) expander.Filter {
+	return &pluginFilter{name: name, expander: e}
+}
+
+// BestOptions selects the options whose node group ids are returned by the plugin.
+// If the plugin doesn't return any valid node group id, or panics, no options are filtered.
+func (p *pluginFilter) BestOptions(expansionOptions []expander.Option, nodeInfo map[string]*framework.NodeInfo) []expander.Option {
+	pluginOptions := make([]Option, 0, len(expansionOptions))
+	optionsByNodeGroupId := make(map[string]expander.Option, len(expansionOptions))
+	for _, option := range expansionOptions {
+		optionsByNodeGroupId[option.NodeGroup.Id()] = option
+		pluginOptions = append(pluginOptions, toPluginOption(option, nodeInfo))
+	}
+
+	var options []expander.Option
+	for _, id := range p.pluginBestOptions(pluginOptions) {
+		option, found := optionsByNodeGroupId[id]
+		if !found {
+			klog.Errorf("Expander plugin %s returned invalid node group id: %s", p.name, id)
+			continue
+		}
+		options = append(options, option)
+	}
+	if len(options) == 0 {
+		klog.V(4).Infof("Expander plugin %s returned no valid options, no options filtered", p.name)
+		return expansionOptions
+	}
+	return options
+}
+
+// pluginBestOptions calls the plugin, recovering from its panics, so that a faulty plugin doesn't crash CA.
+func (p *pluginFilter) pluginBestOptions(options []Option) (ids []string) {
+	defer func() {
+		if r := recover(); r != nil {
+			klog.Errorf("Expander plugin %s panicked: %v", p.name, r)
+			ids = nil
+		}
+	}()
+	return p.expander.BestOptions(options)
+}
+
+// toPluginOption converts the option, passing copies of the pods and the template node,
+// so that the plugin can't modify the objects shared with the rest of CA.
+func toPluginOption(option expander.Option, nodeInfo map[string]*framework.NodeInfo) Option {
+	pluginOption := Option{
+		NodeGroupId: option.NodeGroup.Id(),
+		NodeCount:   option.NodeCount,
+		Debug:       option.Debug,
+	}
+	for _, pod := range option.Pods {
+		pluginOption.Pods = append(pluginOption.Pods, pod.DeepCopy())
+	}
+	for _, similar := range option.SimilarNodeGroups {
+		pluginOption.SimilarNodeGroupIds = append(pluginOption.SimilarNodeGroupIds, similar.Id())
+	}
+	if info, found := nodeInfo[option.NodeGroup.Id()]; found && info != nil {
+		pluginOption.TemplateNode = info.Node().DeepCopy()
+	}
+	return pluginOption
+}
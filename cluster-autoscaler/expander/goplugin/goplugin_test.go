@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package goplugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+type fakeExpander struct {
+	bestOptions []string
+	options     []Option
+}
+
+func (f *fakeExpander) BestOptions(options []Option) []string {
+	f.options = options
+	return f.bestOptions
+}
+
+type panickingExpander struct{}
+
+func (panickingExpander) BestOptions(options []Option) []string {
+	panic("boom")
+}
+
+func TestPluginFilter(t *testing.T) {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	for _, id := range []string{"ng1", "ng2", "ng3"} {
+		provider.AddNodeGroup(id, 1, 10, 1)
+	}
+	pod := BuildTestPod("p1", 100, 100)
+	node := BuildTestNode("ng1-template", 1000, 1000)
+	nodeInfos := map[string]*framework.NodeInfo{"ng1": framework.NewTestNodeInfo(node)}
+	eo1 := expander.Option{Debug: "EO1", NodeGroup: provider.GetNodeGroup("ng1"), NodeCount: 2, Pods: []*apiv1.Pod{pod}, SimilarNodeGroups: []cloudprovider.NodeGroup{provider.GetNodeGroup("ng3")}}
+	eo2 := expander.Option{Debug: "EO2", NodeGroup: provider.GetNodeGroup("ng2"), NodeCount: 1}
+
+	for _, tc := range []struct {
+		name            string
+		bestOptions     []string
+		expectedOptions []expander.Option
+	}{
+		{
+			name:            "plugin selects an option",
+			bestOptions:     []string{"ng2"},
+			expectedOptions: []expander.Option{eo2},
+		},
+		{
+			name:            "invalid node group ids are ignored",
+			bestOptions:     []string{"ng1", "ng4"},
+			expectedOptions: []expander.Option{eo1},
+		},
+		{
+			name:            "no valid options filters nothing",
+			bestOptions:     []string{"ng4"},
+			expectedOptions: []expander.Option{eo1, eo2},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			e := &fakeExpander{bestOptions: tc.bestOptions}
+			filter := NewFilter("fake", e)
+			assert.Equal(t, tc.expectedOptions, filter.BestOptions([]expander.Option{eo1, eo2}, nodeInfos))
+			assert.Equal(t, []Option{
+				{NodeGroupId: "ng1", SimilarNodeGroupIds: []string{"ng3"}, NodeCount: 2, Pods: []*apiv1.Pod{pod}, TemplateNode: node, Debug: "EO1"},
+				{NodeGroupId: "ng2", NodeCount: 1, Debug: "EO2"},
+			}, e.options)
+			// The plugin gets copies of the objects.
+			assert.NotSame(t, pod, e.options[0].Pods[0])
+			assert.NotSame(t, node, e.options[0].TemplateNode)
+		})
+	}
+}
+
+func TestPluginFilterPanic(t *testing.T) {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 1, 10, 1)
+	options := []expander.Option{{Debug: "EO1", NodeGroup: provider.GetNodeGroup("ng1"), NodeCount: 1}}
+	assert.Equal(t, options, NewFilter("panicking", panickingExpander{}).BestOptions(options, nil))
+}
+
+func TestLoadMissingPlugin(t *testing.T) {
+	_, _, err := Load("/nonexistent/expander.so")
+	assert.Error(t, err)
+}
@@ -0,0 +1,35 @@
+//go:build goplugin
+// +build goplugin
+
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package goplugin
+
+import (
+	"plugin"
+)
+
+// openPlugin opens the Go plugin at the given path and returns a function looking up its symbols.
+func openPlugin(path string) (func(symbol string) (any, error), error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return func(symbol string) (any, error) {
+		return p.Lookup(symbol)
+	}, nil
+}
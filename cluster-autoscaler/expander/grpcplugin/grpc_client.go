@@ -18,43 +18,69 @@ package grpcplugin
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
 	"log"
+	"os"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/autoscaler/cluster-autoscaler/expander"
 	"k8s.io/autoscaler/cluster-autoscaler/expander/grpcplugin/protos"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
 	"k8s.io/klog/v2"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
 const (
 	gRPCTimeout        = 5 * time.Second
 	gRPCMaxRecvMsgSize = 128 << 20
+	// gRPCMaxRetries is the number of times a failed call is retried if the
+	// error is transient.
+	gRPCMaxRetries     = 3
+	gRPCInitialBackoff = 100 * time.Millisecond
+	gRPCMaxBackoff     = 2 * time.Second
 )
 
 type grpcclientstrategy struct {
 	grpcClient protos.ExpanderClient
+	backoff    wait.Backoff
+	// timeout bounds a BestOptions call, including retries.
+	timeout time.Duration
 }
 
-// NewFilter returns an expansion filter that creates a gRPC client, and calls out to a gRPC server
-func NewFilter(expanderCert string, expanderUrl string) expander.Filter {
-	client := createGRPCClient(expanderCert, expanderUrl)
+// NewFilter returns an expansion filter that creates a gRPC client, and calls out to a gRPC server.
+// If clientCert and clientKey are set, the client authenticates itself to the server with them (mTLS).
+func NewFilter(expanderCert string, expanderUrl string, clientCert string, clientKey string) expander.Filter {
+	client := createGRPCClient(expanderCert, expanderUrl, clientCert, clientKey)
 	if client == nil {
 		return &grpcclientstrategy{grpcClient: nil}
 	}
-	return &grpcclientstrategy{grpcClient: client}
+	return &grpcclientstrategy{grpcClient: client, backoff: defaultBackoff(), timeout: gRPCTimeout}
 }
 
-func createGRPCClient(expanderCert string, expanderUrl string) protos.ExpanderClient {
+func defaultBackoff() wait.Backoff {
+	return wait.Backoff{
+		Duration: gRPCInitialBackoff,
+		Factor:   2,
+		Jitter:   0.1,
+		Steps:    gRPCMaxRetries,
+		Cap:      gRPCMaxBackoff,
+	}
+}
+
+func createGRPCClient(expanderCert string, expanderUrl string, clientCert string, clientKey string) protos.ExpanderClient {
 	if expanderCert == "" {
 		log.Fatalf("GRPC Expander Cert not specified, insecure connections not allowed")
 		return nil
 	}
-	creds, err := credentials.NewClientTLSFromFile(expanderCert, "")
+	creds, err := transportCredentials(expanderCert, clientCert, clientKey)
 	if err != nil {
 		log.Fatalf("Failed to create TLS credentials %v", err)
 		return nil
@@ -72,6 +98,32 @@ func createGRPCClient(expanderCert string, expanderUrl string) protos.ExpanderCl
 	return protos.NewExpanderClient(conn)
 }
 
+func transportCredentials(expanderCert string, clientCert string, clientKey string) (credentials.TransportCredentials, error) {
+	if clientCert == "" && clientKey == "" {
+		return credentials.NewClientTLSFromFile(expanderCert, "")
+	}
+	if clientCert == "" || clientKey == "" {
+		return nil, fmt.Errorf("both client cert and client key need to be specified for mTLS")
+	}
+	certificate, err := tls.LoadX509KeyPair(clientCert, clientKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client key pair: %v", err)
+	}
+	caPEM, err := os.ReadFile(expanderCert)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read server cert: %v", err)
+	}
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("failed to parse server cert %s", expanderCert)
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		RootCAs:      rootCAs,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
+
 func (g *grpcclientstrategy) BestOptions(expansionOptions []expander.Option, nodeInfo map[string]*framework.NodeInfo) []expander.Option {
 	if g.grpcClient == nil {
 		klog.Errorf("Incorrect gRPC client config, filtering no options")
@@ -84,9 +136,7 @@ func (g *grpcclientstrategy) BestOptions(expansionOptions []expander.Option, nod
 
 	// call gRPC server to get BestOption
 	klog.V(2).Infof("GPRC call of best options to server with %v options", len(nodeGroupIDOptionMap))
-	ctx, cancel := context.WithTimeout(context.Background(), gRPCTimeout)
-	defer cancel()
-	bestOptionsResponse, err := g.grpcClient.BestOptions(ctx, &protos.BestOptionsRequest{Options: grpcOptionsSlice, NodeMap: grpcNodeMap})
+	bestOptionsResponse, err := g.callBestOptions(&protos.BestOptionsRequest{Options: grpcOptionsSlice, NodeMap: grpcNodeMap})
 	if err != nil {
 		klog.V(4).Infof("GRPC call failed, no options filtered: %v", err)
 		return expansionOptions
@@ -105,6 +155,40 @@ func (g *grpcclientstrategy) BestOptions(expansionOptions []expander.Option, nod
 	return options
 }
 
+// callBestOptions calls the gRPC server, retrying with exponential backoff if
+// the call fails with a transient error. All attempts, including the backoff
+// between them, share a single gRPCTimeout deadline, so a slow or unavailable
+// server can't hold up the autoscaler loop for longer than that.
+func (g *grpcclientstrategy) callBestOptions(req *protos.BestOptionsRequest) (*protos.BestOptionsResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), g.timeout)
+	defer cancel()
+	backoff := g.backoff
+	for {
+		resp, err := g.grpcClient.BestOptions(ctx, req)
+		if err == nil || !isRetryable(err) || backoff.Steps < 1 {
+			return resp, err
+		}
+		delay := backoff.Step()
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+			return nil, err
+		}
+		klog.V(4).Infof("GRPC call failed, retrying in %v: %v", delay, err)
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(delay):
+		}
+	}
+}
+
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	}
+	return false
+}
+
 // populateOptionsForGRPC creates a map of nodegroup ID and options, as well as a slice of Options objects for the gRPC call
 func populateOptionsForGRPC(expansionOptions []expander.Option) ([]*protos.Option, map[string]expander.Option) {
 	grpcOptionsSlice := []*protos.Option{}
@@ -17,8 +17,10 @@ limitations under the License.
 package grpcplugin
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -32,6 +34,9 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/expander"
 
 	_ "github.com/golang/mock/mockgen/model"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -175,7 +180,7 @@ func TestBestOptionsValid(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockClient := mocks.NewMockExpanderClient(ctrl)
-	g := &grpcclientstrategy{mockClient}
+	g := &grpcclientstrategy{grpcClient: mockClient}
 
 	nodeInfos := makeFakeNodeInfos()
 	grpcNodeInfoMap := make(map[string]*v1.Node)
@@ -200,7 +205,7 @@ func TestBestOptionsEmpty(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockClient := mocks.NewMockExpanderClient(ctrl)
-	g := grpcclientstrategy{mockClient}
+	g := grpcclientstrategy{grpcClient: mockClient}
 
 	testCases := []struct {
 		desc         string
@@ -238,7 +243,7 @@ func TestBestOptionsErrors(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockClient := mocks.NewMockExpanderClient(ctrl)
-	g := grpcclientstrategy{mockClient}
+	g := grpcclientstrategy{grpcClient: mockClient}
 
 	badProtosOption := protos.Option{
 		NodeGroupId: "badID",
@@ -256,7 +261,7 @@ func TestBestOptionsErrors(t *testing.T) {
 	}{
 		{
 			desc:         "Bad gRPC client config",
-			client:       grpcclientstrategy{nil},
+			client:       grpcclientstrategy{grpcClient: nil},
 			nodeInfo:     makeFakeNodeInfos(),
 			mockResponse: protos.BestOptionsResponse{},
 			errResponse:  nil,
@@ -298,3 +303,75 @@ func TestBestOptionsErrors(t *testing.T) {
 		assert.Equal(t, resp, options)
 	}
 }
+
+func TestBestOptionsRetries(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		errors      []error
+		backoff     time.Duration
+		wantCalls   int
+		wantOptions []expander.Option
+	}{
+		{
+			desc:        "transient error is retried",
+			errors:      []error{status.Error(codes.Unavailable, "unavailable"), status.Error(codes.DeadlineExceeded, "timeout")},
+			wantCalls:   3,
+			wantOptions: []expander.Option{eoT3Large},
+		},
+		{
+			desc:        "retries are limited",
+			errors:      []error{status.Error(codes.Unavailable, "1"), status.Error(codes.Unavailable, "2"), status.Error(codes.Unavailable, "3"), status.Error(codes.Unavailable, "4")},
+			wantCalls:   4,
+			wantOptions: options,
+		},
+		{
+			desc:        "non transient error is not retried",
+			errors:      []error{status.Error(codes.InvalidArgument, "invalid")},
+			wantCalls:   1,
+			wantOptions: options,
+		},
+		{
+			desc:        "retries are bounded by the call timeout",
+			errors:      []error{status.Error(codes.Unavailable, "1"), status.Error(codes.Unavailable, "2")},
+			backoff:     time.Hour,
+			wantCalls:   1,
+			wantOptions: options,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockClient := mocks.NewMockExpanderClient(ctrl)
+			backoff := defaultBackoff()
+			backoff.Duration = time.Millisecond
+			if tc.backoff != 0 {
+				backoff.Duration = tc.backoff
+				backoff.Cap = tc.backoff
+			}
+			g := &grpcclientstrategy{grpcClient: mockClient, backoff: backoff, timeout: gRPCTimeout}
+
+			calls := 0
+			mockClient.EXPECT().BestOptions(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ *protos.BestOptionsRequest, _ ...grpc.CallOption) (*protos.BestOptionsResponse, error) {
+					calls++
+					if calls <= len(tc.errors) {
+						return nil, tc.errors[calls-1]
+					}
+					return &protos.BestOptionsResponse{Options: []*protos.Option{&grpcEoT3Large}}, nil
+				}).Times(tc.wantCalls)
+
+			resp := g.BestOptions(options, makeFakeNodeInfos())
+
+			assert.Equal(t, tc.wantOptions, resp)
+			assert.Equal(t, tc.wantCalls, calls)
+		})
+	}
+}
+
+func TestTransportCredentialsRequiresClientKeyPair(t *testing.T) {
+	_, err := transportCredentials("server.crt", "client.crt", "")
+	assert.Error(t, err)
+	_, err = transportCredentials("server.crt", "", "client.key")
+	assert.Error(t, err)
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package server contains a reference implementation of the gRPC Expander
+// Server, meant to be imported by external expanders deployed alongside
+// Cluster Autoscaler. Expansion logic is provided by a pluggable Strategy.
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/expander/grpcplugin/protos"
+	"k8s.io/klog/v2"
+)
+
+// Strategy selects the best expansion options out of the ones sent by
+// Cluster Autoscaler. nodeMap maps node group ids to template nodes.
+// Implementations should return when ctx is done.
+type Strategy interface {
+	BestOptions(ctx context.Context, options []*protos.Option, nodeMap map[string]*v1.Node) ([]*protos.Option, error)
+}
+
+// StrategyFunc allows using an ordinary function as a Strategy.
+type StrategyFunc func(ctx context.Context, options []*protos.Option, nodeMap map[string]*v1.Node) ([]*protos.Option, error)
+
+// BestOptions calls f(ctx, options, nodeMap).
+func (f StrategyFunc) BestOptions(ctx context.Context, options []*protos.Option, nodeMap map[string]*v1.Node) ([]*protos.Option, error) {
+	return f(ctx, options, nodeMap)
+}
+
+// Config contains configuration of the gRPC Expander Server.
+type Config struct {
+	// Port is the port to listen on.
+	Port uint
+	// CertPath and KeyPath point to the server certificate and private key.
+	// Both are required, as Cluster Autoscaler doesn't allow insecure
+	// connections to gRPC expanders.
+	CertPath string
+	KeyPath  string
+	// ClientCAPath points to CA certificates used to verify client
+	// certificates. If set, clients are required to present a valid
+	// certificate (mTLS).
+	ClientCAPath string
+	// RequestTimeout bounds the time spent by Strategy on a single request.
+	// 0 means no timeout other than the one set by the client.
+	RequestTimeout time.Duration
+}
+
+// ExpanderServer implements protos.ExpanderServer by delegating to a Strategy.
+type ExpanderServer struct {
+	strategy       Strategy
+	requestTimeout time.Duration
+}
+
+// NewExpanderServer returns an ExpanderServer using a given strategy.
+func NewExpanderServer(strategy Strategy, requestTimeout time.Duration) *ExpanderServer {
+	return &ExpanderServer{strategy: strategy, requestTimeout: requestTimeout}
+}
+
+type strategyResult struct {
+	options []*protos.Option
+	err     error
+}
+
+// BestOptions returns the options selected by the strategy. Options not
+// present in the request are dropped.
+func (s *ExpanderServer) BestOptions(ctx context.Context, req *protos.BestOptionsRequest) (*protos.BestOptionsResponse, error) {
+	options := req.GetOptions()
+	if len(options) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no options to choose from")
+	}
+	if s.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.requestTimeout)
+		defer cancel()
+	}
+
+	resultCh := make(chan strategyResult, 1)
+	go func() {
+		bestOptions, err := s.strategy.BestOptions(ctx, options, req.GetNodeMap())
+		resultCh <- strategyResult{options: bestOptions, err: err}
+	}()
+
+	var result strategyResult
+	select {
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	case result = <-resultCh:
+	}
+	if result.err != nil {
+		if _, ok := status.FromError(result.err); ok {
+			return nil, result.err
+		}
+		return nil, status.Errorf(codes.Internal, "strategy failed: %v", result.err)
+	}
+
+	known := make(map[string]bool, len(options))
+	for _, option := range options {
+		known[option.GetNodeGroupId()] = true
+	}
+	var bestOptions []*protos.Option
+	for _, option := range result.options {
+		if option == nil || !known[option.GetNodeGroupId()] {
+			klog.Warningf("Strategy returned unknown option %v, skipping", option)
+			continue
+		}
+		bestOptions = append(bestOptions, option)
+	}
+	return &protos.BestOptionsResponse{Options: bestOptions}, nil
+}
+
+// NewGRPCServer returns a gRPC server with the ExpanderServer registered,
+// configured according to config.
+func NewGRPCServer(config Config, strategy Strategy) (*grpc.Server, error) {
+	creds, err := serverCredentials(config)
+	if err != nil {
+		return nil, err
+	}
+	grpcServer := grpc.NewServer(grpc.Creds(creds))
+	protos.RegisterExpanderServer(grpcServer, NewExpanderServer(strategy, config.RequestTimeout))
+	return grpcServer, nil
+}
+
+// Serve starts serving expansion requests on config.Port. It blocks until
+// the server stops.
+func Serve(config Config, strategy Strategy) error {
+	grpcServer, err := NewGRPCServer(config, strategy)
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", config.Port))
+	if err != nil {
+		return fmt.Errorf("failed to listen: %v", err)
+	}
+	klog.Infof("Starting gRPC Expander Server on port %d", config.Port)
+	return grpcServer.Serve(listener)
+}
+
+func serverCredentials(config Config) (credentials.TransportCredentials, error) {
+	if config.CertPath == "" || config.KeyPath == "" {
+		return nil, fmt.Errorf("both cert and key need to be specified, insecure connections are not supported")
+	}
+	certificate, err := tls.LoadX509KeyPair(config.CertPath, config.KeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server key pair: %v", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if config.ClientCAPath != "" {
+		caPEM, err := os.ReadFile(config.ClientCAPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA: %v", err)
+		}
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("failed to parse client CA %s", config.ClientCAPath)
+		}
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/expander/grpcplugin/protos"
+)
+
+var (
+	small  = &protos.Option{NodeGroupId: "small", NodeCount: 3}
+	medium = &protos.Option{NodeGroupId: "medium", NodeCount: 2}
+	large  = &protos.Option{NodeGroupId: "large", NodeCount: 1}
+)
+
+func TestBestOptions(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		strategy    Strategy
+		timeout     time.Duration
+		options     []*protos.Option
+		wantOptions []*protos.Option
+		wantCode    codes.Code
+	}{
+		{
+			desc:        "options selected by strategy are returned",
+			strategy:    NewLeastNodesStrategy(),
+			options:     []*protos.Option{small, medium, large},
+			wantOptions: []*protos.Option{large},
+		},
+		{
+			desc:     "no options",
+			strategy: NewLeastNodesStrategy(),
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			desc: "unknown options are dropped",
+			strategy: StrategyFunc(func(context.Context, []*protos.Option, map[string]*v1.Node) ([]*protos.Option, error) {
+				return []*protos.Option{{NodeGroupId: "unknown"}, nil, medium}, nil
+			}),
+			options:     []*protos.Option{small, medium},
+			wantOptions: []*protos.Option{medium},
+		},
+		{
+			desc: "strategy error",
+			strategy: StrategyFunc(func(context.Context, []*protos.Option, map[string]*v1.Node) ([]*protos.Option, error) {
+				return nil, errors.New("boom")
+			}),
+			options:  []*protos.Option{small},
+			wantCode: codes.Internal,
+		},
+		{
+			desc: "strategy status is preserved",
+			strategy: StrategyFunc(func(context.Context, []*protos.Option, map[string]*v1.Node) ([]*protos.Option, error) {
+				return nil, status.Error(codes.Unavailable, "pricing API unavailable")
+			}),
+			options:  []*protos.Option{small},
+			wantCode: codes.Unavailable,
+		},
+		{
+			desc: "request timeout",
+			strategy: StrategyFunc(func(ctx context.Context, options []*protos.Option, _ map[string]*v1.Node) ([]*protos.Option, error) {
+				<-ctx.Done()
+				return options, nil
+			}),
+			timeout:  10 * time.Millisecond,
+			options:  []*protos.Option{small},
+			wantCode: codes.DeadlineExceeded,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s := NewExpanderServer(tc.strategy, tc.timeout)
+			resp, err := s.BestOptions(context.Background(), &protos.BestOptionsRequest{Options: tc.options})
+			assert.Equal(t, tc.wantCode, status.Code(err))
+			if tc.wantCode == codes.OK {
+				assert.Equal(t, tc.wantOptions, resp.Options)
+			}
+		})
+	}
+}
+
+func TestPreferredNodeGroupsStrategy(t *testing.T) {
+	strategy := NewPreferredNodeGroupsStrategy([]string{"missing", "medium", "small"})
+	got, err := strategy.BestOptions(context.Background(), []*protos.Option{small, medium, large}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []*protos.Option{medium}, got)
+
+	got, err = strategy.BestOptions(context.Background(), []*protos.Option{large}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []*protos.Option{large}, got)
+}
+
+func TestNewGRPCServerRequiresTLS(t *testing.T) {
+	_, err := NewGRPCServer(Config{}, NewLeastNodesStrategy())
+	assert.Error(t, err)
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca, caKey := writeTestCert(t, dir, "ca", nil, nil)
+	writeTestCert(t, dir, "server", ca, caKey)
+	writeTestCert(t, dir, "client", ca, caKey)
+
+	grpcServer, err := NewGRPCServer(Config{
+		CertPath:     filepath.Join(dir, "server.crt"),
+		KeyPath:      filepath.Join(dir, "server.key"),
+		ClientCAPath: filepath.Join(dir, "ca.crt"),
+	}, NewLeastNodesStrategy())
+	assert.NoError(t, err)
+	listener := bufconn.Listen(1 << 20)
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(ca)
+	clientCert, err := tls.LoadX509KeyPair(filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key"))
+	assert.NoError(t, err)
+
+	for _, tc := range []struct {
+		desc         string
+		certificates []tls.Certificate
+		wantErr      bool
+	}{
+		{desc: "client with certificate", certificates: []tls.Certificate{clientCert}},
+		{desc: "client without certificate", wantErr: true},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			creds := credentials.NewTLS(&tls.Config{RootCAs: rootCAs, Certificates: tc.certificates, ServerName: "localhost"})
+			conn, err := grpc.Dial("bufnet",
+				grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+				grpc.WithTransportCredentials(creds))
+			assert.NoError(t, err)
+			defer conn.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			resp, err := protos.NewExpanderClient(conn).BestOptions(ctx, &protos.BestOptionsRequest{Options: []*protos.Option{small, large}})
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"large"}, []string{resp.Options[0].NodeGroupId})
+		})
+	}
+}
+
+// writeTestCert writes a certificate and a key named after name to dir. The
+// certificate is self-signed if parent is nil.
+func writeTestCert(t *testing.T, dir, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, name+".crt"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, name+".key"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return cert, key
+}
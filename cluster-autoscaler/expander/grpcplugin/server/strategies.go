@@ -0,0 +1,56 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/expander/grpcplugin/protos"
+)
+
+// NewLeastNodesStrategy returns a Strategy selecting options that add the
+// fewest nodes.
+func NewLeastNodesStrategy() Strategy {
+	return StrategyFunc(func(_ context.Context, options []*protos.Option, _ map[string]*v1.Node) ([]*protos.Option, error) {
+		var best []*protos.Option
+		for _, option := range options {
+			if len(best) == 0 || option.NodeCount < best[0].NodeCount {
+				best = []*protos.Option{option}
+			} else if option.NodeCount == best[0].NodeCount {
+				best = append(best, option)
+			}
+		}
+		return best, nil
+	})
+}
+
+// NewPreferredNodeGroupsStrategy returns a Strategy selecting options for the
+// first node group from a given list that is present among the options. If
+// none of them is, all options are returned.
+func NewPreferredNodeGroupsStrategy(nodeGroupIds []string) Strategy {
+	return StrategyFunc(func(_ context.Context, options []*protos.Option, _ map[string]*v1.Node) ([]*protos.Option, error) {
+		for _, id := range nodeGroupIds {
+			for _, option := range options {
+				if option.NodeGroupId == id {
+					return []*protos.Option{option}, nil
+				}
+			}
+		}
+		return options, nil
+	})
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+)
+
+// HealthScorer provides health scores of node groups, from 0 (unhealthy) to 1 (healthy).
+type HealthScorer interface {
+	HealthScore(nodeGroup cloudprovider.NodeGroup, nodeInfo *framework.NodeInfo, currentTime time.Time) float64
+}
+
+type health struct {
+	scorer HealthScorer
+}
+
+// NewFilter returns a scale up filter that picks the healthiest node groups
+func NewFilter(scorer HealthScorer) expander.Filter {
+	return &health{scorer: scorer}
+}
+
+// BestOptions selects the expansion options whose node groups have the highest health score
+func (h *health) BestOptions(expansionOptions []expander.Option, nodeInfo map[string]*framework.NodeInfo) []expander.Option {
+	now := time.Now()
+	bestScore := -1.0
+	var bestOptions []expander.Option
+	for _, option := range expansionOptions {
+		score := h.scorer.HealthScore(option.NodeGroup, nodeInfo[option.NodeGroup.Id()], now)
+		if score == bestScore {
+			bestOptions = append(bestOptions, option)
+		}
+		if score > bestScore {
+			bestScore = score
+			bestOptions = []expander.Option{option}
+		}
+	}
+	return bestOptions
+}
+
+// ScoreOptions scores options by how unhealthy their node groups are
+func (h *health) ScoreOptions(expansionOptions []expander.Option, nodeInfo map[string]*framework.NodeInfo) map[string]float64 {
+	now := time.Now()
+	scores := make(map[string]float64, len(expansionOptions))
+	for _, option := range expansionOptions {
+		scores[option.NodeGroup.Id()] = 1 - h.scorer.HealthScore(option.NodeGroup, nodeInfo[option.NodeGroup.Id()], now)
+	}
+	return scores
+}
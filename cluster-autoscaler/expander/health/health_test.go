@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+)
+
+type fakeHealthScorer map[string]float64
+
+func (f fakeHealthScorer) HealthScore(nodeGroup cloudprovider.NodeGroup, nodeInfo *framework.NodeInfo, currentTime time.Time) float64 {
+	return f[nodeGroup.Id()]
+}
+
+func TestHealth(t *testing.T) {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	for _, id := range []string{"ng1", "ng2", "ng3"} {
+		provider.AddNodeGroup(id, 1, 10, 1)
+	}
+	eo1 := expander.Option{Debug: "EO1", NodeGroup: provider.GetNodeGroup("ng1")}
+	eo2 := expander.Option{Debug: "EO2", NodeGroup: provider.GetNodeGroup("ng2")}
+	eo3 := expander.Option{Debug: "EO3", NodeGroup: provider.GetNodeGroup("ng3")}
+
+	for _, tc := range []struct {
+		name            string
+		scores          fakeHealthScorer
+		expectedOptions []expander.Option
+	}{
+		{
+			name:            "all healthy",
+			scores:          fakeHealthScorer{"ng1": 1, "ng2": 1, "ng3": 1},
+			expectedOptions: []expander.Option{eo1, eo2, eo3},
+		},
+		{
+			name:            "one unhealthy",
+			scores:          fakeHealthScorer{"ng1": 0.5, "ng2": 1, "ng3": 1},
+			expectedOptions: []expander.Option{eo2, eo3},
+		},
+		{
+			name:            "all unhealthy",
+			scores:          fakeHealthScorer{"ng1": 0.5, "ng2": 0.25, "ng3": 0.1},
+			expectedOptions: []expander.Option{eo1},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			filter := NewFilter(tc.scores)
+			assert.Equal(t, tc.expectedOptions, filter.BestOptions([]expander.Option{eo1, eo2, eo3}, nil))
+			scores := filter.(expander.Scorer).ScoreOptions([]expander.Option{eo1, eo2, eo3}, nil)
+			assert.InDelta(t, 1-tc.scores["ng1"], scores["ng1"], 1e-9)
+			assert.InDelta(t, 1-tc.scores["ng3"], scores["ng3"], 1e-9)
+		})
+	}
+}
@@ -59,3 +59,15 @@ func (m *leastnodes) BestOptions(expansionOptions []expander.Option, nodeInfo ma
 
 	return leastOptions
 }
+
+// ScoreOptions scores options by the number of nodes they use
+func (m *leastnodes) ScoreOptions(expansionOptions []expander.Option, nodeInfo map[string]*framework.NodeInfo) map[string]float64 {
+	scores := make(map[string]float64, len(expansionOptions))
+	for _, option := range expansionOptions {
+		if option.NodeCount == 0 {
+			continue
+		}
+		scores[option.NodeGroup.Id()] = float64(option.NodeCount)
+	}
+	return scores
+}
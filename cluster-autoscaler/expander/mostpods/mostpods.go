@@ -52,3 +52,12 @@ func (m *mostpods) BestOptions(expansionOptions []expander.Option, nodeInfo map[
 
 	return maxOptions
 }
+
+// ScoreOptions scores options by the number of pods they schedule, more pods resulting in a lower score
+func (m *mostpods) ScoreOptions(expansionOptions []expander.Option, nodeInfo map[string]*framework.NodeInfo) map[string]float64 {
+	scores := make(map[string]float64, len(expansionOptions))
+	for _, option := range expansionOptions {
+		scores[option.NodeGroup.Id()] = -float64(len(option.Pods))
+	}
+	return scores
+}
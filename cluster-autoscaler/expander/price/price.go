@@ -27,6 +27,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/expander"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/units"
 
@@ -41,6 +42,7 @@ import (
 
 type priceBased struct {
 	cloudProvider         cloudprovider.CloudProvider
+	pricingModel          func() (cloudprovider.PricingModel, errors.AutoscalerError)
 	preferredNodeProvider PreferredNodeProvider
 	nodeUnfitness         NodeUnfitness
 }
@@ -81,15 +83,62 @@ func NewFilter(cloudProvider cloudprovider.CloudProvider,
 ) expander.Filter {
 	return &priceBased{
 		cloudProvider:         cloudProvider,
+		pricingModel:          cloudProvider.Pricing,
 		preferredNodeProvider: preferredNodeProvider,
 		nodeUnfitness:         nodeUnfitness,
 	}
 }
 
+// NewFilterWithPricingModel returns an expansion filter that picks nodes based on price and preferred node type,
+// using a given pricing model instead of the one provided by the cloud provider.
+func NewFilterWithPricingModel(cloudProvider cloudprovider.CloudProvider,
+	pricingModel cloudprovider.PricingModel,
+	preferredNodeProvider PreferredNodeProvider,
+	nodeUnfitness NodeUnfitness,
+) expander.Filter {
+	return &priceBased{
+		cloudProvider: cloudProvider,
+		pricingModel: func() (cloudprovider.PricingModel, errors.AutoscalerError) {
+			return pricingModel, nil
+		},
+		preferredNodeProvider: preferredNodeProvider,
+		nodeUnfitness:         nodeUnfitness,
+	}
+}
+
+// scoredOption is an expansion option along with its price score. Lower scores are better.
+type scoredOption struct {
+	option expander.Option
+	score  float64
+}
+
 // BestOption selects option based on cost and preferred node type.
 func (p *priceBased) BestOptions(expansionOptions []expander.Option, nodeInfos map[string]*framework.NodeInfo) []expander.Option {
 	var bestOptions []expander.Option
 	bestOptionScore := 0.0
+	for _, scored := range p.scoreOptions(expansionOptions, nodeInfos) {
+		if len(bestOptions) == 0 || bestOptionScore == scored.score {
+			bestOptions = append(bestOptions, scored.option)
+			bestOptionScore = scored.score
+		} else if bestOptionScore > scored.score {
+			bestOptions = []expander.Option{scored.option}
+			bestOptionScore = scored.score
+		}
+	}
+	return bestOptions
+}
+
+// ScoreOptions scores options based on cost and preferred node type.
+func (p *priceBased) ScoreOptions(expansionOptions []expander.Option, nodeInfos map[string]*framework.NodeInfo) map[string]float64 {
+	scores := make(map[string]float64, len(expansionOptions))
+	for _, scored := range p.scoreOptions(expansionOptions, nodeInfos) {
+		scores[scored.option.NodeGroup.Id()] = scored.score
+	}
+	return scores
+}
+
+func (p *priceBased) scoreOptions(expansionOptions []expander.Option, nodeInfos map[string]*framework.NodeInfo) []scoredOption {
+	var scoredOptions []scoredOption
 	now := time.Now()
 	then := now.Add(time.Hour)
 
@@ -99,7 +148,7 @@ func (p *priceBased) BestOptions(expansionOptions []expander.Option, nodeInfos m
 		preferredNode = defaultPreferredNode
 	}
 
-	pricingModel, err := p.cloudProvider.Pricing()
+	pricingModel, err := p.pricingModel()
 	if err != nil {
 		klog.Errorf("Failed to get pricing model from cloud provider: %v", err)
 	}
@@ -175,15 +224,9 @@ nextoption:
 			Debug:     fmt.Sprintf("%s | price-expander: %s", option.Debug, debug),
 			Pods:      option.Pods,
 		}
-		if len(bestOptions) == 0 || bestOptionScore == optionScore {
-			bestOptions = append(bestOptions, maybeBestOption)
-			bestOptionScore = optionScore
-		} else if bestOptionScore > optionScore {
-			bestOptions = []expander.Option{maybeBestOption}
-			bestOptionScore = optionScore
-		}
+		scoredOptions = append(scoredOptions, scoredOption{option: maybeBestOption, score: optionScore})
 	}
-	return bestOptions
+	return scoredOptions
 }
 
 // buildPod creates a pod with specified resources.
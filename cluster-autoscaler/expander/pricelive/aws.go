@@ -0,0 +1,227 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pricelive
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/aws"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/aws/session"
+	v4 "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/aws/signer/v4"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/service/ec2"
+)
+
+const (
+	// The Price List API is only served from a few regions and returns
+	// prices for all of them.
+	awsPricingRegion   = "us-east-1"
+	awsPricingEndpoint = "https://api.pricing.us-east-1.amazonaws.com/"
+)
+
+// spotPriceHistoryDescriber is the subset of EC2 API used by AwsSource.
+type spotPriceHistoryDescriber interface {
+	DescribeSpotPriceHistory(input *ec2.DescribeSpotPriceHistoryInput) (*ec2.DescribeSpotPriceHistoryOutput, error)
+}
+
+// AwsSource is a PriceSource using the AWS Price List API for on-demand prices
+// and EC2 spot price history for spot prices. It uses the default AWS
+// credential chain.
+type AwsSource struct {
+	httpClient      *http.Client
+	signer          *v4.Signer
+	pricingEndpoint string
+	newEC2          func(region string) spotPriceHistoryDescriber
+
+	mutex      sync.Mutex
+	ec2Clients map[string]spotPriceHistoryDescriber
+}
+
+// NewAwsSource returns a new AwsSource.
+func NewAwsSource() (*AwsSource, error) {
+	sess, err := session.NewSession(&aws.Config{Region: aws.String(awsPricingRegion)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS session: %v", err)
+	}
+	return &AwsSource{
+		httpClient:      &http.Client{Timeout: 30 * time.Second},
+		signer:          v4.NewSigner(sess.Config.Credentials),
+		pricingEndpoint: awsPricingEndpoint,
+		newEC2: func(region string) spotPriceHistoryDescriber {
+			return ec2.New(sess.Copy(&aws.Config{Region: aws.String(region)}))
+		},
+		ec2Clients: make(map[string]spotPriceHistoryDescriber),
+	}, nil
+}
+
+// InstancePrice returns Linux on-demand price or current lowest spot price
+// across availability zones of an instance type in a given region.
+func (s *AwsSource) InstancePrice(instanceType, region string, spot bool) (float64, error) {
+	if spot {
+		return s.spotPrice(instanceType, region)
+	}
+	return s.onDemandPrice(instanceType, region)
+}
+
+func (s *AwsSource) spotPrice(instanceType, region string) (float64, error) {
+	input := &ec2.DescribeSpotPriceHistoryInput{
+		InstanceTypes:       []*string{aws.String(instanceType)},
+		ProductDescriptions: []*string{aws.String("Linux/UNIX")},
+		StartTime:           aws.Time(time.Now()),
+	}
+	found := false
+	price := 0.0
+	for {
+		output, err := s.ec2Client(region).DescribeSpotPriceHistory(input)
+		if err != nil {
+			return 0, fmt.Errorf("failed to describe spot price history: %v", err)
+		}
+		for _, spotPrice := range output.SpotPriceHistory {
+			value, err := strconv.ParseFloat(aws.StringValue(spotPrice.SpotPrice), 64)
+			if err != nil {
+				continue
+			}
+			if !found || value < price {
+				price = value
+				found = true
+			}
+		}
+		if aws.StringValue(output.NextToken) == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+	if !found {
+		return 0, fmt.Errorf("no spot price found for %s in %s", instanceType, region)
+	}
+	return price, nil
+}
+
+func (s *AwsSource) ec2Client(region string) spotPriceHistoryDescriber {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	client, found := s.ec2Clients[region]
+	if !found {
+		client = s.newEC2(region)
+		s.ec2Clients[region] = client
+	}
+	return client
+}
+
+type awsPriceFilter struct {
+	Type  string `json:"Type"`
+	Field string `json:"Field"`
+	Value string `json:"Value"`
+}
+
+type awsGetProductsRequest struct {
+	ServiceCode string           `json:"ServiceCode"`
+	Filters     []awsPriceFilter `json:"Filters"`
+	NextToken   string           `json:"NextToken,omitempty"`
+}
+
+type awsGetProductsResponse struct {
+	PriceList []string `json:"PriceList"`
+	NextToken string   `json:"NextToken"`
+}
+
+type awsProduct struct {
+	Terms struct {
+		OnDemand map[string]struct {
+			PriceDimensions map[string]struct {
+				PricePerUnit map[string]string `json:"pricePerUnit"`
+			} `json:"priceDimensions"`
+		} `json:"OnDemand"`
+	} `json:"terms"`
+}
+
+func (s *AwsSource) onDemandPrice(instanceType, region string) (float64, error) {
+	request := awsGetProductsRequest{
+		ServiceCode: "AmazonEC2",
+		Filters: []awsPriceFilter{
+			{Type: "TERM_MATCH", Field: "instanceType", Value: instanceType},
+			{Type: "TERM_MATCH", Field: "regionCode", Value: region},
+			{Type: "TERM_MATCH", Field: "operatingSystem", Value: "Linux"},
+			{Type: "TERM_MATCH", Field: "tenancy", Value: "Shared"},
+			{Type: "TERM_MATCH", Field: "preInstalledSw", Value: "NA"},
+			{Type: "TERM_MATCH", Field: "capacitystatus", Value: "Used"},
+		},
+	}
+	for {
+		response, err := s.getProducts(request)
+		if err != nil {
+			return 0, err
+		}
+		for _, item := range response.PriceList {
+			var product awsProduct
+			if err := json.Unmarshal([]byte(item), &product); err != nil {
+				return 0, fmt.Errorf("failed to parse price list: %v", err)
+			}
+			for _, term := range product.Terms.OnDemand {
+				for _, dimension := range term.PriceDimensions {
+					price, err := strconv.ParseFloat(dimension.PricePerUnit["USD"], 64)
+					if err == nil && price > 0 {
+						return price, nil
+					}
+				}
+			}
+		}
+		if response.NextToken == "" {
+			break
+		}
+		request.NextToken = response.NextToken
+	}
+	return 0, fmt.Errorf("no on-demand price found for %s in %s", instanceType, region)
+}
+
+// getProducts calls AWSPriceListService.GetProducts. The vendored SDK doesn't
+// contain a Price List API client, so requests are signed directly.
+func (s *AwsSource) getProducts(request awsGetProductsRequest) (*awsGetProductsResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	httpRequest, err := http.NewRequest(http.MethodPost, s.pricingEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	httpRequest.Header.Set("X-Amz-Target", "AWSPriceListService.GetProducts")
+	if _, err := s.signer.Sign(httpRequest, bytes.NewReader(body), "pricing", awsPricingRegion, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to sign pricing request: %v", err)
+	}
+	httpResponse, err := s.httpClient.Do(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(httpResponse.Body, 1024))
+		return nil, fmt.Errorf("unexpected status %s from AWS Price List API: %s", httpResponse.Status, message)
+	}
+	var response awsGetProductsResponse
+	if err := json.NewDecoder(httpResponse.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode AWS Price List API response: %v", err)
+	}
+	return &response, nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pricelive
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/aws"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/aws/credentials"
+	v4 "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/aws/signer/v4"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/aws/aws-sdk-go/service/ec2"
+)
+
+type fakeEC2 struct {
+	spotPrices []*ec2.SpotPrice
+}
+
+// DescribeSpotPriceHistory returns one price per page.
+func (f *fakeEC2) DescribeSpotPriceHistory(input *ec2.DescribeSpotPriceHistoryInput) (*ec2.DescribeSpotPriceHistoryOutput, error) {
+	if len(f.spotPrices) == 0 {
+		return &ec2.DescribeSpotPriceHistoryOutput{}, nil
+	}
+	page := 0
+	if input.NextToken != nil {
+		page, _ = strconv.Atoi(*input.NextToken)
+	}
+	output := &ec2.DescribeSpotPriceHistoryOutput{SpotPriceHistory: f.spotPrices[page : page+1]}
+	if page+1 < len(f.spotPrices) {
+		output.NextToken = aws.String(strconv.Itoa(page + 1))
+	}
+	return output, nil
+}
+
+func TestAwsInstancePrice(t *testing.T) {
+	product := `{"terms": {"OnDemand": {"ABC.JRTCKXETXF": {"priceDimensions": {"ABC.JRTCKXETXF.6YS6EN2CT7": {"unit": "Hrs", "pricePerUnit": {"USD": "0.0960000000"}}}}}}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "AWSPriceListService.GetProducts", r.Header.Get("X-Amz-Target"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=id/"))
+		var request awsGetProductsRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		if request.Filters[0].Value != "m5.large" {
+			json.NewEncoder(w).Encode(awsGetProductsResponse{})
+			return
+		}
+		json.NewEncoder(w).Encode(awsGetProductsResponse{PriceList: []string{product}})
+	}))
+	defer server.Close()
+
+	fake := &fakeEC2{spotPrices: []*ec2.SpotPrice{
+		{AvailabilityZone: aws.String("us-east-1a"), SpotPrice: aws.String("0.0412")},
+		{AvailabilityZone: aws.String("us-east-1b"), SpotPrice: aws.String("0.0385")},
+		{AvailabilityZone: aws.String("us-east-1c"), SpotPrice: aws.String("0.0397")},
+	}}
+	source := &AwsSource{
+		httpClient:      server.Client(),
+		signer:          v4.NewSigner(credentials.NewStaticCredentials("id", "secret", "")),
+		pricingEndpoint: server.URL,
+		newEC2:          func(string) spotPriceHistoryDescriber { return fake },
+		ec2Clients:      make(map[string]spotPriceHistoryDescriber),
+	}
+
+	price, err := source.InstancePrice("m5.large", "us-east-1", false)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.096, price)
+
+	price, err = source.InstancePrice("m5.large", "us-east-1", true)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0385, price)
+
+	_, err = source.InstancePrice("m5.huge", "us-east-1", false)
+	assert.Error(t, err)
+
+	fake.spotPrices = nil
+	_, err = source.InstancePrice("m5.huge", "us-east-1", true)
+	assert.Error(t, err)
+}
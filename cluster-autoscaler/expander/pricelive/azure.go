@@ -0,0 +1,104 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pricelive
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const azureRetailPricesURL = "https://prices.azure.com/api/retail/prices"
+
+// AzureSource is a PriceSource using the Azure Retail Prices API, which
+// doesn't require authentication.
+type AzureSource struct {
+	client  *http.Client
+	baseURL string
+}
+
+// NewAzureSource returns a new AzureSource.
+func NewAzureSource() *AzureSource {
+	return &AzureSource{
+		client:  &http.Client{Timeout: 30 * time.Second},
+		baseURL: azureRetailPricesURL,
+	}
+}
+
+type azureRetailPrices struct {
+	Items        []azureRetailPrice `json:"Items"`
+	NextPageLink string             `json:"NextPageLink"`
+}
+
+type azureRetailPrice struct {
+	RetailPrice float64 `json:"retailPrice"`
+	SkuName     string  `json:"skuName"`
+	ProductName string  `json:"productName"`
+}
+
+// InstancePrice returns the lowest Linux pay-as-you-go or spot price of a VM
+// size in a given region.
+func (s *AzureSource) InstancePrice(instanceType, region string, spot bool) (float64, error) {
+	filter := fmt.Sprintf("serviceName eq 'Virtual Machines' and priceType eq 'Consumption' and armRegionName eq %s and armSkuName eq %s", odataString(region), odataString(instanceType))
+	next := s.baseURL + "?" + url.Values{"$filter": []string{filter}}.Encode()
+	found := false
+	price := 0.0
+	for next != "" {
+		var page azureRetailPrices
+		if err := getJSON(s.client, next, &page); err != nil {
+			return 0, err
+		}
+		for _, item := range page.Items {
+			if strings.Contains(item.ProductName, "Windows") || strings.Contains(item.SkuName, "Low Priority") {
+				continue
+			}
+			if strings.Contains(item.SkuName, "Spot") != spot {
+				continue
+			}
+			if !found || item.RetailPrice < price {
+				price = item.RetailPrice
+				found = true
+			}
+		}
+		next = page.NextPageLink
+	}
+	if !found {
+		return 0, fmt.Errorf("no Azure price found for %s in %s", instanceType, region)
+	}
+	return price, nil
+}
+
+// odataString returns an OData string literal with a given value, quotes in
+// the value are escaped by doubling them.
+func odataString(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+func getJSON(client *http.Client, url string, result interface{}) error {
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s from %s", resp.Status, url)
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pricelive
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAzureInstancePrice(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("$filter") != "serviceName eq 'Virtual Machines' and priceType eq 'Consumption' and armRegionName eq 'eastus' and armSkuName eq 'Standard_D2s_v3'" {
+			fmt.Fprint(w, `{"Items": []}`)
+			return
+		}
+		if r.URL.Query().Get("page") == "" {
+			fmt.Fprintf(w, `{"Items": [
+				{"retailPrice": 0.188, "skuName": "D2s v3", "productName": "Virtual Machines DSv3 Series Windows"},
+				{"retailPrice": 0.096, "skuName": "D2s v3", "productName": "Virtual Machines DSv3 Series"},
+				{"retailPrice": 0.019, "skuName": "D2s v3 Low Priority", "productName": "Virtual Machines DSv3 Series"}
+			], "NextPageLink": "%s?page=2&%s"}`, server.URL, r.URL.RawQuery)
+			return
+		}
+		fmt.Fprint(w, `{"Items": [{"retailPrice": 0.0123, "skuName": "D2s v3 Spot", "productName": "Virtual Machines DSv3 Series"}]}`)
+	}))
+	defer server.Close()
+
+	source := NewAzureSource()
+	source.baseURL = server.URL
+
+	price, err := source.InstancePrice("Standard_D2s_v3", "eastus", false)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.096, price)
+
+	price, err = source.InstancePrice("Standard_D2s_v3", "eastus", true)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0123, price)
+
+	_, err = source.InstancePrice("Standard_D2s_v3", "westus", false)
+	assert.Error(t, err)
+}
+
+func TestAzureInstancePriceEscapesFilter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "serviceName eq 'Virtual Machines' and priceType eq 'Consumption' and armRegionName eq 'eastus' and armSkuName eq 'a'' or ''1'' eq ''1'", r.URL.Query().Get("$filter"))
+		fmt.Fprint(w, `{"Items": []}`)
+	}))
+	defer server.Close()
+
+	source := NewAzureSource()
+	source.baseURL = server.URL
+
+	_, err := source.InstancePrice("a' or '1' eq '1", "eastus", false)
+	assert.Error(t, err)
+}
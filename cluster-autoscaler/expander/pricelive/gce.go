@@ -0,0 +1,218 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pricelive
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	cloudbilling "google.golang.org/api/cloudbilling/v1"
+	"google.golang.org/api/option"
+)
+
+const (
+	// computeEngineService is the Cloud Billing Catalog id of Compute Engine.
+	computeEngineService = "services/6F81-5844-456A"
+	spotSkuPrefix        = "Spot Preemptible "
+)
+
+// GceSource is a PriceSource using the Cloud Billing Catalog API. Instance
+// prices are computed from per vCPU and per GiB prices of the machine family.
+// As the catalog is large, it is downloaded at most once per refreshInterval.
+type GceSource struct {
+	service         *cloudbilling.APIService
+	refreshInterval time.Duration
+
+	// fetches deduplicates concurrent catalog downloads, which are made
+	// without holding mutex.
+	fetches   singleflight.Group
+	mutex     sync.Mutex
+	skus      []*cloudbilling.Sku
+	fetchTime time.Time
+}
+
+// NewGceSource returns a new GceSource, authenticated with Application
+// Default Credentials.
+func NewGceSource(refreshInterval time.Duration) (*GceSource, error) {
+	service, err := cloudbilling.NewService(context.Background(), option.WithScopes(cloudbilling.CloudPlatformScope))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Cloud Billing client: %v", err)
+	}
+	return newGceSource(service, refreshInterval), nil
+}
+
+func newGceSource(service *cloudbilling.APIService, refreshInterval time.Duration) *GceSource {
+	return &GceSource{service: service, refreshInterval: refreshInterval}
+}
+
+// InstancePrice returns the price of a predefined or custom machine type in a
+// given region.
+func (s *GceSource) InstancePrice(instanceType, region string, spot bool) (float64, error) {
+	machineType, err := parseMachineType(instanceType)
+	if err != nil {
+		return 0, err
+	}
+	skus, err := s.getSkus()
+	if err != nil {
+		return 0, err
+	}
+	prefix := machineType.skuPrefix()
+	if spot {
+		prefix = spotSkuPrefix + prefix
+	}
+	cpuPrice, err := findSkuPrice(skus, prefix+" Core running in", region)
+	if err != nil {
+		return 0, err
+	}
+	memoryPrice, err := findSkuPrice(skus, prefix+" Ram running in", region)
+	if err != nil {
+		return 0, err
+	}
+	return float64(machineType.cpus)*cpuPrice + machineType.memoryGiB*memoryPrice, nil
+}
+
+func (s *GceSource) getSkus() ([]*cloudbilling.Sku, error) {
+	if skus := s.cachedSkus(); skus != nil {
+		return skus, nil
+	}
+	result, err, _ := s.fetches.Do("skus", func() (interface{}, error) {
+		if skus := s.cachedSkus(); skus != nil {
+			return skus, nil
+		}
+		return s.fetchSkus()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]*cloudbilling.Sku), nil
+}
+
+func (s *GceSource) cachedSkus() []*cloudbilling.Sku {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.skus != nil && time.Since(s.fetchTime) < s.refreshInterval {
+		return s.skus
+	}
+	return nil
+}
+
+func (s *GceSource) fetchSkus() ([]*cloudbilling.Sku, error) {
+	var skus []*cloudbilling.Sku
+	err := s.service.Services.Skus.List(computeEngineService).Pages(context.Background(), func(page *cloudbilling.ListSkusResponse) error {
+		for _, sku := range page.Skus {
+			if sku.Category != nil && sku.Category.ResourceFamily == "Compute" {
+				skus = append(skus, sku)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Compute Engine SKUs: %v", err)
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.skus = skus
+	s.fetchTime = time.Now()
+	return skus, nil
+}
+
+// findSkuPrice returns the hourly unit price of a SKU available in a given
+// region, with description starting with a given prefix.
+func findSkuPrice(skus []*cloudbilling.Sku, descriptionPrefix, region string) (float64, error) {
+	for _, sku := range skus {
+		if !strings.HasPrefix(sku.Description, descriptionPrefix) || !slices.Contains(sku.ServiceRegions, region) {
+			continue
+		}
+		if len(sku.PricingInfo) == 0 || sku.PricingInfo[0].PricingExpression == nil {
+			continue
+		}
+		rates := sku.PricingInfo[0].PricingExpression.TieredRates
+		if len(rates) == 0 || rates[len(rates)-1].UnitPrice == nil {
+			continue
+		}
+		unitPrice := rates[len(rates)-1].UnitPrice
+		return float64(unitPrice.Units) + float64(unitPrice.Nanos)/1e9, nil
+	}
+	return 0, fmt.Errorf("no SKU %q found in %s", descriptionPrefix, region)
+}
+
+type gceMachineType struct {
+	family    string
+	custom    bool
+	cpus      int64
+	memoryGiB float64
+}
+
+// memoryPerCpu contains GiB of memory per vCPU of predefined machine classes.
+var memoryPerCpu = map[string]map[string]float64{
+	"n1":      {"standard": 3.75, "highmem": 6.5, "highcpu": 0.9},
+	"default": {"standard": 4, "highmem": 8, "highcpu": 1},
+}
+
+// parseMachineType parses names of predefined machine types, such as
+// n2-standard-8, and custom ones, such as n2-custom-4-16384.
+func parseMachineType(name string) (gceMachineType, error) {
+	parts := strings.Split(name, "-")
+	if len(parts) < 3 {
+		return gceMachineType{}, fmt.Errorf("unsupported machine type %s", name)
+	}
+	family, class := parts[0], parts[1]
+	cpus, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return gceMachineType{}, fmt.Errorf("unsupported machine type %s", name)
+	}
+	if class == "custom" {
+		if len(parts) < 4 {
+			return gceMachineType{}, fmt.Errorf("unsupported machine type %s", name)
+		}
+		memoryMiB, err := strconv.ParseInt(parts[3], 10, 64)
+		if err != nil {
+			return gceMachineType{}, fmt.Errorf("unsupported machine type %s", name)
+		}
+		return gceMachineType{family: family, custom: true, cpus: cpus, memoryGiB: float64(memoryMiB) / 1024}, nil
+	}
+	ratios, found := memoryPerCpu[family]
+	if !found {
+		ratios = memoryPerCpu["default"]
+	}
+	ratio, found := ratios[class]
+	if !found {
+		return gceMachineType{}, fmt.Errorf("unsupported machine type %s", name)
+	}
+	return gceMachineType{family: family, cpus: cpus, memoryGiB: float64(cpus) * ratio}, nil
+}
+
+// skuPrefix returns the beginning of descriptions of SKUs of a machine type,
+// e.g. "N2 Instance" or "N2 Custom Instance".
+func (m gceMachineType) skuPrefix() string {
+	family := strings.ToUpper(m.family)
+	switch {
+	case m.custom && m.family == "n1":
+		return "Custom Instance"
+	case m.custom:
+		return family + " Custom Instance"
+	case m.family == "n1":
+		return "N1 Predefined Instance"
+	}
+	return family + " Instance"
+}
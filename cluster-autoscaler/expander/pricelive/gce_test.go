@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pricelive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	cloudbilling "google.golang.org/api/cloudbilling/v1"
+	"google.golang.org/api/option"
+)
+
+func gceSku(description, region string, units, nanos int64) string {
+	return fmt.Sprintf(`{"description": %q, "category": {"resourceFamily": "Compute"}, "serviceRegions": [%q],
+		"pricingInfo": [{"pricingExpression": {"tieredRates": [{"unitPrice": {"units": "%d", "nanos": %d}}]}}]}`, description, region, units, nanos)
+}
+
+func TestGceInstancePrice(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("pageToken") == "" {
+			fmt.Fprintf(w, `{"skus": [%s, %s, %s], "nextPageToken": "2"}`,
+				gceSku("N2 Instance Core running in Americas", "us-central1", 0, 31611000),
+				gceSku("N2 Instance Ram running in Americas", "us-central1", 0, 4237000),
+				gceSku("Spot Preemptible N2 Instance Core running in Americas", "us-central1", 0, 7650000))
+			return
+		}
+		fmt.Fprintf(w, `{"skus": [%s, %s, %s, %s]}`,
+			gceSku("Spot Preemptible N2 Instance Ram running in Americas", "us-central1", 0, 1025000),
+			gceSku("N1 Predefined Instance Core running in Americas", "us-central1", 0, 31611000),
+			gceSku("N1 Predefined Instance Ram running in Americas", "us-central1", 0, 4237000),
+			gceSku("N2 Custom Instance Core running in Americas", "us-central1", 1, 0))
+	}))
+	defer server.Close()
+
+	service, err := cloudbilling.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	assert.NoError(t, err)
+	source := newGceSource(service, time.Hour)
+
+	testCases := []struct {
+		name         string
+		instanceType string
+		region       string
+		spot         bool
+		wantPrice    float64
+		wantErr      bool
+	}{
+		{name: "predefined", instanceType: "n2-standard-4", region: "us-central1", wantPrice: 4*0.031611 + 16*0.004237},
+		{name: "spot", instanceType: "n2-highmem-2", region: "us-central1", spot: true, wantPrice: 2*0.00765 + 16*0.001025},
+		{name: "n1", instanceType: "n1-standard-2", region: "us-central1", wantPrice: 2*0.031611 + 7.5*0.004237},
+		{name: "missing custom RAM SKU", instanceType: "n2-custom-2-4096", region: "us-central1", wantErr: true},
+		{name: "other region", instanceType: "n2-standard-4", region: "europe-west1", wantErr: true},
+		{name: "shared core", instanceType: "e2-micro", region: "us-central1", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			price, err := source.InstancePrice(tc.instanceType, tc.region, tc.spot)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, tc.wantPrice, price, 1e-9)
+		})
+	}
+	// The catalog is downloaded once, in two pages.
+	assert.Equal(t, 2, requests)
+}
+
+func TestParseMachineType(t *testing.T) {
+	testCases := []struct {
+		name    string
+		want    gceMachineType
+		wantErr bool
+	}{
+		{name: "e2-standard-8", want: gceMachineType{family: "e2", cpus: 8, memoryGiB: 32}},
+		{name: "n1-highcpu-16", want: gceMachineType{family: "n1", cpus: 16, memoryGiB: 14.4}},
+		{name: "c3-highmem-4-lssd", want: gceMachineType{family: "c3", cpus: 4, memoryGiB: 32}},
+		{name: "n2-custom-6-12288", want: gceMachineType{family: "n2", custom: true, cpus: 6, memoryGiB: 12}},
+		{name: "m1-megamem-96", wantErr: true},
+		{name: "g1-small", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseMachineType(tc.name)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, tc.want.memoryGiB, got.memoryGiB, 1e-9)
+			got.memoryGiB = tc.want.memoryGiB
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pricelive contains a pricing model backed by public pricing APIs of
+// cloud providers, queried at runtime. It is used by the price-live expander,
+// which ranks expansion options the same way the price expander does, but
+// doesn't depend on the cloud provider implementing cloudprovider.PricingModel.
+package pricelive
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
+	podutils "k8s.io/autoscaler/cluster-autoscaler/utils/pod"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/units"
+	klog "k8s.io/klog/v2"
+)
+
+const (
+	// Reference prices used to estimate the price of a pod, in USD per hour.
+	// Only relative node and pod prices matter to the price expander, so
+	// these don't have to match any particular provider or region.
+	cpuPricePerHour    = 0.033174
+	memoryPricePerHour = 0.004446 // per GiB
+	gpuPricePerHour    = 0.950
+
+	// errorCacheTTL is how long a failed price lookup is cached, so that
+	// an unavailable pricing API isn't queried for every expansion option.
+	errorCacheTTL = time.Minute
+)
+
+// PriceSource returns on-demand or spot price of an instance type in a given
+// region, in USD per hour.
+type PriceSource interface {
+	InstancePrice(instanceType, region string, spot bool) (float64, error)
+}
+
+// NewSource returns a PriceSource querying public pricing API of a given
+// cloud provider. Sources that need to download the whole price catalog do it
+// at most once per refreshInterval.
+func NewSource(providerName string, refreshInterval time.Duration) (PriceSource, error) {
+	switch providerName {
+	case cloudprovider.AwsProviderName:
+		return NewAwsSource()
+	case cloudprovider.GceProviderName:
+		return NewGceSource(refreshInterval)
+	case cloudprovider.AzureProviderName:
+		return NewAzureSource(), nil
+	}
+	return nil, fmt.Errorf("live pricing is not supported for cloud provider %q", providerName)
+}
+
+type cacheKey struct {
+	instanceType string
+	region       string
+	spot         bool
+}
+
+type cacheEntry struct {
+	price   float64
+	err     error
+	expires time.Time
+}
+
+// PricingModel implements cloudprovider.PricingModel using a PriceSource.
+// Instance prices are cached for a configurable amount of time.
+type PricingModel struct {
+	source   PriceSource
+	cacheTTL time.Duration
+	now      func() time.Time
+
+	// lookups deduplicates concurrent lookups of the same price, which are
+	// made without holding mutex.
+	lookups singleflight.Group
+	mutex   sync.Mutex
+	cache   map[cacheKey]cacheEntry
+}
+
+// NewPricingModel returns a PricingModel using a given source, caching prices
+// for cacheTTL.
+func NewPricingModel(source PriceSource, cacheTTL time.Duration) *PricingModel {
+	return &PricingModel{
+		source:   source,
+		cacheTTL: cacheTTL,
+		now:      time.Now,
+		cache:    make(map[cacheKey]cacheEntry),
+	}
+}
+
+// NodePrice returns a price of running the given node for a given period of time.
+// All prices are in USD.
+func (m *PricingModel) NodePrice(node *apiv1.Node, startTime time.Time, endTime time.Time) (float64, error) {
+	instanceType, found := labelValue(node.Labels, apiv1.LabelInstanceTypeStable, apiv1.LabelInstanceType)
+	if !found {
+		return 0, fmt.Errorf("node %s has no instance type label", node.Name)
+	}
+	region, found := labelValue(node.Labels, apiv1.LabelTopologyRegion, apiv1.LabelFailureDomainBetaRegion)
+	if !found {
+		return 0, fmt.Errorf("node %s has no region label", node.Name)
+	}
+	price, err := m.instancePrice(cacheKey{instanceType: instanceType, region: region, spot: isSpot(node)})
+	if err != nil {
+		return 0, err
+	}
+	return price * getHours(startTime, endTime), nil
+}
+
+// PodPrice returns a theoretical minimum price of running a pod for a given
+// period of time on a perfectly matching machine.
+func (m *PricingModel) PodPrice(pod *apiv1.Pod, startTime time.Time, endTime time.Time) (float64, error) {
+	requests := podutils.PodRequests(pod)
+	price := 0.0
+	if cpu, found := requests[apiv1.ResourceCPU]; found {
+		price += float64(cpu.MilliValue()) / 1000.0 * cpuPricePerHour
+	}
+	if memory, found := requests[apiv1.ResourceMemory]; found {
+		price += float64(memory.Value()) / float64(units.GiB) * memoryPricePerHour
+	}
+	if gpus, found := requests[gpu.ResourceNvidiaGPU]; found {
+		price += float64(gpus.Value()) * gpuPricePerHour
+	}
+	return price * getHours(startTime, endTime), nil
+}
+
+func (m *PricingModel) instancePrice(key cacheKey) (float64, error) {
+	if entry, found := m.cachedEntry(key); found {
+		return entry.price, entry.err
+	}
+	result, _, _ := m.lookups.Do(fmt.Sprintf("%s/%s/%v", key.instanceType, key.region, key.spot), func() (interface{}, error) {
+		if entry, found := m.cachedEntry(key); found {
+			return entry, nil
+		}
+		price, err := m.source.InstancePrice(key.instanceType, key.region, key.spot)
+		now := m.now()
+		entry := cacheEntry{price: price, err: err, expires: now.Add(m.cacheTTL)}
+		if err != nil {
+			klog.Warningf("Failed to get live price of %s in %s (spot: %v): %v", key.instanceType, key.region, key.spot, err)
+			entry.expires = now.Add(errorCacheTTL)
+		}
+		m.mutex.Lock()
+		m.cache[key] = entry
+		m.mutex.Unlock()
+		return entry, nil
+	})
+	entry := result.(cacheEntry)
+	return entry.price, entry.err
+}
+
+func (m *PricingModel) cachedEntry(key cacheKey) (cacheEntry, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	entry, found := m.cache[key]
+	if !found || !m.now().Before(entry.expires) {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+// spotLabels maps labels set on spot nodes by various providers and
+// provisioners to the value they have on spot nodes.
+var spotLabels = map[string]string{
+	"eks.amazonaws.com/capacityType":        "SPOT",
+	"karpenter.sh/capacity-type":            "spot",
+	"cloud.google.com/gke-spot":             "true",
+	"cloud.google.com/gke-preemptible":      "true",
+	"kubernetes.azure.com/scalesetpriority": "spot",
+}
+
+func isSpot(node *apiv1.Node) bool {
+	for label, value := range spotLabels {
+		if node.Labels[label] == value {
+			return true
+		}
+	}
+	return false
+}
+
+func labelValue(labels map[string]string, keys ...string) (string, bool) {
+	for _, key := range keys {
+		if value, found := labels[key]; found && value != "" {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+func getHours(startTime time.Time, endTime time.Time) float64 {
+	minutes := math.Ceil(float64(endTime.Sub(startTime)) / float64(time.Minute))
+	hours := minutes / 60.0
+	return hours
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pricelive
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+type fakeSource struct {
+	prices map[cacheKey]float64
+	err    error
+	calls  int
+}
+
+func (s *fakeSource) InstancePrice(instanceType, region string, spot bool) (float64, error) {
+	s.calls++
+	if s.err != nil {
+		return 0, s.err
+	}
+	price, found := s.prices[cacheKey{instanceType: instanceType, region: region, spot: spot}]
+	if !found {
+		return 0, errors.New("not found")
+	}
+	return price, nil
+}
+
+func buildNode(name, instanceType, region string, labels map[string]string) *apiv1.Node {
+	node := BuildTestNode(name, 1000, 1000)
+	node.Labels = map[string]string{
+		apiv1.LabelInstanceTypeStable: instanceType,
+		apiv1.LabelTopologyRegion:     region,
+	}
+	for k, v := range labels {
+		node.Labels[k] = v
+	}
+	return node
+}
+
+func TestNodePrice(t *testing.T) {
+	source := &fakeSource{prices: map[cacheKey]float64{
+		{instanceType: "m5.large", region: "us-east-1"}:             0.096,
+		{instanceType: "m5.large", region: "us-east-1", spot: true}: 0.04,
+	}}
+	model := NewPricingModel(source, time.Hour)
+	start := time.Now()
+	end := start.Add(2 * time.Hour)
+
+	testCases := []struct {
+		name      string
+		node      *apiv1.Node
+		wantPrice float64
+		wantErr   bool
+	}{
+		{
+			name:      "on-demand node",
+			node:      buildNode("n1", "m5.large", "us-east-1", nil),
+			wantPrice: 0.192,
+		},
+		{
+			name:      "spot node",
+			node:      buildNode("n2", "m5.large", "us-east-1", map[string]string{"karpenter.sh/capacity-type": "spot"}),
+			wantPrice: 0.08,
+		},
+		{
+			name: "beta labels",
+			node: func() *apiv1.Node {
+				node := BuildTestNode("n3", 1000, 1000)
+				node.Labels = map[string]string{apiv1.LabelInstanceType: "m5.large", apiv1.LabelFailureDomainBetaRegion: "us-east-1"}
+				return node
+			}(),
+			wantPrice: 0.192,
+		},
+		{
+			name:    "no instance type",
+			node:    BuildTestNode("n4", 1000, 1000),
+			wantErr: true,
+		},
+		{
+			name:    "unknown instance type",
+			node:    buildNode("n5", "m5.huge", "us-east-1", nil),
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			price, err := model.NodePrice(tc.node, start, end)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, tc.wantPrice, price, 1e-9)
+		})
+	}
+}
+
+func TestPriceCache(t *testing.T) {
+	source := &fakeSource{prices: map[cacheKey]float64{{instanceType: "m5.large", region: "us-east-1"}: 0.096}}
+	model := NewPricingModel(source, time.Hour)
+	now := time.Now()
+	model.now = func() time.Time { return now }
+	node := buildNode("n1", "m5.large", "us-east-1", nil)
+
+	for i := 0; i < 3; i++ {
+		_, err := model.NodePrice(node, now, now.Add(time.Hour))
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, source.calls)
+
+	now = now.Add(2 * time.Hour)
+	source.err = errors.New("pricing API unavailable")
+	_, err := model.NodePrice(node, now, now.Add(time.Hour))
+	assert.Error(t, err)
+	_, err = model.NodePrice(node, now, now.Add(time.Hour))
+	assert.Error(t, err)
+	assert.Equal(t, 2, source.calls)
+
+	now = now.Add(errorCacheTTL)
+	source.err = nil
+	price, err := model.NodePrice(node, now, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.096, price, 1e-9)
+	assert.Equal(t, 3, source.calls)
+}
+
+type blockingSource struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingSource) InstancePrice(instanceType, _ string, _ bool) (float64, error) {
+	if instanceType == "slow" {
+		close(s.started)
+		<-s.release
+	}
+	return 1, nil
+}
+
+func TestPriceLookupDoesNotBlockCachedPrices(t *testing.T) {
+	source := &blockingSource{started: make(chan struct{}), release: make(chan struct{})}
+	model := NewPricingModel(source, time.Hour)
+	now := time.Now()
+	fast := buildNode("fast", "fast", "us-east-1", nil)
+	_, err := model.NodePrice(fast, now, now.Add(time.Hour))
+	assert.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err := model.NodePrice(buildNode("slow", "slow", "us-east-1", nil), now, now.Add(time.Hour))
+		assert.NoError(t, err)
+	}()
+	<-source.started
+	price, err := model.NodePrice(fast, now, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.InDelta(t, 1.0, price, 1e-9)
+	close(source.release)
+	<-done
+}
+
+func TestPodPrice(t *testing.T) {
+	model := NewPricingModel(&fakeSource{}, time.Hour)
+	now := time.Now()
+	pod := BuildTestPod("p1", 2000, 4*1024*1024*1024)
+	price, err := model.PodPrice(pod, now, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.InDelta(t, 2*cpuPricePerHour+4*memoryPricePerHour, price, 1e-9)
+}
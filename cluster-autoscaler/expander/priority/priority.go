@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"time"
 
 	"gopkg.in/yaml.v2"
 
@@ -27,6 +28,7 @@ import (
 
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/backoff"
 	v1lister "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/record"
 	klog "k8s.io/klog/v2"
@@ -37,33 +39,75 @@ const (
 	PriorityConfigMapName = "cluster-autoscaler-priority-expander"
 	// ConfigMapKey defines the key used in the ConfigMap to configure priorities
 	ConfigMapKey = "priorities"
+	// ConfigMapKeyV2 defines the key used in the ConfigMap to configure priorities with the v2 schema,
+	// which also supports skipping recently backed off node groups. It takes precedence over ConfigMapKey.
+	ConfigMapKeyV2 = "priorities-v2"
+	// defaultBackoffCooldown is how long after the end of their backoff node groups are skipped by default.
+	defaultBackoffCooldown = 10 * time.Minute
 )
 
 type priorities map[int][]*regexp.Regexp
 
+// configV2 is the v2 schema of the priority expander configuration.
+type configV2 struct {
+	// Priorities maps priorities to regular expressions matching node group ids, like the v1 schema.
+	Priorities map[int][]string `yaml:"priorities"`
+	// SkipBackedOffNodeGroups makes the expander drop options whose node groups were backed off within BackoffCooldown.
+	SkipBackedOffNodeGroups bool `yaml:"skipBackedOffNodeGroups"`
+	// BackoffCooldown is how long after the end of their backoff node groups are still skipped.
+	BackoffCooldown time.Duration `yaml:"backoffCooldown"`
+}
+
+type priorityConfig struct {
+	priorities              priorities
+	skipBackedOffNodeGroups bool
+	backoffCooldown         time.Duration
+}
+
 type priority struct {
 	logRecorder      record.EventRecorder
 	okConfigUpdates  int
 	badConfigUpdates int
 	configMapLister  v1lister.ConfigMapNamespaceLister
+	tieBreaker       expander.Strategy
+	nodeGroupBackoff backoff.Backoff
 }
 
 // NewFilter returns an expansion filter that picks node groups based on user-defined priorities
 func NewFilter(configMapLister v1lister.ConfigMapNamespaceLister,
 	logRecorder record.EventRecorder) expander.Filter {
-	res := &priority{
-		logRecorder:     logRecorder,
-		configMapLister: configMapLister,
+	return NewFilterWithTieBreaking(configMapLister, logRecorder, nil, nil)
+}
+
+// NewFilterWithTieBreaking returns an expansion filter that picks node groups based on user-defined priorities.
+// Options with the same priority are passed to tieBreaker, if not nil. With the v2 configuration schema, options
+// whose node groups were recently backed off according to nodeGroupBackoff can be skipped.
+func NewFilterWithTieBreaking(configMapLister v1lister.ConfigMapNamespaceLister,
+	logRecorder record.EventRecorder, tieBreaker expander.Strategy, nodeGroupBackoff backoff.Backoff) expander.Filter {
+	return &priority{
+		logRecorder:      logRecorder,
+		configMapLister:  configMapLister,
+		tieBreaker:       tieBreaker,
+		nodeGroupBackoff: nodeGroupBackoff,
 	}
-	return res
 }
 
-func (p *priority) reloadConfigMap() (priorities, *apiv1.ConfigMap, error) {
+func (p *priority) reloadConfigMap() (*priorityConfig, *apiv1.ConfigMap, error) {
 	cm, err := p.configMapLister.Get(PriorityConfigMapName)
 	if err != nil {
 		return nil, nil, fmt.Errorf("Priority expander config map %s not found: %v", PriorityConfigMapName, err)
 	}
 
+	if configString, found := cm.Data[ConfigMapKeyV2]; found {
+		config, err := p.parseConfigV2YAMLString(configString)
+		if err != nil {
+			msg := fmt.Sprintf("Wrong configuration for priority expander: %v. Ignoring update.", err)
+			p.logConfigWarning(cm, "PriorityConfigMapInvalid", msg)
+			return nil, cm, err
+		}
+		return config, cm, nil
+	}
+
 	prioString, found := cm.Data[ConfigMapKey]
 	if !found {
 		msg := fmt.Sprintf("Wrong configmap for priority expander, doesn't contain %s key. Ignoring update.",
@@ -79,7 +123,7 @@ func (p *priority) reloadConfigMap() (priorities, *apiv1.ConfigMap, error) {
 		return nil, cm, err
 	}
 
-	return newPriorities, cm, nil
+	return &priorityConfig{priorities: newPriorities}, cm, nil
 }
 
 func (p *priority) logConfigWarning(cm *apiv1.ConfigMap, reason, msg string) {
@@ -98,6 +142,53 @@ func (p *priority) parsePrioritiesYAMLString(prioritiesYAML string) (priorities,
 		return nil, fmt.Errorf("Can't parse YAML with priorities in the configmap: %v", err)
 	}
 
+	newPriorities, err := compilePriorities(config)
+	if err != nil {
+		return nil, err
+	}
+
+	p.okConfigUpdates++
+	msg := "Successfully loaded priority configuration from configmap."
+	klog.V(4).Info(msg)
+
+	return newPriorities, nil
+}
+
+func (p *priority) parseConfigV2YAMLString(configYAML string) (*priorityConfig, error) {
+	if configYAML == "" {
+		return nil, fmt.Errorf("priority configuration in %s configmap is empty; please provide valid configuration",
+			PriorityConfigMapName)
+	}
+	var config configV2
+	if err := yaml.Unmarshal([]byte(configYAML), &config); err != nil {
+		return nil, fmt.Errorf("Can't parse YAML with priorities in the configmap: %v", err)
+	}
+	if len(config.Priorities) == 0 {
+		return nil, fmt.Errorf("priority configuration in %s configmap has no priorities", PriorityConfigMapName)
+	}
+	if config.BackoffCooldown < 0 {
+		return nil, fmt.Errorf("backoffCooldown can't be negative, got %v", config.BackoffCooldown)
+	}
+	if config.BackoffCooldown == 0 {
+		config.BackoffCooldown = defaultBackoffCooldown
+	}
+
+	newPriorities, err := compilePriorities(config.Priorities)
+	if err != nil {
+		return nil, err
+	}
+
+	p.okConfigUpdates++
+	klog.V(4).Info("Successfully loaded priority configuration v2 from configmap.")
+
+	return &priorityConfig{
+		priorities:              newPriorities,
+		skipBackedOffNodeGroups: config.SkipBackedOffNodeGroups,
+		backoffCooldown:         config.BackoffCooldown,
+	}, nil
+}
+
+func compilePriorities(config map[int][]string) (priorities, error) {
 	newPriorities := make(map[int][]*regexp.Regexp)
 	for prio, reList := range config {
 		for _, re := range reList {
@@ -108,11 +199,6 @@ func (p *priority) parsePrioritiesYAMLString(prioritiesYAML string) (priorities,
 			newPriorities[prio] = append(newPriorities[prio], regexp)
 		}
 	}
-
-	p.okConfigUpdates++
-	msg := "Successfully loaded priority configuration from configmap."
-	klog.V(4).Info(msg)
-
 	return newPriorities, nil
 }
 
@@ -121,17 +207,21 @@ func (p *priority) BestOptions(expansionOptions []expander.Option, nodeInfo map[
 		return nil
 	}
 
-	priorities, cm, err := p.reloadConfigMap()
+	config, cm, err := p.reloadConfigMap()
 	if err != nil {
 		return expansionOptions
 	}
 
+	if config.skipBackedOffNodeGroups {
+		expansionOptions = p.skipBackedOffOptions(expansionOptions, nodeInfo, config.backoffCooldown)
+	}
+
 	maxPrio := -1
 	best := []expander.Option{}
 	for _, option := range expansionOptions {
 		id := option.NodeGroup.Id()
 		found := false
-		for prio, nameRegexpList := range priorities {
+		for prio, nameRegexpList := range config.priorities {
 			if !p.groupIDMatchesList(id, nameRegexpList) {
 				continue
 			}
@@ -159,12 +249,43 @@ func (p *priority) BestOptions(expansionOptions []expander.Option, nodeInfo map[
 		return expansionOptions
 	}
 
+	if len(best) > 1 && p.tieBreaker != nil {
+		if option := p.tieBreaker.BestOption(best, nodeInfo); option != nil {
+			best = []expander.Option{*option}
+		}
+	}
+
 	for _, opt := range best {
 		klog.V(2).Infof("priority expander: %s chosen as the highest available", opt.NodeGroup.Id())
 	}
 	return best
 }
 
+// skipBackedOffOptions drops options whose node groups are backed off or whose backoff ended within cooldown,
+// unless all of them are. Node groups which are still backed off are already excluded from scale-up before the
+// expander is called, so the cooldown keeps them deprioritized until they have stayed out of backoff for a while.
+func (p *priority) skipBackedOffOptions(expansionOptions []expander.Option, nodeInfo map[string]*framework.NodeInfo, cooldown time.Duration) []expander.Option {
+	if p.nodeGroupBackoff == nil {
+		return expansionOptions
+	}
+	// A node group backed off until any point after cooldownStart is backed off at cooldownStart.
+	cooldownStart := time.Now().Add(-cooldown)
+	var options []expander.Option
+	for _, option := range expansionOptions {
+		id := option.NodeGroup.Id()
+		if p.nodeGroupBackoff.BackoffStatus(option.NodeGroup, nodeInfo[id], cooldownStart).IsBackedOff {
+			klog.V(2).Infof("priority expander: skipping node group %s, it was backed off within the last %v", id, cooldown)
+			continue
+		}
+		options = append(options, option)
+	}
+	if len(options) == 0 {
+		klog.V(2).Info("priority expander: all node groups were recently backed off, none skipped")
+		return expansionOptions
+	}
+	return options
+}
+
 func (p *priority) groupIDMatchesList(id string, nameRegexpList []*regexp.Regexp) bool {
 	for _, re := range nameRegexpList {
 		if re.FindStringIndex(id) != nil {
@@ -19,6 +19,7 @@ package priority
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -26,8 +27,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
 	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/backoff"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
 )
 
@@ -61,6 +65,16 @@ var (
   - ".*t\\.micro.*"
 10: 
   - ".*t\\.large.*"
+`
+	v2Config = `
+priorities:
+  10:
+    - ".*t2\\.large.*"
+    - ".*t3\\.large.*"
+  50:
+    - ".*m4\\.4xlarge.*"
+skipBackedOffNodeGroups: true
+backoffCooldown: 15m
 `
 	wildcardMatchConfig = `
 5:
@@ -167,3 +181,122 @@ func TestPriorityExpanderCorrecltySkipsBadChangeConfig(t *testing.T) {
 	assert.EqualValues(t, configWarnConfigMapEmpty, event)
 	assert.Equal(t, ret, []expander.Option{eoT2Large, eoT3Large, eoM44XLarge})
 }
+
+type lastOptionStrategy struct{}
+
+func (lastOptionStrategy) BestOption(options []expander.Option, nodeInfo map[string]*framework.NodeInfo) *expander.Option {
+	return &options[len(options)-1]
+}
+
+func getFilterInstanceV2(t *testing.T, config string, tieBreaker expander.Strategy, nodeGroupBackoff backoff.Backoff) (expander.Filter, *record.FakeRecorder) {
+	cm := &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      PriorityConfigMapName,
+		},
+		Data: map[string]string{
+			ConfigMapKey:   oneEntryConfig,
+			ConfigMapKeyV2: config,
+		},
+	}
+	lister, err := kubernetes.NewTestConfigMapLister([]*apiv1.ConfigMap{cm})
+	assert.Nil(t, err)
+	r := record.NewFakeRecorder(100)
+	s := NewFilterWithTieBreaking(lister.ConfigMaps(testNamespace), r, tieBreaker, nodeGroupBackoff)
+	return s, r
+}
+
+func TestPriorityExpanderV2(t *testing.T) {
+	quotaError := cloudprovider.InstanceErrorInfo{ErrorClass: cloudprovider.OutOfResourcesErrorClass, ErrorCode: "QUOTA_EXCEEDED"}
+	now := time.Now()
+
+	for _, tc := range []struct {
+		name            string
+		config          string
+		tieBreaker      expander.Strategy
+		backedOff       []expander.Option
+		backedOffAt     time.Time
+		expectedOptions []expander.Option
+		expectedEvent   string
+	}{
+		{
+			name:            "highest priority wins",
+			config:          v2Config,
+			expectedOptions: []expander.Option{eoM44XLarge},
+		},
+		{
+			name:            "recently backed off node groups are skipped and ties are broken",
+			config:          v2Config,
+			tieBreaker:      lastOptionStrategy{},
+			backedOff:       []expander.Option{eoM44XLarge},
+			backedOffAt:     now.Add(-15 * time.Minute),
+			expectedOptions: []expander.Option{eoT3Large},
+		},
+		{
+			name:            "node groups backed off before the cooldown aren't skipped",
+			config:          v2Config,
+			backedOff:       []expander.Option{eoM44XLarge},
+			backedOffAt:     now.Add(-25 * time.Minute),
+			expectedOptions: []expander.Option{eoM44XLarge},
+		},
+		{
+			name:            "default cooldown",
+			config:          "priorities:\n  10:\n    - \"my-asg.t\"\nskipBackedOffNodeGroups: true\n",
+			backedOff:       []expander.Option{eoT2Large},
+			backedOffAt:     now.Add(-10 * time.Minute),
+			expectedOptions: []expander.Option{eoT3Large},
+		},
+		{
+			name:            "all node groups recently backed off",
+			config:          v2Config,
+			tieBreaker:      lastOptionStrategy{},
+			backedOff:       []expander.Option{eoT2Large, eoT3Large, eoM44XLarge},
+			backedOffAt:     now,
+			expectedOptions: []expander.Option{eoM44XLarge},
+		},
+		{
+			name:            "backed off node groups are not skipped by default",
+			config:          "priorities:\n  10:\n    - \"my-asg.t\"\n",
+			backedOff:       []expander.Option{eoT2Large},
+			backedOffAt:     now,
+			expectedOptions: []expander.Option{eoT2Large, eoT3Large},
+		},
+		{
+			name:            "negative cooldown",
+			config:          "priorities:\n  10:\n    - \".*\"\nbackoffCooldown: -1m\n",
+			expectedOptions: []expander.Option{eoT2Large, eoT3Large, eoM44XLarge},
+			expectedEvent:   "Warning PriorityConfigMapInvalid Wrong configuration for priority expander: backoffCooldown can't be negative, got -1m0s. Ignoring update.",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Backoffs start at 5 minutes.
+			nodeGroupBackoff := backoff.NewIdBasedExponentialBackoff(5*time.Minute, 30*time.Minute, 3*time.Hour)
+			for _, option := range tc.backedOff {
+				nodeGroupBackoff.Backoff(option.NodeGroup, nil, quotaError, tc.backedOffAt)
+			}
+			s, r := getFilterInstanceV2(t, tc.config, tc.tieBreaker, nodeGroupBackoff)
+			ret := s.BestOptions([]expander.Option{eoT2Large, eoT3Large, eoM44XLarge}, nil)
+			assert.Equal(t, tc.expectedOptions, ret)
+			if tc.expectedEvent != "" {
+				assert.Equal(t, tc.expectedEvent, <-r.Events)
+			}
+		})
+	}
+}
+
+func TestPriorityExpanderTieBreakerWithV1Config(t *testing.T) {
+	cm := &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      PriorityConfigMapName,
+		},
+		Data: map[string]string{
+			ConfigMapKey: config,
+		},
+	}
+	lister, err := kubernetes.NewTestConfigMapLister([]*apiv1.ConfigMap{cm})
+	assert.Nil(t, err)
+	s := NewFilterWithTieBreaking(lister.ConfigMaps(testNamespace), record.NewFakeRecorder(100), lastOptionStrategy{}, nil)
+	ret := s.BestOptions([]expander.Option{eoT2Micro, eoT2Large, eoT3Large}, nil)
+	assert.Equal(t, []expander.Option{eoT3Large}, ret)
+}
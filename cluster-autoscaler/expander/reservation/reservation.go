@@ -0,0 +1,68 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reservation
+
+import (
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+)
+
+// ReservedCapacityProvider is implemented by cloud providers aware of capacity reservations.
+type ReservedCapacityProvider interface {
+	// FreeReservedCapacity returns the number of nodes which can still be created in the node group from
+	// the reservations it consumes, and whether the node group consumes reservations at all.
+	FreeReservedCapacity(nodeGroup cloudprovider.NodeGroup) (int, bool)
+}
+
+type reservation struct {
+	provider ReservedCapacityProvider
+}
+
+// NewFilter returns a scale up filter that prefers node groups whose reservations have free capacity.
+// If provider is nil, the filter is disabled and returns all options.
+func NewFilter(provider ReservedCapacityProvider) expander.Filter {
+	return &reservation{provider: provider}
+}
+
+// BestOptions selects the expansion options whose reservations cover all the new nodes. If there are none,
+// the options whose reservations cover at least some of them are selected. Otherwise all options are returned.
+func (r *reservation) BestOptions(expansionOptions []expander.Option, nodeInfo map[string]*framework.NodeInfo) []expander.Option {
+	if r.provider == nil {
+		return expansionOptions
+	}
+	var fullyReserved, partiallyReserved []expander.Option
+	for _, option := range expansionOptions {
+		free, consumes := r.provider.FreeReservedCapacity(option.NodeGroup)
+		if !consumes || free <= 0 {
+			continue
+		}
+		if free >= option.NodeCount {
+			fullyReserved = append(fullyReserved, option)
+		} else {
+			partiallyReserved = append(partiallyReserved, option)
+		}
+	}
+
+	if len(fullyReserved) > 0 {
+		return fullyReserved
+	}
+	if len(partiallyReserved) > 0 {
+		return partiallyReserved
+	}
+	return expansionOptions
+}
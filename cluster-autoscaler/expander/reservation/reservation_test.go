@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reservation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/expander"
+)
+
+type fakeReservedCapacityProvider map[string]int
+
+func (p fakeReservedCapacityProvider) FreeReservedCapacity(nodeGroup cloudprovider.NodeGroup) (int, bool) {
+	free, found := p[nodeGroup.Id()]
+	return free, found
+}
+
+func option(id string, nodeCount int) expander.Option {
+	return expander.Option{
+		NodeGroup: test.NewTestNodeGroup(id, 10, 0, 0, true, false, "n2-standard-4", nil, nil),
+		NodeCount: nodeCount,
+		Debug:     id,
+	}
+}
+
+func TestReservation(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		freeCapacity     fakeReservedCapacityProvider
+		expansionOptions []expander.Option
+		expectedOptions  []string
+	}{
+		{
+			name:             "no options",
+			freeCapacity:     fakeReservedCapacityProvider{},
+			expansionOptions: nil,
+			expectedOptions:  nil,
+		},
+		{
+			name:             "no reservations",
+			freeCapacity:     fakeReservedCapacityProvider{},
+			expansionOptions: []expander.Option{option("ng1", 2), option("ng2", 3)},
+			expectedOptions:  []string{"ng1", "ng2"},
+		},
+		{
+			name:             "all reservations exhausted",
+			freeCapacity:     fakeReservedCapacityProvider{"ng1": 0, "ng2": 0},
+			expansionOptions: []expander.Option{option("ng1", 2), option("ng2", 3)},
+			expectedOptions:  []string{"ng1", "ng2"},
+		},
+		{
+			name:             "reservation covering all nodes preferred",
+			freeCapacity:     fakeReservedCapacityProvider{"ng1": 1, "ng2": 3, "ng3": 5},
+			expansionOptions: []expander.Option{option("ng1", 2), option("ng2", 3), option("ng3", 2), option("ng4", 1)},
+			expectedOptions:  []string{"ng2", "ng3"},
+		},
+		{
+			name:             "reservation covering some nodes preferred",
+			freeCapacity:     fakeReservedCapacityProvider{"ng1": 1, "ng2": 0},
+			expansionOptions: []expander.Option{option("ng1", 2), option("ng2", 3), option("ng3", 1)},
+			expectedOptions:  []string{"ng1"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			options := NewFilter(tc.freeCapacity).BestOptions(tc.expansionOptions, nil)
+			var ids []string
+			for _, option := range options {
+				ids = append(ids, option.NodeGroup.Id())
+			}
+			assert.Equal(t, tc.expectedOptions, ids)
+		})
+	}
+}
+
+func TestReservationWithoutProvider(t *testing.T) {
+	options := []expander.Option{option("ng1", 2), option("ng2", 3)}
+	assert.Equal(t, options, NewFilter(nil).BestOptions(options, nil))
+}
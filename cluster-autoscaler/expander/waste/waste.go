@@ -39,22 +39,11 @@ func (l *leastwaste) BestOptions(expansionOptions []expander.Option, nodeInfo ma
 	var leastWastedOptions []expander.Option
 
 	for _, option := range expansionOptions {
-		requestedCPU, requestedMemory := resourcesForPods(option.Pods)
-		node, found := nodeInfo[option.NodeGroup.Id()]
+		wastedScore, found := wasteScore(option, nodeInfo)
 		if !found {
-			klog.Errorf("No node info for: %s", option.NodeGroup.Id())
 			continue
 		}
 
-		nodeCPU, nodeMemory := resourcesForNode(node.Node())
-		availCPU := nodeCPU.MilliValue() * int64(option.NodeCount)
-		availMemory := nodeMemory.Value() * int64(option.NodeCount)
-		wastedCPU := float64(availCPU-requestedCPU.MilliValue()) / float64(availCPU)
-		wastedMemory := float64(availMemory-requestedMemory.Value()) / float64(availMemory)
-		wastedScore := wastedCPU + wastedMemory
-
-		klog.V(1).Infof("Expanding Node Group %s would waste %0.2f%% CPU, %0.2f%% Memory, %0.2f%% Blended\n", option.NodeGroup.Id(), wastedCPU*100.0, wastedMemory*100.0, wastedScore*50.0)
-
 		if wastedScore == leastWastedScore {
 			leastWastedOptions = append(leastWastedOptions, option)
 		}
@@ -72,6 +61,36 @@ func (l *leastwaste) BestOptions(expansionOptions []expander.Option, nodeInfo ma
 	return leastWastedOptions
 }
 
+// ScoreOptions scores options by the fraction of CPU and Memory they would waste
+func (l *leastwaste) ScoreOptions(expansionOptions []expander.Option, nodeInfo map[string]*framework.NodeInfo) map[string]float64 {
+	scores := make(map[string]float64, len(expansionOptions))
+	for _, option := range expansionOptions {
+		if wastedScore, found := wasteScore(option, nodeInfo); found {
+			scores[option.NodeGroup.Id()] = wastedScore
+		}
+	}
+	return scores
+}
+
+func wasteScore(option expander.Option, nodeInfo map[string]*framework.NodeInfo) (float64, bool) {
+	requestedCPU, requestedMemory := resourcesForPods(option.Pods)
+	node, found := nodeInfo[option.NodeGroup.Id()]
+	if !found {
+		klog.Errorf("No node info for: %s", option.NodeGroup.Id())
+		return 0, false
+	}
+
+	nodeCPU, nodeMemory := resourcesForNode(node.Node())
+	availCPU := nodeCPU.MilliValue() * int64(option.NodeCount)
+	availMemory := nodeMemory.Value() * int64(option.NodeCount)
+	wastedCPU := float64(availCPU-requestedCPU.MilliValue()) / float64(availCPU)
+	wastedMemory := float64(availMemory-requestedMemory.Value()) / float64(availMemory)
+	wastedScore := wastedCPU + wastedMemory
+
+	klog.V(1).Infof("Expanding Node Group %s would waste %0.2f%% CPU, %0.2f%% Memory, %0.2f%% Blended\n", option.NodeGroup.Id(), wastedCPU*100.0, wastedMemory*100.0, wastedScore*50.0)
+	return wastedScore, true
+}
+
 func resourcesForPods(pods []*apiv1.Pod) (cpu resource.Quantity, memory resource.Quantity) {
 	for _, pod := range pods {
 		podRequests := podutils.PodRequests(pod)
@@ -125,4 +125,9 @@ func TestLeastWaste(t *testing.T) {
 	lowcpuOption := expander.Option{NodeGroup: &FakeNodeGroup{"lowcpu"}, NodeCount: 1, Pods: []*apiv1.Pod{pod}}
 	ret = e.BestOptions([]expander.Option{balancedOption, highmemOption, lowcpuOption}, nodeMap)
 	assert.Equal(t, ret, []expander.Option{lowcpuOption})
+
+	// Test scores, options without node info are not scored
+	unknownOption := expander.Option{NodeGroup: &FakeNodeGroup{"unknown"}, NodeCount: 1, Pods: []*apiv1.Pod{pod}}
+	scores := e.(expander.Scorer).ScoreOptions([]expander.Option{balancedOption, highmemOption, lowcpuOption, unknownOption}, nodeMap)
+	assert.InDeltaMapValues(t, map[string]float64{"balanced": 15.0/16 + 15.0/16, "highmem": 15.0/16 + 31.0/32, "lowcpu": 7.0/8 + 15.0/16}, scores, 1e-9)
 }
@@ -38,10 +38,11 @@ var (
 		string(apiv1.PodSucceeded) + ",status.phase!=" + string(apiv1.PodFailed))
 )
 
-// scalingTimesGetter exposes recent autoscaler activity
+// scalingTimesGetter exposes recent autoscaler activity and how often the autoscaler should run
 type scalingTimesGetter interface {
 	LastScaleUpTime() time.Time
 	LastScaleDownDeleteTime() time.Time
+	ScanInterval() time.Duration
 }
 
 // provisioningRequestProcessingTimesGetter exposes recent provisioning request processing activity regardless of wether the
@@ -55,16 +56,14 @@ type provisioningRequestProcessingTimesGetter interface {
 // LoopTrigger object implements criteria used to start new autoscaling iteration
 type LoopTrigger struct {
 	podObserver                          *UnschedulablePodObserver
-	scanInterval                         time.Duration
 	scalingTimesGetter                   scalingTimesGetter
 	provisioningRequestProcessTimeGetter provisioningRequestProcessingTimesGetter
 }
 
 // NewLoopTrigger creates a LoopTrigger object
-func NewLoopTrigger(scalingTimesGetter scalingTimesGetter, provisioningRequestProcessTimeGetter provisioningRequestProcessingTimesGetter, podObserver *UnschedulablePodObserver, scanInterval time.Duration) *LoopTrigger {
+func NewLoopTrigger(scalingTimesGetter scalingTimesGetter, provisioningRequestProcessTimeGetter provisioningRequestProcessingTimesGetter, podObserver *UnschedulablePodObserver) *LoopTrigger {
 	return &LoopTrigger{
 		podObserver:                          podObserver,
-		scanInterval:                         scanInterval,
 		scalingTimesGetter:                   scalingTimesGetter,
 		provisioningRequestProcessTimeGetter: provisioningRequestProcessTimeGetter,
 	}
@@ -93,9 +92,10 @@ func (t *LoopTrigger) Wait(lastRun time.Time) {
 	}
 
 	// Unschedulable pod triggers autoscaling immediately.
+	scanInterval := t.scalingTimesGetter.ScanInterval()
 	select {
-	case <-time.After(t.scanInterval):
-		klog.Infof("Autoscaler loop triggered by a %v timer", t.scanInterval)
+	case <-time.After(scanInterval):
+		klog.Infof("Autoscaler loop triggered by a %v timer", scanInterval)
 	case <-t.podObserver.unschedulablePodChan:
 		klog.Info("Autoscaler loop triggered by unschedulable pod appearing")
 	}
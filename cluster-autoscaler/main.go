@@ -27,8 +27,14 @@ import (
 
 	"github.com/spf13/pflag"
 
+	"k8s.io/autoscaler/cluster-autoscaler/config/dynamic"
 	"k8s.io/autoscaler/cluster-autoscaler/config/flags"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/predelete"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup/dryrun"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup/orchestrator"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleupforecast"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleupintent"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scalingschedule"
 	"k8s.io/autoscaler/cluster-autoscaler/debuggingsnapshot"
 	"k8s.io/autoscaler/cluster-autoscaler/loop"
 	"k8s.io/autoscaler/cluster-autoscaler/provisioningrequest/besteffortatomic"
@@ -47,11 +53,17 @@ import (
 	"k8s.io/apiserver/pkg/server/routes"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/pause"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/utils"
 	"k8s.io/autoscaler/cluster-autoscaler/core"
 	"k8s.io/autoscaler/cluster-autoscaler/core/podlistprocessor"
 	"k8s.io/autoscaler/cluster-autoscaler/metrics"
 	"k8s.io/autoscaler/cluster-autoscaler/observers/loopstart"
 	ca_processors "k8s.io/autoscaler/cluster-autoscaler/processors"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/capacitybuffer"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupconfig"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroups/autoprovisioning"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroups/volumetopology"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupset"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/nodeinfosprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/podinjection"
@@ -60,13 +72,21 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/processors/provreq"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/scaledowncandidates"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/scaledowncandidates/emptycandidates"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/scaledowncandidates/nodegrouppriority"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/scaledowncandidates/previouscandidates"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/scaledowncandidates/strategies"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/scalingdecision"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
 	provreqorchestrator "k8s.io/autoscaler/cluster-autoscaler/provisioningrequest/orchestrator"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules/volumelimits"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules/webhook"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/options"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/basicauth"
 	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/tracing"
 	"k8s.io/autoscaler/cluster-autoscaler/version"
+	"k8s.io/autoscaler/cluster-autoscaler/webui"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
@@ -80,7 +100,7 @@ import (
 	"k8s.io/klog/v2"
 )
 
-func registerSignalHandlers(autoscaler core.Autoscaler) {
+func registerSignalHandlers(autoscaler core.Autoscaler, releaseLeadership func()) {
 	sigs := make(chan os.Signal, 1)
 	signal.Notify(sigs, os.Interrupt, os.Kill, syscall.SIGTERM, syscall.SIGQUIT)
 	klog.V(1).Info("Registered cleanup signal handler")
@@ -89,13 +109,18 @@ func registerSignalHandlers(autoscaler core.Autoscaler) {
 		<-sigs
 		klog.V(1).Info("Received signal, attempting cleanup")
 		autoscaler.ExitCleanUp()
+		if releaseLeadership != nil {
+			// Releasing the lease lets another replica take over right away, instead of after the lease expires.
+			klog.V(1).Info("Cleaned up, releasing leadership...")
+			releaseLeadership()
+		}
 		klog.V(1).Info("Cleaned up, exiting...")
 		klog.Flush()
 		os.Exit(0)
 	}()
 }
 
-func buildAutoscaler(context ctx.Context, debuggingSnapshotter debuggingsnapshot.DebuggingSnapshotter) (core.Autoscaler, *loop.LoopTrigger, error) {
+func buildAutoscaler(context ctx.Context, debuggingSnapshotter debuggingsnapshot.DebuggingSnapshotter, scaleUpSimulationHandler *dryrun.Handler, pauses *pause.Registry, webUIState *webui.State) (core.Autoscaler, *loop.LoopTrigger, error) {
 	// Get AutoscalingOptions from flags.
 	autoscalingOptions := flags.AutoscalingOptions()
 
@@ -116,6 +141,17 @@ func buildAutoscaler(context ctx.Context, debuggingSnapshotter debuggingsnapshot
 	}
 	deleteOptions := options.NewNodeDeleteOptions(autoscalingOptions)
 	drainabilityRules := rules.Default(deleteOptions)
+	if autoscalingOptions.SkipNodesWithUnattachableVolumes {
+		drainabilityRules = append(drainabilityRules, volumelimits.New(informerFactory))
+	}
+	if autoscalingOptions.DrainabilityWebhookURL != "" {
+		webhookRule, err := webhook.New(autoscalingOptions.DrainabilityWebhookURL, autoscalingOptions.DrainabilityWebhookCACert, autoscalingOptions.DrainabilityWebhookTimeout, webhook.FailurePolicy(autoscalingOptions.DrainabilityWebhookFailurePolicy))
+		if err != nil {
+			return nil, nil, err
+		}
+		// The webhook runs first, so that its decision to block draining a node isn't overridden by built-in rules.
+		drainabilityRules = append(rules.Rules{webhookRule}, drainabilityRules...)
+	}
 
 	var snapshotStore clustersnapshot.ClusterSnapshotStore = store.NewDeltaSnapshotStore(autoscalingOptions.ClusterSnapshotParallelism)
 	if autoscalingOptions.DynamicResourceAllocationEnabled {
@@ -136,9 +172,57 @@ func buildAutoscaler(context ctx.Context, debuggingSnapshotter debuggingsnapshot
 		ScaleUpOrchestrator:  orchestrator.New(),
 	}
 
+	opts.ScaleUpSimulationHandler = scaleUpSimulationHandler
+	opts.Pauses = pauses
+	opts.WebUI = webUIState
+	if autoscalingOptions.OptionsConfigMapName != "" {
+		optionsReloader, err := dynamic.NewOptionsReloader(kubeClient, autoscalingOptions.ConfigNamespace, autoscalingOptions.OptionsConfigMapName, autoscalingOptions)
+		if err != nil {
+			return nil, nil, err
+		}
+		opts.OptionsReloader = optionsReloader
+	}
+	if autoscalingOptions.WriteStatusObject {
+		restConfig := kube_util.GetKubeConfig(autoscalingOptions.KubeClientOpts)
+		statusObjectWriter, err := utils.NewStatusObjectWriter(restConfig, autoscalingOptions.ConfigNamespace, autoscalingOptions.StatusObjectName)
+		if err != nil {
+			return nil, nil, err
+		}
+		opts.StatusObjectWriter = statusObjectWriter
+	}
+	if autoscalingOptions.ScaleUpIntentsEnabled {
+		restConfig := kube_util.GetKubeConfig(autoscalingOptions.KubeClientOpts)
+		scaleUpIntentStore, err := scaleupintent.NewStore(restConfig, autoscalingOptions.ConfigNamespace)
+		if err != nil {
+			return nil, nil, err
+		}
+		opts.ScaleUpIntentStore = scaleUpIntentStore
+	}
+	if autoscalingOptions.PredictiveScaleUpEnabled {
+		restConfig := kube_util.GetKubeConfig(autoscalingOptions.KubeClientOpts)
+		scaleUpHistoryStore, err := scaleupforecast.NewStore(restConfig, autoscalingOptions.ConfigNamespace)
+		if err != nil {
+			return nil, nil, err
+		}
+		opts.ScaleUpHistoryStore = scaleUpHistoryStore
+	}
+	switch autoscalingOptions.PreDeleteHook {
+	case predelete.AnnotationMode:
+		opts.PreDeleteHook = predelete.NewAnnotationHook(kubeClient, autoscalingOptions.PreDeleteHookTimeout)
+	case predelete.NodeDeletionHookMode:
+		restConfig := kube_util.GetKubeConfig(autoscalingOptions.KubeClientOpts)
+		nodeDeletionHookClient, err := predelete.NewNodeDeletionHookClient(restConfig, autoscalingOptions.PreDeleteHookTimeout)
+		if err != nil {
+			return nil, nil, err
+		}
+		opts.PreDeleteHook = nodeDeletionHookClient
+	}
 	opts.Processors = ca_processors.DefaultProcessors(autoscalingOptions)
 	opts.Processors.TemplateNodeInfoProvider = nodeinfosprovider.NewDefaultTemplateNodeInfoProvider(&autoscalingOptions.NodeInfoCacheExpireTime, autoscalingOptions.ForceDaemonSets)
 	podListProcessor := podlistprocessor.NewDefaultPodListProcessor(scheduling.ScheduleAnywhere)
+	if autoscalingOptions.ScaleUpForUnsatisfiableTopologySpread {
+		podListProcessor.AddProcessor(podlistprocessor.NewEnforceTopologySpreadPodListProcessor())
+	}
 
 	var ProvisioningRequestInjector *provreq.ProvisioningRequestPodsInjector
 	if autoscalingOptions.ProvisioningRequestEnabled {
@@ -162,15 +246,16 @@ func buildAutoscaler(context ctx.Context, debuggingSnapshotter debuggingsnapshot
 			provisioningRequestPodsInjector = ProvisioningRequestInjector
 		}
 
+		bestEffortAtomicClass := besteffortatomic.New(client)
 		provreqOrchestrator := provreqorchestrator.New(client, []provreqorchestrator.ProvisioningClass{
 			checkcapacity.New(client, provisioningRequestPodsInjector),
-			besteffortatomic.New(client),
+			bestEffortAtomicClass,
 		})
 
 		scaleUpOrchestrator := provreqorchestrator.NewWrapperOrchestrator(provreqOrchestrator)
 		opts.ScaleUpOrchestrator = scaleUpOrchestrator
 		provreqProcesor := provreq.NewProvReqProcessor(client, opts.CheckCapacityProcessorInstance)
-		opts.LoopStartNotifier = loopstart.NewObserversList([]loopstart.Observer{provreqProcesor})
+		opts.LoopStartNotifier = loopstart.NewObserversList([]loopstart.Observer{provreqProcesor, bestEffortAtomicClass})
 
 		podListProcessor.AddProcessor(provreqProcesor)
 
@@ -190,12 +275,96 @@ func buildAutoscaler(context ctx.Context, debuggingSnapshotter debuggingsnapshot
 		opts.Processors.ScaleUpStatusProcessor = status.NewCombinedScaleUpStatusProcessor([]status.ScaleUpStatusProcessor{podinjection.NewFakePodsScaleUpStatusProcessor(podInjectionBackoffRegistry), opts.Processors.ScaleUpStatusProcessor})
 	}
 
+	if autoscalingOptions.CapacityBuffersEnabled {
+		restConfig := kube_util.GetKubeConfig(autoscalingOptions.KubeClientOpts)
+		bufferPodListProcessor, err := capacitybuffer.NewBufferPodListProcessor(restConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+		// Virtual pods are injected before the ones fitting on existing nodes are filtered out, which takes up their
+		// free capacity in the cluster snapshot.
+		podListProcessor = pods.NewCombinedPodListProcessor([]pods.PodListProcessor{bufferPodListProcessor, podListProcessor, bufferPodListProcessor.ConsumptionProcessor()})
+		opts.Processors.ScaleUpStatusProcessor = status.NewCombinedScaleUpStatusProcessor([]status.ScaleUpStatusProcessor{capacitybuffer.NewScaleUpStatusProcessor(), opts.Processors.ScaleUpStatusProcessor})
+	}
+
+	if autoscalingOptions.NodeAutoprovisioningEnabled {
+		opts.Processors.NodeGroupListProcessor = autoprovisioning.NewNodeGroupListProcessor(autoscalingOptions.MaxAutoprovisionedNodeGroupCount)
+		opts.Processors.NodeGroupManager = autoprovisioning.NewNodeGroupManager()
+	}
+	if autoscalingOptions.ScaleUpForVolumeTopology {
+		opts.Processors.NodeGroupListProcessor = volumetopology.NewNodeGroupListProcessor(opts.Processors.NodeGroupListProcessor, informerFactory)
+	}
+
+	if autoscalingOptions.NodeGroupConfigCrdEnabled {
+		restConfig := kube_util.GetKubeConfig(autoscalingOptions.KubeClientOpts)
+		nodeGroupConfigProcessor, err := nodegroupconfig.NewCrdNodeGroupConfigProcessor(restConfig, autoscalingOptions.ConfigNamespace, opts.Processors.NodeGroupConfigProcessor)
+		if err != nil {
+			klog.Errorf("Failed to start NodeGroupConfig processor, NodeGroupConfig objects will be ignored: %v", err)
+		} else {
+			opts.Processors.NodeGroupConfigProcessor = nodeGroupConfigProcessor
+		}
+	}
+
+	if autoscalingOptions.ScaleDownUtilizationBreakdownPods > 0 {
+		opts.Processors.ScaleDownStatusProcessor = status.NewUtilizationBreakdownScaleDownStatusProcessor(opts.Processors.ScaleDownStatusProcessor)
+	}
+	if autoscalingOptions.ScaleDownDryRun {
+		opts.Processors.ScaleDownStatusProcessor = status.NewScaleDownDryRunReportProcessor(opts.Processors.ScaleDownStatusProcessor, autoscalingOptions.ScaleDownDryRunReportFile, autoscalingOptions.ScaleDownDryRunReportInterval)
+	}
+	if autoscalingOptions.CostMetricsEnabled {
+		opts.Processors.ScaleDownStatusProcessor = status.NewCostMetricsScaleDownStatusProcessor(opts.Processors.ScaleDownStatusProcessor, autoscalingOptions.EmitPerNodeGroupMetrics)
+	}
+	if autoscalingOptions.NodeGroupLimitEventsEnabled {
+		opts.Processors.ScaleUpStatusProcessor = status.NewNodeGroupMaxSizeScaleUpStatusProcessor(opts.Processors.ScaleUpStatusProcessor)
+		opts.Processors.ScaleDownStatusProcessor = status.NewNodeGroupMinSizeScaleDownStatusProcessor(opts.Processors.ScaleDownStatusProcessor)
+	}
+	if autoscalingOptions.EmitPerNodeGroupMetrics {
+		opts.Processors.ScaleUpStatusProcessor = status.NewScaleUpLatencyStatusProcessor(opts.Processors.ScaleUpStatusProcessor)
+	}
+	if autoscalingOptions.DecisionLoggingEnabled || autoscalingOptions.ScalingDecisionHistoryEnabled {
+		decisionTrace := status.NewDecisionTrace()
+		if autoscalingOptions.DecisionLoggingEnabled {
+			opts.Processors.ScaleUpStatusProcessor = status.NewDecisionLogScaleUpStatusProcessor(opts.Processors.ScaleUpStatusProcessor, decisionTrace)
+			opts.Processors.ScaleDownStatusProcessor = status.NewDecisionLogScaleDownStatusProcessor(opts.Processors.ScaleDownStatusProcessor, decisionTrace)
+		}
+		if autoscalingOptions.ScalingDecisionHistoryEnabled {
+			restConfig := kube_util.GetKubeConfig(autoscalingOptions.KubeClientOpts)
+			scalingDecisionStore, err := scalingdecision.NewStore(restConfig, autoscalingOptions.ConfigNamespace, autoscalingOptions.ScalingDecisionHistoryMaxCount, autoscalingOptions.ScalingDecisionHistoryMaxAge)
+			if err != nil {
+				return nil, nil, err
+			}
+			opts.Processors.ScaleUpStatusProcessor = scalingdecision.NewScaleUpStatusProcessor(opts.Processors.ScaleUpStatusProcessor, scalingDecisionStore, decisionTrace)
+			opts.Processors.ScaleDownStatusProcessor = scalingdecision.NewScaleDownStatusProcessor(opts.Processors.ScaleDownStatusProcessor, scalingDecisionStore, decisionTrace)
+		}
+		if opts.LoopStartNotifier == nil {
+			opts.LoopStartNotifier = loopstart.NewObserversList(nil)
+		}
+		opts.LoopStartNotifier.Register(decisionTrace)
+	}
+
 	opts.Processors.PodListProcessor = podListProcessor
 	sdCandidatesSorting := previouscandidates.NewPreviousCandidates()
 	scaleDownCandidatesComparers := []scaledowncandidates.CandidatesComparer{
 		emptycandidates.NewEmptySortingProcessor(emptycandidates.NewNodeInfoGetter(opts.ClusterSnapshot), deleteOptions, drainabilityRules),
 		sdCandidatesSorting,
 	}
+	if autoscalingOptions.ScaleDownNodeGroupPriorityConfigMap != "" {
+		configMapLister := kube_util.NewConfigMapListerForNamespace(kubeClient, context.Done(), autoscalingOptions.ConfigNamespace)
+		scaleDownCandidatesComparers = append(scaleDownCandidatesComparers, nodegrouppriority.NewNodeGroupPriorityComparer(
+			configMapLister.ConfigMaps(autoscalingOptions.ConfigNamespace), autoscalingOptions.ScaleDownNodeGroupPriorityConfigMap))
+	}
+	if autoscalingOptions.ScalingScheduleConfigMap != "" {
+		configMapLister := kube_util.NewConfigMapListerForNamespace(kubeClient, context.Done(), autoscalingOptions.ConfigNamespace)
+		opts.ScalingSchedule = scalingschedule.NewSchedule(
+			configMapLister.ConfigMaps(autoscalingOptions.ConfigNamespace), autoscalingOptions.ScalingScheduleConfigMap)
+	}
+	if len(autoscalingOptions.ScaleDownSortingStrategies) > 0 {
+		sorter, err := strategies.NewScaleDownCandidatesSorter(autoscalingOptions.ScaleDownSortingStrategies, opts.Processors.UtilizationCalculator)
+		if err != nil {
+			return nil, nil, err
+		}
+		scaleDownCandidatesComparers = append(scaleDownCandidatesComparers, sorter)
+	}
 	opts.Processors.ScaleDownCandidatesNotifier.Register(sdCandidatesSorting)
 
 	cp := scaledowncandidates.NewCombinedScaleDownCandidatesProcessor()
@@ -209,26 +378,14 @@ func buildAutoscaler(context ctx.Context, debuggingSnapshotter debuggingsnapshot
 	}
 	opts.Processors.ScaleDownNodeProcessor = cp
 
-	var nodeInfoComparator nodegroupset.NodeInfoComparator
-	if len(autoscalingOptions.BalancingLabels) > 0 {
-		nodeInfoComparator = nodegroupset.CreateLabelNodeInfoComparator(autoscalingOptions.BalancingLabels)
-	} else {
-		nodeInfoComparatorBuilder := nodegroupset.CreateGenericNodeInfoComparator
-		if autoscalingOptions.CloudProviderName == cloudprovider.AzureProviderName {
-			nodeInfoComparatorBuilder = nodegroupset.CreateAzureNodeInfoComparator
-		} else if autoscalingOptions.CloudProviderName == cloudprovider.AwsProviderName {
-			nodeInfoComparatorBuilder = nodegroupset.CreateAwsNodeInfoComparator
+	if len(autoscalingOptions.BalancingLabels) == 0 {
+		if autoscalingOptions.CloudProviderName == cloudprovider.AwsProviderName {
 			opts.Processors.TemplateNodeInfoProvider = nodeinfosprovider.NewAsgTagResourceNodeInfoProvider(&autoscalingOptions.NodeInfoCacheExpireTime, autoscalingOptions.ForceDaemonSets)
 		} else if autoscalingOptions.CloudProviderName == cloudprovider.GceProviderName {
-			nodeInfoComparatorBuilder = nodegroupset.CreateGceNodeInfoComparator
 			opts.Processors.TemplateNodeInfoProvider = nodeinfosprovider.NewAnnotationNodeInfoProvider(&autoscalingOptions.NodeInfoCacheExpireTime, autoscalingOptions.ForceDaemonSets)
 		}
-		nodeInfoComparator = nodeInfoComparatorBuilder(autoscalingOptions.BalancingExtraIgnoredLabels, autoscalingOptions.NodeGroupSetRatios)
-	}
-
-	opts.Processors.NodeGroupSetProcessor = &nodegroupset.BalancingNodeGroupSetProcessor{
-		Comparator: nodeInfoComparator,
 	}
+	opts.Processors.NodeGroupSetProcessor = nodegroupset.NewBalancingNodeGroupSetProcessor(autoscalingOptions)
 
 	// These metrics should be published only once.
 	metrics.UpdateCPULimitsCores(autoscalingOptions.MinCoresTotal, autoscalingOptions.MaxCoresTotal)
@@ -261,25 +418,25 @@ func buildAutoscaler(context ctx.Context, debuggingSnapshotter debuggingsnapshot
 	// A ProvisioningRequestPodsInjector is used as provisioningRequestProcessingTimesGetter here to obtain the last time a
 	// ProvisioningRequest was processed. This is because the ProvisioningRequestPodsInjector in addition to injecting pods
 	// also marks the ProvisioningRequest as accepted or failed.
-	trigger := loop.NewLoopTrigger(autoscaler, ProvisioningRequestInjector, podObserver, autoscalingOptions.ScanInterval)
+	trigger := loop.NewLoopTrigger(autoscaler, ProvisioningRequestInjector, podObserver)
 
 	return autoscaler, trigger, nil
 }
 
-func run(healthCheck *metrics.HealthCheck, debuggingSnapshotter debuggingsnapshot.DebuggingSnapshotter) {
+func run(healthCheck *metrics.HealthCheck, debuggingSnapshotter debuggingsnapshot.DebuggingSnapshotter, scaleUpSimulationHandler *dryrun.Handler, pauses *pause.Registry, webUIState *webui.State, releaseLeadership func()) {
 	autoscalingOpts := flags.AutoscalingOptions()
 
 	metrics.RegisterAll(autoscalingOpts.EmitPerNodeGroupMetrics)
 	context, cancel := ctx.WithCancel(ctx.Background())
 	defer cancel()
 
-	autoscaler, trigger, err := buildAutoscaler(context, debuggingSnapshotter)
+	autoscaler, trigger, err := buildAutoscaler(context, debuggingSnapshotter, scaleUpSimulationHandler, pauses, webUIState)
 	if err != nil {
 		klog.Fatalf("Failed to create autoscaler: %v", err)
 	}
 
 	// Register signal handlers for graceful shutdown.
-	registerSignalHandlers(autoscaler)
+	registerSignalHandlers(autoscaler, releaseLeadership)
 
 	// Start updating health check endpoint.
 	healthCheck.StartMonitoring()
@@ -302,7 +459,7 @@ func run(healthCheck *metrics.HealthCheck, debuggingSnapshotter debuggingsnapsho
 		}
 	} else {
 		for {
-			time.Sleep(autoscalingOpts.ScanInterval)
+			time.Sleep(autoscaler.ScanInterval())
 			loop.RunAutoscalerOnce(autoscaler, healthCheck, time.Now())
 		}
 	}
@@ -342,12 +499,53 @@ func main() {
 		klog.Fatalf("Failed to validate and apply logging configuration: %v", err)
 	}
 
+	if autoscalingOpts.TracingEndpoint != "" {
+		if err := tracing.Setup(ctx.Background(), autoscalingOpts.TracingEndpoint, autoscalingOpts.TracingSamplingRatePerMillion); err != nil {
+			klog.Fatalf("Failed to set up tracing: %v", err)
+		}
+	}
+
 	healthCheck := metrics.NewHealthCheck(autoscalingOpts.MaxInactivityTime, autoscalingOpts.MaxFailingTime)
 
 	klog.V(1).Infof("Cluster Autoscaler %s", version.ClusterAutoscalerVersion)
 
 	debuggingSnapshotter := debuggingsnapshot.NewDebuggingSnapshotter(autoscalingOpts.DebuggingSnapshotEnabled)
 
+	var scaleUpSimulationHandler *dryrun.Handler
+	if autoscalingOpts.ScaleUpSimulationEnabled {
+		credentials, err := basicauth.LoadCredentials(autoscalingOpts.ScaleUpSimulationBasicAuthFile)
+		if err != nil {
+			klog.Fatalf("Failed to load scale-up simulation credentials: %v", err)
+		}
+		// Requests are processed by the autoscaler loop, so allow them to wait for a few loops.
+		scaleUpSimulationHandler = dryrun.NewHandler(max(time.Minute, 3*autoscalingOpts.ScanInterval), credentials)
+	}
+
+	var pauses *pause.Registry
+	var pauseHandler *pause.Handler
+	if autoscalingOpts.AutoscalingPauseEnabled {
+		pauses = pause.NewRegistry()
+		// Without credentials, pauses can only be declared in the status object.
+		if autoscalingOpts.AutoscalingPauseBasicAuthFile != "" {
+			credentials, err := basicauth.LoadCredentials(autoscalingOpts.AutoscalingPauseBasicAuthFile)
+			if err != nil {
+				klog.Fatalf("Failed to load autoscaling pause credentials: %v", err)
+			}
+			pauseHandler = pause.NewHandler(pauses, autoscalingOpts.MaxPauseDuration, credentials)
+		}
+	}
+
+	var webUIState *webui.State
+	var webUIHandler *webui.Handler
+	if autoscalingOpts.WebUIEnabled {
+		credentials, err := basicauth.LoadCredentials(autoscalingOpts.WebUIBasicAuthFile)
+		if err != nil {
+			klog.Fatalf("Failed to load web UI credentials: %v", err)
+		}
+		webUIState = webui.NewState(webui.DefaultMaxEvents)
+		webUIHandler = webui.NewHandler(webUIState, credentials)
+	}
+
 	go func() {
 		pathRecorderMux := mux.NewPathRecorderMux("cluster-autoscaler")
 		defaultMetricsHandler := legacyregistry.Handler().ServeHTTP
@@ -357,6 +555,15 @@ func main() {
 		if autoscalingOpts.DebuggingSnapshotEnabled {
 			pathRecorderMux.HandleFunc("/snapshotz", debuggingSnapshotter.ResponseHandler)
 		}
+		if scaleUpSimulationHandler != nil {
+			pathRecorderMux.Handle(dryrun.Path, scaleUpSimulationHandler)
+		}
+		if pauseHandler != nil {
+			pathRecorderMux.Handle(pause.Path, pauseHandler)
+		}
+		if webUIHandler != nil {
+			pathRecorderMux.Handle(webui.Path, webUIHandler)
+		}
 		pathRecorderMux.HandleFunc("/health-check", healthCheck.ServeHTTP)
 		if autoscalingOpts.EnableProfiling {
 			routes.Profiling{}.Install(pathRecorderMux)
@@ -366,7 +573,7 @@ func main() {
 	}()
 
 	if !leaderElection.LeaderElect {
-		run(healthCheck, debuggingSnapshotter)
+		run(healthCheck, debuggingSnapshotter, scaleUpSimulationHandler, pauses, webUIState, nil)
 	} else {
 		id, err := os.Hostname()
 		if err != nil {
@@ -396,7 +603,23 @@ func main() {
 			klog.Fatalf("Unable to create leader election lock: %v", err)
 		}
 
-		leaderelection.RunOrDie(ctx.TODO(), leaderelection.LeaderElectionConfig{
+		// A replica taking over after a crash waits for the lease to expire, so failover takes up to
+		// the lease duration plus the retry period. On shutdown, the lease is released right away.
+		klog.V(1).Infof("Leader election: lease duration %v, renew deadline %v, retry period %v",
+			leaderElection.LeaseDuration.Duration, leaderElection.RenewDeadline.Duration, leaderElection.RetryPeriod.Duration)
+		leaderCtx, stopLeading := ctx.WithCancel(ctx.Background())
+		released := make(chan struct{})
+		releaseLeadership := func() {
+			stopLeading()
+			select {
+			case <-released:
+				klog.V(1).Info("Released leader lease")
+			case <-time.After(leaderElection.RenewDeadline.Duration):
+				klog.Warning("Timed out waiting for the leader lease to be released")
+			}
+		}
+
+		leaderelection.RunOrDie(leaderCtx, leaderelection.LeaderElectionConfig{
 			Lock:            lock,
 			LeaseDuration:   leaderElection.LeaseDuration.Duration,
 			RenewDeadline:   leaderElection.RenewDeadline.Duration,
@@ -406,9 +629,14 @@ func main() {
 				OnStartedLeading: func(_ ctx.Context) {
 					// Since we are committing a suicide after losing
 					// mastership, we can safely ignore the argument.
-					run(healthCheck, debuggingSnapshotter)
+					run(healthCheck, debuggingSnapshotter, scaleUpSimulationHandler, pauses, webUIState, releaseLeadership)
 				},
 				OnStoppedLeading: func() {
+					if leaderCtx.Err() != nil {
+						// The lease was released on shutdown, let the signal handler exit once it's done.
+						close(released)
+						select {}
+					}
 					klog.Fatalf("lost master")
 				},
 			},
@@ -427,8 +655,9 @@ func leaderElectionConfiguration() componentbaseconfig.LeaderElectionConfigurati
 	}
 }
 
+// The defaults keep the failover after a leader crash, lease duration plus retry period, below 10 seconds.
 const (
-	defaultLeaseDuration = 15 * time.Second
-	defaultRenewDeadline = 10 * time.Second
-	defaultRetryPeriod   = 2 * time.Second
+	defaultLeaseDuration = 8 * time.Second
+	defaultRenewDeadline = 6 * time.Second
+	defaultRetryPeriod   = 1 * time.Second
 )
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"time"
 
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator"
 
 	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
@@ -47,6 +48,12 @@ type NodeGroupType string
 // PodEvictionResult describes result of the pod eviction attempt
 type PodEvictionResult string
 
+// NodeAutoRepairReason describes why a node is recreated by node auto-repair
+type NodeAutoRepairReason string
+
+// NodeAutoRepairResult describes result of the node auto-repair attempt
+type NodeAutoRepairResult string
+
 const (
 	caNamespace           = "cluster_autoscaler"
 	readyLabel            = "ready"
@@ -94,6 +101,17 @@ const (
 	PodEvictionSucceed PodEvictionResult = "succeeded"
 	// PodEvictionFailed means creation of the pod eviction object failed
 	PodEvictionFailed PodEvictionResult = "failed"
+
+	// NotReadyRepair means the node was NotReady for too long
+	NotReadyRepair NodeAutoRepairReason = "notReady"
+	// UnregisteredRepair means the instance didn't register as a node for too long
+	UnregisteredRepair NodeAutoRepairReason = "unregistered"
+	// NodeAutoRepairSucceeded means the node was deleted and its replacement requested
+	NodeAutoRepairSucceeded NodeAutoRepairResult = "succeeded"
+	// NodeAutoRepairFailed means deleting the node or requesting its replacement failed
+	NodeAutoRepairFailed NodeAutoRepairResult = "failed"
+	// NodeAutoRepairRateLimited means the node wasn't repaired because of the repair rate limit
+	NodeAutoRepairRateLimited NodeAutoRepairResult = "rateLimited"
 )
 
 // Names of Cluster Autoscaler operations
@@ -234,6 +252,32 @@ var (
 		}, []string{"node_group", "reason"},
 	)
 
+	nodeGroupHealthScore = k8smetrics.NewGaugeVec(
+		&k8smetrics.GaugeOpts{
+			Namespace: caNamespace,
+			Name:      "node_group_health_score",
+			Help:      "Health score of node group based on its recent scale-ups, from 0 (unhealthy) to 1 (healthy). Only reported with adaptive node group backoff.",
+		}, []string{"node_group"},
+	)
+
+	nodeGroupScaleUpNodeReadySeconds = k8smetrics.NewHistogramVec(
+		&k8smetrics.HistogramOpts{
+			Namespace: caNamespace,
+			Name:      "node_group_scale_up_node_ready_seconds",
+			Help:      "Time from a scale-up request of the node group to each node added by it becoming ready.",
+			Buckets:   k8smetrics.ExponentialBuckets(5, 1.5, 18), // 5, 7.5, 11.25, ..., 3284.2, 4926.3
+		}, []string{"node_group"},
+	)
+
+	nodeGroupScaleUpPodScheduledSeconds = k8smetrics.NewHistogramVec(
+		&k8smetrics.HistogramOpts{
+			Namespace: caNamespace,
+			Name:      "node_group_scale_up_pod_scheduled_seconds",
+			Help:      "Time from a pod becoming pending to it being scheduled on a node of the node group, for pods which triggered a scale-up of the node group.",
+			Buckets:   k8smetrics.ExponentialBuckets(5, 1.5, 18), // 5, 7.5, 11.25, ..., 3284.2, 4926.3
+		}, []string{"node_group"},
+	)
+
 	/**** Metrics related to autoscaler execution ****/
 	lastActivity = k8smetrics.NewGaugeVec(
 		&k8smetrics.GaugeOpts{
@@ -302,6 +346,62 @@ var (
 		}, []string{"reason"},
 	)
 
+	scaleUpFailuresCount = k8smetrics.NewCounterVec(
+		&k8smetrics.CounterOpts{
+			Namespace: caNamespace,
+			Name:      "scaleup_failures_total",
+			Help:      "Number of scale-up failures, by provider independent failure category.",
+		}, []string{"reason"},
+	)
+
+	zonalStockoutsCount = k8smetrics.NewCounterVec(
+		&k8smetrics.CounterOpts{
+			Namespace: caNamespace,
+			Name:      "zonal_stockouts_total",
+			Help:      "Number of stockouts recorded for a zone and instance type.",
+		}, []string{"zone", "instance_type"},
+	)
+
+	zonalStockoutUntil = k8smetrics.NewGaugeVec(
+		&k8smetrics.GaugeOpts{
+			Namespace: caNamespace,
+			Name:      "zonal_stockout_until_seconds",
+			Help:      "Unix timestamp until which node groups of a stocked out zone and instance type are excluded from scale-up.",
+		}, []string{"zone", "instance_type"},
+	)
+
+	scaleDownSimulationCacheLookupsCount = k8smetrics.NewCounterVec(
+		&k8smetrics.CounterOpts{
+			Namespace: caNamespace,
+			Name:      "scale_down_simulation_cache_lookups_total",
+			Help:      "Number of lookups in the scale down simulation cache, by result.",
+		}, []string{"result"},
+	)
+
+	costBudgetExceededCount = k8smetrics.NewCounterVec(
+		&k8smetrics.CounterOpts{
+			Namespace: caNamespace,
+			Name:      "scale_up_cost_budget_exceeded_total",
+			Help:      "Number of scale-ups that would exceed the cluster hourly cost budget, by budget mode.",
+		}, []string{"mode"},
+	)
+
+	clusterHourlyCost = k8smetrics.NewGauge(
+		&k8smetrics.GaugeOpts{
+			Namespace: caNamespace,
+			Name:      "cluster_hourly_cost",
+			Help:      "Hourly cost of all node groups in the cluster at their target sizes, as computed by the cost budget or the cost metrics.",
+		},
+	)
+
+	scaledDownCostSavings = k8smetrics.NewCounterVec(
+		&k8smetrics.CounterOpts{
+			Namespace: caNamespace,
+			Name:      "scaled_down_cost_savings_total",
+			Help:      "Estimated cost saved by the nodes removed by scale-down, per node group: the hourly cost of each removed node accrued until its node group is scaled up again.",
+		}, []string{"node_group"},
+	)
+
 	failedGPUScaleUpCount = k8smetrics.NewCounterVec(
 		&k8smetrics.CounterOpts{
 			Namespace: caNamespace,
@@ -367,6 +467,15 @@ var (
 		},
 	)
 
+	nodeAutoRepairsCount = k8smetrics.NewCounterVec(
+		&k8smetrics.CounterOpts{
+			Namespace: caNamespace,
+			Name:      "node_auto_repairs_total",
+			Help:      "Number of node auto-repair attempts, by the reason of the repair and its result.",
+		},
+		[]string{"reason", "result"},
+	)
+
 	overflowingControllersCount = k8smetrics.NewGauge(
 		&k8smetrics.GaugeOpts{
 			Namespace: caNamespace,
@@ -411,6 +520,33 @@ var (
 		[]string{"type"},
 	)
 
+	capacityBufferPods = k8smetrics.NewGaugeVec(
+		&k8smetrics.GaugeOpts{
+			Namespace: caNamespace,
+			Name:      "capacity_buffer_pods",
+			Help:      "Number of virtual pods of each CapacityBuffer, by state: desired, available on existing nodes, or consumed, i.e. waiting for a scale-up.",
+		},
+		[]string{"buffer", "state"},
+	)
+
+	nodeGroupMaxSizeReachedPendingPods = k8smetrics.NewGaugeVec(
+		&k8smetrics.GaugeOpts{
+			Namespace: caNamespace,
+			Name:      "node_group_max_size_reached_pending_pods",
+			Help:      "Number of pending pods which could be helped by scaling up the node group, if it wasn't at its max size.",
+		},
+		[]string{"node_group"},
+	)
+
+	nodeGroupMinSizeReachedUnneededNodes = k8smetrics.NewGaugeVec(
+		&k8smetrics.GaugeOpts{
+			Namespace: caNamespace,
+			Name:      "node_group_min_size_reached_unneeded_nodes",
+			Help:      "Number of unneeded nodes of the node group which aren't removed because the node group is at its min size.",
+		},
+		[]string{"node_group"},
+	)
+
 	inconsistentInstancesMigsCount = k8smetrics.NewGauge(
 		&k8smetrics.GaugeOpts{
 			Namespace: caNamespace,
@@ -438,6 +574,12 @@ func RegisterAll(emitPerNodeGroupMetrics bool) {
 	legacyregistry.MustRegister(scaleUpCount)
 	legacyregistry.MustRegister(gpuScaleUpCount)
 	legacyregistry.MustRegister(failedScaleUpCount)
+	legacyregistry.MustRegister(scaleUpFailuresCount)
+	legacyregistry.MustRegister(zonalStockoutsCount)
+	legacyregistry.MustRegister(zonalStockoutUntil)
+	legacyregistry.MustRegister(scaleDownSimulationCacheLookupsCount)
+	legacyregistry.MustRegister(costBudgetExceededCount)
+	legacyregistry.MustRegister(clusterHourlyCost)
 	legacyregistry.MustRegister(failedGPUScaleUpCount)
 	legacyregistry.MustRegister(scaleDownCount)
 	legacyregistry.MustRegister(gpuScaleDownCount)
@@ -446,6 +588,7 @@ func RegisterAll(emitPerNodeGroupMetrics bool) {
 	legacyregistry.MustRegister(unremovableNodesCount)
 	legacyregistry.MustRegister(scaleDownInCooldown)
 	legacyregistry.MustRegister(oldUnregisteredNodesRemovedCount)
+	legacyregistry.MustRegister(nodeAutoRepairsCount)
 	legacyregistry.MustRegister(overflowingControllersCount)
 	legacyregistry.MustRegister(skippedScaleEventsCount)
 	legacyregistry.MustRegister(nodeGroupCreationCount)
@@ -453,6 +596,7 @@ func RegisterAll(emitPerNodeGroupMetrics bool) {
 	legacyregistry.MustRegister(pendingNodeDeletions)
 	legacyregistry.MustRegister(nodeTaintsCount)
 	legacyregistry.MustRegister(inconsistentInstancesMigsCount)
+	legacyregistry.MustRegister(capacityBufferPods)
 
 	if emitPerNodeGroupMetrics {
 		legacyregistry.MustRegister(nodesGroupMinNodes)
@@ -460,6 +604,12 @@ func RegisterAll(emitPerNodeGroupMetrics bool) {
 		legacyregistry.MustRegister(nodesGroupTargetSize)
 		legacyregistry.MustRegister(nodesGroupHealthiness)
 		legacyregistry.MustRegister(nodeGroupBackOffStatus)
+		legacyregistry.MustRegister(nodeGroupHealthScore)
+		legacyregistry.MustRegister(nodeGroupScaleUpNodeReadySeconds)
+		legacyregistry.MustRegister(nodeGroupScaleUpPodScheduledSeconds)
+		legacyregistry.MustRegister(nodeGroupMaxSizeReachedPendingPods)
+		legacyregistry.MustRegister(nodeGroupMinSizeReachedUnneededNodes)
+		legacyregistry.MustRegister(scaledDownCostSavings)
 	}
 }
 
@@ -474,6 +624,10 @@ func InitMetrics() {
 		failedScaleUpCount.WithLabelValues(string(reason)).Add(0)
 	}
 
+	for _, category := range cloudprovider.ScaleUpFailureCategories {
+		scaleUpFailuresCount.WithLabelValues(string(category)).Add(0)
+	}
+
 	for _, result := range []PodEvictionResult{PodEvictionSucceed, PodEvictionFailed} {
 		evictionsCount.WithLabelValues(string(result)).Add(0)
 	}
@@ -611,6 +765,23 @@ func UpdateNodeGroupBackOffStatus(nodeGroup string, backoffReasonStatus map[stri
 	}
 }
 
+// UpdateNodeGroupHealthScore records the health score of node group
+func UpdateNodeGroupHealthScore(nodeGroup string, score float64) {
+	nodeGroupHealthScore.WithLabelValues(nodeGroup).Set(score)
+}
+
+// ObserveNodeGroupScaleUpNodeReady records the time from a scale-up request of the node group to a node added by it
+// becoming ready.
+func ObserveNodeGroupScaleUpNodeReady(nodeGroup string, latency time.Duration) {
+	nodeGroupScaleUpNodeReadySeconds.WithLabelValues(nodeGroup).Observe(latency.Seconds())
+}
+
+// ObserveNodeGroupScaleUpPodScheduled records the time from a pod which triggered a scale-up of the node group
+// becoming pending to it being scheduled on a node of the node group.
+func ObserveNodeGroupScaleUpPodScheduled(nodeGroup string, latency time.Duration) {
+	nodeGroupScaleUpPodScheduledSeconds.WithLabelValues(nodeGroup).Observe(latency.Seconds())
+}
+
 // RegisterError records any errors preventing Cluster Autoscaler from working.
 // No more than one error should be recorded per loop.
 func RegisterError(err errors.AutoscalerError) {
@@ -633,6 +804,43 @@ func RegisterFailedScaleUp(reason FailedScaleUpReason, gpuResourceName, gpuType
 	}
 }
 
+// RegisterScaleUpFailureCategory records a scale-up failure of the given category
+func RegisterScaleUpFailureCategory(category cloudprovider.ScaleUpFailureCategory) {
+	scaleUpFailuresCount.WithLabelValues(string(category)).Inc()
+}
+
+// RegisterZonalStockout records a stockout of the given zone and instance type lasting until the given time
+func RegisterZonalStockout(zone, instanceType string, until time.Time) {
+	zonalStockoutsCount.WithLabelValues(zone, instanceType).Inc()
+	zonalStockoutUntil.WithLabelValues(zone, instanceType).Set(float64(until.Unix()))
+}
+
+// RemoveZonalStockout records the end of the stockout of the given zone and instance type
+func RemoveZonalStockout(zone, instanceType string) {
+	zonalStockoutUntil.Delete(map[string]string{"zone": zone, "instance_type": instanceType})
+}
+
+// RegisterScaleDownSimulationCacheLookups records hits and misses of the scale down simulation cache
+func RegisterScaleDownSimulationCacheLookups(hits, misses int) {
+	scaleDownSimulationCacheLookupsCount.WithLabelValues("hit").Add(float64(hits))
+	scaleDownSimulationCacheLookupsCount.WithLabelValues("miss").Add(float64(misses))
+}
+
+// RegisterCostBudgetExceeded records a scale-up that would exceed the cluster cost budget
+func RegisterCostBudgetExceeded(mode string) {
+	costBudgetExceededCount.WithLabelValues(mode).Inc()
+}
+
+// RegisterScaledDownCostSavings records the estimated cost saved by nodes removed by scale-down
+func RegisterScaledDownCostSavings(nodeGroup string, savings float64) {
+	scaledDownCostSavings.WithLabelValues(nodeGroup).Add(savings)
+}
+
+// UpdateClusterHourlyCost records the hourly cost of the cluster
+func UpdateClusterHourlyCost(cost float64) {
+	clusterHourlyCost.Set(cost)
+}
+
 // RegisterScaleDown records number of nodes removed by scale down
 func RegisterScaleDown(nodesCount int, gpuResourceName, gpuType string, reason NodeScaleDownReason) {
 	scaleDownCount.WithLabelValues(string(reason)).Add(float64(nodesCount))
@@ -694,6 +902,11 @@ func RegisterOldUnregisteredNodesRemoved(nodesCount int) {
 	oldUnregisteredNodesRemovedCount.Add(float64(nodesCount))
 }
 
+// RegisterNodeAutoRepair records a node auto-repair attempt
+func RegisterNodeAutoRepair(reason NodeAutoRepairReason, result NodeAutoRepairResult) {
+	nodeAutoRepairsCount.WithLabelValues(string(reason), string(result)).Inc()
+}
+
 // UpdateOverflowingControllers sets the number of controllers that could not
 // have their pods cached.
 func UpdateOverflowingControllers(count int) {
@@ -730,6 +943,33 @@ func ObserveNodeTaintsCount(taintType string, count float64) {
 	nodeTaintsCount.WithLabelValues(taintType).Set(count)
 }
 
+// UpdateCapacityBufferPods records the desired and available virtual pods of CapacityBuffers, by buffer. Buffers
+// which no longer exist are removed.
+func UpdateCapacityBufferPods(desired, available map[string]int) {
+	capacityBufferPods.Reset()
+	for buffer, count := range desired {
+		capacityBufferPods.WithLabelValues(buffer, "desired").Set(float64(count))
+		capacityBufferPods.WithLabelValues(buffer, "available").Set(float64(available[buffer]))
+		capacityBufferPods.WithLabelValues(buffer, "consumed").Set(float64(count - available[buffer]))
+	}
+}
+
+// UpdateNodeGroupMaxSizeReachedPendingPods records the number of pending pods blocked by the max size of each node group.
+func UpdateNodeGroupMaxSizeReachedPendingPods(pendingPods map[string]int) {
+	nodeGroupMaxSizeReachedPendingPods.Reset()
+	for nodeGroup, count := range pendingPods {
+		nodeGroupMaxSizeReachedPendingPods.WithLabelValues(nodeGroup).Set(float64(count))
+	}
+}
+
+// UpdateNodeGroupMinSizeReachedUnneededNodes records the number of unneeded nodes kept by the min size of each node group.
+func UpdateNodeGroupMinSizeReachedUnneededNodes(unneededNodes map[string]int) {
+	nodeGroupMinSizeReachedUnneededNodes.Reset()
+	for nodeGroup, count := range unneededNodes {
+		nodeGroupMinSizeReachedUnneededNodes.WithLabelValues(nodeGroup).Set(float64(count))
+	}
+}
+
 // UpdateInconsistentInstancesMigsCount records the observed number of migs where instance count
 // according to InstanceGroupManagers.List() differs from the results of Instances.List().
 // This can happen when some instances are abandoned or a user edits instance 'created-by' metadata.
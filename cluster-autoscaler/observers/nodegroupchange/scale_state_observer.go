@@ -41,6 +41,14 @@ type NodeGroupChangeObserver interface {
 	RegisterFailedScaleDown(nodeGroup cloudprovider.NodeGroup, reason string, currentTime time.Time)
 }
 
+// ScaleUpOperationObserver is implemented by NodeGroupChangeObservers which track asynchronous
+// scale-up operations, requested with cloudprovider.AsyncSizeIncreaser.
+type ScaleUpOperationObserver interface {
+	// RegisterScaleUpOperation records an operation increasing the size of a nodegroup by delta.
+	// It's called after RegisterScaleUp for the same scale-up.
+	RegisterScaleUpOperation(nodeGroup cloudprovider.NodeGroup, operation cloudprovider.ScaleUpOperation, delta int, currentTime time.Time)
+}
+
 // NodeGroupChangeObserversList is a slice of observers
 // of state of scale up/down in the cluster
 type NodeGroupChangeObserversList struct {
@@ -64,6 +72,18 @@ func (l *NodeGroupChangeObserversList) RegisterScaleUp(nodeGroup cloudprovider.N
 	}
 }
 
+// RegisterScaleUpOperation calls RegisterScaleUpOperation for each observer implementing ScaleUpOperationObserver.
+func (l *NodeGroupChangeObserversList) RegisterScaleUpOperation(nodeGroup cloudprovider.NodeGroup,
+	operation cloudprovider.ScaleUpOperation, delta int, currentTime time.Time) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	for _, observer := range l.observers {
+		if operationObserver, ok := observer.(ScaleUpOperationObserver); ok {
+			operationObserver.RegisterScaleUpOperation(nodeGroup, operation, delta, currentTime)
+		}
+	}
+}
+
 // RegisterScaleDown calls RegisterScaleDown for each observer.
 func (l *NodeGroupChangeObserversList) RegisterScaleDown(nodeGroup cloudprovider.NodeGroup,
 	nodeName string, currentTime time.Time, expectedDeleteTime time.Time) {
@@ -0,0 +1,216 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacitybuffer
+
+import (
+	"fmt"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/metrics"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/pods"
+	pod_util "k8s.io/autoscaler/cluster-autoscaler/utils/pod"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	klog "k8s.io/klog/v2"
+)
+
+const (
+	// BufferKind is the kind of the CapacityBuffer CRD.
+	BufferKind   = pod_util.CapacityBufferKind
+	bufferResync = 1 * time.Hour
+)
+
+// BufferResource is the resource of the CapacityBuffer CRD.
+var BufferResource = schema.GroupVersionResource{
+	Group:    pod_util.CapacityBufferGroup,
+	Version:  "v1alpha1",
+	Resource: "capacitybuffers",
+}
+
+// Spec is the spec of a CapacityBuffer object, spare capacity kept in the cluster for a number of pods
+// of a given shape.
+type Spec struct {
+	// Replicas is the number of pods the spare capacity is kept for.
+	Replicas int `json:"replicas"`
+	// Template is the shape of the pods.
+	Template apiv1.PodTemplateSpec `json:"template"`
+}
+
+// BufferPodListProcessor keeps the headroom declared in CapacityBuffer objects by injecting virtual pods
+// of their shape into the unschedulable pods. Virtual pods which fit on existing nodes are filtered out
+// like other pods, and take up their free capacity in the cluster snapshot, the other ones trigger a
+// scale-up. In scale-down, virtual pods have to be moved to other nodes like regular pods, so that nodes
+// keeping the headroom aren't removed. CapacityBuffer objects of all namespaces are read from an
+// informer cache.
+//
+// It has to run before the pods schedulable on existing nodes are filtered out, its ConsumptionProcessor
+// after.
+type BufferPodListProcessor struct {
+	informer cache.SharedIndexInformer
+	stopCh   chan struct{}
+	// desired are the virtual pods injected in the current loop, by buffer.
+	desired map[string]int
+}
+
+// NewBufferPodListProcessor returns a BufferPodListProcessor reading CapacityBuffer objects from all
+// namespaces. It blocks until the initial sync completes.
+func NewBufferPodListProcessor(kubeConfig *rest.Config) (*BufferPodListProcessor, error) {
+	client, err := dynamic.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CapacityBuffer client: %v", err)
+	}
+	return newBufferPodListProcessor(client)
+}
+
+func newBufferPodListProcessor(client dynamic.Interface) (*BufferPodListProcessor, error) {
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(client, bufferResync, metav1.NamespaceAll, nil)
+	informer := factory.ForResource(BufferResource).Informer()
+	stopCh := make(chan struct{})
+	factory.Start(stopCh)
+	for _, synced := range factory.WaitForCacheSync(stopCh) {
+		if !synced {
+			close(stopCh)
+			return nil, fmt.Errorf("can't sync CapacityBuffer informer")
+		}
+	}
+	klog.V(2).Infof("Successful initial CapacityBuffer sync")
+	return &BufferPodListProcessor{
+		informer: informer,
+		stopCh:   stopCh,
+		desired:  make(map[string]int),
+	}, nil
+}
+
+// Process appends the virtual pods of all CapacityBuffers to the unschedulable pods.
+func (p *BufferPodListProcessor) Process(_ *context.AutoscalingContext, unschedulablePods []*apiv1.Pod) ([]*apiv1.Pod, error) {
+	p.desired = make(map[string]int)
+	for _, o := range p.informer.GetStore().List() {
+		obj, ok := o.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		bufferPods, err := virtualPods(obj)
+		if err != nil {
+			klog.Warningf("Ignoring CapacityBuffer %s/%s: %v", obj.GetNamespace(), obj.GetName(), err)
+			continue
+		}
+		p.desired[bufferKey(obj)] = len(bufferPods)
+		unschedulablePods = append(unschedulablePods, bufferPods...)
+	}
+	return unschedulablePods, nil
+}
+
+// CleanUp stops the CapacityBuffer informer.
+func (p *BufferPodListProcessor) CleanUp() {
+	close(p.stopCh)
+}
+
+// ConsumptionProcessor returns a PodListProcessor recording how many virtual pods of each CapacityBuffer
+// fit on existing nodes. It has to run after the pods schedulable on existing nodes are filtered out.
+func (p *BufferPodListProcessor) ConsumptionProcessor() pods.PodListProcessor {
+	return &consumptionProcessor{injector: p}
+}
+
+type consumptionProcessor struct {
+	injector *BufferPodListProcessor
+}
+
+// Process updates the CapacityBuffer metrics, the pods are returned unchanged.
+func (p *consumptionProcessor) Process(_ *context.AutoscalingContext, unschedulablePods []*apiv1.Pod) ([]*apiv1.Pod, error) {
+	consumed := make(map[string]int)
+	for _, pod := range unschedulablePods {
+		if pod_util.IsCapacityBufferPod(pod) {
+			consumed[pod.Annotations[pod_util.CapacityBufferPodAnnotationKey]]++
+		}
+	}
+	available := make(map[string]int)
+	for buffer, desired := range p.injector.desired {
+		available[buffer] = desired - consumed[buffer]
+		if consumed[buffer] > 0 {
+			klog.V(2).Infof("CapacityBuffer %s: %d of %d pods don't fit on existing nodes", buffer, consumed[buffer], desired)
+		}
+	}
+	metrics.UpdateCapacityBufferPods(p.injector.desired, available)
+	return unschedulablePods, nil
+}
+
+// CleanUp is called at CA termination.
+func (p *consumptionProcessor) CleanUp() {
+}
+
+func bufferKey(obj *unstructured.Unstructured) string {
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
+
+// virtualPods returns the virtual pods of a CapacityBuffer, owned by the buffer so that they're treated
+// as equivalent in scale-up.
+func virtualPods(obj *unstructured.Unstructured) ([]*apiv1.Pod, error) {
+	specMap, found, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil || !found {
+		return nil, fmt.Errorf("no valid spec: %v", err)
+	}
+	var spec Spec
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(specMap, &spec); err != nil {
+		return nil, fmt.Errorf("invalid spec: %v", err)
+	}
+	if spec.Replicas < 0 {
+		return nil, fmt.Errorf("replicas must not be negative, got %d", spec.Replicas)
+	}
+	controller := true
+	var bufferPods []*apiv1.Pod
+	for i := 0; i < spec.Replicas; i++ {
+		pod := &apiv1.Pod{
+			ObjectMeta: *spec.Template.ObjectMeta.DeepCopy(),
+			Spec:       *spec.Template.Spec.DeepCopy(),
+		}
+		pod.Namespace = obj.GetNamespace()
+		pod.Name = fmt.Sprintf("%s-buffer-%d", obj.GetName(), i)
+		pod.UID = types.UID(fmt.Sprintf("%s-%d", obj.GetUID(), i))
+		pod.CreationTimestamp = obj.GetCreationTimestamp()
+		if pod.Annotations == nil {
+			pod.Annotations = make(map[string]string, 1)
+		}
+		pod.Annotations[pod_util.CapacityBufferPodAnnotationKey] = bufferKey(obj)
+		pod.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: obj.GetAPIVersion(),
+			Kind:       BufferKind,
+			Name:       obj.GetName(),
+			UID:        obj.GetUID(),
+			Controller: &controller,
+		}}
+		pod.Spec.NodeName = ""
+		pod.Status = apiv1.PodStatus{
+			Phase: apiv1.PodPending,
+			Conditions: []apiv1.PodCondition{{
+				Type:   apiv1.PodScheduled,
+				Status: apiv1.ConditionFalse,
+				Reason: apiv1.PodReasonUnschedulable,
+			}},
+		}
+		bufferPods = append(bufferPods, pod)
+	}
+	return bufferPods, nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacitybuffer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	pod_util "k8s.io/autoscaler/cluster-autoscaler/utils/pod"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+)
+
+func capacityBuffer(namespace, name string, created time.Time, spec map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	obj.SetAPIVersion(BufferResource.GroupVersion().String())
+	obj.SetKind(BufferKind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetUID(types.UID(name + "-uid"))
+	obj.SetCreationTimestamp(metav1.NewTime(created))
+	obj.Object["spec"] = spec
+	return obj
+}
+
+func newFakeBufferClient(objs ...runtime.Object) *fakedynamic.FakeDynamicClient {
+	return fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		BufferResource: "CapacityBufferList",
+	}, objs...)
+}
+
+func TestBufferPodListProcessor(t *testing.T) {
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	template := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{"app": "web"},
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{map[string]interface{}{
+				"name":      "web",
+				"resources": map[string]interface{}{"requests": map[string]interface{}{"cpu": "500m", "memory": "1Gi"}},
+			}},
+			"nodeSelector": map[string]interface{}{"pool": "web"},
+		},
+	}
+	client := newFakeBufferClient(
+		capacityBuffer("team-a", "web", created, map[string]interface{}{"replicas": int64(2), "template": template}),
+		capacityBuffer("team-b", "empty", created, map[string]interface{}{"replicas": int64(0), "template": template}),
+		capacityBuffer("team-b", "negative", created, map[string]interface{}{"replicas": int64(-1), "template": template}),
+		capacityBuffer("team-b", "invalid", created, map[string]interface{}{"replicas": "many"}),
+	)
+	p, err := newBufferPodListProcessor(client)
+	assert.NoError(t, err)
+	defer p.CleanUp()
+
+	pending := BuildTestPod("pending", 100, 100)
+	pods, err := p.Process(nil, []*apiv1.Pod{pending})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"team-a/web": 2, "team-b/empty": 0}, p.desired)
+	if assert.Len(t, pods, 3) {
+		assert.Equal(t, pending, pods[0])
+		for i, pod := range pods[1:] {
+			assert.True(t, pod_util.IsCapacityBufferPod(pod))
+			assert.Equal(t, "team-a/web", pod.Annotations[pod_util.CapacityBufferPodAnnotationKey])
+			assert.Equal(t, "team-a", pod.Namespace)
+			assert.Equal(t, []string{"web-buffer-0", "web-buffer-1"}[i], pod.Name)
+			assert.Equal(t, map[string]string{"app": "web"}, pod.Labels)
+			assert.Equal(t, map[string]string{"pool": "web"}, pod.Spec.NodeSelector)
+			assert.True(t, resource.MustParse("500m").Equal(pod.Spec.Containers[0].Resources.Requests[apiv1.ResourceCPU]))
+			assert.True(t, created.Equal(pod.CreationTimestamp.Time))
+			if controller := metav1.GetControllerOf(pod); assert.NotNil(t, controller) {
+				assert.Equal(t, BufferKind, controller.Kind)
+				assert.Equal(t, types.UID("web-uid"), controller.UID)
+			}
+		}
+		assert.NotEqual(t, pods[1].UID, pods[2].UID)
+	}
+
+	// One of the virtual pods fits on existing nodes and was filtered out.
+	consumption := p.ConsumptionProcessor()
+	remaining := []*apiv1.Pod{pending, pods[2]}
+	result, err := consumption.Process(nil, remaining)
+	assert.NoError(t, err)
+	assert.Equal(t, remaining, result)
+}
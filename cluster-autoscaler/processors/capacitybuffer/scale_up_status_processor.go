@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacitybuffer
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	pod_util "k8s.io/autoscaler/cluster-autoscaler/utils/pod"
+)
+
+// ScaleUpStatusProcessor removes the virtual pods of CapacityBuffers from the scale-up status, so that no
+// events are emitted for them. It has to run before the processors emitting events.
+type ScaleUpStatusProcessor struct{}
+
+// NewScaleUpStatusProcessor returns a new ScaleUpStatusProcessor.
+func NewScaleUpStatusProcessor() *ScaleUpStatusProcessor {
+	return &ScaleUpStatusProcessor{}
+}
+
+// Process removes the virtual pods from PodsRemainUnschedulable, PodsAwaitEvaluation and PodsTriggeredScaleUp.
+func (p *ScaleUpStatusProcessor) Process(_ *context.AutoscalingContext, scaleUpStatus *status.ScaleUpStatus) {
+	var remainUnschedulable []status.NoScaleUpInfo
+	for _, info := range scaleUpStatus.PodsRemainUnschedulable {
+		if !pod_util.IsCapacityBufferPod(info.Pod) {
+			remainUnschedulable = append(remainUnschedulable, info)
+		}
+	}
+	scaleUpStatus.PodsRemainUnschedulable = remainUnschedulable
+	scaleUpStatus.PodsAwaitEvaluation = filterOutBufferPods(scaleUpStatus.PodsAwaitEvaluation)
+	scaleUpStatus.PodsTriggeredScaleUp = filterOutBufferPods(scaleUpStatus.PodsTriggeredScaleUp)
+}
+
+// CleanUp is called at CA termination.
+func (p *ScaleUpStatusProcessor) CleanUp() {
+}
+
+func filterOutBufferPods(pods []*apiv1.Pod) []*apiv1.Pod {
+	var result []*apiv1.Pod
+	for _, pod := range pods {
+		if !pod_util.IsCapacityBufferPod(pod) {
+			result = append(result, pod)
+		}
+	}
+	return result
+}
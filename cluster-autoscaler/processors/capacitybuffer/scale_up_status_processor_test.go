@@ -0,0 +1,44 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacitybuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	pod_util "k8s.io/autoscaler/cluster-autoscaler/utils/pod"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+func TestScaleUpStatusProcessor(t *testing.T) {
+	pod := BuildTestPod("pod", 100, 100)
+	bufferPod := BuildTestPod("buffer-0", 100, 100)
+	bufferPod.Annotations = map[string]string{pod_util.CapacityBufferPodAnnotationKey: "default/buffer"}
+	bufferPod.OwnerReferences = GenerateOwnerReferences("buffer", BufferKind, "autoscaling.x-k8s.io/v1alpha1", "")
+
+	scaleUpStatus := &status.ScaleUpStatus{
+		PodsRemainUnschedulable: []status.NoScaleUpInfo{{Pod: pod}, {Pod: bufferPod}},
+		PodsAwaitEvaluation:     []*apiv1.Pod{bufferPod},
+		PodsTriggeredScaleUp:    []*apiv1.Pod{bufferPod, pod},
+	}
+	NewScaleUpStatusProcessor().Process(nil, scaleUpStatus)
+	assert.Equal(t, []status.NoScaleUpInfo{{Pod: pod}}, scaleUpStatus.PodsRemainUnschedulable)
+	assert.Empty(t, scaleUpStatus.PodsAwaitEvaluation)
+	assert.Equal(t, []*apiv1.Pod{pod}, scaleUpStatus.PodsTriggeredScaleUp)
+}
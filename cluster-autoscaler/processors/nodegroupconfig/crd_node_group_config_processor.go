@@ -0,0 +1,240 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodegroupconfig
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	klog "k8s.io/klog/v2"
+)
+
+const (
+	nodeGroupIndex        = "nodeGroup"
+	nodeGroupConfigResync = 1 * time.Hour
+)
+
+// NodeGroupConfigResource is the resource of the NodeGroupConfig CRD.
+var NodeGroupConfigResource = schema.GroupVersionResource{
+	Group:    "autoscaling.x-k8s.io",
+	Version:  "v1alpha1",
+	Resource: "nodegroupconfigs",
+}
+
+// CrdNodeGroupConfigProcessor overrides per-NodeGroup scale-down unneeded
+// time, scale-down utilization threshold, scale-down soft taint only mode,
+// node auto-repair and max node provision time with
+// values from NodeGroupConfig objects in a single namespace. All other
+// options, and options not set in a matching NodeGroupConfig, are provided
+// by the wrapped processor. NodeGroupConfig objects are read from an informer
+// cache, so changes to them are picked up without restarting CA.
+type CrdNodeGroupConfigProcessor struct {
+	NodeGroupConfigProcessor
+	informer cache.SharedIndexInformer
+	stopCh   chan struct{}
+}
+
+// NewCrdNodeGroupConfigProcessor returns a NodeGroupConfigProcessor reading
+// overrides from NodeGroupConfig objects in the given namespace and falling
+// back to the given processor. It blocks until the initial sync completes and
+// returns an error if it doesn't complete in time.
+func NewCrdNodeGroupConfigProcessor(kubeConfig *rest.Config, namespace string, fallback NodeGroupConfigProcessor) (*CrdNodeGroupConfigProcessor, error) {
+	client, err := dynamic.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create NodeGroupConfig client: %v", err)
+	}
+	return newCrdNodeGroupConfigProcessor(client, namespace, fallback, kube_util.InformerSyncTimeout)
+}
+
+func newCrdNodeGroupConfigProcessor(client dynamic.Interface, namespace string, fallback NodeGroupConfigProcessor, syncTimeout time.Duration) (*CrdNodeGroupConfigProcessor, error) {
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(client, nodeGroupConfigResync, namespace, nil)
+	informer := factory.ForResource(NodeGroupConfigResource).Informer()
+	if err := informer.AddIndexers(cache.Indexers{nodeGroupIndex: nodeGroupIndexFunc}); err != nil {
+		return nil, fmt.Errorf("failed to add NodeGroupConfig indexer: %v", err)
+	}
+	stopCh, err := kube_util.StartAndSyncInformers(factory, syncTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("can't sync NodeGroupConfig informer: %v", err)
+	}
+	klog.V(2).Infof("Successful initial NodeGroupConfig sync in namespace %q", namespace)
+	return &CrdNodeGroupConfigProcessor{
+		NodeGroupConfigProcessor: fallback,
+		informer:                 informer,
+		stopCh:                   stopCh,
+	}, nil
+}
+
+// GetScaleDownUnneededTime returns ScaleDownUnneededTime value that should be used for a given NodeGroup.
+func (p *CrdNodeGroupConfigProcessor) GetScaleDownUnneededTime(nodeGroup cloudprovider.NodeGroup) (time.Duration, error) {
+	if d, found, err := p.durationOverride(nodeGroup, "scaleDownUnneededTime"); found || err != nil {
+		return d, err
+	}
+	return p.NodeGroupConfigProcessor.GetScaleDownUnneededTime(nodeGroup)
+}
+
+// GetScaleDownUtilizationThreshold returns ScaleDownUtilizationThreshold value that should be used for a given NodeGroup.
+func (p *CrdNodeGroupConfigProcessor) GetScaleDownUtilizationThreshold(nodeGroup cloudprovider.NodeGroup) (float64, error) {
+	obj, err := p.configFor(nodeGroup)
+	if err != nil {
+		return 0.0, err
+	}
+	if obj != nil {
+		threshold, found, err := nestedFloat(obj, "spec", "scaleDownUtilizationThreshold")
+		if err != nil {
+			return 0.0, fmt.Errorf("invalid scaleDownUtilizationThreshold in NodeGroupConfig %s/%s: %v", obj.GetNamespace(), obj.GetName(), err)
+		}
+		if found {
+			return threshold, nil
+		}
+	}
+	return p.NodeGroupConfigProcessor.GetScaleDownUtilizationThreshold(nodeGroup)
+}
+
+// GetScaleDownSoftTaintOnly returns ScaleDownSoftTaintOnly value that should be used for a given NodeGroup.
+func (p *CrdNodeGroupConfigProcessor) GetScaleDownSoftTaintOnly(nodeGroup cloudprovider.NodeGroup) (bool, error) {
+	obj, err := p.configFor(nodeGroup)
+	if err != nil {
+		return false, err
+	}
+	if obj != nil {
+		softTaintOnly, found, err := unstructured.NestedBool(obj.Object, "spec", "scaleDownSoftTaintOnly")
+		if err != nil {
+			return false, fmt.Errorf("invalid scaleDownSoftTaintOnly in NodeGroupConfig %s/%s: %v", obj.GetNamespace(), obj.GetName(), err)
+		}
+		if found {
+			return softTaintOnly, nil
+		}
+	}
+	return p.NodeGroupConfigProcessor.GetScaleDownSoftTaintOnly(nodeGroup)
+}
+
+// GetNodeAutoRepairEnabled returns NodeAutoRepairEnabled value that should be used for a given NodeGroup.
+func (p *CrdNodeGroupConfigProcessor) GetNodeAutoRepairEnabled(nodeGroup cloudprovider.NodeGroup) (bool, error) {
+	obj, err := p.configFor(nodeGroup)
+	if err != nil {
+		return false, err
+	}
+	if obj != nil {
+		autoRepair, found, err := unstructured.NestedBool(obj.Object, "spec", "nodeAutoRepairEnabled")
+		if err != nil {
+			return false, fmt.Errorf("invalid nodeAutoRepairEnabled in NodeGroupConfig %s/%s: %v", obj.GetNamespace(), obj.GetName(), err)
+		}
+		if found {
+			return autoRepair, nil
+		}
+	}
+	return p.NodeGroupConfigProcessor.GetNodeAutoRepairEnabled(nodeGroup)
+}
+
+// GetMaxNodeProvisionTime returns MaxNodeProvisionTime value that should be used for a given NodeGroup.
+func (p *CrdNodeGroupConfigProcessor) GetMaxNodeProvisionTime(nodeGroup cloudprovider.NodeGroup) (time.Duration, error) {
+	if d, found, err := p.durationOverride(nodeGroup, "maxNodeProvisionTime"); found || err != nil {
+		return d, err
+	}
+	return p.NodeGroupConfigProcessor.GetMaxNodeProvisionTime(nodeGroup)
+}
+
+// CleanUp stops the NodeGroupConfig informer and cleans up the wrapped processor.
+func (p *CrdNodeGroupConfigProcessor) CleanUp() {
+	close(p.stopCh)
+	p.NodeGroupConfigProcessor.CleanUp()
+}
+
+func (p *CrdNodeGroupConfigProcessor) durationOverride(nodeGroup cloudprovider.NodeGroup, field string) (time.Duration, bool, error) {
+	obj, err := p.configFor(nodeGroup)
+	if err != nil || obj == nil {
+		return time.Duration(0), false, err
+	}
+	value, found, err := unstructured.NestedString(obj.Object, "spec", field)
+	if err != nil || !found {
+		return time.Duration(0), false, err
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Duration(0), false, fmt.Errorf("invalid %s in NodeGroupConfig %s/%s: %v", field, obj.GetNamespace(), obj.GetName(), err)
+	}
+	return d, true, nil
+}
+
+// configFor returns the NodeGroupConfig for the given NodeGroup or nil if
+// there is none. If there are several, the oldest one wins so that the
+// choice doesn't change between loops.
+func (p *CrdNodeGroupConfigProcessor) configFor(nodeGroup cloudprovider.NodeGroup) (*unstructured.Unstructured, error) {
+	objs, err := p.informer.GetIndexer().ByIndex(nodeGroupIndex, nodeGroup.Id())
+	if err != nil {
+		return nil, err
+	}
+	var oldest *unstructured.Unstructured
+	for _, o := range objs {
+		obj, ok := o.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		if oldest == nil || olderThan(obj, oldest) {
+			oldest = obj
+		}
+	}
+	if len(objs) > 1 && oldest != nil {
+		klog.Warningf("Found %d NodeGroupConfigs for node group %s, using %s", len(objs), nodeGroup.Id(), oldest.GetName())
+	}
+	return oldest, nil
+}
+
+func olderThan(a, b *unstructured.Unstructured) bool {
+	ta, tb := a.GetCreationTimestamp(), b.GetCreationTimestamp()
+	if !ta.Equal(&tb) {
+		return ta.Before(&tb)
+	}
+	return a.GetName() < b.GetName()
+}
+
+func nodeGroupIndexFunc(obj interface{}) ([]string, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, nil
+	}
+	nodeGroup, found, err := unstructured.NestedString(u.Object, "spec", "nodeGroup")
+	if err != nil || !found || nodeGroup == "" {
+		return nil, nil
+	}
+	return []string{nodeGroup}, nil
+}
+
+// nestedFloat reads a number field which, depending on how it was written,
+// is decoded either as int64 or float64.
+func nestedFloat(obj *unstructured.Unstructured, fields ...string) (float64, bool, error) {
+	value, found, err := unstructured.NestedFieldNoCopy(obj.Object, fields...)
+	if err != nil || !found {
+		return 0.0, false, err
+	}
+	switch v := value.(type) {
+	case float64:
+		return v, true, nil
+	case int64:
+		return float64(v), true, nil
+	default:
+		return 0.0, false, fmt.Errorf("expected a number, got %T", value)
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodegroupconfig
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	core "k8s.io/client-go/testing"
+)
+
+func nodeGroupConfig(name, nodeGroup string, created time.Time, spec map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	obj.SetAPIVersion(NodeGroupConfigResource.GroupVersion().String())
+	obj.SetKind("NodeGroupConfig")
+	obj.SetNamespace("kube-system")
+	obj.SetName(name)
+	obj.SetCreationTimestamp(metav1.NewTime(created))
+	s := map[string]interface{}{"nodeGroup": nodeGroup}
+	for k, v := range spec {
+		s[k] = v
+	}
+	obj.Object["spec"] = s
+	return obj
+}
+
+func newFakeNodeGroupConfigClient(objs ...runtime.Object) *fakedynamic.FakeDynamicClient {
+	return fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		NodeGroupConfigResource: "NodeGroupConfigList",
+	}, objs...)
+}
+
+func TestCrdNodeGroupConfigProcessor(t *testing.T) {
+	defaults := config.NodeGroupAutoscalingOptions{
+		ScaleDownUnneededTime:         10 * time.Minute,
+		ScaleDownUnreadyTime:          20 * time.Minute,
+		ScaleDownUtilizationThreshold: 0.5,
+		MaxNodeProvisionTime:          15 * time.Minute,
+	}
+	now := time.Now()
+
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 0, 10, 1)
+	provider.AddNodeGroup("ng2", 0, 10, 1)
+	provider.AddNodeGroup("ng3", 0, 10, 1)
+	ng1 := provider.GetNodeGroup("ng1")
+	ng2 := provider.GetNodeGroup("ng2")
+	ng3 := provider.GetNodeGroup("ng3")
+
+	client := newFakeNodeGroupConfigClient(
+		nodeGroupConfig("ng1-config", "ng1", now, map[string]interface{}{
+			"scaleDownUnneededTime":         "2m",
+			"scaleDownUtilizationThreshold": 0.7,
+			"maxNodeProvisionTime":          "30m",
+			"scaleDownSoftTaintOnly":        true,
+			"nodeAutoRepairEnabled":         true,
+		}),
+		nodeGroupConfig("ng2-new", "ng2", now, map[string]interface{}{
+			"scaleDownUnneededTime": "5m",
+		}),
+		nodeGroupConfig("ng2-old", "ng2", now.Add(-time.Hour), map[string]interface{}{
+			"scaleDownUnneededTime":         "1m",
+			"scaleDownUtilizationThreshold": int64(1),
+		}),
+	)
+	p, err := newCrdNodeGroupConfigProcessor(client, "kube-system", NewDefaultNodeGroupConfigProcessor(defaults), time.Minute)
+	assert.NoError(t, err)
+	defer p.CleanUp()
+
+	unneeded, err := p.GetScaleDownUnneededTime(ng1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2*time.Minute, unneeded)
+	threshold, err := p.GetScaleDownUtilizationThreshold(ng1)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.7, threshold)
+	provisionTime, err := p.GetMaxNodeProvisionTime(ng1)
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, provisionTime)
+	unready, err := p.GetScaleDownUnreadyTime(ng1)
+	assert.NoError(t, err)
+	assert.Equal(t, 20*time.Minute, unready)
+	softTaintOnly, err := p.GetScaleDownSoftTaintOnly(ng1)
+	assert.NoError(t, err)
+	assert.True(t, softTaintOnly)
+	autoRepair, err := p.GetNodeAutoRepairEnabled(ng1)
+	assert.NoError(t, err)
+	assert.True(t, autoRepair)
+
+	// The oldest NodeGroupConfig wins, missing fields fall back to defaults.
+	unneeded, err = p.GetScaleDownUnneededTime(ng2)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, unneeded)
+	threshold, err = p.GetScaleDownUtilizationThreshold(ng2)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, threshold)
+	provisionTime, err = p.GetMaxNodeProvisionTime(ng2)
+	assert.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, provisionTime)
+	softTaintOnly, err = p.GetScaleDownSoftTaintOnly(ng2)
+	assert.NoError(t, err)
+	assert.False(t, softTaintOnly)
+	autoRepair, err = p.GetNodeAutoRepairEnabled(ng2)
+	assert.NoError(t, err)
+	assert.False(t, autoRepair)
+
+	unneeded, err = p.GetScaleDownUnneededTime(ng3)
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, unneeded)
+
+	// Changes to NodeGroupConfigs are picked up without recreating the processor.
+	_, err = client.Resource(NodeGroupConfigResource).Namespace("kube-system").Create(context.Background(), nodeGroupConfig("ng3-config", "ng3", now, map[string]interface{}{
+		"scaleDownUnneededTime": "not-a-duration",
+	}), metav1.CreateOptions{})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		_, err := p.GetScaleDownUnneededTime(ng3)
+		return err != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	err = client.Resource(NodeGroupConfigResource).Namespace("kube-system").Delete(context.Background(), "ng1-config", metav1.DeleteOptions{})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		unneeded, err := p.GetScaleDownUnneededTime(ng1)
+		return err == nil && unneeded == 10*time.Minute
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestCrdNodeGroupConfigProcessorSyncTimeout(t *testing.T) {
+	client := newFakeNodeGroupConfigClient()
+	client.PrependReactor("list", "nodegroupconfigs", func(core.Action) (bool, runtime.Object, error) {
+		return true, nil, fmt.Errorf("nodegroupconfigs is forbidden")
+	})
+	_, err := newCrdNodeGroupConfigProcessor(client, "kube-system", NewDefaultNodeGroupConfigProcessor(config.NodeGroupAutoscalingOptions{}), 100*time.Millisecond)
+	assert.Error(t, err)
+}
@@ -37,6 +37,12 @@ type NodeGroupConfigProcessor interface {
 	GetMaxNodeProvisionTime(nodeGroup cloudprovider.NodeGroup) (time.Duration, error)
 	// GetIgnoreDaemonSetsUtilization returns IgnoreDaemonSetsUtilization value that should be used for a given NodeGroup.
 	GetIgnoreDaemonSetsUtilization(nodeGroup cloudprovider.NodeGroup) (bool, error)
+	// GetScaleDownSoftTaintOnly returns ScaleDownSoftTaintOnly value that should be used for a given NodeGroup.
+	GetScaleDownSoftTaintOnly(nodeGroup cloudprovider.NodeGroup) (bool, error)
+	// GetNodeAutoRepairEnabled returns NodeAutoRepairEnabled value that should be used for a given NodeGroup.
+	GetNodeAutoRepairEnabled(nodeGroup cloudprovider.NodeGroup) (bool, error)
+	// SetNodeGroupDefaults replaces the values used for NodeGroups that don't provide their own.
+	SetNodeGroupDefaults(nodeGroupDefaults config.NodeGroupAutoscalingOptions)
 	// CleanUp cleans up processor's internal structures.
 	CleanUp()
 }
@@ -120,6 +126,35 @@ func (p *DelegatingNodeGroupConfigProcessor) GetIgnoreDaemonSetsUtilization(node
 	return ngConfig.IgnoreDaemonSetsUtilization, nil
 }
 
+// GetScaleDownSoftTaintOnly returns ScaleDownSoftTaintOnly value that should be used for a given NodeGroup.
+func (p *DelegatingNodeGroupConfigProcessor) GetScaleDownSoftTaintOnly(nodeGroup cloudprovider.NodeGroup) (bool, error) {
+	ngConfig, err := nodeGroup.GetOptions(p.nodeGroupDefaults)
+	if err != nil && err != cloudprovider.ErrNotImplemented {
+		return false, err
+	}
+	if ngConfig == nil || err == cloudprovider.ErrNotImplemented {
+		return p.nodeGroupDefaults.ScaleDownSoftTaintOnly, nil
+	}
+	return ngConfig.ScaleDownSoftTaintOnly, nil
+}
+
+// GetNodeAutoRepairEnabled returns NodeAutoRepairEnabled value that should be used for a given NodeGroup.
+func (p *DelegatingNodeGroupConfigProcessor) GetNodeAutoRepairEnabled(nodeGroup cloudprovider.NodeGroup) (bool, error) {
+	ngConfig, err := nodeGroup.GetOptions(p.nodeGroupDefaults)
+	if err != nil && err != cloudprovider.ErrNotImplemented {
+		return false, err
+	}
+	if ngConfig == nil || err == cloudprovider.ErrNotImplemented {
+		return p.nodeGroupDefaults.NodeAutoRepairEnabled, nil
+	}
+	return ngConfig.NodeAutoRepairEnabled, nil
+}
+
+// SetNodeGroupDefaults replaces the values used for NodeGroups that don't provide their own.
+func (p *DelegatingNodeGroupConfigProcessor) SetNodeGroupDefaults(nodeGroupDefaults config.NodeGroupAutoscalingOptions) {
+	p.nodeGroupDefaults = nodeGroupDefaults
+}
+
 // CleanUp cleans up processor's internal structures.
 func (p *DelegatingNodeGroupConfigProcessor) CleanUp() {
 }
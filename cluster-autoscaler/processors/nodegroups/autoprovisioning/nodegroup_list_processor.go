@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoprovisioning
+
+import (
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/labels"
+	klog "k8s.io/klog/v2"
+)
+
+// NodeGroupListProcessor adds node groups that don't exist yet, one per machine type available
+// in the cloud provider, to the node groups considered in scale-up. They are only added for
+// pods that don't fit on a node of any existing node group, and are created only if chosen
+// by the expander.
+type NodeGroupListProcessor struct {
+	maxAutoprovisionedNodeGroupCount int
+}
+
+// NewNodeGroupListProcessor returns a NodeGroupListProcessor that stops adding node groups once
+// maxAutoprovisionedNodeGroupCount autoprovisioned node groups exist.
+func NewNodeGroupListProcessor(maxAutoprovisionedNodeGroupCount int) *NodeGroupListProcessor {
+	return &NodeGroupListProcessor{maxAutoprovisionedNodeGroupCount: maxAutoprovisionedNodeGroupCount}
+}
+
+// Process extends the list of node groups with autoprovisioning candidates.
+func (p *NodeGroupListProcessor) Process(context *context.AutoscalingContext, nodeGroups []cloudprovider.NodeGroup, nodeInfos map[string]*framework.NodeInfo,
+	unschedulablePods []*apiv1.Pod) ([]cloudprovider.NodeGroup, map[string]*framework.NodeInfo, error) {
+	if len(unschedulablePods) == 0 {
+		return nodeGroups, nodeInfos, nil
+	}
+	autoprovisionedCount := 0
+	for _, nodeGroup := range nodeGroups {
+		if nodeGroup.Autoprovisioned() && nodeGroup.Exist() {
+			autoprovisionedCount++
+		}
+	}
+	if autoprovisionedCount >= p.maxAutoprovisionedNodeGroupCount {
+		klog.V(4).Infof("Max autoprovisioned node group count %d reached, not adding new node groups", p.maxAutoprovisionedNodeGroupCount)
+		return nodeGroups, nodeInfos, nil
+	}
+
+	pods := podsNotFittingNodeGroups(context, nodeGroups, nodeInfos, unschedulablePods)
+	if len(pods) == 0 {
+		return nodeGroups, nodeInfos, nil
+	}
+	machineTypes, err := context.CloudProvider.GetAvailableMachineTypes()
+	if err != nil {
+		return nodeGroups, nodeInfos, err
+	}
+	bestLabels := labels.BestLabelSet(pods)
+	for _, machineType := range machineTypes {
+		nodeGroup, err := context.CloudProvider.NewNodeGroup(machineType, bestLabels, map[string]string{}, []apiv1.Taint{}, map[string]resource.Quantity{})
+		if err != nil {
+			klog.Warningf("Failed to build node group for machine type %s: %v", machineType, err)
+			continue
+		}
+		nodeInfo, err := nodeGroup.TemplateNodeInfo()
+		if err != nil {
+			klog.Warningf("Failed to build template node for machine type %s: %v", machineType, err)
+			continue
+		}
+		nodeInfos[nodeGroup.Id()] = nodeInfo
+		nodeGroups = append(nodeGroups, nodeGroup)
+	}
+	return nodeGroups, nodeInfos, nil
+}
+
+// CleanUp cleans up the processor's internal structures.
+func (p *NodeGroupListProcessor) CleanUp() {
+}
+
+// podsNotFittingNodeGroups returns the pods that can't be scheduled on an empty node of any of the node groups.
+func podsNotFittingNodeGroups(context *context.AutoscalingContext, nodeGroups []cloudprovider.NodeGroup, nodeInfos map[string]*framework.NodeInfo, pods []*apiv1.Pod) []*apiv1.Pod {
+	fits := make(map[*apiv1.Pod]bool, len(pods))
+	for _, nodeGroup := range nodeGroups {
+		template, found := nodeInfos[nodeGroup.Id()]
+		if !found {
+			continue
+		}
+		nodeInfo, err := simulator.SanitizedNodeInfo(template, "autoprovisioning")
+		if err != nil {
+			klog.Warningf("Failed to sanitize template node of %s: %v", nodeGroup.Id(), err)
+			continue
+		}
+		context.ClusterSnapshot.Fork()
+		if err := context.ClusterSnapshot.AddNodeInfo(nodeInfo); err != nil {
+			klog.Warningf("Failed to add template node of %s to snapshot: %v", nodeGroup.Id(), err)
+			context.ClusterSnapshot.Revert()
+			continue
+		}
+		for _, pod := range pods {
+			if !fits[pod] && context.ClusterSnapshot.CheckPredicates(pod, nodeInfo.Node().Name) == nil {
+				fits[pod] = true
+			}
+		}
+		context.ClusterSnapshot.Revert()
+	}
+	var result []*apiv1.Pod
+	for _, pod := range pods {
+		if !fits[pod] {
+			result = append(result, pod)
+		}
+	}
+	return result
+}
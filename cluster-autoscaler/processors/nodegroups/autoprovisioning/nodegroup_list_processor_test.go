@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoprovisioning
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	. "k8s.io/autoscaler/cluster-autoscaler/core/test"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+func TestNodeGroupListProcessor(t *testing.T) {
+	for _, tc := range []struct {
+		name                  string
+		podCpu                int64
+		autoprovisionedGroups int
+		wantNodeGroups        []string
+	}{
+		{
+			name:           "pod fits existing node group",
+			podCpu:         500,
+			wantNodeGroups: []string{"ng1"},
+		},
+		{
+			name:           "pod doesn't fit existing node group",
+			podCpu:         3000,
+			wantNodeGroups: []string{"ng1", "autoprovisioned-small", "autoprovisioned-large"},
+		},
+		{
+			name:                  "max autoprovisioned node group count reached",
+			podCpu:                3000,
+			autoprovisionedGroups: 2,
+			wantNodeGroups:        []string{"ng1", "existing-0", "existing-1"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			small := BuildTestNode("small", 1000, 1000)
+			large := BuildTestNode("large", 4000, 4000)
+			machineTemplates := map[string]*framework.NodeInfo{
+				"small": framework.NewTestNodeInfo(small),
+				"large": framework.NewTestNodeInfo(large),
+			}
+			provider := testprovider.NewTestAutoprovisioningCloudProvider(nil, nil, nil, nil, []string{"small", "large"}, machineTemplates)
+			provider.AddNodeGroup("ng1", 0, 10, 1)
+			nodeGroups := []cloudprovider.NodeGroup{provider.GetNodeGroup("ng1")}
+			ng1Template := BuildTestNode("ng1-template", 1000, 1000)
+			nodeInfos := map[string]*framework.NodeInfo{"ng1": framework.NewTestNodeInfo(ng1Template)}
+			for i := 0; i < tc.autoprovisionedGroups; i++ {
+				nodeGroups = append(nodeGroups, provider.AddAutoprovisionedNodeGroup(fmt.Sprintf("existing-%d", i), 0, 10, 1, "small"))
+			}
+
+			ctx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{}, nil, nil, provider, nil, nil)
+			assert.NoError(t, err)
+
+			pod := BuildTestPod("p", tc.podCpu, 100)
+			p := NewNodeGroupListProcessor(2)
+			gotNodeGroups, gotNodeInfos, err := p.Process(&ctx, nodeGroups, nodeInfos, []*apiv1.Pod{pod})
+			assert.NoError(t, err)
+			var gotIds []string
+			for _, nodeGroup := range gotNodeGroups {
+				gotIds = append(gotIds, nodeGroup.Id())
+			}
+			assert.Equal(t, tc.wantNodeGroups, gotIds)
+			for _, id := range gotIds {
+				if id == "autoprovisioned-small" || id == "autoprovisioned-large" {
+					assert.Contains(t, gotNodeInfos, id)
+				}
+			}
+		})
+	}
+}
+
+func TestRemoveUnneededNodeGroups(t *testing.T) {
+	var deleted []string
+	provider := testprovider.NewTestAutoprovisioningCloudProvider(nil, nil, nil, func(id string) error {
+		deleted = append(deleted, id)
+		return nil
+	}, nil, nil)
+	provider.AddNodeGroup("ng1", 0, 10, 0)
+	provider.AddAutoprovisionedNodeGroup("empty", 0, 10, 0, "small")
+	provider.AddAutoprovisionedNodeGroup("scaling-up", 0, 10, 1, "small")
+	provider.AddAutoprovisionedNodeGroup("with-node", 0, 10, 0, "small")
+	provider.AddNode("with-node", BuildTestNode("n1", 1000, 1000))
+
+	ctx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{}, nil, nil, provider, nil, nil)
+	assert.NoError(t, err)
+
+	removed, err := NewNodeGroupManager().RemoveUnneededNodeGroups(&ctx)
+	assert.NoError(t, err)
+	assert.Len(t, removed, 1)
+	assert.Equal(t, []string{"empty"}, deleted)
+	assert.Nil(t, provider.GetNodeGroup("empty"))
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoprovisioning
+
+import (
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/metrics"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroups"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	klog "k8s.io/klog/v2"
+)
+
+// NodeGroupManager creates node groups added by NodeGroupListProcessor and deletes
+// autoprovisioned node groups once they have no nodes.
+type NodeGroupManager struct {
+}
+
+// NewNodeGroupManager creates a new NodeGroupManager.
+func NewNodeGroupManager() *NodeGroupManager {
+	return &NodeGroupManager{}
+}
+
+// CreateNodeGroup creates the node group on the cloud provider side.
+func (m *NodeGroupManager) CreateNodeGroup(context *context.AutoscalingContext, nodeGroup cloudprovider.NodeGroup) (nodegroups.CreateNodeGroupResult, errors.AutoscalerError) {
+	newNodeGroup, err := nodeGroup.Create()
+	if err != nil {
+		return nodegroups.CreateNodeGroupResult{}, errors.ToAutoscalerError(errors.CloudProviderError, err).AddPrefix("failed to create node group %s: ", nodeGroup.Id())
+	}
+	metrics.RegisterNodeGroupCreation()
+	return nodegroups.CreateNodeGroupResult{MainCreatedNodeGroup: newNodeGroup}, nil
+}
+
+// CreateNodeGroupAsync isn't supported, node groups are always created synchronously.
+func (m *NodeGroupManager) CreateNodeGroupAsync(context *context.AutoscalingContext, nodeGroup cloudprovider.NodeGroup, nodeGroupInitializer nodegroups.AsyncNodeGroupInitializer) (nodegroups.CreateNodeGroupResult, errors.AutoscalerError) {
+	return nodegroups.CreateNodeGroupResult{}, errors.NewAutoscalerError(errors.InternalError, "asynchronous node group creation is not supported")
+}
+
+// RemoveUnneededNodeGroups deletes autoprovisioned node groups with target size 0 and no nodes.
+func (m *NodeGroupManager) RemoveUnneededNodeGroups(context *context.AutoscalingContext) (removedNodeGroups []cloudprovider.NodeGroup, err error) {
+	for _, nodeGroup := range context.CloudProvider.NodeGroups() {
+		if !nodeGroup.Autoprovisioned() || !nodeGroup.Exist() {
+			continue
+		}
+		targetSize, err := nodeGroup.TargetSize()
+		if err != nil {
+			klog.Warningf("Failed to get target size of %s: %v", nodeGroup.Id(), err)
+			continue
+		}
+		if targetSize > 0 {
+			continue
+		}
+		nodes, err := nodeGroup.Nodes()
+		if err != nil {
+			klog.Warningf("Failed to list nodes of %s: %v", nodeGroup.Id(), err)
+			continue
+		}
+		if len(nodes) > 0 {
+			continue
+		}
+		if err := nodeGroup.Delete(); err != nil {
+			klog.Errorf("Failed to delete node group %s: %v", nodeGroup.Id(), err)
+			continue
+		}
+		metrics.RegisterNodeGroupDeletion()
+		klog.V(1).Infof("Deleted autoprovisioned node group %s", nodeGroup.Id())
+		removedNodeGroups = append(removedNodeGroups, nodeGroup)
+	}
+	return removedNodeGroups, nil
+}
+
+// CleanUp cleans up the manager's internal structures.
+func (m *NodeGroupManager) CleanUp() {
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumetopology
+
+import (
+	"slices"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroups"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/client-go/informers"
+	v1lister "k8s.io/client-go/listers/core/v1"
+	"k8s.io/component-helpers/storage/ephemeral"
+	"k8s.io/component-helpers/storage/volume"
+	klog "k8s.io/klog/v2"
+)
+
+const (
+	// ScaleUpForVolumeTopologyReason is the reason of events on pods whose bound persistent volumes
+	// don't match the topology of any node, listing the node groups matching it.
+	ScaleUpForVolumeTopologyReason = "ScaleUpForVolumeTopology"
+	// NoNodeGroupForVolumeTopologyReason is the reason of events on pods whose bound persistent volumes
+	// don't match the topology of any node nor node group.
+	NoNodeGroupForVolumeTopologyReason = "NoNodeGroupForVolumeTopology"
+)
+
+// wellKnownTopologyLabels are the labels of nodes and node group templates that topology labels of
+// CSI drivers can be aliases of.
+var wellKnownTopologyLabels = []string{
+	apiv1.LabelTopologyZone,
+	apiv1.LabelTopologyRegion,
+	apiv1.LabelFailureDomainBetaZone,
+	apiv1.LabelFailureDomainBetaRegion,
+}
+
+// NodeGroupListProcessor makes node groups considered in scale-up match the topology of persistent
+// volumes bound to unschedulable pods, so that pods whose volumes are e.g. in a zone without nodes
+// trigger a scale-up of node groups in that zone.
+//
+// The scheduler only places such pods on nodes matching the node affinity of their volumes, which often
+// uses topology labels of CSI drivers (e.g. topology.ebs.csi.aws.com/zone). Nodes get these labels when
+// the driver registers on them, so templates of node groups without nodes don't have them and never match.
+// They are added to the templates when the labels of existing nodes show them to be aliases of a well-known
+// topology label, like topology.kubernetes.io/zone. Pods whose volumes don't match any node also get an
+// event listing the node groups matching them.
+type NodeGroupListProcessor struct {
+	next      nodegroups.NodeGroupListProcessor
+	pvcLister v1lister.PersistentVolumeClaimLister
+	pvLister  v1lister.PersistentVolumeLister
+}
+
+// NewNodeGroupListProcessor returns a NodeGroupListProcessor processing the node groups returned by next.
+func NewNodeGroupListProcessor(next nodegroups.NodeGroupListProcessor, informerFactory informers.SharedInformerFactory) *NodeGroupListProcessor {
+	return &NodeGroupListProcessor{
+		next:      next,
+		pvcLister: informerFactory.Core().V1().PersistentVolumeClaims().Lister(),
+		pvLister:  informerFactory.Core().V1().PersistentVolumes().Lister(),
+	}
+}
+
+// Process adds topology labels of persistent volumes bound to the unschedulable pods to the templates of
+// the node groups, and emits events on the pods whose volumes don't match any node.
+func (p *NodeGroupListProcessor) Process(context *context.AutoscalingContext, nodeGroups []cloudprovider.NodeGroup, nodeInfos map[string]*framework.NodeInfo,
+	unschedulablePods []*apiv1.Pod) ([]cloudprovider.NodeGroup, map[string]*framework.NodeInfo, error) {
+	nodeGroups, nodeInfos, err := p.next.Process(context, nodeGroups, nodeInfos, unschedulablePods)
+	if err != nil || len(unschedulablePods) == 0 {
+		return nodeGroups, nodeInfos, err
+	}
+
+	volumes := make(map[*apiv1.Pod][]*apiv1.PersistentVolume)
+	topologyKeys := make(map[string]bool)
+	for _, pod := range unschedulablePods {
+		pvs := p.boundVolumesWithTopology(pod)
+		if len(pvs) == 0 {
+			continue
+		}
+		volumes[pod] = pvs
+		for _, pv := range pvs {
+			for _, term := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+				for _, requirement := range term.MatchExpressions {
+					topologyKeys[requirement.Key] = true
+				}
+			}
+		}
+	}
+	if len(volumes) == 0 {
+		return nodeGroups, nodeInfos, nil
+	}
+
+	nodes, err := context.AllNodeLister().List()
+	if err != nil {
+		klog.Warningf("Failed to list nodes, not matching node groups with volume topology: %v", err)
+		return nodeGroups, nodeInfos, nil
+	}
+	aliases := topologyAliases(nodes, topologyKeys)
+	processedNodeInfos := make(map[string]*framework.NodeInfo, len(nodeInfos))
+	for id, nodeInfo := range nodeInfos {
+		processedNodeInfos[id] = withTopologyAliases(nodeInfo, aliases)
+	}
+
+	for pod, pvs := range volumes {
+		var unmatchedPvs []*apiv1.PersistentVolume
+		for _, pv := range pvs {
+			if !matchesAnyNode(pv, nodes) {
+				unmatchedPvs = append(unmatchedPvs, pv)
+			}
+		}
+		if len(unmatchedPvs) == 0 {
+			continue
+		}
+		var matchingNodeGroups []string
+		for _, nodeGroup := range nodeGroups {
+			if nodeInfo, found := processedNodeInfos[nodeGroup.Id()]; found && matchesAll(pvs, nodeInfo.Node().Labels) {
+				matchingNodeGroups = append(matchingNodeGroups, nodeGroup.Id())
+			}
+		}
+		if len(matchingNodeGroups) == 0 {
+			context.Recorder.Eventf(pod, apiv1.EventTypeWarning, NoNodeGroupForVolumeTopologyReason,
+				"persistent volumes %s bound to the pod don't match the topology of any node nor node group", volumeNames(unmatchedPvs))
+			continue
+		}
+		klog.V(4).Infof("Volumes %s of pod %s/%s don't match any node, node groups matching them: %v", volumeNames(unmatchedPvs), pod.Namespace, pod.Name, matchingNodeGroups)
+		context.Recorder.Eventf(pod, apiv1.EventTypeNormal, ScaleUpForVolumeTopologyReason,
+			"persistent volumes %s bound to the pod don't match the topology of any node, considering node groups matching it for scale-up: %s",
+			volumeNames(unmatchedPvs), strings.Join(matchingNodeGroups, ", "))
+	}
+	return nodeGroups, processedNodeInfos, nil
+}
+
+// CleanUp cleans up the processor's internal structures.
+func (p *NodeGroupListProcessor) CleanUp() {
+	p.next.CleanUp()
+}
+
+// boundVolumesWithTopology returns the persistent volumes bound to the claims of the pod which have a
+// required node affinity.
+func (p *NodeGroupListProcessor) boundVolumesWithTopology(pod *apiv1.Pod) []*apiv1.PersistentVolume {
+	var pvs []*apiv1.PersistentVolume
+	for _, podVolume := range pod.Spec.Volumes {
+		var claimName string
+		switch {
+		case podVolume.PersistentVolumeClaim != nil:
+			claimName = podVolume.PersistentVolumeClaim.ClaimName
+		case podVolume.Ephemeral != nil:
+			claimName = ephemeral.VolumeClaimName(pod, &podVolume)
+		default:
+			continue
+		}
+		pvc, err := p.pvcLister.PersistentVolumeClaims(pod.Namespace).Get(claimName)
+		if err != nil || pvc.Spec.VolumeName == "" {
+			continue
+		}
+		pv, err := p.pvLister.Get(pvc.Spec.VolumeName)
+		if err != nil || pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+			continue
+		}
+		pvs = append(pvs, pv)
+	}
+	return pvs
+}
+
+// topologyAliases returns the well-known topology labels that the given topology keys are aliases of.
+// A key is an alias of a label if all nodes having the key have the same value for both.
+func topologyAliases(nodes []*apiv1.Node, topologyKeys map[string]bool) map[string]string {
+	aliases := make(map[string]string)
+	for key := range topologyKeys {
+		if slices.Contains(wellKnownTopologyLabels, key) {
+			continue
+		}
+		for _, label := range wellKnownTopologyLabels {
+			if isAlias(nodes, key, label) {
+				aliases[key] = label
+				break
+			}
+		}
+	}
+	return aliases
+}
+
+func isAlias(nodes []*apiv1.Node, key, label string) bool {
+	found := false
+	for _, node := range nodes {
+		value, hasKey := node.Labels[key]
+		if !hasKey {
+			continue
+		}
+		if labelValue, hasLabel := node.Labels[label]; !hasLabel || labelValue != value {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// withTopologyAliases returns the node info with the aliases it's missing, copying it if needed.
+func withTopologyAliases(nodeInfo *framework.NodeInfo, aliases map[string]string) *framework.NodeInfo {
+	node := nodeInfo.Node()
+	if node == nil {
+		return nodeInfo
+	}
+	var processed *framework.NodeInfo
+	for key, label := range aliases {
+		if _, found := node.Labels[key]; found {
+			continue
+		}
+		value, found := node.Labels[label]
+		if !found {
+			continue
+		}
+		if processed == nil {
+			processed = nodeInfo.DeepCopy()
+		}
+		processed.Node().Labels[key] = value
+	}
+	if processed == nil {
+		return nodeInfo
+	}
+	return processed
+}
+
+func matchesAnyNode(pv *apiv1.PersistentVolume, nodes []*apiv1.Node) bool {
+	for _, node := range nodes {
+		if volume.CheckNodeAffinity(pv, node.Labels) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAll(pvs []*apiv1.PersistentVolume, labels map[string]string) bool {
+	for _, pv := range pvs {
+		if volume.CheckNodeAffinity(pv, labels) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func volumeNames(pvs []*apiv1.PersistentVolume) string {
+	names := make([]string, 0, len(pvs))
+	for _, pv := range pvs {
+		names = append(names, pv.Name)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumetopology
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	. "k8s.io/autoscaler/cluster-autoscaler/core/test"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroups"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	v1lister "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	kube_record "k8s.io/client-go/tools/record"
+)
+
+const ebsZoneLabel = "topology.ebs.csi.aws.com/zone"
+
+func buildVolume(name, key, value string) (*apiv1.PersistentVolume, *apiv1.PersistentVolumeClaim) {
+	pv := &apiv1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: apiv1.PersistentVolumeSpec{
+			NodeAffinity: &apiv1.VolumeNodeAffinity{
+				Required: &apiv1.NodeSelector{
+					NodeSelectorTerms: []apiv1.NodeSelectorTerm{{
+						MatchExpressions: []apiv1.NodeSelectorRequirement{{
+							Key:      key,
+							Operator: apiv1.NodeSelectorOpIn,
+							Values:   []string{value},
+						}},
+					}},
+				},
+			},
+		},
+	}
+	pvc := &apiv1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       apiv1.PersistentVolumeClaimSpec{VolumeName: name},
+	}
+	return pv, pvc
+}
+
+func buildPod(name string, claimNames ...string) *apiv1.Pod {
+	pod := BuildTestPod(name, 100, 100)
+	for _, claimName := range claimNames {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, apiv1.Volume{
+			Name: claimName,
+			VolumeSource: apiv1.VolumeSource{
+				PersistentVolumeClaim: &apiv1.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+			},
+		})
+	}
+	return pod
+}
+
+func buildTemplate(name string, labels map[string]string) *framework.NodeInfo {
+	node := BuildTestNode(name, 1000, 1000)
+	for k, v := range labels {
+		node.Labels[k] = v
+	}
+	return framework.NewNodeInfo(node, nil)
+}
+
+func TestNodeGroupListProcessor(t *testing.T) {
+	node := BuildTestNode("node-a", 1000, 1000)
+	node.Labels[apiv1.LabelTopologyZone] = "a"
+	node.Labels[ebsZoneLabel] = "a"
+
+	pvcStore := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	pvStore := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, volume := range []struct{ name, key, value string }{
+		{"volume-a", ebsZoneLabel, "a"},
+		{"volume-b", ebsZoneLabel, "b"},
+		{"volume-local", apiv1.LabelHostname, "deleted-node"},
+	} {
+		pv, pvc := buildVolume(volume.name, volume.key, volume.value)
+		assert.NoError(t, pvStore.Add(pv))
+		assert.NoError(t, pvcStore.Add(pvc))
+	}
+	processor := &NodeGroupListProcessor{
+		next:      &nodegroups.NoOpNodeGroupListProcessor{},
+		pvcLister: v1lister.NewPersistentVolumeClaimLister(pvcStore),
+		pvLister:  v1lister.NewPersistentVolumeLister(pvStore),
+	}
+
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng-a", 0, 10, 0)
+	provider.AddNodeGroup("ng-b", 0, 10, 0)
+	provider.AddNodeGroup("ng-no-zone", 0, 10, 0)
+	nodeInfos := map[string]*framework.NodeInfo{
+		"ng-a":       buildTemplate("ng-a-template", map[string]string{apiv1.LabelTopologyZone: "a"}),
+		"ng-b":       buildTemplate("ng-b-template", map[string]string{apiv1.LabelTopologyZone: "b"}),
+		"ng-no-zone": buildTemplate("ng-no-zone-template", nil),
+	}
+	nodeLister := kube_util.NewTestNodeLister([]*apiv1.Node{node})
+	listers := kube_util.NewListerRegistry(nodeLister, nodeLister, nil, nil, nil, nil, nil, nil, nil)
+	ctx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{}, nil, listers, provider, nil, nil)
+	assert.NoError(t, err)
+
+	pods := []*apiv1.Pod{
+		buildPod("no-volumes"),
+		buildPod("volume-matching-node", "volume-a"),
+		buildPod("volume-in-zone-without-nodes", "volume-b"),
+		buildPod("local-volume", "volume-local"),
+	}
+	nodeGroups, processedNodeInfos, err := processor.Process(&ctx, provider.NodeGroups(), nodeInfos, pods)
+	assert.NoError(t, err)
+	assert.Len(t, nodeGroups, 3)
+
+	assert.Equal(t, "a", processedNodeInfos["ng-a"].Node().Labels[ebsZoneLabel])
+	assert.Equal(t, "b", processedNodeInfos["ng-b"].Node().Labels[ebsZoneLabel])
+	assert.Same(t, nodeInfos["ng-no-zone"], processedNodeInfos["ng-no-zone"])
+	// Templates passed to the processor aren't modified.
+	assert.NotContains(t, nodeInfos["ng-b"].Node().Labels, ebsZoneLabel)
+
+	var events []string
+	recorder := ctx.Recorder.(*kube_record.FakeRecorder)
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	assert.ElementsMatch(t, []string{
+		"Normal ScaleUpForVolumeTopology persistent volumes volume-b bound to the pod don't match the topology of any node, considering node groups matching it for scale-up: ng-b",
+		"Warning NoNodeGroupForVolumeTopology persistent volumes volume-local bound to the pod don't match the topology of any node nor node group",
+	}, events)
+}
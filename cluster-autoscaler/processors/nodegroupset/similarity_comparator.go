@@ -0,0 +1,161 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodegroupset
+
+import (
+	"regexp"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	klog "k8s.io/klog/v2"
+)
+
+// InstanceTypeLabels are the labels holding the instance type of a node.
+var InstanceTypeLabels = []string{apiv1.LabelInstanceTypeStable, apiv1.LabelInstanceType}
+
+var (
+	// e.g. m5.xlarge, m5a.xlarge, c7gn.16xlarge
+	awsInstanceTypeRegexp = regexp.MustCompile(`^([a-z]+[0-9]+)[a-z0-9-]*\.`)
+	// e.g. n2-standard-8, n2d-standard-8
+	gceInstanceTypeRegexp = regexp.MustCompile(`^([a-z]+[0-9]+)[a-z]*-([a-z]+)-[0-9]+`)
+	// e.g. Standard_D4s_v3, Standard_D4as_v3
+	azureInstanceTypeRegexp = regexp.MustCompile(`^(Standard_[A-Z]+)[0-9]+[a-z]*(_v[0-9]+)?$`)
+)
+
+// NewNodeInfoComparator returns the comparator used to find similar node groups with the given
+// options. If BalancingLabels are set, only they are compared. Otherwise the cloud provider
+// specific heuristics are used, with BalancingExtraIgnoredLabels ignored on top of the provider
+// defaults. In both cases, BalancingRequiredLabels have to be set to the same value on both nodes,
+// even if they are ignored by the heuristics, and with BalancingInstanceFamilyMatch nodes of
+// different instance types of the same family are considered similar.
+func NewNodeInfoComparator(options config.AutoscalingOptions) NodeInfoComparator {
+	var comparator NodeInfoComparator
+	if len(options.BalancingLabels) > 0 {
+		labels := options.BalancingLabels
+		if options.BalancingInstanceFamilyMatch {
+			labels = withoutInstanceTypeLabels(labels)
+		}
+		comparator = CreateLabelNodeInfoComparator(labels)
+	} else {
+		comparatorBuilder := CreateGenericNodeInfoComparator
+		switch options.CloudProviderName {
+		case cloudprovider.AzureProviderName:
+			comparatorBuilder = CreateAzureNodeInfoComparator
+		case cloudprovider.AwsProviderName:
+			comparatorBuilder = CreateAwsNodeInfoComparator
+		case cloudprovider.GceProviderName:
+			comparatorBuilder = CreateGceNodeInfoComparator
+		}
+		ignoredLabels := options.BalancingExtraIgnoredLabels
+		if options.BalancingInstanceFamilyMatch {
+			ignoredLabels = append(append([]string{}, ignoredLabels...), InstanceTypeLabels...)
+		}
+		comparator = comparatorBuilder(ignoredLabels, options.NodeGroupSetRatios)
+	}
+	if options.BalancingInstanceFamilyMatch {
+		comparator = CreateInstanceFamilyNodeInfoComparator(comparator)
+	}
+	if len(options.BalancingRequiredLabels) > 0 {
+		comparator = CreateRequiredLabelsNodeInfoComparator(comparator, options.BalancingRequiredLabels)
+	}
+	return comparator
+}
+
+// NewBalancingNodeGroupSetProcessor returns a BalancingNodeGroupSetProcessor using the comparator
+// returned by NewNodeInfoComparator.
+func NewBalancingNodeGroupSetProcessor(options config.AutoscalingOptions) *BalancingNodeGroupSetProcessor {
+	return &BalancingNodeGroupSetProcessor{
+		Comparator: NewNodeInfoComparator(options),
+	}
+}
+
+// CreateRequiredLabelsNodeInfoComparator returns a comparator that considers two nodes similar if
+// they are similar according to the given comparator and all the required labels are set to the
+// same value on both of them.
+func CreateRequiredLabelsNodeInfoComparator(comparator NodeInfoComparator, requiredLabels []string) NodeInfoComparator {
+	return func(n1, n2 *framework.NodeInfo) bool {
+		return areLabelsSame(n1, n2, requiredLabels) && comparator(n1, n2)
+	}
+}
+
+// CreateInstanceFamilyNodeInfoComparator returns a comparator that considers two nodes similar if
+// they are similar according to the given comparator, which is expected to ignore the instance
+// type labels, and their instance types are of the same family. Resources are still compared by
+// the given comparator, so instance types of the same family only match if they have the same size.
+func CreateInstanceFamilyNodeInfoComparator(comparator NodeInfoComparator) NodeInfoComparator {
+	return func(n1, n2 *framework.NodeInfo) bool {
+		return isInstanceFamilySame(n1, n2) && comparator(n1, n2)
+	}
+}
+
+func isInstanceFamilySame(n1, n2 *framework.NodeInfo) bool {
+	type1, type2 := instanceType(n1), instanceType(n2)
+	if type1 == type2 {
+		return true
+	}
+	family1, family2 := InstanceFamily(type1), InstanceFamily(type2)
+	if family1 != family2 {
+		klog.V(8).Infof("Instance families did not match. %s: %s (%s), %s: %s (%s)", n1.Node().Name, type1, family1, n2.Node().Name, type2, family2)
+		return false
+	}
+	return true
+}
+
+func instanceType(nodeInfo *framework.NodeInfo) string {
+	for _, label := range InstanceTypeLabels {
+		if instanceType, found := nodeInfo.Node().Labels[label]; found {
+			return instanceType
+		}
+	}
+	return ""
+}
+
+// InstanceFamily returns the family of an instance type: its series and generation, without its
+// size or processor and storage variants. For example, "m5.xlarge" and "m5a.xlarge" are both of the
+// "m5" family, "n2-standard-8" and "n2d-standard-8" of "n2-standard", "Standard_D4s_v3" and
+// "Standard_D4as_v3" of "Standard_D_v3". Instance types not following any of these naming schemes
+// are their own family.
+func InstanceFamily(instanceType string) string {
+	if match := awsInstanceTypeRegexp.FindStringSubmatch(instanceType); match != nil {
+		return match[1]
+	}
+	if match := gceInstanceTypeRegexp.FindStringSubmatch(instanceType); match != nil {
+		return match[1] + "-" + match[2]
+	}
+	if match := azureInstanceTypeRegexp.FindStringSubmatch(instanceType); match != nil {
+		return match[1] + match[2]
+	}
+	return instanceType
+}
+
+func withoutInstanceTypeLabels(labels []string) []string {
+	var result []string
+	for _, label := range labels {
+		isInstanceTypeLabel := false
+		for _, instanceTypeLabel := range InstanceTypeLabels {
+			if label == instanceTypeLabel {
+				isInstanceTypeLabel = true
+			}
+		}
+		if !isInstanceTypeLabel {
+			result = append(result, label)
+		}
+	}
+	return result
+}
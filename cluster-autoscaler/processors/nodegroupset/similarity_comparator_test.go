@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodegroupset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+func TestInstanceFamily(t *testing.T) {
+	for instanceType, family := range map[string]string{
+		"m5.xlarge":        "m5",
+		"m5a.xlarge":       "m5",
+		"c7gn.16xlarge":    "c7",
+		"u-6tb1.metal":     "u-6tb1.metal",
+		"n2-standard-8":    "n2-standard",
+		"n2d-standard-8":   "n2-standard",
+		"e2-medium":        "e2-medium",
+		"Standard_D4s_v3":  "Standard_D_v3",
+		"Standard_D4as_v3": "Standard_D_v3",
+		"Standard_A2":      "Standard_A",
+		"custom":           "custom",
+		"":                 "",
+	} {
+		assert.Equal(t, family, InstanceFamily(instanceType), instanceType)
+	}
+}
+
+func TestNewNodeInfoComparator(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		options     config.AutoscalingOptions
+		labels1     map[string]string
+		labels2     map[string]string
+		isSimilar   bool
+	}{
+		{
+			description: "different instance types",
+			labels1:     map[string]string{"node.kubernetes.io/instance-type": "m5.xlarge"},
+			labels2:     map[string]string{"node.kubernetes.io/instance-type": "m5a.xlarge"},
+			isSimilar:   false,
+		},
+		{
+			description: "same instance family",
+			options:     config.AutoscalingOptions{BalancingInstanceFamilyMatch: true},
+			labels1:     map[string]string{"node.kubernetes.io/instance-type": "m5.xlarge", "beta.kubernetes.io/instance-type": "m5.xlarge"},
+			labels2:     map[string]string{"node.kubernetes.io/instance-type": "m5a.xlarge", "beta.kubernetes.io/instance-type": "m5a.xlarge"},
+			isSimilar:   true,
+		},
+		{
+			description: "different instance families",
+			options:     config.AutoscalingOptions{BalancingInstanceFamilyMatch: true},
+			labels1:     map[string]string{"node.kubernetes.io/instance-type": "m5.xlarge"},
+			labels2:     map[string]string{"node.kubernetes.io/instance-type": "c5.xlarge"},
+			isSimilar:   false,
+		},
+		{
+			description: "same instance family, other labels differ",
+			options:     config.AutoscalingOptions{BalancingInstanceFamilyMatch: true},
+			labels1:     map[string]string{"node.kubernetes.io/instance-type": "m5.xlarge", "pool": "a"},
+			labels2:     map[string]string{"node.kubernetes.io/instance-type": "m5a.xlarge", "pool": "b"},
+			isSimilar:   false,
+		},
+		{
+			description: "same instance family, balancing labels",
+			options:     config.AutoscalingOptions{BalancingInstanceFamilyMatch: true, BalancingLabels: []string{"node.kubernetes.io/instance-type", "pool"}},
+			labels1:     map[string]string{"node.kubernetes.io/instance-type": "n2-standard-8", "pool": "a"},
+			labels2:     map[string]string{"node.kubernetes.io/instance-type": "n2d-standard-8", "pool": "a"},
+			isSimilar:   true,
+		},
+		{
+			description: "required label ignored by the cloud provider matches",
+			options:     config.AutoscalingOptions{CloudProviderName: cloudprovider.AwsProviderName, BalancingRequiredLabels: []string{"lifecycle"}},
+			labels1:     map[string]string{"lifecycle": "spot"},
+			labels2:     map[string]string{"lifecycle": "spot"},
+			isSimilar:   true,
+		},
+		{
+			description: "required label ignored by the cloud provider differs",
+			options:     config.AutoscalingOptions{CloudProviderName: cloudprovider.AwsProviderName, BalancingRequiredLabels: []string{"lifecycle"}},
+			labels1:     map[string]string{"lifecycle": "spot"},
+			labels2:     map[string]string{"lifecycle": "on-demand"},
+			isSimilar:   false,
+		},
+		{
+			description: "required label missing",
+			options:     config.AutoscalingOptions{BalancingRequiredLabels: []string{"pool"}},
+			labels1:     map[string]string{},
+			labels2:     map[string]string{},
+			isSimilar:   false,
+		},
+		{
+			description: "required and balancing labels",
+			options:     config.AutoscalingOptions{BalancingLabels: []string{"pool"}, BalancingRequiredLabels: []string{"team"}},
+			labels1:     map[string]string{"pool": "a", "team": "x"},
+			labels2:     map[string]string{"pool": "a", "team": "y"},
+			isSimilar:   false,
+		},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.options.NodeGroupSetRatios = config.NewDefaultNodeGroupDifferenceRatios()
+			comparator := NewBalancingNodeGroupSetProcessor(tc.options).Comparator
+			node1 := BuildTestNode("node1", 1000, 2000)
+			node2 := BuildTestNode("node2", 1000, 2000)
+			node1.ObjectMeta.Labels = tc.labels1
+			node2.ObjectMeta.Labels = tc.labels2
+			checkNodesSimilar(t, node1, node2, comparator, tc.isSimilar)
+		})
+	}
+}
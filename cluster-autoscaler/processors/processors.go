@@ -31,6 +31,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/processors/pods"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/scaledowncandidates"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/utilizationcalculator"
 )
 
 // AutoscalingProcessors are a set of customizable processors used for encapsulating
@@ -76,6 +77,8 @@ type AutoscalingProcessors struct {
 	AsyncNodeGroupStateChecker asyncnodegroups.AsyncNodeGroupStateChecker
 	// ScaleUpEnforcer can force scale up even if all pods are new or MaxNodesTotal was achieved.
 	ScaleUpEnforcer pods.ScaleUpEnforcer
+	// UtilizationCalculator calculates node utilization used to decide whether a node can be scaled down.
+	UtilizationCalculator utilizationcalculator.UtilizationCalculator
 }
 
 // DefaultProcessors returns default set of processors.
@@ -103,6 +106,7 @@ func DefaultProcessors(options config.AutoscalingOptions) *AutoscalingProcessors
 		ScaleDownCandidatesNotifier: scaledowncandidates.NewObserversList(),
 		ScaleStateNotifier:          nodegroupchange.NewNodeGroupChangeObserversList(),
 		ScaleUpEnforcer:             pods.NewDefaultScaleUpEnforcer(),
+		UtilizationCalculator:       utilizationcalculator.NewDefaultUtilizationCalculator(),
 	}
 }
 
@@ -121,4 +125,5 @@ func (ap *AutoscalingProcessors) CleanUp() {
 	ap.CustomResourcesProcessor.CleanUp()
 	ap.TemplateNodeInfoProvider.CleanUp()
 	ap.ActionableClusterProcessor.CleanUp()
+	ap.UtilizationCalculator.CleanUp()
 }
@@ -45,6 +45,8 @@ type ProvisioningRequestPodsInjector struct {
 	lastProvisioningRequestProcessTime time.Time
 	checkCapacityBatchProcessing       bool
 	checkCapacityProcessorInstance     string
+	// queueLastAdmissionTime is the last time a BestEffortQueue ProvisioningRequest was admitted, by namespace.
+	queueLastAdmissionTime map[string]time.Time
 }
 
 // IsAvailableForProvisioning checks if the provisioning request is the correct state for processing and provisioning has not been attempted recently.
@@ -114,13 +116,20 @@ func (p *ProvisioningRequestPodsInjector) GetPodsFromNextRequest() ([]*apiv1.Pod
 	if err != nil {
 		return nil, err
 	}
+	queuedProvReq := p.nextQueuedRequest(provReqs)
 	for _, pr := range provReqs {
 		if !p.isSupportedClass(pr) {
 			continue
 		}
 
-		// Inject pods if ProvReq wasn't scaled up before or it has Provisioned == False condition more than defaultRetryTime
-		if !p.IsAvailableForProvisioning(pr) {
+		if pr.Spec.ProvisioningClassName == v1.ProvisioningClassBestEffortQueue {
+			// BestEffortQueue ProvReqs are only picked in the queue order.
+			if pr != queuedProvReq {
+				continue
+			}
+			p.markAdmittedFromQueue(pr)
+		} else if !p.IsAvailableForProvisioning(pr) {
+			// Inject pods if ProvReq wasn't scaled up before or it has Provisioned == False condition more than defaultRetryTime
 			continue
 		}
 
@@ -211,6 +220,7 @@ func NewProvisioningRequestPodsInjector(kubeConfig *rest.Config, initialBackoffT
 		lastProvisioningRequestProcessTime: time.Now(),
 		checkCapacityBatchProcessing:       checkCapacityBatchProcessing,
 		checkCapacityProcessorInstance:     checkCapacityProcessorInstance,
+		queueLastAdmissionTime:             map[string]time.Time{},
 	}, nil
 }
 
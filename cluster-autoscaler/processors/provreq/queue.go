@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provreq
+
+import (
+	"sort"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/autoscaler/cluster-autoscaler/apis/provisioningrequest/autoscaling.x-k8s.io/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/provisioningrequest/provreqwrapper"
+)
+
+// namespaceQueue holds the waiting BestEffortQueue ProvisioningRequests from a single namespace, oldest first.
+type namespaceQueue struct {
+	namespace     string
+	lastAdmission time.Time
+	provReqs      []*provreqwrapper.ProvisioningRequest
+}
+
+// nextQueuedRequest returns the BestEffortQueue ProvisioningRequest which should be provisioned next, or nil if there is none.
+// ProvisioningRequests are admitted in FIFO order within a namespace, and a request waiting for a retry blocks the
+// requests created after it in the same namespace. Namespaces take turns, the one admitted least recently goes first.
+func (p *ProvisioningRequestPodsInjector) nextQueuedRequest(provReqs []*provreqwrapper.ProvisioningRequest) *provreqwrapper.ProvisioningRequest {
+	queues := map[string]*namespaceQueue{}
+	for _, pr := range provReqs {
+		if pr.Spec.ProvisioningClassName != v1.ProvisioningClassBestEffortQueue || !p.isSupportedClass(pr) {
+			continue
+		}
+		conditions := pr.Status.Conditions
+		if apimeta.IsStatusConditionTrue(conditions, v1.Failed) || apimeta.IsStatusConditionTrue(conditions, v1.Provisioned) {
+			continue
+		}
+		queue, found := queues[pr.Namespace]
+		if !found {
+			queue = &namespaceQueue{namespace: pr.Namespace, lastAdmission: p.queueLastAdmissionTime[pr.Namespace]}
+			queues[pr.Namespace] = queue
+		}
+		queue.provReqs = append(queue.provReqs, pr)
+	}
+
+	sortedQueues := make([]*namespaceQueue, 0, len(queues))
+	for _, queue := range queues {
+		sort.Slice(queue.provReqs, func(i, j int) bool {
+			return olderProvisioningRequest(queue.provReqs[i], queue.provReqs[j])
+		})
+		sortedQueues = append(sortedQueues, queue)
+	}
+	sort.Slice(sortedQueues, func(i, j int) bool {
+		if !sortedQueues[i].lastAdmission.Equal(sortedQueues[j].lastAdmission) {
+			return sortedQueues[i].lastAdmission.Before(sortedQueues[j].lastAdmission)
+		}
+		return olderProvisioningRequest(sortedQueues[i].provReqs[0], sortedQueues[j].provReqs[0])
+	})
+
+	for _, queue := range sortedQueues {
+		if head := queue.provReqs[0]; p.IsAvailableForProvisioning(head) {
+			return head
+		}
+	}
+	return nil
+}
+
+// markAdmittedFromQueue records that a ProvisioningRequest from the namespace was just admitted from the queue.
+func (p *ProvisioningRequestPodsInjector) markAdmittedFromQueue(pr *provreqwrapper.ProvisioningRequest) {
+	if p.queueLastAdmissionTime == nil {
+		p.queueLastAdmissionTime = map[string]time.Time{}
+	}
+	p.queueLastAdmissionTime[pr.Namespace] = p.clock.Now()
+}
+
+func olderProvisioningRequest(a, b *provreqwrapper.ProvisioningRequest) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Name < b.Name
+}
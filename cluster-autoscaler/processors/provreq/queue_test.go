@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provreq
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/autoscaler/cluster-autoscaler/apis/provisioningrequest/autoscaling.x-k8s.io/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/provisioningrequest/provreqwrapper"
+	clock "k8s.io/utils/clock/testing"
+)
+
+func TestNextQueuedRequest(t *testing.T) {
+	now := time.Now()
+	queuedProvReq := func(namespace, name string, created time.Time, conditions ...metav1.Condition) *provreqwrapper.ProvisioningRequest {
+		pr := provreqwrapper.BuildTestProvisioningRequest(namespace, name, "10", "100", "", 1, false, created, v1.ProvisioningClassBestEffortQueue)
+		pr.Status.Conditions = conditions
+		return pr
+	}
+	provisioned := metav1.Condition{Type: v1.Provisioned, Status: metav1.ConditionTrue, LastTransitionTime: metav1.NewTime(now)}
+	notProvisionedRecently := metav1.Condition{Type: v1.Provisioned, Status: metav1.ConditionFalse, LastTransitionTime: metav1.NewTime(now)}
+	notProvisionedLongAgo := metav1.Condition{Type: v1.Provisioned, Status: metav1.ConditionFalse, LastTransitionTime: metav1.NewTime(now.Add(-time.Hour))}
+
+	testCases := []struct {
+		name           string
+		provReqs       []*provreqwrapper.ProvisioningRequest
+		lastAdmissions map[string]time.Time
+		want           string
+	}{
+		{
+			name:     "no queued requests",
+			provReqs: []*provreqwrapper.ProvisioningRequest{queuedProvReq("a", "a1", now.Add(-3*time.Minute), provisioned)},
+		},
+		{
+			name: "oldest request first",
+			provReqs: []*provreqwrapper.ProvisioningRequest{
+				queuedProvReq("a", "a2", now.Add(-2*time.Minute)),
+				queuedProvReq("b", "b1", now.Add(-1*time.Minute)),
+				queuedProvReq("a", "a1", now.Add(-3*time.Minute)),
+			},
+			want: "a1",
+		},
+		{
+			name: "namespace admitted least recently goes first",
+			provReqs: []*provreqwrapper.ProvisioningRequest{
+				queuedProvReq("a", "a1", now.Add(-3*time.Minute), provisioned),
+				queuedProvReq("a", "a2", now.Add(-2*time.Minute)),
+				queuedProvReq("b", "b1", now.Add(-1*time.Minute)),
+			},
+			lastAdmissions: map[string]time.Time{"a": now},
+			want:           "b1",
+		},
+		{
+			name: "request waiting for retry blocks its namespace",
+			provReqs: []*provreqwrapper.ProvisioningRequest{
+				queuedProvReq("a", "a1", now.Add(-3*time.Minute), notProvisionedRecently),
+				queuedProvReq("a", "a2", now.Add(-2*time.Minute)),
+			},
+		},
+		{
+			name: "request waiting for retry doesn't block other namespaces",
+			provReqs: []*provreqwrapper.ProvisioningRequest{
+				queuedProvReq("a", "a1", now.Add(-3*time.Minute), notProvisionedRecently),
+				queuedProvReq("a", "a2", now.Add(-2*time.Minute)),
+				queuedProvReq("b", "b1", now.Add(-1*time.Minute)),
+			},
+			want: "b1",
+		},
+		{
+			name: "request is retried before newer requests from its namespace",
+			provReqs: []*provreqwrapper.ProvisioningRequest{
+				queuedProvReq("a", "a1", now.Add(-3*time.Minute), notProvisionedLongAgo),
+				queuedProvReq("a", "a2", now.Add(-2*time.Minute)),
+			},
+			want: "a1",
+		},
+		{
+			name: "other provisioning classes are ignored",
+			provReqs: []*provreqwrapper.ProvisioningRequest{
+				provreqwrapper.BuildTestProvisioningRequest("a", "atomic", "10", "100", "", 1, false, now.Add(-time.Hour), v1.ProvisioningClassBestEffortAtomicScaleUp),
+				queuedProvReq("b", "b1", now.Add(-1*time.Minute)),
+			},
+			want: "b1",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			injector := NewFakePodsInjector(nil, clock.NewFakePassiveClock(now))
+			injector.queueLastAdmissionTime = tc.lastAdmissions
+			pr := injector.nextQueuedRequest(tc.provReqs)
+			if tc.want == "" {
+				assert.Nil(t, pr)
+				return
+			}
+			if assert.NotNil(t, pr) {
+				assert.Equal(t, tc.want, pr.Name)
+			}
+		})
+	}
+}
+
+func TestNextQueuedRequestTakesTurns(t *testing.T) {
+	now := time.Now()
+	var provReqs []*provreqwrapper.ProvisioningRequest
+	for i, name := range []string{"a1", "a2", "a3", "b1", "c1", "b2"} {
+		provReqs = append(provReqs, provreqwrapper.BuildTestProvisioningRequest(name[:1], name, "10", "100", "", 1, false, now.Add(time.Duration(i)*time.Minute), v1.ProvisioningClassBestEffortQueue))
+	}
+	fakeClock := clock.NewFakePassiveClock(now)
+	injector := NewFakePodsInjector(nil, fakeClock)
+
+	var got []string
+	for pr := injector.nextQueuedRequest(provReqs); pr != nil; pr = injector.nextQueuedRequest(provReqs) {
+		got = append(got, pr.Name)
+		injector.markAdmittedFromQueue(pr)
+		pr.Status.Conditions = []metav1.Condition{{Type: v1.Provisioned, Status: metav1.ConditionTrue, LastTransitionTime: metav1.NewTime(fakeClock.Now())}}
+		fakeClock.SetTime(fakeClock.Now().Add(time.Second))
+	}
+	assert.Equal(t, []string{"a1", "b1", "c1", "a2", "b2", "a3"}, got)
+}
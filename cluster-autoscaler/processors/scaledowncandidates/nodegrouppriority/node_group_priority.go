@@ -0,0 +1,165 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodegrouppriority
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+
+	apiv1 "k8s.io/api/core/v1"
+	kube_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	v1lister "k8s.io/client-go/listers/core/v1"
+	klog "k8s.io/klog/v2"
+)
+
+// ConfigMapKey is the key of the ConfigMap holding the node group priorities.
+const ConfigMapKey = "priorities"
+
+type priority struct {
+	value   int
+	regexps []*regexp.Regexp
+}
+
+// NodeGroupPriorityComparer is a scaledowncandidates.CandidatesComparer preferring to scale down
+// nodes of node groups with a higher priority, e.g. spot node groups before on-demand ones. Priorities
+// are configured in a ConfigMap, in the format used by the priority expander: a map from priorities to
+// lists of regular expressions matching node group ids. A node group matching expressions of several
+// priorities gets the highest one, nodes of node groups matching no expression are scaled down last.
+type NodeGroupPriorityComparer struct {
+	configMapName   string
+	configMapLister v1lister.ConfigMapNamespaceLister
+	// resourceVersion is the version of the last ConfigMap parsed.
+	resourceVersion string
+	// priorities are sorted from the highest to the lowest.
+	priorities []priority
+	// nodePriorities are the priorities of the scale down candidates, by node name.
+	nodePriorities map[string]int
+}
+
+// NewNodeGroupPriorityComparer returns a NodeGroupPriorityComparer reading priorities from the ConfigMap
+// with the given name.
+func NewNodeGroupPriorityComparer(configMapLister v1lister.ConfigMapNamespaceLister, configMapName string) *NodeGroupPriorityComparer {
+	return &NodeGroupPriorityComparer{
+		configMapName:   configMapName,
+		configMapLister: configMapLister,
+		nodePriorities:  make(map[string]int),
+	}
+}
+
+// Refresh reloads the priorities if the ConfigMap changed and computes the priorities of the candidates.
+// An invalid ConfigMap is reported and ignored, the last valid priorities are kept.
+func (c *NodeGroupPriorityComparer) Refresh(ctx *context.AutoscalingContext, nodes []*apiv1.Node) {
+	c.reloadConfigMap(ctx)
+	c.nodePriorities = make(map[string]int, len(nodes))
+	if len(c.priorities) == 0 {
+		return
+	}
+	for _, node := range nodes {
+		nodeGroup, err := ctx.CloudProvider.NodeGroupForNode(node)
+		if err != nil {
+			klog.Warningf("Failed to get node group of %s: %v", node.Name, err)
+			continue
+		}
+		if nodeGroup == nil || reflect.ValueOf(nodeGroup).IsNil() {
+			continue
+		}
+		if value, found := c.priorityOf(nodeGroup.Id()); found {
+			c.nodePriorities[node.Name] = value
+		}
+	}
+}
+
+// ScaleDownEarlierThan returns true if node1 belongs to a node group with a higher priority than node2.
+func (c *NodeGroupPriorityComparer) ScaleDownEarlierThan(node1, node2 *apiv1.Node) bool {
+	priority1, found1 := c.nodePriorities[node1.Name]
+	priority2, found2 := c.nodePriorities[node2.Name]
+	if found1 != found2 {
+		return found1
+	}
+	return priority1 > priority2
+}
+
+func (c *NodeGroupPriorityComparer) priorityOf(nodeGroupId string) (int, bool) {
+	for _, p := range c.priorities {
+		for _, re := range p.regexps {
+			if re.MatchString(nodeGroupId) {
+				return p.value, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func (c *NodeGroupPriorityComparer) reloadConfigMap(ctx *context.AutoscalingContext) {
+	cm, err := c.configMapLister.Get(c.configMapName)
+	if kube_errors.IsNotFound(err) {
+		if c.priorities != nil {
+			klog.Warningf("Scale down node group priority config map %s not found, ignoring node group priorities", c.configMapName)
+		}
+		c.priorities = nil
+		c.resourceVersion = ""
+		return
+	}
+	if err != nil {
+		klog.Warningf("Failed to get scale down node group priority config map %s: %v", c.configMapName, err)
+		return
+	}
+	if cm.ResourceVersion == c.resourceVersion {
+		return
+	}
+	c.resourceVersion = cm.ResourceVersion
+	priorities, err := parsePriorities(cm.Data[ConfigMapKey])
+	if err != nil {
+		msg := fmt.Sprintf("Wrong configuration of scale down node group priorities in config map %s: %v. Ignoring update.", c.configMapName, err)
+		klog.Warning(msg)
+		ctx.Recorder.Event(cm, apiv1.EventTypeWarning, "ScaleDownPriorityConfigMapInvalid", msg)
+		return
+	}
+	klog.V(1).Infof("Loaded scale down node group priorities from config map %s", c.configMapName)
+	c.priorities = priorities
+}
+
+func parsePriorities(prioritiesYAML string) ([]priority, error) {
+	if prioritiesYAML == "" {
+		return nil, fmt.Errorf("%s key is missing or empty", ConfigMapKey)
+	}
+	var config map[int][]string
+	if err := yaml.Unmarshal([]byte(prioritiesYAML), &config); err != nil {
+		return nil, fmt.Errorf("can't parse YAML with priorities: %v", err)
+	}
+	var priorities []priority
+	for value, expressions := range config {
+		p := priority{value: value}
+		for _, expression := range expressions {
+			re, err := regexp.Compile(expression)
+			if err != nil {
+				return nil, fmt.Errorf("can't compile regexp rule for priority %d and rule %s: %v", value, expression, err)
+			}
+			p.regexps = append(p.regexps, re)
+		}
+		priorities = append(priorities, p)
+	}
+	sort.Slice(priorities, func(i, j int) bool {
+		return priorities[i].value > priorities[j].value
+	})
+	return priorities, nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodegrouppriority
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	. "k8s.io/autoscaler/cluster-autoscaler/core/test"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	v1lister "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	testNamespace     = "kube-system"
+	testConfigMapName = "scale-down-priorities"
+)
+
+func buildConfigMap(resourceVersion, priorities string) *apiv1.ConfigMap {
+	return &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       testNamespace,
+			Name:            testConfigMapName,
+			ResourceVersion: resourceVersion,
+		},
+		Data: map[string]string{ConfigMapKey: priorities},
+	}
+}
+
+func TestNodeGroupPriorityComparer(t *testing.T) {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	var nodes []*apiv1.Node
+	for _, n := range []struct{ name, nodeGroup string }{
+		{"on-demand-1", "on-demand"},
+		{"spot-1", "spot-a"},
+		{"other-1", "other"},
+		{"spot-2", "spot-b"},
+		{"batch-1", "batch"},
+	} {
+		if provider.GetNodeGroup(n.nodeGroup) == nil {
+			provider.AddNodeGroup(n.nodeGroup, 0, 10, 1)
+		}
+		node := BuildTestNode(n.name, 1000, 1000)
+		provider.AddNode(n.nodeGroup, node)
+		nodes = append(nodes, node)
+	}
+	ctx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{}, nil, nil, provider, nil, nil)
+	assert.NoError(t, err)
+
+	store := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	lister := v1lister.NewConfigMapLister(store).ConfigMaps(testNamespace)
+	comparer := NewNodeGroupPriorityComparer(lister, testConfigMapName)
+
+	order := func() []string {
+		candidates := append([]*apiv1.Node{}, nodes...)
+		comparer.Refresh(&ctx, candidates)
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return comparer.ScaleDownEarlierThan(candidates[i], candidates[j])
+		})
+		var names []string
+		for _, node := range candidates {
+			names = append(names, node.Name)
+		}
+		return names
+	}
+
+	// Without the config map, the order is kept.
+	assert.Equal(t, []string{"on-demand-1", "spot-1", "other-1", "spot-2", "batch-1"}, order())
+
+	assert.NoError(t, store.Add(buildConfigMap("1", `
+30:
+  - spot-.*
+10:
+  - on-demand
+  - batch
+20:
+  - batch
+`)))
+	assert.Equal(t, []string{"spot-1", "spot-2", "batch-1", "on-demand-1", "other-1"}, order())
+
+	// Invalid updates are ignored.
+	assert.NoError(t, store.Update(buildConfigMap("2", "30: [\"(\"]")))
+	assert.Equal(t, []string{"spot-1", "spot-2", "batch-1", "on-demand-1", "other-1"}, order())
+
+	assert.NoError(t, store.Update(buildConfigMap("3", "10: [on-demand]")))
+	assert.Equal(t, []string{"on-demand-1", "spot-1", "other-1", "spot-2", "batch-1"}, order())
+
+	assert.NoError(t, store.Delete(buildConfigMap("3", "")))
+	assert.Equal(t, []string{"on-demand-1", "spot-1", "other-1", "spot-2", "batch-1"}, order())
+}
@@ -45,6 +45,11 @@ func (p *ScaleDownCandidatesSortingProcessor) GetScaleDownCandidates(ctx *contex
 	if err != nil {
 		return candidates, err
 	}
+	for _, comparer := range p.sorting {
+		if refreshable, ok := comparer.(RefreshableCandidatesComparer); ok {
+			refreshable.Refresh(ctx, candidates)
+		}
+	}
 	n := NodeSorter{nodes: candidates, processors: p.sorting}
 	return n.Sort(), err
 }
@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/utilizationcalculator"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// LowestUtilizationFirst removes nodes with the lowest utilization first.
+	LowestUtilizationFirst = "lowest-utilization"
+	// OldestNodeFirst removes the oldest nodes first.
+	OldestNodeFirst = "oldest-node"
+	// MostExpensiveFirst removes the most expensive nodes first. Requires cloud provider pricing.
+	MostExpensiveFirst = "most-expensive"
+	// SpotFirst removes spot and preemptible nodes first.
+	SpotFirst = "spot-first"
+)
+
+// AvailableStrategies lists the names of all scale down candidates sorting strategies.
+var AvailableStrategies = []string{LowestUtilizationFirst, OldestNodeFirst, MostExpensiveFirst, SpotFirst}
+
+// spotLabels are node labels, with their values, used by cloud providers to mark spot and preemptible nodes.
+var spotLabels = map[string]string{
+	"eks.amazonaws.com/capacityType":        "SPOT",
+	"karpenter.sh/capacity-type":            "spot",
+	"cloud.google.com/gke-spot":             "true",
+	"cloud.google.com/gke-preemptible":      "true",
+	"kubernetes.azure.com/scalesetpriority": "spot",
+}
+
+// sortKey returns a key of a node, nodes with lower keys are scaled down earlier.
+type sortKey func(ctx *context.AutoscalingContext, node *apiv1.Node, now time.Time) float64
+
+// ScaleDownCandidatesSorter is a scaledowncandidates.CandidatesComparer ordering scale down candidates
+// with a list of strategies. Strategies are applied in order, a strategy is used only if the previous
+// ones consider the nodes equal.
+type ScaleDownCandidatesSorter struct {
+	keyFuncs []sortKey
+	keys     []map[string]float64
+}
+
+// NewScaleDownCandidatesSorter returns a sorter applying strategies with given names.
+// Node utilization is computed with the given utilization calculator, the same one
+// used to decide whether nodes are underutilized.
+func NewScaleDownCandidatesSorter(names []string, utilizationCalculator utilizationcalculator.UtilizationCalculator) (*ScaleDownCandidatesSorter, error) {
+	s := &ScaleDownCandidatesSorter{}
+	for _, name := range names {
+		switch name {
+		case LowestUtilizationFirst:
+			s.keyFuncs = append(s.keyFuncs, utilizationKey(utilizationCalculator))
+		case OldestNodeFirst:
+			s.keyFuncs = append(s.keyFuncs, creationTimeKey)
+		case MostExpensiveFirst:
+			s.keyFuncs = append(s.keyFuncs, negativePriceKey)
+		case SpotFirst:
+			s.keyFuncs = append(s.keyFuncs, spotKey)
+		default:
+			return nil, fmt.Errorf("unknown scale down candidates sorting strategy %q, available strategies: %s", name, strings.Join(AvailableStrategies, ","))
+		}
+	}
+	return s, nil
+}
+
+// Refresh computes keys of the candidates, it's called before candidates are sorted.
+func (s *ScaleDownCandidatesSorter) Refresh(ctx *context.AutoscalingContext, nodes []*apiv1.Node) {
+	now := time.Now()
+	s.keys = make([]map[string]float64, len(s.keyFuncs))
+	for i, keyFunc := range s.keyFuncs {
+		s.keys[i] = make(map[string]float64, len(nodes))
+		for _, node := range nodes {
+			s.keys[i][node.Name] = keyFunc(ctx, node, now)
+		}
+	}
+}
+
+// ScaleDownEarlierThan returns true if node1 should be scaled down earlier than node2 according to
+// the first strategy that doesn't consider them equal.
+func (s *ScaleDownCandidatesSorter) ScaleDownEarlierThan(node1, node2 *apiv1.Node) bool {
+	for _, keys := range s.keys {
+		key1, key2 := keys[node1.Name], keys[node2.Name]
+		if key1 != key2 {
+			return key1 < key2
+		}
+	}
+	return false
+}
+
+func utilizationKey(utilizationCalculator utilizationcalculator.UtilizationCalculator) sortKey {
+	return func(ctx *context.AutoscalingContext, node *apiv1.Node, now time.Time) float64 {
+		nodeInfo, err := ctx.ClusterSnapshot.GetNodeInfo(node.Name)
+		if err != nil {
+			klog.Warningf("Failed to get node info for %s: %v", node.Name, err)
+			return 1
+		}
+		utilInfo, err := utilizationCalculator.Calculate(ctx, nodeInfo, ctx.NodeGroupDefaults.IgnoreDaemonSetsUtilization, now)
+		if err != nil {
+			klog.Warningf("Failed to calculate utilization for %s: %v", node.Name, err)
+			return 1
+		}
+		return utilInfo.Utilization
+	}
+}
+
+func creationTimeKey(_ *context.AutoscalingContext, node *apiv1.Node, _ time.Time) float64 {
+	return float64(node.CreationTimestamp.Unix())
+}
+
+func negativePriceKey(ctx *context.AutoscalingContext, node *apiv1.Node, now time.Time) float64 {
+	pricing, pricingErr := ctx.CloudProvider.Pricing()
+	if pricingErr != nil {
+		return 0
+	}
+	price, err := pricing.NodePrice(node, now, now.Add(time.Hour))
+	if err != nil {
+		klog.Warningf("Failed to get price of %s: %v", node.Name, err)
+		return 0
+	}
+	return -price
+}
+
+func spotKey(_ *context.AutoscalingContext, node *apiv1.Node, _ time.Time) float64 {
+	for label, value := range spotLabels {
+		if strings.EqualFold(node.Labels[label], value) {
+			return 0
+		}
+	}
+	return 1
+}
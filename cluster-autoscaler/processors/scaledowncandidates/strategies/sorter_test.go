@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package strategies
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	. "k8s.io/autoscaler/cluster-autoscaler/core/test"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/utilizationcalculator"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/utilization"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+type fakePricingModel struct {
+	prices map[string]float64
+}
+
+func (m *fakePricingModel) NodePrice(node *apiv1.Node, _, _ time.Time) (float64, error) {
+	return m.prices[node.Name], nil
+}
+
+func (m *fakePricingModel) PodPrice(_ *apiv1.Pod, _, _ time.Time) (float64, error) {
+	return 0, nil
+}
+
+func TestScaleDownCandidatesSorter(t *testing.T) {
+	now := time.Now()
+	n1 := BuildTestNode("n1", 1000, 1000)
+	n1.CreationTimestamp = metav1.NewTime(now.Add(-1 * time.Hour))
+	n2 := BuildTestNode("n2", 1000, 1000)
+	n2.CreationTimestamp = metav1.NewTime(now.Add(-3 * time.Hour))
+	n2.Labels = map[string]string{"cloud.google.com/gke-spot": "true"}
+	n3 := BuildTestNode("n3", 1000, 1000)
+	n3.CreationTimestamp = metav1.NewTime(now.Add(-2 * time.Hour))
+	n3.Labels = map[string]string{"eks.amazonaws.com/capacityType": "SPOT"}
+	nodes := []*apiv1.Node{n1, n2, n3}
+
+	p1 := BuildTestPod("p1", 100, 0, WithNodeName(n1.Name))
+	p2 := BuildTestPod("p2", 500, 0, WithNodeName(n2.Name))
+	p3 := BuildTestPod("p3", 300, 0, WithNodeName(n3.Name))
+
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.SetPricingModel(&fakePricingModel{prices: map[string]float64{"n1": 1, "n2": 3, "n3": 2}})
+	ctx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{}, nil, nil, provider, nil, nil)
+	assert.NoError(t, err)
+	clustersnapshot.InitializeClusterSnapshotOrDie(t, ctx.ClusterSnapshot, nodes, []*apiv1.Pod{p1, p2, p3})
+
+	testCases := []struct {
+		name       string
+		strategies []string
+		wantOrder  []string
+	}{
+		{
+			name:       "lowest utilization first",
+			strategies: []string{LowestUtilizationFirst},
+			wantOrder:  []string{"n1", "n3", "n2"},
+		},
+		{
+			name:       "oldest node first",
+			strategies: []string{OldestNodeFirst},
+			wantOrder:  []string{"n2", "n3", "n1"},
+		},
+		{
+			name:       "most expensive first",
+			strategies: []string{MostExpensiveFirst},
+			wantOrder:  []string{"n2", "n3", "n1"},
+		},
+		{
+			name:       "spot first, then lowest utilization",
+			strategies: []string{SpotFirst, LowestUtilizationFirst},
+			wantOrder:  []string{"n3", "n2", "n1"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sorter, err := NewScaleDownCandidatesSorter(tc.strategies, utilizationcalculator.NewDefaultUtilizationCalculator())
+			assert.NoError(t, err)
+			sorter.Refresh(&ctx, nodes)
+
+			sorted := append([]*apiv1.Node{}, nodes...)
+			sort.SliceStable(sorted, func(i, j int) bool {
+				return sorter.ScaleDownEarlierThan(sorted[i], sorted[j])
+			})
+			var gotOrder []string
+			for _, node := range sorted {
+				gotOrder = append(gotOrder, node.Name)
+			}
+			assert.Equal(t, tc.wantOrder, gotOrder)
+		})
+	}
+}
+
+type fakeUtilizationCalculator struct {
+	utilization map[string]float64
+}
+
+func (c *fakeUtilizationCalculator) Calculate(_ *context.AutoscalingContext, nodeInfo *framework.NodeInfo, _ bool, _ time.Time) (utilization.Info, error) {
+	return utilization.Info{Utilization: c.utilization[nodeInfo.Node().Name]}, nil
+}
+
+func (c *fakeUtilizationCalculator) CleanUp() {
+}
+
+func TestScaleDownCandidatesSorterUtilizationCalculator(t *testing.T) {
+	n1 := BuildTestNode("n1", 1000, 1000)
+	n2 := BuildTestNode("n2", 1000, 1000)
+	nodes := []*apiv1.Node{n1, n2}
+	ctx, err := NewScaleTestAutoscalingContext(config.AutoscalingOptions{}, nil, nil, testprovider.NewTestCloudProvider(nil, nil), nil, nil)
+	assert.NoError(t, err)
+	clustersnapshot.InitializeClusterSnapshotOrDie(t, ctx.ClusterSnapshot, nodes, nil)
+
+	sorter, err := NewScaleDownCandidatesSorter([]string{LowestUtilizationFirst}, &fakeUtilizationCalculator{utilization: map[string]float64{"n1": 0.8, "n2": 0.2}})
+	assert.NoError(t, err)
+	sorter.Refresh(&ctx, nodes)
+	assert.True(t, sorter.ScaleDownEarlierThan(n2, n1))
+	assert.False(t, sorter.ScaleDownEarlierThan(n1, n2))
+}
+
+func TestNewScaleDownCandidatesSorterUnknownStrategy(t *testing.T) {
+	_, err := NewScaleDownCandidatesSorter([]string{OldestNodeFirst, "newest-node"}, utilizationcalculator.NewDefaultUtilizationCalculator())
+	assert.Error(t, err)
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalingdecision
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	scaledownstatus "k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+)
+
+// maxPodsPerDecision caps the pods listed in a decision, keeping objects small in large scale-ups.
+const maxPodsPerDecision = 100
+
+// recorder builds decisions of a loop and records them in the store.
+type recorder struct {
+	store *Store
+	// trace, if set, links the decisions to the decision log records of the same loop.
+	trace *status.DecisionTrace
+	now   func() time.Time
+}
+
+func (r *recorder) traceId() string {
+	if r.trace == nil {
+		return ""
+	}
+	return r.trace.Id()
+}
+
+func (r *recorder) record(decisions []ScalingDecision) {
+	now := r.now()
+	for i := range decisions {
+		decisions[i].Time = metav1.NewTime(now)
+		if decisions[i].TraceID == "" {
+			decisions[i].TraceID = r.traceId()
+		}
+	}
+	r.store.Record(decisions, now)
+}
+
+// ScaleUpStatusProcessor records a ScalingDecision for each node group scaled up,
+// or failed to be scaled up, along with the pods which triggered the scale-up.
+type ScaleUpStatusProcessor struct {
+	recorder
+	wrapped status.ScaleUpStatusProcessor
+}
+
+// NewScaleUpStatusProcessor creates a new instance of ScaleUpStatusProcessor.
+func NewScaleUpStatusProcessor(wrapped status.ScaleUpStatusProcessor, store *Store, trace *status.DecisionTrace) *ScaleUpStatusProcessor {
+	return &ScaleUpStatusProcessor{
+		recorder: recorder{store: store, trace: trace, now: time.Now},
+		wrapped:  wrapped,
+	}
+}
+
+// Process records the scale-up decisions of the loop.
+func (p *ScaleUpStatusProcessor) Process(context *context.AutoscalingContext, scaleUpStatus *status.ScaleUpStatus) {
+	if p.wrapped != nil {
+		p.wrapped.Process(context, scaleUpStatus)
+	}
+	triggeringPods := podNames(scaleUpStatus.PodsTriggeredScaleUp)
+	reason := fmt.Sprintf("%d pending pods fit the node group", len(scaleUpStatus.PodsTriggeredScaleUp))
+	var decisions []ScalingDecision
+	if scaleUpStatus.Result == status.ScaleUpSuccessful {
+		for _, info := range scaleUpStatus.ScaleUpInfos {
+			decisions = append(decisions, ScalingDecision{
+				Type:               ScaleUp,
+				NodeGroup:          info.Group.Id(),
+				Result:             Successful,
+				Reason:             reason,
+				CurrentSize:        info.CurrentSize,
+				NewSize:            info.NewSize,
+				TriggeringPods:     triggeringPods,
+				TriggeringPodCount: len(scaleUpStatus.PodsTriggeredScaleUp),
+			})
+		}
+	}
+	if scaleUpStatus.Result == status.ScaleUpError && scaleUpStatus.ScaleUpError != nil {
+		for _, nodeGroup := range scaleUpStatus.FailedResizeNodeGroups {
+			decisions = append(decisions, ScalingDecision{
+				Type:               ScaleUp,
+				NodeGroup:          nodeGroup.Id(),
+				Result:             Failed,
+				Reason:             reason,
+				Message:            (*scaleUpStatus.ScaleUpError).Error(),
+				TriggeringPods:     triggeringPods,
+				TriggeringPodCount: len(scaleUpStatus.PodsTriggeredScaleUp),
+			})
+		}
+	}
+	p.record(decisions)
+}
+
+// CleanUp stops the store and cleans up the wrapped processor.
+func (p *ScaleUpStatusProcessor) CleanUp() {
+	p.store.Stop()
+	if p.wrapped != nil {
+		p.wrapped.CleanUp()
+	}
+}
+
+// ScaleDownStatusProcessor records a ScalingDecision for each node whose deletion
+// completed or failed, along with the pods evicted from it. Deletions outlive the
+// loop which started them, so nodes are kept pending until their deletion result
+// is reported.
+type ScaleDownStatusProcessor struct {
+	recorder
+	wrapped status.ScaleDownStatusProcessor
+	// pending maps names of the nodes being deleted to their decision, without a result yet.
+	pending map[string]pendingDeletion
+}
+
+type pendingDeletion struct {
+	decision ScalingDecision
+	started  time.Time
+}
+
+// NewScaleDownStatusProcessor creates a new instance of ScaleDownStatusProcessor.
+func NewScaleDownStatusProcessor(wrapped status.ScaleDownStatusProcessor, store *Store, trace *status.DecisionTrace) *ScaleDownStatusProcessor {
+	return &ScaleDownStatusProcessor{
+		recorder: recorder{store: store, trace: trace, now: time.Now},
+		wrapped:  wrapped,
+		pending:  make(map[string]pendingDeletion),
+	}
+}
+
+// Process records the scale-down decisions whose deletion result is reported in the loop.
+func (p *ScaleDownStatusProcessor) Process(context *context.AutoscalingContext, scaleDownStatus *scaledownstatus.ScaleDownStatus) {
+	if p.wrapped != nil {
+		p.wrapped.Process(context, scaleDownStatus)
+	}
+	now := p.now()
+	for _, sdNode := range scaleDownStatus.ScaledDownNodes {
+		reason := "Node was empty"
+		if len(sdNode.EvictedPods) > 0 {
+			reason = fmt.Sprintf("Node was underutilized, with %s utilization %.2f, and its pods fit other nodes", sdNode.UtilInfo.ResourceName, sdNode.UtilInfo.Utilization)
+		}
+		p.pending[sdNode.Node.Name] = pendingDeletion{
+			decision: ScalingDecision{
+				Type:            ScaleDown,
+				TraceID:         p.traceId(),
+				NodeGroup:       nodeGroupIdOrEmpty(sdNode.NodeGroup),
+				Reason:          reason,
+				Node:            sdNode.Node.Name,
+				EvictedPods:     podNames(sdNode.EvictedPods),
+				EvictedPodCount: len(sdNode.EvictedPods),
+			},
+			started: now,
+		}
+	}
+	var decisions []ScalingDecision
+	for nodeName, result := range scaleDownStatus.NodeDeleteResults {
+		deletion, found := p.pending[nodeName]
+		if !found {
+			continue
+		}
+		delete(p.pending, nodeName)
+		decision := deletion.decision
+		decision.Result = Successful
+		if result.ResultType != scaledownstatus.NodeDeleteOk {
+			decision.Result = Failed
+			if result.Err != nil {
+				decision.Message = result.Err.Error()
+			}
+		}
+		decisions = append(decisions, decision)
+	}
+	sort.Slice(decisions, func(i, j int) bool { return decisions[i].Node < decisions[j].Node })
+	// Deletions whose result is never reported, e.g. of nodes removed by someone else, would be
+	// pruned from the history by now anyway.
+	for nodeName, deletion := range p.pending {
+		if now.Sub(deletion.started) > p.store.maxAge {
+			delete(p.pending, nodeName)
+		}
+	}
+	p.record(decisions)
+}
+
+// CleanUp stops the store and cleans up the wrapped processor.
+func (p *ScaleDownStatusProcessor) CleanUp() {
+	p.store.Stop()
+	if p.wrapped != nil {
+		p.wrapped.CleanUp()
+	}
+}
+
+// podNames returns the names of up to maxPodsPerDecision pods, as namespace/name.
+func podNames(pods []*apiv1.Pod) []string {
+	if len(pods) > maxPodsPerDecision {
+		pods = pods[:maxPodsPerDecision]
+	}
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, pod.Namespace+"/"+pod.Name)
+	}
+	return names
+}
+
+func nodeGroupIdOrEmpty(nodeGroup cloudprovider.NodeGroup) string {
+	if nodeGroup == nil || reflect.ValueOf(nodeGroup).IsNil() {
+		return ""
+	}
+	return nodeGroup.Id()
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalingdecision
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	ca_context "k8s.io/autoscaler/cluster-autoscaler/context"
+	scaledownstatus "k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupset"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/utilization"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/errors"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+)
+
+func newFakeStore(t *testing.T, maxCount int, maxAge time.Duration) *Store {
+	client := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		Resource: "ScalingDecisionList",
+	})
+	s, err := newStore(client, "kube-system", maxCount, maxAge, time.Minute)
+	assert.NoError(t, err)
+	t.Cleanup(s.Stop)
+	return s
+}
+
+// waitForCache waits until the informer cache of the store holds the given number of decisions.
+func waitForCache(t *testing.T, s *Store, count int) {
+	assert.Eventually(t, func() bool { return len(s.informer.GetStore().List()) == count }, 5*time.Second, 10*time.Millisecond)
+}
+
+// recordedDecisions returns the specs of the ScalingDecision objects of the store by name.
+func recordedDecisions(t *testing.T, s *Store) map[string]ScalingDecision {
+	list, err := s.client.Resource(Resource).Namespace(s.namespace).List(context.TODO(), metav1.ListOptions{})
+	assert.NoError(t, err)
+	decisions := map[string]ScalingDecision{}
+	for _, obj := range list.Items {
+		specMap, _, err := unstructured.NestedMap(obj.Object, "spec")
+		assert.NoError(t, err)
+		var decision ScalingDecision
+		assert.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(specMap, &decision))
+		decisions[obj.GetName()] = decision
+	}
+	return decisions
+}
+
+func names(decisions map[string]ScalingDecision) []string {
+	var result []string
+	for name := range decisions {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func TestStoreRetention(t *testing.T) {
+	start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	s := newFakeStore(t, 3, time.Hour)
+	decisionAt := func(at time.Time) ScalingDecision {
+		return ScalingDecision{Type: ScaleDown, Time: metav1.NewTime(at), Result: Successful, Node: "n"}
+	}
+
+	s.Record([]ScalingDecision{decisionAt(start), decisionAt(start)}, start)
+	first := fmt.Sprintf("scaledown-%d-", start.UnixNano())
+	assert.Equal(t, []string{first + "0", first + "1"}, names(recordedDecisions(t, s)))
+	waitForCache(t, s, 2)
+
+	// The oldest decisions beyond maxCount, counting the new ones, are deleted.
+	second := start.Add(time.Minute)
+	s.Record([]ScalingDecision{decisionAt(second), decisionAt(second)}, second)
+	secondPrefix := fmt.Sprintf("scaledown-%d-", second.UnixNano())
+	assert.Equal(t, []string{first + "1", secondPrefix + "0", secondPrefix + "1"}, names(recordedDecisions(t, s)))
+	waitForCache(t, s, 3)
+
+	// Decisions younger than maxAge are kept.
+	lastPrune := start.Add(55 * time.Minute)
+	s.Record(nil, lastPrune)
+	assert.Len(t, recordedDecisions(t, s), 3)
+
+	// Without new decisions, the history isn't pruned until pruneInterval passes.
+	s.Record(nil, lastPrune.Add(pruneInterval-time.Second))
+	assert.Len(t, recordedDecisions(t, s), 3)
+
+	// Decisions older than maxAge are deleted.
+	s.Record(nil, lastPrune.Add(pruneInterval))
+	assert.Empty(t, recordedDecisions(t, s))
+}
+
+func TestScaleUpStatusProcessor(t *testing.T) {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 0, 10, 1)
+	provider.AddNodeGroup("ng2", 0, 10, 1)
+	ng1 := provider.GetNodeGroup("ng1")
+	ng2 := provider.GetNodeGroup("ng2")
+	pods := []*apiv1.Pod{BuildTestPod("p1", 100, 0), BuildTestPod("p2", 100, 0)}
+	now := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.Local)
+
+	store := newFakeStore(t, 100, time.Hour)
+	trace := status.NewDecisionTrace()
+	p := NewScaleUpStatusProcessor(nil, store, trace)
+	p.now = func() time.Time { return now }
+	autoscalingContext := &ca_context.AutoscalingContext{CloudProvider: provider}
+
+	p.Process(autoscalingContext, &status.ScaleUpStatus{Result: status.ScaleUpNoOptionsAvailable, PodsTriggeredScaleUp: pods})
+	assert.Empty(t, recordedDecisions(t, store))
+
+	p.Process(autoscalingContext, &status.ScaleUpStatus{
+		Result:               status.ScaleUpSuccessful,
+		ScaleUpInfos:         []nodegroupset.ScaleUpInfo{{Group: ng1, CurrentSize: 1, NewSize: 3, MaxSize: 10}},
+		PodsTriggeredScaleUp: pods,
+	})
+	scaleUpError := errors.NewAutoscalerError(errors.CloudProviderError, "out of quota")
+	p.now = func() time.Time { return now.Add(time.Minute) }
+	p.Process(autoscalingContext, &status.ScaleUpStatus{
+		Result:                 status.ScaleUpError,
+		ScaleUpError:           &scaleUpError,
+		FailedResizeNodeGroups: []cloudprovider.NodeGroup{ng2},
+		PodsTriggeredScaleUp:   pods[:1],
+	})
+
+	assert.Equal(t, map[string]ScalingDecision{
+		fmt.Sprintf("scaleup-%d-0", now.UnixNano()): {
+			Type:               ScaleUp,
+			Time:               metav1.NewTime(now),
+			TraceID:            trace.Id(),
+			NodeGroup:          "ng1",
+			Result:             Successful,
+			Reason:             "2 pending pods fit the node group",
+			CurrentSize:        1,
+			NewSize:            3,
+			TriggeringPods:     []string{"default/p1", "default/p2"},
+			TriggeringPodCount: 2,
+		},
+		fmt.Sprintf("scaleup-%d-0", now.Add(time.Minute).UnixNano()): {
+			Type:               ScaleUp,
+			Time:               metav1.NewTime(now.Add(time.Minute)),
+			TraceID:            trace.Id(),
+			NodeGroup:          "ng2",
+			Result:             Failed,
+			Reason:             "1 pending pods fit the node group",
+			Message:            "out of quota",
+			TriggeringPods:     []string{"default/p1"},
+			TriggeringPodCount: 1,
+		},
+	}, recordedDecisions(t, store))
+}
+
+func TestScaleDownStatusProcessor(t *testing.T) {
+	n1 := BuildTestNode("n1", 1000, 1000)
+	n2 := BuildTestNode("n2", 1000, 1000)
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 0, 10, 2)
+	provider.AddNode("ng1", n1)
+	provider.AddNode("ng1", n2)
+	ng1 := provider.GetNodeGroup("ng1")
+	var pods []*apiv1.Pod
+	for i := 0; i < maxPodsPerDecision+1; i++ {
+		pods = append(pods, BuildTestPod(fmt.Sprintf("p%d", i), 1, 0))
+	}
+	now := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.Local)
+
+	store := newFakeStore(t, 100, time.Hour)
+	trace := status.NewDecisionTrace()
+	p := NewScaleDownStatusProcessor(nil, store, trace)
+	p.now = func() time.Time { return now }
+	autoscalingContext := &ca_context.AutoscalingContext{CloudProvider: provider}
+	p.Process(autoscalingContext, &scaledownstatus.ScaleDownStatus{
+		Result: scaledownstatus.ScaleDownNodeDeleteStarted,
+		ScaledDownNodes: []*scaledownstatus.ScaleDownNode{
+			{Node: n1, NodeGroup: ng1},
+			{Node: n2, NodeGroup: ng1, EvictedPods: pods, UtilInfo: utilization.Info{ResourceName: apiv1.ResourceCPU, Utilization: 0.1}},
+		},
+	})
+	startTraceId := trace.Id()
+	// Nodes are only recorded once their deletion completes.
+	assert.Empty(t, recordedDecisions(t, store))
+
+	trace.Refresh()
+	deleted := now.Add(time.Minute)
+	p.now = func() time.Time { return deleted }
+	p.Process(autoscalingContext, &scaledownstatus.ScaleDownStatus{
+		Result: scaledownstatus.ScaleDownNoNodeDeleted,
+		NodeDeleteResults: map[string]scaledownstatus.NodeDeleteResult{
+			"n1": {ResultType: scaledownstatus.NodeDeleteOk},
+			"n2": {ResultType: scaledownstatus.NodeDeleteErrorFailedToEvictPods, Err: fmt.Errorf("eviction timed out")},
+		},
+	})
+
+	decisions := recordedDecisions(t, store)
+	assert.Equal(t, ScalingDecision{
+		Type:      ScaleDown,
+		Time:      metav1.NewTime(deleted),
+		TraceID:   startTraceId,
+		NodeGroup: "ng1",
+		Result:    Successful,
+		Reason:    "Node was empty",
+		Node:      "n1",
+	}, decisions[fmt.Sprintf("scaledown-%d-0", deleted.UnixNano())])
+	evicted := decisions[fmt.Sprintf("scaledown-%d-1", deleted.UnixNano())]
+	assert.Equal(t, "n2", evicted.Node)
+	assert.Equal(t, Failed, evicted.Result)
+	assert.Equal(t, "eviction timed out", evicted.Message)
+	assert.Equal(t, "Node was underutilized, with cpu utilization 0.10, and its pods fit other nodes", evicted.Reason)
+	assert.Len(t, evicted.EvictedPods, maxPodsPerDecision)
+	assert.Equal(t, maxPodsPerDecision+1, evicted.EvictedPodCount)
+}
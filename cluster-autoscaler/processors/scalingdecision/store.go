@@ -0,0 +1,232 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scalingdecision
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// Kind is the kind of the ScalingDecision CRD.
+const Kind = "ScalingDecision"
+
+// Resource is the resource of the ScalingDecision CRD.
+var Resource = schema.GroupVersionResource{
+	Group:    "autoscaling.x-k8s.io",
+	Version:  "v1alpha1",
+	Resource: "scalingdecisions",
+}
+
+// Types of scaling decisions.
+const (
+	// ScaleUp is a node group scaled up, or failed to be scaled up, for pending pods.
+	ScaleUp = "ScaleUp"
+	// ScaleDown is a node whose deletion completed, or failed.
+	ScaleDown = "ScaleDown"
+)
+
+// Results of scaling decisions.
+const (
+	// Successful means the cloud provider accepted the change of the node group size, or the node was deleted.
+	Successful = "Successful"
+	// Failed means the cloud provider failed to change the node group size, or the node failed to be drained or deleted.
+	Failed = "Failed"
+)
+
+const (
+	// pruneInterval is the longest time between two prunings of the history, so that decisions
+	// older than the retention age are deleted even if no new decision is made.
+	pruneInterval = 10 * time.Minute
+	// scalingDecisionResync is the resync period of the ScalingDecision informer.
+	scalingDecisionResync = 1 * time.Hour
+)
+
+// ScalingDecision is the spec of a ScalingDecision object.
+type ScalingDecision struct {
+	// Type is the type of the decision, ScaleUp or ScaleDown.
+	Type string `json:"type"`
+	// Time is the time of the loop the decision was made in, or for a scale-down, the time of the loop
+	// which saw the deletion of the node complete.
+	Time metav1.Time `json:"time"`
+	// TraceID links decisions made in the same loop, and matches the traceID of decision log records.
+	// For a scale-down, it's the loop which started the deletion.
+	TraceID string `json:"traceID,omitempty"`
+	// NodeGroup is the id of the node group scaled up, or of the node scaled down.
+	NodeGroup string `json:"nodeGroup,omitempty"`
+	// Result tells if the decision was carried out, Successful or Failed.
+	Result string `json:"result"`
+	// Reason tells why the decision was made.
+	Reason string `json:"reason"`
+	// Message is the error the decision failed with.
+	Message string `json:"message,omitempty"`
+	// CurrentSize is the size of the node group before a scale-up.
+	CurrentSize int `json:"currentSize,omitempty"`
+	// NewSize is the size of the node group after a scale-up.
+	NewSize int `json:"newSize,omitempty"`
+	// TriggeringPods are the pending pods, as namespace/name, which triggered a scale-up,
+	// up to maxPodsPerDecision of them.
+	TriggeringPods []string `json:"triggeringPods,omitempty"`
+	// TriggeringPodCount is the number of pods which triggered a scale-up.
+	TriggeringPodCount int `json:"triggeringPodCount,omitempty"`
+	// Node is the node scaled down.
+	Node string `json:"node,omitempty"`
+	// EvictedPods are the pods, as namespace/name, evicted from the node scaled down,
+	// up to maxPodsPerDecision of them.
+	EvictedPods []string `json:"evictedPods,omitempty"`
+	// EvictedPodCount is the number of pods evicted from the node scaled down.
+	EvictedPodCount int `json:"evictedPodCount,omitempty"`
+}
+
+// Store persists scaling decisions as ScalingDecision objects, keeping at most
+// maxCount of them, none older than maxAge. Existing objects are read from an
+// informer cache, so that pruning doesn't list them from the API server.
+type Store struct {
+	client    dynamic.Interface
+	informer  cache.SharedIndexInformer
+	stopCh    chan struct{}
+	stopOnce  sync.Once
+	namespace string
+	maxCount  int
+	maxAge    time.Duration
+	lastPrune time.Time
+}
+
+// NewStore returns a Store keeping ScalingDecision objects in the given namespace.
+// It blocks until the initial sync of the informer completes and returns an error
+// if it doesn't complete in time.
+func NewStore(kubeConfig *rest.Config, namespace string, maxCount int, maxAge time.Duration) (*Store, error) {
+	client, err := dynamic.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ScalingDecision client: %v", err)
+	}
+	return newStore(client, namespace, maxCount, maxAge, kube_util.InformerSyncTimeout)
+}
+
+func newStore(client dynamic.Interface, namespace string, maxCount int, maxAge time.Duration, syncTimeout time.Duration) (*Store, error) {
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(client, scalingDecisionResync, namespace, nil)
+	informer := factory.ForResource(Resource).Informer()
+	stopCh, err := kube_util.StartAndSyncInformers(factory, syncTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("can't sync ScalingDecision informer: %v", err)
+	}
+	klog.V(2).Infof("Successful initial ScalingDecision sync in namespace %q", namespace)
+	return &Store{
+		client:    client,
+		informer:  informer,
+		stopCh:    stopCh,
+		namespace: namespace,
+		maxCount:  maxCount,
+		maxAge:    maxAge,
+	}, nil
+}
+
+// Stop stops the ScalingDecision informer. It can be called more than once.
+func (s *Store) Stop() {
+	s.stopOnce.Do(func() { close(s.stopCh) })
+}
+
+// Record deletes the objects beyond the retention policy, leaving room for the
+// new decisions, then creates an object for each decision. Errors are logged,
+// as the history is best effort.
+func (s *Store) Record(decisions []ScalingDecision, now time.Time) {
+	if len(decisions) > 0 || now.Sub(s.lastPrune) >= pruneInterval {
+		if err := s.prune(now, len(decisions)); err != nil {
+			klog.Warningf("Failed to prune scaling decisions: %v", err)
+		} else {
+			s.lastPrune = now
+		}
+	}
+	for i, decision := range decisions {
+		if err := s.create(decision, objectName(decision, now, i)); err != nil {
+			klog.Warningf("Failed to record scaling decision: %v", err)
+		}
+	}
+}
+
+// objectName returns a name unique to the decision within its loop.
+func objectName(decision ScalingDecision, now time.Time, index int) string {
+	return fmt.Sprintf("%s-%d-%d", strings.ToLower(decision.Type), now.UnixNano(), index)
+}
+
+func (s *Store) create(decision ScalingDecision, name string) error {
+	specMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&decision)
+	if err != nil {
+		return fmt.Errorf("failed to convert ScalingDecision: %v", err)
+	}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": specMap}}
+	obj.SetAPIVersion(Resource.GroupVersion().String())
+	obj.SetKind(Kind)
+	obj.SetNamespace(s.namespace)
+	obj.SetName(name)
+	if _, err := s.client.Resource(Resource).Namespace(s.namespace).Create(context.TODO(), obj, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create ScalingDecision %s/%s: %v", s.namespace, name, err)
+	}
+	return nil
+}
+
+// prune deletes decisions older than maxAge, and the oldest decisions beyond maxCount,
+// keeping room for the given number of new decisions. Decisions are listed from the
+// informer cache, decisions created or deleted since the last sync are pruned next time.
+func (s *Store) prune(now time.Time, newDecisions int) error {
+	objects := s.client.Resource(Resource).Namespace(s.namespace)
+	type recorded struct {
+		name string
+		time time.Time
+	}
+	var decisions []recorded
+	for _, item := range s.informer.GetStore().List() {
+		obj, ok := item.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		// Objects without a valid time sort first, and are deleted first.
+		timestamp, _, _ := unstructured.NestedString(obj.Object, "spec", "time")
+		t, _ := time.Parse(time.RFC3339, timestamp)
+		decisions = append(decisions, recorded{name: obj.GetName(), time: t})
+	}
+	sort.Slice(decisions, func(i, j int) bool {
+		if decisions[i].time.Equal(decisions[j].time) {
+			return decisions[i].name < decisions[j].name
+		}
+		return decisions[i].time.Before(decisions[j].time)
+	})
+	for i, decision := range decisions {
+		if len(decisions)-i+newDecisions <= s.maxCount && now.Sub(decision.time) <= s.maxAge {
+			break
+		}
+		if err := objects.Delete(context.TODO(), decision.name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete ScalingDecision %s/%s: %v", s.namespace, decision.name, err)
+		}
+	}
+	return nil
+}
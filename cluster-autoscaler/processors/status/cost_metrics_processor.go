@@ -0,0 +1,222 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup/costbudget"
+	"k8s.io/autoscaler/cluster-autoscaler/metrics"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/klog/v2"
+)
+
+// pendingDeletionTimeout bounds how long a node whose deletion started is waited for. Deletion
+// results are reported well within it, it only drops nodes whose result is never reported.
+const pendingDeletionTimeout = 24 * time.Hour
+
+// CostMetricsScaleDownStatusProcessor estimates the cost saved by scale-down per node group,
+// and the hourly cost of all node groups in the cluster at their target sizes in each loop.
+// A node removed by scale-down saves its hourly cost from the loop its deletion completed in
+// until its node group is scaled up again, so the savings accrue over time. Prices are the
+// configured node group prices or the ones of the cloud provider pricing model.
+type CostMetricsScaleDownStatusProcessor struct {
+	wrapped ScaleDownStatusProcessor
+	now     func() time.Time
+	// lastLoop is the time of the previous loop, savings are accrued for the time since.
+	lastLoop time.Time
+	// pending maps names of the nodes being deleted to their hourly cost.
+	pending map[string]pendingDeletion
+	// removed holds the nodes removed from each node group, by node group id.
+	removed map[string]*removedNodes
+
+	registerSavings   func(nodeGroup string, savings float64)
+	updateClusterCost func(hourlyCost float64)
+}
+
+type pendingDeletion struct {
+	nodeGroup  string
+	hourlyCost float64
+	started    time.Time
+}
+
+type removedNodes struct {
+	// hourlyCosts are the hourly costs of the nodes removed from the node group, in the order of their removal.
+	hourlyCosts []float64
+	// targetSize is the target size of the node group in the previous loop.
+	targetSize int
+}
+
+// NewCostMetricsScaleDownStatusProcessor creates a new instance of CostMetricsScaleDownStatusProcessor.
+// The savings are only estimated if emitPerNodeGroupMetrics is true, as they are reported per node group.
+func NewCostMetricsScaleDownStatusProcessor(wrapped ScaleDownStatusProcessor, emitPerNodeGroupMetrics bool) *CostMetricsScaleDownStatusProcessor {
+	p := &CostMetricsScaleDownStatusProcessor{
+		wrapped:           wrapped,
+		now:               time.Now,
+		pending:           make(map[string]pendingDeletion),
+		removed:           make(map[string]*removedNodes),
+		updateClusterCost: metrics.UpdateClusterHourlyCost,
+	}
+	if emitPerNodeGroupMetrics {
+		p.registerSavings = metrics.RegisterScaledDownCostSavings
+	}
+	return p
+}
+
+// Process records the estimated savings of scale-down since the previous loop and the
+// estimated cost of the cluster.
+func (p *CostMetricsScaleDownStatusProcessor) Process(context *context.AutoscalingContext, scaleDownStatus *status.ScaleDownStatus) {
+	if p.wrapped != nil {
+		p.wrapped.Process(context, scaleDownStatus)
+	}
+	now := p.now()
+	nodeGroups := context.CloudProvider.NodeGroups()
+	// The budget only computes prices here, its limit and mode don't matter.
+	budget := costbudget.NewBudget(context.CloudProvider, 0, costbudget.SoftMode, context.NodeGroupHourlyPrices)
+	if p.registerSavings != nil {
+		p.recordSavings(nodeGroups, budget, scaleDownStatus, now)
+	}
+
+	cost, err := budget.ClusterCost(nodeGroups, p.nodeInfos(context, nodeGroups), now)
+	if err != nil {
+		klog.Warningf("Failed to estimate the hourly cost of the cluster: %v", err)
+		return
+	}
+	p.updateClusterCost(cost)
+}
+
+// recordSavings registers the savings of the removed nodes since the previous loop, and
+// tracks the nodes scaled down in this loop.
+func (p *CostMetricsScaleDownStatusProcessor) recordSavings(nodeGroups []cloudprovider.NodeGroup, budget *costbudget.Budget, scaleDownStatus *status.ScaleDownStatus, now time.Time) {
+	if !p.lastLoop.IsZero() {
+		hours := now.Sub(p.lastLoop).Hours()
+		for id, removed := range p.removed {
+			hourlyCost := 0.0
+			for _, cost := range removed.hourlyCosts {
+				hourlyCost += cost
+			}
+			p.registerSavings(id, hourlyCost*hours)
+		}
+	}
+	p.lastLoop = now
+
+	targetSizes := make(map[string]int, len(nodeGroups))
+	for _, nodeGroup := range nodeGroups {
+		if size, err := nodeGroup.TargetSize(); err == nil {
+			targetSizes[nodeGroup.Id()] = size
+		}
+	}
+	p.updateTargetSizes(targetSizes)
+
+	for _, sdNode := range scaleDownStatus.ScaledDownNodes {
+		if nodeGroupIdOrEmpty(sdNode.NodeGroup) == "" {
+			continue
+		}
+		price, err := budget.NodePrice(sdNode.NodeGroup, framework.NewNodeInfo(sdNode.Node, nil), now)
+		if err != nil {
+			klog.Warningf("Failed to estimate the savings of scaling down node %s: %v", sdNode.Node.Name, err)
+			continue
+		}
+		p.pending[sdNode.Node.Name] = pendingDeletion{nodeGroup: sdNode.NodeGroup.Id(), hourlyCost: price, started: now}
+	}
+	p.completeDeletions(targetSizes, scaleDownStatus.NodeDeleteResults, now)
+}
+
+// updateTargetSizes forgets the nodes replaced by scale-ups of their node groups since the
+// previous loop, the most recently removed first, and the nodes of node groups which were
+// deleted or whose target size isn't known.
+func (p *CostMetricsScaleDownStatusProcessor) updateTargetSizes(targetSizes map[string]int) {
+	for id, removed := range p.removed {
+		size, found := targetSizes[id]
+		if !found {
+			delete(p.removed, id)
+			continue
+		}
+		if increase := size - removed.targetSize; increase > 0 {
+			replaced := min(increase, len(removed.hourlyCosts))
+			removed.hourlyCosts = removed.hourlyCosts[:len(removed.hourlyCosts)-replaced]
+		}
+		removed.targetSize = size
+		if len(removed.hourlyCosts) == 0 {
+			delete(p.removed, id)
+		}
+	}
+}
+
+// completeDeletions moves the nodes whose deletion succeeded from pending to removed.
+func (p *CostMetricsScaleDownStatusProcessor) completeDeletions(targetSizes map[string]int, results map[string]status.NodeDeleteResult, now time.Time) {
+	for nodeName, result := range results {
+		deletion, found := p.pending[nodeName]
+		if !found {
+			continue
+		}
+		delete(p.pending, nodeName)
+		size, found := targetSizes[deletion.nodeGroup]
+		if result.ResultType != status.NodeDeleteOk || !found {
+			continue
+		}
+		removed, found := p.removed[deletion.nodeGroup]
+		if !found {
+			removed = &removedNodes{targetSize: size}
+			p.removed[deletion.nodeGroup] = removed
+		}
+		removed.hourlyCosts = append(removed.hourlyCosts, deletion.hourlyCost)
+	}
+	for nodeName, deletion := range p.pending {
+		if now.Sub(deletion.started) > pendingDeletionTimeout {
+			delete(p.pending, nodeName)
+		}
+	}
+}
+
+// nodeInfos returns a node to price the nodes of each node group by. Existing nodes are
+// used first, node templates are used for node groups without nodes.
+func (p *CostMetricsScaleDownStatusProcessor) nodeInfos(context *context.AutoscalingContext, nodeGroups []cloudprovider.NodeGroup) map[string]*framework.NodeInfo {
+	nodeInfos := make(map[string]*framework.NodeInfo, len(nodeGroups))
+	nodes, err := context.AllNodeLister().List()
+	if err != nil {
+		klog.Warningf("Failed to list nodes for cost metrics: %v", err)
+	}
+	for _, node := range nodes {
+		if len(nodeInfos) == len(nodeGroups) {
+			break
+		}
+		id := nodeGroupId(context, node)
+		if _, found := nodeInfos[id]; id != "" && !found {
+			nodeInfos[id] = framework.NewNodeInfo(node, nil)
+		}
+	}
+	for _, nodeGroup := range nodeGroups {
+		if _, found := nodeInfos[nodeGroup.Id()]; found {
+			continue
+		}
+		if nodeInfo, err := nodeGroup.TemplateNodeInfo(); err == nil {
+			nodeInfos[nodeGroup.Id()] = nodeInfo
+		}
+	}
+	return nodeInfos
+}
+
+// CleanUp cleans up the processor's internal structures.
+func (p *CostMetricsScaleDownStatusProcessor) CleanUp() {
+	if p.wrapped != nil {
+		p.wrapped.CleanUp()
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+func TestCostMetricsScaleDownStatusProcessor(t *testing.T) {
+	n1 := BuildTestNode("n1", 1000, 1000)
+	n2 := BuildTestNode("n2", 2000, 1000)
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 0, 10, 2)
+	provider.AddNode("ng1", n1)
+	provider.AddNode("ng1", n2)
+	// ng2 has no nodes and is priced by its template, ng3 by the configured price.
+	provider.AddNodeGroup("ng2", 0, 10, 1)
+	provider.AddNodeGroup("ng3", 0, 10, 1)
+	provider.AddNodeGroup("ng4", 0, 10, 0)
+	provider.SetMachineTemplates(map[string]*framework.NodeInfo{"ng2": framework.NewTestNodeInfo(BuildTestNode("ng2-template", 1000, 1000))})
+	provider.SetPricingModel(testNodePricingModel{"n1": 0.5, "n2": 1.25, "ng2-template": 2})
+
+	ng1 := provider.GetNodeGroup("ng1").(*testprovider.TestNodeGroup)
+
+	savings := map[string]float64{}
+	var clusterCost []float64
+	now := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	p := NewCostMetricsScaleDownStatusProcessor(nil, true)
+	p.now = func() time.Time { return now }
+	p.registerSavings = func(nodeGroup string, savingsSinceLastLoop float64) { savings[nodeGroup] += savingsSinceLastLoop }
+	p.updateClusterCost = func(hourlyCost float64) { clusterCost = append(clusterCost, hourlyCost) }
+	autoscalingContext := &context.AutoscalingContext{
+		AutoscalingOptions: config.AutoscalingOptions{NodeGroupHourlyPrices: map[string]float64{"ng3": 3}},
+		CloudProvider:      provider,
+		AutoscalingKubeClients: context.AutoscalingKubeClients{
+			ListerRegistry: kube_util.NewListerRegistry(kube_util.NewTestNodeLister([]*apiv1.Node{n1, n2}), nil, nil, nil, nil, nil, nil, nil, nil),
+		},
+	}
+
+	p.Process(autoscalingContext, &status.ScaleDownStatus{Result: status.ScaleDownNoNodeDeleted})
+	assert.Empty(t, savings)
+	assert.Equal(t, []float64{6}, clusterCost)
+
+	// Nodes don't save anything until their deletion completes.
+	p.Process(autoscalingContext, &status.ScaleDownStatus{
+		Result:          status.ScaleDownNodeDeleteStarted,
+		ScaledDownNodes: []*status.ScaleDownNode{{Node: n2, NodeGroup: ng1}, {Node: n2}},
+	})
+	ng1.SetTargetSize(1)
+	now = now.Add(time.Hour)
+	p.Process(autoscalingContext, &status.ScaleDownStatus{
+		Result:            status.ScaleDownNodeDeleteStarted,
+		ScaledDownNodes:   []*status.ScaleDownNode{{Node: n1, NodeGroup: ng1}},
+		NodeDeleteResults: map[string]status.NodeDeleteResult{"n2": {ResultType: status.NodeDeleteOk}},
+	})
+	assert.Empty(t, savings)
+
+	// Savings accrue over time, failed deletions don't save anything.
+	now = now.Add(2 * time.Hour)
+	p.Process(autoscalingContext, &status.ScaleDownStatus{
+		Result:            status.ScaleDownNoNodeDeleted,
+		NodeDeleteResults: map[string]status.NodeDeleteResult{"n1": {ResultType: status.NodeDeleteErrorFailedToDelete, Err: fmt.Errorf("failed")}},
+	})
+	assert.Equal(t, map[string]float64{"ng1": 2.5}, savings)
+
+	// A scale-up of the node group replaces the removed node, which stops saving.
+	ng1.SetTargetSize(2)
+	now = now.Add(time.Hour)
+	p.Process(autoscalingContext, &status.ScaleDownStatus{Result: status.ScaleDownNoNodeDeleted})
+	now = now.Add(time.Hour)
+	p.Process(autoscalingContext, &status.ScaleDownStatus{Result: status.ScaleDownNoNodeDeleted})
+	assert.Equal(t, map[string]float64{"ng1": 3.75}, savings)
+	assert.Equal(t, []float64{6, 6, 5.5, 5.5, 6, 6}, clusterCost)
+
+	// The cluster cost isn't updated if a price is unknown.
+	provider.SetPricingModel(testNodePricingModel{})
+	p.Process(autoscalingContext, &status.ScaleDownStatus{Result: status.ScaleDownNoNodeDeleted})
+	assert.Equal(t, []float64{6, 6, 5.5, 5.5, 6, 6}, clusterCost)
+}
+
+func TestCostMetricsScaleDownStatusProcessorWithoutPerNodeGroupMetrics(t *testing.T) {
+	n1 := BuildTestNode("n1", 1000, 1000)
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 0, 10, 1)
+	provider.AddNode("ng1", n1)
+	provider.SetPricingModel(testNodePricingModel{"n1": 0.5})
+	ng1 := provider.GetNodeGroup("ng1")
+
+	var clusterCost []float64
+	p := NewCostMetricsScaleDownStatusProcessor(nil, false)
+	p.updateClusterCost = func(hourlyCost float64) { clusterCost = append(clusterCost, hourlyCost) }
+	autoscalingContext := &context.AutoscalingContext{
+		CloudProvider: provider,
+		AutoscalingKubeClients: context.AutoscalingKubeClients{
+			ListerRegistry: kube_util.NewListerRegistry(kube_util.NewTestNodeLister([]*apiv1.Node{n1}), nil, nil, nil, nil, nil, nil, nil, nil),
+		},
+	}
+
+	// Savings aren't tracked, the cluster cost still is.
+	p.Process(autoscalingContext, &status.ScaleDownStatus{
+		Result:            status.ScaleDownNodeDeleteStarted,
+		ScaledDownNodes:   []*status.ScaleDownNode{{Node: n1, NodeGroup: ng1}},
+		NodeDeleteResults: map[string]status.NodeDeleteResult{"n1": {ResultType: status.NodeDeleteOk}},
+	})
+	assert.Empty(t, p.pending)
+	assert.Empty(t, p.removed)
+	assert.Equal(t, []float64{0.5}, clusterCost)
+}
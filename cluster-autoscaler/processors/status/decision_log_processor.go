@@ -0,0 +1,260 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	"k8s.io/klog/v2"
+)
+
+// DecisionLogMessage is the message of all decision records, which tell them apart from other log records.
+const DecisionLogMessage = "Autoscaling decision"
+
+// Types of decision records.
+const (
+	// ScaleUpAttemptDecision sums up a scale-up attempt of a loop.
+	ScaleUpAttemptDecision = "ScaleUpAttempt"
+	// ScaleUpDecision is a node group scaled up.
+	ScaleUpDecision = "ScaleUp"
+	// NoScaleUpDecision is a pod which didn't trigger a scale-up, along with the reasons for each node group.
+	NoScaleUpDecision = "NoScaleUp"
+	// ScaleDownAttemptDecision sums up a scale-down attempt of a loop.
+	ScaleDownAttemptDecision = "ScaleDownAttempt"
+	// ScaleDownCandidateDecision is a node evaluated for scale-down, which is either unneeded or unremovable.
+	ScaleDownCandidateDecision = "ScaleDownCandidate"
+	// ScaleDownDecision is a node whose deletion started.
+	ScaleDownDecision = "ScaleDown"
+)
+
+var scaleUpResultNames = map[ScaleUpResult]string{
+	ScaleUpSuccessful:             "Successful",
+	ScaleUpError:                  "Error",
+	ScaleUpNoOptionsAvailable:     "NoOptionsAvailable",
+	ScaleUpNotNeeded:              "NotNeeded",
+	ScaleUpNotTried:               "NotTried",
+	ScaleUpInCooldown:             "InCooldown",
+	ScaleUpLimitedByMaxNodesTotal: "LimitedByMaxNodesTotal",
+}
+
+var scaleDownResultNames = map[status.ScaleDownResult]string{
+	status.ScaleDownError:             "Error",
+	status.ScaleDownNoNodeDeleted:     "NoNodeDeleted",
+	status.ScaleDownNodeDeleteStarted: "NodeDeleteStarted",
+	status.ScaleDownNotTried:          "NotTried",
+	status.ScaleDownInCooldown:        "InCooldown",
+	status.ScaleDownInProgress:        "InProgress",
+	status.ScaleDownNoCandidates:      "NoCandidates",
+}
+
+// DecisionTrace holds the trace id of the current loop, linking decision records
+// emitted in the same loop. It's refreshed at the beginning of each loop.
+type DecisionTrace struct {
+	mutex sync.Mutex
+	id    string
+	newId func() string
+}
+
+// NewDecisionTrace returns a DecisionTrace with a random trace id per loop.
+func NewDecisionTrace() *DecisionTrace {
+	t := &DecisionTrace{newId: func() string { return string(uuid.NewUUID()) }}
+	t.Refresh()
+	return t
+}
+
+// Refresh starts a new trace.
+func (t *DecisionTrace) Refresh() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.id = t.newId()
+}
+
+// Id returns the trace id of the current loop.
+func (t *DecisionTrace) Id() string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.id
+}
+
+// decisionLogger emits decision records as structured log records, which are
+// JSON objects with --logging-format=json.
+type decisionLogger struct {
+	trace *DecisionTrace
+	logf  func(msg string, keysAndValues ...interface{})
+}
+
+func (l *decisionLogger) log(decision string, keysAndValues ...interface{}) {
+	l.logf(DecisionLogMessage, append([]interface{}{"traceID", l.trace.Id(), "decision", decision}, keysAndValues...)...)
+}
+
+// DecisionLogScaleUpStatusProcessor emits a decision record for each scale-up
+// attempt, each node group scaled up and each pod which didn't trigger a
+// scale-up.
+type DecisionLogScaleUpStatusProcessor struct {
+	decisionLogger
+	wrapped ScaleUpStatusProcessor
+}
+
+// NewDecisionLogScaleUpStatusProcessor creates a new instance of DecisionLogScaleUpStatusProcessor.
+func NewDecisionLogScaleUpStatusProcessor(wrapped ScaleUpStatusProcessor, trace *DecisionTrace) *DecisionLogScaleUpStatusProcessor {
+	return &DecisionLogScaleUpStatusProcessor{
+		decisionLogger: decisionLogger{trace: trace, logf: klog.InfoS},
+		wrapped:        wrapped,
+	}
+}
+
+// Process emits decision records unless there was nothing to scale up for.
+func (p *DecisionLogScaleUpStatusProcessor) Process(context *context.AutoscalingContext, scaleUpStatus *ScaleUpStatus) {
+	if p.wrapped != nil {
+		p.wrapped.Process(context, scaleUpStatus)
+	}
+	if scaleUpStatus.Result == ScaleUpNotNeeded || scaleUpStatus.Result == ScaleUpNotTried {
+		return
+	}
+	attempt := []interface{}{
+		"result", scaleUpResultNames[scaleUpStatus.Result],
+		"podsTriggeredScaleUp", podNames(scaleUpStatus.PodsTriggeredScaleUp),
+		"podsRemainUnschedulable", len(scaleUpStatus.PodsRemainUnschedulable),
+		"podsAwaitEvaluation", len(scaleUpStatus.PodsAwaitEvaluation),
+		"consideredNodeGroups", nodeGroupIds(scaleUpStatus.ConsideredNodeGroups),
+	}
+	if scaleUpStatus.ScaleUpError != nil {
+		attempt = append(attempt, "error", (*scaleUpStatus.ScaleUpError).Error())
+	}
+	p.log(ScaleUpAttemptDecision, attempt...)
+	for _, info := range scaleUpStatus.ScaleUpInfos {
+		p.log(ScaleUpDecision, "nodeGroup", info.Group.Id(), "currentSize", info.CurrentSize, "newSize", info.NewSize, "maxSize", info.MaxSize)
+	}
+	for _, noScaleUp := range scaleUpStatus.PodsRemainUnschedulable {
+		p.log(NoScaleUpDecision,
+			"pod", noScaleUp.Pod.Namespace+"/"+noScaleUp.Pod.Name,
+			"rejectedNodeGroups", reasonsByNodeGroup(noScaleUp.RejectedNodeGroups),
+			"skippedNodeGroups", reasonsByNodeGroup(noScaleUp.SkippedNodeGroups))
+	}
+}
+
+// CleanUp cleans up the processor's internal structures.
+func (p *DecisionLogScaleUpStatusProcessor) CleanUp() {
+	if p.wrapped != nil {
+		p.wrapped.CleanUp()
+	}
+}
+
+// DecisionLogScaleDownStatusProcessor emits a decision record for each scale-down
+// attempt, each node evaluated for scale-down and each node whose deletion
+// started.
+type DecisionLogScaleDownStatusProcessor struct {
+	decisionLogger
+	wrapped ScaleDownStatusProcessor
+}
+
+// NewDecisionLogScaleDownStatusProcessor creates a new instance of DecisionLogScaleDownStatusProcessor.
+func NewDecisionLogScaleDownStatusProcessor(wrapped ScaleDownStatusProcessor, trace *DecisionTrace) *DecisionLogScaleDownStatusProcessor {
+	return &DecisionLogScaleDownStatusProcessor{
+		decisionLogger: decisionLogger{trace: trace, logf: klog.InfoS},
+		wrapped:        wrapped,
+	}
+}
+
+// Process emits decision records unless scale-down wasn't tried.
+func (p *DecisionLogScaleDownStatusProcessor) Process(context *context.AutoscalingContext, scaleDownStatus *status.ScaleDownStatus) {
+	if p.wrapped != nil {
+		p.wrapped.Process(context, scaleDownStatus)
+	}
+	if scaleDownStatus.Result == status.ScaleDownNotTried {
+		return
+	}
+	p.log(ScaleDownAttemptDecision,
+		"result", scaleDownResultNames[scaleDownStatus.Result],
+		"unneededNodes", len(scaleDownStatus.UnneededNodes),
+		"unremovableNodes", len(scaleDownStatus.UnremovableNodes),
+		"scaledDownNodes", len(scaleDownStatus.ScaledDownNodes))
+	for _, node := range scaleDownStatus.UnneededNodes {
+		p.log(ScaleDownCandidateDecision, "node", node.Name, "nodeGroup", nodeGroupId(context, node), "removable", true)
+	}
+	for _, unremovableNode := range scaleDownStatus.UnremovableNodes {
+		candidate := []interface{}{
+			"node", unremovableNode.Node.Name,
+			"nodeGroup", nodeGroupIdOrEmpty(unremovableNode.NodeGroup),
+			"removable", false,
+			"reason", unremovableNode.Reason.String(),
+		}
+		if unremovableNode.UtilInfo != nil {
+			candidate = append(candidate, "utilization", unremovableNode.UtilInfo.Utilization)
+		}
+		if unremovableNode.BlockingPod != nil {
+			candidate = append(candidate,
+				"blockingPod", unremovableNode.BlockingPod.Pod.Namespace+"/"+unremovableNode.BlockingPod.Pod.Name,
+				"blockingPodReason", unremovableNode.BlockingPod.Reason.String())
+		}
+		p.log(ScaleDownCandidateDecision, candidate...)
+	}
+	for _, sdNode := range scaleDownStatus.ScaledDownNodes {
+		p.log(ScaleDownDecision,
+			"node", sdNode.Node.Name,
+			"nodeGroup", nodeGroupIdOrEmpty(sdNode.NodeGroup),
+			"utilization", sdNode.UtilInfo.Utilization,
+			"evictedPods", podNames(sdNode.EvictedPods))
+	}
+}
+
+// CleanUp cleans up the processor's internal structures.
+func (p *DecisionLogScaleDownStatusProcessor) CleanUp() {
+	if p.wrapped != nil {
+		p.wrapped.CleanUp()
+	}
+}
+
+func podNames(pods []*apiv1.Pod) []string {
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, pod.Namespace+"/"+pod.Name)
+	}
+	return names
+}
+
+func nodeGroupIds(nodeGroups []cloudprovider.NodeGroup) []string {
+	ids := make([]string, 0, len(nodeGroups))
+	for _, nodeGroup := range nodeGroups {
+		ids = append(ids, nodeGroup.Id())
+	}
+	return ids
+}
+
+func nodeGroupIdOrEmpty(nodeGroup cloudprovider.NodeGroup) string {
+	if nodeGroup == nil || reflect.ValueOf(nodeGroup).IsNil() {
+		return ""
+	}
+	return nodeGroup.Id()
+}
+
+func reasonsByNodeGroup(reasons map[string]Reasons) map[string][]string {
+	result := make(map[string][]string, len(reasons))
+	for nodeGroupId, r := range reasons {
+		messages := append([]string{}, r.Reasons()...)
+		sort.Strings(messages)
+		result[nodeGroupId] = messages
+	}
+	return result
+}
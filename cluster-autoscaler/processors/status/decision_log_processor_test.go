@@ -0,0 +1,146 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupset"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/utilization"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+type decisionRecorder struct {
+	records [][]interface{}
+}
+
+func (r *decisionRecorder) logf(msg string, keysAndValues ...interface{}) {
+	r.records = append(r.records, append([]interface{}{msg}, keysAndValues...))
+}
+
+func newTestDecisionTrace() *DecisionTrace {
+	loop := 0
+	return &DecisionTrace{newId: func() string {
+		loop++
+		return fmt.Sprintf("trace-%d", loop)
+	}}
+}
+
+func TestDecisionLogScaleUpStatusProcessor(t *testing.T) {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 0, 10, 1)
+	provider.AddNodeGroup("ng2", 0, 10, 1)
+	ng1 := provider.GetNodeGroup("ng1")
+	ng2 := provider.GetNodeGroup("ng2")
+	p1 := BuildTestPod("p1", 100, 0)
+	p2 := BuildTestPod("p2", 100, 0)
+
+	trace := newTestDecisionTrace()
+	recorder := &decisionRecorder{}
+	wrapped := &countingScaleUpStatusProcessor{}
+	p := NewDecisionLogScaleUpStatusProcessor(wrapped, trace)
+	p.logf = recorder.logf
+	autoscalingContext := &context.AutoscalingContext{CloudProvider: provider}
+
+	trace.Refresh()
+	p.Process(autoscalingContext, &ScaleUpStatus{Result: ScaleUpNotNeeded})
+	assert.Empty(t, recorder.records)
+
+	trace.Refresh()
+	p.Process(autoscalingContext, &ScaleUpStatus{
+		Result:               ScaleUpSuccessful,
+		ScaleUpInfos:         []nodegroupset.ScaleUpInfo{{Group: ng1, CurrentSize: 1, NewSize: 3, MaxSize: 10}},
+		PodsTriggeredScaleUp: []*apiv1.Pod{p1},
+		PodsRemainUnschedulable: []NoScaleUpInfo{{
+			Pod:                p2,
+			RejectedNodeGroups: map[string]Reasons{"ng2": &testReason{"Insufficient cpu"}},
+			SkippedNodeGroups:  map[string]Reasons{},
+		}},
+		ConsideredNodeGroups: []cloudprovider.NodeGroup{ng1, ng2},
+	})
+	assert.Equal(t, 2, wrapped.processed)
+	assert.Equal(t, [][]interface{}{
+		{DecisionLogMessage, "traceID", "trace-2", "decision", ScaleUpAttemptDecision, "result", "Successful", "podsTriggeredScaleUp", []string{"default/p1"},
+			"podsRemainUnschedulable", 1, "podsAwaitEvaluation", 0, "consideredNodeGroups", []string{"ng1", "ng2"}},
+		{DecisionLogMessage, "traceID", "trace-2", "decision", ScaleUpDecision, "nodeGroup", "ng1", "currentSize", 1, "newSize", 3, "maxSize", 10},
+		{DecisionLogMessage, "traceID", "trace-2", "decision", NoScaleUpDecision, "pod", "default/p2",
+			"rejectedNodeGroups", map[string][]string{"ng2": {"Insufficient cpu"}}, "skippedNodeGroups", map[string][]string{}},
+	}, recorder.records)
+}
+
+func TestDecisionLogScaleDownStatusProcessor(t *testing.T) {
+	n1 := BuildTestNode("n1", 1000, 1000)
+	n2 := BuildTestNode("n2", 1000, 1000)
+	n3 := BuildTestNode("n3", 1000, 1000)
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 0, 10, 3)
+	provider.AddNode("ng1", n1)
+	provider.AddNode("ng1", n2)
+	provider.AddNode("ng1", n3)
+	ng1 := provider.GetNodeGroup("ng1")
+	p1 := BuildTestPod("p1", 100, 0)
+	blocking := BuildTestPod("blocking", 100, 0)
+
+	trace := newTestDecisionTrace()
+	recorder := &decisionRecorder{}
+	p := NewDecisionLogScaleDownStatusProcessor(nil, trace)
+	p.logf = recorder.logf
+	autoscalingContext := &context.AutoscalingContext{CloudProvider: provider}
+
+	trace.Refresh()
+	p.Process(autoscalingContext, &status.ScaleDownStatus{Result: status.ScaleDownNotTried})
+	assert.Empty(t, recorder.records)
+
+	trace.Refresh()
+	p.Process(autoscalingContext, &status.ScaleDownStatus{
+		Result:          status.ScaleDownNodeDeleteStarted,
+		UnneededNodes:   []*apiv1.Node{n1},
+		ScaledDownNodes: []*status.ScaleDownNode{{Node: n1, NodeGroup: ng1, EvictedPods: []*apiv1.Pod{p1}, UtilInfo: utilization.Info{Utilization: 0.1}}},
+		UnremovableNodes: []*status.UnremovableNode{
+			{Node: n2, NodeGroup: ng1, Reason: simulator.NotUnderutilized, UtilInfo: &utilization.Info{Utilization: 0.9}},
+			{Node: n3, NodeGroup: ng1, Reason: simulator.BlockedByPod, BlockingPod: &drain.BlockingPod{Pod: blocking, Reason: drain.NotReplicated}},
+		},
+	})
+	assert.Equal(t, [][]interface{}{
+		{DecisionLogMessage, "traceID", "trace-2", "decision", ScaleDownAttemptDecision, "result", "NodeDeleteStarted", "unneededNodes", 1, "unremovableNodes", 2, "scaledDownNodes", 1},
+		{DecisionLogMessage, "traceID", "trace-2", "decision", ScaleDownCandidateDecision, "node", "n1", "nodeGroup", "ng1", "removable", true},
+		{DecisionLogMessage, "traceID", "trace-2", "decision", ScaleDownCandidateDecision, "node", "n2", "nodeGroup", "ng1", "removable", false, "reason", "NotUnderutilized", "utilization", 0.9},
+		{DecisionLogMessage, "traceID", "trace-2", "decision", ScaleDownCandidateDecision, "node", "n3", "nodeGroup", "ng1", "removable", false, "reason", "BlockedByPod",
+			"blockingPod", "default/blocking", "blockingPodReason", "NotReplicated"},
+		{DecisionLogMessage, "traceID", "trace-2", "decision", ScaleDownDecision, "node", "n1", "nodeGroup", "ng1", "utilization", 0.1, "evictedPods", []string{"default/p1"}},
+	}, recorder.records)
+}
+
+type countingScaleUpStatusProcessor struct {
+	processed int
+}
+
+func (p *countingScaleUpStatusProcessor) Process(_ *context.AutoscalingContext, _ *ScaleUpStatus) {
+	p.processed++
+}
+
+func (p *countingScaleUpStatusProcessor) CleanUp() {}
@@ -47,6 +47,9 @@ func (p *MetricsAutoscalingStatusProcessor) Process(context *context.Autoscaling
 		metrics.UpdateNodeGroupHealthStatus(nodeGroup.Id(), csr.IsNodeGroupHealthy(nodeGroup.Id()))
 		backoffStatus := csr.BackoffStatusForNodeGroup(nodeGroup, now)
 		p.updateNodeGroupBackoffStatusMetrics(nodeGroup.Id(), backoffStatus)
+		if score, found := csr.NodeGroupHealthScore(nodeGroup, now); found {
+			metrics.UpdateNodeGroupHealthScore(nodeGroup.Id(), score)
+		}
 	}
 	return nil
 }
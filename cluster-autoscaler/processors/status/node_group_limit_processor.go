@@ -0,0 +1,180 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	"k8s.io/autoscaler/cluster-autoscaler/metrics"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// NodeGroupMaxSizeReachedEventReason is the reason of events emitted on pods which remain
+	// pending while node groups they could be scheduled on are at their max size.
+	NodeGroupMaxSizeReachedEventReason = "NodeGroupMaxSizeReached"
+	// NodeGroupMinSizeReachedEventReason is the reason of events emitted on unneeded nodes
+	// which aren't removed because their node group is at its min size.
+	NodeGroupMinSizeReachedEventReason = "NodeGroupMinSizeReached"
+)
+
+// NodeGroupMaxSizeScaleUpStatusProcessor emits a warning event on pods which remain pending
+// while node groups are at their max size, and keeps a count of such pods per node group.
+// Node groups at their max size are skipped before pods are simulated on them, so they are
+// reported for every pending pod which no other node group could help. An event is emitted
+// once per pod, until the set of node groups blocking it changes.
+type NodeGroupMaxSizeScaleUpStatusProcessor struct {
+	wrapped ScaleUpStatusProcessor
+	// blocked holds the node groups blocking each pod, as reported in the last event.
+	blocked map[types.UID]string
+
+	updatePendingPods func(pendingPods map[string]int)
+}
+
+// NewNodeGroupMaxSizeScaleUpStatusProcessor creates a new instance of NodeGroupMaxSizeScaleUpStatusProcessor.
+func NewNodeGroupMaxSizeScaleUpStatusProcessor(wrapped ScaleUpStatusProcessor) *NodeGroupMaxSizeScaleUpStatusProcessor {
+	return &NodeGroupMaxSizeScaleUpStatusProcessor{
+		wrapped:           wrapped,
+		blocked:           map[types.UID]string{},
+		updatePendingPods: metrics.UpdateNodeGroupMaxSizeReachedPendingPods,
+	}
+}
+
+// Process emits events on pods blocked by node groups at their max size and updates the metric.
+func (p *NodeGroupMaxSizeScaleUpStatusProcessor) Process(context *context.AutoscalingContext, scaleUpStatus *ScaleUpStatus) {
+	if p.wrapped != nil {
+		p.wrapped.Process(context, scaleUpStatus)
+	}
+	if scaleUpStatus.Result == ScaleUpNotTried {
+		return
+	}
+	atMaxSize := map[string]int{}
+	for _, nodeGroup := range scaleUpStatus.ConsideredNodeGroups {
+		if !nodeGroup.Exist() {
+			continue
+		}
+		targetSize, err := nodeGroup.TargetSize()
+		if err != nil {
+			klog.Warningf("Failed to get target size of node group %s: %v", nodeGroup.Id(), err)
+			continue
+		}
+		if targetSize >= nodeGroup.MaxSize() {
+			atMaxSize[nodeGroup.Id()] = nodeGroup.MaxSize()
+		}
+	}
+
+	pendingPods := map[string]int{}
+	blocked := map[types.UID]string{}
+	for _, noScaleUpInfo := range scaleUpStatus.PodsRemainUnschedulable {
+		var nodeGroups []string
+		for nodeGroupId := range noScaleUpInfo.SkippedNodeGroups {
+			if _, found := atMaxSize[nodeGroupId]; found {
+				nodeGroups = append(nodeGroups, nodeGroupId)
+			}
+		}
+		if len(nodeGroups) == 0 {
+			continue
+		}
+		sort.Strings(nodeGroups)
+		limits := make([]string, 0, len(nodeGroups))
+		for _, nodeGroupId := range nodeGroups {
+			pendingPods[nodeGroupId]++
+			limits = append(limits, fmt.Sprintf("%s (max %d)", nodeGroupId, atMaxSize[nodeGroupId]))
+		}
+		pod := noScaleUpInfo.Pod
+		message := strings.Join(limits, ", ")
+		blocked[pod.UID] = message
+		if p.blocked[pod.UID] != message {
+			context.Recorder.Eventf(pod, apiv1.EventTypeWarning, NodeGroupMaxSizeReachedEventReason,
+				"pod didn't trigger scale-up: node groups reached their max size: %s", message)
+		}
+	}
+	p.blocked = blocked
+	p.updatePendingPods(pendingPods)
+}
+
+// CleanUp cleans up the processor's internal structures.
+func (p *NodeGroupMaxSizeScaleUpStatusProcessor) CleanUp() {
+	if p.wrapped != nil {
+		p.wrapped.CleanUp()
+	}
+}
+
+// NodeGroupMinSizeScaleDownStatusProcessor emits a warning event on unneeded nodes which
+// aren't removed because their node group is at its min size, and keeps a count of such
+// nodes per node group. An event is emitted once per node, until it stops being blocked.
+type NodeGroupMinSizeScaleDownStatusProcessor struct {
+	wrapped ScaleDownStatusProcessor
+	// blocked holds the nodes blocked in the last loop.
+	blocked map[string]bool
+
+	updateUnneededNodes func(unneededNodes map[string]int)
+}
+
+// NewNodeGroupMinSizeScaleDownStatusProcessor creates a new instance of NodeGroupMinSizeScaleDownStatusProcessor.
+func NewNodeGroupMinSizeScaleDownStatusProcessor(wrapped ScaleDownStatusProcessor) *NodeGroupMinSizeScaleDownStatusProcessor {
+	return &NodeGroupMinSizeScaleDownStatusProcessor{
+		wrapped:             wrapped,
+		blocked:             map[string]bool{},
+		updateUnneededNodes: metrics.UpdateNodeGroupMinSizeReachedUnneededNodes,
+	}
+}
+
+// Process emits events on nodes blocked by node groups at their min size and updates the metric.
+func (p *NodeGroupMinSizeScaleDownStatusProcessor) Process(context *context.AutoscalingContext, scaleDownStatus *status.ScaleDownStatus) {
+	if p.wrapped != nil {
+		p.wrapped.Process(context, scaleDownStatus)
+	}
+	if scaleDownStatus.Result == status.ScaleDownNotTried {
+		return
+	}
+	unneededNodes := map[string]int{}
+	blocked := map[string]bool{}
+	for _, unremovableNode := range scaleDownStatus.UnremovableNodes {
+		if unremovableNode.Reason != simulator.NodeGroupMinSizeReached {
+			continue
+		}
+		nodeGroupId := nodeGroupIdOrEmpty(unremovableNode.NodeGroup)
+		if nodeGroupId == "" {
+			continue
+		}
+		node := unremovableNode.Node
+		unneededNodes[nodeGroupId]++
+		blocked[node.Name] = true
+		if !p.blocked[node.Name] {
+			context.Recorder.Eventf(node, apiv1.EventTypeWarning, NodeGroupMinSizeReachedEventReason,
+				"node is unneeded, but isn't removed because node group %s reached its min size %d",
+				nodeGroupId, unremovableNode.NodeGroup.MinSize())
+		}
+	}
+	p.blocked = blocked
+	p.updateUnneededNodes(unneededNodes)
+}
+
+// CleanUp cleans up the processor's internal structures.
+func (p *NodeGroupMinSizeScaleDownStatusProcessor) CleanUp() {
+	if p.wrapped != nil {
+		p.wrapped.CleanUp()
+	}
+}
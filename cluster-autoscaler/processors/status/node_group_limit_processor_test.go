@@ -0,0 +1,151 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	kube_record "k8s.io/client-go/tools/record"
+)
+
+func drainEvents(recorder *kube_record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestNodeGroupMaxSizeScaleUpStatusProcessor(t *testing.T) {
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 0, 2, 2)
+	provider.AddNodeGroup("ng2", 0, 3, 3)
+	provider.AddNodeGroup("ng3", 0, 10, 1)
+	nodeGroups := provider.NodeGroups()
+	maxSizeReached := &testReason{message: "max node group size reached"}
+	atMaxSize := map[string]Reasons{"ng1": maxSizeReached, "ng2": maxSizeReached}
+	p1 := BuildTestPod("p1", 100, 100)
+	p1.UID = "p1"
+	p2 := BuildTestPod("p2", 100, 100)
+	p2.UID = "p2"
+
+	recorder := kube_record.NewFakeRecorder(10)
+	autoscalingContext := &context.AutoscalingContext{
+		CloudProvider:          provider,
+		AutoscalingKubeClients: context.AutoscalingKubeClients{Recorder: recorder},
+	}
+	var pendingPods []map[string]int
+	p := NewNodeGroupMaxSizeScaleUpStatusProcessor(nil)
+	p.updatePendingPods = func(pods map[string]int) { pendingPods = append(pendingPods, pods) }
+
+	p.Process(autoscalingContext, &ScaleUpStatus{
+		Result:               ScaleUpNoOptionsAvailable,
+		ConsideredNodeGroups: nodeGroups,
+		PodsRemainUnschedulable: []NoScaleUpInfo{
+			{Pod: p1, SkippedNodeGroups: atMaxSize},
+			{Pod: p2, SkippedNodeGroups: map[string]Reasons{"ng3": &testReason{message: "in backoff"}}},
+		},
+	})
+	assert.Equal(t, []string{"Warning NodeGroupMaxSizeReached pod didn't trigger scale-up: node groups reached their max size: ng1 (max 2), ng2 (max 3)"}, drainEvents(recorder))
+	assert.Equal(t, []map[string]int{{"ng1": 1, "ng2": 1}}, pendingPods)
+
+	// The event isn't repeated while the pod is blocked by the same node groups.
+	p.Process(autoscalingContext, &ScaleUpStatus{
+		Result:                  ScaleUpNoOptionsAvailable,
+		ConsideredNodeGroups:    nodeGroups,
+		PodsRemainUnschedulable: []NoScaleUpInfo{{Pod: p1, SkippedNodeGroups: atMaxSize}},
+	})
+	assert.Empty(t, drainEvents(recorder))
+
+	// Loops in which scale-up wasn't tried don't change anything.
+	p.Process(autoscalingContext, &ScaleUpStatus{Result: ScaleUpNotTried})
+	assert.Len(t, pendingPods, 2)
+
+	// Once ng1 is scaled down, only ng2 blocks the pod.
+	provider.GetNodeGroup("ng1").(*testprovider.TestNodeGroup).SetTargetSize(1)
+	p.Process(autoscalingContext, &ScaleUpStatus{
+		Result:                  ScaleUpNoOptionsAvailable,
+		ConsideredNodeGroups:    nodeGroups,
+		PodsRemainUnschedulable: []NoScaleUpInfo{{Pod: p1, SkippedNodeGroups: atMaxSize}},
+	})
+	assert.Equal(t, []string{"Warning NodeGroupMaxSizeReached pod didn't trigger scale-up: node groups reached their max size: ng2 (max 3)"}, drainEvents(recorder))
+	assert.Equal(t, map[string]int{"ng2": 1}, pendingPods[len(pendingPods)-1])
+
+	p.Process(autoscalingContext, &ScaleUpStatus{Result: ScaleUpNotNeeded, ConsideredNodeGroups: nodeGroups})
+	assert.Empty(t, drainEvents(recorder))
+	assert.Equal(t, map[string]int{}, pendingPods[len(pendingPods)-1])
+}
+
+func TestNodeGroupMinSizeScaleDownStatusProcessor(t *testing.T) {
+	n1 := BuildTestNode("n1", 1000, 1000)
+	n2 := BuildTestNode("n2", 1000, 1000)
+	n3 := BuildTestNode("n3", 1000, 1000)
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 2, 10, 2)
+	provider.AddNode("ng1", n1)
+	provider.AddNode("ng1", n2)
+	provider.AddNodeGroup("ng2", 0, 10, 1)
+	provider.AddNode("ng2", n3)
+	ng1 := provider.GetNodeGroup("ng1")
+
+	recorder := kube_record.NewFakeRecorder(10)
+	autoscalingContext := &context.AutoscalingContext{
+		CloudProvider:          provider,
+		AutoscalingKubeClients: context.AutoscalingKubeClients{Recorder: recorder},
+	}
+	var unneededNodes []map[string]int
+	p := NewNodeGroupMinSizeScaleDownStatusProcessor(nil)
+	p.updateUnneededNodes = func(nodes map[string]int) { unneededNodes = append(unneededNodes, nodes) }
+
+	p.Process(autoscalingContext, &status.ScaleDownStatus{
+		Result: status.ScaleDownNoNodeDeleted,
+		UnremovableNodes: []*status.UnremovableNode{
+			{Node: n1, NodeGroup: ng1, Reason: simulator.NodeGroupMinSizeReached},
+			{Node: n2, NodeGroup: ng1, Reason: simulator.NodeGroupMinSizeReached},
+			{Node: n3, NodeGroup: provider.GetNodeGroup("ng2"), Reason: simulator.NotUnderutilized},
+		},
+	})
+	assert.Equal(t, []string{
+		"Warning NodeGroupMinSizeReached node is unneeded, but isn't removed because node group ng1 reached its min size 2",
+		"Warning NodeGroupMinSizeReached node is unneeded, but isn't removed because node group ng1 reached its min size 2",
+	}, drainEvents(recorder))
+	assert.Equal(t, []map[string]int{{"ng1": 2}}, unneededNodes)
+
+	// Only nodes which weren't blocked in the previous loop get an event.
+	p.Process(autoscalingContext, &status.ScaleDownStatus{
+		Result:           status.ScaleDownNoNodeDeleted,
+		UnremovableNodes: []*status.UnremovableNode{{Node: n2, NodeGroup: ng1, Reason: simulator.NodeGroupMinSizeReached}},
+	})
+	p.Process(autoscalingContext, &status.ScaleDownStatus{Result: status.ScaleDownNotTried})
+	p.Process(autoscalingContext, &status.ScaleDownStatus{
+		Result:           status.ScaleDownNoNodeDeleted,
+		UnremovableNodes: []*status.UnremovableNode{{Node: n1, NodeGroup: ng1, Reason: simulator.NodeGroupMinSizeReached}},
+	})
+	assert.Equal(t, []string{"Warning NodeGroupMinSizeReached node is unneeded, but isn't removed because node group ng1 reached its min size 2"}, drainEvents(recorder))
+	assert.Equal(t, []map[string]int{{"ng1": 2}, {"ng1": 1}, {"ng1": 1}}, unneededNodes)
+}
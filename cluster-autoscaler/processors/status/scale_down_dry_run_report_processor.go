@@ -0,0 +1,253 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	ctx "context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	kube_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// ScaleDownDryRunReportConfigMapName is the name of the ConfigMap the scale-down dry-run report is written to.
+	ScaleDownDryRunReportConfigMapName = "cluster-autoscaler-scale-down-dry-run"
+	// ScaleDownDryRunReportKey is the ConfigMap key holding the scale-down dry-run report.
+	ScaleDownDryRunReportKey = "report"
+)
+
+// ScaleDownDryRunReport describes what scale-down would do if dry-run wasn't enabled.
+type ScaleDownDryRunReport struct {
+	Time time.Time `json:"time"`
+	// CandidateNodes lists nodes that are unneeded and would be removed once eligible.
+	CandidateNodes []ScaleDownDryRunCandidate `json:"candidateNodes"`
+	// UnremovableNodes lists nodes that can't be removed, along with the reasons.
+	UnremovableNodes []ScaleDownDryRunUnremovableNode `json:"unremovableNodes"`
+	// EstimatedSavings sums up resources and cost of all candidate nodes.
+	EstimatedSavings ScaleDownDryRunSavings `json:"estimatedSavings"`
+}
+
+// ScaleDownDryRunCandidate is a node that would be removed by scale-down.
+type ScaleDownDryRunCandidate struct {
+	Node      string `json:"node"`
+	NodeGroup string `json:"nodeGroup,omitempty"`
+	// WouldBeDeleted is true if the node would be deleted in the last loop. Other
+	// candidates weren't unneeded for long enough or were cropped by deletion budgets.
+	WouldBeDeleted bool `json:"wouldBeDeleted"`
+	// PodsToEvict lists pods that would be evicted from the node, if it would be deleted.
+	PodsToEvict []string `json:"podsToEvict,omitempty"`
+	// HourlyPrice is set if the cloud provider supports pricing.
+	HourlyPrice *float64 `json:"hourlyPrice,omitempty"`
+}
+
+// ScaleDownDryRunUnremovableNode is a node that can't be removed by scale-down.
+type ScaleDownDryRunUnremovableNode struct {
+	Node              string `json:"node"`
+	NodeGroup         string `json:"nodeGroup,omitempty"`
+	Reason            string `json:"reason"`
+	BlockingPod       string `json:"blockingPod,omitempty"`
+	BlockingPodReason string `json:"blockingPodReason,omitempty"`
+}
+
+// ScaleDownDryRunSavings sums up resources and cost of candidate nodes.
+type ScaleDownDryRunSavings struct {
+	Nodes  int               `json:"nodes"`
+	CPU    resource.Quantity `json:"cpu"`
+	Memory resource.Quantity `json:"memory"`
+	// HourlyCost is set if the cloud provider supports pricing.
+	HourlyCost *float64 `json:"hourlyCost,omitempty"`
+}
+
+// ScaleDownDryRunReportProcessor periodically writes a report of what scale-down
+// would do to a file or a ConfigMap. It is meant to be used along with the
+// scale-down dry-run mode.
+type ScaleDownDryRunReportProcessor struct {
+	wrapped    ScaleDownStatusProcessor
+	reportFile string
+	interval   time.Duration
+	lastReport time.Time
+	now        func() time.Time
+}
+
+// NewScaleDownDryRunReportProcessor creates a new instance of ScaleDownDryRunReportProcessor.
+// If reportFile is empty, the report is written to the ScaleDownDryRunReportConfigMapName ConfigMap.
+func NewScaleDownDryRunReportProcessor(wrapped ScaleDownStatusProcessor, reportFile string, interval time.Duration) *ScaleDownDryRunReportProcessor {
+	return &ScaleDownDryRunReportProcessor{
+		wrapped:    wrapped,
+		reportFile: reportFile,
+		interval:   interval,
+		now:        time.Now,
+	}
+}
+
+// Process writes the report if the interval has passed since the last one.
+func (p *ScaleDownDryRunReportProcessor) Process(context *context.AutoscalingContext, scaleDownStatus *status.ScaleDownStatus) {
+	if p.wrapped != nil {
+		p.wrapped.Process(context, scaleDownStatus)
+	}
+	if scaleDownStatus.Result == status.ScaleDownNotTried {
+		return
+	}
+	now := p.now()
+	if now.Sub(p.lastReport) < p.interval {
+		return
+	}
+	report, err := json.MarshalIndent(BuildScaleDownDryRunReport(context, scaleDownStatus, now), "", "  ")
+	if err != nil {
+		klog.Errorf("Failed to marshal scale-down dry-run report: %v", err)
+		return
+	}
+	if p.reportFile != "" {
+		err = writeReportFile(p.reportFile, report)
+	} else {
+		err = writeReportConfigMap(context, string(report))
+	}
+	if err != nil {
+		klog.Errorf("Failed to write scale-down dry-run report: %v", err)
+		return
+	}
+	p.lastReport = now
+}
+
+// CleanUp cleans up the processor's internal structures.
+func (p *ScaleDownDryRunReportProcessor) CleanUp() {
+	if p.wrapped != nil {
+		p.wrapped.CleanUp()
+	}
+}
+
+// BuildScaleDownDryRunReport builds a ScaleDownDryRunReport out of the scale-down status.
+func BuildScaleDownDryRunReport(context *context.AutoscalingContext, scaleDownStatus *status.ScaleDownStatus, now time.Time) *ScaleDownDryRunReport {
+	report := &ScaleDownDryRunReport{
+		Time:             now,
+		CandidateNodes:   []ScaleDownDryRunCandidate{},
+		UnremovableNodes: []ScaleDownDryRunUnremovableNode{},
+	}
+	pricing, err := context.CloudProvider.Pricing()
+	if err != nil {
+		klog.V(4).Infof("Scale-down dry-run report won't include prices: %v", err)
+		pricing = nil
+	} else {
+		report.EstimatedSavings.HourlyCost = new(float64)
+	}
+
+	wouldBeDeleted := make(map[string]*status.ScaleDownNode, len(scaleDownStatus.DryRunScaledDownNodes))
+	for _, sdNode := range scaleDownStatus.DryRunScaledDownNodes {
+		wouldBeDeleted[sdNode.Node.Name] = sdNode
+	}
+	for _, node := range scaleDownStatus.UnneededNodes {
+		candidate := ScaleDownDryRunCandidate{Node: node.Name, NodeGroup: nodeGroupId(context, node)}
+		if sdNode, found := wouldBeDeleted[node.Name]; found {
+			candidate.WouldBeDeleted = true
+			for _, pod := range sdNode.EvictedPods {
+				candidate.PodsToEvict = append(candidate.PodsToEvict, pod.Namespace+"/"+pod.Name)
+			}
+		}
+		if pricing != nil {
+			if price, err := pricing.NodePrice(node, now, now.Add(time.Hour)); err == nil {
+				candidate.HourlyPrice = &price
+				*report.EstimatedSavings.HourlyCost += price
+			} else {
+				klog.V(4).Infof("Couldn't get price of node %s: %v", node.Name, err)
+			}
+		}
+		report.EstimatedSavings.Nodes++
+		report.EstimatedSavings.CPU.Add(*node.Status.Allocatable.Cpu())
+		report.EstimatedSavings.Memory.Add(*node.Status.Allocatable.Memory())
+		report.CandidateNodes = append(report.CandidateNodes, candidate)
+	}
+	sort.Slice(report.CandidateNodes, func(i, j int) bool { return report.CandidateNodes[i].Node < report.CandidateNodes[j].Node })
+
+	for _, unremovableNode := range scaleDownStatus.UnremovableNodes {
+		node := ScaleDownDryRunUnremovableNode{
+			Node:   unremovableNode.Node.Name,
+			Reason: unremovableNode.Reason.String(),
+		}
+		if nodeGroup := unremovableNode.NodeGroup; nodeGroup != nil && !reflect.ValueOf(nodeGroup).IsNil() {
+			node.NodeGroup = nodeGroup.Id()
+		}
+		if unremovableNode.BlockingPod != nil {
+			node.BlockingPod = unremovableNode.BlockingPod.Pod.Namespace + "/" + unremovableNode.BlockingPod.Pod.Name
+			node.BlockingPodReason = unremovableNode.BlockingPod.Reason.String()
+		}
+		report.UnremovableNodes = append(report.UnremovableNodes, node)
+	}
+	sort.Slice(report.UnremovableNodes, func(i, j int) bool { return report.UnremovableNodes[i].Node < report.UnremovableNodes[j].Node })
+	return report
+}
+
+func nodeGroupId(context *context.AutoscalingContext, node *apiv1.Node) string {
+	nodeGroup, err := context.CloudProvider.NodeGroupForNode(node)
+	if err != nil || nodeGroup == nil || reflect.ValueOf(nodeGroup).IsNil() {
+		return ""
+	}
+	return nodeGroup.Id()
+}
+
+// writeReportFile replaces the file atomically, so that readers never see a partial report.
+func writeReportFile(path string, report []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(report); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func writeReportConfigMap(context *context.AutoscalingContext, report string) error {
+	maps := context.ClientSet.CoreV1().ConfigMaps(context.ConfigNamespace)
+	configMap, err := maps.Get(ctx.TODO(), ScaleDownDryRunReportConfigMapName, metav1.GetOptions{})
+	if kube_errors.IsNotFound(err) {
+		configMap = &apiv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: context.ConfigNamespace,
+				Name:      ScaleDownDryRunReportConfigMapName,
+			},
+		}
+		configMap.Data = map[string]string{ScaleDownDryRunReportKey: report}
+		_, err = maps.Create(ctx.TODO(), configMap, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to retrieve ConfigMap: %v", err)
+	}
+	if configMap.Data == nil {
+		configMap.Data = make(map[string]string)
+	}
+	configMap.Data[ScaleDownDryRunReportKey] = report
+	_, err = maps.Update(ctx.TODO(), configMap, metav1.UpdateOptions{})
+	return err
+}
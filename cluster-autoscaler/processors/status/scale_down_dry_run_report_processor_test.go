@@ -0,0 +1,171 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	ctx "context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type testNodePricingModel map[string]float64
+
+func (m testNodePricingModel) NodePrice(node *apiv1.Node, startTime time.Time, endTime time.Time) (float64, error) {
+	if price, found := m[node.Name]; found {
+		return price * endTime.Sub(startTime).Hours(), nil
+	}
+	return 0, fmt.Errorf("unknown node %s", node.Name)
+}
+
+func (m testNodePricingModel) PodPrice(pod *apiv1.Pod, startTime time.Time, endTime time.Time) (float64, error) {
+	return 0, nil
+}
+
+func TestBuildScaleDownDryRunReport(t *testing.T) {
+	n1 := BuildTestNode("n1", 1000, 1000)
+	n2 := BuildTestNode("n2", 2000, 1000)
+	n3 := BuildTestNode("n3", 1000, 1000)
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 0, 10, 3)
+	provider.AddNode("ng1", n1)
+	provider.AddNode("ng1", n2)
+	provider.AddNode("ng1", n3)
+	ng1 := provider.GetNodeGroup("ng1")
+	p1 := BuildTestPod("p1", 100, 0)
+	blocking := BuildTestPod("blocking", 100, 0)
+	now := time.Now()
+
+	scaleDownStatus := &status.ScaleDownStatus{
+		Result:                status.ScaleDownNoNodeDeleted,
+		UnneededNodes:         []*apiv1.Node{n2, n1},
+		DryRunScaledDownNodes: []*status.ScaleDownNode{{Node: n1, NodeGroup: ng1, EvictedPods: []*apiv1.Pod{p1}}},
+		UnremovableNodes: []*status.UnremovableNode{
+			{Node: n3, NodeGroup: ng1, Reason: simulator.BlockedByPod, BlockingPod: &drain.BlockingPod{Pod: blocking, Reason: drain.NotReplicated}},
+		},
+	}
+	want := &ScaleDownDryRunReport{
+		Time: now,
+		CandidateNodes: []ScaleDownDryRunCandidate{
+			{Node: "n1", NodeGroup: "ng1", WouldBeDeleted: true, PodsToEvict: []string{"default/p1"}},
+			{Node: "n2", NodeGroup: "ng1"},
+		},
+		UnremovableNodes: []ScaleDownDryRunUnremovableNode{
+			{Node: "n3", NodeGroup: "ng1", Reason: "BlockedByPod", BlockingPod: "default/blocking", BlockingPodReason: "NotReplicated"},
+		},
+		EstimatedSavings: ScaleDownDryRunSavings{
+			Nodes:  2,
+			CPU:    resource.MustParse("3"),
+			Memory: resource.MustParse("2000"),
+		},
+	}
+
+	autoscalingContext := &context.AutoscalingContext{CloudProvider: provider}
+	report := BuildScaleDownDryRunReport(autoscalingContext, scaleDownStatus, now)
+	assert.Equal(t, want.CandidateNodes, report.CandidateNodes)
+	assert.Equal(t, want.UnremovableNodes, report.UnremovableNodes)
+	assert.Equal(t, want.EstimatedSavings.Nodes, report.EstimatedSavings.Nodes)
+	assert.True(t, want.EstimatedSavings.CPU.Equal(report.EstimatedSavings.CPU))
+	assert.True(t, want.EstimatedSavings.Memory.Equal(report.EstimatedSavings.Memory))
+	assert.Nil(t, report.EstimatedSavings.HourlyCost)
+
+	provider.SetPricingModel(testNodePricingModel{"n1": 0.5, "n2": 1.25})
+	report = BuildScaleDownDryRunReport(autoscalingContext, scaleDownStatus, now)
+	assert.Equal(t, 0.5, *report.CandidateNodes[0].HourlyPrice)
+	assert.Equal(t, 1.25, *report.CandidateNodes[1].HourlyPrice)
+	assert.Equal(t, 1.75, *report.EstimatedSavings.HourlyCost)
+}
+
+func TestScaleDownDryRunReportProcessor(t *testing.T) {
+	n1 := BuildTestNode("n1", 1000, 1000)
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 0, 10, 1)
+	provider.AddNode("ng1", n1)
+
+	for _, toFile := range []bool{true, false} {
+		t.Run(fmt.Sprintf("to file: %v", toFile), func(t *testing.T) {
+			client := fake.NewSimpleClientset()
+			autoscalingContext := &context.AutoscalingContext{
+				AutoscalingOptions:     config.AutoscalingOptions{ConfigNamespace: "kube-system"},
+				AutoscalingKubeClients: context.AutoscalingKubeClients{ClientSet: client},
+				CloudProvider:          provider,
+			}
+			reportFile := ""
+			if toFile {
+				reportFile = filepath.Join(t.TempDir(), "report.json")
+			}
+			readReport := func() *ScaleDownDryRunReport {
+				var data []byte
+				if toFile {
+					var err error
+					data, err = os.ReadFile(reportFile)
+					if os.IsNotExist(err) {
+						return nil
+					}
+					assert.NoError(t, err)
+				} else {
+					configMap, err := client.CoreV1().ConfigMaps("kube-system").Get(ctx.TODO(), ScaleDownDryRunReportConfigMapName, metav1.GetOptions{})
+					if err != nil {
+						return nil
+					}
+					data = []byte(configMap.Data[ScaleDownDryRunReportKey])
+				}
+				report := &ScaleDownDryRunReport{}
+				assert.NoError(t, json.Unmarshal(data, report))
+				return report
+			}
+
+			now := time.Now()
+			p := NewScaleDownDryRunReportProcessor(NewDefaultScaleDownStatusProcessor(), reportFile, time.Minute)
+			p.now = func() time.Time { return now }
+
+			p.Process(autoscalingContext, &status.ScaleDownStatus{Result: status.ScaleDownNotTried, UnneededNodes: []*apiv1.Node{n1}})
+			assert.Nil(t, readReport())
+
+			p.Process(autoscalingContext, &status.ScaleDownStatus{Result: status.ScaleDownNoNodeDeleted, UnneededNodes: []*apiv1.Node{n1}})
+			report := readReport()
+			assert.NotNil(t, report)
+			assert.Equal(t, 1, len(report.CandidateNodes))
+
+			// Reports are not written more often than the interval.
+			now = now.Add(30 * time.Second)
+			p.Process(autoscalingContext, &status.ScaleDownStatus{Result: status.ScaleDownNoNodeDeleted})
+			assert.Equal(t, 1, len(readReport().CandidateNodes))
+
+			now = now.Add(time.Minute)
+			p.Process(autoscalingContext, &status.ScaleDownStatus{Result: status.ScaleDownNoNodeDeleted})
+			assert.Equal(t, 0, len(readReport().CandidateNodes))
+		})
+	}
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/metrics"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// defaultScaleUpLatencyTrackingTime is how long nodes and pods of a scale-up are waited for
+// if the max node provision time isn't configured.
+const defaultScaleUpLatencyTrackingTime = 15 * time.Minute
+
+// triggeringPod is a pod which triggered a scale-up, waiting to be scheduled.
+type triggeringPod struct {
+	nodeGroups   map[string]bool
+	pendingSince time.Time
+	requestTime  time.Time
+}
+
+// ScaleUpLatencyStatusProcessor observes, per node group, the time from a scale-up
+// request to each node added by it becoming ready, and the time from each pod which
+// triggered the scale-up becoming pending to it being scheduled on a node of the
+// node group. Nodes and pods are waited for up to the max node provision time.
+type ScaleUpLatencyStatusProcessor struct {
+	wrapped ScaleUpStatusProcessor
+	// requests holds the request time of each node requested and not ready yet, oldest first, by node group.
+	requests map[string][]time.Time
+	// observedNodes are the nodes already matched with a request.
+	observedNodes map[string]bool
+	pods          map[types.UID]triggeringPod
+	now           func() time.Time
+
+	observeNodeReady    func(nodeGroup string, latency time.Duration)
+	observePodScheduled func(nodeGroup string, latency time.Duration)
+}
+
+// NewScaleUpLatencyStatusProcessor creates a new instance of ScaleUpLatencyStatusProcessor.
+func NewScaleUpLatencyStatusProcessor(wrapped ScaleUpStatusProcessor) *ScaleUpLatencyStatusProcessor {
+	return &ScaleUpLatencyStatusProcessor{
+		wrapped:             wrapped,
+		requests:            map[string][]time.Time{},
+		observedNodes:       map[string]bool{},
+		pods:                map[types.UID]triggeringPod{},
+		now:                 time.Now,
+		observeNodeReady:    metrics.ObserveNodeGroupScaleUpNodeReady,
+		observePodScheduled: metrics.ObserveNodeGroupScaleUpPodScheduled,
+	}
+}
+
+// Process registers the nodes and pods of a successful scale-up, and observes the
+// latencies of the nodes which became ready and the pods which got scheduled since
+// the previous loop.
+func (p *ScaleUpLatencyStatusProcessor) Process(context *context.AutoscalingContext, scaleUpStatus *ScaleUpStatus) {
+	if p.wrapped != nil {
+		p.wrapped.Process(context, scaleUpStatus)
+	}
+	now := p.now()
+	if scaleUpStatus.Result == ScaleUpSuccessful {
+		p.registerScaleUp(scaleUpStatus, now)
+	}
+	trackingTime := context.NodeGroupDefaults.MaxNodeProvisionTime
+	if trackingTime <= 0 {
+		trackingTime = defaultScaleUpLatencyTrackingTime
+	}
+	p.expire(now.Add(-trackingTime))
+	if len(p.requests) == 0 && len(p.pods) == 0 {
+		p.observedNodes = map[string]bool{}
+		return
+	}
+
+	nodes, err := context.AllNodeLister().List()
+	if err != nil {
+		klog.Warningf("Failed to list nodes for scale-up latency metrics: %v", err)
+		return
+	}
+	nodeGroups := p.observeReadyNodes(context, nodes)
+	if len(p.pods) == 0 {
+		return
+	}
+	pods, err := context.AllPodLister().List()
+	if err != nil {
+		klog.Warningf("Failed to list pods for scale-up latency metrics: %v", err)
+		return
+	}
+	p.observeScheduledPods(context, pods, nodes, nodeGroups, now)
+}
+
+func (p *ScaleUpLatencyStatusProcessor) registerScaleUp(scaleUpStatus *ScaleUpStatus, now time.Time) {
+	nodeGroups := map[string]bool{}
+	for _, info := range scaleUpStatus.ScaleUpInfos {
+		nodeGroups[info.Group.Id()] = true
+		for i := info.CurrentSize; i < info.NewSize; i++ {
+			p.requests[info.Group.Id()] = append(p.requests[info.Group.Id()], now)
+		}
+	}
+	for _, pod := range scaleUpStatus.PodsTriggeredScaleUp {
+		p.pods[pod.UID] = triggeringPod{
+			nodeGroups:   nodeGroups,
+			pendingSince: pendingSince(pod),
+			requestTime:  now,
+		}
+	}
+}
+
+// expire stops waiting for nodes and pods of scale-ups requested before the given time.
+func (p *ScaleUpLatencyStatusProcessor) expire(requestedBefore time.Time) {
+	for nodeGroupId, requests := range p.requests {
+		for len(requests) > 0 && requests[0].Before(requestedBefore) {
+			requests = requests[1:]
+		}
+		if len(requests) == 0 {
+			delete(p.requests, nodeGroupId)
+		} else {
+			p.requests[nodeGroupId] = requests
+		}
+	}
+	for uid, pod := range p.pods {
+		if pod.requestTime.Before(requestedBefore) {
+			delete(p.pods, uid)
+		}
+	}
+}
+
+// observeReadyNodes matches ready nodes, created after a pending request of their
+// node group, with the oldest such request. It returns the node group ids of the
+// nodes it looked up, by node name.
+func (p *ScaleUpLatencyStatusProcessor) observeReadyNodes(context *context.AutoscalingContext, nodes []*apiv1.Node) map[string]string {
+	nodeGroups := map[string]string{}
+	existing := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		existing[node.Name] = true
+		if p.observedNodes[node.Name] {
+			continue
+		}
+		id := nodeGroupId(context, node)
+		if id == "" {
+			continue
+		}
+		nodeGroups[node.Name] = id
+		requests := p.requests[id]
+		if len(requests) == 0 || node.CreationTimestamp.Time.Before(requests[0]) {
+			continue
+		}
+		ready, readyTime, err := kube_util.GetReadinessState(node)
+		if err != nil || !ready {
+			continue
+		}
+		p.observeNodeReady(id, readyTime.Sub(requests[0]))
+		p.observedNodes[node.Name] = true
+		if len(requests) == 1 {
+			delete(p.requests, id)
+		} else {
+			p.requests[id] = requests[1:]
+		}
+	}
+	for name := range p.observedNodes {
+		if !existing[name] {
+			delete(p.observedNodes, name)
+		}
+	}
+	return nodeGroups
+}
+
+// observeScheduledPods observes the pods which triggered a scale-up and got scheduled
+// on a node of a node group scaled up for them.
+func (p *ScaleUpLatencyStatusProcessor) observeScheduledPods(context *context.AutoscalingContext, pods []*apiv1.Pod, nodes []*apiv1.Node, nodeGroups map[string]string, now time.Time) {
+	nodesByName := make(map[string]*apiv1.Node, len(nodes))
+	for _, node := range nodes {
+		nodesByName[node.Name] = node
+	}
+	for _, pod := range pods {
+		tracked, found := p.pods[pod.UID]
+		if !found || pod.Spec.NodeName == "" {
+			continue
+		}
+		delete(p.pods, pod.UID)
+		id, found := nodeGroups[pod.Spec.NodeName]
+		if !found {
+			node, found := nodesByName[pod.Spec.NodeName]
+			if !found {
+				continue
+			}
+			id = nodeGroupId(context, node)
+		}
+		if tracked.nodeGroups[id] {
+			p.observePodScheduled(id, scheduledAt(pod, now).Sub(tracked.pendingSince))
+		}
+	}
+}
+
+// pendingSince returns the time the pod failed to be scheduled at, or its creation time.
+func pendingSince(pod *apiv1.Pod) time.Time {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == apiv1.PodScheduled && condition.Status == apiv1.ConditionFalse && !condition.LastTransitionTime.IsZero() {
+			return condition.LastTransitionTime.Time
+		}
+	}
+	return pod.CreationTimestamp.Time
+}
+
+// scheduledAt returns the time the pod was scheduled at, or now if unknown.
+func scheduledAt(pod *apiv1.Pod, now time.Time) time.Time {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == apiv1.PodScheduled && condition.Status == apiv1.ConditionTrue && !condition.LastTransitionTime.IsZero() {
+			return condition.LastTransitionTime.Time
+		}
+	}
+	return now
+}
+
+// CleanUp cleans up the processor's internal structures.
+func (p *ScaleUpLatencyStatusProcessor) CleanUp() {
+	if p.wrapped != nil {
+		p.wrapped.CleanUp()
+	}
+}
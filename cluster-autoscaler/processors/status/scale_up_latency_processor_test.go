@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/config"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupset"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+type latencyRecorder struct {
+	latencies map[string][]time.Duration
+}
+
+func (r *latencyRecorder) observe(nodeGroup string, latency time.Duration) {
+	r.latencies[nodeGroup] = append(r.latencies[nodeGroup], latency)
+}
+
+func TestScaleUpLatencyStatusProcessor(t *testing.T) {
+	start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	buildNode := func(name string, created time.Time, ready bool, readySince time.Time) *apiv1.Node {
+		node := BuildTestNode(name, 1000, 1000)
+		node.CreationTimestamp = metav1.NewTime(created)
+		SetNodeReadyState(node, ready, readySince)
+		return node
+	}
+	schedule := func(pod *apiv1.Pod, node string, at time.Time) *apiv1.Pod {
+		scheduled := pod.DeepCopy()
+		scheduled.Spec.NodeName = node
+		scheduled.Status.Conditions = []apiv1.PodCondition{{Type: apiv1.PodScheduled, Status: apiv1.ConditionTrue, LastTransitionTime: metav1.NewTime(at)}}
+		return scheduled
+	}
+
+	n0 := buildNode("n0", start.Add(-time.Hour), true, start.Add(-time.Hour))
+	n3 := buildNode("n3", start.Add(-time.Hour), true, start.Add(-time.Hour))
+	n1 := buildNode("n1", start.Add(10*time.Second), true, start.Add(90*time.Second))
+	n2NotReady := buildNode("n2", start.Add(10*time.Second), false, start.Add(10*time.Second))
+	n2 := buildNode("n2", start.Add(10*time.Second), true, start.Add(150*time.Second))
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 0, 10, 3)
+	provider.AddNodeGroup("ng2", 0, 10, 1)
+	provider.AddNode("ng1", n0)
+	provider.AddNode("ng1", n1)
+	provider.AddNode("ng1", n2)
+	provider.AddNode("ng2", n3)
+
+	p1 := BuildTestPod("p1", 100, 0)
+	p1.Status.Conditions = []apiv1.PodCondition{{Type: apiv1.PodScheduled, Status: apiv1.ConditionFalse, LastTransitionTime: metav1.NewTime(start.Add(-30 * time.Second))}}
+	p2 := BuildTestPod("p2", 100, 0)
+	p2.CreationTimestamp = metav1.NewTime(start.Add(-10 * time.Second))
+
+	nodeReady := &latencyRecorder{latencies: map[string][]time.Duration{}}
+	podScheduled := &latencyRecorder{latencies: map[string][]time.Duration{}}
+	p := NewScaleUpLatencyStatusProcessor(nil)
+	p.observeNodeReady = nodeReady.observe
+	p.observePodScheduled = podScheduled.observe
+	process := func(now time.Time, nodes []*apiv1.Node, pods []*apiv1.Pod, scaleUpStatus *ScaleUpStatus) {
+		p.now = func() time.Time { return now }
+		autoscalingContext := &context.AutoscalingContext{
+			CloudProvider: provider,
+			AutoscalingKubeClients: context.AutoscalingKubeClients{
+				ListerRegistry: kube_util.NewListerRegistry(kube_util.NewTestNodeLister(nodes), nil, kube_util.NewTestPodLister(pods), nil, nil, nil, nil, nil, nil),
+			},
+			AutoscalingOptions: config.AutoscalingOptions{NodeGroupDefaults: config.NodeGroupAutoscalingOptions{MaxNodeProvisionTime: 10 * time.Minute}},
+		}
+		p.Process(autoscalingContext, scaleUpStatus)
+	}
+
+	process(start, []*apiv1.Node{n0, n3}, []*apiv1.Pod{p1, p2}, &ScaleUpStatus{
+		Result:               ScaleUpSuccessful,
+		ScaleUpInfos:         []nodegroupset.ScaleUpInfo{{Group: provider.GetNodeGroup("ng1"), CurrentSize: 1, NewSize: 3, MaxSize: 10}},
+		PodsTriggeredScaleUp: []*apiv1.Pod{p1, p2},
+	})
+	assert.Empty(t, nodeReady.latencies)
+	assert.Empty(t, podScheduled.latencies)
+
+	// n1 became ready, and p1 got scheduled on it. Old nodes aren't matched with the scale-up.
+	process(start.Add(2*time.Minute), []*apiv1.Node{n0, n1, n2NotReady, n3}, []*apiv1.Pod{schedule(p1, "n1", start.Add(100*time.Second)), p2}, &ScaleUpStatus{Result: ScaleUpNotNeeded})
+	assert.Equal(t, map[string][]time.Duration{"ng1": {90 * time.Second}}, nodeReady.latencies)
+	assert.Equal(t, map[string][]time.Duration{"ng1": {130 * time.Second}}, podScheduled.latencies)
+
+	// n2 became ready, and p2 got scheduled on a node of a node group which wasn't scaled up for it.
+	process(start.Add(3*time.Minute), []*apiv1.Node{n0, n1, n2, n3}, []*apiv1.Pod{schedule(p1, "n1", start.Add(100*time.Second)), schedule(p2, "n3", start.Add(170*time.Second))}, &ScaleUpStatus{Result: ScaleUpNotNeeded})
+	assert.Equal(t, map[string][]time.Duration{"ng1": {90 * time.Second, 150 * time.Second}}, nodeReady.latencies)
+	assert.Equal(t, map[string][]time.Duration{"ng1": {130 * time.Second}}, podScheduled.latencies)
+	assert.Empty(t, p.requests)
+	assert.Empty(t, p.pods)
+
+	// Nodes and pods which don't show up within the max node provision time aren't waited for anymore.
+	process(start.Add(4*time.Minute), []*apiv1.Node{n0, n1, n2, n3}, nil, &ScaleUpStatus{
+		Result:               ScaleUpSuccessful,
+		ScaleUpInfos:         []nodegroupset.ScaleUpInfo{{Group: provider.GetNodeGroup("ng2"), CurrentSize: 1, NewSize: 2, MaxSize: 10}},
+		PodsTriggeredScaleUp: []*apiv1.Pod{BuildTestPod("p3", 100, 0)},
+	})
+	assert.Len(t, p.requests["ng2"], 1)
+	assert.Len(t, p.pods, 1)
+	process(start.Add(15*time.Minute), []*apiv1.Node{n0, n1, n2, n3}, nil, &ScaleUpStatus{Result: ScaleUpNotNeeded})
+	assert.Empty(t, p.requests)
+	assert.Empty(t, p.pods)
+	assert.Empty(t, p.observedNodes)
+}
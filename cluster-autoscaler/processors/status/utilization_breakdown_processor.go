@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	ctx "context"
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// ScaleDownDisabledReasonKey is the name of annotation explaining why a node is not scaled down.
+	ScaleDownDisabledReasonKey = "cluster-autoscaler.kubernetes.io/scale-down-disabled-reason"
+)
+
+// UtilizationBreakdownScaleDownStatusProcessor annotates nodes that are not
+// scaled down because of high utilization with a JSON encoded breakdown of
+// pods holding them. The annotation is removed from nodes that no longer
+// report a breakdown. Nodes are only patched when their annotation changes.
+type UtilizationBreakdownScaleDownStatusProcessor struct {
+	wrapped ScaleDownStatusProcessor
+}
+
+// NewUtilizationBreakdownScaleDownStatusProcessor creates a new instance of UtilizationBreakdownScaleDownStatusProcessor.
+func NewUtilizationBreakdownScaleDownStatusProcessor(wrapped ScaleDownStatusProcessor) *UtilizationBreakdownScaleDownStatusProcessor {
+	return &UtilizationBreakdownScaleDownStatusProcessor{wrapped: wrapped}
+}
+
+// Process updates the scale-down-disabled-reason annotation of nodes.
+func (p *UtilizationBreakdownScaleDownStatusProcessor) Process(context *context.AutoscalingContext, scaleDownStatus *status.ScaleDownStatus) {
+	if p.wrapped != nil {
+		p.wrapped.Process(context, scaleDownStatus)
+	}
+	if scaleDownStatus.Result == status.ScaleDownNotTried {
+		return
+	}
+	nodes, err := context.ListerRegistry.AllNodeLister().List()
+	if err != nil {
+		klog.Errorf("Failed to list nodes to update %s annotations: %v", ScaleDownDisabledReasonKey, err)
+		return
+	}
+	reasons := make(map[string]string)
+	for _, unremovableNode := range scaleDownStatus.UnremovableNodes {
+		if unremovableNode.UtilizationBreakdown == nil {
+			continue
+		}
+		reason, err := json.Marshal(unremovableNode.UtilizationBreakdown)
+		if err != nil {
+			klog.Errorf("Failed to marshal utilization breakdown of node %s: %v", unremovableNode.Node.Name, err)
+			continue
+		}
+		reasons[unremovableNode.Node.Name] = string(reason)
+	}
+
+	for _, node := range nodes {
+		current, annotated := node.Annotations[ScaleDownDisabledReasonKey]
+		reason, found := reasons[node.Name]
+		switch {
+		case found && (!annotated || current != reason):
+			p.patchAnnotation(context, node.Name, &reason)
+		case !found && annotated:
+			p.patchAnnotation(context, node.Name, nil)
+		}
+	}
+}
+
+// CleanUp cleans up the processor's internal structures.
+func (p *UtilizationBreakdownScaleDownStatusProcessor) CleanUp() {
+	if p.wrapped != nil {
+		p.wrapped.CleanUp()
+	}
+}
+
+// patchAnnotation sets the annotation to a given value, or removes it if value is nil.
+func (p *UtilizationBreakdownScaleDownStatusProcessor) patchAnnotation(context *context.AutoscalingContext, nodeName string, value *string) {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]*string{ScaleDownDisabledReasonKey: value},
+		},
+	})
+	if err != nil {
+		klog.Errorf("Failed to build %s annotation patch for node %s: %v", ScaleDownDisabledReasonKey, nodeName, err)
+		return
+	}
+	if _, err := context.ClientSet.CoreV1().Nodes().Patch(ctx.TODO(), nodeName, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		klog.Warningf("Failed to update %s annotation of node %s: %v", ScaleDownDisabledReasonKey, nodeName, err)
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	ctx "context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/utilization"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestUtilizationBreakdownScaleDownStatusProcessor(t *testing.T) {
+	breakdown := &utilization.Breakdown{
+		ResourceName: apiv1.ResourceCPU,
+		Utilization:  0.9,
+		TopPods: []utilization.PodUtilization{
+			{Namespace: "default", Name: "p1", Utilization: map[apiv1.ResourceName]float64{apiv1.ResourceCPU: 0.9}},
+		},
+	}
+	wantReason := `{"resourceName":"cpu","utilization":0.9,"topPods":[{"namespace":"default","name":"p1","utilization":{"cpu":0.9}}]}`
+
+	busy := BuildTestNode("busy", 1000, 1000)
+	stale := BuildTestNode("stale", 1000, 1000)
+	stale.Annotations = map[string]string{ScaleDownDisabledReasonKey: wantReason}
+	annotated := BuildTestNode("busy", 1000, 1000)
+	annotated.Annotations = map[string]string{ScaleDownDisabledReasonKey: wantReason}
+	other := BuildTestNode("other", 1000, 1000)
+
+	testCases := []struct {
+		name        string
+		result      status.ScaleDownResult
+		nodes       []*apiv1.Node
+		wantReasons map[string]string
+		wantPatches int
+	}{
+		{
+			name:        "breakdown is set on highly utilized nodes and cleared from others",
+			result:      status.ScaleDownNoNodeDeleted,
+			nodes:       []*apiv1.Node{busy, stale, other},
+			wantReasons: map[string]string{"busy": wantReason},
+			wantPatches: 2,
+		},
+		{
+			name:        "unchanged annotations are not patched",
+			result:      status.ScaleDownNoNodeDeleted,
+			nodes:       []*apiv1.Node{annotated, other},
+			wantReasons: map[string]string{"busy": wantReason},
+			wantPatches: 0,
+		},
+		{
+			name:        "annotations are left intact if scale-down was not tried",
+			result:      status.ScaleDownNotTried,
+			nodes:       []*apiv1.Node{busy, stale, other},
+			wantReasons: map[string]string{"stale": wantReason},
+			wantPatches: 0,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			nodes := tc.nodes
+			var objects []runtime.Object
+			for _, node := range nodes {
+				objects = append(objects, node.DeepCopy())
+			}
+			client := fake.NewSimpleClientset(objects...)
+			autoscalingContext := &context.AutoscalingContext{
+				AutoscalingKubeClients: context.AutoscalingKubeClients{
+					ClientSet:      client,
+					ListerRegistry: kube_util.NewListerRegistry(kube_util.NewTestNodeLister(nodes), nil, nil, nil, nil, nil, nil, nil, nil),
+				},
+			}
+			scaleDownStatus := &status.ScaleDownStatus{
+				Result: tc.result,
+				UnremovableNodes: []*status.UnremovableNode{
+					{Node: busy, Reason: simulator.NotUnderutilized, UtilizationBreakdown: breakdown},
+					{Node: other, Reason: simulator.NotUnneededLongEnough},
+				},
+			}
+
+			wrapped := &countingScaleDownStatusProcessor{}
+			NewUtilizationBreakdownScaleDownStatusProcessor(wrapped).Process(autoscalingContext, scaleDownStatus)
+			assert.Equal(t, 1, wrapped.processed)
+
+			patches := 0
+			for _, action := range client.Actions() {
+				if action.GetVerb() == "patch" {
+					patches++
+				}
+			}
+			assert.Equal(t, tc.wantPatches, patches)
+
+			gotReasons := make(map[string]string)
+			for _, node := range nodes {
+				updated, err := client.CoreV1().Nodes().Get(ctx.TODO(), node.Name, metav1.GetOptions{})
+				assert.NoError(t, err)
+				if reason, found := updated.Annotations[ScaleDownDisabledReasonKey]; found {
+					gotReasons[node.Name] = reason
+				}
+			}
+			assert.Equal(t, tc.wantReasons, gotReasons)
+		})
+	}
+}
+
+type countingScaleDownStatusProcessor struct {
+	processed int
+}
+
+func (p *countingScaleDownStatusProcessor) Process(_ *context.AutoscalingContext, _ *status.ScaleDownStatus) {
+	p.processed++
+}
+
+func (p *countingScaleDownStatusProcessor) CleanUp() {}
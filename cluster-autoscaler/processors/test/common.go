@@ -34,6 +34,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/processors/pods"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/scaledowncandidates"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/utilizationcalculator"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/scheduling"
 )
 
@@ -58,5 +59,6 @@ func NewTestProcessors(context *context.AutoscalingContext) *processors.Autoscal
 		ScaleStateNotifier:          nodegroupchange.NewNodeGroupChangeObserversList(),
 		AsyncNodeGroupStateChecker:  asyncnodegroups.NewDefaultAsyncNodeGroupStateChecker(),
 		ScaleUpEnforcer:             pods.NewDefaultScaleUpEnforcer(),
+		UtilizationCalculator:       utilizationcalculator.NewDefaultUtilizationCalculator(),
 	}
 }
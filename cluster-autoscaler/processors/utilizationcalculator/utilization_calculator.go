@@ -0,0 +1,54 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utilizationcalculator
+
+import (
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/context"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/utilization"
+)
+
+// UtilizationCalculator calculates node utilization that is compared against
+// scale-down utilization thresholds.
+type UtilizationCalculator interface {
+	// Calculate returns utilization of a given node. skipDaemonSetPods
+	// reflects IgnoreDaemonSetsUtilization option of the node's node group.
+	Calculate(context *context.AutoscalingContext, nodeInfo *framework.NodeInfo, skipDaemonSetPods bool, currentTime time.Time) (utilization.Info, error)
+	// CleanUp cleans up processor's internal structures.
+	CleanUp()
+}
+
+// NewDefaultUtilizationCalculator returns a default instance of UtilizationCalculator.
+func NewDefaultUtilizationCalculator() UtilizationCalculator {
+	return &RequestsUtilizationCalculator{}
+}
+
+// RequestsUtilizationCalculator calculates node utilization based on pod
+// requests, using utilization.Calculate.
+type RequestsUtilizationCalculator struct{}
+
+// Calculate returns utilization of a given node.
+func (c *RequestsUtilizationCalculator) Calculate(context *context.AutoscalingContext, nodeInfo *framework.NodeInfo, skipDaemonSetPods bool, currentTime time.Time) (utilization.Info, error) {
+	gpuConfig := context.CloudProvider.GetNodeGpuConfig(nodeInfo.Node())
+	return utilization.Calculate(nodeInfo, skipDaemonSetPods, context.IgnoreMirrorPodsUtilization, context.DynamicResourceAllocationEnabled, gpuConfig, context.ScaleDownUtilizationExtendedResources, currentTime)
+}
+
+// CleanUp cleans up processor's internal structures.
+func (c *RequestsUtilizationCalculator) CleanUp() {
+}
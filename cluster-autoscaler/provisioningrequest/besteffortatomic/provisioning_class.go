@@ -17,6 +17,9 @@ limitations under the License.
 package besteffortatomic
 
 import (
+	ctx "context"
+	"time"
+
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -30,7 +33,10 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup/orchestrator"
 	"k8s.io/autoscaler/cluster-autoscaler/estimator"
+	"k8s.io/autoscaler/cluster-autoscaler/observers/nodegroupchange"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupset"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	"k8s.io/autoscaler/cluster-autoscaler/provisioningrequest"
 	"k8s.io/autoscaler/cluster-autoscaler/provisioningrequest/conditions"
 	"k8s.io/autoscaler/cluster-autoscaler/provisioningrequest/provreqclient"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/scheduling"
@@ -44,18 +50,39 @@ import (
 // to atomically request enough resources for all pods specified in a
 // ProvisioningRequest. It's "best effort" as it admits workload immediately
 // after successful request, without waiting to verify that resources started.
+// ProvisioningRequests with the MultiNodeGroupScaleUpKey parameter set to "true"
+// can be provisioned from several node groups. Such scale-ups are reverted if not
+// all node groups provide the requested capacity in time.
 type bestEffortAtomicProvClass struct {
-	context             *context.AutoscalingContext
-	client              *provreqclient.ProvisioningRequestClient
-	injector            *scheduling.HintingSimulator
-	scaleUpOrchestrator scaleup.Orchestrator
+	context              *context.AutoscalingContext
+	client               *provreqclient.ProvisioningRequestClient
+	injector             *scheduling.HintingSimulator
+	scaleUpOrchestrator  scaleup.Orchestrator
+	clusterStateRegistry *clusterstate.ClusterStateRegistry
+	scaleStateNotifier   nodegroupchange.NodeGroupChangeObserver
+	now                  func() time.Time
+	// pendingScaleUps are the multi node group scale-ups which haven't provided all the requested capacity yet, by ProvisioningRequest.
+	pendingScaleUps map[string]*pendingScaleUp
+}
+
+// multiNodeGroupOrchestrator is implemented by scale-up orchestrators able to spread an all-or-nothing scale-up over several node groups.
+type multiNodeGroupOrchestrator interface {
+	ScaleUpAcrossNodeGroups(traceContext ctx.Context, unschedulablePods []*apiv1.Pod, nodes []*apiv1.Node, nodeInfos map[string]*framework.NodeInfo) (*status.ScaleUpStatus, errors.AutoscalerError)
+}
+
+// pendingScaleUp is a scale-up spread over several node groups for a ProvisioningRequest.
+type pendingScaleUp struct {
+	namespace    string
+	name         string
+	scaleUpInfos []nodegroupset.ScaleUpInfo
+	deadline     time.Time
 }
 
 // New creates best effort atomic provisioning class supporting create capacity scale-up mode.
 func New(
 	client *provreqclient.ProvisioningRequestClient,
 ) *bestEffortAtomicProvClass {
-	return &bestEffortAtomicProvClass{client: client, scaleUpOrchestrator: orchestrator.New()}
+	return &bestEffortAtomicProvClass{client: client, scaleUpOrchestrator: orchestrator.New(), now: time.Now, pendingScaleUps: make(map[string]*pendingScaleUp)}
 }
 
 func (o *bestEffortAtomicProvClass) Initialize(
@@ -68,11 +95,14 @@ func (o *bestEffortAtomicProvClass) Initialize(
 ) {
 	o.context = autoscalingContext
 	o.injector = injector
+	o.clusterStateRegistry = clusterStateRegistry
+	o.scaleStateNotifier = processors.ScaleStateNotifier
 	o.scaleUpOrchestrator.Initialize(autoscalingContext, processors, clusterStateRegistry, estimatorBuilder, taintConfig)
 }
 
 // Provision returns success if there is, or has just been requested, sufficient capacity in the cluster for pods from ProvisioningRequest.
 func (o *bestEffortAtomicProvClass) Provision(
+	traceContext ctx.Context,
 	unschedulablePods []*apiv1.Pod,
 	nodes []*apiv1.Node,
 	daemonSets []*appsv1.DaemonSet,
@@ -82,7 +112,9 @@ func (o *bestEffortAtomicProvClass) Provision(
 		return &status.ScaleUpStatus{Result: status.ScaleUpNotTried}, nil
 	}
 	prs := provreqclient.ProvisioningRequestsForPods(o.client, unschedulablePods)
-	prs = provreqclient.FilterOutProvisioningClass(prs, v1.ProvisioningClassBestEffortAtomicScaleUp, "")
+	// BestEffortQueue ProvReqs are provisioned the same way, they only differ in the order they are picked in.
+	prs = append(provreqclient.FilterOutProvisioningClass(prs, v1.ProvisioningClassBestEffortAtomicScaleUp, ""),
+		provreqclient.FilterOutProvisioningClass(prs, v1.ProvisioningClassBestEffortQueue, "")...)
 	if len(prs) == 0 {
 		return &status.ScaleUpStatus{Result: status.ScaleUpNotTried}, nil
 	}
@@ -112,7 +144,21 @@ func (o *bestEffortAtomicProvClass) Provision(
 		return &status.ScaleUpStatus{Result: status.ScaleUpNotNeeded}, nil
 	}
 
-	st, err := o.scaleUpOrchestrator.ScaleUp(actuallyUnschedulablePods, nodes, daemonSets, nodeInfos, true)
+	st, err := o.scaleUpOrchestrator.ScaleUp(traceContext, actuallyUnschedulablePods, nodes, daemonSets, nodeInfos, true)
+	if err == nil && st.Result != status.ScaleUpSuccessful && pr.Spec.Parameters[provisioningrequest.MultiNodeGroupScaleUpKey] == "true" {
+		if multiNodeGroupOrchestrator, ok := o.scaleUpOrchestrator.(multiNodeGroupOrchestrator); ok {
+			klog.V(2).Infof("ProvReq %s/%s doesn't fit in a single node group, trying to spread it over multiple node groups", pr.Namespace, pr.Name)
+			st, err = multiNodeGroupOrchestrator.ScaleUpAcrossNodeGroups(traceContext, actuallyUnschedulablePods, nodes, nodeInfos)
+			if err == nil && st.Result == status.ScaleUpSuccessful && len(st.ScaleUpInfos) > 1 {
+				o.pendingScaleUps[pr.Namespace+"/"+pr.Name] = &pendingScaleUp{
+					namespace:    pr.Namespace,
+					name:         pr.Name,
+					scaleUpInfos: st.ScaleUpInfos,
+					deadline:     o.now().Add(o.context.ProvisioningRequestMultiNodeGroupTimeout),
+				}
+			}
+		}
+	}
 	if err == nil && st.Result == status.ScaleUpSuccessful {
 		// Happy path - all is well.
 		conditions.AddOrUpdateCondition(pr, v1.Provisioned, metav1.ConditionTrue, conditions.CapacityIsProvisionedReason, conditions.CapacityIsProvisionedMsg, metav1.Now())
@@ -134,6 +180,65 @@ func (o *bestEffortAtomicProvClass) Provision(
 	return st, nil
 }
 
+// Refresh implements loop.Observer interface and will be run at the start of every iteration
+// of the main loop. It reverts the multi node group scale-ups which didn't provide all the
+// requested capacity in time, or which failed in any of the node groups.
+func (o *bestEffortAtomicProvClass) Refresh() {
+	if o.clusterStateRegistry == nil {
+		return
+	}
+	now := o.now()
+	for key, scaleUp := range o.pendingScaleUps {
+		scalingUp, failed := false, false
+		for _, info := range scaleUp.scaleUpInfos {
+			if o.clusterStateRegistry.HasNodeGroupStartedScaleUp(info.Group.Id()) {
+				scalingUp = true
+			}
+			if o.clusterStateRegistry.BackoffStatusForNodeGroup(info.Group, now).IsBackedOff {
+				failed = true
+			}
+		}
+		if !scalingUp && !failed {
+			klog.V(2).Infof("Multi node group scale-up for ProvReq %s is complete", key)
+			delete(o.pendingScaleUps, key)
+			continue
+		}
+		if !failed && now.Before(scaleUp.deadline) {
+			continue
+		}
+		o.revertScaleUp(scaleUp, now)
+		delete(o.pendingScaleUps, key)
+	}
+}
+
+// revertScaleUp cancels the requests for nodes which haven't been provided yet, and marks the ProvisioningRequest
+// as not provisioned, so that the nodes which have been provided are no longer booked and can be scaled down.
+func (o *bestEffortAtomicProvClass) revertScaleUp(scaleUp *pendingScaleUp, now time.Time) {
+	klog.Warningf("Reverting multi node group scale-up for ProvReq %s/%s", scaleUp.namespace, scaleUp.name)
+	upcomingCounts, _ := o.clusterStateRegistry.GetUpcomingNodes()
+	for _, info := range scaleUp.scaleUpInfos {
+		missing := min(upcomingCounts[info.Group.Id()], info.NewSize-info.CurrentSize)
+		if missing <= 0 {
+			continue
+		}
+		if err := info.Group.DecreaseTargetSize(-missing); err != nil {
+			klog.Errorf("Failed to decrease target size of %s by %d: %v", info.Group.Id(), missing, err)
+			continue
+		}
+		o.scaleStateNotifier.RegisterScaleUp(info.Group, -missing, now)
+	}
+
+	pr, err := o.client.ProvisioningRequest(scaleUp.namespace, scaleUp.name)
+	if err != nil {
+		klog.Errorf("failed to get ProvReq %s/%s, err: %v", scaleUp.namespace, scaleUp.name, err)
+		return
+	}
+	conditions.AddOrUpdateCondition(pr, v1.Provisioned, metav1.ConditionFalse, conditions.MultiNodeGroupScaleUpRevertedReason, conditions.MultiNodeGroupScaleUpRevertedMsg, metav1.NewTime(now))
+	if _, updateErr := o.client.UpdateProvisioningRequest(pr.ProvisioningRequest); updateErr != nil {
+		klog.Errorf("failed to add Provisioned=false condition to ProvReq %s/%s, err: %v", pr.Namespace, pr.Name, updateErr)
+	}
+}
+
 func (o *bestEffortAtomicProvClass) filterOutSchedulable(pods []*apiv1.Pod) ([]*apiv1.Pod, error) {
 	statuses, _, err := o.injector.TrySchedulePods(o.context.ClusterSnapshot, pods, scheduling.ScheduleAnywhere, false)
 	if err != nil {
@@ -17,6 +17,7 @@ limitations under the License.
 package checkcapacity
 
 import (
+	ctx "context"
 	"fmt"
 	"sort"
 	"strings"
@@ -89,6 +90,7 @@ func (o *checkCapacityProvClass) Initialize(
 
 // Provision return if there is capacity in the cluster for pods from ProvisioningRequest.
 func (o *checkCapacityProvClass) Provision(
+	_ ctx.Context,
 	unschedulablePods []*apiv1.Pod,
 	nodes []*apiv1.Node,
 	daemonSets []*appsv1.DaemonSet,
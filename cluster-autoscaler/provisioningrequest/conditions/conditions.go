@@ -52,6 +52,10 @@ const (
 	CapacityReservationTimeExpiredReason = "CapacityReservationTimeExpired"
 	// CapacityReservationTimeExpiredMsg is added if capacity reservation time is expired.
 	CapacityReservationTimeExpiredMsg = "Capacity reservation time is expired"
+	// MultiNodeGroupScaleUpRevertedReason is added when a scale-up spread over multiple node groups was reverted.
+	MultiNodeGroupScaleUpRevertedReason = "MultiNodeGroupScaleUpReverted"
+	// MultiNodeGroupScaleUpRevertedMsg is added when a scale-up spread over multiple node groups was reverted.
+	MultiNodeGroupScaleUpRevertedMsg = "Not all node groups provided the requested capacity in time, the scale-up was reverted. CA will try again later."
 	// ExpiredReason is added if ProvisioningRequest is expired.
 	ExpiredReason = "Expired"
 	// ExpiredMsg is added if ProvisioningRequest is expired.
@@ -17,10 +17,12 @@ limitations under the License.
 package orchestrator
 
 import (
+	ctx "context"
 	"fmt"
 
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
 	"k8s.io/autoscaler/cluster-autoscaler/context"
 	"k8s.io/autoscaler/cluster-autoscaler/estimator"
@@ -36,7 +38,7 @@ import (
 
 // ProvisioningClass is an interface for ProvisioningRequests.
 type ProvisioningClass interface {
-	Provision([]*apiv1.Pod, []*apiv1.Node, []*appsv1.DaemonSet,
+	Provision(ctx.Context, []*apiv1.Pod, []*apiv1.Node, []*appsv1.DaemonSet,
 		map[string]*framework.NodeInfo) (*status.ScaleUpStatus, ca_errors.AutoscalerError)
 	Initialize(*context.AutoscalingContext, *ca_processors.AutoscalingProcessors, *clusterstate.ClusterStateRegistry,
 		estimator.EstimatorBuilder, taints.TaintConfig, *scheduling.HintingSimulator)
@@ -79,6 +81,7 @@ func (o *provReqOrchestrator) Initialize(
 // so only one ProvisioningClass return non empty scaleUp result.
 // In case we implement multiple ProvisioningRequest ScaleUp, the function should return combined status
 func (o *provReqOrchestrator) ScaleUp(
+	traceContext ctx.Context,
 	unschedulablePods []*apiv1.Pod,
 	nodes []*apiv1.Node,
 	daemonSets []*appsv1.DaemonSet,
@@ -94,7 +97,7 @@ func (o *provReqOrchestrator) ScaleUp(
 
 	// unschedulablePods pods should belong to one ProvisioningClass, so only one provClass should try to ScaleUp.
 	for _, provClass := range o.provisioningClasses {
-		st, err := provClass.Provision(unschedulablePods, nodes, daemonSets, nodeInfos)
+		st, err := provClass.Provision(traceContext, unschedulablePods, nodes, daemonSets, nodeInfos)
 		if err != nil || st != nil && st.Result != status.ScaleUpNotTried {
 			return st, err
 		}
@@ -104,6 +107,18 @@ func (o *provReqOrchestrator) ScaleUp(
 
 // ScaleUpToNodeGroupMinSize doesn't have implementation for ProvisioningRequest Orchestrator.
 func (o *provReqOrchestrator) ScaleUpToNodeGroupMinSize(
+	_ ctx.Context,
+	nodes []*apiv1.Node,
+	nodeInfos map[string]*framework.NodeInfo,
+) (*status.ScaleUpStatus, ca_errors.AutoscalerError) {
+	return nil, nil
+}
+
+// ScaleUpNodeGroup doesn't have implementation for ProvisioningRequest Orchestrator.
+func (o *provReqOrchestrator) ScaleUpNodeGroup(
+	_ ctx.Context,
+	nodeGroup cloudprovider.NodeGroup,
+	newNodes int,
 	nodes []*apiv1.Node,
 	nodeInfos map[string]*framework.NodeInfo,
 ) (*status.ScaleUpStatus, ca_errors.AutoscalerError) {
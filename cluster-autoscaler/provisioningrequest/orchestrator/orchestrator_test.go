@@ -37,6 +37,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/processors/provreq"
 	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
 	processorstest "k8s.io/autoscaler/cluster-autoscaler/processors/test"
+	"k8s.io/autoscaler/cluster-autoscaler/provisioningrequest"
 	"k8s.io/autoscaler/cluster-autoscaler/provisioningrequest/besteffortatomic"
 	"k8s.io/autoscaler/cluster-autoscaler/provisioningrequest/checkcapacity"
 	"k8s.io/autoscaler/cluster-autoscaler/provisioningrequest/pods"
@@ -124,6 +125,14 @@ func TestScaleUp(t *testing.T) {
 			PodCount: int32(5),
 			Class:    v1.ProvisioningClassBestEffortAtomicScaleUp,
 		})
+	queuedScaleUpProvReq := provreqwrapper.BuildValidTestProvisioningRequestFromOptions(
+		provreqwrapper.TestProvReqOptions{
+			Name:     "queuedScaleUpProvReq",
+			CPU:      "5m",
+			Memory:   "5",
+			PodCount: int32(5),
+			Class:    v1.ProvisioningClassBestEffortQueue,
+		})
 	largeAtomicScaleUpProvReq := provreqwrapper.BuildValidTestProvisioningRequestFromOptions(
 		provreqwrapper.TestProvReqOptions{
 			Name:     "largeAtomicScaleUpProvReq",
@@ -221,6 +230,12 @@ func TestScaleUp(t *testing.T) {
 			provReqToScaleUp: atomicScaleUpProvReq,
 			scaleUpResult:    status.ScaleUpNotNeeded,
 		},
+		{
+			name:             "one ProvisioningRequest of best effort queue class",
+			provReqs:         []*provreqwrapper.ProvisioningRequest{queuedScaleUpProvReq},
+			provReqToScaleUp: queuedScaleUpProvReq,
+			scaleUpResult:    status.ScaleUpNotNeeded,
+		},
 		{
 			name:             "capacity is there, check-capacity class",
 			provReqs:         []*provreqwrapper.ProvisioningRequest{newCheckCapacityMemProvReq},
@@ -431,7 +446,7 @@ func TestScaleUp(t *testing.T) {
 			client := provreqclient.NewFakeProvisioningRequestClient(context.Background(), t, testProvReqs...)
 			orchestrator, nodeInfos := setupTest(t, client, nodes, onScaleUpFunc, tc.autoprovisioning, tc.batchProcessing, tc.maxBatchSize, tc.batchTimebox)
 
-			st, err := orchestrator.ScaleUp(prPods, []*apiv1.Node{}, []*appsv1.DaemonSet{}, nodeInfos, false)
+			st, err := orchestrator.ScaleUp(context.Background(), prPods, []*apiv1.Node{}, []*appsv1.DaemonSet{}, nodeInfos, false)
 			if !tc.err {
 				assert.NoError(t, err)
 				if tc.scaleUpResult != st.Result && len(st.PodsRemainUnschedulable) > 0 {
@@ -539,3 +554,82 @@ func NumProvisioningRequestsWithCondition(prList []*provreqwrapper.ProvisioningR
 
 	return count
 }
+
+func TestMultiNodeGroupScaleUp(t *testing.T) {
+	// Set up a cluster with two node groups, each with a half-occupied node and room for two more nodes.
+	// Neither of the node groups can provide capacity for the whole ProvisioningRequest on its own.
+	now := time.Now()
+	nodes := []*apiv1.Node{BuildTestNode("ng1-node", 1000, 1000), BuildTestNode("ng2-node", 1000, 1000)}
+	for _, n := range nodes {
+		SetNodeReadyState(n, true, now.Add(-2*time.Minute))
+	}
+	scheduledPods := []*apiv1.Pod{BuildScheduledTestPod("ng1-pod", 500, 10, "ng1-node"), BuildScheduledTestPod("ng2-pod", 500, 10, "ng2-node")}
+
+	pr := provreqwrapper.BuildValidTestProvisioningRequestFromOptions(
+		provreqwrapper.TestProvReqOptions{
+			Name:     "multiNodeGroupProvReq",
+			CPU:      "600m",
+			Memory:   "10",
+			PodCount: int32(4),
+			Class:    v1.ProvisioningClassBestEffortAtomicScaleUp,
+		})
+	pr.Spec.Parameters = map[string]v1.Parameter{provisioningrequest.MultiNodeGroupScaleUpKey: "true"}
+	prPods, err := pods.PodsForProvisioningRequest(pr)
+	assert.NoError(t, err)
+
+	provider := testprovider.NewTestCloudProvider(func(string, int) error { return nil }, nil)
+	provider.AddNodeGroup("ng1", 0, 3, 1)
+	provider.AddNode("ng1", nodes[0])
+	provider.AddNodeGroup("ng2", 0, 3, 1)
+	provider.AddNode("ng2", nodes[1])
+
+	podLister := kube_util.NewTestPodLister(scheduledPods)
+	listers := kube_util.NewListerRegistry(nil, nil, podLister, nil, nil, nil, nil, nil, nil)
+	options := config.AutoscalingOptions{ProvisioningRequestMultiNodeGroupTimeout: time.Nanosecond}
+	autoscalingContext, err := NewScaleTestAutoscalingContext(options, &fake.Clientset{}, listers, provider, nil, nil)
+	assert.NoError(t, err)
+	clustersnapshot.InitializeClusterSnapshotOrDie(t, autoscalingContext.ClusterSnapshot, nodes, scheduledPods)
+	processors := processorstest.NewTestProcessors(&autoscalingContext)
+	nodeInfos, err := nodeinfosprovider.NewDefaultTemplateNodeInfoProvider(nil, false).Process(&autoscalingContext, nodes, []*appsv1.DaemonSet{}, taints.TaintConfig{}, now)
+	assert.NoError(t, err)
+	estimatorBuilder, _ := estimator.NewEstimatorBuilder(
+		estimator.BinpackingEstimatorName,
+		estimator.NewThresholdBasedEstimationLimiter([]estimator.Threshold{estimator.NewSngCapacityThreshold()}),
+		estimator.NewDecreasingPodOrderer(),
+		nil,
+	)
+	clusterState := clusterstate.NewClusterStateRegistry(provider, clusterstate.ClusterStateRegistryConfig{}, autoscalingContext.LogRecorder, NewBackoff(), nodegroupconfig.NewDefaultNodeGroupConfigProcessor(autoscalingContext.NodeGroupDefaults), processors.AsyncNodeGroupStateChecker)
+	clusterState.UpdateNodes(nodes, nodeInfos, now)
+	processors.ScaleStateNotifier.Register(clusterState)
+
+	client := provreqclient.NewFakeProvisioningRequestClient(context.Background(), t, pr)
+	bestEffortAtomicClass := besteffortatomic.New(client)
+	orchestrator := &provReqOrchestrator{
+		client:              client,
+		provisioningClasses: []ProvisioningClass{bestEffortAtomicClass},
+	}
+	orchestrator.Initialize(&autoscalingContext, processors, clusterState, estimatorBuilder, taints.TaintConfig{})
+
+	st, err := orchestrator.ScaleUp(context.Background(), prPods, nodes, []*appsv1.DaemonSet{}, nodeInfos, false)
+	assert.NoError(t, err)
+	assert.Equal(t, status.ScaleUpSuccessful, st.Result)
+	for _, id := range []string{"ng1", "ng2"} {
+		targetSize, err := provider.GetNodeGroup(id).TargetSize()
+		assert.NoError(t, err)
+		assert.Equal(t, 3, targetSize)
+	}
+	provReqs, err := client.ProvisioningRequestsNoCache()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, NumProvisioningRequestsWithCondition(provReqs, v1.Provisioned, metav1.ConditionTrue))
+
+	// The nodes didn't come up in time, so the scale-up is reverted.
+	bestEffortAtomicClass.Refresh()
+	for _, id := range []string{"ng1", "ng2"} {
+		targetSize, err := provider.GetNodeGroup(id).TargetSize()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, targetSize)
+	}
+	provReqs, err = client.ProvisioningRequestsNoCache()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, NumProvisioningRequestsWithCondition(provReqs, v1.Provisioned, metav1.ConditionFalse))
+}
@@ -17,9 +17,12 @@ limitations under the License.
 package orchestrator
 
 import (
+	ctx "context"
+
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/autoscaler/cluster-autoscaler/apis/provisioningrequest/autoscaling.x-k8s.io/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
 	"k8s.io/autoscaler/cluster-autoscaler/clusterstate"
 	"k8s.io/autoscaler/cluster-autoscaler/context"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaleup"
@@ -64,6 +67,7 @@ func (o *WrapperOrchestrator) Initialize(
 
 // ScaleUp run scaleUp function for regular pods of pods from ProvisioningRequest.
 func (o *WrapperOrchestrator) ScaleUp(
+	traceContext ctx.Context,
 	unschedulablePods []*apiv1.Pod,
 	nodes []*apiv1.Node,
 	daemonSets []*appsv1.DaemonSet,
@@ -82,9 +86,9 @@ func (o *WrapperOrchestrator) ScaleUp(
 	}
 
 	if o.autoscalingContext.ProvisioningRequestScaleUpMode {
-		return o.provReqOrchestrator.ScaleUp(provReqPods, nodes, daemonSets, nodeInfos, allOrNothing)
+		return o.provReqOrchestrator.ScaleUp(traceContext, provReqPods, nodes, daemonSets, nodeInfos, allOrNothing)
 	}
-	return o.podsOrchestrator.ScaleUp(regularPods, nodes, daemonSets, nodeInfos, allOrNothing)
+	return o.podsOrchestrator.ScaleUp(traceContext, regularPods, nodes, daemonSets, nodeInfos, allOrNothing)
 }
 
 func splitOut(unschedulablePods []*apiv1.Pod) (provReqPods, regularPods []*apiv1.Pod) {
@@ -103,8 +107,21 @@ func splitOut(unschedulablePods []*apiv1.Pod) (provReqPods, regularPods []*apiv1
 // size is the TargetSize queried directly from cloud providers. Returns
 // appropriate status or error if an unexpected error occurred.
 func (o *WrapperOrchestrator) ScaleUpToNodeGroupMinSize(
+	traceContext ctx.Context,
+	nodes []*apiv1.Node,
+	nodeInfos map[string]*framework.NodeInfo,
+) (*status.ScaleUpStatus, errors.AutoscalerError) {
+	return o.podsOrchestrator.ScaleUpToNodeGroupMinSize(traceContext, nodes, nodeInfos)
+}
+
+// ScaleUpNodeGroup tries to scale up the given node group by newNodes nodes,
+// subject to the same backoff, limit and cost budget checks as ScaleUp.
+func (o *WrapperOrchestrator) ScaleUpNodeGroup(
+	traceContext ctx.Context,
+	nodeGroup cloudprovider.NodeGroup,
+	newNodes int,
 	nodes []*apiv1.Node,
 	nodeInfos map[string]*framework.NodeInfo,
 ) (*status.ScaleUpStatus, errors.AutoscalerError) {
-	return o.podsOrchestrator.ScaleUpToNodeGroupMinSize(nodes, nodeInfos)
+	return o.podsOrchestrator.ScaleUpNodeGroup(traceContext, nodeGroup, newNodes, nodes, nodeInfos)
 }
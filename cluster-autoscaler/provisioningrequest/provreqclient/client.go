@@ -231,6 +231,8 @@ func matchesProvisioningClass(pr *provreqwrapper.ProvisioningRequest, class stri
 		return provisioningrequest.SupportedCheckCapacityClass(pr.ProvisioningRequest, checkCapacityProcessorInstance)
 	case v1.ProvisioningClassBestEffortAtomicScaleUp:
 		return pr.Spec.ProvisioningClassName == v1.ProvisioningClassBestEffortAtomicScaleUp
+	case v1.ProvisioningClassBestEffortQueue:
+		return pr.Spec.ProvisioningClassName == v1.ProvisioningClassBestEffortQueue
 	default:
 		return false
 	}
@@ -72,6 +72,17 @@ func TestSupportedProvisioningClass(t *testing.T) {
 			checkCapacityProcessorInstance: "instance",
 			want:                           false,
 		},
+		{
+			name:                  "Best effort queue",
+			provisioningClassName: v1.ProvisioningClassBestEffortQueue,
+			want:                  true,
+		},
+		{
+			name:                           "Best effort queue with any instance",
+			provisioningClassName:          v1.ProvisioningClassBestEffortQueue,
+			checkCapacityProcessorInstance: "instance",
+			want:                           false,
+		},
 		{
 			name:                  "Invalid class name",
 			provisioningClassName: "invalid",
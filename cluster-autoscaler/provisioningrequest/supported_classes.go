@@ -29,13 +29,17 @@ const (
 	// and if not empty, it should match CheckCapacityProcessorInstance defined in CA's options.
 	// Unrecommended: Until CA 1.35, ProvReqs with this value as prefix in their class will be also processed.
 	CheckCapacityProcessorInstanceKey = "processorInstance"
+	// MultiNodeGroupScaleUpKey is a key for ProvReq's Parameters.
+	// If its value is "true", a best-effort atomic ProvReq not fitting in a single node group
+	// can be provisioned from several node groups, all of which are scaled up at once.
+	MultiNodeGroupScaleUpKey = "multiNodeGroupScaleUp"
 )
 
 // SupportedProvisioningClass verifies if the ProvisioningRequest with the given checkCapacityProcessorInstance is supported.
 func SupportedProvisioningClass(pr *v1.ProvisioningRequest, checkCapacityProcessorInstance string) bool {
-	if pr.Spec.ProvisioningClassName == v1.ProvisioningClassBestEffortAtomicScaleUp {
+	if pr.Spec.ProvisioningClassName == v1.ProvisioningClassBestEffortAtomicScaleUp || pr.Spec.ProvisioningClassName == v1.ProvisioningClassBestEffortQueue {
 		if checkCapacityProcessorInstance != "" {
-			// If processor instance is set, BestEffortAtomicScaleUp should not be processed.
+			// If processor instance is set, BestEffortAtomicScaleUp and BestEffortQueue should not be processed.
 			return false
 		}
 		return true
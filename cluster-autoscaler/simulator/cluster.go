@@ -27,6 +27,7 @@ import (
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/options"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/scheduling"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/utilization"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
 	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/tpu"
@@ -50,6 +51,9 @@ type UnremovableNode struct {
 	Node        *apiv1.Node
 	Reason      UnremovableReason
 	BlockingPod *drain.BlockingPod
+	// UtilizationBreakdown lists pods holding the node, set only for
+	// NotUnderutilized nodes if breakdown reporting is enabled.
+	UtilizationBreakdown *utilization.Breakdown
 }
 
 // UnremovableReason represents a reason why a node can't be removed by CA.
@@ -91,8 +95,57 @@ const (
 	BlockedByPod
 	// UnexpectedError - node can't be removed because of an unexpected error.
 	UnexpectedError
+	// ScaleDownSoftTaintOnly - node can't be removed because its node group only soft-taints and cordons unneeded nodes.
+	ScaleDownSoftTaintOnly
+	// DrainScheduledLater - node can't be removed yet because PodDisruptionBudgets only allow draining it after other nodes.
+	DrainScheduledLater
 )
 
+func (r UnremovableReason) String() string {
+	switch r {
+	case NoReason:
+		return "NoReason"
+	case ScaleDownDisabledAnnotation:
+		return "ScaleDownDisabledAnnotation"
+	case ScaleDownUnreadyDisabled:
+		return "ScaleDownUnreadyDisabled"
+	case NotAutoscaled:
+		return "NotAutoscaled"
+	case NotUnneededLongEnough:
+		return "NotUnneededLongEnough"
+	case NotUnreadyLongEnough:
+		return "NotUnreadyLongEnough"
+	case NodeGroupMinSizeReached:
+		return "NodeGroupMinSizeReached"
+	case NodeGroupMaxDeletionCountReached:
+		return "NodeGroupMaxDeletionCountReached"
+	case AtomicScaleDownFailed:
+		return "AtomicScaleDownFailed"
+	case MinimalResourceLimitExceeded:
+		return "MinimalResourceLimitExceeded"
+	case CurrentlyBeingDeleted:
+		return "CurrentlyBeingDeleted"
+	case NotUnderutilized:
+		return "NotUnderutilized"
+	case NotUnneededOtherReason:
+		return "NotUnneededOtherReason"
+	case RecentlyUnremovable:
+		return "RecentlyUnremovable"
+	case NoPlaceToMovePods:
+		return "NoPlaceToMovePods"
+	case BlockedByPod:
+		return "BlockedByPod"
+	case UnexpectedError:
+		return "UnexpectedError"
+	case ScaleDownSoftTaintOnly:
+		return "ScaleDownSoftTaintOnly"
+	case DrainScheduledLater:
+		return "DrainScheduledLater"
+	default:
+		return fmt.Sprintf("unrecognized reason: %d", int(r))
+	}
+}
+
 // RemovalSimulator is a helper object for simulating node removal scenarios.
 type RemovalSimulator struct {
 	listers             kube_util.ListerRegistry
@@ -101,6 +154,7 @@ type RemovalSimulator struct {
 	deleteOptions       options.NodeDeleteOptions
 	drainabilityRules   rules.Rules
 	schedulingSimulator *scheduling.HintingSimulator
+	simulationCache     *SimulationCache
 }
 
 // NewRemovalSimulator returns a new RemovalSimulator.
@@ -115,6 +169,13 @@ func NewRemovalSimulator(listers kube_util.ListerRegistry, clusterSnapshot clust
 	}
 }
 
+// SetSimulationCache makes the simulator cache the outcomes of moving pods of removed nodes. The cache
+// is reset by DropOldHints, which has to be called whenever the cluster snapshot changes outside of
+// the simulator.
+func (r *RemovalSimulator) SetSimulationCache(cache *SimulationCache) {
+	r.simulationCache = cache
+}
+
 // FindNodesToRemove finds nodes that can be removed.
 func (r *RemovalSimulator) FindNodesToRemove(
 	candidates []string,
@@ -163,9 +224,7 @@ func (r *RemovalSimulator) SimulateNodeRemoval(
 		return nil, &UnremovableNode{Node: nodeInfo.Node(), Reason: UnexpectedError}
 	}
 
-	err = r.withForkedSnapshot(func() error {
-		return r.findPlaceFor(nodeName, podsToRemove, destinationMap, timestamp)
-	})
+	err = r.movePods(nodeInfo, podsToRemove, destinationMap, timestamp)
 	if err != nil {
 		klog.V(2).Infof("Node %s is not suitable for removal: %v", nodeName, err)
 		return nil, &UnremovableNode{Node: nodeInfo.Node(), Reason: NoPlaceToMovePods}
@@ -212,11 +271,96 @@ func (r *RemovalSimulator) withForkedSnapshot(f func() error) (err error) {
 	return err
 }
 
-func (r *RemovalSimulator) findPlaceFor(removedNode string, pods []*apiv1.Pod, nodes map[string]bool, timestamp time.Time) error {
+// movePods simulates moving the pods of the removed node to the destinations, using the simulation cache
+// if it's set. Nodes with pods referencing ResourceClaims are always simulated, the state of the claims
+// isn't part of the cache key.
+func (r *RemovalSimulator) movePods(removedNode *framework.NodeInfo, pods []*apiv1.Pod, destinations map[string]bool, timestamp time.Time) error {
+	nodeName := removedNode.Node().Name
+	if r.simulationCache == nil || hasResourceClaims(removedNode) {
+		return r.withForkedSnapshot(func() error {
+			_, err := r.findPlaceFor(nodeName, pods, destinations, timestamp)
+			return err
+		})
+	}
+
+	key := r.simulationCache.key(r.clusterSnapshot, removedNode, pods, destinations)
+	if entry, found := r.simulationCache.get(nodeName, key); found {
+		if entry.err != nil {
+			r.simulationCache.hits++
+			return entry.err
+		}
+		err := r.withForkedSnapshot(func() error {
+			return r.replayPlacements(nodeName, pods, entry.placements)
+		})
+		if err == nil {
+			r.simulationCache.hits++
+			r.simulationCache.invalidate(append(placementNodes(entry.placements), nodeName)...)
+			return nil
+		}
+		klog.V(4).Infof("Cached placements of pods from node %s are no longer valid: %v", nodeName, err)
+	}
+	r.simulationCache.misses++
+
+	var placements map[string]string
+	err := r.withForkedSnapshot(func() (err error) {
+		placements, err = r.findPlaceFor(nodeName, pods, destinations, timestamp)
+		return err
+	})
+	r.simulationCache.put(nodeName, key, placements, err)
+	if err == nil {
+		r.simulationCache.invalidate(append(placementNodes(placements), nodeName)...)
+	}
+	return err
+}
+
+// findPlaceFor schedules the pods of the removed node on the given nodes. Returns the names of the nodes
+// the pods were scheduled on, by pod namespace and name.
+func (r *RemovalSimulator) findPlaceFor(removedNode string, pods []*apiv1.Pod, nodes map[string]bool, timestamp time.Time) (map[string]string, error) {
 	isCandidateNode := func(nodeInfo *framework.NodeInfo) bool {
 		return nodeInfo.Node().Name != removedNode && nodes[nodeInfo.Node().Name]
 	}
 
+	newpods, err := r.unscheduleFromRemovedNode(removedNode, pods)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, _, err := r.schedulingSimulator.TrySchedulePods(r.clusterSnapshot, newpods, isCandidateNode, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(statuses) != len(newpods) {
+		return nil, fmt.Errorf("can reschedule only %d out of %d pods", len(statuses), len(newpods))
+	}
+	placements := make(map[string]string, len(statuses))
+	for _, status := range statuses {
+		placements[podKey(status.Pod)] = status.NodeName
+	}
+	return placements, nil
+}
+
+// replayPlacements schedules the pods of the removed node on the nodes they were scheduled on by a previous
+// simulation, checking scheduling predicates.
+func (r *RemovalSimulator) replayPlacements(removedNode string, pods []*apiv1.Pod, placements map[string]string) error {
+	newpods, err := r.unscheduleFromRemovedNode(removedNode, pods)
+	if err != nil {
+		return err
+	}
+	for _, pod := range newpods {
+		nodeName, found := placements[podKey(pod)]
+		if !found {
+			return fmt.Errorf("no cached placement for pod %s/%s", pod.Namespace, pod.Name)
+		}
+		if err := r.clusterSnapshot.SchedulePod(pod, nodeName); err != nil {
+			return fmt.Errorf("can't schedule pod %s/%s on node %s: %v", pod.Namespace, pod.Name, nodeName, err)
+		}
+	}
+	return nil
+}
+
+// unscheduleFromRemovedNode unschedules the pods from the removed node in the snapshot, so that they can be
+// scheduled elsewhere. Returns copies of the pods to schedule.
+func (r *RemovalSimulator) unscheduleFromRemovedNode(removedNode string, pods []*apiv1.Pod) ([]*apiv1.Pod, error) {
 	pods = tpu.ClearTPURequests(pods)
 
 	// Unschedule the pods from the Node in the snapshot first, so that they can be scheduled elsewhere by TrySchedulePods().
@@ -226,6 +370,11 @@ func (r *RemovalSimulator) findPlaceFor(removedNode string, pods []*apiv1.Pod, n
 			klog.Errorf("Simulating removal of %s/%s return error; %v", pod.Namespace, pod.Name, err)
 		}
 	}
+	// Pods which aren't moved (e.g. DaemonSet pods) are deleted together with the Node. Unschedule the ones referencing ResourceClaims
+	// as well, so that the shared claims they reserve are deallocated and can be re-allocated on other Nodes by TrySchedulePods().
+	if err := r.unscheduleRemainingPodsWithClaims(removedNode); err != nil {
+		return nil, err
+	}
 
 	newpods := make([]*apiv1.Pod, 0, len(pods))
 	for _, podptr := range pods {
@@ -233,18 +382,40 @@ func (r *RemovalSimulator) findPlaceFor(removedNode string, pods []*apiv1.Pod, n
 		newpod.Spec.NodeName = ""
 		newpods = append(newpods, &newpod)
 	}
+	return newpods, nil
+}
 
-	statuses, _, err := r.schedulingSimulator.TrySchedulePods(r.clusterSnapshot, newpods, isCandidateNode, true)
+func placementNodes(placements map[string]string) []string {
+	var nodeNames []string
+	for _, nodeName := range placements {
+		nodeNames = append(nodeNames, nodeName)
+	}
+	return nodeNames
+}
+
+func (r *RemovalSimulator) unscheduleRemainingPodsWithClaims(removedNode string) error {
+	nodeInfo, err := r.clusterSnapshot.GetNodeInfo(removedNode)
 	if err != nil {
 		return err
 	}
-	if len(statuses) != len(newpods) {
-		return fmt.Errorf("can reschedule only %d out of %d pods", len(statuses), len(newpods))
+	var podsWithClaims []*apiv1.Pod
+	for _, podInfo := range nodeInfo.Pods() {
+		if len(podInfo.Spec.ResourceClaims) > 0 {
+			podsWithClaims = append(podsWithClaims, podInfo.Pod)
+		}
+	}
+	for _, pod := range podsWithClaims {
+		if err := r.clusterSnapshot.UnschedulePod(pod.Namespace, pod.Name, removedNode); err != nil {
+			return fmt.Errorf("simulating removal of %s/%s returned error: %v", pod.Namespace, pod.Name, err)
+		}
 	}
 	return nil
 }
 
-// DropOldHints drops old scheduling hints.
+// DropOldHints drops old scheduling hints, and resets the simulation cache if it's set.
 func (r *RemovalSimulator) DropOldHints() {
 	r.schedulingSimulator.DropOldHints()
+	if r.simulationCache != nil {
+		r.simulationCache.Reset()
+	}
 }
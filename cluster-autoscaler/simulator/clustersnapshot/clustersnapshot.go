@@ -102,6 +102,9 @@ type ClusterSnapshotStore interface {
 	Revert()
 	// Commit commits changes done after forking.
 	Commit() error
+	// Clone returns an unforked copy of the current snapshot state. The copy and the original can be modified
+	// independently of each other, and can be used concurrently from different goroutines.
+	Clone() ClusterSnapshotStore
 }
 
 // ErrNodeNotFound means that a node wasn't found in the snapshot.
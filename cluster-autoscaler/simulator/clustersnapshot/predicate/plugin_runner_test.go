@@ -37,6 +37,7 @@ import (
 	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
 
 	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestRunFiltersOnNode(t *testing.T) {
@@ -319,6 +320,183 @@ func TestDebugInfo(t *testing.T) {
 	assert.Nil(t, predicateErr)
 }
 
+func TestRunFiltersWithAdditionalSchedulerProfiles(t *testing.T) {
+	node := BuildTestNode("n1000", 1000, 2000000)
+	SetNodeReadyState(node, true, time.Time{})
+
+	tmpDir, err := os.MkdirTemp("", "scheduler-configs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	customConfigFile := filepath.Join(tmpDir, "custom_config.yaml")
+	if err := os.WriteFile(customConfigFile,
+		[]byte(testconfig.SchedulerConfigNodeResourcesFitDisabled),
+		os.FileMode(0600)); err != nil {
+		t.Fatal(err)
+	}
+	schedConfig, err := scheduler.AddProfilesFromPaths(nil, []string{customConfigFile})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		schedulerName string
+		expectError   bool
+	}{
+		{
+			name:        "pod without scheduler name uses the default profile",
+			expectError: true,
+		},
+		{
+			name:          "pod of the default scheduler uses the default profile",
+			schedulerName: apiv1.DefaultSchedulerName,
+			expectError:   true,
+		},
+		{
+			name:          "pod of the custom scheduler uses its profile",
+			schedulerName: "custom-scheduler",
+			expectError:   false,
+		},
+		{
+			name:          "pod of a scheduler without a profile uses the default profile",
+			schedulerName: "unknown-scheduler",
+			expectError:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pluginRunner, snapshot, err := newTestPluginRunnerAndSnapshot(schedConfig)
+			assert.NoError(t, err)
+			err = snapshot.AddNodeInfo(framework.NewTestNodeInfo(node))
+			assert.NoError(t, err)
+
+			pod := BuildTestPod("p8000", 8000, 0)
+			pod.Spec.SchedulerName = tt.schedulerName
+
+			_, _, predicateError := pluginRunner.RunFiltersOnNode(pod, node.Name)
+			assert.Equal(t, tt.expectError, predicateError != nil)
+			_, _, predicateError = pluginRunner.RunFiltersUntilPassingNode(pod, func(*framework.NodeInfo) bool { return true })
+			assert.Equal(t, tt.expectError, predicateError != nil)
+		})
+	}
+}
+
+func TestRunFiltersWithTopologySpread(t *testing.T) {
+	buildZoneNode := func(name, zone string, options ...func(*apiv1.Node)) *apiv1.Node {
+		node := BuildTestNode(name, 10000, 10000000)
+		SetNodeReadyState(node, true, time.Time{})
+		node.Labels[apiv1.LabelTopologyZone] = zone
+		node.Labels["pool"] = "main"
+		for _, option := range options {
+			option(node)
+		}
+		return node
+	}
+	inOtherPool := func(node *apiv1.Node) {
+		node.Labels["pool"] = "other"
+	}
+	tainted := func(node *apiv1.Node) {
+		node.Spec.Taints = []apiv1.Taint{{Key: "dedicated", Value: "other", Effect: apiv1.TaintEffectNoSchedule}}
+	}
+	buildPod := func(name, templateHash string, constraintOptions ...func(*apiv1.TopologySpreadConstraint)) *apiv1.Pod {
+		pod := BuildTestPod(name, 100, 1000)
+		pod.Labels = map[string]string{"app": "foo", "pod-template-hash": templateHash}
+		pod.Spec.NodeSelector = map[string]string{"pool": "main"}
+		constraint := apiv1.TopologySpreadConstraint{
+			MaxSkew:           1,
+			TopologyKey:       apiv1.LabelTopologyZone,
+			WhenUnsatisfiable: apiv1.DoNotSchedule,
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}},
+		}
+		for _, option := range constraintOptions {
+			option(&constraint)
+		}
+		pod.Spec.TopologySpreadConstraints = []apiv1.TopologySpreadConstraint{constraint}
+		return pod
+	}
+	withMatchLabelKeys := func(constraint *apiv1.TopologySpreadConstraint) {
+		constraint.MatchLabelKeys = []string{"pod-template-hash"}
+	}
+	policy := func(policy apiv1.NodeInclusionPolicy) *apiv1.NodeInclusionPolicy {
+		return &policy
+	}
+	withNodeAffinityPolicy := func(nodeAffinityPolicy apiv1.NodeInclusionPolicy) func(*apiv1.TopologySpreadConstraint) {
+		return func(constraint *apiv1.TopologySpreadConstraint) {
+			constraint.NodeAffinityPolicy = policy(nodeAffinityPolicy)
+		}
+	}
+	withNodeTaintsPolicy := func(nodeTaintsPolicy apiv1.NodeInclusionPolicy) func(*apiv1.TopologySpreadConstraint) {
+		return func(constraint *apiv1.TopologySpreadConstraint) {
+			constraint.NodeTaintsPolicy = policy(nodeTaintsPolicy)
+		}
+	}
+
+	// Node n1 in zone-a runs a pod of the old revision, the test pod is checked against n1.
+	oldPod := buildPod("old", "old")
+	tests := []struct {
+		name        string
+		otherNode   *apiv1.Node
+		testPod     *apiv1.Pod
+		expectError bool
+	}{
+		{
+			name:        "skew exceeded",
+			otherNode:   buildZoneNode("n2", "zone-b"),
+			testPod:     buildPod("new", "new"),
+			expectError: true,
+		},
+		{
+			name:        "matchLabelKeys - pods of other revisions aren't counted",
+			otherNode:   buildZoneNode("n2", "zone-b"),
+			testPod:     buildPod("new", "new", withMatchLabelKeys),
+			expectError: false,
+		},
+		{
+			name:        "nodeAffinityPolicy Honor - domains of nodes not matching the pod aren't counted",
+			otherNode:   buildZoneNode("n2", "zone-b", inOtherPool),
+			testPod:     buildPod("new", "new", withNodeAffinityPolicy(apiv1.NodeInclusionPolicyHonor)),
+			expectError: false,
+		},
+		{
+			name:        "nodeAffinityPolicy Ignore - domains of nodes not matching the pod are counted",
+			otherNode:   buildZoneNode("n2", "zone-b", inOtherPool),
+			testPod:     buildPod("new", "new", withNodeAffinityPolicy(apiv1.NodeInclusionPolicyIgnore)),
+			expectError: true,
+		},
+		{
+			name:        "nodeTaintsPolicy Honor - domains of nodes with untolerated taints aren't counted",
+			otherNode:   buildZoneNode("n2", "zone-b", tainted),
+			testPod:     buildPod("new", "new", withNodeTaintsPolicy(apiv1.NodeInclusionPolicyHonor)),
+			expectError: false,
+		},
+		{
+			name:        "nodeTaintsPolicy Ignore - domains of nodes with untolerated taints are counted",
+			otherNode:   buildZoneNode("n2", "zone-b", tainted),
+			testPod:     buildPod("new", "new", withNodeTaintsPolicy(apiv1.NodeInclusionPolicyIgnore)),
+			expectError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pluginRunner, snapshot, err := newTestPluginRunnerAndSnapshot(nil)
+			assert.NoError(t, err)
+			err = snapshot.AddNodeInfo(framework.NewTestNodeInfo(buildZoneNode("n1", "zone-a"), oldPod))
+			assert.NoError(t, err)
+			err = snapshot.AddNodeInfo(framework.NewTestNodeInfo(tt.otherNode))
+			assert.NoError(t, err)
+
+			_, _, predicateError := pluginRunner.RunFiltersOnNode(tt.testPod, "n1")
+			if tt.expectError {
+				assert.NotNil(t, predicateError)
+				assert.Equal(t, "PodTopologySpread", predicateError.FailingPredicateName())
+			} else {
+				assert.Nil(t, predicateError)
+			}
+		})
+	}
+}
+
 func newTestPluginRunnerAndSnapshot(schedConfig *config.KubeSchedulerConfiguration) (*SchedulerPluginRunner, clustersnapshot.ClusterSnapshot, error) {
 	if schedConfig == nil {
 		defaultConfig, err := scheduler_config_latest.Default()
@@ -82,14 +82,26 @@ func (s *PredicateSnapshot) ListNodeInfos() ([]*framework.NodeInfo, error) {
 	return result, nil
 }
 
-// AddNodeInfo adds the provided internal NodeInfo to the snapshot.
+// AddNodeInfo adds the provided internal NodeInfo to the snapshot. The snapshot isn't modified if an error is returned.
 func (s *PredicateSnapshot) AddNodeInfo(nodeInfo *framework.NodeInfo) error {
-	if s.draEnabled {
-		// TODO(DRA): Add transaction-like clean-up in case of errors here - don't modify the state on any errors.
+	if !s.draEnabled {
+		return s.ClusterSnapshotStore.AddSchedulerNodeInfo(nodeInfo.ToScheduler())
+	}
+
+	var pods []*apiv1.Pod
+	var neededClaims []*resourceapi.ResourceClaim
+	for _, podInfo := range nodeInfo.Pods() {
+		pods = append(pods, podInfo.Pod)
+		neededClaims = append(neededClaims, podInfo.NeededResourceClaims...)
+	}
+	claimsState := s.ClusterSnapshotStore.DraSnapshot().SaveClaimsState(pods, neededClaims)
+	slicesAdded := false
+	err := func() error {
 		if len(nodeInfo.LocalResourceSlices) > 0 {
 			if err := s.ClusterSnapshotStore.DraSnapshot().AddNodeResourceSlices(nodeInfo.Node().Name, nodeInfo.LocalResourceSlices); err != nil {
 				return fmt.Errorf("couldn't add ResourceSlices to DRA snapshot: %v", err)
 			}
+			slicesAdded = true
 		}
 
 		for _, podInfo := range nodeInfo.Pods() {
@@ -102,9 +114,16 @@ func (s *PredicateSnapshot) AddNodeInfo(nodeInfo *framework.NodeInfo) error {
 				}
 			}
 		}
+		return s.ClusterSnapshotStore.AddSchedulerNodeInfo(nodeInfo.ToScheduler())
+	}()
+	if err != nil {
+		// Undo the DRA modifications, so that the snapshot is left in the state from before the call.
+		if slicesAdded {
+			s.ClusterSnapshotStore.DraSnapshot().RemoveNodeResourceSlices(nodeInfo.Node().Name)
+		}
+		s.ClusterSnapshotStore.DraSnapshot().RestoreClaimsState(claimsState)
 	}
-
-	return s.ClusterSnapshotStore.AddSchedulerNodeInfo(nodeInfo.ToScheduler())
+	return err
 }
 
 // RemoveNodeInfo removes a NodeInfo matching the provided nodeName from the snapshot.
@@ -135,17 +154,7 @@ func (s *PredicateSnapshot) SchedulePod(pod *apiv1.Pod, nodeName string) cluster
 		return schedErr
 	}
 
-	if s.draEnabled && len(pod.Spec.ResourceClaims) > 0 {
-		// TODO(DRA): Add transaction-like clean-up in case of errors here - don't modify the state on any errors.
-		if err := s.modifyResourceClaimsForScheduledPod(pod, node, cycleState); err != nil {
-			return clustersnapshot.NewSchedulingInternalError(pod, err.Error())
-		}
-		if err := s.verifyScheduledPodResourceClaims(pod, node); err != nil {
-			return clustersnapshot.NewSchedulingInternalError(pod, err.Error())
-		}
-	}
-
-	if err := s.ClusterSnapshotStore.ForceAddPod(pod, nodeName); err != nil {
+	if err := s.forceAddPodWithClaims(pod, node, cycleState); err != nil {
 		return clustersnapshot.NewSchedulingInternalError(pod, err.Error())
 	}
 	return nil
@@ -158,20 +167,34 @@ func (s *PredicateSnapshot) SchedulePodOnAnyNodeMatching(pod *apiv1.Pod, anyNode
 		return "", schedErr
 	}
 
-	if s.draEnabled && len(pod.Spec.ResourceClaims) > 0 {
-		// TODO(DRA): Add transaction-like clean-up in case of errors here - don't modify the state on any errors.
-		if err := s.modifyResourceClaimsForScheduledPod(pod, node, cycleState); err != nil {
-			return "", clustersnapshot.NewSchedulingInternalError(pod, err.Error())
+	if err := s.forceAddPodWithClaims(pod, node, cycleState); err != nil {
+		return "", clustersnapshot.NewSchedulingInternalError(pod, err.Error())
+	}
+	return node.Name, nil
+}
+
+// forceAddPodWithClaims adds the pod to the node in the snapshot, allocating and reserving the ResourceClaims it references.
+// The snapshot isn't modified if an error is returned.
+func (s *PredicateSnapshot) forceAddPodWithClaims(pod *apiv1.Pod, node *apiv1.Node, postFilterState *schedulerframework.CycleState) error {
+	if !s.draEnabled || len(pod.Spec.ResourceClaims) == 0 {
+		return s.ClusterSnapshotStore.ForceAddPod(pod, node.Name)
+	}
+
+	claimsState := s.ClusterSnapshotStore.DraSnapshot().SaveClaimsState([]*apiv1.Pod{pod}, nil)
+	err := func() error {
+		if err := s.modifyResourceClaimsForScheduledPod(pod, node, postFilterState); err != nil {
+			return err
 		}
 		if err := s.verifyScheduledPodResourceClaims(pod, node); err != nil {
-			return "", clustersnapshot.NewSchedulingInternalError(pod, err.Error())
+			return err
 		}
+		return s.ClusterSnapshotStore.ForceAddPod(pod, node.Name)
+	}()
+	if err != nil {
+		// Undo the claim allocations and reservations, so that the snapshot is left in the state from before the call.
+		s.ClusterSnapshotStore.DraSnapshot().RestoreClaimsState(claimsState)
 	}
-
-	if err := s.ClusterSnapshotStore.ForceAddPod(pod, node.Name); err != nil {
-		return "", clustersnapshot.NewSchedulingInternalError(pod, err.Error())
-	}
-	return node.Name, nil
+	return err
 }
 
 // UnschedulePod removes the given Pod from the given Node inside the snapshot.
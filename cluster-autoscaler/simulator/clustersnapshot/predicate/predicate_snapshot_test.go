@@ -568,13 +568,12 @@ func validTestCases(t *testing.T, snapshotName string) []modificationTestCase {
 				// The pod-owned claim already exists in the DRA snapshot, so trying to add it again should be an error.
 				wantErr: cmpopts.AnyError,
 				// The state shouldn't change on error.
-				// TODO(DRA): Until transaction-like clean-up is implemented in AddNodeInfo, the state is not cleaned up on error. Make modifiedState identical to initial state after the clean-up is implemented.
 				modifiedState: snapshotState{
 					draSnapshot: drasnapshot.NewSnapshot(
 						map[drasnapshot.ResourceClaimId]*resourceapi.ResourceClaim{
 							drasnapshot.GetClaimId(sharedClaim):   drautils.TestClaimWithAllocation(sharedClaim, sharedClaimAlloc),
-							drasnapshot.GetClaimId(podOwnedClaim): podOwnedClaim,
-						}, map[string][]*resourceapi.ResourceSlice{node.Name: resourceSlices}, nil, deviceClasses),
+							drasnapshot.GetClaimId(podOwnedClaim): podOwnedClaim.DeepCopy(),
+						}, nil, nil, deviceClasses),
 				},
 			},
 			{
@@ -595,13 +594,11 @@ func validTestCases(t *testing.T, snapshotName string) []modificationTestCase {
 				// The added pod-owned claim isn't allocated, so AddNodeInfo should fail.
 				wantErr: cmpopts.AnyError,
 				// The state shouldn't change on error.
-				// TODO(DRA): Until transaction-like clean-up is implemented in AddNodeInfo, the state is not cleaned up on error. Make modifiedState identical to initial state after the clean-up is implemented.
 				modifiedState: snapshotState{
 					draSnapshot: drasnapshot.NewSnapshot(
 						map[drasnapshot.ResourceClaimId]*resourceapi.ResourceClaim{
-							drasnapshot.GetClaimId(sharedClaim):   drautils.TestClaimWithPodReservations(drautils.TestClaimWithAllocation(sharedClaim, sharedClaimAlloc), podWithClaims),
-							drasnapshot.GetClaimId(podOwnedClaim): drautils.TestClaimWithPodReservations(podOwnedClaim, podWithClaims),
-						}, map[string][]*resourceapi.ResourceSlice{node.Name: resourceSlices}, nil, deviceClasses),
+							drasnapshot.GetClaimId(sharedClaim): drautils.TestClaimWithAllocation(sharedClaim, sharedClaimAlloc),
+						}, nil, nil, deviceClasses),
 				},
 			},
 			{
@@ -622,13 +619,11 @@ func validTestCases(t *testing.T, snapshotName string) []modificationTestCase {
 				// The added pod-owned claim is allocated to a different Node than the one being added, so AddNodeInfo should fail.
 				wantErr: cmpopts.AnyError,
 				// The state shouldn't change on error.
-				// TODO(DRA): Until transaction-like clean-up is implemented in AddNodeInfo, the state is not cleaned up on error. Make modifiedState identical to initial state after the clean-up is implemented.
 				modifiedState: snapshotState{
 					draSnapshot: drasnapshot.NewSnapshot(
 						map[drasnapshot.ResourceClaimId]*resourceapi.ResourceClaim{
-							drasnapshot.GetClaimId(sharedClaim):   drautils.TestClaimWithPodReservations(drautils.TestClaimWithAllocation(sharedClaim, sharedClaimAlloc), podWithClaims),
-							drasnapshot.GetClaimId(podOwnedClaim): drautils.TestClaimWithPodReservations(drautils.TestClaimWithAllocation(podOwnedClaim, podOwnedClaimAllocWrongNode), podWithClaims),
-						}, map[string][]*resourceapi.ResourceSlice{node.Name: resourceSlices}, nil, deviceClasses),
+							drasnapshot.GetClaimId(sharedClaim): drautils.TestClaimWithAllocation(sharedClaim, sharedClaimAlloc),
+						}, nil, nil, deviceClasses),
 				},
 			},
 			{
@@ -649,13 +644,11 @@ func validTestCases(t *testing.T, snapshotName string) []modificationTestCase {
 				// The shared claim referenced by the pod is already at the max reservation count, and no more reservations can be added - this should be an error to match scheduler behavior.
 				wantErr: cmpopts.AnyError,
 				// The state shouldn't change on error.
-				// TODO(DRA): Until transaction-like clean-up is implemented in AddNodeInfo, the state is not cleaned up on error. Make modifiedState identical to initial state after the clean-up is implemented.
 				modifiedState: snapshotState{
 					draSnapshot: drasnapshot.NewSnapshot(
 						map[drasnapshot.ResourceClaimId]*resourceapi.ResourceClaim{
-							drasnapshot.GetClaimId(sharedClaim):   fullyReservedClaim(drautils.TestClaimWithAllocation(sharedClaim, sharedClaimAlloc)),
-							drasnapshot.GetClaimId(podOwnedClaim): drautils.TestClaimWithAllocation(podOwnedClaim, podOwnedClaimAlloc),
-						}, map[string][]*resourceapi.ResourceSlice{node.Name: resourceSlices}, nil, deviceClasses),
+							drasnapshot.GetClaimId(sharedClaim): fullyReservedClaim(drautils.TestClaimWithAllocation(sharedClaim, sharedClaimAlloc)),
+						}, nil, nil, deviceClasses),
 				},
 			},
 			{
@@ -675,12 +668,11 @@ func validTestCases(t *testing.T, snapshotName string) []modificationTestCase {
 				// The added pod references a pod-owned claim that isn't present in the PodInfo - this should be an error.
 				wantErr: cmpopts.AnyError,
 				// The state shouldn't change on error.
-				// TODO(DRA): Until transaction-like clean-up is implemented in AddNodeInfo, the state is not cleaned up on error. Make modifiedState identical to initial state after the clean-up is implemented.
 				modifiedState: snapshotState{
 					draSnapshot: drasnapshot.NewSnapshot(
 						map[drasnapshot.ResourceClaimId]*resourceapi.ResourceClaim{
 							drasnapshot.GetClaimId(sharedClaim): drautils.TestClaimWithAllocation(sharedClaim, sharedClaimAlloc),
-						}, map[string][]*resourceapi.ResourceSlice{node.Name: resourceSlices}, nil, deviceClasses),
+						}, nil, nil, deviceClasses),
 				},
 			},
 			{
@@ -869,12 +861,11 @@ func validTestCases(t *testing.T, snapshotName string) []modificationTestCase {
 				// The shared claim referenced by the pod is already at the max reservation count, and no more reservations can be added - this should be an error to match scheduler behavior.
 				wantErr: clustersnapshot.NewSchedulingInternalError(nil, ""), // Only the type of the error is asserted (via cmp.EquateErrors() and errors.Is()), so the parameters don't matter here.
 				// The state shouldn't change on error.
-				// TODO(DRA): Until transaction-like clean-up is implemented in SchedulePod, the state is not cleaned up on error. Make modifiedState identical to initial state after the clean-up is implemented.
 				modifiedState: snapshotState{
 					nodes: []*apiv1.Node{node},
 					draSnapshot: drasnapshot.NewSnapshot(
 						map[drasnapshot.ResourceClaimId]*resourceapi.ResourceClaim{
-							drasnapshot.GetClaimId(podOwnedClaim): drautils.TestClaimWithAllocation(podOwnedClaim, podOwnedClaimAlloc),
+							drasnapshot.GetClaimId(podOwnedClaim): podOwnedClaim.DeepCopy(),
 							drasnapshot.GetClaimId(sharedClaim):   fullyReservedClaim(drautils.TestClaimWithAllocation(sharedClaim, sharedClaimAlloc)),
 						},
 						map[string][]*resourceapi.ResourceSlice{node.Name: resourceSlices}, nil, deviceClasses),
@@ -968,12 +959,11 @@ func validTestCases(t *testing.T, snapshotName string) []modificationTestCase {
 				// SchedulePodOnAnyNodeMatching should fail at trying to add a reservation to the shared claim for every Node.
 				wantErr: clustersnapshot.NewSchedulingInternalError(nil, ""), // Only the type of the error is asserted (via cmp.EquateErrors() and errors.Is()), so the parameters don't matter here.
 				// The state shouldn't change on error.
-				// TODO(DRA): Until transaction-like clean-up is implemented in SchedulePodOnAnyNodeMatching, the state is not cleaned up on error. Make modifiedState identical to initial state after the clean-up is implemented.
 				modifiedState: snapshotState{
 					nodes: []*apiv1.Node{otherNode, node, largeNode},
 					draSnapshot: drasnapshot.NewSnapshot(
 						map[drasnapshot.ResourceClaimId]*resourceapi.ResourceClaim{
-							drasnapshot.GetClaimId(podOwnedClaim): drautils.TestClaimWithAllocation(podOwnedClaim, podOwnedClaimAlloc),
+							drasnapshot.GetClaimId(podOwnedClaim): podOwnedClaim.DeepCopy(),
 							drasnapshot.GetClaimId(sharedClaim):   fullyReservedClaim(drautils.TestClaimWithAllocation(sharedClaim, sharedClaimAlloc)),
 						},
 						map[string][]*resourceapi.ResourceSlice{node.Name: resourceSlices}, nil, deviceClasses),
@@ -1288,6 +1278,52 @@ func TestForking(t *testing.T) {
 	}
 }
 
+func TestClone(t *testing.T) {
+	for snapshotName, snapshotFactory := range snapshots {
+		for _, tc := range validTestCases(t, snapshotName) {
+			t.Run(fmt.Sprintf("%s: %s clone", snapshotName, tc.name), func(t *testing.T) {
+				snapshot := startSnapshot(t, snapshotFactory, tc.state)
+				clone := NewPredicateSnapshot(snapshot.Clone(), framework.NewTestFrameworkHandleOrDie(t), true)
+
+				tc.runAndValidateOp(t, clone)
+
+				// Modifications should only be applied to the clone.
+				compareStates(t, tc.modifiedState, getSnapshotState(t, clone))
+				compareStates(t, tc.state, getSnapshotState(t, snapshot))
+			})
+			t.Run(fmt.Sprintf("%s: %s clone & modify original", snapshotName, tc.name), func(t *testing.T) {
+				snapshot := startSnapshot(t, snapshotFactory, tc.state)
+				clone := NewPredicateSnapshot(snapshot.Clone(), framework.NewTestFrameworkHandleOrDie(t), true)
+
+				tc.runAndValidateOp(t, snapshot)
+
+				// Modifications should only be applied to the original.
+				compareStates(t, tc.modifiedState, getSnapshotState(t, snapshot))
+				compareStates(t, tc.state, getSnapshotState(t, clone))
+			})
+			t.Run(fmt.Sprintf("%s: %s fork, clone & revert", snapshotName, tc.name), func(t *testing.T) {
+				snapshot := startSnapshot(t, snapshotFactory, tc.state)
+
+				snapshot.Fork()
+				tc.runAndValidateOp(t, snapshot)
+				clone := NewPredicateSnapshot(snapshot.Clone(), framework.NewTestFrameworkHandleOrDie(t), true)
+				snapshot.Revert()
+
+				// The clone should keep the state from before reverting.
+				compareStates(t, tc.state, getSnapshotState(t, snapshot))
+				compareStates(t, tc.modifiedState, getSnapshotState(t, clone))
+
+				// The clone can't be reverted, and isn't affected by modifying the reverted original.
+				clone.Revert()
+				for _, node := range tc.state.nodes {
+					assert.NoError(t, snapshot.ForceAddPod(BuildTestPod("extra-"+node.Name, 1, 1), node.Name))
+				}
+				compareStates(t, tc.modifiedState, getSnapshotState(t, clone))
+			})
+		}
+	}
+}
+
 func TestSetClusterState(t *testing.T) {
 	// Run with -count=1 to avoid caching.
 	localRand := rand.New(rand.NewSource(time.Now().Unix()))
@@ -291,6 +291,11 @@ func (snapshot *BasicSnapshotStore) Commit() error {
 	return nil
 }
 
+// Clone returns an unforked deep copy of the current snapshot state.
+func (snapshot *BasicSnapshotStore) Clone() clustersnapshot.ClusterSnapshotStore {
+	return &BasicSnapshotStore{data: []*internalBasicSnapshotData{snapshot.getInternalData().clone()}}
+}
+
 // clear reset cluster snapshot to empty, unforked state
 func (snapshot *BasicSnapshotStore) clear() {
 	baseData := newInternalBasicSnapshotData()
@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"math/bits"
+	"slices"
+)
+
+const (
+	cowTrieBits     = 5
+	cowTrieWidth    = 1 << cowTrieBits
+	cowTrieMask     = cowTrieWidth - 1
+	cowTrieMaxShift = 30
+)
+
+// editToken identifies the snapshot layer that created a trie node or a value.
+// Nodes and values can only be modified in place by the layer that created them,
+// all other layers have to copy them first.
+type editToken struct {
+	// Pointers to distinct zero-size values may be equal, so the token can't be empty.
+	_ byte
+}
+
+// cowTrie is a persistent map from uint32 keys to values, implemented as a 32-way
+// trie with bitmap-compressed nodes. Modifications copy the path from the root to
+// the modified value, so copies of a trie share all nodes that weren't modified.
+// Nodes created with the same edit token as the modification are modified in place
+// instead, which keeps repeated modifications within one snapshot layer cheap.
+//
+// The zero value is an empty trie. Iteration is in the ascending order of keys.
+type cowTrie[V any] struct {
+	root  *cowTrieNode[V]
+	shift uint
+}
+
+type cowTrieNode[V any] struct {
+	edit   *editToken
+	bitmap uint32
+	// children are set on inner nodes, values on leaves (nodes with shift 0).
+	children []*cowTrieNode[V]
+	values   []V
+}
+
+func (n *cowTrieNode[V]) index(key uint32, shift uint) (bit uint32, pos int) {
+	bit = 1 << ((key >> shift) & cowTrieMask)
+	return bit, bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+func (n *cowTrieNode[V]) editable(edit *editToken) *cowTrieNode[V] {
+	if n.edit == edit {
+		return n
+	}
+	return &cowTrieNode[V]{
+		edit:     edit,
+		bitmap:   n.bitmap,
+		children: slices.Clone(n.children),
+		values:   slices.Clone(n.values),
+	}
+}
+
+func (t *cowTrie[V]) inRange(key uint32) bool {
+	return t.shift >= cowTrieMaxShift || key>>(t.shift+cowTrieBits) == 0
+}
+
+// get returns the value stored under the key.
+func (t *cowTrie[V]) get(key uint32) (V, bool) {
+	var zero V
+	if t.root == nil || !t.inRange(key) {
+		return zero, false
+	}
+	n := t.root
+	for shift := t.shift; ; shift -= cowTrieBits {
+		bit, pos := n.index(key, shift)
+		if n.bitmap&bit == 0 {
+			return zero, false
+		}
+		if shift == 0 {
+			return n.values[pos], true
+		}
+		n = n.children[pos]
+	}
+}
+
+// set stores the value under the key, modifying nodes created with the edit token in place.
+func (t *cowTrie[V]) set(key uint32, value V, edit *editToken) {
+	for !t.inRange(key) {
+		if t.root != nil {
+			t.root = &cowTrieNode[V]{edit: edit, bitmap: 1, children: []*cowTrieNode[V]{t.root}}
+		}
+		t.shift += cowTrieBits
+	}
+	if t.root == nil {
+		t.root = &cowTrieNode[V]{edit: edit}
+	}
+	t.root = t.root.set(t.shift, key, value, edit)
+}
+
+func (n *cowTrieNode[V]) set(shift uint, key uint32, value V, edit *editToken) *cowTrieNode[V] {
+	n = n.editable(edit)
+	bit, pos := n.index(key, shift)
+	found := n.bitmap&bit != 0
+	n.bitmap |= bit
+	if shift == 0 {
+		if found {
+			n.values[pos] = value
+		} else {
+			n.values = slices.Insert(n.values, pos, value)
+		}
+		return n
+	}
+	if found {
+		n.children[pos] = n.children[pos].set(shift-cowTrieBits, key, value, edit)
+	} else {
+		child := (&cowTrieNode[V]{edit: edit}).set(shift-cowTrieBits, key, value, edit)
+		n.children = slices.Insert(n.children, pos, child)
+	}
+	return n
+}
+
+// delete removes the value stored under the key, modifying nodes created with the edit token in place.
+func (t *cowTrie[V]) delete(key uint32, edit *editToken) {
+	if t.root == nil || !t.inRange(key) {
+		return
+	}
+	t.root = t.root.delete(t.shift, key, edit)
+}
+
+func (n *cowTrieNode[V]) delete(shift uint, key uint32, edit *editToken) *cowTrieNode[V] {
+	bit, pos := n.index(key, shift)
+	if n.bitmap&bit == 0 {
+		return n
+	}
+	if shift > 0 {
+		child := n.children[pos].delete(shift-cowTrieBits, key, edit)
+		if child != nil {
+			if child != n.children[pos] {
+				n = n.editable(edit)
+				n.children[pos] = child
+			}
+			return n
+		}
+	}
+	if n.bitmap == bit {
+		return nil
+	}
+	n = n.editable(edit)
+	n.bitmap &^= bit
+	if shift == 0 {
+		n.values = slices.Delete(n.values, pos, pos+1)
+	} else {
+		n.children = slices.Delete(n.children, pos, pos+1)
+	}
+	return n
+}
+
+// forEach calls fn for all values in the trie, in the ascending order of their keys.
+func (t *cowTrie[V]) forEach(fn func(V)) {
+	if t.root != nil {
+		t.root.forEach(fn)
+	}
+}
+
+func (n *cowTrieNode[V]) forEach(fn func(V)) {
+	for _, value := range n.values {
+		fn(value)
+	}
+	for _, child := range n.children {
+		child.forEach(fn)
+	}
+}
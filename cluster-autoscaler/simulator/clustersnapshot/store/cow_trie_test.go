@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func trieValues(t *cowTrie[int]) []int {
+	var values []int
+	t.forEach(func(v int) {
+		values = append(values, v)
+	})
+	return values
+}
+
+func TestCowTrie(t *testing.T) {
+	edit := &editToken{}
+	var trie cowTrie[int]
+	keys := []uint32{math.MaxUint32, 0, 31, 32, 1000, 1 << 20}
+	for _, key := range keys {
+		trie.set(key, int(key%1000), edit)
+	}
+	for _, key := range keys {
+		value, found := trie.get(key)
+		assert.True(t, found)
+		assert.Equal(t, int(key%1000), value)
+	}
+	_, found := trie.get(33)
+	assert.False(t, found)
+	assert.Equal(t, []int{0, 31, 32, 0, 576, 295}, trieValues(&trie))
+
+	trie.set(31, 100, edit)
+	trie.delete(32, edit)
+	trie.delete(33, edit)
+	trie.delete(math.MaxUint32, edit)
+	assert.Equal(t, []int{0, 100, 0, 576}, trieValues(&trie))
+
+	for _, key := range []uint32{0, 31, 1000, 1 << 20} {
+		trie.delete(key, edit)
+	}
+	assert.Nil(t, trie.root)
+}
+
+func TestCowTrieCopyOnWrite(t *testing.T) {
+	baseEdit := &editToken{}
+	var base cowTrie[int]
+	for key := uint32(0); key < 100; key++ {
+		base.set(key, int(key), baseEdit)
+	}
+
+	fork := base
+	forkEdit := &editToken{}
+	fork.set(5, 500, forkEdit)
+	fork.set(100, 100, forkEdit)
+	fork.delete(50, forkEdit)
+	fork.set(6, 600, forkEdit)
+
+	// The base trie isn't affected by modifications of the fork.
+	for key := uint32(0); key < 100; key++ {
+		value, found := base.get(key)
+		assert.True(t, found)
+		assert.Equal(t, int(key), value)
+	}
+	_, found := base.get(100)
+	assert.False(t, found)
+
+	value, _ := fork.get(5)
+	assert.Equal(t, 500, value)
+	value, _ = fork.get(6)
+	assert.Equal(t, 600, value)
+	_, found = fork.get(50)
+	assert.False(t, found)
+	assert.Len(t, trieValues(&fork), 100)
+
+	// Modifications with the edit token of the base trie are done in place.
+	root := base.root
+	base.set(7, 700, baseEdit)
+	assert.Same(t, root, base.root)
+	value, _ = fork.get(7)
+	assert.Equal(t, 7, value)
+}
@@ -19,6 +19,8 @@ package store
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
+	"slices"
 
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
@@ -30,19 +32,26 @@ import (
 
 // DeltaSnapshotStore is an implementation of ClusterSnapshotStore optimized for typical Cluster Autoscaler usage - (fork, add stuff, revert), repeated many times per loop.
 //
+// Node infos are kept in persistent (copy-on-write) tries, so a fork shares all the data with
+// the forked state and only copies the small part of the tries and the node infos it modifies.
+//
 // Complexity of some notable operations:
 //
-//	fork - O(1)
+//	fork - O(1), O(n) if node infos weren't listed since the last change of the node set
 //	revert - O(1)
-//	commit - O(n)
+//	commit - O(1)
+//	get node info - O(log n)
+//	add/remove node, add/remove pod - O(log n)
 //	list all pods (no filtering) - O(n), cached
 //	list all pods (with filtering) - O(n)
 //	list node infos - O(n), cached
 //
 // Watch out for:
 //
-//	node deletions, pod additions & deletions - invalidates cache of current snapshot
-//		(when forked affects delta, but not base.)
+//	modifying a node info not added since forking - copies the cached node info list
+//		(memory copy of n pointers, once per fork if the list isn't listed in between).
+//	node deletions, pod additions & deletions of pods with affinity or PVCs - invalidates
+//		pod caches of current snapshot (when forked affects delta, but not base.)
 //	pod affinity - causes scheduler framework to list pods with non-empty selector,
 //		so basic caching doesn't help.
 type DeltaSnapshotStore struct {
@@ -54,90 +63,134 @@ type deltaSnapshotStoreNodeLister DeltaSnapshotStore
 type deltaSnapshotStoreStorageLister DeltaSnapshotStore
 
 type internalDeltaSnapshotData struct {
+	// baseData is the state at the moment of forking, nil if not forked.
 	baseData *internalDeltaSnapshotData
-
-	addedNodeInfoMap    map[string]*schedulerframework.NodeInfo
-	modifiedNodeInfoMap map[string]*schedulerframework.NodeInfo
-	deletedNodeInfos    map[string]bool
-
-	nodeInfoList                     []*schedulerframework.NodeInfo
+	// edit identifies trie nodes and node infos created since forking, which can be modified in place.
+	edit *editToken
+
+	// nodeInfos maps slots 0..nodeCount-1 to node infos. Removing a node moves the node
+	// from the last slot to the freed one, so slots match positions in nodeInfoList.
+	nodeInfos cowTrie[nodeInfoEntry]
+	// nodeSlots maps hashes of node names to slots of the nodes.
+	nodeSlots cowTrie[[]nodeSlot]
+	nodeCount int
+
+	nodeInfoList []*schedulerframework.NodeInfo
+	// nodeInfoListOwned is true if nodeInfoList can be modified in place - it wasn't
+	// returned by List() and isn't shared with the base data.
+	nodeInfoListOwned                bool
 	havePodsWithAffinity             []*schedulerframework.NodeInfo
 	havePodsWithRequiredAntiAffinity []*schedulerframework.NodeInfo
 	pvcNamespaceMap                  map[string]int
 }
 
+type nodeInfoEntry struct {
+	nodeInfo *schedulerframework.NodeInfo
+	edit     *editToken
+}
+
+type nodeSlot struct {
+	name string
+	slot int
+}
+
 func newInternalDeltaSnapshotData() *internalDeltaSnapshotData {
 	return &internalDeltaSnapshotData{
-		addedNodeInfoMap:    make(map[string]*schedulerframework.NodeInfo),
-		modifiedNodeInfoMap: make(map[string]*schedulerframework.NodeInfo),
-		deletedNodeInfos:    make(map[string]bool),
+		edit: &editToken{},
 	}
 }
 
-func (data *internalDeltaSnapshotData) getNodeInfo(name string) (*schedulerframework.NodeInfo, bool) {
-	if data == nil {
-		return nil, false
-	}
-	if nodeInfo, found := data.getNodeInfoLocal(name); found {
-		return nodeInfo, found
+func nodeNameHash(name string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	return h.Sum32()
+}
+
+func (data *internalDeltaSnapshotData) getNodeSlot(name string) (int, bool) {
+	bucket, _ := data.nodeSlots.get(nodeNameHash(name))
+	for _, ns := range bucket {
+		if ns.name == name {
+			return ns.slot, true
+		}
 	}
-	if data.deletedNodeInfos[name] {
-		return nil, false
+	return 0, false
+}
+
+func (data *internalDeltaSnapshotData) setNodeSlot(name string, slot int) {
+	hash := nodeNameHash(name)
+	bucket, _ := data.nodeSlots.get(hash)
+	// Buckets are shared with the base data, so they are never modified in place.
+	newBucket := make([]nodeSlot, 0, len(bucket)+1)
+	for _, ns := range bucket {
+		if ns.name != name {
+			newBucket = append(newBucket, ns)
+		}
 	}
-	return data.baseData.getNodeInfo(name)
+	newBucket = append(newBucket, nodeSlot{name: name, slot: slot})
+	data.nodeSlots.set(hash, newBucket, data.edit)
 }
 
-func (data *internalDeltaSnapshotData) getNodeInfoLocal(name string) (*schedulerframework.NodeInfo, bool) {
-	if data == nil {
-		return nil, false
+func (data *internalDeltaSnapshotData) deleteNodeSlot(name string) {
+	hash := nodeNameHash(name)
+	bucket, _ := data.nodeSlots.get(hash)
+	newBucket := make([]nodeSlot, 0, len(bucket))
+	for _, ns := range bucket {
+		if ns.name != name {
+			newBucket = append(newBucket, ns)
+		}
 	}
-	if nodeInfo, found := data.addedNodeInfoMap[name]; found {
-		return nodeInfo, true
+	if len(newBucket) == 0 {
+		data.nodeSlots.delete(hash, data.edit)
+	} else {
+		data.nodeSlots.set(hash, newBucket, data.edit)
 	}
-	if nodeInfo, found := data.modifiedNodeInfoMap[name]; found {
-		return nodeInfo, true
+}
+
+func (data *internalDeltaSnapshotData) getNodeInfo(name string) (*schedulerframework.NodeInfo, bool) {
+	slot, found := data.getNodeSlot(name)
+	if !found {
+		return nil, false
 	}
-	return nil, false
+	entry, _ := data.nodeInfos.get(uint32(slot))
+	return entry.nodeInfo, true
 }
 
 func (data *internalDeltaSnapshotData) getNodeInfoList() []*schedulerframework.NodeInfo {
-	if data == nil {
-		return nil
-	}
 	if data.nodeInfoList == nil {
 		data.nodeInfoList = data.buildNodeInfoList()
 	}
 	return data.nodeInfoList
 }
 
-// Contains costly copying throughout the struct chain. Use wisely.
 func (data *internalDeltaSnapshotData) buildNodeInfoList() []*schedulerframework.NodeInfo {
-	baseList := data.baseData.getNodeInfoList()
-	totalLen := len(baseList) + len(data.addedNodeInfoMap)
-	var nodeInfoList []*schedulerframework.NodeInfo
-
-	if len(data.deletedNodeInfos) > 0 || len(data.modifiedNodeInfoMap) > 0 {
-		nodeInfoList = make([]*schedulerframework.NodeInfo, 0, totalLen)
-		for _, bni := range baseList {
-			if data.deletedNodeInfos[bni.Node().Name] {
-				continue
-			}
-			if mni, found := data.modifiedNodeInfoMap[bni.Node().Name]; found {
-				nodeInfoList = append(nodeInfoList, mni)
-				continue
-			}
-			nodeInfoList = append(nodeInfoList, bni)
-		}
-	} else {
-		nodeInfoList = make([]*schedulerframework.NodeInfo, len(baseList), totalLen)
-		copy(nodeInfoList, baseList)
-	}
+	nodeInfoList := make([]*schedulerframework.NodeInfo, 0, data.nodeCount)
+	data.nodeInfos.forEach(func(entry nodeInfoEntry) {
+		nodeInfoList = append(nodeInfoList, entry.nodeInfo)
+	})
+	return nodeInfoList
+}
 
-	for _, ani := range data.addedNodeInfoMap {
-		nodeInfoList = append(nodeInfoList, ani)
+// ownNodeInfoList makes the cached node info list safe to modify in place, if it's cached.
+func (data *internalDeltaSnapshotData) ownNodeInfoList() bool {
+	if data.nodeInfoList == nil {
+		return false
+	}
+	if !data.nodeInfoListOwned {
+		data.nodeInfoList = slices.Clone(data.nodeInfoList)
+		data.nodeInfoListOwned = true
 	}
+	return true
+}
 
-	return nodeInfoList
+func (data *internalDeltaSnapshotData) setNodeInfo(slot int, nodeInfo *schedulerframework.NodeInfo) {
+	data.nodeInfos.set(uint32(slot), nodeInfoEntry{nodeInfo: nodeInfo, edit: data.edit}, data.edit)
+	if data.ownNodeInfoList() {
+		if slot == len(data.nodeInfoList) {
+			data.nodeInfoList = append(data.nodeInfoList, nodeInfo)
+		} else {
+			data.nodeInfoList[slot] = nodeInfo
+		}
+	}
 }
 
 func (data *internalDeltaSnapshotData) addNode(node *apiv1.Node) (*schedulerframework.NodeInfo, error) {
@@ -151,20 +204,14 @@ func (data *internalDeltaSnapshotData) addNode(node *apiv1.Node) (*schedulerfram
 }
 
 func (data *internalDeltaSnapshotData) addNodeInfo(nodeInfo *schedulerframework.NodeInfo) error {
-	if _, found := data.getNodeInfo(nodeInfo.Node().Name); found {
+	if _, found := data.getNodeSlot(nodeInfo.Node().Name); found {
 		return fmt.Errorf("node %s already in snapshot", nodeInfo.Node().Name)
 	}
 
-	if _, found := data.deletedNodeInfos[nodeInfo.Node().Name]; found {
-		delete(data.deletedNodeInfos, nodeInfo.Node().Name)
-		data.modifiedNodeInfoMap[nodeInfo.Node().Name] = nodeInfo
-	} else {
-		data.addedNodeInfoMap[nodeInfo.Node().Name] = nodeInfo
-	}
-
-	if data.nodeInfoList != nil {
-		data.nodeInfoList = append(data.nodeInfoList, nodeInfo)
-	}
+	slot := data.nodeCount
+	data.setNodeInfo(slot, nodeInfo)
+	data.setNodeSlot(nodeInfo.Node().Name, slot)
+	data.nodeCount++
 
 	if len(nodeInfo.Pods) > 0 {
 		data.clearPodCaches()
@@ -186,51 +233,47 @@ func (data *internalDeltaSnapshotData) clearPodCaches() {
 }
 
 func (data *internalDeltaSnapshotData) removeNodeInfo(nodeName string) error {
-	_, foundInDelta := data.addedNodeInfoMap[nodeName]
-	if foundInDelta {
-		// If node was added within this delta, delete this change.
-		delete(data.addedNodeInfoMap, nodeName)
-	}
-
-	if _, modified := data.modifiedNodeInfoMap[nodeName]; modified {
-		// If node was modified within this delta, delete this change.
-		delete(data.modifiedNodeInfoMap, nodeName)
-	}
-
-	if _, deleted := data.deletedNodeInfos[nodeName]; deleted {
-		// If node was deleted within this delta, fail with error.
+	slot, found := data.getNodeSlot(nodeName)
+	if !found {
 		return clustersnapshot.ErrNodeNotFound
 	}
 
-	_, foundInBase := data.baseData.getNodeInfo(nodeName)
-	if foundInBase {
-		// If node was found in the underlying data, mark it as deleted in delta.
-		data.deletedNodeInfos[nodeName] = true
+	// Move the node from the last slot to the freed one to keep the slots dense.
+	lastSlot := data.nodeCount - 1
+	if slot != lastSlot {
+		lastEntry, _ := data.nodeInfos.get(uint32(lastSlot))
+		data.nodeInfos.set(uint32(slot), lastEntry, data.edit)
+		data.setNodeSlot(lastEntry.nodeInfo.Node().Name, slot)
+		if data.ownNodeInfoList() {
+			data.nodeInfoList[slot] = lastEntry.nodeInfo
+		}
 	}
-
-	if !foundInBase && !foundInDelta {
-		// Node not found in the chain.
-		return clustersnapshot.ErrNodeNotFound
+	data.nodeInfos.delete(uint32(lastSlot), data.edit)
+	data.deleteNodeSlot(nodeName)
+	data.nodeCount--
+	if data.ownNodeInfoList() {
+		data.nodeInfoList = data.nodeInfoList[:lastSlot]
 	}
 
-	// Maybe consider deleting from the lists instead. Maybe not.
-	data.clearCaches()
+	data.clearPodCaches()
 	return nil
 }
 
 func (data *internalDeltaSnapshotData) nodeInfoToModify(nodeName string) (*schedulerframework.NodeInfo, bool) {
-	dni, found := data.getNodeInfoLocal(nodeName)
+	slot, found := data.getNodeSlot(nodeName)
 	if !found {
-		if _, found := data.deletedNodeInfos[nodeName]; found {
-			return nil, false
-		}
-		bni, found := data.baseData.getNodeInfo(nodeName)
-		if !found {
-			return nil, false
-		}
-		dni = bni.Snapshot()
-		data.modifiedNodeInfoMap[nodeName] = dni
-		data.clearCaches()
+		return nil, false
+	}
+	entry, _ := data.nodeInfos.get(uint32(slot))
+	if entry.edit == data.edit {
+		return entry.nodeInfo, true
+	}
+	// The node info is shared with the base data, copy it before modifying.
+	dni := entry.nodeInfo.Snapshot()
+	data.setNodeInfo(slot, dni)
+	if len(dni.PodsWithAffinity) > 0 || len(dni.PodsWithRequiredAntiAffinity) > 0 {
+		// Cached lists contain the original node info.
+		data.clearPodCaches()
 	}
 	return dni, true
 }
@@ -243,8 +286,7 @@ func (data *internalDeltaSnapshotData) addPod(pod *apiv1.Pod, nodeName string) e
 
 	ni.AddPod(pod)
 
-	// Maybe consider deleting from the list in the future. Maybe not.
-	data.clearCaches()
+	data.clearPodCachesFor(pod)
 	return nil
 }
 
@@ -257,26 +299,42 @@ func (data *internalDeltaSnapshotData) removePod(namespace, name, nodeName strin
 		return clustersnapshot.ErrNodeNotFound
 	}
 
-	podFound := false
+	var removedPod *apiv1.Pod
 	logger := klog.Background()
 	for _, podInfo := range ni.Pods {
 		if podInfo.Pod.Namespace == namespace && podInfo.Pod.Name == name {
 			if err := ni.RemovePod(logger, podInfo.Pod); err != nil {
 				return fmt.Errorf("cannot remove pod; %v", err)
 			}
-			podFound = true
+			removedPod = podInfo.Pod
 			break
 		}
 	}
-	if !podFound {
+	if removedPod == nil {
 		return fmt.Errorf("pod %s/%s not in snapshot", namespace, name)
 	}
 
-	// Maybe consider deleting from the list in the future. Maybe not.
-	data.clearCaches()
+	data.clearPodCachesFor(removedPod)
 	return nil
 }
 
+// clearPodCachesFor invalidates pod caches which can be affected by adding or removing the pod.
+// Pods without affinity and PVCs don't change which nodes are in the cached lists, or PVC usage.
+func (data *internalDeltaSnapshotData) clearPodCachesFor(pod *apiv1.Pod) {
+	if pod.Spec.Affinity != nil || podUsesPVCs(pod) {
+		data.clearPodCaches()
+	}
+}
+
+func podUsesPVCs(pod *apiv1.Pod) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil || volume.Ephemeral != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func (data *internalDeltaSnapshotData) isPVCUsedByPods(key string) bool {
 	if data.pvcNamespaceMap != nil {
 		return data.pvcNamespaceMap[key] > 0
@@ -293,40 +351,47 @@ func (data *internalDeltaSnapshotData) isPVCUsedByPods(key string) bool {
 }
 
 func (data *internalDeltaSnapshotData) fork() *internalDeltaSnapshotData {
-	forkedData := newInternalDeltaSnapshotData()
+	// Build the node info list before forking, so that it isn't rebuilt after every revert.
+	// Caches stay valid, but the node info list can't be modified in place by either of the two.
+	data.getNodeInfoList()
+	data.nodeInfoListOwned = false
+	forkedData := *data
 	forkedData.baseData = data
-	return forkedData
+	forkedData.edit = &editToken{}
+	return &forkedData
+}
+
+func (data *internalDeltaSnapshotData) clone() *internalDeltaSnapshotData {
+	data.getNodeInfoList()
+	data.nodeInfoListOwned = false
+	clonedData := *data
+	clonedData.baseData = nil
+	clonedData.edit = &editToken{}
+	// Everything created so far is now shared with the clone, so neither data nor the states
+	// it can be reverted to can modify it in place anymore.
+	for d := data; d != nil; d = d.baseData {
+		d.edit = &editToken{}
+	}
+	return &clonedData
 }
 
-func (data *internalDeltaSnapshotData) commit() (*internalDeltaSnapshotData, error) {
+func (data *internalDeltaSnapshotData) commit() *internalDeltaSnapshotData {
 	if data.baseData == nil {
 		// do nothing... as in basic snapshot.
-		return data, nil
-	}
-	for node := range data.deletedNodeInfos {
-		if err := data.baseData.removeNodeInfo(node); err != nil {
-			return nil, err
-		}
-	}
-	for _, node := range data.modifiedNodeInfoMap {
-		if err := data.baseData.removeNodeInfo(node.Node().Name); err != nil {
-			return nil, err
-		}
-		if err := data.baseData.addNodeInfo(node); err != nil {
-			return nil, err
-		}
+		return data
 	}
-	for _, node := range data.addedNodeInfoMap {
-		if err := data.baseData.addNodeInfo(node); err != nil {
-			return nil, err
-		}
-	}
-	return data.baseData, nil
+	// Nothing created since forking is shared with the data below the base data,
+	// so the forked data can take the place of the base data, edit token included.
+	data.baseData = data.baseData.baseData
+	return data
 }
 
 // List returns list of all node infos.
 func (snapshot *deltaSnapshotStoreNodeLister) List() ([]*schedulerframework.NodeInfo, error) {
-	return snapshot.data.getNodeInfoList(), nil
+	nodeInfoList := snapshot.data.getNodeInfoList()
+	// The caller may keep the list, so it can't be modified in place anymore.
+	snapshot.data.nodeInfoListOwned = false
+	return nodeInfoList, nil
 }
 
 // HavePodsWithAffinityList returns list of all node infos with pods that have affinity constrints.
@@ -533,16 +598,22 @@ func (snapshot *DeltaSnapshotStore) Revert() {
 }
 
 // Commit commits changes done after forking.
-// Time: O(n), where n = size of delta (number of nodes added, modified or deleted since forking)
+// Time: O(1)
 func (snapshot *DeltaSnapshotStore) Commit() error {
-	newData, err := snapshot.data.commit()
-	if err != nil {
-		return err
-	}
-	snapshot.data = newData
+	snapshot.data = snapshot.data.commit()
 	return nil
 }
 
+// Clone returns an unforked copy of the current snapshot state. The copy shares all the data with
+// the original, both of them copy the parts they modify afterwards.
+// Time: O(1), O(n) if node infos weren't listed since the last change of the node set
+func (snapshot *DeltaSnapshotStore) Clone() clustersnapshot.ClusterSnapshotStore {
+	return &DeltaSnapshotStore{
+		data:        snapshot.data.clone(),
+		parallelism: snapshot.parallelism,
+	}
+}
+
 // Clear reset cluster snapshot to empty, unforked state
 // Time: O(1)
 func (snapshot *DeltaSnapshotStore) clear() {
@@ -82,3 +82,69 @@ func BenchmarkBuildNodeInfoList(b *testing.B) {
 		})
 	}
 }
+
+// BenchmarkForkModifyRevert simulates a binpacking or drain simulation: a fork, pods added to a few
+// existing nodes and a new node, with the node infos listed after each change, and a revert or commit.
+func BenchmarkForkModifyRevert(b *testing.B) {
+	nodeCounts := []int{1000, 5000, 15000, 100000}
+	for _, nodeCount := range nodeCounts {
+		for _, commit := range []bool{false, true} {
+			name := fmt.Sprintf("fork modify revert %d", nodeCount)
+			if commit {
+				name = fmt.Sprintf("fork modify commit %d", nodeCount)
+			}
+			b.Run(name, func(b *testing.B) {
+				nodes := clustersnapshot.CreateTestNodes(nodeCount)
+				deltaStore := NewDeltaSnapshotStore(16)
+				if err := deltaStore.SetClusterState(nodes, nil, drasnapshot.Snapshot{}); err != nil {
+					assert.NoError(b, err)
+				}
+				newNode := clustersnapshot.CreateTestNodesWithPrefix("new", 1)[0]
+				pods := clustersnapshot.CreateTestPods(10)
+				b.ResetTimer()
+				podNodes := make([]string, len(pods))
+				for i := 0; i < b.N; i++ {
+					deltaStore.Fork()
+					for j, pod := range pods[:5] {
+						podNodes[j] = nodes[(i+j*nodeCount/5)%nodeCount].Name
+						if err := deltaStore.ForceAddPod(pod, podNodes[j]); err != nil {
+							assert.NoError(b, err)
+						}
+						if _, err := deltaStore.NodeInfos().List(); err != nil {
+							assert.NoError(b, err)
+						}
+					}
+					newNodeInfo := schedulerframework.NewNodeInfo()
+					newNodeInfo.SetNode(newNode)
+					if err := deltaStore.AddSchedulerNodeInfo(newNodeInfo); err != nil {
+						assert.NoError(b, err)
+					}
+					for j, pod := range pods[5:] {
+						podNodes[5+j] = newNode.Name
+						if err := deltaStore.ForceAddPod(pod, newNode.Name); err != nil {
+							assert.NoError(b, err)
+						}
+						if _, err := deltaStore.NodeInfos().List(); err != nil {
+							assert.NoError(b, err)
+						}
+					}
+					if commit {
+						if err := deltaStore.Commit(); err != nil {
+							assert.NoError(b, err)
+						}
+						for j, pod := range pods {
+							if err := deltaStore.ForceRemovePod(pod.Namespace, pod.Name, podNodes[j]); err != nil {
+								assert.NoError(b, err)
+							}
+						}
+						if err := deltaStore.RemoveSchedulerNodeInfo(newNode.Name); err != nil {
+							assert.NoError(b, err)
+						}
+					} else {
+						deltaStore.Revert()
+					}
+				}
+			})
+		}
+	}
+}
@@ -18,19 +18,26 @@ package localstorage
 
 import (
 	"fmt"
+	"time"
 
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
 	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
+	klog "k8s.io/klog/v2"
 )
 
 // Rule is a drainability rule on how to handle local storage pods.
-type Rule struct{}
+type Rule struct {
+	skipNodesWithLocalStorage bool
+}
 
-// New creates a new Rule.
-func New() *Rule {
-	return &Rule{}
+// New creates a new Rule. Pods with local storage block scale down if skipNodesWithLocalStorage
+// is set, unless they declare for how long their local data has to be preserved.
+func New(skipNodesWithLocalStorage bool) *Rule {
+	return &Rule{
+		skipNodesWithLocalStorage: skipNodesWithLocalStorage,
+	}
 }
 
 // Name returns the name of the rule.
@@ -40,8 +47,36 @@ func (r *Rule) Name() string {
 
 // Drainable decides what to do with local storage pods on node drain.
 func (r *Rule) Drainable(drainCtx *drainability.DrainContext, pod *apiv1.Pod, _ *framework.NodeInfo) drainability.Status {
-	if drain.HasBlockingLocalStorage(pod) {
+	if !drain.HasBlockingLocalStorage(pod) {
+		return drainability.NewUndefinedStatus()
+	}
+	if expiry, found := localDataExpiry(pod); found {
+		if drainCtx.Timestamp.Before(expiry) {
+			return drainability.NewBlockedStatus(drain.LocalDataTTLNotExpired, fmt.Errorf("local data of pod %s has to be preserved until %v", pod.Name, expiry))
+		}
+		return drainability.NewUndefinedStatus()
+	}
+	if r.skipNodesWithLocalStorage {
 		return drainability.NewBlockedStatus(drain.LocalStorageRequested, fmt.Errorf("pod with local storage present: %s", pod.Name))
 	}
 	return drainability.NewUndefinedStatus()
 }
+
+// localDataExpiry returns the time until which the local data of the pod has to be preserved, based on
+// its LocalDataTTLKey annotation and start time. Returns false if the annotation is missing or invalid.
+func localDataExpiry(pod *apiv1.Pod) (time.Time, bool) {
+	value, found := pod.GetAnnotations()[drain.LocalDataTTLKey]
+	if !found {
+		return time.Time{}, false
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl < 0 {
+		klog.Warningf("Ignoring invalid %s annotation %q of pod %s/%s", drain.LocalDataTTLKey, value, pod.Namespace, pod.Name)
+		return time.Time{}, false
+	}
+	startTime := pod.CreationTimestamp.Time
+	if pod.Status.StartTime != nil {
+		startTime = pod.Status.StartTime.Time
+	}
+	return startTime.Add(ttl), true
+}
@@ -277,9 +277,87 @@ func TestDrainable(t *testing.T) {
 			drainCtx := &drainability.DrainContext{
 				Timestamp: testTime,
 			}
-			status := New().Drainable(drainCtx, test.pod, nil)
+			status := New(true).Drainable(drainCtx, test.pod, nil)
 			assert.Equal(t, test.wantReason, status.BlockingReason)
 			assert.Equal(t, test.wantError, status.Error != nil)
 		})
 	}
 }
+
+func TestDrainableLocalDataTTL(t *testing.T) {
+	testTime := time.Date(2020, time.December, 18, 17, 0, 0, 0, time.UTC)
+	buildPod := func(ttl string, startTime *time.Time) *apiv1.Pod {
+		pod := &apiv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "bar",
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(testTime.Add(-time.Hour)),
+			},
+			Spec: apiv1.PodSpec{
+				NodeName: "node",
+				Volumes: []apiv1.Volume{
+					{
+						Name:         "scratch",
+						VolumeSource: apiv1.VolumeSource{EmptyDir: &apiv1.EmptyDirVolumeSource{Medium: ""}},
+					},
+				},
+			},
+		}
+		if ttl != "" {
+			pod.Annotations = map[string]string{drain.LocalDataTTLKey: ttl}
+		}
+		if startTime != nil {
+			pod.Status.StartTime = &metav1.Time{Time: *startTime}
+		}
+		return pod
+	}
+	recentStart := testTime.Add(-10 * time.Minute)
+
+	for desc, test := range map[string]struct {
+		pod                       *apiv1.Pod
+		skipNodesWithLocalStorage bool
+		wantReason                drain.BlockingPodReason
+	}{
+		"no annotation, local storage not skipped": {
+			pod: buildPod("", nil),
+		},
+		"no annotation, local storage skipped": {
+			pod:                       buildPod("", nil),
+			skipNodesWithLocalStorage: true,
+			wantReason:                drain.LocalStorageRequested,
+		},
+		"ttl not expired since creation, local storage not skipped": {
+			pod:        buildPod("2h", nil),
+			wantReason: drain.LocalDataTTLNotExpired,
+		},
+		"ttl expired since creation, local storage skipped": {
+			pod:                       buildPod("30m", nil),
+			skipNodesWithLocalStorage: true,
+		},
+		"ttl not expired since start": {
+			pod:                       buildPod("30m", &recentStart),
+			skipNodesWithLocalStorage: true,
+			wantReason:                drain.LocalDataTTLNotExpired,
+		},
+		"ttl expired since start": {
+			pod: buildPod("5m", &recentStart),
+		},
+		"invalid ttl, local storage skipped": {
+			pod:                       buildPod("forever", nil),
+			skipNodesWithLocalStorage: true,
+			wantReason:                drain.LocalStorageRequested,
+		},
+		"invalid ttl, local storage not skipped": {
+			pod: buildPod("-1h", nil),
+		},
+	} {
+		t.Run(desc, func(t *testing.T) {
+			drainCtx := &drainability.DrainContext{
+				Timestamp: testTime,
+			}
+			status := New(test.skipNodesWithLocalStorage).Drainable(drainCtx, test.pod, nil)
+			assert.Equal(t, test.wantReason, status.BlockingReason)
+			assert.Equal(t, test.wantReason != drain.NoReason, status.Error != nil)
+		})
+	}
+}
@@ -38,10 +38,17 @@ func (r *Rule) Name() string {
 	return "NotSafeToEvict"
 }
 
-// Drainable decides what to do with not safe to evict pods on node drain.
+// Drainable decides what to do with not safe to evict pods on node drain. Pods annotated as safe to
+// evict after running for some time aren't safe to evict until the time passes.
 func (Rule) Drainable(drainCtx *drainability.DrainContext, pod *apiv1.Pod, _ *framework.NodeInfo) drainability.Status {
 	if drain.HasNotSafeToEvictAnnotation(pod) {
 		return drainability.NewBlockedStatus(drain.NotSafeToEvictAnnotation, fmt.Errorf("pod annotated as not safe to evict present: %s", pod.Name))
 	}
+	if drain.HasSafeToEvictAnnotation(pod) {
+		return drainability.NewUndefinedStatus()
+	}
+	if safeToEvictTime, found := drain.SafeToEvictTime(pod); found && drainCtx.Timestamp.Before(safeToEvictTime) {
+		return drainability.NewBlockedStatus(drain.NotSafeToEvictYet, fmt.Errorf("pod %s is not safe to evict until %v", pod.Name, safeToEvictTime))
+	}
 	return drainability.NewUndefinedStatus()
 }
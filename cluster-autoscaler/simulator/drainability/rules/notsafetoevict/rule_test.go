@@ -133,6 +133,51 @@ func TestDrainable(t *testing.T) {
 			wantReason: drain.NotSafeToEvictAnnotation,
 			wantError:  true,
 		},
+		"pod with PodSafeToEvictFor annotation which didn't run long enough": {
+			pod: &apiv1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "bar",
+					Namespace: "default",
+					Annotations: map[string]string{
+						drain.PodSafeToEvictForKey: "2h",
+					},
+				},
+				Status: apiv1.PodStatus{
+					StartTime: &metav1.Time{Time: testTime.Add(-time.Hour)},
+				},
+			},
+			wantReason: drain.NotSafeToEvictYet,
+			wantError:  true,
+		},
+		"pod with PodSafeToEvictFor annotation which ran long enough": {
+			pod: &apiv1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "bar",
+					Namespace: "default",
+					Annotations: map[string]string{
+						drain.PodSafeToEvictForKey: "2h",
+					},
+				},
+				Status: apiv1.PodStatus{
+					StartTime: &metav1.Time{Time: testTime.Add(-3 * time.Hour)},
+				},
+			},
+		},
+		"pod with PodSafeToEvictFor and PodSafeToEvict annotations": {
+			pod: &apiv1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "bar",
+					Namespace: "default",
+					Annotations: map[string]string{
+						drain.PodSafeToEvictKey:    "true",
+						drain.PodSafeToEvictForKey: "2h",
+					},
+				},
+				Status: apiv1.PodStatus{
+					StartTime: &metav1.Time{Time: testTime.Add(-time.Hour)},
+				},
+			},
+		},
 	} {
 		t.Run(desc, func(t *testing.T) {
 			drainCtx := &drainability.DrainContext{
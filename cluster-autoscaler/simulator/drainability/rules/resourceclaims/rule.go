@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceclaims
+
+import (
+	"fmt"
+
+	apiv1 "k8s.io/api/core/v1"
+	resourceapi "k8s.io/api/resource/v1beta1"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability"
+	drautils "k8s.io/autoscaler/cluster-autoscaler/simulator/dynamicresources/utils"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
+)
+
+// Rule is a drainability rule on how to handle pods sharing node-local ResourceClaims
+// with consumers running outside of the drained node.
+type Rule struct{}
+
+// New creates a new Rule.
+func New() *Rule {
+	return &Rule{}
+}
+
+// Name returns the name of the rule.
+func (r *Rule) Name() string {
+	return "ResourceClaims"
+}
+
+// Drainable blocks draining a node if a pod references a shared ResourceClaim which is allocated
+// to devices available only on that node, and which is also reserved for consumers that don't run
+// on the node. Such a claim can't be deallocated when the node is drained, so it can't be re-allocated
+// on any of the remaining nodes, and the pod wouldn't be able to schedule anywhere else.
+//
+// Pod-owned claims, and shared claims used only by pods on the drained node, are deallocated by
+// the removal simulation and re-allocated as part of rescheduling the pods, so they aren't checked here.
+func (r *Rule) Drainable(drainCtx *drainability.DrainContext, pod *apiv1.Pod, nodeInfo *framework.NodeInfo) drainability.Status {
+	if nodeInfo == nil || len(pod.Spec.ResourceClaims) == 0 {
+		return drainability.NewUndefinedStatus()
+	}
+	claims := neededClaims(pod, nodeInfo)
+	if len(claims) == 0 {
+		return drainability.NewUndefinedStatus()
+	}
+
+	var otherNodes []*apiv1.Node
+	if drainCtx.Listers != nil {
+		nodes, err := drainCtx.Listers.ReadyNodeLister().List()
+		if err != nil {
+			return drainability.NewBlockedStatus(drain.UnexpectedError, fmt.Errorf("error when trying to list nodes for %s/%s, err: %v", pod.Namespace, pod.Name, err))
+		}
+		for _, node := range nodes {
+			if node.Name != nodeInfo.Node().Name && !taints.HasToBeDeletedTaint(node) {
+				otherNodes = append(otherNodes, node)
+			}
+		}
+	}
+
+	for _, claim := range claims {
+		if ownerName, _ := drautils.ClaimOwningPod(claim); ownerName != "" {
+			continue
+		}
+		if !claimPinnedToNode(claim, nodeInfo.Node(), otherNodes) {
+			continue
+		}
+		if !claimReservedOutsideNode(claim, nodeInfo) {
+			continue
+		}
+		return drainability.NewBlockedStatus(drain.ResourceClaimNotReallocatable, fmt.Errorf("shared ResourceClaim %s/%s of %s/%s is allocated on node %s and in use outside of it, it can't be re-allocated on any other node", claim.Namespace, claim.Name, pod.Namespace, pod.Name, nodeInfo.Node().Name))
+	}
+	return drainability.NewUndefinedStatus()
+}
+
+// neededClaims returns the ResourceClaims tracked for the pod in the NodeInfo.
+func neededClaims(pod *apiv1.Pod, nodeInfo *framework.NodeInfo) []*resourceapi.ResourceClaim {
+	for _, podInfo := range nodeInfo.Pods() {
+		if podInfo.Namespace == pod.Namespace && podInfo.Name == pod.Name {
+			return podInfo.NeededResourceClaims
+		}
+	}
+	return nil
+}
+
+// claimPinnedToNode checks whether the claim is allocated to devices available on the node, but not on any of the other nodes.
+func claimPinnedToNode(claim *resourceapi.ResourceClaim, node *apiv1.Node, otherNodes []*apiv1.Node) bool {
+	if !drautils.ClaimAllocated(claim) || claim.Status.Allocation.NodeSelector == nil {
+		return false
+	}
+	if available, err := drautils.ClaimAvailableOnNode(claim, node); err != nil || !available {
+		return false
+	}
+	for _, otherNode := range otherNodes {
+		if available, err := drautils.ClaimAvailableOnNode(claim, otherNode); err == nil && available {
+			return false
+		}
+	}
+	return true
+}
+
+// claimReservedOutsideNode checks whether the claim is reserved for any consumer other than the pods running on the node.
+func claimReservedOutsideNode(claim *resourceapi.ResourceClaim, nodeInfo *framework.NodeInfo) bool {
+	for _, consumerRef := range claim.Status.ReservedFor {
+		local := false
+		if consumerRef.APIGroup == "" && consumerRef.Resource == "pods" {
+			for _, podInfo := range nodeInfo.Pods() {
+				if podInfo.Namespace == claim.Namespace && podInfo.Name == consumerRef.Name && podInfo.UID == consumerRef.UID {
+					local = true
+					break
+				}
+			}
+		}
+		if !local {
+			return true
+		}
+	}
+	return false
+}
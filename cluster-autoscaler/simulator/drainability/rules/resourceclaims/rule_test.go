@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceclaims
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	resourceapi "k8s.io/api/resource/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability"
+	drautils "k8s.io/autoscaler/cluster-autoscaler/simulator/dynamicresources/utils"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+func TestDrainable(t *testing.T) {
+	node := BuildTestNode("node", 1000, 1000)
+	otherNode := BuildTestNode("other-node", 1000, 1000)
+	deletedNode := BuildTestNode("deleted-node", 1000, 1000)
+	for _, n := range []*apiv1.Node{node, otherNode, deletedNode} {
+		n.Labels[apiv1.LabelHostname] = n.Name
+	}
+	deletedNode.Spec.Taints = []apiv1.Taint{{Key: taints.ToBeDeletedTaint, Effect: apiv1.TaintEffectNoSchedule}}
+
+	pod := BuildTestPod("pod", 100, 100, WithNodeName(node.Name), WithResourceClaim("ref", "shared-claim", ""))
+	localPod := BuildTestPod("local-pod", 100, 100, WithNodeName(node.Name), WithResourceClaim("ref", "shared-claim", ""))
+	remotePod := BuildTestPod("remote-pod", 100, 100, WithNodeName(otherNode.Name), WithResourceClaim("ref", "shared-claim", ""))
+
+	sharedClaim := &resourceapi.ResourceClaim{ObjectMeta: metav1.ObjectMeta{Name: "shared-claim", UID: "shared-claim", Namespace: "default"}}
+	nodeLocalAllocation := allocationForNodes(node.Name)
+
+	for desc, tc := range map[string]struct {
+		claims      []*resourceapi.ResourceClaim
+		otherPods   []*apiv1.Pod
+		nodes       []*apiv1.Node
+		wantOutcome drainability.OutcomeType
+		wantReason  drain.BlockingPodReason
+	}{
+		"pod without claims": {
+			wantOutcome: drainability.UndefinedOutcome,
+		},
+		"unallocated shared claim": {
+			claims:      []*resourceapi.ResourceClaim{drautils.TestClaimWithPodReservations(sharedClaim, remotePod)},
+			nodes:       []*apiv1.Node{node, otherNode},
+			wantOutcome: drainability.UndefinedOutcome,
+		},
+		"shared claim allocated to global devices": {
+			claims:      []*resourceapi.ResourceClaim{drautils.TestClaimWithPodReservations(drautils.TestClaimWithAllocation(sharedClaim, nil), pod, remotePod)},
+			nodes:       []*apiv1.Node{node, otherNode},
+			wantOutcome: drainability.UndefinedOutcome,
+		},
+		"pod-owned node-local claim": {
+			claims:      []*resourceapi.ResourceClaim{drautils.TestClaimWithPodOwnership(pod, drautils.TestClaimWithPodReservations(drautils.TestClaimWithAllocation(sharedClaim, nodeLocalAllocation), pod, remotePod))},
+			nodes:       []*apiv1.Node{node, otherNode},
+			wantOutcome: drainability.UndefinedOutcome,
+		},
+		"node-local shared claim used only by pods on the node": {
+			claims:      []*resourceapi.ResourceClaim{drautils.TestClaimWithPodReservations(drautils.TestClaimWithAllocation(sharedClaim, nodeLocalAllocation), pod, localPod)},
+			otherPods:   []*apiv1.Pod{localPod},
+			nodes:       []*apiv1.Node{node, otherNode},
+			wantOutcome: drainability.UndefinedOutcome,
+		},
+		"node-local shared claim used by a pod outside of the node": {
+			claims:      []*resourceapi.ResourceClaim{drautils.TestClaimWithPodReservations(drautils.TestClaimWithAllocation(sharedClaim, nodeLocalAllocation), pod, remotePod)},
+			nodes:       []*apiv1.Node{node, otherNode},
+			wantOutcome: drainability.BlockDrain,
+			wantReason:  drain.ResourceClaimNotReallocatable,
+		},
+		"node-local shared claim used outside of the node, no listers": {
+			claims:      []*resourceapi.ResourceClaim{drautils.TestClaimWithPodReservations(drautils.TestClaimWithAllocation(sharedClaim, nodeLocalAllocation), pod, remotePod)},
+			wantOutcome: drainability.BlockDrain,
+			wantReason:  drain.ResourceClaimNotReallocatable,
+		},
+		"shared claim used outside of the node, available on another node": {
+			claims:      []*resourceapi.ResourceClaim{drautils.TestClaimWithPodReservations(drautils.TestClaimWithAllocation(sharedClaim, allocationForNodes(node.Name, otherNode.Name)), pod, remotePod)},
+			nodes:       []*apiv1.Node{node, otherNode},
+			wantOutcome: drainability.UndefinedOutcome,
+		},
+		"shared claim used outside of the node, available only on a node being deleted": {
+			claims:      []*resourceapi.ResourceClaim{drautils.TestClaimWithPodReservations(drautils.TestClaimWithAllocation(sharedClaim, allocationForNodes(node.Name, deletedNode.Name)), pod, remotePod)},
+			nodes:       []*apiv1.Node{node, otherNode, deletedNode},
+			wantOutcome: drainability.BlockDrain,
+			wantReason:  drain.ResourceClaimNotReallocatable,
+		},
+		"shared claim allocated to another node": {
+			claims:      []*resourceapi.ResourceClaim{drautils.TestClaimWithPodReservations(drautils.TestClaimWithAllocation(sharedClaim, allocationForNodes(otherNode.Name)), pod, remotePod)},
+			nodes:       []*apiv1.Node{node, otherNode},
+			wantOutcome: drainability.UndefinedOutcome,
+		},
+	} {
+		t.Run(desc, func(t *testing.T) {
+			testPod := pod
+			if len(tc.claims) == 0 {
+				testPod = BuildTestPod("pod", 100, 100, WithNodeName(node.Name))
+			}
+			nodeInfo := framework.NewNodeInfo(node, nil, framework.NewPodInfo(testPod, tc.claims))
+			for _, otherPod := range tc.otherPods {
+				nodeInfo.AddPod(framework.NewPodInfo(otherPod, tc.claims))
+			}
+			drainCtx := &drainability.DrainContext{}
+			if tc.nodes != nil {
+				nodeLister := kube_util.NewTestNodeLister(tc.nodes)
+				drainCtx.Listers = kube_util.NewListerRegistry(nodeLister, nodeLister, nil, nil, nil, nil, nil, nil, nil)
+			}
+
+			status := New().Drainable(drainCtx, testPod, nodeInfo)
+			assert.Equal(t, tc.wantOutcome, status.Outcome)
+			assert.Equal(t, tc.wantReason, status.BlockingReason)
+			assert.Equal(t, tc.wantOutcome == drainability.BlockDrain, status.Error != nil)
+		})
+	}
+}
+
+func allocationForNodes(nodeNames ...string) *resourceapi.AllocationResult {
+	return &resourceapi.AllocationResult{
+		Devices: resourceapi.DeviceAllocationResult{
+			Results: []resourceapi.DeviceRequestAllocationResult{
+				{Request: "req1", Driver: "driver.example.com", Pool: nodeNames[0], Device: "device1"},
+			},
+		},
+		NodeSelector: &apiv1.NodeSelector{
+			NodeSelectorTerms: []apiv1.NodeSelectorTerm{{
+				MatchExpressions: []apiv1.NodeSelectorRequirement{
+					{Key: apiv1.LabelHostname, Operator: apiv1.NodeSelectorOpIn, Values: nodeNames},
+				},
+			}},
+		},
+	}
+}
@@ -20,6 +20,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/autoscaler/cluster-autoscaler/core/scaledown/pdb"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules/capacitybuffer"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules/daemonset"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules/localstorage"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules/longterminating"
@@ -28,6 +29,7 @@ import (
 	pdbrule "k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules/pdb"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules/replicacount"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules/replicated"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules/resourceclaims"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules/safetoevict"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules/system"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability/rules/terminal"
@@ -55,6 +57,7 @@ func Default(deleteOptions options.NodeDeleteOptions) Rules {
 		skip bool
 	}{
 		{rule: mirror.New()},
+		{rule: capacitybuffer.New()},
 		{rule: longterminating.New()},
 		{rule: replicacount.New(deleteOptions.MinReplicaCount), skip: !deleteOptions.SkipNodesWithCustomControllerPods},
 
@@ -67,7 +70,8 @@ func Default(deleteOptions options.NodeDeleteOptions) Rules {
 		{rule: replicated.New(deleteOptions.SkipNodesWithCustomControllerPods)},
 		{rule: system.New(deleteOptions.BspDisruptionTimeout), skip: !deleteOptions.SkipNodesWithSystemPods},
 		{rule: notsafetoevict.New()},
-		{rule: localstorage.New(), skip: !deleteOptions.SkipNodesWithLocalStorage},
+		{rule: localstorage.New(deleteOptions.SkipNodesWithLocalStorage)},
+		{rule: resourceclaims.New()},
 		{rule: pdbrule.New()},
 	} {
 		if !r.skip {
@@ -36,10 +36,17 @@ func (r *Rule) Name() string {
 	return "SafeToEvict"
 }
 
-// Drainable decides what to do with safe to evict pods on node drain.
+// Drainable decides what to do with safe to evict pods on node drain. Pods annotated as safe to evict
+// after running for some time are safe to evict once the time passes.
 func (r *Rule) Drainable(drainCtx *drainability.DrainContext, pod *apiv1.Pod, _ *framework.NodeInfo) drainability.Status {
 	if drain.HasSafeToEvictAnnotation(pod) {
 		return drainability.NewDrainableStatus()
 	}
+	if drain.HasNotSafeToEvictAnnotation(pod) {
+		return drainability.NewUndefinedStatus()
+	}
+	if safeToEvictTime, found := drain.SafeToEvictTime(pod); found && !drainCtx.Timestamp.Before(safeToEvictTime) {
+		return drainability.NewDrainableStatus()
+	}
 	return drainability.NewUndefinedStatus()
 }
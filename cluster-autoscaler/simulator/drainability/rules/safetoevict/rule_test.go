@@ -18,6 +18,7 @@ package safetoevict
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	apiv1 "k8s.io/api/core/v1"
@@ -27,6 +28,7 @@ import (
 )
 
 func TestDrainable(t *testing.T) {
+	testTime := time.Date(2020, time.December, 18, 17, 0, 0, 0, time.UTC)
 	for desc, tc := range map[string]struct {
 		pod  *apiv1.Pod
 		want drainability.Status
@@ -52,9 +54,71 @@ func TestDrainable(t *testing.T) {
 			},
 			want: drainability.NewDrainableStatus(),
 		},
+		"safe to evict for pod which ran long enough": {
+			pod: &apiv1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "bar",
+					Namespace: "default",
+					Annotations: map[string]string{
+						drain.PodSafeToEvictForKey: "1h",
+					},
+				},
+				Status: apiv1.PodStatus{
+					StartTime: &metav1.Time{Time: testTime.Add(-time.Hour)},
+				},
+			},
+			want: drainability.NewDrainableStatus(),
+		},
+		"safe to evict for pod which didn't run long enough": {
+			pod: &apiv1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "bar",
+					Namespace: "default",
+					Annotations: map[string]string{
+						drain.PodSafeToEvictForKey: "1h",
+					},
+				},
+				Status: apiv1.PodStatus{
+					StartTime: &metav1.Time{Time: testTime.Add(-time.Minute)},
+				},
+			},
+			want: drainability.NewUndefinedStatus(),
+		},
+		"safe to evict for pod annotated as not safe to evict": {
+			pod: &apiv1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "bar",
+					Namespace: "default",
+					Annotations: map[string]string{
+						drain.PodSafeToEvictKey:    "false",
+						drain.PodSafeToEvictForKey: "1h",
+					},
+				},
+				Status: apiv1.PodStatus{
+					StartTime: &metav1.Time{Time: testTime.Add(-2 * time.Hour)},
+				},
+			},
+			want: drainability.NewUndefinedStatus(),
+		},
+		"safe to evict for pod with invalid annotation": {
+			pod: &apiv1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "bar",
+					Namespace:         "default",
+					CreationTimestamp: metav1.Time{Time: testTime.Add(-2 * time.Hour)},
+					Annotations: map[string]string{
+						drain.PodSafeToEvictForKey: "forever",
+					},
+				},
+			},
+			want: drainability.NewUndefinedStatus(),
+		},
 	} {
 		t.Run(desc, func(t *testing.T) {
-			got := New().Drainable(nil, tc.pod, nil)
+			drainCtx := &drainability.DrainContext{
+				Timestamp: testTime,
+			}
+			got := New().Drainable(drainCtx, tc.pod, nil)
 			if diff := cmp.Diff(tc.want, got); diff != "" {
 				t.Errorf("Rule.Drainable(%v): got status diff (-want +got):\n%s", tc.pod.Name, diff)
 			}
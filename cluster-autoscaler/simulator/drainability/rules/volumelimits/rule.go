@@ -0,0 +1,186 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumelimits
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
+	"k8s.io/client-go/informers"
+	v1lister "k8s.io/client-go/listers/core/v1"
+	storagelister "k8s.io/client-go/listers/storage/v1"
+	"k8s.io/component-helpers/storage/ephemeral"
+)
+
+// Rule is a drainability rule on how to handle pods with CSI persistent volumes,
+// which can only be moved to nodes with free volume attachment slots.
+type Rule struct {
+	nodeLister             kube_util.NodeLister
+	csiNodeLister          storagelister.CSINodeLister
+	volumeAttachmentLister storagelister.VolumeAttachmentLister
+	pvcLister              v1lister.PersistentVolumeClaimLister
+	pvLister               v1lister.PersistentVolumeLister
+
+	sync.Mutex
+	// slots caches free attachment slots of nodes, so that they are computed once per drain simulation.
+	slots *cachedSlots
+}
+
+type cachedSlots struct {
+	timestamp time.Time
+	// freePerNode holds free attachment slots per CSI driver installed on each node which pods can be moved to.
+	// Drivers without an attachment limit have math.MaxInt free slots.
+	freePerNode map[string]map[string]int
+	err         error
+}
+
+// New creates a new Rule.
+func New(informerFactory informers.SharedInformerFactory) *Rule {
+	return &Rule{
+		nodeLister:             kube_util.NewReadyNodeLister(informerFactory.Core().V1().Nodes().Lister()),
+		csiNodeLister:          informerFactory.Storage().V1().CSINodes().Lister(),
+		volumeAttachmentLister: informerFactory.Storage().V1().VolumeAttachments().Lister(),
+		pvcLister:              informerFactory.Core().V1().PersistentVolumeClaims().Lister(),
+		pvLister:               informerFactory.Core().V1().PersistentVolumes().Lister(),
+	}
+}
+
+// Name returns the name of the rule.
+func (r *Rule) Name() string {
+	return "VolumeLimits"
+}
+
+// Drainable blocks draining a node if volumes of a pod couldn't be attached to any other
+// ready node, because all of them reached the attachment limit of the volumes' CSI driver.
+func (r *Rule) Drainable(drainCtx *drainability.DrainContext, pod *apiv1.Pod, nodeInfo *framework.NodeInfo) drainability.Status {
+	volumesPerDriver := r.csiVolumesPerDriver(pod)
+	if len(volumesPerDriver) == 0 {
+		return drainability.NewUndefinedStatus()
+	}
+
+	freePerNode, err := r.freeSlots(drainCtx.Timestamp)
+	if err != nil {
+		return drainability.NewBlockedStatus(drain.UnexpectedError, fmt.Errorf("error when trying to compute free volume attachment slots for %s/%s, err: %v", pod.Namespace, pod.Name, err))
+	}
+	for nodeName, freePerDriver := range freePerNode {
+		if nodeInfo != nil && nodeName == nodeInfo.Node().Name {
+			continue
+		}
+		if canAttach(volumesPerDriver, freePerDriver) {
+			return drainability.NewUndefinedStatus()
+		}
+	}
+	return drainability.NewBlockedStatus(drain.VolumeAttachLimitReached, fmt.Errorf("volumes of %s/%s can't be attached to any other node due to CSI volume attach limits", pod.Namespace, pod.Name))
+}
+
+// freeSlots returns free attachment slots per CSI driver of ready nodes which aren't being
+// deleted, computing them only once for all pods checked at the given timestamp.
+func (r *Rule) freeSlots(timestamp time.Time) (map[string]map[string]int, error) {
+	r.Lock()
+	defer r.Unlock()
+	if r.slots != nil && r.slots.timestamp.Equal(timestamp) {
+		return r.slots.freePerNode, r.slots.err
+	}
+	freePerNode, err := r.computeFreeSlots()
+	r.slots = &cachedSlots{timestamp: timestamp, freePerNode: freePerNode, err: err}
+	return freePerNode, err
+}
+
+func (r *Rule) computeFreeSlots() (map[string]map[string]int, error) {
+	nodes, err := r.nodeLister.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %v", err)
+	}
+	attachments, err := r.volumeAttachmentLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list volume attachments: %v", err)
+	}
+	attachedPerNodeAndDriver := make(map[string]map[string]int)
+	for _, attachment := range attachments {
+		if attachedPerNodeAndDriver[attachment.Spec.NodeName] == nil {
+			attachedPerNodeAndDriver[attachment.Spec.NodeName] = make(map[string]int)
+		}
+		attachedPerNodeAndDriver[attachment.Spec.NodeName][attachment.Spec.Attacher]++
+	}
+
+	freePerNode := make(map[string]map[string]int, len(nodes))
+	for _, node := range nodes {
+		if taints.HasToBeDeletedTaint(node) {
+			continue
+		}
+		csiNode, err := r.csiNodeLister.Get(node.Name)
+		if err != nil {
+			continue
+		}
+		freePerDriver := make(map[string]int, len(csiNode.Spec.Drivers))
+		for _, driver := range csiNode.Spec.Drivers {
+			if driver.Allocatable == nil || driver.Allocatable.Count == nil {
+				freePerDriver[driver.Name] = math.MaxInt
+				continue
+			}
+			freePerDriver[driver.Name] = int(*driver.Allocatable.Count) - attachedPerNodeAndDriver[node.Name][driver.Name]
+		}
+		freePerNode[node.Name] = freePerDriver
+	}
+	return freePerNode, nil
+}
+
+// csiVolumesPerDriver returns the number of bound CSI persistent volumes used by the pod, per CSI driver.
+func (r *Rule) csiVolumesPerDriver(pod *apiv1.Pod) map[string]int {
+	volumesPerDriver := make(map[string]int)
+	for _, volume := range pod.Spec.Volumes {
+		var claimName string
+		switch {
+		case volume.PersistentVolumeClaim != nil:
+			claimName = volume.PersistentVolumeClaim.ClaimName
+		case volume.Ephemeral != nil:
+			claimName = ephemeral.VolumeClaimName(pod, &volume)
+		default:
+			continue
+		}
+		pvc, err := r.pvcLister.PersistentVolumeClaims(pod.Namespace).Get(claimName)
+		if err != nil || pvc.Spec.VolumeName == "" {
+			continue
+		}
+		pv, err := r.pvLister.Get(pvc.Spec.VolumeName)
+		if err != nil || pv.Spec.CSI == nil {
+			continue
+		}
+		volumesPerDriver[pv.Spec.CSI.Driver]++
+	}
+	return volumesPerDriver
+}
+
+// canAttach checks whether there are enough free attachment slots for all the volumes.
+func canAttach(volumesPerDriver map[string]int, freePerDriver map[string]int) bool {
+	for driverName, volumes := range volumesPerDriver {
+		free, found := freePerDriver[driverName]
+		if !found || free < volumes {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumelimits
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/taints"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const driverName = "ebs.csi.aws.com"
+
+func TestDrainable(t *testing.T) {
+	for desc, tc := range map[string]struct {
+		volumes     int
+		otherDriver bool
+		limit       *int32
+		attached    int
+		noCSINode   bool
+		toBeDeleted bool
+		wantBlocked bool
+	}{
+		"pod without volumes": {},
+		"free attachment slots": {
+			volumes:  2,
+			limit:    int32Ptr(4),
+			attached: 2,
+		},
+		"no attachment limit": {
+			volumes:  2,
+			attached: 20,
+		},
+		"attachment limit reached": {
+			volumes:     2,
+			limit:       int32Ptr(4),
+			attached:    3,
+			wantBlocked: true,
+		},
+		"driver not installed": {
+			volumes:     1,
+			otherDriver: true,
+			wantBlocked: true,
+		},
+		"no CSINode": {
+			volumes:     1,
+			noCSINode:   true,
+			wantBlocked: true,
+		},
+		"other node is being deleted": {
+			volumes:     1,
+			limit:       int32Ptr(4),
+			toBeDeleted: true,
+			wantBlocked: true,
+		},
+	} {
+		t.Run(desc, func(t *testing.T) {
+			drainedNode := BuildTestNode("drained", 1000, 1000)
+			otherNode := BuildTestNode("other", 1000, 1000)
+			SetNodeReadyState(drainedNode, true, metav1.Now().Time)
+			SetNodeReadyState(otherNode, true, metav1.Now().Time)
+			if tc.toBeDeleted {
+				otherNode.Spec.Taints = []apiv1.Taint{{Key: taints.ToBeDeletedTaint, Effect: apiv1.TaintEffectNoSchedule}}
+			}
+			objects := []runtime.Object{drainedNode, otherNode}
+
+			if !tc.noCSINode {
+				driver := driverName
+				if tc.otherDriver {
+					driver = "pd.csi.storage.gke.io"
+				}
+				objects = append(objects, &storagev1.CSINode{
+					ObjectMeta: metav1.ObjectMeta{Name: otherNode.Name},
+					Spec: storagev1.CSINodeSpec{
+						Drivers: []storagev1.CSINodeDriver{{Name: driver, Allocatable: &storagev1.VolumeNodeResources{Count: tc.limit}}},
+					},
+				})
+			}
+			for i := 0; i < tc.attached; i++ {
+				objects = append(objects, &storagev1.VolumeAttachment{
+					ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("attachment-%d", i)},
+					Spec:       storagev1.VolumeAttachmentSpec{Attacher: driverName, NodeName: otherNode.Name},
+				})
+			}
+
+			pod := BuildTestPod("pod", 100, 0)
+			pod.Spec.NodeName = drainedNode.Name
+			for i := 0; i < tc.volumes; i++ {
+				name := fmt.Sprintf("volume-%d", i)
+				pod.Spec.Volumes = append(pod.Spec.Volumes, apiv1.Volume{
+					Name:         name,
+					VolumeSource: apiv1.VolumeSource{PersistentVolumeClaim: &apiv1.PersistentVolumeClaimVolumeSource{ClaimName: name}},
+				})
+				objects = append(objects,
+					&apiv1.PersistentVolumeClaim{
+						ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: pod.Namespace},
+						Spec:       apiv1.PersistentVolumeClaimSpec{VolumeName: name},
+					},
+					&apiv1.PersistentVolume{
+						ObjectMeta: metav1.ObjectMeta{Name: name},
+						Spec: apiv1.PersistentVolumeSpec{
+							PersistentVolumeSource: apiv1.PersistentVolumeSource{CSI: &apiv1.CSIPersistentVolumeSource{Driver: driverName, VolumeHandle: name}},
+						},
+					})
+			}
+
+			informerFactory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(objects...), 0)
+			rule := New(informerFactory)
+			stop := make(chan struct{})
+			defer close(stop)
+			informerFactory.Start(stop)
+			informerFactory.WaitForCacheSync(stop)
+
+			status := rule.Drainable(&drainability.DrainContext{}, pod, framework.NewTestNodeInfo(drainedNode, pod))
+			if tc.wantBlocked {
+				assert.Equal(t, drainability.BlockDrain, status.Outcome)
+				assert.Equal(t, drain.VolumeAttachLimitReached, status.BlockingReason)
+				assert.Error(t, status.Error)
+			} else {
+				assert.Equal(t, drainability.NewUndefinedStatus(), status)
+			}
+		})
+	}
+}
+
+func TestDrainableComputesFreeSlotsOncePerTimestamp(t *testing.T) {
+	drainedNode := BuildTestNode("drained", 1000, 1000)
+	otherNode := BuildTestNode("other", 1000, 1000)
+	SetNodeReadyState(drainedNode, true, metav1.Now().Time)
+	SetNodeReadyState(otherNode, true, metav1.Now().Time)
+	pod := BuildTestPod("pod", 100, 0)
+	pod.Spec.NodeName = drainedNode.Name
+	pod.Spec.Volumes = []apiv1.Volume{{
+		Name:         "volume",
+		VolumeSource: apiv1.VolumeSource{PersistentVolumeClaim: &apiv1.PersistentVolumeClaimVolumeSource{ClaimName: "volume"}},
+	}}
+	objects := []runtime.Object{
+		drainedNode,
+		otherNode,
+		&storagev1.CSINode{
+			ObjectMeta: metav1.ObjectMeta{Name: otherNode.Name},
+			Spec: storagev1.CSINodeSpec{
+				Drivers: []storagev1.CSINodeDriver{{Name: driverName, Allocatable: &storagev1.VolumeNodeResources{Count: int32Ptr(1)}}},
+			},
+		},
+		&apiv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "volume", Namespace: pod.Namespace},
+			Spec:       apiv1.PersistentVolumeClaimSpec{VolumeName: "volume"},
+		},
+		&apiv1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: "volume"},
+			Spec: apiv1.PersistentVolumeSpec{
+				PersistentVolumeSource: apiv1.PersistentVolumeSource{CSI: &apiv1.CSIPersistentVolumeSource{Driver: driverName, VolumeHandle: "volume"}},
+			},
+		},
+	}
+
+	informerFactory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(objects...), 0)
+	rule := New(informerFactory)
+	stop := make(chan struct{})
+	defer close(stop)
+	informerFactory.Start(stop)
+	informerFactory.WaitForCacheSync(stop)
+
+	now := time.Now()
+	nodeInfo := framework.NewTestNodeInfo(drainedNode, pod)
+	assert.Equal(t, drainability.NewUndefinedStatus(), rule.Drainable(&drainability.DrainContext{Timestamp: now}, pod, nodeInfo))
+
+	err := informerFactory.Storage().V1().VolumeAttachments().Informer().GetStore().Add(&storagev1.VolumeAttachment{
+		ObjectMeta: metav1.ObjectMeta{Name: "attachment"},
+		Spec:       storagev1.VolumeAttachmentSpec{Attacher: driverName, NodeName: otherNode.Name},
+	})
+	assert.NoError(t, err)
+
+	// The attachment isn't taken into account until the next drain simulation.
+	assert.Equal(t, drainability.NewUndefinedStatus(), rule.Drainable(&drainability.DrainContext{Timestamp: now}, pod, nodeInfo))
+	status := rule.Drainable(&drainability.DrainContext{Timestamp: now.Add(time.Second)}, pod, nodeInfo)
+	assert.Equal(t, drainability.BlockDrain, status.Outcome)
+	assert.Equal(t, drain.VolumeAttachLimitReached, status.BlockingReason)
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
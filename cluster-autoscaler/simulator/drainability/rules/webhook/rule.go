@@ -0,0 +1,235 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
+	"k8s.io/klog/v2"
+)
+
+// Decision is the decision of the webhook on whether a node can be drained.
+type Decision string
+
+const (
+	// Allow means the webhook allows draining the node.
+	Allow Decision = "Allow"
+	// Deny means the webhook doesn't allow draining the node.
+	Deny Decision = "Deny"
+	// Block means the webhook doesn't allow draining the node, for the reason given in the response.
+	Block Decision = "Block"
+)
+
+// FailurePolicy defines how errors when calling the webhook are handled.
+type FailurePolicy string
+
+const (
+	// Ignore means that webhook errors are ignored and the drainability is decided by other rules.
+	Ignore FailurePolicy = "Ignore"
+	// Fail means that webhook errors block draining the node.
+	Fail FailurePolicy = "Fail"
+)
+
+// Request is the body sent to the webhook.
+type Request struct {
+	Node *apiv1.Node  `json:"node"`
+	Pods []*apiv1.Pod `json:"pods"`
+}
+
+// Response is the body expected from the webhook.
+type Response struct {
+	Decision Decision `json:"decision"`
+	// Reason explains why draining the node is blocked. Required for the Block decision.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Rule is a drainability rule letting an external webhook veto draining a node. It's meant to run
+// before the built-in rules: Deny and Block decisions block draining the node, while the Allow
+// decision leaves the decision to the other rules.
+type Rule struct {
+	url           string
+	client        *http.Client
+	failurePolicy FailurePolicy
+	calls         singleflight.Group
+
+	sync.Mutex
+	// timestamp is the timestamp of the latest drain simulation.
+	timestamp time.Time
+	// responses caches webhook responses per node for the latest drain simulation, so that
+	// the webhook is called once per node and drain simulation.
+	responses map[string]cachedResponse
+}
+
+type cachedResponse struct {
+	response *Response
+	err      error
+}
+
+// New creates a new Rule calling the webhook at the given HTTPS URL. If caCertPath is not empty,
+// the webhook server certificate is verified using the CA certificate in that file.
+func New(webhookURL, caCertPath string, timeout time.Duration, failurePolicy FailurePolicy) (*Rule, error) {
+	if failurePolicy != Ignore && failurePolicy != Fail {
+		return nil, fmt.Errorf("unknown drainability webhook failure policy %q", failurePolicy)
+	}
+	parsedURL, err := url.Parse(webhookURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse drainability webhook URL: %v", err)
+	}
+	if parsedURL.Scheme != "https" || parsedURL.Host == "" {
+		return nil, fmt.Errorf("drainability webhook URL %q isn't an HTTPS URL", webhookURL)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if caCertPath != "" {
+		caCert, err := os.ReadFile(caCertPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read drainability webhook CA certificate: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("failed to parse drainability webhook CA certificate %s", caCertPath)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	return &Rule{
+		url:           webhookURL,
+		client:        &http.Client{Transport: transport, Timeout: timeout},
+		failurePolicy: failurePolicy,
+		responses:     make(map[string]cachedResponse),
+	}, nil
+}
+
+// Name returns the name of the rule.
+func (r *Rule) Name() string {
+	return "Webhook"
+}
+
+// Drainable decides what to do with pods on node drain, based on the webhook decision for the whole node.
+func (r *Rule) Drainable(drainCtx *drainability.DrainContext, pod *apiv1.Pod, nodeInfo *framework.NodeInfo) drainability.Status {
+	if nodeInfo == nil {
+		return drainability.NewUndefinedStatus()
+	}
+	response, err := r.response(drainCtx.Timestamp, nodeInfo)
+	if err != nil {
+		klog.Errorf("Drainability webhook failed for node %s: %v", nodeInfo.Node().Name, err)
+		if r.failurePolicy == Ignore {
+			return drainability.NewUndefinedStatus()
+		}
+		return drainability.NewBlockedStatus(drain.UnexpectedError, fmt.Errorf("drainability webhook failed for node %s: %v", nodeInfo.Node().Name, err))
+	}
+	switch response.Decision {
+	case Allow:
+		return drainability.NewUndefinedStatus()
+	case Deny:
+		return drainability.NewBlockedStatus(drain.BlockedByWebhook, fmt.Errorf("drain of node %s denied by drainability webhook", nodeInfo.Node().Name))
+	default:
+		return drainability.NewBlockedStatus(drain.BlockedByWebhook, fmt.Errorf("drain of node %s blocked by drainability webhook: %s", nodeInfo.Node().Name, response.Reason))
+	}
+}
+
+func (r *Rule) response(timestamp time.Time, nodeInfo *framework.NodeInfo) (*Response, error) {
+	nodeName := nodeInfo.Node().Name
+	if cached, found := r.cachedResponse(timestamp, nodeName); found {
+		return cached.response, cached.err
+	}
+	// Concurrent checks of the same node share a single call, which is made without holding the lock.
+	key := fmt.Sprintf("%s/%d", nodeName, timestamp.UnixNano())
+	result, _, _ := r.calls.Do(key, func() (interface{}, error) {
+		response, err := r.call(nodeInfo)
+		cached := cachedResponse{response: response, err: err}
+		r.cacheResponse(timestamp, nodeName, cached)
+		return cached, nil
+	})
+	cached := result.(cachedResponse)
+	return cached.response, cached.err
+}
+
+func (r *Rule) cachedResponse(timestamp time.Time, nodeName string) (cachedResponse, bool) {
+	r.Lock()
+	defer r.Unlock()
+	if !r.timestamp.Equal(timestamp) {
+		return cachedResponse{}, false
+	}
+	cached, found := r.responses[nodeName]
+	return cached, found
+}
+
+// cacheResponse caches the response of a drain simulation. Responses of previous drain simulations
+// are dropped, so that the cache only holds nodes checked in the latest one.
+func (r *Rule) cacheResponse(timestamp time.Time, nodeName string, cached cachedResponse) {
+	r.Lock()
+	defer r.Unlock()
+	if timestamp.Before(r.timestamp) {
+		return
+	}
+	if !timestamp.Equal(r.timestamp) {
+		r.timestamp = timestamp
+		r.responses = make(map[string]cachedResponse)
+	}
+	r.responses[nodeName] = cached
+}
+
+func (r *Rule) call(nodeInfo *framework.NodeInfo) (*Response, error) {
+	request := Request{Node: nodeInfo.Node()}
+	for _, podInfo := range nodeInfo.Pods() {
+		request.Pods = append(request.Pods, podInfo.Pod)
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %v", err)
+	}
+	httpResponse, err := r.client.Post(r.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", httpResponse.StatusCode)
+	}
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %v", err)
+	}
+	response := &Response{}
+	if err := json.Unmarshal(responseBody, response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %v", err)
+	}
+	switch response.Decision {
+	case Allow, Deny:
+	case Block:
+		if response.Reason == "" {
+			return nil, fmt.Errorf("no reason given for %s decision", Block)
+		}
+	default:
+		return nil, fmt.Errorf("unknown decision %q", response.Decision)
+	}
+	return response, nil
+}
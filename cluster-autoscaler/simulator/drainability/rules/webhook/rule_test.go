@@ -0,0 +1,171 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/drainability"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrainable(t *testing.T) {
+	testTime := time.Date(2020, time.December, 18, 17, 0, 0, 0, time.UTC)
+
+	for desc, tc := range map[string]struct {
+		status        int
+		response      string
+		failurePolicy FailurePolicy
+		wantOutcome   drainability.OutcomeType
+		wantReason    drain.BlockingPodReason
+	}{
+		"allow": {
+			status:      http.StatusOK,
+			response:    `{"decision": "Allow"}`,
+			wantOutcome: drainability.UndefinedOutcome,
+		},
+		"deny": {
+			status:      http.StatusOK,
+			response:    `{"decision": "Deny"}`,
+			wantOutcome: drainability.BlockDrain,
+			wantReason:  drain.BlockedByWebhook,
+		},
+		"block with reason": {
+			status:      http.StatusOK,
+			response:    `{"decision": "Block", "reason": "maintenance window"}`,
+			wantOutcome: drainability.BlockDrain,
+			wantReason:  drain.BlockedByWebhook,
+		},
+		"block without reason": {
+			status:      http.StatusOK,
+			response:    `{"decision": "Block"}`,
+			wantOutcome: drainability.BlockDrain,
+			wantReason:  drain.UnexpectedError,
+		},
+		"unknown decision": {
+			status:      http.StatusOK,
+			response:    `{"decision": "Maybe"}`,
+			wantOutcome: drainability.BlockDrain,
+			wantReason:  drain.UnexpectedError,
+		},
+		"server error": {
+			status:      http.StatusInternalServerError,
+			wantOutcome: drainability.BlockDrain,
+			wantReason:  drain.UnexpectedError,
+		},
+		"server error ignored": {
+			status:        http.StatusInternalServerError,
+			failurePolicy: Ignore,
+			wantOutcome:   drainability.UndefinedOutcome,
+		},
+	} {
+		t.Run(desc, func(t *testing.T) {
+			node := BuildTestNode("node", 1000, 1000)
+			pod1 := BuildTestPod("pod1", 100, 0)
+			pod2 := BuildTestPod("pod2", 100, 0)
+			nodeInfo := framework.NewTestNodeInfo(node, pod1, pod2)
+
+			calls := 0
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				calls++
+				var request Request
+				assert.NoError(t, json.NewDecoder(req.Body).Decode(&request))
+				assert.Equal(t, node.Name, request.Node.Name)
+				assert.Len(t, request.Pods, 2)
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.response))
+			}))
+			defer server.Close()
+
+			caCertPath := filepath.Join(t.TempDir(), "ca.crt")
+			caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+			assert.NoError(t, os.WriteFile(caCertPath, caCert, 0600))
+
+			failurePolicy := tc.failurePolicy
+			if failurePolicy == "" {
+				failurePolicy = Fail
+			}
+			rule, err := New(server.URL, caCertPath, time.Second, failurePolicy)
+			assert.NoError(t, err)
+
+			drainCtx := &drainability.DrainContext{Timestamp: testTime}
+			for _, pod := range []*framework.PodInfo{nodeInfo.Pods()[0], nodeInfo.Pods()[1]} {
+				status := rule.Drainable(drainCtx, pod.Pod, nodeInfo)
+				assert.Equal(t, tc.wantOutcome, status.Outcome)
+				assert.Equal(t, tc.wantReason, status.BlockingReason)
+			}
+			assert.Equal(t, 1, calls)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	_, err := New("https://example.com", "", time.Second, "Sometimes")
+	assert.Error(t, err)
+
+	_, err = New("https://example.com", filepath.Join(t.TempDir(), "missing.crt"), time.Second, Fail)
+	assert.Error(t, err)
+
+	_, err = New("http://example.com", "", time.Second, Fail)
+	assert.Error(t, err)
+
+	_, err = New("example.com", "", time.Second, Fail)
+	assert.Error(t, err)
+
+	_, err = New("https://example.com", "", time.Second, Ignore)
+	assert.NoError(t, err)
+}
+
+func TestResponsesOfPreviousSimulationsArePruned(t *testing.T) {
+	calls := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"decision": "Deny"}`))
+	}))
+	defer server.Close()
+	caCertPath := filepath.Join(t.TempDir(), "ca.crt")
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	assert.NoError(t, os.WriteFile(caCertPath, caCert, 0600))
+	rule, err := New(server.URL, caCertPath, time.Second, Fail)
+	assert.NoError(t, err)
+
+	now := time.Now()
+	pod := BuildTestPod("pod", 100, 0)
+	n1 := framework.NewTestNodeInfo(BuildTestNode("n1", 1000, 1000), pod)
+	n2 := framework.NewTestNodeInfo(BuildTestNode("n2", 1000, 1000), pod)
+
+	rule.Drainable(&drainability.DrainContext{Timestamp: now}, pod, n1)
+	rule.Drainable(&drainability.DrainContext{Timestamp: now}, pod, n2)
+	rule.Drainable(&drainability.DrainContext{Timestamp: now}, pod, n1)
+	assert.Equal(t, 2, calls)
+	assert.Len(t, rule.responses, 2)
+
+	rule.Drainable(&drainability.DrainContext{Timestamp: now.Add(time.Second)}, pod, n1)
+	assert.Equal(t, 3, calls)
+	assert.Len(t, rule.responses, 1)
+}
@@ -214,7 +214,7 @@ func (s Snapshot) UnreservePodClaims(pod *apiv1.Pod) error {
 	}
 	for _, claim := range claims {
 		ownerPodName, ownerPodUid := drautils.ClaimOwningPod(claim)
-		podOwnedClaim := ownerPodName == pod.Name && ownerPodUid == ownerPodUid
+		podOwnedClaim := ownerPodName == pod.Name && ownerPodUid == pod.UID
 
 		drautils.ClearPodReservationInPlace(claim, pod)
 		if podOwnedClaim || !drautils.ClaimInUse(claim) {
@@ -224,6 +224,48 @@ func (s Snapshot) UnreservePodClaims(pod *apiv1.Pod) error {
 	return nil
 }
 
+// ClaimsState is a saved state of a subset of the ResourceClaims tracked in a Snapshot. A nil claim means that
+// the claim wasn't tracked in the Snapshot when the state was saved.
+type ClaimsState map[ResourceClaimId]*resourceapi.ResourceClaim
+
+// SaveClaimsState saves the current state of all claims referenced by the provided Pods, as well as of the provided claims.
+// The returned ClaimsState can be passed to RestoreClaimsState to undo any modifications done to these claims in the meantime.
+func (s Snapshot) SaveClaimsState(pods []*apiv1.Pod, claims []*resourceapi.ResourceClaim) ClaimsState {
+	state := ClaimsState{}
+	saveClaim := func(claimId ResourceClaimId) {
+		if _, saved := state[claimId]; saved {
+			return
+		}
+		state[claimId] = nil
+		if claim, found := s.resourceClaimsById[claimId]; found {
+			state[claimId] = claim.DeepCopy()
+		}
+	}
+	for _, pod := range pods {
+		for _, podClaimRef := range pod.Spec.ResourceClaims {
+			if claimName := claimRefToName(pod, podClaimRef); claimName != "" {
+				saveClaim(ResourceClaimId{Name: claimName, Namespace: pod.Namespace})
+			}
+		}
+	}
+	for _, claim := range claims {
+		saveClaim(GetClaimId(claim))
+	}
+	return state
+}
+
+// RestoreClaimsState restores the claims saved in the provided ClaimsState to their saved state. Claims that weren't tracked
+// in the Snapshot when the state was saved are removed.
+func (s Snapshot) RestoreClaimsState(state ClaimsState) {
+	for claimId, claim := range state {
+		if claim == nil {
+			delete(s.resourceClaimsById, claimId)
+			continue
+		}
+		s.resourceClaimsById[claimId] = claim
+	}
+}
+
 // NodeResourceSlices returns all node-local ResourceSlices for the given Node.
 func (s Snapshot) NodeResourceSlices(nodeName string) ([]*resourceapi.ResourceSlice, bool) {
 	slices, found := s.resourceSlicesByNodeName[nodeName]
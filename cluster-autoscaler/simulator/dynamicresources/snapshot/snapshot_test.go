@@ -696,6 +696,42 @@ func TestSnapshotClone(t *testing.T) {
 	}
 }
 
+func TestSnapshotRestoreClaimsState(t *testing.T) {
+	snapshot := NewSnapshot(
+		map[ResourceClaimId]*resourceapi.ResourceClaim{
+			GetClaimId(sharedClaim1):  drautils.TestClaimWithPodReservations(sharedClaim1, pod2),
+			GetClaimId(sharedClaim2):  sharedClaim2.DeepCopy(),
+			GetClaimId(sharedClaim3):  drautils.TestClaimWithPodReservations(sharedClaim3, pod2),
+			GetClaimId(pod2OwnClaim1): drautils.TestClaimWithPodReservations(pod2OwnClaim1, pod2),
+		}, nil, nil, nil)
+	claims, err := snapshot.ResourceClaims().List()
+	if err != nil {
+		t.Fatalf("ResourceClaims().List(): unexpected error: %v", err)
+	}
+	// The claims are modified in place, so a copy is needed to compare against.
+	var initialClaims []*resourceapi.ResourceClaim
+	for _, claim := range claims {
+		initialClaims = append(initialClaims, claim.DeepCopy())
+	}
+
+	state := snapshot.SaveClaimsState([]*apiv1.Pod{pod1}, []*resourceapi.ResourceClaim{pod1OwnClaim1, pod1OwnClaim2})
+	if err := snapshot.AddClaims([]*resourceapi.ResourceClaim{pod1OwnClaim1.DeepCopy(), pod1OwnClaim2.DeepCopy()}); err != nil {
+		t.Fatalf("AddClaims(): unexpected error: %v", err)
+	}
+	if err := snapshot.ReservePodClaims(pod1); err != nil {
+		t.Fatalf("ReservePodClaims(): unexpected error: %v", err)
+	}
+	snapshot.RestoreClaimsState(state)
+
+	restoredClaims, err := snapshot.ResourceClaims().List()
+	if err != nil {
+		t.Fatalf("ResourceClaims().List(): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(initialClaims, restoredClaims, cmpopts.EquateEmpty(), test.IgnoreObjectOrder[*resourceapi.ResourceClaim]()); diff != "" {
+		t.Errorf("Snapshot: unexpected ResourceClaim state after restoring claims state (-want +got): %s", diff)
+	}
+}
+
 func testPods(count int) []*apiv1.Pod {
 	var result []*apiv1.Pod
 	for i := range count {
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"sync"
 
+	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/informers"
 	schedulerconfig "k8s.io/kubernetes/pkg/scheduler/apis/config"
 	schedulerconfiglatest "k8s.io/kubernetes/pkg/scheduler/apis/config/latest"
@@ -36,8 +37,16 @@ var (
 
 // Handle is meant for interacting with the scheduler framework.
 type Handle struct {
+	// Framework runs the plugins of the default scheduler profile.
 	Framework        schedulerframework.Framework
 	DelegatingLister *DelegatingSchedulerSharedLister
+
+	// profiles contains frameworks of all scheduler profiles by their scheduler names.
+	profiles map[string]schedulerframework.Framework
+
+	informerFactory informers.SharedInformerFactory
+	schedConfig     *schedulerconfig.KubeSchedulerConfiguration
+	draEnabled      bool
 }
 
 // NewHandle builds a framework Handle based on the provided informers and scheduler config.
@@ -49,8 +58,8 @@ func NewHandle(informerFactory informers.SharedInformerFactory, schedConfig *sch
 			return nil, fmt.Errorf("couldn't create scheduler config: %v", err)
 		}
 	}
-	if len(schedConfig.Profiles) != 1 {
-		return nil, fmt.Errorf("unexpected scheduler config: expected one scheduler profile only (found %d profiles)", len(schedConfig.Profiles))
+	if len(schedConfig.Profiles) == 0 {
+		return nil, fmt.Errorf("unexpected scheduler config: no scheduler profiles found")
 	}
 
 	sharedLister := NewDelegatingSchedulerSharedLister()
@@ -65,19 +74,53 @@ func NewHandle(informerFactory informers.SharedInformerFactory, schedConfig *sch
 	initMetricsOnce.Do(func() {
 		schedulermetrics.InitMetrics()
 	})
-	framework, err := schedulerframeworkruntime.NewFramework(
-		context.TODO(),
-		schedulerplugins.NewInTreeRegistry(),
-		&schedConfig.Profiles[0],
-		opts...,
-	)
-
-	if err != nil {
-		return nil, fmt.Errorf("couldn't create scheduler framework; %v", err)
+
+	// All profiles read the cluster state from the same lister, so plugins of any of them can run for the current snapshot.
+	profiles := make(map[string]schedulerframework.Framework, len(schedConfig.Profiles))
+	var defaultFramework schedulerframework.Framework
+	for i := range schedConfig.Profiles {
+		profile := &schedConfig.Profiles[i]
+		if _, found := profiles[profile.SchedulerName]; found {
+			return nil, fmt.Errorf("unexpected scheduler config: duplicate scheduler profile %q", profile.SchedulerName)
+		}
+		framework, err := schedulerframeworkruntime.NewFramework(
+			context.TODO(),
+			schedulerplugins.NewInTreeRegistry(),
+			profile,
+			opts...,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't create scheduler framework for profile %q; %v", profile.SchedulerName, err)
+		}
+		profiles[profile.SchedulerName] = framework
+		// Pods of schedulers without a profile are simulated with the first profile, unless there's one for the default scheduler.
+		if i == 0 || profile.SchedulerName == apiv1.DefaultSchedulerName {
+			defaultFramework = framework
+		}
 	}
 
 	return &Handle{
-		Framework:        framework,
+		Framework:        defaultFramework,
 		DelegatingLister: sharedLister,
+		profiles:         profiles,
+		informerFactory:  informerFactory,
+		schedConfig:      schedConfig,
+		draEnabled:       draEnabled,
 	}, nil
 }
+
+// FrameworkForPod returns the framework of the scheduler profile used by the pod's scheduler,
+// or of the default profile if there's no profile for the pod's scheduler.
+func (h *Handle) FrameworkForPod(pod *apiv1.Pod) schedulerframework.Framework {
+	if framework, found := h.profiles[pod.Spec.SchedulerName]; found {
+		return framework
+	}
+	return h.Framework
+}
+
+// Copy builds a new Handle with the same configuration as h. Scheduler plugins of a Handle
+// read the cluster state from its DelegatingLister, so running them for different snapshots
+// concurrently requires a separate Handle for each goroutine.
+func (h *Handle) Copy() (*Handle, error) {
+	return NewHandle(h.informerFactory, h.schedConfig, h.draEnabled)
+}
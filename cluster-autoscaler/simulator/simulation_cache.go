@@ -0,0 +1,194 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+)
+
+// SimulationCache caches the outcomes of moving the pods of removed nodes to destination nodes in scale
+// down simulations. Outcomes are keyed by a hash of the removed node, its pods and the destination nodes
+// with their pods, so in a stable cluster the pods of a node aren't rescheduled again in every loop.
+// Pods moved by a cached successful simulation are placed on the same nodes again, checking scheduling
+// predicates, and the full simulation runs if they don't fit anymore.
+//
+// Hashes of the nodes are computed once per loop and updated as simulations move pods, so the cache has
+// to be reset with Reset whenever the cluster snapshot changes in other ways, i.e. at the end of each loop.
+type SimulationCache struct {
+	// entries are the cached outcomes, by removed node name.
+	entries map[string]simulationCacheEntry
+	// used are the names of the removed nodes whose entries were looked up since the last reset.
+	used map[string]bool
+	// nodeHashes are the hashes of the nodes in the current snapshot, computed lazily.
+	nodeHashes map[string]uint64
+	// destinations are the destination nodes included in destinationsHash, with their hashes.
+	destinations     map[string]uint64
+	destinationsHash uint64
+	hits, misses     int
+}
+
+type simulationCacheEntry struct {
+	key uint64
+	// placements are the names of the nodes the pods were moved to, by pod namespace and name.
+	placements map[string]string
+	// err is the reason why the pods couldn't be moved, nil if they could.
+	err error
+}
+
+// NewSimulationCache returns an empty SimulationCache.
+func NewSimulationCache() *SimulationCache {
+	return &SimulationCache{
+		entries:      make(map[string]simulationCacheEntry),
+		used:         make(map[string]bool),
+		nodeHashes:   make(map[string]uint64),
+		destinations: make(map[string]uint64),
+	}
+}
+
+// Reset forgets the hashes of the nodes computed for the current cluster snapshot, and the cached outcomes
+// for nodes which weren't simulated since the previous reset.
+func (c *SimulationCache) Reset() {
+	for nodeName := range c.entries {
+		if !c.used[nodeName] {
+			delete(c.entries, nodeName)
+		}
+	}
+	c.used = make(map[string]bool)
+	c.nodeHashes = make(map[string]uint64)
+	c.destinations = make(map[string]uint64)
+	c.destinationsHash = 0
+}
+
+// TakeStats returns the number of cache hits and misses since the previous call.
+func (c *SimulationCache) TakeStats() (hits, misses int) {
+	hits, misses = c.hits, c.misses
+	c.hits, c.misses = 0, 0
+	return hits, misses
+}
+
+func (c *SimulationCache) get(nodeName string, key uint64) (simulationCacheEntry, bool) {
+	c.used[nodeName] = true
+	entry, found := c.entries[nodeName]
+	if !found || entry.key != key {
+		return simulationCacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (c *SimulationCache) put(nodeName string, key uint64, placements map[string]string, err error) {
+	c.entries[nodeName] = simulationCacheEntry{key: key, placements: placements, err: err}
+}
+
+// invalidate forgets the hashes of nodes whose pods were changed by a simulation.
+func (c *SimulationCache) invalidate(nodeNames ...string) {
+	for _, nodeName := range nodeNames {
+		delete(c.nodeHashes, nodeName)
+		if h, found := c.destinations[nodeName]; found {
+			c.destinationsHash ^= h
+			delete(c.destinations, nodeName)
+		}
+	}
+}
+
+// key returns the key of the outcome of moving the pods of the removed node to the destinations.
+func (c *SimulationCache) key(snapshot clustersnapshot.ClusterSnapshot, removedNode *framework.NodeInfo, pods []*apiv1.Pod, destinations map[string]bool) uint64 {
+	c.syncDestinations(snapshot, destinations)
+	h := fnv.New64a()
+	writeUint64(h, c.nodeHash(snapshot, removedNode.Node().Name))
+	for _, pod := range pods {
+		writeUint64(h, podHash(pod))
+	}
+	writeUint64(h, c.destinationsHash)
+	return h.Sum64()
+}
+
+// syncDestinations updates destinationsHash to the destinations of the current simulation, they change
+// as nodes are found to be removable.
+func (c *SimulationCache) syncDestinations(snapshot clustersnapshot.ClusterSnapshot, destinations map[string]bool) {
+	for nodeName, h := range c.destinations {
+		if !destinations[nodeName] {
+			c.destinationsHash ^= h
+			delete(c.destinations, nodeName)
+		}
+	}
+	for nodeName, isDestination := range destinations {
+		if _, found := c.destinations[nodeName]; found || !isDestination {
+			continue
+		}
+		h := c.nodeHash(snapshot, nodeName)
+		c.destinationsHash ^= h
+		c.destinations[nodeName] = h
+	}
+}
+
+func (c *SimulationCache) nodeHash(snapshot clustersnapshot.ClusterSnapshot, nodeName string) uint64 {
+	if h, found := c.nodeHashes[nodeName]; found {
+		return h
+	}
+	h := fnv.New64a()
+	writeStrings(h, nodeName)
+	if nodeInfo, err := snapshot.GetNodeInfo(nodeName); err == nil {
+		node := nodeInfo.Node()
+		writeStrings(h, string(node.UID), node.ResourceVersion)
+		// Pods are combined regardless of their order, which changes as they're moved around.
+		var pods uint64
+		for _, podInfo := range nodeInfo.Pods() {
+			pods ^= podHash(podInfo.Pod)
+		}
+		writeUint64(h, pods)
+	}
+	c.nodeHashes[nodeName] = h.Sum64()
+	return c.nodeHashes[nodeName]
+}
+
+func podHash(pod *apiv1.Pod) uint64 {
+	h := fnv.New64a()
+	writeStrings(h, pod.Namespace, pod.Name, string(pod.UID), pod.ResourceVersion)
+	return h.Sum64()
+}
+
+func podKey(pod *apiv1.Pod) string {
+	return pod.Namespace + "/" + pod.Name
+}
+
+func writeStrings(h hash.Hash64, values ...string) {
+	for _, value := range values {
+		h.Write([]byte(value))
+		h.Write([]byte{0})
+	}
+}
+
+func writeUint64(h hash.Hash64, value uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], value)
+	h.Write(b[:])
+}
+
+func hasResourceClaims(nodeInfo *framework.NodeInfo) bool {
+	for _, podInfo := range nodeInfo.Pods() {
+		if len(podInfo.Spec.ResourceClaims) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/clustersnapshot/testsnapshot"
+	kube_util "k8s.io/autoscaler/cluster-autoscaler/utils/kubernetes"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+func TestSimulationCache(t *testing.T) {
+	replicas := int32(5)
+	rsLister, err := kube_util.NewTestReplicaSetLister([]*appsv1.ReplicaSet{{
+		ObjectMeta: metav1.ObjectMeta{Name: "rs", Namespace: "default"},
+		Spec:       appsv1.ReplicaSetSpec{Replicas: &replicas},
+	}})
+	assert.NoError(t, err)
+	registry := kube_util.NewListerRegistry(nil, nil, nil, nil, nil, nil, nil, rsLister, nil)
+	ownerRefs := GenerateOwnerReferences("rs", "ReplicaSet", "extensions/v1beta1", "")
+
+	buildNode := func(name string, cpu int64) *apiv1.Node {
+		node := BuildTestNode(name, cpu, 2000000)
+		SetNodeReadyState(node, true, time.Time{})
+		return node
+	}
+	buildPod := func(name string, cpu int64, nodeName string) *apiv1.Pod {
+		pod := BuildTestPod(name, cpu, 100000)
+		pod.OwnerReferences = ownerRefs
+		pod.Spec.NodeName = nodeName
+		return pod
+	}
+	candidate := buildNode("candidate", 1000)
+	p1 := buildPod("p1", 300, "candidate")
+	p2 := buildPod("p2", 300, "candidate")
+	filler := buildPod("filler", 700, "destination")
+	filler.ResourceVersion = "2"
+
+	clusterSnapshot := testsnapshot.NewTestSnapshotOrDie(t)
+	cache := NewSimulationCache()
+	r := NewRemovalSimulator(registry, clusterSnapshot, testDeleteOptions(), nil, true)
+	r.SetSimulationCache(cache)
+
+	for _, tc := range []struct {
+		name            string
+		destination     *apiv1.Node
+		pods            []*apiv1.Pod
+		wantRemovable   bool
+		wantHits        int
+		wantMisses      int
+		wantDestination []string
+	}{
+		{
+			name:            "first simulation",
+			destination:     buildNode("destination", 1000),
+			pods:            []*apiv1.Pod{p1, p2},
+			wantRemovable:   true,
+			wantMisses:      1,
+			wantDestination: []string{"p1", "p2"},
+		},
+		{
+			name:            "cluster didn't change",
+			destination:     buildNode("destination", 1000),
+			pods:            []*apiv1.Pod{p1, p2},
+			wantRemovable:   true,
+			wantHits:        1,
+			wantDestination: []string{"p1", "p2"},
+		},
+		{
+			name:            "cached placements don't fit anymore",
+			destination:     buildNode("destination", 500),
+			pods:            []*apiv1.Pod{p1, p2},
+			wantMisses:      1,
+			wantDestination: []string{},
+		},
+		{
+			name:            "destination pods changed",
+			destination:     buildNode("destination", 1000),
+			pods:            []*apiv1.Pod{p1, p2, filler},
+			wantMisses:      1,
+			wantDestination: []string{"filler"},
+		},
+		{
+			name:            "destination pods didn't change",
+			destination:     buildNode("destination", 1000),
+			pods:            []*apiv1.Pod{p1, p2, filler},
+			wantHits:        1,
+			wantDestination: []string{"filler"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			clustersnapshot.InitializeClusterSnapshotOrDie(t, clusterSnapshot, []*apiv1.Node{candidate, tc.destination}, tc.pods)
+			toRemove, unremovable := r.FindNodesToRemove([]string{"candidate"}, []string{"candidate", "destination"}, time.Now(), nil)
+			if tc.wantRemovable {
+				assert.Len(t, toRemove, 1)
+				assert.Empty(t, unremovable)
+			} else if assert.Len(t, unremovable, 1) {
+				assert.Equal(t, NoPlaceToMovePods, unremovable[0].Reason)
+			}
+			hits, misses := cache.TakeStats()
+			assert.Equal(t, tc.wantHits, hits)
+			assert.Equal(t, tc.wantMisses, misses)
+
+			nodeInfo, err := clusterSnapshot.GetNodeInfo("destination")
+			assert.NoError(t, err)
+			podNames := []string{}
+			for _, podInfo := range nodeInfo.Pods() {
+				podNames = append(podNames, podInfo.Pod.Name)
+			}
+			assert.ElementsMatch(t, tc.wantDestination, podNames)
+			r.DropOldHints()
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utilization
+
+import (
+	"sort"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
+	podutils "k8s.io/autoscaler/cluster-autoscaler/utils/pod"
+)
+
+// PodUtilization contains the share of node allocatable requested by a single pod.
+type PodUtilization struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Utilization maps resource names to the fraction of node allocatable
+	// requested by the pod.
+	Utilization map[apiv1.ResourceName]float64 `json:"utilization"`
+}
+
+// Breakdown explains node utilization by listing pods that contribute most to it.
+type Breakdown struct {
+	// Resource name of highest utilization resource
+	ResourceName apiv1.ResourceName `json:"resourceName"`
+	Utilization  float64            `json:"utilization"`
+	// TopPods lists pods with the highest requests of ResourceName, in
+	// descending order.
+	TopPods []PodUtilization `json:"topPods,omitempty"`
+}
+
+// CalculateBreakdown returns up to topN pods with the highest requests of the
+// highest utilized resource in utilInfo, along with their contribution to cpu,
+// memory and that resource utilization. Pods are accounted for the same way
+// as in Calculate: DaemonSet and mirror pods are skipped (and their requests
+// subtracted from allocatable) if requested and long terminating pods are
+// ignored.
+func CalculateBreakdown(nodeInfo *framework.NodeInfo, utilInfo Info, topN int, skipDaemonSetPods, skipMirrorPods bool, currentTime time.Time) *Breakdown {
+	resourceNames := []apiv1.ResourceName{apiv1.ResourceCPU, apiv1.ResourceMemory}
+	if utilInfo.ResourceName != apiv1.ResourceCPU && utilInfo.ResourceName != apiv1.ResourceMemory {
+		resourceNames = append(resourceNames, utilInfo.ResourceName)
+	}
+
+	allocatable := make(map[apiv1.ResourceName]int64, len(resourceNames))
+	for _, resourceName := range resourceNames {
+		if quantity, found := nodeInfo.Node().Status.Allocatable[resourceName]; found {
+			allocatable[resourceName] = quantity.MilliValue()
+		}
+	}
+
+	var pods []*apiv1.Pod
+	for _, podInfo := range nodeInfo.Pods() {
+		pod := podInfo.Pod
+		if (skipDaemonSetPods && podutils.IsDaemonSetPod(pod)) || (skipMirrorPods && podutils.IsMirrorPod(pod)) {
+			requests := podutils.PodRequests(pod)
+			for resourceName := range allocatable {
+				value := requests[resourceName]
+				allocatable[resourceName] -= value.MilliValue()
+			}
+			continue
+		}
+		if drain.IsPodLongTerminating(pod, currentTime) {
+			continue
+		}
+		pods = append(pods, pod)
+	}
+
+	podUtils := make([]PodUtilization, 0, len(pods))
+	for _, pod := range pods {
+		requests := podutils.PodRequests(pod)
+		podUtil := PodUtilization{Namespace: pod.Namespace, Name: pod.Name, Utilization: make(map[apiv1.ResourceName]float64)}
+		for resourceName, value := range allocatable {
+			if value <= 0 {
+				continue
+			}
+			request := requests[resourceName]
+			podUtil.Utilization[resourceName] = float64(request.MilliValue()) / float64(value)
+		}
+		podUtils = append(podUtils, podUtil)
+	}
+
+	sort.SliceStable(podUtils, func(i, j int) bool {
+		a, b := podUtils[i].Utilization[utilInfo.ResourceName], podUtils[j].Utilization[utilInfo.ResourceName]
+		if a != b {
+			return a > b
+		}
+		if podUtils[i].Namespace != podUtils[j].Namespace {
+			return podUtils[i].Namespace < podUtils[j].Namespace
+		}
+		return podUtils[i].Name < podUtils[j].Name
+	})
+	if len(podUtils) > topN {
+		podUtils = podUtils[:topN]
+	}
+
+	return &Breakdown{
+		ResourceName: utilInfo.ResourceName,
+		Utilization:  utilInfo.Utilization,
+		TopPods:      podUtils,
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utilization
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+func TestCalculateBreakdown(t *testing.T) {
+	testTime := time.Date(2020, time.December, 18, 17, 0, 0, 0, time.UTC)
+	node := BuildTestNode("node1", 2000, 2000000)
+	SetNodeReadyState(node, true, time.Time{})
+
+	small := BuildTestPod("small", 100, 800000)
+	medium := BuildTestPod("medium", 500, 200000)
+	large := BuildTestPod("large", 1000, 100000)
+	ds := BuildTestPod("ds", 400, 0)
+	ds.OwnerReferences = GenerateOwnerReferences("ds", "DaemonSet", "apps/v1", "")
+	terminating := BuildTestPod("terminating", 1000, 0)
+	terminating.DeletionTimestamp = &metav1.Time{Time: testTime.Add(-10 * time.Minute)}
+
+	hugePagesNode := BuildTestNode("node2", 2000, 2000000)
+	hugePagesNode.Status.Allocatable[apiv1.ResourceName("hugepages-2Mi")] = *resource.NewQuantity(100, resource.DecimalSI)
+	hugePagesPod := BuildTestPod("hugepages", 100, 100000)
+	hugePagesPod.Spec.Containers[0].Resources.Requests[apiv1.ResourceName("hugepages-2Mi")] = *resource.NewQuantity(90, resource.DecimalSI)
+
+	for _, tc := range []struct {
+		testName          string
+		nodeInfo          *framework.NodeInfo
+		utilInfo          Info
+		topN              int
+		skipDaemonSetPods bool
+		wantBreakdown     *Breakdown
+	}{
+		{
+			testName: "pods sorted by the highest utilized resource",
+			nodeInfo: framework.NewTestNodeInfo(node, small, medium, large),
+			utilInfo: Info{CpuUtil: 0.8, MemUtil: 0.55, ResourceName: apiv1.ResourceCPU, Utilization: 0.8},
+			topN:     5,
+			wantBreakdown: &Breakdown{
+				ResourceName: apiv1.ResourceCPU,
+				Utilization:  0.8,
+				TopPods: []PodUtilization{
+					{Namespace: "default", Name: "large", Utilization: map[apiv1.ResourceName]float64{apiv1.ResourceCPU: 0.5, apiv1.ResourceMemory: 0.05}},
+					{Namespace: "default", Name: "medium", Utilization: map[apiv1.ResourceName]float64{apiv1.ResourceCPU: 0.25, apiv1.ResourceMemory: 0.1}},
+					{Namespace: "default", Name: "small", Utilization: map[apiv1.ResourceName]float64{apiv1.ResourceCPU: 0.05, apiv1.ResourceMemory: 0.4}},
+				},
+			},
+		},
+		{
+			testName: "only top N pods are listed",
+			nodeInfo: framework.NewTestNodeInfo(node, small, medium, large),
+			utilInfo: Info{CpuUtil: 0.8, MemUtil: 0.55, ResourceName: apiv1.ResourceMemory, Utilization: 0.55},
+			topN:     1,
+			wantBreakdown: &Breakdown{
+				ResourceName: apiv1.ResourceMemory,
+				Utilization:  0.55,
+				TopPods: []PodUtilization{
+					{Namespace: "default", Name: "small", Utilization: map[apiv1.ResourceName]float64{apiv1.ResourceCPU: 0.05, apiv1.ResourceMemory: 0.4}},
+				},
+			},
+		},
+		{
+			testName:          "skipped daemonset pods are subtracted from allocatable and long terminating pods are ignored",
+			nodeInfo:          framework.NewTestNodeInfo(node, large, ds, terminating),
+			utilInfo:          Info{CpuUtil: 0.625, ResourceName: apiv1.ResourceCPU, Utilization: 0.625},
+			topN:              5,
+			skipDaemonSetPods: true,
+			wantBreakdown: &Breakdown{
+				ResourceName: apiv1.ResourceCPU,
+				Utilization:  0.625,
+				TopPods: []PodUtilization{
+					{Namespace: "default", Name: "large", Utilization: map[apiv1.ResourceName]float64{apiv1.ResourceCPU: 0.625, apiv1.ResourceMemory: 0.05}},
+				},
+			},
+		},
+		{
+			testName: "extended resource contribution is reported",
+			nodeInfo: framework.NewTestNodeInfo(hugePagesNode, hugePagesPod),
+			utilInfo: Info{CpuUtil: 0.05, MemUtil: 0.05, ExtendedResourcesUtil: map[apiv1.ResourceName]float64{"hugepages-2Mi": 0.9}, ResourceName: "hugepages-2Mi", Utilization: 0.9},
+			topN:     5,
+			wantBreakdown: &Breakdown{
+				ResourceName: "hugepages-2Mi",
+				Utilization:  0.9,
+				TopPods: []PodUtilization{
+					{Namespace: "default", Name: "hugepages", Utilization: map[apiv1.ResourceName]float64{apiv1.ResourceCPU: 0.05, apiv1.ResourceMemory: 0.05, "hugepages-2Mi": 0.9}},
+				},
+			},
+		},
+	} {
+		t.Run(tc.testName, func(t *testing.T) {
+			got := CalculateBreakdown(tc.nodeInfo, tc.utilInfo, tc.topN, tc.skipDaemonSetPods, false, testTime)
+			if diff := cmp.Diff(tc.wantBreakdown, got, cmpopts.EquateApprox(0, 0.0001)); diff != "" {
+				t.Errorf("CalculateBreakdown(): unexpected output (-want +got): %s", diff)
+			}
+		})
+	}
+}
@@ -37,9 +37,13 @@ type Info struct {
 	MemUtil             float64
 	GpuUtil             float64
 	DynamicResourceUtil float64
+	// ExtendedResourcesUtil holds utilization of additionally configured
+	// resources (e.g. hugepages, ephemeral-storage), keyed by resource name.
+	// Resources not present in node allocatable are omitted.
+	ExtendedResourcesUtil map[apiv1.ResourceName]float64
 	// Resource name of highest utilization resource
 	ResourceName apiv1.ResourceName
-	// Max(CpuUtil, MemUtil) or GpuUtils
+	// Max(CpuUtil, MemUtil, ExtendedResourcesUtil...) or GpuUtils
 	Utilization float64
 }
 
@@ -47,7 +51,11 @@ type Info struct {
 // memory) or gpu utilization based on if the node has GPU or not. Per resource
 // utilization is the sum of requests for it divided by allocatable. It also
 // returns the individual cpu, memory and gpu utilization.
-func Calculate(nodeInfo *framework.NodeInfo, skipDaemonSetPods, skipMirrorPods, draEnabled bool, gpuConfig *cloudprovider.GpuConfig, currentTime time.Time) (utilInfo Info, err error) {
+// For nodes without GPU, utilization of any of extendedResources that the node
+// exposes in its allocatable is also calculated and taken into account when
+// picking the highest utilized resource. extendedResources are ignored for GPU
+// nodes and, if DRA is enabled, for nodes with local resource slices.
+func Calculate(nodeInfo *framework.NodeInfo, skipDaemonSetPods, skipMirrorPods, draEnabled bool, gpuConfig *cloudprovider.GpuConfig, extendedResources []apiv1.ResourceName, currentTime time.Time) (utilInfo Info, err error) {
 	if gpuConfig != nil {
 		gpuUtil, err := CalculateUtilizationOfResource(nodeInfo, gpuConfig.ResourceName, skipDaemonSetPods, skipMirrorPods, currentTime)
 		if err != nil {
@@ -88,6 +96,27 @@ func Calculate(nodeInfo *framework.NodeInfo, skipDaemonSetPods, skipMirrorPods,
 		utilization.Utilization = mem
 	}
 
+	for _, resourceName := range extendedResources {
+		if resourceName == apiv1.ResourceCPU || resourceName == apiv1.ResourceMemory {
+			continue
+		}
+		if allocatable, found := nodeInfo.Node().Status.Allocatable[resourceName]; !found || allocatable.IsZero() {
+			continue
+		}
+		util, err := CalculateUtilizationOfResource(nodeInfo, resourceName, skipDaemonSetPods, skipMirrorPods, currentTime)
+		if err != nil {
+			return Info{}, err
+		}
+		if utilization.ExtendedResourcesUtil == nil {
+			utilization.ExtendedResourcesUtil = make(map[apiv1.ResourceName]float64)
+		}
+		utilization.ExtendedResourcesUtil[resourceName] = util
+		if util > utilization.Utilization {
+			utilization.ResourceName = resourceName
+			utilization.Utilization = util
+		}
+	}
+
 	return utilization, nil
 }
 
@@ -83,7 +83,7 @@ func TestCalculate(t *testing.T) {
 	nodeInfo := framework.NewTestNodeInfo(node, pod, pod, pod2)
 
 	gpuConfig := getGpuConfigFromNode(nodeInfo.Node())
-	utilInfo, err := Calculate(nodeInfo, false, false, false, gpuConfig, testTime)
+	utilInfo, err := Calculate(nodeInfo, false, false, false, gpuConfig, nil, testTime)
 	assert.NoError(t, err)
 	assert.InEpsilon(t, 2.0/10, utilInfo.Utilization, 0.01)
 	assert.Equal(t, 0.1, utilInfo.CpuUtil)
@@ -92,7 +92,7 @@ func TestCalculate(t *testing.T) {
 	nodeInfo = framework.NewTestNodeInfo(node2, pod, pod, pod2)
 
 	gpuConfig = getGpuConfigFromNode(nodeInfo.Node())
-	_, err = Calculate(nodeInfo, false, false, false, gpuConfig, testTime)
+	_, err = Calculate(nodeInfo, false, false, false, gpuConfig, nil, testTime)
 	assert.Error(t, err)
 
 	node3 := BuildTestNode("node3", 2000, 2000000)
@@ -100,7 +100,7 @@ func TestCalculate(t *testing.T) {
 	nodeInfo = framework.NewTestNodeInfo(node3, pod, podWithInitContainers, podWithLargeNonRestartableInitContainers)
 
 	gpuConfig = getGpuConfigFromNode(nodeInfo.Node())
-	utilInfo, err = Calculate(nodeInfo, false, false, false, gpuConfig, testTime)
+	utilInfo, err = Calculate(nodeInfo, false, false, false, gpuConfig, nil, testTime)
 	assert.NoError(t, err)
 	assert.InEpsilon(t, 50.25, utilInfo.Utilization, 0.01)
 	assert.InEpsilon(t, 25.125, utilInfo.CpuUtil, 0.005)
@@ -114,13 +114,13 @@ func TestCalculate(t *testing.T) {
 
 	nodeInfo = framework.NewTestNodeInfo(node, pod, pod, pod2, daemonSetPod3, daemonSetPod4)
 	gpuConfig = getGpuConfigFromNode(nodeInfo.Node())
-	utilInfo, err = Calculate(nodeInfo, true, false, false, gpuConfig, testTime)
+	utilInfo, err = Calculate(nodeInfo, true, false, false, gpuConfig, nil, testTime)
 	assert.NoError(t, err)
 	assert.InEpsilon(t, 2.5/10, utilInfo.Utilization, 0.01)
 
 	nodeInfo = framework.NewTestNodeInfo(node, pod, pod2, daemonSetPod3)
 	gpuConfig = getGpuConfigFromNode(nodeInfo.Node())
-	utilInfo, err = Calculate(nodeInfo, false, false, false, gpuConfig, testTime)
+	utilInfo, err = Calculate(nodeInfo, false, false, false, gpuConfig, nil, testTime)
 	assert.NoError(t, err)
 	assert.InEpsilon(t, 2.0/10, utilInfo.Utilization, 0.01)
 
@@ -128,7 +128,7 @@ func TestCalculate(t *testing.T) {
 	terminatedPod.DeletionTimestamp = &metav1.Time{Time: testTime.Add(-10 * time.Minute)}
 	nodeInfo = framework.NewTestNodeInfo(node, pod, pod, pod2, terminatedPod)
 	gpuConfig = getGpuConfigFromNode(nodeInfo.Node())
-	utilInfo, err = Calculate(nodeInfo, false, false, false, gpuConfig, testTime)
+	utilInfo, err = Calculate(nodeInfo, false, false, false, gpuConfig, nil, testTime)
 	assert.NoError(t, err)
 	assert.InEpsilon(t, 2.0/10, utilInfo.Utilization, 0.01)
 
@@ -139,19 +139,19 @@ func TestCalculate(t *testing.T) {
 
 	nodeInfo = framework.NewTestNodeInfo(node, pod, pod, pod2, mirrorPod)
 	gpuConfig = getGpuConfigFromNode(nodeInfo.Node())
-	utilInfo, err = Calculate(nodeInfo, false, true, false, gpuConfig, testTime)
+	utilInfo, err = Calculate(nodeInfo, false, true, false, gpuConfig, nil, testTime)
 	assert.NoError(t, err)
 	assert.InEpsilon(t, 2.0/9.0, utilInfo.Utilization, 0.01)
 
 	nodeInfo = framework.NewTestNodeInfo(node, pod, pod2, mirrorPod)
 	gpuConfig = getGpuConfigFromNode(nodeInfo.Node())
-	utilInfo, err = Calculate(nodeInfo, false, false, false, gpuConfig, testTime)
+	utilInfo, err = Calculate(nodeInfo, false, false, false, gpuConfig, nil, testTime)
 	assert.NoError(t, err)
 	assert.InEpsilon(t, 2.0/10, utilInfo.Utilization, 0.01)
 
 	nodeInfo = framework.NewTestNodeInfo(node, pod, mirrorPod, daemonSetPod3)
 	gpuConfig = getGpuConfigFromNode(nodeInfo.Node())
-	utilInfo, err = Calculate(nodeInfo, true, true, false, gpuConfig, testTime)
+	utilInfo, err = Calculate(nodeInfo, true, true, false, gpuConfig, nil, testTime)
 	assert.NoError(t, err)
 	assert.InEpsilon(t, 1.0/8.0, utilInfo.Utilization, 0.01)
 
@@ -162,7 +162,7 @@ func TestCalculate(t *testing.T) {
 	TolerateGpuForPod(gpuPod)
 	nodeInfo = framework.NewTestNodeInfo(gpuNode, pod, pod, gpuPod)
 	gpuConfig = getGpuConfigFromNode(nodeInfo.Node())
-	utilInfo, err = Calculate(nodeInfo, false, false, false, gpuConfig, testTime)
+	utilInfo, err = Calculate(nodeInfo, false, false, false, gpuConfig, nil, testTime)
 	assert.NoError(t, err)
 	assert.InEpsilon(t, 1/1, utilInfo.Utilization, 0.01)
 
@@ -171,11 +171,105 @@ func TestCalculate(t *testing.T) {
 	AddGpuLabelToNode(gpuNode)
 	nodeInfo = framework.NewTestNodeInfo(gpuNode, pod, pod)
 	gpuConfig = getGpuConfigFromNode(nodeInfo.Node())
-	utilInfo, err = Calculate(nodeInfo, false, false, false, gpuConfig, testTime)
+	utilInfo, err = Calculate(nodeInfo, false, false, false, gpuConfig, nil, testTime)
 	assert.NoError(t, err)
 	assert.Zero(t, utilInfo.Utilization)
 }
 
+func TestCalculateWithExtendedResources(t *testing.T) {
+	testTime := time.Date(2020, time.December, 18, 17, 0, 0, 0, time.UTC)
+	hugePages := apiv1.ResourceName("hugepages-2Mi")
+
+	node := BuildTestNode("node1", 2000, 2000000)
+	node.Status.Allocatable[hugePages] = *resource.NewQuantity(1000, resource.DecimalSI)
+	node.Status.Allocatable[apiv1.ResourceEphemeralStorage] = *resource.NewQuantity(0, resource.DecimalSI)
+	pod := BuildTestPod("p1", 100, 200000)
+	pod.Spec.Containers[0].Resources.Requests[hugePages] = *resource.NewQuantity(600, resource.DecimalSI)
+	nodeInfo := framework.NewTestNodeInfo(node, pod)
+
+	gpuNode := BuildTestNode("gpu_node", 2000, 2000000)
+	AddGpusToNode(gpuNode, 2)
+	gpuNode.Status.Allocatable[hugePages] = *resource.NewQuantity(1000, resource.DecimalSI)
+	gpuPod := BuildTestPod("gpu_pod", 100, 200000)
+	RequestGpuForPod(gpuPod, 1)
+	gpuPod.Spec.Containers[0].Resources.Requests[hugePages] = *resource.NewQuantity(600, resource.DecimalSI)
+	gpuNodeInfo := framework.NewTestNodeInfo(gpuNode, gpuPod)
+
+	resourceSlice := &resourceapi.ResourceSlice{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-slice", UID: "node-slice"},
+		Spec: resourceapi.ResourceSliceSpec{
+			Driver: "driver.foo.com",
+			Pool: resourceapi.ResourcePool{
+				Name:               "node-pool",
+				ResourceSliceCount: 1,
+			},
+			Devices: []resourceapi.Device{{Name: "dev1"}},
+		},
+	}
+	draNodeInfo := framework.NewNodeInfo(node, []*resourceapi.ResourceSlice{resourceSlice}, framework.NewPodInfo(pod, nil))
+
+	for _, tc := range []struct {
+		testName          string
+		nodeInfo          *framework.NodeInfo
+		gpuConfig         *cloudprovider.GpuConfig
+		draEnabled        bool
+		extendedResources []apiv1.ResourceName
+		wantUtilInfo      Info
+	}{
+		{
+			testName:     "no extended resources configured -> cpu and memory only",
+			nodeInfo:     nodeInfo,
+			wantUtilInfo: Info{CpuUtil: 0.05, MemUtil: 0.1, Utilization: 0.1, ResourceName: apiv1.ResourceMemory},
+		},
+		{
+			testName:          "extended resource utilization higher than cpu and memory",
+			nodeInfo:          nodeInfo,
+			extendedResources: []apiv1.ResourceName{hugePages},
+			wantUtilInfo: Info{
+				CpuUtil:               0.05,
+				MemUtil:               0.1,
+				ExtendedResourcesUtil: map[apiv1.ResourceName]float64{hugePages: 0.6},
+				Utilization:           0.6,
+				ResourceName:          hugePages,
+			},
+		},
+		{
+			testName:          "resources missing or zero in node allocatable are skipped",
+			nodeInfo:          nodeInfo,
+			extendedResources: []apiv1.ResourceName{apiv1.ResourceEphemeralStorage, "hugepages-1Gi"},
+			wantUtilInfo:      Info{CpuUtil: 0.05, MemUtil: 0.1, Utilization: 0.1, ResourceName: apiv1.ResourceMemory},
+		},
+		{
+			testName:          "cpu and memory passed as extended resources are skipped",
+			nodeInfo:          nodeInfo,
+			extendedResources: []apiv1.ResourceName{apiv1.ResourceCPU, apiv1.ResourceMemory},
+			wantUtilInfo:      Info{CpuUtil: 0.05, MemUtil: 0.1, Utilization: 0.1, ResourceName: apiv1.ResourceMemory},
+		},
+		{
+			testName:          "GPU node -> extended resources ignored",
+			nodeInfo:          gpuNodeInfo,
+			gpuConfig:         getGpuConfigFromNode(gpuNode),
+			extendedResources: []apiv1.ResourceName{hugePages},
+			wantUtilInfo:      Info{GpuUtil: 0.5, Utilization: 0.5, ResourceName: gpu.ResourceNvidiaGPU},
+		},
+		{
+			testName:          "DRA slices present, DRA enabled -> extended resources ignored",
+			nodeInfo:          draNodeInfo,
+			draEnabled:        true,
+			extendedResources: []apiv1.ResourceName{hugePages},
+			wantUtilInfo:      Info{DynamicResourceUtil: 0, Utilization: 0, ResourceName: apiv1.ResourceName("driver.foo.com/node-pool")},
+		},
+	} {
+		t.Run(tc.testName, func(t *testing.T) {
+			utilInfo, err := Calculate(tc.nodeInfo, false, false, tc.draEnabled, tc.gpuConfig, tc.extendedResources, testTime)
+			assert.NoError(t, err)
+			if diff := cmp.Diff(tc.wantUtilInfo, utilInfo, cmpopts.EquateApprox(0, 0.0001)); diff != "" {
+				t.Errorf("Calculate(): unexpected output (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
 func TestCalculateWithDynamicResources(t *testing.T) {
 	now := time.Date(2024, 12, 4, 0, 0, 0, 0, time.UTC)
 	node := BuildTestNode("node", 1000, 1000)
@@ -354,7 +448,7 @@ func TestCalculateWithDynamicResources(t *testing.T) {
 		},
 	} {
 		t.Run(tc.testName, func(t *testing.T) {
-			utilInfo, err := Calculate(tc.nodeInfo, false, false, tc.draEnabled, tc.gpuConfig, now)
+			utilInfo, err := Calculate(tc.nodeInfo, false, false, tc.draEnabled, tc.gpuConfig, nil, now)
 			if diff := cmp.Diff(tc.wantErr, err, cmpopts.EquateErrors()); diff != "" {
 				t.Errorf("Calculate(): unexpected error (-want +got): %s", diff)
 			}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utilization
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	nodeMetricsPath = "/apis/metrics.k8s.io/v1beta1/nodes"
+	// DefaultUsageRefreshInterval is how long node usage is cached by usage
+	// providers before being fetched again.
+	DefaultUsageRefreshInterval = time.Minute
+)
+
+// nodeMetricsList mirrors the subset of metrics.k8s.io NodeMetricsList used
+// by the autoscaler.
+type nodeMetricsList struct {
+	Items []nodeMetrics `json:"items"`
+}
+
+type nodeMetrics struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Usage             apiv1.ResourceList `json:"usage"`
+}
+
+type cachedNodeUsage struct {
+	mutex           sync.Mutex
+	refreshInterval time.Duration
+	lastRefresh     time.Time
+	usage           map[string]apiv1.ResourceList
+	fetch           func() (map[string]apiv1.ResourceList, error)
+	now             func() time.Time
+}
+
+func (c *cachedNodeUsage) NodeUsage(nodeName string) (apiv1.ResourceList, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if now := c.now(); c.usage == nil || now.Sub(c.lastRefresh) >= c.refreshInterval {
+		usage, err := c.fetch()
+		if err != nil {
+			return nil, err
+		}
+		c.usage = usage
+		c.lastRefresh = now
+	}
+	usage, found := c.usage[nodeName]
+	if !found {
+		return nil, fmt.Errorf("no usage reported for node %s", nodeName)
+	}
+	return usage, nil
+}
+
+// NewMetricsServerUsageProvider returns a UsageProvider fetching node usage
+// from metrics.k8s.io API. Usage of all nodes is fetched at once and cached
+// for refreshInterval.
+func NewMetricsServerUsageProvider(client rest.Interface, refreshInterval time.Duration) UsageProvider {
+	return &cachedNodeUsage{
+		refreshInterval: refreshInterval,
+		now:             time.Now,
+		fetch: func() (map[string]apiv1.ResourceList, error) {
+			return fetchNodeMetrics(client)
+		},
+	}
+}
+
+func fetchNodeMetrics(client rest.Interface) (map[string]apiv1.ResourceList, error) {
+	data, err := client.Get().AbsPath(nodeMetricsPath).DoRaw(context.TODO())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list node metrics: %v", err)
+	}
+	var metricsList nodeMetricsList
+	if err := json.Unmarshal(data, &metricsList); err != nil {
+		return nil, fmt.Errorf("failed to decode node metrics: %v", err)
+	}
+	usage := make(map[string]apiv1.ResourceList, len(metricsList.Items))
+	for _, item := range metricsList.Items {
+		usage[item.Name] = item.Usage
+	}
+	return usage, nil
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utilization
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/kubernetes/scheme"
+	restfake "k8s.io/client-go/rest/fake"
+)
+
+func TestMetricsServerUsageProvider(t *testing.T) {
+	requests := 0
+	client := &restfake.RESTClient{
+		NegotiatedSerializer: scheme.Codecs.WithoutConversion(),
+		Client: restfake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			requests++
+			assert.Equal(t, nodeMetricsPath, req.URL.Path)
+			body := `{"kind":"NodeMetricsList","apiVersion":"metrics.k8s.io/v1beta1","items":[` +
+				`{"metadata":{"name":"node1"},"usage":{"cpu":"250m","memory":"1Gi"}},` +
+				`{"metadata":{"name":"node2"},"usage":{"cpu":"1","memory":"512Mi"}}]}`
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Content-Type": []string{"application/json"}}, Body: io.NopCloser(bytes.NewBufferString(body))}, nil
+		}),
+	}
+	provider := NewMetricsServerUsageProvider(client, time.Hour)
+
+	usage, err := provider.NodeUsage("node1")
+	assert.NoError(t, err)
+	assert.True(t, resource.MustParse("250m").Equal(usage[apiv1.ResourceCPU]))
+	assert.True(t, resource.MustParse("1Gi").Equal(usage[apiv1.ResourceMemory]))
+
+	usage, err = provider.NodeUsage("node2")
+	assert.NoError(t, err)
+	assert.True(t, resource.MustParse("1").Equal(usage[apiv1.ResourceCPU]))
+
+	_, err = provider.NodeUsage("node3")
+	assert.Error(t, err)
+	assert.Equal(t, 1, requests, "node metrics should be fetched once per refresh interval")
+}
+
+func TestCachedNodeUsageRefresh(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	fetches := 0
+	provider := &cachedNodeUsage{
+		refreshInterval: time.Minute,
+		now:             func() time.Time { return now },
+		fetch: func() (map[string]apiv1.ResourceList, error) {
+			fetches++
+			return map[string]apiv1.ResourceList{"node1": {}}, nil
+		},
+	}
+	_, err := provider.NodeUsage("node1")
+	assert.NoError(t, err)
+	now = now.Add(30 * time.Second)
+	_, err = provider.NodeUsage("node1")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fetches)
+	now = now.Add(30 * time.Second)
+	_, err = provider.NodeUsage("node1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, fetches)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utilization
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	promapi "github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/klog/v2"
+)
+
+const (
+	prometheusQueryTimeout = 30 * time.Second
+	// Both queries are expected to return one sample per node, labeled with node name.
+	prometheusNodeLabel     = "node"
+	prometheusCpuQuery      = `sum by (node) (rate(container_cpu_usage_seconds_total{container!=""}[5m]))`
+	prometheusMemoryQuery   = `sum by (node) (container_memory_working_set_bytes{container!=""})`
+	prometheusCpuMilliScale = 1000
+)
+
+// NewPrometheusUsageProvider returns a UsageProvider fetching node usage from
+// Prometheus available under a given address. Usage is aggregated from
+// cAdvisor container metrics, which need to carry a "node" label. Usage of all
+// nodes is fetched at once and cached for refreshInterval.
+func NewPrometheusUsageProvider(address string, refreshInterval time.Duration) (UsageProvider, error) {
+	client, err := promapi.NewClient(promapi.Config{Address: address})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Prometheus client: %v", err)
+	}
+	api := promv1.NewAPI(client)
+	return &cachedNodeUsage{
+		refreshInterval: refreshInterval,
+		now:             time.Now,
+		fetch: func() (map[string]apiv1.ResourceList, error) {
+			return fetchPrometheusNodeUsage(api)
+		},
+	}, nil
+}
+
+func fetchPrometheusNodeUsage(api promv1.API) (map[string]apiv1.ResourceList, error) {
+	usage := make(map[string]apiv1.ResourceList)
+	cpu, err := queryPerNode(api, prometheusCpuQuery)
+	if err != nil {
+		return nil, err
+	}
+	for node, value := range cpu {
+		usage[node] = apiv1.ResourceList{
+			apiv1.ResourceCPU: *resource.NewMilliQuantity(int64(value*prometheusCpuMilliScale), resource.DecimalSI),
+		}
+	}
+	mem, err := queryPerNode(api, prometheusMemoryQuery)
+	if err != nil {
+		return nil, err
+	}
+	for node, value := range mem {
+		if _, found := usage[node]; !found {
+			usage[node] = apiv1.ResourceList{}
+		}
+		usage[node][apiv1.ResourceMemory] = *resource.NewQuantity(int64(value), resource.BinarySI)
+	}
+	return usage, nil
+}
+
+func queryPerNode(api promv1.API, query string) (map[string]float64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), prometheusQueryTimeout)
+	defer cancel()
+	value, warnings, err := api.Query(ctx, query, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Prometheus: %v", err)
+	}
+	for _, warning := range warnings {
+		klog.Warningf("Prometheus query %q returned warning: %s", query, warning)
+	}
+	vector, ok := value.(model.Vector)
+	if !ok {
+		return nil, fmt.Errorf("unexpected Prometheus result type %s for query %q", value.Type(), query)
+	}
+	result := make(map[string]float64, len(vector))
+	for _, sample := range vector {
+		node := string(sample.Metric[prometheusNodeLabel])
+		if node == "" {
+			continue
+		}
+		result[node] = float64(sample.Value)
+	}
+	return result, nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utilization
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestPrometheusUsageProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("Failed to parse request: %v", err)
+		}
+		var result string
+		switch r.Form.Get("query") {
+		case prometheusCpuQuery:
+			result = `[{"metric":{"node":"node1"},"value":[1700000000,"0.5"]},{"metric":{},"value":[1700000000,"3"]}]`
+		case prometheusMemoryQuery:
+			result = `[{"metric":{"node":"node1"},"value":[1700000000,"1073741824"]}]`
+		default:
+			t.Fatalf("Unexpected query: %s", r.Form.Get("query"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":%s}}`, result)
+	}))
+	defer server.Close()
+
+	provider, err := NewPrometheusUsageProvider(server.URL, time.Minute)
+	assert.NoError(t, err)
+	usage, err := provider.NodeUsage("node1")
+	assert.NoError(t, err)
+	assert.True(t, resource.MustParse("500m").Equal(usage[apiv1.ResourceCPU]))
+	assert.True(t, resource.MustParse("1Gi").Equal(usage[apiv1.ResourceMemory]))
+
+	_, err = provider.NodeUsage("node2")
+	assert.Error(t, err)
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utilization
+
+import (
+	"fmt"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/klog/v2"
+)
+
+// Source determines what node utilization is based on.
+type Source string
+
+const (
+	// RequestsSource bases node utilization on the sum of pod requests.
+	RequestsSource Source = "requests"
+	// ActualSource bases cpu and memory utilization on actual node usage.
+	ActualSource Source = "actual"
+	// MaxSource uses the higher of requests-based and actual cpu and memory utilization.
+	MaxSource Source = "max"
+)
+
+// AvailableSources lists all supported utilization sources.
+var AvailableSources = []Source{RequestsSource, ActualSource, MaxSource}
+
+// ParseSource validates and returns the utilization source with a given name.
+func ParseSource(name string) (Source, error) {
+	for _, source := range AvailableSources {
+		if string(source) == name {
+			return source, nil
+		}
+	}
+	return "", fmt.Errorf("unknown utilization source %q, available sources: %v", name, AvailableSources)
+}
+
+// UsageProvider provides actual resource usage of nodes, e.g. reported by
+// metrics.k8s.io API or Prometheus.
+type UsageProvider interface {
+	// NodeUsage returns current usage of cpu and memory on a given node.
+	NodeUsage(nodeName string) (apiv1.ResourceList, error)
+}
+
+// ApplyUsage adjusts cpu and memory utilization in utilInfo according to the
+// given source, using node usage reported by usageProvider. Utilization of
+// GPU and DRA resources is never adjusted, as usage of these resources is not
+// reported by usage providers. If usage cannot be fetched, requests-based
+// utilization is returned unchanged.
+func ApplyUsage(utilInfo Info, nodeInfo *framework.NodeInfo, usageProvider UsageProvider, source Source) Info {
+	if source == RequestsSource || usageProvider == nil || !isCpuMemUtilization(utilInfo) {
+		return utilInfo
+	}
+	node := nodeInfo.Node()
+	usage, err := usageProvider.NodeUsage(node.Name)
+	if err != nil {
+		klog.Warningf("Failed to get usage of node %s, falling back to requests-based utilization: %v", node.Name, err)
+		return utilInfo
+	}
+
+	cpu, cpuFound := usageUtilization(node, usage, apiv1.ResourceCPU)
+	mem, memFound := usageUtilization(node, usage, apiv1.ResourceMemory)
+	switch source {
+	case ActualSource:
+		if cpuFound {
+			utilInfo.CpuUtil = cpu
+		}
+		if memFound {
+			utilInfo.MemUtil = mem
+		}
+	case MaxSource:
+		if cpuFound && cpu > utilInfo.CpuUtil {
+			utilInfo.CpuUtil = cpu
+		}
+		if memFound && mem > utilInfo.MemUtil {
+			utilInfo.MemUtil = mem
+		}
+	}
+
+	if utilInfo.CpuUtil > utilInfo.MemUtil {
+		utilInfo.ResourceName = apiv1.ResourceCPU
+		utilInfo.Utilization = utilInfo.CpuUtil
+	} else {
+		utilInfo.ResourceName = apiv1.ResourceMemory
+		utilInfo.Utilization = utilInfo.MemUtil
+	}
+	for resourceName, util := range utilInfo.ExtendedResourcesUtil {
+		if util > utilInfo.Utilization {
+			utilInfo.ResourceName = resourceName
+			utilInfo.Utilization = util
+		}
+	}
+	return utilInfo
+}
+
+func isCpuMemUtilization(utilInfo Info) bool {
+	if utilInfo.ResourceName == apiv1.ResourceCPU || utilInfo.ResourceName == apiv1.ResourceMemory {
+		return true
+	}
+	_, found := utilInfo.ExtendedResourcesUtil[utilInfo.ResourceName]
+	return found
+}
+
+func usageUtilization(node *apiv1.Node, usage apiv1.ResourceList, resourceName apiv1.ResourceName) (float64, bool) {
+	used, found := usage[resourceName]
+	if !found {
+		return 0, false
+	}
+	allocatable, found := node.Status.Allocatable[resourceName]
+	if !found || allocatable.MilliValue() == 0 {
+		return 0, false
+	}
+	return float64(used.MilliValue()) / float64(allocatable.MilliValue()), true
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utilization
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/gpu"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+type fakeUsageProvider map[string]apiv1.ResourceList
+
+func (p fakeUsageProvider) NodeUsage(nodeName string) (apiv1.ResourceList, error) {
+	usage, found := p[nodeName]
+	if !found {
+		return nil, fmt.Errorf("no usage for node %s", nodeName)
+	}
+	return usage, nil
+}
+
+func TestParseSource(t *testing.T) {
+	for _, source := range AvailableSources {
+		got, err := ParseSource(string(source))
+		assert.NoError(t, err)
+		assert.Equal(t, source, got)
+	}
+	_, err := ParseSource("limits")
+	assert.Error(t, err)
+}
+
+func TestApplyUsage(t *testing.T) {
+	node := BuildTestNode("node1", 2000, 2000000)
+	nodeInfo := framework.NewTestNodeInfo(node)
+	usage := fakeUsageProvider{
+		"node1": apiv1.ResourceList{
+			apiv1.ResourceCPU:    *resource.NewMilliQuantity(1200, resource.DecimalSI),
+			apiv1.ResourceMemory: *resource.NewQuantity(100000, resource.DecimalSI),
+		},
+	}
+	requestsInfo := Info{CpuUtil: 0.1, MemUtil: 0.3, Utilization: 0.3, ResourceName: apiv1.ResourceMemory}
+
+	for _, tc := range []struct {
+		testName      string
+		utilInfo      Info
+		usageProvider UsageProvider
+		source        Source
+		wantUtilInfo  Info
+	}{
+		{
+			testName:      "requests source -> unchanged",
+			utilInfo:      requestsInfo,
+			usageProvider: usage,
+			source:        RequestsSource,
+			wantUtilInfo:  requestsInfo,
+		},
+		{
+			testName:     "no usage provider -> unchanged",
+			utilInfo:     requestsInfo,
+			source:       ActualSource,
+			wantUtilInfo: requestsInfo,
+		},
+		{
+			testName:      "actual source -> usage replaces requests",
+			utilInfo:      requestsInfo,
+			usageProvider: usage,
+			source:        ActualSource,
+			wantUtilInfo:  Info{CpuUtil: 0.6, MemUtil: 0.05, Utilization: 0.6, ResourceName: apiv1.ResourceCPU},
+		},
+		{
+			testName:      "max source -> higher of usage and requests",
+			utilInfo:      requestsInfo,
+			usageProvider: usage,
+			source:        MaxSource,
+			wantUtilInfo:  Info{CpuUtil: 0.6, MemUtil: 0.3, Utilization: 0.6, ResourceName: apiv1.ResourceCPU},
+		},
+		{
+			testName:      "extended resources still taken into account",
+			utilInfo:      Info{CpuUtil: 0.1, MemUtil: 0.3, ExtendedResourcesUtil: map[apiv1.ResourceName]float64{"hugepages-2Mi": 0.7}, Utilization: 0.7, ResourceName: "hugepages-2Mi"},
+			usageProvider: usage,
+			source:        ActualSource,
+			wantUtilInfo:  Info{CpuUtil: 0.6, MemUtil: 0.05, ExtendedResourcesUtil: map[apiv1.ResourceName]float64{"hugepages-2Mi": 0.7}, Utilization: 0.7, ResourceName: "hugepages-2Mi"},
+		},
+		{
+			testName:      "GPU utilization -> unchanged",
+			utilInfo:      Info{GpuUtil: 0.2, Utilization: 0.2, ResourceName: gpu.ResourceNvidiaGPU},
+			usageProvider: usage,
+			source:        ActualSource,
+			wantUtilInfo:  Info{GpuUtil: 0.2, Utilization: 0.2, ResourceName: gpu.ResourceNvidiaGPU},
+		},
+		{
+			testName:      "usage not available -> unchanged",
+			utilInfo:      requestsInfo,
+			usageProvider: fakeUsageProvider{},
+			source:        MaxSource,
+			wantUtilInfo:  requestsInfo,
+		},
+	} {
+		t.Run(tc.testName, func(t *testing.T) {
+			got := ApplyUsage(tc.utilInfo, nodeInfo, tc.usageProvider, tc.source)
+			if diff := cmp.Diff(tc.wantUtilInfo, got, cmpopts.EquateApprox(0, 0.0001)); diff != "" {
+				t.Errorf("ApplyUsage(): unexpected output (-want +got): %s", diff)
+			}
+		})
+	}
+}
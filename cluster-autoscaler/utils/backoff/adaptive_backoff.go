@@ -0,0 +1,178 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backoff
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+)
+
+// adaptiveBackoff backs off node groups for a duration inversely proportional to
+// their health score. The health score is computed from the success rate of the
+// scale-ups of the node group within the health window and from how long it
+// took the nodes of successful scale-ups to become ready. It's safe for concurrent use.
+type adaptiveBackoff struct {
+	mutex                  sync.Mutex
+	initialBackoffDuration time.Duration
+	maxBackoffDuration     time.Duration
+	healthWindow           time.Duration
+	targetReadinessLatency time.Duration
+	nodeGroups             map[string]*nodeGroupHealth
+}
+
+type nodeGroupHealth struct {
+	outcomes     []scaleUpOutcome
+	backoffUntil time.Time
+	errorInfo    cloudprovider.InstanceErrorInfo
+}
+
+type scaleUpOutcome struct {
+	time             time.Time
+	success          bool
+	readinessLatency time.Duration
+}
+
+// NewIdBasedAdaptiveBackoff creates an instance of adaptive backoff with node group Id used as a key.
+// Scale-up outcomes older than healthWindow don't affect the health score. Successful scale-ups whose
+// nodes became ready slower than targetReadinessLatency lower the health score; zero disables that.
+func NewIdBasedAdaptiveBackoff(initialBackoffDuration, maxBackoffDuration, healthWindow, targetReadinessLatency time.Duration) HealthScorer {
+	return &adaptiveBackoff{
+		initialBackoffDuration: initialBackoffDuration,
+		maxBackoffDuration:     maxBackoffDuration,
+		healthWindow:           healthWindow,
+		targetReadinessLatency: targetReadinessLatency,
+		nodeGroups:             make(map[string]*nodeGroupHealth),
+	}
+}
+
+// Backoff execution for the given node group. Returns time till execution is backed off.
+func (b *adaptiveBackoff) Backoff(nodeGroup cloudprovider.NodeGroup, nodeInfo *framework.NodeInfo, errorInfo cloudprovider.InstanceErrorInfo, currentTime time.Time) time.Time {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	health := b.nodeGroupHealth(nodeGroup.Id())
+	health.outcomes = append(health.outcomes, scaleUpOutcome{time: currentTime, success: false})
+	duration := time.Duration(float64(b.initialBackoffDuration) / b.score(health, currentTime))
+	if duration > b.maxBackoffDuration {
+		duration = b.maxBackoffDuration
+	}
+	// Multiple concurrent scale-ups failing shouldn't shorten the backoff.
+	if backoffUntil := currentTime.Add(duration); backoffUntil.After(health.backoffUntil) {
+		health.backoffUntil = backoffUntil
+	}
+	health.errorInfo = errorInfo
+	return health.backoffUntil
+}
+
+// BackoffStatus returns whether the execution is backed off for the given node group and error info when the node group is backed off.
+func (b *adaptiveBackoff) BackoffStatus(nodeGroup cloudprovider.NodeGroup, nodeInfo *framework.NodeInfo, currentTime time.Time) Status {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	health, found := b.nodeGroups[nodeGroup.Id()]
+	if !found || health.backoffUntil.Before(currentTime) {
+		return Status{IsBackedOff: false}
+	}
+	return Status{
+		IsBackedOff:  true,
+		ErrorInfo:    health.errorInfo,
+		BackoffUntil: health.backoffUntil,
+	}
+}
+
+// RemoveBackoff removes backoff data for the given node group.
+func (b *adaptiveBackoff) RemoveBackoff(nodeGroup cloudprovider.NodeGroup, nodeInfo *framework.NodeInfo) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	delete(b.nodeGroups, nodeGroup.Id())
+}
+
+// RemoveStaleBackoffData removes scale-up outcomes older than the health window.
+func (b *adaptiveBackoff) RemoveStaleBackoffData(currentTime time.Time) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for key, health := range b.nodeGroups {
+		b.removeStaleOutcomes(health, currentTime)
+		if len(health.outcomes) == 0 && health.backoffUntil.Before(currentTime) {
+			delete(b.nodeGroups, key)
+		}
+	}
+}
+
+// RegisterScaleUpSuccess records a successful scale-up of the given node group whose nodes became ready after readinessLatency.
+func (b *adaptiveBackoff) RegisterScaleUpSuccess(nodeGroup cloudprovider.NodeGroup, nodeInfo *framework.NodeInfo, readinessLatency time.Duration, currentTime time.Time) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	health := b.nodeGroupHealth(nodeGroup.Id())
+	health.outcomes = append(health.outcomes, scaleUpOutcome{time: currentTime, success: true, readinessLatency: readinessLatency})
+}
+
+// HealthScore returns the health score of the given node group, from 0 (unhealthy) to 1 (healthy).
+// Node groups without scale-ups within the health window are healthy.
+func (b *adaptiveBackoff) HealthScore(nodeGroup cloudprovider.NodeGroup, nodeInfo *framework.NodeInfo, currentTime time.Time) float64 {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	health, found := b.nodeGroups[nodeGroup.Id()]
+	if !found {
+		return 1
+	}
+	return b.score(health, currentTime)
+}
+
+func (b *adaptiveBackoff) nodeGroupHealth(key string) *nodeGroupHealth {
+	health, found := b.nodeGroups[key]
+	if !found {
+		health = &nodeGroupHealth{}
+		b.nodeGroups[key] = health
+	}
+	return health
+}
+
+func (b *adaptiveBackoff) removeStaleOutcomes(health *nodeGroupHealth, currentTime time.Time) {
+	windowStart := currentTime.Add(-b.healthWindow)
+	i := 0
+	for i < len(health.outcomes) && health.outcomes[i].time.Before(windowStart) {
+		i++
+	}
+	health.outcomes = health.outcomes[i:]
+}
+
+// score is the success rate of the scale-ups within the health window, counting
+// one extra success so that a single failure doesn't make the node group fully
+// unhealthy, multiplied by how much the average readiness latency of successful
+// scale-ups exceeds the target. It is always greater than 0.
+func (b *adaptiveBackoff) score(health *nodeGroupHealth, currentTime time.Time) float64 {
+	b.removeStaleOutcomes(health, currentTime)
+	successes, failures := 0, 0
+	var totalLatency time.Duration
+	for _, outcome := range health.outcomes {
+		if outcome.success {
+			successes++
+			totalLatency += outcome.readinessLatency
+		} else {
+			failures++
+		}
+	}
+	score := float64(successes+1) / float64(successes+failures+1)
+	if successes > 0 && b.targetReadinessLatency > 0 {
+		if averageLatency := totalLatency / time.Duration(successes); averageLatency > b.targetReadinessLatency {
+			score *= float64(b.targetReadinessLatency) / float64(averageLatency)
+		}
+	}
+	return score
+}
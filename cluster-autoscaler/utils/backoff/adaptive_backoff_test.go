@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backoff
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdaptiveBackoff(t *testing.T) {
+	backoff := NewIdBasedAdaptiveBackoff(10*time.Minute, time.Hour, time.Hour, 5*time.Minute)
+	startTime := time.Now()
+	assert.Equal(t, noBackOff, backoff.BackoffStatus(nodeGroup1, nil, startTime))
+	assert.Equal(t, 1.0, backoff.HealthScore(nodeGroup1, nil, startTime))
+
+	// First failure halves the health score and doubles the initial backoff.
+	backoff.Backoff(nodeGroup1, nil, quotaError, startTime)
+	assert.Equal(t, backoffWithQuotaError(startTime.Add(20*time.Minute)), backoff.BackoffStatus(nodeGroup1, nil, startTime.Add(time.Minute)))
+	assert.Equal(t, 0.5, backoff.HealthScore(nodeGroup1, nil, startTime.Add(time.Minute)))
+	assert.Equal(t, noBackOff, backoff.BackoffStatus(nodeGroup1, nil, startTime.Add(20*time.Minute+time.Millisecond)))
+	assert.Equal(t, noBackOff, backoff.BackoffStatus(nodeGroup2, nil, startTime.Add(time.Minute)))
+	assert.Equal(t, 1.0, backoff.HealthScore(nodeGroup2, nil, startTime.Add(time.Minute)))
+
+	// Successful scale-ups slower than the target readiness latency lower the health score.
+	backoff.RegisterScaleUpSuccess(nodeGroup1, nil, 10*time.Minute, startTime.Add(30*time.Minute))
+	assert.InDelta(t, 1.0/3, backoff.HealthScore(nodeGroup1, nil, startTime.Add(30*time.Minute)), 1e-9)
+	backoff.RegisterScaleUpSuccess(nodeGroup1, nil, 5*time.Minute, startTime.Add(40*time.Minute))
+	assert.InDelta(t, 0.5, backoff.HealthScore(nodeGroup1, nil, startTime.Add(40*time.Minute)), 1e-9)
+
+	backoff.Backoff(nodeGroup1, nil, ipSpaceExhaustedError, startTime.Add(50*time.Minute))
+	assert.Equal(t, backoffWithIpSpaceExhaustedError(startTime.Add(75*time.Minute)), backoff.BackoffStatus(nodeGroup1, nil, startTime.Add(50*time.Minute)))
+
+	// Outcomes outside of the health window are forgotten.
+	assert.InDelta(t, 0.5, backoff.HealthScore(nodeGroup1, nil, startTime.Add(65*time.Minute)), 1e-9)
+	backoff.RemoveStaleBackoffData(startTime.Add(3 * time.Hour))
+	assert.Equal(t, 1.0, backoff.HealthScore(nodeGroup1, nil, startTime.Add(3*time.Hour)))
+}
+
+func TestAdaptiveBackoffMaxDuration(t *testing.T) {
+	backoff := NewIdBasedAdaptiveBackoff(10*time.Minute, time.Hour, time.Hour, 0)
+	startTime := time.Now()
+	for i := 0; i < 7; i++ {
+		backoff.Backoff(nodeGroup1, nil, quotaError, startTime)
+	}
+	assert.Equal(t, backoffWithQuotaError(startTime.Add(time.Hour)), backoff.BackoffStatus(nodeGroup1, nil, startTime))
+	assert.InDelta(t, 1.0/8, backoff.HealthScore(nodeGroup1, nil, startTime), 1e-9)
+
+	// Slow scale-ups don't lower the health score without a target readiness latency.
+	backoff.RegisterScaleUpSuccess(nodeGroup2, nil, time.Hour, startTime)
+	assert.Equal(t, 1.0, backoff.HealthScore(nodeGroup2, nil, startTime))
+
+	backoff.RemoveBackoff(nodeGroup1, nil)
+	assert.Equal(t, noBackOff, backoff.BackoffStatus(nodeGroup1, nil, startTime))
+	assert.Equal(t, 1.0, backoff.HealthScore(nodeGroup1, nil, startTime))
+}
+
+func TestAdaptiveBackoffConcurrentHealthScore(t *testing.T) {
+	backoff := NewIdBasedAdaptiveBackoff(10*time.Minute, time.Hour, time.Hour, 0)
+	startTime := time.Now()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			backoff.HealthScore(nodeGroup1, nil, startTime.Add(time.Duration(i)*time.Minute))
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		backoff.Backoff(nodeGroup1, nil, quotaError, startTime.Add(time.Duration(i)*time.Minute))
+	}
+	wg.Wait()
+	assert.Less(t, backoff.HealthScore(nodeGroup1, nil, startTime.Add(100*time.Minute)), 1.0)
+}
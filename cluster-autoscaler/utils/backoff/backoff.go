@@ -27,6 +27,8 @@ import (
 type Status struct {
 	IsBackedOff bool
 	ErrorInfo   cloudprovider.InstanceErrorInfo
+	// BackoffUntil is the time the back off ends at. Only set if IsBackedOff is true.
+	BackoffUntil time.Time
 }
 
 // Backoff allows time-based backing off of node groups considered in scale up algorithm
@@ -40,3 +42,20 @@ type Backoff interface {
 	// RemoveStaleBackoffData removes stale backoff data.
 	RemoveStaleBackoffData(currentTime time.Time)
 }
+
+// HealthScorer is a Backoff which also scores the health of node groups based on
+// the outcomes of their recent scale-ups.
+type HealthScorer interface {
+	Backoff
+	// RegisterScaleUpSuccess records a successful scale-up of the given node group whose nodes became ready after readinessLatency.
+	RegisterScaleUpSuccess(nodeGroup cloudprovider.NodeGroup, nodeInfo *framework.NodeInfo, readinessLatency time.Duration, currentTime time.Time)
+	// HealthScore returns the health score of the given node group, from 0 (unhealthy) to 1 (healthy).
+	HealthScore(nodeGroup cloudprovider.NodeGroup, nodeInfo *framework.NodeInfo, currentTime time.Time) float64
+}
+
+const (
+	// ExponentialBackoffPolicy doubles the backoff duration of a node group on every consecutive failure.
+	ExponentialBackoffPolicy = "exponential"
+	// AdaptiveBackoffPolicy derives the backoff duration of a node group from its health score.
+	AdaptiveBackoffPolicy = "adaptive"
+)
@@ -99,8 +99,9 @@ func (b *exponentialBackoff) BackoffStatus(nodeGroup cloudprovider.NodeGroup, no
 		return Status{IsBackedOff: false}
 	}
 	return Status{
-		IsBackedOff: true,
-		ErrorInfo:   backoffInfo.errorInfo,
+		IsBackedOff:  true,
+		ErrorInfo:    backoffInfo.errorInfo,
+		BackoffUntil: backoffInfo.backoffUntil,
 	}
 }
 
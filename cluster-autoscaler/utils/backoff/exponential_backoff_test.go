@@ -39,13 +39,21 @@ var quotaError = cloudprovider.InstanceErrorInfo{ErrorClass: cloudprovider.OutOf
 var ipSpaceExhaustedError = cloudprovider.InstanceErrorInfo{ErrorClass: cloudprovider.OtherErrorClass, ErrorCode: "IP_SPACE_EXHAUSTED", ErrorMessage: "IP space has been exhausted"}
 
 var noBackOff = Status{IsBackedOff: false}
-var backoffWithQuotaError = Status{
-	IsBackedOff: true,
-	ErrorInfo:   quotaError,
+
+func backoffWithQuotaError(until time.Time) Status {
+	return Status{
+		IsBackedOff:  true,
+		ErrorInfo:    quotaError,
+		BackoffUntil: until,
+	}
 }
-var backoffWithIpSpaceExhaustedError = Status{
-	IsBackedOff: true,
-	ErrorInfo:   ipSpaceExhaustedError,
+
+func backoffWithIpSpaceExhaustedError(until time.Time) Status {
+	return Status{
+		IsBackedOff:  true,
+		ErrorInfo:    ipSpaceExhaustedError,
+		BackoffUntil: until,
+	}
 }
 
 func TestBackoffTwoKeys(t *testing.T) {
@@ -54,7 +62,7 @@ func TestBackoffTwoKeys(t *testing.T) {
 	assert.Equal(t, noBackOff, backoff.BackoffStatus(nodeGroup1, nil, startTime))
 	assert.Equal(t, noBackOff, backoff.BackoffStatus(nodeGroup2, nil, startTime))
 	backoff.Backoff(nodeGroup1, nil, quotaError, startTime.Add(time.Minute))
-	assert.Equal(t, backoffWithQuotaError, backoff.BackoffStatus(nodeGroup1, nil, startTime.Add(2*time.Minute)))
+	assert.Equal(t, backoffWithQuotaError(startTime.Add(11*time.Minute)), backoff.BackoffStatus(nodeGroup1, nil, startTime.Add(2*time.Minute)))
 	assert.Equal(t, noBackOff, backoff.BackoffStatus(nodeGroup2, nil, startTime))
 	assert.Equal(t, noBackOff, backoff.BackoffStatus(nodeGroup1, nil, startTime.Add(11*time.Minute+1*time.Millisecond)))
 }
@@ -63,13 +71,13 @@ func TestMaxBackoff(t *testing.T) {
 	backoff := NewIdBasedExponentialBackoff(1*time.Minute, 3*time.Minute, 3*time.Hour)
 	startTime := time.Now()
 	backoff.Backoff(nodeGroup1, nil, ipSpaceExhaustedError, startTime)
-	assert.Equal(t, backoffWithIpSpaceExhaustedError, backoff.BackoffStatus(nodeGroup1, nil, startTime))
+	assert.Equal(t, backoffWithIpSpaceExhaustedError(startTime.Add(1*time.Minute)), backoff.BackoffStatus(nodeGroup1, nil, startTime))
 	assert.Equal(t, noBackOff, backoff.BackoffStatus(nodeGroup1, nil, startTime.Add(1*time.Minute+1*time.Millisecond)))
 	backoff.Backoff(nodeGroup1, nil, ipSpaceExhaustedError, startTime.Add(1*time.Minute))
-	assert.Equal(t, backoffWithIpSpaceExhaustedError, backoff.BackoffStatus(nodeGroup1, nil, startTime.Add(1*time.Minute)))
+	assert.Equal(t, backoffWithIpSpaceExhaustedError(startTime.Add(2*time.Minute)), backoff.BackoffStatus(nodeGroup1, nil, startTime.Add(1*time.Minute)))
 	assert.Equal(t, noBackOff, backoff.BackoffStatus(nodeGroup1, nil, startTime.Add(3*time.Minute)))
 	backoff.Backoff(nodeGroup1, nil, ipSpaceExhaustedError, startTime.Add(3*time.Minute))
-	assert.Equal(t, backoffWithIpSpaceExhaustedError, backoff.BackoffStatus(nodeGroup1, nil, startTime.Add(3*time.Minute)))
+	assert.Equal(t, backoffWithIpSpaceExhaustedError(startTime.Add(5*time.Minute)), backoff.BackoffStatus(nodeGroup1, nil, startTime.Add(3*time.Minute)))
 	assert.Equal(t, noBackOff, backoff.BackoffStatus(nodeGroup1, nil, startTime.Add(6*time.Minute)))
 }
 
@@ -77,7 +85,7 @@ func TestRemoveBackoff(t *testing.T) {
 	backoff := NewIdBasedExponentialBackoff(1*time.Minute, 3*time.Minute, 3*time.Hour)
 	startTime := time.Now()
 	backoff.Backoff(nodeGroup1, nil, quotaError, startTime)
-	assert.Equal(t, backoffWithQuotaError, backoff.BackoffStatus(nodeGroup1, nil, startTime))
+	assert.Equal(t, backoffWithQuotaError(startTime.Add(1*time.Minute)), backoff.BackoffStatus(nodeGroup1, nil, startTime))
 	backoff.RemoveBackoff(nodeGroup1, nil)
 	assert.Equal(t, noBackOff, backoff.BackoffStatus(nodeGroup1, nil, startTime))
 }
@@ -100,18 +108,18 @@ func TestIncreaseExistingBackoff(t *testing.T) {
 	currentTime := time.Date(2023, 12, 12, 12, 0, 0, 0, time.UTC)
 	backoff.Backoff(nodeGroup1, nil, quotaError, currentTime)
 	// NG in backoff for one second here
-	assert.Equal(t, backoffWithQuotaError, backoff.BackoffStatus(nodeGroup1, nil, currentTime))
+	assert.Equal(t, backoffWithQuotaError(currentTime.Add(1*time.Second)), backoff.BackoffStatus(nodeGroup1, nil, currentTime))
 	// Come out of backoff
 	currentTime = currentTime.Add(1*time.Second + 1*time.Millisecond)
 	assert.Equal(t, noBackOff, backoff.BackoffStatus(nodeGroup1, nil, currentTime))
 	// Confirm existing backoff duration and error info have been increased by backing off again
 	backoff.Backoff(nodeGroup1, nil, ipSpaceExhaustedError, currentTime)
 	// Backoff should be for 2 seconds now
-	assert.Equal(t, backoffWithIpSpaceExhaustedError, backoff.BackoffStatus(nodeGroup1, nil, currentTime))
+	assert.Equal(t, backoffWithIpSpaceExhaustedError(currentTime.Add(2*time.Second)), backoff.BackoffStatus(nodeGroup1, nil, currentTime))
 	currentTime = currentTime.Add(1 * time.Second)
 	// Doing backoff during existing backoff should change error info and backoff end period but doesn't change the duration.
 	backoff.Backoff(nodeGroup1, nil, quotaError, currentTime)
-	assert.Equal(t, backoffWithQuotaError, backoff.BackoffStatus(nodeGroup1, nil, currentTime))
+	assert.Equal(t, backoffWithQuotaError(currentTime.Add(2*time.Second)), backoff.BackoffStatus(nodeGroup1, nil, currentTime))
 	currentTime = currentTime.Add(2*time.Second + 1*time.Millisecond)
 	assert.Equal(t, noBackOff, backoff.BackoffStatus(nodeGroup1, nil, currentTime))
 	// Result: existing backoff duration was scaled up beyond initial duration
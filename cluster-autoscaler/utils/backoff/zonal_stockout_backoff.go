@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backoff
+
+import (
+	"fmt"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/metrics"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+	klog "k8s.io/klog/v2"
+)
+
+// zonalStockoutBackoff backs off, on top of the wrapped backoff, all node groups of a zone and
+// instance type that stocked out in one of them. Node groups of the same instance type in other
+// zones aren't affected, so scale-ups go there instead of failing in every node group of the
+// stocked out zone one after another.
+type zonalStockoutBackoff struct {
+	backoff   Backoff
+	cooldown  time.Duration
+	stockouts map[zonalStockoutKey]zonalStockout
+}
+
+type zonalStockoutKey struct {
+	zone         string
+	instanceType string
+}
+
+type zonalStockout struct {
+	until     time.Time
+	nodeGroup string
+	errorInfo cloudprovider.InstanceErrorInfo
+}
+
+// zonalStockoutHealthScorer is a zonalStockoutBackoff wrapping a HealthScorer.
+type zonalStockoutHealthScorer struct {
+	*zonalStockoutBackoff
+	healthScorer HealthScorer
+}
+
+// NewZonalStockoutBackoff wraps the backoff so that a stockout in a node group backs off all node
+// groups with the same zone and instance type for the cooldown. Node groups whose template nodes
+// have no zone label are only backed off by the wrapped backoff. The returned backoff is a
+// HealthScorer if the wrapped one is.
+func NewZonalStockoutBackoff(backoff Backoff, cooldown time.Duration) Backoff {
+	b := &zonalStockoutBackoff{
+		backoff:   backoff,
+		cooldown:  cooldown,
+		stockouts: make(map[zonalStockoutKey]zonalStockout),
+	}
+	if healthScorer, ok := backoff.(HealthScorer); ok {
+		return &zonalStockoutHealthScorer{zonalStockoutBackoff: b, healthScorer: healthScorer}
+	}
+	return b
+}
+
+func zonalStockoutKeyOf(nodeInfo *framework.NodeInfo) (zonalStockoutKey, bool) {
+	if nodeInfo == nil || nodeInfo.Node() == nil {
+		return zonalStockoutKey{}, false
+	}
+	labels := nodeInfo.Node().Labels
+	zone := labels[apiv1.LabelTopologyZone]
+	if zone == "" {
+		zone = labels[apiv1.LabelFailureDomainBetaZone]
+	}
+	if zone == "" {
+		return zonalStockoutKey{}, false
+	}
+	instanceType := labels[apiv1.LabelInstanceTypeStable]
+	if instanceType == "" {
+		instanceType = labels[apiv1.LabelInstanceType]
+	}
+	return zonalStockoutKey{zone: zone, instanceType: instanceType}, true
+}
+
+// Backoff execution for the given node group. Stockouts are also recorded for the zone and
+// instance type of the node group. Returns time till execution is backed off.
+func (b *zonalStockoutBackoff) Backoff(nodeGroup cloudprovider.NodeGroup, nodeInfo *framework.NodeInfo, errorInfo cloudprovider.InstanceErrorInfo, currentTime time.Time) time.Time {
+	backoffUntil := b.backoff.Backoff(nodeGroup, nodeInfo, errorInfo, currentTime)
+	if cloudprovider.ScaleUpFailureCategoryOf(errorInfo) != cloudprovider.ScaleUpFailureStockout {
+		return backoffUntil
+	}
+	key, found := zonalStockoutKeyOf(nodeInfo)
+	if !found {
+		return backoffUntil
+	}
+	until := currentTime.Add(b.cooldown)
+	if existing, found := b.stockouts[key]; found && existing.until.After(until) {
+		until = existing.until
+	}
+	klog.Warningf("Zone %s is stocked out of instance type %q until %v, reported by node group %s: %s", key.zone, key.instanceType, until, nodeGroup.Id(), errorInfo.ErrorMessage)
+	b.stockouts[key] = zonalStockout{until: until, nodeGroup: nodeGroup.Id(), errorInfo: errorInfo}
+	metrics.RegisterZonalStockout(key.zone, key.instanceType, until)
+	return backoffUntil
+}
+
+// BackoffStatus returns whether the execution is backed off for the given node group, either by
+// the wrapped backoff or by a stockout of its zone and instance type, and the error info when it is.
+func (b *zonalStockoutBackoff) BackoffStatus(nodeGroup cloudprovider.NodeGroup, nodeInfo *framework.NodeInfo, currentTime time.Time) Status {
+	status := b.backoff.BackoffStatus(nodeGroup, nodeInfo, currentTime)
+	if status.IsBackedOff {
+		return status
+	}
+	key, found := zonalStockoutKeyOf(nodeInfo)
+	if !found {
+		return status
+	}
+	stockout, found := b.stockouts[key]
+	if !found || !stockout.until.After(currentTime) {
+		return status
+	}
+	return Status{
+		IsBackedOff: true,
+		ErrorInfo: cloudprovider.InstanceErrorInfo{
+			ErrorClass: cloudprovider.OutOfResourcesErrorClass,
+			ErrorCode:  stockout.errorInfo.ErrorCode,
+			ErrorMessage: fmt.Sprintf("zone %s is stocked out of instance type %q, reported by node group %s: %s",
+				key.zone, key.instanceType, stockout.nodeGroup, stockout.errorInfo.ErrorMessage),
+		},
+		BackoffUntil: stockout.until,
+	}
+}
+
+// RemoveBackoff removes backoff data for the given node group. Stockouts of its zone and instance
+// type are kept until their cooldown ends.
+func (b *zonalStockoutBackoff) RemoveBackoff(nodeGroup cloudprovider.NodeGroup, nodeInfo *framework.NodeInfo) {
+	b.backoff.RemoveBackoff(nodeGroup, nodeInfo)
+}
+
+// RemoveStaleBackoffData removes stale backoff data, including stockouts past their cooldown.
+func (b *zonalStockoutBackoff) RemoveStaleBackoffData(currentTime time.Time) {
+	b.backoff.RemoveStaleBackoffData(currentTime)
+	for key, stockout := range b.stockouts {
+		if !stockout.until.After(currentTime) {
+			klog.V(1).Infof("Zone %s is no longer considered stocked out of instance type %q", key.zone, key.instanceType)
+			delete(b.stockouts, key)
+			metrics.RemoveZonalStockout(key.zone, key.instanceType)
+		}
+	}
+}
+
+// RegisterScaleUpSuccess records a successful scale-up in the wrapped HealthScorer.
+func (b *zonalStockoutHealthScorer) RegisterScaleUpSuccess(nodeGroup cloudprovider.NodeGroup, nodeInfo *framework.NodeInfo, readinessLatency time.Duration, currentTime time.Time) {
+	b.healthScorer.RegisterScaleUpSuccess(nodeGroup, nodeInfo, readinessLatency, currentTime)
+}
+
+// HealthScore returns the health score of the wrapped HealthScorer.
+func (b *zonalStockoutHealthScorer) HealthScore(nodeGroup cloudprovider.NodeGroup, nodeInfo *framework.NodeInfo, currentTime time.Time) float64 {
+	return b.healthScorer.HealthScore(nodeGroup, nodeInfo, currentTime)
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backoff
+
+import (
+	"testing"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func zonalNodeInfo(zone, instanceType string) *framework.NodeInfo {
+	labels := map[string]string{apiv1.LabelInstanceTypeStable: instanceType}
+	if zone != "" {
+		labels[apiv1.LabelTopologyZone] = zone
+	}
+	return framework.NewTestNodeInfo(&apiv1.Node{ObjectMeta: metav1.ObjectMeta{Name: "template", Labels: labels}})
+}
+
+var stockoutError = cloudprovider.InstanceErrorInfo{ErrorClass: cloudprovider.OutOfResourcesErrorClass, ErrorCode: "RESOURCE_POOL_EXHAUSTED", ErrorMessage: "No capacity"}
+
+func TestZonalStockoutBackoff(t *testing.T) {
+	backoff := NewZonalStockoutBackoff(NewIdBasedExponentialBackoff(10*time.Minute, time.Hour, 3*time.Hour), 30*time.Minute)
+	nodeGroup3 := nodeGroup("id3")
+	nodeGroup4 := nodeGroup("id4")
+	zoneA := zonalNodeInfo("zone-a", "n1")
+	zoneAOtherType := zonalNodeInfo("zone-a", "n2")
+	zoneB := zonalNodeInfo("zone-b", "n1")
+	noZone := zonalNodeInfo("", "n1")
+	now := time.Now()
+
+	// Other failures only back off the failing node group.
+	backoff.Backoff(nodeGroup1, zoneA, quotaError, now)
+	assert.True(t, backoff.BackoffStatus(nodeGroup1, zoneA, now).IsBackedOff)
+	assert.Equal(t, noBackOff, backoff.BackoffStatus(nodeGroup2, zoneA, now))
+
+	// Stockouts back off node groups of the same zone and instance type.
+	backoff.Backoff(nodeGroup2, zoneA, stockoutError, now)
+	status := backoff.BackoffStatus(nodeGroup3, zoneA, now.Add(20*time.Minute))
+	assert.True(t, status.IsBackedOff)
+	assert.Equal(t, now.Add(30*time.Minute), status.BackoffUntil)
+	assert.Equal(t, "RESOURCE_POOL_EXHAUSTED", status.ErrorInfo.ErrorCode)
+	assert.Equal(t, noBackOff, backoff.BackoffStatus(nodeGroup3, zoneAOtherType, now))
+	assert.Equal(t, noBackOff, backoff.BackoffStatus(nodeGroup3, zoneB, now))
+	assert.Equal(t, noBackOff, backoff.BackoffStatus(nodeGroup3, noZone, now))
+	assert.Equal(t, noBackOff, backoff.BackoffStatus(nodeGroup3, nil, now))
+
+	// Stockouts of node groups without a zone aren't recorded for zones.
+	backoff.Backoff(nodeGroup4, noZone, stockoutError, now)
+	assert.Equal(t, noBackOff, backoff.BackoffStatus(nodeGroup3, zoneB, now))
+
+	// Stockouts end with the cooldown.
+	backoff.RemoveStaleBackoffData(now.Add(30 * time.Minute))
+	assert.Equal(t, noBackOff, backoff.BackoffStatus(nodeGroup3, zoneA, now.Add(30*time.Minute)))
+}
+
+func TestZonalStockoutBackoffKeepsHealthScorer(t *testing.T) {
+	_, ok := NewZonalStockoutBackoff(NewIdBasedAdaptiveBackoff(time.Minute, time.Hour, time.Hour, 0), time.Minute).(HealthScorer)
+	assert.True(t, ok)
+	_, ok = NewZonalStockoutBackoff(NewIdBasedExponentialBackoff(time.Minute, time.Hour, time.Hour), time.Minute).(HealthScorer)
+	assert.False(t, ok)
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package basicauth authenticates requests to the HTTP endpoints served by
+// Cluster Autoscaler with HTTP basic auth.
+package basicauth
+
+import (
+	"bufio"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Authenticated returns true if the request carries basic auth credentials
+// matching one of the given passwords by user name.
+func Authenticated(r *http.Request, credentials map[string]string) bool {
+	user, password, ok := r.BasicAuth()
+	if !ok {
+		return false
+	}
+	expected, found := credentials[user]
+	// The comparison is made for unknown users too, not to reveal which users exist.
+	match := subtle.ConstantTimeCompare([]byte(password), []byte(expected)) == 1
+	return found && match
+}
+
+// Unauthorized replies to a request that failed to authenticate.
+func Unauthorized(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", `Basic realm="cluster-autoscaler", charset="UTF-8"`)
+	http.Error(w, "unauthorized", http.StatusUnauthorized)
+}
+
+// LoadCredentials reads basic auth credentials from a file with a <user>:<password>
+// pair per line. Empty lines and lines starting with # are skipped.
+func LoadCredentials(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	credentials := map[string]string{}
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		user, password, found := strings.Cut(line, ":")
+		if !found || user == "" || password == "" {
+			return nil, fmt.Errorf("line %d of %s isn't in the format <user>:<password>", lineNumber, path)
+		}
+		credentials[user] = password
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(credentials) == 0 {
+		return nil, fmt.Errorf("no credentials in %s", path)
+	}
+	return credentials, nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package basicauth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthenticated(t *testing.T) {
+	credentials := map[string]string{"admin": "secret"}
+	testCases := []struct {
+		name     string
+		user     string
+		password string
+		want     bool
+	}{
+		{name: "no credentials"},
+		{name: "wrong password", user: "admin", password: "wrong"},
+		{name: "unknown user", user: "other", password: "secret"},
+		{name: "valid", user: "admin", password: "secret", want: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.user != "" {
+				req.SetBasicAuth(tc.user, tc.password)
+			}
+			assert.Equal(t, tc.want, Authenticated(req, credentials))
+		})
+	}
+}
+
+func TestLoadCredentials(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			content: "# comment\nadmin:secret\n\nviewer:pass:word\n",
+			want:    map[string]string{"admin": "secret", "viewer": "pass:word"},
+		},
+		{
+			name:    "missing password",
+			content: "admin\n",
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			content: "# comment\n",
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "credentials")
+			assert.NoError(t, os.WriteFile(path, []byte(tc.content), 0600))
+			credentials, err := LoadCredentials(path)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, credentials)
+		})
+	}
+	_, err := LoadCredentials(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
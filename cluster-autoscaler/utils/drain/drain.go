@@ -23,6 +23,7 @@ import (
 
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klog "k8s.io/klog/v2"
 )
 
 const (
@@ -36,6 +37,17 @@ const (
 	PodSafeToEvictKey = "cluster-autoscaler.kubernetes.io/safe-to-evict"
 	// SafeToEvictLocalVolumesKey - annotation that ignores (doesn't block on) a local storage volume during node scale down
 	SafeToEvictLocalVolumesKey = "cluster-autoscaler.kubernetes.io/safe-to-evict-local-volumes"
+	// PodForceDeleteAfterKey - annotation with the time after which the pod is force deleted if it's still terminating
+	// after being evicted during a node drain. Overrides the --force-delete-pods-after flag, "0s" disables it for the pod.
+	PodForceDeleteAfterKey = "cluster-autoscaler.kubernetes.io/force-delete-after"
+	// LocalDataTTLKey - annotation with the time for which the local storage volumes of a pod have to be preserved after
+	// it starts. The pod blocks scale down until the time passes and doesn't block it afterwards, regardless of the
+	// --skip-nodes-with-local-storage flag.
+	LocalDataTTLKey = "cluster-autoscaler.kubernetes.io/local-data-ttl"
+	// PodSafeToEvictForKey - annotation with the time for which a pod has to run before it can be evicted, e.g. until
+	// a batch job checkpoints its progress. The pod blocks scale down until the time passes, and is safe to evict
+	// afterwards, as if annotated with PodSafeToEvictKey. PodSafeToEvictKey takes precedence over it.
+	PodSafeToEvictForKey = "cluster-autoscaler.kubernetes.io/safe-to-evict-for"
 )
 
 // BlockingPod represents a pod which is blocking the scale down of a node.
@@ -67,6 +79,16 @@ const (
 	NotEnoughPdb
 	// UnexpectedError - pod is blocking scale down because of an unexpected error.
 	UnexpectedError
+	// VolumeAttachLimitReached - pod is blocking scale down because its volumes can't be attached to any other node due to CSI volume attach limits.
+	VolumeAttachLimitReached
+	// BlockedByWebhook - pod is blocking scale down because the drainability webhook doesn't allow draining its node.
+	BlockedByWebhook
+	// ResourceClaimNotReallocatable - pod is blocking scale down because it shares a node-local ResourceClaim with consumers outside of the node.
+	ResourceClaimNotReallocatable
+	// LocalDataTTLNotExpired - pod is blocking scale down because the data in its local storage has to be preserved for longer.
+	LocalDataTTLNotExpired
+	// NotSafeToEvictYet - pod is blocking scale down because it hasn't run for the time in its "safe to evict for" annotation yet.
+	NotSafeToEvictYet
 )
 
 func (e BlockingPodReason) String() string {
@@ -89,6 +111,16 @@ func (e BlockingPodReason) String() string {
 		return "NotEnoughPdb"
 	case UnexpectedError:
 		return "UnexpectedError"
+	case VolumeAttachLimitReached:
+		return "VolumeAttachLimitReached"
+	case BlockedByWebhook:
+		return "BlockedByWebhook"
+	case ResourceClaimNotReallocatable:
+		return "ResourceClaimNotReallocatable"
+	case LocalDataTTLNotExpired:
+		return "LocalDataTTLNotExpired"
+	case NotSafeToEvictYet:
+		return "NotSafeToEvictYet"
 	default:
 		return fmt.Sprintf("unrecognized reason: %d", int(e))
 	}
@@ -152,6 +184,25 @@ func HasNotSafeToEvictAnnotation(pod *apiv1.Pod) bool {
 	return pod.GetAnnotations()[PodSafeToEvictKey] == "false"
 }
 
+// SafeToEvictTime returns the time after which the pod is safe to evict, based on its PodSafeToEvictForKey
+// annotation and start time. Returns false if the annotation is missing or invalid.
+func SafeToEvictTime(pod *apiv1.Pod) (time.Time, bool) {
+	value, found := pod.GetAnnotations()[PodSafeToEvictForKey]
+	if !found {
+		return time.Time{}, false
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		klog.Warningf("Ignoring invalid %s annotation %q of pod %s/%s", PodSafeToEvictForKey, value, pod.Namespace, pod.Name)
+		return time.Time{}, false
+	}
+	startTime := pod.CreationTimestamp.Time
+	if pod.Status.StartTime != nil {
+		startTime = pod.Status.StartTime.Time
+	}
+	return startTime.Add(duration), true
+}
+
 // IsPodLongTerminating checks if a pod has been terminating for a long time (pod's terminationGracePeriod + an additional const buffer)
 func IsPodLongTerminating(pod *apiv1.Pod, currentTime time.Time) bool {
 	// pod has not even been deleted
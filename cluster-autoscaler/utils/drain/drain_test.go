@@ -172,8 +172,28 @@ func TestBlockingPodReasonFormatting(t *testing.T) {
 			want: "UnexpectedError",
 		},
 		{
-			bpr:  BlockingPodReason(9),
-			want: "unrecognized reason: 9",
+			bpr:  VolumeAttachLimitReached,
+			want: "VolumeAttachLimitReached",
+		},
+		{
+			bpr:  BlockedByWebhook,
+			want: "BlockedByWebhook",
+		},
+		{
+			bpr:  ResourceClaimNotReallocatable,
+			want: "ResourceClaimNotReallocatable",
+		},
+		{
+			bpr:  LocalDataTTLNotExpired,
+			want: "LocalDataTTLNotExpired",
+		},
+		{
+			bpr:  NotSafeToEvictYet,
+			want: "NotSafeToEvictYet",
+		},
+		{
+			bpr:  BlockingPodReason(14),
+			want: "unrecognized reason: 14",
 		},
 	} {
 		t.Run(tc.want, func(t *testing.T) {
@@ -0,0 +1,46 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/client-go/dynamic/dynamicinformer"
+)
+
+// InformerSyncTimeout bounds the initial sync of informers of optional CRDs, so that a
+// missing CRD or missing RBAC permissions don't block CA startup forever.
+const InformerSyncTimeout = 1 * time.Minute
+
+// StartAndSyncInformers starts the informers of the factory and waits for their initial
+// sync. It returns the channel stopping the informers, or an error if they don't sync
+// within timeout, in which case they are stopped.
+func StartAndSyncInformers(factory dynamicinformer.DynamicSharedInformerFactory, timeout time.Duration) (chan struct{}, error) {
+	stopCh := make(chan struct{})
+	factory.Start(stopCh)
+	syncCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	for _, synced := range factory.WaitForCacheSync(syncCtx.Done()) {
+		if !synced {
+			close(stopCh)
+			return nil, fmt.Errorf("informers didn't sync within %v", timeout)
+		}
+	}
+	return stopCh, nil
+}
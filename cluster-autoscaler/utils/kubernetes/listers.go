@@ -30,6 +30,7 @@ import (
 	v1lister "k8s.io/client-go/listers/core/v1"
 	v1policylister "k8s.io/client-go/listers/policy/v1"
 	"k8s.io/client-go/tools/cache"
+	klog "k8s.io/klog/v2"
 	podv1 "k8s.io/kubernetes/pkg/api/v1/pod"
 )
 
@@ -78,7 +79,14 @@ func NewListerRegistry(allNode NodeLister, readyNode NodeLister, allPodLister Po
 
 // NewListerRegistryWithDefaultListers returns a registry filled with listers of the default implementations
 func NewListerRegistryWithDefaultListers(informerFactory informers.SharedInformerFactory) ListerRegistry {
-	allPodLister := NewAllPodLister(informerFactory.Core().V1().Pods().Lister())
+	var allPodLister PodLister
+	podIndex, err := NewPodIndex(informerFactory.Core().V1().Pods().Informer())
+	if err != nil {
+		klog.Errorf("Failed to create pod index, falling back to listing all pods: %v", err)
+		allPodLister = NewAllPodLister(informerFactory.Core().V1().Pods().Lister())
+	} else {
+		allPodLister = podIndex
+	}
 	readyNodeLister := NewReadyNodeLister(informerFactory.Core().V1().Nodes().Lister())
 	allNodeLister := NewAllNodeLister(informerFactory.Core().V1().Nodes().Lister())
 
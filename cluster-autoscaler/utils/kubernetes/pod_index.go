@@ -0,0 +1,234 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"sync"
+
+	apiv1 "k8s.io/api/core/v1"
+	v1lister "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	klog "k8s.io/klog/v2"
+)
+
+// IndexedPodLister is a PodLister which can also list scheduled and unschedulable pods,
+// without filtering all pods in the cluster.
+type IndexedPodLister interface {
+	PodLister
+	// ScheduledPods returns all pods scheduled on a node.
+	ScheduledPods() ([]*apiv1.Pod, error)
+	// UnschedulablePods returns all pods marked unschedulable by the scheduler.
+	UnschedulablePods() ([]*apiv1.Pod, error)
+	// UnscheduledPods returns all pods which aren't scheduled on a node, including unschedulable ones.
+	UnscheduledPods() ([]*apiv1.Pod, error)
+}
+
+// PodIndex is an IndexedPodLister keeping the pods in sets of scheduled, unscheduled and unschedulable
+// pods, which are updated incrementally on pod informer events. Like AllPodLister, it ignores
+// succeeded and failed pods. Until the index is synced, pods are listed from the informer cache.
+type PodIndex struct {
+	mutex sync.RWMutex
+	// pods contains all pods in the index by their keys.
+	pods          map[string]*apiv1.Pod
+	scheduled     map[string]*apiv1.Pod
+	unscheduled   map[string]*apiv1.Pod
+	unschedulable map[string]*apiv1.Pod
+	// podChangeHandlers are called with the node name on every change of pods scheduled on a node.
+	podChangeHandlers []func(nodeName string)
+
+	fallback PodLister
+	synced   func() bool
+}
+
+// NewPodIndex builds a PodIndex updated from the given pod informer.
+func NewPodIndex(informer cache.SharedIndexInformer) (*PodIndex, error) {
+	index := newPodIndex()
+	index.fallback = NewAllPodLister(v1lister.NewPodLister(informer.GetIndexer()))
+	registration, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			index.update(obj)
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			index.update(obj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			index.delete(obj)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	index.synced = registration.HasSynced
+	return index, nil
+}
+
+func newPodIndex() *PodIndex {
+	return &PodIndex{
+		pods:          make(map[string]*apiv1.Pod),
+		scheduled:     make(map[string]*apiv1.Pod),
+		unscheduled:   make(map[string]*apiv1.Pod),
+		unschedulable: make(map[string]*apiv1.Pod),
+		synced:        func() bool { return true },
+	}
+}
+
+func (i *PodIndex) update(obj interface{}) {
+	pod, ok := obj.(*apiv1.Pod)
+	if !ok {
+		klog.Errorf("Unexpected object %T in pod index", obj)
+		return
+	}
+	key, err := cache.MetaNamespaceKeyFunc(pod)
+	if err != nil {
+		klog.Errorf("Failed to get key of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		return
+	}
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	i.remove(key)
+	if pod.Status.Phase == apiv1.PodSucceeded || pod.Status.Phase == apiv1.PodFailed {
+		return
+	}
+	i.pods[key] = pod
+	if isScheduled(pod) {
+		i.scheduled[key] = pod
+		i.touch(pod.Spec.NodeName)
+		return
+	}
+	i.unscheduled[key] = pod
+	if isUnschedulable(pod) {
+		i.unschedulable[key] = pod
+	}
+}
+
+func (i *PodIndex) delete(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		klog.Errorf("Failed to get key of deleted pod: %v", err)
+		return
+	}
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	i.remove(key)
+}
+
+// remove removes the pod with the given key from all sets. Has to be called with the mutex held.
+func (i *PodIndex) remove(key string) {
+	pod, found := i.pods[key]
+	if !found {
+		return
+	}
+	delete(i.pods, key)
+	if isScheduled(pod) {
+		delete(i.scheduled, key)
+		i.touch(pod.Spec.NodeName)
+		return
+	}
+	delete(i.unscheduled, key)
+	delete(i.unschedulable, key)
+}
+
+// touch notifies the handlers about a change of pods scheduled on the node. Has to be called with the mutex held.
+func (i *PodIndex) touch(nodeName string) {
+	for _, handler := range i.podChangeHandlers {
+		handler(nodeName)
+	}
+}
+
+func podsFrom(podSet map[string]*apiv1.Pod) []*apiv1.Pod {
+	pods := make([]*apiv1.Pod, 0, len(podSet))
+	for _, pod := range podSet {
+		pods = append(pods, pod)
+	}
+	return pods
+}
+
+// List returns all pods.
+func (i *PodIndex) List() ([]*apiv1.Pod, error) {
+	if !i.synced() {
+		return i.fallback.List()
+	}
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+	return podsFrom(i.pods), nil
+}
+
+// ScheduledPods returns all pods scheduled on a node.
+func (i *PodIndex) ScheduledPods() ([]*apiv1.Pod, error) {
+	if !i.synced() {
+		pods, err := i.fallback.List()
+		return ScheduledPods(pods), err
+	}
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+	return podsFrom(i.scheduled), nil
+}
+
+// UnschedulablePods returns all pods marked unschedulable by the scheduler.
+func (i *PodIndex) UnschedulablePods() ([]*apiv1.Pod, error) {
+	if !i.synced() {
+		pods, err := i.fallback.List()
+		return UnschedulablePods(pods), err
+	}
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+	return podsFrom(i.unschedulable), nil
+}
+
+// UnscheduledPods returns all pods which aren't scheduled on a node, including unschedulable ones.
+func (i *PodIndex) UnscheduledPods() ([]*apiv1.Pod, error) {
+	if !i.synced() {
+		pods, err := i.fallback.List()
+		var unscheduled []*apiv1.Pod
+		for _, pod := range pods {
+			if !isScheduled(pod) {
+				unscheduled = append(unscheduled, pod)
+			}
+		}
+		return unscheduled, err
+	}
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+	return podsFrom(i.unscheduled), nil
+}
+
+// AddPodChangeHandler registers a handler called with the node name whenever a pod scheduled on the
+// node is added, updated or removed. Handlers are called from the informer event handlers, with the
+// index locked, so they have to be quick and can't use the index.
+func (i *PodIndex) AddPodChangeHandler(handler func(nodeName string)) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	i.podChangeHandlers = append(i.podChangeHandlers, handler)
+}
+
+// HasSynced returns true once the index contains all pods from the initial list of the informer.
+func (i *PodIndex) HasSynced() bool {
+	return i.synced()
+}
+
+// ListScheduledPods returns all scheduled pods from the lister. Only pods in the index are
+// listed if the lister is an IndexedPodLister, otherwise all pods are listed and filtered.
+func ListScheduledPods(podLister PodLister) ([]*apiv1.Pod, error) {
+	if indexedLister, ok := podLister.(IndexedPodLister); ok {
+		return indexedLister.ScheduledPods()
+	}
+	pods, err := podLister.List()
+	if err != nil {
+		return nil, err
+	}
+	return ScheduledPods(pods), nil
+}
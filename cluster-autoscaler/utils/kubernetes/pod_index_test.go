@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+func TestPodIndex(t *testing.T) {
+	scheduled1 := BuildScheduledTestPod("scheduled-1", 100, 0, "n1")
+	scheduled2 := BuildScheduledTestPod("scheduled-2", 100, 0, "n1")
+	scheduled3 := BuildScheduledTestPod("scheduled-3", 100, 0, "n2")
+	unschedulable := BuildTestPod("unschedulable", 100, 0, MarkUnschedulable())
+	pending := BuildTestPod("pending", 100, 0)
+	succeeded := BuildScheduledTestPod("succeeded", 100, 0, "n2")
+	succeeded.Status.Phase = apiv1.PodSucceeded
+
+	index := newPodIndex()
+	for _, pod := range []*apiv1.Pod{scheduled1, scheduled2, scheduled3, unschedulable, pending, succeeded} {
+		index.update(pod)
+	}
+	assertPods := func(want []*apiv1.Pod, list func() ([]*apiv1.Pod, error)) {
+		t.Helper()
+		pods, err := list()
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, want, pods)
+	}
+	assertPods([]*apiv1.Pod{scheduled1, scheduled2, scheduled3, unschedulable, pending}, index.List)
+	assertPods([]*apiv1.Pod{scheduled1, scheduled2, scheduled3}, index.ScheduledPods)
+	assertPods([]*apiv1.Pod{unschedulable}, index.UnschedulablePods)
+	assertPods([]*apiv1.Pod{unschedulable, pending}, index.UnscheduledPods)
+
+	// The unschedulable pod gets scheduled, the pending one becomes unschedulable.
+	unschedulableScheduled := BuildScheduledTestPod("unschedulable", 100, 0, "n2")
+	pendingUnschedulable := BuildTestPod("pending", 100, 0, MarkUnschedulable())
+	index.update(unschedulableScheduled)
+	index.update(pendingUnschedulable)
+	// A scheduled pod finishes, another one is deleted.
+	scheduled1Failed := scheduled1.DeepCopy()
+	scheduled1Failed.Status.Phase = apiv1.PodFailed
+	index.update(scheduled1Failed)
+	index.delete(cache.DeletedFinalStateUnknown{Key: "default/scheduled-2", Obj: scheduled2})
+
+	assertPods([]*apiv1.Pod{scheduled3, unschedulableScheduled, pendingUnschedulable}, index.List)
+	assertPods([]*apiv1.Pod{scheduled3, unschedulableScheduled}, index.ScheduledPods)
+	assertPods([]*apiv1.Pod{pendingUnschedulable}, index.UnschedulablePods)
+	assertPods([]*apiv1.Pod{pendingUnschedulable}, index.UnscheduledPods)
+	assert.NotContains(t, index.scheduled, "default/scheduled-1")
+}
+
+func TestPodIndexFromInformer(t *testing.T) {
+	scheduled := BuildScheduledTestPod("scheduled", 100, 0, "n1")
+	unschedulable := BuildTestPod("unschedulable", 100, 0, MarkUnschedulable())
+	client := fake.NewSimpleClientset(scheduled, unschedulable)
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	index, err := NewPodIndex(informerFactory.Core().V1().Pods().Informer())
+	assert.NoError(t, err)
+
+	// Before the index is synced, pods are listed from the informer cache.
+	pods, err := index.List()
+	assert.NoError(t, err)
+	assert.Empty(t, pods)
+
+	stop := make(chan struct{})
+	defer close(stop)
+	informerFactory.Start(stop)
+	assert.True(t, cache.WaitForCacheSync(stop, index.synced))
+
+	pods, err = index.UnschedulablePods()
+	assert.NoError(t, err)
+	assert.Equal(t, []*apiv1.Pod{unschedulable}, pods)
+
+	newUnschedulable := BuildTestPod("new-unschedulable", 100, 0, MarkUnschedulable())
+	_, err = client.CoreV1().Pods(newUnschedulable.Namespace).Create(context.Background(), newUnschedulable, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	err = client.CoreV1().Pods(scheduled.Namespace).Delete(context.Background(), scheduled.Name, metav1.DeleteOptions{})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		unschedulablePods, _ := index.UnschedulablePods()
+		scheduledPods, _ := index.ScheduledPods()
+		return len(unschedulablePods) == 2 && len(scheduledPods) == 0
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestPodIndexPodChangeHandler(t *testing.T) {
+	index := newPodIndex()
+	var changed []string
+	index.AddPodChangeHandler(func(nodeName string) {
+		changed = append(changed, nodeName)
+	})
+
+	p1 := BuildScheduledTestPod("p1", 100, 0, "n1")
+	index.update(p1)
+	index.update(BuildScheduledTestPod("p2", 100, 0, "n2"))
+	assert.Equal(t, []string{"n1", "n2"}, changed)
+
+	// Pending pods don't change nodes.
+	changed = nil
+	index.update(BuildTestPod("pending", 100, 0))
+	assert.Empty(t, changed)
+
+	// An updated pod changes its node only.
+	index.update(p1.DeepCopy())
+	assert.Equal(t, []string{"n1", "n1"}, changed)
+
+	// A pod moving between nodes changes both.
+	changed = nil
+	index.update(BuildScheduledTestPod("p1", 100, 0, "n2"))
+	assert.Equal(t, []string{"n1", "n2"}, changed)
+
+	changed = nil
+	index.delete(p1)
+	assert.Equal(t, []string{"n2"}, changed)
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodegroupcrd
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+
+	kube_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+// Objects describes a CRD with one object per node group, whose spec is of type T.
+type Objects[T any] struct {
+	// Resource is the resource of the CRD.
+	Resource schema.GroupVersionResource
+	// Kind is the kind of the CRD.
+	Kind string
+	// ListKind is the kind of lists of the CRD.
+	ListKind string
+	// NamePrefix is the prefix of the object names.
+	NamePrefix string
+	// NodeGroup returns the id of the node group of a spec.
+	NodeGroup func(spec T) string
+}
+
+// Store persists specs of type T as objects of a CRD, one per node group.
+type Store[T any] struct {
+	client    dynamic.Interface
+	namespace string
+	objects   Objects[T]
+}
+
+// NewStore returns a Store keeping objects in the given namespace.
+func NewStore[T any](kubeConfig *rest.Config, namespace string, objects Objects[T]) (*Store[T], error) {
+	client, err := dynamic.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s client: %v", objects.Kind, err)
+	}
+	return newStore(client, namespace, objects), nil
+}
+
+func newStore[T any](client dynamic.Interface, namespace string, objects Objects[T]) *Store[T] {
+	return &Store[T]{
+		client:    client,
+		namespace: namespace,
+		objects:   objects,
+	}
+}
+
+// objectName returns the name of the object of a node group. Node group ids aren't
+// valid object names in general, e.g. they may be URLs.
+func (s *Store[T]) objectName(nodeGroupId string) string {
+	h := fnv.New64a()
+	h.Write([]byte(nodeGroupId))
+	return fmt.Sprintf("%s-%x", s.objects.NamePrefix, h.Sum64())
+}
+
+// List returns the specs of all persisted objects.
+func (s *Store[T]) List() ([]T, error) {
+	kind := s.objects.Kind
+	list, err := s.client.Resource(s.objects.Resource).Namespace(s.namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s objects in %s: %v", kind, s.namespace, err)
+	}
+	var specs []T
+	for _, obj := range list.Items {
+		specMap, found, err := unstructured.NestedMap(obj.Object, "spec")
+		if err != nil || !found {
+			return nil, fmt.Errorf("%s %s/%s has no valid spec: %v", kind, s.namespace, obj.GetName(), err)
+		}
+		var spec T
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(specMap, &spec); err != nil {
+			return nil, fmt.Errorf("failed to convert %s %s/%s: %v", kind, s.namespace, obj.GetName(), err)
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// Write creates or updates the object of the node group of a spec.
+func (s *Store[T]) Write(spec T) error {
+	kind := s.objects.Kind
+	specMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&spec)
+	if err != nil {
+		return fmt.Errorf("failed to convert %s: %v", kind, err)
+	}
+	name := s.objectName(s.objects.NodeGroup(spec))
+	objects := s.client.Resource(s.objects.Resource).Namespace(s.namespace)
+	obj, err := objects.Get(context.TODO(), name, metav1.GetOptions{})
+	if err == nil {
+		obj.Object["spec"] = specMap
+		_, err = objects.Update(context.TODO(), obj, metav1.UpdateOptions{})
+	} else if kube_errors.IsNotFound(err) {
+		obj = &unstructured.Unstructured{Object: map[string]interface{}{"spec": specMap}}
+		obj.SetAPIVersion(s.objects.Resource.GroupVersion().String())
+		obj.SetKind(kind)
+		obj.SetNamespace(s.namespace)
+		obj.SetName(name)
+		_, err = objects.Create(context.TODO(), obj, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s %s/%s: %v", kind, s.namespace, name, err)
+	}
+	return nil
+}
+
+// Delete removes the object of a node group, if any.
+func (s *Store[T]) Delete(nodeGroupId string) error {
+	name := s.objectName(nodeGroupId)
+	err := s.client.Resource(s.objects.Resource).Namespace(s.namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	if err != nil && !kube_errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete %s %s/%s: %v", s.objects.Kind, s.namespace, name, err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodegroupcrd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type testSpec struct {
+	NodeGroup string `json:"nodeGroup"`
+	Size      int    `json:"size"`
+}
+
+func TestStore(t *testing.T) {
+	store := NewFakeStore(Objects[testSpec]{
+		Resource:   schema.GroupVersionResource{Group: "autoscaling.x-k8s.io", Version: "v1alpha1", Resource: "tests"},
+		Kind:       "Test",
+		ListKind:   "TestList",
+		NamePrefix: "test",
+		NodeGroup:  func(spec testSpec) string { return spec.NodeGroup },
+	})
+	spec := testSpec{NodeGroup: "https://example.com/ng1", Size: 2}
+
+	assert.NoError(t, store.Write(spec))
+	spec.Size = 3
+	assert.NoError(t, store.Write(spec))
+	assert.NoError(t, store.Write(testSpec{NodeGroup: "ng2", Size: 1}))
+	specs, err := store.List()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []testSpec{spec, {NodeGroup: "ng2", Size: 1}}, specs)
+
+	assert.NoError(t, store.Delete(spec.NodeGroup))
+	assert.NoError(t, store.Delete(spec.NodeGroup))
+	specs, err = store.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []testSpec{{NodeGroup: "ng2", Size: 1}}, specs)
+}
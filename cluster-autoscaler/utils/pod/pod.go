@@ -22,6 +22,7 @@ import (
 
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	resourcehelper "k8s.io/component-helpers/resource"
 )
@@ -29,6 +30,13 @@ import (
 const (
 	// DaemonSetPodAnnotationKey - annotation use to informs the cluster-autoscaler controller when a pod needs to be considered as a Daemonset's Pod.
 	DaemonSetPodAnnotationKey = "cluster-autoscaler.kubernetes.io/daemonset-pod"
+	// CapacityBufferPodAnnotationKey - annotation marking virtual pods injected into the simulation to keep the headroom
+	// of a CapacityBuffer, its value is the namespace and name of the buffer.
+	CapacityBufferPodAnnotationKey = "cluster-autoscaler.kubernetes.io/capacity-buffer"
+	// CapacityBufferKind - kind of the CapacityBuffer objects controlling the virtual pods keeping their headroom.
+	CapacityBufferKind = "CapacityBuffer"
+	// CapacityBufferGroup - API group of the CapacityBuffer objects.
+	CapacityBufferGroup = "autoscaling.x-k8s.io"
 )
 
 // IsDaemonSetPod returns true if the Pod should be considered as Pod managed by a DaemonSet
@@ -41,6 +49,21 @@ func IsDaemonSetPod(pod *apiv1.Pod) bool {
 	return pod.Annotations[DaemonSetPodAnnotationKey] == "true"
 }
 
+// IsCapacityBufferPod returns true if the pod is a virtual pod keeping the headroom of a CapacityBuffer.
+// Both the annotation and the controller reference to the buffer are required, so that real pods copying
+// the annotation, e.g. from a buffer's pod template, aren't treated as virtual pods.
+func IsCapacityBufferPod(pod *apiv1.Pod) bool {
+	if _, found := pod.Annotations[CapacityBufferPodAnnotationKey]; !found {
+		return false
+	}
+	controllerRef := metav1.GetControllerOf(pod)
+	if controllerRef == nil || controllerRef.Kind != CapacityBufferKind {
+		return false
+	}
+	gv, err := schema.ParseGroupVersion(controllerRef.APIVersion)
+	return err == nil && gv.Group == CapacityBufferGroup
+}
+
 // IsMirrorPod checks whether the pod is a mirror pod.
 func IsMirrorPod(pod *apiv1.Pod) bool {
 	if pod.ObjectMeta.Annotations == nil {
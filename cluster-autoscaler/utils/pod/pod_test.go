@@ -161,6 +161,87 @@ func TestIsMirrorPod(t *testing.T) {
 	}
 }
 
+func TestIsCapacityBufferPod(t *testing.T) {
+	tests := []struct {
+		name string
+		pod  *apiv1.Pod
+		want bool
+	}{
+		{
+			name: "pod with CapacityBufferPodAnnotationKey",
+			pod: &apiv1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "bar",
+					Annotations: map[string]string{
+						CapacityBufferPodAnnotationKey: "bar/buffer",
+					},
+					OwnerReferences: GenerateOwnerReferences("buffer", CapacityBufferKind, "autoscaling.x-k8s.io/v1alpha1", ""),
+				},
+			},
+			want: true,
+		},
+		{
+			name: "pod with CapacityBufferPodAnnotationKey without controller",
+			pod: &apiv1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "bar",
+					Annotations: map[string]string{
+						CapacityBufferPodAnnotationKey: "bar/buffer",
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "pod with CapacityBufferPodAnnotationKey controlled by a ReplicaSet",
+			pod: &apiv1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "bar",
+					Annotations: map[string]string{
+						CapacityBufferPodAnnotationKey: "bar/buffer",
+					},
+					OwnerReferences: GenerateOwnerReferences("rs", "ReplicaSet", "apps/v1", ""),
+				},
+			},
+			want: false,
+		},
+		{
+			name: "pod with CapacityBufferPodAnnotationKey controlled by a CapacityBuffer of another group",
+			pod: &apiv1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "bar",
+					Annotations: map[string]string{
+						CapacityBufferPodAnnotationKey: "bar/buffer",
+					},
+					OwnerReferences: GenerateOwnerReferences("buffer", CapacityBufferKind, "example.com/v1", ""),
+				},
+			},
+			want: false,
+		},
+		{
+			name: "pod with nil Annotations map",
+			pod: &apiv1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "foo",
+					Namespace: "bar",
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsCapacityBufferPod(tt.pod); got != tt.want {
+				t.Errorf("IsCapacityBufferPod() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestIsStaticPod(t *testing.T) {
 	tests := []struct {
 		name string
@@ -25,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/autoscaler/cluster-autoscaler/simulator/framework"
 	scheduler_config "k8s.io/kubernetes/pkg/scheduler/apis/config"
+	scheduler_config_latest "k8s.io/kubernetes/pkg/scheduler/apis/config/latest"
 	scheduler_scheme "k8s.io/kubernetes/pkg/scheduler/apis/config/scheme"
 	scheduler_validation "k8s.io/kubernetes/pkg/scheduler/apis/config/validation"
 	schedulerframework "k8s.io/kubernetes/pkg/scheduler/framework"
@@ -126,6 +127,33 @@ func ConfigFromPath(path string) (*scheduler_config.KubeSchedulerConfiguration,
 	return cfgObj, nil
 }
 
+// AddProfilesFromPaths returns a copy of the scheduler config with scheduler profiles loaded from
+// the additional paths appended, so that pods using the scheduler names of these profiles are
+// simulated with them. The default scheduler config is used if cfg is nil.
+func AddProfilesFromPaths(cfg *scheduler_config.KubeSchedulerConfiguration, additionalPaths []string) (*scheduler_config.KubeSchedulerConfiguration, error) {
+	if cfg == nil {
+		var err error
+		cfg, err = scheduler_config_latest.Default()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", schedulerConfigLoadErr, err)
+		}
+	} else {
+		cfg = cfg.DeepCopy()
+	}
+	for _, path := range additionalPaths {
+		additionalCfg, err := ConfigFromPath(path)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Profiles = append(cfg.Profiles, additionalCfg.Profiles...)
+	}
+	// Validation makes sure the scheduler names of all profiles are unique.
+	if err := scheduler_validation.ValidateKubeSchedulerConfiguration(cfg); err != nil {
+		return nil, fmt.Errorf("%s: %v", schedulerConfigInvalidErr, err)
+	}
+	return cfg, nil
+}
+
 // GetBypassedSchedulersMap returns a map of scheduler names that should be bypassed as keys, and values are set to true
 // Also sets "" (empty string) to true if default scheduler is bypassed
 func GetBypassedSchedulersMap(bypassedSchedulers []string) map[string]bool {
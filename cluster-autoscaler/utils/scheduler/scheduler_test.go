@@ -189,3 +189,74 @@ func TestConfigFromPath(t *testing.T) {
 
 	}
 }
+
+func TestAddProfilesFromPaths(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "scheduler-configs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	customConfigFile := filepath.Join(tmpDir, "custom_config.yaml")
+	if err := os.WriteFile(customConfigFile,
+		[]byte(testconfig.SchedulerConfigNodeResourcesFitDisabled),
+		os.FileMode(0600)); err != nil {
+		t.Fatal(err)
+	}
+	validationErrConfigFile := filepath.Join(tmpDir, "invalid_percent_node_score_config.yaml")
+	if err := os.WriteFile(validationErrConfigFile,
+		[]byte(testconfig.SchedulerConfigInvalid),
+		os.FileMode(0600)); err != nil {
+		t.Fatal(err)
+	}
+	baseConfig, err := ConfigFromPath(customConfigFile)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name                   string
+		config                 *config.KubeSchedulerConfiguration
+		paths                  []string
+		expectedErr            error
+		expectedSchedulerNames []string
+	}{
+		{
+			name:                   "No additional paths",
+			expectedSchedulerNames: []string{apiv1.DefaultSchedulerName},
+		},
+		{
+			name:                   "Profile added to the default config",
+			paths:                  []string{customConfigFile},
+			expectedSchedulerNames: []string{apiv1.DefaultSchedulerName, "custom-scheduler"},
+		},
+		{
+			name:        "Duplicate scheduler name",
+			config:      baseConfig,
+			paths:       []string{customConfigFile},
+			expectedErr: fmt.Errorf(schedulerConfigInvalidErr),
+		},
+		{
+			name:        "Invalid additional config",
+			paths:       []string{validationErrConfigFile},
+			expectedErr: fmt.Errorf(schedulerConfigInvalidErr),
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf("case_%d: %s", i, test.name), func(t *testing.T) {
+			cfg, err := AddProfilesFromPaths(test.config, test.paths)
+			if test.expectedErr != nil {
+				assert.ErrorContains(t, err, test.expectedErr.Error())
+				assert.Nil(t, cfg)
+				return
+			}
+			assert.NoError(t, err)
+			var schedulerNames []string
+			for _, profile := range cfg.Profiles {
+				schedulerNames = append(schedulerNames, profile.SchedulerName)
+			}
+			assert.Equal(t, test.expectedSchedulerNames, schedulerNames)
+		})
+	}
+	// The passed config isn't modified.
+	assert.Len(t, baseConfig.Profiles, 1)
+}
@@ -386,6 +386,79 @@ func CleanAllTaints(nodes []*apiv1.Node, client kube_client.Interface, recorder
 	}
 }
 
+// IsCordonedDeletionCandidate returns true if the node is cordoned and has the DeletionCandidate taint.
+func IsCordonedDeletionCandidate(node *apiv1.Node) bool {
+	return node.Spec.Unschedulable && HasDeletionCandidateTaint(node)
+}
+
+// CordonDeletionCandidate cordons the node, making sure it has the DeletionCandidate taint, and returns an updated copy of the node.
+func CordonDeletionCandidate(node *apiv1.Node, client kube_client.Interface) (*apiv1.Node, error) {
+	taint := apiv1.Taint{
+		Key:    DeletionCandidateTaint,
+		Value:  fmt.Sprint(time.Now().Unix()),
+		Effect: apiv1.TaintEffectPreferNoSchedule,
+	}
+	return updateNodeSpec(node, client, "cordon", func(node *apiv1.Node) bool {
+		taintAdded := addTaintsToSpec(node, []apiv1.Taint{taint}, true)
+		if node.Spec.Unschedulable {
+			return taintAdded
+		}
+		node.Spec.Unschedulable = true
+		return true
+	})
+}
+
+// UncordonDeletionCandidate uncordons the node, keeping its DeletionCandidate taint, and returns an updated copy of the node.
+func UncordonDeletionCandidate(node *apiv1.Node, client kube_client.Interface) (*apiv1.Node, error) {
+	return updateNodeSpec(node, client, "uncordon", func(node *apiv1.Node) bool {
+		if !node.Spec.Unschedulable {
+			return false
+		}
+		node.Spec.Unschedulable = false
+		return true
+	})
+}
+
+// updateNodeSpec updates the node with the given modification, retrying on conflicts. The modification returns
+// false if the node doesn't need an update.
+func updateNodeSpec(node *apiv1.Node, client kube_client.Interface, action string, modify func(*apiv1.Node) bool) (*apiv1.Node, error) {
+	retryDeadline := time.Now().Add(maxRetryDeadline)
+	freshNode := node.DeepCopy()
+	var err error
+	refresh := false
+	for {
+		if refresh {
+			// Get the newest version of the node.
+			freshNode, err = client.CoreV1().Nodes().Get(context.TODO(), node.Name, metav1.GetOptions{})
+			if err != nil || freshNode == nil {
+				klog.Warningf("Error while trying to %s node %v: %v", action, node.Name, err)
+				return nil, fmt.Errorf("failed to get node %v: %v", node.Name, err)
+			}
+		}
+		if !modify(freshNode) {
+			if !refresh {
+				// Make sure we have the latest version before skipping update.
+				refresh = true
+				continue
+			}
+			return freshNode, nil
+		}
+		_, err = client.CoreV1().Nodes().Update(context.TODO(), freshNode, metav1.UpdateOptions{})
+		if err != nil && errors.IsConflict(err) && time.Now().Before(retryDeadline) {
+			refresh = true
+			time.Sleep(conflictRetryInterval)
+			continue
+		}
+
+		if err != nil {
+			klog.Warningf("Error while trying to %s node %v: %v", action, node.Name, err)
+			return nil, err
+		}
+		klog.V(1).Infof("Successfully %sed node %v", action, node.Name)
+		return freshNode, nil
+	}
+}
+
 func matchesAnyPrefix(prefixes []string, key string) bool {
 	for _, prefix := range prefixes {
 		if strings.HasPrefix(key, prefix) {
@@ -314,6 +314,32 @@ func TestCleanAllDeletionCandidates(t *testing.T) {
 	assert.Equal(t, 0, len(getNode(t, fakeClient, "n2").Spec.Taints))
 }
 
+func TestCordonDeletionCandidate(t *testing.T) {
+	defer setConflictRetryInterval(setConflictRetryInterval(time.Millisecond))
+	node := BuildTestNode("node", 1000, 1000)
+	softTainted := BuildTestNode("soft-tainted", 1000, 1000)
+	addTaintsToSpec(softTainted, []apiv1.Taint{{Key: DeletionCandidateTaint, Effect: apiv1.TaintEffectPreferNoSchedule}}, false)
+	fakeClient := buildFakeClientWithConflicts(t, node, softTainted)
+
+	for _, n := range []*apiv1.Node{node, softTainted} {
+		assert.False(t, IsCordonedDeletionCandidate(n))
+		_, err := CordonDeletionCandidate(n, fakeClient)
+		assert.NoError(t, err)
+
+		apiNode := getNode(t, fakeClient, n.Name)
+		assert.True(t, IsCordonedDeletionCandidate(apiNode))
+		assert.Len(t, apiNode.Spec.Taints, 1)
+
+		_, err = UncordonDeletionCandidate(apiNode, fakeClient)
+		assert.NoError(t, err)
+
+		apiNode = getNode(t, fakeClient, n.Name)
+		assert.False(t, IsCordonedDeletionCandidate(apiNode))
+		assert.False(t, apiNode.Spec.Unschedulable)
+		assert.True(t, HasDeletionCandidateTaint(apiNode))
+	}
+}
+
 func setConflictRetryInterval(interval time.Duration) time.Duration {
 	before := conflictRetryInterval
 	conflictRetryInterval = interval
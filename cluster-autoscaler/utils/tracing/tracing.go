@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/autoscaler/cluster-autoscaler/version"
+	k8s_tracing "k8s.io/component-base/tracing"
+	tracingapi "k8s.io/component-base/tracing/api/v1"
+)
+
+const (
+	instrumentationScope = "k8s.io/autoscaler/cluster-autoscaler"
+	serviceName          = "cluster-autoscaler"
+)
+
+// Setup makes spans get exported via OTLP gRPC to the collector listening on
+// endpoint, e.g. localhost:4317. A fraction of loops given by the sampling
+// rate, per million, is traced. Spans are discarded until Setup is called.
+func Setup(ctx context.Context, endpoint string, samplingRatePerMillion int32) error {
+	config := &tracingapi.TracingConfiguration{
+		Endpoint:               &endpoint,
+		SamplingRatePerMillion: &samplingRatePerMillion,
+	}
+	provider, err := k8s_tracing.NewProvider(ctx, config, nil, []resource.Option{
+		resource.WithAttributes(semconv.ServiceName(serviceName), semconv.ServiceVersion(version.ClusterAutoscalerVersion)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create tracer provider: %v", err)
+	}
+	otel.SetTracerProvider(provider)
+	return nil
+}
+
+// Start starts a span, nested in the span of ctx if any.
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationScope).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// End ends the span, recording the error if it's not nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
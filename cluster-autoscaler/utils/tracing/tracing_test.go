@@ -0,0 +1,63 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestStartEnd(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previous)
+
+	loopCtx, loop := Start(context.Background(), "RunOnce")
+	_, phase := Start(loopCtx, "ScaleUp", attribute.Int("pods", 3))
+	End(phase, fmt.Errorf("no node group fits"))
+	End(loop, nil)
+	_, deletion := Start(context.Background(), "NodeGroup.DeleteNodes")
+	End(deletion, nil)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 3)
+	phaseSpan, loopSpan, deletionSpan := spans[0], spans[1], spans[2]
+
+	assert.Equal(t, "ScaleUp", phaseSpan.Name())
+	assert.Equal(t, loopSpan.SpanContext().SpanID(), phaseSpan.Parent().SpanID())
+	assert.Equal(t, loopSpan.SpanContext().TraceID(), phaseSpan.SpanContext().TraceID())
+	assert.Equal(t, []attribute.KeyValue{attribute.Int("pods", 3)}, phaseSpan.Attributes())
+	assert.Equal(t, codes.Error, phaseSpan.Status().Code)
+	assert.Equal(t, "no node group fits", phaseSpan.Status().Description)
+	assert.Len(t, phaseSpan.Events(), 1)
+
+	assert.Equal(t, "RunOnce", loopSpan.Name())
+	assert.False(t, loopSpan.Parent().IsValid())
+	assert.Equal(t, codes.Unset, loopSpan.Status().Code)
+
+	assert.False(t, deletionSpan.Parent().IsValid())
+	assert.NotEqual(t, loopSpan.SpanContext().TraceID(), deletionSpan.SpanContext().TraceID())
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webui
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+	"net/http"
+
+	"k8s.io/autoscaler/cluster-autoscaler/utils/basicauth"
+	klog "k8s.io/klog/v2"
+)
+
+// Path is the path the web UI is served on.
+const Path = "/ui"
+
+//go:embed index.html
+var indexTemplate string
+
+var index = template.Must(template.New("index").Parse(indexTemplate))
+
+// Handler serves a read-only page rendering the last snapshot of a State. Requests
+// have to authenticate with HTTP basic auth, using one of the configured credentials.
+type Handler struct {
+	state *State
+	// credentials are the passwords by user name.
+	credentials map[string]string
+}
+
+// NewHandler returns a Handler rendering the given State, accepting the given
+// passwords by user name.
+func NewHandler(state *State, credentials map[string]string) *Handler {
+	return &Handler{
+		state:       state,
+		credentials: credentials,
+	}
+}
+
+// ServeHTTP handles a single request to the web UI.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !basicauth.Authenticated(r, h.credentials) {
+		basicauth.Unauthorized(w)
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	var page bytes.Buffer
+	if err := index.Execute(&page, h.state.Snapshot()); err != nil {
+		klog.Errorf("Failed to render web UI: %v", err)
+		http.Error(w, "failed to render page", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := w.Write(page.Bytes()); err != nil {
+		klog.Errorf("Failed to write web UI response: %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/api"
+)
+
+func TestHandler(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	backoffUntil := metav1.NewTime(now.Add(5 * time.Minute))
+	state := NewState(DefaultMaxEvents)
+	handler := NewHandler(state, map[string]string{"admin": "secret"})
+
+	testCases := []struct {
+		name           string
+		method         string
+		user           string
+		password       string
+		update         bool
+		wantStatusCode int
+		wantContains   []string
+	}{
+		{
+			name:           "no credentials",
+			method:         http.MethodGet,
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "wrong password",
+			method:         http.MethodGet,
+			user:           "admin",
+			password:       "wrong",
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "unknown user",
+			method:         http.MethodGet,
+			user:           "other",
+			password:       "secret",
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "post",
+			method:         http.MethodPost,
+			user:           "admin",
+			password:       "secret",
+			wantStatusCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "before the first loop",
+			method:         http.MethodGet,
+			user:           "admin",
+			password:       "secret",
+			wantStatusCode: http.StatusOK,
+			wantContains:   []string{"No loop has finished yet."},
+		},
+		{
+			name:           "after a loop",
+			method:         http.MethodGet,
+			user:           "admin",
+			password:       "secret",
+			update:         true,
+			wantStatusCode: http.StatusOK,
+			wantContains: []string{
+				"<td>ng1</td>",
+				"5m0s left: QuotaExceeded",
+				"<td>n1</td><td>ng1</td><td>cpu utilization 0.10</td>",
+				"<td>n2</td><td>ng1</td><td>BlockedByPod</td><td>default/p&lt;1&gt;</td>",
+				"<td>ScaleUp</td><td>ng1</td><td>size 1 -&gt; 2 (max 3)</td>",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.update {
+				state.Update(api.ClusterAutoscalerStatus{
+					AutoscalerStatus: api.ClusterAutoscalerRunning,
+					NodeGroups: []api.NodeGroupStatus{{
+						Name:    "ng1",
+						Health:  api.NodeGroupHealthCondition{Status: api.ClusterAutoscalerHealthy, CloudProviderTarget: 2, MinSize: 1, MaxSize: 3},
+						ScaleUp: api.NodeGroupScaleUpCondition{Status: api.ClusterAutoscalerBackoff, BackoffInfo: api.BackoffInfo{ErrorCode: "QuotaExceeded", BackoffUntil: &backoffUntil}},
+					}},
+				},
+					[]UnneededNode{{Name: "n1", NodeGroup: "ng1", Reason: "cpu utilization 0.10"}},
+					[]UnremovableNode{{Name: "n2", NodeGroup: "ng1", Reason: "BlockedByPod", BlockingPod: "default/p<1>"}},
+					[]ScaleEvent{{Time: now, Type: ScaleUp, NodeGroup: "ng1", Message: "size 1 -> 2 (max 3)"}},
+					now)
+			}
+			req := httptest.NewRequest(tc.method, Path, nil)
+			if tc.user != "" {
+				req.SetBasicAuth(tc.user, tc.password)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			assert.Equal(t, tc.wantStatusCode, w.Code)
+			for _, want := range tc.wantContains {
+				assert.Contains(t, w.Body.String(), want)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webui
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/api"
+	scaledownstatus "k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/utilization"
+	"k8s.io/klog/v2"
+)
+
+// DefaultMaxEvents is the number of recent scale events kept by default.
+const DefaultMaxEvents = 100
+
+// Event types of ScaleEvent.
+const (
+	ScaleUp         = "ScaleUp"
+	ScaleUpFailed   = "ScaleUpFailed"
+	ScaleDown       = "ScaleDown"
+	ScaleDownFailed = "ScaleDownFailed"
+)
+
+// UnneededNode is a node which is a candidate for scale-down.
+type UnneededNode struct {
+	Name      string
+	NodeGroup string
+	// Reason explains why the node is unneeded.
+	Reason string
+}
+
+// UnremovableNode is a node which can't be scaled down.
+type UnremovableNode struct {
+	Name      string
+	NodeGroup string
+	Reason    string
+	// BlockingPod is the namespace/name of the pod keeping the node, if any.
+	BlockingPod string
+}
+
+// ScaleEvent is a scale-up or scale-down executed, or attempted, by the autoscaler.
+type ScaleEvent struct {
+	Time      time.Time
+	Type      string
+	NodeGroup string
+	Message   string
+}
+
+// Snapshot is what the web UI renders, as of the end of a loop.
+type Snapshot struct {
+	Time             time.Time
+	Status           api.ClusterAutoscalerStatus
+	UnneededNodes    []UnneededNode
+	UnremovableNodes []UnremovableNode
+	// Events are the most recent scale events, newest first.
+	Events []ScaleEvent
+}
+
+// Remaining returns the time left until t as of the snapshot, or an empty string if t isn't set or has passed.
+func (s Snapshot) Remaining(t *metav1.Time) string {
+	if t == nil || !t.Time.After(s.Time) {
+		return ""
+	}
+	return t.Time.Sub(s.Time).Round(time.Second).String()
+}
+
+// State holds the snapshot rendered by the web UI. It's updated by the autoscaler at the end
+// of each loop, with the same data as the status object, and read by the web UI handler.
+type State struct {
+	mutex     sync.Mutex
+	snapshot  Snapshot
+	maxEvents int
+}
+
+// NewState creates a new State, keeping up to maxEvents recent scale events.
+func NewState(maxEvents int) *State {
+	return &State{maxEvents: maxEvents}
+}
+
+// Update replaces the snapshot, adding new scale events to the recent ones.
+func (s *State) Update(clusterStatus api.ClusterAutoscalerStatus, unneeded []UnneededNode, unremovable []UnremovableNode, events []ScaleEvent, now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	recent := make([]ScaleEvent, 0, len(events)+len(s.snapshot.Events))
+	for i := len(events) - 1; i >= 0; i-- {
+		recent = append(recent, events[i])
+	}
+	recent = append(recent, s.snapshot.Events...)
+	if len(recent) > s.maxEvents {
+		recent = recent[:s.maxEvents]
+	}
+	s.snapshot = Snapshot{
+		Time:             now,
+		Status:           clusterStatus,
+		UnneededNodes:    unneeded,
+		UnremovableNodes: unremovable,
+		Events:           recent,
+	}
+}
+
+// Snapshot returns the last snapshot.
+func (s *State) Snapshot() Snapshot {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.snapshot
+}
+
+// UnneededNodes describes the scale-down candidates, with the utilization which made them unneeded.
+func UnneededNodes(nodes []*apiv1.Node, utilizationMap map[string]utilization.Info, cloudProvider cloudprovider.CloudProvider) []UnneededNode {
+	result := make([]UnneededNode, 0, len(nodes))
+	for _, node := range nodes {
+		reason := "node is underutilized"
+		if info, found := utilizationMap[node.Name]; found {
+			reason = fmt.Sprintf("%s utilization %.2f", info.ResourceName, info.Utilization)
+		}
+		result = append(result, UnneededNode{
+			Name:      node.Name,
+			NodeGroup: nodeGroupId(cloudProvider, node),
+			Reason:    reason,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// UnremovableNodes describes the nodes which can't be scaled down, with the reasons.
+func UnremovableNodes(nodes []*simulator.UnremovableNode, cloudProvider cloudprovider.CloudProvider) []UnremovableNode {
+	result := make([]UnremovableNode, 0, len(nodes))
+	for _, node := range nodes {
+		unremovable := UnremovableNode{
+			Name:      node.Node.Name,
+			NodeGroup: nodeGroupId(cloudProvider, node.Node),
+			Reason:    node.Reason.String(),
+		}
+		if node.BlockingPod != nil && node.BlockingPod.Pod != nil {
+			unremovable.BlockingPod = fmt.Sprintf("%s/%s", node.BlockingPod.Pod.Namespace, node.BlockingPod.Pod.Name)
+		}
+		result = append(result, unremovable)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// ScaleEvents describes the scale-ups and scale-downs of a loop.
+func ScaleEvents(scaleUpStatus *status.ScaleUpStatus, scaleDownStatus *scaledownstatus.ScaleDownStatus, now time.Time) []ScaleEvent {
+	var events []ScaleEvent
+	if scaleUpStatus != nil {
+		if scaleUpStatus.Result == status.ScaleUpSuccessful {
+			for _, info := range scaleUpStatus.ScaleUpInfos {
+				events = append(events, ScaleEvent{
+					Time:      now,
+					Type:      ScaleUp,
+					NodeGroup: info.Group.Id(),
+					Message:   fmt.Sprintf("size %d -> %d (max %d)", info.CurrentSize, info.NewSize, info.MaxSize),
+				})
+			}
+		}
+		for _, nodeGroup := range scaleUpStatus.FailedResizeNodeGroups {
+			message := "failed to increase the size"
+			if scaleUpStatus.ScaleUpError != nil {
+				message = fmt.Sprintf("%s: %v", message, *scaleUpStatus.ScaleUpError)
+			}
+			events = append(events, ScaleEvent{Time: now, Type: ScaleUpFailed, NodeGroup: nodeGroup.Id(), Message: message})
+		}
+	}
+	if scaleDownStatus != nil {
+		for _, node := range scaleDownStatus.ScaledDownNodes {
+			message := fmt.Sprintf("removing node %s", node.Node.Name)
+			if len(node.EvictedPods) > 0 {
+				message = fmt.Sprintf("%s, evicting %d pods", message, len(node.EvictedPods))
+			}
+			events = append(events, ScaleEvent{Time: now, Type: ScaleDown, NodeGroup: nodeGroupIdOrEmpty(node.NodeGroup), Message: message})
+		}
+		var failedNodes []string
+		for nodeName, result := range scaleDownStatus.NodeDeleteResults {
+			if result.Err != nil {
+				failedNodes = append(failedNodes, nodeName)
+			}
+		}
+		sort.Strings(failedNodes)
+		for _, nodeName := range failedNodes {
+			events = append(events, ScaleEvent{
+				Time:    scaleDownStatus.NodeDeleteResultsAsOf,
+				Type:    ScaleDownFailed,
+				Message: fmt.Sprintf("failed to remove node %s: %v", nodeName, scaleDownStatus.NodeDeleteResults[nodeName].Err),
+			})
+		}
+	}
+	return events
+}
+
+func nodeGroupId(cloudProvider cloudprovider.CloudProvider, node *apiv1.Node) string {
+	nodeGroup, err := cloudProvider.NodeGroupForNode(node)
+	if err != nil {
+		klog.V(4).Infof("Failed to get node group of node %s: %v", node.Name, err)
+		return ""
+	}
+	return nodeGroupIdOrEmpty(nodeGroup)
+}
+
+func nodeGroupIdOrEmpty(nodeGroup cloudprovider.NodeGroup) string {
+	if nodeGroup == nil || reflect.ValueOf(nodeGroup).IsNil() {
+		return ""
+	}
+	return nodeGroup.Id()
+}
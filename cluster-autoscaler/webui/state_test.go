@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webui
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/autoscaler/cluster-autoscaler/cloudprovider"
+	testprovider "k8s.io/autoscaler/cluster-autoscaler/cloudprovider/test"
+	"k8s.io/autoscaler/cluster-autoscaler/clusterstate/api"
+	scaledownstatus "k8s.io/autoscaler/cluster-autoscaler/core/scaledown/status"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/nodegroupset"
+	"k8s.io/autoscaler/cluster-autoscaler/processors/status"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator"
+	"k8s.io/autoscaler/cluster-autoscaler/simulator/utilization"
+	"k8s.io/autoscaler/cluster-autoscaler/utils/drain"
+	. "k8s.io/autoscaler/cluster-autoscaler/utils/test"
+)
+
+func TestStateUpdate(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	state := NewState(3)
+	assert.True(t, state.Snapshot().Time.IsZero())
+
+	state.Update(api.ClusterAutoscalerStatus{AutoscalerStatus: api.ClusterAutoscalerRunning}, nil, nil, []ScaleEvent{
+		{Time: now, Type: ScaleUp, NodeGroup: "ng1"},
+		{Time: now, Type: ScaleUp, NodeGroup: "ng2"},
+	}, now)
+	state.Update(api.ClusterAutoscalerStatus{AutoscalerStatus: api.ClusterAutoscalerRunning}, []UnneededNode{{Name: "n1"}}, nil, nil, now.Add(time.Minute))
+	state.Update(api.ClusterAutoscalerStatus{AutoscalerStatus: api.ClusterAutoscalerRunning}, nil, nil, []ScaleEvent{
+		{Time: now.Add(2 * time.Minute), Type: ScaleDown, NodeGroup: "ng3"},
+		{Time: now.Add(2 * time.Minute), Type: ScaleDown, NodeGroup: "ng4"},
+	}, now.Add(2*time.Minute))
+
+	snapshot := state.Snapshot()
+	assert.Equal(t, now.Add(2*time.Minute), snapshot.Time)
+	assert.Empty(t, snapshot.UnneededNodes)
+	// The newest events are kept, newest first.
+	var nodeGroups []string
+	for _, event := range snapshot.Events {
+		nodeGroups = append(nodeGroups, event.NodeGroup)
+	}
+	assert.Equal(t, []string{"ng4", "ng3", "ng2"}, nodeGroups)
+}
+
+func TestSnapshotRemaining(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	snapshot := Snapshot{Time: now}
+	future := metav1.NewTime(now.Add(90 * time.Second))
+	past := metav1.NewTime(now.Add(-time.Second))
+	assert.Equal(t, "1m30s", snapshot.Remaining(&future))
+	assert.Equal(t, "", snapshot.Remaining(&past))
+	assert.Equal(t, "", snapshot.Remaining(nil))
+}
+
+func TestNodes(t *testing.T) {
+	n1 := BuildTestNode("n1", 1000, 1000)
+	n2 := BuildTestNode("n2", 1000, 1000)
+	n3 := BuildTestNode("n3", 1000, 1000)
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 0, 10, 2)
+	provider.AddNode("ng1", n1)
+	provider.AddNode("ng1", n2)
+
+	unneeded := UnneededNodes([]*apiv1.Node{n2, n1}, map[string]utilization.Info{"n1": {ResourceName: apiv1.ResourceCPU, Utilization: 0.25}}, provider)
+	assert.Equal(t, []UnneededNode{
+		{Name: "n1", NodeGroup: "ng1", Reason: "cpu utilization 0.25"},
+		{Name: "n2", NodeGroup: "ng1", Reason: "node is underutilized"},
+	}, unneeded)
+
+	pod := BuildTestPod("p1", 100, 100)
+	unremovable := UnremovableNodes([]*simulator.UnremovableNode{
+		{Node: n3, Reason: simulator.NotAutoscaled},
+		{Node: n1, Reason: simulator.BlockedByPod, BlockingPod: &drain.BlockingPod{Pod: pod, Reason: drain.NotReplicated}},
+	}, provider)
+	assert.Equal(t, []UnremovableNode{
+		{Name: "n1", NodeGroup: "ng1", Reason: "BlockedByPod", BlockingPod: "default/p1"},
+		{Name: "n3", Reason: "NotAutoscaled"},
+	}, unremovable)
+}
+
+func TestScaleEvents(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	provider := testprovider.NewTestCloudProvider(nil, nil)
+	provider.AddNodeGroup("ng1", 0, 10, 2)
+	provider.AddNodeGroup("ng2", 0, 10, 2)
+	ng1 := provider.GetNodeGroup("ng1")
+	n1 := BuildTestNode("n1", 1000, 1000)
+	n2 := BuildTestNode("n2", 1000, 1000)
+
+	events := ScaleEvents(&status.ScaleUpStatus{
+		Result:                 status.ScaleUpSuccessful,
+		ScaleUpInfos:           []nodegroupset.ScaleUpInfo{{Group: ng1, CurrentSize: 2, NewSize: 4, MaxSize: 10}},
+		FailedResizeNodeGroups: []cloudprovider.NodeGroup{provider.GetNodeGroup("ng2")},
+	}, &scaledownstatus.ScaleDownStatus{
+		ScaledDownNodes:       []*scaledownstatus.ScaleDownNode{{Node: n1, NodeGroup: ng1, EvictedPods: []*apiv1.Pod{BuildTestPod("p1", 100, 100)}}},
+		NodeDeleteResults:     map[string]scaledownstatus.NodeDeleteResult{"n1": {}, "n2": {Err: fmt.Errorf("not found")}},
+		NodeDeleteResultsAsOf: now.Add(-time.Minute),
+	}, now)
+	assert.Equal(t, []ScaleEvent{
+		{Time: now, Type: ScaleUp, NodeGroup: "ng1", Message: "size 2 -> 4 (max 10)"},
+		{Time: now, Type: ScaleUpFailed, NodeGroup: "ng2", Message: "failed to increase the size"},
+		{Time: now, Type: ScaleDown, NodeGroup: "ng1", Message: "removing node n1, evicting 1 pods"},
+		{Time: now.Add(-time.Minute), Type: ScaleDownFailed, Message: "failed to remove node " + n2.Name + ": not found"},
+	}, events)
+	assert.Empty(t, ScaleEvents(&status.ScaleUpStatus{Result: status.ScaleUpNotTried}, &scaledownstatus.ScaleDownStatus{}, now))
+}
@@ -46,3 +46,4 @@ grep -v -e 'map keys must be strings, not int' -e 'not all generators ran succes
 
 cat "${WORKSPACE}/autoscaling.k8s.io_verticalpodautoscalercheckpoints.yaml" > ${OUTPUT}
 cat "${WORKSPACE}/autoscaling.k8s.io_verticalpodautoscalers.yaml" >> ${OUTPUT}
+cat "${WORKSPACE}/autoscaling.k8s.io_verticalpodautoscalerpolicies.yaml" >> ${OUTPUT}
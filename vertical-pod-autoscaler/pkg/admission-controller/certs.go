@@ -17,18 +17,23 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
-	"encoding/base64"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/fsnotify/fsnotify"
 	admissionregistrationv1 "k8s.io/client-go/kubernetes/typed/admissionregistration/v1"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 )
 
@@ -84,6 +89,13 @@ func (cr *certReloader) start(stop <-chan struct{}) error {
 				switch event.Name {
 				case cr.tlsCertPath, cr.tlsKeyPath:
 					klog.V(2).InfoS("New certificate found, reloading")
+					// The CA of the new certificate has to be trusted before it's served,
+					// or the requests sent to this replica fail until the CA is reloaded.
+					if cr.mutatingWebhookClient != nil && cr.clientCaPath != "" {
+						if err := cr.reloadWebhookCA(); err != nil {
+							klog.ErrorS(err, "Failed to reload client CA")
+						}
+					}
 					if err := cr.load(); err != nil {
 						klog.ErrorS(err, "Failed to reload certificate")
 					}
@@ -121,34 +133,97 @@ func (cr *certReloader) load() error {
 	return nil
 }
 
+// reloadWebhookCA adds the client CA to the CA bundles of all the webhooks.
+// Replicas may pick up a new CA at different times, so the bundles keep the
+// certificates of the previous CAs until they expire instead of being replaced.
 func (cr *certReloader) reloadWebhookCA() error {
 	client := cr.mutatingWebhookClient
-	webhook, err := client.Get(context.TODO(), webhookConfigName, metav1.GetOptions{})
-	if err != nil {
-		return err
-	}
-	if webhook == nil {
-		return fmt.Errorf("webhook not found")
+	newBundle := readFile(cr.clientCaPath)
+	if len(newBundle) == 0 {
+		return fmt.Errorf("client CA %s is empty", cr.clientCaPath)
 	}
-	if len(webhook.Webhooks) == 0 {
-		return fmt.Errorf("webhook configuration has no webhooks")
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		webhookConfig, err := client.Get(context.TODO(), webhookConfigName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if webhookConfig == nil {
+			return fmt.Errorf("webhook not found")
+		}
+		if len(webhookConfig.Webhooks) == 0 {
+			return fmt.Errorf("webhook configuration has no webhooks")
+		}
+		changed := false
+		webhooks := make([]map[string]interface{}, 0, len(webhookConfig.Webhooks))
+		for _, webhook := range webhookConfig.Webhooks {
+			bundle := mergeCABundles(webhook.ClientConfig.CABundle, newBundle, time.Now())
+			changed = changed || !bytes.Equal(bundle, webhook.ClientConfig.CABundle)
+			webhooks = append(webhooks, map[string]interface{}{
+				"name":         webhook.Name,
+				"clientConfig": map[string]interface{}{"caBundle": bundle},
+			})
+		}
+		// make sure clientCA actually changed
+		if !changed {
+			klog.V(2).InfoS("Client CA did not change, skipping patch")
+			return nil
+		}
+		klog.V(2).InfoS("New client CA found, reloading and patching webhook")
+		// The resource version makes the patch fail with a conflict if another
+		// replica changed the bundles in the meantime.
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{"resourceVersion": webhookConfig.ResourceVersion},
+			"webhooks": webhooks,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = client.Patch(context.TODO(), webhookConfigName, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		if err == nil {
+			klog.V(2).InfoS("Successfully patched webhook with new client CA")
+		}
+		return err
+	})
+}
+
+// mergeCABundles returns the PEM encoded certificates of the added bundle
+// followed by the ones of the current bundle, without duplicates and expired
+// certificates. If the added bundle has no certificates, it's returned as is.
+func mergeCABundles(current, added []byte, now time.Time) []byte {
+	addedCerts := parseCertificates(added)
+	if len(addedCerts) == 0 {
+		return added
 	}
-	currentBundle := webhook.Webhooks[0].ClientConfig.CABundle[:]
-	base64CurrentBundle := base64.StdEncoding.EncodeToString(currentBundle)
-	newBundle := readFile(cr.clientCaPath)
-	base64NewBundle := base64.StdEncoding.EncodeToString(newBundle)
-	// make sure clientCA actually changed
-	if base64CurrentBundle == base64NewBundle {
-		klog.V(2).InfoS("Client CA did not change, skipping patch")
-		return nil
+	var merged []byte
+	seen := make(map[string]bool)
+	for _, cert := range append(addedCerts, parseCertificates(current)...) {
+		if seen[string(cert.Raw)] || now.After(cert.NotAfter) {
+			continue
+		}
+		seen[string(cert.Raw)] = true
+		merged = append(merged, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
 	}
-	klog.V(2).InfoS("New client CA found, reloading and patching webhook")
-	patch := []byte(fmt.Sprintf(`{"webhooks":[{"name":"%s","clientConfig":{"caBundle":"%s"}}]}`, webhookName, base64NewBundle))
-	_, err = client.Patch(context.TODO(), webhookConfigName, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
-	if err == nil {
-		klog.V(2).InfoS("Successfully patched webhook with new client CA")
+	return merged
+}
+
+func parseCertificates(bundle []byte) []*x509.Certificate {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, bundle = pem.Decode(bundle)
+		if block == nil {
+			return certs
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			klog.ErrorS(err, "Skipping invalid certificate in CA bundle")
+			continue
+		}
+		certs = append(certs, cert)
 	}
-	return err
 }
 
 func (cr *certReloader) getCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
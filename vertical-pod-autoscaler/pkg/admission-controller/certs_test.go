@@ -209,6 +209,7 @@ func TestChangedCAReloader(t *testing.T) {
 		[]string{},
 		false,
 		"key1:value1,key2:value2",
+		1,
 	)
 
 	webhookConfigInterface := testClientSet.AdmissionregistrationV1().MutatingWebhookConfigurations()
@@ -327,6 +328,7 @@ func TestChangedCAReloader(t *testing.T) {
 // 		[]string{},
 // 		false,
 // 		"key1:value1,key2:value2",
+// 		1,
 // 	)
 
 // 	webhookConfigInterface := testClientSet.AdmissionregistrationV1().MutatingWebhookConfigurations()
@@ -393,3 +395,76 @@ func TestChangedCAReloader(t *testing.T) {
 // 	newCAEncodedString := base64.StdEncoding.EncodeToString(newWebhookCABundle)
 // 	assert.Equal(t, oldCAEncodedString, newCAEncodedString, "expected CA to not change")
 // }
+
+func generateCA(t *testing.T, org string, notAfter time.Time) []byte {
+	caCert := &x509.Certificate{
+		SerialNumber: big.NewInt(0),
+		Subject: pkix.Name{
+			Organization: []string{org},
+		},
+		NotBefore:             notAfter.AddDate(-1, 0, 0),
+		NotAfter:              notAfter,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caBytes, err := x509.CreateCertificate(rand.Reader, caCert, caCert, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caBytes})
+}
+
+func TestMergeCABundles(t *testing.T) {
+	now := time.Now()
+	oldCA := generateCA(t, "old", now.AddDate(0, 1, 0))
+	newCA := generateCA(t, "new", now.AddDate(1, 0, 0))
+	expiredCA := generateCA(t, "expired", now.AddDate(0, 0, -1))
+
+	testCases := []struct {
+		name     string
+		current  []byte
+		added    []byte
+		expected []byte
+	}{
+		{
+			name:     "no current bundle",
+			current:  nil,
+			added:    newCA,
+			expected: newCA,
+		},
+		{
+			name:     "new CA is added before the current one",
+			current:  oldCA,
+			added:    newCA,
+			expected: append(append([]byte{}, newCA...), oldCA...),
+		},
+		{
+			name:     "CA already in the bundle",
+			current:  append(append([]byte{}, newCA...), oldCA...),
+			added:    oldCA,
+			expected: append(append([]byte{}, oldCA...), newCA...),
+		},
+		{
+			name:     "expired CA is dropped",
+			current:  append(append([]byte{}, expiredCA...), oldCA...),
+			added:    newCA,
+			expected: append(append([]byte{}, newCA...), oldCA...),
+		},
+		{
+			name:     "added bundle without certificates is kept as is",
+			current:  oldCA,
+			added:    []byte("fake"),
+			expected: []byte("fake"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, string(tc.expected), string(mergeCABundles(tc.current, tc.added, now)))
+		})
+	}
+}
@@ -20,13 +20,16 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	admissionregistration "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	typedadmregv1 "k8s.io/client-go/kubernetes/typed/admissionregistration/v1"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 )
 
@@ -94,16 +97,14 @@ func configTLS(cfg certsConfig, minTlsVersion, ciphers string, stop <-chan struc
 }
 
 // register this webhook admission controller with the kube-apiserver
-// by creating MutatingWebhookConfiguration.
-func selfRegistration(clientset kubernetes.Interface, caCert []byte, webHookDelay time.Duration, namespace, serviceName, url string, registerByURL bool, timeoutSeconds int32, selectedNamespace string, ignoredNamespaces []string, webHookFailurePolicy bool, webHookLabels string) {
+// by creating or updating MutatingWebhookConfiguration. All replicas register
+// the same configuration, so it's updated in place instead of being recreated.
+// If shards is more than one, the namespaces are split between the webhooks of
+// the shards by namespaceShardLabel, and the namespaces which aren't labeled
+// yet are served by all replicas.
+func selfRegistration(clientset kubernetes.Interface, caCert []byte, webHookDelay time.Duration, namespace, serviceName, url string, registerByURL bool, timeoutSeconds int32, selectedNamespace string, ignoredNamespaces []string, webHookFailurePolicy bool, webHookLabels string, shards int) {
 	time.Sleep(webHookDelay)
 	client := clientset.AdmissionregistrationV1().MutatingWebhookConfigurations()
-	_, err := client.Get(context.TODO(), webhookConfigName, metav1.GetOptions{})
-	if err == nil {
-		if err2 := client.Delete(context.TODO(), webhookConfigName, metav1.DeleteOptions{}); err2 != nil {
-			klog.Fatal(err2)
-		}
-	}
 	RegisterClientConfig := admissionregistration.WebhookClientConfig{}
 	if !registerByURL {
 		RegisterClientConfig.Service = &admissionregistration.ServiceReference{
@@ -151,48 +152,99 @@ func selfRegistration(clientset kubernetes.Interface, caCert []byte, webHookDela
 		klog.ErrorS(err, "Unable to parse webhook labels")
 		webhookLabelsMap = map[string]string{}
 	}
+	webhook := admissionregistration.MutatingWebhook{
+		Name:                    webhookName,
+		AdmissionReviewVersions: []string{"v1"},
+		Rules: []admissionregistration.RuleWithOperations{
+			{
+				Operations: []admissionregistration.OperationType{admissionregistration.Create},
+				Rule: admissionregistration.Rule{
+					APIGroups:   []string{""},
+					APIVersions: []string{"v1"},
+					Resources:   []string{"pods"},
+				},
+			},
+			{
+				Operations: []admissionregistration.OperationType{admissionregistration.Create, admissionregistration.Update},
+				Rule: admissionregistration.Rule{
+					APIGroups:   []string{"autoscaling.k8s.io"},
+					APIVersions: []string{"*"},
+					Resources:   []string{"verticalpodautoscalers"},
+				},
+			},
+		},
+		FailurePolicy:     &failurePolicy,
+		ClientConfig:      RegisterClientConfig,
+		SideEffects:       &sideEffects,
+		TimeoutSeconds:    &timeoutSeconds,
+		NamespaceSelector: &namespaceSelector,
+	}
+	webhooks := []admissionregistration.MutatingWebhook{webhook}
+	if shards > 1 && !registerByURL {
+		shardIndexes := make([]string, 0, shards)
+		for shard := 0; shard < shards; shard++ {
+			shardWebhook := *webhook.DeepCopy()
+			shardWebhook.Name = shardWebhookName(shard)
+			shardWebhook.ClientConfig.Service.Name = shardServiceName(serviceName, shard)
+			shardWebhook.NamespaceSelector.MatchExpressions = append(shardWebhook.NamespaceSelector.MatchExpressions, metav1.LabelSelectorRequirement{
+				Key:      namespaceShardLabel,
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   []string{strconv.Itoa(shard)},
+			})
+			webhooks = append(webhooks, shardWebhook)
+			shardIndexes = append(shardIndexes, strconv.Itoa(shard))
+		}
+		// NotIn also selects the namespaces without the label.
+		webhooks[0].NamespaceSelector.MatchExpressions = append(webhooks[0].NamespaceSelector.MatchExpressions, metav1.LabelSelectorRequirement{
+			Key:      namespaceShardLabel,
+			Operator: metav1.LabelSelectorOpNotIn,
+			Values:   shardIndexes,
+		})
+	}
 	webhookConfig := &admissionregistration.MutatingWebhookConfiguration{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:   webhookConfigName,
 			Labels: webhookLabelsMap,
 		},
-		Webhooks: []admissionregistration.MutatingWebhook{
-			{
-				Name:                    webhookName,
-				AdmissionReviewVersions: []string{"v1"},
-				Rules: []admissionregistration.RuleWithOperations{
-					{
-						Operations: []admissionregistration.OperationType{admissionregistration.Create},
-						Rule: admissionregistration.Rule{
-							APIGroups:   []string{""},
-							APIVersions: []string{"v1"},
-							Resources:   []string{"pods"},
-						},
-					},
-					{
-						Operations: []admissionregistration.OperationType{admissionregistration.Create, admissionregistration.Update},
-						Rule: admissionregistration.Rule{
-							APIGroups:   []string{"autoscaling.k8s.io"},
-							APIVersions: []string{"*"},
-							Resources:   []string{"verticalpodautoscalers"},
-						},
-					},
-				},
-				FailurePolicy:     &failurePolicy,
-				ClientConfig:      RegisterClientConfig,
-				SideEffects:       &sideEffects,
-				TimeoutSeconds:    &timeoutSeconds,
-				NamespaceSelector: &namespaceSelector,
-			},
-		},
+		Webhooks: webhooks,
 	}
-	if _, err := client.Create(context.TODO(), webhookConfig, metav1.CreateOptions{}); err != nil {
+	if err := createOrUpdateWebhookConfig(client, webhookConfig); err != nil {
 		klog.Fatal(err)
 	} else {
 		klog.V(3).Info("Self registration as MutatingWebhook succeeded.")
 	}
 }
 
+// createOrUpdateWebhookConfig creates the webhook configuration, or updates it
+// if another replica already created it. The CA bundles already trusted by the
+// existing configuration are kept, so that replicas still serving certificates
+// signed by a previous CA keep working while the certificates are rotated.
+func createOrUpdateWebhookConfig(client typedadmregv1.MutatingWebhookConfigurationInterface, webhookConfig *admissionregistration.MutatingWebhookConfiguration) error {
+	_, err := client.Create(context.TODO(), webhookConfig, metav1.CreateOptions{})
+	if !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := client.Get(context.TODO(), webhookConfigName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		existingBundles := make(map[string][]byte)
+		for _, webhook := range existing.Webhooks {
+			existingBundles[webhook.Name] = webhook.ClientConfig.CABundle
+		}
+		updated := existing.DeepCopy()
+		updated.Labels = webhookConfig.Labels
+		updated.Webhooks = make([]admissionregistration.MutatingWebhook, 0, len(webhookConfig.Webhooks))
+		for _, webhook := range webhookConfig.Webhooks {
+			webhook.ClientConfig.CABundle = mergeCABundles(existingBundles[webhook.Name], webhook.ClientConfig.CABundle, time.Now())
+			updated.Webhooks = append(updated.Webhooks, webhook)
+		}
+		_, err = client.Update(context.TODO(), updated, metav1.UpdateOptions{})
+		return err
+	})
+}
+
 // convertLabelsToMap convert the labels from string to map
 // the valid labels format is "key1:value1,key2:value2", which could be converted to
 // {"key1": "value1", "key2": "value2"}
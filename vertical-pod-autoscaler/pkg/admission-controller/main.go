@@ -74,7 +74,10 @@ var (
 	webHookFailurePolicy = flag.Bool("webhook-failure-policy-fail", false, "If set to true, will configure the admission webhook failurePolicy to \"Fail\". Use with caution.")
 	registerWebhook      = flag.Bool("register-webhook", true, "If set to true, admission webhook object will be created on start up to register with the API server.")
 	webhookLabels        = flag.String("webhook-labels", "", "Comma separated list of labels to add to the webhook object. Format: key1:value1,key2:value2")
+	enablePolicies       = flag.Bool("enable-recommendation-policies", false, "If set to true, VerticalPodAutoscalerPolicy objects will be applied to the recommended resources set on pods.")
 	registerByURL        = flag.Bool("register-by-url", false, "If set to true, admission webhook will be registered by URL (webhookAddress:webhookPort) instead of by service name")
+	shards               = flag.Int("shards", 1, "Number of shards the namespaces are split into by the hash of their name. Each shard is served by the replicas behind the service <webhook-service>-<shard-index>. Can't be used with register-by-url.")
+	shardIndex           = flag.Int("shard-index", 0, "Index of the shard served by this replica, from 0 to shards-1. Used when shards is more than 1.")
 )
 
 func main() {
@@ -88,6 +91,14 @@ func main() {
 		klog.ErrorS(nil, "--vpa-object-namespace and --ignored-vpa-object-namespaces are mutually exclusive and can't be set together.")
 		os.Exit(255)
 	}
+	if *shards < 1 || *shardIndex < 0 || *shardIndex >= *shards {
+		klog.ErrorS(nil, "--shard-index must be between 0 and --shards - 1.", "shards", *shards, "shardIndex", *shardIndex)
+		os.Exit(255)
+	}
+	if *shards > 1 && *registerByURL {
+		klog.ErrorS(nil, "--shards and --register-by-url can't be set together.")
+		os.Exit(255)
+	}
 
 	healthCheck := metrics.NewHealthCheck(time.Minute)
 	metrics_admission.Register()
@@ -109,7 +120,20 @@ func main() {
 		klog.ErrorS(err, "Failed to create limitRangeCalculator, falling back to not checking limits.")
 		limitRangeCalculator = limitrange.NewNoopLimitsCalculator()
 	}
-	recommendationProvider := recommendation.NewProvider(limitRangeCalculator, vpa_api_util.NewCappingRecommendationProcessor(limitRangeCalculator))
+	recommendationProcessor := vpa_api_util.NewCappingRecommendationProcessor(limitRangeCalculator)
+	var policies *vpa_api_util.RecommendationPolicies
+	if *enablePolicies {
+		policies, err = vpa_api_util.NewRecommendationPolicies(vpa_api_util.NewVpaPoliciesLister(vpaClient, make(chan struct{})))
+		if err != nil {
+			klog.ErrorS(err, "Failed to create recommendation policies")
+			os.Exit(255)
+		}
+		recommendationProcessor = vpa_api_util.NewPolicyRecommendationProcessor(recommendationProcessor, policies)
+	}
+	recommendationProvider := recommendation.NewProvider(limitRangeCalculator, recommendationProcessor)
+	if policies != nil {
+		recommendationProvider = recommendation.NewPolicyProvider(recommendationProvider, policies)
+	}
 	vpaMatcher := vpa.NewMatcher(vpaLister, targetSelectorFetcher, controllerFetcher)
 
 	hostname, err := os.Hostname()
@@ -132,6 +156,14 @@ func main() {
 	)
 	defer close(stopCh)
 
+	if *shards > 1 {
+		labeler := &namespaceShardLabeler{client: kubeClient.CoreV1().Namespaces(), shard: *shardIndex, shards: *shards}
+		if err := labeler.run(factory, stopCh); err != nil {
+			klog.ErrorS(err, "Failed to start namespace shard labeler")
+			os.Exit(255)
+		}
+	}
+
 	calculators := []patch.Calculator{patch.NewResourceUpdatesCalculator(recommendationProvider), patch.NewObservedContainersCalculator()}
 	as := logic.NewAdmissionServer(podPreprocessor, vpaPreprocessor, limitRangeCalculator, vpaMatcher, calculators)
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
@@ -146,7 +178,7 @@ func main() {
 	ignoredNamespaces := strings.Split(commonFlags.IgnoredVpaObjectNamespaces, ",")
 	go func() {
 		if *registerWebhook {
-			selfRegistration(kubeClient, readFile(*certsConfiguration.clientCaFile), webHookDelay, namespace, *serviceName, url, *registerByURL, int32(*webhookTimeout), commonFlags.VpaObjectNamespace, ignoredNamespaces, *webHookFailurePolicy, *webhookLabels)
+			selfRegistration(kubeClient, readFile(*certsConfiguration.clientCaFile), webHookDelay, namespace, *serviceName, url, *registerByURL, int32(*webhookTimeout), commonFlags.VpaObjectNamespace, ignoredNamespaces, *webHookFailurePolicy, *webhookLabels, *shards)
 		}
 		// Start status updates after the webhook is initialized.
 		statusUpdater.Run(stopCh)
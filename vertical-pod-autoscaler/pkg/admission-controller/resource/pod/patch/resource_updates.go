@@ -61,8 +61,16 @@ func (c *resourcesUpdatesPatchCalculator) CalculatePatches(pod *core.Pod, vpa *v
 	}
 
 	updatesAnnotation := []string{}
+	containers := resourcehelpers.PodContainers(pod)
 	for i, containerResources := range containersResources {
-		newPatches, newUpdatesAnnotation := getContainerPatch(pod, i, annotationsPerContainer, containerResources)
+		if i >= len(containers) {
+			break
+		}
+		if i >= len(pod.Spec.Containers) && len(containerResources.Requests) == 0 && len(containerResources.Limits) == 0 {
+			// Sidecar containers without recommendation are left untouched.
+			continue
+		}
+		newPatches, newUpdatesAnnotation := getContainerPatch(pod, i, containers[i], annotationsPerContainer, containerResources)
 		result = append(result, newPatches...)
 		updatesAnnotation = append(updatesAnnotation, newUpdatesAnnotation)
 	}
@@ -74,57 +82,78 @@ func (c *resourcesUpdatesPatchCalculator) CalculatePatches(pod *core.Pod, vpa *v
 	return result, nil
 }
 
-func getContainerPatch(pod *core.Pod, i int, annotationsPerContainer vpa_api_util.ContainerToAnnotationsMap, containerResources vpa_api_util.ContainerResources) ([]resource_admission.PatchRecord, string) {
+// getContainerPath returns the JSON path of the i-th container in the list of
+// containers of the pod followed by its sidecar containers, and its description
+// for the updates annotation.
+func getContainerPath(pod *core.Pod, i int) (string, string) {
+	if i < len(pod.Spec.Containers) {
+		return fmt.Sprintf("/spec/containers/%d", i), fmt.Sprintf("container %d", i)
+	}
+	sidecarIndex := i - len(pod.Spec.Containers)
+	for j, initContainer := range pod.Spec.InitContainers {
+		if !resourcehelpers.IsSidecarContainer(initContainer) {
+			continue
+		}
+		if sidecarIndex == 0 {
+			return fmt.Sprintf("/spec/initContainers/%d", j), fmt.Sprintf("init container %d", j)
+		}
+		sidecarIndex--
+	}
+	return "", ""
+}
+
+func getContainerPatch(pod *core.Pod, i int, container *core.Container, annotationsPerContainer vpa_api_util.ContainerToAnnotationsMap, containerResources vpa_api_util.ContainerResources) ([]resource_admission.PatchRecord, string) {
 	var patches []resource_admission.PatchRecord
+	containerPath, containerDescription := getContainerPath(pod, i)
 	// Add empty resources object if missing.
-	requests, limits := resourcehelpers.ContainerRequestsAndLimits(pod.Spec.Containers[i].Name, pod)
+	requests, limits := resourcehelpers.ContainerRequestsAndLimits(container.Name, pod)
 	if limits == nil && requests == nil {
-		patches = append(patches, getPatchInitializingEmptyResources(i))
+		patches = append(patches, getPatchInitializingEmptyResources(containerPath))
 	}
 
-	annotations, found := annotationsPerContainer[pod.Spec.Containers[i].Name]
+	annotations, found := annotationsPerContainer[container.Name]
 	if !found {
 		annotations = make([]string, 0)
 	}
 
-	patches, annotations = appendPatchesAndAnnotations(patches, annotations, requests, i, containerResources.Requests, "requests", "request")
-	patches, annotations = appendPatchesAndAnnotations(patches, annotations, limits, i, containerResources.Limits, "limits", "limit")
+	patches, annotations = appendPatchesAndAnnotations(patches, annotations, requests, containerPath, containerResources.Requests, "requests", "request")
+	patches, annotations = appendPatchesAndAnnotations(patches, annotations, limits, containerPath, containerResources.Limits, "limits", "limit")
 
-	updatesAnnotation := fmt.Sprintf("container %d: ", i) + strings.Join(annotations, ", ")
+	updatesAnnotation := containerDescription + ": " + strings.Join(annotations, ", ")
 	return patches, updatesAnnotation
 }
 
-func appendPatchesAndAnnotations(patches []resource_admission.PatchRecord, annotations []string, current core.ResourceList, containerIndex int, resources core.ResourceList, fieldName, resourceName string) ([]resource_admission.PatchRecord, []string) {
+func appendPatchesAndAnnotations(patches []resource_admission.PatchRecord, annotations []string, current core.ResourceList, containerPath string, resources core.ResourceList, fieldName, resourceName string) ([]resource_admission.PatchRecord, []string) {
 	// Add empty object if it's missing and we're about to fill it.
 	if current == nil && len(resources) > 0 {
-		patches = append(patches, getPatchInitializingEmptyResourcesSubfield(containerIndex, fieldName))
+		patches = append(patches, getPatchInitializingEmptyResourcesSubfield(containerPath, fieldName))
 	}
 	for resource, request := range resources {
-		patches = append(patches, getAddResourceRequirementValuePatch(containerIndex, fieldName, resource, request))
+		patches = append(patches, getAddResourceRequirementValuePatch(containerPath, fieldName, resource, request))
 		annotations = append(annotations, fmt.Sprintf("%s %s", resource, resourceName))
 	}
 	return patches, annotations
 }
 
-func getAddResourceRequirementValuePatch(i int, kind string, resource core.ResourceName, quantity resource.Quantity) resource_admission.PatchRecord {
+func getAddResourceRequirementValuePatch(containerPath string, kind string, resource core.ResourceName, quantity resource.Quantity) resource_admission.PatchRecord {
 	return resource_admission.PatchRecord{
 		Op:    "add",
-		Path:  fmt.Sprintf("/spec/containers/%d/resources/%s/%s", i, kind, resource),
+		Path:  fmt.Sprintf("%s/resources/%s/%s", containerPath, kind, resource),
 		Value: quantity.String()}
 }
 
-func getPatchInitializingEmptyResources(i int) resource_admission.PatchRecord {
+func getPatchInitializingEmptyResources(containerPath string) resource_admission.PatchRecord {
 	return resource_admission.PatchRecord{
 		Op:    "add",
-		Path:  fmt.Sprintf("/spec/containers/%d/resources", i),
+		Path:  fmt.Sprintf("%s/resources", containerPath),
 		Value: core.ResourceRequirements{},
 	}
 }
 
-func getPatchInitializingEmptyResourcesSubfield(i int, kind string) resource_admission.PatchRecord {
+func getPatchInitializingEmptyResourcesSubfield(containerPath string, kind string) resource_admission.PatchRecord {
 	return resource_admission.PatchRecord{
 		Op:    "add",
-		Path:  fmt.Sprintf("/spec/containers/%d/resources/%s", i, kind),
+		Path:  fmt.Sprintf("%s/resources/%s", containerPath, kind),
 		Value: core.ResourceList{},
 	}
 }
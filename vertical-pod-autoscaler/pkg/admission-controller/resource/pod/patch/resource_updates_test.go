@@ -290,6 +290,57 @@ func TestCalculatePatches_ResourceUpdates(t *testing.T) {
 				addAnnotationRequest([][]string{{cpu}}, limit),
 			},
 		},
+		{
+			name: "new cpu recommendation for sidecar container",
+			pod: test.Pod().
+				AddContainer(test.Container().WithName("container").WithCPURequest(resource.MustParse("1")).Get()).
+				AddInitContainer(test.Container().WithName("init").Get()).
+				AddInitContainer(test.Container().WithName("sidecar").WithRestartPolicy(core.ContainerRestartPolicyAlways).
+					WithCPURequest(resource.MustParse("100m")).Get()).Get(),
+			namespace: "default",
+			recommendResources: []vpa_api_util.ContainerResources{
+				{
+					Requests: core.ResourceList{
+						cpu: resource.MustParse("1"),
+					},
+				},
+				{
+					Requests: core.ResourceList{
+						cpu: resource.MustParse("500m"),
+					},
+				},
+			},
+			recommendAnnotations: vpa_api_util.ContainerToAnnotationsMap{},
+			expectPatches: []resource_admission.PatchRecord{
+				addResourceRequestPatch(0, cpu, "1"),
+				{
+					Op:    "add",
+					Path:  "/spec/initContainers/1/resources/requests/cpu",
+					Value: resource.MustParse("500m"),
+				},
+				GetAddAnnotationPatch(ResourceUpdatesAnnotation, "Pod resources updated by name: container 0: cpu request; init container 1: cpu request"),
+			},
+		},
+		{
+			name: "no recommendation for sidecar container",
+			pod: test.Pod().
+				AddContainer(test.Container().WithName("container").WithCPURequest(resource.MustParse("1")).Get()).
+				AddInitContainer(test.Container().WithName("sidecar").WithRestartPolicy(core.ContainerRestartPolicyAlways).Get()).Get(),
+			namespace: "default",
+			recommendResources: []vpa_api_util.ContainerResources{
+				{
+					Requests: core.ResourceList{
+						cpu: resource.MustParse("1"),
+					},
+				},
+				{},
+			},
+			recommendAnnotations: vpa_api_util.ContainerToAnnotationsMap{},
+			expectPatches: []resource_admission.PatchRecord{
+				addResourceRequestPatch(0, cpu, "1"),
+				addAnnotationRequest([][]string{{cpu}}, request),
+			},
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
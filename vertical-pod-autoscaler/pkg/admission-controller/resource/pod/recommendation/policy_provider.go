@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recommendation
+
+import (
+	"fmt"
+
+	core "k8s.io/api/core/v1"
+
+	vpa_types "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
+	resourcehelpers "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/resources"
+	vpa_api_util "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/vpa"
+)
+
+// policyProvider is a Provider applying the limit rules of the
+// VerticalPodAutoscalerPolicy objects to the container resources returned by
+// another Provider. The request rules are applied by the recommendation
+// processor of the underlying Provider, see
+// vpa_api_util.NewPolicyRecommendationProcessor.
+type policyProvider struct {
+	provider Provider
+	policies *vpa_api_util.RecommendationPolicies
+}
+
+// NewPolicyProvider constructs a Provider which applies the limit rules of the
+// policies to the resources returned by the given provider, and makes sure the
+// requests don't exceed the limits.
+func NewPolicyProvider(provider Provider, policies *vpa_api_util.RecommendationPolicies) Provider {
+	return &policyProvider{
+		provider: provider,
+		policies: policies,
+	}
+}
+
+// GetContainersResourcesForPod returns the resources of the underlying provider
+// with the limit rules applied to the containers with recommended resources.
+func (p *policyProvider) GetContainersResourcesForPod(pod *core.Pod, vpa *vpa_types.VerticalPodAutoscaler) ([]vpa_api_util.ContainerResources, vpa_api_util.ContainerToAnnotationsMap, error) {
+	containerResources, annotations, err := p.provider.GetContainersResourcesForPod(pod, vpa)
+	if err != nil || len(containerResources) == 0 {
+		return containerResources, annotations, err
+	}
+	containers := resourcehelpers.PodContainers(pod)
+	for i := range containerResources {
+		if i >= len(containers) || len(containerResources[i].Requests) == 0 {
+			continue
+		}
+		containerName := containers[i].Name
+		applied := p.policies.Apply(pod, vpa, containerName, vpa_types.RecommendationPolicyTargetLimit, &containerResources[i])
+		applied = append(applied, clampRequestsToLimits(pod, containerName, &containerResources[i])...)
+		if len(applied) > 0 {
+			if annotations == nil {
+				annotations = vpa_api_util.ContainerToAnnotationsMap{}
+			}
+			annotations[containerName] = append(annotations[containerName], applied...)
+		}
+	}
+	return containerResources, annotations, nil
+}
+
+// clampRequestsToLimits makes sure the CPU and memory requests of the container
+// don't exceed its limits, which the API server would reject. Limits set by VPA
+// are raised to the requests, requests exceeding the limits the container keeps
+// are lowered to them. Returns the annotations describing the changes.
+func clampRequestsToLimits(pod *core.Pod, containerName string, resources *vpa_api_util.ContainerResources) []string {
+	_, containerLimits := resourcehelpers.ContainerRequestsAndLimits(containerName, pod)
+	var annotations []string
+	for _, resource := range []core.ResourceName{core.ResourceCPU, core.ResourceMemory} {
+		request, found := resources.Requests[resource]
+		if !found {
+			continue
+		}
+		if limit, found := resources.Limits[resource]; found {
+			if request.Cmp(limit) > 0 {
+				resources.Limits[resource] = request
+				annotations = append(annotations, fmt.Sprintf("%s limit raised to request", resource))
+			}
+			continue
+		}
+		if limit, found := containerLimits[resource]; found && request.Cmp(limit) > 0 {
+			resources.Requests[resource] = limit
+			annotations = append(annotations, fmt.Sprintf("%s request capped to limit", resource))
+		}
+	}
+	return annotations
+}
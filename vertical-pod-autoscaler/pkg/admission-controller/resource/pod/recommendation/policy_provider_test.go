@@ -0,0 +1,145 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recommendation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	vpa_types "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
+	vpa_lister "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/client/listers/autoscaling.k8s.io/v1"
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/test"
+	vpa_api_util "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/vpa"
+)
+
+type fakeProvider struct {
+	resources []vpa_api_util.ContainerResources
+}
+
+func (p *fakeProvider) GetContainersResourcesForPod(_ *core.Pod, _ *vpa_types.VerticalPodAutoscaler) ([]vpa_api_util.ContainerResources, vpa_api_util.ContainerToAnnotationsMap, error) {
+	resources := make([]vpa_api_util.ContainerResources, len(p.resources))
+	for i, r := range p.resources {
+		resources[i] = vpa_api_util.ContainerResources{Requests: r.Requests.DeepCopy(), Limits: r.Limits.DeepCopy()}
+	}
+	return resources, nil, nil
+}
+
+func newPolicy(name string, rules ...vpa_types.RecommendationPolicyRule) *vpa_types.VerticalPodAutoscalerPolicy {
+	return &vpa_types.VerticalPodAutoscalerPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, UID: types.UID("uid-" + name), ResourceVersion: "1"},
+		Spec:       vpa_types.VerticalPodAutoscalerPolicySpec{Rules: rules},
+	}
+}
+
+func newRule(resource core.ResourceName, target vpa_types.RecommendationPolicyTarget, expression string) vpa_types.RecommendationPolicyRule {
+	return vpa_types.RecommendationPolicyRule{Resource: resource, Target: target, Expression: expression}
+}
+
+func TestPolicyProvider(t *testing.T) {
+	testCases := []struct {
+		name                string
+		policies            []*vpa_types.VerticalPodAutoscalerPolicy
+		memoryLimit         string
+		recommended         vpa_api_util.ContainerResources
+		expectedResources   vpa_api_util.ContainerResources
+		expectedAnnotations vpa_api_util.ContainerToAnnotationsMap
+	}{
+		{
+			name:              "no policies",
+			recommended:       vpa_api_util.ContainerResources{Requests: test.Resources("500m", "100Mi")},
+			expectedResources: vpa_api_util.ContainerResources{Requests: test.Resources("500m", "100Mi")},
+		},
+		{
+			name: "cpu limit twice the request",
+			policies: []*vpa_types.VerticalPodAutoscalerPolicy{
+				newPolicy("cpu-limit", newRule(core.ResourceCPU, vpa_types.RecommendationPolicyTargetLimit, "cpuRequest * 2")),
+			},
+			recommended: vpa_api_util.ContainerResources{Requests: test.Resources("500m", "100Mi")},
+			expectedResources: vpa_api_util.ContainerResources{
+				Requests: test.Resources("500m", "100Mi"),
+				Limits:   core.ResourceList{core.ResourceCPU: resource.MustParse("1")},
+			},
+			expectedAnnotations: vpa_api_util.ContainerToAnnotationsMap{"container": {"modified by policy cpu-limit"}},
+		},
+		{
+			name: "request rules are left to the recommendation processor",
+			policies: []*vpa_types.VerticalPodAutoscalerPolicy{
+				newPolicy("min-memory", newRule(core.ResourceMemory, vpa_types.RecommendationPolicyTargetRequest, "memory('256Mi')")),
+			},
+			recommended:       vpa_api_util.ContainerResources{Requests: test.Resources("500m", "100Mi")},
+			expectedResources: vpa_api_util.ContainerResources{Requests: test.Resources("500m", "100Mi")},
+		},
+		{
+			name: "limit below the request is raised to it",
+			policies: []*vpa_types.VerticalPodAutoscalerPolicy{
+				newPolicy("max-memory-limit", newRule(core.ResourceMemory, vpa_types.RecommendationPolicyTargetLimit, "memory('64Mi')")),
+			},
+			recommended: vpa_api_util.ContainerResources{Requests: test.Resources("500m", "100Mi")},
+			expectedResources: vpa_api_util.ContainerResources{
+				Requests: test.Resources("500m", "100Mi"),
+				Limits:   core.ResourceList{core.ResourceMemory: resource.MustParse("100Mi")},
+			},
+			expectedAnnotations: vpa_api_util.ContainerToAnnotationsMap{"container": {"modified by policy max-memory-limit", "memory limit raised to request"}},
+		},
+		{
+			name:        "request above the limit kept by the container is capped to it",
+			memoryLimit: "80Mi",
+			recommended: vpa_api_util.ContainerResources{Requests: test.Resources("500m", "100Mi")},
+			expectedResources: vpa_api_util.ContainerResources{
+				Requests: test.Resources("500m", "80Mi"),
+			},
+			expectedAnnotations: vpa_api_util.ContainerToAnnotationsMap{"container": {"memory request capped to limit"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, policy := range tc.policies {
+				assert.NoError(t, indexer.Add(policy))
+			}
+			policies, err := vpa_api_util.NewRecommendationPolicies(vpa_lister.NewVerticalPodAutoscalerPolicyLister(indexer))
+			assert.NoError(t, err)
+			provider := NewPolicyProvider(&fakeProvider{resources: []vpa_api_util.ContainerResources{tc.recommended}}, policies)
+
+			container := test.Container().WithName("container")
+			if tc.memoryLimit != "" {
+				container = container.WithMemLimit(resource.MustParse(tc.memoryLimit))
+			}
+			pod := test.Pod().WithName("pod").AddContainer(container.Get()).Get()
+			vpa := test.VerticalPodAutoscaler().WithName("vpa").WithContainer("container").Get()
+			resources, annotations, err := provider.GetContainersResourcesForPod(pod, vpa)
+			assert.NoError(t, err)
+			if assert.Len(t, resources, 1) {
+				assert.Equal(t, len(tc.expectedResources.Requests), len(resources[0].Requests))
+				for name, quantity := range tc.expectedResources.Requests {
+					assert.True(t, quantity.Equal(resources[0].Requests[name]), "request %s: expected %v, got %v", name, quantity.String(), resources[0].Requests[name])
+				}
+				assert.Equal(t, len(tc.expectedResources.Limits), len(resources[0].Limits))
+				for name, quantity := range tc.expectedResources.Limits {
+					assert.True(t, quantity.Equal(resources[0].Limits[name]), "limit %s: expected %v, got %v", name, quantity.String(), resources[0].Limits[name])
+				}
+			}
+			assert.Equal(t, tc.expectedAnnotations, annotations)
+		})
+	}
+}
@@ -47,13 +47,15 @@ func NewProvider(calculator limitrange.LimitRangeCalculator,
 	}
 }
 
-// GetContainersResources returns the recommended resources for each container in the given pod in the same order they are specified in the pod.Spec.
+// GetContainersResources returns the recommended resources for each container in the given pod in the same order they are specified in the pod.Spec,
+// followed by the sidecar containers of the pod.
 // If addAll is set to true, containers w/o a recommendation are also added to the list (and their non-recommended requests and limits will always be preserved if present),
 // otherwise they're skipped (default behaviour).
 func GetContainersResources(pod *core.Pod, vpaResourcePolicy *vpa_types.PodResourcePolicy, podRecommendation vpa_types.RecommendedPodResources, limitRange *core.LimitRangeItem,
 	addAll bool, annotations vpa_api_util.ContainerToAnnotationsMap) []vpa_api_util.ContainerResources {
-	resources := make([]vpa_api_util.ContainerResources, len(pod.Spec.Containers))
-	for i, container := range pod.Spec.Containers {
+	containers := resourcehelpers.PodContainers(pod)
+	resources := make([]vpa_api_util.ContainerResources, len(containers))
+	for i, container := range containers {
 		containerRequests, containerLimits := resourcehelpers.ContainerRequestsAndLimits(container.Name, pod)
 		recommendation := vpa_api_util.GetRecommendationForContainer(container.Name, &podRecommendation)
 		if recommendation == nil {
@@ -64,22 +66,35 @@ func GetContainersResources(pod *core.Pod, vpaResourcePolicy *vpa_types.PodResou
 			klog.V(2).InfoS("No match found for container, using Pod request", "container", container.Name)
 			resources[i].Requests = containerRequests
 		} else {
-			resources[i].Requests = recommendation.Target
+			resources[i].Requests = filterExtendedResources(recommendation.Target, containerRequests, containerLimits)
 		}
 		defaultLimit := core.ResourceList{}
+		maxLimitRequestRatio := core.ResourceList{}
 		if limitRange != nil {
 			defaultLimit = limitRange.Default
+			maxLimitRequestRatio = limitRange.MaxLimitRequestRatio
 		}
 		containerControlledValues := vpa_api_util.GetContainerControlledValues(container.Name, vpaResourcePolicy)
 		if containerControlledValues == vpa_types.ContainerControlledValuesRequestsAndLimits {
 			proportionalLimits, limitAnnotations := vpa_api_util.GetProportionalLimit(containerLimits, containerRequests, resources[i].Requests, defaultLimit)
 			if proportionalLimits != nil {
+				limitAnnotations = append(limitAnnotations, vpa_api_util.CapLimitsToLimitRequestRatio(proportionalLimits, resources[i].Requests, maxLimitRequestRatio)...)
 				resources[i].Limits = proportionalLimits
 				if len(limitAnnotations) > 0 {
 					annotations[container.Name] = append(annotations[container.Name], limitAnnotations...)
 				}
 			}
 		}
+		// Extended resources need limits equal to their requests.
+		for resource, quantity := range resources[i].Requests {
+			if !resourcehelpers.IsExtendedResource(resource) {
+				continue
+			}
+			if resources[i].Limits == nil {
+				resources[i].Limits = core.ResourceList{}
+			}
+			resources[i].Limits[resource] = quantity
+		}
 		// If the recommendation only contains CPU or Memory (if the VPA was configured this way), we need to make sure we "backfill" the other.
 		// Only do this when the addAll flag is true.
 		if addAll {
@@ -111,8 +126,33 @@ func GetContainersResources(pod *core.Pod, vpaResourcePolicy *vpa_types.PodResou
 	return resources
 }
 
+// filterExtendedResources removes the recommendations of extended resources
+// which the container doesn't use, as adding them could make the pod
+// unschedulable.
+func filterExtendedResources(recommended, containerRequests, containerLimits core.ResourceList) core.ResourceList {
+	var result core.ResourceList
+	for resource := range recommended {
+		if !resourcehelpers.IsExtendedResource(resource) {
+			continue
+		}
+		_, requested := containerRequests[resource]
+		_, limited := containerLimits[resource]
+		if requested || limited {
+			continue
+		}
+		if result == nil {
+			result = recommended.DeepCopy()
+		}
+		delete(result, resource)
+	}
+	if result == nil {
+		return recommended
+	}
+	return result
+}
+
 // GetContainersResourcesForPod returns recommended request for a given pod and associated annotations.
-// The returned slice corresponds 1-1 to containers in the Pod.
+// The returned slice corresponds 1-1 to containers in the Pod, followed by its sidecar containers.
 func (p *recommendationProvider) GetContainersResourcesForPod(pod *core.Pod, vpa *vpa_types.VerticalPodAutoscaler) ([]vpa_api_util.ContainerResources, vpa_api_util.ContainerToAnnotationsMap, error) {
 	if vpa == nil || pod == nil {
 		klog.V(2).InfoS("Can't calculate recommendations, one of VPA or Pod is nil", "vpa", vpa, "pod", pod)
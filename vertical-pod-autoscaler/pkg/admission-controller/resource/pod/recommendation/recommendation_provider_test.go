@@ -291,6 +291,29 @@ func TestUpdateResourceRequests(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:             "proportional limit capped to max limit request ratio",
+			pod:              podWithTenfoldLimit,
+			vpa:              vpa,
+			expectedAction:   true,
+			expectedCPU:      resource.MustParse("2"),
+			expectedMem:      resource.MustParse("200Mi"),
+			expectedCPULimit: mustParseResourcePointer("8"),
+			expectedMemLimit: mustParseResourcePointer("1000Mi"),
+			limitRange: &apiv1.LimitRangeItem{
+				Type: apiv1.LimitTypeContainer,
+				MaxLimitRequestRatio: apiv1.ResourceList{
+					apiv1.ResourceCPU:    resource.MustParse("4"),
+					apiv1.ResourceMemory: resource.MustParse("5"),
+				},
+			},
+			annotations: vpa_api_util.ContainerToAnnotationsMap{
+				containerName: []string{
+					"cpu: limit capped to fit MaxLimitRequestRatio in container LimitRange",
+					"memory: limit capped to fit MaxLimitRequestRatio in container LimitRange",
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -575,3 +598,55 @@ func TestGetContainersResources(t *testing.T) {
 		})
 	}
 }
+
+func TestGetContainersResourcesForPodWithSidecar(t *testing.T) {
+	pod := test.Pod().WithName("pod").
+		AddContainer(test.Container().WithName("container").WithCPURequest(resource.MustParse("1")).Get()).
+		AddInitContainer(test.Container().WithName("init").WithCPURequest(resource.MustParse("1")).Get()).
+		AddInitContainer(test.Container().WithName("sidecar").WithRestartPolicy(apiv1.ContainerRestartPolicyAlways).
+			WithCPURequest(resource.MustParse("100m")).Get()).Get()
+	vpa := test.VerticalPodAutoscaler().WithName("vpa").WithContainer("container").WithTarget("2", "200Mi").
+		AppendRecommendation(vpa_types.RecommendedContainerResources{
+			ContainerName: "sidecar",
+			Target:        test.Resources("500m", "50Mi"),
+		}).
+		AppendRecommendation(vpa_types.RecommendedContainerResources{
+			ContainerName: "init",
+			Target:        test.Resources("3", "300Mi"),
+		}).Get()
+
+	provider := NewProvider(limitrange.NewNoopLimitsCalculator(), vpa_api_util.NewCappingRecommendationProcessor(limitrange.NewNoopLimitsCalculator()))
+	resources, _, err := provider.GetContainersResourcesForPod(pod, vpa)
+	assert.NoError(t, err)
+	if assert.Len(t, resources, 2) {
+		assert.Equal(t, resource.MustParse("2"), resources[0].Requests[apiv1.ResourceCPU])
+		assert.Equal(t, resource.MustParse("500m"), resources[1].Requests[apiv1.ResourceCPU])
+	}
+}
+
+func TestGetContainersResourcesForPodWithExtendedResources(t *testing.T) {
+	hugePages := apiv1.ResourceName(apiv1.ResourceHugePagesPrefix + "2Mi")
+	gpuMemory := apiv1.ResourceName("nvidia.com/gpumem")
+	container := test.Container().WithName("container").WithCPURequest(resource.MustParse("1")).Get()
+	container.Resources.Requests[hugePages] = resource.MustParse("20Mi")
+	container.Resources.Limits = apiv1.ResourceList{hugePages: resource.MustParse("20Mi")}
+	pod := test.Pod().WithName("pod").AddContainer(container).Get()
+	target := test.Resources("2", "200Mi")
+	target[hugePages] = resource.MustParse("40Mi")
+	target[gpuMemory] = resource.MustParse("1Gi")
+	vpa := test.VerticalPodAutoscaler().WithName("vpa").WithContainer("other").
+		AppendRecommendation(vpa_types.RecommendedContainerResources{
+			ContainerName: "container",
+			Target:        target,
+		}).Get()
+
+	provider := NewProvider(limitrange.NewNoopLimitsCalculator(), vpa_api_util.NewCappingRecommendationProcessor(limitrange.NewNoopLimitsCalculator()))
+	resources, _, err := provider.GetContainersResourcesForPod(pod, vpa)
+	assert.NoError(t, err)
+	if assert.Len(t, resources, 1) {
+		assert.Equal(t, resource.MustParse("40Mi"), resources[0].Requests[hugePages])
+		assert.Equal(t, resource.MustParse("40Mi"), resources[0].Limits[hugePages])
+		assert.NotContains(t, resources[0].Requests, gpuMemory)
+		assert.NotContains(t, resources[0].Limits, gpuMemory)
+	}
+}
@@ -35,16 +35,22 @@ import (
 
 var (
 	possibleUpdateModes = map[vpa_types.UpdateMode]interface{}{
-		vpa_types.UpdateModeOff:      struct{}{},
-		vpa_types.UpdateModeInitial:  struct{}{},
-		vpa_types.UpdateModeRecreate: struct{}{},
-		vpa_types.UpdateModeAuto:     struct{}{},
+		vpa_types.UpdateModeOff:               struct{}{},
+		vpa_types.UpdateModeInitial:           struct{}{},
+		vpa_types.UpdateModeRecreate:          struct{}{},
+		vpa_types.UpdateModeAuto:              struct{}{},
+		vpa_types.UpdateModeInPlaceOrRecreate: struct{}{},
+		vpa_types.UpdateModeSurgeRecreate:     struct{}{},
 	}
 
 	possibleScalingModes = map[vpa_types.ContainerScalingMode]interface{}{
 		vpa_types.ContainerScalingModeAuto: struct{}{},
 		vpa_types.ContainerScalingModeOff:  struct{}{},
 	}
+	possibleOOMModes = map[vpa_types.OOMMode]interface{}{
+		vpa_types.OOMModeOff:             struct{}{},
+		vpa_types.OOMModeRaiseLowerBound: struct{}{},
+	}
 )
 
 // resourceHandler builds patches for VPAs.
@@ -159,6 +165,12 @@ func ValidateVPA(vpa *vpa_types.VerticalPodAutoscaler, isCreate bool) error {
 					return fmt.Errorf("ControlledValues shouldn't be specified if container scaling mode is off.")
 				}
 			}
+			if err := validatePercentiles(policy); err != nil {
+				return err
+			}
+			if err := validateOOMPolicy(policy.OOMPolicy); err != nil {
+				return fmt.Errorf("OOMPolicy: %v", err)
+			}
 		}
 	}
 
@@ -166,13 +178,72 @@ func ValidateVPA(vpa *vpa_types.VerticalPodAutoscaler, isCreate bool) error {
 		return fmt.Errorf("TargetRef is required. If you're using v1beta1 version of the API, please migrate to v1")
 	}
 
-	if len(vpa.Spec.Recommenders) > 1 {
-		return fmt.Errorf("The current version of VPA object shouldn't specify more than one recommenders.")
+	recommenders := make(map[string]bool)
+	for _, recommender := range vpa.Spec.Recommenders {
+		if recommender == nil || recommender.Name == "" {
+			return fmt.Errorf("recommender name is required")
+		}
+		if recommenders[recommender.Name] {
+			return fmt.Errorf("recommender %s is specified more than once", recommender.Name)
+		}
+		recommenders[recommender.Name] = true
 	}
 
 	return nil
 }
 
+func validatePercentiles(policy vpa_types.ContainerResourcePolicy) error {
+	percentiles := []struct {
+		name  string
+		value *float64
+	}{
+		{"LowerBoundPercentile", policy.LowerBoundPercentile},
+		{"TargetPercentile", policy.TargetPercentile},
+		{"UpperBoundPercentile", policy.UpperBoundPercentile},
+	}
+	for _, p := range percentiles {
+		if p.value != nil && (*p.value < 0 || *p.value > 1) {
+			return fmt.Errorf("%s has to be between 0 and 1, got %v", p.name, *p.value)
+		}
+	}
+	if policy.LowerBoundPercentile != nil && policy.TargetPercentile != nil && *policy.LowerBoundPercentile > *policy.TargetPercentile {
+		return fmt.Errorf("LowerBoundPercentile is higher than TargetPercentile")
+	}
+	if policy.TargetPercentile != nil && policy.UpperBoundPercentile != nil && *policy.TargetPercentile > *policy.UpperBoundPercentile {
+		return fmt.Errorf("TargetPercentile is higher than UpperBoundPercentile")
+	}
+	return nil
+}
+
+func validateOOMPolicy(policy *vpa_types.OOMPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	if policy.BumpUpRatio != nil && *policy.BumpUpRatio < 1 {
+		return fmt.Errorf("BumpUpRatio has to be at least 1, got %v", *policy.BumpUpRatio)
+	}
+	if policy.MinBumpUp != nil {
+		if policy.MinBumpUp.Sign() < 0 {
+			return fmt.Errorf("MinBumpUp can't be negative, got %v", policy.MinBumpUp)
+		}
+		if err := validateMemoryResolution(*policy.MinBumpUp); err != nil {
+			return err
+		}
+	}
+	if policy.Mode != nil {
+		if _, found := possibleOOMModes[*policy.Mode]; !found {
+			return fmt.Errorf("unexpected Mode value %s", *policy.Mode)
+		}
+	}
+	if policy.OOMKillCount != nil && *policy.OOMKillCount < 1 {
+		return fmt.Errorf("OOMKillCount has to be positive, got %v", *policy.OOMKillCount)
+	}
+	if policy.Window != nil && policy.Window.Duration <= 0 {
+		return fmt.Errorf("Window has to be positive, got %v", policy.Window.Duration)
+	}
+	return nil
+}
+
 func validateResourceResolution(name corev1.ResourceName, val apires.Quantity) error {
 	switch name {
 	case corev1.ResourceCPU:
@@ -19,10 +19,12 @@ package vpa
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	vpa_types "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
 )
@@ -42,6 +44,16 @@ func TestValidateVPA(t *testing.T) {
 	validScalingMode := vpa_types.ContainerScalingModeAuto
 	scalingModeOff := vpa_types.ContainerScalingModeOff
 	controlledValuesRequestsAndLimits := vpa_types.ContainerControlledValuesRequestsAndLimits
+	lowerBoundPercentile := 0.5
+	targetPercentile := 0.9
+	upperBoundPercentile := 0.95
+	invalidPercentile := 1.5
+	badOOMBumpUpRatio := 0.5
+	validOOMBumpUpRatio := 1.5
+	validOOMMinBumpUp := resource.MustParse("100Mi")
+	badOOMMode := vpa_types.OOMMode("bad")
+	validOOMMode := vpa_types.OOMModeRaiseLowerBound
+	badOOMKillCount := int32(0)
 	tests := []struct {
 		name        string
 		vpa         vpa_types.VerticalPodAutoscaler
@@ -130,7 +142,32 @@ func TestValidateVPA(t *testing.T) {
 					},
 				},
 			},
-			expectError: fmt.Errorf("The current version of VPA object shouldn't specify more than one recommenders."),
+		},
+		{
+			name: "duplicate recommender",
+			vpa: vpa_types.VerticalPodAutoscaler{
+				Spec: vpa_types.VerticalPodAutoscalerSpec{
+					UpdatePolicy: &vpa_types.PodUpdatePolicy{
+						UpdateMode: &validUpdateMode,
+					},
+					Recommenders: []*vpa_types.VerticalPodAutoscalerRecommenderSelector{
+						{Name: "test1"},
+						{Name: "test1"},
+					},
+				},
+			},
+			expectError: fmt.Errorf("recommender test1 is specified more than once"),
+		},
+		{
+			name: "empty recommender name",
+			vpa: vpa_types.VerticalPodAutoscaler{
+				Spec: vpa_types.VerticalPodAutoscalerSpec{
+					Recommenders: []*vpa_types.VerticalPodAutoscalerRecommenderSelector{
+						{Name: ""},
+					},
+				},
+			},
+			expectError: fmt.Errorf("recommender name is required"),
 		},
 		{
 			name: "bad limits",
@@ -254,6 +291,104 @@ func TestValidateVPA(t *testing.T) {
 			},
 			expectError: fmt.Errorf("ControlledValues shouldn't be specified if container scaling mode is off."),
 		},
+		{
+			name: "percentile out of range",
+			vpa: vpa_types.VerticalPodAutoscaler{
+				Spec: vpa_types.VerticalPodAutoscalerSpec{
+					ResourcePolicy: &vpa_types.PodResourcePolicy{
+						ContainerPolicies: []vpa_types.ContainerResourcePolicy{
+							{
+								ContainerName:    "loot box",
+								TargetPercentile: &invalidPercentile,
+							},
+						},
+					},
+				},
+			},
+			expectError: fmt.Errorf("TargetPercentile has to be between 0 and 1, got 1.5"),
+		},
+		{
+			name: "lower bound percentile higher than target",
+			vpa: vpa_types.VerticalPodAutoscaler{
+				Spec: vpa_types.VerticalPodAutoscalerSpec{
+					ResourcePolicy: &vpa_types.PodResourcePolicy{
+						ContainerPolicies: []vpa_types.ContainerResourcePolicy{
+							{
+								ContainerName:        "loot box",
+								LowerBoundPercentile: &upperBoundPercentile,
+								TargetPercentile:     &targetPercentile,
+							},
+						},
+					},
+				},
+			},
+			expectError: fmt.Errorf("LowerBoundPercentile is higher than TargetPercentile"),
+		},
+		{
+			name: "target percentile higher than upper bound",
+			vpa: vpa_types.VerticalPodAutoscaler{
+				Spec: vpa_types.VerticalPodAutoscalerSpec{
+					ResourcePolicy: &vpa_types.PodResourcePolicy{
+						ContainerPolicies: []vpa_types.ContainerResourcePolicy{
+							{
+								ContainerName:        "loot box",
+								TargetPercentile:     &upperBoundPercentile,
+								UpperBoundPercentile: &targetPercentile,
+							},
+						},
+					},
+				},
+			},
+			expectError: fmt.Errorf("TargetPercentile is higher than UpperBoundPercentile"),
+		},
+		{
+			name: "OOM bump up ratio below 1",
+			vpa: vpa_types.VerticalPodAutoscaler{
+				Spec: vpa_types.VerticalPodAutoscalerSpec{
+					ResourcePolicy: &vpa_types.PodResourcePolicy{
+						ContainerPolicies: []vpa_types.ContainerResourcePolicy{
+							{
+								ContainerName: "loot box",
+								OOMPolicy:     &vpa_types.OOMPolicy{BumpUpRatio: &badOOMBumpUpRatio},
+							},
+						},
+					},
+				},
+			},
+			expectError: fmt.Errorf("OOMPolicy: BumpUpRatio has to be at least 1, got 0.5"),
+		},
+		{
+			name: "invalid OOM mode",
+			vpa: vpa_types.VerticalPodAutoscaler{
+				Spec: vpa_types.VerticalPodAutoscalerSpec{
+					ResourcePolicy: &vpa_types.PodResourcePolicy{
+						ContainerPolicies: []vpa_types.ContainerResourcePolicy{
+							{
+								ContainerName: "loot box",
+								OOMPolicy:     &vpa_types.OOMPolicy{Mode: &badOOMMode},
+							},
+						},
+					},
+				},
+			},
+			expectError: fmt.Errorf("OOMPolicy: unexpected Mode value bad"),
+		},
+		{
+			name: "zero OOM kill count",
+			vpa: vpa_types.VerticalPodAutoscaler{
+				Spec: vpa_types.VerticalPodAutoscalerSpec{
+					ResourcePolicy: &vpa_types.PodResourcePolicy{
+						ContainerPolicies: []vpa_types.ContainerResourcePolicy{
+							{
+								ContainerName: "loot box",
+								OOMPolicy:     &vpa_types.OOMPolicy{OOMKillCount: &badOOMKillCount},
+							},
+						},
+					},
+				},
+			},
+			expectError: fmt.Errorf("OOMPolicy: OOMKillCount has to be positive, got 0"),
+		},
 		{
 			name: "all valid",
 			vpa: vpa_types.VerticalPodAutoscaler{
@@ -269,6 +404,16 @@ func TestValidateVPA(t *testing.T) {
 								MaxAllowed: apiv1.ResourceList{
 									cpu: resource.MustParse("100"),
 								},
+								LowerBoundPercentile: &lowerBoundPercentile,
+								TargetPercentile:     &targetPercentile,
+								UpperBoundPercentile: &upperBoundPercentile,
+								OOMPolicy: &vpa_types.OOMPolicy{
+									BumpUpRatio:  &validOOMBumpUpRatio,
+									MinBumpUp:    &validOOMMinBumpUp,
+									Mode:         &validOOMMode,
+									OOMKillCount: &validMinReplicas,
+									Window:       &metav1.Duration{Duration: time.Hour},
+								},
 							},
 						},
 					},
@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// namespaceShardLabel is the label on namespaces holding the index of the
+	// admission controller shard serving them.
+	namespaceShardLabel = "vpa.k8s.io/admission-controller-shard"
+)
+
+// namespaceShard returns the index of the shard serving the namespace, based
+// on the hash of its name.
+func namespaceShard(namespace string, shards int) int {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(namespace))
+	return int(hash.Sum32() % uint32(shards))
+}
+
+// shardServiceName returns the name of the service of the shard with the given index.
+func shardServiceName(serviceName string, shard int) string {
+	return fmt.Sprintf("%s-%d", serviceName, shard)
+}
+
+// shardWebhookName returns the name of the webhook of the shard with the given index.
+func shardWebhookName(shard int) string {
+	return fmt.Sprintf("shard-%d.%s", shard, webhookName)
+}
+
+// namespaceShardLabeler sets namespaceShardLabel on the namespaces served by
+// its shard, so that the webhook of the shard selects them. Each shard labels
+// only its own namespaces, so that the replicas of different shards never
+// patch the same namespace.
+type namespaceShardLabeler struct {
+	client typedcorev1.NamespaceInterface
+	shard  int
+	shards int
+}
+
+// run labels the namespaces of the shard as they are listed and whenever they change.
+func (l *namespaceShardLabeler) run(factory informers.SharedInformerFactory, stopCh <-chan struct{}) error {
+	informer := factory.Core().V1().Namespaces().Informer()
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { l.label(obj) },
+		UpdateFunc: func(_, obj interface{}) { l.label(obj) },
+	}); err != nil {
+		return err
+	}
+	go informer.Run(stopCh)
+	if !cache.WaitForCacheSync(stopCh, informer.HasSynced) {
+		return fmt.Errorf("namespace informer did not sync")
+	}
+	return nil
+}
+
+func (l *namespaceShardLabeler) label(obj interface{}) {
+	namespace, ok := obj.(*corev1.Namespace)
+	if !ok || namespaceShard(namespace.Name, l.shards) != l.shard {
+		return
+	}
+	shard := strconv.Itoa(l.shard)
+	if namespace.Labels[namespaceShardLabel] == shard {
+		return
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]string{namespaceShardLabel: shard},
+		},
+	})
+	if err != nil {
+		klog.ErrorS(err, "Cannot marshal namespace shard label patch")
+		return
+	}
+	if _, err := l.client.Patch(context.TODO(), namespace.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		klog.ErrorS(err, "Cannot label namespace with its admission controller shard", "namespace", namespace.Name, "shard", shard)
+		return
+	}
+	klog.V(3).InfoS("Labeled namespace with its admission controller shard", "namespace", namespace.Name, "shard", shard)
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNamespaceShard(t *testing.T) {
+	counts := make([]int, 4)
+	for i := 0; i < 1000; i++ {
+		namespace := fmt.Sprintf("namespace-%d", i)
+		shard := namespaceShard(namespace, 4)
+		assert.Equal(t, shard, namespaceShard(namespace, 4), "expected the shard to be stable")
+		if assert.True(t, shard >= 0 && shard < 4, "shard %d out of range", shard) {
+			counts[shard]++
+		}
+	}
+	for shard, count := range counts {
+		assert.Greater(t, count, 150, "expected namespaces to be spread between shards, got %d in shard %d", count, shard)
+	}
+	assert.Equal(t, 0, namespaceShard("default", 1))
+}
+
+func TestNamespaceShardLabeler(t *testing.T) {
+	shards := 3
+	var namespaces []*corev1.Namespace
+	for i := 0; i < 10; i++ {
+		namespaces = append(namespaces, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("namespace-%d", i)}})
+	}
+	testClientSet := fake.NewSimpleClientset()
+	for _, namespace := range namespaces {
+		_, err := testClientSet.CoreV1().Namespaces().Create(context.TODO(), namespace, metav1.CreateOptions{})
+		assert.NoError(t, err)
+	}
+
+	labeler := &namespaceShardLabeler{client: testClientSet.CoreV1().Namespaces(), shard: 1, shards: shards}
+	for _, namespace := range namespaces {
+		labeler.label(namespace)
+	}
+
+	for _, namespace := range namespaces {
+		labeled, err := testClientSet.CoreV1().Namespaces().Get(context.TODO(), namespace.Name, metav1.GetOptions{})
+		assert.NoError(t, err)
+		shard, found := labeled.Labels[namespaceShardLabel]
+		if namespaceShard(namespace.Name, shards) == 1 {
+			assert.True(t, found, "expected namespace %s to be labeled", namespace.Name)
+			assert.Equal(t, strconv.Itoa(1), shard)
+		} else {
+			assert.False(t, found, "expected namespace %s of another shard not to be labeled", namespace.Name)
+		}
+	}
+}
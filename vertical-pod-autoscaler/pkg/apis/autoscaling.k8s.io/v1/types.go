@@ -19,6 +19,7 @@ package v1
 import (
 	autoscaling "k8s.io/api/autoscaling/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -102,9 +103,12 @@ type VerticalPodAutoscalerSpec struct {
 	// +optional
 	ResourcePolicy *PodResourcePolicy `json:"resourcePolicy,omitempty" protobuf:"bytes,3,opt,name=resourcePolicy"`
 
-	// Recommender responsible for generating recommendation for this object.
+	// Recommenders responsible for generating recommendation for this object.
 	// List should be empty (then the default recommender will generate the
-	// recommendation) or contain exactly one recommender.
+	// recommendation) or contain recommenders in the order of preference.
+	// The first recommender generates the recommendation. If the active
+	// recommender stops updating the recommendation for the failover period of
+	// the recommenders, the next recommender in the list takes over.
 	// +optional
 	Recommenders []*VerticalPodAutoscalerRecommenderSelector `json:"recommenders,omitempty" protobuf:"bytes,4,opt,name=recommenders"`
 }
@@ -151,7 +155,7 @@ type PodUpdatePolicy struct {
 }
 
 // UpdateMode controls when autoscaler applies changes to the pod resources.
-// +kubebuilder:validation:Enum=Off;Initial;Recreate;Auto
+// +kubebuilder:validation:Enum=Off;Initial;Recreate;Auto;InPlaceOrRecreate;SurgeRecreate
 type UpdateMode string
 
 const (
@@ -171,6 +175,16 @@ const (
 	// using any available update method. Currently this is equivalent to
 	// Recreate, which is the only available update method.
 	UpdateModeAuto UpdateMode = "Auto"
+	// UpdateModeInPlaceOrRecreate means that autoscaler assigns resources on
+	// pod creation and additionally can update them during the lifetime of
+	// the pod by resizing it in place, if the cluster supports it. The pod is
+	// deleted and recreated only if the resources can't be changed in place.
+	UpdateModeInPlaceOrRecreate UpdateMode = "InPlaceOrRecreate"
+	// UpdateModeSurgeRecreate means that autoscaler assigns resources on pod
+	// creation and additionally can update them during the lifetime of the
+	// pod by deleting and recreating the pod, after temporarily scaling up its
+	// workload by one replica, so that the workload doesn't lose capacity.
+	UpdateModeSurgeRecreate UpdateMode = "SurgeRecreate"
 )
 
 // PodResourcePolicy controls how autoscaler computes the recommended resources
@@ -214,6 +228,32 @@ type ContainerResourcePolicy struct {
 	// The default is "RequestsAndLimits".
 	// +optional
 	ControlledValues *ContainerControlledValues `json:"controlledValues,omitempty" protobuf:"bytes,6,rep,name=controlledValues"`
+
+	// Usage percentile used as a base for the target recommendation of the
+	// container, between 0 and 1. The default is set by the recommender.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1
+	TargetPercentile *float64 `json:"targetPercentile,omitempty" protobuf:"fixed64,7,opt,name=targetPercentile"`
+
+	// Usage percentile used for the lower bound recommendation of the
+	// container, between 0 and 1. The default is set by the recommender.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1
+	LowerBoundPercentile *float64 `json:"lowerBoundPercentile,omitempty" protobuf:"fixed64,8,opt,name=lowerBoundPercentile"`
+
+	// Usage percentile used for the upper bound recommendation of the
+	// container, between 0 and 1. The default is set by the recommender.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1
+	UpperBoundPercentile *float64 `json:"upperBoundPercentile,omitempty" protobuf:"fixed64,9,opt,name=upperBoundPercentile"`
+
+	// Controls how the memory recommendation reacts to the container being
+	// OOM killed. The defaults are set by the recommender.
+	// +optional
+	OOMPolicy *OOMPolicy `json:"oomPolicy,omitempty" protobuf:"bytes,10,opt,name=oomPolicy"`
 }
 
 const (
@@ -246,6 +286,54 @@ const (
 	ContainerControlledValuesRequestsOnly ContainerControlledValues = "RequestsOnly"
 )
 
+// OOMPolicy controls how the memory recommendation of a container reacts to
+// the container being OOM killed. After each OOM kill, the memory used by the
+// container is bumped up and added as a memory usage sample.
+type OOMPolicy struct {
+	// Ratio by which the memory used by the container is bumped up after it
+	// was OOM killed. The default is set by the recommender.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	BumpUpRatio *float64 `json:"bumpUpRatio,omitempty" protobuf:"fixed64,1,opt,name=bumpUpRatio"`
+
+	// Minimal increase of the memory used by the container after it was
+	// OOM killed. The default is set by the recommender.
+	// +optional
+	MinBumpUp *resource.Quantity `json:"minBumpUp,omitempty" protobuf:"bytes,2,opt,name=minBumpUp"`
+
+	// Whether the memory lower bound is raised after repeated OOM kills.
+	// The default is "Off".
+	// +optional
+	Mode *OOMMode `json:"mode,omitempty" protobuf:"bytes,3,opt,name=mode"`
+
+	// Number of OOM kills within the window after which the memory lower
+	// bound is raised in "RaiseLowerBound" mode. The default is 3.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	OOMKillCount *int32 `json:"oomKillCount,omitempty" protobuf:"varint,4,opt,name=oomKillCount"`
+
+	// Period in which OOM kills are counted. The raised memory lower bound
+	// is kept until there were no OOM kills for this long. The default is 1h.
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty" protobuf:"bytes,5,opt,name=window"`
+}
+
+// OOMMode controls whether the memory lower bound is raised after repeated
+// OOM kills.
+// +kubebuilder:validation:Enum=Off;RaiseLowerBound
+type OOMMode string
+
+const (
+	// OOMModeOff means OOM kills only bump up the memory usage samples.
+	OOMModeOff OOMMode = "Off"
+	// OOMModeRaiseLowerBound means that after OOMKillCount OOM kills within
+	// the window, the memory recommendation, including the lower bound, is
+	// raised to at least the memory bumped up after the OOM kills. This stops
+	// crash loops of containers whose memory usage grows faster than the
+	// usage samples catch up.
+	OOMModeRaiseLowerBound OOMMode = "RaiseLowerBound"
+)
+
 // VerticalPodAutoscalerStatus describes the runtime state of the autoscaler.
 type VerticalPodAutoscalerStatus struct {
 	// The most recently computed amount of resources recommended by the
@@ -259,6 +347,23 @@ type VerticalPodAutoscalerStatus struct {
 	// +patchMergeKey=type
 	// +patchStrategy=merge
 	Conditions []VerticalPodAutoscalerCondition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
+
+	// Name of the recommender currently generating the recommendation, set
+	// only if more than one recommender is specified.
+	// +optional
+	Recommender string `json:"recommender,omitempty" protobuf:"bytes,3,opt,name=recommender"`
+
+	// The last time the recommender updated the recommendation, set only if
+	// more than one recommender is specified. Standby recommenders use it to
+	// detect that the active recommender stopped working.
+	// +optional
+	LastRecommendationTime *metav1.Time `json:"lastRecommendationTime,omitempty" protobuf:"bytes,4,opt,name=lastRecommendationTime"`
+
+	// Explanation of how the recommendation was computed from the usage
+	// history of the containers. Set only if the recommender is configured
+	// to report recommendation details.
+	// +optional
+	RecommendationDetails *RecommendationDetails `json:"recommendationDetails,omitempty" protobuf:"bytes,5,opt,name=recommendationDetails"`
 }
 
 // RecommendedPodResources is the recommendation of resources computed by
@@ -300,6 +405,83 @@ type RecommendedContainerResources struct {
 	UncappedTarget v1.ResourceList `json:"uncappedTarget,omitempty" protobuf:"bytes,5,opt,name=uncappedTarget"`
 }
 
+// RecommendationDetails explains how the recommendation was computed.
+type RecommendationDetails struct {
+	// Details of the recommendation for each container.
+	// +optional
+	ContainerDetails []ContainerRecommendationDetails `json:"containerDetails,omitempty" protobuf:"bytes,1,rep,name=containerDetails"`
+}
+
+// ContainerRecommendationDetails explains how the recommendation for a
+// container was computed from its usage history. The values describe the
+// estimation before the ContainerResourcePolicy, LimitRanges and the minimal
+// pod resources are applied.
+type ContainerRecommendationDetails struct {
+	// Name of the container.
+	ContainerName string `json:"containerName,omitempty" protobuf:"bytes,1,opt,name=containerName"`
+	// Number of CPU usage samples in the usage history of the container.
+	// +optional
+	TotalSamplesCount int32 `json:"totalSamplesCount,omitempty" protobuf:"varint,2,opt,name=totalSamplesCount"`
+	// Start of the first CPU usage sample in the usage history.
+	// +optional
+	FirstSampleStart *metav1.Time `json:"firstSampleStart,omitempty" protobuf:"bytes,3,opt,name=firstSampleStart"`
+	// Start of the last CPU usage sample in the usage history.
+	// +optional
+	LastSampleStart *metav1.Time `json:"lastSampleStart,omitempty" protobuf:"bytes,4,opt,name=lastSampleStart"`
+	// The last OOM kill of the container taken into account by the memory
+	// recommendation, if any.
+	// +optional
+	LastOOMKill *OOMKillDetails `json:"lastOOMKill,omitempty" protobuf:"bytes,5,opt,name=lastOOMKill"`
+	// Details of the recommendation for each resource.
+	// +optional
+	Resources []ResourceRecommendationDetails `json:"resources,omitempty" protobuf:"bytes,6,rep,name=resources"`
+}
+
+// OOMKillDetails describes an OOM kill of a container.
+type OOMKillDetails struct {
+	// Time of the OOM kill.
+	Time metav1.Time `json:"time" protobuf:"bytes,1,opt,name=time"`
+	// Memory the container needed after the OOM kill, i.e. the memory it used
+	// bumped up according to the OOMPolicy. It is added as a memory usage sample.
+	MemoryNeeded resource.Quantity `json:"memoryNeeded" protobuf:"bytes,2,opt,name=memoryNeeded"`
+	// Whether repeated OOM kills raised the memory lower bound to MemoryNeeded
+	// or more, because the OOMPolicy mode is RaiseLowerBound.
+	// +optional
+	RaisedLowerBound bool `json:"raisedLowerBound,omitempty" protobuf:"varint,3,opt,name=raisedLowerBound"`
+}
+
+// ResourceRecommendationDetails explains how the recommendation of a resource
+// for a container was computed. The safety margin is added to the usage at the
+// percentiles, and the lower and upper bounds are scaled by a multiplier
+// derived from the confidence, so that they are wider for a short usage history.
+type ResourceRecommendationDetails struct {
+	// Name of the resource.
+	Resource v1.ResourceName `json:"resource" protobuf:"bytes,1,opt,name=resource,casttype=ResourceName"`
+	// Usage percentile the target recommendation is based on.
+	TargetPercentile float64 `json:"targetPercentile" protobuf:"fixed64,2,opt,name=targetPercentile"`
+	// Usage of the resource at TargetPercentile.
+	TargetPercentileUsage resource.Quantity `json:"targetPercentileUsage" protobuf:"bytes,3,opt,name=targetPercentileUsage"`
+	// Usage percentile the lower bound recommendation is based on.
+	LowerBoundPercentile float64 `json:"lowerBoundPercentile" protobuf:"fixed64,4,opt,name=lowerBoundPercentile"`
+	// Usage of the resource at LowerBoundPercentile.
+	LowerBoundPercentileUsage resource.Quantity `json:"lowerBoundPercentileUsage" protobuf:"bytes,5,opt,name=lowerBoundPercentileUsage"`
+	// Usage percentile the upper bound recommendation is based on.
+	UpperBoundPercentile float64 `json:"upperBoundPercentile" protobuf:"fixed64,6,opt,name=upperBoundPercentile"`
+	// Usage of the resource at UpperBoundPercentile.
+	UpperBoundPercentileUsage resource.Quantity `json:"upperBoundPercentileUsage" protobuf:"bytes,7,opt,name=upperBoundPercentileUsage"`
+	// The interval in which the usage history is measured for Confidence.
+	// Not set for resources whose bounds don't depend on the confidence.
+	// +optional
+	ConfidenceInterval *metav1.Duration `json:"confidenceInterval,omitempty" protobuf:"bytes,8,opt,name=confidenceInterval"`
+	// Heuristic measure of the amount of usage history, equal to the number of
+	// ConfidenceIntervals covered by the history when there is one sample per
+	// minute. The lower and upper bounds get closer to the usage at their
+	// percentiles as it grows. Not set for resources whose bounds don't depend
+	// on the confidence.
+	// +optional
+	Confidence *float64 `json:"confidence,omitempty" protobuf:"fixed64,9,opt,name=confidence"`
+}
+
 // VerticalPodAutoscalerConditionType are the valid conditions of
 // a VerticalPodAutoscaler.
 type VerticalPodAutoscalerConditionType string
@@ -320,6 +502,14 @@ var (
 	// ConfigUnsupported indicates that this VPA configuration is unsupported
 	// and recommendations will not be provided for it.
 	ConfigUnsupported VerticalPodAutoscalerConditionType = "ConfigUnsupported"
+	// RecommendationCappedByLimitRange indicates that the recommendation was
+	// capped to fit the LimitRange in the namespace of the VPA. The message
+	// describes which containers and resources were capped and by what.
+	RecommendationCappedByLimitRange VerticalPodAutoscalerConditionType = "RecommendationCappedByLimitRange"
+	// RecommenderSwitched indicates that a standby recommender took over
+	// generating the recommendation, because the previously active recommender
+	// stopped updating it. The message names both recommenders.
+	RecommenderSwitched VerticalPodAutoscalerConditionType = "RecommenderSwitched"
 )
 
 // VerticalPodAutoscalerCondition describes the state of
@@ -424,3 +614,88 @@ type HistogramCheckpoint struct {
 	// Sum of samples to be used as denominator for weights from BucketWeights.
 	TotalWeight float64 `json:"totalWeight,omitempty" protobuf:"bytes,3,opt,name=totalWeight"`
 }
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:storageversion
+// +kubebuilder:resource:scope=Cluster,shortName=vpapolicy
+// +kubebuilder:metadata:annotations="api-approved.kubernetes.io=https://github.com/kubernetes/kubernetes/pull/63797"
+
+// VerticalPodAutoscalerPolicy is a cluster-scoped policy that clamps or
+// transforms the recommended resources set on pods by the admission controller.
+type VerticalPodAutoscalerPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// Specification of the policy.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#spec-and-status.
+	Spec VerticalPodAutoscalerPolicySpec `json:"spec" protobuf:"bytes,2,name=spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VerticalPodAutoscalerPolicyList is a list of VerticalPodAutoscalerPolicy objects.
+type VerticalPodAutoscalerPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []VerticalPodAutoscalerPolicy `json:"items"`
+}
+
+// VerticalPodAutoscalerPolicySpec is the specification of the policy object.
+// The expressions of the policy are written in the Common Expression Language
+// (CEL), and have access to the following variables:
+//   - podNamespace: the namespace of the pod,
+//   - podLabels: the labels of the pod,
+//   - vpaName: the name of the VPA object controlling the pod,
+//   - containerName: the name of the container,
+//   - cpuRequest, cpuLimit: the recommended CPU request and limit in
+//     millicores, 0 if not set,
+//   - memoryRequest, memoryLimit: the recommended memory request and limit in
+//     bytes, 0 if not set.
+//
+// The functions cpu('500m') and memory('256Mi') return the amount of a
+// quantity in millicores and bytes, and the functions of the CEL math
+// extension, e.g. math.greatest(), are available.
+type VerticalPodAutoscalerPolicySpec struct {
+	// MatchCondition is a CEL expression evaluating to a boolean, selecting the
+	// containers to which the policy applies. The policy applies to all
+	// containers if it's empty.
+	// +optional
+	MatchCondition string `json:"matchCondition,omitempty" protobuf:"bytes,1,opt,name=matchCondition"`
+
+	// Rules setting the recommended resources of the matching containers.
+	// Rules are applied in order, each rule sees the resources set by the
+	// previous ones.
+	// +kubebuilder:validation:MinItems=1
+	Rules []RecommendationPolicyRule `json:"rules" protobuf:"bytes,2,rep,name=rules"`
+}
+
+// RecommendationPolicyRule sets a recommended request or limit of a container
+// to the result of a CEL expression.
+type RecommendationPolicyRule struct {
+	// Resource set by the rule.
+	// +kubebuilder:validation:Enum=cpu;memory
+	Resource v1.ResourceName `json:"resource" protobuf:"bytes,1,name=resource"`
+
+	// Target of the rule, either the request or the limit of the resource.
+	Target RecommendationPolicyTarget `json:"target" protobuf:"bytes,2,name=target"`
+
+	// Expression is a CEL expression evaluating to the new amount of the
+	// resource, in millicores for CPU and in bytes for memory. A limit is
+	// removed if the expression evaluates to 0.
+	Expression string `json:"expression" protobuf:"bytes,3,name=expression"`
+}
+
+// RecommendationPolicyTarget is the part of the resource requirements set by a
+// recommendation policy rule.
+// +kubebuilder:validation:Enum=Request;Limit
+type RecommendationPolicyTarget string
+
+const (
+	// RecommendationPolicyTargetRequest means that the rule sets the request.
+	RecommendationPolicyTargetRequest RecommendationPolicyTarget = "Request"
+	// RecommendationPolicyTargetLimit means that the rule sets the limit.
+	RecommendationPolicyTargetLimit RecommendationPolicyTarget = "Limit"
+)
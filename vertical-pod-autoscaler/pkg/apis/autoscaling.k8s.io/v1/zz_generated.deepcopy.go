@@ -24,9 +24,46 @@ package v1
 import (
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerRecommendationDetails) DeepCopyInto(out *ContainerRecommendationDetails) {
+	*out = *in
+	if in.FirstSampleStart != nil {
+		in, out := &in.FirstSampleStart, &out.FirstSampleStart
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSampleStart != nil {
+		in, out := &in.LastSampleStart, &out.LastSampleStart
+		*out = (*in).DeepCopy()
+	}
+	if in.LastOOMKill != nil {
+		in, out := &in.LastOOMKill, &out.LastOOMKill
+		*out = new(OOMKillDetails)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceRecommendationDetails, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerRecommendationDetails.
+func (in *ContainerRecommendationDetails) DeepCopy() *ContainerRecommendationDetails {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerRecommendationDetails)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerResourcePolicy) DeepCopyInto(out *ContainerResourcePolicy) {
 	*out = *in
@@ -63,6 +100,26 @@ func (in *ContainerResourcePolicy) DeepCopyInto(out *ContainerResourcePolicy) {
 		*out = new(ContainerControlledValues)
 		**out = **in
 	}
+	if in.TargetPercentile != nil {
+		in, out := &in.TargetPercentile, &out.TargetPercentile
+		*out = new(float64)
+		**out = **in
+	}
+	if in.LowerBoundPercentile != nil {
+		in, out := &in.LowerBoundPercentile, &out.LowerBoundPercentile
+		*out = new(float64)
+		**out = **in
+	}
+	if in.UpperBoundPercentile != nil {
+		in, out := &in.UpperBoundPercentile, &out.UpperBoundPercentile
+		*out = new(float64)
+		**out = **in
+	}
+	if in.OOMPolicy != nil {
+		in, out := &in.OOMPolicy, &out.OOMPolicy
+		*out = new(OOMPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -121,6 +178,65 @@ func (in *HistogramCheckpoint) DeepCopy() *HistogramCheckpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OOMKillDetails) DeepCopyInto(out *OOMKillDetails) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	out.MemoryNeeded = in.MemoryNeeded.DeepCopy()
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OOMKillDetails.
+func (in *OOMKillDetails) DeepCopy() *OOMKillDetails {
+	if in == nil {
+		return nil
+	}
+	out := new(OOMKillDetails)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OOMPolicy) DeepCopyInto(out *OOMPolicy) {
+	*out = *in
+	if in.BumpUpRatio != nil {
+		in, out := &in.BumpUpRatio, &out.BumpUpRatio
+		*out = new(float64)
+		**out = **in
+	}
+	if in.MinBumpUp != nil {
+		in, out := &in.MinBumpUp, &out.MinBumpUp
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(OOMMode)
+		**out = **in
+	}
+	if in.OOMKillCount != nil {
+		in, out := &in.OOMKillCount, &out.OOMKillCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OOMPolicy.
+func (in *OOMPolicy) DeepCopy() *OOMPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(OOMPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodResourcePolicy) DeepCopyInto(out *PodResourcePolicy) {
 	*out = *in
@@ -181,6 +297,45 @@ func (in *PodUpdatePolicy) DeepCopy() *PodUpdatePolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecommendationDetails) DeepCopyInto(out *RecommendationDetails) {
+	*out = *in
+	if in.ContainerDetails != nil {
+		in, out := &in.ContainerDetails, &out.ContainerDetails
+		*out = make([]ContainerRecommendationDetails, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecommendationDetails.
+func (in *RecommendationDetails) DeepCopy() *RecommendationDetails {
+	if in == nil {
+		return nil
+	}
+	out := new(RecommendationDetails)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecommendationPolicyRule) DeepCopyInto(out *RecommendationPolicyRule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecommendationPolicyRule.
+func (in *RecommendationPolicyRule) DeepCopy() *RecommendationPolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(RecommendationPolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RecommendedContainerResources) DeepCopyInto(out *RecommendedContainerResources) {
 	*out = *in
@@ -248,6 +403,35 @@ func (in *RecommendedPodResources) DeepCopy() *RecommendedPodResources {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRecommendationDetails) DeepCopyInto(out *ResourceRecommendationDetails) {
+	*out = *in
+	out.TargetPercentileUsage = in.TargetPercentileUsage.DeepCopy()
+	out.LowerBoundPercentileUsage = in.LowerBoundPercentileUsage.DeepCopy()
+	out.UpperBoundPercentileUsage = in.UpperBoundPercentileUsage.DeepCopy()
+	if in.ConfidenceInterval != nil {
+		in, out := &in.ConfidenceInterval, &out.ConfidenceInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Confidence != nil {
+		in, out := &in.Confidence, &out.Confidence
+		*out = new(float64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRecommendationDetails.
+func (in *ResourceRecommendationDetails) DeepCopy() *ResourceRecommendationDetails {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRecommendationDetails)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VerticalPodAutoscaler) DeepCopyInto(out *VerticalPodAutoscaler) {
 	*out = *in
@@ -424,6 +608,87 @@ func (in *VerticalPodAutoscalerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerticalPodAutoscalerPolicy) DeepCopyInto(out *VerticalPodAutoscalerPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerticalPodAutoscalerPolicy.
+func (in *VerticalPodAutoscalerPolicy) DeepCopy() *VerticalPodAutoscalerPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(VerticalPodAutoscalerPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VerticalPodAutoscalerPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerticalPodAutoscalerPolicyList) DeepCopyInto(out *VerticalPodAutoscalerPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VerticalPodAutoscalerPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerticalPodAutoscalerPolicyList.
+func (in *VerticalPodAutoscalerPolicyList) DeepCopy() *VerticalPodAutoscalerPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(VerticalPodAutoscalerPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VerticalPodAutoscalerPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerticalPodAutoscalerPolicySpec) DeepCopyInto(out *VerticalPodAutoscalerPolicySpec) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]RecommendationPolicyRule, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerticalPodAutoscalerPolicySpec.
+func (in *VerticalPodAutoscalerPolicySpec) DeepCopy() *VerticalPodAutoscalerPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VerticalPodAutoscalerPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VerticalPodAutoscalerRecommenderSelector) DeepCopyInto(out *VerticalPodAutoscalerRecommenderSelector) {
 	*out = *in
@@ -497,6 +762,15 @@ func (in *VerticalPodAutoscalerStatus) DeepCopyInto(out *VerticalPodAutoscalerSt
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastRecommendationTime != nil {
+		in, out := &in.LastRecommendationTime, &out.LastRecommendationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RecommendationDetails != nil {
+		in, out := &in.RecommendationDetails, &out.RecommendationDetails
+		*out = new(RecommendationDetails)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
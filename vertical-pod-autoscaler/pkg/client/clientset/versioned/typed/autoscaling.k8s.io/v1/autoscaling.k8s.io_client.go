@@ -30,6 +30,7 @@ type AutoscalingV1Interface interface {
 	RESTClient() rest.Interface
 	VerticalPodAutoscalersGetter
 	VerticalPodAutoscalerCheckpointsGetter
+	VerticalPodAutoscalerPoliciesGetter
 }
 
 // AutoscalingV1Client is used to interact with features provided by the autoscaling.k8s.io group.
@@ -45,6 +46,10 @@ func (c *AutoscalingV1Client) VerticalPodAutoscalerCheckpoints(namespace string)
 	return newVerticalPodAutoscalerCheckpoints(c, namespace)
 }
 
+func (c *AutoscalingV1Client) VerticalPodAutoscalerPolicies() VerticalPodAutoscalerPolicyInterface {
+	return newVerticalPodAutoscalerPolicies(c)
+}
+
 // NewForConfig creates a new AutoscalingV1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
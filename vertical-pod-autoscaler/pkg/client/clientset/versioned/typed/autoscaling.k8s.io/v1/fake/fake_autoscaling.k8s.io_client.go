@@ -36,6 +36,10 @@ func (c *FakeAutoscalingV1) VerticalPodAutoscalerCheckpoints(namespace string) v
 	return newFakeVerticalPodAutoscalerCheckpoints(c, namespace)
 }
 
+func (c *FakeAutoscalingV1) VerticalPodAutoscalerPolicies() v1.VerticalPodAutoscalerPolicyInterface {
+	return newFakeVerticalPodAutoscalerPolicies(c)
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeAutoscalingV1) RESTClient() rest.Interface {
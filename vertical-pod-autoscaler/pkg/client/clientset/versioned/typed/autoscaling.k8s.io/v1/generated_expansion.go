@@ -21,3 +21,5 @@ package v1
 type VerticalPodAutoscalerExpansion interface{}
 
 type VerticalPodAutoscalerCheckpointExpansion interface{}
+
+type VerticalPodAutoscalerPolicyExpansion interface{}
@@ -28,6 +28,8 @@ type Interface interface {
 	VerticalPodAutoscalers() VerticalPodAutoscalerInformer
 	// VerticalPodAutoscalerCheckpoints returns a VerticalPodAutoscalerCheckpointInformer.
 	VerticalPodAutoscalerCheckpoints() VerticalPodAutoscalerCheckpointInformer
+	// VerticalPodAutoscalerPolicies returns a VerticalPodAutoscalerPolicyInformer.
+	VerticalPodAutoscalerPolicies() VerticalPodAutoscalerPolicyInformer
 }
 
 type version struct {
@@ -50,3 +52,8 @@ func (v *version) VerticalPodAutoscalers() VerticalPodAutoscalerInformer {
 func (v *version) VerticalPodAutoscalerCheckpoints() VerticalPodAutoscalerCheckpointInformer {
 	return &verticalPodAutoscalerCheckpointInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// VerticalPodAutoscalerPolicies returns a VerticalPodAutoscalerPolicyInformer.
+func (v *version) VerticalPodAutoscalerPolicies() VerticalPodAutoscalerPolicyInformer {
+	return &verticalPodAutoscalerPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
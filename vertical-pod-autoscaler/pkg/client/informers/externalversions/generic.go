@@ -60,6 +60,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Autoscaling().V1().VerticalPodAutoscalers().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("verticalpodautoscalercheckpoints"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Autoscaling().V1().VerticalPodAutoscalerCheckpoints().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("verticalpodautoscalerpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Autoscaling().V1().VerticalPodAutoscalerPolicies().Informer()}, nil
 
 		// Group=autoscaling.k8s.io, Version=v1beta1
 	case v1beta1.SchemeGroupVersion.WithResource("verticalpodautoscalers"):
@@ -33,3 +33,7 @@ type VerticalPodAutoscalerCheckpointListerExpansion interface{}
 // VerticalPodAutoscalerCheckpointNamespaceListerExpansion allows custom methods to be added to
 // VerticalPodAutoscalerCheckpointNamespaceLister.
 type VerticalPodAutoscalerCheckpointNamespaceListerExpansion interface{}
+
+// VerticalPodAutoscalerPolicyListerExpansion allows custom methods to be added to
+// VerticalPodAutoscalerPolicyLister.
+type VerticalPodAutoscalerPolicyListerExpansion interface{}
@@ -91,6 +91,13 @@ type ClusterStateFeederFactory struct {
 	RecommenderName     string
 	IgnoredNamespaces   []string
 	VpaObjectNamespace  string
+	// RecommendSidecarContainers enables tracking usage of restartable init
+	// (sidecar) containers, so that they receive recommendations.
+	RecommendSidecarContainers bool
+	// RecommenderFailoverPeriod is how long the active recommender of a VPA
+	// object specifying more than one recommender may not update the
+	// recommendation before the next recommender takes over. 0 disables failover.
+	RecommenderFailoverPeriod time.Duration
 }
 
 // Make creates new ClusterStateFeeder with internal data providers, based on kube client.
@@ -109,6 +116,9 @@ func (m ClusterStateFeederFactory) Make() *clusterStateFeeder {
 		recommenderName:     m.RecommenderName,
 		ignoredNamespaces:   m.IgnoredNamespaces,
 		vpaObjectNamespace:  m.VpaObjectNamespace,
+
+		recommendSidecarContainers: m.RecommendSidecarContainers,
+		recommenderFailoverPeriod:  m.RecommenderFailoverPeriod,
 	}
 }
 
@@ -215,6 +225,9 @@ type clusterStateFeeder struct {
 	recommenderName     string
 	ignoredNamespaces   []string
 	vpaObjectNamespace  string
+
+	recommendSidecarContainers bool
+	recommenderFailoverPeriod  time.Duration
 }
 
 func (feeder *clusterStateFeeder) InitFromHistoryProvider(historyProvider history.HistoryProvider) {
@@ -376,12 +389,43 @@ func selectsRecommender(selectors []*vpa_types.VerticalPodAutoscalerRecommenderS
 	return false
 }
 
+// activeRecommender returns the name of the recommender which should generate
+// the recommendation for a VPA object specifying more than one recommender,
+// and for how long the recommendation wasn't updated. The recommender in the
+// status is active, or the first one if none is. Once the recommendation wasn't
+// updated for the failover period, the next recommenders in the list take turns
+// to take over, each for one failover period.
+func activeRecommender(vpa *vpa_types.VerticalPodAutoscaler, failoverPeriod time.Duration, now time.Time) (string, time.Duration) {
+	recommenders := vpa.Spec.Recommenders
+	active := 0
+	for i, recommender := range recommenders {
+		if recommender.Name == vpa.Status.Recommender {
+			active = i
+		}
+	}
+	lastRecommendationTime := vpa.CreationTimestamp.Time
+	if vpa.Status.LastRecommendationTime != nil {
+		lastRecommendationTime = vpa.Status.LastRecommendationTime.Time
+	}
+	staleness := now.Sub(lastRecommendationTime)
+	if failoverPeriod > 0 && staleness >= failoverPeriod {
+		active = (active + int(staleness/failoverPeriod)) % len(recommenders)
+	}
+	return recommenders[active].Name, staleness
+}
+
 // Filter VPA objects whose specified recommender names are not default
 func filterVPAs(feeder *clusterStateFeeder, allVpaCRDs []*vpa_types.VerticalPodAutoscaler) []*vpa_types.VerticalPodAutoscaler {
 	klog.V(3).InfoS("Start selecting the vpaCRDs.")
+	now := time.Now()
 	var vpaCRDs []*vpa_types.VerticalPodAutoscaler
 	for _, vpaCRD := range allVpaCRDs {
-		if feeder.recommenderName == DefaultRecommenderName {
+		if len(vpaCRD.Spec.Recommenders) > 1 {
+			if active, _ := activeRecommender(vpaCRD, feeder.recommenderFailoverPeriod, now); active != feeder.recommenderName {
+				klog.V(6).InfoS("Ignoring vpaCRD as another of its recommenders is active", "vpaCRD", klog.KObj(vpaCRD), "recommenderName", feeder.recommenderName, "activeRecommender", active)
+				continue
+			}
+		} else if feeder.recommenderName == DefaultRecommenderName {
 			if !implicitDefaultRecommender(vpaCRD.Spec.Recommenders) && !selectsRecommender(vpaCRD.Spec.Recommenders, &feeder.recommenderName) {
 				klog.V(6).InfoS("Ignoring vpaCRD as current recommender's name doesn't appear among its recommenders", "vpaCRD", klog.KObj(vpaCRD), "recommenderName", feeder.recommenderName)
 				continue
@@ -407,6 +451,28 @@ func filterVPAs(feeder *clusterStateFeeder, allVpaCRDs []*vpa_types.VerticalPodA
 	return vpaCRDs
 }
 
+// setActiveRecommender records this recommender as the one generating the
+// recommendation for VPA objects specifying more than one recommender. If it
+// takes over from another recommender, the RecommenderSwitched condition is set.
+func (feeder *clusterStateFeeder) setActiveRecommender(vpaCRD *vpa_types.VerticalPodAutoscaler, vpa *model.Vpa) {
+	if len(vpaCRD.Spec.Recommenders) <= 1 {
+		vpa.Recommender = ""
+		delete(vpa.Conditions, vpa_types.RecommenderSwitched)
+		return
+	}
+	previous := vpaCRD.Status.Recommender
+	if !selectsRecommender(vpaCRD.Spec.Recommenders, &previous) {
+		previous = vpaCRD.Spec.Recommenders[0].Name
+	}
+	if previous != feeder.recommenderName {
+		_, staleness := activeRecommender(vpaCRD, feeder.recommenderFailoverPeriod, time.Now())
+		klog.V(1).InfoS("Taking over generating the recommendation", "vpa", klog.KObj(vpaCRD), "recommenderName", feeder.recommenderName, "previousRecommender", previous, "staleness", staleness)
+		vpa.Conditions.Set(vpa_types.RecommenderSwitched, true, "Failover",
+			fmt.Sprintf("recommender %s didn't update the recommendation for %v, recommender %s took over", previous, staleness.Round(time.Second), feeder.recommenderName))
+	}
+	vpa.Recommender = feeder.recommenderName
+}
+
 // LoadVPAs fetches VPA objects and loads them into the cluster state.
 func (feeder *clusterStateFeeder) LoadVPAs(ctx context.Context) {
 	// List VPA API objects.
@@ -442,6 +508,7 @@ func (feeder *clusterStateFeeder) LoadVPAs(ctx context.Context) {
 					feeder.clusterState.VPAs()[vpaID].Conditions.Set(condition.conditionType, true, "", condition.message)
 				}
 			}
+			feeder.setActiveRecommender(vpaCRD, feeder.clusterState.VPAs()[vpaID])
 		}
 	}
 	// Delete non-existent VPAs from the model.
@@ -482,10 +549,26 @@ func (feeder *clusterStateFeeder) LoadPods() {
 				klog.V(0).InfoS("Failed to add container", "container", container.ID, "error", err)
 			}
 		}
+		if feeder.recommendSidecarContainers {
+			// Sidecar containers run for the whole lifetime of the pod, so
+			// their usage is tracked like for regular containers.
+			for _, sidecarContainer := range pod.SidecarContainers {
+				if err = feeder.clusterState.AddOrUpdateContainer(sidecarContainer.ID, sidecarContainer.Request); err != nil {
+					klog.V(0).InfoS("Failed to add sidecar container", "container", sidecarContainer.ID, "error", err)
+				}
+			}
+		}
+		var podInitContainers []string
 		for _, initContainer := range pod.InitContainers {
-			podInitContainers := feeder.clusterState.Pods()[pod.ID].InitContainers
-			feeder.clusterState.Pods()[pod.ID].InitContainers = append(podInitContainers, initContainer.ID.ContainerName)
-
+			podInitContainers = append(podInitContainers, initContainer.ID.ContainerName)
+		}
+		if !feeder.recommendSidecarContainers {
+			for _, sidecarContainer := range pod.SidecarContainers {
+				podInitContainers = append(podInitContainers, sidecarContainer.ID.ContainerName)
+			}
+		}
+		if podState, found := feeder.clusterState.Pods()[pod.ID]; found {
+			podState.InitContainers = podInitContainers
 		}
 	}
 }
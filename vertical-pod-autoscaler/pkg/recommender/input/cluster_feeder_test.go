@@ -484,6 +484,60 @@ func TestClusterStateFeeder_LoadPods_ContainerTracking(t *testing.T) {
 
 }
 
+func TestClusterStateFeeder_LoadPods_SidecarContainers(t *testing.T) {
+	podID := model.PodID{Namespace: "default", PodName: "PodWithSidecarContainers"}
+	containerSpecs := []spec.BasicContainerSpec{
+		newTestContainerSpec(podID, "container1", 500, 512*1024*1024),
+	}
+	initContainerSpecs := []spec.BasicContainerSpec{
+		newTestContainerSpec(podID, "init1", 40, 128*1024*1024),
+	}
+	pod := newTestPodSpec(podID, containerSpecs, initContainerSpecs)
+	pod.SidecarContainers = []spec.BasicContainerSpec{
+		newTestContainerSpec(podID, "sidecar1", 100, 256*1024*1024),
+	}
+
+	for _, tc := range []struct {
+		name                       string
+		recommendSidecarContainers bool
+		expectedContainers         []string
+		expectedInitContainers     []string
+	}{
+		{
+			name:                       "sidecar containers not recommended",
+			recommendSidecarContainers: false,
+			expectedContainers:         []string{"container1"},
+			expectedInitContainers:     []string{"init1", "sidecar1"},
+		},
+		{
+			name:                       "sidecar containers recommended",
+			recommendSidecarContainers: true,
+			expectedContainers:         []string{"container1", "sidecar1"},
+			expectedInitContainers:     []string{"init1"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			feeder := clusterStateFeeder{
+				specClient:                 &testSpecClient{pods: []*spec.BasicPodSpec{pod}},
+				clusterState:               model.NewClusterState(testGcPeriod),
+				recommendSidecarContainers: tc.recommendSidecarContainers,
+			}
+
+			// Loading pods repeatedly must not duplicate the init containers.
+			feeder.LoadPods()
+			feeder.LoadPods()
+
+			podState := feeder.clusterState.Pods()[podID]
+			var containers []string
+			for name := range podState.Containers {
+				containers = append(containers, name)
+			}
+			assert.ElementsMatch(t, tc.expectedContainers, containers)
+			assert.Equal(t, tc.expectedInitContainers, podState.InitContainers)
+		})
+	}
+}
+
 func TestClusterStateFeeder_LoadPods_MemorySaverMode(t *testing.T) {
 	for _, tc := range []struct {
 		Name              string
@@ -769,6 +823,152 @@ func TestFilterVPAs(t *testing.T) {
 	assert.ElementsMatch(t, expectedResult, result)
 }
 
+func TestFilterVPAsWithFailover(t *testing.T) {
+	failoverPeriod := 10 * time.Minute
+	now := time.Now()
+	recommenders := []*vpa_types.VerticalPodAutoscalerRecommenderSelector{
+		{Name: "primary"},
+		{Name: "standby1"},
+		{Name: "standby2"},
+	}
+	testCases := []struct {
+		name                   string
+		statusRecommender      string
+		lastRecommendationTime *metav1.Time
+		expectedActive         string
+	}{
+		{
+			name:           "new VPA",
+			expectedActive: "primary",
+		},
+		{
+			name:                   "primary is up to date",
+			statusRecommender:      "primary",
+			lastRecommendationTime: &metav1.Time{Time: now.Add(-time.Minute)},
+			expectedActive:         "primary",
+		},
+		{
+			name:                   "primary is stale",
+			statusRecommender:      "primary",
+			lastRecommendationTime: &metav1.Time{Time: now.Add(-11 * time.Minute)},
+			expectedActive:         "standby1",
+		},
+		{
+			name:                   "primary and first standby are stale",
+			statusRecommender:      "primary",
+			lastRecommendationTime: &metav1.Time{Time: now.Add(-21 * time.Minute)},
+			expectedActive:         "standby2",
+		},
+		{
+			name:                   "standby is up to date",
+			statusRecommender:      "standby1",
+			lastRecommendationTime: &metav1.Time{Time: now.Add(-time.Minute)},
+			expectedActive:         "standby1",
+		},
+		{
+			name:                   "last standby is stale",
+			statusRecommender:      "standby2",
+			lastRecommendationTime: &metav1.Time{Time: now.Add(-11 * time.Minute)},
+			expectedActive:         "primary",
+		},
+		{
+			name:                   "unknown recommender in status",
+			statusRecommender:      "removed",
+			lastRecommendationTime: &metav1.Time{Time: now.Add(-time.Minute)},
+			expectedActive:         "primary",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vpa := &vpa_types.VerticalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Time{Time: now.Add(-time.Minute)}},
+				Spec:       vpa_types.VerticalPodAutoscalerSpec{Recommenders: recommenders},
+				Status: vpa_types.VerticalPodAutoscalerStatus{
+					Recommender:            tc.statusRecommender,
+					LastRecommendationTime: tc.lastRecommendationTime,
+				},
+			}
+			for _, recommender := range recommenders {
+				feeder := &clusterStateFeeder{
+					recommenderName:           recommender.Name,
+					recommenderFailoverPeriod: failoverPeriod,
+				}
+				result := filterVPAs(feeder, []*vpa_types.VerticalPodAutoscaler{vpa})
+				assert.Equal(t, recommender.Name == tc.expectedActive, len(result) == 1, "recommender %s", recommender.Name)
+			}
+		})
+	}
+}
+
+func TestSetActiveRecommender(t *testing.T) {
+	recommenders := []*vpa_types.VerticalPodAutoscalerRecommenderSelector{
+		{Name: "primary"},
+		{Name: "standby"},
+	}
+	testCases := []struct {
+		name              string
+		recommenders      []*vpa_types.VerticalPodAutoscalerRecommenderSelector
+		recommenderName   string
+		statusRecommender string
+		expectedActive    string
+		expectedSwitched  bool
+	}{
+		{
+			name:            "single recommender",
+			recommenders:    recommenders[:1],
+			recommenderName: "primary",
+		},
+		{
+			name:            "primary",
+			recommenders:    recommenders,
+			recommenderName: "primary",
+			expectedActive:  "primary",
+		},
+		{
+			name:              "standby takes over",
+			recommenders:      recommenders,
+			recommenderName:   "standby",
+			statusRecommender: "primary",
+			expectedActive:    "standby",
+			expectedSwitched:  true,
+		},
+		{
+			name:              "primary takes back over",
+			recommenders:      recommenders,
+			recommenderName:   "primary",
+			statusRecommender: "standby",
+			expectedActive:    "primary",
+			expectedSwitched:  true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vpaCRD := &vpa_types.VerticalPodAutoscaler{
+				ObjectMeta: metav1.ObjectMeta{Name: "vpa", Namespace: "default"},
+				Spec:       vpa_types.VerticalPodAutoscalerSpec{Recommenders: tc.recommenders},
+				Status: vpa_types.VerticalPodAutoscalerStatus{
+					Recommender:            tc.statusRecommender,
+					LastRecommendationTime: &metav1.Time{Time: time.Now().Add(-15 * time.Minute)},
+				},
+			}
+			vpa := model.NewVpa(model.VpaID{Namespace: "default", VpaName: "vpa"}, labels.Everything(), time.Now())
+			feeder := &clusterStateFeeder{
+				recommenderName:           tc.recommenderName,
+				recommenderFailoverPeriod: 10 * time.Minute,
+			}
+
+			feeder.setActiveRecommender(vpaCRD, vpa)
+			assert.Equal(t, tc.expectedActive, vpa.Recommender)
+			condition, found := vpa.Conditions[vpa_types.RecommenderSwitched]
+			assert.Equal(t, tc.expectedSwitched, found)
+			if tc.expectedSwitched {
+				assert.Equal(t, fmt.Sprintf("recommender %s didn't update the recommendation for 15m0s, recommender %s took over",
+					tc.statusRecommender, tc.recommenderName), condition.Message)
+			}
+		})
+	}
+}
+
 func TestFilterVPAsIgnoreNamespaces(t *testing.T) {
 
 	vpa1 := &vpa_types.VerticalPodAutoscaler{
@@ -57,6 +57,16 @@ type PrometheusHistoryProviderConfig struct {
 	CadvisorMetricsJobName                           string
 	Namespace                                        string
 	PrometheusBasicAuthTransport
+
+	// UseRemoteRead makes the provider read the usage history from the remote
+	// read API, downsampled to HistoryResolution, instead of range queries.
+	UseRemoteRead bool
+	// QueryChunkLength is the length of the time ranges in which the history
+	// is read from the remote read API.
+	QueryChunkLength string
+	// ShardByNamespace makes the provider read the history from the remote
+	// read API separately for each namespace.
+	ShardByNamespace bool
 }
 
 // PodHistory represents history of usage and labels for a given pod.
@@ -118,12 +128,33 @@ func NewPrometheusHistoryProvider(config PrometheusHistoryProviderConfig) (Histo
 		return &prometheusHistoryProvider{}, fmt.Errorf("history resolution %s is not a valid Prometheus duration: %v", config.HistoryResolution, err)
 	}
 
-	return &prometheusHistoryProvider{
+	provider := &prometheusHistoryProvider{
 		prometheusClient:  prometheusv1.NewAPI(promClient),
 		config:            config,
 		queryTimeout:      config.QueryTimeout,
 		historyDuration:   historyDuration,
 		historyResolution: historyResolution,
+	}
+	if !config.UseRemoteRead {
+		return provider, nil
+	}
+
+	queryChunkLength, err := prommodel.ParseDuration(config.QueryChunkLength)
+	if err != nil {
+		return &prometheusHistoryProvider{}, fmt.Errorf("query chunk length %s is not a valid Prometheus duration: %v", config.QueryChunkLength, err)
+	}
+	if queryChunkLength <= 0 {
+		return &prometheusHistoryProvider{}, fmt.Errorf("query chunk length %s has to be positive", config.QueryChunkLength)
+	}
+	roundTripper := http.DefaultTransport
+	if promConfig.RoundTripper != nil {
+		roundTripper = promConfig.RoundTripper
+	}
+	return &remoteReadHistoryProvider{
+		prometheusHistoryProvider: provider,
+		remoteReadClient:          newRemoteReadClient(config.Address, roundTripper),
+		queryChunkLength:          time.Duration(queryChunkLength),
+		shardByNamespace:          config.ShardByNamespace,
 	}, nil
 }
 
@@ -246,6 +277,19 @@ func (p *prometheusHistoryProvider) readResourceHistory(res map[model.PodID]*Pod
 	return nil
 }
 
+func sortSamples(res map[model.PodID]*PodHistory) {
+	for _, podHistory := range res {
+		for _, samples := range podHistory.Samples {
+			sort.Slice(samples, func(i, j int) bool {
+				if !samples[i].MeasureStart.Equal(samples[j].MeasureStart) {
+					return samples[i].MeasureStart.Before(samples[j].MeasureStart)
+				}
+				return samples[i].Resource < samples[j].Resource
+			})
+		}
+	}
+}
+
 func (p *prometheusHistoryProvider) readLastLabels(res map[model.PodID]*PodHistory, query string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), p.queryTimeout)
 	defer cancel()
@@ -283,8 +327,8 @@ func (p *prometheusHistoryProvider) readLastLabels(res map[model.PodID]*PodHisto
 	return nil
 }
 
-func (p *prometheusHistoryProvider) GetClusterHistory() (map[model.PodID]*PodHistory, error) {
-	res := make(map[model.PodID]*PodHistory)
+// podSelector returns the label selector of the cAdvisor container metrics.
+func (p *prometheusHistoryProvider) podSelector() string {
 	var podSelector string
 	if p.config.CadvisorMetricsJobName != "" {
 		podSelector = fmt.Sprintf("job=\"%s\", ", p.config.CadvisorMetricsJobName)
@@ -295,6 +339,12 @@ func (p *prometheusHistoryProvider) GetClusterHistory() (map[model.PodID]*PodHis
 	if p.config.Namespace != "" {
 		podSelector = fmt.Sprintf("%s, %s=\"%s\"", podSelector, p.config.CtrNamespaceLabel, p.config.Namespace)
 	}
+	return podSelector
+}
+
+func (p *prometheusHistoryProvider) GetClusterHistory() (map[model.PodID]*PodHistory, error) {
+	res := make(map[model.PodID]*PodHistory)
+	podSelector := p.podSelector()
 	historicalCpuQuery := fmt.Sprintf("rate(container_cpu_usage_seconds_total{%s}[%s])", podSelector, p.config.HistoryResolution)
 	klog.V(4).InfoS("Historical CPU usage query", "query", historicalCpuQuery)
 	err := p.readResourceHistory(res, historicalCpuQuery, model.ResourceCPU)
@@ -308,11 +358,7 @@ func (p *prometheusHistoryProvider) GetClusterHistory() (map[model.PodID]*PodHis
 	if err != nil {
 		return nil, fmt.Errorf("cannot get usage history: %v", err)
 	}
-	for _, podHistory := range res {
-		for _, samples := range podHistory.Samples {
-			sort.Slice(samples, func(i, j int) bool { return samples[i].MeasureStart.Before(samples[j].MeasureStart) })
-		}
-	}
+	sortSamples(res)
 	err = p.readLastLabels(res, p.config.PodLabelsMetricName)
 	if err != nil {
 		return nil, fmt.Errorf("cannot read last labels: %v", err)
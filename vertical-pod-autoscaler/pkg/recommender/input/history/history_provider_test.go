@@ -87,7 +87,12 @@ func (m *mockPrometheusAPI) LabelNames(ctx context.Context, matches []string, st
 }
 
 func (m *mockPrometheusAPI) LabelValues(ctx context.Context, label string, matches []string, startTime time.Time, endTime time.Time, opts ...prometheusv1.Option) (prommodel.LabelValues, prometheusv1.Warnings, error) {
-	panic("not implemented")
+	args := m.Called(ctx, label, matches)
+	var returnArg prommodel.LabelValues
+	if args.Get(0) != nil {
+		returnArg = args.Get(0).(prommodel.LabelValues)
+	}
+	return returnArg, nil, args.Error(1)
 }
 
 func (m *mockPrometheusAPI) Series(ctx context.Context, matches []string, startTime time.Time, endTime time.Time, opts ...prometheusv1.Option) ([]prommodel.LabelSet, prometheusv1.Warnings, error) {
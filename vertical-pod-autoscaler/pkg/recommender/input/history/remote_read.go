@@ -0,0 +1,447 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+	prommodel "github.com/prometheus/common/model"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// This file implements a client of the Prometheus remote read API, limited to
+// the streamed XOR chunks response type. Samples are decoded from the chunks
+// frame by frame, so the whole response is never held in memory. See
+// https://prometheus.io/docs/prometheus/latest/querying/remote_read_api/ for
+// the protocol and the prompb package of Prometheus for the messages.
+
+const (
+	remoteReadPath    = "/api/v1/read"
+	remoteReadVersion = "0.1.0"
+	// streamedChunksContentType is the content type of streamed XOR chunks responses.
+	streamedChunksContentType = "application/x-streamed-protobuf; proto=prometheus.ChunkedReadResponse"
+	// maxFrameSize limits the size of a single response frame. Prometheus
+	// splits the chunks of a series into frames of about 1MB.
+	maxFrameSize = 50 * 1024 * 1024
+	// Values of the prompb.ReadRequest.ResponseType and prompb.Chunk.Encoding enums.
+	streamedXORChunksResponseType = 1
+	xorChunkEncoding              = 1
+)
+
+var castagnoliTable = crc32.MakeTable(crc32.Castagnoli)
+
+// labelMatchType is the type of a label matcher, mirroring prompb.LabelMatcher.Type.
+type labelMatchType int
+
+const (
+	matchEqual labelMatchType = iota
+	matchNotEqual
+	matchRegexp
+	matchNotRegexp
+)
+
+type labelMatcher struct {
+	matchType labelMatchType
+	name      string
+	value     string
+}
+
+// remoteReadQuery selects series matching all the matchers, with samples
+// between start and end (inclusive).
+type remoteReadQuery struct {
+	start, end time.Time
+	matchers   []labelMatcher
+}
+
+// remoteReadClient reads samples from the Prometheus remote read API.
+type remoteReadClient struct {
+	url        string
+	httpClient *http.Client
+}
+
+func newRemoteReadClient(address string, roundTripper http.RoundTripper) *remoteReadClient {
+	return &remoteReadClient{
+		url:        strings.TrimSuffix(address, "/") + remoteReadPath,
+		httpClient: &http.Client{Transport: roundTripper},
+	}
+}
+
+// read runs the query and calls fn with the samples of each returned series,
+// in chronological order. Samples of a single series may be split across
+// multiple calls.
+func (c *remoteReadClient) read(ctx context.Context, query remoteReadQuery, fn func(metric prommodel.Metric, samples []prommodel.SamplePair) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(snappy.Encode(nil, encodeReadRequest(query))))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Read-Version", remoteReadVersion)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("remote read returned HTTP status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	if contentType := resp.Header.Get("Content-Type"); contentType != streamedChunksContentType {
+		return fmt.Errorf("remote read doesn't support streamed chunks, got content type %q", contentType)
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	for {
+		frame, err := readFrame(reader)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("cannot read remote read response: %v", err)
+		}
+		if err := decodeChunkedReadResponse(frame, fn); err != nil {
+			return fmt.Errorf("cannot decode remote read response: %v", err)
+		}
+	}
+}
+
+// readFrame reads a single frame of a streamed response, which consists of
+// the uvarint size of the data, its CRC32 checksum and the data itself.
+// Returns io.EOF if there are no more frames.
+func readFrame(reader *bufio.Reader) ([]byte, error) {
+	size, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, err
+	}
+	if size > maxFrameSize {
+		return nil, fmt.Errorf("frame of %d bytes exceeds the limit of %d bytes", size, maxFrameSize)
+	}
+	var checksum [4]byte
+	if _, err := io.ReadFull(reader, checksum[:]); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(reader, data); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if crc32.Checksum(data, castagnoliTable) != binary.BigEndian.Uint32(checksum[:]) {
+		return nil, errors.New("frame checksum mismatch")
+	}
+	return data, nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// encodeReadRequest encodes a prompb.ReadRequest with the query, accepting
+// only streamed XOR chunks in the response.
+func encodeReadRequest(query remoteReadQuery) []byte {
+	var q []byte
+	q = protowire.AppendTag(q, 1, protowire.VarintType)
+	q = protowire.AppendVarint(q, uint64(query.start.UnixMilli()))
+	q = protowire.AppendTag(q, 2, protowire.VarintType)
+	q = protowire.AppendVarint(q, uint64(query.end.UnixMilli()))
+	for _, matcher := range query.matchers {
+		var m []byte
+		m = protowire.AppendTag(m, 1, protowire.VarintType)
+		m = protowire.AppendVarint(m, uint64(matcher.matchType))
+		m = protowire.AppendTag(m, 2, protowire.BytesType)
+		m = protowire.AppendString(m, matcher.name)
+		m = protowire.AppendTag(m, 3, protowire.BytesType)
+		m = protowire.AppendString(m, matcher.value)
+		q = protowire.AppendTag(q, 3, protowire.BytesType)
+		q = protowire.AppendBytes(q, m)
+	}
+
+	var req []byte
+	req = protowire.AppendTag(req, 1, protowire.BytesType)
+	req = protowire.AppendBytes(req, q)
+	req = protowire.AppendTag(req, 2, protowire.VarintType)
+	req = protowire.AppendVarint(req, streamedXORChunksResponseType)
+	return req
+}
+
+// decodeChunkedReadResponse decodes a prompb.ChunkedReadResponse and calls fn
+// with the samples of each of its series.
+func decodeChunkedReadResponse(data []byte, fn func(metric prommodel.Metric, samples []prommodel.SamplePair) error) error {
+	return forEachField(data, func(num protowire.Number, _ uint64, value []byte) error {
+		if num != 1 {
+			return nil
+		}
+		metric, samples, err := decodeChunkedSeries(value)
+		if err != nil {
+			return err
+		}
+		return fn(metric, samples)
+	})
+}
+
+// decodeChunkedSeries decodes the labels and the samples of a prompb.ChunkedSeries.
+func decodeChunkedSeries(data []byte) (prommodel.Metric, []prommodel.SamplePair, error) {
+	metric := prommodel.Metric{}
+	var samples []prommodel.SamplePair
+	err := forEachField(data, func(num protowire.Number, _ uint64, value []byte) error {
+		switch num {
+		case 1:
+			var name, labelValue string
+			err := forEachField(value, func(num protowire.Number, _ uint64, value []byte) error {
+				switch num {
+				case 1:
+					name = string(value)
+				case 2:
+					labelValue = string(value)
+				}
+				return nil
+			})
+			metric[prommodel.LabelName(name)] = prommodel.LabelValue(labelValue)
+			return err
+		case 2:
+			var encoding uint64
+			var chunk []byte
+			err := forEachField(value, func(num protowire.Number, v uint64, value []byte) error {
+				switch num {
+				case 3:
+					encoding = v
+				case 4:
+					chunk = value
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if encoding != xorChunkEncoding {
+				return fmt.Errorf("unsupported chunk encoding %d", encoding)
+			}
+			samples, err = decodeXORChunk(chunk, samples)
+			return err
+		}
+		return nil
+	})
+	return metric, samples, err
+}
+
+// forEachField calls fn with the number and the value of each field of the
+// protobuf message. Values of varint fields are passed as v, values of
+// length-delimited fields as value, other fields are skipped.
+func forEachField(data []byte, fn func(num protowire.Number, v uint64, value []byte) error) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+		var v uint64
+		var value []byte
+		switch typ {
+		case protowire.VarintType:
+			v, n = protowire.ConsumeVarint(data)
+		case protowire.BytesType:
+			value, n = protowire.ConsumeBytes(data)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, data)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+		if typ != protowire.VarintType && typ != protowire.BytesType {
+			continue
+		}
+		if err := fn(num, v, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeXORChunk appends the samples of a Prometheus XOR (Gorilla) chunk to
+// samples. The chunk starts with the number of samples, followed by the first
+// timestamp and value, and then delta-of-delta encoded timestamps and XOR
+// encoded values.
+func decodeXORChunk(chunk []byte, samples []prommodel.SamplePair) ([]prommodel.SamplePair, error) {
+	if len(chunk) < 2 {
+		return samples, errors.New("XOR chunk too short")
+	}
+	count := int(binary.BigEndian.Uint16(chunk))
+	reader := &bitReader{data: chunk[2:]}
+	var t, tDelta int64
+	var value uint64
+	var leading, trailing uint8
+	for i := 0; i < count; i++ {
+		var err error
+		switch i {
+		case 0:
+			if t, err = binary.ReadVarint(reader); err != nil {
+				return samples, err
+			}
+			if value, err = reader.readBits(64); err != nil {
+				return samples, err
+			}
+		case 1:
+			delta, err := binary.ReadUvarint(reader)
+			if err != nil {
+				return samples, err
+			}
+			tDelta = int64(delta)
+			t += tDelta
+			if value, err = readXORValue(reader, value, &leading, &trailing); err != nil {
+				return samples, err
+			}
+		default:
+			dod, err := readDeltaOfDelta(reader)
+			if err != nil {
+				return samples, err
+			}
+			tDelta += dod
+			t += tDelta
+			if value, err = readXORValue(reader, value, &leading, &trailing); err != nil {
+				return samples, err
+			}
+		}
+		samples = append(samples, prommodel.SamplePair{
+			Timestamp: prommodel.Time(t),
+			Value:     prommodel.SampleValue(math.Float64frombits(value)),
+		})
+	}
+	return samples, nil
+}
+
+func readDeltaOfDelta(reader *bitReader) (int64, error) {
+	// The number of leading one bits (up to 4) determines the size of the delta-of-delta.
+	var prefix byte
+	for i := 0; i < 4; i++ {
+		prefix <<= 1
+		bit, err := reader.readBit()
+		if err != nil {
+			return 0, err
+		}
+		if !bit {
+			break
+		}
+		prefix |= 1
+	}
+	var size int
+	switch prefix {
+	case 0b0:
+		return 0, nil
+	case 0b10:
+		size = 14
+	case 0b110:
+		size = 17
+	case 0b1110:
+		size = 20
+	case 0b1111:
+		bits, err := reader.readBits(64)
+		return int64(bits), err
+	}
+	bits, err := reader.readBits(size)
+	if err != nil {
+		return 0, err
+	}
+	// Negative values are stored as high unsigned numbers.
+	if bits > 1<<(size-1) {
+		bits -= 1 << size
+	}
+	return int64(bits), nil
+}
+
+func readXORValue(reader *bitReader, value uint64, leading, trailing *uint8) (uint64, error) {
+	changed, err := reader.readBit()
+	if err != nil || !changed {
+		return value, err
+	}
+	newWindow, err := reader.readBit()
+	if err != nil {
+		return value, err
+	}
+	if newWindow {
+		bits, err := reader.readBits(5)
+		if err != nil {
+			return value, err
+		}
+		significant, err := reader.readBits(6)
+		if err != nil {
+			return value, err
+		}
+		// 0 significant bits means all 64 of them.
+		if significant == 0 {
+			significant = 64
+		}
+		*leading = uint8(bits)
+		*trailing = 64 - *leading - uint8(significant)
+	}
+	bits, err := reader.readBits(int(64 - *leading - *trailing))
+	if err != nil {
+		return value, err
+	}
+	return value ^ bits<<*trailing, nil
+}
+
+// bitReader reads a stream of bits, most significant bit first.
+type bitReader struct {
+	data []byte
+	// pos is the position of the next bit.
+	pos int
+}
+
+func (r *bitReader) readBit() (bool, error) {
+	if r.pos >= len(r.data)*8 {
+		return false, io.ErrUnexpectedEOF
+	}
+	bit := r.data[r.pos/8]&(0x80>>(r.pos%8)) != 0
+	r.pos++
+	return bit, nil
+}
+
+func (r *bitReader) readBits(n int) (uint64, error) {
+	var bits uint64
+	for i := 0; i < n; i++ {
+		bit, err := r.readBit()
+		if err != nil {
+			return 0, err
+		}
+		bits <<= 1
+		if bit {
+			bits |= 1
+		}
+	}
+	return bits, nil
+}
+
+// ReadByte implements io.ByteReader, so that varints can be read from the stream.
+func (r *bitReader) ReadByte() (byte, error) {
+	bits, err := r.readBits(8)
+	return byte(bits), err
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	prommodel "github.com/prometheus/common/model"
+	"k8s.io/klog/v2"
+
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/recommender/model"
+)
+
+const (
+	cpuUsageMetricName    = "container_cpu_usage_seconds_total"
+	memoryUsageMetricName = "container_memory_working_set_bytes"
+)
+
+// remoteReadHistoryProvider reads the usage history from the Prometheus
+// remote read API instead of range queries. The raw samples are streamed and
+// downsampled to the history resolution in the recommender, so the memory
+// needed doesn't depend on the scrape interval. To bound the size of the
+// responses, the history is read in chunks of queryChunkLength and, if
+// shardByNamespace is set, separately for each namespace.
+type remoteReadHistoryProvider struct {
+	*prometheusHistoryProvider
+	remoteReadClient *remoteReadClient
+	queryChunkLength time.Duration
+	shardByNamespace bool
+}
+
+func (p *remoteReadHistoryProvider) GetClusterHistory() (map[model.PodID]*PodHistory, error) {
+	res := make(map[model.PodID]*PodHistory)
+	end := time.Now()
+	start := end.Add(-time.Duration(p.historyDuration))
+
+	namespaces, err := p.getNamespaceShards(start, end)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get namespaces: %v", err)
+	}
+	for _, namespace := range namespaces {
+		d := newDownsampler(time.Duration(p.historyResolution))
+		for chunkStart := start; chunkStart.Before(end); chunkStart = chunkStart.Add(p.queryChunkLength) {
+			chunkEnd := chunkStart.Add(p.queryChunkLength)
+			if chunkEnd.After(end) {
+				chunkEnd = end
+			}
+			if err := p.readHistoryChunk(d, namespace, chunkStart, chunkEnd); err != nil {
+				return nil, fmt.Errorf("cannot get usage history: %v", err)
+			}
+		}
+		d.addSamplesTo(res)
+		klog.V(4).InfoS("Read usage history", "namespace", namespace)
+	}
+	sortSamples(res)
+	err = p.readLastLabels(res, p.config.PodLabelsMetricName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read last labels: %v", err)
+	}
+	return res, nil
+}
+
+// getNamespaceShards returns the namespaces whose history is read separately,
+// or a single empty namespace if the history is read for all of them at once.
+func (p *remoteReadHistoryProvider) getNamespaceShards(start, end time.Time) ([]string, error) {
+	if p.config.Namespace != "" {
+		return []string{p.config.Namespace}, nil
+	}
+	if !p.shardByNamespace {
+		return []string{""}, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), p.queryTimeout)
+	defer cancel()
+	selector := fmt.Sprintf("%s{%s}", memoryUsageMetricName, p.podSelector())
+	values, _, err := p.prometheusClient.LabelValues(ctx, p.config.CtrNamespaceLabel, []string{selector}, start, end)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := make([]string, 0, len(values))
+	for _, value := range values {
+		namespaces = append(namespaces, string(value))
+	}
+	return namespaces, nil
+}
+
+// readHistoryChunk reads the CPU and memory usage samples between start and end into the downsampler.
+func (p *remoteReadHistoryProvider) readHistoryChunk(d *downsampler, namespace string, start, end time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), p.queryTimeout)
+	defer cancel()
+	return p.remoteReadClient.read(ctx, p.remoteReadQuery(namespace, start, end), func(metric prommodel.Metric, samples []prommodel.SamplePair) error {
+		var resource model.ResourceName
+		switch metric[prommodel.MetricNameLabel] {
+		case cpuUsageMetricName:
+			resource = model.ResourceCPU
+		case memoryUsageMetricName:
+			resource = model.ResourceMemory
+		default:
+			return nil
+		}
+		containerID, err := p.getContainerIDFromLabels(metric)
+		if err != nil {
+			return fmt.Errorf("cannot get container ID from labels: %v", metric)
+		}
+		d.addSamples(metric.Fingerprint(), *containerID, resource, samples)
+		return nil
+	})
+}
+
+// remoteReadQuery returns the query for the cAdvisor usage metrics, equivalent to podSelector.
+func (p *remoteReadHistoryProvider) remoteReadQuery(namespace string, start, end time.Time) remoteReadQuery {
+	matchers := []labelMatcher{
+		{matchRegexp, prommodel.MetricNameLabel, cpuUsageMetricName + "|" + memoryUsageMetricName},
+	}
+	if p.config.CadvisorMetricsJobName != "" {
+		matchers = append(matchers, labelMatcher{matchEqual, "job", p.config.CadvisorMetricsJobName})
+	}
+	matchers = append(matchers,
+		labelMatcher{matchRegexp, p.config.CtrPodNameLabel, ".+"},
+		labelMatcher{matchNotEqual, p.config.CtrNameLabel, "POD"},
+		labelMatcher{matchNotEqual, p.config.CtrNameLabel, ""},
+	)
+	if namespace != "" {
+		matchers = append(matchers, labelMatcher{matchEqual, p.config.CtrNamespaceLabel, namespace})
+	}
+	return remoteReadQuery{start: start, end: end, matchers: matchers}
+}
+
+// downsampler aggregates the raw samples of the usage series into one sample
+// per resolution step: the average CPU usage rate in the step and the peak
+// memory usage in the step.
+type downsampler struct {
+	resolution time.Duration
+	// lastSamples holds the last sample of each series, used to compute CPU
+	// usage rates and to drop samples repeated at the query chunk boundaries.
+	lastSamples map[prommodel.Fingerprint]prommodel.SamplePair
+	steps       map[downsampledStepKey]*downsampledStep
+}
+
+type downsampledStepKey struct {
+	containerID model.ContainerID
+	resource    model.ResourceName
+	start       prommodel.Time
+}
+
+type downsampledStep struct {
+	// value is the CPU usage in core-seconds or the peak memory usage in bytes.
+	value float64
+	// duration is the time in which the CPU usage was measured.
+	duration time.Duration
+}
+
+func newDownsampler(resolution time.Duration) *downsampler {
+	return &downsampler{
+		resolution:  resolution,
+		lastSamples: make(map[prommodel.Fingerprint]prommodel.SamplePair),
+		steps:       make(map[downsampledStepKey]*downsampledStep),
+	}
+}
+
+// addSamples adds chronologically ordered samples of a series.
+func (d *downsampler) addSamples(series prommodel.Fingerprint, containerID model.ContainerID, resource model.ResourceName, samples []prommodel.SamplePair) {
+	for _, sample := range samples {
+		last, found := d.lastSamples[series]
+		if found && !sample.Timestamp.After(last.Timestamp) {
+			continue
+		}
+		d.lastSamples[series] = sample
+		key := downsampledStepKey{
+			containerID: containerID,
+			resource:    resource,
+			start:       prommodel.TimeFromUnixNano(sample.Timestamp.Time().Truncate(d.resolution).UnixNano()),
+		}
+		step, stepFound := d.steps[key]
+		switch resource {
+		case model.ResourceCPU:
+			// CPU usage is a counter, so the usage in the step is the sum of its increases.
+			if !found {
+				continue
+			}
+			increase := float64(sample.Value - last.Value)
+			if increase < 0 {
+				// The counter was reset.
+				increase = float64(sample.Value)
+			}
+			if !stepFound {
+				step = &downsampledStep{}
+				d.steps[key] = step
+			}
+			step.value += increase
+			step.duration += sample.Timestamp.Sub(last.Timestamp)
+		case model.ResourceMemory:
+			if !stepFound {
+				d.steps[key] = &downsampledStep{value: float64(sample.Value)}
+			} else if float64(sample.Value) > step.value {
+				step.value = float64(sample.Value)
+			}
+		}
+	}
+}
+
+// addSamplesTo adds the downsampled samples to the pod histories.
+func (d *downsampler) addSamplesTo(res map[model.PodID]*PodHistory) {
+	for key, step := range d.steps {
+		value := step.value
+		if key.resource == model.ResourceCPU {
+			if step.duration <= 0 {
+				continue
+			}
+			value /= step.duration.Seconds()
+		}
+		podHistory, ok := res[key.containerID.PodID]
+		if !ok {
+			podHistory = newEmptyHistory()
+			res[key.containerID.PodID] = podHistory
+		}
+		podHistory.Samples[key.containerID.ContainerName] = append(podHistory.Samples[key.containerID.ContainerName], model.ContainerUsageSample{
+			MeasureStart: key.start.Time(),
+			Usage:        resourceAmountFromValue(value, key.resource),
+			Resource:     key.resource,
+		})
+	}
+}
@@ -0,0 +1,359 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"math"
+	"math/bits"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+	prommodel "github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/recommender/model"
+)
+
+// bitWriter writes a stream of bits, most significant bit first.
+type bitWriter struct {
+	data  []byte
+	count int
+}
+
+func (w *bitWriter) writeBits(v uint64, n int) {
+	for i := n - 1; i >= 0; i-- {
+		if w.count%8 == 0 {
+			w.data = append(w.data, 0)
+		}
+		if v&(1<<i) != 0 {
+			w.data[len(w.data)-1] |= 0x80 >> (w.count % 8)
+		}
+		w.count++
+	}
+}
+
+func (w *bitWriter) writeBit(bit bool) {
+	if bit {
+		w.writeBits(1, 1)
+	} else {
+		w.writeBits(0, 1)
+	}
+}
+
+func (w *bitWriter) writeBytes(b []byte) {
+	for _, v := range b {
+		w.writeBits(uint64(v), 8)
+	}
+}
+
+// encodeXORChunk encodes the samples the way Prometheus encodes XOR chunks.
+func encodeXORChunk(samples []prommodel.SamplePair) []byte {
+	w := &bitWriter{}
+	w.writeBits(uint64(len(samples)), 16)
+	var t, tDelta int64
+	var value uint64
+	leading, trailing := uint8(0xff), uint8(0)
+	buf := make([]byte, binary.MaxVarintLen64)
+	for i, sample := range samples {
+		newValue := math.Float64bits(float64(sample.Value))
+		switch i {
+		case 0:
+			w.writeBytes(buf[:binary.PutVarint(buf, int64(sample.Timestamp))])
+			w.writeBits(newValue, 64)
+		case 1:
+			tDelta = int64(sample.Timestamp) - t
+			w.writeBytes(buf[:binary.PutUvarint(buf, uint64(tDelta))])
+			writeXORValue(w, newValue, value, &leading, &trailing)
+		default:
+			newDelta := int64(sample.Timestamp) - t
+			dod := newDelta - tDelta
+			switch {
+			case dod == 0:
+				w.writeBit(false)
+			case dod >= -(1<<13-1) && dod <= 1<<13:
+				w.writeBits(0b10, 2)
+				w.writeBits(uint64(dod), 14)
+			case dod >= -(1<<16-1) && dod <= 1<<16:
+				w.writeBits(0b110, 3)
+				w.writeBits(uint64(dod), 17)
+			case dod >= -(1<<19-1) && dod <= 1<<19:
+				w.writeBits(0b1110, 4)
+				w.writeBits(uint64(dod), 20)
+			default:
+				w.writeBits(0b1111, 4)
+				w.writeBits(uint64(dod), 64)
+			}
+			tDelta = newDelta
+			writeXORValue(w, newValue, value, &leading, &trailing)
+		}
+		t = int64(sample.Timestamp)
+		value = newValue
+	}
+	return w.data
+}
+
+func writeXORValue(w *bitWriter, newValue, value uint64, leading, trailing *uint8) {
+	delta := newValue ^ value
+	if delta == 0 {
+		w.writeBit(false)
+		return
+	}
+	w.writeBit(true)
+	newLeading := uint8(bits.LeadingZeros64(delta))
+	newTrailing := uint8(bits.TrailingZeros64(delta))
+	if newLeading >= 32 {
+		newLeading = 31
+	}
+	if *leading != 0xff && newLeading >= *leading && newTrailing >= *trailing {
+		w.writeBit(false)
+		w.writeBits(delta>>*trailing, 64-int(*leading)-int(*trailing))
+		return
+	}
+	*leading, *trailing = newLeading, newTrailing
+	w.writeBit(true)
+	w.writeBits(uint64(newLeading), 5)
+	significant := 64 - newLeading - newTrailing
+	w.writeBits(uint64(significant), 6)
+	w.writeBits(delta>>newTrailing, int(significant))
+}
+
+func TestDecodeXORChunk(t *testing.T) {
+	samples := []prommodel.SamplePair{
+		{Timestamp: 1000, Value: 1.5},
+		{Timestamp: 16000, Value: 1.5},
+		{Timestamp: 31000, Value: 2.75},
+		{Timestamp: 46000, Value: -3},
+		{Timestamp: 46500, Value: 1e12},
+		{Timestamp: 146500, Value: 0},
+		{Timestamp: 10146500, Value: 0.001},
+		{Timestamp: 10146501, Value: 0.001},
+	}
+	decoded, err := decodeXORChunk(encodeXORChunk(samples), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, samples, decoded)
+
+	_, err = decodeXORChunk(encodeXORChunk(samples)[:10], nil)
+	assert.Error(t, err)
+}
+
+type testSeries struct {
+	metric  prommodel.Metric
+	samples []prommodel.SamplePair
+}
+
+// newRemoteReadServer returns a server streaming the samples of the series
+// matching the namespace and time range of the query.
+func newRemoteReadServer(t *testing.T, series []testSeries) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "snappy", r.Header.Get("Content-Encoding"))
+		compressed, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		request, err := snappy.Decode(nil, compressed)
+		assert.NoError(t, err)
+		start, end, namespace := decodeTestReadRequest(t, request)
+
+		w.Header().Set("Content-Type", streamedChunksContentType)
+		for _, s := range series {
+			if namespace != "" && string(s.metric["namespace"]) != namespace {
+				continue
+			}
+			var samples []prommodel.SamplePair
+			for _, sample := range s.samples {
+				if sample.Timestamp >= start && sample.Timestamp <= end {
+					samples = append(samples, sample)
+				}
+			}
+			if len(samples) == 0 {
+				continue
+			}
+			writeTestFrame(w, encodeTestChunkedReadResponse(s.metric, samples))
+		}
+	}))
+}
+
+func decodeTestReadRequest(t *testing.T, request []byte) (start, end prommodel.Time, namespace string) {
+	err := forEachField(request, func(num protowire.Number, _ uint64, query []byte) error {
+		if num != 1 {
+			return nil
+		}
+		return forEachField(query, func(num protowire.Number, v uint64, matcher []byte) error {
+			switch num {
+			case 1:
+				start = prommodel.Time(v)
+			case 2:
+				end = prommodel.Time(v)
+			case 3:
+				var name, value string
+				err := forEachField(matcher, func(num protowire.Number, _ uint64, b []byte) error {
+					switch num {
+					case 2:
+						name = string(b)
+					case 3:
+						value = string(b)
+					}
+					return nil
+				})
+				if name == "namespace" {
+					namespace = value
+				}
+				return err
+			}
+			return nil
+		})
+	})
+	assert.NoError(t, err)
+	return start, end, namespace
+}
+
+func encodeTestChunkedReadResponse(metric prommodel.Metric, samples []prommodel.SamplePair) []byte {
+	var series []byte
+	for name, value := range metric {
+		var label []byte
+		label = protowire.AppendTag(label, 1, protowire.BytesType)
+		label = protowire.AppendString(label, string(name))
+		label = protowire.AppendTag(label, 2, protowire.BytesType)
+		label = protowire.AppendString(label, string(value))
+		series = protowire.AppendTag(series, 1, protowire.BytesType)
+		series = protowire.AppendBytes(series, label)
+	}
+	// Prometheus cuts chunks at 120 samples.
+	for len(samples) > 0 {
+		n := min(len(samples), 120)
+		var chunk []byte
+		chunk = protowire.AppendTag(chunk, 3, protowire.VarintType)
+		chunk = protowire.AppendVarint(chunk, xorChunkEncoding)
+		chunk = protowire.AppendTag(chunk, 4, protowire.BytesType)
+		chunk = protowire.AppendBytes(chunk, encodeXORChunk(samples[:n]))
+		series = protowire.AppendTag(series, 2, protowire.BytesType)
+		series = protowire.AppendBytes(series, chunk)
+		samples = samples[n:]
+	}
+	var response []byte
+	response = protowire.AppendTag(response, 1, protowire.BytesType)
+	return protowire.AppendBytes(response, series)
+}
+
+func writeTestFrame(w io.Writer, data []byte) {
+	buf := make([]byte, binary.MaxVarintLen64)
+	_, _ = w.Write(buf[:binary.PutUvarint(buf, uint64(len(data)))])
+	binary.BigEndian.PutUint32(buf, crc32.Checksum(data, castagnoliTable))
+	_, _ = w.Write(buf[:4])
+	_, _ = w.Write(data)
+}
+
+func newTestRemoteReadHistoryProvider(address string, mockClient *mockPrometheusAPI) *remoteReadHistoryProvider {
+	return &remoteReadHistoryProvider{
+		prometheusHistoryProvider: &prometheusHistoryProvider{
+			config:            getDefaultPrometheusHistoryProviderConfigForTest(),
+			prometheusClient:  mockClient,
+			queryTimeout:      time.Minute,
+			historyDuration:   prommodel.Duration(4 * time.Hour),
+			historyResolution: prommodel.Duration(time.Hour),
+		},
+		remoteReadClient: newRemoteReadClient(address, http.DefaultTransport),
+		queryChunkLength: 30 * time.Minute,
+		shardByNamespace: true,
+	}
+}
+
+func TestRemoteReadGetClusterHistory(t *testing.T) {
+	hourStart := time.Now().Truncate(time.Hour).Add(-2 * time.Hour)
+	var cpuSamples, memorySamples, otherMemorySamples []prommodel.SamplePair
+	for ts := hourStart; ts.Before(hourStart.Add(2 * time.Hour)); ts = ts.Add(30 * time.Second) {
+		// 0.5 cores are used in the first hour and 2 cores in the second one.
+		cores := 0.5
+		memory := 1e9
+		if !ts.Before(hourStart.Add(time.Hour)) {
+			cores = 2
+			memory = 2e9
+		}
+		var cpuUsage float64
+		if len(cpuSamples) > 0 {
+			cpuUsage = float64(cpuSamples[len(cpuSamples)-1].Value) + cores*30
+		}
+		cpuSamples = append(cpuSamples, prommodel.SamplePair{Timestamp: prommodel.TimeFromUnixNano(ts.UnixNano()), Value: prommodel.SampleValue(cpuUsage)})
+		memorySamples = append(memorySamples, prommodel.SamplePair{Timestamp: prommodel.TimeFromUnixNano(ts.UnixNano()), Value: prommodel.SampleValue(memory)})
+		otherMemorySamples = append(otherMemorySamples, prommodel.SamplePair{Timestamp: prommodel.TimeFromUnixNano(ts.UnixNano()), Value: 1e8})
+	}
+	// The memory peak within the first hour.
+	memorySamples[10].Value = 1.5e9
+	server := newRemoteReadServer(t, []testSeries{
+		{
+			metric:  prommodel.Metric{"__name__": cpuUsageMetricName, "namespace": "default", "pod_name": "pod", "name": "container"},
+			samples: cpuSamples,
+		},
+		{
+			metric:  prommodel.Metric{"__name__": memoryUsageMetricName, "namespace": "default", "pod_name": "pod", "name": "container"},
+			samples: memorySamples,
+		},
+		{
+			metric:  prommodel.Metric{"__name__": memoryUsageMetricName, "namespace": "kube-system", "pod_name": "other-pod", "name": "container"},
+			samples: otherMemorySamples,
+		},
+	})
+	defer server.Close()
+
+	mockClient := mockPrometheusAPI{}
+	mockClient.On("LabelValues", mock.Anything, "namespace", []string{"container_memory_working_set_bytes{job=\"kubernetes-cadvisor\", pod_name=~\".+\", name!=\"POD\", name!=\"\"}"}).Return(
+		prommodel.LabelValues{"default", "kube-system"}, nil)
+	mockClient.On("Query", mock.Anything, labelsQuery, mock.AnythingOfType("time.Time")).Return(prommodel.Matrix{}, nil)
+	historyProvider := newTestRemoteReadHistoryProvider(server.URL, &mockClient)
+
+	histories, err := historyProvider.GetClusterHistory()
+	assert.NoError(t, err)
+	secondHourStart := hourStart.Add(time.Hour)
+	assert.Equal(t, map[model.PodID]*PodHistory{
+		{Namespace: "default", PodName: "pod"}: {
+			LastLabels: map[string]string{},
+			Samples: map[string][]model.ContainerUsageSample{"container": {
+				{MeasureStart: hourStart, Usage: model.CPUAmountFromCores(0.5), Resource: model.ResourceCPU},
+				{MeasureStart: hourStart, Usage: model.MemoryAmountFromBytes(1.5e9), Resource: model.ResourceMemory},
+				{MeasureStart: secondHourStart, Usage: model.CPUAmountFromCores(2), Resource: model.ResourceCPU},
+				{MeasureStart: secondHourStart, Usage: model.MemoryAmountFromBytes(2e9), Resource: model.ResourceMemory},
+			}},
+		},
+		{Namespace: "kube-system", PodName: "other-pod"}: {
+			LastLabels: map[string]string{},
+			Samples: map[string][]model.ContainerUsageSample{"container": {
+				{MeasureStart: hourStart, Usage: model.MemoryAmountFromBytes(1e8), Resource: model.ResourceMemory},
+				{MeasureStart: secondHourStart, Usage: model.MemoryAmountFromBytes(1e8), Resource: model.ResourceMemory},
+			}},
+		},
+	}, histories)
+}
+
+func TestRemoteReadError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "remote read disabled", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	mockClient := mockPrometheusAPI{}
+	historyProvider := newTestRemoteReadHistoryProvider(server.URL, &mockClient)
+	historyProvider.shardByNamespace = false
+
+	_, err := historyProvider.GetClusterHistory()
+	assert.ErrorContains(t, err, "remote read disabled")
+}
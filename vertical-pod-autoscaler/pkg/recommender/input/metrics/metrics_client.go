@@ -49,14 +49,14 @@ type MetricsClient interface {
 }
 
 type metricsClient struct {
-	source     PodMetricsLister
+	source     MetricsSource
 	namespace  string
 	clientName string
 }
 
 // NewMetricsClient creates new instance of MetricsClient, which is used by recommender.
 // namespace limits queries to particular namespace, use k8sapiv1.NamespaceAll to select all namespaces.
-func NewMetricsClient(source PodMetricsLister, namespace, clientName string) MetricsClient {
+func NewMetricsClient(source MetricsSource, namespace, clientName string) MetricsClient {
 	return &metricsClient{
 		source:     source,
 		namespace:  namespace,
@@ -117,8 +117,14 @@ func calculateUsage(containerUsage k8sapiv1.ResourceList) model.Resources {
 	memoryQuantity := containerUsage[k8sapiv1.ResourceMemory]
 	memoryBytes := memoryQuantity.Value()
 
-	return model.Resources{
+	usage := model.Resources{
 		model.ResourceCPU:    model.ResourceAmount(cpuMillicores),
 		model.ResourceMemory: model.ResourceAmount(memoryBytes),
 	}
+	for resourceName, quantity := range containerUsage {
+		if model.IsExtendedResource(model.ResourceName(resourceName)) {
+			usage[model.ResourceName(resourceName)] = model.ResourceAmount(quantity.Value())
+		}
+	}
+	return usage
 }
@@ -17,10 +17,17 @@ limitations under the License.
 package metrics
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	k8sapiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2/ktesting"
+	"k8s.io/metrics/pkg/apis/metrics/v1beta1"
+
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/recommender/model"
 )
 
 func TestGetContainersMetricsReturnsEmptyList(t *testing.T) {
@@ -47,3 +54,48 @@ func TestGetContainersMetricsReturnsResults(t *testing.T) {
 		assert.Contains(t, tc.getAllSnaps(), snap, "One of returned ContainerMetricsSnapshot is different then expected ")
 	}
 }
+
+type staticPodMetricsLister struct {
+	podMetrics []v1beta1.PodMetrics
+}
+
+func (l *staticPodMetricsLister) List(_ context.Context, _ string, _ metav1.ListOptions) (*v1beta1.PodMetricsList, error) {
+	return &v1beta1.PodMetricsList{Items: l.podMetrics}, nil
+}
+
+func TestGetContainersMetricsWithExtendedResources(t *testing.T) {
+	_, tctx := ktesting.NewTestContext(t)
+	gpuMemory := k8sapiv1.ResourceName("nvidia.com/gpumem")
+	primary := &staticPodMetricsLister{podMetrics: []v1beta1.PodMetrics{{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod"},
+		Containers: []v1beta1.ContainerMetrics{{
+			Name: "container",
+			Usage: k8sapiv1.ResourceList{
+				k8sapiv1.ResourceCPU:    resource.MustParse("1"),
+				k8sapiv1.ResourceMemory: resource.MustParse("100Mi"),
+			},
+		}},
+	}}}
+	extended := &staticPodMetricsLister{podMetrics: []v1beta1.PodMetrics{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod"},
+			Containers: []v1beta1.ContainerMetrics{{Name: "container", Usage: k8sapiv1.ResourceList{gpuMemory: resource.MustParse("2Gi")}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "other-pod"},
+			Containers: []v1beta1.ContainerMetrics{{Name: "container", Usage: k8sapiv1.ResourceList{gpuMemory: resource.MustParse("1Gi")}}},
+		},
+	}}
+	client := NewMetricsClient(NewMergedPodMetricsLister(primary, extended), k8sapiv1.NamespaceAll, "test-client")
+
+	snapshots, err := client.GetContainersMetrics(tctx)
+
+	assert.NoError(t, err)
+	if assert.Len(t, snapshots, 1) {
+		assert.Equal(t, model.Resources{
+			model.ResourceCPU:             model.ResourceAmount(1000),
+			model.ResourceMemory:          model.ResourceAmount(100 * 1024 * 1024),
+			model.ResourceName(gpuMemory): model.ResourceAmount(2 * 1024 * 1024 * 1024),
+		}, snapshots[0].Usage)
+	}
+}
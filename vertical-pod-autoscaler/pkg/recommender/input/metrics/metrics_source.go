@@ -34,18 +34,26 @@ import (
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/recommender/model"
 )
 
-// PodMetricsLister wraps both metrics-client and External Metrics
-type PodMetricsLister interface {
+// MetricsSource is a source of the current resource usage of pods, e.g. the
+// metrics server, an external metrics provider, Prometheus or an OTLP receiver.
+type MetricsSource interface {
+	// List returns the usage of the containers of the pods in the namespace,
+	// or in all namespaces if it's empty.
 	List(ctx context.Context, namespace string, opts v1.ListOptions) (*v1beta1.PodMetricsList, error)
 }
 
+// PodMetricsLister is the former name of MetricsSource.
+//
+// Deprecated: Use MetricsSource instead.
+type PodMetricsLister = MetricsSource
+
 // podMetricsSource is the metrics-client source of metrics.
 type podMetricsSource struct {
 	metricsGetter resourceclient.PodMetricsesGetter
 }
 
 // NewPodMetricsesSource Returns a Source-wrapper around PodMetricsesGetter.
-func NewPodMetricsesSource(source resourceclient.PodMetricsesGetter) PodMetricsLister {
+func NewPodMetricsesSource(source resourceclient.PodMetricsesGetter) MetricsSource {
 	return podMetricsSource{metricsGetter: source}
 }
 
@@ -69,7 +77,7 @@ type ExternalClientOptions struct {
 }
 
 // NewExternalClient returns a Source for an External Metrics Client.
-func NewExternalClient(c *rest.Config, clusterState model.ClusterState, options ExternalClientOptions) PodMetricsLister {
+func NewExternalClient(c *rest.Config, clusterState model.ClusterState, options ExternalClientOptions) MetricsSource {
 	extClient, err := external_metrics.NewForConfig(c)
 	if err != nil {
 		klog.ErrorS(err, "Failed initializing external metrics client")
@@ -146,3 +154,56 @@ func (s *externalMetricsClient) List(ctx context.Context, namespace string, opts
 	}
 	return &result, nil
 }
+
+// mergedPodMetricsLister adds the usage of extended resources to the metrics
+// of the primary source.
+type mergedPodMetricsLister struct {
+	primary  MetricsSource
+	extended MetricsSource
+}
+
+// NewMergedPodMetricsLister returns a Source which lists pod metrics from the
+// primary source, with the usage reported by the extended source added to the
+// containers. Pods and containers missing from the primary source are skipped.
+func NewMergedPodMetricsLister(primary, extended MetricsSource) MetricsSource {
+	return &mergedPodMetricsLister{primary: primary, extended: extended}
+}
+
+func (s *mergedPodMetricsLister) List(ctx context.Context, namespace string, opts v1.ListOptions) (*v1beta1.PodMetricsList, error) {
+	result, err := s.primary.List(ctx, namespace, opts)
+	if err != nil {
+		return nil, err
+	}
+	extended, err := s.extended.List(ctx, namespace, opts)
+	if err != nil {
+		klog.ErrorS(err, "Failed to list extended resource metrics")
+		return result, nil
+	}
+	extendedUsage := make(map[model.ContainerID]k8sapiv1.ResourceList)
+	for _, podMetrics := range extended.Items {
+		for _, containerMetrics := range podMetrics.Containers {
+			id := model.ContainerID{
+				PodID:         model.PodID{Namespace: podMetrics.Namespace, PodName: podMetrics.Name},
+				ContainerName: containerMetrics.Name,
+			}
+			extendedUsage[id] = containerMetrics.Usage
+		}
+	}
+	for i := range result.Items {
+		podMetrics := &result.Items[i]
+		for j := range podMetrics.Containers {
+			containerMetrics := &podMetrics.Containers[j]
+			id := model.ContainerID{
+				PodID:         model.PodID{Namespace: podMetrics.Namespace, PodName: podMetrics.Name},
+				ContainerName: containerMetrics.Name,
+			}
+			for resourceName, quantity := range extendedUsage[id] {
+				if containerMetrics.Usage == nil {
+					containerMetrics.Usage = make(k8sapiv1.ResourceList)
+				}
+				containerMetrics.Usage[resourceName] = quantity
+			}
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	otlpmetricsv1 "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	k8sapiv1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/metrics/pkg/apis/metrics/v1beta1"
+
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/recommender/model"
+)
+
+const (
+	// OTLPMetricsPath is the path on which the OTLP receiver accepts metrics.
+	OTLPMetricsPath = "/v1/metrics"
+
+	otlpNamespaceAttribute     = "k8s.namespace.name"
+	otlpPodNameAttribute       = "k8s.pod.name"
+	otlpContainerNameAttribute = "k8s.container.name"
+
+	// maxOTLPRequestBytes is the maximum size of an uncompressed export request.
+	maxOTLPRequestBytes = 64 << 20
+)
+
+// OTLPReceiverConfig specifies which metrics pushed to the OTLP receiver hold
+// the usage of containers.
+type OTLPReceiverConfig struct {
+	// CPUMetric is the name of the gauge with the CPU usage of containers, in cores.
+	CPUMetric string
+	// MemoryMetric is the name of the gauge with the memory usage of containers, in bytes.
+	MemoryMetric string
+	// MaxSampleAge is the age after which the latest sample of a container is
+	// no longer listed, e.g. because the container is gone.
+	MaxSampleAge time.Duration
+	// BearerToken, if not empty, has to be sent as a bearer token in the
+	// Authorization header of export requests.
+	BearerToken string
+}
+
+// OTLPReceiver is the source of metrics receiving the usage of containers
+// pushed with the OTLP/HTTP protocol, e.g. by the kubeletstats receiver of an
+// OpenTelemetry collector. Containers are identified by the k8s.namespace.name,
+// k8s.pod.name and k8s.container.name attributes of the resource or the data
+// point. Only the latest sample of each container tracked by the cluster state
+// is kept, samples of other containers are dropped.
+type OTLPReceiver struct {
+	config       OTLPReceiverConfig
+	clusterState model.ClusterState
+	now          func() time.Time
+
+	mutex sync.Mutex
+	usage map[model.ContainerID]*containerUsage
+	// tracked are the containers of the cluster state as of the last List. The
+	// cluster state isn't safe to read while export requests are handled, so
+	// it's only read in List, which is called by the recommender loop.
+	tracked map[model.ContainerID]bool
+}
+
+// NewOTLPReceiver returns an OTLPReceiver. It has to be served on OTLPMetricsPath
+// for metrics to be pushed to it.
+func NewOTLPReceiver(config OTLPReceiverConfig, clusterState model.ClusterState) *OTLPReceiver {
+	return &OTLPReceiver{
+		config:       config,
+		clusterState: clusterState,
+		now:          time.Now,
+		usage:        make(map[model.ContainerID]*containerUsage),
+		tracked:      make(map[model.ContainerID]bool),
+	}
+}
+
+// List returns the latest usage of the containers which isn't older than MaxSampleAge.
+// Samples pushed before the containers were tracked by the cluster state were dropped,
+// so containers are only listed once a sample is pushed after the next List.
+func (r *OTLPReceiver) List(_ context.Context, namespace string, _ v1.ListOptions) (*v1beta1.PodMetricsList, error) {
+	tracked := make(map[model.ContainerID]bool)
+	for podID, pod := range r.clusterState.Pods() {
+		for containerName := range pod.Containers {
+			tracked[model.ContainerID{PodID: podID, ContainerName: containerName}] = true
+		}
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.tracked = tracked
+	oldest := r.now().Add(-r.config.MaxSampleAge)
+	usage := make(map[model.ContainerID]*containerUsage)
+	for id, container := range r.usage {
+		if container.time.Before(oldest) || !tracked[id] {
+			delete(r.usage, id)
+			continue
+		}
+		if namespace != "" && id.Namespace != namespace {
+			continue
+		}
+		usage[id] = &containerUsage{usage: container.usage.DeepCopy(), time: container.time}
+	}
+	return podMetricsList(usage, 0), nil
+}
+
+// ServeHTTP handles an OTLP/HTTP export request, encoded as protobuf or JSON.
+func (r *OTLPReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if !r.authorized(req) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if req.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	body := io.Reader(req.Body)
+	if req.Header.Get("Content-Encoding") == "gzip" {
+		gzipReader, err := gzip.NewReader(req.Body)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid gzip body: %v", err), http.StatusBadRequest)
+			return
+		}
+		defer gzipReader.Close()
+		body = gzipReader
+	}
+	data, err := io.ReadAll(io.LimitReader(body, maxOTLPRequestBytes+1))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to read body: %v", err), http.StatusBadRequest)
+		return
+	}
+	if len(data) > maxOTLPRequestBytes {
+		http.Error(w, "request too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	// MetricsData has the same encoding as ExportMetricsServiceRequest.
+	request := &otlpmetricsv1.MetricsData{}
+	contentType := req.Header.Get("Content-Type")
+	isJSON := strings.HasPrefix(contentType, "application/json")
+	if isJSON {
+		err = protojson.Unmarshal(data, request)
+	} else {
+		err = proto.Unmarshal(data, request)
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid export request: %v", err), http.StatusBadRequest)
+		return
+	}
+	r.record(request)
+
+	// An empty ExportMetricsServiceResponse.
+	if isJSON {
+		w.Header().Set("Content-Type", "application/json")
+		_, err = w.Write([]byte("{}"))
+	} else {
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		w.WriteHeader(http.StatusOK)
+	}
+	if err != nil {
+		klog.ErrorS(err, "Failed to write OTLP export response")
+	}
+}
+
+// authorized tells if the request carries the configured bearer token, if any.
+func (r *OTLPReceiver) authorized(req *http.Request) bool {
+	if r.config.BearerToken == "" {
+		return true
+	}
+	token, found := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	return found && subtle.ConstantTimeCompare([]byte(token), []byte(r.config.BearerToken)) == 1
+}
+
+// record keeps the latest CPU and memory usage of each tracked container in the request.
+func (r *OTLPReceiver) record(request *otlpmetricsv1.MetricsData) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, resourceMetrics := range request.GetResourceMetrics() {
+		resourceAttributes := resourceMetrics.GetResource().GetAttributes()
+		for _, scopeMetrics := range resourceMetrics.GetScopeMetrics() {
+			for _, metric := range scopeMetrics.GetMetrics() {
+				var resourceName k8sapiv1.ResourceName
+				switch metric.GetName() {
+				case r.config.CPUMetric:
+					resourceName = k8sapiv1.ResourceCPU
+				case r.config.MemoryMetric:
+					resourceName = k8sapiv1.ResourceMemory
+				default:
+					continue
+				}
+				var dataPoints []*otlpmetricsv1.NumberDataPoint
+				switch {
+				case metric.GetGauge() != nil:
+					dataPoints = metric.GetGauge().GetDataPoints()
+				case metric.GetSum() != nil && !metric.GetSum().GetIsMonotonic():
+					dataPoints = metric.GetSum().GetDataPoints()
+				default:
+					klog.V(4).InfoS("Skipping OTLP metric which isn't a gauge", "metric", metric.GetName())
+					continue
+				}
+				for _, dataPoint := range dataPoints {
+					r.recordDataPoint(resourceName, resourceAttributes, dataPoint)
+				}
+			}
+		}
+	}
+}
+
+func (r *OTLPReceiver) recordDataPoint(resourceName k8sapiv1.ResourceName, resourceAttributes []*commonv1.KeyValue, dataPoint *otlpmetricsv1.NumberDataPoint) {
+	// Attributes of the data point take precedence over the ones of the resource.
+	attributes := make(map[string]string)
+	for _, attributeList := range [][]*commonv1.KeyValue{resourceAttributes, dataPoint.GetAttributes()} {
+		for _, attribute := range attributeList {
+			attributes[attribute.GetKey()] = attribute.GetValue().GetStringValue()
+		}
+	}
+	id := model.ContainerID{
+		PodID:         model.PodID{Namespace: attributes[otlpNamespaceAttribute], PodName: attributes[otlpPodNameAttribute]},
+		ContainerName: attributes[otlpContainerNameAttribute],
+	}
+	if id.Namespace == "" || id.PodName == "" || id.ContainerName == "" {
+		klog.V(4).InfoS("Skipping OTLP data point without container attributes", "resource", resourceName, "attributes", attributes)
+		return
+	}
+	if !r.tracked[id] {
+		klog.V(4).InfoS("Skipping OTLP data point of a container not tracked by the recommender", "resource", resourceName, "container", id)
+		return
+	}
+	value := dataPoint.GetAsDouble()
+	if _, isInt := dataPoint.GetValue().(*otlpmetricsv1.NumberDataPoint_AsInt); isInt {
+		value = float64(dataPoint.GetAsInt())
+	}
+	sampleTime := time.Unix(0, int64(dataPoint.GetTimeUnixNano()))
+	container := r.usage[id]
+	if container != nil && sampleTime.Before(container.time) {
+		// An older sample, e.g. a retried request.
+		return
+	}
+	r.usage[id] = container.add(resourceName, quantityFromValue(value, resourceName), sampleTime)
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	otlpmetricsv1 "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcev1 "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	k8sapiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2/ktesting"
+
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/recommender/model"
+)
+
+var otlpTestTime = time.Unix(1700000000, 0)
+
+func stringAttribute(key, value string) *commonv1.KeyValue {
+	return &commonv1.KeyValue{Key: key, Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: value}}}
+}
+
+func containerResource(namespace, pod, container string, metrics ...*otlpmetricsv1.Metric) *otlpmetricsv1.ResourceMetrics {
+	return &otlpmetricsv1.ResourceMetrics{
+		Resource: &resourcev1.Resource{Attributes: []*commonv1.KeyValue{
+			stringAttribute(otlpNamespaceAttribute, namespace),
+			stringAttribute(otlpPodNameAttribute, pod),
+			stringAttribute(otlpContainerNameAttribute, container),
+		}},
+		ScopeMetrics: []*otlpmetricsv1.ScopeMetrics{{Metrics: metrics}},
+	}
+}
+
+func doubleGauge(name string, value float64, timestamp time.Time) *otlpmetricsv1.Metric {
+	return &otlpmetricsv1.Metric{Name: name, Data: &otlpmetricsv1.Metric_Gauge{Gauge: &otlpmetricsv1.Gauge{
+		DataPoints: []*otlpmetricsv1.NumberDataPoint{{
+			TimeUnixNano: uint64(timestamp.UnixNano()),
+			Value:        &otlpmetricsv1.NumberDataPoint_AsDouble{AsDouble: value},
+		}},
+	}}}
+}
+
+func intSum(name string, value int64, timestamp time.Time, monotonic bool) *otlpmetricsv1.Metric {
+	return &otlpmetricsv1.Metric{Name: name, Data: &otlpmetricsv1.Metric_Sum{Sum: &otlpmetricsv1.Sum{
+		IsMonotonic: monotonic,
+		DataPoints: []*otlpmetricsv1.NumberDataPoint{{
+			TimeUnixNano: uint64(timestamp.UnixNano()),
+			Value:        &otlpmetricsv1.NumberDataPoint_AsInt{AsInt: value},
+		}},
+	}}}
+}
+
+// newTestOTLPReceiver returns a receiver tracking containers c1 and c2 of default/p1, c1 of default/p2 and
+// c1 of kube-system/p3.
+func newTestOTLPReceiver(t *testing.T, bearerToken string) *OTLPReceiver {
+	clusterState := model.NewClusterState(time.Minute)
+	for _, id := range []model.ContainerID{
+		{PodID: model.PodID{Namespace: "default", PodName: "p1"}, ContainerName: "c1"},
+		{PodID: model.PodID{Namespace: "default", PodName: "p1"}, ContainerName: "c2"},
+		{PodID: model.PodID{Namespace: "default", PodName: "p2"}, ContainerName: "c1"},
+		{PodID: model.PodID{Namespace: "kube-system", PodName: "p3"}, ContainerName: "c1"},
+	} {
+		clusterState.AddOrUpdatePod(id.PodID, nil, k8sapiv1.PodRunning)
+		assert.NoError(t, clusterState.AddOrUpdateContainer(id, nil))
+	}
+	receiver := NewOTLPReceiver(OTLPReceiverConfig{
+		CPUMetric:    "container.cpu.usage",
+		MemoryMetric: "container.memory.working_set",
+		MaxSampleAge: 2 * time.Minute,
+		BearerToken:  bearerToken,
+	}, clusterState)
+	receiver.now = func() time.Time { return otlpTestTime }
+	// The containers of the cluster state are tracked once listed.
+	_, tctx := ktesting.NewTestContext(t)
+	_, err := receiver.List(tctx, "", metav1.ListOptions{})
+	assert.NoError(t, err)
+	return receiver
+}
+
+func export(t *testing.T, receiver *OTLPReceiver, request *otlpmetricsv1.MetricsData, asJSON bool) *httptest.ResponseRecorder {
+	t.Helper()
+	var body []byte
+	var err error
+	contentType := "application/x-protobuf"
+	if asJSON {
+		body, err = protojson.Marshal(request)
+		contentType = "application/json"
+	} else {
+		body, err = proto.Marshal(request)
+	}
+	assert.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, OTLPMetricsPath, bytes.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	if receiver.config.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+receiver.config.BearerToken)
+	}
+	recorder := httptest.NewRecorder()
+	receiver.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestOTLPReceiverList(t *testing.T) {
+	for _, asJSON := range []bool{false, true} {
+		t.Run(map[bool]string{false: "protobuf", true: "json"}[asJSON], func(t *testing.T) {
+			_, tctx := ktesting.NewTestContext(t)
+			receiver := newTestOTLPReceiver(t, "")
+			response := export(t, receiver, &otlpmetricsv1.MetricsData{ResourceMetrics: []*otlpmetricsv1.ResourceMetrics{
+				containerResource("default", "p1", "c1",
+					doubleGauge("container.cpu.usage", 0.25, otlpTestTime),
+					intSum("container.memory.working_set", 1<<20, otlpTestTime, false)),
+				containerResource("default", "p1", "c2",
+					doubleGauge("container.cpu.usage", 1.5, otlpTestTime.Add(-time.Minute)),
+					doubleGauge("container.memory.working_set", 2048, otlpTestTime.Add(-time.Minute)),
+					doubleGauge("other.metric", 1, otlpTestTime)),
+				// Cumulative sums aren't usage.
+				containerResource("default", "p2", "c1",
+					doubleGauge("container.cpu.usage", 0.5, otlpTestTime),
+					intSum("container.memory.working_set", 1<<20, otlpTestTime, true)),
+				containerResource("kube-system", "p3", "c1",
+					doubleGauge("container.cpu.usage", 0.5, otlpTestTime),
+					doubleGauge("container.memory.working_set", 1024, otlpTestTime)),
+			}}, asJSON)
+			assert.Equal(t, http.StatusOK, response.Code)
+
+			podMetrics, err := receiver.List(tctx, "default", metav1.ListOptions{})
+			assert.NoError(t, err)
+			if assert.Len(t, podMetrics.Items, 1) {
+				pod := podMetrics.Items[0]
+				assert.Equal(t, "p1", pod.Name)
+				assert.Equal(t, otlpTestTime, pod.Timestamp.Time)
+				sort.Slice(pod.Containers, func(i, j int) bool { return pod.Containers[i].Name < pod.Containers[j].Name })
+				if assert.Len(t, pod.Containers, 2) {
+					assertQuantity(t, resource.MustParse("250m"), pod.Containers[0].Usage[k8sapiv1.ResourceCPU])
+					assertQuantity(t, resource.MustParse("1Mi"), pod.Containers[0].Usage[k8sapiv1.ResourceMemory])
+					assertQuantity(t, resource.MustParse("1500m"), pod.Containers[1].Usage[k8sapiv1.ResourceCPU])
+					assertQuantity(t, resource.MustParse("2Ki"), pod.Containers[1].Usage[k8sapiv1.ResourceMemory])
+				}
+			}
+
+			podMetrics, err = receiver.List(tctx, "", metav1.ListOptions{})
+			assert.NoError(t, err)
+			assert.Len(t, podMetrics.Items, 2)
+		})
+	}
+}
+
+func TestOTLPReceiverDropsStaleSamples(t *testing.T) {
+	_, tctx := ktesting.NewTestContext(t)
+	receiver := newTestOTLPReceiver(t, "")
+	export(t, receiver, &otlpmetricsv1.MetricsData{ResourceMetrics: []*otlpmetricsv1.ResourceMetrics{
+		containerResource("default", "p1", "c1",
+			doubleGauge("container.cpu.usage", 0.25, otlpTestTime),
+			doubleGauge("container.memory.working_set", 1024, otlpTestTime)),
+	}}, false)
+	// An older sample doesn't replace the latest one.
+	export(t, receiver, &otlpmetricsv1.MetricsData{ResourceMetrics: []*otlpmetricsv1.ResourceMetrics{
+		containerResource("default", "p1", "c1", doubleGauge("container.cpu.usage", 4, otlpTestTime.Add(-time.Second))),
+	}}, false)
+
+	podMetrics, err := receiver.List(tctx, "", metav1.ListOptions{})
+	assert.NoError(t, err)
+	if assert.Len(t, podMetrics.Items, 1) && assert.Len(t, podMetrics.Items[0].Containers, 1) {
+		assertQuantity(t, resource.MustParse("250m"), podMetrics.Items[0].Containers[0].Usage[k8sapiv1.ResourceCPU])
+	}
+
+	receiver.now = func() time.Time { return otlpTestTime.Add(3 * time.Minute) }
+	podMetrics, err = receiver.List(tctx, "", metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, podMetrics.Items)
+	assert.Empty(t, receiver.usage)
+}
+
+func TestOTLPReceiverDropsUntrackedContainers(t *testing.T) {
+	_, tctx := ktesting.NewTestContext(t)
+	receiver := newTestOTLPReceiver(t, "")
+	export(t, receiver, &otlpmetricsv1.MetricsData{ResourceMetrics: []*otlpmetricsv1.ResourceMetrics{
+		containerResource("default", "p1", "c1",
+			doubleGauge("container.cpu.usage", 0.25, otlpTestTime),
+			doubleGauge("container.memory.working_set", 1024, otlpTestTime)),
+		containerResource("default", "unknown", "c1",
+			doubleGauge("container.cpu.usage", 0.25, otlpTestTime),
+			doubleGauge("container.memory.working_set", 1024, otlpTestTime)),
+	}}, false)
+	assert.Len(t, receiver.usage, 1)
+
+	// Samples of containers no longer tracked are dropped on the next List.
+	receiver.clusterState.DeletePod(model.PodID{Namespace: "default", PodName: "p1"})
+	podMetrics, err := receiver.List(tctx, "", metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, podMetrics.Items)
+	assert.Empty(t, receiver.usage)
+}
+
+func TestOTLPReceiverRequiresBearerToken(t *testing.T) {
+	receiver := newTestOTLPReceiver(t, "secret")
+	request := &otlpmetricsv1.MetricsData{ResourceMetrics: []*otlpmetricsv1.ResourceMetrics{
+		containerResource("default", "p1", "c1", doubleGauge("container.cpu.usage", 0.25, otlpTestTime)),
+	}}
+	body, err := proto.Marshal(request)
+	assert.NoError(t, err)
+	for _, authorization := range []string{"", "Bearer other", "Basic secret"} {
+		req := httptest.NewRequest(http.MethodPost, OTLPMetricsPath, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-protobuf")
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		recorder := httptest.NewRecorder()
+		receiver.ServeHTTP(recorder, req)
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code, authorization)
+	}
+	assert.Empty(t, receiver.usage)
+
+	assert.Equal(t, http.StatusOK, export(t, receiver, request, false).Code)
+	assert.Len(t, receiver.usage, 1)
+}
+
+func TestOTLPReceiverRejectsInvalidRequests(t *testing.T) {
+	receiver := newTestOTLPReceiver(t, "")
+
+	recorder := httptest.NewRecorder()
+	receiver.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, OTLPMetricsPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, OTLPMetricsPath, bytes.NewReader([]byte("{")))
+	req.Header.Set("Content-Type", "application/json")
+	receiver.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
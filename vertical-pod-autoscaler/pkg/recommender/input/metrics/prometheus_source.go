@@ -0,0 +1,214 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	promapi "github.com/prometheus/client_golang/api"
+	prometheusv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	prommodel "github.com/prometheus/common/model"
+	k8sapiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/metrics/pkg/apis/metrics/v1beta1"
+
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/recommender/model"
+)
+
+// PrometheusSourceConfig specifies how the current usage of containers is
+// queried from Prometheus.
+type PrometheusSourceConfig struct {
+	Address      string
+	QueryTimeout time.Duration
+	// CPUUsageWindow is the range over which the CPU usage rate is computed.
+	CPUUsageWindow                                   time.Duration
+	CtrNamespaceLabel, CtrPodNameLabel, CtrNameLabel string
+	CadvisorMetricsJobName                           string
+	// RoundTripper, if set, is used for the requests to Prometheus, e.g. to
+	// add basic auth.
+	RoundTripper http.RoundTripper
+}
+
+// prometheusSource is the source of metrics reading the current usage of
+// containers from the cAdvisor metrics in Prometheus with instant queries.
+type prometheusSource struct {
+	client prometheusv1.API
+	config PrometheusSourceConfig
+}
+
+// NewPrometheusSource returns a Source querying the current usage of containers
+// from Prometheus.
+func NewPrometheusSource(config PrometheusSourceConfig) (MetricsSource, error) {
+	promConfig := promapi.Config{
+		Address:      config.Address,
+		RoundTripper: config.RoundTripper,
+	}
+	promClient, err := promapi.NewClient(promConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &prometheusSource{
+		client: prometheusv1.NewAPI(promClient),
+		config: config,
+	}, nil
+}
+
+func (s *prometheusSource) List(ctx context.Context, namespace string, opts v1.ListOptions) (*v1beta1.PodMetricsList, error) {
+	selector := s.selector(namespace)
+	usage := make(map[model.ContainerID]*containerUsage)
+
+	cpuQuery := fmt.Sprintf("rate(container_cpu_usage_seconds_total{%s}[%s])", selector, prommodel.Duration(s.config.CPUUsageWindow))
+	if err := s.query(ctx, cpuQuery, k8sapiv1.ResourceCPU, usage); err != nil {
+		return nil, err
+	}
+	memoryQuery := fmt.Sprintf("container_memory_working_set_bytes{%s}", selector)
+	if err := s.query(ctx, memoryQuery, k8sapiv1.ResourceMemory, usage); err != nil {
+		return nil, err
+	}
+	return podMetricsList(usage, s.config.CPUUsageWindow), nil
+}
+
+// query runs an instant query and adds the usage of the resource reported by
+// each series to the usage of its container.
+func (s *prometheusSource) query(ctx context.Context, query string, resourceName k8sapiv1.ResourceName, usage map[model.ContainerID]*containerUsage) error {
+	ctx, cancel := context.WithTimeout(ctx, s.config.QueryTimeout)
+	defer cancel()
+
+	klog.V(4).InfoS("Current usage query", "resource", resourceName, "query", query)
+	result, warnings, err := s.client.Query(ctx, query, time.Now())
+	if err != nil {
+		return fmt.Errorf("cannot get current %v usage: %v", resourceName, err)
+	}
+	if len(warnings) > 0 {
+		klog.V(4).InfoS("Warnings of current usage query", "resource", resourceName, "warnings", warnings)
+	}
+	vector, ok := result.(prommodel.Vector)
+	if !ok {
+		return fmt.Errorf("expected query to return a vector; got result type %T", result)
+	}
+
+	for _, sample := range vector {
+		id, err := s.containerID(sample.Metric)
+		if err != nil {
+			klog.V(4).InfoS("Skipping series of current usage query", "resource", resourceName, "metric", sample.Metric, "err", err)
+			continue
+		}
+		usage[id] = usage[id].add(resourceName, quantityFromValue(float64(sample.Value), resourceName), sample.Timestamp.Time())
+	}
+	return nil
+}
+
+// selector returns the label selector of the cAdvisor container metrics.
+func (s *prometheusSource) selector(namespace string) string {
+	matchers := []string{
+		fmt.Sprintf("%s=~\".+\"", s.config.CtrPodNameLabel),
+		fmt.Sprintf("%s!=\"POD\"", s.config.CtrNameLabel),
+		fmt.Sprintf("%s!=\"\"", s.config.CtrNameLabel),
+	}
+	if s.config.CadvisorMetricsJobName != "" {
+		matchers = append([]string{fmt.Sprintf("job=\"%s\"", s.config.CadvisorMetricsJobName)}, matchers...)
+	}
+	if namespace != "" {
+		matchers = append(matchers, fmt.Sprintf("%s=\"%s\"", s.config.CtrNamespaceLabel, namespace))
+	}
+	return strings.Join(matchers, ", ")
+}
+
+func (s *prometheusSource) containerID(metric prommodel.Metric) (model.ContainerID, error) {
+	namespace, ok := metric[prommodel.LabelName(s.config.CtrNamespaceLabel)]
+	if !ok {
+		return model.ContainerID{}, fmt.Errorf("no %s label", s.config.CtrNamespaceLabel)
+	}
+	podName, ok := metric[prommodel.LabelName(s.config.CtrPodNameLabel)]
+	if !ok {
+		return model.ContainerID{}, fmt.Errorf("no %s label", s.config.CtrPodNameLabel)
+	}
+	containerName, ok := metric[prommodel.LabelName(s.config.CtrNameLabel)]
+	if !ok {
+		return model.ContainerID{}, fmt.Errorf("no %s label", s.config.CtrNameLabel)
+	}
+	return model.ContainerID{
+		PodID:         model.PodID{Namespace: string(namespace), PodName: string(podName)},
+		ContainerName: string(containerName),
+	}, nil
+}
+
+// quantityFromValue converts a CPU usage in cores or a memory usage in bytes
+// to a quantity.
+func quantityFromValue(value float64, resourceName k8sapiv1.ResourceName) resource.Quantity {
+	if resourceName == k8sapiv1.ResourceCPU {
+		return *resource.NewMilliQuantity(int64(value*1000), resource.DecimalSI)
+	}
+	return *resource.NewQuantity(int64(value), resource.BinarySI)
+}
+
+// containerUsage is the usage of the resources of a container, as of the time
+// of its latest sample.
+type containerUsage struct {
+	usage k8sapiv1.ResourceList
+	time  time.Time
+}
+
+// add sets the usage of a resource, creating the containerUsage if it's nil.
+func (u *containerUsage) add(resourceName k8sapiv1.ResourceName, quantity resource.Quantity, sampleTime time.Time) *containerUsage {
+	if u == nil {
+		u = &containerUsage{usage: make(k8sapiv1.ResourceList)}
+	}
+	u.usage[resourceName] = quantity
+	if sampleTime.After(u.time) {
+		u.time = sampleTime
+	}
+	return u
+}
+
+// podMetricsList groups the usage of containers by pod, as of the latest sample
+// of their containers. Containers missing the usage of CPU or memory are skipped,
+// as a missing usage would be taken for a zero usage.
+func podMetricsList(usage map[model.ContainerID]*containerUsage, window time.Duration) *v1beta1.PodMetricsList {
+	pods := make(map[model.PodID]*v1beta1.PodMetrics)
+	for id, container := range usage {
+		_, hasCPU := container.usage[k8sapiv1.ResourceCPU]
+		_, hasMemory := container.usage[k8sapiv1.ResourceMemory]
+		if !hasCPU || !hasMemory {
+			klog.V(4).InfoS("Skipping container without both CPU and memory usage", "pod", klog.KRef(id.Namespace, id.PodName), "container", id.ContainerName)
+			continue
+		}
+		pod, found := pods[id.PodID]
+		if !found {
+			pod = &v1beta1.PodMetrics{
+				ObjectMeta: v1.ObjectMeta{Namespace: id.Namespace, Name: id.PodName},
+				Window:     v1.Duration{Duration: window},
+			}
+			pods[id.PodID] = pod
+		}
+		if container.time.After(pod.Timestamp.Time) {
+			pod.Timestamp = v1.NewTime(container.time)
+		}
+		pod.Containers = append(pod.Containers, v1beta1.ContainerMetrics{Name: id.ContainerName, Usage: container.usage})
+	}
+	result := &v1beta1.PodMetricsList{Items: make([]v1beta1.PodMetrics, 0, len(pods))}
+	for _, pod := range pods {
+		result.Items = append(result.Items, *pod)
+	}
+	return result
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	k8sapiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2/ktesting"
+)
+
+func vectorResponse(samples ...string) string {
+	return fmt.Sprintf(`{"status":"success","data":{"resultType":"vector","result":[%s]}}`, strings.Join(samples, ","))
+}
+
+func vectorSample(namespace, pod, container string, timestamp int64, value string) string {
+	return fmt.Sprintf(`{"metric":{"namespace":%q,"pod":%q,"container":%q},"value":[%d,%q]}`, namespace, pod, container, timestamp, value)
+}
+
+func TestPrometheusSourceList(t *testing.T) {
+	_, tctx := ktesting.NewTestContext(t)
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		query := r.Form.Get("query")
+		queries = append(queries, query)
+		switch {
+		case strings.HasPrefix(query, "rate(container_cpu_usage_seconds_total"):
+			fmt.Fprint(w, vectorResponse(
+				vectorSample("default", "p1", "c1", 1700000000, "0.25"),
+				vectorSample("default", "p1", "c2", 1700000000, "1.5"),
+				// No memory usage.
+				vectorSample("default", "p2", "c1", 1700000000, "0.5"),
+			))
+		case strings.HasPrefix(query, "container_memory_working_set_bytes"):
+			fmt.Fprint(w, vectorResponse(
+				vectorSample("default", "p1", "c1", 1700000030, "1048576"),
+				vectorSample("default", "p1", "c2", 1700000000, "2048"),
+			))
+		default:
+			t.Errorf("unexpected query %s", query)
+		}
+	}))
+	defer server.Close()
+
+	source, err := NewPrometheusSource(PrometheusSourceConfig{
+		Address:                server.URL,
+		QueryTimeout:           time.Minute,
+		CPUUsageWindow:         2 * time.Minute,
+		CtrNamespaceLabel:      "namespace",
+		CtrPodNameLabel:        "pod",
+		CtrNameLabel:           "container",
+		CadvisorMetricsJobName: "cadvisor",
+	})
+	assert.NoError(t, err)
+	podMetrics, err := source.List(tctx, "default", metav1.ListOptions{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		`rate(container_cpu_usage_seconds_total{job="cadvisor", pod=~".+", container!="POD", container!="", namespace="default"}[2m])`,
+		`container_memory_working_set_bytes{job="cadvisor", pod=~".+", container!="POD", container!="", namespace="default"}`,
+	}, queries)
+	if assert.Len(t, podMetrics.Items, 1) {
+		pod := podMetrics.Items[0]
+		assert.Equal(t, "default", pod.Namespace)
+		assert.Equal(t, "p1", pod.Name)
+		assert.Equal(t, time.Unix(1700000030, 0), pod.Timestamp.Time)
+		assert.Equal(t, 2*time.Minute, pod.Window.Duration)
+		sort.Slice(pod.Containers, func(i, j int) bool { return pod.Containers[i].Name < pod.Containers[j].Name })
+		if assert.Len(t, pod.Containers, 2) {
+			assert.Equal(t, "c1", pod.Containers[0].Name)
+			assertQuantity(t, resource.MustParse("250m"), pod.Containers[0].Usage[k8sapiv1.ResourceCPU])
+			assertQuantity(t, resource.MustParse("1Mi"), pod.Containers[0].Usage[k8sapiv1.ResourceMemory])
+			assert.Equal(t, "c2", pod.Containers[1].Name)
+			assertQuantity(t, resource.MustParse("1500m"), pod.Containers[1].Usage[k8sapiv1.ResourceCPU])
+			assertQuantity(t, resource.MustParse("2Ki"), pod.Containers[1].Usage[k8sapiv1.ResourceMemory])
+		}
+	}
+}
+
+func TestPrometheusSourceListError(t *testing.T) {
+	_, tctx := ktesting.NewTestContext(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"status":"error","errorType":"internal","error":"unavailable"}`, http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	source, err := NewPrometheusSource(PrometheusSourceConfig{Address: server.URL, QueryTimeout: time.Minute, CPUUsageWindow: time.Minute})
+	assert.NoError(t, err)
+	_, err = source.List(tctx, "", metav1.ListOptions{})
+	assert.Error(t, err)
+}
+
+func assertQuantity(t *testing.T, expected, actual resource.Quantity) {
+	t.Helper()
+	assert.Equal(t, 0, expected.Cmp(actual), "expected %s, got %s", expected.String(), actual.String())
+}
@@ -22,6 +22,7 @@ import (
 	v1lister "k8s.io/client-go/listers/core/v1"
 
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/recommender/model"
+	resourcehelpers "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/resources"
 )
 
 // BasicPodSpec contains basic information defining a pod and its containers.
@@ -32,8 +33,10 @@ type BasicPodSpec struct {
 	PodLabels map[string]string
 	// List of containers within this pod.
 	Containers []BasicContainerSpec
-	// List of init containers within this pod.
+	// List of init containers within this pod, excluding sidecar containers.
 	InitContainers []BasicContainerSpec
+	// List of restartable init (sidecar) containers within this pod.
+	SidecarContainers []BasicContainerSpec
 	// PodPhase describing current life cycle phase of the Pod.
 	Phase v1.PodPhase
 }
@@ -85,14 +88,24 @@ func newBasicPodSpec(pod *v1.Pod) *BasicPodSpec {
 		Namespace: pod.Namespace,
 	}
 	containerSpecs := newContainerSpecs(podId, pod.Spec.Containers)
-	initContainerSpecs := newContainerSpecs(podId, pod.Spec.InitContainers)
+	var initContainers, sidecarContainers []v1.Container
+	for _, initContainer := range pod.Spec.InitContainers {
+		if resourcehelpers.IsSidecarContainer(initContainer) {
+			sidecarContainers = append(sidecarContainers, initContainer)
+		} else {
+			initContainers = append(initContainers, initContainer)
+		}
+	}
+	initContainerSpecs := newContainerSpecs(podId, initContainers)
+	sidecarContainerSpecs := newContainerSpecs(podId, sidecarContainers)
 
 	basicPodSpec := &BasicPodSpec{
-		ID:             podId,
-		PodLabels:      pod.Labels,
-		Containers:     containerSpecs,
-		InitContainers: initContainerSpecs,
-		Phase:          pod.Status.Phase,
+		ID:                podId,
+		PodLabels:         pod.Labels,
+		Containers:        containerSpecs,
+		InitContainers:    initContainerSpecs,
+		SidecarContainers: sidecarContainerSpecs,
+		Phase:             pod.Status.Phase,
 	}
 	return basicPodSpec
 }
@@ -89,6 +89,13 @@ spec:
       requests:
         memory: "128Mi"
         cpu: "40m"
+  - name: Name22-sidecar
+    image: Name22-sidecarImage
+    restartPolicy: Always
+    resources:
+      requests:
+        memory: "64Mi"
+        cpu: "20m"
 `
 
 type podListerMock struct {
@@ -124,9 +131,11 @@ func newSpecClientTestCase() *specClientTestCase {
 	containerSpec22 := newTestContainerSpec(podID2, "Name22", 4000, 4096*1024*1024)
 
 	initContainerSpec21 := newTestContainerSpec(podID2, "Name21-init", 40, 128*1024*1024)
+	sidecarContainerSpec22 := newTestContainerSpec(podID2, "Name22-sidecar", 20, 64*1024*1024)
 
 	podSpec1 := newTestPodSpec(podID1, []BasicContainerSpec{containerSpec11, containerSpec12}, nil)
 	podSpec2 := newTestPodSpec(podID2, []BasicContainerSpec{containerSpec21, containerSpec22}, []BasicContainerSpec{initContainerSpec21})
+	podSpec2.SidecarContainers = []BasicContainerSpec{sidecarContainerSpec22}
 
 	return &specClientTestCase{
 		podSpecs: []*BasicPodSpec{podSpec1, podSpec2},
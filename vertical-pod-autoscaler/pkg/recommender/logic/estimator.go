@@ -40,18 +40,34 @@ type MemoryEstimator interface {
 	GetMemoryEstimation(s *model.AggregateContainerState) model.ResourceAmount
 }
 
+// ExtendedResourceEstimator predicts the amount of an extended resource needed by a container
+type ExtendedResourceEstimator interface {
+	GetExtendedResourceEstimation(s *model.AggregateContainerState, resource model.ResourceName) model.ResourceAmount
+}
+
 // combinedEstimator is a ResourceEstimator that combines two estimators: one for CPU and one for memory.
 type combinedEstimator struct {
 	cpuEstimator    CPUEstimator
 	memoryEstimator MemoryEstimator
 }
 
+// PolicyPercentile returns the percentile set by the resource policy of the
+// aggregated containers, or nil if the estimator's default should be used.
+type PolicyPercentile func(s *model.AggregateContainerState) *float64
+
 type percentileCPUEstimator struct {
-	percentile float64
+	percentile       float64
+	policyPercentile PolicyPercentile
 }
 
 type percentileMemoryEstimator struct {
-	percentile float64
+	percentile       float64
+	policyPercentile PolicyPercentile
+}
+
+type percentileExtendedResourceEstimator struct {
+	percentile       float64
+	policyPercentile PolicyPercentile
 }
 
 // margins
@@ -66,6 +82,11 @@ type memoryMarginEstimator struct {
 	baseEstimator  MemoryEstimator
 }
 
+type extendedResourceMarginEstimator struct {
+	marginFraction float64
+	baseEstimator  ExtendedResourceEstimator
+}
+
 type cpuConfidenceMultiplier struct {
 	multiplier         float64
 	exponent           float64
@@ -90,6 +111,10 @@ type memoryMinResourceEstimator struct {
 	baseEstimator MemoryEstimator
 }
 
+type memoryOOMFloorEstimator struct {
+	baseEstimator MemoryEstimator
+}
+
 // NewCombinedEstimator returns a new combinedEstimator that uses provided estimators.
 func NewCombinedEstimator(cpuEstimator CPUEstimator, memoryEstimator MemoryEstimator) ResourceEstimator {
 	return &combinedEstimator{cpuEstimator, memoryEstimator}
@@ -97,17 +122,35 @@ func NewCombinedEstimator(cpuEstimator CPUEstimator, memoryEstimator MemoryEstim
 
 // NewPercentileCPUEstimator returns a new percentileCPUEstimator that uses provided percentile.
 func NewPercentileCPUEstimator(percentile float64) CPUEstimator {
-	return &percentileCPUEstimator{percentile}
+	return &percentileCPUEstimator{percentile: percentile}
 }
 
 // NewPercentileMemoryEstimator returns a new percentileMemoryEstimator that uses provided percentile.
 func NewPercentileMemoryEstimator(percentile float64) MemoryEstimator {
-	return &percentileMemoryEstimator{percentile}
+	return &percentileMemoryEstimator{percentile: percentile}
 }
 
 // NewMemoryEstimator returns a new percentileMemoryEstimator that uses provided percentile.
 func NewMemoryEstimator(percentile float64) MemoryEstimator {
-	return &percentileMemoryEstimator{percentile}
+	return &percentileMemoryEstimator{percentile: percentile}
+}
+
+// NewPolicyPercentileCPUEstimator returns a new percentileCPUEstimator that uses the percentile
+// set by the resource policy, falling back to the provided one.
+func NewPolicyPercentileCPUEstimator(percentile float64, policyPercentile PolicyPercentile) CPUEstimator {
+	return &percentileCPUEstimator{percentile: percentile, policyPercentile: policyPercentile}
+}
+
+// NewPolicyPercentileMemoryEstimator returns a new percentileMemoryEstimator that uses the percentile
+// set by the resource policy, falling back to the provided one.
+func NewPolicyPercentileMemoryEstimator(percentile float64, policyPercentile PolicyPercentile) MemoryEstimator {
+	return &percentileMemoryEstimator{percentile: percentile, policyPercentile: policyPercentile}
+}
+
+// NewPolicyPercentileExtendedResourceEstimator returns a new percentileExtendedResourceEstimator that uses
+// the percentile set by the resource policy, falling back to the provided one.
+func NewPolicyPercentileExtendedResourceEstimator(percentile float64, policyPercentile PolicyPercentile) ExtendedResourceEstimator {
+	return &percentileExtendedResourceEstimator{percentile: percentile, policyPercentile: policyPercentile}
 }
 
 // GetCPUEstimation returns the CPU estimation for the given AggregateContainerState.
@@ -124,6 +167,13 @@ func (e *memoryMarginEstimator) GetMemoryEstimation(s *model.AggregateContainerS
 	return base + margin
 }
 
+// GetExtendedResourceEstimation returns the extended resource estimation for the given AggregateContainerState.
+func (e *extendedResourceMarginEstimator) GetExtendedResourceEstimation(s *model.AggregateContainerState, resource model.ResourceName) model.ResourceAmount {
+	base := e.baseEstimator.GetExtendedResourceEstimation(s, resource)
+	margin := model.ScaleResource(base, e.marginFraction)
+	return base + margin
+}
+
 // WithCPUMargin returns a CPUEstimator that adds a margin to the base estimator.
 func WithCPUMargin(marginFraction float64, baseEstimator CPUEstimator) CPUEstimator {
 	return &cpuMarginEstimator{marginFraction: marginFraction, baseEstimator: baseEstimator}
@@ -134,6 +184,11 @@ func WithMemoryMargin(marginFraction float64, baseEstimator MemoryEstimator) Mem
 	return &memoryMarginEstimator{marginFraction: marginFraction, baseEstimator: baseEstimator}
 }
 
+// WithExtendedResourceMargin returns an ExtendedResourceEstimator that adds a margin to the base estimator.
+func WithExtendedResourceMargin(marginFraction float64, baseEstimator ExtendedResourceEstimator) ExtendedResourceEstimator {
+	return &extendedResourceMarginEstimator{marginFraction: marginFraction, baseEstimator: baseEstimator}
+}
+
 // WithCPUConfidenceMultiplier return a CPUEstimator estimator
 func WithCPUConfidenceMultiplier(multiplier, exponent float64, baseEstimator CPUEstimator, confidenceInterval time.Duration) CPUEstimator {
 	return &cpuConfidenceMultiplier{
@@ -155,11 +210,39 @@ func WithMemoryConfidenceMultiplier(multiplier, exponent float64, baseEstimator
 }
 
 func (e *percentileCPUEstimator) GetCPUEstimation(s *model.AggregateContainerState) model.ResourceAmount {
-	return model.CPUAmountFromCores(s.AggregateCPUUsage.Percentile(e.percentile))
+	percentile := getPercentile(e.percentile, e.policyPercentile, s)
+	if cpu, ok := s.GetRecommenderModel().Percentile(model.ResourceCPU, percentile); ok {
+		return cpu
+	}
+	// The recommender model has no usage yet, fall back to the histogram.
+	return model.CPUAmountFromCores(s.AggregateCPUUsage.Percentile(percentile))
 }
 
 func (e *percentileMemoryEstimator) GetMemoryEstimation(s *model.AggregateContainerState) model.ResourceAmount {
-	return model.MemoryAmountFromBytes(s.AggregateMemoryPeaks.Percentile(e.percentile))
+	percentile := getPercentile(e.percentile, e.policyPercentile, s)
+	if memory, ok := s.GetRecommenderModel().Percentile(model.ResourceMemory, percentile); ok {
+		return memory
+	}
+	// The recommender model has no usage yet, fall back to the histogram.
+	return model.MemoryAmountFromBytes(s.AggregateMemoryPeaks.Percentile(percentile))
+}
+
+func (e *percentileExtendedResourceEstimator) GetExtendedResourceEstimation(s *model.AggregateContainerState, resource model.ResourceName) model.ResourceAmount {
+	usage := s.GetExtendedResourceUsage(resource)
+	if usage == nil {
+		return 0
+	}
+	percentile := getPercentile(e.percentile, e.policyPercentile, s)
+	return model.ExtendedResourceAmountFromFloat(usage.Percentile(percentile))
+}
+
+func getPercentile(percentile float64, policyPercentile PolicyPercentile, s *model.AggregateContainerState) float64 {
+	if policyPercentile != nil {
+		if p := policyPercentile(s); p != nil {
+			return *p
+		}
+	}
+	return percentile
 }
 
 // Returns resources computed by the underlying estimators, scaled based on the
@@ -222,6 +305,16 @@ func (e *memoryMinResourceEstimator) GetMemoryEstimation(s *model.AggregateConta
 	return model.ResourceAmountMax(e.baseEstimator.GetMemoryEstimation(s), e.minResource)
 }
 
+// WithMemoryOOMFloor returns a MemoryEstimator that returns at least the memory
+// needed after repeated OOM kills, if the OOM policy raises the lower bound.
+func WithMemoryOOMFloor(baseEstimator MemoryEstimator) MemoryEstimator {
+	return &memoryOOMFloorEstimator{baseEstimator}
+}
+
+func (e *memoryOOMFloorEstimator) GetMemoryEstimation(s *model.AggregateContainerState) model.ResourceAmount {
+	return model.ResourceAmountMax(e.baseEstimator.GetMemoryEstimation(s), s.GetOOMMemoryFloor())
+}
+
 // NewConstMemoryEstimator returns a Memory estimator that always returns the same value
 func NewConstMemoryEstimator(memory model.ResourceAmount) MemoryEstimator {
 	return &constMemoryEstimator{memory}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	vpa_types "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/recommender/model"
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/recommender/util"
 )
@@ -62,6 +63,47 @@ func TestPercentileEstimator(t *testing.T) {
 	assert.InEpsilon(t, 2e9, model.BytesFromMemoryAmount(resourceEstimation[model.ResourceMemory]), maxRelativeError)
 }
 
+// Verifies that the PercentileEstimator uses percentiles set by the resource
+// policy instead of the default ones.
+func TestPolicyPercentileEstimator(t *testing.T) {
+	config := model.GetAggregationsConfig()
+	cpuHistogram := util.NewHistogram(config.CPUHistogramOptions)
+	cpuHistogram.AddSample(1.0, 1.0, anyTime)
+	cpuHistogram.AddSample(2.0, 1.0, anyTime)
+	cpuHistogram.AddSample(3.0, 1.0, anyTime)
+	memoryPeaksHistogram := util.NewHistogram(config.MemoryHistogramOptions)
+	memoryPeaksHistogram.AddSample(1e9, 1.0, anyTime)
+	memoryPeaksHistogram.AddSample(2e9, 1.0, anyTime)
+	memoryPeaksHistogram.AddSample(3e9, 1.0, anyTime)
+	targetPercentile := func(s *model.AggregateContainerState) *float64 { return s.TargetPercentile }
+	combinedEstimator := NewCombinedEstimator(
+		NewPolicyPercentileCPUEstimator(0.2, targetPercentile),
+		NewPolicyPercentileMemoryEstimator(0.2, targetPercentile))
+	maxRelativeError := 0.05 // Allow 5% relative error to account for histogram rounding.
+
+	policyPercentile := 0.9
+	for _, tc := range []struct {
+		name             string
+		targetPercentile *float64
+		wantCPU          float64
+		wantMemory       float64
+	}{
+		{name: "default percentile", wantCPU: 1.0, wantMemory: 1e9},
+		{name: "policy percentile", targetPercentile: &policyPercentile, wantCPU: 3.0, wantMemory: 3e9},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resourceEstimation := combinedEstimator.GetResourceEstimation(
+				&model.AggregateContainerState{
+					AggregateCPUUsage:    cpuHistogram,
+					AggregateMemoryPeaks: memoryPeaksHistogram,
+					TargetPercentile:     tc.targetPercentile,
+				})
+			assert.InEpsilon(t, tc.wantCPU, model.CoresFromCPUAmount(resourceEstimation[model.ResourceCPU]), maxRelativeError)
+			assert.InEpsilon(t, tc.wantMemory, model.BytesFromMemoryAmount(resourceEstimation[model.ResourceMemory]), maxRelativeError)
+		})
+	}
+}
+
 // Verifies that the confidenceMultiplier calculates the internal
 // confidence based on the amount of historical samples and scales the resources
 // returned by the base estimator according to the formula, using the calculated
@@ -192,3 +234,40 @@ func TestMinResourcesEstimator(t *testing.T) {
 	assert.Equal(t, 4e8, model.BytesFromMemoryAmount(memoryEstimation))
 
 }
+
+func TestMemoryOOMFloorEstimator(t *testing.T) {
+	raiseLowerBound := vpa_types.OOMModeRaiseLowerBound
+	s := model.NewAggregateContainerState()
+	s.UpdateFromPolicy(&vpa_types.ContainerResourcePolicy{OOMPolicy: &vpa_types.OOMPolicy{Mode: &raiseLowerBound}})
+	estimator := WithMemoryOOMFloor(NewConstMemoryEstimator(model.MemoryAmountFromBytes(1e9)))
+	assert.Equal(t, model.MemoryAmountFromBytes(1e9), estimator.GetMemoryEstimation(s))
+
+	for i := 0; i < model.DefaultOOMKillCount; i++ {
+		s.AddOOMKill(anyTime.Add(time.Duration(i)*time.Minute), model.MemoryAmountFromBytes(2e9))
+	}
+	assert.Equal(t, model.MemoryAmountFromBytes(2e9), estimator.GetMemoryEstimation(s))
+}
+
+func TestRecommenderModelPercentileEstimator(t *testing.T) {
+	s := model.NewAggregateContainerState()
+	s.SetRecommenderModel(model.MovingMaxRecommenderModel)
+	s.AggregateCPUUsage.AddSample(1.0, 1.0, anyTime)
+	s.AggregateMemoryPeaks.AddSample(1e9, 1.0, anyTime)
+	cpuEstimator := NewPercentileCPUEstimator(0.9)
+	memoryEstimator := NewPercentileMemoryEstimator(0.9)
+
+	// The model has no usage yet, so the histograms are used.
+	assert.InEpsilon(t, 1.0, model.CoresFromCPUAmount(cpuEstimator.GetCPUEstimation(s)), 0.1)
+	assert.InEpsilon(t, 1e9, model.BytesFromMemoryAmount(memoryEstimator.GetMemoryEstimation(s)), 0.1)
+
+	s.AddSample(&model.ContainerUsageSample{MeasureStart: anyTime, Usage: model.CPUAmountFromCores(3), Resource: model.ResourceCPU})
+	s.AddMemoryUsageSample(&model.ContainerUsageSample{MeasureStart: anyTime, Usage: model.MemoryAmountFromBytes(3e9), Resource: model.ResourceMemory})
+	// The usage of the model doesn't span the moving max window yet, so the histograms are still used.
+	assert.Less(t, cpuEstimator.GetCPUEstimation(s), model.CPUAmountFromCores(3))
+
+	earlier := anyTime.Add(-23 * time.Hour)
+	s.AddSample(&model.ContainerUsageSample{MeasureStart: earlier, Usage: model.CPUAmountFromCores(2), Resource: model.ResourceCPU})
+	s.AddMemoryUsageSample(&model.ContainerUsageSample{MeasureStart: earlier, Usage: model.MemoryAmountFromBytes(2e9), Resource: model.ResourceMemory})
+	assert.Equal(t, model.CPUAmountFromCores(3), cpuEstimator.GetCPUEstimation(s))
+	assert.Equal(t, model.MemoryAmountFromBytes(3e9), memoryEstimator.GetMemoryEstimation(s))
+}
@@ -21,6 +21,10 @@ import (
 	"sort"
 	"time"
 
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	vpa_types "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/recommender/model"
 )
@@ -67,6 +71,10 @@ type podResourceRecommender struct {
 	lowerBoundMemory MemoryEstimator
 	upperBoundCPU    CPUEstimator
 	upperBoundMemory MemoryEstimator
+
+	targetExtendedResource     ExtendedResourceEstimator
+	lowerBoundExtendedResource ExtendedResourceEstimator
+	upperBoundExtendedResource ExtendedResourceEstimator
 }
 
 func (r *podResourceRecommender) GetRecommendedPodResources(containerNameToAggregateStateMap model.ContainerNameToAggregateStateMap) RecommendedPodResources {
@@ -86,6 +94,9 @@ func (r *podResourceRecommender) GetRecommendedPodResources(containerNameToAggre
 		WithMemoryMinResource(minMemory, r.lowerBoundMemory),
 		WithCPUMinResource(minCPU, r.upperBoundCPU),
 		WithMemoryMinResource(minMemory, r.upperBoundMemory),
+		r.targetExtendedResource,
+		r.lowerBoundExtendedResource,
+		r.upperBoundExtendedResource,
 	}
 
 	for containerName, aggregatedContainerState := range containerNameToAggregateStateMap {
@@ -100,6 +111,17 @@ func (r *podResourceRecommender) estimateContainerResources(s *model.AggregateCo
 	target := model.Resources{model.ResourceCPU: r.targetCPU.GetCPUEstimation(s), model.ResourceMemory: r.targetMemory.GetMemoryEstimation(s)}
 	lowerBound := model.Resources{model.ResourceCPU: r.lowerBoundCPU.GetCPUEstimation(s), model.ResourceMemory: r.lowerBoundMemory.GetMemoryEstimation(s)}
 	upperBound := model.Resources{model.ResourceCPU: r.upperBoundCPU.GetCPUEstimation(s), model.ResourceMemory: r.upperBoundMemory.GetMemoryEstimation(s)}
+	for _, resource := range resources {
+		if !model.IsExtendedResource(resource) {
+			continue
+		}
+		if usage := s.GetExtendedResourceUsage(resource); usage == nil || usage.IsEmpty() {
+			continue
+		}
+		target[resource] = r.targetExtendedResource.GetExtendedResourceEstimation(s, resource)
+		lowerBound[resource] = r.lowerBoundExtendedResource.GetExtendedResourceEstimation(s, resource)
+		upperBound[resource] = r.upperBoundExtendedResource.GetExtendedResourceEstimation(s, resource)
+	}
 	return RecommendedContainerResources{
 		FilterControlledResources(target, resources),
 		FilterControlledResources(lowerBound, resources),
@@ -118,16 +140,29 @@ func FilterControlledResources(estimation model.Resources, controlledResources [
 	return result
 }
 
+// Percentiles set by the container resource policies take precedence over the flags.
+var (
+	targetPercentile     PolicyPercentile = func(s *model.AggregateContainerState) *float64 { return s.TargetPercentile }
+	lowerBoundPercentile PolicyPercentile = func(s *model.AggregateContainerState) *float64 { return s.LowerBoundPercentile }
+	upperBoundPercentile PolicyPercentile = func(s *model.AggregateContainerState) *float64 { return s.UpperBoundPercentile }
+)
+
 // CreatePodResourceRecommender returns the primary recommender.
 func CreatePodResourceRecommender() PodResourceRecommender {
-	targetCPU := NewPercentileCPUEstimator(*targetCPUPercentile)
-	lowerBoundCPU := NewPercentileCPUEstimator(*lowerBoundCPUPercentile)
-	upperBoundCPU := NewPercentileCPUEstimator(*upperBoundCPUPercentile)
+	targetCPU := NewPolicyPercentileCPUEstimator(*targetCPUPercentile, targetPercentile)
+	lowerBoundCPU := NewPolicyPercentileCPUEstimator(*lowerBoundCPUPercentile, lowerBoundPercentile)
+	upperBoundCPU := NewPolicyPercentileCPUEstimator(*upperBoundCPUPercentile, upperBoundPercentile)
 
 	// Create base memory estimators
-	targetMemory := NewPercentileMemoryEstimator(*targetMemoryPercentile)
-	lowerBoundMemory := NewPercentileMemoryEstimator(*lowerBoundMemoryPercentile)
-	upperBoundMemory := NewPercentileMemoryEstimator(*upperBoundMemoryPercentile)
+	targetMemory := NewPolicyPercentileMemoryEstimator(*targetMemoryPercentile, targetPercentile)
+	lowerBoundMemory := NewPolicyPercentileMemoryEstimator(*lowerBoundMemoryPercentile, lowerBoundPercentile)
+	upperBoundMemory := NewPolicyPercentileMemoryEstimator(*upperBoundMemoryPercentile, upperBoundPercentile)
+
+	// Extended resources, like GPU memory and huge pages, are estimated
+	// like memory, from the percentiles of their usage.
+	targetExtendedResource := NewPolicyPercentileExtendedResourceEstimator(*targetMemoryPercentile, targetPercentile)
+	lowerBoundExtendedResource := NewPolicyPercentileExtendedResourceEstimator(*lowerBoundMemoryPercentile, lowerBoundPercentile)
+	upperBoundExtendedResource := NewPolicyPercentileExtendedResourceEstimator(*upperBoundMemoryPercentile, upperBoundPercentile)
 
 	// Apply safety margins
 	targetCPU = WithCPUMargin(*safetyMarginFraction, targetCPU)
@@ -138,6 +173,10 @@ func CreatePodResourceRecommender() PodResourceRecommender {
 	lowerBoundMemory = WithMemoryMargin(*safetyMarginFraction, lowerBoundMemory)
 	upperBoundMemory = WithMemoryMargin(*safetyMarginFraction, upperBoundMemory)
 
+	targetExtendedResource = WithExtendedResourceMargin(*safetyMarginFraction, targetExtendedResource)
+	lowerBoundExtendedResource = WithExtendedResourceMargin(*safetyMarginFraction, lowerBoundExtendedResource)
+	upperBoundExtendedResource = WithExtendedResourceMargin(*safetyMarginFraction, upperBoundExtendedResource)
+
 	// Apply confidence multiplier to the upper bound estimator. This means
 	// that the updater will be less eager to evict pods with short history
 	// in order to reclaim unused resources.
@@ -167,6 +206,13 @@ func CreatePodResourceRecommender() PodResourceRecommender {
 	// 60m history  : *0.95
 	lowerBoundCPU = WithCPUConfidenceMultiplier(0.001, -2.0, lowerBoundCPU, *confidenceIntervalCPU)
 	lowerBoundMemory = WithMemoryConfidenceMultiplier(0.001, -2.0, lowerBoundMemory, *confidenceIntervalMemory)
+
+	// Raise the memory recommendation after repeated OOM kills, for containers
+	// with the RaiseLowerBound OOM mode. Besides the lower bound, the target and
+	// the upper bound are raised too, so that they stay above the lower bound.
+	targetMemory = WithMemoryOOMFloor(targetMemory)
+	lowerBoundMemory = WithMemoryOOMFloor(lowerBoundMemory)
+	upperBoundMemory = WithMemoryOOMFloor(upperBoundMemory)
 	return &podResourceRecommender{
 		targetCPU,
 		targetMemory,
@@ -174,6 +220,9 @@ func CreatePodResourceRecommender() PodResourceRecommender {
 		lowerBoundMemory,
 		upperBoundCPU,
 		upperBoundMemory,
+		targetExtendedResource,
+		lowerBoundExtendedResource,
+		upperBoundExtendedResource,
 	}
 }
 
@@ -204,3 +253,92 @@ func MapToListOfRecommendedContainerResources(resources RecommendedPodResources)
 	}
 	return recommendation
 }
+
+// GetRecommendationDetails explains how the recommendation for each container
+// is computed by the primary recommender: from which usage history, which OOM
+// kill and the usage at which percentiles. Containers are sorted by name.
+func GetRecommendationDetails(containerNameToAggregateStateMap model.ContainerNameToAggregateStateMap) *vpa_types.RecommendationDetails {
+	containerNames := make([]string, 0, len(containerNameToAggregateStateMap))
+	for containerName := range containerNameToAggregateStateMap {
+		containerNames = append(containerNames, containerName)
+	}
+	sort.Strings(containerNames)
+	details := &vpa_types.RecommendationDetails{}
+	for _, name := range containerNames {
+		details.ContainerDetails = append(details.ContainerDetails, getContainerRecommendationDetails(name, containerNameToAggregateStateMap[name]))
+	}
+	return details
+}
+
+func getContainerRecommendationDetails(containerName string, s *model.AggregateContainerState) vpa_types.ContainerRecommendationDetails {
+	details := vpa_types.ContainerRecommendationDetails{
+		ContainerName:     containerName,
+		TotalSamplesCount: int32(s.TotalSamplesCount),
+	}
+	if !s.FirstSampleStart.IsZero() {
+		details.FirstSampleStart = &metav1.Time{Time: s.FirstSampleStart}
+		details.LastSampleStart = &metav1.Time{Time: s.LastSampleStart}
+	}
+	if timestamp, memoryNeeded, found := s.GetLastOOMKill(); found {
+		details.LastOOMKill = &vpa_types.OOMKillDetails{
+			Time:             metav1.NewTime(timestamp),
+			MemoryNeeded:     model.QuantityFromMemoryAmount(memoryNeeded),
+			RaisedLowerBound: s.GetOOMMemoryFloor() > 0,
+		}
+	}
+	for _, resourceName := range s.GetControlledResources() {
+		switch resourceName {
+		case model.ResourceCPU:
+			details.Resources = append(details.Resources, getResourceRecommendationDetails(s, resourceName,
+				*targetCPUPercentile, *lowerBoundCPUPercentile, *upperBoundCPUPercentile, confidenceIntervalCPU))
+		case model.ResourceMemory:
+			details.Resources = append(details.Resources, getResourceRecommendationDetails(s, resourceName,
+				*targetMemoryPercentile, *lowerBoundMemoryPercentile, *upperBoundMemoryPercentile, confidenceIntervalMemory))
+		default:
+			// Extended resources are recommended only once they have usage,
+			// and their bounds don't depend on the confidence.
+			if usage := s.GetExtendedResourceUsage(resourceName); !model.IsExtendedResource(resourceName) || usage == nil || usage.IsEmpty() {
+				continue
+			}
+			details.Resources = append(details.Resources, getResourceRecommendationDetails(s, resourceName,
+				*targetMemoryPercentile, *lowerBoundMemoryPercentile, *upperBoundMemoryPercentile, nil))
+		}
+	}
+	return details
+}
+
+func getResourceRecommendationDetails(s *model.AggregateContainerState, resourceName model.ResourceName,
+	defaultTarget, defaultLowerBound, defaultUpperBound float64, confidenceInterval *time.Duration) vpa_types.ResourceRecommendationDetails {
+	target := getPercentile(defaultTarget, targetPercentile, s)
+	lowerBound := getPercentile(defaultLowerBound, lowerBoundPercentile, s)
+	upperBound := getPercentile(defaultUpperBound, upperBoundPercentile, s)
+	details := vpa_types.ResourceRecommendationDetails{
+		Resource:                  apiv1.ResourceName(resourceName),
+		TargetPercentile:          target,
+		TargetPercentileUsage:     usageAtPercentile(s, resourceName, target),
+		LowerBoundPercentile:      lowerBound,
+		LowerBoundPercentileUsage: usageAtPercentile(s, resourceName, lowerBound),
+		UpperBoundPercentile:      upperBound,
+		UpperBoundPercentileUsage: usageAtPercentile(s, resourceName, upperBound),
+	}
+	if confidenceInterval != nil {
+		confidence := getConfidence(s, *confidenceInterval)
+		details.ConfidenceInterval = &metav1.Duration{Duration: *confidenceInterval}
+		details.Confidence = &confidence
+	}
+	return details
+}
+
+// usageAtPercentile returns the usage of the resource at the percentile, as
+// estimated before the safety margin and the confidence multipliers are applied.
+func usageAtPercentile(s *model.AggregateContainerState, resourceName model.ResourceName, percentile float64) resource.Quantity {
+	switch resourceName {
+	case model.ResourceCPU:
+		return model.QuantityFromCPUAmount(NewPercentileCPUEstimator(percentile).GetCPUEstimation(s))
+	case model.ResourceMemory:
+		return model.QuantityFromMemoryAmount(NewPercentileMemoryEstimator(percentile).GetMemoryEstimation(s))
+	default:
+		estimator := NewPolicyPercentileExtendedResourceEstimator(percentile, nil)
+		return model.QuantityFromExtendedResourceAmount(resourceName, estimator.GetExtendedResourceEstimation(s, resourceName))
+	}
+}
@@ -18,9 +18,12 @@ package logic
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
 
+	vpa_types "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/recommender/model"
 )
 
@@ -129,6 +132,42 @@ func TestControlledResourcesFilteredDefault(t *testing.T) {
 	assert.Contains(t, recommendedResources[containerName].UpperBound, model.ResourceCPU)
 }
 
+func TestExtendedResourcesRecommended(t *testing.T) {
+	constCPUEstimator := NewConstCPUEstimator(model.CPUAmountFromCores(0.001))
+	constMemoryEstimator := NewConstMemoryEstimator(model.MemoryAmountFromBytes(1e6))
+	extendedResourceEstimator := NewPolicyPercentileExtendedResourceEstimator(0.9, nil)
+
+	recommender := podResourceRecommender{
+		targetCPU:                  constCPUEstimator,
+		targetMemory:               constMemoryEstimator,
+		lowerBoundCPU:              constCPUEstimator,
+		lowerBoundMemory:           constMemoryEstimator,
+		upperBoundCPU:              constCPUEstimator,
+		upperBoundMemory:           constMemoryEstimator,
+		targetExtendedResource:     extendedResourceEstimator,
+		lowerBoundExtendedResource: extendedResourceEstimator,
+		upperBoundExtendedResource: extendedResourceEstimator,
+	}
+
+	hugePages := model.ResourceName("hugepages-2Mi")
+	gpuMemory := model.ResourceName("nvidia.com/gpumem")
+	containerName := "container-1"
+	state := model.NewAggregateContainerState()
+	state.ControlledResources = &[]model.ResourceName{model.ResourceCPU, model.ResourceMemory, hugePages, gpuMemory}
+	state.AddSample(&model.ContainerUsageSample{
+		MeasureStart: time.Now(),
+		Usage:        model.ResourceAmount(100 * 1024 * 1024),
+		Resource:     hugePages,
+	})
+	containerNameToAggregateStateMap := model.ContainerNameToAggregateStateMap{containerName: state}
+
+	recommendedResources := recommender.GetRecommendedPodResources(containerNameToAggregateStateMap)
+	assert.Contains(t, recommendedResources[containerName].Target, hugePages)
+	assert.GreaterOrEqual(t, recommendedResources[containerName].Target[hugePages], model.ResourceAmount(100*1024*1024))
+	assert.NotContains(t, recommendedResources[containerName].Target, gpuMemory)
+	assert.NotContains(t, recommendedResources[containerName].UpperBound, gpuMemory)
+}
+
 func TestMapToListOfRecommendedContainerResources(t *testing.T) {
 	cases := []struct {
 		name         string
@@ -179,3 +218,71 @@ func TestMapToListOfRecommendedContainerResources(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRecommendationDetails(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	targetPercentile := 0.5
+	raiseLowerBound := vpa_types.OOMModeRaiseLowerBound
+	oneKill := int32(1)
+
+	withHistory := model.NewAggregateContainerState()
+	withHistory.UpdateFromPolicy(&vpa_types.ContainerResourcePolicy{
+		TargetPercentile: &targetPercentile,
+		OOMPolicy:        &vpa_types.OOMPolicy{Mode: &raiseLowerBound, OOMKillCount: &oneKill},
+	})
+	for i := 0; i < 3; i++ {
+		timestamp := start.Add(time.Duration(i) * time.Hour)
+		withHistory.AddSample(&model.ContainerUsageSample{MeasureStart: timestamp, Usage: model.CPUAmountFromCores(1), Resource: model.ResourceCPU})
+		withHistory.AddSample(&model.ContainerUsageSample{MeasureStart: timestamp, Usage: model.MemoryAmountFromBytes(1e9), Resource: model.ResourceMemory})
+	}
+	withHistory.AddOOMKill(start.Add(90*time.Minute), model.MemoryAmountFromBytes(2e9))
+
+	details := GetRecommendationDetails(model.ContainerNameToAggregateStateMap{
+		"b-container": withHistory,
+		"a-container": model.NewAggregateContainerState(),
+	})
+
+	if !assert.Len(t, details.ContainerDetails, 2) {
+		return
+	}
+	empty := details.ContainerDetails[0]
+	assert.Equal(t, "a-container", empty.ContainerName)
+	assert.Zero(t, empty.TotalSamplesCount)
+	assert.Nil(t, empty.FirstSampleStart)
+	assert.Nil(t, empty.LastOOMKill)
+	if assert.Len(t, empty.Resources, 2) {
+		assert.Zero(t, *empty.Resources[0].Confidence)
+		assert.True(t, empty.Resources[0].TargetPercentileUsage.IsZero())
+	}
+
+	container := details.ContainerDetails[1]
+	assert.Equal(t, "b-container", container.ContainerName)
+	assert.Equal(t, int32(3), container.TotalSamplesCount)
+	assert.Equal(t, start, container.FirstSampleStart.Time)
+	assert.Equal(t, start.Add(2*time.Hour), container.LastSampleStart.Time)
+	if assert.NotNil(t, container.LastOOMKill) {
+		assert.Equal(t, start.Add(90*time.Minute), container.LastOOMKill.Time.Time)
+		assert.Equal(t, int64(2e9), container.LastOOMKill.MemoryNeeded.Value())
+		assert.True(t, container.LastOOMKill.RaisedLowerBound)
+	}
+	if !assert.Len(t, container.Resources, 2) {
+		return
+	}
+	maxRelativeError := 0.05 // Allow 5% relative error to account for histogram rounding.
+	cpu := container.Resources[0]
+	assert.Equal(t, apiv1.ResourceCPU, cpu.Resource)
+	assert.Equal(t, targetPercentile, cpu.TargetPercentile)
+	assert.Equal(t, *lowerBoundCPUPercentile, cpu.LowerBoundPercentile)
+	assert.Equal(t, *upperBoundCPUPercentile, cpu.UpperBoundPercentile)
+	assert.InEpsilon(t, 1.0, cpu.TargetPercentileUsage.AsApproximateFloat64(), maxRelativeError)
+	assert.Equal(t, *confidenceIntervalCPU, cpu.ConfidenceInterval.Duration)
+	// Three samples in two hours are less than one sample per minute.
+	assert.InDelta(t, 3.0/(24*60), *cpu.Confidence, 1e-9)
+
+	memory := container.Resources[1]
+	assert.Equal(t, apiv1.ResourceMemory, memory.Resource)
+	assert.Equal(t, targetPercentile, memory.TargetPercentile)
+	assert.Equal(t, *upperBoundMemoryPercentile, memory.UpperBoundPercentile)
+	assert.InEpsilon(t, 1e9, memory.TargetPercentileUsage.AsApproximateFloat64(), maxRelativeError)
+	assert.Equal(t, *lowerBoundMemoryPercentile, memory.LowerBoundPercentile)
+}
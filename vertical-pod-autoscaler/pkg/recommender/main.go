@@ -18,7 +18,11 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"flag"
+	"fmt"
+	"net/http"
 	"os"
 	"strings"
 	"time"
@@ -30,6 +34,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/client-go/informers"
 	kube_client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	kube_flag "k8s.io/component-base/cli/flag"
@@ -49,9 +54,11 @@ import (
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/recommender/routines"
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/target"
 	controllerfetcher "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/target/controller_fetcher"
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/limitrange"
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/metrics"
 	metrics_quality "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/metrics/quality"
 	metrics_recommender "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/metrics/recommender"
+	resourcehelpers "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/resources"
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/server"
 	vpa_api_util "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/vpa"
 )
@@ -63,6 +70,9 @@ var (
 	address                = flag.String("address", ":8942", "The address to expose Prometheus metrics.")
 	storage                = flag.String("storage", "", `Specifies storage mode. Supported values: prometheus, checkpoint (default)`)
 	memorySaver            = flag.Bool("memory-saver", false, `If true, only track pods which have an associated VPA`)
+	recommendSidecars      = flag.Bool("recommend-sidecar-containers", false, `If true, track usage of restartable init (sidecar) containers and produce recommendations for them`)
+	failoverPeriod         = flag.Duration("recommender-failover-period", 10*time.Minute, `How long the active recommender of a VPA object specifying more than one recommender may not update the recommendation before the next recommender takes over. 0 disables failover.`)
+	recommendationDetails  = flag.Bool("report-recommendation-details", false, `If true, explain the recommendations in status.recommendationDetails of the VPA objects. As the details change with every sample, the status of each VPA object is then updated in every recommender loop.`)
 )
 
 // Prometheus history provider flags
@@ -83,11 +93,41 @@ var (
 	password            = flag.String("password", "", "The password used in the prometheus server basic auth")
 )
 
+// Prometheus remote read history provider flags
+var (
+	prometheusRemoteRead       = flag.Bool("prometheus-remote-read", false, `Read historical metrics with the Prometheus remote read API and downsample them to --history-resolution in the recommender, instead of using range queries`)
+	historyQueryChunkLength    = flag.String("history-query-chunk-length", "1d", `Length of the time ranges in which historical metrics are read with the Prometheus remote read API`)
+	prometheusShardByNamespace = flag.Bool("prometheus-shard-by-namespace", false, `Read historical metrics with the Prometheus remote read API separately for each namespace`)
+)
+
+// Metrics source flags
+var (
+	metricsSource       = flag.String("metrics-source", metricsServerSource, `Source of the current resource usage of containers. Supported values: metrics-server, external (an external metrics provider), prometheus (instant queries to --prometheus-address), otlp (metrics pushed to an OTLP/HTTP receiver)`)
+	prometheusCPUWindow = flag.Duration("prometheus-cpu-usage-window", 1*time.Minute, `The range over which the CPU usage rate is computed when the current usage is read from Prometheus with --metrics-source=prometheus`)
+	otlpReceiverAddress = flag.String("otlp-receiver-address", "localhost:4318", `The address on which the OTLP/HTTP receiver accepts metrics, on the /v1/metrics path, with --metrics-source=otlp. The receiver requires --otlp-tls-cert-file or --otlp-bearer-token-file`)
+	otlpTLSCertFile     = flag.String("otlp-tls-cert-file", "", `Path to the server certificate PEM file of the OTLP receiver. If set, the receiver is served over TLS`)
+	otlpTLSPrivateKey   = flag.String("otlp-tls-private-key", "", `Path to the server private key PEM file of the OTLP receiver`)
+	otlpClientCAFile    = flag.String("otlp-client-ca-file", "", `Path to the CA PEM file the client certificates of OTLP exporters are verified with. If set, the OTLP receiver requires client certificates`)
+	otlpBearerTokenFile = flag.String("otlp-bearer-token-file", "", `Path to a file with the bearer token OTLP exporters have to send in the Authorization header`)
+	otlpCPUMetric       = flag.String("otlp-cpu-metric", "container.cpu.usage", `Name of the OTLP gauge with the CPU usage of containers, in cores`)
+	otlpMemoryMetric    = flag.String("otlp-memory-metric", "container.memory.working_set", `Name of the OTLP gauge with the memory usage of containers, in bytes`)
+	otlpMaxSampleAge    = flag.Duration("otlp-max-sample-age", 2*time.Minute, `How long the latest usage of a container pushed to the OTLP receiver is used, if no newer one is pushed`)
+)
+
+// Supported values of --metrics-source.
+const (
+	metricsServerSource     = "metrics-server"
+	externalMetricsSource   = "external"
+	prometheusMetricsSource = "prometheus"
+	otlpMetricsSource       = "otlp"
+)
+
 // External metrics provider flags
 var (
-	useExternalMetrics   = flag.Bool("use-external-metrics", false, "ALPHA.  Use an external metrics provider instead of metrics_server.")
+	useExternalMetrics   = flag.Bool("use-external-metrics", false, "ALPHA.  Use an external metrics provider instead of metrics_server. Same as --metrics-source=external.")
 	externalCpuMetric    = flag.String("external-metrics-cpu-metric", "", "ALPHA.  Metric to use with external metrics provider for CPU usage.")
 	externalMemoryMetric = flag.String("external-metrics-memory-metric", "", "ALPHA.  Metric to use with external metrics provider for memory usage.")
+	extendedMetrics      = flag.String("extended-resource-metrics", "", "ALPHA.  Comma-separated list of resource=metric pairs, e.g. nvidia.com/gpumem=DCGM_FI_DEV_FB_USED. Usage of these extended resources is read from the external metrics provider, in the units of the resource, and recommended for containers which list them in controlledResources.")
 )
 
 // Aggregation configuration flags
@@ -98,6 +138,9 @@ var (
 	cpuHistogramDecayHalfLife      = flag.Duration("cpu-histogram-decay-half-life", model.DefaultCPUHistogramDecayHalfLife, `The amount of time it takes a historical CPU usage sample to lose half of its weight.`)
 	oomBumpUpRatio                 = flag.Float64("oom-bump-up-ratio", model.DefaultOOMBumpUpRatio, `The memory bump up ratio when OOM occurred, default is 1.2.`)
 	oomMinBumpUp                   = flag.Float64("oom-min-bump-up-bytes", model.DefaultOOMMinBumpUp, `The minimal increase of memory when OOM occurred in bytes, default is 100 * 1024 * 1024`)
+	movingMaxWindow                = flag.Duration("moving-max-window", model.DefaultMovingMaxWindow, `The length of the window in which hourly usage peaks are kept for VPAs using the moving-max recommender model.`)
+	seasonalPeriodCount            = flag.Int("seasonal-period-count", model.DefaultSeasonalPeriodCount, `The number of previous days or weeks in which hourly usage peaks are kept for VPAs using the seasonal-daily or seasonal-weekly recommender model.`)
+	seasonalLookahead              = flag.Duration("seasonal-lookahead", model.DefaultSeasonalLookahead, `How far after the current hour of the previous days or weeks the usage peaks are taken into account by the seasonal recommender models.`)
 )
 
 // Post processors flags
@@ -221,7 +264,11 @@ func run(ctx context.Context, healthCheck *metrics.HealthCheck, commonFlag *comm
 	controllerFetcher := controllerfetcher.NewControllerFetcher(config, kubeClient, factory, scaleCacheEntryFreshnessTime, scaleCacheEntryLifetime, scaleCacheEntryJitterFactor)
 	podLister, oomObserver := input.NewPodListerAndOOMObserver(ctx, kubeClient, commonFlag.VpaObjectNamespace, stopCh)
 
-	model.InitializeAggregationsConfig(model.NewAggregationsConfig(*memoryAggregationInterval, *memoryAggregationIntervalCount, *memoryHistogramDecayHalfLife, *cpuHistogramDecayHalfLife, *oomBumpUpRatio, *oomMinBumpUp))
+	aggregationsConfig := model.NewAggregationsConfig(*memoryAggregationInterval, *memoryAggregationIntervalCount, *memoryHistogramDecayHalfLife, *cpuHistogramDecayHalfLife, *oomBumpUpRatio, *oomMinBumpUp)
+	aggregationsConfig.MovingMaxWindow = *movingMaxWindow
+	aggregationsConfig.SeasonalPeriodCount = *seasonalPeriodCount
+	aggregationsConfig.SeasonalLookahead = *seasonalLookahead
+	model.InitializeAggregationsConfig(aggregationsConfig)
 
 	useCheckpoints := *storage != "prometheus"
 
@@ -233,21 +280,20 @@ func run(ctx context.Context, healthCheck *metrics.HealthCheck, commonFlag *comm
 	globalMaxAllowed := initGlobalMaxAllowed()
 	// CappingPostProcessor, should always come in the last position for post-processing
 	postProcessors = append(postProcessors, routines.NewCappingRecommendationProcessor(globalMaxAllowed))
-	var source input_metrics.PodMetricsLister
-	if *useExternalMetrics {
-		resourceMetrics := map[apiv1.ResourceName]string{}
-		if externalCpuMetric != nil && *externalCpuMetric != "" {
-			resourceMetrics[apiv1.ResourceCPU] = *externalCpuMetric
-		}
-		if externalMemoryMetric != nil && *externalMemoryMetric != "" {
-			resourceMetrics[apiv1.ResourceMemory] = *externalMemoryMetric
-		}
-		externalClientOptions := &input_metrics.ExternalClientOptions{ResourceMetrics: resourceMetrics, ContainerNameLabel: *ctrNameLabel}
-		klog.V(1).InfoS("Using External Metrics", "options", externalClientOptions)
-		source = input_metrics.NewExternalClient(config, clusterState, *externalClientOptions)
-	} else {
-		klog.V(1).InfoS("Using Metrics Server")
-		source = input_metrics.NewPodMetricsesSource(resourceclient.NewForConfigOrDie(config))
+	extendedResourceMetrics, err := parseExtendedResourceMetrics(*extendedMetrics)
+	if err != nil {
+		klog.ErrorS(err, "Could not parse --extended-resource-metrics")
+		os.Exit(255)
+	}
+	promQueryTimeout, err := time.ParseDuration(*queryTimeout)
+	if err != nil {
+		klog.ErrorS(err, "Could not parse --prometheus-query-timeout as a time.Duration")
+		os.Exit(255)
+	}
+	source, err := newMetricsSource(config, clusterState, extendedResourceMetrics, promQueryTimeout)
+	if err != nil {
+		klog.ErrorS(err, "Could not initialize metrics source")
+		os.Exit(255)
 	}
 
 	ignoredNamespaces := strings.Split(commonFlag.IgnoredVpaObjectNamespaces, ",")
@@ -266,9 +312,19 @@ func run(ctx context.Context, healthCheck *metrics.HealthCheck, commonFlag *comm
 		RecommenderName:     *recommenderName,
 		IgnoredNamespaces:   ignoredNamespaces,
 		VpaObjectNamespace:  commonFlag.VpaObjectNamespace,
+
+		RecommendSidecarContainers: *recommendSidecars,
+		RecommenderFailoverPeriod:  *failoverPeriod,
 	}.Make()
 	controllerFetcher.Start(ctx, scaleCacheLoopPeriod)
 
+	var limitRangeCalculator limitrange.LimitRangeCalculator
+	limitRangeCalculator, err = limitrange.NewLimitsRangeCalculator(factory)
+	if err != nil {
+		klog.ErrorS(err, "Failed to create limitRangeCalculator, falling back to not checking limits")
+		limitRangeCalculator = limitrange.NewNoopLimitsCalculator()
+	}
+
 	recommender := routines.RecommenderFactory{
 		ClusterState:                 clusterState,
 		ClusterStateFeeder:           clusterStateFeeder,
@@ -277,16 +333,12 @@ func run(ctx context.Context, healthCheck *metrics.HealthCheck, commonFlag *comm
 		VpaClient:                    vpa_clientset.NewForConfigOrDie(config).AutoscalingV1(),
 		PodResourceRecommender:       logic.CreatePodResourceRecommender(),
 		RecommendationPostProcessors: postProcessors,
+		LimitRangeCalculator:         limitRangeCalculator,
 		CheckpointsGCInterval:        *checkpointsGCInterval,
 		UseCheckpoints:               useCheckpoints,
+		ReportRecommendationDetails:  *recommendationDetails,
 	}.Make()
 
-	promQueryTimeout, err := time.ParseDuration(*queryTimeout)
-	if err != nil {
-		klog.ErrorS(err, "Could not parse --prometheus-query-timeout as a time.Duration")
-		os.Exit(255)
-	}
-
 	if useCheckpoints {
 		recommender.GetClusterStateFeeder().InitFromCheckpoints(ctx)
 	} else {
@@ -308,6 +360,9 @@ func run(ctx context.Context, healthCheck *metrics.HealthCheck, commonFlag *comm
 				Username: *username,
 				Password: *password,
 			},
+			UseRemoteRead:    *prometheusRemoteRead,
+			QueryChunkLength: *historyQueryChunkLength,
+			ShardByNamespace: *prometheusShardByNamespace,
 		}
 		provider, err := history.NewPrometheusHistoryProvider(config)
 		if err != nil {
@@ -327,6 +382,131 @@ func run(ctx context.Context, healthCheck *metrics.HealthCheck, commonFlag *comm
 	}
 }
 
+// newMetricsSource returns the source of the current resource usage of containers
+// selected with --metrics-source. The usage of extended resources is added from
+// the external metrics provider, if any is configured.
+func newMetricsSource(config *rest.Config, clusterState model.ClusterState, extendedResourceMetrics map[apiv1.ResourceName]string, promQueryTimeout time.Duration) (input_metrics.MetricsSource, error) {
+	sourceName := *metricsSource
+	if *useExternalMetrics {
+		sourceName = externalMetricsSource
+	}
+
+	var source input_metrics.MetricsSource
+	switch sourceName {
+	case externalMetricsSource:
+		resourceMetrics := map[apiv1.ResourceName]string{}
+		for resourceName, metricName := range extendedResourceMetrics {
+			resourceMetrics[resourceName] = metricName
+		}
+		if externalCpuMetric != nil && *externalCpuMetric != "" {
+			resourceMetrics[apiv1.ResourceCPU] = *externalCpuMetric
+		}
+		if externalMemoryMetric != nil && *externalMemoryMetric != "" {
+			resourceMetrics[apiv1.ResourceMemory] = *externalMemoryMetric
+		}
+		externalClientOptions := &input_metrics.ExternalClientOptions{ResourceMetrics: resourceMetrics, ContainerNameLabel: *ctrNameLabel}
+		klog.V(1).InfoS("Using External Metrics", "options", externalClientOptions)
+		return input_metrics.NewExternalClient(config, clusterState, *externalClientOptions), nil
+	case metricsServerSource:
+		klog.V(1).InfoS("Using Metrics Server")
+		source = input_metrics.NewPodMetricsesSource(resourceclient.NewForConfigOrDie(config))
+	case prometheusMetricsSource:
+		sourceConfig := input_metrics.PrometheusSourceConfig{
+			Address:                *prometheusAddress,
+			QueryTimeout:           promQueryTimeout,
+			CPUUsageWindow:         *prometheusCPUWindow,
+			CtrNamespaceLabel:      *ctrNamespaceLabel,
+			CtrPodNameLabel:        *ctrPodNameLabel,
+			CtrNameLabel:           *ctrNameLabel,
+			CadvisorMetricsJobName: *prometheusJobName,
+		}
+		if *username != "" && *password != "" {
+			sourceConfig.RoundTripper = &history.PrometheusBasicAuthTransport{Username: *username, Password: *password}
+		}
+		klog.V(1).InfoS("Using Prometheus", "address", sourceConfig.Address)
+		prometheusSource, err := input_metrics.NewPrometheusSource(sourceConfig)
+		if err != nil {
+			return nil, err
+		}
+		source = prometheusSource
+	case otlpMetricsSource:
+		receiver, err := newOTLPReceiver(clusterState)
+		if err != nil {
+			return nil, err
+		}
+		source = receiver
+	default:
+		return nil, fmt.Errorf("unsupported metrics source %q", sourceName)
+	}
+
+	if len(extendedResourceMetrics) > 0 {
+		externalClientOptions := input_metrics.ExternalClientOptions{ResourceMetrics: extendedResourceMetrics, ContainerNameLabel: *ctrNameLabel}
+		klog.V(1).InfoS("Using External Metrics for extended resources", "options", externalClientOptions)
+		source = input_metrics.NewMergedPodMetricsLister(source, input_metrics.NewExternalClient(config, clusterState, externalClientOptions))
+	}
+	return source, nil
+}
+
+// newOTLPReceiver starts serving the OTLP receiver on --otlp-receiver-address. Anyone
+// able to push metrics to it can change recommendations, so exporters have to be
+// authenticated with a client certificate or a bearer token, or at least the receiver
+// has to be served over TLS.
+func newOTLPReceiver(clusterState model.ClusterState) (*input_metrics.OTLPReceiver, error) {
+	if *otlpTLSCertFile == "" && *otlpBearerTokenFile == "" {
+		return nil, fmt.Errorf("--metrics-source=otlp requires --otlp-tls-cert-file or --otlp-bearer-token-file")
+	}
+	if *otlpClientCAFile != "" && *otlpTLSCertFile == "" {
+		return nil, fmt.Errorf("--otlp-client-ca-file requires --otlp-tls-cert-file")
+	}
+	receiverConfig := input_metrics.OTLPReceiverConfig{
+		CPUMetric:    *otlpCPUMetric,
+		MemoryMetric: *otlpMemoryMetric,
+		MaxSampleAge: *otlpMaxSampleAge,
+	}
+	if *otlpBearerTokenFile != "" {
+		token, err := os.ReadFile(*otlpBearerTokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read OTLP bearer token: %v", err)
+		}
+		receiverConfig.BearerToken = strings.TrimSpace(string(token))
+		if receiverConfig.BearerToken == "" {
+			return nil, fmt.Errorf("OTLP bearer token file %s is empty", *otlpBearerTokenFile)
+		}
+	}
+	receiver := input_metrics.NewOTLPReceiver(receiverConfig, clusterState)
+
+	mux := http.NewServeMux()
+	mux.Handle(input_metrics.OTLPMetricsPath, receiver)
+	server := &http.Server{Addr: *otlpReceiverAddress, Handler: mux}
+	if *otlpClientCAFile != "" {
+		caCert, err := os.ReadFile(*otlpClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read OTLP client CA: %v", err)
+		}
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no certificates found in OTLP client CA file %s", *otlpClientCAFile)
+		}
+		server.TLSConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			ClientCAs:  clientCAs,
+			ClientAuth: tls.RequireAndVerifyClientCert,
+		}
+	}
+	go func() {
+		var err error
+		if *otlpTLSCertFile != "" {
+			err = server.ListenAndServeTLS(*otlpTLSCertFile, *otlpTLSPrivateKey)
+		} else {
+			err = server.ListenAndServe()
+		}
+		klog.ErrorS(err, "Failed to start OTLP receiver")
+		os.Exit(255)
+	}()
+	klog.V(1).InfoS("Using OTLP receiver", "address", *otlpReceiverAddress, "tls", *otlpTLSCertFile != "", "clientCertificates", *otlpClientCAFile != "", "bearerToken", *otlpBearerTokenFile != "")
+	return receiver, nil
+}
+
 func initGlobalMaxAllowed() apiv1.ResourceList {
 	result := make(apiv1.ResourceList)
 	if !maxAllowedCPU.Quantity.IsZero() {
@@ -338,3 +518,23 @@ func initGlobalMaxAllowed() apiv1.ResourceList {
 
 	return result
 }
+
+// parseExtendedResourceMetrics parses the comma-separated resource=metric
+// pairs of the --extended-resource-metrics flag.
+func parseExtendedResourceMetrics(value string) (map[apiv1.ResourceName]string, error) {
+	result := make(map[apiv1.ResourceName]string)
+	if value == "" {
+		return result, nil
+	}
+	for _, pair := range strings.Split(value, ",") {
+		resourceName, metricName, found := strings.Cut(pair, "=")
+		if !found || resourceName == "" || metricName == "" {
+			return nil, fmt.Errorf("%q is not a resource=metric pair", pair)
+		}
+		if !resourcehelpers.IsExtendedResource(apiv1.ResourceName(resourceName)) {
+			return nil, fmt.Errorf("%s is not an extended resource", resourceName)
+		}
+		result[apiv1.ResourceName(resourceName)] = metricName
+	}
+	return result, nil
+}
@@ -81,6 +81,16 @@ type ContainerStateAggregator interface {
 	// GetUpdateMode returns the update mode of VPA controlling this aggregator,
 	// nil if aggregator is not autoscaled.
 	GetUpdateMode() *vpa_types.UpdateMode
+	// GetOOMPolicy returns the OOM policy of the container resource policy
+	// of VPA controlling this aggregator, nil if not set.
+	GetOOMPolicy() *vpa_types.OOMPolicy
+	// AddOOMKill records an OOM kill of an aggregated container, after which
+	// the container needs memoryNeeded.
+	AddOOMKill(timestamp time.Time, memoryNeeded ResourceAmount)
+	// AddMemoryUsageSample adds a raw memory usage sample, before it is
+	// aggregated into the memory peak of its interval, to the recommender
+	// model of the aggregator.
+	AddMemoryUsageSample(sample *ContainerUsageSample)
 }
 
 // AggregateContainerState holds input signals aggregated from a set of containers.
@@ -94,6 +104,9 @@ type AggregateContainerState struct {
 	// AggregateMemoryPeaks is a distribution of memory peaks from all containers:
 	// each container should add one peak per memory aggregation interval (e.g. once every 24h).
 	AggregateMemoryPeaks util.Histogram
+	// AggregateExtendedResourceUsage holds a distribution of usage samples of
+	// each tracked extended resource. It isn't checkpointed.
+	AggregateExtendedResourceUsage map[ResourceName]util.Histogram
 	// Note: first/last sample timestamps as well as the sample count are based only on CPU samples.
 	FirstSampleStart  time.Time
 	LastSampleStart   time.Time
@@ -110,6 +123,24 @@ type AggregateContainerState struct {
 	UpdateMode          *vpa_types.UpdateMode
 	ScalingMode         *vpa_types.ContainerScalingMode
 	ControlledResources *[]ResourceName
+
+	// Percentiles set by the resource policy of the VPA object, nil if
+	// the recommender defaults should be used.
+	TargetPercentile     *float64
+	LowerBoundPercentile *float64
+	UpperBoundPercentile *float64
+	// OOMPolicy set by the resource policy of the VPA object, nil if the
+	// recommender defaults should be used.
+	OOMPolicy *vpa_types.OOMPolicy
+	// Recent OOM kills of the aggregated containers, within the OOM window.
+	oomKills []oomKill
+	// recommenderModel selected by the VPA object, nil for the histogram model.
+	recommenderModel RecommenderModel
+}
+
+type oomKill struct {
+	timestamp    time.Time
+	memoryNeeded ResourceAmount
 }
 
 // GetLastRecommendation returns last recorded recommendation.
@@ -134,6 +165,99 @@ func (a *AggregateContainerState) GetScalingMode() *vpa_types.ContainerScalingMo
 	return a.ScalingMode
 }
 
+// GetOOMPolicy returns the OOM policy of the container represented by this
+// aggregator, nil if not set.
+func (a *AggregateContainerState) GetOOMPolicy() *vpa_types.OOMPolicy {
+	return a.OOMPolicy
+}
+
+// AddOOMKill records an OOM kill of an aggregated container, after which the
+// container needs memoryNeeded. OOM kills older than the OOM window are dropped.
+func (a *AggregateContainerState) AddOOMKill(timestamp time.Time, memoryNeeded ResourceAmount) {
+	a.oomKills = append(a.oomKills, oomKill{timestamp: timestamp, memoryNeeded: memoryNeeded})
+	a.pruneOOMKills(a.lastOOMKill())
+}
+
+// GetOOMMemoryFloor returns the memory needed after the recent OOM kills if
+// the OOM policy raises the lower bound and the aggregated containers were OOM
+// killed enough times within the OOM window. Returns 0 otherwise.
+// The window ends at the latest sample or OOM kill.
+func (a *AggregateContainerState) GetOOMMemoryFloor() ResourceAmount {
+	if a.OOMPolicy == nil || a.OOMPolicy.Mode == nil || *a.OOMPolicy.Mode != vpa_types.OOMModeRaiseLowerBound {
+		return 0
+	}
+	windowEnd := a.LastSampleStart
+	if last := a.lastOOMKill(); last.After(windowEnd) {
+		windowEnd = last
+	}
+	count := 0
+	var floor ResourceAmount
+	for _, kill := range a.oomKills {
+		if kill.timestamp.After(windowEnd.Add(-a.oomWindow())) {
+			count++
+			floor = ResourceAmountMax(floor, kill.memoryNeeded)
+		}
+	}
+	if count < a.oomKillCount() {
+		return 0
+	}
+	return floor
+}
+
+// GetLastOOMKill returns the time of the latest recent OOM kill of the
+// aggregated containers and the memory they needed after it. Returns false if
+// there was no OOM kill within the OOM window.
+func (a *AggregateContainerState) GetLastOOMKill() (time.Time, ResourceAmount, bool) {
+	windowEnd := a.LastSampleStart
+	if last := a.lastOOMKill(); last.After(windowEnd) {
+		windowEnd = last
+	}
+	var latest *oomKill
+	for i, kill := range a.oomKills {
+		if kill.timestamp.After(windowEnd.Add(-a.oomWindow())) && (latest == nil || kill.timestamp.After(latest.timestamp)) {
+			latest = &a.oomKills[i]
+		}
+	}
+	if latest == nil {
+		return time.Time{}, 0, false
+	}
+	return latest.timestamp, latest.memoryNeeded, true
+}
+
+func (a *AggregateContainerState) lastOOMKill() time.Time {
+	var last time.Time
+	for _, kill := range a.oomKills {
+		if kill.timestamp.After(last) {
+			last = kill.timestamp
+		}
+	}
+	return last
+}
+
+func (a *AggregateContainerState) pruneOOMKills(windowEnd time.Time) {
+	recent := a.oomKills[:0]
+	for _, kill := range a.oomKills {
+		if kill.timestamp.After(windowEnd.Add(-a.oomWindow())) {
+			recent = append(recent, kill)
+		}
+	}
+	a.oomKills = recent
+}
+
+func (a *AggregateContainerState) oomWindow() time.Duration {
+	if a.OOMPolicy != nil && a.OOMPolicy.Window != nil {
+		return a.OOMPolicy.Window.Duration
+	}
+	return DefaultOOMWindow
+}
+
+func (a *AggregateContainerState) oomKillCount() int {
+	if a.OOMPolicy != nil && a.OOMPolicy.OOMKillCount != nil {
+		return int(*a.OOMPolicy.OOMKillCount)
+	}
+	return DefaultOOMKillCount
+}
+
 // GetControlledResources returns the list of resources controlled by VPA controlling this aggregator.
 // Returns default if not set.
 func (a *AggregateContainerState) GetControlledResources() []ResourceName {
@@ -151,12 +275,20 @@ func (a *AggregateContainerState) MarkNotAutoscaled() {
 	a.UpdateMode = nil
 	a.ScalingMode = nil
 	a.ControlledResources = nil
+	a.TargetPercentile = nil
+	a.LowerBoundPercentile = nil
+	a.UpperBoundPercentile = nil
+	a.OOMPolicy = nil
+	a.recommenderModel = nil
 }
 
 // MergeContainerState merges two AggregateContainerStates.
 func (a *AggregateContainerState) MergeContainerState(other *AggregateContainerState) {
 	a.AggregateCPUUsage.Merge(other.AggregateCPUUsage)
 	a.AggregateMemoryPeaks.Merge(other.AggregateMemoryPeaks)
+	for resource, usage := range other.AggregateExtendedResourceUsage {
+		a.extendedResourceUsage(resource).Merge(usage)
+	}
 
 	if a.FirstSampleStart.IsZero() ||
 		(!other.FirstSampleStart.IsZero() && other.FirstSampleStart.Before(a.FirstSampleStart)) {
@@ -166,6 +298,13 @@ func (a *AggregateContainerState) MergeContainerState(other *AggregateContainerS
 		a.LastSampleStart = other.LastSampleStart
 	}
 	a.TotalSamplesCount += other.TotalSamplesCount
+	a.oomKills = append(a.oomKills, other.oomKills...)
+	if other.recommenderModel != nil {
+		if a.recommenderModel == nil {
+			a.recommenderModel = NewRecommenderModel(other.recommenderModel.Name())
+		}
+		a.recommenderModel.Merge(other.recommenderModel)
+	}
 }
 
 // NewAggregateContainerState returns a new, empty AggregateContainerState.
@@ -186,8 +325,30 @@ func (a *AggregateContainerState) AddSample(sample *ContainerUsageSample) {
 	case ResourceMemory:
 		a.AggregateMemoryPeaks.AddSample(BytesFromMemoryAmount(sample.Usage), 1.0, sample.MeasureStart)
 	default:
-		panic(fmt.Sprintf("AddSample doesn't support resource '%s'", sample.Resource))
+		if !IsExtendedResource(sample.Resource) {
+			panic(fmt.Sprintf("AddSample doesn't support resource '%s'", sample.Resource))
+		}
+		a.extendedResourceUsage(sample.Resource).AddSample(float64(sample.Usage), 1.0, sample.MeasureStart)
+	}
+}
+
+// GetExtendedResourceUsage returns the distribution of usage of the extended
+// resource, or nil if no usage of it was aggregated.
+func (a *AggregateContainerState) GetExtendedResourceUsage(resource ResourceName) util.Histogram {
+	return a.AggregateExtendedResourceUsage[resource]
+}
+
+func (a *AggregateContainerState) extendedResourceUsage(resource ResourceName) util.Histogram {
+	if a.AggregateExtendedResourceUsage == nil {
+		a.AggregateExtendedResourceUsage = make(map[ResourceName]util.Histogram)
+	}
+	usage, found := a.AggregateExtendedResourceUsage[resource]
+	if !found {
+		config := GetAggregationsConfig()
+		usage = util.NewDecayingHistogram(config.ExtendedResourceHistogramOptions, config.MemoryHistogramDecayHalfLife)
+		a.AggregateExtendedResourceUsage[resource] = usage
 	}
+	return usage
 }
 
 // SubtractSample removes a single usage sample from an aggregation.
@@ -208,6 +369,9 @@ func (a *AggregateContainerState) addCPUSample(sample *ContainerUsageSample) {
 	cpuUsageCores := CoresFromCPUAmount(sample.Usage)
 	a.AggregateCPUUsage.AddSample(
 		cpuUsageCores, minSampleWeight, sample.MeasureStart)
+	if a.recommenderModel != nil {
+		a.recommenderModel.AddSample(sample)
+	}
 	if sample.MeasureStart.After(a.LastSampleStart) {
 		a.LastSampleStart = sample.MeasureStart
 	}
@@ -217,6 +381,30 @@ func (a *AggregateContainerState) addCPUSample(sample *ContainerUsageSample) {
 	a.TotalSamplesCount++
 }
 
+// AddMemoryUsageSample adds a raw memory usage sample to the recommender model.
+func (a *AggregateContainerState) AddMemoryUsageSample(sample *ContainerUsageSample) {
+	if a.recommenderModel != nil {
+		a.recommenderModel.AddSample(sample)
+	}
+}
+
+// GetRecommenderModel returns the recommender model of the aggregated usage.
+func (a *AggregateContainerState) GetRecommenderModel() RecommenderModel {
+	if a.recommenderModel == nil {
+		return &histogramModel{state: a}
+	}
+	return a.recommenderModel
+}
+
+// SetRecommenderModel sets the recommender model of the aggregated usage. If
+// the model changes, the new one starts without usage.
+func (a *AggregateContainerState) SetRecommenderModel(name RecommenderModelName) {
+	if a.GetRecommenderModel().Name() == name {
+		return
+	}
+	a.recommenderModel = NewRecommenderModel(name)
+}
+
 // SaveToCheckpoint serializes AggregateContainerState as VerticalPodAutoscalerCheckpointStatus.
 // The serialization may result in loss of precission of the histograms.
 func (a *AggregateContainerState) SaveToCheckpoint() (*vpa_types.VerticalPodAutoscalerCheckpointStatus, error) {
@@ -270,8 +458,8 @@ func (a *AggregateContainerState) isEmpty() bool {
 	return a.TotalSamplesCount == 0
 }
 
-// UpdateFromPolicy updates container state scaling mode and controlled resources based on resource
-// policy of the VPA object.
+// UpdateFromPolicy updates container state scaling mode, controlled resources, percentiles and OOM policy
+// based on resource policy of the VPA object.
 func (a *AggregateContainerState) UpdateFromPolicy(resourcePolicy *vpa_types.ContainerResourcePolicy) {
 	// ContainerScalingModeAuto is the default scaling mode
 	scalingModeAuto := vpa_types.ContainerScalingModeAuto
@@ -283,6 +471,14 @@ func (a *AggregateContainerState) UpdateFromPolicy(resourcePolicy *vpa_types.Con
 	if resourcePolicy != nil && resourcePolicy.ControlledResources != nil {
 		a.ControlledResources = ResourceNamesApiToModel(*resourcePolicy.ControlledResources)
 	}
+	a.TargetPercentile, a.LowerBoundPercentile, a.UpperBoundPercentile = nil, nil, nil
+	a.OOMPolicy = nil
+	if resourcePolicy != nil {
+		a.TargetPercentile = resourcePolicy.TargetPercentile
+		a.LowerBoundPercentile = resourcePolicy.LowerBoundPercentile
+		a.UpperBoundPercentile = resourcePolicy.UpperBoundPercentile
+		a.OOMPolicy = resourcePolicy.OOMPolicy
+	}
 }
 
 // AggregateStateByContainerName takes a set of AggregateContainerStates and merge them
@@ -346,6 +542,24 @@ func (p *ContainerStateAggregatorProxy) GetUpdateMode() *vpa_types.UpdateMode {
 	return aggregator.GetUpdateMode()
 }
 
+// GetOOMPolicy returns OOM policy of container represented by the aggregator.
+func (p *ContainerStateAggregatorProxy) GetOOMPolicy() *vpa_types.OOMPolicy {
+	aggregator := p.cluster.findOrCreateAggregateContainerState(p.containerID)
+	return aggregator.GetOOMPolicy()
+}
+
+// AddOOMKill records an OOM kill in the aggregator.
+func (p *ContainerStateAggregatorProxy) AddOOMKill(timestamp time.Time, memoryNeeded ResourceAmount) {
+	aggregator := p.cluster.findOrCreateAggregateContainerState(p.containerID)
+	aggregator.AddOOMKill(timestamp, memoryNeeded)
+}
+
+// AddMemoryUsageSample adds a raw memory usage sample to the aggregator.
+func (p *ContainerStateAggregatorProxy) AddMemoryUsageSample(sample *ContainerUsageSample) {
+	aggregator := p.cluster.findOrCreateAggregateContainerState(p.containerID)
+	aggregator.AddMemoryUsageSample(sample)
+}
+
 // GetScalingMode returns scaling mode of container represented by the aggregator.
 func (p *ContainerStateAggregatorProxy) GetScalingMode() *vpa_types.ContainerScalingMode {
 	aggregator := p.cluster.findOrCreateAggregateContainerState(p.containerID)
@@ -293,3 +293,83 @@ func TestUpdateFromPolicyControlledResources(t *testing.T) {
 		})
 	}
 }
+
+func TestGetOOMMemoryFloor(t *testing.T) {
+	raiseLowerBound := vpa_types.OOMModeRaiseLowerBound
+	off := vpa_types.OOMModeOff
+	twoKills := int32(2)
+	testCases := []struct {
+		name      string
+		policy    *vpa_types.OOMPolicy
+		oomKills  []time.Duration
+		lastStart time.Duration
+		expected  ResourceAmount
+	}{
+		{
+			name:     "No OOM policy",
+			oomKills: []time.Duration{0, time.Minute, 2 * time.Minute},
+			expected: 0,
+		}, {
+			name:     "Mode off",
+			policy:   &vpa_types.OOMPolicy{Mode: &off},
+			oomKills: []time.Duration{0, time.Minute, 2 * time.Minute},
+			expected: 0,
+		}, {
+			name:     "Default OOM kill count reached",
+			policy:   &vpa_types.OOMPolicy{Mode: &raiseLowerBound},
+			oomKills: []time.Duration{0, time.Minute, 2 * time.Minute},
+			expected: ResourceAmount(3 * mb),
+		}, {
+			name:     "Default OOM kill count not reached",
+			policy:   &vpa_types.OOMPolicy{Mode: &raiseLowerBound},
+			oomKills: []time.Duration{0, time.Minute},
+			expected: 0,
+		}, {
+			name:     "Custom OOM kill count reached",
+			policy:   &vpa_types.OOMPolicy{Mode: &raiseLowerBound, OOMKillCount: &twoKills},
+			oomKills: []time.Duration{0, time.Minute},
+			expected: ResourceAmount(2 * mb),
+		}, {
+			name:     "OOM kills outside of custom window",
+			policy:   &vpa_types.OOMPolicy{Mode: &raiseLowerBound, Window: &metav1.Duration{Duration: time.Minute}},
+			oomKills: []time.Duration{0, time.Minute, 2 * time.Minute},
+			expected: 0,
+		}, {
+			name:      "No OOM kills within window before latest sample",
+			policy:    &vpa_types.OOMPolicy{Mode: &raiseLowerBound},
+			oomKills:  []time.Duration{0, time.Minute, 2 * time.Minute},
+			lastStart: 3 * time.Hour,
+			expected:  0,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cs := NewAggregateContainerState()
+			cs.UpdateFromPolicy(&vpa_types.ContainerResourcePolicy{OOMPolicy: tc.policy})
+			for i, offset := range tc.oomKills {
+				cs.AddOOMKill(testTimestamp.Add(offset), ResourceAmount((i+1)*mb))
+			}
+			cs.LastSampleStart = testTimestamp.Add(tc.lastStart)
+			assert.Equal(t, tc.expected, cs.GetOOMMemoryFloor())
+		})
+	}
+}
+
+func TestGetLastOOMKill(t *testing.T) {
+	cs := NewAggregateContainerState()
+	_, _, found := cs.GetLastOOMKill()
+	assert.False(t, found)
+
+	cs.AddOOMKill(testTimestamp.Add(time.Minute), ResourceAmount(2*mb))
+	cs.AddOOMKill(testTimestamp, ResourceAmount(mb))
+	cs.LastSampleStart = testTimestamp.Add(2 * time.Minute)
+	timestamp, memoryNeeded, found := cs.GetLastOOMKill()
+	assert.True(t, found)
+	assert.Equal(t, testTimestamp.Add(time.Minute), timestamp)
+	assert.Equal(t, ResourceAmount(2*mb), memoryNeeded)
+
+	// The OOM kills are outside of the default window ending at the latest sample.
+	cs.LastSampleStart = testTimestamp.Add(3 * time.Hour)
+	_, _, found = cs.GetLastOOMKill()
+	assert.False(t, found)
+}
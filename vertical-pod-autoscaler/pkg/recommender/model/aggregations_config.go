@@ -40,6 +40,9 @@ type AggregationsConfig struct {
 	// MemoryHistogramOptions are options to be used by histograms that
 	// store memory measures expressed in bytes.
 	MemoryHistogramOptions util.HistogramOptions
+	// ExtendedResourceHistogramOptions are options to be used by histograms
+	// that store usage of extended resources, expressed in their units.
+	ExtendedResourceHistogramOptions util.HistogramOptions
 	// HistogramBucketSizeGrowth defines the growth rate of the histogram buckets.
 	// Each bucket is wider than the previous one by this fraction.
 	HistogramBucketSizeGrowth float64
@@ -55,6 +58,16 @@ type AggregationsConfig struct {
 	OOMBumpUpRatio float64
 	// OOMMinBumpUp specifies the minimal increase of memory when OOM occurred in bytes.
 	OOMMinBumpUp float64
+	// MovingMaxWindow is the length of the window in which the usage peaks
+	// are kept by the moving-max recommender model.
+	MovingMaxWindow time.Duration
+	// SeasonalPeriodCount is the number of previous days or weeks whose
+	// usage peaks are kept by the seasonal recommender models.
+	SeasonalPeriodCount int
+	// SeasonalLookahead is the length of the time after the current hour
+	// of the previous days or weeks, whose usage peaks are taken into
+	// account by the seasonal recommender models.
+	SeasonalLookahead time.Duration
 }
 
 const (
@@ -79,6 +92,17 @@ const (
 	DefaultOOMBumpUpRatio float64 = 1.2 // Memory is increased by 20% after an OOMKill.
 	// DefaultOOMMinBumpUp is the default value for OOMMinBumpUp.
 	DefaultOOMMinBumpUp float64 = 100 * 1024 * 1024 // Memory is increased by at least 100MB after an OOMKill.
+	// DefaultOOMKillCount is the default number of OOM kills within DefaultOOMWindow after which
+	// the memory lower bound is raised, for VPAs with the RaiseLowerBound OOM mode.
+	DefaultOOMKillCount = 3
+	// DefaultOOMWindow is the default period in which OOM kills are counted.
+	DefaultOOMWindow = time.Hour
+	// DefaultMovingMaxWindow is the default value for MovingMaxWindow.
+	DefaultMovingMaxWindow = time.Hour * 24
+	// DefaultSeasonalPeriodCount is the default value for SeasonalPeriodCount.
+	DefaultSeasonalPeriodCount = 4
+	// DefaultSeasonalLookahead is the default value for SeasonalLookahead.
+	DefaultSeasonalLookahead = time.Hour * 2
 )
 
 // GetMemoryAggregationWindowLength returns the total length of the memory usage history aggregated by VPA.
@@ -110,6 +134,17 @@ func (a *AggregationsConfig) memoryHistogramOptions() util.HistogramOptions {
 	return options
 }
 
+func (a *AggregationsConfig) extendedResourceHistogramOptions() util.HistogramOptions {
+	// Extended resource histograms use exponential bucketing scheme with the
+	// smallest bucket size of 1 unit (e.g. byte) and max of MaxResourceAmount.
+	// They are not checkpointed.
+	options, err := util.NewExponentialHistogramOptions(float64(MaxResourceAmount), 1, 1.+a.HistogramBucketSizeGrowth, epsilon)
+	if err != nil {
+		panic("Invalid extended resource histogram options") // Should not happen.
+	}
+	return options
+}
+
 // NewAggregationsConfig creates a new AggregationsConfig based on the supplied parameters and default values.
 func NewAggregationsConfig(memoryAggregationInterval time.Duration, memoryAggregationIntervalCount int64, memoryHistogramDecayHalfLife, cpuHistogramDecayHalfLife time.Duration, oomBumpUpRatio float64, oomMinBumpUp float64) *AggregationsConfig {
 	a := &AggregationsConfig{
@@ -120,9 +155,13 @@ func NewAggregationsConfig(memoryAggregationInterval time.Duration, memoryAggreg
 		CPUHistogramDecayHalfLife:      cpuHistogramDecayHalfLife,
 		OOMBumpUpRatio:                 oomBumpUpRatio,
 		OOMMinBumpUp:                   oomMinBumpUp,
+		MovingMaxWindow:                DefaultMovingMaxWindow,
+		SeasonalPeriodCount:            DefaultSeasonalPeriodCount,
+		SeasonalLookahead:              DefaultSeasonalLookahead,
 	}
 	a.CPUHistogramOptions = a.cpuHistogramOptions()
 	a.MemoryHistogramOptions = a.memoryHistogramOptions()
+	a.ExtendedResourceHistogramOptions = a.extendedResourceHistogramOptions()
 	return a
 }
 
@@ -303,7 +303,13 @@ func (cluster *clusterState) AddOrUpdateVpa(apiObject *vpa_types.VerticalPodAuto
 	vpa.Annotations = annotationsMap
 	vpa.Conditions = conditionsMap
 	vpa.Recommendation = currentRecommendation
+	vpa.Recommender = apiObject.Status.Recommender
+	vpa.LastRecommendationTime = time.Time{}
+	if apiObject.Status.LastRecommendationTime != nil {
+		vpa.LastRecommendationTime = apiObject.Status.LastRecommendationTime.Time
+	}
 	vpa.SetUpdateMode(apiObject.Spec.UpdatePolicy)
+	vpa.SetRecommenderModel(GetRecommenderModelName(annotationsMap))
 	vpa.SetResourcePolicy(apiObject.Spec.ResourcePolicy)
 	vpa.SetAPIVersion(apiObject.GetObjectKind().GroupVersionKind().Version)
 	return nil
@@ -31,7 +31,8 @@ import (
 type ContainerUsageSample struct {
 	// Start of the measurement interval.
 	MeasureStart time.Time
-	// Average CPU usage in cores or memory usage in bytes.
+	// Average CPU usage in cores, memory usage in bytes or usage of an extended
+	// resource in its units.
 	Usage ResourceAmount
 	// Which resource is this sample for.
 	Resource ResourceName
@@ -56,6 +57,8 @@ type ContainerState struct {
 	WindowEnd time.Time
 	// Start of the latest memory usage sample that was aggregated.
 	lastMemorySampleStart time.Time
+	// Start of the latest usage sample of each extended resource that was aggregated.
+	lastExtendedResourceSampleStart map[ResourceName]time.Time
 	// Aggregation to add usage samples to.
 	aggregator ContainerStateAggregator
 }
@@ -133,6 +136,7 @@ func (container *ContainerState) addMemorySample(sample *ContainerUsageSample, i
 		return false // Discard invalid or outdated samples.
 	}
 	container.lastMemorySampleStart = ts
+	container.aggregator.AddMemoryUsageSample(sample)
 	if container.WindowEnd.IsZero() { // This is the first sample.
 		container.WindowEnd = ts
 	}
@@ -189,8 +193,17 @@ func (container *ContainerState) RecordOOM(timestamp time.Time, requestedMemory
 	// Get max of the request and the recent usage-based memory peak.
 	// Omitting oomPeak here to protect against recommendation running too high on subsequent OOMs.
 	memoryUsed := ResourceAmountMax(requestedMemory, container.memoryPeak)
-	memoryNeeded := ResourceAmountMax(memoryUsed+MemoryAmountFromBytes(GetAggregationsConfig().OOMMinBumpUp),
-		ScaleResource(memoryUsed, GetAggregationsConfig().OOMBumpUpRatio))
+	bumpUpRatio, minBumpUp := GetAggregationsConfig().OOMBumpUpRatio, GetAggregationsConfig().OOMMinBumpUp
+	if oomPolicy := container.aggregator.GetOOMPolicy(); oomPolicy != nil {
+		if oomPolicy.BumpUpRatio != nil {
+			bumpUpRatio = *oomPolicy.BumpUpRatio
+		}
+		if oomPolicy.MinBumpUp != nil {
+			minBumpUp = float64(oomPolicy.MinBumpUp.Value())
+		}
+	}
+	memoryNeeded := ResourceAmountMax(memoryUsed+MemoryAmountFromBytes(minBumpUp),
+		ScaleResource(memoryUsed, bumpUpRatio))
 
 	oomMemorySample := ContainerUsageSample{
 		MeasureStart: timestamp,
@@ -200,6 +213,7 @@ func (container *ContainerState) RecordOOM(timestamp time.Time, requestedMemory
 	if !container.addMemorySample(&oomMemorySample, true) {
 		return fmt.Errorf("adding OOM sample failed")
 	}
+	container.aggregator.AddOOMKill(timestamp, memoryNeeded)
 	return nil
 }
 
@@ -217,6 +231,21 @@ func (container *ContainerState) AddSample(sample *ContainerUsageSample) bool {
 	case ResourceMemory:
 		return container.addMemorySample(sample, false)
 	default:
+		if IsExtendedResource(sample.Resource) {
+			return container.addExtendedResourceSample(sample)
+		}
 		return false
 	}
 }
+
+func (container *ContainerState) addExtendedResourceSample(sample *ContainerUsageSample) bool {
+	if !sample.isValid(sample.Resource) || !sample.MeasureStart.After(container.lastExtendedResourceSampleStart[sample.Resource]) {
+		return false // Discard invalid, duplicate or out-of-order samples.
+	}
+	if container.lastExtendedResourceSampleStart == nil {
+		container.lastExtendedResourceSampleStart = make(map[ResourceName]time.Time)
+	}
+	container.aggregator.AddSample(sample)
+	container.lastExtendedResourceSampleStart[sample.Resource] = sample.MeasureStart
+	return true
+}
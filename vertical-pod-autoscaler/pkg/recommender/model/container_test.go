@@ -21,7 +21,9 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
 
+	vpa_types "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/recommender/util"
 )
 
@@ -157,6 +159,19 @@ func TestRecordOOMIncreasedByMin(t *testing.T) {
 	assert.NoError(t, test.container.RecordOOM(testTimestamp, ResourceAmount(1*mb)))
 }
 
+func TestRecordOOMIncreasedByOOMPolicy(t *testing.T) {
+	test := newContainerTest()
+	bumpUpRatio := 2.0
+	minBumpUp := resource.MustParse("500Mi")
+	test.aggregateContainerState.OOMPolicy = &vpa_types.OOMPolicy{BumpUpRatio: &bumpUpRatio, MinBumpUp: &minBumpUp}
+	memoryAggregationWindowEnd := testTimestamp.Add(GetAggregationsConfig().MemoryAggregationInterval)
+	// Bump up factor from the policy is 100%.
+	test.mockMemoryHistogram.On("AddSample", 2000.0*mb, 1.0, memoryAggregationWindowEnd)
+
+	assert.NoError(t, test.container.RecordOOM(testTimestamp, ResourceAmount(1000*mb)))
+	assert.Equal(t, []oomKill{{timestamp: testTimestamp, memoryNeeded: ResourceAmount(2000 * mb)}}, test.aggregateContainerState.oomKills)
+}
+
 func TestRecordOOMMaxedWithKnownSample(t *testing.T) {
 	test := newContainerTest()
 	memoryAggregationWindowEnd := testTimestamp.Add(GetAggregationsConfig().MemoryAggregationInterval)
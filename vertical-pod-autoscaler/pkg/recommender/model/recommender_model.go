@@ -0,0 +1,307 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// RecommenderModelName is the name of a model of the resource usage of
+// containers, on which their recommendations are based.
+type RecommenderModelName string
+
+const (
+	// RecommenderModelAnnotation is the annotation of VPA objects selecting the
+	// recommender model of their containers.
+	RecommenderModelAnnotation = "vpa-recommender.kubernetes.io/model"
+
+	// HistogramRecommenderModel bases the recommendations on percentiles of
+	// the decaying histograms of the usage. This is the default model.
+	HistogramRecommenderModel RecommenderModelName = "histogram"
+	// MovingMaxRecommenderModel bases the recommendations on percentiles of
+	// the hourly usage peaks within a moving window.
+	MovingMaxRecommenderModel RecommenderModelName = "moving-max"
+	// SeasonalDailyRecommenderModel bases the recommendations on percentiles
+	// of the hourly usage peaks at the current and upcoming hours of the
+	// previous days.
+	SeasonalDailyRecommenderModel RecommenderModelName = "seasonal-daily"
+	// SeasonalWeeklyRecommenderModel bases the recommendations on percentiles
+	// of the hourly usage peaks at the current and upcoming hours of the
+	// previous weeks.
+	SeasonalWeeklyRecommenderModel RecommenderModelName = "seasonal-weekly"
+
+	// recommenderModelInterval is the interval for which the usage peaks are
+	// kept by the moving-max and seasonal models.
+	recommenderModelInterval = time.Hour
+)
+
+// RecommenderModel models the resource usage of an aggregation of containers.
+// The recommendations of the containers are based on its usage percentiles.
+type RecommenderModel interface {
+	// Name returns the name of the model.
+	Name() RecommenderModelName
+	// AddSample adds a usage sample of an aggregated container. Memory
+	// samples are the raw usage, not the peaks per memory aggregation interval.
+	AddSample(sample *ContainerUsageSample)
+	// Merge adds the usage from another model of the same kind.
+	Merge(other RecommenderModel)
+	// Percentile returns the given percentile of the resource usage expected
+	// by the model, and false if the model has no usage to base it on yet.
+	Percentile(resource ResourceName, percentile float64) (ResourceAmount, bool)
+}
+
+// GetRecommenderModelName returns the recommender model selected by the
+// annotations of a VPA object. Defaults to the histogram model.
+func GetRecommenderModelName(annotations map[string]string) RecommenderModelName {
+	name, found := annotations[RecommenderModelAnnotation]
+	if !found {
+		return HistogramRecommenderModel
+	}
+	switch modelName := RecommenderModelName(name); modelName {
+	case HistogramRecommenderModel, MovingMaxRecommenderModel, SeasonalDailyRecommenderModel, SeasonalWeeklyRecommenderModel:
+		return modelName
+	default:
+		klog.V(4).InfoS("Unknown recommender model, using the histogram model", "model", name)
+		return HistogramRecommenderModel
+	}
+}
+
+// NewRecommenderModel returns a new, empty recommender model with the given
+// name, nil for the histogram model, which is based on the histograms of the
+// AggregateContainerState.
+func NewRecommenderModel(name RecommenderModelName) RecommenderModel {
+	config := GetAggregationsConfig()
+	switch name {
+	case MovingMaxRecommenderModel:
+		return &movingMaxModel{peaks: newUsagePeaks(config.MovingMaxWindow)}
+	case SeasonalDailyRecommenderModel:
+		return newSeasonalModel(name, 24*time.Hour, config)
+	case SeasonalWeeklyRecommenderModel:
+		return newSeasonalModel(name, 7*24*time.Hour, config)
+	default:
+		return nil
+	}
+}
+
+// histogramModel is the RecommenderModel of the decaying histograms of an
+// AggregateContainerState.
+type histogramModel struct {
+	state *AggregateContainerState
+}
+
+func (m *histogramModel) Name() RecommenderModelName {
+	return HistogramRecommenderModel
+}
+
+// AddSample does nothing, the samples are added to the histograms by the
+// AggregateContainerState.
+func (m *histogramModel) AddSample(*ContainerUsageSample) {}
+
+// Merge does nothing, the histograms are merged by the AggregateContainerState.
+func (m *histogramModel) Merge(RecommenderModel) {}
+
+func (m *histogramModel) Percentile(resource ResourceName, percentile float64) (ResourceAmount, bool) {
+	switch resource {
+	case ResourceCPU:
+		return CPUAmountFromCores(m.state.AggregateCPUUsage.Percentile(percentile)), true
+	case ResourceMemory:
+		return MemoryAmountFromBytes(m.state.AggregateMemoryPeaks.Percentile(percentile)), true
+	default:
+		return 0, false
+	}
+}
+
+// movingMaxModel keeps the hourly usage peaks within the moving max window.
+type movingMaxModel struct {
+	peaks *usagePeaks
+}
+
+func (m *movingMaxModel) Name() RecommenderModelName {
+	return MovingMaxRecommenderModel
+}
+
+func (m *movingMaxModel) AddSample(sample *ContainerUsageSample) {
+	m.peaks.add(sample)
+}
+
+func (m *movingMaxModel) Merge(other RecommenderModel) {
+	if o, ok := other.(*movingMaxModel); ok {
+		m.peaks.merge(o.peaks)
+	}
+}
+
+// Percentile returns false until the usage spans the whole window, e.g. after
+// the model is selected or the recommender restarts, as the peaks aren't
+// checkpointed and a partial window would underestimate the usage.
+func (m *movingMaxModel) Percentile(resource ResourceName, percentile float64) (ResourceAmount, bool) {
+	if m.peaks.latest-m.peaks.first+1 < m.peaks.count {
+		return 0, false
+	}
+	var values []ResourceAmount
+	for interval, peak := range m.peaks.peaks[resource] {
+		if interval > m.peaks.latest-m.peaks.count {
+			values = append(values, peak)
+		}
+	}
+	return percentileOf(values, percentile)
+}
+
+// seasonalModel keeps the hourly usage peaks of the last periods. The usage
+// expected by the model is based on the peaks within the lookahead from the
+// current hour in each of the previous periods, and the peak of the current
+// hour.
+type seasonalModel struct {
+	name      RecommenderModelName
+	peaks     *usagePeaks
+	period    int64
+	lookahead int64
+}
+
+func newSeasonalModel(name RecommenderModelName, period time.Duration, config *AggregationsConfig) *seasonalModel {
+	// The current interval of the oldest period is kept too.
+	return &seasonalModel{
+		name:      name,
+		peaks:     newUsagePeaks(period*time.Duration(config.SeasonalPeriodCount) + recommenderModelInterval),
+		period:    intervalCount(period),
+		lookahead: intervalCount(config.SeasonalLookahead),
+	}
+}
+
+func (m *seasonalModel) Name() RecommenderModelName {
+	return m.name
+}
+
+func (m *seasonalModel) AddSample(sample *ContainerUsageSample) {
+	m.peaks.add(sample)
+}
+
+func (m *seasonalModel) Merge(other RecommenderModel) {
+	if o, ok := other.(*seasonalModel); ok && o.name == m.name {
+		m.peaks.merge(o.peaks)
+	}
+}
+
+func (m *seasonalModel) Percentile(resource ResourceName, percentile float64) (ResourceAmount, bool) {
+	peaks := m.peaks.peaks[resource]
+	var values []ResourceAmount
+	for start := m.peaks.latest - m.period; start > m.peaks.latest-m.peaks.count; start -= m.period {
+		for interval := start; interval <= start+m.lookahead; interval++ {
+			if peak, found := peaks[interval]; found {
+				values = append(values, peak)
+			}
+		}
+	}
+	if len(values) == 0 {
+		// There is no usage from the previous periods yet.
+		return 0, false
+	}
+	if peak, found := peaks[m.peaks.latest]; found {
+		values = append(values, peak)
+	}
+	return percentileOf(values, percentile)
+}
+
+// usagePeaks holds the peak CPU and memory usage per recommender model
+// interval, for the intervals within a retention period of the latest sample.
+type usagePeaks struct {
+	// count is the number of intervals within the retention period.
+	count int64
+	// latest is the index of the interval of the latest sample, counted
+	// from the Unix epoch. It is 0 if there are no samples.
+	latest int64
+	// first is the index of the interval of the earliest sample, counted
+	// from the Unix epoch. It is 0 if there are no samples.
+	first int64
+	// peaks maps the index of the interval to its peak usage.
+	peaks map[ResourceName]map[int64]ResourceAmount
+}
+
+func newUsagePeaks(retention time.Duration) *usagePeaks {
+	return &usagePeaks{
+		count: max(intervalCount(retention), 1),
+		peaks: map[ResourceName]map[int64]ResourceAmount{
+			ResourceCPU:    make(map[int64]ResourceAmount),
+			ResourceMemory: make(map[int64]ResourceAmount),
+		},
+	}
+}
+
+func (p *usagePeaks) add(sample *ContainerUsageSample) {
+	peaks, found := p.peaks[sample.Resource]
+	if !found {
+		return
+	}
+	latest := p.latest
+	p.addPeak(peaks, intervalCount(time.Duration(sample.MeasureStart.UnixNano())), sample.Usage)
+	if p.latest != latest {
+		p.prune()
+	}
+}
+
+func (p *usagePeaks) merge(other *usagePeaks) {
+	for resource, otherPeaks := range other.peaks {
+		for interval, peak := range otherPeaks {
+			p.addPeak(p.peaks[resource], interval, peak)
+		}
+	}
+	p.prune()
+}
+
+func (p *usagePeaks) addPeak(peaks map[int64]ResourceAmount, interval int64, usage ResourceAmount) {
+	if p.first == 0 || interval < p.first {
+		p.first = interval
+	}
+	if interval <= p.latest-p.count {
+		return
+	}
+	if interval > p.latest {
+		p.latest = interval
+	}
+	peaks[interval] = ResourceAmountMax(peaks[interval], usage)
+}
+
+// prune drops the peaks of intervals out of the retention period.
+func (p *usagePeaks) prune() {
+	for _, peaks := range p.peaks {
+		for interval := range peaks {
+			if interval <= p.latest-p.count {
+				delete(peaks, interval)
+			}
+		}
+	}
+}
+
+// intervalCount returns the number of whole recommender model intervals in d.
+func intervalCount(d time.Duration) int64 {
+	return int64(d / recommenderModelInterval)
+}
+
+// percentileOf returns the given percentile of values, and false if there are
+// no values.
+func percentileOf(values []ResourceAmount, percentile float64) (ResourceAmount, bool) {
+	if len(values) == 0 {
+		return 0, false
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	index := int(math.Ceil(percentile*float64(len(values)))) - 1
+	index = min(max(index, 0), len(values)-1)
+	return values[index], true
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var modelTestTimestamp = time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+func addModelSample(m RecommenderModel, ts time.Time, resource ResourceName, usage ResourceAmount) {
+	m.AddSample(&ContainerUsageSample{MeasureStart: ts, Usage: usage, Resource: resource})
+}
+
+func TestGetRecommenderModelName(t *testing.T) {
+	for _, tc := range []struct {
+		annotations map[string]string
+		expected    RecommenderModelName
+	}{
+		{annotations: nil, expected: HistogramRecommenderModel},
+		{annotations: map[string]string{RecommenderModelAnnotation: "moving-max"}, expected: MovingMaxRecommenderModel},
+		{annotations: map[string]string{RecommenderModelAnnotation: "seasonal-daily"}, expected: SeasonalDailyRecommenderModel},
+		{annotations: map[string]string{RecommenderModelAnnotation: "seasonal-weekly"}, expected: SeasonalWeeklyRecommenderModel},
+		{annotations: map[string]string{RecommenderModelAnnotation: "unknown"}, expected: HistogramRecommenderModel},
+	} {
+		assert.Equal(t, tc.expected, GetRecommenderModelName(tc.annotations))
+	}
+}
+
+func TestMovingMaxModel(t *testing.T) {
+	m := NewRecommenderModel(MovingMaxRecommenderModel)
+	_, ok := m.Percentile(ResourceCPU, 0.9)
+	assert.False(t, ok)
+
+	// Hourly peaks of 1..30 cores, only the last 24 are within the window.
+	for i := 1; i <= 30; i++ {
+		ts := modelTestTimestamp.Add(time.Duration(i) * time.Hour)
+		addModelSample(m, ts, ResourceCPU, CPUAmountFromCores(float64(i)/2))
+		addModelSample(m, ts.Add(time.Minute), ResourceCPU, CPUAmountFromCores(float64(i)))
+		addModelSample(m, ts, ResourceMemory, MemoryAmountFromBytes(1e9))
+		if i == 23 {
+			// The usage doesn't span the whole window yet.
+			_, ok = m.Percentile(ResourceCPU, 1)
+			assert.False(t, ok)
+		}
+	}
+	cpu, ok := m.Percentile(ResourceCPU, 1)
+	assert.True(t, ok)
+	assert.Equal(t, CPUAmountFromCores(30), cpu)
+	cpu, _ = m.Percentile(ResourceCPU, 0.5)
+	assert.Equal(t, CPUAmountFromCores(18), cpu)
+	cpu, _ = m.Percentile(ResourceCPU, 0)
+	assert.Equal(t, CPUAmountFromCores(7), cpu)
+	memory, ok := m.Percentile(ResourceMemory, 0.9)
+	assert.True(t, ok)
+	assert.Equal(t, MemoryAmountFromBytes(1e9), memory)
+}
+
+func TestSeasonalDailyModel(t *testing.T) {
+	m := NewRecommenderModel(SeasonalDailyRecommenderModel)
+	// The usage peaks at 1 core every day at 10:00, and is 0.1 core otherwise.
+	// The samples end at 8:00 on the fifth day.
+	for hour := 0; hour <= 4*24+8; hour++ {
+		usage := CPUAmountFromCores(0.1)
+		if hour%24 == 10 {
+			usage = CPUAmountFromCores(1)
+		}
+		addModelSample(m, modelTestTimestamp.Add(time.Duration(hour)*time.Hour), ResourceCPU, usage)
+	}
+	// The peak at 10:00 is within the lookahead of 2h from 8:00.
+	cpu, ok := m.Percentile(ResourceCPU, 0.9)
+	assert.True(t, ok)
+	assert.Equal(t, CPUAmountFromCores(1), cpu)
+	// The median is the usage outside of the peak.
+	cpu, _ = m.Percentile(ResourceCPU, 0.5)
+	assert.Equal(t, CPUAmountFromCores(0.1), cpu)
+
+	// After the peak, the next one is more than 2h ahead.
+	addModelSample(m, modelTestTimestamp.Add((4*24+11)*time.Hour), ResourceCPU, CPUAmountFromCores(0.1))
+	cpu, _ = m.Percentile(ResourceCPU, 0.9)
+	assert.Equal(t, CPUAmountFromCores(0.1), cpu)
+}
+
+func TestSeasonalModelWithoutPreviousPeriods(t *testing.T) {
+	m := NewRecommenderModel(SeasonalWeeklyRecommenderModel)
+	for hour := 0; hour < 48; hour++ {
+		addModelSample(m, modelTestTimestamp.Add(time.Duration(hour)*time.Hour), ResourceMemory, MemoryAmountFromBytes(1e9))
+	}
+	_, ok := m.Percentile(ResourceMemory, 0.9)
+	assert.False(t, ok)
+}
+
+func TestAggregateContainerStateRecommenderModel(t *testing.T) {
+	state1 := NewAggregateContainerState()
+	assert.Equal(t, HistogramRecommenderModel, state1.GetRecommenderModel().Name())
+	state1.SetRecommenderModel(MovingMaxRecommenderModel)
+	state1.AddSample(&ContainerUsageSample{MeasureStart: modelTestTimestamp, Usage: CPUAmountFromCores(1), Resource: ResourceCPU})
+	state1.AddMemoryUsageSample(&ContainerUsageSample{MeasureStart: modelTestTimestamp, Usage: MemoryAmountFromBytes(1e9), Resource: ResourceMemory})
+
+	state2 := NewAggregateContainerState()
+	state2.SetRecommenderModel(MovingMaxRecommenderModel)
+	state2.AddSample(&ContainerUsageSample{MeasureStart: modelTestTimestamp.Add(time.Minute), Usage: CPUAmountFromCores(2), Resource: ResourceCPU})
+	// The usage of the merged state spans the whole moving max window.
+	state2.AddSample(&ContainerUsageSample{MeasureStart: modelTestTimestamp.Add(-23 * time.Hour), Usage: CPUAmountFromCores(0.5), Resource: ResourceCPU})
+
+	merged := NewAggregateContainerState()
+	merged.MergeContainerState(state1)
+	merged.MergeContainerState(state2)
+	assert.Equal(t, MovingMaxRecommenderModel, merged.GetRecommenderModel().Name())
+	cpu, ok := merged.GetRecommenderModel().Percentile(ResourceCPU, 1)
+	assert.True(t, ok)
+	assert.Equal(t, CPUAmountFromCores(2), cpu)
+	memory, ok := merged.GetRecommenderModel().Percentile(ResourceMemory, 0.5)
+	assert.True(t, ok)
+	assert.Equal(t, MemoryAmountFromBytes(1e9), memory)
+
+	state1.MarkNotAutoscaled()
+	assert.Equal(t, HistogramRecommenderModel, state1.GetRecommenderModel().Name())
+}
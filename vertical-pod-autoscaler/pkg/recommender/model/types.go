@@ -19,10 +19,13 @@ package model
 import (
 	"fmt"
 	"math"
+	"strings"
 
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/klog/v2"
+
+	resourcehelpers "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/resources"
 )
 
 // ResourceName represents the name of the resource monitored by recommender.
@@ -46,6 +49,34 @@ const (
 	MaxResourceAmount = ResourceAmount(1e14)
 )
 
+// IsExtendedResource returns true for resources other than CPU and memory
+// whose usage can be tracked by the recommender: extended resources with
+// a domain prefix (e.g. nvidia.com/gpu) and huge pages (e.g. hugepages-2Mi).
+// Their amounts are kept in their units, e.g. in bytes for huge pages.
+func IsExtendedResource(name ResourceName) bool {
+	return resourcehelpers.IsExtendedResource(apiv1.ResourceName(name))
+}
+
+// QuantityFromExtendedResourceAmount converts an extended resource
+// ResourceAmount to a resource.Quantity. Huge pages are rounded up to
+// a multiple of the page size.
+func QuantityFromExtendedResourceAmount(name ResourceName, amount ResourceAmount) resource.Quantity {
+	if strings.HasPrefix(string(name), apiv1.ResourceHugePagesPrefix) {
+		pageSize, err := resource.ParseQuantity(strings.TrimPrefix(string(name), apiv1.ResourceHugePagesPrefix))
+		if err == nil && pageSize.Value() > 0 {
+			pages := (int64(amount) + pageSize.Value() - 1) / pageSize.Value()
+			return *resource.NewQuantity(pages*pageSize.Value(), resource.BinarySI)
+		}
+	}
+	return *resource.NewQuantity(int64(amount), resource.DecimalSI)
+}
+
+// ExtendedResourceAmountFromFloat converts an extended resource amount
+// expressed in its units to a ResourceAmount, rounding up.
+func ExtendedResourceAmountFromFloat(amount float64) ResourceAmount {
+	return resourceAmountFromFloat(math.Ceil(amount))
+}
+
 // CPUAmountFromCores converts CPU cores to a ResourceAmount.
 func CPUAmountFromCores(cores float64) ResourceAmount {
 	return resourceAmountFromFloat(cores * 1000.0)
@@ -110,8 +141,12 @@ func ResourcesAsResourceList(resources Resources, humanizeMemory bool, roundCPUM
 				quantity = resource.MustParse(humanizedValue)
 			}
 		default:
-			klog.ErrorS(nil, "Cannot translate resource name", "resourceName", key)
-			continue
+			if !IsExtendedResource(key) {
+				klog.ErrorS(nil, "Cannot translate resource name", "resourceName", key)
+				continue
+			}
+			newKey = apiv1.ResourceName(key)
+			quantity = QuantityFromExtendedResourceAmount(key, resourceAmount)
 		}
 		result[newKey] = quantity
 	}
@@ -128,8 +163,11 @@ func ResourceNamesApiToModel(resources []apiv1.ResourceName) *[]ResourceName {
 		case apiv1.ResourceMemory:
 			result = append(result, ResourceMemory)
 		default:
-			klog.ErrorS(nil, "Cannot translate resource name", "resourceName", resource)
-			continue
+			if !IsExtendedResource(ResourceName(resource)) {
+				klog.ErrorS(nil, "Cannot translate resource name", "resourceName", resource)
+				continue
+			}
+			result = append(result, ResourceName(resource))
 		}
 	}
 	return &result
@@ -549,6 +549,40 @@ func TestQuantityFromMemoryAmount(t *testing.T) {
 	}
 }
 
+func TestQuantityFromExtendedResourceAmount(t *testing.T) {
+	tc := []struct {
+		name     string
+		resource ResourceName
+		amount   ResourceAmount
+		want     resource.Quantity
+	}{
+		{
+			name:     "gpu memory",
+			resource: "nvidia.com/gpumem",
+			amount:   1000,
+			want:     *resource.NewQuantity(1000, resource.DecimalSI),
+		},
+		{
+			name:     "huge pages rounded up to the page size",
+			resource: "hugepages-2Mi",
+			amount:   3 * 1024 * 1024,
+			want:     resource.MustParse("4Mi"),
+		},
+		{
+			name:     "whole huge pages",
+			resource: "hugepages-1Gi",
+			amount:   2 * 1024 * 1024 * 1024,
+			want:     resource.MustParse("2Gi"),
+		},
+	}
+	for _, tc := range tc {
+		t.Run(tc.name, func(t *testing.T) {
+			result := QuantityFromExtendedResourceAmount(tc.resource, tc.amount)
+			assert.True(t, tc.want.Equal(result), "expected %v, got %v", tc.want.String(), result.String())
+		})
+	}
+}
+
 type ScaleResourceTestCase struct {
 	name   string
 	amount ResourceAmount
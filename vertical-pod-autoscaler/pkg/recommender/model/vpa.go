@@ -101,6 +101,8 @@ type Vpa struct {
 	ContainersInitialAggregateState ContainerNameToAggregateStateMap
 	// UpdateMode describes how recommendations will be applied to pods
 	UpdateMode *vpa_types.UpdateMode
+	// RecommenderModel is the model of the usage on which recommendations are based.
+	RecommenderModel RecommenderModelName
 	// Created denotes timestamp of the original VPA object creation
 	Created time.Time
 	// CheckpointWritten indicates when last checkpoint for the VPA object was stored.
@@ -111,6 +113,15 @@ type Vpa struct {
 	TargetRef *autoscaling.CrossVersionObjectReference
 	// PodCount contains number of live Pods matching a given VPA object.
 	PodCount int
+	// Recommender is the name of the recommender generating the recommendation,
+	// set only if the VPA object specifies more than one recommender.
+	Recommender string
+	// LastRecommendationTime is the last time the recommender updated the
+	// recommendation, set only if the VPA object specifies more than one recommender.
+	LastRecommendationTime time.Time
+	// RecommendationDetails explain how the recommendation was computed, set
+	// only if the recommender reports recommendation details.
+	RecommendationDetails *vpa_types.RecommendationDetails
 }
 
 // NewVpa returns a new Vpa with a given ID and pod selector. Doesn't set the
@@ -129,8 +140,9 @@ func NewVpa(id VpaID, selector labels.Selector, created time.Time) *Vpa {
 		// client conversion, this needs to be done based on the resource content.
 		// The K8s client will not return the resource apiVersion as it's converted
 		// to the version requested by the client server side.
-		APIVersion: vpa_types.SchemeGroupVersion.Version,
-		PodCount:   0,
+		APIVersion:       vpa_types.SchemeGroupVersion.Version,
+		PodCount:         0,
+		RecommenderModel: HistogramRecommenderModel,
 	}
 	return vpa
 }
@@ -156,6 +168,7 @@ func (vpa *Vpa) UseAggregationIfMatching(aggregationKey AggregateStateKey, aggre
 		vpa.aggregateContainerStates[aggregationKey] = aggregation
 		aggregation.IsUnderVPA = true
 		aggregation.UpdateMode = vpa.UpdateMode
+		aggregation.SetRecommenderModel(vpa.RecommenderModel)
 		aggregation.UpdateFromPolicy(vpa_api_util.GetContainerResourcePolicy(aggregationKey.ContainerName(), vpa.ResourcePolicy))
 	}
 }
@@ -250,6 +263,14 @@ func (vpa *Vpa) SetUpdateMode(updatePolicy *vpa_types.PodUpdatePolicy) {
 	}
 }
 
+// SetRecommenderModel updates the recommender model of the VPA and aggregators under this VPA.
+func (vpa *Vpa) SetRecommenderModel(name RecommenderModelName) {
+	vpa.RecommenderModel = name
+	for _, state := range vpa.aggregateContainerStates {
+		state.SetRecommenderModel(name)
+	}
+}
+
 // UpdateConditions updates the conditions of VPA objects based on it's state.
 // PodsMatched is passed to indicate if there are currently active pods in the
 // cluster matching this VPA.
@@ -280,6 +301,11 @@ func (vpa *Vpa) AsStatus() *vpa_types.VerticalPodAutoscalerStatus {
 	if vpa.Recommendation != nil {
 		status.Recommendation = vpa.Recommendation
 	}
+	if vpa.Recommender != "" {
+		status.Recommender = vpa.Recommender
+		status.LastRecommendationTime = &metav1.Time{Time: vpa.LastRecommendationTime}
+	}
+	status.RecommendationDetails = vpa.RecommendationDetails
 	return status
 }
 
@@ -559,6 +559,27 @@ func TestSetResourcePolicy(t *testing.T) {
 	}
 }
 
+func TestSetRecommenderModel(t *testing.T) {
+	vpa := NewVpa(VpaID{Namespace: "test-namespace", VpaName: "my-favourite-vpa"}, labels.Nothing(), anyTime)
+	key, aggregation := testAggregation(vpa, "test-container", "label-1=value-1")
+	vpa.aggregateContainerStates[key] = aggregation
+
+	vpa.SetRecommenderModel(SeasonalDailyRecommenderModel)
+	assert.Equal(t, SeasonalDailyRecommenderModel, aggregation.GetRecommenderModel().Name())
+
+	// The model of a newly matched aggregation is set too.
+	selector, err := labels.Parse(testSelectorStr)
+	assert.NoError(t, err)
+	vpa.PodSelector = selector
+	otherKey, otherAggregation := testAggregation(vpa, "other-container", "label-1=value-1")
+	vpa.UseAggregationIfMatching(otherKey, otherAggregation)
+	assert.Equal(t, SeasonalDailyRecommenderModel, otherAggregation.GetRecommenderModel().Name())
+
+	vpa.SetRecommenderModel(HistogramRecommenderModel)
+	assert.Equal(t, HistogramRecommenderModel, aggregation.GetRecommenderModel().Name())
+	assert.Equal(t, HistogramRecommenderModel, otherAggregation.GetRecommenderModel().Name())
+}
+
 func testAggregation(vpa *Vpa, containerName, labels string) (mockAggregateStateKey, *AggregateContainerState) {
 	scalingModeAuto := vpa_types.ContainerScalingModeAuto
 	containerKey := mockAggregateStateKey{
@@ -19,16 +19,19 @@ package routines
 import (
 	"context"
 	"flag"
+	"strings"
 	"time"
 
 	"k8s.io/klog/v2"
 
+	vpa_types "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
 	vpa_api "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/client/clientset/versioned/typed/autoscaling.k8s.io/v1"
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/recommender/checkpoint"
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/recommender/input"
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/recommender/logic"
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/recommender/model"
 	controllerfetcher "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/target/controller_fetcher"
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/limitrange"
 	metrics_recommender "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/metrics/recommender"
 	vpa_utils "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/vpa"
 )
@@ -66,6 +69,8 @@ type recommender struct {
 	useCheckpoints                bool
 	lastAggregateContainerStateGC time.Time
 	recommendationPostProcessor   []RecommendationPostProcessor
+	limitRangeCalculator          limitrange.LimitRangeCalculator
+	reportRecommendationDetails   bool
 }
 
 func (r *recommender) GetClusterState() model.ClusterState {
@@ -91,11 +96,17 @@ func (r *recommender) UpdateVPAs() {
 		if !found {
 			continue
 		}
-		resources := r.podResourceRecommender.GetRecommendedPodResources(GetContainerNameToAggregateStateMap(vpa))
+		containerNameToAggregateStateMap := GetContainerNameToAggregateStateMap(vpa)
+		resources := r.podResourceRecommender.GetRecommendedPodResources(containerNameToAggregateStateMap)
 		had := vpa.HasRecommendation()
+		if r.reportRecommendationDetails {
+			vpa.RecommendationDetails = logic.GetRecommendationDetails(containerNameToAggregateStateMap)
+		}
 
 		listOfResourceRecommendation := logic.MapToListOfRecommendedContainerResources(resources)
 
+		// LimitRange capping comes before post-processing, so that the VPA resource policy prevails.
+		listOfResourceRecommendation = r.capToLimitRange(observedVpa, vpa, listOfResourceRecommendation)
 		for _, postProcessor := range r.recommendationPostProcessor {
 			listOfResourceRecommendation = postProcessor.Process(observedVpa, listOfResourceRecommendation)
 		}
@@ -106,7 +117,12 @@ func (r *recommender) UpdateVPAs() {
 		}
 		hasMatchingPods := vpa.PodCount > 0
 		vpa.UpdateConditions(hasMatchingPods)
-		if err := r.clusterState.RecordRecommendation(vpa, time.Now()); err != nil {
+		now := time.Now()
+		if vpa.Recommender != "" {
+			// Standby recommenders take over once this heartbeat becomes stale.
+			vpa.LastRecommendationTime = now
+		}
+		if err := r.clusterState.RecordRecommendation(vpa, now); err != nil {
 			klog.V(0).InfoS("", "err", err)
 			if klog.V(4).Enabled() {
 				pods := r.clusterState.GetMatchingPods(vpa)
@@ -126,6 +142,28 @@ func (r *recommender) UpdateVPAs() {
 	}
 }
 
+// capToLimitRange caps the recommendation to fit the container LimitRange in
+// the namespace of the VPA and updates the RecommendationCappedByLimitRange
+// condition accordingly.
+func (r *recommender) capToLimitRange(observedVpa *vpa_types.VerticalPodAutoscaler, vpa *model.Vpa,
+	recommendation *vpa_types.RecommendedPodResources) *vpa_types.RecommendedPodResources {
+	if r.limitRangeCalculator == nil {
+		return recommendation
+	}
+	limitRange, err := r.limitRangeCalculator.GetContainerLimitRangeItem(observedVpa.Namespace)
+	if err != nil {
+		klog.ErrorS(err, "Failed to fetch LimitRange for namespace", "namespace", observedVpa.Namespace)
+		return recommendation
+	}
+	cappedRecommendation, messages := vpa_utils.ApplyLimitRange(recommendation, observedVpa.Spec.ResourcePolicy, limitRange)
+	if len(messages) > 0 {
+		vpa.Conditions.Set(vpa_types.RecommendationCappedByLimitRange, true, "LimitRange", strings.Join(messages, "; "))
+	} else {
+		delete(vpa.Conditions, vpa_types.RecommendationCappedByLimitRange)
+	}
+	return cappedRecommendation
+}
+
 func (r *recommender) MaintainCheckpoints(ctx context.Context, minCheckpointsPerRun int) {
 	now := time.Now()
 	if r.useCheckpoints {
@@ -181,9 +219,14 @@ type RecommenderFactory struct {
 	VpaClient              vpa_api.VerticalPodAutoscalersGetter
 
 	RecommendationPostProcessors []RecommendationPostProcessor
+	LimitRangeCalculator         limitrange.LimitRangeCalculator
 
 	CheckpointsGCInterval time.Duration
 	UseCheckpoints        bool
+
+	// ReportRecommendationDetails enables explaining the recommendations in
+	// the status of the VPA objects.
+	ReportRecommendationDetails bool
 }
 
 // Make creates a new recommender instance,
@@ -199,6 +242,8 @@ func (c RecommenderFactory) Make() Recommender {
 		vpaClient:                     c.VpaClient,
 		podResourceRecommender:        c.PodResourceRecommender,
 		recommendationPostProcessor:   c.RecommendationPostProcessors,
+		limitRangeCalculator:          c.LimitRangeCalculator,
+		reportRecommendationDetails:   c.ReportRecommendationDetails,
 		lastAggregateContainerStateGC: time.Now(),
 		lastCheckpointGC:              time.Now(),
 	}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routines
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+
+	vpa_types "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/recommender/model"
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/test"
+)
+
+type fakeLimitRangeCalculator struct {
+	containerLimitRange *v1.LimitRangeItem
+}
+
+func (c *fakeLimitRangeCalculator) GetContainerLimitRangeItem(_ string) (*v1.LimitRangeItem, error) {
+	return c.containerLimitRange, nil
+}
+
+func (c *fakeLimitRangeCalculator) GetPodLimitRangeItem(_ string) (*v1.LimitRangeItem, error) {
+	return nil, nil
+}
+
+func TestCapToLimitRange(t *testing.T) {
+	limitRange := &v1.LimitRangeItem{
+		Type: v1.LimitTypeContainer,
+		Max:  v1.ResourceList{v1.ResourceMemory: resource.MustParse("1G")},
+	}
+	testCases := []struct {
+		name              string
+		target            v1.ResourceList
+		expectedMemory    resource.Quantity
+		expectedCondition bool
+	}{
+		{
+			name:              "capped",
+			target:            test.Resources("1", "2G"),
+			expectedMemory:    resource.MustParse("1G"),
+			expectedCondition: true,
+		},
+		{
+			name:              "not capped",
+			target:            test.Resources("1", "500M"),
+			expectedMemory:    resource.MustParse("500M"),
+			expectedCondition: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &recommender{limitRangeCalculator: &fakeLimitRangeCalculator{containerLimitRange: limitRange}}
+			observedVpa := test.VerticalPodAutoscaler().WithName("vpa").WithNamespace("default").WithContainer("container").Get()
+			vpa := model.NewVpa(model.VpaID{Namespace: "default", VpaName: "vpa"}, labels.Everything(), time.Now())
+			vpa.Conditions.Set(vpa_types.RecommendationCappedByLimitRange, true, "LimitRange", "stale")
+			recommendation := &vpa_types.RecommendedPodResources{
+				ContainerRecommendations: []vpa_types.RecommendedContainerResources{
+					{ContainerName: "container", Target: tc.target},
+				},
+			}
+
+			capped := r.capToLimitRange(observedVpa, vpa, recommendation)
+			assert.True(t, tc.expectedMemory.Equal(*capped.ContainerRecommendations[0].Target.Memory()))
+			condition, found := vpa.Conditions[vpa_types.RecommendationCappedByLimitRange]
+			assert.Equal(t, tc.expectedCondition, found)
+			if tc.expectedCondition {
+				assert.Equal(t, "container container: memory capped to LimitRange max 1G", condition.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actuation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+const (
+	statefulSetKind = "StatefulSet"
+	daemonSetKind   = "DaemonSet"
+)
+
+// Strategy decides how the pods of a kind of workload are updated.
+type Strategy interface {
+	// SelectPods returns the pods of the workload which can be updated in
+	// this loop, out of the pods waiting for an update.
+	SelectPods(namespace, name string, pods []*apiv1.Pod) []*apiv1.Pod
+	// AllowsEviction returns false if the pods can only be resized in place.
+	AllowsEviction() bool
+}
+
+// Strategies applies the Strategy of the kind of workload of each pod.
+type Strategies interface {
+	// SelectPods returns the pods which can be updated in this loop, in the
+	// order of podsForUpdate.
+	SelectPods(podsForUpdate []*apiv1.Pod) []*apiv1.Pod
+	// AllowsEviction returns false if the pod can only be resized in place.
+	AllowsEviction(pod *apiv1.Pod) bool
+}
+
+type strategies struct {
+	byKind          map[string]Strategy
+	defaultStrategy Strategy
+}
+
+// NewStrategies creates Strategies updating the pods of StatefulSets one at a
+// time in reverse ordinal order, never evicting the pods of DaemonSets,
+// and updating the pods of other workloads without restrictions. It uses the
+// StatefulSet informer of the factory, which has to be running, e.g. started
+// by target.NewVpaTargetSelectorFetcher.
+func NewStrategies(factory informers.SharedInformerFactory) (Strategies, error) {
+	statefulSetInformer := factory.Apps().V1().StatefulSets()
+	stopCh := make(chan struct{})
+	if !cache.WaitForCacheSync(stopCh, statefulSetInformer.Informer().HasSynced) {
+		return nil, fmt.Errorf("informer did not sync")
+	}
+	return newStrategies(statefulSetInformer.Lister()), nil
+}
+
+func newStrategies(statefulSetLister appslisters.StatefulSetLister) *strategies {
+	return &strategies{
+		byKind: map[string]Strategy{
+			statefulSetKind: &statefulSetStrategy{statefulSetLister: statefulSetLister},
+			daemonSetKind:   &daemonSetStrategy{},
+		},
+		defaultStrategy: &defaultStrategy{},
+	}
+}
+
+func (s *strategies) SelectPods(podsForUpdate []*apiv1.Pod) []*apiv1.Pod {
+	type workload struct{ kind, name string }
+	var workloads []workload
+	podsByWorkload := make(map[workload][]*apiv1.Pod)
+	for _, pod := range podsForUpdate {
+		var w workload
+		if owner := metav1.GetControllerOf(pod); owner != nil {
+			w = workload{kind: owner.Kind, name: owner.Name}
+		}
+		if _, found := podsByWorkload[w]; !found {
+			workloads = append(workloads, w)
+		}
+		podsByWorkload[w] = append(podsByWorkload[w], pod)
+	}
+	selected := make(map[*apiv1.Pod]bool)
+	for _, w := range workloads {
+		pods := podsByWorkload[w]
+		for _, pod := range s.strategy(w.kind).SelectPods(pods[0].Namespace, w.name, pods) {
+			selected[pod] = true
+		}
+	}
+	result := make([]*apiv1.Pod, 0, len(selected))
+	for _, pod := range podsForUpdate {
+		if selected[pod] {
+			result = append(result, pod)
+		}
+	}
+	return result
+}
+
+func (s *strategies) AllowsEviction(pod *apiv1.Pod) bool {
+	kind := ""
+	if owner := metav1.GetControllerOf(pod); owner != nil {
+		kind = owner.Kind
+	}
+	return s.strategy(kind).AllowsEviction()
+}
+
+func (s *strategies) strategy(kind string) Strategy {
+	if strategy, found := s.byKind[kind]; found {
+		return strategy
+	}
+	return s.defaultStrategy
+}
+
+// defaultStrategy updates the pods in the order of their update priority.
+type defaultStrategy struct{}
+
+func (*defaultStrategy) SelectPods(_, _ string, pods []*apiv1.Pod) []*apiv1.Pod {
+	return pods
+}
+
+func (*defaultStrategy) AllowsEviction() bool {
+	return true
+}
+
+// statefulSetStrategy follows the order of StatefulSet rolling updates: the
+// pod with the highest ordinal is updated first, and the next one only once
+// all the replicas are ready again. Pods aren't updated while a rollout of the
+// StatefulSet is in progress.
+type statefulSetStrategy struct {
+	statefulSetLister appslisters.StatefulSetLister
+}
+
+func (s *statefulSetStrategy) SelectPods(namespace, name string, pods []*apiv1.Pod) []*apiv1.Pod {
+	statefulSet, err := s.statefulSetLister.StatefulSets(namespace).Get(name)
+	if err != nil {
+		klog.V(2).InfoS("Cannot get StatefulSet, not updating its pods", "statefulSet", klog.KRef(namespace, name), "error", err)
+		return nil
+	}
+	replicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+	if statefulSet.Status.ObservedGeneration < statefulSet.Generation ||
+		statefulSet.Status.CurrentRevision != statefulSet.Status.UpdateRevision ||
+		statefulSet.Status.ReadyReplicas < replicas {
+		klog.V(4).InfoS("Waiting for StatefulSet to be ready before updating its next pod", "statefulSet", klog.KObj(statefulSet))
+		return nil
+	}
+	var selected *apiv1.Pod
+	selectedOrdinal := -1
+	for _, pod := range pods {
+		ordinal, err := strconv.Atoi(strings.TrimPrefix(pod.Name, name+"-"))
+		if err != nil {
+			klog.V(2).InfoS("Cannot get ordinal of StatefulSet pod", "pod", klog.KObj(pod), "error", err)
+			continue
+		}
+		if ordinal > selectedOrdinal {
+			selected, selectedOrdinal = pod, ordinal
+		}
+	}
+	if selected == nil {
+		return nil
+	}
+	return []*apiv1.Pod{selected}
+}
+
+func (*statefulSetStrategy) AllowsEviction() bool {
+	return true
+}
+
+// daemonSetStrategy doesn't allow evicting the pods of DaemonSets, since an
+// evicted pod leaves its node without the daemon until it's recreated. They're
+// only resized in place, if their VPA is in InPlaceOrRecreate mode.
+type daemonSetStrategy struct{}
+
+func (*daemonSetStrategy) SelectPods(_, _ string, pods []*apiv1.Pod) []*apiv1.Pod {
+	return pods
+}
+
+func (*daemonSetStrategy) AllowsEviction() bool {
+	return false
+}
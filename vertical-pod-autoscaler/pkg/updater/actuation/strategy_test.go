@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actuation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/test"
+)
+
+func newPod(name, ownerKind, ownerName string) *apiv1.Pod {
+	pod := test.Pod().WithName(name).Get()
+	controller := true
+	pod.OwnerReferences = []metav1.OwnerReference{{Kind: ownerKind, Name: ownerName, Controller: &controller}}
+	return pod
+}
+
+func newStatefulSet(replicas, ready int32, currentRevision, updateRevision string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "sts", Namespace: "default", Generation: 1},
+		Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+		Status: appsv1.StatefulSetStatus{
+			ObservedGeneration: 1,
+			ReadyReplicas:      ready,
+			CurrentRevision:    currentRevision,
+			UpdateRevision:     updateRevision,
+		},
+	}
+}
+
+func newTestStrategies(t *testing.T, statefulSets ...*appsv1.StatefulSet) *strategies {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, statefulSet := range statefulSets {
+		assert.NoError(t, indexer.Add(statefulSet))
+	}
+	return newStrategies(appslisters.NewStatefulSetLister(indexer))
+}
+
+func TestSelectPods(t *testing.T) {
+	sts2 := newPod("sts-2", "StatefulSet", "sts")
+	sts10 := newPod("sts-10", "StatefulSet", "sts")
+	sts9 := newPod("sts-9", "StatefulSet", "sts")
+	rs1 := newPod("rs-1", "ReplicaSet", "rs")
+	rs2 := newPod("rs-2", "ReplicaSet", "rs")
+	ds1 := newPod("ds-1", "DaemonSet", "ds")
+	standalone := test.Pod().WithName("standalone").Get()
+
+	tests := []struct {
+		name          string
+		statefulSet   *appsv1.StatefulSet
+		podsForUpdate []*apiv1.Pod
+		expected      []*apiv1.Pod
+	}{
+		{
+			name:          "pods of other workloads keep their order",
+			podsForUpdate: []*apiv1.Pod{rs2, ds1, standalone, rs1},
+			expected:      []*apiv1.Pod{rs2, ds1, standalone, rs1},
+		},
+		{
+			name:          "only the StatefulSet pod with the highest ordinal",
+			statefulSet:   newStatefulSet(11, 11, "r1", "r1"),
+			podsForUpdate: []*apiv1.Pod{rs1, sts2, sts10, sts9, rs2},
+			expected:      []*apiv1.Pod{rs1, sts10, rs2},
+		},
+		{
+			name:          "no StatefulSet pod while a replica isn't ready",
+			statefulSet:   newStatefulSet(11, 10, "r1", "r1"),
+			podsForUpdate: []*apiv1.Pod{rs1, sts2, sts10},
+			expected:      []*apiv1.Pod{rs1},
+		},
+		{
+			name:          "no StatefulSet pod during a rollout",
+			statefulSet:   newStatefulSet(11, 11, "r1", "r2"),
+			podsForUpdate: []*apiv1.Pod{sts2, sts10},
+			expected:      []*apiv1.Pod{},
+		},
+		{
+			name:          "no pod of missing StatefulSet",
+			podsForUpdate: []*apiv1.Pod{sts2},
+			expected:      []*apiv1.Pod{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var s *strategies
+			if tc.statefulSet != nil {
+				s = newTestStrategies(t, tc.statefulSet)
+			} else {
+				s = newTestStrategies(t)
+			}
+			assert.Equal(t, tc.expected, s.SelectPods(tc.podsForUpdate))
+		})
+	}
+}
+
+func TestAllowsEviction(t *testing.T) {
+	s := newTestStrategies(t)
+	assert.True(t, s.AllowsEviction(newPod("rs-1", "ReplicaSet", "rs")))
+	assert.True(t, s.AllowsEviction(newPod("sts-0", "StatefulSet", "sts")))
+	assert.True(t, s.AllowsEviction(test.Pod().WithName("standalone").Get()))
+	assert.False(t, s.AllowsEviction(newPod("ds-1", "DaemonSet", "ds")))
+}
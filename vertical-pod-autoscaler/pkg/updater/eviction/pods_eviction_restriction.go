@@ -62,6 +62,9 @@ type singleGroupStats struct {
 	running           int
 	evictionTolerance int
 	evicted           int
+	// degraded is true if the workload is degraded, so evictions of its
+	// running and ready pods are paused.
+	degraded bool
 }
 
 // PodsEvictionRestrictionFactory creates PodsEvictionRestriction
@@ -79,6 +82,8 @@ type podsEvictionRestrictionFactoryImpl struct {
 	dsInformer                cache.SharedIndexInformer // informer for Daemon Sets
 	minReplicas               int
 	evictionToleranceFraction float64
+	// workloadHealthChecker is nil if evictions aren't paused for degraded workloads.
+	workloadHealthChecker *workloadHealthChecker
 }
 
 type controllerKind string
@@ -106,6 +111,11 @@ func (e *podsEvictionRestrictionImpl) CanEvict(pod *apiv1.Pod) bool {
 			return true
 		}
 		if present {
+			if singleGroupStats.degraded {
+				// Pods which aren't ready don't add to the availability of the
+				// workload, and they may need the recommendation to recover.
+				return !isPodReady(pod)
+			}
 			shouldBeAlive := singleGroupStats.configured - singleGroupStats.evictionTolerance
 			if singleGroupStats.running-singleGroupStats.evicted > shouldBeAlive {
 				return true
@@ -162,9 +172,12 @@ func (e *podsEvictionRestrictionImpl) Evict(podToEvict *apiv1.Pod, vpa *vpa_type
 	return nil
 }
 
-// NewPodsEvictionRestrictionFactory creates PodsEvictionRestrictionFactory
+// NewPodsEvictionRestrictionFactory creates PodsEvictionRestrictionFactory. If
+// pauseEvictionsOnDegradedWorkload is true, running and ready pods aren't evicted while
+// their workload has fewer available replicas than desired, or while a pod
+// disruption budget matching them has fewer healthy pods than desired.
 func NewPodsEvictionRestrictionFactory(client kube_client.Interface, minReplicas int,
-	evictionToleranceFraction float64) (PodsEvictionRestrictionFactory, error) {
+	evictionToleranceFraction float64, pauseEvictionsOnDegradedWorkload bool) (PodsEvictionRestrictionFactory, error) {
 	rcInformer, err := setUpInformer(client, replicationController)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to create rcInformer: %v", err)
@@ -181,6 +194,13 @@ func NewPodsEvictionRestrictionFactory(client kube_client.Interface, minReplicas
 	if err != nil {
 		return nil, fmt.Errorf("Failed to create dsInformer: %v", err)
 	}
+	var healthChecker *workloadHealthChecker
+	if pauseEvictionsOnDegradedWorkload {
+		healthChecker, err = newWorkloadHealthChecker(client, rcInformer, ssInformer, rsInformer, dsInformer)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to create workload health checker: %v", err)
+		}
+	}
 	return &podsEvictionRestrictionFactoryImpl{
 		client:                    client,
 		rcInformer:                rcInformer, // informer for Replication Controllers
@@ -188,7 +208,8 @@ func NewPodsEvictionRestrictionFactory(client kube_client.Interface, minReplicas
 		rsInformer:                rsInformer, // informer for Stateful Sets
 		dsInformer:                dsInformer, // informer for Daemon Sets
 		minReplicas:               minReplicas,
-		evictionToleranceFraction: evictionToleranceFraction}, nil
+		evictionToleranceFraction: evictionToleranceFraction,
+		workloadHealthChecker:     healthChecker}, nil
 }
 
 // NewPodsEvictionRestriction creates PodsEvictionRestriction for a given set of pods,
@@ -253,6 +274,16 @@ func (f *podsEvictionRestrictionFactoryImpl) NewPodsEvictionRestriction(pods []*
 			}
 		}
 		singleGroup.running = len(replicas) - singleGroup.pending
+		if f.workloadHealthChecker != nil {
+			degraded, reason, err := f.workloadHealthChecker.isDegraded(creator, replicas)
+			if err != nil {
+				klog.ErrorS(err, "Failed to check workload health", "kind", creator.Kind, "object", klog.KRef(creator.Namespace, creator.Name))
+			}
+			if degraded {
+				klog.V(2).InfoS("Pausing evictions, workload is degraded", "kind", creator.Kind, "object", klog.KRef(creator.Namespace, creator.Name), "reason", reason)
+			}
+			singleGroup.degraded = degraded
+		}
 		creatorToSingleGroupStatsMap[creator] = singleGroup
 	}
 	return &podsEvictionRestrictionImpl{
@@ -384,11 +415,18 @@ func setUpInformer(kubeClient kube_client.Interface, kind controllerKind) (cache
 	default:
 		return nil, fmt.Errorf("Unknown controller kind: %v", kind)
 	}
+	if err := runInformer(informer, string(kind)); err != nil {
+		return nil, err
+	}
+	return informer, nil
+}
+
+func runInformer(informer cache.SharedIndexInformer, kind string) error {
 	stopCh := make(chan struct{})
 	go informer.Run(stopCh)
 	synced := cache.WaitForCacheSync(stopCh, informer.HasSynced)
 	if !synced {
-		return nil, fmt.Errorf("Failed to sync %v cache.", kind)
+		return fmt.Errorf("Failed to sync %v cache.", kind)
 	}
-	return informer, nil
+	return nil
 }
@@ -0,0 +1,174 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eviction
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	appsinformer "k8s.io/client-go/informers/apps/v1"
+	policyinformer "k8s.io/client-go/informers/policy/v1"
+	kube_client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+)
+
+// workloadHealthChecker checks if the workloads owning the pods are degraded.
+// Evictions of their pods are paused until they recover.
+type workloadHealthChecker struct {
+	rcInformer         cache.SharedIndexInformer // informer for Replication Controllers
+	ssInformer         cache.SharedIndexInformer // informer for Stateful Sets
+	rsInformer         cache.SharedIndexInformer // informer for Replica Sets
+	dsInformer         cache.SharedIndexInformer // informer for Daemon Sets
+	deploymentInformer cache.SharedIndexInformer // informer for Deployments
+	pdbInformer        cache.SharedIndexInformer // informer for Pod Disruption Budgets
+}
+
+func newWorkloadHealthChecker(client kube_client.Interface, rcInformer, ssInformer, rsInformer, dsInformer cache.SharedIndexInformer) (*workloadHealthChecker, error) {
+	deploymentInformer := appsinformer.NewDeploymentInformer(client, apiv1.NamespaceAll,
+		resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if err := runInformer(deploymentInformer, "Deployment"); err != nil {
+		return nil, err
+	}
+	pdbInformer := policyinformer.NewPodDisruptionBudgetInformer(client, apiv1.NamespaceAll,
+		resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if err := runInformer(pdbInformer, "PodDisruptionBudget"); err != nil {
+		return nil, err
+	}
+	return &workloadHealthChecker{
+		rcInformer:         rcInformer,
+		ssInformer:         ssInformer,
+		rsInformer:         rsInformer,
+		dsInformer:         dsInformer,
+		deploymentInformer: deploymentInformer,
+		pdbInformer:        pdbInformer,
+	}, nil
+}
+
+// isDegraded returns true and the reason if the workload which created the
+// pods has fewer available replicas than desired, or if a pod disruption
+// budget matching the pods has fewer healthy pods than desired.
+func (c *workloadHealthChecker) isDegraded(creator podReplicaCreator, pods []*apiv1.Pod) (bool, string, error) {
+	degraded, reason, err := c.isCreatorDegraded(creator)
+	if err != nil || degraded {
+		return degraded, reason, err
+	}
+	return c.isDisruptionBudgetDegraded(creator.Namespace, pods)
+}
+
+func (c *workloadHealthChecker) isCreatorDegraded(creator podReplicaCreator) (bool, string, error) {
+	key := creator.Namespace + "/" + creator.Name
+	switch creator.Kind {
+	case replicationController:
+		rc, err := getFromStore[*apiv1.ReplicationController](c.rcInformer, key)
+		if err != nil {
+			return false, "", err
+		}
+		return availabilityDegraded("replication controller", key, rc.Status.AvailableReplicas, ptr.Deref(rc.Spec.Replicas, 1))
+	case replicaSet:
+		rs, err := getFromStore[*appsv1.ReplicaSet](c.rsInformer, key)
+		if err != nil {
+			return false, "", err
+		}
+		if owner := metav1.GetControllerOf(rs); owner != nil && owner.Kind == "Deployment" {
+			deploymentKey := creator.Namespace + "/" + owner.Name
+			deployment, err := getFromStore[*appsv1.Deployment](c.deploymentInformer, deploymentKey)
+			if err != nil {
+				return false, "", err
+			}
+			return availabilityDegraded("deployment", deploymentKey, deployment.Status.AvailableReplicas, ptr.Deref(deployment.Spec.Replicas, 1))
+		}
+		return availabilityDegraded("replica set", key, rs.Status.AvailableReplicas, ptr.Deref(rs.Spec.Replicas, 1))
+	case statefulSet:
+		ss, err := getFromStore[*appsv1.StatefulSet](c.ssInformer, key)
+		if err != nil {
+			return false, "", err
+		}
+		return availabilityDegraded("stateful set", key, ss.Status.AvailableReplicas, ptr.Deref(ss.Spec.Replicas, 1))
+	case daemonSet:
+		ds, err := getFromStore[*appsv1.DaemonSet](c.dsInformer, key)
+		if err != nil {
+			return false, "", err
+		}
+		if ds.Status.NumberUnavailable > 0 {
+			return true, fmt.Sprintf("daemon set %s has %d unavailable pods", key, ds.Status.NumberUnavailable), nil
+		}
+	}
+	return false, "", nil
+}
+
+func (c *workloadHealthChecker) isDisruptionBudgetDegraded(namespace string, pods []*apiv1.Pod) (bool, string, error) {
+	pdbs, err := c.pdbInformer.GetIndexer().ByIndex(cache.NamespaceIndex, namespace)
+	if err != nil {
+		return false, "", fmt.Errorf("pod disruption budgets in namespace %s are not available, err: %v", namespace, err)
+	}
+	for _, obj := range pdbs {
+		pdb, ok := obj.(*policyv1.PodDisruptionBudget)
+		if !ok || pdb.Status.CurrentHealthy >= pdb.Status.DesiredHealthy {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || selector.Empty() {
+			continue
+		}
+		for _, pod := range pods {
+			if selector.Matches(labels.Set(pod.Labels)) {
+				return true, fmt.Sprintf("pod disruption budget %s/%s has %d healthy pods, desired %d",
+					pdb.Namespace, pdb.Name, pdb.Status.CurrentHealthy, pdb.Status.DesiredHealthy), nil
+			}
+		}
+	}
+	return false, "", nil
+}
+
+// isPodReady returns false if the pod reports its Ready condition as anything
+// but true.
+func isPodReady(pod *apiv1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == apiv1.PodReady {
+			return condition.Status == apiv1.ConditionTrue
+		}
+	}
+	return true
+}
+
+func availabilityDegraded(kind, key string, available, desired int32) (bool, string, error) {
+	if available < desired {
+		return true, fmt.Sprintf("%s %s has %d available replicas, desired %d", kind, key, available, desired), nil
+	}
+	return false, "", nil
+}
+
+func getFromStore[T any](informer cache.SharedIndexInformer, key string) (T, error) {
+	var result T
+	obj, exists, err := informer.GetStore().GetByKey(key)
+	if err != nil {
+		return result, fmt.Errorf("%s is not available, err: %v", key, err)
+	}
+	if !exists {
+		return result, fmt.Errorf("%s does not exist", key)
+	}
+	result, ok := obj.(T)
+	if !ok {
+		return result, fmt.Errorf("failed to parse %s", key)
+	}
+	return result, nil
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eviction
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	appsinformer "k8s.io/client-go/informers/apps/v1"
+	policyinformer "k8s.io/client-go/informers/policy/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/test"
+)
+
+func TestEvictionPausedForDegradedWorkload(t *testing.T) {
+	replicas := int32(5)
+	isController := true
+	deployment := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "deployment", Namespace: "default"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+	}
+	rs := appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "rs",
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "Deployment", Name: deployment.Name, Controller: &isController},
+			},
+		},
+		TypeMeta: metav1.TypeMeta{Kind: "ReplicaSet"},
+		Spec:     appsv1.ReplicaSetSpec{Replicas: &replicas},
+	}
+	podLabels := map[string]string{"app": "test"}
+
+	testCases := []struct {
+		name              string
+		availableReplicas int32
+		pdbSelector       map[string]string
+		pdbCurrentHealthy int32
+		pendingPod        bool
+		podReady          apiv1.ConditionStatus
+		expectedCanEvict  bool
+	}{
+		{
+			name:              "healthy deployment",
+			availableReplicas: 5,
+			expectedCanEvict:  true,
+		},
+		{
+			name:              "degraded deployment",
+			availableReplicas: 4,
+			expectedCanEvict:  false,
+		},
+		{
+			name:              "pending pod of degraded deployment",
+			availableReplicas: 4,
+			pendingPod:        true,
+			expectedCanEvict:  true,
+		},
+		{
+			name:              "ready pod of degraded deployment",
+			availableReplicas: 4,
+			podReady:          apiv1.ConditionTrue,
+			expectedCanEvict:  false,
+		},
+		{
+			name:              "running pod which isn't ready of degraded deployment",
+			availableReplicas: 0,
+			podReady:          apiv1.ConditionFalse,
+			expectedCanEvict:  true,
+		},
+		{
+			name:              "degraded pod disruption budget",
+			availableReplicas: 5,
+			pdbSelector:       podLabels,
+			pdbCurrentHealthy: 3,
+			expectedCanEvict:  false,
+		},
+		{
+			name:              "degraded pod disruption budget of other pods",
+			availableReplicas: 5,
+			pdbSelector:       map[string]string{"app": "other"},
+			pdbCurrentHealthy: 3,
+			expectedCanEvict:  true,
+		},
+		{
+			name:              "healthy pod disruption budget",
+			availableReplicas: 5,
+			pdbSelector:       podLabels,
+			pdbCurrentHealthy: 4,
+			expectedCanEvict:  true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pods := make([]*apiv1.Pod, replicas)
+			for i := range pods {
+				pods[i] = test.Pod().WithName(getTestPodName(i)).WithLabels(podLabels).WithCreator(&rs.ObjectMeta, &rs.TypeMeta).WithPhase(apiv1.PodRunning).Get()
+			}
+			if tc.podReady != "" {
+				for _, pod := range pods {
+					pod.Status.Conditions = []apiv1.PodCondition{{Type: apiv1.PodReady, Status: tc.podReady}}
+				}
+			}
+			if tc.pendingPod {
+				pods[0].Status.Phase = apiv1.PodPending
+			}
+
+			factory, err := getEvictionRestrictionFactory(nil, &rs, nil, nil, 2, 0.5)
+			assert.NoError(t, err)
+			impl := factory.(*podsEvictionRestrictionFactoryImpl)
+			impl.workloadHealthChecker = getWorkloadHealthChecker(t, impl, deployment, tc.availableReplicas, tc.pdbSelector, tc.pdbCurrentHealthy)
+
+			eviction := factory.NewPodsEvictionRestriction(pods, getBasicVpa())
+			assert.Equal(t, tc.expectedCanEvict, eviction.CanEvict(pods[0]))
+		})
+	}
+}
+
+func getWorkloadHealthChecker(t *testing.T, f *podsEvictionRestrictionFactoryImpl, deployment appsv1.Deployment,
+	availableReplicas int32, pdbSelector map[string]string, pdbCurrentHealthy int32) *workloadHealthChecker {
+	kubeClient := &fake.Clientset{}
+	deploymentInformer := appsinformer.NewDeploymentInformer(kubeClient, apiv1.NamespaceAll,
+		0*time.Second, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	pdbInformer := policyinformer.NewPodDisruptionBudgetInformer(kubeClient, apiv1.NamespaceAll,
+		0*time.Second, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+
+	deployment.Status.AvailableReplicas = availableReplicas
+	assert.NoError(t, deploymentInformer.GetIndexer().Add(&deployment))
+	if pdbSelector != nil {
+		pdb := &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: "pdb", Namespace: "default"},
+			Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: pdbSelector}},
+			Status:     policyv1.PodDisruptionBudgetStatus{CurrentHealthy: pdbCurrentHealthy, DesiredHealthy: 4},
+		}
+		assert.NoError(t, pdbInformer.GetIndexer().Add(pdb))
+	}
+	return &workloadHealthChecker{
+		rcInformer:         f.rcInformer,
+		ssInformer:         f.ssInformer,
+		rsInformer:         f.rsInformer,
+		dsInformer:         f.dsInformer,
+		deploymentInformer: deploymentInformer,
+		pdbInformer:        pdbInformer,
+	}
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inplace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	kube_client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/admission-controller/resource/pod/recommendation"
+	vpa_types "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
+	resourcehelpers "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/resources"
+	vpa_api_util "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/vpa"
+)
+
+const (
+	// resizeSubresource is the name of the pod subresource used to resize pods in place.
+	resizeSubresource = "resize"
+)
+
+// Action is the way the recommended resources are applied to a pod.
+type Action string
+
+const (
+	// ActionResize applies the recommended resources by resizing the pod in place.
+	ActionResize Action = "Resize"
+	// ActionWait waits for the in-place resize of the pod that is in progress.
+	ActionWait Action = "Wait"
+	// ActionEvict applies the recommended resources by evicting the pod, so
+	// that it is recreated with them.
+	ActionEvict Action = "Evict"
+)
+
+// PodResizer applies the recommended resources to pods by resizing them in place.
+type PodResizer interface {
+	// GetAction returns how the resources recommended by the VPA should be applied to the pod.
+	GetAction(pod *apiv1.Pod, vpa *vpa_types.VerticalPodAutoscaler) Action
+	// Resize resizes the pod in place to the resources recommended by the VPA.
+	// Returns error if the client returned error.
+	Resize(pod *apiv1.Pod, vpa *vpa_types.VerticalPodAutoscaler, eventRecorder record.EventRecorder) error
+}
+
+type podResizer struct {
+	client                 kube_client.Interface
+	recommendationProvider recommendation.Provider
+	// supported is true if the cluster serves the resize subresource of pods.
+	supported bool
+}
+
+// NewPodResizer creates a PodResizer. In-place resizes are used only if the
+// cluster serves the resize subresource of pods.
+func NewPodResizer(client kube_client.Interface, recommendationProvider recommendation.Provider) PodResizer {
+	return &podResizer{
+		client:                 client,
+		recommendationProvider: recommendationProvider,
+		supported:              isResizeSupported(client),
+	}
+}
+
+func isResizeSupported(client kube_client.Interface) bool {
+	resources, err := client.Discovery().ServerResourcesForGroupVersion("v1")
+	if err != nil {
+		klog.ErrorS(err, "Failed to discover core API resources, in-place pod resize won't be used")
+		return false
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Name == "pods/"+resizeSubresource {
+			return true
+		}
+	}
+	klog.V(1).InfoS("Pod resize subresource is not supported by the cluster, in-place pod resize won't be used")
+	return false
+}
+
+// GetAction returns ActionResize if the recommended resources can be applied
+// without restarting any container of the pod. Pods whose previous resize is
+// deferred by the node wait for it, as the node may fit it once other pods
+// release their resources. Pods whose previous resize is infeasible, pods whose
+// QoS class would change, pods whose extended resources would change and pods
+// whose containers need a restart to change the resources are evicted.
+func (r *podResizer) GetAction(pod *apiv1.Pod, vpa *vpa_types.VerticalPodAutoscaler) Action {
+	if !r.supported {
+		return ActionEvict
+	}
+	switch pod.Status.Resize {
+	case apiv1.PodResizeStatusInfeasible:
+		return ActionEvict
+	case apiv1.PodResizeStatusProposed, apiv1.PodResizeStatusInProgress, apiv1.PodResizeStatusDeferred:
+		return ActionWait
+	}
+	resized, err := r.getResizedPod(pod, vpa)
+	if err != nil {
+		klog.V(2).InfoS("Cannot get resources for in-place resize", "pod", klog.KObj(pod), "error", err)
+		return ActionEvict
+	}
+	if equality.Semantic.DeepEqual(pod.Spec.Containers, resized.Spec.Containers) &&
+		equality.Semantic.DeepEqual(pod.Spec.InitContainers, resized.Spec.InitContainers) {
+		return ActionWait
+	}
+	if podQOSClass(resized) != podQOSClass(pod) {
+		return ActionEvict
+	}
+	containers := resourcehelpers.PodContainers(pod)
+	for i, container := range resourcehelpers.PodContainers(resized) {
+		if requiresRestart(*containers[i], *container) || extendedResourcesChanged(*containers[i], *container) {
+			return ActionEvict
+		}
+	}
+	return ActionResize
+}
+
+// Resize resizes the pod in place to the recommended resources.
+func (r *podResizer) Resize(pod *apiv1.Pod, vpa *vpa_types.VerticalPodAutoscaler, eventRecorder record.EventRecorder) error {
+	resized, err := r.getResizedPod(pod, vpa)
+	if err != nil {
+		return err
+	}
+	patch, err := resizePatch(resized)
+	if err != nil {
+		return err
+	}
+	_, err = r.client.CoreV1().Pods(pod.Namespace).Patch(context.TODO(), pod.Name, k8stypes.StrategicMergePatchType, patch, metav1.PatchOptions{}, resizeSubresource)
+	if err != nil {
+		klog.ErrorS(err, "Failed to resize pod", "pod", klog.KObj(pod))
+		return err
+	}
+	eventRecorder.Event(pod, apiv1.EventTypeNormal, "ResizedByVPA",
+		"Pod was resized in place by VPA Updater to apply resource recommendation.")
+
+	eventRecorder.Event(vpa, apiv1.EventTypeNormal, "ResizedPod",
+		"VPA Updater resized Pod "+pod.Name+" in place to apply resource recommendation.")
+	return nil
+}
+
+// getResizedPod returns a copy of the pod with the recommended resources set.
+func (r *podResizer) getResizedPod(pod *apiv1.Pod, vpa *vpa_types.VerticalPodAutoscaler) (*apiv1.Pod, error) {
+	containersResources, _, err := r.recommendationProvider.GetContainersResourcesForPod(pod, vpa)
+	if err != nil {
+		return nil, err
+	}
+	resized := pod.DeepCopy()
+	containers := resourcehelpers.PodContainers(resized)
+	if len(containersResources) != len(containers) {
+		return nil, fmt.Errorf("got resources for %d containers, pod has %d", len(containersResources), len(containers))
+	}
+	for i, resources := range containersResources {
+		setResources(&containers[i].Resources, resources)
+	}
+	return resized, nil
+}
+
+func setResources(requirements *apiv1.ResourceRequirements, resources vpa_api_util.ContainerResources) {
+	for resource, quantity := range resources.Requests {
+		if requirements.Requests == nil {
+			requirements.Requests = apiv1.ResourceList{}
+		}
+		requirements.Requests[resource] = quantity
+	}
+	for resource, quantity := range resources.Limits {
+		if requirements.Limits == nil {
+			requirements.Limits = apiv1.ResourceList{}
+		}
+		requirements.Limits[resource] = quantity
+	}
+}
+
+// extendedResourcesChanged returns true if a resource other than cpu and
+// memory of the container changes. Only cpu and memory can be resized in place.
+func extendedResourcesChanged(container, resized apiv1.Container) bool {
+	for _, resources := range []struct{ old, new apiv1.ResourceList }{
+		{container.Resources.Requests, resized.Resources.Requests},
+		{container.Resources.Limits, resized.Resources.Limits},
+	} {
+		for name, quantity := range resources.new {
+			if name == apiv1.ResourceCPU || name == apiv1.ResourceMemory {
+				continue
+			}
+			if !quantity.Equal(resources.old[name]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// requiresRestart returns true if a changed resource of the container has the
+// RestartContainer resize policy. Such containers are not resized in place,
+// because the restart wouldn't be subject to the eviction restrictions.
+func requiresRestart(container, resized apiv1.Container) bool {
+	for _, policy := range container.ResizePolicy {
+		if policy.RestartPolicy != apiv1.RestartContainer {
+			continue
+		}
+		if !container.Resources.Requests[policy.ResourceName].Equal(resized.Resources.Requests[policy.ResourceName]) ||
+			!container.Resources.Limits[policy.ResourceName].Equal(resized.Resources.Limits[policy.ResourceName]) {
+			return true
+		}
+	}
+	return false
+}
+
+type resizePatchContainer struct {
+	Name      string                     `json:"name"`
+	Resources apiv1.ResourceRequirements `json:"resources"`
+}
+
+// resizePatch returns the strategic merge patch setting the resources of the containers and the sidecar containers of the pod.
+func resizePatch(pod *apiv1.Pod) ([]byte, error) {
+	containers := make([]resizePatchContainer, 0, len(pod.Spec.Containers))
+	for _, container := range pod.Spec.Containers {
+		containers = append(containers, resizePatchContainer{Name: container.Name, Resources: container.Resources})
+	}
+	spec := map[string]interface{}{"containers": containers}
+	var sidecars []resizePatchContainer
+	for _, container := range pod.Spec.InitContainers {
+		if resourcehelpers.IsSidecarContainer(container) {
+			sidecars = append(sidecars, resizePatchContainer{Name: container.Name, Resources: container.Resources})
+		}
+	}
+	if len(sidecars) > 0 {
+		spec["initContainers"] = sidecars
+	}
+	return json.Marshal(map[string]interface{}{"spec": spec})
+}
+
+// podQOSClass returns the QoS class of the pod, as determined by the kubelet
+// from the CPU and memory requests and limits of its containers.
+func podQOSClass(pod *apiv1.Pod) apiv1.PodQOSClass {
+	isGuaranteed := true
+	hasResources := false
+	containers := append(append([]apiv1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	for _, container := range containers {
+		for _, resource := range []apiv1.ResourceName{apiv1.ResourceCPU, apiv1.ResourceMemory} {
+			request, hasRequest := container.Resources.Requests[resource]
+			limit, hasLimit := container.Resources.Limits[resource]
+			if (hasRequest && !request.IsZero()) || (hasLimit && !limit.IsZero()) {
+				hasResources = true
+			}
+			if !hasLimit || limit.IsZero() || (hasRequest && !request.Equal(limit)) {
+				isGuaranteed = false
+			}
+		}
+	}
+	switch {
+	case !hasResources:
+		return apiv1.PodQOSBestEffort
+	case isGuaranteed:
+		return apiv1.PodQOSGuaranteed
+	default:
+		return apiv1.PodQOSBurstable
+	}
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inplace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+
+	vpa_types "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/test"
+	vpa_api_util "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/vpa"
+)
+
+type fakeRecommendationProvider struct {
+	resources []vpa_api_util.ContainerResources
+}
+
+func (p *fakeRecommendationProvider) GetContainersResourcesForPod(_ *apiv1.Pod, _ *vpa_types.VerticalPodAutoscaler) ([]vpa_api_util.ContainerResources, vpa_api_util.ContainerToAnnotationsMap, error) {
+	return p.resources, nil, nil
+}
+
+func newFakeClient(resizeSupported bool) *fake.Clientset {
+	client := fake.NewSimpleClientset()
+	resources := []metav1.APIResource{{Name: "pods"}}
+	if resizeSupported {
+		resources = append(resources, metav1.APIResource{Name: "pods/resize"})
+	}
+	client.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{GroupVersion: "v1", APIResources: resources},
+	}
+	return client
+}
+
+func requests(cpu, memory string) vpa_api_util.ContainerResources {
+	return vpa_api_util.ContainerResources{Requests: apiv1.ResourceList{
+		apiv1.ResourceCPU:    resource.MustParse(cpu),
+		apiv1.ResourceMemory: resource.MustParse(memory),
+	}}
+}
+
+func TestGetAction(t *testing.T) {
+	container := test.Container().WithName("container").
+		WithCPURequest(resource.MustParse("1")).WithMemRequest(resource.MustParse("100M")).Get()
+	restartOnMemory := container.DeepCopy()
+	restartOnMemory.ResizePolicy = []apiv1.ContainerResizePolicy{
+		{ResourceName: apiv1.ResourceMemory, RestartPolicy: apiv1.RestartContainer},
+	}
+	guaranteed := test.Container().WithName("container").
+		WithCPURequest(resource.MustParse("1")).WithMemRequest(resource.MustParse("100M")).
+		WithCPULimit(resource.MustParse("1")).WithMemLimit(resource.MustParse("100M")).Get()
+	hugePages := container.DeepCopy()
+	hugePages.Resources.Requests[apiv1.ResourceHugePagesPrefix+"2Mi"] = resource.MustParse("20Mi")
+	hugePagesRecommendation := requests("2", "200M")
+	hugePagesRecommendation.Requests[apiv1.ResourceHugePagesPrefix+"2Mi"] = resource.MustParse("40Mi")
+
+	testCases := []struct {
+		name            string
+		resizeSupported bool
+		container       apiv1.Container
+		resizeStatus    apiv1.PodResizeStatus
+		recommendation  vpa_api_util.ContainerResources
+		expectedAction  Action
+	}{
+		{
+			name:            "resize",
+			resizeSupported: true,
+			container:       container,
+			recommendation:  requests("2", "200M"),
+			expectedAction:  ActionResize,
+		},
+		{
+			name:            "resize not supported",
+			resizeSupported: false,
+			container:       container,
+			recommendation:  requests("2", "200M"),
+			expectedAction:  ActionEvict,
+		},
+		{
+			name:            "no change",
+			resizeSupported: true,
+			container:       container,
+			recommendation:  requests("1", "100M"),
+			expectedAction:  ActionWait,
+		},
+		{
+			name:            "resize in progress",
+			resizeSupported: true,
+			container:       container,
+			resizeStatus:    apiv1.PodResizeStatusInProgress,
+			recommendation:  requests("2", "200M"),
+			expectedAction:  ActionWait,
+		},
+		{
+			name:            "resize deferred",
+			resizeSupported: true,
+			container:       container,
+			resizeStatus:    apiv1.PodResizeStatusDeferred,
+			recommendation:  requests("2", "200M"),
+			expectedAction:  ActionWait,
+		},
+		{
+			name:            "resize infeasible",
+			resizeSupported: true,
+			container:       container,
+			resizeStatus:    apiv1.PodResizeStatusInfeasible,
+			recommendation:  requests("2", "200M"),
+			expectedAction:  ActionEvict,
+		},
+		{
+			name:            "changed resource requires restart",
+			resizeSupported: true,
+			container:       *restartOnMemory,
+			recommendation:  requests("2", "200M"),
+			expectedAction:  ActionEvict,
+		},
+		{
+			name:            "unchanged resource requires restart",
+			resizeSupported: true,
+			container:       *restartOnMemory,
+			recommendation:  requests("2", "100M"),
+			expectedAction:  ActionResize,
+		},
+		{
+			name:            "QoS class changes",
+			resizeSupported: true,
+			container:       guaranteed,
+			recommendation:  requests("2", "200M"),
+			expectedAction:  ActionEvict,
+		},
+		{
+			name:            "extended resource changes",
+			resizeSupported: true,
+			container:       *hugePages,
+			recommendation:  hugePagesRecommendation,
+			expectedAction:  ActionEvict,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := test.Pod().WithName("pod").AddContainer(tc.container).Get()
+			pod.Status.Resize = tc.resizeStatus
+			resizer := NewPodResizer(newFakeClient(tc.resizeSupported),
+				&fakeRecommendationProvider{resources: []vpa_api_util.ContainerResources{tc.recommendation}})
+			assert.Equal(t, tc.expectedAction, resizer.GetAction(pod, test.VerticalPodAutoscaler().WithContainer("container").Get()))
+		})
+	}
+}
+
+func TestResize(t *testing.T) {
+	pod := test.Pod().WithName("pod").AddContainer(test.Container().WithName("container").
+		WithCPURequest(resource.MustParse("1")).WithMemRequest(resource.MustParse("100M")).Get()).Get()
+	client := newFakeClient(true)
+	assert.NoError(t, client.Tracker().Add(pod))
+	resizer := NewPodResizer(client, &fakeRecommendationProvider{resources: []vpa_api_util.ContainerResources{requests("2", "200M")}})
+	eventRecorder := record.NewFakeRecorder(2)
+
+	err := resizer.Resize(pod, test.VerticalPodAutoscaler().WithContainer("container").Get(), eventRecorder)
+	assert.NoError(t, err)
+	assert.Len(t, eventRecorder.Events, 2)
+
+	var patch core.PatchAction
+	for _, action := range client.Actions() {
+		if p, ok := action.(core.PatchAction); ok {
+			patch = p
+		}
+	}
+	if assert.NotNil(t, patch) {
+		assert.Equal(t, "resize", patch.GetSubresource())
+		assert.Equal(t, "pod", patch.GetName())
+		assert.JSONEq(t, `{"spec":{"containers":[{"name":"container","resources":{"requests":{"cpu":"2","memory":"200M"}}}]}}`, string(patch.GetPatch()))
+	}
+}
+
+func TestResizeWithSidecar(t *testing.T) {
+	pod := test.Pod().WithName("pod").AddContainer(test.Container().WithName("container").
+		WithCPURequest(resource.MustParse("1")).WithMemRequest(resource.MustParse("100M")).Get()).
+		AddInitContainer(test.Container().WithName("init").Get()).
+		AddInitContainer(test.Container().WithName("sidecar").WithRestartPolicy(apiv1.ContainerRestartPolicyAlways).
+			WithCPURequest(resource.MustParse("100m")).WithMemRequest(resource.MustParse("10M")).Get()).Get()
+	client := newFakeClient(true)
+	assert.NoError(t, client.Tracker().Add(pod))
+	resizer := NewPodResizer(client, &fakeRecommendationProvider{resources: []vpa_api_util.ContainerResources{
+		requests("1", "100M"), requests("200m", "20M"),
+	}})
+	vpa := test.VerticalPodAutoscaler().WithContainer("container").Get()
+	assert.Equal(t, ActionResize, resizer.GetAction(pod, vpa))
+
+	err := resizer.Resize(pod, vpa, record.NewFakeRecorder(2))
+	assert.NoError(t, err)
+	var patch core.PatchAction
+	for _, action := range client.Actions() {
+		if p, ok := action.(core.PatchAction); ok {
+			patch = p
+		}
+	}
+	if assert.NotNil(t, patch) {
+		assert.JSONEq(t, `{"spec":{"containers":[{"name":"container","resources":{"requests":{"cpu":"1","memory":"100M"}}}],`+
+			`"initContainers":[{"name":"sidecar","resources":{"requests":{"cpu":"200m","memory":"20M"}}}]}}`, string(patch.GetPatch()))
+	}
+}
+
+func TestPodQOSClass(t *testing.T) {
+	bestEffort := test.Pod().WithName("pod").AddContainer(test.Container().WithName("container").Get()).Get()
+	assert.Equal(t, apiv1.PodQOSBestEffort, podQOSClass(bestEffort))
+
+	burstable := test.Pod().WithName("pod").AddContainer(test.Container().WithName("container").
+		WithCPURequest(resource.MustParse("1")).Get()).Get()
+	assert.Equal(t, apiv1.PodQOSBurstable, podQOSClass(burstable))
+
+	guaranteed := test.Pod().WithName("pod").AddContainer(test.Container().WithName("container").
+		WithCPURequest(resource.MustParse("1")).WithCPULimit(resource.MustParse("1")).
+		WithMemRequest(resource.MustParse("1G")).WithMemLimit(resource.MustParse("1G")).Get()).Get()
+	assert.Equal(t, apiv1.PodQOSGuaranteed, podQOSClass(guaranteed))
+}
@@ -44,8 +44,11 @@ import (
 	vpa_lister "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/client/listers/autoscaling.k8s.io/v1"
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/target"
 	controllerfetcher "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/target/controller_fetcher"
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/updater/actuation"
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/updater/eviction"
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/updater/inplace"
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/updater/priority"
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/updater/surge"
 	metrics_updater "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/metrics/updater"
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/status"
 	vpa_api_util "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/vpa"
@@ -62,10 +65,14 @@ type updater struct {
 	podLister                    v1lister.PodLister
 	eventRecorder                record.EventRecorder
 	evictionFactory              eviction.PodsEvictionRestrictionFactory
+	podResizer                   inplace.PodResizer
+	workloadSurger               surge.WorkloadSurger
+	actuationStrategies          actuation.Strategies
 	recommendationProcessor      vpa_api_util.RecommendationProcessor
 	evictionAdmission            priority.PodEvictionAdmission
 	priorityProcessor            priority.PriorityProcessor
 	evictionRateLimiter          *rate.Limiter
+	evictionToleranceFraction    float64
 	selectorFetcher              target.VpaTargetSelectorFetcher
 	useAdmissionControllerStatus bool
 	statusValidator              status.Validator
@@ -81,6 +88,7 @@ func NewUpdater(
 	evictionRateLimit float64,
 	evictionRateBurst int,
 	evictionToleranceFraction float64,
+	pauseEvictionsOnDegradedWorkload bool,
 	useAdmissionControllerStatus bool,
 	statusNamespace string,
 	recommendationProcessor vpa_api_util.RecommendationProcessor,
@@ -90,9 +98,12 @@ func NewUpdater(
 	priorityProcessor priority.PriorityProcessor,
 	namespace string,
 	ignoredNamespaces []string,
+	podResizer inplace.PodResizer,
+	workloadSurger surge.WorkloadSurger,
+	actuationStrategies actuation.Strategies,
 ) (Updater, error) {
 	evictionRateLimiter := getRateLimiter(evictionRateLimit, evictionRateBurst)
-	factory, err := eviction.NewPodsEvictionRestrictionFactory(kubeClient, minReplicasForEvicition, evictionToleranceFraction)
+	factory, err := eviction.NewPodsEvictionRestrictionFactory(kubeClient, minReplicasForEvicition, evictionToleranceFraction, pauseEvictionsOnDegradedWorkload)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to create eviction restriction factory: %v", err)
 	}
@@ -101,8 +112,12 @@ func NewUpdater(
 		podLister:                    newPodLister(kubeClient, namespace),
 		eventRecorder:                newEventRecorder(kubeClient),
 		evictionFactory:              factory,
+		podResizer:                   podResizer,
+		workloadSurger:               workloadSurger,
+		actuationStrategies:          actuationStrategies,
 		recommendationProcessor:      recommendationProcessor,
 		evictionRateLimiter:          evictionRateLimiter,
+		evictionToleranceFraction:    evictionToleranceFraction,
 		evictionAdmission:            evictionAdmission,
 		priorityProcessor:            priorityProcessor,
 		selectorFetcher:              selectorFetcher,
@@ -149,8 +164,10 @@ func (u *updater) RunOnce(ctx context.Context) {
 			continue
 		}
 		if vpa_api_util.GetUpdateMode(vpa) != vpa_types.UpdateModeRecreate &&
-			vpa_api_util.GetUpdateMode(vpa) != vpa_types.UpdateModeAuto {
-			klog.V(3).InfoS("Skipping VPA object because its mode is not \"Recreate\" or \"Auto\"", "vpa", klog.KObj(vpa))
+			vpa_api_util.GetUpdateMode(vpa) != vpa_types.UpdateModeAuto &&
+			vpa_api_util.GetUpdateMode(vpa) != vpa_types.UpdateModeInPlaceOrRecreate &&
+			vpa_api_util.GetUpdateMode(vpa) != vpa_types.UpdateModeSurgeRecreate {
+			klog.V(3).InfoS("Skipping VPA object because its mode is not \"Recreate\", \"Auto\", \"InPlaceOrRecreate\" or \"SurgeRecreate\"", "vpa", klog.KObj(vpa))
 			continue
 		}
 		selector, err := u.selectorFetcher.Fetch(ctx, vpa)
@@ -170,6 +187,9 @@ func (u *updater) RunOnce(ctx context.Context) {
 		if u.evictionAdmission != nil {
 			u.evictionAdmission.CleanUp()
 		}
+		if u.workloadSurger != nil {
+			u.workloadSurger.ScaleDown(nil, u.eventRecorder)
+		}
 		return
 	}
 
@@ -207,19 +227,71 @@ func (u *updater) RunOnce(ctx context.Context) {
 	defer vpasWithEvictablePodsCounter.Observe()
 	defer vpasWithEvictedPodsCounter.Observe()
 
+	// Pods of VPAs in surge recreate mode waiting for an update, whose
+	// workloads keep their surge replica.
+	var surgePodsForUpdate []*apiv1.Pod
+
 	// NOTE: this loop assumes that controlledPods are filtered
-	// to contain only Pods controlled by a VPA in auto or recreate mode
+	// to contain only Pods controlled by a VPA in auto, recreate, in-place or recreate or surge recreate mode
 	for vpa, livePods := range controlledPods {
 		vpaSize := len(livePods)
 		controlledPodsCounter.Add(vpaSize, vpaSize)
 		evictionLimiter := u.evictionFactory.NewPodsEvictionRestriction(livePods, vpa)
-		podsForUpdate := u.getPodsUpdateOrder(filterNonEvictablePods(livePods, evictionLimiter), vpa)
+		inPlace := vpa_api_util.GetUpdateMode(vpa) == vpa_types.UpdateModeInPlaceOrRecreate && u.podResizer != nil
+		withSurge := vpa_api_util.GetUpdateMode(vpa) == vpa_types.UpdateModeSurgeRecreate && u.workloadSurger != nil
+		var podsForUpdate []*apiv1.Pod
+		if inPlace || withSurge {
+			// Pods resized in place aren't subject to the eviction restrictions.
+			// Pods of workloads which are scaled up before the eviction become
+			// evictable once the surge replica is running.
+			podsForUpdate = u.getPodsUpdateOrder(livePods, vpa)
+		} else {
+			podsForUpdate = u.getPodsUpdateOrder(u.filterNonEvictablePods(livePods, evictionLimiter), vpa)
+		}
 		evictablePodsCounter.Add(vpaSize, len(podsForUpdate))
+		if withSurge {
+			surgePodsForUpdate = append(surgePodsForUpdate, podsForUpdate...)
+		}
+		if u.actuationStrategies != nil {
+			podsForUpdate = u.actuationStrategies.SelectPods(podsForUpdate)
+		}
 
 		withEvictable := false
 		withEvicted := false
+		resizeBudget := inPlaceResizeBudget(livePods, u.evictionToleranceFraction)
 		for _, pod := range podsForUpdate {
 			withEvictable = true
+			if u.actuationStrategies != nil && !u.actuationStrategies.AllowsEviction(pod) {
+				// Pods are resized in place only if the VPA allows it, with
+				// the same rate limit and budget as other in-place resizes.
+				if !inPlace {
+					klog.V(3).InfoS("Not evicting pod whose workload allows only in-place resizes, as its VPA isn't in InPlaceOrRecreate mode", "pod", klog.KObj(pod))
+					continue
+				}
+				resized, err := u.resizeInPlace(ctx, pod, vpa, vpaSize, &resizeBudget)
+				if err != nil {
+					klog.V(0).InfoS("Eviction rate limiter wait failed", "error", err)
+					return
+				}
+				if !resized {
+					klog.V(3).InfoS("Not evicting pod whose workload allows only in-place resizes", "pod", klog.KObj(pod))
+				}
+				continue
+			}
+			if inPlace {
+				resized, err := u.resizeInPlace(ctx, pod, vpa, vpaSize, &resizeBudget)
+				if err != nil {
+					klog.V(0).InfoS("Eviction rate limiter wait failed", "error", err)
+					return
+				}
+				if resized {
+					continue
+				}
+			}
+			if withSurge && u.waitForSurge(pod, vpa) {
+				// Pods are updated one at a time, after the surge replica is ready.
+				break
+			}
 			if !evictionLimiter.CanEvict(pod) {
 				continue
 			}
@@ -235,6 +307,10 @@ func (u *updater) RunOnce(ctx context.Context) {
 			} else {
 				withEvicted = true
 				metrics_updater.AddEvictedPod(vpaSize)
+				if withSurge {
+					// The next pod is evicted once the replacement of this one is ready.
+					break
+				}
 			}
 		}
 
@@ -246,6 +322,75 @@ func (u *updater) RunOnce(ctx context.Context) {
 		}
 	}
 	timer.ObserveStep("EvictPods")
+
+	if u.workloadSurger != nil {
+		// Workloads are scaled back whatever the current mode of their VPA.
+		u.workloadSurger.ScaleDown(surgePodsForUpdate, u.eventRecorder)
+	}
+	timer.ObserveStep("ScaleDownWorkloads")
+}
+
+// resizeInPlace resizes the pod in place if possible. Returns true if the pod
+// was resized or is waiting for a resize, false if it should be evicted.
+// Resizes are paced by the eviction rate limiter, and at most resizeBudget pods
+// are resized, pods over the budget wait for the next loop. Returns an error if
+// waiting for the rate limiter failed.
+func (u *updater) resizeInPlace(ctx context.Context, pod *apiv1.Pod, vpa *vpa_types.VerticalPodAutoscaler, vpaSize int, resizeBudget *int) (bool, error) {
+	switch u.podResizer.GetAction(pod, vpa) {
+	case inplace.ActionWait:
+		klog.V(4).InfoS("Waiting for in-place resize of pod", "pod", klog.KObj(pod))
+		return true, nil
+	case inplace.ActionResize:
+		if *resizeBudget <= 0 {
+			klog.V(4).InfoS("Too many in-place resizes in progress, waiting to resize pod", "pod", klog.KObj(pod))
+			return true, nil
+		}
+		if err := u.evictionRateLimiter.Wait(ctx); err != nil {
+			return false, err
+		}
+		klog.V(2).InfoS("Resizing pod in place", "pod", klog.KObj(pod))
+		if err := u.podResizer.Resize(pod, vpa, u.eventRecorder); err != nil {
+			klog.V(0).InfoS("In-place resize failed, falling back to eviction", "error", err, "pod", klog.KObj(pod))
+			return false, nil
+		}
+		*resizeBudget--
+		metrics_updater.AddResizedPod(vpaSize)
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// inPlaceResizeBudget returns how many more pods can be resized in place, so
+// that at most the eviction tolerance fraction of the pods, or one pod, are
+// being resized at a time. Pods whose previous resize didn't complete yet count
+// against the budget.
+func inPlaceResizeBudget(pods []*apiv1.Pod, evictionToleranceFraction float64) int {
+	budget := max(int(float64(len(pods))*evictionToleranceFraction), 1)
+	for _, pod := range pods {
+		switch pod.Status.Resize {
+		case apiv1.PodResizeStatusProposed, apiv1.PodResizeStatusInProgress, apiv1.PodResizeStatusDeferred:
+			budget--
+		}
+	}
+	return budget
+}
+
+// waitForSurge scales up the workload of the pod if needed. Returns true if the
+// pod should wait for the surge replica, false if it should be evicted.
+func (u *updater) waitForSurge(pod *apiv1.Pod, vpa *vpa_types.VerticalPodAutoscaler) bool {
+	switch u.workloadSurger.GetAction(pod) {
+	case surge.ActionSurge:
+		if err := u.workloadSurger.Surge(pod, vpa, u.eventRecorder); err != nil {
+			klog.V(0).InfoS("Scaling up workload failed", "error", err, "pod", klog.KObj(pod))
+		}
+		return true
+	case surge.ActionWait:
+		klog.V(4).InfoS("Waiting for surge replica before evicting pod", "pod", klog.KObj(pod))
+		return true
+	default:
+		return false
+	}
 }
 
 func getRateLimiter(evictionRateLimit float64, evictionRateLimitBurst int) *rate.Limiter {
@@ -276,10 +421,12 @@ func (u *updater) getPodsUpdateOrder(pods []*apiv1.Pod, vpa *vpa_types.VerticalP
 	return priorityCalculator.GetSortedPods(u.evictionAdmission)
 }
 
-func filterNonEvictablePods(pods []*apiv1.Pod, evictionRestriction eviction.PodsEvictionRestriction) []*apiv1.Pod {
+// filterNonEvictablePods drops the pods which can't be evicted. Pods which
+// can only be resized in place aren't subject to the eviction restrictions.
+func (u *updater) filterNonEvictablePods(pods []*apiv1.Pod, evictionRestriction eviction.PodsEvictionRestriction) []*apiv1.Pod {
 	result := make([]*apiv1.Pod, 0)
 	for _, pod := range pods {
-		if evictionRestriction.CanEvict(pod) {
+		if evictionRestriction.CanEvict(pod) || (u.actuationStrategies != nil && !u.actuationStrategies.AllowsEviction(pod)) {
 			result = append(result, pod)
 		}
 	}
@@ -18,6 +18,7 @@ package logic
 
 import (
 	"context"
+	"errors"
 	"strconv"
 	"testing"
 	"time"
@@ -33,12 +34,16 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
 
 	vpa_types "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
 	controllerfetcher "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/target/controller_fetcher"
 	target_mock "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/target/mock"
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/updater/actuation"
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/updater/eviction"
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/updater/inplace"
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/updater/priority"
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/updater/surge"
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/status"
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/test"
 )
@@ -83,6 +88,9 @@ func TestRunOnce_Mode(t *testing.T) {
 				newFakeValidator(true),
 				tc.expectFetchCalls,
 				tc.expectedEvictionCount,
+				nil,
+				nil,
+				nil,
 			)
 		})
 	}
@@ -116,6 +124,9 @@ func TestRunOnce_Status(t *testing.T) {
 				tc.statusValidator,
 				tc.expectFetchCalls,
 				tc.expectedEvictionCount,
+				nil,
+				nil,
+				nil,
 			)
 		})
 	}
@@ -127,6 +138,9 @@ func testRunOnceBase(
 	statusValidator status.Validator,
 	expectFetchCalls bool,
 	expectedEvictionCount int,
+	podResizer inplace.PodResizer,
+	workloadSurger surge.WorkloadSurger,
+	actuationStrategies actuation.Strategies,
 ) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -190,6 +204,7 @@ func testRunOnceBase(
 		podLister:                    podLister,
 		evictionFactory:              factory,
 		evictionRateLimiter:          rate.NewLimiter(rate.Inf, 0),
+		evictionToleranceFraction:    0.5,
 		evictionAdmission:            priority.NewDefaultPodEvictionAdmission(),
 		recommendationProcessor:      &test.FakeRecommendationProcessor{},
 		selectorFetcher:              mockSelectorFetcher,
@@ -197,6 +212,9 @@ func testRunOnceBase(
 		useAdmissionControllerStatus: true,
 		statusValidator:              statusValidator,
 		priorityProcessor:            priority.NewProcessor(),
+		podResizer:                   podResizer,
+		workloadSurger:               workloadSurger,
+		actuationStrategies:          actuationStrategies,
 	}
 
 	if expectFetchCalls {
@@ -206,6 +224,253 @@ func testRunOnceBase(
 	eviction.AssertNumberOfCalls(t, "Evict", expectedEvictionCount)
 }
 
+func TestRunOnce_InPlaceOrRecreate(t *testing.T) {
+	tests := []struct {
+		name                  string
+		updateMode            vpa_types.UpdateMode
+		action                inplace.Action
+		resizeErr             error
+		expectedResizeCount   int
+		expectedEvictionCount int
+	}{
+		{
+			name:                  "resizes up to the eviction tolerance of pods in place",
+			updateMode:            vpa_types.UpdateModeInPlaceOrRecreate,
+			action:                inplace.ActionResize,
+			expectedResizeCount:   2,
+			expectedEvictionCount: 0,
+		},
+		{
+			name:                  "waits for resize in progress",
+			updateMode:            vpa_types.UpdateModeInPlaceOrRecreate,
+			action:                inplace.ActionWait,
+			expectedResizeCount:   0,
+			expectedEvictionCount: 0,
+		},
+		{
+			name:                  "evicts pods which cannot be resized",
+			updateMode:            vpa_types.UpdateModeInPlaceOrRecreate,
+			action:                inplace.ActionEvict,
+			expectedResizeCount:   0,
+			expectedEvictionCount: 5,
+		},
+		{
+			name:                  "evicts pods when resize fails",
+			updateMode:            vpa_types.UpdateModeInPlaceOrRecreate,
+			action:                inplace.ActionResize,
+			resizeErr:             errors.New("resize failed"),
+			expectedResizeCount:   5,
+			expectedEvictionCount: 5,
+		},
+		{
+			name:                  "doesn't resize in Auto mode",
+			updateMode:            vpa_types.UpdateModeAuto,
+			action:                inplace.ActionResize,
+			expectedResizeCount:   0,
+			expectedEvictionCount: 5,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resizer := &fakePodResizer{action: tc.action, err: tc.resizeErr}
+			testRunOnceBase(
+				t,
+				tc.updateMode,
+				newFakeValidator(true),
+				true,
+				tc.expectedEvictionCount,
+				resizer,
+				nil,
+				nil,
+			)
+			assert.Equal(t, tc.expectedResizeCount, resizer.resizeCount)
+		})
+	}
+}
+
+func TestInPlaceResizeBudget(t *testing.T) {
+	pod := func(status apiv1.PodResizeStatus) *apiv1.Pod {
+		p := test.Pod().WithName("pod").Get()
+		p.Status.Resize = status
+		return p
+	}
+	tests := []struct {
+		name                      string
+		pods                      []*apiv1.Pod
+		evictionToleranceFraction float64
+		expectedBudget            int
+	}{
+		{
+			name:                      "eviction tolerance fraction of pods",
+			pods:                      []*apiv1.Pod{pod(""), pod(""), pod(""), pod("")},
+			evictionToleranceFraction: 0.5,
+			expectedBudget:            2,
+		},
+		{
+			name:                      "at least one pod",
+			pods:                      []*apiv1.Pod{pod(""), pod("")},
+			evictionToleranceFraction: 0.1,
+			expectedBudget:            1,
+		},
+		{
+			name:                      "resizes in progress count against the budget",
+			pods:                      []*apiv1.Pod{pod(apiv1.PodResizeStatusInProgress), pod(apiv1.PodResizeStatusDeferred), pod(""), pod("")},
+			evictionToleranceFraction: 0.75,
+			expectedBudget:            1,
+		},
+		{
+			name:                      "no budget left",
+			pods:                      []*apiv1.Pod{pod(apiv1.PodResizeStatusProposed), pod("")},
+			evictionToleranceFraction: 0.5,
+			expectedBudget:            0,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedBudget, inPlaceResizeBudget(tc.pods, tc.evictionToleranceFraction))
+		})
+	}
+}
+
+func TestRunOnce_SurgeRecreate(t *testing.T) {
+	tests := []struct {
+		name                  string
+		updateMode            vpa_types.UpdateMode
+		action                surge.Action
+		surgeErr              error
+		expectedSurgeCount    int
+		expectedEvictionCount int
+	}{
+		{
+			name:                  "scales up workload before eviction",
+			updateMode:            vpa_types.UpdateModeSurgeRecreate,
+			action:                surge.ActionSurge,
+			expectedSurgeCount:    1,
+			expectedEvictionCount: 0,
+		},
+		{
+			name:                  "doesn't evict when scaling up fails",
+			updateMode:            vpa_types.UpdateModeSurgeRecreate,
+			action:                surge.ActionSurge,
+			surgeErr:              errors.New("scale up failed"),
+			expectedSurgeCount:    1,
+			expectedEvictionCount: 0,
+		},
+		{
+			name:                  "waits for surge replica",
+			updateMode:            vpa_types.UpdateModeSurgeRecreate,
+			action:                surge.ActionWait,
+			expectedSurgeCount:    0,
+			expectedEvictionCount: 0,
+		},
+		{
+			name:                  "evicts one pod when surge replica is ready",
+			updateMode:            vpa_types.UpdateModeSurgeRecreate,
+			action:                surge.ActionEvict,
+			expectedSurgeCount:    0,
+			expectedEvictionCount: 1,
+		},
+		{
+			name:                  "evicts one pod of workloads which can't be scaled up",
+			updateMode:            vpa_types.UpdateModeSurgeRecreate,
+			action:                surge.ActionRecreate,
+			expectedSurgeCount:    0,
+			expectedEvictionCount: 1,
+		},
+		{
+			name:                  "doesn't scale up in Auto mode",
+			updateMode:            vpa_types.UpdateModeAuto,
+			action:                surge.ActionSurge,
+			expectedSurgeCount:    0,
+			expectedEvictionCount: 5,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			surger := &fakeWorkloadSurger{action: tc.action, err: tc.surgeErr}
+			testRunOnceBase(
+				t,
+				tc.updateMode,
+				newFakeValidator(true),
+				true,
+				tc.expectedEvictionCount,
+				nil,
+				surger,
+				nil,
+			)
+			assert.Equal(t, tc.expectedSurgeCount, surger.surgeCount)
+			// Workloads are scaled back whatever the mode of the VPA, keeping
+			// the surge replica only for the pods waiting for an update.
+			assert.Equal(t, 1, surger.scaleDownCount)
+			if tc.updateMode == vpa_types.UpdateModeSurgeRecreate {
+				assert.Len(t, surger.scaleDownPodsForUpdate, 5)
+			} else {
+				assert.Empty(t, surger.scaleDownPodsForUpdate)
+			}
+		})
+	}
+}
+
+func TestRunOnce_ActuationStrategies(t *testing.T) {
+	tests := []struct {
+		name                  string
+		updateMode            vpa_types.UpdateMode
+		strategies            *fakeStrategies
+		action                inplace.Action
+		expectedResizeCount   int
+		expectedEvictionCount int
+	}{
+		{
+			name:                  "evicts selected pods",
+			updateMode:            vpa_types.UpdateModeAuto,
+			strategies:            &fakeStrategies{selectedPods: 1, allowsEviction: true},
+			action:                inplace.ActionResize,
+			expectedResizeCount:   0,
+			expectedEvictionCount: 1,
+		},
+		{
+			name:                  "doesn't update pods in Auto mode if eviction isn't allowed",
+			updateMode:            vpa_types.UpdateModeAuto,
+			strategies:            &fakeStrategies{selectedPods: 5, allowsEviction: false},
+			action:                inplace.ActionResize,
+			expectedResizeCount:   0,
+			expectedEvictionCount: 0,
+		},
+		{
+			name:                  "resizes pods in place within the budget in InPlaceOrRecreate mode if eviction isn't allowed",
+			updateMode:            vpa_types.UpdateModeInPlaceOrRecreate,
+			strategies:            &fakeStrategies{selectedPods: 5, allowsEviction: false},
+			action:                inplace.ActionResize,
+			expectedResizeCount:   2,
+			expectedEvictionCount: 0,
+		},
+		{
+			name:                  "doesn't evict pods which can't be resized in place if eviction isn't allowed",
+			updateMode:            vpa_types.UpdateModeInPlaceOrRecreate,
+			strategies:            &fakeStrategies{selectedPods: 5, allowsEviction: false},
+			action:                inplace.ActionEvict,
+			expectedResizeCount:   0,
+			expectedEvictionCount: 0,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resizer := &fakePodResizer{action: tc.action}
+			testRunOnceBase(
+				t,
+				tc.updateMode,
+				newFakeValidator(true),
+				true,
+				tc.expectedEvictionCount,
+				resizer,
+				nil,
+				tc.strategies,
+			)
+			assert.Equal(t, tc.expectedResizeCount, resizer.resizeCount)
+		})
+	}
+}
+
 func TestRunOnceNotingToProcess(t *testing.T) {
 	eviction := &test.PodsEvictionRestrictionMock{}
 	factory := &fakeEvictFactory{eviction}
@@ -412,3 +677,54 @@ func TestNewEventRecorder(t *testing.T) {
 		})
 	}
 }
+
+type fakePodResizer struct {
+	action      inplace.Action
+	err         error
+	resizeCount int
+}
+
+func (f *fakePodResizer) GetAction(_ *apiv1.Pod, _ *vpa_types.VerticalPodAutoscaler) inplace.Action {
+	return f.action
+}
+
+func (f *fakePodResizer) Resize(_ *apiv1.Pod, _ *vpa_types.VerticalPodAutoscaler, _ record.EventRecorder) error {
+	f.resizeCount++
+	return f.err
+}
+
+type fakeWorkloadSurger struct {
+	action         surge.Action
+	err            error
+	surgeCount     int
+	scaleDownCount int
+	// scaleDownPodsForUpdate are the pods passed to the last ScaleDown.
+	scaleDownPodsForUpdate []*apiv1.Pod
+}
+
+func (f *fakeWorkloadSurger) GetAction(_ *apiv1.Pod) surge.Action {
+	return f.action
+}
+
+func (f *fakeWorkloadSurger) Surge(_ *apiv1.Pod, _ *vpa_types.VerticalPodAutoscaler, _ record.EventRecorder) error {
+	f.surgeCount++
+	return f.err
+}
+
+func (f *fakeWorkloadSurger) ScaleDown(podsForUpdate []*apiv1.Pod, _ record.EventRecorder) {
+	f.scaleDownCount++
+	f.scaleDownPodsForUpdate = podsForUpdate
+}
+
+type fakeStrategies struct {
+	selectedPods   int
+	allowsEviction bool
+}
+
+func (f *fakeStrategies) SelectPods(podsForUpdate []*apiv1.Pod) []*apiv1.Pod {
+	return podsForUpdate[:min(f.selectedPods, len(podsForUpdate))]
+}
+
+func (f *fakeStrategies) AllowsEviction(_ *apiv1.Pod) bool {
+	return f.allowsEviction
+}
@@ -36,11 +36,15 @@ import (
 	"k8s.io/klog/v2"
 
 	"k8s.io/autoscaler/vertical-pod-autoscaler/common"
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/admission-controller/resource/pod/recommendation"
 	vpa_clientset "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/client/clientset/versioned"
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/target"
 	controllerfetcher "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/target/controller_fetcher"
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/updater/actuation"
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/updater/inplace"
 	updater "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/updater/logic"
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/updater/priority"
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/updater/surge"
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/limitrange"
 	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/metrics"
 	metrics_updater "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/metrics/updater"
@@ -59,6 +63,9 @@ var (
 	evictionToleranceFraction = flag.Float64("eviction-tolerance", 0.5,
 		`Fraction of replica count that can be evicted for update, if more than one pod can be evicted.`)
 
+	pauseEvictionsOnDegradedWorkload = flag.Bool("pause-evictions-on-degraded-workload", false,
+		`If true, updater won't evict ready pods while their workload has fewer available replicas than desired, or while a PodDisruptionBudget matching them has fewer healthy pods than desired.`)
+
 	evictionRateLimit = flag.Float64("eviction-rate-limit", -1,
 		`Number of pods that can be evicted per seconds. A rate limit set to 0 or -1 will disable
 		the rate limiter.`)
@@ -70,6 +77,12 @@ var (
 	useAdmissionControllerStatus = flag.Bool("use-admission-controller-status", true,
 		"If true, updater will only evict pods when admission controller status is valid.")
 
+	enablePolicies = flag.Bool("enable-recommendation-policies", false,
+		"If set to true, VerticalPodAutoscalerPolicy objects will be applied to the recommended resources, as by the admission controller. Set it to the same value as for the admission controller.")
+
+	surgeTimeout = flag.Duration("surge-timeout", 10*time.Minute,
+		"How long a workload scaled up in the SurgeRecreate mode keeps its surge replica at most, e.g. if the surge replica can't be scheduled, before it's scaled back.")
+
 	namespace = os.Getenv("NAMESPACE")
 )
 
@@ -185,6 +198,32 @@ func run(healthCheck *metrics.HealthCheck, commonFlag *common.CommonFlags) {
 
 	ignoredNamespaces := strings.Split(commonFlag.IgnoredVpaObjectNamespaces, ",")
 
+	recommendationProcessor := vpa_api_util.NewCappingRecommendationProcessor(limitRangeCalculator)
+	var policies *vpa_api_util.RecommendationPolicies
+	if *enablePolicies {
+		policies, err = vpa_api_util.NewRecommendationPolicies(vpa_api_util.NewVpaPoliciesLister(vpaClient, make(chan struct{})))
+		if err != nil {
+			klog.ErrorS(err, "Failed to create recommendation policies")
+			os.Exit(255)
+		}
+		recommendationProcessor = vpa_api_util.NewPolicyRecommendationProcessor(recommendationProcessor, policies)
+	}
+	recommendationProvider := recommendation.NewProvider(limitRangeCalculator, recommendationProcessor)
+	if policies != nil {
+		recommendationProvider = recommendation.NewPolicyProvider(recommendationProvider, policies)
+	}
+	podResizer := inplace.NewPodResizer(kubeClient, recommendationProvider)
+	workloadSurger, err := surge.NewWorkloadSurger(kubeClient, factory, *surgeTimeout)
+	if err != nil {
+		klog.ErrorS(err, "Failed to create workload surger")
+		os.Exit(255)
+	}
+	actuationStrategies, err := actuation.NewStrategies(factory)
+	if err != nil {
+		klog.ErrorS(err, "Failed to create actuation strategies")
+		os.Exit(255)
+	}
+
 	// TODO: use SharedInformerFactory in updater
 	updater, err := updater.NewUpdater(
 		kubeClient,
@@ -193,15 +232,19 @@ func run(healthCheck *metrics.HealthCheck, commonFlag *common.CommonFlags) {
 		*evictionRateLimit,
 		*evictionRateBurst,
 		*evictionToleranceFraction,
+		*pauseEvictionsOnDegradedWorkload,
 		*useAdmissionControllerStatus,
 		admissionControllerStatusNamespace,
-		vpa_api_util.NewCappingRecommendationProcessor(limitRangeCalculator),
+		recommendationProcessor,
 		priority.NewScalingDirectionPodEvictionAdmission(),
 		targetSelectorFetcher,
 		controllerFetcher,
 		priority.NewProcessor(),
 		commonFlag.VpaObjectNamespace,
 		ignoredNamespaces,
+		podResizer,
+		workloadSurger,
+		actuationStrategies,
 	)
 	if err != nil {
 		klog.ErrorS(err, "Failed to create updater")
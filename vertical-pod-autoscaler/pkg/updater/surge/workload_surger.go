@@ -0,0 +1,403 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package surge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	kube_client "k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	autoscalinglisters "k8s.io/client-go/listers/autoscaling/v2"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	vpa_types "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
+)
+
+const (
+	// SurgeReplicasAnnotation is set on the workloads scaled up by the updater
+	// to the number of replicas they were scaled up to. It's removed when the
+	// workload is scaled back.
+	SurgeReplicasAnnotation = "vpa-updater.kubernetes.io/surge-replicas"
+	// SurgeTimeAnnotation is set on the workloads scaled up by the updater
+	// to the time they were scaled up at, in RFC 3339 format. It's removed
+	// when the workload is scaled back.
+	SurgeTimeAnnotation = "vpa-updater.kubernetes.io/surge-time"
+
+	deploymentKind            = "Deployment"
+	replicaSetKind            = "ReplicaSet"
+	replicationControllerKind = "ReplicationController"
+)
+
+// Action is the step to take before a pod is evicted.
+type Action string
+
+const (
+	// ActionSurge scales up the workload of the pod by one replica.
+	ActionSurge Action = "Surge"
+	// ActionWait waits for the surge replica of the workload to be ready.
+	ActionWait Action = "Wait"
+	// ActionEvict evicts the pod, as the surge replica of its workload is ready.
+	ActionEvict Action = "Evict"
+	// ActionRecreate evicts the pod without a surge replica, as its workload
+	// can't be scaled up, e.g. because it's a StatefulSet or a DaemonSet, or
+	// because its replicas are managed by a HorizontalPodAutoscaler.
+	ActionRecreate Action = "Recreate"
+)
+
+// WorkloadSurger scales up the workloads of pods by one replica before the
+// pods are evicted, so that the workloads don't lose capacity while the pods
+// are recreated.
+type WorkloadSurger interface {
+	// GetAction returns the step to take before the pod is evicted.
+	GetAction(pod *apiv1.Pod) Action
+	// Surge scales up the workload of the pod by one replica.
+	// Returns error if the client returned error.
+	Surge(pod *apiv1.Pod, vpa *vpa_types.VerticalPodAutoscaler, eventRecorder record.EventRecorder) error
+	// ScaleDown scales back all the workloads which were scaled up, whatever
+	// the mode of their VPA, once none of the podsForUpdate belongs to them
+	// and all their replicas are ready, or once they were scaled up for longer
+	// than the surge timeout.
+	ScaleDown(podsForUpdate []*apiv1.Pod, eventRecorder record.EventRecorder)
+}
+
+// workload is a scalable controller of pods.
+type workload struct {
+	kind      string
+	namespace string
+	name      string
+	// replicas is the desired number of replicas.
+	replicas int32
+	// ready is true if all the desired replicas are ready.
+	ready bool
+	// surgeReplicas is the value of SurgeReplicasAnnotation, 0 if the workload isn't scaled up.
+	surgeReplicas int32
+	// surgeTime is the value of SurgeTimeAnnotation, zero if it's missing or
+	// invalid, in which case the surge is considered timed out.
+	surgeTime       time.Time
+	resourceVersion string
+	// object is the workload, on which the events of its scale-back are recorded.
+	object runtime.Object
+}
+
+type workloadSurger struct {
+	client           kube_client.Interface
+	deploymentLister appslisters.DeploymentLister
+	replicaSetLister appslisters.ReplicaSetLister
+	rcLister         corelisters.ReplicationControllerLister
+	hpaLister        autoscalinglisters.HorizontalPodAutoscalerLister
+	surgeTimeout     time.Duration
+	now              func() time.Time
+}
+
+// NewWorkloadSurger creates a WorkloadSurger scaling up Deployments, ReplicaSets
+// and ReplicationControllers which aren't targeted by a HorizontalPodAutoscaler,
+// and scaling them back after at most surgeTimeout. It uses the informers of
+// the factory, which have to be running, e.g. started by
+// target.NewVpaTargetSelectorFetcher, and starts the HorizontalPodAutoscaler
+// informer.
+func NewWorkloadSurger(client kube_client.Interface, factory informers.SharedInformerFactory, surgeTimeout time.Duration) (WorkloadSurger, error) {
+	deploymentInformer := factory.Apps().V1().Deployments()
+	replicaSetInformer := factory.Apps().V1().ReplicaSets()
+	rcInformer := factory.Core().V1().ReplicationControllers()
+	hpaInformer := factory.Autoscaling().V2().HorizontalPodAutoscalers()
+	stopCh := make(chan struct{})
+	go hpaInformer.Informer().Run(stopCh)
+	if !cache.WaitForCacheSync(stopCh, deploymentInformer.Informer().HasSynced, replicaSetInformer.Informer().HasSynced, rcInformer.Informer().HasSynced, hpaInformer.Informer().HasSynced) {
+		return nil, fmt.Errorf("informers did not sync")
+	}
+	return &workloadSurger{
+		client:           client,
+		deploymentLister: deploymentInformer.Lister(),
+		replicaSetLister: replicaSetInformer.Lister(),
+		rcLister:         rcInformer.Lister(),
+		hpaLister:        hpaInformer.Lister(),
+		surgeTimeout:     surgeTimeout,
+		now:              time.Now,
+	}, nil
+}
+
+// GetAction returns ActionEvict if the workload of the pod was scaled up and
+// all its replicas are ready, and ActionSurge if it wasn't scaled up yet.
+func (s *workloadSurger) GetAction(pod *apiv1.Pod) Action {
+	w, err := s.getWorkload(pod)
+	if err != nil {
+		klog.V(2).InfoS("Cannot get workload of pod, waiting", "pod", klog.KObj(pod), "error", err)
+		return ActionWait
+	}
+	if w == nil {
+		return ActionRecreate
+	}
+	if w.surgeReplicas == 0 {
+		if !w.ready {
+			klog.V(4).InfoS("Waiting for workload to be ready before scaling it up", "kind", w.kind, "workload", klog.KRef(w.namespace, w.name))
+			return ActionWait
+		}
+		return ActionSurge
+	}
+	if !w.ready {
+		klog.V(4).InfoS("Waiting for surge replica to be ready", "kind", w.kind, "workload", klog.KRef(w.namespace, w.name))
+		return ActionWait
+	}
+	return ActionEvict
+}
+
+// Surge scales up the workload of the pod by one replica and records the
+// number of replicas in SurgeReplicasAnnotation and the time in
+// SurgeTimeAnnotation.
+func (s *workloadSurger) Surge(pod *apiv1.Pod, vpa *vpa_types.VerticalPodAutoscaler, eventRecorder record.EventRecorder) error {
+	w, err := s.getWorkload(pod)
+	if err != nil {
+		return err
+	}
+	if w == nil {
+		return fmt.Errorf("pod %s/%s has no workload which can be scaled up", pod.Namespace, pod.Name)
+	}
+	replicas := w.replicas + 1
+	if err := s.patch(w, replicas, map[string]interface{}{
+		SurgeReplicasAnnotation: strconv.Itoa(int(replicas)),
+		SurgeTimeAnnotation:     s.now().UTC().Format(time.RFC3339),
+	}); err != nil {
+		klog.ErrorS(err, "Failed to scale up workload", "kind", w.kind, "workload", klog.KRef(w.namespace, w.name))
+		return err
+	}
+	klog.V(2).InfoS("Scaled up workload before evicting pod", "kind", w.kind, "workload", klog.KRef(w.namespace, w.name), "replicas", replicas, "pod", klog.KObj(pod))
+	eventRecorder.Event(vpa, apiv1.EventTypeNormal, "SurgedWorkload",
+		fmt.Sprintf("VPA Updater scaled up %s %s to %d replicas to evict Pod %s without losing capacity.", w.kind, w.name, replicas, pod.Name))
+	return nil
+}
+
+// ScaleDown scales back the workloads which were scaled up by one replica,
+// once none of the podsForUpdate belongs to them and all their replicas are
+// ready. The workloads are found by SurgeReplicasAnnotation rather than by the
+// pods of the VPAs in the SurgeRecreate mode, so that workloads whose VPA was
+// deleted or changed to another mode are scaled back too. Workloads scaled up
+// for longer than the surge timeout, e.g. because the surge replica can't be
+// scheduled, are scaled back even if they aren't ready; their pods are scaled
+// up again in a later loop. If the replicas of a workload were changed since
+// it was scaled up, only the annotations are removed.
+func (s *workloadSurger) ScaleDown(podsForUpdate []*apiv1.Pod, eventRecorder record.EventRecorder) {
+	waiting := make(map[string]bool)
+	for _, pod := range podsForUpdate {
+		if w, err := s.getWorkload(pod); err == nil && w != nil {
+			waiting[w.key()] = true
+		}
+	}
+	workloads, err := s.listSurgedWorkloads()
+	if err != nil {
+		klog.ErrorS(err, "Failed to list scaled up workloads")
+		return
+	}
+	now := s.now()
+	for _, w := range workloads {
+		timedOut := now.Sub(w.surgeTime) > s.surgeTimeout
+		if !timedOut && (waiting[w.key()] || !w.ready) {
+			continue
+		}
+		replicas := w.replicas
+		if replicas == w.surgeReplicas {
+			replicas--
+		}
+		if err := s.patch(w, replicas, map[string]interface{}{SurgeReplicasAnnotation: nil, SurgeTimeAnnotation: nil}); err != nil {
+			klog.ErrorS(err, "Failed to scale back workload", "kind", w.kind, "workload", klog.KRef(w.namespace, w.name))
+			continue
+		}
+		if timedOut {
+			klog.V(0).InfoS("Scaled back workload after surge timeout", "kind", w.kind, "workload", klog.KRef(w.namespace, w.name), "replicas", replicas, "surgeTimeout", s.surgeTimeout)
+			eventRecorder.Event(w.object, apiv1.EventTypeWarning, "SurgeTimedOut",
+				fmt.Sprintf("VPA Updater scaled %s %s back to %d replicas as its surge replica wasn't ready within %v.", w.kind, w.name, replicas, s.surgeTimeout))
+			continue
+		}
+		klog.V(2).InfoS("Scaled back workload", "kind", w.kind, "workload", klog.KRef(w.namespace, w.name), "replicas", replicas)
+		eventRecorder.Event(w.object, apiv1.EventTypeNormal, "ScaledBackWorkload",
+			fmt.Sprintf("VPA Updater scaled %s %s back to %d replicas after applying resource recommendation.", w.kind, w.name, replicas))
+	}
+}
+
+// listSurgedWorkloads returns the workloads with SurgeReplicasAnnotation.
+func (s *workloadSurger) listSurgedWorkloads() ([]*workload, error) {
+	var workloads []*workload
+	deployments, err := s.deploymentLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, deployment := range deployments {
+		if _, found := deployment.Annotations[SurgeReplicasAnnotation]; found {
+			workloads = append(workloads, deploymentWorkload(deployment))
+		}
+	}
+	replicaSets, err := s.replicaSetLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, rs := range replicaSets {
+		if _, found := rs.Annotations[SurgeReplicasAnnotation]; found {
+			workloads = append(workloads, newWorkload(replicaSetKind, rs, rs.ObjectMeta, rs.Spec.Replicas, rs.Status.ReadyReplicas, rs.Status.ObservedGeneration))
+		}
+	}
+	rcs, err := s.rcLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, rc := range rcs {
+		if _, found := rc.Annotations[SurgeReplicasAnnotation]; found {
+			workloads = append(workloads, newWorkload(replicationControllerKind, rc, rc.ObjectMeta, rc.Spec.Replicas, rc.Status.ReadyReplicas, rc.Status.ObservedGeneration))
+		}
+	}
+	return workloads, nil
+}
+
+// patch sets the replicas and the annotations of the workload, removing the
+// annotations set to nil. The patch fails with a conflict if the workload
+// changed since it was listed.
+func (s *workloadSurger) patch(w *workload, replicas int32, annotations map[string]interface{}) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"resourceVersion": w.resourceVersion,
+			"annotations":     annotations,
+		},
+		"spec": map[string]interface{}{"replicas": replicas},
+	})
+	if err != nil {
+		return err
+	}
+	switch w.kind {
+	case deploymentKind:
+		_, err = s.client.AppsV1().Deployments(w.namespace).Patch(context.TODO(), w.name, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+	case replicaSetKind:
+		_, err = s.client.AppsV1().ReplicaSets(w.namespace).Patch(context.TODO(), w.name, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+	case replicationControllerKind:
+		_, err = s.client.CoreV1().ReplicationControllers(w.namespace).Patch(context.TODO(), w.name, k8stypes.MergePatchType, patch, metav1.PatchOptions{})
+	default:
+		err = fmt.Errorf("unsupported workload kind %s", w.kind)
+	}
+	return err
+}
+
+// getWorkload returns the workload which has to be scaled up to add a replica
+// of the pod, or nil if the pod has no such workload or if its replicas are
+// managed by a HorizontalPodAutoscaler, which would revert the surge replica.
+func (s *workloadSurger) getWorkload(pod *apiv1.Pod) (*workload, error) {
+	w, err := s.getScalableController(pod)
+	if err != nil || w == nil {
+		return w, err
+	}
+	hpas, err := s.hpaLister.HorizontalPodAutoscalers(w.namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, hpa := range hpas {
+		if hpa.Spec.ScaleTargetRef.Kind == w.kind && hpa.Spec.ScaleTargetRef.Name == w.name {
+			klog.V(4).InfoS("Not scaling up workload targeted by a HorizontalPodAutoscaler", "kind", w.kind, "workload", klog.KRef(w.namespace, w.name), "hpa", klog.KObj(hpa))
+			return nil, nil
+		}
+	}
+	return w, nil
+}
+
+// getScalableController returns the scalable controller of the pod, or nil
+// if the pod has no such controller.
+func (s *workloadSurger) getScalableController(pod *apiv1.Pod) (*workload, error) {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return nil, nil
+	}
+	switch owner.Kind {
+	case replicaSetKind:
+		rs, err := s.replicaSetLister.ReplicaSets(pod.Namespace).Get(owner.Name)
+		if err != nil {
+			return nil, err
+		}
+		if rsOwner := metav1.GetControllerOf(rs); rsOwner != nil {
+			if rsOwner.Kind != deploymentKind {
+				return nil, nil
+			}
+			deployment, err := s.deploymentLister.Deployments(pod.Namespace).Get(rsOwner.Name)
+			if err != nil {
+				return nil, err
+			}
+			return deploymentWorkload(deployment), nil
+		}
+		return newWorkload(replicaSetKind, rs, rs.ObjectMeta, rs.Spec.Replicas, rs.Status.ReadyReplicas, rs.Status.ObservedGeneration), nil
+	case replicationControllerKind:
+		rc, err := s.rcLister.ReplicationControllers(pod.Namespace).Get(owner.Name)
+		if err != nil {
+			return nil, err
+		}
+		return newWorkload(replicationControllerKind, rc, rc.ObjectMeta, rc.Spec.Replicas, rc.Status.ReadyReplicas, rc.Status.ObservedGeneration), nil
+	}
+	return nil, nil
+}
+
+func deploymentWorkload(deployment *appsv1.Deployment) *workload {
+	w := newWorkload(deploymentKind, deployment, deployment.ObjectMeta, deployment.Spec.Replicas, deployment.Status.AvailableReplicas, deployment.Status.ObservedGeneration)
+	// Replicas of a previous revision are still being replaced.
+	if deployment.Status.UpdatedReplicas < w.replicas || deployment.Status.Replicas > w.replicas {
+		w.ready = false
+	}
+	return w
+}
+
+func newWorkload(kind string, object runtime.Object, meta metav1.ObjectMeta, specReplicas *int32, readyReplicas int32, observedGeneration int64) *workload {
+	replicas := int32(1)
+	if specReplicas != nil {
+		replicas = *specReplicas
+	}
+	w := &workload{
+		kind:            kind,
+		namespace:       meta.Namespace,
+		name:            meta.Name,
+		replicas:        replicas,
+		ready:           observedGeneration >= meta.Generation && readyReplicas >= replicas,
+		resourceVersion: meta.ResourceVersion,
+		object:          object,
+	}
+	if value, found := meta.Annotations[SurgeReplicasAnnotation]; found {
+		surgeReplicas, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || surgeReplicas <= 0 {
+			klog.V(2).InfoS("Ignoring invalid surge replicas annotation", "kind", kind, "workload", klog.KRef(meta.Namespace, meta.Name), "value", value)
+		} else {
+			w.surgeReplicas = int32(surgeReplicas)
+		}
+	}
+	if value, found := meta.Annotations[SurgeTimeAnnotation]; found {
+		surgeTime, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			klog.V(2).InfoS("Ignoring invalid surge time annotation", "kind", kind, "workload", klog.KRef(meta.Namespace, meta.Name), "value", value)
+		} else {
+			w.surgeTime = surgeTime
+		}
+	}
+	return w
+}
+
+func (w *workload) key() string {
+	return w.kind + "/" + w.namespace + "/" + w.name
+}
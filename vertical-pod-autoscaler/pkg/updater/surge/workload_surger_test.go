@@ -0,0 +1,319 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package surge
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	autoscalinglisters "k8s.io/client-go/listers/autoscaling/v2"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/test"
+)
+
+var surgeTestTime = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newDeployment(replicas, available int32, surgeReplicas string) *appsv1.Deployment {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "deployment", Namespace: "default", Generation: 1},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+			Replicas:           replicas,
+			UpdatedReplicas:    replicas,
+			AvailableReplicas:  available,
+		},
+	}
+	if surgeReplicas != "" {
+		deployment.Annotations = map[string]string{
+			SurgeReplicasAnnotation: surgeReplicas,
+			SurgeTimeAnnotation:     surgeTestTime.Format(time.RFC3339),
+		}
+	}
+	return deployment
+}
+
+func newHPA(targetKind, targetName string) *autoscalingv2.HorizontalPodAutoscaler {
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "hpa", Namespace: "default"},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: targetKind, Name: targetName},
+		},
+	}
+}
+
+func newReplicaSet(name string, owner *metav1.OwnerReference) *appsv1.ReplicaSet {
+	replicas := int32(1)
+	rs := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       appsv1.ReplicaSetSpec{Replicas: &replicas},
+		Status:     appsv1.ReplicaSetStatus{ReadyReplicas: 1},
+	}
+	if owner != nil {
+		rs.OwnerReferences = []metav1.OwnerReference{*owner}
+	}
+	return rs
+}
+
+func newPod(name, ownerKind, ownerName string) *apiv1.Pod {
+	pod := test.Pod().WithName(name).Get()
+	controller := true
+	pod.OwnerReferences = []metav1.OwnerReference{{Kind: ownerKind, Name: ownerName, Controller: &controller}}
+	return pod
+}
+
+func newTestSurger(t *testing.T, objects ...runtime.Object) (*workloadSurger, *fake.Clientset) {
+	deploymentIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	replicaSetIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	rcIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	hpaIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, obj := range objects {
+		var err error
+		switch obj.(type) {
+		case *appsv1.Deployment:
+			err = deploymentIndexer.Add(obj)
+		case *appsv1.ReplicaSet:
+			err = replicaSetIndexer.Add(obj)
+		case *apiv1.ReplicationController:
+			err = rcIndexer.Add(obj)
+		case *autoscalingv2.HorizontalPodAutoscaler:
+			err = hpaIndexer.Add(obj)
+		}
+		assert.NoError(t, err)
+	}
+	client := fake.NewSimpleClientset(objects...)
+	return &workloadSurger{
+		client:           client,
+		deploymentLister: appslisters.NewDeploymentLister(deploymentIndexer),
+		replicaSetLister: appslisters.NewReplicaSetLister(replicaSetIndexer),
+		rcLister:         corelisters.NewReplicationControllerLister(rcIndexer),
+		hpaLister:        autoscalinglisters.NewHorizontalPodAutoscalerLister(hpaIndexer),
+		surgeTimeout:     10 * time.Minute,
+		now:              func() time.Time { return surgeTestTime.Add(time.Minute) },
+	}, client
+}
+
+func TestGetAction(t *testing.T) {
+	controller := true
+	deploymentOwner := &metav1.OwnerReference{Kind: "Deployment", Name: "deployment", Controller: &controller}
+	tests := []struct {
+		name     string
+		objects  []runtime.Object
+		pod      *apiv1.Pod
+		expected Action
+	}{
+		{
+			name:     "deployment which wasn't scaled up",
+			objects:  []runtime.Object{newDeployment(1, 1, ""), newReplicaSet("rs", deploymentOwner)},
+			pod:      newPod("pod", "ReplicaSet", "rs"),
+			expected: ActionSurge,
+		},
+		{
+			name:     "deployment which isn't ready",
+			objects:  []runtime.Object{newDeployment(2, 1, ""), newReplicaSet("rs", deploymentOwner)},
+			pod:      newPod("pod", "ReplicaSet", "rs"),
+			expected: ActionWait,
+		},
+		{
+			name:     "surge replica isn't ready",
+			objects:  []runtime.Object{newDeployment(2, 1, "2"), newReplicaSet("rs", deploymentOwner)},
+			pod:      newPod("pod", "ReplicaSet", "rs"),
+			expected: ActionWait,
+		},
+		{
+			name:     "surge replica is ready",
+			objects:  []runtime.Object{newDeployment(2, 2, "2"), newReplicaSet("rs", deploymentOwner)},
+			pod:      newPod("pod", "ReplicaSet", "rs"),
+			expected: ActionEvict,
+		},
+		{
+			name:     "replica set without deployment",
+			objects:  []runtime.Object{newReplicaSet("rs", nil)},
+			pod:      newPod("pod", "ReplicaSet", "rs"),
+			expected: ActionSurge,
+		},
+		{
+			name:     "missing replica set",
+			pod:      newPod("pod", "ReplicaSet", "rs"),
+			expected: ActionWait,
+		},
+		{
+			name:     "deployment targeted by a horizontal pod autoscaler",
+			objects:  []runtime.Object{newDeployment(1, 1, ""), newReplicaSet("rs", deploymentOwner), newHPA("Deployment", "deployment")},
+			pod:      newPod("pod", "ReplicaSet", "rs"),
+			expected: ActionRecreate,
+		},
+		{
+			name:     "horizontal pod autoscaler targeting another deployment",
+			objects:  []runtime.Object{newDeployment(1, 1, ""), newReplicaSet("rs", deploymentOwner), newHPA("Deployment", "other")},
+			pod:      newPod("pod", "ReplicaSet", "rs"),
+			expected: ActionSurge,
+		},
+		{
+			name:     "stateful set",
+			pod:      newPod("pod", "StatefulSet", "ss"),
+			expected: ActionRecreate,
+		},
+		{
+			name:     "pod without controller",
+			pod:      test.Pod().WithName("pod").Get(),
+			expected: ActionRecreate,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			surger, _ := newTestSurger(t, tc.objects...)
+			assert.Equal(t, tc.expected, surger.GetAction(tc.pod))
+		})
+	}
+}
+
+func TestSurge(t *testing.T) {
+	controller := true
+	deploymentOwner := &metav1.OwnerReference{Kind: "Deployment", Name: "deployment", Controller: &controller}
+	surger, client := newTestSurger(t, newDeployment(1, 1, ""), newReplicaSet("rs", deploymentOwner))
+	vpa := test.VerticalPodAutoscaler().WithContainer("container").Get()
+
+	err := surger.Surge(newPod("pod", "ReplicaSet", "rs"), vpa, record.NewFakeRecorder(10))
+	assert.NoError(t, err)
+
+	deployment, err := client.AppsV1().Deployments("default").Get(context.TODO(), "deployment", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), *deployment.Spec.Replicas)
+	assert.Equal(t, "2", deployment.Annotations[SurgeReplicasAnnotation])
+	assert.Equal(t, surgeTestTime.Add(time.Minute).Format(time.RFC3339), deployment.Annotations[SurgeTimeAnnotation])
+
+	err = surger.Surge(newPod("pod", "StatefulSet", "ss"), vpa, record.NewFakeRecorder(10))
+	assert.Error(t, err)
+}
+
+func TestScaleDown(t *testing.T) {
+	controller := true
+	deploymentOwner := &metav1.OwnerReference{Kind: "Deployment", Name: "deployment", Controller: &controller}
+	surgeAnnotations := map[string]string{
+		SurgeReplicasAnnotation: "2",
+		SurgeTimeAnnotation:     surgeTestTime.Format(time.RFC3339),
+	}
+	tests := []struct {
+		name                string
+		deployment          *appsv1.Deployment
+		podsForUpdate       []*apiv1.Pod
+		now                 time.Time
+		expectedReplicas    int32
+		expectedAnnotations map[string]string
+		expectedEvent       string
+	}{
+		{
+			name:             "scales back when no pod is waiting for an update",
+			deployment:       newDeployment(2, 2, "2"),
+			expectedReplicas: 1,
+			expectedEvent:    "Normal ScaledBackWorkload",
+		},
+		{
+			name:                "keeps surge replica while pods are waiting for an update",
+			deployment:          newDeployment(2, 2, "2"),
+			podsForUpdate:       []*apiv1.Pod{newPod("pod", "ReplicaSet", "rs")},
+			expectedReplicas:    2,
+			expectedAnnotations: surgeAnnotations,
+		},
+		{
+			name:                "keeps surge replica until all replicas are ready",
+			deployment:          newDeployment(2, 1, "2"),
+			expectedReplicas:    2,
+			expectedAnnotations: surgeAnnotations,
+		},
+		{
+			name:             "scales back when the surge replica isn't ready within the timeout",
+			deployment:       newDeployment(2, 1, "2"),
+			podsForUpdate:    []*apiv1.Pod{newPod("pod", "ReplicaSet", "rs")},
+			now:              surgeTestTime.Add(11 * time.Minute),
+			expectedReplicas: 1,
+			expectedEvent:    "Warning SurgeTimedOut",
+		},
+		{
+			name:             "only removes the annotations if the replicas changed",
+			deployment:       newDeployment(5, 5, "2"),
+			expectedReplicas: 5,
+			expectedEvent:    "Normal ScaledBackWorkload",
+		},
+		{
+			name:             "doesn't scale down deployments which weren't scaled up",
+			deployment:       newDeployment(2, 2, ""),
+			expectedReplicas: 2,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			surger, client := newTestSurger(t, tc.deployment, newReplicaSet("rs", deploymentOwner))
+			if !tc.now.IsZero() {
+				surger.now = func() time.Time { return tc.now }
+			}
+			eventRecorder := record.NewFakeRecorder(10)
+
+			surger.ScaleDown(tc.podsForUpdate, eventRecorder)
+
+			deployment, err := client.AppsV1().Deployments("default").Get(context.TODO(), "deployment", metav1.GetOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedReplicas, *deployment.Spec.Replicas)
+			if tc.expectedAnnotations == nil {
+				assert.Empty(t, deployment.Annotations)
+			} else {
+				assert.Equal(t, tc.expectedAnnotations, deployment.Annotations)
+			}
+			if tc.expectedEvent == "" {
+				assert.Empty(t, eventRecorder.Events)
+			} else {
+				assert.Contains(t, <-eventRecorder.Events, tc.expectedEvent)
+			}
+		})
+	}
+}
+
+func TestScaleDownReplicationController(t *testing.T) {
+	replicas := int32(2)
+	rc := &apiv1.ReplicationController{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "rc",
+			Namespace:   "default",
+			Annotations: map[string]string{SurgeReplicasAnnotation: "2", SurgeTimeAnnotation: surgeTestTime.Format(time.RFC3339)},
+		},
+		Spec:   apiv1.ReplicationControllerSpec{Replicas: &replicas},
+		Status: apiv1.ReplicationControllerStatus{ReadyReplicas: 2},
+	}
+	// The VPA of the replication controller isn't in the SurgeRecreate mode
+	// anymore, so none of its pods is passed as waiting for an update.
+	surger, client := newTestSurger(t, rc)
+
+	surger.ScaleDown(nil, record.NewFakeRecorder(10))
+
+	rc, err := client.CoreV1().ReplicationControllers("default").Get(context.TODO(), "rc", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), *rc.Spec.Replicas)
+	assert.Empty(t, rc.Annotations)
+}
@@ -22,6 +22,8 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/validation"
+
+	resourcehelpers "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/resources"
 )
 
 const (
@@ -32,9 +34,10 @@ const (
 
 // GetVpaObservedContainersValue creates an annotation value for a given pod.
 func GetVpaObservedContainersValue(pod *v1.Pod) string {
-	containerNames := make([]string, len(pod.Spec.Containers))
-	for i := range pod.Spec.Containers {
-		containerNames[i] = pod.Spec.Containers[i].Name
+	containers := resourcehelpers.PodContainers(pod)
+	containerNames := make([]string, len(containers))
+	for i := range containers {
+		containerNames[i] = containers[i].Name
 	}
 	return strings.Join(containerNames, listSeparator)
 }
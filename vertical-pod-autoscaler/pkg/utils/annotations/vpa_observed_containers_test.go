@@ -41,6 +41,15 @@ func TestGetVpaObservedContainersValue(t *testing.T) {
 				Get(),
 			want: "test1, test2, test3",
 		},
+		{
+			name: "creating vpa observed containers annotation with sidecar containers",
+			pod: test.Pod().
+				AddContainer(test.Container().WithName("test1").Get()).
+				AddInitContainer(test.Container().WithName("init").Get()).
+				AddInitContainer(test.Container().WithName("sidecar").WithRestartPolicy(v1.ContainerRestartPolicyAlways).Get()).
+				Get(),
+			want: "test1, sidecar",
+		},
 	}
 	for _, tc := range tests {
 		t.Run(fmt.Sprintf("test case: %s", tc.name), func(t *testing.T) {
@@ -118,7 +118,7 @@ func (lc *limitsChecker) getLimitRangeItem(namespace string, limitType core.Limi
 	result := &core.LimitRangeItem{Type: limitType}
 	for _, lr := range limitRanges {
 		for _, lri := range lr.Spec.Limits {
-			if lri.Type == limitType && (lri.Max != nil || lri.Default != nil || lri.Min != nil) {
+			if lri.Type == limitType && (lri.Max != nil || lri.Default != nil || lri.Min != nil || lri.MaxLimitRequestRatio != nil) {
 				if lri.Default != nil {
 					result.Default = lri.Default
 				}
@@ -126,10 +126,12 @@ func (lc *limitsChecker) getLimitRangeItem(namespace string, limitType core.Limi
 				result.Max = updatedResult(result.Max, lri.Max, core.ResourceMemory, pickLowerMax)
 				result.Min = updatedResult(result.Min, lri.Min, core.ResourceCPU, chooseHigherMin)
 				result.Min = updatedResult(result.Min, lri.Min, core.ResourceMemory, chooseHigherMin)
+				result.MaxLimitRequestRatio = updatedResult(result.MaxLimitRequestRatio, lri.MaxLimitRequestRatio, core.ResourceCPU, pickLowerMax)
+				result.MaxLimitRequestRatio = updatedResult(result.MaxLimitRequestRatio, lri.MaxLimitRequestRatio, core.ResourceMemory, pickLowerMax)
 			}
 		}
 	}
-	if result.Min != nil || result.Max != nil || result.Default != nil {
+	if result.Min != nil || result.Max != nil || result.Default != nil || result.MaxLimitRequestRatio != nil {
 		return result, nil
 	}
 	return nil, nil
@@ -131,6 +131,17 @@ func TestGetContainerLimitRangeItem(t *testing.T) {
 				Min:  test.Resources("1.5", "1.5"),
 			},
 		},
+		{
+			name: "takes lowest max limit to request ratio",
+			limitRanges: []runtime.Object{
+				baseContainerLimitRange.WithMaxLimitRequestRatio(test.Resources("2", "3")).WithMaxLimitRequestRatio(test.Resources("4", "1.5")).Get(),
+			},
+			expectedErr: nil,
+			expectedLimits: &core.LimitRangeItem{
+				Type:                 core.LimitTypeContainer,
+				MaxLimitRequestRatio: test.Resources("2", "1.5"),
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -41,6 +41,8 @@ var (
 		string(vpa_types.UpdateModeInitial),
 		string(vpa_types.UpdateModeRecreate),
 		string(vpa_types.UpdateModeAuto),
+		string(vpa_types.UpdateModeInPlaceOrRecreate),
+		string(vpa_types.UpdateModeSurgeRecreate),
 	}
 )
 
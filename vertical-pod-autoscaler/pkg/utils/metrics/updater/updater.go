@@ -60,6 +60,14 @@ var (
 		}, []string{"vpa_size_log2"},
 	)
 
+	resizedCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "in_place_resized_pods_total",
+			Help:      "Number of Pods resized in place by Updater to apply a new recommendation.",
+		}, []string{"vpa_size_log2"},
+	)
+
 	vpasWithEvictablePodsCount = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: metricsNamespace,
@@ -82,7 +90,7 @@ var (
 
 // Register initializes all metrics for VPA Updater
 func Register() {
-	prometheus.MustRegister(controlledCount, evictableCount, evictedCount, vpasWithEvictablePodsCount, vpasWithEvictedPodsCount, functionLatency)
+	prometheus.MustRegister(controlledCount, evictableCount, evictedCount, resizedCount, vpasWithEvictablePodsCount, vpasWithEvictedPodsCount, functionLatency)
 }
 
 // NewExecutionTimer provides a timer for Updater's RunOnce execution
@@ -124,6 +132,12 @@ func AddEvictedPod(vpaSize int) {
 	evictedCount.WithLabelValues(strconv.Itoa(log2)).Inc()
 }
 
+// AddResizedPod increases the counter of pods resized in place by Updater, by given VPA size
+func AddResizedPod(vpaSize int) {
+	log2 := metrics.GetVpaSizeLog2(vpaSize)
+	resizedCount.WithLabelValues(strconv.Itoa(log2)).Inc()
+}
+
 // Add increases the counter for the given VPA size
 func (g *SizeBasedGauge) Add(vpaSize int, value int) {
 	log2 := metrics.GetVpaSizeLog2(vpaSize)
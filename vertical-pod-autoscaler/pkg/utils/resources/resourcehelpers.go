@@ -17,6 +17,8 @@ limitations under the License.
 package resourcehelpers
 
 import (
+	"strings"
+
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 )
@@ -44,10 +46,38 @@ func ContainerRequestsAndLimits(containerName string, pod *v1.Pod) (v1.ResourceL
 	return nil, nil
 }
 
+// IsSidecarContainer returns true if the init container is a restartable init
+// (sidecar) container, which keeps running alongside the regular containers.
+func IsSidecarContainer(initContainer v1.Container) bool {
+	return initContainer.RestartPolicy != nil && *initContainer.RestartPolicy == v1.ContainerRestartPolicyAlways
+}
+
+// IsExtendedResource returns true for extended resources with a domain prefix
+// (e.g. nvidia.com/gpu) and for huge pages (e.g. hugepages-2Mi). Their requests
+// must be equal to their limits and they can't be resized in place.
+func IsExtendedResource(name v1.ResourceName) bool {
+	return strings.HasPrefix(string(name), v1.ResourceHugePagesPrefix) || strings.Contains(string(name), "/")
+}
+
+// PodContainers returns the containers of the pod followed by its sidecar
+// containers. Containers get resource recommendations in this order.
+func PodContainers(pod *v1.Pod) []*v1.Container {
+	containers := make([]*v1.Container, 0, len(pod.Spec.Containers))
+	for i := range pod.Spec.Containers {
+		containers = append(containers, &pod.Spec.Containers[i])
+	}
+	for i := range pod.Spec.InitContainers {
+		if IsSidecarContainer(pod.Spec.InitContainers[i]) {
+			containers = append(containers, &pod.Spec.InitContainers[i])
+		}
+	}
+	return containers
+}
+
 func findContainer(containerName string, pod *v1.Pod) *v1.Container {
-	for i, container := range pod.Spec.Containers {
+	for _, container := range PodContainers(pod) {
 		if container.Name == containerName {
-			return &pod.Spec.Containers[i]
+			return container
 		}
 	}
 	return nil
@@ -59,5 +89,10 @@ func containerStatusForContainer(containerName string, pod *v1.Pod) *v1.Containe
 			return &pod.Status.ContainerStatuses[i]
 		}
 	}
+	for i, containerStatus := range pod.Status.InitContainerStatuses {
+		if containerStatus.Name == containerName {
+			return &pod.Status.InitContainerStatuses[i]
+		}
+	}
 	return nil
 }
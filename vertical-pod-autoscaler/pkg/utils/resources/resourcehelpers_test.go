@@ -150,6 +150,30 @@ func TestContainerRequestsAndLimits(t *testing.T) {
 				apiv1.ResourceMemory: resource.MustParse("40Mi"),
 			},
 		},
+		{
+			desc:          "Sidecar container",
+			containerName: "sidecar",
+			pod: test.Pod().AddContainer(test.Container().WithName("container").Get()).
+				AddInitContainer(test.Container().WithName("sidecar").
+					WithRestartPolicy(apiv1.ContainerRestartPolicyAlways).
+					WithCPURequest(resource.MustParse("1")).
+					WithCPULimit(resource.MustParse("2")).Get()).Get(),
+			wantRequests: apiv1.ResourceList{
+				apiv1.ResourceCPU: resource.MustParse("1"),
+			},
+			wantLimits: apiv1.ResourceList{
+				apiv1.ResourceCPU: resource.MustParse("2"),
+			},
+		},
+		{
+			desc:          "Init container",
+			containerName: "init",
+			pod: test.Pod().AddContainer(test.Container().WithName("container").Get()).
+				AddInitContainer(test.Container().WithName("init").
+					WithCPURequest(resource.MustParse("1")).Get()).Get(),
+			wantRequests: nil,
+			wantLimits:   nil,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -159,3 +183,14 @@ func TestContainerRequestsAndLimits(t *testing.T) {
 		})
 	}
 }
+
+func TestPodContainers(t *testing.T) {
+	pod := test.Pod().AddContainer(test.Container().WithName("container").Get()).
+		AddInitContainer(test.Container().WithName("init").Get()).
+		AddInitContainer(test.Container().WithName("sidecar").WithRestartPolicy(apiv1.ContainerRestartPolicyAlways).Get()).Get()
+	var names []string
+	for _, container := range PodContainers(pod) {
+		names = append(names, container.Name)
+	}
+	assert.Equal(t, []string{"container", "sidecar"}, names)
+}
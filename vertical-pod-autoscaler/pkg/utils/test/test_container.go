@@ -22,11 +22,12 @@ import (
 )
 
 type containerBuilder struct {
-	name       string
-	cpuRequest *resource.Quantity
-	memRequest *resource.Quantity
-	cpuLimit   *resource.Quantity
-	memLimit   *resource.Quantity
+	name          string
+	cpuRequest    *resource.Quantity
+	memRequest    *resource.Quantity
+	cpuLimit      *resource.Quantity
+	memLimit      *resource.Quantity
+	restartPolicy *apiv1.ContainerRestartPolicy
 }
 
 // Container returns object that helps build containers for tests.
@@ -64,9 +65,16 @@ func (cb *containerBuilder) WithMemLimit(memLimit resource.Quantity) *containerB
 	return &r
 }
 
+func (cb *containerBuilder) WithRestartPolicy(restartPolicy apiv1.ContainerRestartPolicy) *containerBuilder {
+	r := *cb
+	r.restartPolicy = &restartPolicy
+	return &r
+}
+
 func (cb *containerBuilder) Get() apiv1.Container {
 	container := apiv1.Container{
-		Name: cb.name,
+		Name:          cb.name,
+		RestartPolicy: cb.restartPolicy,
 		Resources: apiv1.ResourceRequirements{
 			Requests: apiv1.ResourceList{},
 			Limits:   apiv1.ResourceList{},
@@ -33,6 +33,7 @@ type limitRangeBuilder struct {
 	defaultValues []*core.ResourceList
 	maxValues     []*core.ResourceList
 	minValues     []*core.ResourceList
+	ratioValues   []*core.ResourceList
 }
 
 func (lrb *limitRangeBuilder) WithName(name string) *limitRangeBuilder {
@@ -71,6 +72,12 @@ func (lrb *limitRangeBuilder) WithMin(min core.ResourceList) *limitRangeBuilder
 	return &result
 }
 
+func (lrb *limitRangeBuilder) WithMaxLimitRequestRatio(ratio core.ResourceList) *limitRangeBuilder {
+	result := *lrb
+	result.ratioValues = append(result.ratioValues, &ratio)
+	return &result
+}
+
 func (lrb *limitRangeBuilder) Get() *core.LimitRange {
 	result := core.LimitRange{
 		ObjectMeta: meta.ObjectMeta{
@@ -78,7 +85,7 @@ func (lrb *limitRangeBuilder) Get() *core.LimitRange {
 			Name:      lrb.name,
 		},
 	}
-	if len(lrb.defaultValues) > 0 || len(lrb.maxValues) > 0 || len(lrb.minValues) > 0 {
+	if len(lrb.defaultValues) > 0 || len(lrb.maxValues) > 0 || len(lrb.minValues) > 0 || len(lrb.ratioValues) > 0 {
 		result.Spec = core.LimitRangeSpec{
 			Limits: []core.LimitRangeItem{},
 		}
@@ -101,5 +108,11 @@ func (lrb *limitRangeBuilder) Get() *core.LimitRange {
 			Min:  *v,
 		})
 	}
+	for _, v := range lrb.ratioValues {
+		result.Spec.Limits = append(result.Spec.Limits, core.LimitRangeItem{
+			Type:                 lrb.rangeType,
+			MaxLimitRequestRatio: *v,
+		})
+	}
 	return &result
 }
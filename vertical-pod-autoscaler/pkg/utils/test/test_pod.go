@@ -25,6 +25,7 @@ import (
 type PodBuilder interface {
 	WithName(name string) PodBuilder
 	AddContainer(container apiv1.Container) PodBuilder
+	AddInitContainer(initContainer apiv1.Container) PodBuilder
 	AddContainerStatus(containerStatus apiv1.ContainerStatus) PodBuilder
 	WithCreator(creatorObjectMeta *metav1.ObjectMeta, creatorTypeMeta *metav1.TypeMeta) PodBuilder
 	WithLabels(labels map[string]string) PodBuilder
@@ -44,6 +45,7 @@ func Pod() PodBuilder {
 type podBuilderImpl struct {
 	name              string
 	containers        []apiv1.Container
+	initContainers    []apiv1.Container
 	creatorObjectMeta *metav1.ObjectMeta
 	creatorTypeMeta   *metav1.TypeMeta
 	labels            map[string]string
@@ -76,6 +78,12 @@ func (pb *podBuilderImpl) AddContainer(container apiv1.Container) PodBuilder {
 	return &r
 }
 
+func (pb *podBuilderImpl) AddInitContainer(initContainer apiv1.Container) PodBuilder {
+	r := *pb
+	r.initContainers = append(r.initContainers, initContainer)
+	return &r
+}
+
 func (pb *podBuilderImpl) WithCreator(creatorObjectMeta *metav1.ObjectMeta, creatorTypeMeta *metav1.TypeMeta) PodBuilder {
 	r := *pb
 	r.creatorObjectMeta = creatorObjectMeta
@@ -105,7 +113,8 @@ func (pb *podBuilderImpl) Get() *apiv1.Pod {
 			Name:      pb.name,
 		},
 		Spec: apiv1.PodSpec{
-			Containers: pb.containers,
+			Containers:     pb.containers,
+			InitContainers: pb.initContainers,
 		},
 		Status: apiv1.PodStatus{
 			StartTime: &startTime,
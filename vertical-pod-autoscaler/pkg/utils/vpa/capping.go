@@ -18,6 +18,7 @@ package api
 
 import (
 	"fmt"
+	"sort"
 
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -42,6 +43,7 @@ var (
 	cappedToLimit                  cappingAction = "capped to container limit"
 	cappedProportionallyToMaxLimit cappingAction = "capped to fit Max in container LimitRange"
 	cappedProportionallyToMinLimit cappingAction = "capped to fit Min in container LimitRange"
+	cappedToLimitRequestRatio      cappingAction = "capped to fit MaxLimitRequestRatio in container LimitRange"
 )
 
 func toCappingAnnotation(resourceName apiv1.ResourceName, action cappingAction) string {
@@ -140,6 +142,10 @@ func getCappedRecommendationForContainer(
 			if genAnnotations {
 				cappingAnnotations = append(cappingAnnotations, annotations...)
 			}
+			annotations = capRecommendationToLimitRequestRatio(recommendation, containerLimits, limitRange)
+			if genAnnotations {
+				cappingAnnotations = append(cappingAnnotations, annotations...)
+			}
 		}
 	}
 
@@ -165,6 +171,33 @@ func capRecommendationToContainerLimit(recommendation apiv1.ResourceList, contai
 	return annotations
 }
 
+// capRecommendationToLimitRequestRatio makes sure recommendation is high enough for the limit of the container
+// to fit MaxLimitRequestRatio in the container LimitRange. Containers without a limit get the default limit
+// of the LimitRange. If this function makes adjustments appropriate annotations are returned.
+func capRecommendationToLimitRequestRatio(recommendation apiv1.ResourceList, containerLimits apiv1.ResourceList,
+	limitRange *apiv1.LimitRangeItem) []string {
+	annotations := make([]string, 0)
+	if limitRange == nil {
+		return annotations
+	}
+	for resourceName, ratio := range limitRange.MaxLimitRequestRatio {
+		limit, found := containerLimits[resourceName]
+		if !found {
+			limit, found = limitRange.Default[resourceName]
+		}
+		recommendedValue, recommended := recommendation[resourceName]
+		if !found || !recommended || ratio.IsZero() {
+			continue
+		}
+		minRequest := getMinRequestForRatio(resourceName, limit, ratio)
+		if recommendedValue.Cmp(minRequest) < 0 {
+			recommendation[resourceName] = minRequest
+			annotations = append(annotations, toCappingAnnotation(resourceName, cappedToLimitRequestRatio))
+		}
+	}
+	return annotations
+}
+
 // applyVPAPolicy updates recommendation if recommended resources are outside of limits defined in VPA resources policy
 func applyVPAPolicy(recommendation apiv1.ResourceList, policy *vpa_types.ContainerResourcePolicy) []string {
 	if policy == nil {
@@ -286,6 +319,89 @@ func ApplyVPAPolicy(podRecommendation *vpa_types.RecommendedPodResources,
 	return &vpa_types.RecommendedPodResources{ContainerRecommendations: updatedRecommendations}, nil
 }
 
+// limitRangeBound is a bound on the recommended requests imposed by a LimitRange.
+type limitRangeBound struct {
+	quantity resource.Quantity
+	// source describes what in the LimitRange imposes the bound.
+	source string
+}
+
+// ApplyLimitRange returns a recommendation adjusted to fit the container LimitRange in the namespace of the VPA,
+// together with messages describing how the target was capped. Recommended requests are kept between Min and Max
+// of the LimitRange. For containers with only requests controlled by the VPA, the LimitRange defaults the limits
+// of containers without limits, so the requests are also kept between the default limit divided by
+// MaxLimitRequestRatio and the default limit.
+func ApplyLimitRange(podRecommendation *vpa_types.RecommendedPodResources,
+	policy *vpa_types.PodResourcePolicy, limitRange *apiv1.LimitRangeItem) (*vpa_types.RecommendedPodResources, []string) {
+	if podRecommendation == nil || limitRange == nil {
+		return podRecommendation, nil
+	}
+	var messages []string
+	updatedRecommendations := []vpa_types.RecommendedContainerResources{}
+	for _, containerRecommendation := range podRecommendation.ContainerRecommendations {
+		containerName := containerRecommendation.ContainerName
+		minBounds, maxBounds := getLimitRangeBounds(limitRange, GetContainerControlledValues(containerName, policy))
+		cappedRecommendation := containerRecommendation.DeepCopy()
+		process := func(recommendation apiv1.ResourceList, genMessages bool) {
+			for resourceName, recommended := range recommendation {
+				if bound, found := minBounds[resourceName]; found && recommended.Cmp(bound.quantity) < 0 {
+					recommended = bound.quantity
+					if genMessages {
+						messages = append(messages, fmt.Sprintf("container %s: %s capped to %s %s", containerName, resourceName, bound.source, bound.quantity.String()))
+					}
+				}
+				if bound, found := maxBounds[resourceName]; found && recommended.Cmp(bound.quantity) > 0 {
+					recommended = bound.quantity
+					if genMessages {
+						messages = append(messages, fmt.Sprintf("container %s: %s capped to %s %s", containerName, resourceName, bound.source, bound.quantity.String()))
+					}
+				}
+				recommendation[resourceName] = recommended
+			}
+		}
+		process(cappedRecommendation.Target, true)
+		process(cappedRecommendation.LowerBound, false)
+		process(cappedRecommendation.UpperBound, false)
+		updatedRecommendations = append(updatedRecommendations, *cappedRecommendation)
+	}
+	sort.Strings(messages)
+	return &vpa_types.RecommendedPodResources{ContainerRecommendations: updatedRecommendations}, messages
+}
+
+// getLimitRangeBounds returns the lowest and highest requests allowed by the container LimitRange.
+func getLimitRangeBounds(limitRange *apiv1.LimitRangeItem,
+	controlledValues vpa_types.ContainerControlledValues) (map[apiv1.ResourceName]limitRangeBound, map[apiv1.ResourceName]limitRangeBound) {
+	minBounds := map[apiv1.ResourceName]limitRangeBound{}
+	maxBounds := map[apiv1.ResourceName]limitRangeBound{}
+	raiseMin := func(resourceName apiv1.ResourceName, quantity resource.Quantity, source string) {
+		if bound, found := minBounds[resourceName]; quantity.IsZero() || found && bound.quantity.Cmp(quantity) >= 0 {
+			return
+		}
+		minBounds[resourceName] = limitRangeBound{quantity: quantity, source: source}
+	}
+	lowerMax := func(resourceName apiv1.ResourceName, quantity resource.Quantity, source string) {
+		if bound, found := maxBounds[resourceName]; quantity.IsZero() || found && bound.quantity.Cmp(quantity) <= 0 {
+			return
+		}
+		maxBounds[resourceName] = limitRangeBound{quantity: quantity, source: source}
+	}
+	for resourceName, quantity := range limitRange.Min {
+		raiseMin(resourceName, quantity, "LimitRange min")
+	}
+	for resourceName, quantity := range limitRange.Max {
+		lowerMax(resourceName, quantity, "LimitRange max")
+	}
+	if controlledValues == vpa_types.ContainerControlledValuesRequestsOnly {
+		for resourceName, defaultLimit := range limitRange.Default {
+			lowerMax(resourceName, defaultLimit, "LimitRange default limit")
+			if ratio, found := limitRange.MaxLimitRequestRatio[resourceName]; found && !ratio.IsZero() {
+				raiseMin(resourceName, getMinRequestForRatio(resourceName, defaultLimit, ratio), "LimitRange maxLimitRequestRatio for default limit")
+			}
+		}
+	}
+	return minBounds, maxBounds
+}
+
 func getRecommendationForContainer(containerName string, resources []vpa_types.RecommendedContainerResources) *vpa_types.RecommendedContainerResources {
 	for _, containerRec := range resources {
 		if containerRec.ContainerName == containerName {
@@ -307,9 +423,9 @@ func GetRecommendationForContainer(containerName string, recommendation *vpa_typ
 }
 
 func getContainer(containerName string, pod *apiv1.Pod) *apiv1.Container {
-	for i, container := range pod.Spec.Containers {
+	for _, container := range resourcehelpers.PodContainers(pod) {
 		if container.Name == containerName {
-			return &pod.Spec.Containers[i]
+			return container
 		}
 	}
 	return nil
@@ -393,9 +509,9 @@ type containerWithRecommendation struct {
 
 func zipContainersWithRecommendations(resources []vpa_types.RecommendedContainerResources, pod *apiv1.Pod) []containerWithRecommendation {
 	result := make([]containerWithRecommendation, 0)
-	for _, container := range pod.Spec.Containers {
+	for _, container := range resourcehelpers.PodContainers(pod) {
 		recommendation := getRecommendationForContainer(container.Name, resources)
-		result = append(result, containerWithRecommendation{container: &container, recommendation: recommendation})
+		result = append(result, containerWithRecommendation{container: container, recommendation: recommendation})
 	}
 	return result
 }
@@ -495,7 +611,7 @@ func insertRequestsForMissingRecommendations(containerRecommendations []vpa_type
 	for _, r := range containerRecommendations {
 		result = append(result, *r.DeepCopy())
 	}
-	for _, container := range pod.Spec.Containers {
+	for _, container := range resourcehelpers.PodContainers(pod) {
 		if recommendationForContainerExists(container.Name, containerRecommendations) {
 			continue
 		}
@@ -695,6 +695,136 @@ func TestApplyCapsToLimitRange(t *testing.T) {
 	assert.Equal(t, expectedRecommendation, *processedRecommendation)
 }
 
+func TestApplyCapsToMaxLimitRequestRatio(t *testing.T) {
+	limitRange := apiv1.LimitRangeItem{
+		Type: apiv1.LimitTypeContainer,
+		MaxLimitRequestRatio: apiv1.ResourceList{
+			apiv1.ResourceCPU:    resource.MustParse("4"),
+			apiv1.ResourceMemory: resource.MustParse("2"),
+		},
+		Default: apiv1.ResourceList{
+			apiv1.ResourceMemory: resource.MustParse("1G"),
+		},
+	}
+
+	containerName := "container"
+	vpa := test.VerticalPodAutoscaler().
+		WithContainer(containerName).
+		WithControlledValues(containerName, vpa_types.ContainerControlledValuesRequestsOnly).
+		WithTarget("100m", "200M").
+		Get()
+
+	pod := test.Pod().WithName("pod").AddContainer(test.Container().WithName(containerName).
+		WithCPURequest(resource.MustParse("1")).WithCPULimit(resource.MustParse("2")).Get()).Get()
+	expectedTarget := apiv1.ResourceList{
+		apiv1.ResourceCPU:    resource.MustParse("500m"),
+		apiv1.ResourceMemory: resource.MustParse("500M"),
+	}
+
+	calculator := fakeLimitRangeCalculator{containerLimitRange: limitRange}
+	processor := NewCappingRecommendationProcessor(&calculator)
+	processedRecommendation, annotations, err := processor.Apply(vpa, pod)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"cpu capped to fit MaxLimitRequestRatio in container LimitRange", "memory capped to fit MaxLimitRequestRatio in container LimitRange"}, annotations[containerName])
+	if assert.Len(t, processedRecommendation.ContainerRecommendations, 1) {
+		target := processedRecommendation.ContainerRecommendations[0].Target
+		assert.True(t, expectedTarget.Cpu().Equal(*target.Cpu()), "expected cpu %v, got %v", expectedTarget.Cpu(), target.Cpu())
+		assert.True(t, expectedTarget.Memory().Equal(*target.Memory()), "expected memory %v, got %v", expectedTarget.Memory(), target.Memory())
+	}
+}
+
+func TestApplyLimitRange(t *testing.T) {
+	limitRange := &apiv1.LimitRangeItem{
+		Type: apiv1.LimitTypeContainer,
+		Min: apiv1.ResourceList{
+			apiv1.ResourceCPU: resource.MustParse("100m"),
+		},
+		Max: apiv1.ResourceList{
+			apiv1.ResourceCPU:    resource.MustParse("4"),
+			apiv1.ResourceMemory: resource.MustParse("4G"),
+		},
+		Default: apiv1.ResourceList{
+			apiv1.ResourceMemory: resource.MustParse("2G"),
+		},
+		MaxLimitRequestRatio: apiv1.ResourceList{
+			apiv1.ResourceMemory: resource.MustParse("4"),
+		},
+	}
+	requestsOnlyPolicy := &vpa_types.PodResourcePolicy{
+		ContainerPolicies: []vpa_types.ContainerResourcePolicy{
+			{ContainerName: "container", ControlledValues: &[]vpa_types.ContainerControlledValues{vpa_types.ContainerControlledValuesRequestsOnly}[0]},
+		},
+	}
+	testCases := []struct {
+		name             string
+		target           apiv1.ResourceList
+		policy           *vpa_types.PodResourcePolicy
+		limitRange       *apiv1.LimitRangeItem
+		expectedTarget   apiv1.ResourceList
+		expectedMessages []string
+	}{
+		{
+			name:           "no limit range",
+			target:         test.Resources("5", "5G"),
+			expectedTarget: test.Resources("5", "5G"),
+		},
+		{
+			name:           "within limit range",
+			target:         test.Resources("1", "1G"),
+			limitRange:     limitRange,
+			expectedTarget: test.Resources("1", "1G"),
+		},
+		{
+			name:           "capped to min and max",
+			target:         test.Resources("10m", "5G"),
+			limitRange:     limitRange,
+			expectedTarget: test.Resources("100m", "4G"),
+			expectedMessages: []string{
+				"container container: cpu capped to LimitRange min 100m",
+				"container container: memory capped to LimitRange max 4G",
+			},
+		},
+		{
+			name:           "requests only capped to default limit",
+			target:         test.Resources("1", "3G"),
+			policy:         requestsOnlyPolicy,
+			limitRange:     limitRange,
+			expectedTarget: test.Resources("1", "2G"),
+			expectedMessages: []string{
+				"container container: memory capped to LimitRange default limit 2G",
+			},
+		},
+		{
+			name:           "requests only capped to max limit request ratio of default limit",
+			target:         test.Resources("1", "100M"),
+			policy:         requestsOnlyPolicy,
+			limitRange:     limitRange,
+			expectedTarget: test.Resources("1", "500M"),
+			expectedMessages: []string{
+				"container container: memory capped to LimitRange maxLimitRequestRatio for default limit 500M",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recommendation := &vpa_types.RecommendedPodResources{
+				ContainerRecommendations: []vpa_types.RecommendedContainerResources{
+					{ContainerName: "container", Target: tc.target, LowerBound: tc.target.DeepCopy(), UpperBound: tc.target.DeepCopy()},
+				},
+			}
+			capped, messages := ApplyLimitRange(recommendation, tc.policy, tc.limitRange)
+			assert.Equal(t, tc.expectedMessages, messages)
+			if assert.Len(t, capped.ContainerRecommendations, 1) {
+				for _, resources := range []apiv1.ResourceList{capped.ContainerRecommendations[0].Target, capped.ContainerRecommendations[0].LowerBound} {
+					for resourceName, expected := range tc.expectedTarget {
+						assert.True(t, expected.Equal(resources[resourceName]), "%s: expected %v, got %v", resourceName, expected.String(), resources[resourceName])
+					}
+				}
+			}
+		})
+	}
+}
+
 func TestApplyPodLimitRange(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -119,6 +119,68 @@ func GetBoundaryRequest(resourceName core.ResourceName, originalRequest, origina
 	return result
 }
 
+// CapLimitsToLimitRequestRatio makes sure the limits are not higher than the
+// requests multiplied by the max limit to request ratio of the LimitRange.
+// If this function makes adjustments appropriate annotations are returned.
+func CapLimitsToLimitRequestRatio(limits, requests, maxLimitRequestRatio core.ResourceList) []string {
+	annotations := []string{}
+	for resourceName, ratio := range maxLimitRequestRatio {
+		limit, hasLimit := limits[resourceName]
+		request, hasRequest := requests[resourceName]
+		if !hasLimit || !hasRequest || ratio.IsZero() {
+			continue
+		}
+		maxLimit := getMaxLimitForRatio(resourceName, request, ratio)
+		if limit.Cmp(maxLimit) > 0 {
+			limits[resourceName] = maxLimit
+			annotations = append(annotations, fmt.Sprintf(
+				"%v: limit capped to fit MaxLimitRequestRatio in container LimitRange", resourceName))
+		}
+	}
+	return annotations
+}
+
+// getMaxLimitForRatio returns the highest limit allowed for the request by the
+// max limit to request ratio.
+func getMaxLimitForRatio(resourceName core.ResourceName, request, ratio resource.Quantity) resource.Quantity {
+	var result big.Int
+	result.Mul(big.NewInt(ratioBaseValue(resourceName, request)), big.NewInt(ratio.MilliValue()))
+	result.Div(&result, big.NewInt(1000))
+	return quantityFromRatioBaseValue(resourceName, &result, request.Format)
+}
+
+// getMinRequestForRatio returns the lowest request allowed for the limit by the
+// max limit to request ratio.
+func getMinRequestForRatio(resourceName core.ResourceName, limit, ratio resource.Quantity) resource.Quantity {
+	var result, remainder big.Int
+	result.Mul(big.NewInt(ratioBaseValue(resourceName, limit)), big.NewInt(1000))
+	result.DivMod(&result, big.NewInt(ratio.MilliValue()), &remainder)
+	if remainder.Sign() != 0 {
+		result.Add(&result, big.NewInt(1))
+	}
+	return quantityFromRatioBaseValue(resourceName, &result, limit.Format)
+}
+
+// ratioBaseValue returns the quantity in milliunits for CPU and in whole units
+// for other resources.
+func ratioBaseValue(resourceName core.ResourceName, quantity resource.Quantity) int64 {
+	if resourceName == core.ResourceCPU {
+		return quantity.MilliValue()
+	}
+	return quantity.Value()
+}
+
+func quantityFromRatioBaseValue(resourceName core.ResourceName, value *big.Int, format resource.Format) resource.Quantity {
+	v := int64(math.MaxInt64)
+	if value.IsInt64() {
+		v = value.Int64()
+	}
+	if resourceName == core.ResourceCPU {
+		return *resource.NewMilliQuantity(v, format)
+	}
+	return *resource.NewQuantity(v, format)
+}
+
 type roundingMode int
 
 const (
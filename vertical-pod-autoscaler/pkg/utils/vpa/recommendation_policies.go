@@ -0,0 +1,374 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/ext"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	vpa_types "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
+	vpa_lister "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/client/listers/autoscaling.k8s.io/v1"
+	resourcehelpers "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/resources"
+)
+
+// policyExpressionCostLimit is the maximum runtime cost of evaluating a single
+// match condition or rule, as estimated by CEL. It's the per-call limit of the
+// CEL expressions of the Kubernetes API server.
+const policyExpressionCostLimit = 1000000
+
+// RecommendationPolicies evaluates the VerticalPodAutoscalerPolicy objects.
+// The policies are applied in the order of their names.
+type RecommendationPolicies struct {
+	policyLister vpa_lister.VerticalPodAutoscalerPolicyLister
+	env          *cel.Env
+
+	mutex sync.Mutex
+	// compiledPolicies caches the compiled policies by their UID.
+	compiledPolicies map[k8stypes.UID]*compiledPolicy
+}
+
+type compiledPolicy struct {
+	name            string
+	resourceVersion string
+	// matchCondition is nil if the policy applies to all containers.
+	matchCondition cel.Program
+	rules          []compiledRule
+}
+
+type compiledRule struct {
+	resource   core.ResourceName
+	target     vpa_types.RecommendationPolicyTarget
+	expression cel.Program
+}
+
+// NewRecommendationPolicies returns the RecommendationPolicies of the
+// VerticalPodAutoscalerPolicy objects listed by the given lister.
+func NewRecommendationPolicies(policyLister vpa_lister.VerticalPodAutoscalerPolicyLister) (*RecommendationPolicies, error) {
+	env, err := newPolicyEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %v", err)
+	}
+	return &RecommendationPolicies{
+		policyLister:     policyLister,
+		env:              env,
+		compiledPolicies: make(map[k8stypes.UID]*compiledPolicy),
+	}, nil
+}
+
+func newPolicyEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("podNamespace", cel.StringType),
+		cel.Variable("podLabels", cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable("vpaName", cel.StringType),
+		cel.Variable("containerName", cel.StringType),
+		cel.Variable("cpuRequest", cel.IntType),
+		cel.Variable("cpuLimit", cel.IntType),
+		cel.Variable("memoryRequest", cel.IntType),
+		cel.Variable("memoryLimit", cel.IntType),
+		cel.Function("cpu", cel.Overload("cpu_string", []*cel.Type{cel.StringType}, cel.IntType,
+			cel.UnaryBinding(quantityBinding(func(q resource.Quantity) int64 { return q.MilliValue() })))),
+		cel.Function("memory", cel.Overload("memory_string", []*cel.Type{cel.StringType}, cel.IntType,
+			cel.UnaryBinding(quantityBinding(func(q resource.Quantity) int64 { return q.Value() })))),
+		ext.Math(),
+	)
+}
+
+func quantityBinding(amount func(resource.Quantity) int64) func(ref.Val) ref.Val {
+	return func(arg ref.Val) ref.Val {
+		s, ok := arg.(types.String)
+		if !ok {
+			return types.MaybeNoSuchOverloadErr(arg)
+		}
+		q, err := resource.ParseQuantity(string(s))
+		if err != nil {
+			return types.NewErr("invalid quantity %q: %v", string(s), err)
+		}
+		return types.Int(amount(q))
+	}
+}
+
+// Apply applies the rules of the policies with the given target to the
+// resources of the container, in place. Returns the annotations describing the
+// policies which modified the resources. Policies which fail to compile or to
+// evaluate are logged and skipped.
+func (p *RecommendationPolicies) Apply(pod *core.Pod, vpa *vpa_types.VerticalPodAutoscaler, containerName string,
+	target vpa_types.RecommendationPolicyTarget, resources *ContainerResources) []string {
+	var annotations []string
+	for _, policy := range p.listCompiledPolicies() {
+		applied, err := policy.apply(pod, vpa, containerName, target, resources)
+		if err != nil {
+			klog.ErrorS(err, "Failed to apply VPA policy", "policy", policy.name, "pod", klog.KObj(pod), "container", containerName)
+			continue
+		}
+		if applied {
+			annotations = append(annotations, fmt.Sprintf("modified by policy %s", policy.name))
+		}
+	}
+	return annotations
+}
+
+// listCompiledPolicies returns the valid policies in the order of their names.
+// Policies which were deleted are dropped from the cache.
+func (p *RecommendationPolicies) listCompiledPolicies() []*compiledPolicy {
+	policies, err := p.policyLister.List(labels.Everything())
+	if err != nil {
+		klog.ErrorS(err, "Failed to list VPA policies, not applying them")
+		return nil
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].Name < policies[j].Name })
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	live := make(map[k8stypes.UID]bool, len(policies))
+	compiled := make([]*compiledPolicy, 0, len(policies))
+	for _, policy := range policies {
+		live[policy.UID] = true
+		c, found := p.compiledPolicies[policy.UID]
+		if !found || c.resourceVersion != policy.ResourceVersion {
+			c, err = p.compilePolicy(policy)
+			if err != nil {
+				klog.ErrorS(err, "Invalid VPA policy", "policy", policy.Name)
+				delete(p.compiledPolicies, policy.UID)
+				continue
+			}
+			p.compiledPolicies[policy.UID] = c
+		}
+		compiled = append(compiled, c)
+	}
+	for uid := range p.compiledPolicies {
+		if !live[uid] {
+			delete(p.compiledPolicies, uid)
+		}
+	}
+	return compiled
+}
+
+func (p *RecommendationPolicies) compilePolicy(policy *vpa_types.VerticalPodAutoscalerPolicy) (*compiledPolicy, error) {
+	compiled := &compiledPolicy{name: policy.Name, resourceVersion: policy.ResourceVersion}
+	if policy.Spec.MatchCondition != "" {
+		program, err := p.compileExpression(policy.Spec.MatchCondition, cel.BoolType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid match condition: %v", err)
+		}
+		compiled.matchCondition = program
+	}
+	for i, rule := range policy.Spec.Rules {
+		if rule.Resource != core.ResourceCPU && rule.Resource != core.ResourceMemory {
+			return nil, fmt.Errorf("rule %d: unsupported resource %s", i, rule.Resource)
+		}
+		if rule.Target != vpa_types.RecommendationPolicyTargetRequest && rule.Target != vpa_types.RecommendationPolicyTargetLimit {
+			return nil, fmt.Errorf("rule %d: unsupported target %s", i, rule.Target)
+		}
+		program, err := p.compileExpression(rule.Expression, cel.IntType, cel.DoubleType)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %v", i, err)
+		}
+		compiled.rules = append(compiled.rules, compiledRule{resource: rule.Resource, target: rule.Target, expression: program})
+	}
+	return compiled, nil
+}
+
+// compileExpression compiles the expression, checking that it returns one of
+// the given types. Expressions returning dyn are checked when evaluated.
+func (p *RecommendationPolicies) compileExpression(expression string, outputTypes ...*cel.Type) (cel.Program, error) {
+	ast, issues := p.env.Compile(expression)
+	if issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if !ast.OutputType().IsExactType(cel.DynType) && !slices.ContainsFunc(outputTypes, ast.OutputType().IsExactType) {
+		return nil, fmt.Errorf("expression %q returns %v, expected %v", expression, ast.OutputType(), outputTypes[0])
+	}
+	return p.env.Program(ast, cel.CostLimit(policyExpressionCostLimit))
+}
+
+// apply applies the rules of the policy with the given target to the resources
+// of the container if it matches the policy. Returns true if a rule was applied.
+func (c *compiledPolicy) apply(pod *core.Pod, vpa *vpa_types.VerticalPodAutoscaler, containerName string,
+	target vpa_types.RecommendationPolicyTarget, resources *ContainerResources) (bool, error) {
+	if !slices.ContainsFunc(c.rules, func(rule compiledRule) bool { return rule.target == target }) {
+		return false, nil
+	}
+	if c.matchCondition != nil {
+		out, _, err := c.matchCondition.Eval(policyVariables(pod, vpa, containerName, resources))
+		if err != nil {
+			return false, fmt.Errorf("failed to evaluate match condition: %v", err)
+		}
+		matches, ok := out.Value().(bool)
+		if !ok {
+			return false, fmt.Errorf("match condition returned %v, expected bool", out.Type())
+		}
+		if !matches {
+			return false, nil
+		}
+	}
+	// Rules are applied on copies, so that the resources are left unchanged on error.
+	requests := resources.Requests.DeepCopy()
+	limits := resources.Limits.DeepCopy()
+	for i, rule := range c.rules {
+		if rule.target != target {
+			continue
+		}
+		current := &ContainerResources{Requests: requests, Limits: limits}
+		out, _, err := rule.expression.Eval(policyVariables(pod, vpa, containerName, current))
+		if err != nil {
+			return false, fmt.Errorf("failed to evaluate rule %d: %v", i, err)
+		}
+		amount, err := ruleAmount(out)
+		if err != nil {
+			return false, fmt.Errorf("rule %d: %v", i, err)
+		}
+		if amount < 0 {
+			return false, fmt.Errorf("rule %d returned negative amount %d", i, amount)
+		}
+		var quantity resource.Quantity
+		if rule.resource == core.ResourceCPU {
+			quantity = *resource.NewMilliQuantity(amount, resource.DecimalSI)
+		} else {
+			quantity = *resource.NewQuantity(amount, resource.BinarySI)
+		}
+		switch rule.target {
+		case vpa_types.RecommendationPolicyTargetRequest:
+			if amount == 0 {
+				return false, fmt.Errorf("rule %d returned zero request", i)
+			}
+			if requests == nil {
+				requests = core.ResourceList{}
+			}
+			requests[rule.resource] = quantity
+		case vpa_types.RecommendationPolicyTargetLimit:
+			if limits == nil {
+				limits = core.ResourceList{}
+			}
+			if amount == 0 {
+				delete(limits, rule.resource)
+			} else {
+				limits[rule.resource] = quantity
+			}
+		}
+	}
+	resources.Requests = requests
+	resources.Limits = limits
+	return true, nil
+}
+
+func ruleAmount(out ref.Val) (int64, error) {
+	switch value := out.Value().(type) {
+	case int64:
+		return value, nil
+	case float64:
+		return int64(math.Ceil(value)), nil
+	default:
+		return 0, fmt.Errorf("expression returned %v, expected int", out.Type())
+	}
+}
+
+func policyVariables(pod *core.Pod, vpa *vpa_types.VerticalPodAutoscaler, containerName string, resources *ContainerResources) map[string]any {
+	podLabels := pod.Labels
+	if podLabels == nil {
+		podLabels = map[string]string{}
+	}
+	return map[string]any{
+		"podNamespace":  pod.Namespace,
+		"podLabels":     podLabels,
+		"vpaName":       vpa.Name,
+		"containerName": containerName,
+		"cpuRequest":    quantityMilliValue(resources.Requests, core.ResourceCPU),
+		"cpuLimit":      quantityMilliValue(resources.Limits, core.ResourceCPU),
+		"memoryRequest": quantityValue(resources.Requests, core.ResourceMemory),
+		"memoryLimit":   quantityValue(resources.Limits, core.ResourceMemory),
+	}
+}
+
+func quantityMilliValue(resources core.ResourceList, name core.ResourceName) int64 {
+	if quantity, found := resources[name]; found {
+		return quantity.MilliValue()
+	}
+	return 0
+}
+
+func quantityValue(resources core.ResourceList, name core.ResourceName) int64 {
+	if quantity, found := resources[name]; found {
+		return quantity.Value()
+	}
+	return 0
+}
+
+// NewPolicyRecommendationProcessor constructs a RecommendationProcessor
+// applying the request rules of the policies to the target recommendations
+// returned by the given processor. The lower and upper bounds are widened to
+// include the new targets, so that pods with them are within the recommended
+// range. Limit rules are applied when the limits are computed from the requests.
+func NewPolicyRecommendationProcessor(processor RecommendationProcessor, policies *RecommendationPolicies) RecommendationProcessor {
+	return &policyRecommendationProcessor{processor: processor, policies: policies}
+}
+
+type policyRecommendationProcessor struct {
+	processor RecommendationProcessor
+	policies  *RecommendationPolicies
+}
+
+// Apply returns the recommendation of the underlying processor with the
+// request rules applied. The limits seen by the rules are the current limits of
+// the containers.
+func (p *policyRecommendationProcessor) Apply(vpa *vpa_types.VerticalPodAutoscaler, pod *core.Pod) (*vpa_types.RecommendedPodResources, ContainerToAnnotationsMap, error) {
+	processed, annotations, err := p.processor.Apply(vpa, pod)
+	if err != nil || processed == nil || pod == nil {
+		return processed, annotations, err
+	}
+	recommendation := processed.DeepCopy()
+	containers := resourcehelpers.PodContainers(pod)
+	for i := range recommendation.ContainerRecommendations {
+		containerRecommendation := &recommendation.ContainerRecommendations[i]
+		if len(containerRecommendation.Target) == 0 ||
+			!slices.ContainsFunc(containers, func(c *core.Container) bool { return c.Name == containerRecommendation.ContainerName }) {
+			continue
+		}
+		_, limits := resourcehelpers.ContainerRequestsAndLimits(containerRecommendation.ContainerName, pod)
+		resources := &ContainerResources{Requests: containerRecommendation.Target, Limits: limits}
+		applied := p.policies.Apply(pod, vpa, containerRecommendation.ContainerName, vpa_types.RecommendationPolicyTargetRequest, resources)
+		if len(applied) == 0 {
+			continue
+		}
+		if annotations == nil {
+			annotations = ContainerToAnnotationsMap{}
+		}
+		annotations[containerRecommendation.ContainerName] = append(annotations[containerRecommendation.ContainerName], applied...)
+		containerRecommendation.Target = resources.Requests
+		for resource, quantity := range resources.Requests {
+			if lower, found := containerRecommendation.LowerBound[resource]; found && quantity.Cmp(lower) < 0 {
+				containerRecommendation.LowerBound[resource] = quantity
+			}
+			if upper, found := containerRecommendation.UpperBound[resource]; found && quantity.Cmp(upper) > 0 {
+				containerRecommendation.UpperBound[resource] = quantity
+			}
+		}
+	}
+	return recommendation, annotations, nil
+}
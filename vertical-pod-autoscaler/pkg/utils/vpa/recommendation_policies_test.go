@@ -0,0 +1,191 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	vpa_types "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
+	vpa_lister "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/client/listers/autoscaling.k8s.io/v1"
+	"k8s.io/autoscaler/vertical-pod-autoscaler/pkg/utils/test"
+)
+
+func newPolicy(name, matchCondition string, rules ...vpa_types.RecommendationPolicyRule) *vpa_types.VerticalPodAutoscalerPolicy {
+	return &vpa_types.VerticalPodAutoscalerPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, UID: types.UID("uid-" + name), ResourceVersion: "1"},
+		Spec:       vpa_types.VerticalPodAutoscalerPolicySpec{MatchCondition: matchCondition, Rules: rules},
+	}
+}
+
+func newRule(resource core.ResourceName, target vpa_types.RecommendationPolicyTarget, expression string) vpa_types.RecommendationPolicyRule {
+	return vpa_types.RecommendationPolicyRule{Resource: resource, Target: target, Expression: expression}
+}
+
+func TestPolicyRecommendationProcessor(t *testing.T) {
+	testCases := []struct {
+		name                string
+		policies            []*vpa_types.VerticalPodAutoscalerPolicy
+		expectedTarget      core.ResourceList
+		expectedUpperBound  core.ResourceList
+		expectedAnnotations ContainerToAnnotationsMap
+	}{
+		{
+			name:               "no policies",
+			expectedTarget:     test.Resources("500m", "100Mi"),
+			expectedUpperBound: test.Resources("1", "200Mi"),
+		},
+		{
+			name: "minimum memory request in namespace widens the upper bound",
+			policies: []*vpa_types.VerticalPodAutoscalerPolicy{
+				newPolicy("min-memory", "podNamespace == 'default'",
+					newRule(core.ResourceMemory, vpa_types.RecommendationPolicyTargetRequest, "math.greatest(memoryRequest, memory('256Mi'))")),
+			},
+			expectedTarget:      test.Resources("500m", "256Mi"),
+			expectedUpperBound:  test.Resources("1", "256Mi"),
+			expectedAnnotations: ContainerToAnnotationsMap{"container": {"modified by policy min-memory"}},
+		},
+		{
+			name: "match condition doesn't match",
+			policies: []*vpa_types.VerticalPodAutoscalerPolicy{
+				newPolicy("min-memory", "podNamespace == 'other'",
+					newRule(core.ResourceMemory, vpa_types.RecommendationPolicyTargetRequest, "math.greatest(memoryRequest, memory('256Mi'))")),
+			},
+			expectedTarget:     test.Resources("500m", "100Mi"),
+			expectedUpperBound: test.Resources("1", "200Mi"),
+		},
+		{
+			name: "policies are applied in order, limit rules are left to the admission controller",
+			policies: []*vpa_types.VerticalPodAutoscalerPolicy{
+				newPolicy("b-max-cpu", "",
+					newRule(core.ResourceCPU, vpa_types.RecommendationPolicyTargetRequest, "math.least(cpuRequest, cpu('800m'))"),
+					newRule(core.ResourceCPU, vpa_types.RecommendationPolicyTargetLimit, "cpuRequest * 2")),
+				newPolicy("a-min-cpu", "containerName == 'container'",
+					newRule(core.ResourceCPU, vpa_types.RecommendationPolicyTargetRequest, "math.greatest(cpuRequest, cpu('1'))")),
+			},
+			expectedTarget:      test.Resources("800m", "100Mi"),
+			expectedUpperBound:  test.Resources("1", "200Mi"),
+			expectedAnnotations: ContainerToAnnotationsMap{"container": {"modified by policy a-min-cpu", "modified by policy b-max-cpu"}},
+		},
+		{
+			name: "invalid policy is skipped",
+			policies: []*vpa_types.VerticalPodAutoscalerPolicy{
+				newPolicy("invalid", "",
+					newRule(core.ResourceCPU, vpa_types.RecommendationPolicyTargetRequest, "podNamespace")),
+			},
+			expectedTarget:     test.Resources("500m", "100Mi"),
+			expectedUpperBound: test.Resources("1", "200Mi"),
+		},
+		{
+			name: "failing rule leaves resources unchanged",
+			policies: []*vpa_types.VerticalPodAutoscalerPolicy{
+				newPolicy("failing", "",
+					newRule(core.ResourceCPU, vpa_types.RecommendationPolicyTargetRequest, "cpu('2')"),
+					newRule(core.ResourceMemory, vpa_types.RecommendationPolicyTargetRequest, "memory('invalid')")),
+			},
+			expectedTarget:     test.Resources("500m", "100Mi"),
+			expectedUpperBound: test.Resources("1", "200Mi"),
+		},
+		{
+			name: "expression exceeding the cost limit is skipped",
+			policies: []*vpa_types.VerticalPodAutoscalerPolicy{
+				newPolicy("expensive", "",
+					newRule(core.ResourceCPU, vpa_types.RecommendationPolicyTargetRequest,
+						"[1, 2, 3, 4, 5, 6, 7, 8, 9, 10].map(a, [1, 2, 3, 4, 5, 6, 7, 8, 9, 10].map(b, [1, 2, 3, 4, 5, 6, 7, 8, 9, 10].map(c, [1, 2, 3, 4, 5, 6, 7, 8, 9, 10].map(d, [1, 2, 3, 4, 5, 6, 7, 8, 9, 10].map(e, a * b * c * d * e))))).size() * cpuRequest")),
+			},
+			expectedTarget:     test.Resources("500m", "100Mi"),
+			expectedUpperBound: test.Resources("1", "200Mi"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, policy := range tc.policies {
+				assert.NoError(t, indexer.Add(policy))
+			}
+			policies, err := NewRecommendationPolicies(vpa_lister.NewVerticalPodAutoscalerPolicyLister(indexer))
+			assert.NoError(t, err)
+			processor := NewPolicyRecommendationProcessor(&test.FakeRecommendationProcessor{}, policies)
+
+			pod := test.Pod().WithName("pod").AddContainer(test.Container().WithName("container").Get()).Get()
+			vpa := test.VerticalPodAutoscaler().WithName("vpa").WithContainer("container").
+				WithTarget("500m", "100Mi").WithLowerBound("250m", "50Mi").WithUpperBound("1", "200Mi").Get()
+			original := vpa.Status.Recommendation.DeepCopy()
+
+			recommendation, annotations, err := processor.Apply(vpa, pod)
+			assert.NoError(t, err)
+			if assert.Len(t, recommendation.ContainerRecommendations, 1) {
+				assertResourcesEqual(t, tc.expectedTarget, recommendation.ContainerRecommendations[0].Target)
+				assertResourcesEqual(t, tc.expectedUpperBound, recommendation.ContainerRecommendations[0].UpperBound)
+			}
+			assert.Equal(t, tc.expectedAnnotations, annotations)
+			// The recommendation of the VPA object is left unchanged.
+			assert.Equal(t, original, vpa.Status.Recommendation)
+		})
+	}
+}
+
+func TestRecommendationPoliciesPruneDeleted(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	kept := newPolicy("kept", "", newRule(core.ResourceCPU, vpa_types.RecommendationPolicyTargetRequest, "cpuRequest"))
+	deleted := newPolicy("deleted", "", newRule(core.ResourceCPU, vpa_types.RecommendationPolicyTargetRequest, "cpuRequest"))
+	assert.NoError(t, indexer.Add(kept))
+	assert.NoError(t, indexer.Add(deleted))
+	policies, err := NewRecommendationPolicies(vpa_lister.NewVerticalPodAutoscalerPolicyLister(indexer))
+	assert.NoError(t, err)
+
+	assert.Len(t, policies.listCompiledPolicies(), 2)
+	assert.Len(t, policies.compiledPolicies, 2)
+
+	assert.NoError(t, indexer.Delete(deleted))
+	assert.Len(t, policies.listCompiledPolicies(), 1)
+	assert.Len(t, policies.compiledPolicies, 1)
+	assert.Contains(t, policies.compiledPolicies, kept.UID)
+}
+
+func TestRecommendationPoliciesApplyLimits(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	assert.NoError(t, indexer.Add(newPolicy("cpu-limit", "",
+		newRule(core.ResourceCPU, vpa_types.RecommendationPolicyTargetLimit, "cpuRequest * 2"),
+		newRule(core.ResourceMemory, vpa_types.RecommendationPolicyTargetLimit, "0"))))
+	policies, err := NewRecommendationPolicies(vpa_lister.NewVerticalPodAutoscalerPolicyLister(indexer))
+	assert.NoError(t, err)
+
+	pod := test.Pod().WithName("pod").AddContainer(test.Container().WithName("container").Get()).Get()
+	vpa := test.VerticalPodAutoscaler().WithName("vpa").WithContainer("container").Get()
+	resources := &ContainerResources{
+		Requests: core.ResourceList{core.ResourceCPU: resource.MustParse("500m")},
+		Limits:   core.ResourceList{core.ResourceMemory: resource.MustParse("1Gi")},
+	}
+	annotations := policies.Apply(pod, vpa, "container", vpa_types.RecommendationPolicyTargetLimit, resources)
+	assert.Equal(t, []string{"modified by policy cpu-limit"}, annotations)
+	assertResourcesEqual(t, core.ResourceList{core.ResourceCPU: resource.MustParse("1")}, resources.Limits)
+}
+
+func assertResourcesEqual(t *testing.T, expected, actual core.ResourceList) {
+	t.Helper()
+	assert.Equal(t, len(expected), len(actual), "expected %v, got %v", expected, actual)
+	for name, quantity := range expected {
+		assert.True(t, quantity.Equal(actual[name]), "%s: expected %v, got %v", name, quantity.String(), actual[name])
+	}
+}